@@ -2,18 +2,25 @@ package admin
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	gweb "go-template/gateways/web"
 )
 
 const (
-	CookieToken       = "admin_token"
-	CookieUserID      = "admin_user_id"
-	CookieUserEmail   = "admin_user_email"
-	CookieAccountType = "admin_account_type"
+	CookieToken        = "admin_token"
+	CookieTokenExpires = "admin_token_expires"
+	CookieRefreshToken = "admin_refresh_token"
+	CookieUserID       = "admin_user_id"
+	CookieUserEmail    = "admin_user_email"
+	CookieAccountType  = "admin_account_type"
+	CookieMFAToken     = "admin_mfa_token"
 )
 
+// mfaTokenMaxAge matches how long the API accepts a two-factor challenge.
+const mfaTokenMaxAge = 5 * 60
+
 // Cookie helpers
 func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AdminLoginResponse) {
 	maxAge := m.cookieMaxAge
@@ -35,6 +42,34 @@ func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AdminL
 		Expires:  time.Now().Add(time.Duration(maxAge) * time.Second),
 		Domain:   domain,
 	})
+	// The refresh token and the access token's expiry let the middleware
+	// renew the session before the access token runs out
+	if resp.RefreshToken != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieRefreshToken,
+			Value:    resp.RefreshToken,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   m.cookieSecure,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   maxAge,
+			Expires:  time.Now().Add(time.Duration(maxAge) * time.Second),
+			Domain:   domain,
+		})
+	}
+	if !resp.ExpiresAt.IsZero() {
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieTokenExpires,
+			Value:    strconv.FormatInt(resp.ExpiresAt.Unix(), 10),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   m.cookieSecure,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   maxAge,
+			Expires:  time.Now().Add(time.Duration(maxAge) * time.Second),
+			Domain:   domain,
+		})
+	}
 	http.SetCookie(w, &http.Cookie{
 		Name:     CookieUserID,
 		Value:    resp.User.ID.String(),
@@ -71,7 +106,7 @@ func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AdminL
 }
 
 func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
-	cookieNames := []string{CookieToken, CookieUserID, CookieUserEmail, CookieAccountType}
+	cookieNames := []string{CookieToken, CookieTokenExpires, CookieRefreshToken, CookieUserID, CookieUserEmail, CookieAccountType}
 	// Don't set domain for localhost in development
 	var domain string
 	if m.cookieDomain != "localhost" && m.cookieDomain != "" {
@@ -83,7 +118,7 @@ func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
 			Name:     name,
 			Value:    "",
 			Path:     "/",
-			HttpOnly: name == CookieToken,
+			HttpOnly: name == CookieToken || name == CookieTokenExpires || name == CookieRefreshToken,
 			Secure:   m.cookieSecure,
 			SameSite: http.SameSiteLaxMode,
 			MaxAge:   -1,
@@ -93,6 +128,43 @@ func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
 	}
 }
 
+// setMFATokenCookie keeps the challenge from a two-factor login until the
+// admin enters their code.
+func (m *AuthMiddleware) setMFATokenCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieMFAToken,
+		Value:    token,
+		Path:     "/login/2fa",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   mfaTokenMaxAge,
+	})
+}
+
+func (m *AuthMiddleware) clearMFATokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieMFAToken,
+		Value:    "",
+		Path:     "/login/2fa",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
+
+// tokenExpiresSoon reports whether the access token expires within
+// refreshWindow. Sessions from before the expiry was stored never do.
+func tokenExpiresSoon(r *http.Request) bool {
+	expires, err := strconv.ParseInt(getCookieValue(r, CookieTokenExpires), 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.Until(time.Unix(expires, 0)) < refreshWindow
+}
+
 func getCookieValue(r *http.Request, name string) string {
 	c, err := r.Cookie(name)
 	if err != nil {
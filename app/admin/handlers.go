@@ -1,17 +1,27 @@
 package admin
 
 import (
-	"context"
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-template/app/admin/templates"
+	"go-template/domain"
 	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
+	"go-template/internal/clientip"
+	filterExpr "go-template/internal/filter"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
 )
 
 type Handlers struct {
@@ -19,17 +29,24 @@ type Handlers struct {
 	auth       *AuthMiddleware
 	logger     *slog.Logger
 	fileServer http.Handler
+	webBaseURL string
 }
 
-func NewHandlers(client *gweb.Client, auth *AuthMiddleware, logger *slog.Logger, staticPath string) *Handlers {
+func NewHandlers(client *gweb.Client, auth *AuthMiddleware, logger *slog.Logger, staticPath, webBaseURL string) *Handlers {
 	return &Handlers{
 		client:     client,
 		auth:       auth,
 		logger:     logger,
 		fileServer: http.FileServer(http.Dir(staticPath)),
+		webBaseURL: strings.TrimRight(webBaseURL, "/"),
 	}
 }
 
+// log returns the logger of the request, which names it and its user.
+func (h *Handlers) log(r *http.Request) *slog.Logger {
+	return domain.LoggerOr(r.Context(), h.logger)
+}
+
 // Page handlers
 func (h *Handlers) LoginPage(w http.ResponseWriter, r *http.Request) {
 	// If already authenticated, redirect to dashboard
@@ -43,7 +60,7 @@ func (h *Handlers) LoginPage(w http.ResponseWriter, r *http.Request) {
 		"Error": r.URL.Query().Get("error"),
 	}
 
-	renderTemplate(w, "login.templ", data)
+	renderTemplate(w, r, "login.templ", data)
 }
 
 func (h *Handlers) LoginSubmit(w http.ResponseWriter, r *http.Request) {
@@ -62,38 +79,200 @@ func (h *Handlers) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.client.AdminLogin(email, password)
 	if err != nil {
-		h.logger.Error("admin login failed", slog.String("error", err.Error()))
+		h.log(r).Error("admin login failed", slog.String("error", err.Error()))
+		if strings.Contains(err.Error(), "503") {
+			http.Redirect(w, r, "/login?error=service_unavailable", http.StatusSeeOther)
+			return
+		}
+		if strings.Contains(err.Error(), "account suspended") {
+			http.Redirect(w, r, "/login?error=account_suspended", http.StatusSeeOther)
+			return
+		}
 		http.Redirect(w, r, "/login?error=invalid_credentials", http.StatusSeeOther)
 		return
 	}
 
+	// Admins with two-factor authentication enter their code next
+	if resp.MFARequired {
+		h.auth.setMFATokenCookie(w, resp.MFAToken)
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+
 	// Set auth cookies
 	h.auth.setAuthCookies(w, resp)
 
+	if resp.TwoFactorSetupRequired {
+		http.Redirect(w, r, "/2fa/setup", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// TwoFactorPage asks for the authenticator code after a password login
+func (h *Handlers) TwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	if getCookieValue(r, CookieMFAToken) == "" {
+		http.Redirect(w, r, "/login?error=session_expired", http.StatusFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title": "Two-Factor Authentication",
+		"Error": r.URL.Query().Get("error"),
+	}
+
+	renderTemplate(w, r, "two_factor.templ", data)
+}
+
+// TwoFactorSubmit completes the login with the authenticator code or a
+// recovery code
+func (h *Handlers) TwoFactorSubmit(w http.ResponseWriter, r *http.Request) {
+	mfaToken := getCookieValue(r, CookieMFAToken)
+	if mfaToken == "" {
+		http.Redirect(w, r, "/login?error=session_expired", http.StatusSeeOther)
+		return
+	}
+
+	code := r.FormValue("code")
+	recoveryCode := r.FormValue("recovery_code")
+	if code == "" && recoveryCode == "" {
+		http.Redirect(w, r, "/login/2fa?error=missing_code", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.client.AdminTwoFactorChallenge(mfaToken, code, recoveryCode)
+	if err != nil {
+		h.log(r).Warn("admin two-factor challenge failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/login/2fa?error=invalid_code", http.StatusSeeOther)
+		return
+	}
+
+	h.auth.clearMFATokenCookie(w)
+	h.auth.setAuthCookies(w, resp)
+
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// TwoFactorSetupPage starts two-factor enrollment for admins held back by
+// the Require2FA setting
+func (h *Handlers) TwoFactorSetupPage(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.client.AdminEnrollTOTP()
+	if err != nil {
+		h.log(r).Error("failed to start two-factor enrollment", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/dashboard?error=two_factor_setup_failed", http.StatusFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":      "Set Up Two-Factor Authentication",
+		"Enrollment": enrollment,
+	}
+
+	renderTemplate(w, r, "two_factor_setup.templ", data)
+}
+
+// TwoFactorSetupSubmit enables two-factor authentication, swaps the session
+// for one that records the second factor and shows the recovery codes
+func (h *Handlers) TwoFactorSetupSubmit(w http.ResponseWriter, r *http.Request) {
+	// The page posts the enrollment back so a typo doesn't mean rescanning
+	enrollment := &gweb.TwoFactorEnrollment{
+		Secret: r.FormValue("secret"),
+		URI:    r.FormValue("uri"),
+		QRCode: r.FormValue("qr_code"),
+	}
+
+	code := r.FormValue("code")
+	if code == "" {
+		renderTemplate(w, r, "two_factor_setup.templ", map[string]interface{}{
+			"Enrollment": enrollment,
+			"Error":      "missing_code",
+		})
+		return
+	}
+
+	resp, err := h.client.AdminEnableTOTP(code)
+	if err != nil {
+		h.log(r).Warn("admin two-factor enrollment failed", slog.String("error", err.Error()))
+		renderTemplate(w, r, "two_factor_setup.templ", map[string]interface{}{
+			"Enrollment": enrollment,
+			"Error":      "invalid_code",
+		})
+		return
+	}
+
+	h.auth.setAuthCookies(w, resp)
+
+	// The codes are only returned this once, so show them before moving on
+	renderTemplate(w, r, "two_factor_recovery_codes.templ", map[string]interface{}{
+		"RecoveryCodes": resp.RecoveryCodes,
+	})
+}
+
+// BreakGlassPage renders the emergency access form
+func (h *Handlers) BreakGlassPage(w http.ResponseWriter, r *http.Request) {
+	data := map[string]interface{}{
+		"Title": "Emergency Access",
+		"Error": r.URL.Query().Get("error"),
+	}
+
+	renderTemplate(w, r, "break_glass.templ", data)
+}
+
+// BreakGlassSubmit redeems the break-glass credential and signs in with the
+// resulting super admin session
+func (h *Handlers) BreakGlassSubmit(w http.ResponseWriter, r *http.Request) {
+	credential := r.FormValue("credential")
+	if credential == "" {
+		http.Redirect(w, r, "/break-glass?error=missing_credential", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.client.BreakGlass(credential)
+	if err != nil {
+		h.log(r).Warn("break-glass attempt failed",
+			slog.String("remote_addr", clientip.FromRequest(r)),
+			slog.String("error", err.Error()),
+		)
+		// The API refuses break-glass access that no alert channel would
+		// report
+		if strings.Contains(err.Error(), "503") {
+			http.Redirect(w, r, "/break-glass?error=no_alert_channel", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, "/break-glass?error=invalid_credential", http.StatusSeeOther)
+		return
+	}
+
+	h.log(r).Warn("break-glass session started", slog.String("remote_addr", clientip.FromRequest(r)))
+	h.auth.setAuthCookies(w, resp)
+
 	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 }
 
 func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	// Revoke the session at the API before dropping the cookies
+	h.client.SetAuthToken(getCookieValue(r, CookieToken))
+	if err := h.client.AdminLogout(getCookieValue(r, CookieRefreshToken)); err != nil {
+		h.log(r).Warn("failed to revoke session on logout", "error", err)
+	}
+
 	// Clear cookies
 	h.auth.clearAuthCookies(w)
 
-	// Call API logout
-	h.client.AdminLogout()
-
 	http.Redirect(w, r, "/login", http.StatusSeeOther)
 }
 
 func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
-		slog.Error("user not found in context")
+		h.log(r).Error("user not found in context")
 		http.Redirect(w, r, "/login", http.StatusSeeOther)
 		return
 	}
 
 	stats, err := h.client.GetDashboardStats()
 	if err != nil {
-		h.logger.Error("failed to get dashboard stats", slog.String("error", err.Error()))
+		h.log(r).Error("failed to get dashboard stats", slog.String("error", err.Error()))
 		stats = &entities.DashboardStats{} // Use empty stats on error
 	}
 
@@ -103,13 +282,13 @@ func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
 		"Stats": stats,
 	}
 
-	renderTemplate(w, "dashboard.templ", data)
+	renderTemplate(w, r, "dashboard.templ", data)
 }
 
 func (h *Handlers) UsersPage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
-		slog.Error("user not found in context")
+		h.log(r).Error("user not found in context")
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
@@ -133,9 +312,11 @@ func (h *Handlers) UsersPage(w http.ResponseWriter, r *http.Request) {
 	search := r.URL.Query().Get("search")
 	accountType := r.URL.Query().Get("account_type")
 
-	users, err := h.client.ListUsersWithFilter(page, pageSize, search, accountType)
+	sort := userSortFromQuery(r.URL.Query())
+
+	users, err := h.client.ListUsersWithFilter(page, pageSize, search, accountType, sort)
 	if err != nil {
-		h.logger.Error("failed to get users", slog.String("error", err.Error()))
+		h.log(r).Error("failed to get users", slog.String("error", err.Error()))
 		users = &entities.UserListResponse{} // Use empty response on error
 	}
 
@@ -143,422 +324,1806 @@ func (h *Handlers) UsersPage(w http.ResponseWriter, r *http.Request) {
 		"Title": "User Management",
 		"User":  user,
 		"Users": users,
+		"Sort":  sort,
 	}
 
-	renderTemplate(w, "users.templ", data)
+	renderTemplate(w, r, "users.templ", data)
 }
 
-func (h *Handlers) UserDetail(w http.ResponseWriter, r *http.Request) {
-	user := GetUserFromContext(r)
-	if user == nil {
-		http.Redirect(w, r, "/login", http.StatusFound)
+// ExportUsers downloads the users matching the users table's search and
+// account type filter, passing the API's stream on as it comes.
+func (h *Handlers) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ExportUsers(r.URL.Query().Get("format"), r.URL.Query().Get("search"), r.URL.Query().Get("account_type"))
+	if err != nil {
+		h.log(r).Error("failed to export users", slog.String("error", err.Error()))
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "400") {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, apiErrorMessage(err, "Failed to export users"), status)
 		return
 	}
+	defer resp.Body.Close()
 
-	userID := chi.URLParam(r, "id")
-	if userID == "" {
-		http.Error(w, "User ID is required", http.StatusBadRequest)
-		return
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.Header().Set("Content-Disposition", resp.Header.Get("Content-Disposition"))
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		h.log(r).Error("user export interrupted", slog.String("error", err.Error()))
 	}
+}
 
-	// If it's an HTMX request for JSON data, return user data
-	if r.Header.Get("HX-Request") == "true" {
-		userData, err := h.client.GetUser(userID)
-		if err != nil {
-			h.logger.Error("failed to get user", slog.String("error", err.Error()))
-			http.Error(w, "Failed to get user", http.StatusInternalServerError)
-			return
-		}
+// importUploadLimit is a little over the API's 5 MiB import limit, leaving
+// room for the form around the file
+const importUploadLimit = 6 << 20
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(userData)
+// ImportUsers passes the uploaded CSV file on to the API and shows how each
+// of its rows went.
+func (h *Handlers) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+
+	r.Body = http.MaxBytesReader(w, r.Body, importUploadLimit)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		msg := "Choose a CSV file to import"
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			msg = "The file is too large"
+		}
+		_ = templates.UserImportReport(nil, msg).Render(r.Context(), w)
 		return
 	}
+	defer file.Close()
 
-	// For non-HTMX requests, redirect to list for now
-	http.Redirect(w, r, "/users", http.StatusFound)
-}
-
-func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	resp, err := h.client.ImportUsers(header.Filename, file)
+	if err != nil {
+		h.log(r).Error("failed to import users", slog.String("error", err.Error()))
+		_ = templates.UserImportReport(nil, apiErrorMessage(err, "Failed to import users")).Render(r.Context(), w)
 		return
 	}
+	_ = templates.UserImportReport(resp.Results, "").Render(r.Context(), w)
+}
 
+// ApprovalsPage lists the users waiting for approval, oldest first.
+func (h *Handlers) ApprovalsPage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
 
-	// Extract user ID from form
-	userID := r.FormValue("user_id")
-	if userID == "" {
-		http.Error(w, "User ID required", http.StatusBadRequest)
-		return
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
 	}
 
-	accountTypeStr := r.FormValue("account_type")
-	accountType := entities.AccountType(accountTypeStr)
+	errMsg := approvalErrorMessage(r.URL.Query().Get("error"))
+	pending, err := h.client.ListPendingUsers(page, 20)
+	if err != nil {
+		h.log(r).Error("failed to list pending users", slog.String("error", err.Error()))
+		pending = &entities.UserListResponse{}
+		errMsg = "Failed to load the users waiting for approval"
+	}
 
-	req := gweb.UpdateUserRequest{
-		AccountType: accountType,
+	data := map[string]interface{}{
+		"Title":   "Approvals",
+		"User":    user,
+		"Pending": pending,
+		"Message": approvalMessage(r.URL.Query().Get("msg")),
+		"Error":   errMsg,
 	}
 
-	if email := r.FormValue("email"); email != "" {
-		req.Email = email
+	renderTemplate(w, r, "approvals.templ", data)
+}
+
+// ApproveUser lets a pending user in.
+func (h *Handlers) ApproveUser(w http.ResponseWriter, r *http.Request) {
+	h.reviewRegistration(w, r, true)
+}
+
+// RejectUser turns down a pending user, deleting their account.
+func (h *Handlers) RejectUser(w http.ResponseWriter, r *http.Request) {
+	h.reviewRegistration(w, r, false)
+}
+
+func (h *Handlers) reviewRegistration(w http.ResponseWriter, r *http.Request, approve bool) {
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
 	}
+	notify := r.FormValue("notify") == "on"
 
-	_, err := h.client.UpdateUser(userID, req)
+	var err error
+	msg := "approved"
+	if approve {
+		err = h.client.ApproveUser(userID, notify)
+	} else {
+		msg = "rejected"
+		err = h.client.RejectUser(userID, strings.TrimSpace(r.FormValue("reason")), notify)
+	}
 	if err != nil {
-		h.logger.Error("failed to update user", slog.String("error", err.Error()))
-		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		h.log(r).Error("failed to review registration", slog.String("user_id", userID), slog.Bool("approve", approve), slog.String("error", err.Error()))
+		code := "review_failed"
+		switch {
+		case strings.Contains(err.Error(), "409"):
+			code = "not_pending"
+		case strings.Contains(err.Error(), "404"):
+			code = "not_found"
+		}
+		http.Redirect(w, r, "/approvals?error="+code, http.StatusFound)
 		return
 	}
 
-	// If HX-Request, return refreshed users table fragment (preserve container id)
-	if r.Header.Get("HX-Request") == "true" {
-		page := 1
-		pageSize := 20
-		if v := r.URL.Query().Get("page"); v != "" {
-			if p, err := strconv.Atoi(v); err == nil && p > 0 {
-				page = p
-			}
-		}
-		if v := r.URL.Query().Get("page_size"); v != "" {
-			if ps, err := strconv.Atoi(v); err == nil && ps > 0 && ps <= 100 {
-				pageSize = ps
-			}
-		}
+	http.Redirect(w, r, "/approvals?msg="+msg, http.StatusFound)
+}
 
-		users, err := h.client.ListUsers(page, pageSize)
-		if err != nil {
-			users = &entities.UserListResponse{}
-		}
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`<div id="users-table">`))
-		_ = templates.UsersTable(users, user).Render(context.Background(), w)
-		w.Write([]byte(`</div>`))
-		return
+func approvalMessage(msg string) string {
+	switch msg {
+	case "approved":
+		return "The user was approved and can now sign in."
+	case "rejected":
+		return "The registration was rejected and the account deleted."
+	default:
+		return ""
 	}
+}
 
-	// Redirect back to users page
-	http.Redirect(w, r, "/users", http.StatusFound)
+func approvalErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "not_pending":
+		return "That user is no longer waiting for approval."
+	case "not_found":
+		return "That user no longer exists."
+	default:
+		return "Failed to review the registration, try again."
+	}
 }
 
-func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+// InvitationsPage lists the invitation codes, newest first.
+func (h *Handlers) InvitationsPage(w http.ResponseWriter, r *http.Request) {
+	errMsg := invitationErrorMessage(r.URL.Query().Get("error"))
+	msg := ""
+	if r.URL.Query().Get("msg") == "revoked" {
+		msg = "The invitation was revoked."
 	}
+	h.renderInvitations(w, r, "", msg, errMsg)
+}
 
-	user := GetUserFromContext(r)
-	if user == nil {
-		http.Redirect(w, r, "/login", http.StatusFound)
-		return
+// CreateInvitation creates an invitation code and shows it, only this once.
+func (h *Handlers) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	req := gweb.InvitationRequest{
+		AccountType: entities.AccountType(r.FormValue("account_type")),
+		Note:        strings.TrimSpace(r.FormValue("note")),
+	}
+	if v := strings.TrimSpace(r.FormValue("max_uses")); v != "" {
+		maxUses, err := strconv.Atoi(v)
+		if err != nil {
+			http.Redirect(w, r, "/invitations?error=invalid", http.StatusFound)
+			return
+		}
+		req.MaxUses = &maxUses
+	}
+	if days, err := strconv.Atoi(r.FormValue("expires_in_days")); err == nil && days > 0 {
+		expiresAt := time.Now().AddDate(0, 0, days)
+		req.ExpiresAt = &expiresAt
 	}
 
-	// Validate user can create accounts (all admins can create, but with restrictions)
-	if user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin {
-		if r.Header.Get("HX-Request") == "true" {
-			http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
-		} else {
-			http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+	_, code, err := h.client.CreateInvitation(req)
+	if err != nil {
+		h.log(r).Error("failed to create invitation", slog.String("error", err.Error()))
+		reason := "create_failed"
+		switch {
+		case strings.Contains(err.Error(), "400"):
+			reason = "invalid"
+		case strings.Contains(err.Error(), "403"):
+			reason = "forbidden"
 		}
+		http.Redirect(w, r, "/invitations?error="+reason, http.StatusFound)
 		return
 	}
 
-	email := r.FormValue("email")
-	password := r.FormValue("password")
-	accountTypeStr := r.FormValue("account_type")
-	authProvider := r.FormValue("auth_provider")
+	h.renderInvitations(w, r, code, "", "")
+}
 
-	if email == "" || password == "" || accountTypeStr == "" || authProvider == "" {
-		http.Error(w, "All fields are required", http.StatusBadRequest)
+// RevokeInvitation stops an invitation code from being registered with.
+func (h *Handlers) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID := r.FormValue("invitation_id")
+	if invitationID == "" {
+		http.Error(w, "Invitation ID required", http.StatusBadRequest)
 		return
 	}
 
-	accountType := entities.AccountType(accountTypeStr)
-
-	// Validate account type creation permissions
-	if user.AccountType == entities.AccountTypeAdmin && accountType != entities.AccountTypeUser {
-		if r.Header.Get("HX-Request") == "true" {
-			http.Error(w, "Regular admins can only create user accounts", http.StatusForbidden)
-		} else {
-			http.Redirect(w, r, "/users?error=invalid_account_type", http.StatusFound)
-		}
+	if err := h.client.RevokeInvitation(invitationID); err != nil {
+		h.log(r).Error("failed to revoke invitation", slog.String("invitation_id", invitationID), slog.String("error", err.Error()))
+		http.Redirect(w, r, "/invitations?error=revoke_failed", http.StatusFound)
 		return
 	}
 
-	req := gweb.CreateUserRequest{
-		Email:        email,
-		Password:     password,
-		AccountType:  accountType,
-		AuthProvider: authProvider,
+	http.Redirect(w, r, "/invitations?msg=revoked", http.StatusFound)
+}
+
+func (h *Handlers) renderInvitations(w http.ResponseWriter, r *http.Request, code, msg, errMsg string) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
 	}
 
-	_, err := h.client.CreateUser(req)
+	invitations, err := h.client.ListInvitations()
 	if err != nil {
-		h.logger.Error("failed to create user", slog.String("error", err.Error()))
-		http.Error(w, "Failed to create user", http.StatusInternalServerError)
-		return
+		h.log(r).Error("failed to list invitations", slog.String("error", err.Error()))
+		errMsg = "Failed to load the invitations"
 	}
 
-	// If HX-Request, return refreshed users table fragment
-	if r.Header.Get("HX-Request") == "true" {
-		page := 1
-		pageSize := 20
-		users, err := h.client.ListUsers(page, pageSize)
-		if err != nil {
-			users = &entities.UserListResponse{}
-		}
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`<div id="users-table">`))
-		_ = templates.UsersTable(users, user).Render(context.Background(), w)
-		w.Write([]byte(`</div>`))
-		return
+	data := map[string]interface{}{
+		"Title":       "Invitations",
+		"User":        user,
+		"Invitations": invitations,
+		"Code":        code,
+		"Message":     msg,
+		"Error":       errMsg,
 	}
 
-	// Redirect back to users page
-	http.Redirect(w, r, "/users", http.StatusFound)
+	renderTemplate(w, r, "invitations.templ", data)
 }
 
-func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+func invitationErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "invalid":
+		return "Uses must be at least 1, and the expiry in the future."
+	case "forbidden":
+		return "Regular admins can only invite user accounts."
+	case "revoke_failed":
+		return "Failed to revoke the invitation, try again."
+	default:
+		return "Failed to create the invitation, try again."
 	}
+}
 
+// AnnouncementsPage lists the announcements, latest first. With ?edit=ID the
+// form edits that announcement.
+func (h *Handlers) AnnouncementsPage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
 
-	// Validate user can delete accounts (all admins can delete, but with restrictions)
-	if user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin {
-		if r.Header.Get("HX-Request") == "true" {
-			http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
-		} else {
-			http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+	errMsg := announcementErrorMessage(r.URL.Query().Get("error"))
+	announcements, err := h.client.ListAllAnnouncements()
+	if err != nil {
+		h.log(r).Error("failed to list announcements", slog.String("error", err.Error()))
+		errMsg = "Failed to load the announcements"
+	}
+
+	var editing *entities.Announcement
+	if id := r.URL.Query().Get("edit"); id != "" {
+		for i := range announcements {
+			if announcements[i].ID.String() == id {
+				editing = &announcements[i]
+				break
+			}
 		}
-		return
 	}
 
-	userID := r.FormValue("user_id")
-	if userID == "" {
-		http.Error(w, "User ID required", http.StatusBadRequest)
-		return
+	data := map[string]interface{}{
+		"Title":         "Announcements",
+		"User":          user,
+		"Announcements": announcements,
+		"Editing":       editing,
+		"Message":       announcementMessage(r.URL.Query().Get("msg")),
+		"Error":         errMsg,
 	}
 
-	// Get the target user to check their account type
-	targetUser, err := h.client.GetUser(userID)
-	if err != nil {
-		h.logger.Error("failed to get target user", slog.String("error", err.Error()))
-		http.Error(w, "User not found", http.StatusNotFound)
+	renderTemplate(w, r, "announcements.templ", data)
+}
+
+func (h *Handlers) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	req, ok := announcementRequest(r)
+	if !ok {
+		http.Redirect(w, r, "/announcements?error=invalid", http.StatusFound)
 		return
 	}
 
-	// Validate account type deletion permissions
-	if user.AccountType == entities.AccountTypeAdmin && targetUser.AccountType != entities.AccountTypeUser {
-		if r.Header.Get("HX-Request") == "true" {
-			http.Error(w, "Regular admins can only delete user accounts", http.StatusForbidden)
-		} else {
-			http.Redirect(w, r, "/users?error=insufficient_permissions", http.StatusFound)
-		}
+	if _, err := h.client.CreateAnnouncement(req); err != nil {
+		h.log(r).Error("failed to create announcement", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/announcements?error="+announcementErrorCode(err, "create_failed"), http.StatusFound)
 		return
 	}
 
-	// Super admins cannot delete other super admins (existing rule)
-	if targetUser.AccountType == entities.AccountTypeSuperAdmin {
-		if r.Header.Get("HX-Request") == "true" {
-			http.Error(w, "Cannot delete super admin accounts", http.StatusForbidden)
-		} else {
-			http.Redirect(w, r, "/users?error=cannot_delete_superadmin", http.StatusFound)
-		}
+	http.Redirect(w, r, "/announcements?msg=created", http.StatusFound)
+}
+
+func (h *Handlers) UpdateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	req, ok := announcementRequest(r)
+	if !ok {
+		http.Redirect(w, r, "/announcements?error=invalid&edit="+url.QueryEscape(id), http.StatusFound)
 		return
 	}
 
-	if err := h.client.DeleteUser(userID); err != nil {
-		h.logger.Error("failed to delete user", slog.String("error", err.Error()))
-		http.Error(w, "Failed to delete user", http.StatusInternalServerError)
+	if _, err := h.client.UpdateAnnouncement(id, req); err != nil {
+		h.log(r).Error("failed to update announcement", slog.String("announcement_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, "/announcements?error="+announcementErrorCode(err, "update_failed")+"&edit="+url.QueryEscape(id), http.StatusFound)
 		return
 	}
 
-	// If HX-Request, return refreshed users table fragment (preserve container id)
-	if r.Header.Get("HX-Request") == "true" {
-		page := 1
-		pageSize := 20
-		if v := r.URL.Query().Get("page"); v != "" {
-			if p, err := strconv.Atoi(v); err == nil && p > 0 {
-				page = p
-			}
-		}
-		if v := r.URL.Query().Get("page_size"); v != "" {
-			if ps, err := strconv.Atoi(v); err == nil && ps > 0 && ps <= 100 {
-				pageSize = ps
-			}
-		}
+	http.Redirect(w, r, "/announcements?msg=updated", http.StatusFound)
+}
 
-		users, err := h.client.ListUsers(page, pageSize)
-		if err != nil {
-			users = &entities.UserListResponse{}
-		}
+func (h *Handlers) DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.client.DeleteAnnouncement(id); err != nil {
+		h.log(r).Error("failed to delete announcement", slog.String("announcement_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, "/announcements?error="+announcementErrorCode(err, "delete_failed"), http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/announcements?msg=deleted", http.StatusFound)
+}
+
+// announcementRequest reads the announcement form. Times are entered in
+// UTC; it reports false when one can't be parsed.
+func announcementRequest(r *http.Request) (gweb.AnnouncementRequest, bool) {
+	startsAt, ok := formTimeUTC(r, "starts_at")
+	if !ok {
+		return gweb.AnnouncementRequest{}, false
+	}
+	endsAt, ok := formTimeUTC(r, "ends_at")
+	if !ok {
+		return gweb.AnnouncementRequest{}, false
+	}
+	return gweb.AnnouncementRequest{
+		Message:  strings.TrimSpace(r.FormValue("message")),
+		Level:    entities.AnnouncementLevel(r.FormValue("level")),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}, true
+}
+
+// formTimeUTC parses a datetime-local field as UTC. Empty fields are nil.
+func formTimeUTC(r *http.Request, field string) (*time.Time, bool) {
+	v := strings.TrimSpace(r.FormValue(field))
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse("2006-01-02T15:04", v)
+	if err != nil {
+		return nil, false
+	}
+	return &t, true
+}
+
+func announcementErrorCode(err error, fallback string) string {
+	switch {
+	case strings.Contains(err.Error(), "400"):
+		return "invalid"
+	case strings.Contains(err.Error(), "404"):
+		return "not_found"
+	default:
+		return fallback
+	}
+}
+
+func announcementMessage(msg string) string {
+	switch msg {
+	case "created":
+		return "The announcement was created."
+	case "updated":
+		return "The announcement was updated. Users who dismissed it won't see it again."
+	case "deleted":
+		return "The announcement was deleted."
+	default:
+		return ""
+	}
+}
+
+func announcementErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "invalid":
+		return "Announcements need a message of up to 1000 characters, and an end after their start."
+	case "not_found":
+		return "That announcement no longer exists."
+	case "update_failed":
+		return "Failed to update the announcement, try again."
+	case "delete_failed":
+		return "Failed to delete the announcement, try again."
+	default:
+		return "Failed to create the announcement, try again."
+	}
+}
+
+func (h *Handlers) UserDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// If it's an HTMX request for JSON data, return user data
+	if r.Header.Get("HX-Request") == "true" {
+		userData, err := h.client.GetUser(userID)
+		if err != nil {
+			h.log(r).Error("failed to get user", slog.String("error", err.Error()))
+			http.Error(w, "Failed to get user", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(userData)
+		return
+	}
+
+	targetUser, err := h.client.GetUser(userID)
+	if err != nil {
+		h.log(r).Error("failed to get user", slog.String("user_id", userID), slog.String("error", err.Error()))
+		if strings.Contains(err.Error(), "404") {
+			http.Redirect(w, r, "/users?error=user_not_found", http.StatusFound)
+			return
+		}
+		http.Error(w, "Failed to get user", http.StatusInternalServerError)
+		return
+	}
+
+	// The page still renders without the login history
+	logins, err := h.client.ListUserLogins(userID)
+	if err != nil {
+		h.log(r).Warn("failed to list user logins", slog.String("user_id", userID), slog.String("error", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"Title":      targetUser.Email,
+		"User":       user,
+		"TargetUser": targetUser,
+		"ProfileMsg": r.URL.Query().Get("profile"),
+		"Logins":     logins,
+	}
+
+	renderTemplate(w, r, "user_detail.templ", data)
+}
+
+// UpdateUserProfile saves the profile form of the user detail page. An empty
+// metadata box clears the user's metadata.
+func (h *Handlers) UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	detailURL := "/users/" + url.PathEscape(userID)
+
+	profile := entities.UserProfile{
+		FirstName:   r.FormValue("first_name"),
+		LastName:    r.FormValue("last_name"),
+		DisplayName: r.FormValue("display_name"),
+		Timezone:    r.FormValue("timezone"),
+		Locale:      r.FormValue("locale"),
+		Metadata:    map[string]any{},
+	}
+	if raw := strings.TrimSpace(r.FormValue("metadata")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &profile.Metadata); err != nil {
+			http.Redirect(w, r, detailURL+"?profile=invalid", http.StatusFound)
+			return
+		}
+	}
+
+	if err := h.client.UpdateUserProfile(userID, profile); err != nil {
+		h.log(r).Error("failed to update user profile", slog.String("user_id", userID), slog.String("error", err.Error()))
+		msg := "failed"
+		if strings.Contains(err.Error(), "400") {
+			msg = "invalid"
+		}
+		http.Redirect(w, r, detailURL+"?profile="+msg, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, detailURL+"?profile=saved", http.StatusFound)
+}
+
+func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	// Extract user ID from form
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
+	}
+
+	accountTypeStr := r.FormValue("account_type")
+	accountType := entities.AccountType(accountTypeStr)
+
+	req := gweb.UpdateUserRequest{
+		AccountType: accountType,
+	}
+
+	if email := r.FormValue("email"); email != "" {
+		req.Email = email
+	}
+
+	_, err := h.client.UpdateUser(userID, req)
+	if err != nil {
+		h.log(r).Error("failed to update user", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	// If HX-Request, return refreshed users table fragment (preserve container id)
+	if r.Header.Get("HX-Request") == "true" {
+		h.renderUsersTable(w, r, user)
+		return
+	}
+
+	// Redirect back to users page
+	http.Redirect(w, r, "/users", http.StatusFound)
+}
+
+// renderUsersTable writes the users table fragment htmx swaps in after a
+// change to a user, keeping the page the admin was on.
+func (h *Handlers) renderUsersTable(w http.ResponseWriter, r *http.Request, user *entities.User) {
+	page := 1
+	pageSize := 20
+	if v := r.URL.Query().Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		if ps, err := strconv.Atoi(v); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	users, err := h.client.ListUsers(page, pageSize)
+	if err != nil {
+		users = &entities.UserListResponse{}
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(`<div id="users-table">`))
+	_ = templates.UsersTable(users, user, entities.UserSort{}).Render(r.Context(), w)
+	w.Write([]byte(`</div>`))
+}
+
+// userSortFromQuery reads the users table's sort and order parameters. The
+// API checks the field.
+func userSortFromQuery(query url.Values) entities.UserSort {
+	return entities.UserSort{
+		Field: entities.UserSortField(query.Get("sort")),
+		Desc:  query.Get("order") == "desc",
+	}
+}
+
+// InviteUser emails a link to accept an invitation, where the invitee
+// chooses their password and their account is created.
+func (h *Handlers) InviteUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	// Validate user can invite accounts (all admins can invite, but with restrictions)
+	if user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin {
+		http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
+		return
+	}
+
+	email := strings.TrimSpace(r.FormValue("email"))
+	accountType := entities.AccountType(r.FormValue("account_type"))
+	if email == "" || accountType == "" {
+		http.Error(w, "Email and account type are required", http.StatusBadRequest)
+		return
+	}
+
+	// Validate account type invitation permissions
+	if user.AccountType == entities.AccountTypeAdmin && accountType != entities.AccountTypeUser {
+		http.Error(w, "Regular admins can only invite user accounts", http.StatusForbidden)
+		return
+	}
+
+	_, err := h.client.SendInvitation(gweb.InviteRequest{
+		Email:       email,
+		AccountType: accountType,
+	})
+	if err != nil {
+		h.log(r).Error("failed to send invitation", slog.String("error", err.Error()))
+		switch {
+		case strings.Contains(err.Error(), "400"):
+			http.Error(w, "Enter a valid email address", http.StatusBadRequest)
+		case strings.Contains(err.Error(), "404"):
+			http.Error(w, "Email invitations need an email provider to be configured", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, "Failed to send the invitation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("Invitation sent to " + email))
+}
+
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	// Validate user can delete accounts (all admins can delete, but with restrictions)
+	if user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin {
+		if r.Header.Get("HX-Request") == "true" {
+			http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
+		} else {
+			http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+		}
+		return
+	}
+
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
+	}
+
+	// Get the target user to check their account type
+	targetUser, err := h.client.GetUser(userID)
+	if err != nil {
+		h.log(r).Error("failed to get target user", slog.String("error", err.Error()))
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	// Validate account type deletion permissions
+	if user.AccountType == entities.AccountTypeAdmin && targetUser.AccountType != entities.AccountTypeUser {
+		if r.Header.Get("HX-Request") == "true" {
+			http.Error(w, "Regular admins can only delete user accounts", http.StatusForbidden)
+		} else {
+			http.Redirect(w, r, "/users?error=insufficient_permissions", http.StatusFound)
+		}
+		return
+	}
+
+	// Super admins cannot delete other super admins (existing rule)
+	if targetUser.AccountType == entities.AccountTypeSuperAdmin {
+		if r.Header.Get("HX-Request") == "true" {
+			http.Error(w, "Cannot delete super admin accounts", http.StatusForbidden)
+		} else {
+			http.Redirect(w, r, "/users?error=cannot_delete_superadmin", http.StatusFound)
+		}
+		return
+	}
+
+	if err := h.client.DeleteUser(userID); err != nil {
+		h.log(r).Error("failed to delete user", slog.String("error", err.Error()))
+		http.Error(w, "Failed to delete user", http.StatusInternalServerError)
+		return
+	}
+
+	// If HX-Request, return refreshed users table fragment (preserve container id)
+	if r.Header.Get("HX-Request") == "true" {
+		h.renderUsersTable(w, r, user)
+		return
+	}
+
+	// Redirect back to users page
+	http.Redirect(w, r, "/users", http.StatusFound)
+}
+
+// BulkUsers applies one action to the users checked in the users table.
+// The API skips those the admin can't act on; how many were is reported in
+// the X-Bulk-Summary header along with the refreshed table.
+func (h *Handlers) BulkUsers(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	userIDs := r.Form["user_ids"]
+	if len(userIDs) == 0 {
+		http.Error(w, "Select at least one user", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.BulkUsers(gweb.BulkUsersRequest{
+		Action:      entities.BulkUserAction(r.FormValue("bulk_action")),
+		UserIDs:     userIDs,
+		AccountType: entities.AccountType(r.FormValue("bulk_account_type")),
+		Reason:      strings.TrimSpace(r.FormValue("bulk_reason")),
+	})
+	if err != nil {
+		h.log(r).Error("failed to apply bulk user operation", slog.Int("users", len(userIDs)), slog.String("error", err.Error()))
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "400"):
+			status = http.StatusBadRequest
+		case strings.Contains(err.Error(), "403"):
+			status = http.StatusForbidden
+		case strings.Contains(err.Error(), "409"):
+			status = http.StatusConflict
+		}
+		http.Error(w, apiErrorMessage(err, "Failed to apply the bulk action"), status)
+		return
+	}
+
+	summary := fmt.Sprintf("%d users updated", resp.Succeeded)
+	if resp.Failed > 0 {
+		summary += fmt.Sprintf(", %d skipped", resp.Failed)
+		for _, result := range resp.Results {
+			if !result.OK {
+				summary += fmt.Sprintf(" (%s)", result.Error)
+				break
+			}
+		}
+	}
+	w.Header().Set("X-Bulk-Summary", summary)
+	h.renderUsersTable(w, r, user)
+}
+
+// RevokeUserSessions forces a user out of every session. The API decides
+// whether the admin may do so for the target account.
+func (h *Handlers) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.client.RevokeUserSessions(userID); err != nil {
+		h.log(r).Error("failed to revoke user sessions", slog.String("user_id", userID), slog.String("error", err.Error()))
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "403"):
+			status = http.StatusForbidden
+		case strings.Contains(err.Error(), "404"):
+			status = http.StatusNotFound
+		}
+		if r.Header.Get("HX-Request") == "true" {
+			http.Error(w, "Failed to revoke sessions", status)
+		} else {
+			http.Redirect(w, r, "/users?error=revoke_sessions_failed", http.StatusFound)
+		}
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/users", http.StatusFound)
+}
+
+// SuspendUser keeps a user out until reactivated, with the reason the admin
+// gave. The API decides whether the admin may do so for the target account.
+func (h *Handlers) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserStatus(w, r, true)
+}
+
+// ReactivateUser lets a suspended user back in.
+func (h *Handlers) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserStatus(w, r, false)
+}
+
+func (h *Handlers) setUserStatus(w http.ResponseWriter, r *http.Request, suspend bool) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if suspend {
+		err = h.client.SuspendUser(userID, strings.TrimSpace(r.FormValue("reason")))
+	} else {
+		err = h.client.ReactivateUser(userID)
+	}
+	if err != nil {
+		h.log(r).Error("failed to change user status", slog.String("user_id", userID), slog.Bool("suspend", suspend), slog.String("error", err.Error()))
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "403"):
+			status = http.StatusForbidden
+		case strings.Contains(err.Error(), "404"):
+			status = http.StatusNotFound
+		}
+		if r.Header.Get("HX-Request") == "true" {
+			http.Error(w, "Failed to change user status", status)
+		} else {
+			http.Redirect(w, r, "/users?error=status_change_failed", http.StatusFound)
+		}
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		h.renderUsersTable(w, r, user)
+		return
+	}
+	http.Redirect(w, r, "/users", http.StatusFound)
+}
+
+// ImpersonateUser gets a token acting as the user and hands it to the Web
+// app with a form that posts itself, so the token stays out of URLs and
+// logs. The admin's own session is left alone.
+func (h *Handlers) ImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.FormValue("user_id")
+	if userID == "" {
+		http.Error(w, "User ID required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.client.ImpersonateUser(userID)
+	if err != nil || resp.Token == "" {
+		if err != nil {
+			h.log(r).Error("failed to impersonate user", slog.String("user_id", userID), slog.String("error", err.Error()))
+		}
+		http.Redirect(w, r, "/users?error=impersonation_failed", http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.ImpersonationHandoff(h.webBaseURL+"/impersonate", resp.Token, resp.User.Email).Render(r.Context(), w)
+}
+
+func (h *Handlers) SettingsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	// Double-check super admin access (middleware should already protect this)
+	if user.AccountType != entities.AccountTypeSuperAdmin {
+		http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+		return
+	}
+
+	settings, err := h.client.GetSettings()
+	if err != nil {
+		h.log(r).Error("failed to get settings", slog.String("error", err.Error()))
+		settings = &entities.SystemSettings{} // Use empty settings on error
+	}
+
+	data := map[string]interface{}{
+		"Title":    "System Settings",
+		"User":     user,
+		"Settings": settings,
+	}
+
+	renderTemplate(w, r, "settings.templ", data)
+}
+
+func (h *Handlers) GetAuthProviders(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Double-check super admin access (middleware should already protect this)
+	if user.AccountType == entities.AccountTypeUser {
+		http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
+		return
+	}
+
+	providers, err := h.client.GetAuthProviders()
+	if err != nil {
+		h.log(r).Error("failed to get auth providers", slog.String("error", err.Error()))
+		// Return default options if API call fails
 		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`<div id="users-table">`))
-		_ = templates.UsersTable(users, user).Render(context.Background(), w)
-		w.Write([]byte(`</div>`))
+		w.Write([]byte(`
+			<option value="">Select authentication provider</option>
+			<option value="supabase">Supabase</option>
+		`))
+		return
+	}
+
+	// Convert response to HTML options
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(`<option value="">Select authentication provider</option>`))
+
+	if availableProviders, ok := providers["available_providers"].([]interface{}); ok {
+		defaultProvider, _ := providers["default_provider"].(string)
+
+		for _, provider := range availableProviders {
+			if providerStr, ok := provider.(string); ok {
+				selected := ""
+				if providerStr == defaultProvider {
+					selected = " selected"
+				}
+				w.Write([]byte(fmt.Sprintf(`<option value="%s"%s>%s</option>`, providerStr, selected, providerStr)))
+			}
+		}
+	}
+}
+
+func (h *Handlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	// Double-check super admin access (middleware should already protect this)
+	if user.AccountType != entities.AccountTypeSuperAdmin {
+		http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+		return
+	}
+
+	// Parse form values with defaults
+	sessionTimeout := 1440
+	if timeout := r.FormValue("session_timeout"); timeout != "" {
+		if t, err := strconv.Atoi(timeout); err == nil {
+			sessionTimeout = t
+		}
+	}
+
+	minPasswordLength := 8
+	if minLen := r.FormValue("min_password_length"); minLen != "" {
+		if l, err := strconv.Atoi(minLen); err == nil {
+			minPasswordLength = l
+		}
+	}
+
+	backupRetentionDays := 30
+	if retention := r.FormValue("backup_retention_days"); retention != "" {
+		if d, err := strconv.Atoi(retention); err == nil {
+			backupRetentionDays = d
+		}
+	}
+
+	// Parse auth provider fields
+	availableProviders := r.Form["available_auth_providers"] // Gets all checkbox values
+	if len(availableProviders) == 0 {
+		// Default to supabase if none selected
+		availableProviders = []string{"supabase"}
+	}
+
+	defaultAuthProvider := r.FormValue("default_auth_provider")
+	if defaultAuthProvider == "" {
+		defaultAuthProvider = "supabase"
+	}
+
+	settings := entities.SystemSettings{
+		MaintenanceMode:          r.FormValue("maintenance_mode") == "on",
+		RegistrationEnabled:      r.FormValue("registration_enabled") == "on",
+		InvitationsEnabled:       r.FormValue("invitations_enabled") == "on",
+		EmailNotifications:       r.FormValue("email_notifications") == "on",
+		SessionTimeout:           sessionTimeout,
+		MinPasswordLength:        minPasswordLength,
+		PasswordRequireMixedCase: r.FormValue("password_require_mixed_case") == "on",
+		PasswordRequireDigit:     r.FormValue("password_require_digit") == "on",
+		PasswordRequireSymbol:    r.FormValue("password_require_symbol") == "on",
+		PasswordCheckBreached:    r.FormValue("password_check_breached") == "on",
+		Require2FA:               r.FormValue("require_2fa") == "on",
+		RequireEmailVerification: r.FormValue("require_email_verification") == "on",
+		RequireCaptcha:           r.FormValue("require_captcha") == "on",
+		RequireApproval:          r.FormValue("require_approval") == "on",
+		AutoBackup:               r.FormValue("auto_backup") == "on",
+		BackupRetentionDays:      backupRetentionDays,
+		AvailableAuthProviders:   availableProviders,
+		DefaultAuthProvider:      defaultAuthProvider,
+	}
+
+	if err := h.client.UpdateSettings(settings); err != nil {
+		h.log(r).Error("failed to update settings", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/settings", http.StatusFound)
+}
+
+func (h *Handlers) LogsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":     "System Logs",
+		"User":      user,
+		"Level":     r.URL.Query().Get("level"),
+		"RequestID": r.URL.Query().Get("request_id"),
+	}
+
+	renderTemplate(w, r, "logs.templ", data)
+}
+
+// auditTimeLayout is the value of the audit filter's datetime-local inputs,
+// read as UTC.
+const auditTimeLayout = "2006-01-02T15:04"
+
+func (h *Handlers) AuditPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := entities.AuditFilter{
+		Action:     strings.TrimSpace(query.Get("action")),
+		Resource:   strings.TrimSpace(query.Get("resource")),
+		ResourceID: strings.TrimSpace(query.Get("resource_id")),
+		PageSize:   50,
+	}
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		filter.Page = p
+	}
+
+	var errMsg string
+	if v := strings.TrimSpace(query.Get("actor_id")); v != "" {
+		if actorID, err := uuid.FromString(v); err == nil {
+			filter.ActorID = &actorID
+		} else {
+			errMsg = "Actor ID must be a user ID"
+		}
+	}
+	if v := query.Get("from"); v != "" {
+		if from, err := time.ParseInLocation(auditTimeLayout, v, time.UTC); err == nil {
+			filter.From = &from
+		} else {
+			errMsg = "Invalid from time"
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if to, err := time.ParseInLocation(auditTimeLayout, v, time.UTC); err == nil {
+			filter.To = &to
+		} else {
+			errMsg = "Invalid to time"
+		}
+	}
+	if expr, err := filterExpr.Parse(query.Get("filter"), entities.AuditFilterFields); err == nil {
+		filter.Expr = expr
+	} else {
+		errMsg = "Invalid filter: " + strings.TrimPrefix(err.Error(), filterExpr.ErrInvalid.Error()+": ")
+	}
+
+	var events *entities.AuditEventListResponse
+	if errMsg == "" {
+		var err error
+		events, err = h.client.ListAuditEvents(filter)
+		if err != nil {
+			h.log(r).Error("failed to list audit events", slog.String("error", err.Error()))
+			errMsg = apiErrorMessage(err, "Failed to load audit events")
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":  "Audit Log",
+		"User":   user,
+		"Query":  query,
+		"Events": events,
+		"Error":  errMsg,
+	}
+
+	renderTemplate(w, r, "audit.templ", data)
+}
+
+// EmailsPage previews a transactional email, rendered by the API with sample
+// data, so designers can check their templates.
+func (h *Handlers) EmailsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	name := entities.EmailTemplate(r.URL.Query().Get("name"))
+	locale := strings.TrimSpace(r.URL.Query().Get("locale"))
+
+	var errMsg string
+	names, err := h.client.ListEmails()
+	if err != nil {
+		h.log(r).Error("failed to list emails", slog.String("error", err.Error()))
+		errMsg = apiErrorMessage(err, "Failed to load emails")
+	}
+	if name == "" && len(names) > 0 {
+		name = names[0]
+	}
+
+	var preview *entities.RenderedEmail
+	if errMsg == "" && name != "" {
+		preview, err = h.client.PreviewEmail(name, locale)
+		if err != nil {
+			h.log(r).Error("failed to preview email", slog.String("email", string(name)), slog.String("error", err.Error()))
+			errMsg = apiErrorMessage(err, "Failed to preview email")
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Email Templates",
+		"User":    user,
+		"Names":   names,
+		"Name":    name,
+		"Locale":  locale,
+		"Preview": preview,
+		"Error":   errMsg,
+	}
+
+	renderTemplate(w, r, "emails.templ", data)
+}
+
+// SearchPage runs a full-text search across users and examples.
+func (h *Handlers) SearchPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	kind := r.URL.Query().Get("kind")
+
+	var results *entities.SearchResponse
+	var errMsg string
+	if query != "" {
+		var kinds []entities.SearchKind
+		if kind != "" {
+			kinds = []entities.SearchKind{entities.SearchKind(kind)}
+		}
+		var err error
+		results, err = h.client.AdminSearch(query, kinds, 50)
+		if err != nil {
+			h.log(r).Error("failed to search", slog.String("error", err.Error()))
+			errMsg = apiErrorMessage(err, "Search failed")
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Search",
+		"User":    user,
+		"Query":   query,
+		"Kind":    kind,
+		"Results": results,
+		"Error":   errMsg,
+	}
+
+	renderTemplate(w, r, "search.templ", data)
+}
+
+func (h *Handlers) SystemPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	report, err := h.client.GetReconciliationReport()
+	if err != nil {
+		h.log(r).Error("failed to get reconciliation report", slog.String("error", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"Title":  "System Status",
+		"User":   user,
+		"Report": report,
+	}
+
+	renderTemplate(w, r, "system.templ", data)
+}
+
+func (h *Handlers) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	// A failed run is stored in the report, which the page shows
+	if err := h.client.RunReconciliation(); err != nil {
+		h.log(r).Error("user reconciliation failed", slog.String("error", err.Error()))
+	}
+
+	http.Redirect(w, r, "/system", http.StatusFound)
+}
+
+// BackupsPage lists the database backups, newest first.
+func (h *Handlers) BackupsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	errMsg := backupErrorMessage(r.URL.Query().Get("error"))
+	backups, err := h.client.ListBackups(page, 20)
+	if err != nil {
+		h.log(r).Error("failed to list backups", slog.String("error", err.Error()))
+		errMsg = "Failed to load the backups. Backups need file storage and a signing key."
+	}
+
+	msg := ""
+	if r.URL.Query().Get("msg") == "created" {
+		msg = "Backup queued. It shows up as succeeded once the dump is stored."
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Backups",
+		"User":    user,
+		"Backups": backups,
+		"Message": msg,
+		"Error":   errMsg,
+	}
+
+	renderTemplate(w, r, "backups.templ", data)
+}
+
+func (h *Handlers) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.client.CreateBackup(); err != nil {
+		h.log(r).Error("failed to create backup", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/backups?error=create_failed", http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/backups?msg=created", http.StatusFound)
+}
+
+// DownloadBackup sends the browser to a fresh signed link to the backup.
+func (h *Handlers) DownloadBackup(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	backup, err := h.client.GetBackup(id)
+	if err != nil || backup.DownloadURL == "" {
+		if err != nil {
+			h.log(r).Error("failed to get backup", slog.String("backup_id", id), slog.String("error", err.Error()))
+		}
+		http.Redirect(w, r, "/backups?error=download_failed", http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, backup.DownloadURL, http.StatusFound)
+}
+
+func backupErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "create_failed":
+		return "Failed to queue the backup, try again."
+	default:
+		return "That backup can't be downloaded."
+	}
+}
+
+// JobsPage lists the background jobs, newest first, optionally those with
+// a status or of a kind only.
+func (h *Handlers) JobsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	query := r.URL.Query()
+	status := query.Get("status")
+	kind := query.Get("kind")
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	errMsg := jobErrorMessage(query.Get("error"))
+	jobs, err := h.client.ListJobs(status, kind, page, 20)
+	if err != nil {
+		h.log(r).Error("failed to list jobs", slog.String("error", err.Error()))
+		errMsg = "Failed to load the jobs."
+	}
+
+	msg := ""
+	switch query.Get("msg") {
+	case "requeued":
+		msg = "Job requeued."
+	case "canceled":
+		msg = "Job canceled."
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Background Jobs",
+		"User":    user,
+		"Jobs":    jobs,
+		"Status":  status,
+		"Kind":    kind,
+		"Message": msg,
+		"Error":   errMsg,
+	}
+
+	renderTemplate(w, r, "jobs.templ", data)
+}
+
+func (h *Handlers) RequeueJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.client.RequeueJob(id); err != nil {
+		h.log(r).Error("failed to requeue job", slog.String("job_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, jobsURL(r, "error", "requeue_failed"), http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, jobsURL(r, "msg", "requeued"), http.StatusFound)
+}
+
+func (h *Handlers) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.client.CancelJob(id); err != nil {
+		h.log(r).Error("failed to cancel job", slog.String("job_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, jobsURL(r, "error", "cancel_failed"), http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, jobsURL(r, "msg", "canceled"), http.StatusFound)
+}
+
+// jobsURL returns to the jobs listed when the form was posted, with a
+// message.
+func jobsURL(r *http.Request, key, value string) string {
+	params := url.Values{}
+	if status := r.FormValue("status"); status != "" {
+		params.Set("status", status)
+	}
+	if kind := r.FormValue("kind"); kind != "" {
+		params.Set("kind", kind)
+	}
+	params.Set(key, value)
+	return "/jobs?" + params.Encode()
+}
+
+func jobErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "requeue_failed":
+		return "That job can't be requeued: only dead-lettered or canceled jobs can."
+	default:
+		return "That job can't be canceled: it is no longer queued."
+	}
+}
+
+// WebhooksPage lists the outgoing webhooks with the form registering one.
+func (h *Handlers) WebhooksPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
 
-	// Redirect back to users page
-	http.Redirect(w, r, "/users", http.StatusFound)
+	msg := ""
+	if r.URL.Query().Get("msg") == "deleted" {
+		msg = "Webhook deleted."
+	}
+	h.renderWebhooks(w, r, user, nil, msg, webhookErrorMessage(r.URL.Query().Get("error")))
 }
 
-func (h *Handlers) SettingsPage(w http.ResponseWriter, r *http.Request) {
+// CreateWebhook registers a webhook and shows its secret, which the API
+// only returns this once.
+func (h *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
+	if err := r.ParseForm(); err != nil {
+		http.Redirect(w, r, "/webhooks?error=invalid", http.StatusFound)
+		return
+	}
 
-	// Double-check super admin access (middleware should already protect this)
-	if user.AccountType != entities.AccountTypeSuperAdmin {
-		http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+	created, err := h.client.CreateWebhook(gweb.WebhookRequest{
+		URL:    strings.TrimSpace(r.FormValue("url")),
+		Events: r.Form["events"],
+	})
+	if err != nil {
+		h.log(r).Error("failed to create webhook", slog.String("error", err.Error()))
+		code := "create_failed"
+		if strings.Contains(err.Error(), "400") {
+			code = "invalid"
+		}
+		http.Redirect(w, r, "/webhooks?error="+code, http.StatusFound)
 		return
 	}
 
-	settings, err := h.client.GetSettings()
+	h.renderWebhooks(w, r, user, created, "Webhook created. Copy its secret now, it won't be shown again.", "")
+}
+
+func (h *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.client.DeleteWebhook(id); err != nil {
+		h.log(r).Error("failed to delete webhook", slog.String("webhook_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, "/webhooks?error=delete_failed", http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/webhooks?msg=deleted", http.StatusFound)
+}
+
+func (h *Handlers) renderWebhooks(w http.ResponseWriter, r *http.Request, user *entities.User, created *entities.Webhook, msg, errMsg string) {
+	webhooks, err := h.client.ListWebhooks()
 	if err != nil {
-		h.logger.Error("failed to get settings", slog.String("error", err.Error()))
-		settings = &entities.SystemSettings{} // Use empty settings on error
+		h.log(r).Error("failed to list webhooks", slog.String("error", err.Error()))
+		errMsg = "Failed to load the webhooks."
+	}
+	eventNames, err := h.client.ListWebhookEvents()
+	if err != nil {
+		h.log(r).Error("failed to list webhook events", slog.String("error", err.Error()))
 	}
 
 	data := map[string]interface{}{
-		"Title":    "System Settings",
+		"Title":    "Webhooks",
 		"User":     user,
-		"Settings": settings,
+		"Webhooks": webhooks,
+		"Events":   eventNames,
+		"Created":  created,
+		"Message":  msg,
+		"Error":    errMsg,
 	}
 
-	renderTemplate(w, "settings.templ", data)
+	renderTemplate(w, r, "webhooks.templ", data)
 }
 
-func (h *Handlers) GetAuthProviders(w http.ResponseWriter, r *http.Request) {
+// WebhookDeliveriesPage lists a webhook's delivery attempts, newest first,
+// optionally those with a status only, so failed ones can be inspected and
+// redelivered.
+func (h *Handlers) WebhookDeliveriesPage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
 
-	// Double-check super admin access (middleware should already protect this)
-	if user.AccountType == entities.AccountTypeUser {
-		http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
-		return
+	id := chi.URLParam(r, "id")
+	query := r.URL.Query()
+	status := query.Get("status")
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
 	}
 
-	providers, err := h.client.GetAuthProviders()
+	webhook, err := h.client.GetWebhook(id)
 	if err != nil {
-		h.logger.Error("failed to get auth providers", slog.String("error", err.Error()))
-		// Return default options if API call fails
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`
-			<option value="">Select authentication provider</option>
-			<option value="supabase">Supabase</option>
-		`))
+		h.log(r).Error("failed to get webhook", slog.String("webhook_id", id), slog.String("error", err.Error()))
+		http.Redirect(w, r, "/webhooks?error=not_found", http.StatusFound)
 		return
 	}
 
-	// Convert response to HTML options
-	w.Header().Set("Content-Type", "text/html")
-	w.Write([]byte(`<option value="">Select authentication provider</option>`))
+	errMsg := webhookErrorMessage(query.Get("error"))
+	deliveries, err := h.client.ListWebhookDeliveries(id, status, page, 20)
+	if err != nil {
+		h.log(r).Error("failed to list webhook deliveries", slog.String("webhook_id", id), slog.String("error", err.Error()))
+		errMsg = "Failed to load the deliveries."
+	}
 
-	if availableProviders, ok := providers["available_providers"].([]interface{}); ok {
-		defaultProvider, _ := providers["default_provider"].(string)
+	msg := ""
+	switch query.Get("msg") {
+	case "redelivered":
+		msg = "Delivery redelivered."
+	case "redelivery_failed":
+		msg = "Delivery redelivered, but it failed again. The new attempt is listed first."
+	}
 
-		for _, provider := range availableProviders {
-			if providerStr, ok := provider.(string); ok {
-				selected := ""
-				if providerStr == defaultProvider {
-					selected = " selected"
-				}
-				w.Write([]byte(fmt.Sprintf(`<option value="%s"%s>%s</option>`, providerStr, selected, providerStr)))
-			}
-		}
+	data := map[string]interface{}{
+		"Title":      "Webhook Deliveries",
+		"User":       user,
+		"Webhook":    webhook,
+		"Deliveries": deliveries,
+		"Status":     status,
+		"Message":    msg,
+		"Error":      errMsg,
 	}
+
+	renderTemplate(w, r, "webhook_deliveries.templ", data)
 }
 
-func (h *Handlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+func (h *Handlers) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deliveryID := chi.URLParam(r, "deliveryID")
+	delivery, err := h.client.RedeliverWebhookDelivery(id, deliveryID)
+	if err != nil {
+		h.log(r).Error("failed to redeliver webhook delivery", slog.String("webhook_id", id),
+			slog.String("delivery_id", deliveryID), slog.String("error", err.Error()))
+		http.Redirect(w, r, webhookDeliveriesURL(r, id, "error", "redeliver_failed"), http.StatusFound)
+		return
+	}
+
+	if delivery.Status == entities.WebhookDeliveryFailed {
+		http.Redirect(w, r, webhookDeliveriesURL(r, id, "msg", "redelivery_failed"), http.StatusFound)
 		return
 	}
+	http.Redirect(w, r, webhookDeliveriesURL(r, id, "msg", "redelivered"), http.StatusFound)
+}
+
+// webhookDeliveriesURL returns to the deliveries listed when the form was
+// posted, with a message.
+func webhookDeliveriesURL(r *http.Request, id, key, value string) string {
+	params := url.Values{}
+	if status := r.FormValue("status"); status != "" {
+		params.Set("status", status)
+	}
+	params.Set(key, value)
+	return "/webhooks/" + url.PathEscape(id) + "?" + params.Encode()
+}
+
+func webhookErrorMessage(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "invalid":
+		return "The webhook needs an http or https URL and at least one event."
+	case "not_found":
+		return "That webhook no longer exists."
+	case "delete_failed":
+		return "Failed to delete the webhook, try again."
+	case "redeliver_failed":
+		return "Failed to redeliver, try again."
+	default:
+		return "Failed to create the webhook, try again."
+	}
+}
 
+func (h *Handlers) RolesPage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
 	if user == nil {
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
 
-	// Double-check super admin access (middleware should already protect this)
-	if user.AccountType != entities.AccountTypeSuperAdmin {
-		http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
-		return
+	roles, err := h.client.ListRoles()
+	if err != nil {
+		h.log(r).Error("failed to list roles", slog.String("error", err.Error()))
 	}
 
-	// Parse form values with defaults
-	sessionTimeout := 1440
-	if timeout := r.FormValue("session_timeout"); timeout != "" {
-		if t, err := strconv.Atoi(timeout); err == nil {
-			sessionTimeout = t
+	// Linked from the users table to manage one user's roles
+	userID := r.URL.Query().Get("user_id")
+	var userRoles []entities.Role
+	userError := ""
+	if userID != "" {
+		userRoles, err = h.client.GetUserRoles(userID)
+		if err != nil {
+			h.log(r).Error("failed to get user roles", slog.String("user_id", userID), slog.String("error", err.Error()))
+			userError = apiErrorMessage(err, "Failed to load the user's roles")
 		}
 	}
 
-	minPasswordLength := 8
-	if minLen := r.FormValue("min_password_length"); minLen != "" {
-		if l, err := strconv.Atoi(minLen); err == nil {
-			minPasswordLength = l
-		}
+	data := map[string]interface{}{
+		"Title":     "Roles",
+		"User":      user,
+		"Roles":     roles,
+		"UserID":    userID,
+		"UserRoles": userRoles,
+		"UserError": userError,
 	}
 
-	backupRetentionDays := 30
-	if retention := r.FormValue("backup_retention_days"); retention != "" {
-		if d, err := strconv.Atoi(retention); err == nil {
-			backupRetentionDays = d
-		}
+	renderTemplate(w, r, "roles.templ", data)
+}
+
+func (h *Handlers) CreateRole(w http.ResponseWriter, r *http.Request) {
+	_, err := h.client.CreateRole(roleRequest(r))
+	if err != nil {
+		h.log(r).Error("failed to create role", slog.String("error", err.Error()))
 	}
+	h.renderRolesMatrix(w, r, err, "Failed to create role")
+}
 
-	// Parse auth provider fields
-	availableProviders := r.Form["available_auth_providers"] // Gets all checkbox values
-	if len(availableProviders) == 0 {
-		// Default to supabase if none selected
-		availableProviders = []string{"supabase"}
+// UpdateRole saves a row of the permission matrix.
+func (h *Handlers) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	roleID := chi.URLParam(r, "id")
+	_, err := h.client.UpdateRole(roleID, roleRequest(r))
+	if err != nil {
+		h.log(r).Error("failed to update role", slog.String("role_id", roleID), slog.String("error", err.Error()))
 	}
+	h.renderRolesMatrix(w, r, err, "Failed to update role")
+}
 
-	defaultAuthProvider := r.FormValue("default_auth_provider")
-	if defaultAuthProvider == "" {
-		defaultAuthProvider = "supabase"
+func (h *Handlers) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	roleID := chi.URLParam(r, "id")
+	err := h.client.DeleteRole(roleID)
+	if err != nil {
+		h.log(r).Error("failed to delete role", slog.String("role_id", roleID), slog.String("error", err.Error()))
 	}
+	h.renderRolesMatrix(w, r, err, "Failed to delete role")
+}
 
-	settings := entities.SystemSettings{
-		MaintenanceMode:        r.FormValue("maintenance_mode") == "on",
-		RegistrationEnabled:    r.FormValue("registration_enabled") == "on",
-		EmailNotifications:     r.FormValue("email_notifications") == "on",
-		SessionTimeout:         sessionTimeout,
-		MinPasswordLength:      minPasswordLength,
-		Require2FA:             r.FormValue("require_2fa") == "on",
-		AutoBackup:             r.FormValue("auto_backup") == "on",
-		BackupRetentionDays:    backupRetentionDays,
-		AvailableAuthProviders: availableProviders,
-		DefaultAuthProvider:    defaultAuthProvider,
+// GetUserRoles returns the roles section for the user looked up on the roles
+// page.
+func (h *Handlers) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	h.renderUserRoles(w, r, r.URL.Query().Get("user_id"), nil, "")
+}
+
+func (h *Handlers) AssignRole(w http.ResponseWriter, r *http.Request) {
+	userID := r.FormValue("user_id")
+	err := h.client.AssignRole(r.FormValue("role_id"), userID)
+	if err != nil {
+		h.log(r).Error("failed to assign role", slog.String("user_id", userID), slog.String("error", err.Error()))
 	}
+	h.renderUserRoles(w, r, userID, err, "Failed to assign role")
+}
 
-	if err := h.client.UpdateSettings(settings); err != nil {
-		h.logger.Error("failed to update settings", slog.String("error", err.Error()))
-		http.Error(w, "Failed to update settings", http.StatusInternalServerError)
+func (h *Handlers) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	userID := r.FormValue("user_id")
+	err := h.client.UnassignRole(r.FormValue("role_id"), userID)
+	if err != nil {
+		h.log(r).Error("failed to unassign role", slog.String("user_id", userID), slog.String("error", err.Error()))
+	}
+	h.renderUserRoles(w, r, userID, err, "Failed to remove role")
+}
+
+// renderRolesMatrix answers a change to a role with the refreshed permission
+// matrix, showing why the change failed if it did.
+func (h *Handlers) renderRolesMatrix(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/roles", http.StatusFound)
 		return
 	}
 
-	http.Redirect(w, r, "/settings", http.StatusFound)
+	errMsg := ""
+	if err != nil {
+		errMsg = apiErrorMessage(err, fallback)
+	}
+
+	roles, err := h.client.ListRoles()
+	if err != nil {
+		h.log(r).Error("failed to list roles", slog.String("error", err.Error()))
+		http.Error(w, "Failed to list roles", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.RolesMatrix(roles, errMsg).Render(r.Context(), w)
+}
+
+// renderUserRoles answers with the roles section of userID, showing why the
+// last change failed if it did.
+func (h *Handlers) renderUserRoles(w http.ResponseWriter, r *http.Request, userID string, err error, fallback string) {
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/roles?user_id="+url.QueryEscape(userID), http.StatusFound)
+		return
+	}
+
+	errMsg := ""
+	if err != nil {
+		errMsg = apiErrorMessage(err, fallback)
+	}
+
+	var held, roles []entities.Role
+	if userID != "" {
+		held, err = h.client.GetUserRoles(userID)
+		if err != nil {
+			h.log(r).Error("failed to get user roles", slog.String("user_id", userID), slog.String("error", err.Error()))
+			errMsg = apiErrorMessage(err, "Failed to load the user's roles")
+		}
+		roles, err = h.client.ListRoles()
+		if err != nil {
+			h.log(r).Error("failed to list roles", slog.String("error", err.Error()))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.UserRoles(userID, held, roles, errMsg).Render(r.Context(), w)
+}
+
+func roleRequest(r *http.Request) gweb.RoleRequest {
+	req := gweb.RoleRequest{
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		Description: strings.TrimSpace(r.FormValue("description")),
+		Permissions: []entities.Permission{},
+	}
+	for _, perm := range r.Form["permissions"] {
+		req.Permissions = append(req.Permissions, entities.Permission(perm))
+	}
+	return req
+}
+
+// apiErrorMessage is the message the API rejected a request with, or
+// fallback when the API couldn't be reached.
+func apiErrorMessage(err error, fallback string) string {
+	if _, msg, ok := strings.Cut(err.Error(), "): "); ok && strings.HasPrefix(err.Error(), "API error (") {
+		return msg
+	}
+	return fallback
+}
+
+// logsPollInterval is how often LogsStream asks the API for new entries.
+const logsPollInterval = 2 * time.Second
+
+// LogsStream tails the API logs as server-sent events. Each event carries one
+// entry as JSON with its ID, so a reconnecting browser resumes where it left off.
+func (h *Handlers) LogsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	token := getCookieValue(r, CookieToken)
+	filter := entities.LogFilter{
+		Level:     r.URL.Query().Get("level"),
+		RequestID: r.URL.Query().Get("request_id"),
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("after")
+	}
+	if after, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+		filter.After = after
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		h.client.SetAuthToken(token)
+		logs, err := h.client.GetSystemLogs(filter)
+		if err != nil {
+			h.log(r).Error("failed to get system logs", slog.String("error", err.Error()))
+			return
+		}
+
+		for _, entry := range logs.Entries {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", entry.ID, data)
+		}
+		// Move the browser's cursor past entries the filter skipped too
+		if len(logs.Entries) == 0 && logs.LastID != filter.After {
+			fmt.Fprintf(w, "id: %d\n\n", logs.LastID)
+		}
+		filter.After = logs.LastID
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // Additional API endpoints for HTMX responses
@@ -571,7 +2136,66 @@ func (h *Handlers) GetStatsAPI(w http.ResponseWriter, r *http.Request) {
 
 	// Return stats as HTML fragment using templ component
 	w.Header().Set("Content-Type", "text/html")
-	_ = templates.StatsCards(stats).Render(context.Background(), w)
+	_ = templates.StatsCards(stats).Render(r.Context(), w)
+}
+
+// DashboardStream relays the API's dashboard stats events as server-sent
+// events carrying the rendered stats cards, for the HTMX SSE extension to
+// swap in, until the browser or the API ends the stream.
+func (h *Handlers) DashboardStream(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.StreamDashboardStats(r.Context())
+	if err != nil {
+		h.log(r).Warn("failed to open dashboard stream", slog.String("error", err.Error()))
+		http.Error(w, "Dashboard stream unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	var event, data string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+			continue
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+			continue
+		case strings.HasPrefix(line, ":"):
+			// Keep the browser's connection alive too
+			fmt.Fprint(w, line+"\n\n")
+		case line == "" && event == "stats":
+			var stats entities.DashboardStats
+			if err := json.Unmarshal([]byte(data), &stats); err != nil {
+				h.log(r).Warn("failed to decode dashboard stats", slog.String("error", err.Error()))
+				break
+			}
+			var cards bytes.Buffer
+			if err := templates.StatsCards(&stats).Render(r.Context(), &cards); err != nil {
+				return
+			}
+			fmt.Fprint(w, "event: stats\n")
+			for cardsLine := range strings.Lines(cards.String()) {
+				fmt.Fprintf(w, "data: %s\n", strings.TrimSuffix(cardsLine, "\n"))
+			}
+			fmt.Fprint(w, "\n")
+		}
+		event, data = "", ""
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
 }
 
 func (h *Handlers) GetUsersAPI(w http.ResponseWriter, r *http.Request) {
@@ -601,7 +2225,9 @@ func (h *Handlers) GetUsersAPI(w http.ResponseWriter, r *http.Request) {
 	search := r.URL.Query().Get("search")
 	accountType := r.URL.Query().Get("account_type")
 
-	users, err := h.client.ListUsersWithFilter(page, pageSize, search, accountType)
+	sort := userSortFromQuery(r.URL.Query())
+
+	users, err := h.client.ListUsersWithFilter(page, pageSize, search, accountType, sort)
 	if err != nil {
 		http.Error(w, "Failed to get users", http.StatusInternalServerError)
 		return
@@ -610,13 +2236,13 @@ func (h *Handlers) GetUsersAPI(w http.ResponseWriter, r *http.Request) {
 	// Check if this is a request for recent users (dashboard)
 	if r.URL.Query().Get("limit") == "5" {
 		w.Header().Set("Content-Type", "text/html")
-		_ = templates.RecentUsers(users.Users).Render(context.Background(), w)
+		_ = templates.RecentUsers(users.Users).Render(r.Context(), w)
 		return
 	}
 
 	// Return users table as HTML fragment using templ component
 	w.Header().Set("Content-Type", "text/html")
-	_ = templates.UsersTable(users, user).Render(context.Background(), w)
+	_ = templates.UsersTable(users, user, sort).Render(r.Context(), w)
 }
 
 func (h *Handlers) ToggleUserAPI(w http.ResponseWriter, r *http.Request) {
@@ -630,37 +2256,198 @@ func (h *Handlers) ToggleUserAPI(w http.ResponseWriter, r *http.Request) {
 }
 
 // Template rendering using templ templates
-func renderTemplate(w http.ResponseWriter, templateName string, data map[string]interface{}) {
+func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string, data map[string]interface{}) {
 	w.Header().Set("Content-Type", "text/html")
 
 	switch templateName {
 	case "login.templ":
 		errorMsg, _ := data["Error"].(string)
-		err := templates.Login(errorMsg).Render(context.Background(), w)
+		err := templates.Login(errorMsg).Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render login template", http.StatusInternalServerError)
 		}
+	case "break_glass.templ":
+		errorMsg, _ := data["Error"].(string)
+		err := templates.BreakGlass(errorMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render break-glass template", http.StatusInternalServerError)
+		}
+	case "two_factor.templ":
+		errorMsg, _ := data["Error"].(string)
+		err := templates.TwoFactorChallenge(errorMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render two-factor template", http.StatusInternalServerError)
+		}
+	case "two_factor_setup.templ":
+		enrollment, _ := data["Enrollment"].(*gweb.TwoFactorEnrollment)
+		errorMsg, _ := data["Error"].(string)
+		err := templates.TwoFactorSetup(enrollment.Secret, enrollment.URI, enrollment.QRCode, errorMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render two-factor setup template", http.StatusInternalServerError)
+		}
+	case "two_factor_recovery_codes.templ":
+		codes, _ := data["RecoveryCodes"].([]string)
+		err := templates.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render recovery codes template", http.StatusInternalServerError)
+		}
 	case "dashboard.templ":
 		user, _ := data["User"].(*entities.User)
 		stats, _ := data["Stats"].(*entities.DashboardStats)
-		err := templates.Dashboard(user, stats).Render(context.Background(), w)
+		err := templates.Dashboard(user, stats).Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render dashboard template", http.StatusInternalServerError)
 		}
 	case "users.templ":
 		user, _ := data["User"].(*entities.User)
 		users, _ := data["Users"].(*entities.UserListResponse)
-		err := templates.Users(user, users).Render(context.Background(), w)
+		sort, _ := data["Sort"].(entities.UserSort)
+		err := templates.Users(user, users, sort).Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render users template", http.StatusInternalServerError)
 		}
+	case "user_detail.templ":
+		user, _ := data["User"].(*entities.User)
+		targetUser, _ := data["TargetUser"].(*entities.User)
+		profileMsg, _ := data["ProfileMsg"].(string)
+		logins, _ := data["Logins"].([]entities.LoginEvent)
+		err := templates.UserDetail(user, targetUser, profileMsg, logins).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render user detail template", http.StatusInternalServerError)
+		}
+	case "approvals.templ":
+		user, _ := data["User"].(*entities.User)
+		pending, _ := data["Pending"].(*entities.UserListResponse)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Approvals(user, pending, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render approvals template", http.StatusInternalServerError)
+		}
+	case "announcements.templ":
+		user, _ := data["User"].(*entities.User)
+		announcements, _ := data["Announcements"].([]entities.Announcement)
+		editing, _ := data["Editing"].(*entities.Announcement)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Announcements(user, announcements, editing, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render announcements template", http.StatusInternalServerError)
+		}
+	case "invitations.templ":
+		user, _ := data["User"].(*entities.User)
+		invitations, _ := data["Invitations"].([]entities.Invitation)
+		code, _ := data["Code"].(string)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Invitations(user, invitations, code, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render invitations template", http.StatusInternalServerError)
+		}
 	case "settings.templ":
 		user, _ := data["User"].(*entities.User)
 		settings, _ := data["Settings"].(*entities.SystemSettings)
-		err := templates.Settings(user, settings).Render(context.Background(), w)
+		err := templates.Settings(user, settings).Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render settings template", http.StatusInternalServerError)
 		}
+	case "backups.templ":
+		user, _ := data["User"].(*entities.User)
+		backups, _ := data["Backups"].(*entities.BackupListResponse)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Backups(user, backups, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render backups template", http.StatusInternalServerError)
+		}
+	case "jobs.templ":
+		user, _ := data["User"].(*entities.User)
+		jobs, _ := data["Jobs"].(*entities.JobListResponse)
+		status, _ := data["Status"].(string)
+		kind, _ := data["Kind"].(string)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Jobs(user, jobs, status, kind, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render jobs template", http.StatusInternalServerError)
+		}
+	case "webhooks.templ":
+		user, _ := data["User"].(*entities.User)
+		webhooks, _ := data["Webhooks"].([]entities.Webhook)
+		eventNames, _ := data["Events"].([]string)
+		created, _ := data["Created"].(*entities.Webhook)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Webhooks(user, webhooks, eventNames, created, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render webhooks template", http.StatusInternalServerError)
+		}
+	case "webhook_deliveries.templ":
+		user, _ := data["User"].(*entities.User)
+		webhook, _ := data["Webhook"].(*entities.Webhook)
+		deliveries, _ := data["Deliveries"].(*entities.WebhookDeliveryListResponse)
+		status, _ := data["Status"].(string)
+		msg, _ := data["Message"].(string)
+		errMsg, _ := data["Error"].(string)
+		err := templates.WebhookDeliveries(user, webhook, deliveries, status, msg, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render webhook deliveries template", http.StatusInternalServerError)
+		}
+	case "system.templ":
+		user, _ := data["User"].(*entities.User)
+		report, _ := data["Report"].(*entities.ReconciliationReport)
+		err := templates.System(user, report).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render system template", http.StatusInternalServerError)
+		}
+	case "roles.templ":
+		user, _ := data["User"].(*entities.User)
+		roles, _ := data["Roles"].([]entities.Role)
+		userID, _ := data["UserID"].(string)
+		userRoles, _ := data["UserRoles"].([]entities.Role)
+		userError, _ := data["UserError"].(string)
+		err := templates.Roles(user, roles, userID, userRoles, userError).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render roles template", http.StatusInternalServerError)
+		}
+	case "search.templ":
+		user, _ := data["User"].(*entities.User)
+		query, _ := data["Query"].(string)
+		kind, _ := data["Kind"].(string)
+		results, _ := data["Results"].(*entities.SearchResponse)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Search(user, query, kind, results, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render search template", http.StatusInternalServerError)
+		}
+	case "audit.templ":
+		user, _ := data["User"].(*entities.User)
+		query, _ := data["Query"].(url.Values)
+		events, _ := data["Events"].(*entities.AuditEventListResponse)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Audit(user, query, events, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render audit template", http.StatusInternalServerError)
+		}
+	case "emails.templ":
+		user, _ := data["User"].(*entities.User)
+		names, _ := data["Names"].([]entities.EmailTemplate)
+		name, _ := data["Name"].(entities.EmailTemplate)
+		locale, _ := data["Locale"].(string)
+		preview, _ := data["Preview"].(*entities.RenderedEmail)
+		errMsg, _ := data["Error"].(string)
+		err := templates.Emails(user, names, name, locale, preview, errMsg).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render emails template", http.StatusInternalServerError)
+		}
+	case "logs.templ":
+		user, _ := data["User"].(*entities.User)
+		level, _ := data["Level"].(string)
+		requestID, _ := data["RequestID"].(string)
+		err := templates.Logs(user, level, requestID).Render(r.Context(), w)
+		if err != nil {
+			http.Error(w, "Failed to render logs template", http.StatusInternalServerError)
+		}
 	default:
 		http.Error(w, "Template not found", http.StatusNotFound)
 	}
@@ -2,9 +2,12 @@ package admin
 
 import (
 	"context"
+	"go-template/app/admin/templates"
 	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
+	"go-template/internal/reqlog"
 	"net/http"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
@@ -13,6 +16,10 @@ type contextKey string
 
 const userContextKey contextKey = "user"
 
+// refreshWindow is how long before the access token expires the session is
+// refreshed, so requests never carry a token that runs out on the way.
+const refreshWindow = time.Minute
+
 // AuthMiddleware handles user authentication for protected routes
 type AuthMiddleware struct {
 	client       *gweb.Client
@@ -31,10 +38,20 @@ func NewAuthMiddleware(client *gweb.Client, cookieSecure bool, cookieDomain stri
 	}
 }
 
-// RequireAuth middleware that requires user authentication
+// RequireAuth middleware that requires user authentication. Admins whose
+// session lacks the second factor Require2FA asks for are sent to set it up.
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
+	return m.requireAuth(next, true)
+}
+
+// RequireAuthWithout2FA is RequireAuth for the two-factor setup pages
+func (m *AuthMiddleware) RequireAuthWithout2FA(next http.Handler) http.Handler {
+	return m.requireAuth(next, false)
+}
+
+func (m *AuthMiddleware) requireAuth(next http.Handler, enforce2FA bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := getCookieValue(r, CookieToken)
+		token := m.sessionToken(w, r)
 		if token == "" {
 			http.Redirect(w, r, "/login?redirect="+r.URL.Path, http.StatusFound)
 			return
@@ -42,11 +59,16 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 
 		// Set token in client and validate
 		m.client.SetAuthToken(token)
-		if err := m.client.VerifyToken(); err != nil {
+		verified, err := m.client.VerifyToken()
+		if err != nil {
 			m.clearAuthCookies(w)
 			http.Redirect(w, r, "/login?error=session_expired&redirect="+r.URL.Path, http.StatusFound)
 			return
 		}
+		if enforce2FA && verified.TwoFactorSetupRequired {
+			http.Redirect(w, r, "/2fa/setup", http.StatusFound)
+			return
+		}
 
 		// Build user context from cookies (minimal fields)
 		var user entities.User
@@ -61,6 +83,15 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 
 		// Add user to context
 		ctx := context.WithValue(r.Context(), userContextKey, &user)
+		ctx = reqlog.WithUser(ctx, user.ID.String())
+
+		// Load delegated permissions so pages can hide what the admin cannot use
+		if user.AccountType == entities.AccountTypeAdmin {
+			if perms, err := m.client.GetMyPermissions(); err == nil {
+				ctx = templates.WithPermissions(ctx, perms.Permissions)
+			}
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -68,11 +99,11 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 // OptionalAuth middleware that adds user to context if authenticated, but doesn't require it
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := getCookieValue(r, CookieToken)
+		token := m.sessionToken(w, r)
 		if token != "" {
 			// Set token in client and try to verify
 			m.client.SetAuthToken(token)
-			if err := m.client.VerifyToken(); err == nil {
+			if _, err := m.client.VerifyToken(); err == nil {
 				var user entities.User
 				if idStr := getCookieValue(r, CookieUserID); idStr != "" {
 					if id, err := uuid.FromString(idStr); err == nil {
@@ -82,6 +113,7 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 				user.Email = getCookieValue(r, CookieUserEmail)
 				user.AccountType = entities.AccountType(getCookieValue(r, CookieAccountType))
 				ctx := context.WithValue(r.Context(), userContextKey, &user)
+				ctx = reqlog.WithUser(ctx, user.ID.String())
 				r = r.WithContext(ctx)
 			} else {
 				// Clear invalid token cookies
@@ -93,6 +125,31 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	})
 }
 
+// sessionToken returns the access token to call the API with. When the token
+// is gone or about to expire and there is a refresh token, the session is
+// refreshed first and the new tokens are stored in the cookies.
+func (m *AuthMiddleware) sessionToken(w http.ResponseWriter, r *http.Request) string {
+	token := getCookieValue(r, CookieToken)
+	refreshToken := getCookieValue(r, CookieRefreshToken)
+	if refreshToken == "" || (token != "" && !tokenExpiresSoon(r)) {
+		return token
+	}
+
+	resp, err := m.client.RefreshSession(refreshToken)
+	if err != nil {
+		// Leave it to the API to decide whether the old token still works
+		return token
+	}
+	m.setAuthCookies(w, &gweb.AdminLoginResponse{
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+		User:         resp.User,
+		AccountType:  resp.User.AccountType.String(),
+		ExpiresAt:    time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	})
+	return resp.Token
+}
+
 // RequireSuperAdmin middleware ensures only super admin users can access the route
 func (m *AuthMiddleware) RequireSuperAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -117,6 +174,26 @@ func (m *AuthMiddleware) RequireSuperAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// RequirePermission middleware ensures the admin holds a delegated permission.
+// The API enforces the same check; this only avoids rendering pages whose data
+// the admin cannot load.
+func (m *AuthMiddleware) RequirePermission(perm entities.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !templates.HasPermission(r.Context(), perm) {
+				if r.Header.Get("HX-Request") == "true" {
+					http.Error(w, "Access denied: missing permission "+perm.String(), http.StatusForbidden)
+					return
+				}
+				http.Redirect(w, r, "/dashboard?error=access_denied", http.StatusFound)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // GetUserFromContext extracts the user from the request context
 func GetUserFromContext(r *http.Request) *entities.User {
 	if user, ok := r.Context().Value(userContextKey).(*entities.User); ok {
@@ -1,9 +1,13 @@
 package admin
 
 import (
+	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
+	"go-template/internal/clientip"
+	"go-template/internal/reqlog"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,28 +17,35 @@ import (
 
 type Config struct {
 	APIBaseURL     string
+	WebBaseURL     string
 	CookieMaxAge   int
 	CookieSecure   bool
 	CookieDomain   string
 	SessionTimeout int
 	StaticPath     string
+
+	// TrustedProxies forward the client's address in X-Forwarded-For,
+	// X-Real-IP or True-Client-IP. Other callers' headers are ignored.
+	TrustedProxies []netip.Prefix
 }
 
 type AdminApp struct {
 	handlers *Handlers
 	auth     *AuthMiddleware
 	logger   *slog.Logger
+	proxies  []netip.Prefix
 }
 
 func New(cfg Config, log *slog.Logger) *AdminApp {
 	client := gweb.NewClient(cfg.APIBaseURL)
 	auth := NewAuthMiddleware(client, cfg.CookieSecure, cfg.CookieDomain, cfg.CookieMaxAge)
-	handlers := NewHandlers(client, auth, log, cfg.StaticPath)
+	handlers := NewHandlers(client, auth, log, cfg.StaticPath, cfg.WebBaseURL)
 
 	return &AdminApp{
 		handlers: handlers,
 		auth:     auth,
 		logger:   log,
+		proxies:  cfg.TrustedProxies,
 	}
 }
 
@@ -44,8 +55,8 @@ func (app *AdminApp) Routes() chi.Router {
 	// Middleware
 	r.Use(middleware.NoCache)
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(clientip.Middleware(app.proxies))
+	r.Use(reqlog.Middleware(app.logger))
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 	r.Use(middleware.Timeout(60 * time.Second))
@@ -69,6 +80,17 @@ func (app *AdminApp) Routes() chi.Router {
 	})
 	r.Get("/login", app.handlers.LoginPage)
 	r.Post("/login", app.handlers.LoginSubmit)
+	r.Get("/login/2fa", app.handlers.TwoFactorPage)
+	r.Post("/login/2fa", app.handlers.TwoFactorSubmit)
+	r.Get("/break-glass", app.handlers.BreakGlassPage)
+	r.Post("/break-glass", app.handlers.BreakGlassSubmit)
+
+	// Two-factor setup, reachable before the admin has a second factor
+	r.Group(func(r chi.Router) {
+		r.Use(app.auth.RequireAuthWithout2FA)
+		r.Get("/2fa/setup", app.handlers.TwoFactorSetupPage)
+		r.Post("/2fa/setup", app.handlers.TwoFactorSetupSubmit)
+	})
 
 	// Protected routes (auth required)
 	r.Group(func(r chi.Router) {
@@ -78,28 +100,91 @@ func (app *AdminApp) Routes() chi.Router {
 		r.Post("/logout", app.handlers.Logout)
 
 		// User management (all admins - validation handled in handlers)
-		r.Get("/users", app.handlers.UsersPage)
-		r.Get("/users/{id}", app.handlers.UserDetail)
-		r.Post("/users/update", app.handlers.UpdateUser)
-		r.Post("/users/create", app.handlers.CreateUser)
-		r.Post("/users/delete", app.handlers.DeleteUser)
+		usersRead := app.auth.RequirePermission(entities.PermissionUsersRead)
+		usersWrite := app.auth.RequirePermission(entities.PermissionUsersWrite)
+		r.With(usersRead).Get("/users", app.handlers.UsersPage)
+		r.With(usersRead).Get("/users/export", app.handlers.ExportUsers)
+		r.With(usersRead).Get("/users/{id}", app.handlers.UserDetail)
+		r.With(usersWrite).Post("/users/update", app.handlers.UpdateUser)
+		r.With(usersWrite).Post("/users/invite", app.handlers.InviteUser)
+		r.With(usersWrite).Post("/users/import", app.handlers.ImportUsers)
+		r.With(usersWrite).Post("/users/delete", app.handlers.DeleteUser)
+		r.With(usersWrite).Post("/users/bulk", app.handlers.BulkUsers)
+		r.With(usersWrite).Post("/users/revoke-sessions", app.handlers.RevokeUserSessions)
+		r.With(usersWrite).Post("/users/suspend", app.handlers.SuspendUser)
+		r.With(usersWrite).Post("/users/reactivate", app.handlers.ReactivateUser)
+		r.With(usersWrite).Post("/users/{id}/profile", app.handlers.UpdateUserProfile)
+		r.With(usersRead).Get("/search", app.handlers.SearchPage)
+		r.With(usersRead).Get("/approvals", app.handlers.ApprovalsPage)
+		r.With(usersWrite).Post("/approvals/approve", app.handlers.ApproveUser)
+		r.With(usersWrite).Post("/approvals/reject", app.handlers.RejectUser)
+		r.With(usersRead).Get("/invitations", app.handlers.InvitationsPage)
+		r.With(usersWrite).Post("/invitations", app.handlers.CreateInvitation)
+		r.With(usersWrite).Post("/invitations/revoke", app.handlers.RevokeInvitation)
+		r.With(app.auth.RequirePermission(entities.PermissionUsersImpersonate)).Post("/users/impersonate", app.handlers.ImpersonateUser)
 
 		// Settings (super admin only)
 		r.Group(func(r chi.Router) {
+			r.Use(app.auth.RequirePermission(entities.PermissionSettingsRead))
 			r.Get("/settings", app.handlers.SettingsPage)
 			r.Get("/settings/auth-providers", app.handlers.GetAuthProviders)
+
+			// Transactional email previews
+			r.Get("/emails", app.handlers.EmailsPage)
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(app.auth.RequireSuperAdmin)
 			r.Post("/settings", app.handlers.UpdateSettings)
+
+			// Announcements shown as a banner in the web app
+			r.Get("/announcements", app.handlers.AnnouncementsPage)
+			r.Post("/announcements", app.handlers.CreateAnnouncement)
+			r.Post("/announcements/{id}", app.handlers.UpdateAnnouncement)
+			r.Post("/announcements/{id}/delete", app.handlers.DeleteAnnouncement)
+
+			// Auth provider reconciliation
+			r.Get("/system", app.handlers.SystemPage)
+			r.Post("/system/reconciliation", app.handlers.RunReconciliation)
+
+			// Database backups
+			r.Get("/backups", app.handlers.BackupsPage)
+			r.Post("/backups", app.handlers.CreateBackup)
+			r.Get("/backups/{id}/download", app.handlers.DownloadBackup)
+			r.Get("/jobs", app.handlers.JobsPage)
+			r.Post("/jobs/{id}/requeue", app.handlers.RequeueJob)
+			r.Post("/jobs/{id}/cancel", app.handlers.CancelJob)
+
+			// Outgoing webhooks and their deliveries
+			r.Get("/webhooks", app.handlers.WebhooksPage)
+			r.Post("/webhooks", app.handlers.CreateWebhook)
+			r.Get("/webhooks/{id}", app.handlers.WebhookDeliveriesPage)
+			r.Post("/webhooks/{id}/delete", app.handlers.DeleteWebhook)
+			r.Post("/webhooks/{id}/deliveries/{deliveryID}/redeliver", app.handlers.RedeliverWebhook)
+
+			// Roles and the permissions they grant
+			r.Get("/roles", app.handlers.RolesPage)
+			r.Post("/roles", app.handlers.CreateRole)
+			r.Post("/roles/{id}", app.handlers.UpdateRole)
+			r.Post("/roles/{id}/delete", app.handlers.DeleteRole)
+			r.Get("/roles/users", app.handlers.GetUserRoles)
+			r.Post("/roles/users/assign", app.handlers.AssignRole)
+			r.Post("/roles/users/unassign", app.handlers.UnassignRole)
+
+			// Live API logs
+			r.Get("/logs", app.handlers.LogsPage)
+			r.Get("/logs/stream", app.handlers.LogsStream)
+
+			// Audit log
+			r.Get("/audit", app.handlers.AuditPage)
 		})
 
 		// HTMX/API endpoints for dynamic updates
 		r.Route("/api", func(r chi.Router) {
-			r.Get("/stats", app.handlers.GetStatsAPI)
-			r.Get("/users", app.handlers.GetUsersAPI)
-			r.Post("/users/{id}/toggle", app.handlers.ToggleUserAPI)
+			r.With(app.auth.RequirePermission(entities.PermissionDashboardRead)).Get("/stats", app.handlers.GetStatsAPI)
+			r.With(app.auth.RequirePermission(entities.PermissionDashboardRead)).Get("/stats/stream", app.handlers.DashboardStream)
+			r.With(usersRead).Get("/users", app.handlers.GetUsersAPI)
+			r.With(usersWrite).Post("/users/{id}/toggle", app.handlers.ToggleUserAPI)
 		})
 	})
 
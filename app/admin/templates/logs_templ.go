@@ -0,0 +1,271 @@
+// Code generated by templ - DO NOT EDIT.
+
+// templ: version: v0.3.943
+package templates
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
+import "go-template/domain/entities"
+
+func Logs(user *entities.User, level, requestID string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var1 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var1 == nil {
+			templ_7745c5c3_Var1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Var2 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+			if !templ_7745c5c3_IsBuffer {
+				defer func() {
+					templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err == nil {
+						templ_7745c5c3_Err = templ_7745c5c3_BufErr
+					}
+				}()
+			}
+			ctx = templ.InitializeContext(ctx)
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!-- Page header --> <div class=\"mb-8\"><h1 class=\"text-2xl font-bold text-gray-900\">System Logs</h1><p class=\"mt-1 text-sm text-gray-500\">Live tail of the most recent API log entries kept in memory.</p></div><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><form method=\"GET\" action=\"/logs\" class=\"flex flex-wrap items-end gap-4\"><div><label for=\"level\" class=\"block text-sm font-medium text-gray-700\">Minimum level</label> <select id=\"level\" name=\"level\" class=\"mt-1 block w-40 pl-3 pr-10 py-2 text-base border-gray-300 focus:outline-none focus:ring-admin-500 focus:border-admin-500 sm:text-sm rounded-md\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logLevelOption("", "All", level).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logLevelOption("debug", "Debug", level).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logLevelOption("info", "Info", level).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logLevelOption("warn", "Warn", level).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logLevelOption("error", "Error", level).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</select></div><div class=\"flex-1 min-w-[16rem]\"><label for=\"request_id\" class=\"block text-sm font-medium text-gray-700\">Request ID</label> <input id=\"request_id\" name=\"request_id\" type=\"text\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var3 string
+			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(requestID)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/logs.templ`, Line: 31, Col: 76}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" class=\"mt-1 block w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-admin-500 focus:border-admin-500 sm:text-sm\" placeholder=\"Only entries logged for this request\"></div><button type=\"submit\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-admin-600 hover:bg-admin-700\">Apply</button> <button type=\"button\" id=\"logs-toggle\" class=\"inline-flex items-center px-4 py-2 border border-gray-300 text-sm font-medium rounded-md text-gray-700 bg-white hover:bg-gray-50\">Pause</button> <span id=\"logs-status\" class=\"text-sm text-gray-500\">Connecting…</span></form></div><div class=\"border-t border-gray-200 overflow-x-auto\"><table class=\"min-w-full divide-y divide-gray-200 font-mono text-xs\"><thead class=\"bg-gray-50\"><tr><th class=\"px-4 py-2 text-left font-medium text-gray-500 uppercase tracking-wider\">Time</th><th class=\"px-4 py-2 text-left font-medium text-gray-500 uppercase tracking-wider\">Level</th><th class=\"px-4 py-2 text-left font-medium text-gray-500 uppercase tracking-wider\">Message</th><th class=\"px-4 py-2 text-left font-medium text-gray-500 uppercase tracking-wider\">Request ID</th><th class=\"px-4 py-2 text-left font-medium text-gray-500 uppercase tracking-wider\">Attributes</th></tr></thead> <tbody id=\"log-entries\" class=\"bg-white divide-y divide-gray-100\"></tbody></table></div></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = logsStream(level, requestID).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			return nil
+		})
+		templ_7745c5c3_Err = Layout("System Logs", user).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+func logLevelOption(value, label, selected string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var4 == nil {
+			templ_7745c5c3_Var4 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		if value == selected {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(value)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/logs.templ`, Line: 69, Col: 23}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" selected>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 string
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(label)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/logs.templ`, Line: 69, Col: 42}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</option>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var7 string
+			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(value)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/logs.templ`, Line: 71, Col: 23}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var8 string
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(label)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/logs.templ`, Line: 71, Col: 33}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</option>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		return nil
+	})
+}
+
+func logsStream(level, requestID string) templ.ComponentScript {
+	return templ.ComponentScript{
+		Name: `__templ_logsStream_5b36`,
+		Function: `function __templ_logsStream_5b36(level, requestID){const maxRows = 1000;
+	const levelClasses = {
+		DEBUG: 'text-gray-500',
+		INFO: 'text-blue-700',
+		WARN: 'text-yellow-700',
+		ERROR: 'text-red-700',
+	};
+	const body = document.getElementById('log-entries');
+	const status = document.getElementById('logs-status');
+	const toggle = document.getElementById('logs-toggle');
+	const params = new URLSearchParams();
+	if (level) params.set('level', level);
+	if (requestID) params.set('request_id', requestID);
+
+	let source = null;
+	let paused = false;
+	let lastID = '';
+
+	function cell(text, className) {
+		const td = document.createElement('td');
+		td.className = 'px-4 py-1 align-top ' + (className || '');
+		td.textContent = text;
+		return td;
+	}
+
+	function addEntry(entry) {
+		const row = document.createElement('tr');
+		row.appendChild(cell(new Date(entry.time).toLocaleTimeString(), 'whitespace-nowrap text-gray-500'));
+		row.appendChild(cell(entry.level, 'font-semibold ' + (levelClasses[entry.level] || '')));
+		row.appendChild(cell(entry.message, 'text-gray-900'));
+
+		const requestCell = cell('', 'whitespace-nowrap');
+		if (entry.request_id) {
+			const link = document.createElement('a');
+			link.href = '/logs?request_id=' + encodeURIComponent(entry.request_id);
+			link.className = 'text-admin-600 hover:text-admin-500';
+			link.textContent = entry.request_id;
+			requestCell.appendChild(link);
+		}
+		row.appendChild(requestCell);
+		row.appendChild(cell(entry.attrs ? JSON.stringify(entry.attrs) : '', 'text-gray-600 break-all'));
+
+		body.insertBefore(row, body.firstChild);
+		while (body.rows.length > maxRows) {
+			body.deleteRow(body.rows.length - 1);
+		}
+	}
+
+	function connect() {
+		// Resume after the last entry shown when coming back from a pause
+		if (lastID) params.set('after', lastID);
+		source = new EventSource('/logs/stream?' + params.toString());
+		source.onopen = function() { status.textContent = 'Live'; };
+		source.onmessage = function(evt) {
+			lastID = evt.lastEventId;
+			addEntry(JSON.parse(evt.data));
+		};
+		source.onerror = function() { status.textContent = 'Reconnecting…'; };
+	}
+
+	toggle.addEventListener('click', function() {
+		paused = !paused;
+		if (paused) {
+			source.close();
+			status.textContent = 'Paused';
+			toggle.textContent = 'Resume';
+		} else {
+			connect();
+			toggle.textContent = 'Pause';
+		}
+	});
+
+	connect();
+}`,
+		Call:       templ.SafeScript(`__templ_logsStream_5b36`, level, requestID),
+		CallInline: templ.SafeScriptInline(`__templ_logsStream_5b36`, level, requestID),
+	}
+}
+
+var _ = templruntime.GeneratedTemplate
@@ -0,0 +1,25 @@
+package templates
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"slices"
+)
+
+type permissionsKey struct{}
+
+// WithPermissions stores the signed-in admin's permissions so templates can
+// hide the sections the admin cannot use.
+func WithPermissions(ctx context.Context, perms []entities.Permission) context.Context {
+	return context.WithValue(ctx, permissionsKey{}, perms)
+}
+
+// HasPermission reports whether the signed-in admin holds perm. Without permissions in
+// the context everything is shown and the API remains the enforcement point.
+func HasPermission(ctx context.Context, perm entities.Permission) bool {
+	perms, ok := ctx.Value(permissionsKey{}).([]entities.Permission)
+	if !ok {
+		return true
+	}
+	return slices.Contains(perms, perm)
+}
@@ -10,6 +10,7 @@ import templruntime "github.com/a-h/templ/runtime"
 
 import "fmt"
 import "go-template/domain/entities"
+import "slices"
 
 func Settings(user *entities.User, settings *entities.SystemSettings) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
@@ -64,141 +65,281 @@ func Settings(user *entities.User, settings *entities.SystemSettings) templ.Comp
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"registration_enabled\" class=\"font-medium text-gray-700\">User Registration</label><p class=\"text-gray-500\">Allow new users to register for accounts.</p></div></div><!-- Email Notifications --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"email_notifications\" name=\"email_notifications\" type=\"checkbox\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"registration_enabled\" class=\"font-medium text-gray-700\">User Registration</label><p class=\"text-gray-500\">Allow new users to register for accounts.</p></div></div><!-- Invitations Enabled --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"invitations_enabled\" name=\"invitations_enabled\" type=\"checkbox\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			if settings != nil && settings.EmailNotifications {
+			if settings != nil && settings.InvitationsEnabled {
 				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"email_notifications\" class=\"font-medium text-gray-700\">Email Notifications</label><p class=\"text-gray-500\">Send email notifications for important system events.</p></div></div></div></div></div><!-- Authentication Providers --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Authentication Providers</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Configure available authentication providers for user creation.</p></div><div class=\"mt-6 space-y-6\"><!-- Default Auth Provider --><div><label for=\"default_auth_provider\" class=\"block text-sm font-medium text-gray-700\">Default Authentication Provider</label><div class=\"mt-1\"><select id=\"default_auth_provider\" name=\"default_auth_provider\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"><option value=\"supabase\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"invitations_enabled\" class=\"font-medium text-gray-700\">Invitations</label><p class=\"text-gray-500\">While registration is off, let people register with an invitation code from the Invitations page.</p></div></div><!-- Email Notifications --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"email_notifications\" name=\"email_notifications\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.EmailNotifications {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"email_notifications\" class=\"font-medium text-gray-700\">Email Notifications</label><p class=\"text-gray-500\">Send email notifications for important system events.</p></div></div></div></div></div><!-- Authentication Providers --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Authentication Providers</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Configure available authentication providers for user creation.</p></div><div class=\"mt-6 space-y-6\"><!-- Default Auth Provider --><div><label for=\"default_auth_provider\" class=\"block text-sm font-medium text-gray-700\">Default Authentication Provider</label><div class=\"mt-1\"><select id=\"default_auth_provider\" name=\"default_auth_provider\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"><option value=\"supabase\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil && settings.DefaultAuthProvider == "supabase" {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, " selected")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, ">Supabase</option> <option value=\"local\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.DefaultAuthProvider == "local" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, ">Local</option> <option value=\"cognito\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.DefaultAuthProvider == "cognito" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " selected")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, ">Supabase</option></select></div><p class=\"mt-2 text-sm text-gray-500\">Default provider used when creating new users through the admin interface.</p></div><!-- Available Auth Providers --><div><fieldset><legend class=\"text-sm font-medium text-gray-700\">Available Providers</legend><div class=\"mt-2 space-y-2\"><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"provider_supabase\" name=\"available_auth_providers\" value=\"supabase\" type=\"checkbox\" checked class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"provider_supabase\" class=\"font-medium text-gray-700\">Supabase</label><p class=\"text-gray-500\">Supabase authentication service</p></div></div><!-- Future providers can be added here --></div></fieldset><p class=\"mt-2 text-sm text-gray-500\">Select which authentication providers are available for creating users.</p></div></div></div></div><!-- Security Settings --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Security Settings</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Security and access control configuration.</p></div><div class=\"mt-6 space-y-6\"><!-- Session Timeout --><div><label for=\"session_timeout\" class=\"block text-sm font-medium text-gray-700\">Session Timeout (minutes)</label><div class=\"mt-1\"><input type=\"number\" id=\"session_timeout\" name=\"session_timeout\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, ">AWS Cognito</option> <option value=\"auth0\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.DefaultAuthProvider == "auth0" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, ">Auth0</option></select></div><p class=\"mt-2 text-sm text-gray-500\">Default provider used when creating new users through the admin interface.</p></div><!-- Available Auth Providers --><div><fieldset><legend class=\"text-sm font-medium text-gray-700\">Available Providers</legend><div class=\"mt-2 space-y-2\"><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"provider_supabase\" name=\"available_auth_providers\" value=\"supabase\" type=\"checkbox\" checked class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"provider_supabase\" class=\"font-medium text-gray-700\">Supabase</label><p class=\"text-gray-500\">Supabase authentication service</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"provider_local\" name=\"available_auth_providers\" value=\"local\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && slices.Contains(settings.AvailableAuthProviders, "local") {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"provider_local\" class=\"font-medium text-gray-700\">Local</label><p class=\"text-gray-500\">Passwords hashed with argon2id in the application database</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"provider_cognito\" name=\"available_auth_providers\" value=\"cognito\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && slices.Contains(settings.AvailableAuthProviders, "cognito") {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"provider_cognito\" class=\"font-medium text-gray-700\">AWS Cognito</label><p class=\"text-gray-500\">Amazon Cognito user pool</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"provider_auth0\" name=\"available_auth_providers\" value=\"auth0\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && slices.Contains(settings.AvailableAuthProviders, "auth0") {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"provider_auth0\" class=\"font-medium text-gray-700\">Auth0</label><p class=\"text-gray-500\">Auth0 database connection</p></div></div><!-- Future providers can be added here --></div></fieldset><p class=\"mt-2 text-sm text-gray-500\">Select which authentication providers are available for creating users.</p></div></div></div></div><!-- Security Settings --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Security Settings</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Security and access control configuration.</p></div><div class=\"mt-6 space-y-6\"><!-- Session Timeout --><div><label for=\"session_timeout\" class=\"block text-sm font-medium text-gray-700\">Session Timeout (minutes)</label><div class=\"mt-1\"><input type=\"number\" id=\"session_timeout\" name=\"session_timeout\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, " value=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " value=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var3 string
 				templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.SessionTimeout))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 162, Col: 66}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 248, Col: 66}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			} else {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, " value=\"1440\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " value=\"1440\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, " min=\"15\" max=\"10080\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">How long user sessions remain active without activity.</p></div><!-- Password Policy --><div><label for=\"min_password_length\" class=\"block text-sm font-medium text-gray-700\">Minimum Password Length</label><div class=\"mt-1\"><input type=\"number\" id=\"min_password_length\" name=\"min_password_length\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, " min=\"15\" max=\"10080\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">How long user sessions remain active without activity.</p></div><!-- Password Policy --><div><label for=\"min_password_length\" class=\"block text-sm font-medium text-gray-700\">Minimum Password Length</label><div class=\"mt-1\"><input type=\"number\" id=\"min_password_length\" name=\"min_password_length\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " value=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, " value=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var4 string
 				templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.MinPasswordLength))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 183, Col: 69}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 269, Col: 69}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			} else {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, " value=\"8\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, " value=\"8\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, " min=\"6\" max=\"128\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">Minimum number of characters required for user passwords.</p></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"password_require_mixed_case\" name=\"password_require_mixed_case\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.PasswordRequireMixedCase {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"password_require_mixed_case\" class=\"font-medium text-gray-700\">Require Mixed Case</label><p class=\"text-gray-500\">Passwords must contain both upper and lower case letters.</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"password_require_digit\" name=\"password_require_digit\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.PasswordRequireDigit {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"password_require_digit\" class=\"font-medium text-gray-700\">Require a Digit</label><p class=\"text-gray-500\">Passwords must contain at least one digit.</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"password_require_symbol\" name=\"password_require_symbol\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.PasswordRequireSymbol {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, " min=\"6\" max=\"128\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">Minimum number of characters required for user passwords.</p></div><!-- Two-Factor Authentication --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"require_2fa\" name=\"require_2fa\" type=\"checkbox\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"password_require_symbol\" class=\"font-medium text-gray-700\">Require a Symbol</label><p class=\"text-gray-500\">Passwords must contain at least one character that is not a letter or digit.</p></div></div><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"password_check_breached\" name=\"password_check_breached\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.PasswordCheckBreached {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"password_check_breached\" class=\"font-medium text-gray-700\">Reject Breached Passwords</label><p class=\"text-gray-500\">Reject passwords found in known data breaches (only the first five characters of the password's SHA-1 hash are sent to Have I Been Pwned).</p></div></div><!-- Two-Factor Authentication --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"require_2fa\" name=\"require_2fa\" type=\"checkbox\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil && settings.Require2FA {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"require_2fa\" class=\"font-medium text-gray-700\">Require Two-Factor Authentication</label><p class=\"text-gray-500\">Require all admin users to enable two-factor authentication.</p></div></div><!-- Email Verification --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"require_email_verification\" name=\"require_email_verification\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.RequireEmailVerification {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"require_2fa\" class=\"font-medium text-gray-700\">Require Two-Factor Authentication</label><p class=\"text-gray-500\">Require all admin users to enable two-factor authentication.</p></div></div></div></div></div><!-- Backup & Data --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Backup & Data Management</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Data backup and retention settings.</p></div><div class=\"mt-6 space-y-6\"><!-- Auto Backup --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"auto_backup\" name=\"auto_backup\" type=\"checkbox\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"require_email_verification\" class=\"font-medium text-gray-700\">Require Email Verification</label><p class=\"text-gray-500\">Users must confirm their email address before they can sign in. Needs an email provider.</p></div></div><!-- CAPTCHA --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"require_captcha\" name=\"require_captcha\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.RequireCaptcha {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"require_captcha\" class=\"font-medium text-gray-700\">Require CAPTCHA</label><p class=\"text-gray-500\">Ask for a CAPTCHA on registration and login. Needs a CAPTCHA provider.</p></div></div><!-- Registration approval --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"require_approval\" name=\"require_approval\" type=\"checkbox\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if settings != nil && settings.RequireApproval {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"require_approval\" class=\"font-medium text-gray-700\">Require Approval</label><p class=\"text-gray-500\">New users who sign up on their own wait in the approvals queue until an admin lets them in.</p></div></div></div></div></div><!-- Backup & Data --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">Backup & Data Management</h3><div class=\"mt-2 max-w-xl text-sm text-gray-500\"><p>Data backup and retention settings.</p></div><div class=\"mt-6 space-y-6\"><!-- Auto Backup --><div class=\"flex items-start\"><div class=\"flex items-center h-5\"><input id=\"auto_backup\" name=\"auto_backup\" type=\"checkbox\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil && settings.AutoBackup {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, " else")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, " else")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings == nil {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"auto_backup\" class=\"font-medium text-gray-700\">Automatic Backups</label><p class=\"text-gray-500\">Automatically create database backups daily.</p></div></div><!-- Backup Retention --><div><label for=\"backup_retention_days\" class=\"block text-sm font-medium text-gray-700\">Backup Retention (days)</label><div class=\"mt-1\"><input type=\"number\" id=\"backup_retention_days\" name=\"backup_retention_days\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, " class=\"focus:ring-admin-500 h-4 w-4 text-admin-600 border-gray-300 rounded\"></div><div class=\"ml-3 text-sm\"><label for=\"auto_backup\" class=\"font-medium text-gray-700\">Automatic Backups</label><p class=\"text-gray-500\">Automatically create database backups daily.</p></div></div><!-- Backup Retention --><div><label for=\"backup_retention_days\" class=\"block text-sm font-medium text-gray-700\">Backup Retention (days)</label><div class=\"mt-1\"><input type=\"number\" id=\"backup_retention_days\" name=\"backup_retention_days\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if settings != nil {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " value=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, " value=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var5 string
 				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", settings.BackupRetentionDays))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 256, Col: 71}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/admin/templates/settings.templ`, Line: 471, Col: 71}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			} else {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " value=\"30\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, " value=\"30\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, " min=\"1\" max=\"365\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">How many days to keep backup files before automatic deletion.</p></div><!-- Manual Backup --><div class=\"pt-4 border-t border-gray-200\"><button type=\"button\" onclick=\"createBackup()\" class=\"inline-flex items-center px-4 py-2 border border-gray-300 shadow-sm text-sm font-medium rounded-md text-gray-700 bg-white hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\"><svg class=\"h-4 w-4 mr-2\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M5 8h14M5 8a2 2 0 110-4h14a2 2 0 110 4M5 8v10a2 2 0 002 2h10a2 2 0 002-2V8m-9 4h4\"></path></svg> Create Backup Now</button></div></div></div></div><!-- System Information --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">System Information</h3><div class=\"mt-6\"><dl class=\"grid grid-cols-1 gap-x-4 gap-y-6 sm:grid-cols-2\"><div><dt class=\"text-sm font-medium text-gray-500\">System Version</dt><dd class=\"mt-1 text-sm text-gray-900\">v1.0.0</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Last Updated</dt><dd class=\"mt-1 text-sm text-gray-900\">2024-01-15</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Database Version</dt><dd class=\"mt-1 text-sm text-gray-900\">PostgreSQL 15.0</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Uptime</dt><dd class=\"mt-1 text-sm text-gray-900\">7 days, 3 hours</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Environment</dt><dd class=\"mt-1 text-sm text-gray-900\"><span class=\"inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-green-100 text-green-800\">Development</span></dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Last Backup</dt><dd class=\"mt-1 text-sm text-gray-900\">2 hours ago</dd></div></dl></div></div></div><!-- Save Button --><div class=\"flex justify-end\"><button type=\"button\" class=\"bg-white py-2 px-4 border border-gray-300 rounded-md shadow-sm text-sm font-medium text-gray-700 hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\">Cancel</button> <button type=\"submit\" class=\"ml-3 inline-flex justify-center py-2 px-4 border border-transparent shadow-sm text-sm font-medium rounded-md text-white bg-admin-600 hover:bg-admin-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\">Save Settings</button></div></form><script>\n\t\t\tfunction createBackup() {\n\t\t\t\tif (confirm(\"Create a manual backup now? This may take a few minutes.\")) {\n\t\t\t\t\t// Use HTMX to trigger backup\n\t\t\t\t\thtmx.ajax('POST', '/api/backup', {\n\t\t\t\t\t\tvalues: {},\n\t\t\t\t\t\tswap: 'none'\n\t\t\t\t\t});\n\t\t\t\t\talert(\"Backup started. You will be notified when it's complete.\");\n\t\t\t\t}\n\t\t\t}\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, " min=\"1\" max=\"365\" class=\"shadow-sm focus:ring-admin-500 focus:border-admin-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-2 text-sm text-gray-500\">How many days to keep backup files before automatic deletion.</p></div><!-- Manual Backup --><div class=\"pt-4 border-t border-gray-200\"><a href=\"/backups\" class=\"inline-flex items-center px-4 py-2 border border-gray-300 shadow-sm text-sm font-medium rounded-md text-gray-700 bg-white hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\"><svg class=\"h-4 w-4 mr-2\" fill=\"none\" viewBox=\"0 0 24 24\" stroke=\"currentColor\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M5 8h14M5 8a2 2 0 110-4h14a2 2 0 110 4M5 8v10a2 2 0 002 2h10a2 2 0 002-2V8m-9 4h4\"></path></svg> Manage Backups</a></div></div></div></div><!-- System Information --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg font-medium leading-6 text-gray-900\">System Information</h3><div class=\"mt-6\"><dl class=\"grid grid-cols-1 gap-x-4 gap-y-6 sm:grid-cols-2\"><div><dt class=\"text-sm font-medium text-gray-500\">System Version</dt><dd class=\"mt-1 text-sm text-gray-900\">v1.0.0</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Last Updated</dt><dd class=\"mt-1 text-sm text-gray-900\">2024-01-15</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Database Version</dt><dd class=\"mt-1 text-sm text-gray-900\">PostgreSQL 15.0</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Uptime</dt><dd class=\"mt-1 text-sm text-gray-900\">7 days, 3 hours</dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Environment</dt><dd class=\"mt-1 text-sm text-gray-900\"><span class=\"inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-green-100 text-green-800\">Development</span></dd></div><div><dt class=\"text-sm font-medium text-gray-500\">Last Backup</dt><dd class=\"mt-1 text-sm text-gray-900\">2 hours ago</dd></div></dl></div></div></div><!-- Save Button --><div class=\"flex justify-end\"><button type=\"button\" class=\"bg-white py-2 px-4 border border-gray-300 rounded-md shadow-sm text-sm font-medium text-gray-700 hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\">Cancel</button> <button type=\"submit\" class=\"ml-3 inline-flex justify-center py-2 px-4 border border-transparent shadow-sm text-sm font-medium rounded-md text-white bg-admin-600 hover:bg-admin-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-admin-500\">Save Settings</button></div></form>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// StatusClientClosedRequest answers requests whose client went away before
+// the response was ready, as nginx logs them. Nobody reads it, but it keeps
+// them apart from server errors in the access log and metrics.
+const StatusClientClosedRequest = 499
+
+// ErrorStatus maps err to the status code it is answered with: domain
+// errors, validation errors and context errors get their own, anything else
+// is a 500.
+func ErrorStatus(err error) int {
+	var (
+		invalidSetting entities.ErrInvalidSettingValue
+		validation     validator.ValidationErrors
+	)
+	switch {
+	case errors.Is(err, domain.ErrMalformedParameters),
+		errors.As(err, &invalidSetting),
+		errors.As(err, &validation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden),
+		errors.Is(err, domain.ErrAccountSuspended),
+		errors.Is(err, domain.ErrAccountPending):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrDuplicateKey):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrReadOnly):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RespondError answers err with the status ErrorStatus maps it to. Client
+// errors carry err's message, which use cases write for the client, as in
+// "missing name: malformed parameters"; validation errors are prefixed with
+// "validation failed". Suspended and pending users get the reason through
+// AccountStatusResponse. Errors it doesn't know are logged with the
+// request's logger and answered without their message, so internals don't
+// leak.
+func RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	if AccountStatusResponse(w, r, err) {
+		return
+	}
+
+	status := ErrorStatus(err)
+	switch {
+	case status == http.StatusInternalServerError:
+		domain.Logger(r.Context()).ErrorContext(r.Context(), "request failed", "error", err)
+		err = errors.New("internal server error")
+	case status == http.StatusGatewayTimeout:
+		err = errors.New("request timed out")
+	case errors.As(err, new(validator.ValidationErrors)):
+		err = errors.New("validation failed: " + err.Error())
+	}
+	ErrorResponse(w, r, status, err)
+}
+
+// NotFound names what wasn't found when err is domain.ErrNotFound, so
+// RespondError answers "attachment not found" rather than "not found".
+// Other errors are returned as they are.
+func NotFound(err error, what string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%s %w", what, domain.ErrNotFound)
+	}
+	return err
+}
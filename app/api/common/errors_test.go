@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondError(t *testing.T) {
+	validation := validator.New().Var("", "required")
+	require.Error(t, validation)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "malformed parameters", err: fmt.Errorf("missing name: %w", domain.ErrMalformedParameters), wantStatus: http.StatusBadRequest, wantError: "missing name: malformed parameters"},
+		{name: "invalid setting", err: entities.ErrInvalidSettingValue{Field: "session_timeout", Message: "must be positive"}, wantStatus: http.StatusBadRequest, wantError: "invalid value for session_timeout: must be positive"},
+		{name: "validation", err: validation, wantStatus: http.StatusBadRequest, wantError: "validation failed: " + validation.Error()},
+		{name: "forbidden", err: fmt.Errorf("can't suspend your own account: %w", domain.ErrForbidden), wantStatus: http.StatusForbidden, wantError: "can't suspend your own account: forbidden"},
+		{name: "suspended", err: &domain.AccountSuspendedError{Reason: "spam"}, wantStatus: http.StatusForbidden, wantError: "account suspended"},
+		{name: "not found", err: domain.ErrNotFound, wantStatus: http.StatusNotFound, wantError: "not found"},
+		{name: "named not found", err: NotFound(fmt.Errorf("get: %w", domain.ErrNotFound), "example"), wantStatus: http.StatusNotFound, wantError: "example not found"},
+		{name: "conflict", err: domain.ErrConflict, wantStatus: http.StatusConflict, wantError: "data conflict"},
+		{name: "duplicate key", err: domain.ErrDuplicateKey, wantStatus: http.StatusConflict, wantError: "duplicate key"},
+		{name: "read-only", err: domain.ErrReadOnly, wantStatus: http.StatusServiceUnavailable, wantError: "service is in read-only mode"},
+		{name: "client went away", err: fmt.Errorf("query: %w", context.Canceled), wantStatus: StatusClientClosedRequest, wantError: "query: context canceled"},
+		{name: "timeout", err: fmt.Errorf("query: %w", context.DeadlineExceeded), wantStatus: http.StatusGatewayTimeout, wantError: "request timed out"},
+		{name: "unknown errors don't leak", err: errors.New("pq: connection refused to 10.0.0.5"), wantStatus: http.StatusInternalServerError, wantError: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantStatus, ErrorStatus(tt.err))
+			var body ErrorResponseBody
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestRespondError_SuspendedReason(t *testing.T) {
+	w := httptest.NewRecorder()
+	RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), &domain.AccountSuspendedError{Reason: "spam"})
+
+	var body AccountStatusResponseBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "spam", body.Reason)
+}
+
+func TestNotFound(t *testing.T) {
+	other := errors.New("boom")
+	assert.Same(t, other, NotFound(other, "example"))
+
+	err := NotFound(domain.ErrNotFound, "api key")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, "api key not found", err.Error())
+}
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"errors"
+	"go-template/domain"
 	"net/http"
 
 	"github.com/go-chi/render"
@@ -17,7 +19,30 @@ func ErrorResponse(w http.ResponseWriter, r *http.Request, code int, err error)
 	})
 }
 
-func UnknownErrorResponse(w http.ResponseWriter, r *http.Request) {
-	render.Status(r, http.StatusInternalServerError)
-	render.PlainText(w, r, http.StatusText(http.StatusInternalServerError))
+type AccountStatusResponseBody struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AccountStatusResponse answers 403 when err keeps a suspended or pending
+// user out, with the reason an admin gave for a suspension. It reports
+// whether it answered.
+func AccountStatusResponse(w http.ResponseWriter, r *http.Request, err error) bool {
+	var suspended *domain.AccountSuspendedError
+	switch {
+	case errors.As(err, &suspended):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, AccountStatusResponseBody{
+			Error:  domain.ErrAccountSuspended.Error(),
+			Reason: suspended.Reason,
+		})
+	case errors.Is(err, domain.ErrAccountSuspended), errors.Is(err, domain.ErrAccountPending):
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, AccountStatusResponseBody{
+			Error: err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
 }
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/apikey"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// APIKeyHeader carries the API key of a machine client.
+const APIKeyHeader = "X-API-Key"
+
+const APIKeyContextKey contextKey = "api_key"
+
+// APIKeyAuthenticator resolves an API key to the key it is the plain-text
+// form of, rejecting unusable keys with apikey.ErrInvalidKey
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (entities.APIKey, error)
+}
+
+// SetAPIKeyAuthenticator lets RequireAuthOrAPIKey accept API keys. Without an
+// authenticator it only accepts tokens, like RequireAuth.
+func (m *AuthMiddleware) SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	m.apiKeys = authenticator
+}
+
+// RequireAuthOrAPIKey accepts a key holding scope in the X-API-Key header, and
+// otherwise whatever RequireAuth accepts. A key acts as its owner with the
+// rights of a plain user, even when the owner is an admin.
+func (m *AuthMiddleware) RequireAuthOrAPIKey(scope entities.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		requireAuth := m.RequireAuth(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get(APIKeyHeader)
+			if raw == "" || m.apiKeys == nil {
+				requireAuth.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := m.apiKeys.Authenticate(r.Context(), raw)
+			if err != nil {
+				if errors.Is(err, apikey.ErrInvalidKey) {
+					render.Status(r, http.StatusUnauthorized)
+					render.JSON(w, r, map[string]string{
+						"error": "invalid api key",
+					})
+					return
+				}
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, map[string]string{
+					"error": "failed to check api key",
+				})
+				return
+			}
+
+			if !key.HasScope(scope) {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, map[string]string{
+					"error": "api key lacks the " + scope.String() + " scope",
+				})
+				return
+			}
+
+			claims := &jwt.Claims{
+				UserID:      key.UserID.String(),
+				AccountType: entities.AccountTypeUser.String(),
+			}
+			claims.Subject = key.UserID.String()
+			if !m.requireActive(w, r, claims) {
+				return
+			}
+
+			ctx := withClaims(r.Context(), claims)
+			ctx = context.WithValue(ctx, APIKeyContextKey, key)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// APIKeyFromContext returns the key RequireAuthOrAPIKey authenticated the
+// request with, if it was authenticated with one.
+func APIKeyFromContext(ctx context.Context) (entities.APIKey, bool) {
+	key, ok := ctx.Value(APIKeyContextKey).(entities.APIKey)
+	return key, ok
+}
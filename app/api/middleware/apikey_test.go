@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/apikey"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type apiKeyFunc func(ctx context.Context, key string) (entities.APIKey, error)
+
+func (f apiKeyFunc) Authenticate(ctx context.Context, key string) (entities.APIKey, error) {
+	return f(ctx, key)
+}
+
+func TestAuthMiddleware_RequireAuthOrAPIKey(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, _ := jwtService.GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "user@x.com", "user")
+	owner := uuid.Must(uuid.NewV4())
+
+	m := NewAuthMiddleware(jwtService)
+	m.SetAPIKeyAuthenticator(apiKeyFunc(func(ctx context.Context, key string) (entities.APIKey, error) {
+		switch key {
+		case "gtk_read":
+			return entities.APIKey{UserID: owner, Scopes: []entities.APIKeyScope{entities.APIKeyScopeExampleRead}}, nil
+		case "gtk_broken":
+			return entities.APIKey{}, errors.New("db down")
+		}
+		return entities.APIKey{}, apikey.ErrInvalidKey
+	}))
+
+	var gotClaims *jwt.Claims
+	var gotKey bool
+	handler := m.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClaims, _ = GetUserFromContext(r.Context())
+		_, gotKey = APIKeyFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+	writeHandler := m.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)(handler)
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		apiKey     string
+		bearer     string
+		wantStatus int
+		wantKey    bool
+	}{
+		{name: "api key with the scope", handler: handler, apiKey: "gtk_read", wantStatus: http.StatusOK, wantKey: true},
+		{name: "api key without the scope", handler: writeHandler, apiKey: "gtk_read", wantStatus: http.StatusForbidden},
+		{name: "unknown api key", handler: handler, apiKey: "gtk_unknown", wantStatus: http.StatusUnauthorized},
+		{name: "authenticator error", handler: handler, apiKey: "gtk_broken", wantStatus: http.StatusInternalServerError},
+		{name: "bearer token", handler: handler, bearer: token, wantStatus: http.StatusOK},
+		{name: "no credentials", handler: handler, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClaims, gotKey = nil, false
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			rec := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if gotKey != tt.wantKey {
+				t.Fatalf("expected api key in context %v, got %v", tt.wantKey, gotKey)
+			}
+			if tt.wantKey && (gotClaims.UserID != owner.String() || gotClaims.AccountType != "user") {
+				t.Fatalf("expected the key to act as its owner, got %+v", gotClaims)
+			}
+		})
+	}
+}
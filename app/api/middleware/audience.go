@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"go-template/internal/jwt"
+	"net/http"
+	"strings"
+)
+
+// AudienceRoutes maps a token audience to the path prefixes it may call.
+type AudienceRoutes map[string][]string
+
+// ParseAudienceRoutes reads audience routes from config, where each value is a
+// "|" separated list of path prefixes, e.g. {"admin": "/admin/"}.
+func ParseAudienceRoutes(cfg map[string]string) AudienceRoutes {
+	routes := make(AudienceRoutes, len(cfg))
+	for audience, prefixes := range cfg {
+		for _, prefix := range strings.Split(prefixes, "|") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				routes[audience] = append(routes[audience], prefix)
+			}
+		}
+	}
+	return routes
+}
+
+// SetAudienceRoutes restricts every token to the routes of its audiences, so a
+// token issued for one application is rejected by the others. Without routes
+// tokens are accepted on every route.
+func (m *AuthMiddleware) SetAudienceRoutes(routes AudienceRoutes) {
+	m.audiences = routes
+}
+
+// audienceAllowed reports whether claims may be presented to the request path.
+func (m *AuthMiddleware) audienceAllowed(r *http.Request, claims *jwt.Claims) bool {
+	// Pending two-factor challenges only unlock the challenge endpoint
+	if claims.HasAudience(jwt.AudienceMFA) {
+		return false
+	}
+	if len(m.audiences) == 0 {
+		return true
+	}
+
+	for _, audience := range claims.Audience {
+		for _, prefix := range m.audiences[audience] {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware_AudienceRoutes(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	m := NewAuthMiddleware(jwtService)
+	m.SetAudienceRoutes(ParseAudienceRoutes(map[string]string{
+		jwt.AudienceWeb:   "/api/|/oauth2/",
+		jwt.AudienceAdmin: "/admin/",
+	}))
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	token := func(audience string) string {
+		svc := jwtService
+		if audience != "" {
+			svc = svc.WithAudience(audience)
+		}
+		tok, _ := svc.GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "admin@x.com", "super_admin")
+		return tok
+	}
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		path       string
+		audience   string
+		wantStatus int
+	}{
+		{name: "web token on api", handler: m.RequireAuth(ok), path: "/api/v1/auth/me", audience: jwt.AudienceWeb, wantStatus: http.StatusOK},
+		{name: "admin token on api", handler: m.RequireAuth(ok), path: "/api/v1/auth/me", audience: jwt.AudienceAdmin, wantStatus: http.StatusUnauthorized},
+		{name: "admin token on admin", handler: m.RequireAdmin(ok), path: "/admin/v1/users", audience: jwt.AudienceAdmin, wantStatus: http.StatusOK},
+		{name: "web token on admin", handler: m.RequireAdmin(ok), path: "/admin/v1/users", audience: jwt.AudienceWeb, wantStatus: http.StatusUnauthorized},
+		{name: "token without audience", handler: m.RequireAuth(ok), path: "/api/v1/auth/me", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token(tt.audience))
+			w := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -2,12 +2,18 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/authz"
 	"go-template/domain/entities"
+	"go-template/internal/errreport"
 	"go-template/internal/jwt"
+	"go-template/internal/reqlog"
 	"net/http"
 	"strings"
 
 	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
 )
 
 type contextKey string
@@ -15,7 +21,15 @@ type contextKey string
 const UserContextKey contextKey = "user"
 
 type AuthMiddleware struct {
-	jwtService jwt.Service
+	jwtService  jwt.Service
+	permissions PermissionResolver
+	audiences   AudienceRoutes
+	revocations RevocationChecker
+	statuses    StatusChecker
+	twoFactor   TwoFactorPolicy
+	sessions    SessionTracker
+	apiKeys     APIKeyAuthenticator
+	authorizer  authz.Authorizer
 }
 
 func NewAuthMiddleware(jwtService jwt.Service) *AuthMiddleware {
@@ -58,13 +72,62 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		if !m.audienceAllowed(r, claims) {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "token not valid for this application",
+			})
+			return
+		}
+
+		revoked, err := m.revoked(r.Context(), claims)
+		if err != nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, map[string]string{
+				"error": "failed to check token",
+			})
+			return
+		}
+		if revoked {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "token revoked",
+			})
+			return
+		}
+
+		if !m.requireActive(w, r, claims) {
+			return
+		}
+
+		m.trackSession(r, claims)
+
 		// Add user info to context
-		ctx := context.WithValue(r.Context(), UserContextKey, claims)
+		ctx := withClaims(r.Context(), claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// OptionalAuth authenticates requests that carry a token like RequireAuth
+// does, rejecting bad tokens, and lets requests without one through with no
+// principal. It is for public endpoints that answer signed in users
+// differently.
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	authenticated := m.RequireAuth(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authenticated.ServeHTTP(w, r)
+	})
+}
+
 func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return m.requireAdmin(next, true)
+}
+
+func (m *AuthMiddleware) requireAdmin(next http.Handler, enforce2FA bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Extract token from Authorization header or cookie
 		var token string
@@ -99,6 +162,35 @@ func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
 			return
 		}
 
+		if !m.audienceAllowed(r, claims) {
+			render.Status(r, http.StatusUnauthorized)
+			render.PlainText(w, r, "Unauthorized: token not valid for this application")
+			return
+		}
+
+		revoked, err := m.revoked(r.Context(), claims)
+		if err != nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.PlainText(w, r, "Failed to check token")
+			return
+		}
+		if revoked {
+			// Redirect to admin login page
+			http.Redirect(w, r, "/admin/login", http.StatusFound)
+			return
+		}
+
+		if err := m.checkStatus(r.Context(), claims); err != nil {
+			if errors.Is(err, domain.ErrAccountSuspended) || errors.Is(err, domain.ErrAccountPending) {
+				render.Status(r, http.StatusForbidden)
+				render.PlainText(w, r, "Access denied: "+err.Error())
+				return
+			}
+			render.Status(r, http.StatusInternalServerError)
+			render.PlainText(w, r, "Failed to check account status")
+			return
+		}
+
 		// Check if user is admin or super admin
 		accountType := entities.AccountType(claims.AccountType)
 		if accountType != entities.AccountTypeAdmin && accountType != entities.AccountTypeSuperAdmin {
@@ -107,8 +199,24 @@ func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
 			return
 		}
 
+		if enforce2FA {
+			satisfied, err := m.TwoFactorSatisfied(r.Context(), claims)
+			if err != nil {
+				render.Status(r, http.StatusInternalServerError)
+				render.PlainText(w, r, "Failed to check two-factor policy")
+				return
+			}
+			if !satisfied {
+				render.Status(r, http.StatusForbidden)
+				render.PlainText(w, r, "Access denied: two-factor authentication required")
+				return
+			}
+		}
+
+		m.trackSession(r, claims)
+
 		// Add user info to context
-		ctx := context.WithValue(r.Context(), UserContextKey, claims)
+		ctx := withClaims(r.Context(), claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -140,3 +248,20 @@ func GetUserFromContext(ctx context.Context) (*jwt.Claims, bool) {
 	claims, ok := ctx.Value(UserContextKey).(*jwt.Claims)
 	return claims, ok
 }
+
+// withClaims stores the caller in ctx, and records them as the actor of the
+// request for the audit trail. An admin impersonating the user is the actor.
+func withClaims(ctx context.Context, claims *jwt.Claims) context.Context {
+	ctx = context.WithValue(ctx, UserContextKey, claims)
+
+	actor := claims.UserID
+	if claims.Impersonated() {
+		actor = claims.Impersonator.Subject
+	}
+	if id, err := uuid.FromString(actor); err == nil {
+		ctx = domain.WithActor(ctx, id)
+	}
+	errreport.SetUser(ctx, claims.UserID)
+	ctx = reqlog.WithUser(ctx, claims.UserID)
+	return ctx
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"go-template/domain/authz"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// SetAuthorizer replaces the policy Authorize consults. By default it is an
+// authz.StaticAuthorizer checking permissions with the PermissionResolver,
+// or against the token's permissions without one.
+func (m *AuthMiddleware) SetAuthorizer(authorizer authz.Authorizer) {
+	m.authorizer = authorizer
+}
+
+// Authorize rejects callers the authorizer doesn't allow action on object.
+// It must run after RequireAuth or RequireAdmin so the claims are in the
+// context.
+func (m *AuthMiddleware) Authorize(object, action string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := PrincipalFromContext(r.Context()); !ok {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, map[string]string{
+					"error": "unauthorized",
+				})
+				return
+			}
+
+			allowed, err := m.Allowed(r, object, action, uuid.Nil)
+			if err != nil {
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, map[string]string{
+					"error": "failed to authorize request",
+				})
+				return
+			}
+			if !allowed {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, map[string]string{
+					"error": "not allowed to " + action + " " + object,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allowed asks the authorizer whether the caller of r may perform action on
+// object, for handlers that only know the object's owner once they load it.
+// Requests without a caller are never allowed.
+func (m *AuthMiddleware) Allowed(r *http.Request, object, action string, owner uuid.UUID) (bool, error) {
+	principal, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		return false, nil
+	}
+
+	authorizer := m.authorizer
+	if authorizer == nil {
+		authorizer = authz.NewStaticAuthorizer(m.permissions)
+	}
+
+	return authorizer.Authorize(r.Context(), authz.Request{
+		Subject: authz.Subject{
+			UserID:      principal.UserID,
+			AccountType: principal.AccountType,
+			Roles:       principal.Roles,
+			Permissions: principal.Permissions,
+			OrgID:       principal.OrgID,
+		},
+		Object: object,
+		Action: action,
+		Owner:  owner,
+	})
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/authz"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type authorizerFunc func(ctx context.Context, req authz.Request) (bool, error)
+
+func (f authorizerFunc) Authorize(ctx context.Context, req authz.Request) (bool, error) {
+	return f(ctx, req)
+}
+
+func TestAuthMiddleware_Authorize(t *testing.T) {
+	userID := "4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11"
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Allows support staff to read anything, whatever their permissions
+	authorizer := authorizerFunc(func(ctx context.Context, req authz.Request) (bool, error) {
+		for _, role := range req.Subject.Roles {
+			switch role {
+			case "support":
+				return req.Action == "read", nil
+			case "broken":
+				return false, errors.New("policy unavailable")
+			}
+		}
+		return false, nil
+	})
+
+	tests := []struct {
+		name       string
+		authorizer authz.Authorizer
+		claims     *jwt.Claims
+		wantStatus int
+	}{
+		{
+			name:       "default checks token permissions",
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin", Permissions: []string{"users:read"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "default without the permission",
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "custom authorizer allows",
+			authorizer: authorizer,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "user", Roles: []string{"support"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "custom authorizer ignores token permissions",
+			authorizer: authorizer,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin", Permissions: []string{"users:read"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "authorizer error",
+			authorizer: authorizer,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "user", Roles: []string{"broken"}},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "no claims",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(jwt.NewService("test-secret", "test-issuer", "1h"))
+			if tt.authorizer != nil {
+				m.SetAuthorizer(tt.authorizer)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), UserContextKey, tt.claims))
+			}
+			w := httptest.NewRecorder()
+
+			m.Authorize("users", "read")(ok).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"go-template/domain"
+	"go-template/internal/clientip"
+	"net/http"
+)
+
+// ClientInfo records the client's IP address and user agent in the request
+// context (see domain.WithClient). It has to run after chi's RealIP.
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := domain.WithClient(r.Context(), domain.Client{
+			IP:        clientip.FromRequest(r),
+			UserAgent: r.UserAgent(),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
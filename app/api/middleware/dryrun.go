@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"go-template/domain"
+	"net/http"
+	"strconv"
+)
+
+// DryRunHeader asks mutating endpoints to validate the request and return the
+// would-be result without persisting anything. The dry_run query parameter is
+// accepted as well.
+const DryRunHeader = "X-Dry-Run"
+
+// DryRun marks the request context for dry-run execution when the client asks
+// for it, and echoes the header so responses can't be mistaken for real
+// changes.
+func DryRun(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isDryRunRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(DryRunHeader, "true")
+		next.ServeHTTP(w, r.WithContext(domain.WithDryRun(r.Context())))
+	})
+}
+
+func isDryRunRequest(r *http.Request) bool {
+	value := r.Header.Get(DryRunHeader)
+	if value == "" {
+		value = r.URL.Query().Get("dry_run")
+	}
+	dryRun, _ := strconv.ParseBool(value)
+	return dryRun
+}
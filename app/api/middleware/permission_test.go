@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type permissionFunc func(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error)
+
+func (f permissionFunc) HasPermission(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+	return f(ctx, userID, accountType, perm)
+}
+
+func TestAuthMiddleware_RequirePermission(t *testing.T) {
+	userID := "4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11"
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	resolver := permissionFunc(func(ctx context.Context, id uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+		switch accountType {
+		case entities.AccountTypeSuperAdmin:
+			return true, nil
+		case entities.AccountTypeAdmin:
+			return false, errors.New("db down")
+		}
+		return false, nil
+	})
+
+	tests := []struct {
+		name       string
+		resolver   PermissionResolver
+		claims     *jwt.Claims
+		wantStatus int
+	}{
+		{
+			name:       "token holding the permission",
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin", Permissions: []string{"users:write"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "token without the permission",
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin", Permissions: []string{"users:read"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "resolver grants the permission",
+			resolver:   resolver,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "super_admin"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "resolver overrides stale token permissions",
+			resolver:   resolver,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "user", Permissions: []string{"users:write"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "resolver error",
+			resolver:   resolver,
+			claims:     &jwt.Claims{UserID: userID, AccountType: "admin"},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "no claims",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(jwt.NewService("test-secret", "test-issuer", "1h"))
+			if tt.resolver != nil {
+				m.SetPermissionResolver(tt.resolver)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), UserContextKey, tt.claims))
+			}
+			w := httptest.NewRecorder()
+
+			m.RequirePermission(entities.PermissionUsersWrite)(ok).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
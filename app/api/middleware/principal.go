@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Principal is the caller a request was authenticated as, with the token's
+// claims in their domain types.
+type Principal struct {
+	UserID      uuid.UUID
+	Email       string
+	AccountType entities.AccountType
+	Roles       []string
+	// Permissions are the admin permissions the token was issued with. They
+	// may be stale; RequireAdminPermission and RequirePermission resolve them
+	// on every request.
+	Permissions []entities.Permission
+	OrgID       string
+	// MFA is set when the session passed a second factor
+	MFA bool
+	// Claims are the raw claims, including custom ones
+	Claims *jwt.Claims
+}
+
+// PrincipalFromContext returns the caller authenticated by RequireAuth or
+// RequireAdmin. Tokens whose subject isn't a user ID don't yield one.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	claims, ok := GetUserFromContext(ctx)
+	if !ok {
+		return Principal{}, false
+	}
+	userID, err := uuid.FromString(claims.UserID)
+	if err != nil {
+		return Principal{}, false
+	}
+
+	permissions := make([]entities.Permission, 0, len(claims.Permissions))
+	for _, perm := range claims.Permissions {
+		permissions = append(permissions, entities.Permission(perm))
+	}
+
+	return Principal{
+		UserID:      userID,
+		Email:       claims.Email,
+		AccountType: entities.AccountType(claims.AccountType),
+		Roles:       claims.Roles,
+		Permissions: permissions,
+		OrgID:       claims.OrgID,
+		MFA:         claims.MFA(),
+		Claims:      claims,
+	}, true
+}
+
+// IsAdmin reports whether the caller is an admin or a super admin.
+func (p Principal) IsAdmin() bool {
+	return p.AccountType == entities.AccountTypeAdmin || p.AccountType == entities.AccountTypeSuperAdmin
+}
+
+// HasRole reports whether the token was issued with role.
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// HasPermission reports whether the token was issued with perm.
+func (p Principal) HasPermission(perm entities.Permission) bool {
+	return slices.Contains(p.Permissions, perm)
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// ReadOnlyChecker reports whether the service is currently refusing writes
+type ReadOnlyChecker interface {
+	ReadOnly() bool
+}
+
+// WritableInReadOnly marks a route that keeps taking writes in read-only
+// mode, such as logging in and out, where it's registered:
+//
+//	r.Method(http.MethodPost, "/login", middleware.WritableInReadOnly(h.Login))
+func WritableInReadOnly(h http.HandlerFunc) http.Handler {
+	return writableInReadOnly{h}
+}
+
+type writableInReadOnly struct {
+	http.HandlerFunc
+}
+
+// ReadOnly rejects mutating requests with 503 while checker reports read-only
+// mode. Routes registered with WritableInReadOnly are let through.
+// They're looked up on the first rejected request, once every route is
+// registered.
+func ReadOnly(checker ReadOnlyChecker, routes chi.Routes) func(http.Handler) http.Handler {
+	var (
+		once     sync.Once
+		writable map[string]struct{}
+	)
+	isWritable := func(r *http.Request) bool {
+		once.Do(func() {
+			writable = make(map[string]struct{})
+			_ = chi.Walk(routes, func(method, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+				if _, ok := handler.(writableInReadOnly); ok {
+					writable[method+" "+route] = struct{}{}
+				}
+				return nil
+			})
+		})
+		pattern := routes.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+		_, ok := writable[r.Method+" "+pattern]
+		return ok
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Dry runs don't write, so they keep working during a failover
+			if checker == nil || !checker.ReadOnly() || !isWriteMethod(r.Method) || isDryRunRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if isWritable(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", "5")
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": "service is in read-only mode, please try again later",
+			})
+		})
+	}
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type readOnlyFunc func() bool
+
+func (f readOnlyFunc) ReadOnly() bool { return f() }
+
+func TestReadOnly(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := chi.NewRouter()
+	r.Use(ReadOnly(readOnlyFunc(func() bool { return true }), r))
+	r.Route("/api/v1", func(r chi.Router) {
+		auth := chi.NewRouter()
+		auth.With(func(next http.Handler) http.Handler { return next }).
+			Method(http.MethodPost, "/login", WritableInReadOnly(ok))
+		auth.Post("/register", ok)
+		auth.Get("/me", ok)
+		r.Mount("/auth", auth)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		want   int
+	}{
+		{name: "writable route", method: http.MethodPost, path: "/api/v1/auth/login", want: http.StatusOK},
+		{name: "write", method: http.MethodPost, path: "/api/v1/auth/register", want: http.StatusServiceUnavailable},
+		{name: "read", method: http.MethodGet, path: "/api/v1/auth/me", want: http.StatusOK},
+		{name: "dry run", method: http.MethodPost, path: "/api/v1/auth/register", header: "true", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(DryRunHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"go-template/internal/jwt"
+)
+
+// RevocationChecker reports whether a token was revoked before it expired,
+// either on its own or with every other token of its user
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	IsUserRevoked(ctx context.Context, claims *jwt.Claims) (bool, error)
+}
+
+// SetRevocationChecker makes RequireAuth and RequireAdmin reject revoked
+// tokens. Without a checker tokens stay valid until they expire.
+func (m *AuthMiddleware) SetRevocationChecker(checker RevocationChecker) {
+	m.revocations = checker
+}
+
+// revoked reports whether the token the claims were parsed from was revoked.
+func (m *AuthMiddleware) revoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if m.revocations == nil {
+		return false, nil
+	}
+	revoked, err := m.revocations.IsRevoked(ctx, claims.ID)
+	if err != nil || revoked {
+		return revoked, err
+	}
+	return m.revocations.IsUserRevoked(ctx, claims)
+}
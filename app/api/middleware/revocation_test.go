@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type revocationFunc func(ctx context.Context, jti string) (bool, error)
+
+func (f revocationFunc) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return f(ctx, jti)
+}
+
+func (f revocationFunc) IsUserRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	return false, nil
+}
+
+// userRevocation revokes every token of the user
+type userRevocation struct{ revocationFunc }
+
+func (userRevocation) IsUserRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	return true, nil
+}
+
+func TestAuthMiddleware_Revocation(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h").WithAudience(jwt.AudienceAdmin)
+	token, _ := jwtService.GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "admin@x.com", "super_admin")
+	claims, _ := jwtService.ValidateToken(token)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		checker    RevocationChecker
+		wantStatus int
+	}{
+		{name: "no checker", wantStatus: http.StatusOK},
+		{
+			name: "not revoked",
+			checker: revocationFunc(func(ctx context.Context, jti string) (bool, error) {
+				return false, nil
+			}),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "revoked",
+			checker: revocationFunc(func(ctx context.Context, jti string) (bool, error) {
+				return jti == claims.ID, nil
+			}),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "user revoked",
+			checker: userRevocation{revocationFunc(func(ctx context.Context, jti string) (bool, error) {
+				return false, nil
+			})},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "checker error",
+			checker: revocationFunc(func(ctx context.Context, jti string) (bool, error) {
+				return false, errors.New("db down")
+			}),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(jwtService)
+			if tt.checker != nil {
+				m.SetRevocationChecker(tt.checker)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			m.RequireAuth(ok).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
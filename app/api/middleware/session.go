@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"go-template/internal/clientip"
+	"go-template/internal/jwt"
+	"net/http"
+)
+
+// SessionTracker records the tokens requests are authenticated with
+type SessionTracker interface {
+	Touch(ctx context.Context, claims *jwt.Claims, ip, userAgent string)
+}
+
+// SetSessionTracker makes RequireAuth and RequireAdmin record every token
+// they accept, with the client's IP address and user agent.
+func (m *AuthMiddleware) SetSessionTracker(tracker SessionTracker) {
+	m.sessions = tracker
+}
+
+// trackSession records that the request was authenticated with the token the
+// claims were parsed from.
+func (m *AuthMiddleware) trackSession(r *http.Request, claims *jwt.Claims) {
+	if m.sessions == nil {
+		return
+	}
+	m.sessions.Touch(r.Context(), claims, clientip.FromRequest(r), r.UserAgent())
+}
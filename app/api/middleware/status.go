@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain"
+	"go-template/internal/jwt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// StatusChecker tells whether a user may use their account. CheckStatus
+// returns a *domain.AccountSuspendedError for suspended users and
+// domain.ErrAccountPending for pending ones.
+type StatusChecker interface {
+	CheckStatus(ctx context.Context, userID uuid.UUID) error
+}
+
+// SetStatusChecker makes RequireAuth and RequireAdmin turn away suspended and
+// pending users, whose tokens otherwise stay valid until they expire.
+func (m *AuthMiddleware) SetStatusChecker(checker StatusChecker) {
+	m.statuses = checker
+}
+
+// checkStatus returns the error keeping the user of the claims out, if any.
+// Admins impersonating the user are let in, so they can look into the
+// account that was suspended.
+func (m *AuthMiddleware) checkStatus(ctx context.Context, claims *jwt.Claims) error {
+	if m.statuses == nil || claims.Impersonated() {
+		return nil
+	}
+	userID, err := uuid.FromString(claims.UserID)
+	if err != nil {
+		return nil
+	}
+	err = m.statuses.CheckStatus(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Whatever the request does will find the user gone
+		return nil
+	}
+	return err
+}
+
+// requireActive answers the request and returns false when the user of the
+// claims can't use their account.
+func (m *AuthMiddleware) requireActive(w http.ResponseWriter, r *http.Request, claims *jwt.Claims) bool {
+	err := m.checkStatus(r.Context(), claims)
+	if err == nil {
+		return true
+	}
+	if common.AccountStatusResponse(w, r, err) {
+		return false
+	}
+	render.Status(r, http.StatusInternalServerError)
+	render.JSON(w, r, map[string]string{
+		"error": "failed to check account status",
+	})
+	return false
+}
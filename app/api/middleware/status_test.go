@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-template/domain"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type statusFunc func(ctx context.Context, userID uuid.UUID) error
+
+func (f statusFunc) CheckStatus(ctx context.Context, userID uuid.UUID) error {
+	return f(ctx, userID)
+}
+
+func TestAuthMiddleware_Status(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, _ := jwtService.GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "user@x.com", "user")
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		checker    StatusChecker
+		wantStatus int
+		wantReason string
+	}{
+		{name: "no checker", wantStatus: http.StatusOK},
+		{
+			name:       "active",
+			checker:    statusFunc(func(ctx context.Context, userID uuid.UUID) error { return nil }),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "suspended",
+			checker: statusFunc(func(ctx context.Context, userID uuid.UUID) error {
+				return &domain.AccountSuspendedError{Reason: "chargeback"}
+			}),
+			wantStatus: http.StatusForbidden,
+			wantReason: "chargeback",
+		},
+		{
+			name:       "pending",
+			checker:    statusFunc(func(ctx context.Context, userID uuid.UUID) error { return domain.ErrAccountPending }),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "deleted user",
+			checker:    statusFunc(func(ctx context.Context, userID uuid.UUID) error { return domain.ErrNotFound }),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "checker error",
+			checker:    statusFunc(func(ctx context.Context, userID uuid.UUID) error { return errors.New("db down") }),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(jwtService)
+			if tt.checker != nil {
+				m.SetStatusChecker(tt.checker)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			m.RequireAuth(ok).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantReason != "" {
+				var body map[string]string
+				if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				if body["reason"] != tt.wantReason {
+					t.Fatalf("expected reason %q, got %q", tt.wantReason, body["reason"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"go-template/internal/jwt"
+	"net/http"
+)
+
+// TwoFactorPolicy reports whether admins must complete a second factor
+type TwoFactorPolicy interface {
+	AdminTwoFactorRequired(ctx context.Context) (bool, error)
+}
+
+// SetTwoFactorPolicy makes RequireAdmin reject admin tokens issued without a
+// second factor while the policy requires one. Without a policy any admin
+// token is accepted.
+func (m *AuthMiddleware) SetTwoFactorPolicy(policy TwoFactorPolicy) {
+	m.twoFactor = policy
+}
+
+// RequireAdminWithout2FA is RequireAdmin without the two-factor policy. It
+// guards the endpoints an admin uses to set up two-factor authentication in
+// the first place.
+func (m *AuthMiddleware) RequireAdminWithout2FA(next http.Handler) http.Handler {
+	return m.requireAdmin(next, false)
+}
+
+// TwoFactorSatisfied reports whether the token the claims were parsed from
+// meets the two-factor policy.
+func (m *AuthMiddleware) TwoFactorSatisfied(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if m.twoFactor == nil || claims.MFA() {
+		return true, nil
+	}
+	required, err := m.twoFactor.AdminTwoFactorRequired(ctx)
+	if err != nil {
+		return false, err
+	}
+	return !required, nil
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type twoFactorPolicyFunc func(ctx context.Context) (bool, error)
+
+func (f twoFactorPolicyFunc) AdminTwoFactorRequired(ctx context.Context) (bool, error) {
+	return f(ctx)
+}
+
+func TestAuthMiddleware_TwoFactorPolicy(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h").WithAudience(jwt.AudienceAdmin)
+	token := func(svc jwt.Service) string {
+		tok, _ := svc.GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "admin@x.com", "admin")
+		return tok
+	}
+	required := twoFactorPolicyFunc(func(ctx context.Context) (bool, error) { return true, nil })
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		policy     TwoFactorPolicy
+		token      string
+		without2FA bool
+		wantStatus int
+	}{
+		{name: "no policy", token: token(jwtService), wantStatus: http.StatusOK},
+		{
+			name:       "not required",
+			policy:     twoFactorPolicyFunc(func(ctx context.Context) (bool, error) { return false, nil }),
+			token:      token(jwtService),
+			wantStatus: http.StatusOK,
+		},
+		{name: "required without mfa", policy: required, token: token(jwtService), wantStatus: http.StatusForbidden},
+		{name: "required with mfa", policy: required, token: token(jwtService.WithAMR(jwt.AMROneTimePassword, jwt.AMRMultiFactor)), wantStatus: http.StatusOK},
+		{name: "enrollment route", policy: required, token: token(jwtService), without2FA: true, wantStatus: http.StatusOK},
+		{
+			name:       "policy error",
+			policy:     twoFactorPolicyFunc(func(ctx context.Context) (bool, error) { return false, errors.New("db down") }),
+			token:      token(jwtService),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(jwtService)
+			if tt.policy != nil {
+				m.SetTwoFactorPolicy(tt.policy)
+			}
+
+			handler := m.RequireAdmin(ok)
+			if tt.without2FA {
+				handler = m.RequireAdminWithout2FA(ok)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/v1/users", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsMFAChallengeTokens(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	tok, _ := jwtService.WithAudience(jwt.AudienceMFA).GenerateToken("4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11", "a@b.com", "user")
+
+	m := NewAuthMiddleware(jwtService)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+
+	m.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
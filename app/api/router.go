@@ -1,6 +1,12 @@
 package api
 
 import (
+	appMiddleware "go-template/app/api/middleware"
+	"go-template/internal/clientip"
+	"go-template/internal/metrics"
+	"go-template/internal/reqlog"
+	"log/slog"
+	"net/netip"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -8,20 +14,25 @@ import (
 	"github.com/go-chi/cors"
 )
 
-func Router() *chi.Mux {
+// Router returns the API's router with the middleware every route runs
+// through. Requests are logged to log, and the client addresses forwarded
+// by the trusted proxies are believed.
+func Router(log *slog.Logger, trustedProxies []netip.Prefix) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(clientip.Middleware(trustedProxies))
+	r.Use(appMiddleware.ClientInfo)
+	r.Use(reqlog.Middleware(log))
 	r.Use(middleware.Recoverer)
+	r.Use(metrics.HTTPMiddleware)
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	// CORS configuration
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
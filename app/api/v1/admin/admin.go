@@ -1,11 +1,19 @@
 package admin
 
 import (
+	"errors"
+	"fmt"
+	"go-template/app/api/common"
 	"go-template/app/api/middleware"
+	"go-template/domain"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/domain/passwordpolicy"
+	"go-template/internal/filter"
+	"go-template/internal/jwt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -20,10 +28,26 @@ type AdminLoginRequest struct {
 }
 
 type AdminLoginResponse struct {
-	Token       string        `json:"token"`
-	User        entities.User `json:"user"`
-	AccountType string        `json:"account_type"`
-	ExpiresAt   time.Time     `json:"expires_at"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         entities.User `json:"user"`
+	AccountType  string        `json:"account_type"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+	// MFARequired is set instead of the tokens when the admin has two-factor
+	// authentication enabled; complete the login at /admin/v1/2fa/challenge
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	// TwoFactorSetupRequired is set when Require2FA is on and the admin has
+	// not enabled two-factor authentication yet. Until then only the
+	// /admin/v1/2fa endpoints accept the token.
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+	// RecoveryCodes is only set when two-factor authentication was just
+	// enabled. The codes can't be shown again.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type AdminLogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 type DashboardStatsResponse struct {
@@ -33,17 +57,20 @@ type DashboardStatsResponse struct {
 	SystemAlerts   int64 `json:"system_alerts"`
 }
 
+// UserListResponse is a page of users. With cursor pagination only Users,
+// PageSize and NextCursor are set, and NextCursor is empty on the last page.
 type UserListResponse struct {
 	Users      []entities.User `json:"users"`
 	Total      int64           `json:"total"`
 	Page       int             `json:"page"`
 	PageSize   int             `json:"page_size"`
 	TotalPages int             `json:"total_pages"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 type CreateUserRequest struct {
 	Email        string               `json:"email" validate:"required,email"`
-	Password     string               `json:"password" validate:"required,min=8"`
+	Password     string               `json:"password" validate:"required,min=6"`
 	AccountType  entities.AccountType `json:"account_type" validate:"required"`
 	AuthProvider string               `json:"auth_provider" validate:"required"`
 }
@@ -66,6 +93,7 @@ type UpdateUserRequest struct {
 //	@Failure		401	{object}	map[string]string
 //	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
+//	@Failure		503	{object}	map[string]string
 //	@Router			/admin/v1/login [post]
 func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	var req AdminLoginRequest
@@ -78,10 +106,7 @@ func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, map[string]string{
-			"error": "validation failed: " + err.Error(),
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
@@ -89,8 +114,19 @@ func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	response, err := h.authUC.Login(r.Context(), auth.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		Audience: jwt.AudienceAdmin,
 	})
 	if err != nil {
+		if common.AccountStatusResponse(w, r, err) {
+			return
+		}
+		if errors.Is(err, auth.ErrProviderUnavailable) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": "authentication service unavailable, try again later",
+			})
+			return
+		}
 		render.Status(r, http.StatusUnauthorized)
 		render.JSON(w, r, map[string]string{
 			"error": "authentication failed",
@@ -107,6 +143,23 @@ func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Admins with two-factor authentication finish at /2fa/challenge
+	if response.MFARequired {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, AdminLoginResponse{
+			User:        response.User,
+			AccountType: response.User.AccountType.String(),
+			MFARequired: true,
+			MFAToken:    response.MFAToken,
+		})
+		return
+	}
+
+	h.renderAdminTokens(w, r, response)
+}
+
+// renderAdminTokens writes the login response for tokens issued to an admin.
+func (h *AdminHandler) renderAdminTokens(w http.ResponseWriter, r *http.Request, response auth.AuthResponse) {
 	// Parse token to get expiration
 	claims, err := h.jwtService.ValidateToken(response.Token)
 	if err != nil {
@@ -117,12 +170,24 @@ func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	satisfied, err := h.authMw.TwoFactorSatisfied(r.Context(), claims)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to check two-factor policy",
+		})
+		return
+	}
+
 	// Return successful admin login response
 	adminResponse := AdminLoginResponse{
-		Token:       response.Token,
-		User:        response.User,
-		AccountType: response.User.AccountType.String(),
-		ExpiresAt:   claims.ExpiresAt.Time,
+		Token:                  response.Token,
+		RefreshToken:           response.RefreshToken,
+		User:                   response.User,
+		AccountType:            response.User.AccountType.String(),
+		ExpiresAt:              claims.ExpiresAt.Time,
+		TwoFactorSetupRequired: !satisfied,
+		RecoveryCodes:          response.RecoveryCodes,
 	}
 
 	render.Status(r, http.StatusOK)
@@ -130,12 +195,56 @@ func (h *AdminHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AdminHandler) AdminLogout(w http.ResponseWriter, r *http.Request) {
+	// The refresh token is optional; without one there is nothing to revoke
+	var req AdminLogoutRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid request body",
+			})
+			return
+		}
+	}
+
+	// Revoke the session token so it can't be replayed until it expires
+	if h.revoker != nil {
+		if claims, err := h.jwtService.ValidateToken(bearerToken(r)); err == nil {
+			if err := h.revoker.Revoke(r.Context(), claims); err != nil {
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, map[string]string{
+					"error": "logout failed",
+				})
+				return
+			}
+		}
+	}
+
+	if req.RefreshToken != "" {
+		if err := h.authUC.Logout(r.Context(), req.RefreshToken); err != nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, map[string]string{
+				"error": "logout failed",
+			})
+			return
+		}
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]string{
 		"message": "logged out successfully",
 	})
 }
 
+// bearerToken returns the token from the Authorization header, if any.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
+
 func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request) {
 	// Extract token from Authorization header
 	authHeader := r.Header.Get("Authorization")
@@ -158,9 +267,11 @@ func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request)
 
 	token := authHeader[7:] // Remove "Bearer " prefix
 
-	// Validate token using JWT service
+	// Validate token using JWT service. Only tokens issued to the admin app
+	// are valid here; two-factor challenge tokens only work at
+	// /2fa/challenge.
 	claims, err := h.jwtService.ValidateToken(token)
-	if err != nil {
+	if err != nil || !claims.HasAudience(jwt.AudienceAdmin) {
 		render.Status(r, http.StatusUnauthorized)
 		render.JSON(w, r, map[string]string{
 			"error": "invalid token",
@@ -168,6 +279,27 @@ func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if h.revoker != nil {
+		revoked, err := h.revoker.IsRevoked(r.Context(), claims.ID)
+		if err == nil && !revoked {
+			revoked, err = h.revoker.IsUserRevoked(r.Context(), claims)
+		}
+		if err != nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, map[string]string{
+				"error": "failed to check token",
+			})
+			return
+		}
+		if revoked {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "token revoked",
+			})
+			return
+		}
+	}
+
 	// Check if user has admin privileges
 	accountType := entities.AccountType(claims.AccountType)
 	if accountType != entities.AccountTypeAdmin && accountType != entities.AccountTypeSuperAdmin {
@@ -178,6 +310,15 @@ func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	satisfied, err := h.authMw.TwoFactorSatisfied(r.Context(), claims)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to check two-factor policy",
+		})
+		return
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]interface{}{
 		"valid":        true,
@@ -185,13 +326,15 @@ func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request)
 		"email":        claims.Email,
 		"account_type": claims.AccountType,
 		"expires_at":   claims.ExpiresAt.Time,
+
+		"two_factor_setup_required": !satisfied,
 	})
 }
 
 // GetDashboardStats godoc
 //
 //	@Summary		Get dashboard statistics
-//	@Description	Retrieve admin dashboard statistics including user counts and system alerts
+//	@Description	Retrieve admin dashboard statistics: user counts, active sessions and the system alerts raised in the last 24 hours
 //	@Tags			admin
 //	@Produce		json
 //	@Security		BearerAuth
@@ -200,22 +343,15 @@ func (h *AdminHandler) VerifyAdminToken(w http.ResponseWriter, r *http.Request)
 //	@Failure		500	{object}	map[string]string
 //	@Router			/admin/v1/dashboard/stats [get]
 func (h *AdminHandler) GetDashboardStats(w http.ResponseWriter, r *http.Request) {
-	userStats, err := h.userUC.GetUserStats(r.Context())
+	stats, err := h.dashboardStats(r.Context())
 	if err != nil {
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, map[string]string{
-			"error": "failed to get user stats",
+			"error": err.Error(),
 		})
 		return
 	}
 
-	stats := DashboardStatsResponse{
-		TotalUsers:     userStats.TotalUsers,
-		AdminUsers:     userStats.AdminUsers + userStats.SuperAdminUsers,
-		ActiveSessions: 0, // TODO: Implement session tracking
-		SystemAlerts:   0, // TODO: Implement system alerts
-	}
-
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, stats)
 }
@@ -229,11 +365,15 @@ func (h *AdminHandler) GetDashboardStats(w http.ResponseWriter, r *http.Request)
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			request	body	CreateUserRequest	true	"User creation request"
+//	@Param			X-Dry-Run	header	bool	false	"Validate and return the would-be user without creating it"
+//	@Success		200	{object}	entities.User	"Dry run"
 //	@Success		201	{object}	entities.User
 //	@Failure		400	{object}	map[string]string
 //	@Failure		401	{object}	map[string]string
 //	@Failure		403	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
+//	@Failure		503	{object}	map[string]string
 //	@Router			/admin/v1/users [post]
 func (h *AdminHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req CreateUserRequest
@@ -246,10 +386,7 @@ func (h *AdminHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, map[string]string{
-			"error": "validation failed: " + err.Error(),
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
@@ -275,29 +412,57 @@ func (h *AdminHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userUC.CreateUser(r.Context(), req.Email, req.Password, req.AuthProvider, req.AccountType)
 	if err != nil {
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, map[string]string{
-			"error": "failed to create user",
-		})
+		if errors.Is(err, auth.ErrProviderDisabled) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "auth provider is not available",
+			})
+			return
+		}
+		if errors.Is(err, passwordpolicy.ErrWeakPassword) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, auth.ErrProviderUnavailable) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": "authentication service unavailable, try again later",
+			})
+			return
+		}
+		common.RespondError(w, r, err)
 		return
 	}
 
-	render.Status(r, http.StatusCreated)
+	// Nothing was created on a dry run
+	if domain.IsDryRun(r.Context()) {
+		render.Status(r, http.StatusOK)
+	} else {
+		render.Status(r, http.StatusCreated)
+	}
 	render.JSON(w, r, user)
 }
 
 // ListUsers godoc
 //
 //	@Summary		List users
-//	@Description	Retrieve a paginated list of users with optional search and filtering
+//	@Description	Retrieve a paginated list of users with optional search and filtering. Passing cursor, empty for the first page, pages by cursor instead of page number: follow next_cursor until it is absent. Cursor pages have no totals and are always newest first.
 //	@Tags			admin
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			page	query	int	false	"Page number (default: 1)"
 //	@Param			page_size	query	int	false	"Page size (default: 20, max: 100)"
+//	@Param			cursor	query	string	false	"Opaque cursor from next_cursor"
+//	@Param			sort	query	string	false	"Sort by email, created_at or account_type (default: created_at)"
+//	@Param			order	query	string	false	"Sort direction, asc or desc (default: desc for created_at, asc otherwise)"
 //	@Param			search	query	string	false	"Search term for email"
 //	@Param			account_type	query	string	false	"Filter by account type"
+//	@Param			filter	query	string	false	"Filter expression, e.g. status:active,created_at>2024-01-01"
 //	@Success		200	{object}	UserListResponse
+//	@Failure		400	{object}	map[string]string
 //	@Failure		401	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/admin/v1/users [get]
@@ -319,25 +484,60 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse search and filter parameters
-	search := r.URL.Query().Get("search")
-	accountType := r.URL.Query().Get("account_type")
+	userFilter, err := parseUserFilter(r)
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	sort, err := parseUserSort(r)
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// A cursor parameter, even an empty one, switches to cursor pagination
+	if r.URL.Query().Has("cursor") {
+		if sort != (entities.UserSort{Field: entities.UserSortCreatedAt, Desc: true}) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "cursor pages are always sorted by created_at desc",
+			})
+			return
+		}
+		users, next, err := h.userUC.ListUsersAfter(r.Context(), r.URL.Query().Get("cursor"), pageSize, userFilter)
+		if err != nil {
+			common.RespondError(w, r, err)
+			return
+		}
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, UserListResponse{
+			Users:      users,
+			PageSize:   pageSize,
+			NextCursor: next,
+		})
+		return
+	}
 
 	var users []entities.User
 	var total int64
-	var err error
 
 	// Use search if provided, otherwise regular listing
-	if search != "" || accountType != "" {
-		users, total, err = h.userUC.SearchUsers(r.Context(), page, pageSize, search, accountType)
+	if userFilter.Search != "" || userFilter.AccountType != "" || len(userFilter.Expr) > 0 {
+		users, total, err = h.userUC.SearchUsers(r.Context(), page, pageSize, userFilter, sort)
 	} else {
-		users, total, err = h.userUC.ListUsers(r.Context(), page, pageSize)
+		users, total, err = h.userUC.ListUsers(r.Context(), page, pageSize, sort)
 	}
 
 	if err != nil {
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, map[string]string{
-			"error": "failed to list users",
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
@@ -355,6 +555,40 @@ func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, response)
 }
 
+// parseUserFilter reads the search, account_type and filter parameters.
+func parseUserFilter(r *http.Request) (entities.UserFilter, error) {
+	expr, err := filter.Parse(r.URL.Query().Get("filter"), entities.UserFilterFields)
+	if err != nil {
+		return entities.UserFilter{}, err
+	}
+	return entities.UserFilter{
+		Search:      r.URL.Query().Get("search"),
+		AccountType: entities.AccountType(r.URL.Query().Get("account_type")),
+		Expr:        expr,
+	}, nil
+}
+
+// parseUserSort reads the sort and order parameters. Users are sorted by
+// created_at unless sort says otherwise, and order defaults to desc for
+// created_at, so the newest come first, and to asc for the other fields.
+// The field itself is checked by the use case.
+func parseUserSort(r *http.Request) (entities.UserSort, error) {
+	sort := entities.UserSort{Field: entities.UserSortField(r.URL.Query().Get("sort"))}
+	if sort.Field == "" {
+		sort.Field = entities.UserSortCreatedAt
+	}
+	switch r.URL.Query().Get("order") {
+	case "":
+		sort.Desc = sort.Field == entities.UserSortCreatedAt
+	case "asc":
+	case "desc":
+		sort.Desc = true
+	default:
+		return sort, fmt.Errorf("%w: order must be asc or desc", domain.ErrMalformedParameters)
+	}
+	return sort, nil
+}
+
 func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	userIDStr := chi.URLParam(r, "id")
 	userID, err := uuid.FromString(userIDStr)
@@ -400,10 +634,7 @@ func (h *AdminHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, map[string]string{
-			"error": "validation failed: " + err.Error(),
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
@@ -503,12 +734,141 @@ func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	message := "user deleted successfully"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: user would be deleted"
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]string{
-		"message": "user deleted successfully",
+		"message": message,
+	})
+}
+
+// RevokeUserSessions godoc
+//
+//	@Summary		Force a user out
+//	@Description	Revoke every refresh and access token of the user, ending all their sessions
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	map[string]interface{}
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/revoke-sessions [post]
+func (h *AdminHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetUser, err := h.userUC.GetUserByID(r.Context(), userID)
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found",
+		})
+		return
+	}
+
+	// Regular admins can only force users out, as with deletes
+	if entities.AccountType(claims.AccountType) == entities.AccountTypeAdmin && targetUser.AccountType != entities.AccountTypeUser {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, map[string]string{
+			"error": "regular admins can only revoke sessions of user accounts",
+		})
+		return
+	}
+
+	revoked, err := h.sessionRevoker.RevokeUser(r.Context(), userID)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to revoke sessions",
+		})
+		return
+	}
+
+	message := "sessions revoked"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: sessions would be revoked"
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]interface{}{
+		"message": message,
+		"revoked": revoked,
 	})
 }
 
+// ImpersonateUser godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Issue a short-lived Web app token acting as the user. The admin is recorded in the token's act claim and in the audit log. Admin accounts can't be impersonated.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	auth.AuthResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/impersonate [post]
+func (h *AdminHandler) ImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	response, err := h.authUC.Impersonate(r.Context(), jwt.Actor{Subject: claims.UserID, Email: claims.Email}, userID)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, map[string]string{
+				"error": "only user accounts can be impersonated",
+			})
+			return
+		}
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
 func (h *AdminHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
 	userStats, err := h.userUC.GetUserStats(r.Context())
 	if err != nil {
@@ -556,16 +916,18 @@ func (h *AdminHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.settingsUC.UpdateSettings(r.Context(), &settingsRequest); err != nil {
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, map[string]string{
-			"error": "failed to update settings",
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
+	message := "settings updated successfully"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: settings are valid and were not saved"
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, map[string]string{
-		"message": "settings updated successfully",
+		"message": message,
 	})
 }
 
@@ -581,7 +943,7 @@ func (h *AdminHandler) GetAvailableAuthProviders(w http.ResponseWriter, r *http.
 
 	response := map[string]any{
 		"available_providers": settings.AvailableAuthProviders,
-		"default_provider":   settings.DefaultAuthProvider,
+		"default_provider":    settings.DefaultAuthProvider,
 	}
 
 	render.Status(r, http.StatusOK)
@@ -5,14 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	apiMiddleware "go-template/app/api/middleware"
 	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
 	"go-template/internal/jwt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/gofrs/uuid/v5"
@@ -129,10 +133,82 @@ func TestAdminLogin_AuthFailed(t *testing.T) {
 	}
 }
 
+func TestAdminLogout_RevokesRefreshToken(t *testing.T) {
+	uc := &mocks.AuthUseCaseMock{
+		LogoutFunc: func(ctx context.Context, refreshToken string) error {
+			return nil
+		},
+	}
+	jh := newTestJWT()
+	h := NewAdminHandler(uc, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	// Without a body there is nothing to revoke
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	w := httptest.NewRecorder()
+	h.AdminLogout(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(uc.LogoutCalls()) != 0 {
+		t.Fatalf("expected no revocation without a refresh token")
+	}
+
+	body, _ := json.Marshal(AdminLogoutRequest{RefreshToken: "rt_token"})
+	req = httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBuffer(body))
+	w = httptest.NewRecorder()
+	h.AdminLogout(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if calls := uc.LogoutCalls(); len(calls) != 1 || calls[0].RefreshToken != "rt_token" {
+		t.Fatalf("unexpected logout calls: %+v", calls)
+	}
+}
+
+func TestAdminLogout_RevokesAccessToken(t *testing.T) {
+	jh := newTestJWT()
+	tok, _ := jh.GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
+	revoker := &mocks.TokenRevokerMock{}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetTokenRevoker(revoker)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+	h.AdminLogout(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if calls := revoker.RevokeCalls(); len(calls) != 1 || calls[0].Claims.UserID != "u1" {
+		t.Fatalf("unexpected revoke calls: %+v", calls)
+	}
+}
+
+func TestVerifyAdminToken_Revoked(t *testing.T) {
+	jh := newTestJWT()
+	tok, _ := jh.GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetTokenRevoker(&mocks.TokenRevokerMock{
+		IsRevokedFunc: func(ctx context.Context, jti string) (bool, error) {
+			return true, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/verify", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+	h.VerifyAdminToken(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
 func TestVerifyAdminToken_Success(t *testing.T) {
 	jh := newTestJWT()
 	// Generate a real token and parse claims so ExpiresAt is populated
-	tok, _ := jh.GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
+	tok, _ := jh.WithAudience(jwt.AudienceAdmin).GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
 	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
 
 	req := httptest.NewRequest(http.MethodGet, "/auth/verify", nil)
@@ -145,6 +221,22 @@ func TestVerifyAdminToken_Success(t *testing.T) {
 	}
 }
 
+func TestVerifyAdminToken_RejectsOtherAudiences(t *testing.T) {
+	jh := newTestJWT()
+	// An admin's token for the web app doesn't open the admin app
+	tok, _ := jh.WithAudience(jwt.AudienceWeb).GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/verify", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+
+	h.VerifyAdminToken(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
 func TestVerifyAdminToken_Unauthorized(t *testing.T) {
 	jh := newTestJWT()
 	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
@@ -429,3 +521,471 @@ func TestMiscEndpoints(t *testing.T) {
 		}
 	})
 }
+
+func TestListUsers_Cursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		err        error
+		wantStatus int
+		wantCursor string
+		wantNext   string
+	}{
+		{name: "first page", target: "/users?cursor=&page_size=2", wantStatus: http.StatusOK, wantNext: "next"},
+		{name: "next page", target: "/users?cursor=abc&page_size=2&search=jane", wantStatus: http.StatusOK, wantCursor: "abc", wantNext: "next"},
+		{name: "invalid cursor", target: "/users?cursor=bad", err: fmt.Errorf("%w: invalid cursor", domain.ErrMalformedParameters), wantStatus: http.StatusBadRequest},
+		{name: "failed", target: "/users?cursor=", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCursor string
+			uc := &mocks.UserUseCaseMock{
+				ListUsersAfterFunc: func(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error) {
+					gotCursor = cursor
+					if tt.err != nil {
+						return nil, "", tt.err
+					}
+					return []entities.User{{Email: "a@x.com"}, {Email: "b@x.com"}}, "next", nil
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			w := httptest.NewRecorder()
+			h.ListUsers(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if gotCursor != tt.wantCursor {
+				t.Fatalf("expected cursor %q, got %q", tt.wantCursor, gotCursor)
+			}
+			var resp UserListResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Users) != 2 || resp.PageSize != 2 || resp.NextCursor != tt.wantNext {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
+
+func TestListUsers_Sort(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantSort   entities.UserSort
+	}{
+		{name: "default", target: "/users", wantStatus: http.StatusOK, wantSort: entities.UserSort{Field: entities.UserSortCreatedAt, Desc: true}},
+		{name: "email ascending by default", target: "/users?sort=email", wantStatus: http.StatusOK, wantSort: entities.UserSort{Field: entities.UserSortEmail}},
+		{name: "account type descending", target: "/users?sort=account_type&order=desc", wantStatus: http.StatusOK, wantSort: entities.UserSort{Field: entities.UserSortAccountType, Desc: true}},
+		{name: "oldest first", target: "/users?order=asc&search=jane", wantStatus: http.StatusOK, wantSort: entities.UserSort{Field: entities.UserSortCreatedAt}},
+		{name: "invalid order", target: "/users?order=sideways", wantStatus: http.StatusBadRequest},
+		{name: "invalid field", target: "/users?sort=password", wantStatus: http.StatusBadRequest},
+		{name: "sorted cursor", target: "/users?cursor=&sort=email", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSort entities.UserSort
+			check := func(sort entities.UserSort) error {
+				gotSort = sort
+				if !sort.Field.IsValid() {
+					return fmt.Errorf("%w: cannot sort users by %q", domain.ErrMalformedParameters, sort.Field)
+				}
+				return nil
+			}
+			uc := &mocks.UserUseCaseMock{
+				ListUsersFunc: func(ctx context.Context, page, pageSize int, sort entities.UserSort) ([]entities.User, int64, error) {
+					return []entities.User{}, 0, check(sort)
+				},
+				SearchUsersFunc: func(ctx context.Context, page, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error) {
+					return []entities.User{}, 0, check(sort)
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			w := httptest.NewRecorder()
+			h.ListUsers(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && gotSort != tt.wantSort {
+				t.Fatalf("expected sort %+v, got %+v", tt.wantSort, gotSort)
+			}
+		})
+	}
+}
+
+func TestListUsers_Filter(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantFilter entities.UserFilter
+	}{
+		{
+			name:       "expression",
+			target:     "/users?filter=status:active,email_verified:true",
+			wantStatus: http.StatusOK,
+			wantFilter: entities.UserFilter{Expr: entities.Filter{
+				{Field: "status", Op: entities.FilterEq, Value: "active"},
+				{Field: "email_verified", Op: entities.FilterEq, Value: true},
+			}},
+		},
+		{
+			name:       "with search",
+			target:     "/users?search=jane&filter=account_type!:admin",
+			wantStatus: http.StatusOK,
+			wantFilter: entities.UserFilter{Search: "jane", Expr: entities.Filter{
+				{Field: "account_type", Op: entities.FilterNe, Value: "admin"},
+			}},
+		},
+		{name: "unknown field", target: "/users?filter=password:x", wantStatus: http.StatusBadRequest},
+		{name: "bad value", target: "/users?filter=created_at>yesterday", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter entities.UserFilter
+			uc := &mocks.UserUseCaseMock{
+				SearchUsersFunc: func(ctx context.Context, page, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error) {
+					gotFilter = filter
+					return []entities.User{}, 0, nil
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			w := httptest.NewRecorder()
+			h.ListUsers(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && !reflect.DeepEqual(gotFilter, tt.wantFilter) {
+				t.Fatalf("expected filter %+v, got %+v", tt.wantFilter, gotFilter)
+			}
+		})
+	}
+}
+
+type fakePermissions []entities.Permission
+
+func (f fakePermissions) HasPermission(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+	for _, p := range f {
+		if p == perm {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestRoutes_DelegatedPermissions(t *testing.T) {
+	jh := newTestJWT()
+	mw := apiMiddleware.NewAuthMiddleware(jh)
+	mw.SetPermissionResolver(fakePermissions{entities.PermissionUsersRead})
+
+	uc := &mocks.UserUseCaseMock{
+		ListUsersFunc: func(ctx context.Context, page, pageSize int, sort entities.UserSort) ([]entities.User, int64, error) {
+			return []entities.User{}, 0, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, mw)
+	routes := h.Routes()
+
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/users", "", http.StatusOK},
+		{http.MethodPost, "/users", `{}`, http.StatusForbidden},
+		{http.MethodGet, "/settings", "", http.StatusForbidden},
+		{http.MethodGet, "/dashboard/stats", "", http.StatusForbidden},
+		{http.MethodGet, "/dashboard/stream", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		routes.ServeHTTP(w, req)
+
+		if w.Code != tt.status {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.status, w.Code)
+		}
+	}
+}
+
+func TestRoutes_DryRun(t *testing.T) {
+	jh := newTestJWT()
+	target := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "u@x.com", AccountType: entities.AccountTypeUser}
+
+	var dryRun bool
+	uc := &mocks.UserUseCaseMock{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return target, nil
+		},
+		DeleteUserFunc: func(ctx context.Context, userID uuid.UUID) error {
+			dryRun = domain.IsDryRun(ctx)
+			return nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/"+target.ID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(apiMiddleware.DryRunHeader, "true")
+	w := httptest.NewRecorder()
+
+	h.Routes().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !dryRun {
+		t.Fatalf("expected use case to run in dry-run mode")
+	}
+	if w.Header().Get(apiMiddleware.DryRunHeader) != "true" {
+		t.Fatalf("expected dry-run header to be echoed")
+	}
+	var resp map[string]string
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp["message"] != "dry run: user would be deleted" {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+}
+
+func TestGetDashboardStats_ActiveSessions(t *testing.T) {
+	jh := newTestJWT()
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetSessionCounter(&mocks.SessionCounterMock{
+		CountActiveFunc: func(ctx context.Context) (int64, error) {
+			return 7, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	h.GetDashboardStats(w, httptest.NewRequest(http.MethodGet, "/dashboard/stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var stats DashboardStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if stats.ActiveSessions != 7 {
+		t.Fatalf("expected 7 active sessions, got %d", stats.ActiveSessions)
+	}
+
+	h.SetSessionCounter(&mocks.SessionCounterMock{
+		CountActiveFunc: func(ctx context.Context) (int64, error) {
+			return 0, errors.New("db down")
+		},
+	})
+	w = httptest.NewRecorder()
+	h.GetDashboardStats(w, httptest.NewRequest(http.MethodGet, "/dashboard/stats", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestGetDashboardStats_SystemAlerts(t *testing.T) {
+	jh := newTestJWT()
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetAlertCounter(&mocks.AlertCounterMock{
+		CountRecentFunc: func(ctx context.Context) (int64, error) {
+			return 2, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	h.GetDashboardStats(w, httptest.NewRequest(http.MethodGet, "/dashboard/stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var stats DashboardStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if stats.SystemAlerts != 2 {
+		t.Fatalf("expected 2 system alerts, got %d", stats.SystemAlerts)
+	}
+
+	h.SetAlertCounter(&mocks.AlertCounterMock{
+		CountRecentFunc: func(ctx context.Context) (int64, error) {
+			return 0, errors.New("db down")
+		},
+	})
+	w = httptest.NewRecorder()
+	h.GetDashboardStats(w, httptest.NewRequest(http.MethodGet, "/dashboard/stats", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	jh := newTestJWT()
+	targetID := uuid.Must(uuid.NewV4())
+	adminTargetID := uuid.Must(uuid.NewV4())
+	userUC := &mocks.UserUseCaseMock{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			switch id {
+			case targetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeUser}, nil
+			case adminTargetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeAdmin}, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+	}
+	revoker := &mocks.SessionRevokerMock{
+		RevokeUserFunc: func(ctx context.Context, userID uuid.UUID) (int64, error) {
+			return 3, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetSessionRevoker(revoker)
+
+	call := func(id string, accountType entities.AccountType) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/"+id+"/revoke-sessions", nil)
+		ctx := context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: accountType.String()})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.RevokeUserSessions(w, req)
+		return w
+	}
+
+	if w := call("not-a-uuid", entities.AccountTypeAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w := call(uuid.Must(uuid.NewV4()).String(), entities.AccountTypeAdmin); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := call(adminTargetID.String(), entities.AccountTypeAdmin); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for an admin forcing out another admin, got %d", w.Code)
+	}
+	if len(revoker.RevokeUserCalls()) != 0 {
+		t.Fatalf("expected no sessions revoked, got %d calls", len(revoker.RevokeUserCalls()))
+	}
+
+	w := call(adminTargetID.String(), entities.AccountTypeSuperAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var body struct {
+		Revoked int64 `json:"revoked"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if body.Revoked != 3 {
+		t.Fatalf("expected 3 sessions revoked, got %d", body.Revoked)
+	}
+
+	if w := call(targetID.String(), entities.AccountTypeAdmin); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if calls := revoker.RevokeUserCalls(); len(calls) != 2 || calls[1].UserID != targetID {
+		t.Fatalf("expected the user's sessions to be revoked, got %+v", calls)
+	}
+}
+
+func TestImpersonateUser(t *testing.T) {
+	jh := newTestJWT()
+	adminID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+	authUC := &mocks.AuthUseCaseMock{
+		ImpersonateFunc: func(ctx context.Context, admin jwt.Actor, id uuid.UUID) (auth.AuthResponse, error) {
+			switch id {
+			case userID:
+				return auth.AuthResponse{Token: "impersonation-token", User: entities.User{ID: id}}, nil
+			case adminID:
+				return auth.AuthResponse{}, auth.ErrImpersonationNotAllowed
+			}
+			return auth.AuthResponse{}, domain.ErrNotFound
+		},
+	}
+	h := NewAdminHandler(authUC, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	call := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/"+id+"/impersonate", nil)
+		ctx := context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: adminID.String(), Email: "admin@x.com"})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.ImpersonateUser(w, req)
+		return w
+	}
+
+	tests := []struct {
+		id         string
+		wantStatus int
+	}{
+		{"not-a-uuid", http.StatusBadRequest},
+		{uuid.Must(uuid.NewV4()).String(), http.StatusNotFound},
+		{adminID.String(), http.StatusForbidden},
+		{userID.String(), http.StatusOK},
+	}
+	for _, tt := range tests {
+		if w := call(tt.id); w.Code != tt.wantStatus {
+			t.Fatalf("%s: expected %d, got %d", tt.id, tt.wantStatus, w.Code)
+		}
+	}
+
+	calls := authUC.ImpersonateCalls()
+	last := calls[len(calls)-1]
+	if last.Admin.Subject != adminID.String() || last.Admin.Email != "admin@x.com" {
+		t.Fatalf("expected the calling admin as the actor, got %+v", last.Admin)
+	}
+}
+
+func TestStreamDashboardStats(t *testing.T) {
+	jh := newTestJWT()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	userUC := &mocks.UserUseCaseMock{
+		GetUserStatsFunc: func(ctx context.Context) (entities.UserStats, error) {
+			calls++
+			switch calls {
+			case 1:
+				return entities.UserStats{TotalUsers: 1}, nil
+			case 2:
+				return entities.UserStats{}, errors.New("db down")
+			case 3:
+				return entities.UserStats{TotalUsers: 2}, nil
+			}
+			cancel()
+			return entities.UserStats{TotalUsers: 2}, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.dashboardStreamInterval = time.Millisecond
+
+	w := httptest.NewRecorder()
+	h.StreamDashboardStats(w, httptest.NewRequest(http.MethodGet, "/dashboard/stream", nil).WithContext(ctx))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d: %s", w.Code, w.Body.String())
+	}
+	want := "event: stats\ndata: {\"total_users\":1,\"admin_users\":0,\"active_sessions\":0,\"system_alerts\":0}\n\n" +
+		"event: stats\ndata: {\"total_users\":2,\"admin_users\":0,\"active_sessions\":0,\"system_alerts\":0}\n\n"
+	if w.Body.String() != want {
+		t.Fatalf("expected stats only when they change, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,180 @@
+package admin
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain"
+	"go-template/domain/user"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type ApproveUserRequest struct {
+	// Notify emails the user that they can sign in
+	Notify bool `json:"notify"`
+}
+
+type RejectUserRequest struct {
+	// Reason is only included in the email to the user
+	Reason string `json:"reason" validate:"max=500"`
+	Notify bool   `json:"notify"`
+}
+
+// ListPendingUsers godoc
+//
+//	@Summary		List users pending approval
+//	@Description	Users who signed up while the RequireApproval setting was on, oldest first
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query	int	false	"Page number (default: 1)"
+//	@Param			page_size	query	int	false	"Page size (default: 20, max: 100)"
+//	@Success		200	{object}	UserListResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/pending [get]
+func (h *AdminHandler) ListPendingUsers(w http.ResponseWriter, r *http.Request) {
+	page := 1
+	pageSize := 20
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && ps > 0 && ps <= 100 {
+		pageSize = ps
+	}
+
+	users, total, err := h.userUC.ListPending(r.Context(), page, pageSize)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list pending users",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, UserListResponse{
+		Users:      users,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	})
+}
+
+// ApproveUser godoc
+//
+//	@Summary		Approve a pending user
+//	@Description	Let a user waiting for approval sign in, optionally emailing them
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string				true	"User ID"
+//	@Param			request	body	ApproveUserRequest	false	"Approval"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/approve [post]
+func (h *AdminHandler) ApproveUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	var req ApproveUserRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	approved, err := h.userUC.Approve(r.Context(), userID, req.Notify)
+	if err != nil {
+		renderApprovalError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, approved)
+}
+
+// RejectUser godoc
+//
+//	@Summary		Reject a pending user
+//	@Description	Turn down a user waiting for approval and delete their account, optionally emailing them the reason
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string				true	"User ID"
+//	@Param			request	body	RejectUserRequest	false	"Rejection"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/reject [post]
+func (h *AdminHandler) RejectUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	var req RejectUserRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	if err := h.userUC.Reject(r.Context(), userID, req.Reason, req.Notify); err != nil {
+		renderApprovalError(w, r, err)
+		return
+	}
+
+	message := "registration rejected"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: registration would be rejected"
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": message,
+	})
+}
+
+func renderApprovalError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, user.ErrNotPending) {
+		common.ErrorResponse(w, r, http.StatusConflict, err)
+		return
+	}
+	common.RespondError(w, r, common.NotFound(err, "user"))
+}
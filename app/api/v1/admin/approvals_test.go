@@ -0,0 +1,119 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/user"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestListPendingUsers(t *testing.T) {
+	jh := newTestJWT()
+	pending := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "p@x.com", Status: entities.UserStatusPending}
+	userUC := &mocks.UserUseCaseMock{
+		ListPendingFunc: func(ctx context.Context, page, pageSize int) ([]entities.User, int64, error) {
+			return []entities.User{pending}, 21, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	w := httptest.NewRecorder()
+	h.ListPendingUsers(w, httptest.NewRequest(http.MethodGet, "/users/pending?page=2", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp UserListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].ID != pending.ID || resp.Page != 2 || resp.TotalPages != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if calls := userUC.ListPendingCalls(); len(calls) != 1 || calls[0].Page != 2 || calls[0].PageSize != 20 {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+}
+
+func TestApproveAndRejectUser(t *testing.T) {
+	jh := newTestJWT()
+	pendingID := uuid.Must(uuid.NewV4())
+	activeID := uuid.Must(uuid.NewV4())
+	review := func(id uuid.UUID) error {
+		switch id {
+		case pendingID:
+			return nil
+		case activeID:
+			return user.ErrNotPending
+		}
+		return domain.ErrNotFound
+	}
+	userUC := &mocks.UserUseCaseMock{
+		ApproveFunc: func(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error) {
+			if err := review(userID); err != nil {
+				return entities.User{}, err
+			}
+			return entities.User{ID: userID, Status: entities.UserStatusActive}, nil
+		},
+		RejectFunc: func(ctx context.Context, userID uuid.UUID, reason string, notify bool) error {
+			return review(userID)
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	call := func(handler http.HandlerFunc, id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/"+id, strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	for _, handler := range []http.HandlerFunc{h.ApproveUser, h.RejectUser} {
+		if w := call(handler, "not-a-uuid", ""); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+		if w := call(handler, pendingID.String(), "{"); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for a bad body, got %d", w.Code)
+		}
+		if w := call(handler, uuid.Must(uuid.NewV4()).String(), ""); w.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", w.Code)
+		}
+		if w := call(handler, activeID.String(), ""); w.Code != http.StatusConflict {
+			t.Fatalf("expected 409 for a user who isn't pending, got %d", w.Code)
+		}
+	}
+
+	w := call(h.ApproveUser, pendingID.String(), `{"notify":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	calls := userUC.ApproveCalls()
+	if last := calls[len(calls)-1]; last.UserID != pendingID || !last.Notify {
+		t.Fatalf("unexpected approval: %+v", last)
+	}
+
+	if w := call(h.RejectUser, pendingID.String(), `{"reason":"`+strings.Repeat("x", 501)+`"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a long reason, got %d", w.Code)
+	}
+	w = call(h.RejectUser, pendingID.String(), `{"reason":"spam","notify":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	rejects := userUC.RejectCalls()
+	if last := rejects[len(rejects)-1]; last.UserID != pendingID || last.Reason != "spam" || !last.Notify {
+		t.Fatalf("unexpected rejection: %+v", last)
+	}
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type BulkUsersRequest struct {
+	Action      entities.BulkUserAction `json:"action" validate:"required,oneof=delete change_account_type suspend"`
+	UserIDs     []uuid.UUID             `json:"user_ids" validate:"required,min=1,max=100"`
+	AccountType entities.AccountType    `json:"account_type"`
+	Reason      string                  `json:"reason" validate:"max=500"`
+}
+
+// BulkUsersResponse has a result per user, in the order of the request.
+type BulkUsersResponse struct {
+	Results   []entities.BulkUserResult `json:"results"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+}
+
+// BulkUsers godoc
+//
+//	@Summary		Act on many users at once
+//	@Description	Delete, change the account type of (account_type) or suspend (with an optional reason) up to 100 users. Users the single-user rules keep out, such as the caller's own account, are skipped with an error in their result; the others change in one transaction.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		BulkUsersRequest	true	"Operation"
+//	@Success		200		{object}	BulkUsersResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/users/bulk [post]
+func (h *AdminHandler) BulkUsers(w http.ResponseWriter, r *http.Request) {
+	var req BulkUsersRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	results, err := h.userUC.Bulk(r.Context(), entities.BulkUserOperation{
+		Action:      req.Action,
+		UserIDs:     req.UserIDs,
+		AccountType: req.AccountType,
+		Reason:      req.Reason,
+	}, entities.AccountType(claims.AccountType))
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	resp := BulkUsersResponse{Results: results}
+	for _, result := range results {
+		if result.OK {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestBulkUsers(t *testing.T) {
+	first, second := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	valid := fmt.Sprintf(`{"action":"suspend","user_ids":["%s","%s"],"reason":"spam"}`, first, second)
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "applied", body: valid, wantStatus: http.StatusOK},
+		{name: "bad body", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "unknown action", body: fmt.Sprintf(`{"action":"promote","user_ids":["%s"]}`, first), wantStatus: http.StatusBadRequest},
+		{name: "no users", body: `{"action":"delete","user_ids":[]}`, wantStatus: http.StatusBadRequest},
+		{name: "malformed", body: valid, err: fmt.Errorf("%w: invalid account type", domain.ErrMalformedParameters), wantStatus: http.StatusBadRequest},
+		{name: "forbidden", body: valid, err: domain.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "conflict", body: valid, err: domain.ErrConflict, wantStatus: http.StatusConflict},
+		{name: "failed", body: valid, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOp entities.BulkUserOperation
+			var gotActorType entities.AccountType
+			uc := &mocks.UserUseCaseMock{
+				BulkFunc: func(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error) {
+					gotOp, gotActorType = op, actorType
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return []entities.BulkUserResult{
+						{UserID: first, OK: true},
+						{UserID: second, Error: "user not found"},
+					}, nil
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			req := httptest.NewRequest(http.MethodPost, "/users/bulk", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: entities.AccountTypeAdmin.String()}))
+			w := httptest.NewRecorder()
+			h.BulkUsers(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if gotOp.Action != entities.BulkUserSuspend || len(gotOp.UserIDs) != 2 || gotOp.Reason != "spam" || gotActorType != entities.AccountTypeAdmin {
+				t.Fatalf("unexpected operation %+v by %s", gotOp, gotActorType)
+			}
+			var resp BulkUsersResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Results) != 2 || resp.Succeeded != 1 || resp.Failed != 1 || resp.Results[1].Error != "user not found" {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
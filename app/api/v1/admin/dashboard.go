@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// dashboardStreamInterval is how often the dashboard stream checks the
+	// stats for changes.
+	dashboardStreamInterval = 5 * time.Second
+	// streamHeartbeat is how often an idle stream gets a comment, so proxies
+	// don't close it.
+	streamHeartbeat = 30 * time.Second
+)
+
+// dashboardStats gathers the stats shown on the admin dashboard. Its errors
+// are fit for the response.
+func (h *AdminHandler) dashboardStats(ctx context.Context) (DashboardStatsResponse, error) {
+	userStats, err := h.userUC.GetUserStats(ctx)
+	if err != nil {
+		return DashboardStatsResponse{}, errors.New("failed to get user stats")
+	}
+
+	stats := DashboardStatsResponse{
+		TotalUsers: userStats.TotalUsers,
+		AdminUsers: userStats.AdminUsers + userStats.SuperAdminUsers,
+	}
+
+	if h.sessions != nil {
+		stats.ActiveSessions, err = h.sessions.CountActive(ctx)
+		if err != nil {
+			return DashboardStatsResponse{}, errors.New("failed to get session stats")
+		}
+	}
+	if h.alerts != nil {
+		stats.SystemAlerts, err = h.alerts.CountRecent(ctx)
+		if err != nil {
+			return DashboardStatsResponse{}, errors.New("failed to get alert stats")
+		}
+	}
+	return stats, nil
+}
+
+// StreamDashboardStats godoc
+//
+//	@Summary		Stream dashboard statistics
+//	@Description	Stream the admin dashboard statistics as server-sent events. A "stats" event carrying them as JSON is sent right away and then whenever they change. Stats that can't be gathered are skipped until the next check.
+//	@Tags			admin
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Success		200	{object}	DashboardStatsResponse
+//	@Failure		401	{object}	map[string]string
+//	@Router			/admin/v1/dashboard/stream [get]
+func (h *AdminHandler) StreamDashboardStats(w http.ResponseWriter, r *http.Request) {
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	check := time.NewTicker(h.dashboardStreamInterval)
+	defer check.Stop()
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	var last []byte
+	send := func() {
+		stats, err := h.dashboardStats(r.Context())
+		if err != nil {
+			return
+		}
+		data, err := json.Marshal(stats)
+		if err != nil || string(data) == string(last) {
+			return
+		}
+		last = data
+		fmt.Fprintf(w, "event: stats\ndata: %s\n\n", data)
+	}
+
+	send()
+	for {
+		if err := rc.Flush(); err != nil {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-check.C:
+			send()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+	}
+}
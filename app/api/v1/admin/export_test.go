@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain/entities"
+	"go-template/internal/export"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExportUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantType   string
+	}{
+		{name: "csv by default", query: "search=jane&filter=status:active", wantStatus: http.StatusOK, wantType: "text/csv; charset=utf-8"},
+		{name: "xlsx", query: "format=xlsx", wantStatus: http.StatusOK, wantType: export.XLSX.ContentType()},
+		{name: "unknown format", query: "format=pdf", wantStatus: http.StatusBadRequest},
+		{name: "bad filter", query: "filter=password:x", wantStatus: http.StatusBadRequest},
+		{name: "failed", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter entities.UserFilter
+			uc := &mocks.UserUseCaseMock{
+				ExportUsersFunc: func(ctx context.Context, filter entities.UserFilter, w export.Writer) error {
+					gotFilter = filter
+					if tt.err != nil {
+						return tt.err
+					}
+					if err := w.Write([]string{"id", "email"}); err != nil {
+						return err
+					}
+					return w.Flush()
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			req := httptest.NewRequest(http.MethodGet, "/users/export?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.ExportUsers(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if got := w.Header().Get("Content-Type"); got != tt.wantType {
+				t.Fatalf("expected content type %q, got %q", tt.wantType, got)
+			}
+			if got := w.Header().Get("Content-Disposition"); !strings.HasPrefix(got, "attachment; filename=\"users-") {
+				t.Fatalf("unexpected content disposition %q", got)
+			}
+			if tt.wantType == "text/csv; charset=utf-8" {
+				if w.Body.String() != "id,email\n" || !w.Flushed {
+					t.Fatalf("expected the flushed header row, got %q", w.Body.String())
+				}
+				if gotFilter.Search != "jane" || len(gotFilter.Expr) != 1 {
+					t.Fatalf("unexpected filter %+v", gotFilter)
+				}
+			}
+		})
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"go-template/app/api/middleware"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/internal/export"
 	"go-template/internal/jwt"
+	"go-template/internal/ratelimit"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -15,6 +20,42 @@ import (
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/auth_uc.go . AuthUseCase
 type AuthUseCase interface {
 	Login(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	CompleteTwoFactor(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error)
+	TwoFactorStatus(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error)
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error)
+	EnableTOTP(ctx context.Context, userID uuid.UUID, code, audience string) (auth.AuthResponse, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+	RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error)
+	Impersonate(ctx context.Context, admin jwt.Actor, userID uuid.UUID) (auth.AuthResponse, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/token_revoker.go . TokenRevoker
+type TokenRevoker interface {
+	Revoke(ctx context.Context, claims *jwt.Claims) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	IsUserRevoked(ctx context.Context, claims *jwt.Claims) (bool, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/session_counter.go . SessionCounter
+type SessionCounter interface {
+	CountActive(ctx context.Context) (int64, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/alert_counter.go . AlertCounter
+type AlertCounter interface {
+	CountRecent(ctx context.Context) (int64, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/session_revoker.go . SessionRevoker
+type SessionRevoker interface {
+	RevokeUser(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/login_history.go . LoginHistory
+type LoginHistory interface {
+	List(ctx context.Context, userID uuid.UUID, limit int) ([]entities.LoginEvent, error)
 }
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/user_uc.go . UserUseCase
@@ -23,11 +64,21 @@ type UserUseCase interface {
 
 	// Admin methods
 	CreateUser(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error)
-	ListUsers(ctx context.Context, page, pageSize int) ([]entities.User, int64, error)
-	SearchUsers(ctx context.Context, page, pageSize int, search, accountType string) ([]entities.User, int64, error)
+	ListUsers(ctx context.Context, page, pageSize int, sort entities.UserSort) ([]entities.User, int64, error)
+	SearchUsers(ctx context.Context, page, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error)
+	ListUsersAfter(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error)
 	UpdateUser(ctx context.Context, user entities.User) error
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	UpdateProfile(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error)
+	Suspend(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error)
+	Reactivate(ctx context.Context, userID uuid.UUID) (entities.User, error)
+	ListPending(ctx context.Context, page, pageSize int) ([]entities.User, int64, error)
+	Approve(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error)
+	Reject(ctx context.Context, userID uuid.UUID, reason string, notify bool) error
 	GetUserStats(ctx context.Context) (entities.UserStats, error)
+	ExportUsers(ctx context.Context, filter entities.UserFilter, w export.Writer) error
+	ImportUsers(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error)
+	Bulk(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error)
 }
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/settings_uc.go . SettingsUseCase
@@ -37,12 +88,20 @@ type SettingsUseCase interface {
 }
 
 type AdminHandler struct {
-	authUC     AuthUseCase
-	userUC     UserUseCase
-	settingsUC SettingsUseCase
-	jwtService jwt.Service
-	authMw     *middleware.AuthMiddleware
-	validator  *validator.Validate
+	authUC         AuthUseCase
+	userUC         UserUseCase
+	settingsUC     SettingsUseCase
+	jwtService     jwt.Service
+	authMw         *middleware.AuthMiddleware
+	validator      *validator.Validate
+	revoker        TokenRevoker
+	sessions       SessionCounter
+	alerts         AlertCounter
+	sessionRevoker SessionRevoker
+	logins         LoginHistory
+	loginLimiter   *ratelimit.Limiter
+
+	dashboardStreamInterval time.Duration
 }
 
 func NewAdminHandler(authUC AuthUseCase, userUC UserUseCase, settingsUC SettingsUseCase, jwtService jwt.Service, authMw *middleware.AuthMiddleware) *AdminHandler {
@@ -53,37 +112,114 @@ func NewAdminHandler(authUC AuthUseCase, userUC UserUseCase, settingsUC Settings
 		jwtService: jwtService,
 		authMw:     authMw,
 		validator:  validator.New(),
+
+		dashboardStreamInterval: dashboardStreamInterval,
 	}
 }
 
+// SetTokenRevoker makes logout revoke the presented token and verify reject
+// revoked ones.
+func (h *AdminHandler) SetTokenRevoker(revoker TokenRevoker) {
+	h.revoker = revoker
+}
+
+// SetSessionCounter makes the dashboard report active sessions.
+func (h *AdminHandler) SetSessionCounter(counter SessionCounter) {
+	h.sessions = counter
+}
+
+// SetAlertCounter makes the dashboard report the alerts raised recently.
+func (h *AdminHandler) SetAlertCounter(counter AlertCounter) {
+	h.alerts = counter
+}
+
+// SetSessionRevoker enables POST /users/{id}/revoke-sessions, which forces a
+// user out of every session.
+func (h *AdminHandler) SetSessionRevoker(revoker SessionRevoker) {
+	h.sessionRevoker = revoker
+}
+
+// SetLoginHistory enables GET /users/{id}/logins, the user's latest login
+// attempts.
+func (h *AdminHandler) SetLoginHistory(logins LoginHistory) {
+	h.logins = logins
+}
+
+// SetLoginLimiter rate limits login attempts per client IP and account.
+func (h *AdminHandler) SetLoginLimiter(l *ratelimit.Limiter) {
+	h.loginLimiter = l
+}
+
 func (h *AdminHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	// Admin authentication endpoints (public)
-	r.Post("/login", h.AdminLogin)
-	r.Post("/logout", h.AdminLogout)
+	login := r.With()
+	if h.loginLimiter != nil {
+		login = r.With(h.loginLimiter.Middleware("admin_login"))
+	}
+	// Admins can still log in and out while the database refuses writes
+	login.Method(http.MethodPost, "/login", middleware.WritableInReadOnly(h.AdminLogin))
+	r.Method(http.MethodPost, "/logout", middleware.WritableInReadOnly(h.AdminLogout))
 	r.Get("/verify", h.VerifyAdminToken)
+	r.Post("/2fa/challenge", h.TwoFactorChallenge)
+
+	// Two-factor setup has to work before the admin has a second factor
+	r.Group(func(r chi.Router) {
+		r.Use(h.authMw.RequireAdminWithout2FA)
+		r.Get("/2fa", h.TwoFactorStatus)
+		r.Post("/2fa/enroll", h.EnrollTOTP)
+		r.Post("/2fa/enable", h.EnableTOTP)
+	})
 
 	// Protected admin endpoints
 	r.Group(func(r chi.Router) {
 		r.Use(h.authMw.RequireAdmin)
+		r.Use(middleware.DryRun)
 
 		// Dashboard stats
-		r.Get("/dashboard/stats", h.GetDashboardStats)
+		r.With(h.authMw.RequireAdminPermission(entities.PermissionDashboardRead)).Get("/dashboard/stats", h.GetDashboardStats)
+		r.With(h.authMw.RequireAdminPermission(entities.PermissionDashboardRead)).Get("/dashboard/stream", h.StreamDashboardStats)
+
+		r.Post("/2fa/disable", h.DisableTOTP)
+		r.Get("/2fa/recovery-codes", h.RecoveryCodes)
+		r.Post("/2fa/recovery-codes", h.RegenerateRecoveryCodes)
 
 		// User management (all admins - validation handled in handlers)
 		r.Route("/users", func(r chi.Router) {
-			r.Get("/", h.ListUsers)
-			r.Get("/{id}", h.GetUser)
-			r.Put("/{id}", h.UpdateUser)
-			r.Post("/", h.CreateUser)
-			r.Delete("/{id}", h.DeleteUser)
-			r.Get("/stats", h.GetUserStats)
+			read := h.authMw.RequireAdminPermission(entities.PermissionUsersRead)
+			write := h.authMw.RequireAdminPermission(entities.PermissionUsersWrite)
+
+			r.With(read).Get("/", h.ListUsers)
+			r.With(read).Get("/pending", h.ListPendingUsers)
+			r.With(read).Get("/export", h.ExportUsers)
+			r.With(read).Get("/{id}", h.GetUser)
+			r.With(write).Put("/{id}", h.UpdateUser)
+			r.With(write).Put("/{id}/profile", h.UpdateUserProfile)
+			r.With(write).Post("/", h.CreateUser)
+			r.With(write).Post("/bulk", h.BulkUsers)
+			r.With(write).Post("/import", h.ImportUsers)
+			r.With(write).Delete("/{id}", h.DeleteUser)
+			if h.sessionRevoker != nil {
+				r.With(write).Post("/{id}/revoke-sessions", h.RevokeUserSessions)
+			}
+			if h.logins != nil {
+				r.With(read).Get("/{id}/logins", h.ListUserLogins)
+			}
+			r.With(write).Post("/{id}/suspend", h.SuspendUser)
+			r.With(write).Post("/{id}/reactivate", h.ReactivateUser)
+			r.With(write).Post("/{id}/approve", h.ApproveUser)
+			r.With(write).Post("/{id}/reject", h.RejectUser)
+			r.With(read).Get("/stats", h.GetUserStats)
+			r.With(h.authMw.RequireAdminPermission(entities.PermissionUsersImpersonate)).Post("/{id}/impersonate", h.ImpersonateUser)
 		})
 
 		// System settings (admin read-only)
-		r.Get("/settings", h.GetSettings)
-		r.Get("/settings/auth-providers", h.GetAvailableAuthProviders)
+		r.Group(func(r chi.Router) {
+			r.Use(h.authMw.RequireAdminPermission(entities.PermissionSettingsRead))
+			r.Get("/settings", h.GetSettings)
+			r.Get("/settings/auth-providers", h.GetAvailableAuthProviders)
+		})
 
 		// System settings (super admin only)
 		r.Group(func(r chi.Router) {
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// maxImportSize is the largest CSV file ImportUsers accepts, form included.
+const maxImportSize = 5 << 20
+
+// UserImportResponse has a result per row of the file, in its order.
+type UserImportResponse struct {
+	Results []entities.UserImportResult `json:"results"`
+	Created int                         `json:"created"`
+	Failed  int                         `json:"failed"`
+}
+
+// ImportUsers godoc
+//
+//	@Summary		Import users from CSV
+//	@Description	Create up to 1000 users from a CSV file of up to 5 MiB, sent as the "file" field of a multipart form. The first row names the columns: email and password are required, account_type (default user) and auth_provider (default provider) are optional. Rows with an invalid or repeated email, an existing user, an account type the caller may not create or no password are skipped, with an error in their result. With X-Dry-Run, rows are only checked.
+//	@Tags			admin
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			file	formData	file	true	"CSV file"
+//	@Param			X-Dry-Run	header	bool	false	"Check the rows without creating users"
+//	@Success		200	{object}	UserImportResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		413	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/import [post]
+func (h *AdminHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, map[string]string{
+				"error": "file is too large",
+			})
+			return
+		}
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "file is required",
+		})
+		return
+	}
+	defer file.Close()
+
+	results, err := h.userUC.ImportUsers(r.Context(), file, entities.AccountType(claims.AccountType))
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	resp := UserImportResponse{Results: results}
+	for _, result := range results {
+		if result.OK {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
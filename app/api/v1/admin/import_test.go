@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestImportUsers(t *testing.T) {
+	const csvFile = "email,password\na@x.com,secret\nbad,secret\n"
+
+	tests := []struct {
+		name       string
+		file       string
+		noFile     bool
+		err        error
+		wantStatus int
+	}{
+		{name: "imported", file: csvFile, wantStatus: http.StatusOK},
+		{name: "no file", noFile: true, wantStatus: http.StatusBadRequest},
+		{name: "too large", file: strings.Repeat("x", maxImportSize), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "malformed", file: csvFile, err: fmt.Errorf("%w: missing column", domain.ErrMalformedParameters), wantStatus: http.StatusBadRequest},
+		{name: "failed", file: csvFile, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFile string
+			var gotActorType entities.AccountType
+			uc := &mocks.UserUseCaseMock{
+				ImportUsersFunc: func(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error) {
+					body, _ := io.ReadAll(r)
+					gotFile, gotActorType = string(body), actorType
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					id := uuid.Must(uuid.NewV4())
+					return []entities.UserImportResult{
+						{Row: 2, Email: "a@x.com", UserID: &id, OK: true},
+						{Row: 3, Email: "bad", Error: "invalid email"},
+					}, nil
+				},
+			}
+			jh := newTestJWT()
+			h := NewAdminHandler(&mocks.AuthUseCaseMock{}, uc, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			if !tt.noFile {
+				part, _ := form.CreateFormFile("file", "users.csv")
+				part.Write([]byte(tt.file))
+			}
+			form.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/users/import", &body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+			req = req.WithContext(context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: entities.AccountTypeAdmin.String()}))
+			w := httptest.NewRecorder()
+			h.ImportUsers(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if gotFile != csvFile || gotActorType != entities.AccountTypeAdmin {
+				t.Fatalf("unexpected import of %q by %s", gotFile, gotActorType)
+			}
+			var resp UserImportResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Results) != 2 || resp.Created != 1 || resp.Failed != 1 || resp.Results[1].Error != "invalid email" {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
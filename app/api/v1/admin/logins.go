@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"go-template/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListUserLogins godoc
+//
+//	@Summary		List a user's login history
+//	@Description	Return the user's latest login attempts, successful or not, newest first. Attempts waiting on a two-factor challenge show once it is answered.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"User ID"
+//	@Param			limit	query		int		false	"Attempts to return (default 50, max 200)"
+//	@Success		200		{array}		entities.LoginEvent
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/logins [get]
+func (h *AdminHandler) ListUserLogins(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid limit",
+			})
+			return
+		}
+	}
+
+	events, err := h.logins.List(r.Context(), userID, limit)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list login events", "user_id", userID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list login events",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, events)
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestListUserLogins(t *testing.T) {
+	jh := newTestJWT()
+	targetID := uuid.Must(uuid.NewV4())
+	logins := &mocks.LoginHistoryMock{
+		ListFunc: func(ctx context.Context, userID uuid.UUID, limit int) ([]entities.LoginEvent, error) {
+			return []entities.LoginEvent{
+				{ID: 2, UserID: userID, Success: true, Provider: "local"},
+				{ID: 1, UserID: userID, Provider: "local", Reason: entities.LoginReasonInvalidCredentials},
+			}, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+	h.SetLoginHistory(logins)
+
+	call := func(id, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+id+"/logins"+query, nil)
+		ctx := context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: entities.AccountTypeAdmin.String()})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.ListUserLogins(w, req)
+		return w
+	}
+
+	if w := call("not-a-uuid", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w := call(targetID.String(), "?limit=many"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad limit, got %d", w.Code)
+	}
+
+	w := call(targetID.String(), "?limit=10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var events []entities.LoginEvent
+	if err := json.NewDecoder(w.Body).Decode(&events); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(events) != 2 || !events[0].Success || events[1].Reason != entities.LoginReasonInvalidCredentials {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	calls := logins.ListCalls()
+	if last := calls[len(calls)-1]; last.UserID != targetID || last.Limit != 10 {
+		t.Fatalf("unexpected call: %+v", last)
+	}
+}
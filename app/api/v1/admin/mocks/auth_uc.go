@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"github.com/gofrs/uuid/v5"
 	"go-template/domain/auth"
+	"go-template/internal/jwt"
 	"sync"
 )
 
@@ -15,9 +17,36 @@ import (
 //
 //		// make and configure a mocked admin.AuthUseCase
 //		mockedAuthUseCase := &AuthUseCaseMock{
+//			CompleteTwoFactorFunc: func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+//				panic("mock out the CompleteTwoFactor method")
+//			},
+//			DisableTOTPFunc: func(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error {
+//				panic("mock out the DisableTOTP method")
+//			},
+//			EnableTOTPFunc: func(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error) {
+//				panic("mock out the EnableTOTP method")
+//			},
+//			EnrollTOTPFunc: func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error) {
+//				panic("mock out the EnrollTOTP method")
+//			},
+//			ImpersonateFunc: func(ctx context.Context, admin jwt.Actor, userID uuid.UUID) (auth.AuthResponse, error) {
+//				panic("mock out the Impersonate method")
+//			},
 //			LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
 //				panic("mock out the Login method")
 //			},
+//			LogoutFunc: func(ctx context.Context, refreshToken string) error {
+//				panic("mock out the Logout method")
+//			},
+//			RecoveryCodesRemainingFunc: func(ctx context.Context, userID uuid.UUID) (int, error) {
+//				panic("mock out the RecoveryCodesRemaining method")
+//			},
+//			RegenerateRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error) {
+//				panic("mock out the RegenerateRecoveryCodes method")
+//			},
+//			TwoFactorStatusFunc: func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error) {
+//				panic("mock out the TwoFactorStatus method")
+//			},
 //		}
 //
 //		// use mockedAuthUseCase in code that requires admin.AuthUseCase
@@ -25,11 +54,83 @@ import (
 //
 //	}
 type AuthUseCaseMock struct {
+	// CompleteTwoFactorFunc mocks the CompleteTwoFactor method.
+	CompleteTwoFactorFunc func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error)
+
+	// DisableTOTPFunc mocks the DisableTOTP method.
+	DisableTOTPFunc func(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error
+
+	// EnableTOTPFunc mocks the EnableTOTP method.
+	EnableTOTPFunc func(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error)
+
+	// EnrollTOTPFunc mocks the EnrollTOTP method.
+	EnrollTOTPFunc func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error)
+
+	// ImpersonateFunc mocks the Impersonate method.
+	ImpersonateFunc func(ctx context.Context, admin jwt.Actor, userID uuid.UUID) (auth.AuthResponse, error)
+
 	// LoginFunc mocks the Login method.
 	LoginFunc func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error)
 
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, refreshToken string) error
+
+	// RecoveryCodesRemainingFunc mocks the RecoveryCodesRemaining method.
+	RecoveryCodesRemainingFunc func(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// RegenerateRecoveryCodesFunc mocks the RegenerateRecoveryCodes method.
+	RegenerateRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error)
+
+	// TwoFactorStatusFunc mocks the TwoFactorStatus method.
+	TwoFactorStatusFunc func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CompleteTwoFactor holds details about calls to the CompleteTwoFactor method.
+		CompleteTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req auth.TwoFactorChallengeRequest
+		}
+		// DisableTOTP holds details about calls to the DisableTOTP method.
+		DisableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
+		}
+		// EnableTOTP holds details about calls to the EnableTOTP method.
+		EnableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+			// Audience is the audience argument value.
+			Audience string
+		}
+		// EnrollTOTP holds details about calls to the EnrollTOTP method.
+		EnrollTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Impersonate holds details about calls to the Impersonate method.
+		Impersonate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Admin is the admin argument value.
+			Admin jwt.Actor
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
 			// Ctx is the ctx argument value.
@@ -37,8 +138,266 @@ type AuthUseCaseMock struct {
 			// Req is the req argument value.
 			Req auth.LoginRequest
 		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// RecoveryCodesRemaining holds details about calls to the RecoveryCodesRemaining method.
+		RecoveryCodesRemaining []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// RegenerateRecoveryCodes holds details about calls to the RegenerateRecoveryCodes method.
+		RegenerateRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+		}
+		// TwoFactorStatus holds details about calls to the TwoFactorStatus method.
+		TwoFactorStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+	}
+	lockCompleteTwoFactor       sync.RWMutex
+	lockDisableTOTP             sync.RWMutex
+	lockEnableTOTP              sync.RWMutex
+	lockEnrollTOTP              sync.RWMutex
+	lockImpersonate             sync.RWMutex
+	lockLogin                   sync.RWMutex
+	lockLogout                  sync.RWMutex
+	lockRecoveryCodesRemaining  sync.RWMutex
+	lockRegenerateRecoveryCodes sync.RWMutex
+	lockTwoFactorStatus         sync.RWMutex
+}
+
+// CompleteTwoFactor calls CompleteTwoFactorFunc.
+func (mock *AuthUseCaseMock) CompleteTwoFactor(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Req auth.TwoFactorChallengeRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCompleteTwoFactor.Lock()
+	mock.calls.CompleteTwoFactor = append(mock.calls.CompleteTwoFactor, callInfo)
+	mock.lockCompleteTwoFactor.Unlock()
+	if mock.CompleteTwoFactorFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.CompleteTwoFactorFunc(ctx, req)
+}
+
+// CompleteTwoFactorCalls gets all the calls that were made to CompleteTwoFactor.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.CompleteTwoFactorCalls())
+func (mock *AuthUseCaseMock) CompleteTwoFactorCalls() []struct {
+	Ctx context.Context
+	Req auth.TwoFactorChallengeRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req auth.TwoFactorChallengeRequest
+	}
+	mock.lockCompleteTwoFactor.RLock()
+	calls = mock.calls.CompleteTwoFactor
+	mock.lockCompleteTwoFactor.RUnlock()
+	return calls
+}
+
+// DisableTOTP calls DisableTOTPFunc.
+func (mock *AuthUseCaseMock) DisableTOTP(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error {
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockDisableTOTP.Lock()
+	mock.calls.DisableTOTP = append(mock.calls.DisableTOTP, callInfo)
+	mock.lockDisableTOTP.Unlock()
+	if mock.DisableTOTPFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DisableTOTPFunc(ctx, userID, code, recoveryCode)
+}
+
+// DisableTOTPCalls gets all the calls that were made to DisableTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.DisableTOTPCalls())
+func (mock *AuthUseCaseMock) DisableTOTPCalls() []struct {
+	Ctx          context.Context
+	UserID       uuid.UUID
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockDisableTOTP.RLock()
+	calls = mock.calls.DisableTOTP
+	mock.lockDisableTOTP.RUnlock()
+	return calls
+}
+
+// EnableTOTP calls EnableTOTPFunc.
+func (mock *AuthUseCaseMock) EnableTOTP(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Code     string
+		Audience string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Code:     code,
+		Audience: audience,
+	}
+	mock.lockEnableTOTP.Lock()
+	mock.calls.EnableTOTP = append(mock.calls.EnableTOTP, callInfo)
+	mock.lockEnableTOTP.Unlock()
+	if mock.EnableTOTPFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.EnableTOTPFunc(ctx, userID, code, audience)
+}
+
+// EnableTOTPCalls gets all the calls that were made to EnableTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.EnableTOTPCalls())
+func (mock *AuthUseCaseMock) EnableTOTPCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Code     string
+	Audience string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Code     string
+		Audience string
+	}
+	mock.lockEnableTOTP.RLock()
+	calls = mock.calls.EnableTOTP
+	mock.lockEnableTOTP.RUnlock()
+	return calls
+}
+
+// EnrollTOTP calls EnrollTOTPFunc.
+func (mock *AuthUseCaseMock) EnrollTOTP(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockEnrollTOTP.Lock()
+	mock.calls.EnrollTOTP = append(mock.calls.EnrollTOTP, callInfo)
+	mock.lockEnrollTOTP.Unlock()
+	if mock.EnrollTOTPFunc == nil {
+		var (
+			twoFactorEnrollmentOut auth.TwoFactorEnrollment
+			errOut                 error
+		)
+		return twoFactorEnrollmentOut, errOut
+	}
+	return mock.EnrollTOTPFunc(ctx, userID)
+}
+
+// EnrollTOTPCalls gets all the calls that were made to EnrollTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.EnrollTOTPCalls())
+func (mock *AuthUseCaseMock) EnrollTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
 	}
-	lockLogin sync.RWMutex
+	mock.lockEnrollTOTP.RLock()
+	calls = mock.calls.EnrollTOTP
+	mock.lockEnrollTOTP.RUnlock()
+	return calls
+}
+
+// Impersonate calls ImpersonateFunc.
+func (mock *AuthUseCaseMock) Impersonate(ctx context.Context, admin jwt.Actor, userID uuid.UUID) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Admin  jwt.Actor
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		Admin:  admin,
+		UserID: userID,
+	}
+	mock.lockImpersonate.Lock()
+	mock.calls.Impersonate = append(mock.calls.Impersonate, callInfo)
+	mock.lockImpersonate.Unlock()
+	if mock.ImpersonateFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.ImpersonateFunc(ctx, admin, userID)
+}
+
+// ImpersonateCalls gets all the calls that were made to Impersonate.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.ImpersonateCalls())
+func (mock *AuthUseCaseMock) ImpersonateCalls() []struct {
+	Ctx    context.Context
+	Admin  jwt.Actor
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Admin  jwt.Actor
+		UserID uuid.UUID
+	}
+	mock.lockImpersonate.RLock()
+	calls = mock.calls.Impersonate
+	mock.lockImpersonate.RUnlock()
+	return calls
 }
 
 // Login calls LoginFunc.
@@ -80,3 +439,166 @@ func (mock *AuthUseCaseMock) LoginCalls() []struct {
 	mock.lockLogin.RUnlock()
 	return calls
 }
+
+// Logout calls LogoutFunc.
+func (mock *AuthUseCaseMock) Logout(ctx context.Context, refreshToken string) error {
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	if mock.LogoutFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.LogoutFunc(ctx, refreshToken)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.LogoutCalls())
+func (mock *AuthUseCaseMock) LogoutCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// RecoveryCodesRemaining calls RecoveryCodesRemainingFunc.
+func (mock *AuthUseCaseMock) RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRecoveryCodesRemaining.Lock()
+	mock.calls.RecoveryCodesRemaining = append(mock.calls.RecoveryCodesRemaining, callInfo)
+	mock.lockRecoveryCodesRemaining.Unlock()
+	if mock.RecoveryCodesRemainingFunc == nil {
+		var (
+			nOut   int
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.RecoveryCodesRemainingFunc(ctx, userID)
+}
+
+// RecoveryCodesRemainingCalls gets all the calls that were made to RecoveryCodesRemaining.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RecoveryCodesRemainingCalls())
+func (mock *AuthUseCaseMock) RecoveryCodesRemainingCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRecoveryCodesRemaining.RLock()
+	calls = mock.calls.RecoveryCodesRemaining
+	mock.lockRecoveryCodesRemaining.RUnlock()
+	return calls
+}
+
+// RegenerateRecoveryCodes calls RegenerateRecoveryCodesFunc.
+func (mock *AuthUseCaseMock) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockRegenerateRecoveryCodes.Lock()
+	mock.calls.RegenerateRecoveryCodes = append(mock.calls.RegenerateRecoveryCodes, callInfo)
+	mock.lockRegenerateRecoveryCodes.Unlock()
+	if mock.RegenerateRecoveryCodesFunc == nil {
+		var (
+			twoFactorRecoveryCodesOut auth.TwoFactorRecoveryCodes
+			errOut                    error
+		)
+		return twoFactorRecoveryCodesOut, errOut
+	}
+	return mock.RegenerateRecoveryCodesFunc(ctx, userID, code)
+}
+
+// RegenerateRecoveryCodesCalls gets all the calls that were made to RegenerateRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RegenerateRecoveryCodesCalls())
+func (mock *AuthUseCaseMock) RegenerateRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}
+	mock.lockRegenerateRecoveryCodes.RLock()
+	calls = mock.calls.RegenerateRecoveryCodes
+	mock.lockRegenerateRecoveryCodes.RUnlock()
+	return calls
+}
+
+// TwoFactorStatus calls TwoFactorStatusFunc.
+func (mock *AuthUseCaseMock) TwoFactorStatus(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockTwoFactorStatus.Lock()
+	mock.calls.TwoFactorStatus = append(mock.calls.TwoFactorStatus, callInfo)
+	mock.lockTwoFactorStatus.Unlock()
+	if mock.TwoFactorStatusFunc == nil {
+		var (
+			twoFactorStatusOut auth.TwoFactorStatus
+			errOut             error
+		)
+		return twoFactorStatusOut, errOut
+	}
+	return mock.TwoFactorStatusFunc(ctx, userID)
+}
+
+// TwoFactorStatusCalls gets all the calls that were made to TwoFactorStatus.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.TwoFactorStatusCalls())
+func (mock *AuthUseCaseMock) TwoFactorStatusCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockTwoFactorStatus.RLock()
+	calls = mock.calls.TwoFactorStatus
+	mock.lockTwoFactorStatus.RUnlock()
+	return calls
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"github.com/gofrs/uuid/v5"
 	"go-template/domain/entities"
+	"go-template/internal/export"
+	"io"
 	"sync"
 )
 
@@ -16,24 +18,54 @@ import (
 //
 //		// make and configure a mocked admin.UserUseCase
 //		mockedUserUseCase := &UserUseCaseMock{
+//			ApproveFunc: func(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error) {
+//				panic("mock out the Approve method")
+//			},
+//			BulkFunc: func(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error) {
+//				panic("mock out the Bulk method")
+//			},
 //			CreateUserFunc: func(ctx context.Context, email string, password string, authProvider string, accountType entities.AccountType) (entities.User, error) {
 //				panic("mock out the CreateUser method")
 //			},
 //			DeleteUserFunc: func(ctx context.Context, userID uuid.UUID) error {
 //				panic("mock out the DeleteUser method")
 //			},
+//			ExportUsersFunc: func(ctx context.Context, filter entities.UserFilter, w export.Writer) error {
+//				panic("mock out the ExportUsers method")
+//			},
 //			GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
 //				panic("mock out the GetUserByID method")
 //			},
 //			GetUserStatsFunc: func(ctx context.Context) (entities.UserStats, error) {
 //				panic("mock out the GetUserStats method")
 //			},
-//			ListUsersFunc: func(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error) {
+//			ImportUsersFunc: func(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error) {
+//				panic("mock out the ImportUsers method")
+//			},
+//			ListPendingFunc: func(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error) {
+//				panic("mock out the ListPending method")
+//			},
+//			ListUsersFunc: func(ctx context.Context, page int, pageSize int, sort entities.UserSort) ([]entities.User, int64, error) {
 //				panic("mock out the ListUsers method")
 //			},
-//			SearchUsersFunc: func(ctx context.Context, page int, pageSize int, search string, accountType string) ([]entities.User, int64, error) {
+//			ListUsersAfterFunc: func(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error) {
+//				panic("mock out the ListUsersAfter method")
+//			},
+//			ReactivateFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+//				panic("mock out the Reactivate method")
+//			},
+//			RejectFunc: func(ctx context.Context, userID uuid.UUID, reason string, notify bool) error {
+//				panic("mock out the Reject method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, page int, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error) {
 //				panic("mock out the SearchUsers method")
 //			},
+//			SuspendFunc: func(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error) {
+//				panic("mock out the Suspend method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+//				panic("mock out the UpdateProfile method")
+//			},
 //			UpdateUserFunc: func(ctx context.Context, user entities.User) error {
 //				panic("mock out the UpdateUser method")
 //			},
@@ -44,29 +76,77 @@ import (
 //
 //	}
 type UserUseCaseMock struct {
+	// ApproveFunc mocks the Approve method.
+	ApproveFunc func(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error)
+
+	// BulkFunc mocks the Bulk method.
+	BulkFunc func(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error)
+
 	// CreateUserFunc mocks the CreateUser method.
 	CreateUserFunc func(ctx context.Context, email string, password string, authProvider string, accountType entities.AccountType) (entities.User, error)
 
 	// DeleteUserFunc mocks the DeleteUser method.
 	DeleteUserFunc func(ctx context.Context, userID uuid.UUID) error
 
+	// ExportUsersFunc mocks the ExportUsers method.
+	ExportUsersFunc func(ctx context.Context, filter entities.UserFilter, w export.Writer) error
+
 	// GetUserByIDFunc mocks the GetUserByID method.
 	GetUserByIDFunc func(ctx context.Context, id uuid.UUID) (entities.User, error)
 
 	// GetUserStatsFunc mocks the GetUserStats method.
 	GetUserStatsFunc func(ctx context.Context) (entities.UserStats, error)
 
+	// ImportUsersFunc mocks the ImportUsers method.
+	ImportUsersFunc func(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error)
+
+	// ListPendingFunc mocks the ListPending method.
+	ListPendingFunc func(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error)
+
 	// ListUsersFunc mocks the ListUsers method.
-	ListUsersFunc func(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error)
+	ListUsersFunc func(ctx context.Context, page int, pageSize int, sort entities.UserSort) ([]entities.User, int64, error)
+
+	// ListUsersAfterFunc mocks the ListUsersAfter method.
+	ListUsersAfterFunc func(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error)
+
+	// ReactivateFunc mocks the Reactivate method.
+	ReactivateFunc func(ctx context.Context, userID uuid.UUID) (entities.User, error)
+
+	// RejectFunc mocks the Reject method.
+	RejectFunc func(ctx context.Context, userID uuid.UUID, reason string, notify bool) error
 
 	// SearchUsersFunc mocks the SearchUsers method.
-	SearchUsersFunc func(ctx context.Context, page int, pageSize int, search string, accountType string) ([]entities.User, int64, error)
+	SearchUsersFunc func(ctx context.Context, page int, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error)
+
+	// SuspendFunc mocks the Suspend method.
+	SuspendFunc func(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error)
 
 	// UpdateUserFunc mocks the UpdateUser method.
 	UpdateUserFunc func(ctx context.Context, user entities.User) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Approve holds details about calls to the Approve method.
+		Approve []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Notify is the notify argument value.
+			Notify bool
+		}
+		// Bulk holds details about calls to the Bulk method.
+		Bulk []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Op is the op argument value.
+			Op entities.BulkUserOperation
+			// ActorType is the actorType argument value.
+			ActorType entities.AccountType
+		}
 		// CreateUser holds details about calls to the CreateUser method.
 		CreateUser []struct {
 			// Ctx is the ctx argument value.
@@ -87,6 +167,15 @@ type UserUseCaseMock struct {
 			// UserID is the userID argument value.
 			UserID uuid.UUID
 		}
+		// ExportUsers holds details about calls to the ExportUsers method.
+		ExportUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+			// W is the w argument value.
+			W export.Writer
+		}
 		// GetUserByID holds details about calls to the GetUserByID method.
 		GetUserByID []struct {
 			// Ctx is the ctx argument value.
@@ -99,6 +188,24 @@ type UserUseCaseMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// ImportUsers holds details about calls to the ImportUsers method.
+		ImportUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// R is the r argument value.
+			R io.Reader
+			// ActorType is the actorType argument value.
+			ActorType entities.AccountType
+		}
+		// ListPending holds details about calls to the ListPending method.
+		ListPending []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// PageSize is the pageSize argument value.
+			PageSize int
+		}
 		// ListUsers holds details about calls to the ListUsers method.
 		ListUsers []struct {
 			// Ctx is the ctx argument value.
@@ -107,6 +214,37 @@ type UserUseCaseMock struct {
 			Page int
 			// PageSize is the pageSize argument value.
 			PageSize int
+			// Sort is the sort argument value.
+			Sort entities.UserSort
+		}
+		// ListUsersAfter holds details about calls to the ListUsersAfter method.
+		ListUsersAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cursor is the cursor argument value.
+			Cursor string
+			// PageSize is the pageSize argument value.
+			PageSize int
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+		}
+		// Reactivate holds details about calls to the Reactivate method.
+		Reactivate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Reject holds details about calls to the Reject method.
+		Reject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Reason is the reason argument value.
+			Reason string
+			// Notify is the notify argument value.
+			Notify bool
 		}
 		// SearchUsers holds details about calls to the SearchUsers method.
 		SearchUsers []struct {
@@ -116,10 +254,28 @@ type UserUseCaseMock struct {
 			Page int
 			// PageSize is the pageSize argument value.
 			PageSize int
-			// Search is the search argument value.
-			Search string
-			// AccountType is the accountType argument value.
-			AccountType string
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+			// Sort is the sort argument value.
+			Sort entities.UserSort
+		}
+		// Suspend holds details about calls to the Suspend method.
+		Suspend []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Reason is the reason argument value.
+			Reason string
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Profile is the profile argument value.
+			Profile entities.UserProfile
 		}
 		// UpdateUser holds details about calls to the UpdateUser method.
 		UpdateUser []struct {
@@ -129,13 +285,111 @@ type UserUseCaseMock struct {
 			User entities.User
 		}
 	}
-	lockCreateUser   sync.RWMutex
-	lockDeleteUser   sync.RWMutex
-	lockGetUserByID  sync.RWMutex
-	lockGetUserStats sync.RWMutex
-	lockListUsers    sync.RWMutex
-	lockSearchUsers  sync.RWMutex
-	lockUpdateUser   sync.RWMutex
+	lockApprove        sync.RWMutex
+	lockBulk           sync.RWMutex
+	lockCreateUser     sync.RWMutex
+	lockDeleteUser     sync.RWMutex
+	lockExportUsers    sync.RWMutex
+	lockGetUserByID    sync.RWMutex
+	lockGetUserStats   sync.RWMutex
+	lockImportUsers    sync.RWMutex
+	lockListPending    sync.RWMutex
+	lockListUsers      sync.RWMutex
+	lockListUsersAfter sync.RWMutex
+	lockReactivate     sync.RWMutex
+	lockReject         sync.RWMutex
+	lockSearchUsers    sync.RWMutex
+	lockSuspend        sync.RWMutex
+	lockUpdateProfile  sync.RWMutex
+	lockUpdateUser     sync.RWMutex
+}
+
+// Approve calls ApproveFunc.
+func (mock *UserUseCaseMock) Approve(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Notify bool
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Notify: notify,
+	}
+	mock.lockApprove.Lock()
+	mock.calls.Approve = append(mock.calls.Approve, callInfo)
+	mock.lockApprove.Unlock()
+	if mock.ApproveFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.ApproveFunc(ctx, userID, notify)
+}
+
+// ApproveCalls gets all the calls that were made to Approve.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ApproveCalls())
+func (mock *UserUseCaseMock) ApproveCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Notify bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Notify bool
+	}
+	mock.lockApprove.RLock()
+	calls = mock.calls.Approve
+	mock.lockApprove.RUnlock()
+	return calls
+}
+
+// Bulk calls BulkFunc.
+func (mock *UserUseCaseMock) Bulk(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		Op        entities.BulkUserOperation
+		ActorType entities.AccountType
+	}{
+		Ctx:       ctx,
+		Op:        op,
+		ActorType: actorType,
+	}
+	mock.lockBulk.Lock()
+	mock.calls.Bulk = append(mock.calls.Bulk, callInfo)
+	mock.lockBulk.Unlock()
+	if mock.BulkFunc == nil {
+		var (
+			bulkUserResultsOut []entities.BulkUserResult
+			errOut             error
+		)
+		return bulkUserResultsOut, errOut
+	}
+	return mock.BulkFunc(ctx, op, actorType)
+}
+
+// BulkCalls gets all the calls that were made to Bulk.
+// Check the length with:
+//
+//	len(mockedUserUseCase.BulkCalls())
+func (mock *UserUseCaseMock) BulkCalls() []struct {
+	Ctx       context.Context
+	Op        entities.BulkUserOperation
+	ActorType entities.AccountType
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Op        entities.BulkUserOperation
+		ActorType entities.AccountType
+	}
+	mock.lockBulk.RLock()
+	calls = mock.calls.Bulk
+	mock.lockBulk.RUnlock()
+	return calls
 }
 
 // CreateUser calls CreateUserFunc.
@@ -229,6 +483,49 @@ func (mock *UserUseCaseMock) DeleteUserCalls() []struct {
 	return calls
 }
 
+// ExportUsers calls ExportUsersFunc.
+func (mock *UserUseCaseMock) ExportUsers(ctx context.Context, filter entities.UserFilter, w export.Writer) error {
+	callInfo := struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		W      export.Writer
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		W:      w,
+	}
+	mock.lockExportUsers.Lock()
+	mock.calls.ExportUsers = append(mock.calls.ExportUsers, callInfo)
+	mock.lockExportUsers.Unlock()
+	if mock.ExportUsersFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ExportUsersFunc(ctx, filter, w)
+}
+
+// ExportUsersCalls gets all the calls that were made to ExportUsers.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ExportUsersCalls())
+func (mock *UserUseCaseMock) ExportUsersCalls() []struct {
+	Ctx    context.Context
+	Filter entities.UserFilter
+	W      export.Writer
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		W      export.Writer
+	}
+	mock.lockExportUsers.RLock()
+	calls = mock.calls.ExportUsers
+	mock.lockExportUsers.RUnlock()
+	return calls
+}
+
 // GetUserByID calls GetUserByIDFunc.
 func (mock *UserUseCaseMock) GetUserByID(ctx context.Context, id uuid.UUID) (entities.User, error) {
 	callInfo := struct {
@@ -305,16 +602,107 @@ func (mock *UserUseCaseMock) GetUserStatsCalls() []struct {
 	return calls
 }
 
+// ImportUsers calls ImportUsersFunc.
+func (mock *UserUseCaseMock) ImportUsers(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		R         io.Reader
+		ActorType entities.AccountType
+	}{
+		Ctx:       ctx,
+		R:         r,
+		ActorType: actorType,
+	}
+	mock.lockImportUsers.Lock()
+	mock.calls.ImportUsers = append(mock.calls.ImportUsers, callInfo)
+	mock.lockImportUsers.Unlock()
+	if mock.ImportUsersFunc == nil {
+		var (
+			userImportResultsOut []entities.UserImportResult
+			errOut               error
+		)
+		return userImportResultsOut, errOut
+	}
+	return mock.ImportUsersFunc(ctx, r, actorType)
+}
+
+// ImportUsersCalls gets all the calls that were made to ImportUsers.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ImportUsersCalls())
+func (mock *UserUseCaseMock) ImportUsersCalls() []struct {
+	Ctx       context.Context
+	R         io.Reader
+	ActorType entities.AccountType
+} {
+	var calls []struct {
+		Ctx       context.Context
+		R         io.Reader
+		ActorType entities.AccountType
+	}
+	mock.lockImportUsers.RLock()
+	calls = mock.calls.ImportUsers
+	mock.lockImportUsers.RUnlock()
+	return calls
+}
+
+// ListPending calls ListPendingFunc.
+func (mock *UserUseCaseMock) ListPending(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Page     int
+		PageSize int
+	}{
+		Ctx:      ctx,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	mock.lockListPending.Lock()
+	mock.calls.ListPending = append(mock.calls.ListPending, callInfo)
+	mock.lockListPending.Unlock()
+	if mock.ListPendingFunc == nil {
+		var (
+			usersOut []entities.User
+			nOut     int64
+			errOut   error
+		)
+		return usersOut, nOut, errOut
+	}
+	return mock.ListPendingFunc(ctx, page, pageSize)
+}
+
+// ListPendingCalls gets all the calls that were made to ListPending.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ListPendingCalls())
+func (mock *UserUseCaseMock) ListPendingCalls() []struct {
+	Ctx      context.Context
+	Page     int
+	PageSize int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Page     int
+		PageSize int
+	}
+	mock.lockListPending.RLock()
+	calls = mock.calls.ListPending
+	mock.lockListPending.RUnlock()
+	return calls
+}
+
 // ListUsers calls ListUsersFunc.
-func (mock *UserUseCaseMock) ListUsers(ctx context.Context, page int, pageSize int) ([]entities.User, int64, error) {
+func (mock *UserUseCaseMock) ListUsers(ctx context.Context, page int, pageSize int, sort entities.UserSort) ([]entities.User, int64, error) {
 	callInfo := struct {
 		Ctx      context.Context
 		Page     int
 		PageSize int
+		Sort     entities.UserSort
 	}{
 		Ctx:      ctx,
 		Page:     page,
 		PageSize: pageSize,
+		Sort:     sort,
 	}
 	mock.lockListUsers.Lock()
 	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
@@ -327,7 +715,7 @@ func (mock *UserUseCaseMock) ListUsers(ctx context.Context, page int, pageSize i
 		)
 		return usersOut, nOut, errOut
 	}
-	return mock.ListUsersFunc(ctx, page, pageSize)
+	return mock.ListUsersFunc(ctx, page, pageSize, sort)
 }
 
 // ListUsersCalls gets all the calls that were made to ListUsers.
@@ -338,11 +726,13 @@ func (mock *UserUseCaseMock) ListUsersCalls() []struct {
 	Ctx      context.Context
 	Page     int
 	PageSize int
+	Sort     entities.UserSort
 } {
 	var calls []struct {
 		Ctx      context.Context
 		Page     int
 		PageSize int
+		Sort     entities.UserSort
 	}
 	mock.lockListUsers.RLock()
 	calls = mock.calls.ListUsers
@@ -350,20 +740,156 @@ func (mock *UserUseCaseMock) ListUsersCalls() []struct {
 	return calls
 }
 
+// ListUsersAfter calls ListUsersAfterFunc.
+func (mock *UserUseCaseMock) ListUsersAfter(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Cursor   string
+		PageSize int
+		Filter   entities.UserFilter
+	}{
+		Ctx:      ctx,
+		Cursor:   cursor,
+		PageSize: pageSize,
+		Filter:   filter,
+	}
+	mock.lockListUsersAfter.Lock()
+	mock.calls.ListUsersAfter = append(mock.calls.ListUsersAfter, callInfo)
+	mock.lockListUsersAfter.Unlock()
+	if mock.ListUsersAfterFunc == nil {
+		var (
+			usersOut []entities.User
+			sOut     string
+			errOut   error
+		)
+		return usersOut, sOut, errOut
+	}
+	return mock.ListUsersAfterFunc(ctx, cursor, pageSize, filter)
+}
+
+// ListUsersAfterCalls gets all the calls that were made to ListUsersAfter.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ListUsersAfterCalls())
+func (mock *UserUseCaseMock) ListUsersAfterCalls() []struct {
+	Ctx      context.Context
+	Cursor   string
+	PageSize int
+	Filter   entities.UserFilter
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Cursor   string
+		PageSize int
+		Filter   entities.UserFilter
+	}
+	mock.lockListUsersAfter.RLock()
+	calls = mock.calls.ListUsersAfter
+	mock.lockListUsersAfter.RUnlock()
+	return calls
+}
+
+// Reactivate calls ReactivateFunc.
+func (mock *UserUseCaseMock) Reactivate(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockReactivate.Lock()
+	mock.calls.Reactivate = append(mock.calls.Reactivate, callInfo)
+	mock.lockReactivate.Unlock()
+	if mock.ReactivateFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.ReactivateFunc(ctx, userID)
+}
+
+// ReactivateCalls gets all the calls that were made to Reactivate.
+// Check the length with:
+//
+//	len(mockedUserUseCase.ReactivateCalls())
+func (mock *UserUseCaseMock) ReactivateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockReactivate.RLock()
+	calls = mock.calls.Reactivate
+	mock.lockReactivate.RUnlock()
+	return calls
+}
+
+// Reject calls RejectFunc.
+func (mock *UserUseCaseMock) Reject(ctx context.Context, userID uuid.UUID, reason string, notify bool) error {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reason string
+		Notify bool
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Reason: reason,
+		Notify: notify,
+	}
+	mock.lockReject.Lock()
+	mock.calls.Reject = append(mock.calls.Reject, callInfo)
+	mock.lockReject.Unlock()
+	if mock.RejectFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RejectFunc(ctx, userID, reason, notify)
+}
+
+// RejectCalls gets all the calls that were made to Reject.
+// Check the length with:
+//
+//	len(mockedUserUseCase.RejectCalls())
+func (mock *UserUseCaseMock) RejectCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Reason string
+	Notify bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reason string
+		Notify bool
+	}
+	mock.lockReject.RLock()
+	calls = mock.calls.Reject
+	mock.lockReject.RUnlock()
+	return calls
+}
+
 // SearchUsers calls SearchUsersFunc.
-func (mock *UserUseCaseMock) SearchUsers(ctx context.Context, page int, pageSize int, search string, accountType string) ([]entities.User, int64, error) {
+func (mock *UserUseCaseMock) SearchUsers(ctx context.Context, page int, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error) {
 	callInfo := struct {
-		Ctx         context.Context
-		Page        int
-		PageSize    int
-		Search      string
-		AccountType string
+		Ctx      context.Context
+		Page     int
+		PageSize int
+		Filter   entities.UserFilter
+		Sort     entities.UserSort
 	}{
-		Ctx:         ctx,
-		Page:        page,
-		PageSize:    pageSize,
-		Search:      search,
-		AccountType: accountType,
+		Ctx:      ctx,
+		Page:     page,
+		PageSize: pageSize,
+		Filter:   filter,
+		Sort:     sort,
 	}
 	mock.lockSearchUsers.Lock()
 	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
@@ -376,7 +902,7 @@ func (mock *UserUseCaseMock) SearchUsers(ctx context.Context, page int, pageSize
 		)
 		return usersOut, nOut, errOut
 	}
-	return mock.SearchUsersFunc(ctx, page, pageSize, search, accountType)
+	return mock.SearchUsersFunc(ctx, page, pageSize, filter, sort)
 }
 
 // SearchUsersCalls gets all the calls that were made to SearchUsers.
@@ -384,18 +910,18 @@ func (mock *UserUseCaseMock) SearchUsers(ctx context.Context, page int, pageSize
 //
 //	len(mockedUserUseCase.SearchUsersCalls())
 func (mock *UserUseCaseMock) SearchUsersCalls() []struct {
-	Ctx         context.Context
-	Page        int
-	PageSize    int
-	Search      string
-	AccountType string
+	Ctx      context.Context
+	Page     int
+	PageSize int
+	Filter   entities.UserFilter
+	Sort     entities.UserSort
 } {
 	var calls []struct {
-		Ctx         context.Context
-		Page        int
-		PageSize    int
-		Search      string
-		AccountType string
+		Ctx      context.Context
+		Page     int
+		PageSize int
+		Filter   entities.UserFilter
+		Sort     entities.UserSort
 	}
 	mock.lockSearchUsers.RLock()
 	calls = mock.calls.SearchUsers
@@ -403,6 +929,94 @@ func (mock *UserUseCaseMock) SearchUsersCalls() []struct {
 	return calls
 }
 
+// Suspend calls SuspendFunc.
+func (mock *UserUseCaseMock) Suspend(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reason string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Reason: reason,
+	}
+	mock.lockSuspend.Lock()
+	mock.calls.Suspend = append(mock.calls.Suspend, callInfo)
+	mock.lockSuspend.Unlock()
+	if mock.SuspendFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.SuspendFunc(ctx, userID, reason)
+}
+
+// SuspendCalls gets all the calls that were made to Suspend.
+// Check the length with:
+//
+//	len(mockedUserUseCase.SuspendCalls())
+func (mock *UserUseCaseMock) SuspendCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Reason string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reason string
+	}
+	mock.lockSuspend.RLock()
+	calls = mock.calls.Suspend
+	mock.lockSuspend.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UserUseCaseMock) UpdateProfile(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		Profile entities.UserProfile
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		Profile: profile,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	if mock.UpdateProfileFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.UpdateProfileFunc(ctx, userID, profile)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUserUseCase.UpdateProfileCalls())
+func (mock *UserUseCaseMock) UpdateProfileCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	Profile entities.UserProfile
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		Profile entities.UserProfile
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
 // UpdateUser calls UpdateUserFunc.
 func (mock *UserUseCaseMock) UpdateUser(ctx context.Context, user entities.User) error {
 	callInfo := struct {
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type UpdateUserProfileRequest struct {
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	DisplayName string         `json:"display_name"`
+	Timezone    string         `json:"timezone"`
+	Locale      string         `json:"locale"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// UpdateUserProfile godoc
+//
+//	@Summary		Update a user's profile
+//	@Description	Replace the user's names, timezone (IANA name) and locale (BCP 47 tag). Metadata is kept when left out.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string						true	"User ID"
+//	@Param			request	body	UpdateUserProfileRequest	true	"Profile"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/profile [put]
+func (h *AdminHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	var req UpdateUserProfileRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetUser, err := h.userUC.GetUserByID(r.Context(), userID)
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found",
+		})
+		return
+	}
+
+	// Regular admins can only edit users, as with deletes
+	if entities.AccountType(claims.AccountType) == entities.AccountTypeAdmin && targetUser.AccountType != entities.AccountTypeUser && targetUser.ID.String() != claims.UserID {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, map[string]string{
+			"error": "regular admins can only edit the profile of user accounts",
+		})
+		return
+	}
+
+	user, err := h.userUC.UpdateProfile(r.Context(), userID, entities.UserProfile(req))
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestUpdateUserProfile(t *testing.T) {
+	jh := newTestJWT()
+	targetID := uuid.Must(uuid.NewV4())
+	adminTargetID := uuid.Must(uuid.NewV4())
+	userUC := &mocks.UserUseCaseMock{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			switch id {
+			case targetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeUser}, nil
+			case adminTargetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeAdmin}, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+			if profile.Locale == "??" {
+				return entities.User{}, fmt.Errorf("invalid locale: %w", domain.ErrMalformedParameters)
+			}
+			return entities.User{ID: userID, UserProfile: profile}, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	call := func(id, body string, accountType entities.AccountType) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/users/"+id+"/profile", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: accountType.String()})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.UpdateUserProfile(w, req)
+		return w
+	}
+
+	if w := call("not-a-uuid", `{}`, entities.AccountTypeAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w := call(uuid.Must(uuid.NewV4()).String(), `{}`, entities.AccountTypeAdmin); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := call(adminTargetID.String(), `{}`, entities.AccountTypeAdmin); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for an admin editing another admin, got %d", w.Code)
+	}
+	if w := call(targetID.String(), `{"locale":"??"}`, entities.AccountTypeAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid locale, got %d", w.Code)
+	}
+	if w := call(adminTargetID.String(), `{"display_name":"Ops"}`, entities.AccountTypeSuperAdmin); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// SuspendUser godoc
+//
+//	@Summary		Suspend a user
+//	@Description	Keep the user from signing in and from using the tokens they hold until reactivated. The reason is shown to the user.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string				true	"User ID"
+//	@Param			request	body	SuspendUserRequest	false	"Suspension"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	var req SuspendUserRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	h.setUserStatus(w, r, entities.UserStatusSuspended, req.Reason)
+}
+
+// ReactivateUser godoc
+//
+//	@Summary		Reactivate a user
+//	@Description	Let a suspended or pending user back in
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserStatus(w, r, entities.UserStatusActive, "")
+}
+
+func (h *AdminHandler) setUserStatus(w http.ResponseWriter, r *http.Request, status entities.UserStatus, reason string) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID format",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	targetUser, err := h.userUC.GetUserByID(r.Context(), userID)
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found",
+		})
+		return
+	}
+
+	// Regular admins can only suspend users, as with deletes
+	if entities.AccountType(claims.AccountType) == entities.AccountTypeAdmin && targetUser.AccountType != entities.AccountTypeUser {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, map[string]string{
+			"error": "regular admins can only change the status of user accounts",
+		})
+		return
+	}
+
+	var user entities.User
+	if status == entities.UserStatusSuspended {
+		user, err = h.userUC.Suspend(r.Context(), userID, reason)
+	} else {
+		user, err = h.userUC.Reactivate(r.Context(), userID)
+	}
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
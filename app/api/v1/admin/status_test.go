@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestSuspendAndReactivateUser(t *testing.T) {
+	jh := newTestJWT()
+	targetID := uuid.Must(uuid.NewV4())
+	adminTargetID := uuid.Must(uuid.NewV4())
+	userUC := &mocks.UserUseCaseMock{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			switch id {
+			case targetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeUser}, nil
+			case adminTargetID:
+				return entities.User{ID: id, AccountType: entities.AccountTypeAdmin}, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		SuspendFunc: func(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error) {
+			return entities.User{ID: userID, Status: entities.UserStatusSuspended, SuspendedReason: reason}, nil
+		},
+		ReactivateFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+			return entities.User{ID: userID, Status: entities.UserStatusActive}, nil
+		},
+	}
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, userUC, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	call := func(handler http.HandlerFunc, id, body string, accountType entities.AccountType) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users/"+id+"/suspend", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), apiMiddleware.UserContextKey, &jwt.Claims{UserID: uuid.Must(uuid.NewV4()).String(), AccountType: accountType.String()})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	if w := call(h.SuspendUser, "not-a-uuid", "", entities.AccountTypeAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w := call(h.SuspendUser, targetID.String(), "{", entities.AccountTypeAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad body, got %d", w.Code)
+	}
+	if w := call(h.SuspendUser, uuid.Must(uuid.NewV4()).String(), "", entities.AccountTypeAdmin); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := call(h.SuspendUser, adminTargetID.String(), "", entities.AccountTypeAdmin); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for an admin suspending another admin, got %d", w.Code)
+	}
+	if len(userUC.SuspendCalls()) != 0 {
+		t.Fatalf("expected no suspension, got %d calls", len(userUC.SuspendCalls()))
+	}
+
+	w := call(h.SuspendUser, targetID.String(), `{"reason":"spam"}`, entities.AccountTypeAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var user entities.User
+	if err := json.NewDecoder(w.Body).Decode(&user); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if user.Status != entities.UserStatusSuspended || user.SuspendedReason != "spam" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	// The reason is optional
+	if w := call(h.SuspendUser, targetID.String(), "", entities.AccountTypeAdmin); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without a body, got %d", w.Code)
+	}
+
+	w = call(h.ReactivateUser, adminTargetID.String(), "", entities.AccountTypeSuperAdmin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(userUC.ReactivateCalls()) != 1 || userUC.ReactivateCalls()[0].UserID != adminTargetID {
+		t.Fatalf("expected the admin to be reactivated, got %+v", userUC.ReactivateCalls())
+	}
+}
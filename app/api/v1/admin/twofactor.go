@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/auth"
+	"go-template/internal/jwt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type AdminTwoFactorChallengeRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required_without=RecoveryCode"`
+	// RecoveryCode is used instead of Code when the authenticator app is
+	// not at hand
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+type AdminTwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required_without=RecoveryCode"`
+	// RecoveryCode is accepted instead of Code by /2fa/disable
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+// TwoFactorChallenge godoc
+//
+//	@Summary		Complete an admin two-factor login
+//	@Description	Exchange the mfa_token from an admin login that returned mfa_required and a code from the authenticator app, or a recovery code, for an admin session
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		AdminTwoFactorChallengeRequest	true	"Challenge token and code or recovery code"
+//	@Success		200		{object}	AdminLoginResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/2fa/challenge [post]
+func (h *AdminHandler) TwoFactorChallenge(w http.ResponseWriter, r *http.Request) {
+	var req AdminTwoFactorChallengeRequest
+	if !h.decodeTwoFactorRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.authUC.CompleteTwoFactor(r.Context(), auth.TwoFactorChallengeRequest{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		Audience:     jwt.AudienceAdmin,
+	})
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	h.renderAdminTokens(w, r, response)
+}
+
+// TwoFactorStatus godoc
+//
+//	@Summary		Get admin two-factor status
+//	@Description	Report whether the admin has two-factor authentication enabled and whether the Require2FA setting requires it
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	auth.TwoFactorStatus
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/2fa [get]
+func (h *AdminHandler) TwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	status, err := h.authUC.TwoFactorStatus(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, status)
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Start admin two-factor enrollment
+//	@Description	Create a TOTP secret for the admin. Accepts sessions without a second factor so admins can comply with Require2FA.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	auth.TwoFactorEnrollment
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/2fa/enroll [post]
+func (h *AdminHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	enrollment, err := h.authUC.EnrollTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, enrollment)
+}
+
+// EnableTOTP godoc
+//
+//	@Summary		Enable admin two-factor authentication
+//	@Description	Confirm the enrollment with a code from the authenticator app. Returns a new admin session that passes the Require2FA check.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		AdminTwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	AdminLoginResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/2fa/enable [post]
+func (h *AdminHandler) EnableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	var req AdminTwoFactorCodeRequest
+	if !h.decodeTwoFactorRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.authUC.EnableTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code, jwt.AudienceAdmin)
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	h.renderAdminTokens(w, r, response)
+}
+
+// DisableTOTP godoc
+//
+//	@Summary		Disable admin two-factor authentication
+//	@Description	Remove the admin's second factor after checking a code from the authenticator app or a recovery code. Refused while the Require2FA setting is on.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		AdminTwoFactorCodeRequest	true	"Code from the authenticator app or recovery code"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/2fa/disable [post]
+func (h *AdminHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	var req AdminTwoFactorCodeRequest
+	if !h.decodeTwoFactorRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.DisableTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code, req.RecoveryCode); err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "two-factor authentication disabled",
+	})
+}
+
+// RecoveryCodes godoc
+//
+//	@Summary		Count admin recovery codes
+//	@Description	Report how many unused recovery codes the admin has left
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]int
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/2fa/recovery-codes [get]
+func (h *AdminHandler) RecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	remaining, err := h.authUC.RecoveryCodesRemaining(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]int{
+		"remaining": remaining,
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate admin recovery codes
+//	@Description	Replace the admin's recovery codes after checking a code from the authenticator app. Codes from the old set stop working.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		AdminTwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	auth.TwoFactorRecoveryCodes
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/2fa/recovery-codes [post]
+func (h *AdminHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+
+	var req AdminTwoFactorCodeRequest
+	if !h.decodeTwoFactorRequest(w, r, &req) {
+		return
+	}
+
+	codes, err := h.authUC.RegenerateRecoveryCodes(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code)
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, codes)
+}
+
+func (h *AdminHandler) decodeTwoFactorRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := render.DecodeJSON(r.Body, req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return false
+	}
+	return true
+}
+
+func writeTwoFactorError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrTwoFactorUnavailable), errors.Is(err, auth.ErrTwoFactorNotEnrolled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrTwoFactorAlreadyEnabled):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrTwoFactorRequired), errors.Is(err, auth.ErrAdminRequired):
+		status = http.StatusForbidden
+	case errors.Is(err, auth.ErrInvalidTOTP), errors.Is(err, auth.ErrInvalidMFAToken):
+		status = http.StatusUnauthorized
+	case errors.Is(err, auth.ErrTwoFactorLocked):
+		status = http.StatusTooManyRequests
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
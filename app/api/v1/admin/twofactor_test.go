@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/admin/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type requireTwoFactor bool
+
+func (p requireTwoFactor) AdminTwoFactorRequired(ctx context.Context) (bool, error) {
+	return bool(p), nil
+}
+
+func TestAdminLogin_TwoFactor(t *testing.T) {
+	jh := newTestJWT().WithAudience(jwt.AudienceAdmin)
+	admin := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "admin@x.com", AccountType: entities.AccountTypeAdmin}
+	token, _ := jh.GenerateToken(admin.ID.String(), admin.Email, admin.AccountType.String())
+
+	tests := []struct {
+		name      string
+		response  auth.AuthResponse
+		required  bool
+		wantMFA   bool
+		wantSetup bool
+	}{
+		{name: "challenge", response: auth.AuthResponse{User: admin, MFARequired: true, MFAToken: "challenge"}, wantMFA: true},
+		{name: "setup required", response: auth.AuthResponse{User: admin, Token: token}, required: true, wantSetup: true},
+		{name: "not required", response: auth.AuthResponse{User: admin, Token: token}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.AuthUseCaseMock{
+				LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+					return tt.response, nil
+				},
+			}
+			mw := apiMiddleware.NewAuthMiddleware(jh)
+			mw.SetTwoFactorPolicy(requireTwoFactor(tt.required))
+			h := NewAdminHandler(uc, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, mw)
+
+			body, _ := json.Marshal(AdminLoginRequest{Email: "admin@x.com", Password: "pwd"})
+			w := httptest.NewRecorder()
+			h.AdminLogin(w, httptest.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body)))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp AdminLoginResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid json response: %v", err)
+			}
+			if resp.MFARequired != tt.wantMFA || resp.TwoFactorSetupRequired != tt.wantSetup {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			if tt.wantMFA && (resp.Token != "" || resp.MFAToken != "challenge") {
+				t.Fatalf("expected only the challenge token, got %+v", resp)
+			}
+		})
+	}
+}
+
+func TestTwoFactorChallenge(t *testing.T) {
+	jh := newTestJWT().WithAudience(jwt.AudienceAdmin)
+	admin := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "admin@x.com", AccountType: entities.AccountTypeAdmin}
+	mfaToken, _ := jh.WithAMR(jwt.AMROneTimePassword, jwt.AMRMultiFactor).GenerateToken(admin.ID.String(), admin.Email, admin.AccountType.String())
+
+	tests := []struct {
+		name       string
+		response   auth.AuthResponse
+		err        error
+		wantStatus int
+	}{
+		{name: "admin", response: auth.AuthResponse{User: admin, Token: mfaToken}, wantStatus: http.StatusOK},
+		{name: "not an admin", err: auth.ErrAdminRequired, wantStatus: http.StatusForbidden},
+		{name: "wrong code", err: auth.ErrInvalidTOTP, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.AuthUseCaseMock{
+				CompleteTwoFactorFunc: func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+					if req.Audience != jwt.AudienceAdmin {
+						t.Fatalf("expected admin audience, got %q", req.Audience)
+					}
+					return tt.response, tt.err
+				},
+			}
+			mw := apiMiddleware.NewAuthMiddleware(jh)
+			mw.SetTwoFactorPolicy(requireTwoFactor(true))
+			h := NewAdminHandler(uc, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, mw)
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/2fa/challenge", bytes.NewBufferString(`{"mfa_token":"challenge","code":"123456"}`)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp AdminLoginResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("invalid json response: %v", err)
+				}
+				if resp.Token == "" || resp.TwoFactorSetupRequired {
+					t.Fatalf("unexpected response: %+v", resp)
+				}
+			}
+		})
+	}
+}
+
+func TestRoutes_TwoFactorSetupWithoutSecondFactor(t *testing.T) {
+	jh := newTestJWT().WithAudience(jwt.AudienceAdmin)
+	adminID := uuid.Must(uuid.NewV4())
+	token, _ := jh.GenerateToken(adminID.String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+	uc := &mocks.AuthUseCaseMock{
+		EnrollTOTPFunc: func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error) {
+			return auth.TwoFactorEnrollment{Secret: "SECRET"}, nil
+		},
+	}
+	mw := apiMiddleware.NewAuthMiddleware(jh)
+	mw.SetTwoFactorPolicy(requireTwoFactor(true))
+	h := NewAdminHandler(uc, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, mw)
+	router := h.Routes()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+	}{
+		{name: "enroll", method: http.MethodPost, target: "/2fa/enroll", wantStatus: http.StatusOK},
+		{name: "dashboard", method: http.MethodGet, target: "/dashboard/stats", wantStatus: http.StatusForbidden},
+		{name: "recovery codes", method: http.MethodGet, target: "/2fa/recovery-codes", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestVerifyAdminToken_RejectsChallengeToken(t *testing.T) {
+	jh := newTestJWT()
+	tok, _ := jh.WithAudience(jwt.AudienceMFA).GenerateToken("u1", "a@b.com", entities.AccountTypeAdmin.String())
+	h := NewAdminHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, &mocks.SettingsUseCaseMock{}, jh, apiMiddleware.NewAuthMiddleware(jh))
+
+	req := httptest.NewRequest(http.MethodGet, "/verify", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+	h.VerifyAdminToken(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,259 @@
+package announcements
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/announcement"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListActiveAnnouncements godoc
+//
+//	@Summary		List active announcements
+//	@Description	List the announcements shown now, latest first. Requests with a token don't get the announcements the user dismissed.
+//	@Tags			announcements
+//	@Produce		json
+//	@Param			Authorization	header		string	false	"Bearer token, to leave out dismissed announcements"
+//	@Success		200				{array}		entities.Announcement
+//	@Failure		401				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/api/v1/announcements [get]
+func (h *AnnouncementHandler) ListActiveAnnouncements(w http.ResponseWriter, r *http.Request) {
+	userID := uuid.Nil
+	if principal, ok := middleware.PrincipalFromContext(r.Context()); ok {
+		userID = principal.UserID
+	}
+
+	announcements, err := h.uc.Active(r.Context(), userID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list active announcements", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list announcements",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, announcements)
+}
+
+// DismissAnnouncement godoc
+//
+//	@Summary		Dismiss an announcement
+//	@Description	Stop showing an announcement to the signed in user.
+//	@Tags			announcements
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Announcement ID"
+//	@Success		204
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/announcements/{id}/dismiss [post]
+func (h *AnnouncementHandler) DismissAnnouncement(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "announcement not found",
+		})
+		return
+	}
+
+	if err := h.uc.Dismiss(r.Context(), principal.UserID, id); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "announcement"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAnnouncements godoc
+//
+//	@Summary		List announcements
+//	@Description	List every announcement, including scheduled and ended ones, latest first
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.Announcement
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/announcements [get]
+func (h *AnnouncementHandler) ListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	announcements, err := h.uc.List(r.Context())
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list announcements", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list announcements",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, announcements)
+}
+
+// CreateAnnouncement godoc
+//
+//	@Summary		Create an announcement
+//	@Description	Broadcast an announcement to everyone using the apps. It starts now unless starts_at is set, is shown until ends_at or until it is deleted, and has the info level unless level is warning or critical.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		announcement.Request	true	"Message, level and schedule"
+//	@Success		201		{object}	entities.Announcement
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/announcements [post]
+func (h *AnnouncementHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req announcement.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	created, err := h.uc.Create(r.Context(), principal.UserID, req)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, created)
+}
+
+// UpdateAnnouncement godoc
+//
+//	@Summary		Update an announcement
+//	@Description	Replace an announcement's message, level and end. It keeps its start unless starts_at is set. Users who dismissed it don't see it again.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Announcement ID"
+//	@Param			request	body		announcement.Request	true	"Message, level and schedule"
+//	@Success		200		{object}	entities.Announcement
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/announcements/{id} [put]
+func (h *AnnouncementHandler) UpdateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid announcement ID",
+		})
+		return
+	}
+
+	var req announcement.Request
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	updated, err := h.uc.Update(r.Context(), principal.UserID, id, req)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "announcement"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, updated)
+}
+
+// DeleteAnnouncement godoc
+//
+//	@Summary		Delete an announcement
+//	@Description	Stop showing an announcement and forget who dismissed it
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Announcement ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/announcements/{id} [delete]
+func (h *AnnouncementHandler) DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid announcement ID",
+		})
+		return
+	}
+
+	err = h.uc.Delete(r.Context(), principal.UserID, id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "announcement"))
+		return
+	}
+
+	message := "announcement deleted"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: " + message
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": message,
+	})
+}
@@ -0,0 +1,161 @@
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/announcements/mocks"
+	"go-template/domain"
+	"go-template/domain/announcement"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAnnouncementHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	announcementID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.AnnouncementUseCaseMock{
+		ActiveFunc: func(ctx context.Context, id uuid.UUID) ([]entities.Announcement, error) {
+			return []entities.Announcement{{ID: announcementID, Message: "Maintenance tonight", Level: entities.AnnouncementWarning}}, nil
+		},
+		DismissFunc: func(ctx context.Context, id, announcement uuid.UUID) error {
+			if announcement != announcementID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewAnnouncementHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(signedIn bool, method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if signedIn {
+			token, err := jwtService.GenerateToken(userID.String(), "user@x.com", entities.AccountTypeUser.String())
+			if err != nil {
+				t.Fatalf("generating token: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(false, http.MethodGet, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without a token, got %d: %s", w.Code, w.Body.String())
+	}
+	var active []entities.Announcement
+	if err := json.NewDecoder(w.Body).Decode(&active); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != announcementID {
+		t.Fatalf("unexpected announcements: %+v", active)
+	}
+
+	if w := serve(true, http.MethodGet, "/"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with a token, got %d", w.Code)
+	}
+	calls := uc.ActiveCalls()
+	if len(calls) != 2 || calls[0].UserID != uuid.Nil || calls[1].UserID != userID {
+		t.Fatalf("expected dismissals to be left out only for signed in users, got %+v", calls)
+	}
+
+	if w := serve(false, http.MethodPost, "/"+announcementID.String()+"/dismiss"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 dismissing without a token, got %d", w.Code)
+	}
+	if w := serve(true, http.MethodPost, "/"+announcementID.String()+"/dismiss"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls := uc.DismissCalls(); len(calls) != 1 || calls[0].UserID != userID {
+		t.Fatalf("expected the announcement to be dismissed for the user, got %+v", calls)
+	}
+	if w := serve(true, http.MethodPost, "/"+uuid.Must(uuid.NewV4()).String()+"/dismiss"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown announcements, got %d", w.Code)
+	}
+	if w := serve(true, http.MethodPost, "/nope/dismiss"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for invalid IDs, got %d", w.Code)
+	}
+}
+
+func TestAnnouncementHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	announcementID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.AnnouncementUseCaseMock{
+		CreateFunc: func(ctx context.Context, by uuid.UUID, req announcement.Request) (entities.Announcement, error) {
+			if strings.TrimSpace(req.Message) == "" {
+				return entities.Announcement{}, domain.ErrMalformedParameters
+			}
+			return entities.Announcement{ID: announcementID, Message: req.Message, Level: req.Level, CreatedBy: &by}, nil
+		},
+		UpdateFunc: func(ctx context.Context, by, id uuid.UUID, req announcement.Request) (entities.Announcement, error) {
+			if id != announcementID {
+				return entities.Announcement{}, domain.ErrNotFound
+			}
+			return entities.Announcement{ID: id, Message: req.Message, Level: req.Level}, nil
+		},
+		DeleteFunc: func(ctx context.Context, by, id uuid.UUID) error {
+			if id != announcementID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewAnnouncementHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target, body string) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodGet, "/", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for admins, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/", `{"message":"Maintenance tonight","level":"warning"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created entities.Announcement
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if created.ID != announcementID || created.Level != entities.AnnouncementWarning || created.CreatedBy == nil || *created.CreatedBy != adminID {
+		t.Fatalf("unexpected announcement: %+v", created)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/", `{"message":" "}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed announcements, got %d", w.Code)
+	}
+
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPut, "/"+announcementID.String(), `{"message":"Done"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPut, "/"+uuid.Must(uuid.NewV4()).String(), `{"message":"Done"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 updating unknown announcements, got %d", w.Code)
+	}
+
+	w = serve(entities.AccountTypeSuperAdmin, http.MethodDelete, "/"+announcementID.String(), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodDelete, "/nope", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid IDs, got %d", w.Code)
+	}
+}
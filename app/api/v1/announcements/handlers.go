@@ -0,0 +1,61 @@
+package announcements
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/announcement"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/announcement_uc.go . AnnouncementUseCase
+type AnnouncementUseCase interface {
+	Create(ctx context.Context, adminID uuid.UUID, req announcement.Request) (entities.Announcement, error)
+	Update(ctx context.Context, adminID, id uuid.UUID, req announcement.Request) (entities.Announcement, error)
+	Delete(ctx context.Context, adminID, id uuid.UUID) error
+	List(ctx context.Context) ([]entities.Announcement, error)
+	Active(ctx context.Context, userID uuid.UUID) ([]entities.Announcement, error)
+	Dismiss(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type AnnouncementHandler struct {
+	uc AnnouncementUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewAnnouncementHandler(uc AnnouncementUseCase, mw *middleware.AuthMiddleware) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the announcements shown in the apps, mounted at
+// /api/v1/announcements. Anyone can list them; signed in users don't get
+// the ones they dismissed.
+func (h *AnnouncementHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(h.mw.OptionalAuth).Get("/", h.ListActiveAnnouncements)
+	r.With(h.mw.RequireAuth).Post("/{id}/dismiss", h.DismissAnnouncement)
+
+	return r
+}
+
+// AdminRoutes returns the endpoints super admins broadcast announcements
+// with, mounted at /admin/v1/announcements
+func (h *AnnouncementHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireSuperAdmin)
+	r.Use(middleware.DryRun)
+
+	r.Get("/", h.ListAnnouncements)
+	r.Post("/", h.CreateAnnouncement)
+	r.Put("/{id}", h.UpdateAnnouncement)
+	r.Delete("/{id}", h.DeleteAnnouncement)
+
+	return r
+}
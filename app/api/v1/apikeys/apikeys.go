@@ -0,0 +1,246 @@
+package apikeys
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/apikey"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type CreateKeyResponse struct {
+	entities.APIKey
+	Key string `json:"key"`
+}
+
+// ListKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	List the signed in user's API keys, including revoked and expired ones, newest first
+//	@Tags			api-keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.APIKey
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/keys [get]
+func (h *APIKeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	keys, err := h.uc.List(r.Context(), principal.UserID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list api keys", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list api keys",
+		})
+		return
+	}
+	if keys == nil {
+		keys = []entities.APIKey{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, keys)
+}
+
+// CreateKey godoc
+//
+//	@Summary		Create an API key
+//	@Description	Create a key a machine client can send in the X-API-Key header to act as the user, limited to the given scopes. The key is only returned once.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		apikey.CreateRequest	true	"Key name, scopes and optional expiry"
+//	@Success		201		{object}	CreateKeyResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/keys [post]
+func (h *APIKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req apikey.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	key, plain, err := h.uc.Create(r.Context(), principal.UserID, req)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, CreateKeyResponse{
+		APIKey: key,
+		Key:    plain,
+	})
+}
+
+// RevokeKey godoc
+//
+//	@Summary		Revoke an API key
+//	@Description	Revoke one of the signed in user's API keys. Requests made with it are rejected from then on.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"API key ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/keys/{id} [delete]
+func (h *APIKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid api key ID",
+		})
+		return
+	}
+
+	err = h.uc.Revoke(r.Context(), principal.UserID, id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "api key"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "api key revoked",
+	})
+}
+
+// AdminListKeys godoc
+//
+//	@Summary		List all API keys
+//	@Description	List every user's API keys, newest first, optionally only those of one user
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user_id	query		string	false	"Only keys of this user"
+//	@Success		200		{array}		entities.APIKey
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/api-keys [get]
+func (h *APIKeyHandler) AdminListKeys(w http.ResponseWriter, r *http.Request) {
+	var (
+		keys []entities.APIKey
+		err  error
+	)
+	if raw := r.URL.Query().Get("user_id"); raw != "" {
+		userID, parseErr := uuid.FromString(raw)
+		if parseErr != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid user ID",
+			})
+			return
+		}
+		keys, err = h.uc.List(r.Context(), userID)
+	} else {
+		keys, err = h.uc.ListAll(r.Context())
+	}
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list api keys", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list api keys",
+		})
+		return
+	}
+	if keys == nil {
+		keys = []entities.APIKey{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, keys)
+}
+
+// AdminRevokeKey godoc
+//
+//	@Summary		Revoke any API key
+//	@Description	Revoke an API key of any user. The revocation is audited with the admin who made it.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"API key ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) AdminRevokeKey(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid api key ID",
+		})
+		return
+	}
+
+	err = h.uc.RevokeAny(r.Context(), id, principal.UserID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "api key"))
+		return
+	}
+
+	message := "api key revoked"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: " + message
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": message,
+	})
+}
@@ -0,0 +1,163 @@
+package apikeys
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/apikeys/mocks"
+	"go-template/domain"
+	"go-template/domain/apikey"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestToken(t *testing.T, jwtService jwt.Service, userID uuid.UUID, accountType entities.AccountType) string {
+	t.Helper()
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", accountType.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+	return token
+}
+
+func TestAPIKeyHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	keyID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token := newTestToken(t, jwtService, userID, entities.AccountTypeUser)
+
+	uc := &mocks.APIKeyUseCaseMock{
+		CreateFunc: func(ctx context.Context, id uuid.UUID, req apikey.CreateRequest) (entities.APIKey, string, error) {
+			if req.Name == "" {
+				return entities.APIKey{}, "", domain.ErrMalformedParameters
+			}
+			return entities.APIKey{ID: keyID, UserID: id, Name: req.Name, Scopes: req.Scopes}, "gtk_secret", nil
+		},
+		ListFunc: func(ctx context.Context, id uuid.UUID) ([]entities.APIKey, error) {
+			return []entities.APIKey{{ID: keyID, UserID: id}}, nil
+		},
+		RevokeFunc: func(ctx context.Context, id, key uuid.UUID) error {
+			if key != keyID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewAPIKeyHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(method, target, body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodGet, "/", "", false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+
+	w := serve(http.MethodPost, "/", `{"name":"ci","scopes":["example:read"]}`, true)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created CreateKeyResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if created.Key != "gtk_secret" || created.UserID != userID || !created.HasScope(entities.APIKeyScopeExampleRead) {
+		t.Fatalf("unexpected key: %+v", created)
+	}
+
+	if w := serve(http.MethodPost, "/", `{"scopes":["example:read"]}`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a name, got %d", w.Code)
+	}
+
+	w = serve(http.MethodGet, "/", "", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var keys []entities.APIKey
+	if err := json.NewDecoder(w.Body).Decode(&keys); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != keyID {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	if w := serve(http.MethodDelete, "/"+keyID.String(), "", true); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(http.MethodDelete, "/"+uuid.Must(uuid.NewV4()).String(), "", true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for another user's key, got %d", w.Code)
+	}
+	if w := serve(http.MethodDelete, "/not-a-uuid", "", true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAPIKeyHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	ownerID := uuid.Must(uuid.NewV4())
+	keyID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token := newTestToken(t, jwtService, adminID, entities.AccountTypeAdmin)
+
+	uc := &mocks.APIKeyUseCaseMock{
+		ListAllFunc: func(ctx context.Context) ([]entities.APIKey, error) {
+			return []entities.APIKey{{ID: keyID, UserID: ownerID}, {ID: uuid.Must(uuid.NewV4())}}, nil
+		},
+		ListFunc: func(ctx context.Context, id uuid.UUID) ([]entities.APIKey, error) {
+			return []entities.APIKey{{ID: keyID, UserID: id}}, nil
+		},
+		RevokeAnyFunc: func(ctx context.Context, id, by uuid.UUID) error {
+			if id != keyID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewAPIKeyHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodGet, "/")
+	var keys []entities.APIKey
+	if err := json.NewDecoder(w.Body).Decode(&keys); err != nil || len(keys) != 2 {
+		t.Fatalf("expected every key, got %d %+v (%v)", w.Code, keys, err)
+	}
+
+	w = serve(http.MethodGet, "/?user_id="+ownerID.String())
+	keys = nil
+	if err := json.NewDecoder(w.Body).Decode(&keys); err != nil || len(keys) != 1 {
+		t.Fatalf("expected the user's keys, got %d %+v (%v)", w.Code, keys, err)
+	}
+	if w := serve(http.MethodGet, "/?user_id=nope"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	if w := serve(http.MethodDelete, "/"+keyID.String()); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	calls := uc.RevokeAnyCalls()
+	if len(calls) != 1 || calls[0].AdminID != adminID {
+		t.Fatalf("expected the revocation to be made by the admin, got %+v", calls)
+	}
+	if w := serve(http.MethodDelete, "/"+uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,61 @@
+package apikeys
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/apikey"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/apikey_uc.go . APIKeyUseCase
+type APIKeyUseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, req apikey.CreateRequest) (entities.APIKey, string, error)
+	List(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+
+	// Admin oversight
+	ListAll(ctx context.Context) ([]entities.APIKey, error)
+	RevokeAny(ctx context.Context, id, adminID uuid.UUID) error
+}
+
+type APIKeyHandler struct {
+	uc APIKeyUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewAPIKeyHandler(uc APIKeyUseCase, mw *middleware.AuthMiddleware) *APIKeyHandler {
+	return &APIKeyHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the endpoints users manage their keys with, mounted at
+// /api/v1/keys. They take a user token: a key can't be used to mint or revoke
+// keys.
+func (h *APIKeyHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAuth)
+
+	r.Get("/", h.ListKeys)
+	r.Post("/", h.CreateKey)
+	r.Delete("/{id}", h.RevokeKey)
+
+	return r
+}
+
+// AdminRoutes returns the oversight endpoints, mounted at /admin/v1/api-keys
+func (h *APIKeyHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAdmin)
+
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersRead)).Get("/", h.AdminListKeys)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersWrite), middleware.DryRun).Delete("/{id}", h.AdminRevokeKey)
+
+	return r
+}
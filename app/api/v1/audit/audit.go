@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"fmt"
+	"go-template/app/api/common"
+	"go-template/domain"
+	"go-template/domain/entities"
+	filterExpr "go-template/internal/filter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListAuditEvents godoc
+//
+//	@Summary		Search the audit log
+//	@Description	List audit events, newest first, filtered by who acted, what they did, what they did it to and when.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			actor_id	query		string	false	"User who acted"
+//	@Param			action		query		string	false	"Action, such as \"user deleted\""
+//	@Param			resource	query		string	false	"Kind of resource acted on, such as user"
+//	@Param			resource_id	query		string	false	"Resource acted on"
+//	@Param			from		query		string	false	"Only events at or after this time (RFC 3339)"
+//	@Param			to			query		string	false	"Only events before this time (RFC 3339)"
+//	@Param			filter		query		string	false	"Filter expression, e.g. action:\"user deleted\",occurred_at>2024-01-01"
+//	@Param			page		query		int		false	"Page number (default 1)"
+//	@Param			page_size	query		int		false	"Events per page (default 50, max 100)"
+//	@Success		200			{object}	entities.AuditEventListResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/audit [get]
+func (h *AuditHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAuditFilter(r.URL.Query())
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	events, err := h.audit.List(r.Context(), filter)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, events)
+}
+
+func parseAuditFilter(query url.Values) (entities.AuditFilter, error) {
+	expr, err := filterExpr.Parse(query.Get("filter"), entities.AuditFilterFields)
+	if err != nil {
+		return entities.AuditFilter{}, fmt.Errorf("%w: %s", domain.ErrMalformedParameters, err)
+	}
+
+	filter := entities.AuditFilter{
+		Action:     query.Get("action"),
+		Resource:   query.Get("resource"),
+		ResourceID: query.Get("resource_id"),
+		Expr:       expr,
+	}
+
+	if v := query.Get("actor_id"); v != "" {
+		actorID, err := uuid.FromString(v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid actor_id", domain.ErrMalformedParameters)
+		}
+		filter.ActorID = &actorID
+	}
+
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid from", domain.ErrMalformedParameters)
+		}
+		filter.From = &from
+	}
+
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid to", domain.ErrMalformedParameters)
+		}
+		filter.To = &to
+	}
+
+	if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page <= 0 {
+			return filter, fmt.Errorf("%w: invalid page", domain.ErrMalformedParameters)
+		}
+		filter.Page = page
+	}
+
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil || pageSize <= 0 {
+			return filter, fmt.Errorf("%w: invalid page_size", domain.ErrMalformedParameters)
+		}
+		filter.PageSize = pageSize
+	}
+
+	return filter, nil
+}
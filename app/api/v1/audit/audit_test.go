@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/audit/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestRoutes(uc AuditUseCase) (http.Handler, jwt.Service) {
+	jh := jwt.NewService("test-secret", "test-issuer", "1h")
+	h := NewAuditHandler(uc, apiMiddleware.NewAuthMiddleware(jh))
+	return h.AdminRoutes(), jh
+}
+
+func TestListAuditEvents(t *testing.T) {
+	actorID := uuid.Must(uuid.NewV4())
+	var gotFilter entities.AuditFilter
+	uc := &mocks.AuditUseCaseMock{
+		ListFunc: func(ctx context.Context, filter entities.AuditFilter) (entities.AuditEventListResponse, error) {
+			gotFilter = filter
+			return entities.AuditEventListResponse{
+				Events:     []entities.AuditEvent{{ID: 3, ActorID: &actorID, Action: "user deleted", Resource: "user", ResourceID: "u-1"}},
+				Total:      1,
+				Page:       2,
+				PageSize:   10,
+				TotalPages: 1,
+			}, nil
+		},
+	}
+	routes, jh := newTestRoutes(uc)
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+	query := fmt.Sprintf("/?actor_id=%s&action=user+deleted&resource=user&resource_id=u-1&from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&filter=request_id:req-1&page=2&page_size=10", actorID)
+	req := httptest.NewRequest(http.MethodGet, query, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	if gotFilter.ActorID == nil || *gotFilter.ActorID != actorID ||
+		gotFilter.Action != "user deleted" || gotFilter.Resource != "user" || gotFilter.ResourceID != "u-1" ||
+		gotFilter.From == nil || !gotFilter.From.Equal(from) || gotFilter.To == nil || !gotFilter.To.Equal(to) ||
+		gotFilter.Page != 2 || gotFilter.PageSize != 10 ||
+		len(gotFilter.Expr) != 1 || gotFilter.Expr[0] != (entities.FilterPredicate{Field: "request_id", Op: entities.FilterEq, Value: "req-1"}) {
+		t.Fatalf("unexpected filter: %+v", gotFilter)
+	}
+	var got entities.AuditEventListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Total != 1 || len(got.Events) != 1 || got.Events[0].Action != "user deleted" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
+
+func TestListAuditEvents_Rejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		query       string
+		listErr     error
+		want        int
+	}{
+		{name: "admins cannot read the audit log", accountType: entities.AccountTypeAdmin, want: http.StatusForbidden},
+		{name: "invalid actor_id", accountType: entities.AccountTypeSuperAdmin, query: "?actor_id=someone", want: http.StatusBadRequest},
+		{name: "invalid from", accountType: entities.AccountTypeSuperAdmin, query: "?from=yesterday", want: http.StatusBadRequest},
+		{name: "invalid page", accountType: entities.AccountTypeSuperAdmin, query: "?page=0", want: http.StatusBadRequest},
+		{name: "invalid filter", accountType: entities.AccountTypeSuperAdmin, query: "?filter=details:x", want: http.StatusBadRequest},
+		{name: "empty range", accountType: entities.AccountTypeSuperAdmin, listErr: domain.ErrMalformedParameters, want: http.StatusBadRequest},
+		{name: "store failure", accountType: entities.AccountTypeSuperAdmin, listErr: fmt.Errorf("boom"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.AuditUseCaseMock{
+				ListFunc: func(ctx context.Context, filter entities.AuditFilter) (entities.AuditEventListResponse, error) {
+					return entities.AuditEventListResponse{}, tt.listErr
+				},
+			}
+			routes, jh := newTestRoutes(uc)
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "a@x.com", tt.accountType.String())
+
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/audit_uc.go . AuditUseCase
+type AuditUseCase interface {
+	List(ctx context.Context, filter entities.AuditFilter) (entities.AuditEventListResponse, error)
+}
+
+type AuditHandler struct {
+	audit AuditUseCase
+	mw    *middleware.AuthMiddleware
+}
+
+func NewAuditHandler(audit AuditUseCase, mw *middleware.AuthMiddleware) *AuditHandler {
+	return &AuditHandler{
+		audit: audit,
+		mw:    mw,
+	}
+}
+
+// AdminRoutes returns the audit log search, mounted at /admin/v1/audit.
+// Events name the users involved, so like the logs they are limited to super
+// admins.
+func (h *AuditHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireSuperAdmin)
+		r.Get("/", h.ListAuditEvents)
+	})
+
+	return r
+}
@@ -1,10 +1,17 @@
 package auth
 
 import (
+	"errors"
+	"go-template/app/api/common"
 	"go-template/app/api/middleware"
+	"go-template/domain"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/domain/invitation"
+	"go-template/domain/passwordpolicy"
+	userDomain "go-template/domain/user"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/render"
 	"github.com/gofrs/uuid/v5"
@@ -13,20 +20,30 @@ import (
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	// Audience is the application the token is for. Defaults to api.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+	// CaptchaToken is the CAPTCHA widget response, needed while the
+	// RequireCaptcha setting is on.
+	CaptchaToken string `json:"captcha_token,omitempty"`
+	// InvitationCode is an invitation from an admin, needed while
+	// registration is disabled and invitations are allowed.
+	InvitationCode string `json:"invitation_code,omitempty"`
 }
 
 // Register godoc
 //
 //	@Summary		Register a new user
-//	@Description	Register a new user with email and password
+//	@Description	Register a new user with email and password. A verification link is emailed when an email provider is configured. While the RequireEmailVerification setting is on, the response has email_verification_required instead of tokens. While the RequireApproval setting is on, the user is pending and the response has approval_required instead of tokens. While the RequireCaptcha setting is on, captcha_token must hold a valid CAPTCHA response. While the RegistrationEnabled setting is off, only people with an invitation_code can register, if the InvitationsEnabled setting is on; invited users get the invitation's account type and skip approval.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body	RegisterRequest	true	"Registration request"
 //	@Success		201	{object}	auth.AuthResponse
 //	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		409	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
+//	@Failure		503	{object}	map[string]string
 //	@Router			/api/v1/auth/register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
@@ -39,16 +56,39 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, map[string]string{
-			"error": "validation failed: " + err.Error(),
-		})
+		common.RespondError(w, r, err)
 		return
 	}
 
-	// Create user using userUC with empty provider (uses default)
-	user, err := h.userUC.CreateUser(r.Context(), req.Email, req.Password, "", entities.AccountTypeUser)
+	if !h.verifyCaptcha(w, r, req.CaptchaToken) {
+		return
+	}
+
+	// Create user with the default provider, pending while approval is required
+	// unless invited
+	user, err := h.userUC.Register(r.Context(), req.Email, req.Password, req.InvitationCode)
 	if err != nil {
+		if errors.Is(err, userDomain.ErrRegistrationDisabled) || errors.Is(err, userDomain.ErrInvitationRequired) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, invitation.ErrInvalidCode) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid or expired invitation code",
+			})
+			return
+		}
+		if errors.Is(err, passwordpolicy.ErrWeakPassword) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
 		// Check for duplicate key error
 		if err.Error() == "duplicate key" {
 			render.Status(r, http.StatusConflict)
@@ -57,6 +97,13 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+		if errors.Is(err, auth.ErrProviderUnavailable) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": "authentication service unavailable, try again later",
+			})
+			return
+		}
 
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, map[string]string{
@@ -65,20 +112,33 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate JWT token
-	token, err := h.jwtService.GenerateToken(user.ID.String(), user.Email, user.AccountType.String())
+	required, err := h.authUC.EmailVerificationRequired(r.Context())
 	if err != nil {
+		domain.Logger(r.Context()).Error("failed to check email verification setting", "error", err)
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, map[string]string{
-			"error": "failed to generate token",
+			"error": "registration failed",
+		})
+		return
+	}
+	pending := user.Status == entities.UserStatusPending
+	if required || pending {
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, auth.AuthResponse{
+			User:                      user,
+			EmailVerificationRequired: required,
+			ApprovalRequired:          pending,
 		})
 		return
 	}
 
-	// Create auth response
-	response := auth.AuthResponse{
-		Token: token,
-		User:  user,
+	response, err := h.authUC.IssueTokens(r.Context(), user, req.Audience)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to generate token",
+		})
+		return
 	}
 
 	render.Status(r, http.StatusCreated)
@@ -88,7 +148,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 // Login godoc
 //
 //	@Summary		User login
-//	@Description	Authenticate user with email and password
+//	@Description	Authenticate user with email and password. While the RequireCaptcha setting is on, captcha_token must hold a valid CAPTCHA response.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -96,7 +156,9 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 //	@Success		200	{object}	auth.AuthResponse
 //	@Failure		400	{object}	map[string]string
 //	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
+//	@Failure		503	{object}	map[string]string
 //	@Router			/api/v1/auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req auth.LoginRequest
@@ -109,15 +171,33 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, map[string]string{
-			"error": "validation failed: " + err.Error(),
-		})
+		common.RespondError(w, r, err)
+		return
+	}
+
+	if !h.verifyCaptcha(w, r, req.CaptchaToken) {
 		return
 	}
 
 	response, err := h.authUC.Login(r.Context(), req)
 	if err != nil {
+		if common.AccountStatusResponse(w, r, err) {
+			return
+		}
+		if errors.Is(err, auth.ErrProviderUnavailable) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": "authentication service unavailable, try again later",
+			})
+			return
+		}
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
 		render.Status(r, http.StatusUnauthorized)
 		render.JSON(w, r, map[string]string{
 			"error": "authentication failed",
@@ -129,6 +209,147 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, response)
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchange a refresh token for a new access and refresh token pair. Refresh tokens are single use; reusing one revokes every token issued from the same login.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	RefreshRequest	true	"Refresh request"
+//	@Success		200	{object}	auth.AuthResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	response, err := h.authUC.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		if common.AccountStatusResponse(w, r, err) {
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid refresh token",
+			})
+			return
+		}
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to refresh token",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// Logout godoc
+//
+//	@Summary		User logout
+//	@Description	Revoke the access token in the Authorization header and the refresh token in the body, along with every token rotated from the same login. Both are optional, but at least one is required.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	LogoutRequest	false	"Logout request"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req LogoutRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid request body",
+			})
+			return
+		}
+	}
+
+	accessToken := bearerToken(r)
+	if accessToken == "" && req.RefreshToken == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "no token to revoke",
+		})
+		return
+	}
+
+	// Invalid or expired access tokens are already rejected, so there is
+	// nothing to revoke for them
+	if accessToken != "" && h.revoker != nil {
+		if claims, err := h.jwtService.ValidateToken(accessToken); err == nil {
+			if err := h.revoker.Revoke(r.Context(), claims); err != nil {
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, map[string]string{
+					"error": "logout failed",
+				})
+				return
+			}
+		}
+	}
+
+	if req.RefreshToken != "" {
+		if err := h.authUC.Logout(r.Context(), req.RefreshToken); err != nil {
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, map[string]string{
+				"error": "logout failed",
+			})
+			return
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "logged out successfully",
+	})
+}
+
+// bearerToken returns the token from the Authorization header, if any.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
+
 // GetMe godoc
 //
 //	@Summary		Get current user
@@ -159,6 +380,12 @@ func (h *AuthHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if claims.Impersonated() {
+		user.ImpersonatedBy = claims.Impersonator.Email
+		if user.ImpersonatedBy == "" {
+			user.ImpersonatedBy = claims.Impersonator.Subject
+		}
+	}
 
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, user)
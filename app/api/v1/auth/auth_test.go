@@ -5,12 +5,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	apiMiddleware "go-template/app/api/middleware"
 	"go-template/app/api/v1/auth/mocks"
 	"go-template/domain"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/domain/invitation"
+	"go-template/domain/passwordpolicy"
+	userDomain "go-template/domain/user"
+	"go-template/internal/botdetect"
 	"go-template/internal/jwt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -26,7 +33,7 @@ func createTestJWTService() jwt.Service {
 
 func TestAuthHandler_Register_Success(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{
 				ID:          uuid.Must(uuid.NewV4()),
 				Email:       email,
@@ -41,10 +48,11 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	}
 
 	authUC := &mocks.AuthUseCaseMock{
-		LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+		IssueTokensFunc: func(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error) {
 			return auth.AuthResponse{
-				Token: "token",
-				User:  entities.User{Email: "a@b.com"},
+				Token:        "token",
+				RefreshToken: "rt_token",
+				User:         user,
 			}, nil
 		},
 	}
@@ -64,14 +72,78 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	}
 	var resp auth.AuthResponse
 	_ = json.Unmarshal(w.Body.Bytes(), &resp)
-	if resp.Token == "" || resp.User.Email != "a@b.com" {
+	if resp.Token == "" || resp.RefreshToken == "" || resp.User.Email != "a@b.com" {
 		t.Fatalf("unexpected response: %+v", resp)
 	}
 }
 
+func TestAuthHandler_Register_ApprovalRequired(t *testing.T) {
+	userUC := &mocks.UserUseCaseMock{
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+			return entities.User{ID: uuid.Must(uuid.NewV4()), Email: email, Status: entities.UserStatusPending}, nil
+		},
+	}
+	authUC := &mocks.AuthUseCaseMock{}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	body, _ := json.Marshal(RegisterRequest{Email: "a@b.com", Password: "123456"})
+	w := httptest.NewRecorder()
+	h.Register(w, httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(body)))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	var resp auth.AuthResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !resp.ApprovalRequired || resp.EmailVerificationRequired || resp.Token != "" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(authUC.IssueTokensCalls()) != 0 {
+		t.Fatalf("expected no tokens to be issued")
+	}
+}
+
+func TestAuthHandler_Register_Invitations(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		err      error
+		wantCode int
+	}{
+		{name: "registration disabled", err: userDomain.ErrRegistrationDisabled, wantCode: http.StatusForbidden},
+		{name: "invitation required", err: userDomain.ErrInvitationRequired, wantCode: http.StatusForbidden},
+		{name: "invalid code", code: "BADCODE", err: invitation.ErrInvalidCode, wantCode: http.StatusBadRequest},
+		{name: "weak password", err: passwordpolicy.ErrWeakPassword, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userUC := &mocks.UserUseCaseMock{
+				RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+					if invitationCode != tt.code {
+						t.Errorf("expected code %q, got %q", tt.code, invitationCode)
+					}
+					return entities.User{}, tt.err
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(&mocks.AuthUseCaseMock{}, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			body, _ := json.Marshal(RegisterRequest{Email: "a@b.com", Password: "123456", InvitationCode: tt.code})
+			w := httptest.NewRecorder()
+			h.Register(w, httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(body)))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestAuthHandler_Register_InvalidJSON(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, nil
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -101,7 +173,7 @@ func TestAuthHandler_Register_InvalidJSON(t *testing.T) {
 
 func TestAuthHandler_Register_ValidationFailed(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, nil
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -133,7 +205,7 @@ func TestAuthHandler_Register_ValidationFailed(t *testing.T) {
 
 func TestAuthHandler_Register_CreateUserFailed(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, errors.New("creation failed")
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -164,7 +236,7 @@ func TestAuthHandler_Register_CreateUserFailed(t *testing.T) {
 
 func TestAuthHandler_Login_Success(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, nil
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -201,9 +273,134 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_ProviderUnavailable(t *testing.T) {
+	unavailable := fmt.Errorf("authentication failed: %w", auth.ErrProviderUnavailable)
+	authUC := &mocks.AuthUseCaseMock{
+		LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+			return auth.AuthResponse{}, unavailable
+		},
+	}
+	userUC := &mocks.UserUseCaseMock{
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+			return entities.User{}, unavailable
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	for _, target := range []string{"/login", "/register"} {
+		req := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(`{"email":"a@b.com","password":"secret"}`))
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("%s: expected 503, got %d: %s", target, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "rotated", body: `{"refresh_token":"rt_old"}`, wantStatus: http.StatusOK},
+		{name: "missing token", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid token", body: `{"refresh_token":"rt_old"}`, err: auth.ErrInvalidRefreshToken, wantStatus: http.StatusUnauthorized},
+		{name: "reused token", body: `{"refresh_token":"rt_old"}`, err: auth.ErrRefreshTokenReused, wantStatus: http.StatusUnauthorized},
+		{name: "store error", body: `{"refresh_token":"rt_old"}`, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				RefreshFunc: func(ctx context.Context, refreshToken string) (auth.AuthResponse, error) {
+					if tt.err != nil {
+						return auth.AuthResponse{}, tt.err
+					}
+					return auth.AuthResponse{Token: "token", RefreshToken: "rt_new"}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			h.Refresh(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				var resp auth.AuthResponse
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				if resp.RefreshToken != "rt_new" {
+					t.Fatalf("unexpected response: %+v", resp)
+				}
+				if calls := authUC.RefreshCalls(); len(calls) != 1 || calls[0].RefreshToken != "rt_old" {
+					t.Fatalf("unexpected refresh calls: %+v", calls)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	authUC := &mocks.AuthUseCaseMock{
+		LogoutFunc: func(ctx context.Context, refreshToken string) error {
+			return nil
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(`{"refresh_token":"rt_old"}`))
+	w := httptest.NewRecorder()
+
+	h.Logout(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if calls := authUC.LogoutCalls(); len(calls) != 1 || calls[0].RefreshToken != "rt_old" {
+		t.Fatalf("unexpected logout calls: %+v", calls)
+	}
+}
+
+func TestAuthHandler_Logout_RevokesAccessToken(t *testing.T) {
+	jwtService := createTestJWTService()
+	token, _ := jwtService.GenerateToken("user-1", "a@b.com", entities.AccountTypeUser.String())
+
+	revoker := &mocks.TokenRevokerMock{}
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	h.SetTokenRevoker(revoker)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	w := httptest.NewRecorder()
+	h.Logout(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without any token, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	h.Logout(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	calls := revoker.RevokeCalls()
+	if len(calls) != 1 || calls[0].Claims.UserID != "user-1" || calls[0].Claims.ID == "" {
+		t.Fatalf("unexpected revoke calls: %+v", calls)
+	}
+}
+
 func TestAuthHandler_GetMe_Success(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, nil
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -237,9 +434,35 @@ func TestAuthHandler_GetMe_Success(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_GetMe_Impersonated(t *testing.T) {
+	userUC := &mocks.UserUseCaseMock{
+		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+			return entities.User{Email: "a@b.com"}, nil
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	claims := &jwt.Claims{
+		UserID:       uuid.Must(uuid.NewV4()).String(),
+		Impersonator: &jwt.Actor{Subject: uuid.Must(uuid.NewV4()).String(), Email: "admin@b.com"},
+	}
+	req = req.WithContext(context.WithValue(req.Context(), apiMiddleware.UserContextKey, claims))
+	w := httptest.NewRecorder()
+
+	h.GetMe(w, req)
+
+	var user entities.User
+	_ = json.Unmarshal(w.Body.Bytes(), &user)
+	if w.Code != http.StatusOK || user.ImpersonatedBy != "admin@b.com" {
+		t.Fatalf("expected the impersonating admin in the response, got %d %+v", w.Code, user)
+	}
+}
+
 func TestAuthHandler_GetMe_NotFound(t *testing.T) {
 	userUC := &mocks.UserUseCaseMock{
-		CreateUserFunc: func(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
 			return entities.User{}, nil
 		},
 		GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
@@ -272,3 +495,46 @@ func TestAuthHandler_GetMe_NotFound(t *testing.T) {
 		t.Fatalf("expected 404, got %d", w.Code)
 	}
 }
+
+func TestAuthHandler_Register_BotDetection(t *testing.T) {
+	userUC := &mocks.UserUseCaseMock{
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+			return entities.User{ID: uuid.Must(uuid.NewV4()), Email: email}, nil
+		},
+	}
+	authUC := &mocks.AuthUseCaseMock{
+		LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+			return auth.AuthResponse{Token: "token"}, nil
+		},
+	}
+
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	h.SetBotDetector(botdetect.New(botdetect.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	router := h.Routes()
+
+	body := []byte(`{"email":"a@b.com","password":"123456","website":"http://spam.example"}`)
+	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if len(userUC.RegisterCalls()) != 0 {
+		t.Fatalf("expected no user to be created")
+	}
+
+	body = []byte(`{"email":"a@b.com","password":"123456"}`)
+	req = httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
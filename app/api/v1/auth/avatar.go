@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/user"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// avatarFormOverhead is room for the multipart headers around the image.
+const avatarFormOverhead = 64 << 10
+
+// UploadAvatar godoc
+//
+//	@Summary		Upload the current user's avatar
+//	@Description	Replace the signed in user's avatar with a PNG, JPEG, GIF or WebP image of up to 2 MiB, sent as the "avatar" field of a multipart form.
+//	@Tags			auth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			avatar	formData	file	true	"Avatar image"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		413	{object}	map[string]string
+//	@Failure		415	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/avatar [put]
+func (h *AuthHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, user.MaxAvatarSize+avatarFormOverhead)
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAvatarError(w, r, user.ErrAvatarTooLarge)
+			return
+		}
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "avatar file is required",
+		})
+		return
+	}
+	defer file.Close()
+
+	updated, err := h.userUC.UploadAvatar(r.Context(), principal.UserID, file)
+	if err != nil {
+		writeAvatarError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, updated)
+}
+
+// DeleteAvatar godoc
+//
+//	@Summary		Remove the current user's avatar
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.User
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/avatar [delete]
+func (h *AuthHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	updated, err := h.userUC.DeleteAvatar(r.Context(), principal.UserID)
+	if err != nil {
+		writeAvatarError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, updated)
+}
+
+func writeAvatarError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, user.ErrAvatarsDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, user.ErrAvatarTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, user.ErrAvatarType):
+		status = http.StatusUnsupportedMediaType
+	default:
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
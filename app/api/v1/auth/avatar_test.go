@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/entities"
+	"go-template/domain/user"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_Avatar(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	userUC := &mocks.UserUseCaseMock{
+		UploadAvatarFunc: func(ctx context.Context, id uuid.UUID, body io.Reader) (entities.User, error) {
+			data, _ := io.ReadAll(body)
+			if string(data) == "not an image" {
+				return entities.User{}, user.ErrAvatarType
+			}
+			return entities.User{ID: id, AvatarURL: "http://files/avatars/a.png"}, nil
+		},
+		DeleteAvatarFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return entities.User{ID: id}, nil
+		},
+	}
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	upload := func(field string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile(field, "avatar.png")
+		part.Write(content)
+		mw.Close()
+
+		req := httptest.NewRequest(http.MethodPut, "/me/avatar", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := upload("picture", []byte("png")); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without an avatar field, got %d", w.Code)
+	}
+	if w := upload("avatar", []byte("not an image")); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", w.Code)
+	}
+	if w := upload("avatar", make([]byte, user.MaxAvatarSize+avatarFormOverhead)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", w.Code)
+	}
+
+	w := upload("avatar", []byte("png"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got entities.User
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.AvatarURL != "http://files/avatars/a.png" {
+		t.Fatalf("unexpected avatar url %q", got.AvatarURL)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/me/avatar", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	h.Routes().ServeHTTP(w, req)
+	if w.Code != http.StatusOK || len(userUC.DeleteAvatarCalls()) != 1 {
+		t.Fatalf("expected the avatar to be removed, got %d", w.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// Captcha godoc
+//
+//	@Summary		Get the CAPTCHA widget
+//	@Description	Tell clients whether registration and login need a CAPTCHA, and which provider and site key to render the widget with
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	auth.CaptchaConfig
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/captcha [get]
+func (h *AuthHandler) Captcha(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.authUC.Captcha(r.Context())
+	if err != nil {
+		writeCaptchaError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, cfg)
+}
+
+// verifyCaptcha checks the CAPTCHA response of a registration or login and
+// writes the error response when it fails.
+func (h *AuthHandler) verifyCaptcha(w http.ResponseWriter, r *http.Request, token string) bool {
+	if err := h.authUC.VerifyCaptcha(r.Context(), token); err != nil {
+		writeCaptchaError(w, r, err)
+		return false
+	}
+	return true
+}
+
+func writeCaptchaError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrCaptchaRequired), errors.Is(err, auth.ErrInvalidCaptcha):
+		status = http.StatusBadRequest
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
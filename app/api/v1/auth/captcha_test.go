@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthHandler_Captcha(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "login missing captcha", target: "/login", body: `{"email":"a@b.com","password":"secret"}`, err: auth.ErrCaptchaRequired, wantStatus: http.StatusBadRequest},
+		{name: "login invalid captcha", target: "/login", body: `{"email":"a@b.com","password":"secret","captcha_token":"bad"}`, err: auth.ErrInvalidCaptcha, wantStatus: http.StatusBadRequest},
+		{name: "login", target: "/login", body: `{"email":"a@b.com","password":"secret","captcha_token":"good"}`, wantStatus: http.StatusOK},
+		{name: "register invalid captcha", target: "/register", body: `{"email":"a@b.com","password":"secret","captcha_token":"bad"}`, err: auth.ErrInvalidCaptcha, wantStatus: http.StatusBadRequest},
+		{name: "register", target: "/register", body: `{"email":"a@b.com","password":"secret","captcha_token":"good"}`, wantStatus: http.StatusCreated},
+		{name: "provider unavailable", target: "/login", body: `{"email":"a@b.com","password":"secret","captcha_token":"good"}`, err: errors.New("timeout"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loggedIn, created bool
+			authUC := &mocks.AuthUseCaseMock{
+				VerifyCaptchaFunc: func(ctx context.Context, token string) error {
+					return tt.err
+				},
+				LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+					loggedIn = true
+					return auth.AuthResponse{Token: "token"}, nil
+				},
+			}
+			userUC := &mocks.UserUseCaseMock{
+				RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+					created = true
+					return entities.User{Email: email}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.err != nil && (loggedIn || created) {
+				t.Fatalf("expected the request to stop at the captcha check")
+			}
+		})
+	}
+}
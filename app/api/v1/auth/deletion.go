@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetAccountDeletion godoc
+//
+//	@Summary		Get the pending account deletion
+//	@Description	Get when the signed in user's account is due to be deleted, if they asked for it
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.DeletionRequest
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/deletion [get]
+func (h *AuthHandler) GetAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	req, err := h.deletion.Get(r.Context(), principal.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, map[string]string{
+				"error": "no account deletion requested",
+			})
+			return
+		}
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, req)
+}
+
+// RequestAccountDeletion godoc
+//
+//	@Summary		Request account deletion
+//	@Description	Schedule the signed in user's account for deletion after the grace period. The user can sign in and cancel until then. Asking again returns the pending request.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	entities.DeletionRequest
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/deletion [post]
+func (h *AuthHandler) RequestAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	req, err := h.deletion.Request(r.Context(), principal.UserID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to request account deletion", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to request account deletion",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, req)
+}
+
+// CancelAccountDeletion godoc
+//
+//	@Summary		Cancel account deletion
+//	@Description	Keep the signed in user's account, dropping their pending deletion request
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/deletion [delete]
+func (h *AuthHandler) CancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := h.deletion.Cancel(r.Context(), principal.UserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, map[string]string{
+				"error": "no account deletion requested",
+			})
+			return
+		}
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "account deletion canceled",
+	})
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_AccountDeletion(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	var pending *entities.DeletionRequest
+	deletionUC := &mocks.AccountDeletionUseCaseMock{
+		RequestFunc: func(ctx context.Context, id uuid.UUID) (entities.DeletionRequest, error) {
+			if pending == nil {
+				now := time.Now()
+				pending = &entities.DeletionRequest{UserID: id, RequestedAt: now, ScheduledFor: now.Add(time.Hour)}
+			}
+			return *pending, nil
+		},
+		GetFunc: func(ctx context.Context, id uuid.UUID) (entities.DeletionRequest, error) {
+			if pending == nil {
+				return entities.DeletionRequest{}, domain.ErrNotFound
+			}
+			return *pending, nil
+		},
+		CancelFunc: func(ctx context.Context, id uuid.UUID) error {
+			if pending == nil {
+				return domain.ErrNotFound
+			}
+			pending = nil
+			return nil
+		},
+	}
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	h.SetAccountDeletion(deletionUC)
+
+	serve := func(method string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/me/deletion", nil)
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodPost, false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if w := serve(http.MethodGet, true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 before a request, got %d", w.Code)
+	}
+
+	w := serve(http.MethodPost, true)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var req entities.DeletionRequest
+	if err := json.NewDecoder(w.Body).Decode(&req); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if req.UserID != userID || !req.ScheduledFor.After(req.RequestedAt) {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	if w := serve(http.MethodGet, true); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(http.MethodDelete, true); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(http.MethodDelete, true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 once canceled, got %d", w.Code)
+	}
+}
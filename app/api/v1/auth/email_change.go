@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// RequestEmailChange godoc
+//
+//	@Summary		Change email address
+//	@Description	Email a confirmation link to the new address. The signed in user's email only changes once the link is opened.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.ChangeEmailRequest	true	"New email address"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/email [post]
+func (h *AuthHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.ChangeEmailRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.RequestEmailChange(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Email); err != nil {
+		writeEmailChangeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "a confirmation link has been sent to the new address",
+	})
+}
+
+// ConfirmEmailChange godoc
+//
+//	@Summary		Confirm an email change
+//	@Description	Replace the user's email with the address the token was sent to, at the auth provider and in the application
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.ConfirmEmailChangeRequest	true	"Email change token"
+//	@Success		200		{object}	entities.User
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/email-change/confirm [post]
+func (h *AuthHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req auth.ConfirmEmailChangeRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.authUC.ConfirmEmailChange(r.Context(), req.Token)
+	if err != nil {
+		writeEmailChangeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
+
+func writeEmailChangeError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrEmailChangeDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrInvalidEmailChangeToken), errors.Is(err, auth.ErrEmailUnchanged):
+		status = http.StatusBadRequest
+	case errors.Is(err, auth.ErrEmailInUse):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrEmailChangeRequestTooSoon):
+		status = http.StatusTooManyRequests
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_EmailChange(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, _ := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		auth       bool
+		err        error
+		wantStatus int
+	}{
+		{name: "unauthenticated", target: "/me/email", body: `{"email":"new@b.com"}`, wantStatus: http.StatusUnauthorized},
+		{name: "requested", target: "/me/email", body: `{"email":"new@b.com"}`, auth: true, wantStatus: http.StatusAccepted},
+		{name: "invalid email", target: "/me/email", body: `{"email":"nope"}`, auth: true, wantStatus: http.StatusBadRequest},
+		{name: "in use", target: "/me/email", body: `{"email":"new@b.com"}`, auth: true, err: auth.ErrEmailInUse, wantStatus: http.StatusConflict},
+		{name: "too soon", target: "/me/email", body: `{"email":"new@b.com"}`, auth: true, err: auth.ErrEmailChangeRequestTooSoon, wantStatus: http.StatusTooManyRequests},
+		{name: "disabled", target: "/me/email", body: `{"email":"new@b.com"}`, auth: true, err: auth.ErrEmailChangeDisabled, wantStatus: http.StatusNotFound},
+		{name: "confirmed", target: "/email-change/confirm", body: `{"token":"abc"}`, wantStatus: http.StatusOK},
+		{name: "invalid token", target: "/email-change/confirm", body: `{"token":"abc"}`, err: auth.ErrInvalidEmailChangeToken, wantStatus: http.StatusBadRequest},
+		{name: "taken since", target: "/email-change/confirm", body: `{"token":"abc"}`, err: auth.ErrEmailInUse, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				RequestEmailChangeFunc: func(ctx context.Context, id uuid.UUID, newEmail string) error {
+					if id != userID {
+						t.Fatalf("unexpected user %s", id)
+					}
+					return tt.err
+				},
+				ConfirmEmailChangeFunc: func(ctx context.Context, token string) (entities.User, error) {
+					if tt.err != nil {
+						return entities.User{}, tt.err
+					}
+					return entities.User{ID: userID, Email: "new@b.com", EmailVerified: true}, nil
+				},
+			}
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString(tt.body))
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
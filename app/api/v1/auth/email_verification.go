@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// SendVerificationEmail godoc
+//
+//	@Summary		Send a verification email
+//	@Description	Email a link that confirms the signed in user's address
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		429	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/email/verify [post]
+func (h *AuthHandler) SendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	if err := h.authUC.SendVerificationEmail(r.Context(), uuid.FromStringOrNil(claims.UserID)); err != nil {
+		writeEmailVerificationError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "a verification email has been sent",
+	})
+}
+
+// ResendVerificationEmail godoc
+//
+//	@Summary		Resend a verification email
+//	@Description	Email a new verification link to a user who can't sign in until their address is verified. The response is the same whether or not the email belongs to an unverified user.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.ResendVerificationRequest	true	"Account email"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	var req auth.ResendVerificationRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.ResendVerificationEmail(r.Context(), req.Email); err != nil {
+		writeEmailVerificationError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "if the email needs verifying, a link has been sent",
+	})
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify an email address
+//	@Description	Confirm the user's email with the token from a verification email
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	entities.User
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req auth.VerifyEmailRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.authUC.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		writeEmailVerificationError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
+
+func writeEmailVerificationError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrEmailVerificationDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrInvalidVerificationToken):
+		status = http.StatusBadRequest
+	case errors.Is(err, auth.ErrEmailAlreadyVerified):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrVerificationRequestTooSoon):
+		status = http.StatusTooManyRequests
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
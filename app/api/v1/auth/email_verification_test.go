@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_Register_EmailVerificationRequired(t *testing.T) {
+	userUC := &mocks.UserUseCaseMock{
+		RegisterFunc: func(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+			return entities.User{ID: uuid.Must(uuid.NewV4()), Email: email, AccountType: entities.AccountTypeUser}, nil
+		},
+	}
+	authUC := &mocks.AuthUseCaseMock{
+		EmailVerificationRequiredFunc: func(ctx context.Context) (bool, error) {
+			return true, nil
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	body, _ := json.Marshal(RegisterRequest{Email: "a@b.com", Password: "123456"})
+	w := httptest.NewRecorder()
+	h.Register(w, httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(body)))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	var resp auth.AuthResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !resp.EmailVerificationRequired || resp.Token != "" || resp.RefreshToken != "" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(authUC.IssueTokensCalls()) != 0 {
+		t.Fatalf("expected no tokens to be issued")
+	}
+}
+
+func TestAuthHandler_Login_EmailNotVerified(t *testing.T) {
+	authUC := &mocks.AuthUseCaseMock{
+		LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+			return auth.AuthResponse{}, auth.ErrEmailNotVerified
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	w := httptest.NewRecorder()
+	h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email":"a@b.com","password":"secret"}`)))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "verified", body: `{"token":"abc"}`, wantStatus: http.StatusOK},
+		{name: "missing token", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid token", body: `{"token":"abc"}`, err: auth.ErrInvalidVerificationToken, wantStatus: http.StatusBadRequest},
+		{name: "disabled", body: `{"token":"abc"}`, err: auth.ErrEmailVerificationDisabled, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				VerifyEmailFunc: func(ctx context.Context, token string) (entities.User, error) {
+					if tt.err != nil {
+						return entities.User{}, tt.err
+					}
+					return entities.User{ID: uuid.Must(uuid.NewV4()), EmailVerified: true}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/verify-email", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_SendVerificationEmail(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, _ := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		auth       bool
+		err        error
+		wantStatus int
+	}{
+		{name: "unauthenticated", target: "/me/email/verify", wantStatus: http.StatusUnauthorized},
+		{name: "sent", target: "/me/email/verify", auth: true, wantStatus: http.StatusAccepted},
+		{name: "already verified", target: "/me/email/verify", auth: true, err: auth.ErrEmailAlreadyVerified, wantStatus: http.StatusConflict},
+		{name: "too soon", target: "/me/email/verify", auth: true, err: auth.ErrVerificationRequestTooSoon, wantStatus: http.StatusTooManyRequests},
+		{name: "resend", target: "/verify-email/resend", body: `{"email":"a@b.com"}`, wantStatus: http.StatusAccepted},
+		{name: "resend invalid email", target: "/verify-email/resend", body: `{"email":"nope"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				SendVerificationEmailFunc: func(ctx context.Context, id uuid.UUID) error {
+					if id != userID {
+						t.Fatalf("unexpected user %s", id)
+					}
+					return tt.err
+				},
+				ResendVerificationEmailFunc: func(ctx context.Context, email string) error {
+					return tt.err
+				},
+			}
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString(tt.body))
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
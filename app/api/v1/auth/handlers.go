@@ -5,9 +5,14 @@ import (
 	"go-template/app/api/middleware"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/internal/botdetect"
 	"go-template/internal/jwt"
+	"go-template/internal/ratelimit"
+	"io"
+	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofrs/uuid/v5"
 )
@@ -15,12 +20,62 @@ import (
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/auth_uc.go . AuthUseCase
 type AuthUseCase interface {
 	Login(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error)
+	IssueTokens(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (auth.AuthResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	SocialProviders() []string
+	SocialAuthURL(provider, state, redirectURI string) (string, error)
+	SocialLogin(ctx context.Context, provider string, req auth.SocialLoginRequest) (auth.AuthResponse, error)
+	RequestLoginCode(ctx context.Context, phone string) error
+	LoginWithCode(ctx context.Context, req auth.OTPLoginRequest) (auth.AuthResponse, error)
+	RequestPhoneVerification(ctx context.Context, userID uuid.UUID, phone string) error
+	VerifyPhone(ctx context.Context, userID uuid.UUID, phone, code string) (entities.User, error)
+	TwoFactorStatus(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error)
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error)
+	EnableTOTP(ctx context.Context, userID uuid.UUID, code, audience string) (auth.AuthResponse, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+	CompleteTwoFactor(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error)
+	RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, req auth.ResetPasswordRequest) error
+	EmailVerificationRequired(ctx context.Context) (bool, error)
+	SendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) (entities.User, error)
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, token string) (entities.User, error)
+	Captcha(ctx context.Context) (auth.CaptchaConfig, error)
+	VerifyCaptcha(ctx context.Context, token string) error
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/token_revoker.go . TokenRevoker
+type TokenRevoker interface {
+	Revoke(ctx context.Context, claims *jwt.Claims) error
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/session_uc.go . SessionUseCase
+type SessionUseCase interface {
+	List(ctx context.Context, userID uuid.UUID) ([]entities.Session, error)
+	Revoke(ctx context.Context, userID uuid.UUID, id string) error
+	RevokeOthers(ctx context.Context, userID uuid.UUID, current string) (int, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/account_deletion_uc.go . AccountDeletionUseCase
+type AccountDeletionUseCase interface {
+	Request(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error)
+	Get(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error)
+	Cancel(ctx context.Context, userID uuid.UUID) error
 }
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/user_uc.go . UserUseCase
 type UserUseCase interface {
 	GetMe(ctx context.Context, userID uuid.UUID) (entities.User, error)
-	CreateUser(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error)
+	UploadAvatar(ctx context.Context, userID uuid.UUID, body io.Reader) (entities.User, error)
+	DeleteAvatar(ctx context.Context, userID uuid.UUID) (entities.User, error)
+	Register(ctx context.Context, email, password, invitationCode string) (entities.User, error)
+	AcceptInvitation(ctx context.Context, code, password string) (entities.User, error)
 }
 
 type AuthHandler struct {
@@ -29,6 +84,11 @@ type AuthHandler struct {
 	jwtService     jwt.Service
 	validator      *validator.Validate
 	authMiddleware *middleware.AuthMiddleware
+	botDetector    *botdetect.Detector
+	loginLimiter   *ratelimit.Limiter
+	revoker        TokenRevoker
+	sessions       SessionUseCase
+	deletion       AccountDeletionUseCase
 }
 
 func NewAuthHandler(authUC AuthUseCase, userUC UserUseCase, jwtService jwt.Service, authMiddleware *middleware.AuthMiddleware) *AuthHandler {
@@ -41,17 +101,111 @@ func NewAuthHandler(authUC AuthUseCase, userUC UserUseCase, jwtService jwt.Servi
 	}
 }
 
+// SetBotDetector screens registrations for automated submissions.
+func (h *AuthHandler) SetBotDetector(d *botdetect.Detector) {
+	h.botDetector = d
+}
+
+// SetLoginLimiter rate limits login attempts per client IP and account.
+func (h *AuthHandler) SetLoginLimiter(l *ratelimit.Limiter) {
+	h.loginLimiter = l
+}
+
+// SetTokenRevoker makes logout revoke the presented access token.
+func (h *AuthHandler) SetTokenRevoker(revoker TokenRevoker) {
+	h.revoker = revoker
+}
+
+// SetSessionUseCase lets users list and revoke their sessions.
+func (h *AuthHandler) SetSessionUseCase(uc SessionUseCase) {
+	h.sessions = uc
+}
+
+// SetAccountDeletion lets users schedule the deletion of their account.
+func (h *AuthHandler) SetAccountDeletion(uc AccountDeletionUseCase) {
+	h.deletion = uc
+}
+
 func (h *AuthHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
-	r.Post("/register", h.Register)
-	r.Post("/login", h.Login)
+	register := r.With()
+	if h.botDetector != nil {
+		register = r.With(h.botDetector.Middleware("register", rejectBot))
+	}
+	register.Post("/register", h.Register)
+	login := r.With()
+	if h.loginLimiter != nil {
+		login = r.With(h.loginLimiter.Middleware("login"))
+	}
+	// Users can still log in while the database refuses writes
+	login.Method(http.MethodPost, "/login", middleware.WritableInReadOnly(h.Login))
+	r.Get("/captcha", h.Captcha)
+	r.Post("/refresh", h.Refresh)
+	r.Post("/logout", h.Logout)
+
+	// Social login
+	r.Get("/social", h.SocialProviders)
+	r.Get("/social/{provider}", h.SocialAuthURL)
+	r.Post("/social/{provider}/callback", h.SocialCallback)
+
+	// SMS one-time code login
+	r.Post("/otp/request", h.RequestOTP)
+	r.Post("/otp/verify", h.VerifyOTP)
+
+	// Second step of a login for users with two-factor authentication
+	r.Post("/2fa/challenge", h.TwoFactorChallenge)
+
+	// Password reset
+	r.Post("/forgot-password", h.ForgotPassword)
+	r.Post("/reset-password", h.ResetPassword)
+
+	// Email verification
+	r.Post("/verify-email", h.VerifyEmail)
+	r.Post("/verify-email/resend", h.ResendVerificationEmail)
+
+	// Email change, confirmed from a link sent to the new address
+	r.Post("/email-change/confirm", h.ConfirmEmailChange)
+
+	// Accounts an admin invited by email
+	r.Post("/invitations/accept", h.AcceptInvitation)
 
 	// Protected routes
 	r.Group(func(r chi.Router) {
 		r.Use(h.authMiddleware.RequireAuth)
 		r.Get("/me", h.GetMe)
+		r.Put("/me/profile", h.UpdateProfile)
+		r.Put("/me/avatar", h.UploadAvatar)
+		r.Delete("/me/avatar", h.DeleteAvatar)
+		r.Post("/me/phone", h.RequestPhoneVerification)
+		r.Post("/me/phone/verify", h.VerifyPhone)
+		r.Post("/me/email", h.RequestEmailChange)
+		r.Post("/me/email/verify", h.SendVerificationEmail)
+		r.Get("/2fa", h.TwoFactorStatus)
+		r.Post("/2fa/enroll", h.EnrollTOTP)
+		r.Post("/2fa/enable", h.EnableTOTP)
+		r.Post("/2fa/disable", h.DisableTOTP)
+		r.Get("/2fa/recovery-codes", h.RecoveryCodes)
+		r.Post("/2fa/recovery-codes", h.RegenerateRecoveryCodes)
+		if h.sessions != nil {
+			r.Get("/sessions", h.ListSessions)
+			r.Delete("/sessions", h.RevokeOtherSessions)
+			r.Delete("/sessions/{id}", h.RevokeSession)
+		}
+		if h.deletion != nil {
+			r.Get("/me/deletion", h.GetAccountDeletion)
+			r.Post("/me/deletion", h.RequestAccountDeletion)
+			r.Delete("/me/deletion", h.CancelAccountDeletion)
+		}
 	})
 
 	return r
 }
+
+// rejectBot answers blocked submissions without revealing which check failed.
+func rejectBot(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, map[string]string{
+		"error": "submission rejected",
+	})
+}
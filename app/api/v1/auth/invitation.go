@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain/auth"
+	"go-template/domain/invitation"
+	"go-template/domain/passwordpolicy"
+	userDomain "go-template/domain/user"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+type AcceptInvitationRequest struct {
+	// Token is the code from the invitation email's link.
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+	// Audience is the application the token is for. Defaults to api.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+}
+
+// AcceptInvitation godoc
+//
+//	@Summary		Accept an emailed invitation
+//	@Description	Create the account an admin invited by email, with the password the invitee chose, and sign them in. The address counts as verified. The token works once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		AcceptInvitationRequest	true	"Invitation token and password"
+//	@Success		201		{object}	auth.AuthResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Failure		503		{object}	map[string]string
+//	@Router			/api/v1/auth/invitations/accept [post]
+func (h *AuthHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req AcceptInvitationRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.userUC.AcceptInvitation(r.Context(), req.Token, req.Password)
+	if err != nil {
+		var status int
+		var message string
+		switch {
+		case errors.Is(err, invitation.ErrInvalidCode):
+			status, message = http.StatusBadRequest, "invalid or expired invitation"
+		case errors.Is(err, passwordpolicy.ErrWeakPassword):
+			status, message = http.StatusBadRequest, err.Error()
+		case errors.Is(err, userDomain.ErrRegistrationDisabled):
+			status, message = http.StatusForbidden, err.Error()
+		case errors.Is(err, auth.ErrProviderUnavailable):
+			status, message = http.StatusServiceUnavailable, "authentication service unavailable, try again later"
+		default:
+			common.RespondError(w, r, err)
+			return
+		}
+		render.Status(r, status)
+		render.JSON(w, r, map[string]string{
+			"error": message,
+		})
+		return
+	}
+
+	response, err := h.authUC.IssueTokens(r.Context(), user, req.Audience)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to generate token",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
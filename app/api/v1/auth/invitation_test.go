@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"go-template/domain/invitation"
+	"go-template/domain/passwordpolicy"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthHandler_AcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "accepted", body: `{"token":"ABCD","password":"newpassword"}`, wantStatus: http.StatusCreated},
+		{name: "short password", body: `{"token":"ABCD","password":"123"}`, wantStatus: http.StatusBadRequest},
+		{name: "missing token", body: `{"password":"newpassword"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid token", body: `{"token":"ABCD","password":"newpassword"}`, err: invitation.ErrInvalidCode, wantStatus: http.StatusBadRequest},
+		{name: "weak password", body: `{"token":"ABCD","password":"newpassword"}`, err: fmt.Errorf("%w: it must contain a digit", passwordpolicy.ErrWeakPassword), wantStatus: http.StatusBadRequest},
+		{name: "already registered", body: `{"token":"ABCD","password":"newpassword"}`, err: domain.ErrDuplicateKey, wantStatus: http.StatusConflict},
+		{name: "provider down", body: `{"token":"ABCD","password":"newpassword"}`, err: auth.ErrProviderUnavailable, wantStatus: http.StatusServiceUnavailable},
+		{name: "failed", body: `{"token":"ABCD","password":"newpassword"}`, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userUC := &mocks.UserUseCaseMock{
+				AcceptInvitationFunc: func(ctx context.Context, code, password string) (entities.User, error) {
+					if tt.err != nil {
+						return entities.User{}, tt.err
+					}
+					return entities.User{Email: "new@x.com", EmailVerified: true}, nil
+				},
+			}
+			authUC := &mocks.AuthUseCaseMock{
+				IssueTokensFunc: func(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error) {
+					return auth.AuthResponse{Token: "token", User: user}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/invitations/accept", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusCreated && len(authUC.IssueTokensCalls()) != 1 {
+				t.Fatal("expected the invitee to be signed in")
+			}
+		})
+	}
+}
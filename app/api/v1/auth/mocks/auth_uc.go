@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"github.com/gofrs/uuid/v5"
 	"go-template/domain/auth"
+	"go-template/domain/entities"
 	"sync"
 )
 
@@ -15,9 +17,90 @@ import (
 //
 //		// make and configure a mocked auth.AuthUseCase
 //		mockedAuthUseCase := &AuthUseCaseMock{
+//			CaptchaFunc: func(ctx context.Context) (auth.CaptchaConfig, error) {
+//				panic("mock out the Captcha method")
+//			},
+//			CompleteTwoFactorFunc: func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+//				panic("mock out the CompleteTwoFactor method")
+//			},
+//			ConfirmEmailChangeFunc: func(ctx context.Context, token string) (entities.User, error) {
+//				panic("mock out the ConfirmEmailChange method")
+//			},
+//			DisableTOTPFunc: func(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error {
+//				panic("mock out the DisableTOTP method")
+//			},
+//			EmailVerificationRequiredFunc: func(ctx context.Context) (bool, error) {
+//				panic("mock out the EmailVerificationRequired method")
+//			},
+//			EnableTOTPFunc: func(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error) {
+//				panic("mock out the EnableTOTP method")
+//			},
+//			EnrollTOTPFunc: func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error) {
+//				panic("mock out the EnrollTOTP method")
+//			},
+//			ForgotPasswordFunc: func(ctx context.Context, email string) error {
+//				panic("mock out the ForgotPassword method")
+//			},
+//			IssueTokensFunc: func(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error) {
+//				panic("mock out the IssueTokens method")
+//			},
 //			LoginFunc: func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
 //				panic("mock out the Login method")
 //			},
+//			LoginWithCodeFunc: func(ctx context.Context, req auth.OTPLoginRequest) (auth.AuthResponse, error) {
+//				panic("mock out the LoginWithCode method")
+//			},
+//			LogoutFunc: func(ctx context.Context, refreshToken string) error {
+//				panic("mock out the Logout method")
+//			},
+//			RecoveryCodesRemainingFunc: func(ctx context.Context, userID uuid.UUID) (int, error) {
+//				panic("mock out the RecoveryCodesRemaining method")
+//			},
+//			RefreshFunc: func(ctx context.Context, refreshToken string) (auth.AuthResponse, error) {
+//				panic("mock out the Refresh method")
+//			},
+//			RegenerateRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error) {
+//				panic("mock out the RegenerateRecoveryCodes method")
+//			},
+//			RequestEmailChangeFunc: func(ctx context.Context, userID uuid.UUID, newEmail string) error {
+//				panic("mock out the RequestEmailChange method")
+//			},
+//			RequestLoginCodeFunc: func(ctx context.Context, phone string) error {
+//				panic("mock out the RequestLoginCode method")
+//			},
+//			RequestPhoneVerificationFunc: func(ctx context.Context, userID uuid.UUID, phone string) error {
+//				panic("mock out the RequestPhoneVerification method")
+//			},
+//			ResendVerificationEmailFunc: func(ctx context.Context, email string) error {
+//				panic("mock out the ResendVerificationEmail method")
+//			},
+//			ResetPasswordFunc: func(ctx context.Context, req auth.ResetPasswordRequest) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//			SendVerificationEmailFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the SendVerificationEmail method")
+//			},
+//			SocialAuthURLFunc: func(provider string, state string, redirectURI string) (string, error) {
+//				panic("mock out the SocialAuthURL method")
+//			},
+//			SocialLoginFunc: func(ctx context.Context, provider string, req auth.SocialLoginRequest) (auth.AuthResponse, error) {
+//				panic("mock out the SocialLogin method")
+//			},
+//			SocialProvidersFunc: func() []string {
+//				panic("mock out the SocialProviders method")
+//			},
+//			TwoFactorStatusFunc: func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error) {
+//				panic("mock out the TwoFactorStatus method")
+//			},
+//			VerifyCaptchaFunc: func(ctx context.Context, token string) error {
+//				panic("mock out the VerifyCaptcha method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, token string) (entities.User, error) {
+//				panic("mock out the VerifyEmail method")
+//			},
+//			VerifyPhoneFunc: func(ctx context.Context, userID uuid.UUID, phone string, code string) (entities.User, error) {
+//				panic("mock out the VerifyPhone method")
+//			},
 //		}
 //
 //		// use mockedAuthUseCase in code that requires auth.AuthUseCase
@@ -25,11 +108,161 @@ import (
 //
 //	}
 type AuthUseCaseMock struct {
+	// CaptchaFunc mocks the Captcha method.
+	CaptchaFunc func(ctx context.Context) (auth.CaptchaConfig, error)
+
+	// CompleteTwoFactorFunc mocks the CompleteTwoFactor method.
+	CompleteTwoFactorFunc func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error)
+
+	// ConfirmEmailChangeFunc mocks the ConfirmEmailChange method.
+	ConfirmEmailChangeFunc func(ctx context.Context, token string) (entities.User, error)
+
+	// DisableTOTPFunc mocks the DisableTOTP method.
+	DisableTOTPFunc func(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error
+
+	// EmailVerificationRequiredFunc mocks the EmailVerificationRequired method.
+	EmailVerificationRequiredFunc func(ctx context.Context) (bool, error)
+
+	// EnableTOTPFunc mocks the EnableTOTP method.
+	EnableTOTPFunc func(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error)
+
+	// EnrollTOTPFunc mocks the EnrollTOTP method.
+	EnrollTOTPFunc func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error)
+
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx context.Context, email string) error
+
+	// IssueTokensFunc mocks the IssueTokens method.
+	IssueTokensFunc func(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error)
+
 	// LoginFunc mocks the Login method.
 	LoginFunc func(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error)
 
+	// LoginWithCodeFunc mocks the LoginWithCode method.
+	LoginWithCodeFunc func(ctx context.Context, req auth.OTPLoginRequest) (auth.AuthResponse, error)
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, refreshToken string) error
+
+	// RecoveryCodesRemainingFunc mocks the RecoveryCodesRemaining method.
+	RecoveryCodesRemainingFunc func(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, refreshToken string) (auth.AuthResponse, error)
+
+	// RegenerateRecoveryCodesFunc mocks the RegenerateRecoveryCodes method.
+	RegenerateRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error)
+
+	// RequestEmailChangeFunc mocks the RequestEmailChange method.
+	RequestEmailChangeFunc func(ctx context.Context, userID uuid.UUID, newEmail string) error
+
+	// RequestLoginCodeFunc mocks the RequestLoginCode method.
+	RequestLoginCodeFunc func(ctx context.Context, phone string) error
+
+	// RequestPhoneVerificationFunc mocks the RequestPhoneVerification method.
+	RequestPhoneVerificationFunc func(ctx context.Context, userID uuid.UUID, phone string) error
+
+	// ResendVerificationEmailFunc mocks the ResendVerificationEmail method.
+	ResendVerificationEmailFunc func(ctx context.Context, email string) error
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, req auth.ResetPasswordRequest) error
+
+	// SendVerificationEmailFunc mocks the SendVerificationEmail method.
+	SendVerificationEmailFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// SocialAuthURLFunc mocks the SocialAuthURL method.
+	SocialAuthURLFunc func(provider string, state string, redirectURI string) (string, error)
+
+	// SocialLoginFunc mocks the SocialLogin method.
+	SocialLoginFunc func(ctx context.Context, provider string, req auth.SocialLoginRequest) (auth.AuthResponse, error)
+
+	// SocialProvidersFunc mocks the SocialProviders method.
+	SocialProvidersFunc func() []string
+
+	// TwoFactorStatusFunc mocks the TwoFactorStatus method.
+	TwoFactorStatusFunc func(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error)
+
+	// VerifyCaptchaFunc mocks the VerifyCaptcha method.
+	VerifyCaptchaFunc func(ctx context.Context, token string) error
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx context.Context, token string) (entities.User, error)
+
+	// VerifyPhoneFunc mocks the VerifyPhone method.
+	VerifyPhoneFunc func(ctx context.Context, userID uuid.UUID, phone string, code string) (entities.User, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Captcha holds details about calls to the Captcha method.
+		Captcha []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// CompleteTwoFactor holds details about calls to the CompleteTwoFactor method.
+		CompleteTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req auth.TwoFactorChallengeRequest
+		}
+		// ConfirmEmailChange holds details about calls to the ConfirmEmailChange method.
+		ConfirmEmailChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// DisableTOTP holds details about calls to the DisableTOTP method.
+		DisableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
+		}
+		// EmailVerificationRequired holds details about calls to the EmailVerificationRequired method.
+		EmailVerificationRequired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// EnableTOTP holds details about calls to the EnableTOTP method.
+		EnableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+			// Audience is the audience argument value.
+			Audience string
+		}
+		// EnrollTOTP holds details about calls to the EnrollTOTP method.
+		EnrollTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// IssueTokens holds details about calls to the IssueTokens method.
+		IssueTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User entities.User
+			// Audience is the audience argument value.
+			Audience string
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
 			// Ctx is the ctx argument value.
@@ -37,46 +270,1309 @@ type AuthUseCaseMock struct {
 			// Req is the req argument value.
 			Req auth.LoginRequest
 		}
+		// LoginWithCode holds details about calls to the LoginWithCode method.
+		LoginWithCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req auth.OTPLoginRequest
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// RecoveryCodesRemaining holds details about calls to the RecoveryCodesRemaining method.
+		RecoveryCodesRemaining []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// RegenerateRecoveryCodes holds details about calls to the RegenerateRecoveryCodes method.
+		RegenerateRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+		}
+		// RequestEmailChange holds details about calls to the RequestEmailChange method.
+		RequestEmailChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// NewEmail is the newEmail argument value.
+			NewEmail string
+		}
+		// RequestLoginCode holds details about calls to the RequestLoginCode method.
+		RequestLoginCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// RequestPhoneVerification holds details about calls to the RequestPhoneVerification method.
+		RequestPhoneVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// ResendVerificationEmail holds details about calls to the ResendVerificationEmail method.
+		ResendVerificationEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req auth.ResetPasswordRequest
+		}
+		// SendVerificationEmail holds details about calls to the SendVerificationEmail method.
+		SendVerificationEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// SocialAuthURL holds details about calls to the SocialAuthURL method.
+		SocialAuthURL []struct {
+			// Provider is the provider argument value.
+			Provider string
+			// State is the state argument value.
+			State string
+			// RedirectURI is the redirectURI argument value.
+			RedirectURI string
+		}
+		// SocialLogin holds details about calls to the SocialLogin method.
+		SocialLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Req is the req argument value.
+			Req auth.SocialLoginRequest
+		}
+		// SocialProviders holds details about calls to the SocialProviders method.
+		SocialProviders []struct {
+		}
+		// TwoFactorStatus holds details about calls to the TwoFactorStatus method.
+		TwoFactorStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// VerifyCaptcha holds details about calls to the VerifyCaptcha method.
+		VerifyCaptcha []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// VerifyPhone holds details about calls to the VerifyPhone method.
+		VerifyPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Phone is the phone argument value.
+			Phone string
+			// Code is the code argument value.
+			Code string
+		}
 	}
-	lockLogin sync.RWMutex
+	lockCaptcha                   sync.RWMutex
+	lockCompleteTwoFactor         sync.RWMutex
+	lockConfirmEmailChange        sync.RWMutex
+	lockDisableTOTP               sync.RWMutex
+	lockEmailVerificationRequired sync.RWMutex
+	lockEnableTOTP                sync.RWMutex
+	lockEnrollTOTP                sync.RWMutex
+	lockForgotPassword            sync.RWMutex
+	lockIssueTokens               sync.RWMutex
+	lockLogin                     sync.RWMutex
+	lockLoginWithCode             sync.RWMutex
+	lockLogout                    sync.RWMutex
+	lockRecoveryCodesRemaining    sync.RWMutex
+	lockRefresh                   sync.RWMutex
+	lockRegenerateRecoveryCodes   sync.RWMutex
+	lockRequestEmailChange        sync.RWMutex
+	lockRequestLoginCode          sync.RWMutex
+	lockRequestPhoneVerification  sync.RWMutex
+	lockResendVerificationEmail   sync.RWMutex
+	lockResetPassword             sync.RWMutex
+	lockSendVerificationEmail     sync.RWMutex
+	lockSocialAuthURL             sync.RWMutex
+	lockSocialLogin               sync.RWMutex
+	lockSocialProviders           sync.RWMutex
+	lockTwoFactorStatus           sync.RWMutex
+	lockVerifyCaptcha             sync.RWMutex
+	lockVerifyEmail               sync.RWMutex
+	lockVerifyPhone               sync.RWMutex
 }
 
-// Login calls LoginFunc.
-func (mock *AuthUseCaseMock) Login(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+// Captcha calls CaptchaFunc.
+func (mock *AuthUseCaseMock) Captcha(ctx context.Context) (auth.CaptchaConfig, error) {
 	callInfo := struct {
 		Ctx context.Context
-		Req auth.LoginRequest
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCaptcha.Lock()
+	mock.calls.Captcha = append(mock.calls.Captcha, callInfo)
+	mock.lockCaptcha.Unlock()
+	if mock.CaptchaFunc == nil {
+		var (
+			captchaConfigOut auth.CaptchaConfig
+			errOut           error
+		)
+		return captchaConfigOut, errOut
+	}
+	return mock.CaptchaFunc(ctx)
+}
+
+// CaptchaCalls gets all the calls that were made to Captcha.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.CaptchaCalls())
+func (mock *AuthUseCaseMock) CaptchaCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCaptcha.RLock()
+	calls = mock.calls.Captcha
+	mock.lockCaptcha.RUnlock()
+	return calls
+}
+
+// CompleteTwoFactor calls CompleteTwoFactorFunc.
+func (mock *AuthUseCaseMock) CompleteTwoFactor(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Req auth.TwoFactorChallengeRequest
 	}{
 		Ctx: ctx,
 		Req: req,
 	}
-	mock.lockLogin.Lock()
-	mock.calls.Login = append(mock.calls.Login, callInfo)
-	mock.lockLogin.Unlock()
-	if mock.LoginFunc == nil {
+	mock.lockCompleteTwoFactor.Lock()
+	mock.calls.CompleteTwoFactor = append(mock.calls.CompleteTwoFactor, callInfo)
+	mock.lockCompleteTwoFactor.Unlock()
+	if mock.CompleteTwoFactorFunc == nil {
 		var (
 			authResponseOut auth.AuthResponse
 			errOut          error
 		)
 		return authResponseOut, errOut
 	}
-	return mock.LoginFunc(ctx, req)
+	return mock.CompleteTwoFactorFunc(ctx, req)
 }
 
-// LoginCalls gets all the calls that were made to Login.
+// CompleteTwoFactorCalls gets all the calls that were made to CompleteTwoFactor.
 // Check the length with:
 //
-//	len(mockedAuthUseCase.LoginCalls())
-func (mock *AuthUseCaseMock) LoginCalls() []struct {
+//	len(mockedAuthUseCase.CompleteTwoFactorCalls())
+func (mock *AuthUseCaseMock) CompleteTwoFactorCalls() []struct {
 	Ctx context.Context
-	Req auth.LoginRequest
+	Req auth.TwoFactorChallengeRequest
 } {
 	var calls []struct {
 		Ctx context.Context
-		Req auth.LoginRequest
+		Req auth.TwoFactorChallengeRequest
 	}
-	mock.lockLogin.RLock()
-	calls = mock.calls.Login
-	mock.lockLogin.RUnlock()
+	mock.lockCompleteTwoFactor.RLock()
+	calls = mock.calls.CompleteTwoFactor
+	mock.lockCompleteTwoFactor.RUnlock()
+	return calls
+}
+
+// ConfirmEmailChange calls ConfirmEmailChangeFunc.
+func (mock *AuthUseCaseMock) ConfirmEmailChange(ctx context.Context, token string) (entities.User, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockConfirmEmailChange.Lock()
+	mock.calls.ConfirmEmailChange = append(mock.calls.ConfirmEmailChange, callInfo)
+	mock.lockConfirmEmailChange.Unlock()
+	if mock.ConfirmEmailChangeFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.ConfirmEmailChangeFunc(ctx, token)
+}
+
+// ConfirmEmailChangeCalls gets all the calls that were made to ConfirmEmailChange.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.ConfirmEmailChangeCalls())
+func (mock *AuthUseCaseMock) ConfirmEmailChangeCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockConfirmEmailChange.RLock()
+	calls = mock.calls.ConfirmEmailChange
+	mock.lockConfirmEmailChange.RUnlock()
+	return calls
+}
+
+// DisableTOTP calls DisableTOTPFunc.
+func (mock *AuthUseCaseMock) DisableTOTP(ctx context.Context, userID uuid.UUID, code string, recoveryCode string) error {
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockDisableTOTP.Lock()
+	mock.calls.DisableTOTP = append(mock.calls.DisableTOTP, callInfo)
+	mock.lockDisableTOTP.Unlock()
+	if mock.DisableTOTPFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DisableTOTPFunc(ctx, userID, code, recoveryCode)
+}
+
+// DisableTOTPCalls gets all the calls that were made to DisableTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.DisableTOTPCalls())
+func (mock *AuthUseCaseMock) DisableTOTPCalls() []struct {
+	Ctx          context.Context
+	UserID       uuid.UUID
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockDisableTOTP.RLock()
+	calls = mock.calls.DisableTOTP
+	mock.lockDisableTOTP.RUnlock()
+	return calls
+}
+
+// EmailVerificationRequired calls EmailVerificationRequiredFunc.
+func (mock *AuthUseCaseMock) EmailVerificationRequired(ctx context.Context) (bool, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockEmailVerificationRequired.Lock()
+	mock.calls.EmailVerificationRequired = append(mock.calls.EmailVerificationRequired, callInfo)
+	mock.lockEmailVerificationRequired.Unlock()
+	if mock.EmailVerificationRequiredFunc == nil {
+		var (
+			bOut   bool
+			errOut error
+		)
+		return bOut, errOut
+	}
+	return mock.EmailVerificationRequiredFunc(ctx)
+}
+
+// EmailVerificationRequiredCalls gets all the calls that were made to EmailVerificationRequired.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.EmailVerificationRequiredCalls())
+func (mock *AuthUseCaseMock) EmailVerificationRequiredCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockEmailVerificationRequired.RLock()
+	calls = mock.calls.EmailVerificationRequired
+	mock.lockEmailVerificationRequired.RUnlock()
+	return calls
+}
+
+// EnableTOTP calls EnableTOTPFunc.
+func (mock *AuthUseCaseMock) EnableTOTP(ctx context.Context, userID uuid.UUID, code string, audience string) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Code     string
+		Audience string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Code:     code,
+		Audience: audience,
+	}
+	mock.lockEnableTOTP.Lock()
+	mock.calls.EnableTOTP = append(mock.calls.EnableTOTP, callInfo)
+	mock.lockEnableTOTP.Unlock()
+	if mock.EnableTOTPFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.EnableTOTPFunc(ctx, userID, code, audience)
+}
+
+// EnableTOTPCalls gets all the calls that were made to EnableTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.EnableTOTPCalls())
+func (mock *AuthUseCaseMock) EnableTOTPCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Code     string
+	Audience string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Code     string
+		Audience string
+	}
+	mock.lockEnableTOTP.RLock()
+	calls = mock.calls.EnableTOTP
+	mock.lockEnableTOTP.RUnlock()
+	return calls
+}
+
+// EnrollTOTP calls EnrollTOTPFunc.
+func (mock *AuthUseCaseMock) EnrollTOTP(ctx context.Context, userID uuid.UUID) (auth.TwoFactorEnrollment, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockEnrollTOTP.Lock()
+	mock.calls.EnrollTOTP = append(mock.calls.EnrollTOTP, callInfo)
+	mock.lockEnrollTOTP.Unlock()
+	if mock.EnrollTOTPFunc == nil {
+		var (
+			twoFactorEnrollmentOut auth.TwoFactorEnrollment
+			errOut                 error
+		)
+		return twoFactorEnrollmentOut, errOut
+	}
+	return mock.EnrollTOTPFunc(ctx, userID)
+}
+
+// EnrollTOTPCalls gets all the calls that were made to EnrollTOTP.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.EnrollTOTPCalls())
+func (mock *AuthUseCaseMock) EnrollTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockEnrollTOTP.RLock()
+	calls = mock.calls.EnrollTOTP
+	mock.lockEnrollTOTP.RUnlock()
+	return calls
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *AuthUseCaseMock) ForgotPassword(ctx context.Context, email string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	mock.lockForgotPassword.Unlock()
+	if mock.ForgotPasswordFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ForgotPasswordFunc(ctx, email)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.ForgotPasswordCalls())
+func (mock *AuthUseCaseMock) ForgotPasswordCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	mock.lockForgotPassword.RUnlock()
+	return calls
+}
+
+// IssueTokens calls IssueTokensFunc.
+func (mock *AuthUseCaseMock) IssueTokens(ctx context.Context, user entities.User, audience string) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		User     entities.User
+		Audience string
+	}{
+		Ctx:      ctx,
+		User:     user,
+		Audience: audience,
+	}
+	mock.lockIssueTokens.Lock()
+	mock.calls.IssueTokens = append(mock.calls.IssueTokens, callInfo)
+	mock.lockIssueTokens.Unlock()
+	if mock.IssueTokensFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.IssueTokensFunc(ctx, user, audience)
+}
+
+// IssueTokensCalls gets all the calls that were made to IssueTokens.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.IssueTokensCalls())
+func (mock *AuthUseCaseMock) IssueTokensCalls() []struct {
+	Ctx      context.Context
+	User     entities.User
+	Audience string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		User     entities.User
+		Audience string
+	}
+	mock.lockIssueTokens.RLock()
+	calls = mock.calls.IssueTokens
+	mock.lockIssueTokens.RUnlock()
+	return calls
+}
+
+// Login calls LoginFunc.
+func (mock *AuthUseCaseMock) Login(ctx context.Context, req auth.LoginRequest) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Req auth.LoginRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	if mock.LoginFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.LoginFunc(ctx, req)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.LoginCalls())
+func (mock *AuthUseCaseMock) LoginCalls() []struct {
+	Ctx context.Context
+	Req auth.LoginRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req auth.LoginRequest
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// LoginWithCode calls LoginWithCodeFunc.
+func (mock *AuthUseCaseMock) LoginWithCode(ctx context.Context, req auth.OTPLoginRequest) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Req auth.OTPLoginRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockLoginWithCode.Lock()
+	mock.calls.LoginWithCode = append(mock.calls.LoginWithCode, callInfo)
+	mock.lockLoginWithCode.Unlock()
+	if mock.LoginWithCodeFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.LoginWithCodeFunc(ctx, req)
+}
+
+// LoginWithCodeCalls gets all the calls that were made to LoginWithCode.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.LoginWithCodeCalls())
+func (mock *AuthUseCaseMock) LoginWithCodeCalls() []struct {
+	Ctx context.Context
+	Req auth.OTPLoginRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req auth.OTPLoginRequest
+	}
+	mock.lockLoginWithCode.RLock()
+	calls = mock.calls.LoginWithCode
+	mock.lockLoginWithCode.RUnlock()
+	return calls
+}
+
+// Logout calls LogoutFunc.
+func (mock *AuthUseCaseMock) Logout(ctx context.Context, refreshToken string) error {
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	if mock.LogoutFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.LogoutFunc(ctx, refreshToken)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.LogoutCalls())
+func (mock *AuthUseCaseMock) LogoutCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// RecoveryCodesRemaining calls RecoveryCodesRemainingFunc.
+func (mock *AuthUseCaseMock) RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRecoveryCodesRemaining.Lock()
+	mock.calls.RecoveryCodesRemaining = append(mock.calls.RecoveryCodesRemaining, callInfo)
+	mock.lockRecoveryCodesRemaining.Unlock()
+	if mock.RecoveryCodesRemainingFunc == nil {
+		var (
+			nOut   int
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.RecoveryCodesRemainingFunc(ctx, userID)
+}
+
+// RecoveryCodesRemainingCalls gets all the calls that were made to RecoveryCodesRemaining.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RecoveryCodesRemainingCalls())
+func (mock *AuthUseCaseMock) RecoveryCodesRemainingCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRecoveryCodesRemaining.RLock()
+	calls = mock.calls.RecoveryCodesRemaining
+	mock.lockRecoveryCodesRemaining.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *AuthUseCaseMock) Refresh(ctx context.Context, refreshToken string) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	if mock.RefreshFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.RefreshFunc(ctx, refreshToken)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RefreshCalls())
+func (mock *AuthUseCaseMock) RefreshCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// RegenerateRecoveryCodes calls RegenerateRecoveryCodesFunc.
+func (mock *AuthUseCaseMock) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockRegenerateRecoveryCodes.Lock()
+	mock.calls.RegenerateRecoveryCodes = append(mock.calls.RegenerateRecoveryCodes, callInfo)
+	mock.lockRegenerateRecoveryCodes.Unlock()
+	if mock.RegenerateRecoveryCodesFunc == nil {
+		var (
+			twoFactorRecoveryCodesOut auth.TwoFactorRecoveryCodes
+			errOut                    error
+		)
+		return twoFactorRecoveryCodesOut, errOut
+	}
+	return mock.RegenerateRecoveryCodesFunc(ctx, userID, code)
+}
+
+// RegenerateRecoveryCodesCalls gets all the calls that were made to RegenerateRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RegenerateRecoveryCodesCalls())
+func (mock *AuthUseCaseMock) RegenerateRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}
+	mock.lockRegenerateRecoveryCodes.RLock()
+	calls = mock.calls.RegenerateRecoveryCodes
+	mock.lockRegenerateRecoveryCodes.RUnlock()
+	return calls
+}
+
+// RequestEmailChange calls RequestEmailChangeFunc.
+func (mock *AuthUseCaseMock) RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error {
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		NewEmail string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		NewEmail: newEmail,
+	}
+	mock.lockRequestEmailChange.Lock()
+	mock.calls.RequestEmailChange = append(mock.calls.RequestEmailChange, callInfo)
+	mock.lockRequestEmailChange.Unlock()
+	if mock.RequestEmailChangeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RequestEmailChangeFunc(ctx, userID, newEmail)
+}
+
+// RequestEmailChangeCalls gets all the calls that were made to RequestEmailChange.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RequestEmailChangeCalls())
+func (mock *AuthUseCaseMock) RequestEmailChangeCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	NewEmail string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		NewEmail string
+	}
+	mock.lockRequestEmailChange.RLock()
+	calls = mock.calls.RequestEmailChange
+	mock.lockRequestEmailChange.RUnlock()
+	return calls
+}
+
+// RequestLoginCode calls RequestLoginCodeFunc.
+func (mock *AuthUseCaseMock) RequestLoginCode(ctx context.Context, phone string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+	}
+	mock.lockRequestLoginCode.Lock()
+	mock.calls.RequestLoginCode = append(mock.calls.RequestLoginCode, callInfo)
+	mock.lockRequestLoginCode.Unlock()
+	if mock.RequestLoginCodeFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RequestLoginCodeFunc(ctx, phone)
+}
+
+// RequestLoginCodeCalls gets all the calls that were made to RequestLoginCode.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RequestLoginCodeCalls())
+func (mock *AuthUseCaseMock) RequestLoginCodeCalls() []struct {
+	Ctx   context.Context
+	Phone string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+	}
+	mock.lockRequestLoginCode.RLock()
+	calls = mock.calls.RequestLoginCode
+	mock.lockRequestLoginCode.RUnlock()
+	return calls
+}
+
+// RequestPhoneVerification calls RequestPhoneVerificationFunc.
+func (mock *AuthUseCaseMock) RequestPhoneVerification(ctx context.Context, userID uuid.UUID, phone string) error {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Phone:  phone,
+	}
+	mock.lockRequestPhoneVerification.Lock()
+	mock.calls.RequestPhoneVerification = append(mock.calls.RequestPhoneVerification, callInfo)
+	mock.lockRequestPhoneVerification.Unlock()
+	if mock.RequestPhoneVerificationFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RequestPhoneVerificationFunc(ctx, userID, phone)
+}
+
+// RequestPhoneVerificationCalls gets all the calls that were made to RequestPhoneVerification.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.RequestPhoneVerificationCalls())
+func (mock *AuthUseCaseMock) RequestPhoneVerificationCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Phone  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+	}
+	mock.lockRequestPhoneVerification.RLock()
+	calls = mock.calls.RequestPhoneVerification
+	mock.lockRequestPhoneVerification.RUnlock()
+	return calls
+}
+
+// ResendVerificationEmail calls ResendVerificationEmailFunc.
+func (mock *AuthUseCaseMock) ResendVerificationEmail(ctx context.Context, email string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockResendVerificationEmail.Lock()
+	mock.calls.ResendVerificationEmail = append(mock.calls.ResendVerificationEmail, callInfo)
+	mock.lockResendVerificationEmail.Unlock()
+	if mock.ResendVerificationEmailFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ResendVerificationEmailFunc(ctx, email)
+}
+
+// ResendVerificationEmailCalls gets all the calls that were made to ResendVerificationEmail.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.ResendVerificationEmailCalls())
+func (mock *AuthUseCaseMock) ResendVerificationEmailCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockResendVerificationEmail.RLock()
+	calls = mock.calls.ResendVerificationEmail
+	mock.lockResendVerificationEmail.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *AuthUseCaseMock) ResetPassword(ctx context.Context, req auth.ResetPasswordRequest) error {
+	callInfo := struct {
+		Ctx context.Context
+		Req auth.ResetPasswordRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	if mock.ResetPasswordFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ResetPasswordFunc(ctx, req)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.ResetPasswordCalls())
+func (mock *AuthUseCaseMock) ResetPasswordCalls() []struct {
+	Ctx context.Context
+	Req auth.ResetPasswordRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req auth.ResetPasswordRequest
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
+// SendVerificationEmail calls SendVerificationEmailFunc.
+func (mock *AuthUseCaseMock) SendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockSendVerificationEmail.Lock()
+	mock.calls.SendVerificationEmail = append(mock.calls.SendVerificationEmail, callInfo)
+	mock.lockSendVerificationEmail.Unlock()
+	if mock.SendVerificationEmailFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SendVerificationEmailFunc(ctx, userID)
+}
+
+// SendVerificationEmailCalls gets all the calls that were made to SendVerificationEmail.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.SendVerificationEmailCalls())
+func (mock *AuthUseCaseMock) SendVerificationEmailCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockSendVerificationEmail.RLock()
+	calls = mock.calls.SendVerificationEmail
+	mock.lockSendVerificationEmail.RUnlock()
+	return calls
+}
+
+// SocialAuthURL calls SocialAuthURLFunc.
+func (mock *AuthUseCaseMock) SocialAuthURL(provider string, state string, redirectURI string) (string, error) {
+	callInfo := struct {
+		Provider    string
+		State       string
+		RedirectURI string
+	}{
+		Provider:    provider,
+		State:       state,
+		RedirectURI: redirectURI,
+	}
+	mock.lockSocialAuthURL.Lock()
+	mock.calls.SocialAuthURL = append(mock.calls.SocialAuthURL, callInfo)
+	mock.lockSocialAuthURL.Unlock()
+	if mock.SocialAuthURLFunc == nil {
+		var (
+			sOut   string
+			errOut error
+		)
+		return sOut, errOut
+	}
+	return mock.SocialAuthURLFunc(provider, state, redirectURI)
+}
+
+// SocialAuthURLCalls gets all the calls that were made to SocialAuthURL.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.SocialAuthURLCalls())
+func (mock *AuthUseCaseMock) SocialAuthURLCalls() []struct {
+	Provider    string
+	State       string
+	RedirectURI string
+} {
+	var calls []struct {
+		Provider    string
+		State       string
+		RedirectURI string
+	}
+	mock.lockSocialAuthURL.RLock()
+	calls = mock.calls.SocialAuthURL
+	mock.lockSocialAuthURL.RUnlock()
+	return calls
+}
+
+// SocialLogin calls SocialLoginFunc.
+func (mock *AuthUseCaseMock) SocialLogin(ctx context.Context, provider string, req auth.SocialLoginRequest) (auth.AuthResponse, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Req      auth.SocialLoginRequest
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Req:      req,
+	}
+	mock.lockSocialLogin.Lock()
+	mock.calls.SocialLogin = append(mock.calls.SocialLogin, callInfo)
+	mock.lockSocialLogin.Unlock()
+	if mock.SocialLoginFunc == nil {
+		var (
+			authResponseOut auth.AuthResponse
+			errOut          error
+		)
+		return authResponseOut, errOut
+	}
+	return mock.SocialLoginFunc(ctx, provider, req)
+}
+
+// SocialLoginCalls gets all the calls that were made to SocialLogin.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.SocialLoginCalls())
+func (mock *AuthUseCaseMock) SocialLoginCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Req      auth.SocialLoginRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Req      auth.SocialLoginRequest
+	}
+	mock.lockSocialLogin.RLock()
+	calls = mock.calls.SocialLogin
+	mock.lockSocialLogin.RUnlock()
+	return calls
+}
+
+// SocialProviders calls SocialProvidersFunc.
+func (mock *AuthUseCaseMock) SocialProviders() []string {
+	callInfo := struct {
+	}{}
+	mock.lockSocialProviders.Lock()
+	mock.calls.SocialProviders = append(mock.calls.SocialProviders, callInfo)
+	mock.lockSocialProviders.Unlock()
+	if mock.SocialProvidersFunc == nil {
+		var (
+			stringsOut []string
+		)
+		return stringsOut
+	}
+	return mock.SocialProvidersFunc()
+}
+
+// SocialProvidersCalls gets all the calls that were made to SocialProviders.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.SocialProvidersCalls())
+func (mock *AuthUseCaseMock) SocialProvidersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSocialProviders.RLock()
+	calls = mock.calls.SocialProviders
+	mock.lockSocialProviders.RUnlock()
+	return calls
+}
+
+// TwoFactorStatus calls TwoFactorStatusFunc.
+func (mock *AuthUseCaseMock) TwoFactorStatus(ctx context.Context, userID uuid.UUID) (auth.TwoFactorStatus, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockTwoFactorStatus.Lock()
+	mock.calls.TwoFactorStatus = append(mock.calls.TwoFactorStatus, callInfo)
+	mock.lockTwoFactorStatus.Unlock()
+	if mock.TwoFactorStatusFunc == nil {
+		var (
+			twoFactorStatusOut auth.TwoFactorStatus
+			errOut             error
+		)
+		return twoFactorStatusOut, errOut
+	}
+	return mock.TwoFactorStatusFunc(ctx, userID)
+}
+
+// TwoFactorStatusCalls gets all the calls that were made to TwoFactorStatus.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.TwoFactorStatusCalls())
+func (mock *AuthUseCaseMock) TwoFactorStatusCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockTwoFactorStatus.RLock()
+	calls = mock.calls.TwoFactorStatus
+	mock.lockTwoFactorStatus.RUnlock()
+	return calls
+}
+
+// VerifyCaptcha calls VerifyCaptchaFunc.
+func (mock *AuthUseCaseMock) VerifyCaptcha(ctx context.Context, token string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockVerifyCaptcha.Lock()
+	mock.calls.VerifyCaptcha = append(mock.calls.VerifyCaptcha, callInfo)
+	mock.lockVerifyCaptcha.Unlock()
+	if mock.VerifyCaptchaFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.VerifyCaptchaFunc(ctx, token)
+}
+
+// VerifyCaptchaCalls gets all the calls that were made to VerifyCaptcha.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.VerifyCaptchaCalls())
+func (mock *AuthUseCaseMock) VerifyCaptchaCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockVerifyCaptcha.RLock()
+	calls = mock.calls.VerifyCaptcha
+	mock.lockVerifyCaptcha.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *AuthUseCaseMock) VerifyEmail(ctx context.Context, token string) (entities.User, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	mock.lockVerifyEmail.Unlock()
+	if mock.VerifyEmailFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.VerifyEmailFunc(ctx, token)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.VerifyEmailCalls())
+func (mock *AuthUseCaseMock) VerifyEmailCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	mock.lockVerifyEmail.RUnlock()
+	return calls
+}
+
+// VerifyPhone calls VerifyPhoneFunc.
+func (mock *AuthUseCaseMock) VerifyPhone(ctx context.Context, userID uuid.UUID, phone string, code string) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Phone:  phone,
+		Code:   code,
+	}
+	mock.lockVerifyPhone.Lock()
+	mock.calls.VerifyPhone = append(mock.calls.VerifyPhone, callInfo)
+	mock.lockVerifyPhone.Unlock()
+	if mock.VerifyPhoneFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.VerifyPhoneFunc(ctx, userID, phone, code)
+}
+
+// VerifyPhoneCalls gets all the calls that were made to VerifyPhone.
+// Check the length with:
+//
+//	len(mockedAuthUseCase.VerifyPhoneCalls())
+func (mock *AuthUseCaseMock) VerifyPhoneCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Phone  string
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+		Code   string
+	}
+	mock.lockVerifyPhone.RLock()
+	calls = mock.calls.VerifyPhone
+	mock.lockVerifyPhone.RUnlock()
 	return calls
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/gofrs/uuid/v5"
 	"go-template/domain/entities"
+	"io"
 	"sync"
 )
 
@@ -16,12 +17,24 @@ import (
 //
 //		// make and configure a mocked auth.UserUseCase
 //		mockedUserUseCase := &UserUseCaseMock{
-//			CreateUserFunc: func(ctx context.Context, email string, password string, authProvider string, accountType entities.AccountType) (entities.User, error) {
-//				panic("mock out the CreateUser method")
+//			AcceptInvitationFunc: func(ctx context.Context, code string, password string) (entities.User, error) {
+//				panic("mock out the AcceptInvitation method")
+//			},
+//			DeleteAvatarFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+//				panic("mock out the DeleteAvatar method")
 //			},
 //			GetMeFunc: func(ctx context.Context, userID uuid.UUID) (entities.User, error) {
 //				panic("mock out the GetMe method")
 //			},
+//			RegisterFunc: func(ctx context.Context, email string, password string, invitationCode string) (entities.User, error) {
+//				panic("mock out the Register method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+//				panic("mock out the UpdateProfile method")
+//			},
+//			UploadAvatarFunc: func(ctx context.Context, userID uuid.UUID, body io.Reader) (entities.User, error) {
+//				panic("mock out the UploadAvatar method")
+//			},
 //		}
 //
 //		// use mockedUserUseCase in code that requires auth.UserUseCase
@@ -29,26 +42,41 @@ import (
 //
 //	}
 type UserUseCaseMock struct {
-	// CreateUserFunc mocks the CreateUser method.
-	CreateUserFunc func(ctx context.Context, email string, password string, authProvider string, accountType entities.AccountType) (entities.User, error)
+	// AcceptInvitationFunc mocks the AcceptInvitation method.
+	AcceptInvitationFunc func(ctx context.Context, code string, password string) (entities.User, error)
+
+	// DeleteAvatarFunc mocks the DeleteAvatar method.
+	DeleteAvatarFunc func(ctx context.Context, userID uuid.UUID) (entities.User, error)
 
 	// GetMeFunc mocks the GetMe method.
 	GetMeFunc func(ctx context.Context, userID uuid.UUID) (entities.User, error)
 
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(ctx context.Context, email string, password string, invitationCode string) (entities.User, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error)
+
+	// UploadAvatarFunc mocks the UploadAvatar method.
+	UploadAvatarFunc func(ctx context.Context, userID uuid.UUID, body io.Reader) (entities.User, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
-		// CreateUser holds details about calls to the CreateUser method.
-		CreateUser []struct {
+		// AcceptInvitation holds details about calls to the AcceptInvitation method.
+		AcceptInvitation []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Email is the email argument value.
-			Email string
+			// Code is the code argument value.
+			Code string
 			// Password is the password argument value.
 			Password string
-			// AuthProvider is the authProvider argument value.
-			AuthProvider string
-			// AccountType is the accountType argument value.
-			AccountType entities.AccountType
+		}
+		// DeleteAvatar holds details about calls to the DeleteAvatar method.
+		DeleteAvatar []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
 		}
 		// GetMe holds details about calls to the GetMe method.
 		GetMe []struct {
@@ -57,60 +85,125 @@ type UserUseCaseMock struct {
 			// UserID is the userID argument value.
 			UserID uuid.UUID
 		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+			// Password is the password argument value.
+			Password string
+			// InvitationCode is the invitationCode argument value.
+			InvitationCode string
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Profile is the profile argument value.
+			Profile entities.UserProfile
+		}
+		// UploadAvatar holds details about calls to the UploadAvatar method.
+		UploadAvatar []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Body is the body argument value.
+			Body io.Reader
+		}
 	}
-	lockCreateUser sync.RWMutex
-	lockGetMe      sync.RWMutex
+	lockAcceptInvitation sync.RWMutex
+	lockDeleteAvatar     sync.RWMutex
+	lockGetMe            sync.RWMutex
+	lockRegister         sync.RWMutex
+	lockUpdateProfile    sync.RWMutex
+	lockUploadAvatar     sync.RWMutex
 }
 
-// CreateUser calls CreateUserFunc.
-func (mock *UserUseCaseMock) CreateUser(ctx context.Context, email string, password string, authProvider string, accountType entities.AccountType) (entities.User, error) {
+// AcceptInvitation calls AcceptInvitationFunc.
+func (mock *UserUseCaseMock) AcceptInvitation(ctx context.Context, code string, password string) (entities.User, error) {
 	callInfo := struct {
-		Ctx          context.Context
-		Email        string
-		Password     string
-		AuthProvider string
-		AccountType  entities.AccountType
+		Ctx      context.Context
+		Code     string
+		Password string
 	}{
-		Ctx:          ctx,
-		Email:        email,
-		Password:     password,
-		AuthProvider: authProvider,
-		AccountType:  accountType,
-	}
-	mock.lockCreateUser.Lock()
-	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
-	mock.lockCreateUser.Unlock()
-	if mock.CreateUserFunc == nil {
+		Ctx:      ctx,
+		Code:     code,
+		Password: password,
+	}
+	mock.lockAcceptInvitation.Lock()
+	mock.calls.AcceptInvitation = append(mock.calls.AcceptInvitation, callInfo)
+	mock.lockAcceptInvitation.Unlock()
+	if mock.AcceptInvitationFunc == nil {
 		var (
 			userOut entities.User
 			errOut  error
 		)
 		return userOut, errOut
 	}
-	return mock.CreateUserFunc(ctx, email, password, authProvider, accountType)
+	return mock.AcceptInvitationFunc(ctx, code, password)
 }
 
-// CreateUserCalls gets all the calls that were made to CreateUser.
+// AcceptInvitationCalls gets all the calls that were made to AcceptInvitation.
 // Check the length with:
 //
-//	len(mockedUserUseCase.CreateUserCalls())
-func (mock *UserUseCaseMock) CreateUserCalls() []struct {
-	Ctx          context.Context
-	Email        string
-	Password     string
-	AuthProvider string
-	AccountType  entities.AccountType
+//	len(mockedUserUseCase.AcceptInvitationCalls())
+func (mock *UserUseCaseMock) AcceptInvitationCalls() []struct {
+	Ctx      context.Context
+	Code     string
+	Password string
 } {
 	var calls []struct {
-		Ctx          context.Context
-		Email        string
-		Password     string
-		AuthProvider string
-		AccountType  entities.AccountType
-	}
-	mock.lockCreateUser.RLock()
-	calls = mock.calls.CreateUser
-	mock.lockCreateUser.RUnlock()
+		Ctx      context.Context
+		Code     string
+		Password string
+	}
+	mock.lockAcceptInvitation.RLock()
+	calls = mock.calls.AcceptInvitation
+	mock.lockAcceptInvitation.RUnlock()
+	return calls
+}
+
+// DeleteAvatar calls DeleteAvatarFunc.
+func (mock *UserUseCaseMock) DeleteAvatar(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAvatar.Lock()
+	mock.calls.DeleteAvatar = append(mock.calls.DeleteAvatar, callInfo)
+	mock.lockDeleteAvatar.Unlock()
+	if mock.DeleteAvatarFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.DeleteAvatarFunc(ctx, userID)
+}
+
+// DeleteAvatarCalls gets all the calls that were made to DeleteAvatar.
+// Check the length with:
+//
+//	len(mockedUserUseCase.DeleteAvatarCalls())
+func (mock *UserUseCaseMock) DeleteAvatarCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockDeleteAvatar.RLock()
+	calls = mock.calls.DeleteAvatar
+	mock.lockDeleteAvatar.RUnlock()
 	return calls
 }
 
@@ -153,3 +246,139 @@ func (mock *UserUseCaseMock) GetMeCalls() []struct {
 	mock.lockGetMe.RUnlock()
 	return calls
 }
+
+// Register calls RegisterFunc.
+func (mock *UserUseCaseMock) Register(ctx context.Context, email string, password string, invitationCode string) (entities.User, error) {
+	callInfo := struct {
+		Ctx            context.Context
+		Email          string
+		Password       string
+		InvitationCode string
+	}{
+		Ctx:            ctx,
+		Email:          email,
+		Password:       password,
+		InvitationCode: invitationCode,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	if mock.RegisterFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.RegisterFunc(ctx, email, password, invitationCode)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedUserUseCase.RegisterCalls())
+func (mock *UserUseCaseMock) RegisterCalls() []struct {
+	Ctx            context.Context
+	Email          string
+	Password       string
+	InvitationCode string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Email          string
+		Password       string
+		InvitationCode string
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UserUseCaseMock) UpdateProfile(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		Profile entities.UserProfile
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		Profile: profile,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	if mock.UpdateProfileFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.UpdateProfileFunc(ctx, userID, profile)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUserUseCase.UpdateProfileCalls())
+func (mock *UserUseCaseMock) UpdateProfileCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	Profile entities.UserProfile
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		Profile entities.UserProfile
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
+// UploadAvatar calls UploadAvatarFunc.
+func (mock *UserUseCaseMock) UploadAvatar(ctx context.Context, userID uuid.UUID, body io.Reader) (entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Body   io.Reader
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Body:   body,
+	}
+	mock.lockUploadAvatar.Lock()
+	mock.calls.UploadAvatar = append(mock.calls.UploadAvatar, callInfo)
+	mock.lockUploadAvatar.Unlock()
+	if mock.UploadAvatarFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.UploadAvatarFunc(ctx, userID, body)
+}
+
+// UploadAvatarCalls gets all the calls that were made to UploadAvatar.
+// Check the length with:
+//
+//	len(mockedUserUseCase.UploadAvatarCalls())
+func (mock *UserUseCaseMock) UploadAvatarCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Body   io.Reader
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Body   io.Reader
+	}
+	mock.lockUploadAvatar.RLock()
+	calls = mock.calls.UploadAvatar
+	mock.lockUploadAvatar.RUnlock()
+	return calls
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// RequestOTP godoc
+//
+//	@Summary		Request an SMS login code
+//	@Description	Send a one-time login code to a verified phone number. The response is the same whether or not the number belongs to a user.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.OTPRequest	true	"Phone number in E.164 format"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/otp/request [post]
+func (h *AuthHandler) RequestOTP(w http.ResponseWriter, r *http.Request) {
+	var req auth.OTPRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.RequestLoginCode(r.Context(), req.Phone); err != nil {
+		writeOTPError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "if the number is registered, a code has been sent",
+	})
+}
+
+// VerifyOTP godoc
+//
+//	@Summary		Sign in with an SMS code
+//	@Description	Exchange a one-time code sent to the user's phone for tokens
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.OTPLoginRequest	true	"Phone number and code"
+//	@Success		200		{object}	auth.AuthResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/otp/verify [post]
+func (h *AuthHandler) VerifyOTP(w http.ResponseWriter, r *http.Request) {
+	var req auth.OTPLoginRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.authUC.LoginWithCode(r.Context(), req)
+	if err != nil {
+		writeOTPError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// RequestPhoneVerification godoc
+//
+//	@Summary		Add a phone number
+//	@Description	Send a code to the phone number. It becomes the user's number once the code is verified.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.OTPRequest	true	"Phone number in E.164 format"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/phone [post]
+func (h *AuthHandler) RequestPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.OTPRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.RequestPhoneVerification(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Phone); err != nil {
+		writeOTPError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "a code has been sent",
+	})
+}
+
+// VerifyPhone godoc
+//
+//	@Summary		Verify a phone number
+//	@Description	Confirm the code sent to the phone number and store it on the user
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.VerifyPhoneRequest	true	"Phone number and code"
+//	@Success		200		{object}	entities.User
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/phone/verify [post]
+func (h *AuthHandler) VerifyPhone(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.VerifyPhoneRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.authUC.VerifyPhone(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Phone, req.Code)
+	if err != nil {
+		writeOTPError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
+
+func (h *AuthHandler) decodeOTPRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := render.DecodeJSON(r.Body, req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return false
+	}
+	return true
+}
+
+func writeOTPError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrSMSLoginDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrInvalidPhone):
+		status = http.StatusBadRequest
+	case errors.Is(err, auth.ErrInvalidOTP):
+		status = http.StatusUnauthorized
+	case errors.Is(err, auth.ErrOTPRequestTooSoon):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, auth.ErrPhoneInUse):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrEmailNotVerified):
+		status = http.StatusForbidden
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_RequestOTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "sent", body: `{"phone":"+15550001111"}`, wantStatus: http.StatusAccepted},
+		{name: "missing phone", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid phone", body: `{"phone":"555"}`, err: auth.ErrInvalidPhone, wantStatus: http.StatusBadRequest},
+		{name: "too soon", body: `{"phone":"+15550001111"}`, err: auth.ErrOTPRequestTooSoon, wantStatus: http.StatusTooManyRequests},
+		{name: "disabled", body: `{"phone":"+15550001111"}`, err: auth.ErrSMSLoginDisabled, wantStatus: http.StatusNotFound},
+		{name: "gateway failed", body: `{"phone":"+15550001111"}`, err: errors.New("twilio down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				RequestLoginCodeFunc: func(ctx context.Context, phone string) error {
+					return tt.err
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/otp/request", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_VerifyOTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "signed in", body: `{"phone":"+15550001111","code":"123456"}`, wantStatus: http.StatusOK},
+		{name: "missing code", body: `{"phone":"+15550001111"}`, wantStatus: http.StatusBadRequest},
+		{name: "wrong code", body: `{"phone":"+15550001111","code":"000000"}`, err: auth.ErrInvalidOTP, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				LoginWithCodeFunc: func(ctx context.Context, req auth.OTPLoginRequest) (auth.AuthResponse, error) {
+					if tt.err != nil {
+						return auth.AuthResponse{}, tt.err
+					}
+					return auth.AuthResponse{Token: "token", RefreshToken: "rt_token"}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/otp/verify", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_VerifyPhone(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, _ := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+
+	authUC := &mocks.AuthUseCaseMock{
+		RequestPhoneVerificationFunc: func(ctx context.Context, id uuid.UUID, phone string) error {
+			if phone == "+15559999999" {
+				return auth.ErrPhoneInUse
+			}
+			return nil
+		},
+		VerifyPhoneFunc: func(ctx context.Context, id uuid.UUID, phone, code string) (entities.User, error) {
+			return entities.User{ID: id, Phone: phone}, nil
+		},
+	}
+	h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	router := h.Routes()
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		auth       bool
+		wantStatus int
+	}{
+		{name: "unauthenticated", target: "/me/phone", body: `{"phone":"+15550001111"}`, wantStatus: http.StatusUnauthorized},
+		{name: "code sent", target: "/me/phone", body: `{"phone":"+15550001111"}`, auth: true, wantStatus: http.StatusAccepted},
+		{name: "phone in use", target: "/me/phone", body: `{"phone":"+15559999999"}`, auth: true, wantStatus: http.StatusConflict},
+		{name: "verified", target: "/me/phone/verify", body: `{"phone":"+15550001111","code":"123456"}`, auth: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString(tt.body))
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	calls := authUC.VerifyPhoneCalls()
+	if len(calls) != 1 || calls[0].UserID != userID {
+		t.Fatalf("unexpected verify calls: %+v", calls)
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain/auth"
+	"go-template/domain/passwordpolicy"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// ForgotPassword godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Email a single-use password reset link. The response is the same whether or not the email belongs to a user.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.ForgotPasswordRequest	true	"Account email"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req auth.ForgotPasswordRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.ForgotPassword(r.Context(), req.Email); err != nil {
+		writePasswordResetError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, map[string]string{
+		"message": "if the email is registered, a reset link has been sent",
+	})
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset a password
+//	@Description	Set a new password with the token from a password reset email. The token works once, and the user's other sessions are signed out.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req auth.ResetPasswordRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.ResetPassword(r.Context(), req); err != nil {
+		writePasswordResetError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "password has been reset",
+	})
+}
+
+func writePasswordResetError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrPasswordResetDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrInvalidResetToken), errors.Is(err, passwordpolicy.ErrWeakPassword):
+		status = http.StatusBadRequest
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/passwordpolicy"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthHandler_ForgotPassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "sent", body: `{"email":"a@b.com"}`, wantStatus: http.StatusAccepted},
+		{name: "invalid email", body: `{"email":"nope"}`, wantStatus: http.StatusBadRequest},
+		{name: "disabled", body: `{"email":"a@b.com"}`, err: auth.ErrPasswordResetDisabled, wantStatus: http.StatusNotFound},
+		{name: "mailer failed", body: `{"email":"a@b.com"}`, err: errors.New("smtp down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				ForgotPasswordFunc: func(ctx context.Context, email string) error {
+					return tt.err
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "reset", body: `{"token":"abc","password":"newpassword"}`, wantStatus: http.StatusOK},
+		{name: "short password", body: `{"token":"abc","password":"123"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid token", body: `{"token":"abc","password":"newpassword"}`, err: auth.ErrInvalidResetToken, wantStatus: http.StatusBadRequest},
+		{name: "weak password", body: `{"token":"abc","password":"newpassword"}`, err: fmt.Errorf("%w: it must contain a digit", passwordpolicy.ErrWeakPassword), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				ResetPasswordFunc: func(ctx context.Context, req auth.ResetPasswordRequest) error {
+					return tt.err
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reset-password", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
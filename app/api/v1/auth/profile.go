@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+type UpdateProfileRequest struct {
+	FirstName   string         `json:"first_name"`
+	LastName    string         `json:"last_name"`
+	DisplayName string         `json:"display_name"`
+	Timezone    string         `json:"timezone"`
+	Locale      string         `json:"locale"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Update the current user's profile
+//	@Description	Replace the signed in user's names, timezone (IANA name) and locale (BCP 47 tag). Metadata is kept when left out.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	UpdateProfileRequest	true	"Profile"
+//	@Success		200	{object}	entities.User
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/profile [put]
+func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	user, err := h.userUC.UpdateProfile(r.Context(), principal.UserID, entities.UserProfile(req))
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_UpdateProfile(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	userUC := &mocks.UserUseCaseMock{
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+			if profile.Timezone == "Mars/Olympus" {
+				return entities.User{}, fmt.Errorf("unknown timezone: %w", domain.ErrMalformedParameters)
+			}
+			return entities.User{ID: id, Email: "a@b.com", UserProfile: profile}, nil
+		},
+	}
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, userUC, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/me/profile", strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(`{}`, false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if w := serve(`{`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad body, got %d", w.Code)
+	}
+	if w := serve(`{"timezone":"Mars/Olympus"}`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown timezone, got %d", w.Code)
+	}
+
+	w := serve(`{"first_name":"Ada","last_name":"Lovelace","timezone":"Europe/London","locale":"en-GB","metadata":{"plan":"pro"}}`, true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var user entities.User
+	if err := json.NewDecoder(w.Body).Decode(&user); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if user.FirstName != "Ada" || user.Timezone != "Europe/London" || user.Metadata["plan"] != "pro" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	calls := userUC.UpdateProfileCalls()
+	if len(calls) != 2 || calls[1].UserID != userID {
+		t.Fatalf("expected the caller's profile to be updated, got %+v", calls)
+	}
+}
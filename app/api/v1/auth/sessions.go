@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// ListSessions godoc
+//
+//	@Summary		List sessions
+//	@Description	List the signed in user's sessions with their device, IP address and last activity, most recent first
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.SessionInfo
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/sessions [get]
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	sessions, err := h.sessions.List(r.Context(), principal.UserID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list sessions", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list sessions",
+		})
+		return
+	}
+
+	current := principal.Claims.Session()
+	resp := make([]entities.SessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, entities.SessionInfo{
+			ID:         s.ID,
+			Device:     s.Device(),
+			IPAddress:  s.IPAddress,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.ID == current,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke a session
+//	@Description	Sign the user out of one of their sessions. Its refresh token stops working and its access token is rejected from then on.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Session ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	err := h.sessions.Revoke(r.Context(), principal.UserID, chi.URLParam(r, "id"))
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "session"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "session revoked",
+	})
+}
+
+// RevokeOtherSessions godoc
+//
+//	@Summary		Sign out other sessions
+//	@Description	Revoke every session of the signed in user except the one the request is made with
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]int
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/sessions [delete]
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	revoked, err := h.sessions.RevokeOthers(r.Context(), principal.UserID, principal.Claims.Session())
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to revoke other sessions", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to revoke sessions",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]int{
+		"revoked": revoked,
+	})
+}
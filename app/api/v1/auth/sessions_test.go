@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_Sessions(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	claims := jwtService.NewClaims(userID.String(), "a@b.com", entities.AccountTypeUser.String(), jwtService.Expiry())
+	claims.SessionID = "current"
+	token, err := jwtService.Sign(claims)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+
+	sessionUC := &mocks.SessionUseCaseMock{
+		ListFunc: func(ctx context.Context, id uuid.UUID) ([]entities.Session, error) {
+			return []entities.Session{
+				{ID: "current", UserID: id, UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"},
+				{ID: "other", UserID: id},
+			}, nil
+		},
+		RevokeFunc: func(ctx context.Context, id uuid.UUID, sessionID string) error {
+			if sessionID != "other" {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+		RevokeOthersFunc: func(ctx context.Context, id uuid.UUID, current string) (int, error) {
+			return 1, nil
+		},
+	}
+	h := NewAuthHandler(&mocks.AuthUseCaseMock{}, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	h.SetSessionUseCase(sessionUC)
+
+	serve := func(method, target string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodGet, "/sessions", false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+
+	w := serve(http.MethodGet, "/sessions", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var sessions []entities.SessionInfo
+	if err := json.NewDecoder(w.Body).Decode(&sessions); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(sessions) != 2 || !sessions[0].Current || sessions[1].Current {
+		t.Fatalf("expected the first session to be current, got %+v", sessions)
+	}
+	if sessions[0].Device != "Firefox on Linux" {
+		t.Fatalf("unexpected device %q", sessions[0].Device)
+	}
+	if got := sessionUC.ListCalls()[0].UserID; got != userID {
+		t.Fatalf("expected sessions of %s, got %s", userID, got)
+	}
+
+	if w := serve(http.MethodDelete, "/sessions/other", true); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(http.MethodDelete, "/sessions/unknown", true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	if w := serve(http.MethodDelete, "/sessions", true); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if got := sessionUC.RevokeOthersCalls()[0].Current; got != "current" {
+		t.Fatalf("expected the current session to be kept, got %q", got)
+	}
+}
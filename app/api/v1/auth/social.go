@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type SocialProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+type SocialAuthURLResponse struct {
+	URL string `json:"url"`
+}
+
+// SocialProviders godoc
+//
+//	@Summary		List social login providers
+//	@Description	List the enabled social login providers
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	SocialProvidersResponse
+//	@Router			/api/v1/auth/social [get]
+func (h *AuthHandler) SocialProviders(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, SocialProvidersResponse{
+		Providers: h.authUC.SocialProviders(),
+	})
+}
+
+// SocialAuthURL godoc
+//
+//	@Summary		Start social login
+//	@Description	Get the provider page to send the user to. The provider redirects back to redirect_uri with the given state and an authorization code.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			provider		path	string	true	"Provider (google or github)"
+//	@Param			redirect_uri	query	string	true	"Callback URL registered with the provider"
+//	@Param			state			query	string	true	"Opaque value checked by the caller on the callback"
+//	@Success		200	{object}	SocialAuthURLResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Router			/api/v1/auth/social/{provider} [get]
+func (h *AuthHandler) SocialAuthURL(w http.ResponseWriter, r *http.Request) {
+	redirectURI := r.URL.Query().Get("redirect_uri")
+	state := r.URL.Query().Get("state")
+	if redirectURI == "" || state == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "redirect_uri and state are required",
+		})
+		return
+	}
+
+	url, err := h.authUC.SocialAuthURL(chi.URLParam(r, "provider"), state, redirectURI)
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "unknown provider",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, SocialAuthURLResponse{URL: url})
+}
+
+// SocialCallback godoc
+//
+//	@Summary		Complete social login
+//	@Description	Exchange the authorization code returned by the provider for tokens. Users are linked to existing accounts by email and created when unknown.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	path	string					true	"Provider (google or github)"
+//	@Param			request		body	auth.SocialLoginRequest	true	"Callback request"
+//	@Success		200	{object}	auth.AuthResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/social/{provider}/callback [post]
+func (h *AuthHandler) SocialCallback(w http.ResponseWriter, r *http.Request) {
+	var req auth.SocialLoginRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	response, err := h.authUC.SocialLogin(r.Context(), chi.URLParam(r, "provider"), req)
+	if err != nil {
+		if common.AccountStatusResponse(w, r, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, auth.ErrUnknownSocialProvider):
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, map[string]string{
+				"error": "unknown provider",
+			})
+		case errors.Is(err, auth.ErrSocialEmailUnverified):
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "a verified email is required",
+			})
+		default:
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "authentication failed",
+			})
+		}
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthHandler_SocialAuthURL(t *testing.T) {
+	authUC := &mocks.AuthUseCaseMock{
+		SocialAuthURLFunc: func(provider, state, redirectURI string) (string, error) {
+			if provider != "google" {
+				return "", auth.ErrUnknownSocialProvider
+			}
+			return "https://accounts.google.com/o/oauth2/v2/auth?state=" + state, nil
+		},
+	}
+	jwtService := createTestJWTService()
+	h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	router := h.Routes()
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{name: "ok", target: "/social/google?state=abc&redirect_uri=http://localhost:8080/auth/google/callback", wantStatus: http.StatusOK},
+		{name: "missing state", target: "/social/google?redirect_uri=http://localhost:8080/auth/google/callback", wantStatus: http.StatusBadRequest},
+		{name: "unknown provider", target: "/social/gitlab?state=abc&redirect_uri=http://localhost:8080/auth/gitlab/callback", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_SocialCallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "signed in", body: `{"code":"abc","redirect_uri":"http://localhost:8080/auth/github/callback"}`, wantStatus: http.StatusOK},
+		{name: "missing code", body: `{"redirect_uri":"http://localhost:8080/auth/github/callback"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown provider", body: `{"code":"abc","redirect_uri":"http://localhost:8080/auth/github/callback"}`, err: auth.ErrUnknownSocialProvider, wantStatus: http.StatusNotFound},
+		{name: "unverified email", body: `{"code":"abc","redirect_uri":"http://localhost:8080/auth/github/callback"}`, err: auth.ErrSocialEmailUnverified, wantStatus: http.StatusUnauthorized},
+		{name: "exchange failed", body: `{"code":"abc","redirect_uri":"http://localhost:8080/auth/github/callback"}`, err: errors.New("bad code"), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				SocialLoginFunc: func(ctx context.Context, provider string, req auth.SocialLoginRequest) (auth.AuthResponse, error) {
+					if tt.err != nil {
+						return auth.AuthResponse{}, tt.err
+					}
+					return auth.AuthResponse{Token: "token", RefreshToken: "rt_token"}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/social/github/callback", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				if calls := authUC.SocialLoginCalls(); len(calls) != 1 || calls[0].Provider != "github" || calls[0].Req.Code != "abc" {
+					t.Fatalf("unexpected social login calls: %+v", calls)
+				}
+			}
+		})
+	}
+}
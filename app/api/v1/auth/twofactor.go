@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/auth"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// TwoFactorChallenge godoc
+//
+//	@Summary		Complete a two-factor login
+//	@Description	Exchange the mfa_token from a login that returned mfa_required and a code from the authenticator app, or a recovery code, for tokens
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		auth.TwoFactorChallengeRequest	true	"Challenge token and code or recovery code"
+//	@Success		200		{object}	auth.AuthResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/challenge [post]
+func (h *AuthHandler) TwoFactorChallenge(w http.ResponseWriter, r *http.Request) {
+	var req auth.TwoFactorChallengeRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.authUC.CompleteTwoFactor(r.Context(), req)
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// TwoFactorStatus godoc
+//
+//	@Summary		Get two-factor status
+//	@Description	Report whether the current user has two-factor authentication enabled and whether it is required
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	auth.TwoFactorStatus
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/2fa [get]
+func (h *AuthHandler) TwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	status, err := h.authUC.TwoFactorStatus(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, status)
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Start two-factor enrollment
+//	@Description	Create a TOTP secret for the current user. Scan the QR code with an authenticator app, then confirm with /2fa/enable.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	auth.TwoFactorEnrollment
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/enroll [post]
+func (h *AuthHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	enrollment, err := h.authUC.EnrollTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, enrollment)
+}
+
+// EnableTOTP godoc
+//
+//	@Summary		Enable two-factor authentication
+//	@Description	Confirm the enrollment with a code from the authenticator app. Returns new tokens that record the second factor and a set of recovery codes, which are shown only this once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.TwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	auth.AuthResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/enable [post]
+func (h *AuthHandler) EnableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.TwoFactorCodeRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	// Keep the audience of the session the user enabled it from
+	var audience string
+	if len(claims.Audience) > 0 {
+		audience = claims.Audience[0]
+	}
+
+	response, err := h.authUC.EnableTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code, audience)
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// DisableTOTP godoc
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Remove the current user's second factor after checking a code from the authenticator app or a recovery code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.TwoFactorCodeRequest	true	"Code from the authenticator app or recovery code"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/disable [post]
+func (h *AuthHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.TwoFactorCodeRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authUC.DisableTOTP(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code, req.RecoveryCode); err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "two-factor authentication disabled",
+	})
+}
+
+// RecoveryCodes godoc
+//
+//	@Summary		Count recovery codes
+//	@Description	Report how many unused recovery codes the current user has left. The codes themselves are only shown when they are generated.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]int
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/recovery-codes [get]
+func (h *AuthHandler) RecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	remaining, err := h.authUC.RecoveryCodesRemaining(r.Context(), uuid.FromStringOrNil(claims.UserID))
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]int{
+		"remaining": remaining,
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace the current user's recovery codes after checking a code from the authenticator app. Codes from the old set stop working.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		auth.TwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	auth.TwoFactorRecoveryCodes
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/2fa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req auth.TwoFactorCodeRequest
+	if !h.decodeOTPRequest(w, r, &req) {
+		return
+	}
+
+	codes, err := h.authUC.RegenerateRecoveryCodes(r.Context(), uuid.FromStringOrNil(claims.UserID), req.Code)
+	if err != nil {
+		writeTwoFactorError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, codes)
+}
+
+func writeTwoFactorError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, auth.ErrTwoFactorUnavailable), errors.Is(err, auth.ErrTwoFactorNotEnrolled):
+		status = http.StatusNotFound
+	case errors.Is(err, auth.ErrTwoFactorAlreadyEnabled):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrTwoFactorRequired):
+		status = http.StatusForbidden
+	case errors.Is(err, auth.ErrInvalidTOTP), errors.Is(err, auth.ErrInvalidMFAToken):
+		status = http.StatusUnauthorized
+	case errors.Is(err, auth.ErrTwoFactorLocked):
+		status = http.StatusTooManyRequests
+	default:
+		common.RespondError(w, r, err)
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/auth/mocks"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAuthHandler_TwoFactorChallenge(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "completed", body: `{"mfa_token":"challenge","code":"123456"}`, wantStatus: http.StatusOK},
+		{name: "missing code", body: `{"mfa_token":"challenge"}`, wantStatus: http.StatusBadRequest},
+		{name: "recovery code", body: `{"mfa_token":"challenge","recovery_code":"abcde-fghij"}`, wantStatus: http.StatusOK},
+		{name: "wrong code", body: `{"mfa_token":"challenge","code":"000000"}`, err: auth.ErrInvalidTOTP, wantStatus: http.StatusUnauthorized},
+		{name: "expired challenge", body: `{"mfa_token":"old","code":"123456"}`, err: auth.ErrInvalidMFAToken, wantStatus: http.StatusUnauthorized},
+		{name: "locked", body: `{"mfa_token":"challenge","code":"123456"}`, err: auth.ErrTwoFactorLocked, wantStatus: http.StatusTooManyRequests},
+		{name: "repository failed", body: `{"mfa_token":"challenge","code":"123456"}`, err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUC := &mocks.AuthUseCaseMock{
+				CompleteTwoFactorFunc: func(ctx context.Context, req auth.TwoFactorChallengeRequest) (auth.AuthResponse, error) {
+					if tt.err != nil {
+						return auth.AuthResponse{}, tt.err
+					}
+					return auth.AuthResponse{Token: "token", RefreshToken: "rt_token"}, nil
+				},
+			}
+			jwtService := createTestJWTService()
+			h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/2fa/challenge", bytes.NewBufferString(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthHandler_TwoFactorEnrollment(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := createTestJWTService()
+	token, _ := jwtService.WithAudience(jwt.AudienceWeb).GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+
+	authUC := &mocks.AuthUseCaseMock{
+		TwoFactorStatusFunc: func(ctx context.Context, id uuid.UUID) (auth.TwoFactorStatus, error) {
+			return auth.TwoFactorStatus{}, nil
+		},
+		EnrollTOTPFunc: func(ctx context.Context, id uuid.UUID) (auth.TwoFactorEnrollment, error) {
+			return auth.TwoFactorEnrollment{Secret: "SECRET", URI: "otpauth://totp/x"}, nil
+		},
+		EnableTOTPFunc: func(ctx context.Context, id uuid.UUID, code, audience string) (auth.AuthResponse, error) {
+			if code != "123456" {
+				return auth.AuthResponse{}, auth.ErrInvalidTOTP
+			}
+			return auth.AuthResponse{Token: "token"}, nil
+		},
+		DisableTOTPFunc: func(ctx context.Context, id uuid.UUID, code, recoveryCode string) error {
+			return auth.ErrTwoFactorRequired
+		},
+		RecoveryCodesRemainingFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+			return 0, auth.ErrTwoFactorNotEnrolled
+		},
+		RegenerateRecoveryCodesFunc: func(ctx context.Context, id uuid.UUID, code string) (auth.TwoFactorRecoveryCodes, error) {
+			return auth.TwoFactorRecoveryCodes{Codes: []string{"abcde-fghij"}}, nil
+		},
+	}
+	h := NewAuthHandler(authUC, &mocks.UserUseCaseMock{}, jwtService, apiMiddleware.NewAuthMiddleware(jwtService))
+	router := h.Routes()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		auth       bool
+		wantStatus int
+	}{
+		{name: "unauthenticated", method: http.MethodPost, target: "/2fa/enroll", wantStatus: http.StatusUnauthorized},
+		{name: "status", method: http.MethodGet, target: "/2fa", auth: true, wantStatus: http.StatusOK},
+		{name: "enroll", method: http.MethodPost, target: "/2fa/enroll", auth: true, wantStatus: http.StatusOK},
+		{name: "enable wrong code", method: http.MethodPost, target: "/2fa/enable", body: `{"code":"000000"}`, auth: true, wantStatus: http.StatusUnauthorized},
+		{name: "enable", method: http.MethodPost, target: "/2fa/enable", body: `{"code":"123456"}`, auth: true, wantStatus: http.StatusOK},
+		{name: "disable required", method: http.MethodPost, target: "/2fa/disable", body: `{"code":"123456"}`, auth: true, wantStatus: http.StatusForbidden},
+		{name: "recovery codes not enrolled", method: http.MethodGet, target: "/2fa/recovery-codes", auth: true, wantStatus: http.StatusNotFound},
+		{name: "regenerate recovery codes", method: http.MethodPost, target: "/2fa/recovery-codes", body: `{"code":"123456"}`, auth: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	calls := authUC.EnableTOTPCalls()
+	if len(calls) != 2 || calls[1].UserID != userID || calls[1].Audience != jwt.AudienceWeb {
+		t.Fatalf("unexpected enable calls: %+v", calls)
+	}
+}
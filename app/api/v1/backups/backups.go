@@ -0,0 +1,107 @@
+package backups
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListBackups godoc
+//
+//	@Summary		List backups
+//	@Description	List the database backups a page at a time, newest first. Backups older than the backup_retention_days setting are removed, except the latest one that succeeded.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int	false	"Page number (default: 1)"
+//	@Param			page_size	query		int	false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	entities.BackupListResponse
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/backups [get]
+func (h *BackupHandler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	list, err := h.uc.List(r.Context(), page, pageSize)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list backups", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list backups",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// CreateBackup godoc
+//
+//	@Summary		Queue a backup
+//	@Description	Queue a backup of the database, made by the background workers with pg_dump. Poll the backup's Location until it has succeeded, then download the dump from its download_url and restore it with pg_restore.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	entities.Backup
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/backups [post]
+func (h *BackupHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	backup, err := h.uc.Create(r.Context())
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to create backup", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to create backup",
+		})
+		return
+	}
+
+	w.Header().Set("Location", "/admin/v1/backups/"+backup.ID.String())
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, backup)
+}
+
+// GetBackup godoc
+//
+//	@Summary		Get a backup
+//	@Description	Get a backup's status: pending, running, succeeded or failed. Succeeded backups come with a download_url that works until download_url_expires_at; get the backup again for a fresh one.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Backup ID"
+//	@Success		200	{object}	entities.Backup
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/backups/{id} [get]
+func (h *BackupHandler) GetBackup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid backup ID",
+		})
+		return
+	}
+
+	backup, err := h.uc.Get(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "backup"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, backup)
+}
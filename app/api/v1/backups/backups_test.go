@@ -0,0 +1,93 @@
+package backups
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/backups/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestBackupHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	backupID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.BackupUseCaseMock{
+		CreateFunc: func(ctx context.Context) (entities.Backup, error) {
+			return entities.Backup{ID: backupID, Status: entities.BackupPending, Trigger: entities.BackupManual}, nil
+		},
+		ListFunc: func(ctx context.Context, page, pageSize int) (entities.BackupListResponse, error) {
+			return entities.BackupListResponse{Backups: []entities.Backup{{ID: backupID}}, Total: 1, Page: page, PageSize: pageSize}, nil
+		},
+		GetFunc: func(ctx context.Context, id uuid.UUID) (entities.Backup, error) {
+			if id != backupID {
+				return entities.Backup{}, domain.ErrNotFound
+			}
+			return entities.Backup{ID: backupID, Status: entities.BackupSucceeded, FileKey: "private/backups/x.dump", DownloadURL: "/files/private/backups/x.dump?signature=x"}, nil
+		},
+	}
+	h := NewBackupHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target string) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodPost, "/"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for admins, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Location"); got != "/admin/v1/backups/"+backupID.String() {
+		t.Fatalf("unexpected Location %q", got)
+	}
+
+	w = serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/?page=2&page_size=10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls := uc.ListCalls(); len(calls) != 1 || calls[0].Page != 2 || calls[0].PageSize != 10 {
+		t.Fatalf("unexpected list calls: %+v", calls)
+	}
+
+	w = serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+backupID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "file_key") {
+		t.Fatalf("the storage key leaked: %s", w.Body.String())
+	}
+	var backup entities.Backup
+	if err := json.NewDecoder(w.Body).Decode(&backup); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if backup.Status != entities.BackupSucceeded || backup.DownloadURL == "" {
+		t.Fatalf("unexpected backup: %+v", backup)
+	}
+
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/nope"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad ID, got %d", w.Code)
+	}
+}
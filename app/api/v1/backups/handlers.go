@@ -0,0 +1,45 @@
+package backups
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/backup_uc.go . BackupUseCase
+type BackupUseCase interface {
+	Create(ctx context.Context) (entities.Backup, error)
+	List(ctx context.Context, page, pageSize int) (entities.BackupListResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (entities.Backup, error)
+}
+
+type BackupHandler struct {
+	uc BackupUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewBackupHandler(uc BackupUseCase, mw *middleware.AuthMiddleware) *BackupHandler {
+	return &BackupHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// AdminRoutes returns the endpoints listing, making and downloading database
+// backups, mounted at /admin/v1/backups. Backups hold every user's data, so
+// they are limited to super admins.
+func (h *BackupHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireSuperAdmin)
+	r.Use(middleware.DryRun)
+
+	r.Get("/", h.ListBackups)
+	r.Post("/", h.CreateBackup)
+	r.Get("/{id}", h.GetBackup)
+
+	return r
+}
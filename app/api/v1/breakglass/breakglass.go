@@ -0,0 +1,86 @@
+package breakglass
+
+import (
+	"errors"
+	"go-template/domain/breakglass"
+	"go-template/domain/entities"
+	"go-template/internal/clientip"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+)
+
+type RedeemRequest struct {
+	Credential string `json:"credential"`
+}
+
+// RedeemResponse mirrors the admin login response so admin clients can treat
+// a break-glass session like any other.
+type RedeemResponse struct {
+	Token       string        `json:"token"`
+	User        entities.User `json:"user"`
+	AccountType string        `json:"account_type"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// Redeem godoc
+//
+//	@Summary		Redeem the break-glass credential
+//	@Description	Burn the sealed emergency credential and get a short-lived super admin session. Works while the auth provider is down. Every use is audited and alerted on.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RedeemRequest	true	"Break-glass credential"
+//	@Success		200		{object}	RedeemResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Failure		503		{object}	map[string]string
+//	@Router			/admin/v1/break-glass [post]
+func (h *BreakGlassHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	var req RedeemRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil || req.Credential == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "credential is required",
+		})
+		return
+	}
+
+	session, err := h.uc.Redeem(r.Context(), req.Credential, clientip.FromRequest(r))
+	if err != nil {
+		if errors.Is(err, breakglass.ErrInvalidCredential) {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid credential",
+			})
+			return
+		}
+		if errors.Is(err, breakglass.ErrNoAlertChannel) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to redeem credential",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, RedeemResponse{
+		Token: session.Token,
+		User: entities.User{
+			ID:          session.CredentialID,
+			Email:       entities.BreakGlassEmail,
+			AccountType: entities.AccountTypeSuperAdmin,
+		},
+		AccountType: entities.AccountTypeSuperAdmin.String(),
+		ExpiresAt:   session.ExpiresAt,
+	})
+}
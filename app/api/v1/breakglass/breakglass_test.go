@@ -0,0 +1,68 @@
+package breakglass
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-template/app/api/v1/breakglass/mocks"
+	"go-template/domain/breakglass"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRedeem(t *testing.T) {
+	credentialID := uuid.Must(uuid.NewV4())
+	uc := &mocks.BreakGlassUseCaseMock{
+		RedeemFunc: func(ctx context.Context, secret, from string) (entities.BreakGlassSession, error) {
+			if secret == "bg_unalerted" {
+				return entities.BreakGlassSession{}, breakglass.ErrNoAlertChannel
+			}
+			if secret != "bg_valid" {
+				return entities.BreakGlassSession{}, breakglass.ErrInvalidCredential
+			}
+			return entities.BreakGlassSession{Token: "token", CredentialID: credentialID, ExpiresAt: time.Now().Add(15 * time.Minute)}, nil
+		},
+	}
+	routes := NewBreakGlassHandler(uc).AdminRoutes()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid credential", body: `{"credential":"bg_valid"}`, wantStatus: http.StatusOK},
+		{name: "invalid credential", body: `{"credential":"bg_wrong"}`, wantStatus: http.StatusUnauthorized},
+		{name: "no alert channel", body: `{"credential":"bg_unalerted"}`, wantStatus: http.StatusServiceUnavailable},
+		{name: "missing credential", body: `{}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp RedeemResponse
+			_ = json.Unmarshal(w.Body.Bytes(), &resp)
+			if resp.Token != "token" || resp.User.ID != credentialID || resp.AccountType != entities.AccountTypeSuperAdmin.String() {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			calls := uc.RedeemCalls()
+			if from := calls[len(calls)-1].From; from != "192.0.2.1" {
+				t.Fatalf("expected the client IP without its port, got %q", from)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package breakglass
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/breakglass_uc.go . BreakGlassUseCase
+type BreakGlassUseCase interface {
+	Redeem(ctx context.Context, secret, from string) (entities.BreakGlassSession, error)
+}
+
+type BreakGlassHandler struct {
+	uc BreakGlassUseCase
+}
+
+func NewBreakGlassHandler(uc BreakGlassUseCase) *BreakGlassHandler {
+	return &BreakGlassHandler{
+		uc: uc,
+	}
+}
+
+// AdminRoutes returns the break-glass endpoint, mounted at /admin/v1/break-glass.
+// It is public on purpose: the credential is the only thing that authenticates.
+func (h *BreakGlassHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.Redeem)
+
+	return r
+}
@@ -0,0 +1,53 @@
+package emails
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// ListEmails godoc
+//
+//	@Summary		List transactional emails
+//	@Description	List the names of the transactional email templates that can be previewed.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Router			/admin/v1/emails [get]
+func (h *EmailHandler) ListEmails(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entities.EmailTemplates)
+}
+
+// PreviewEmail godoc
+//
+//	@Summary		Preview a transactional email
+//	@Description	Render an email template with sample data, in the language of the locale when it has a translation.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			name	path		string	true	"Email template, such as welcome"
+//	@Param			locale	query		string	false	"Locale to render, such as pt-BR"
+//	@Success		200		{object}	entities.RenderedEmail
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/emails/{name}/preview [get]
+func (h *EmailHandler) PreviewEmail(w http.ResponseWriter, r *http.Request) {
+	name := entities.EmailTemplate(chi.URLParam(r, "name"))
+	rendered, err := h.previewer.PreviewEmail(name, r.URL.Query().Get("locale"))
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "email"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, rendered)
+}
@@ -0,0 +1,76 @@
+package emails
+
+import (
+	"encoding/json"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/emails/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestRoutes(previewer EmailPreviewer) (http.Handler, jwt.Service) {
+	jh := jwt.NewService("test-secret", "test-issuer", "1h")
+	h := NewEmailHandler(previewer, apiMiddleware.NewAuthMiddleware(jh))
+	return h.AdminRoutes(), jh
+}
+
+func serve(routes http.Handler, token, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+	return w
+}
+
+func TestListEmails(t *testing.T) {
+	routes, jh := newTestRoutes(&mocks.EmailPreviewerMock{})
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+	w := serve(routes, token, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got []entities.EmailTemplate
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got) != len(entities.EmailTemplates) || got[0] != entities.EmailWelcome {
+		t.Fatalf("unexpected response: %v", got)
+	}
+}
+
+func TestPreviewEmail(t *testing.T) {
+	previewer := &mocks.EmailPreviewerMock{
+		PreviewEmailFunc: func(name entities.EmailTemplate, locale string) (entities.RenderedEmail, error) {
+			if name != entities.EmailWelcome {
+				return entities.RenderedEmail{}, fmt.Errorf("email template %q: %w", name, domain.ErrNotFound)
+			}
+			return entities.RenderedEmail{Template: name, Locale: locale, Subject: "Welcome", Text: "Hi\n"}, nil
+		},
+	}
+	routes, jh := newTestRoutes(previewer)
+	admin, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", entities.AccountTypeAdmin.String())
+	user, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "user@x.com", entities.AccountTypeUser.String())
+
+	w := serve(routes, admin, "/welcome/preview?locale=pt-BR")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got entities.RenderedEmail
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Template != entities.EmailWelcome || got.Locale != "pt-BR" || got.Subject != "Welcome" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+
+	if w := serve(routes, admin, "/unknown/preview"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(routes, user, "/welcome/preview"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+}
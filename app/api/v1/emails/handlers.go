@@ -0,0 +1,40 @@
+package emails
+
+import (
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/email_previewer.go . EmailPreviewer
+type EmailPreviewer interface {
+	PreviewEmail(name entities.EmailTemplate, locale string) (entities.RenderedEmail, error)
+}
+
+type EmailHandler struct {
+	previewer EmailPreviewer
+	mw        *middleware.AuthMiddleware
+}
+
+func NewEmailHandler(previewer EmailPreviewer, mw *middleware.AuthMiddleware) *EmailHandler {
+	return &EmailHandler{
+		previewer: previewer,
+		mw:        mw,
+	}
+}
+
+// AdminRoutes returns the email template previews, mounted at
+// /admin/v1/emails. Previews are rendered with sample data only, so any admin
+// can see them.
+func (h *EmailHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireAdmin)
+		r.Get("/", h.ListEmails)
+		r.Get("/{name}/preview", h.PreviewEmail)
+	})
+
+	return r
+}
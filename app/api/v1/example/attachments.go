@@ -0,0 +1,241 @@
+package example
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/attachment"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// attachmentFormOverhead is room for the multipart headers around the file.
+const attachmentFormOverhead = 64 << 10
+
+type ListAttachmentsResponse struct {
+	Attachments []entities.Attachment `json:"attachments"`
+}
+
+// UploadAttachment godoc
+//
+//	@Summary		Attach a file to an example
+//	@Description	Attach the "file" field of a multipart form to the example, up to ATTACHMENT_MAX_SIZE bytes. The content type is told from the file's content.
+//	@Tags			examples
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id		path		string	true	"Example ID"
+//	@Param			file	formData	file	true	"File to attach"
+//	@Success		201	{object}	entities.Attachment
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		413	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/attachments [post]
+func (h *ExampleHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("id is required"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.attachments.MaxSize()+attachmentFormOverhead)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			common.ErrorResponse(w, r, http.StatusRequestEntityTooLarge, attachment.ErrTooLarge)
+			return
+		}
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("file is required"))
+		return
+	}
+	defer file.Close()
+
+	created, err := h.attachments.Upload(r.Context(), id, header.Filename, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, attachment.ErrTooLarge):
+			common.ErrorResponse(w, r, http.StatusRequestEntityTooLarge, err)
+		case errors.Is(err, attachment.ErrEmpty):
+			common.ErrorResponse(w, r, http.StatusBadRequest, err)
+		default:
+			common.RespondError(w, r, common.NotFound(err, "example"))
+		}
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, created)
+}
+
+// ListAttachments godoc
+//
+//	@Summary		List an example's attachments
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id	path		string	true	"Example ID"
+//	@Success		200	{object}	ListAttachmentsResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/attachments [get]
+func (h *ExampleHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	attachments, err := h.attachments.List(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return
+	}
+	if attachments == nil {
+		attachments = []entities.Attachment{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ListAttachmentsResponse{Attachments: attachments})
+}
+
+// GetAttachment godoc
+//
+//	@Summary		Get an example's attachment
+//	@Description	Get the attachment with a download_url that works until download_url_expires_at. Only its uploader, the example's creator and admins can get it, unless the authorization policy allows others to read examples.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id				path		string	true	"Example ID"
+//	@Param			attachmentID	path		string	true	"Attachment ID"
+//	@Success		200	{object}	entities.Attachment
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/attachments/{attachmentID} [get]
+func (h *ExampleHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	found, ok := h.findAttachment(w, r)
+	if !ok || !h.authorizeAttachment(w, r, found, "read") {
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, found)
+}
+
+// DownloadAttachment godoc
+//
+//	@Summary		Download an example's attachment
+//	@Description	Redirect to a short lived signed link to the attached file. Only its uploader, the example's creator and admins can download it, unless the authorization policy allows others to read examples.
+//	@Tags			examples
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id				path	string	true	"Example ID"
+//	@Param			attachmentID	path	string	true	"Attachment ID"
+//	@Success		302
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/attachments/{attachmentID}/download [get]
+func (h *ExampleHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	found, ok := h.findAttachment(w, r)
+	if !ok || !h.authorizeAttachment(w, r, found, "read") {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, found.DownloadURL, http.StatusFound)
+}
+
+// DeleteAttachment godoc
+//
+//	@Summary		Delete an example's attachment
+//	@Description	Delete an attachment. Only its uploader, the example's creator and admins can delete it, unless the authorization policy allows others to write examples.
+//	@Tags			examples
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id				path	string	true	"Example ID"
+//	@Param			attachmentID	path	string	true	"Attachment ID"
+//	@Success		204
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/attachments/{attachmentID} [delete]
+func (h *ExampleHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	found, ok := h.findAttachment(w, r)
+	if !ok || !h.authorizeAttachment(w, r, found, "write") {
+		return
+	}
+
+	if err := h.attachments.Delete(r.Context(), found.ExampleID, found.ID); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "attachment"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findAttachment loads the attachment named in the URL, answering the
+// request itself when it can't.
+func (h *ExampleHandler) findAttachment(w http.ResponseWriter, r *http.Request) (entities.Attachment, bool) {
+	attachmentID, err := uuid.FromString(chi.URLParam(r, "attachmentID"))
+	if err != nil {
+		common.ErrorResponse(w, r, http.StatusNotFound, errors.New("attachment not found"))
+		return entities.Attachment{}, false
+	}
+
+	found, err := h.attachments.Get(r.Context(), chi.URLParam(r, "id"), attachmentID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "attachment"))
+		return entities.Attachment{}, false
+	}
+	return found, true
+}
+
+// authorizeAttachment tells whether the caller may perform action, read or
+// write, on the attachment: its uploader, the creator of its example, an
+// admin or one the policy allows to on examples. It answers the request
+// itself when not.
+func (h *ExampleHandler) authorizeAttachment(w http.ResponseWriter, r *http.Request, found entities.Attachment, action string) bool {
+	principal, _ := middleware.PrincipalFromContext(r.Context())
+	if principal.IsAdmin() {
+		return true
+	}
+
+	example, err := h.uc.GetExampleByID(r.Context(), found.ExampleID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "attachment"))
+		return false
+	}
+	var owners []uuid.UUID
+	if found.UploadedBy != nil {
+		owners = append(owners, *found.UploadedBy)
+	}
+	if example.CreatedBy != nil {
+		owners = append(owners, *example.CreatedBy)
+	}
+	if len(owners) == 0 {
+		owners = append(owners, uuid.Nil)
+	}
+
+	for _, owner := range owners {
+		allowed, err := h.mw.Allowed(r, "example", action, owner)
+		if err != nil {
+			common.RespondError(w, r, err)
+			return false
+		}
+		if allowed {
+			return true
+		}
+	}
+	common.ErrorResponse(w, r, http.StatusForbidden, errors.New("only the uploader or the example's creator can "+action+" this attachment"))
+	return false
+}
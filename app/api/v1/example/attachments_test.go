@@ -0,0 +1,269 @@
+package example
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-template/app/api/middleware"
+	"go-template/app/api/v1/example/mocks"
+	"go-template/domain"
+	"go-template/domain/attachment"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type attachmentTest struct {
+	t          *testing.T
+	jwtService jwt.Service
+	handler    http.Handler
+}
+
+// newAttachmentTest serves the example routes with attachments, on examples
+// created by exampleOwner.
+func newAttachmentTest(t *testing.T, attachments *mocks.AttachmentUseCaseMock, exampleOwner uuid.UUID) *attachmentTest {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	examples := &mocks.ExampleUseCaseMock{
+		GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{ID: id, CreatedBy: &exampleOwner}, nil
+		},
+	}
+	h := NewExampleHandler(examples, middleware.NewAuthMiddleware(jwtService))
+	h.SetAttachments(attachments)
+	return &attachmentTest{t: t, jwtService: jwtService, handler: h.Routes()}
+}
+
+func (a *attachmentTest) serve(userID uuid.UUID, accountType entities.AccountType, req *http.Request) *httptest.ResponseRecorder {
+	token, err := a.jwtService.GenerateToken(userID.String(), "someone@x.com", accountType.String())
+	if err != nil {
+		a.t.Fatalf("generating token: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	a.handler.ServeHTTP(w, req)
+	return w
+}
+
+func uploadRequest(t *testing.T, target, fileName, content string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatalf("creating form: %v", err)
+	}
+	io.WriteString(part, content)
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestUploadAttachment(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	mockUC := &mocks.AttachmentUseCaseMock{
+		MaxSizeFunc: func() int64 {
+			return 1 << 10
+		},
+		UploadFunc: func(ctx context.Context, exampleID, fileName string, body io.Reader) (entities.Attachment, error) {
+			if exampleID != "123" {
+				return entities.Attachment{}, domain.ErrNotFound
+			}
+			data, _ := io.ReadAll(body)
+			return entities.Attachment{ID: uuid.Must(uuid.NewV4()), ExampleID: exampleID, FileName: fileName, Size: int64(len(data))}, nil
+		},
+	}
+	a := newAttachmentTest(t, mockUC, userID)
+
+	t.Run("successful upload", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, uploadRequest(t, "/123/attachments", "notes.txt", "hello"))
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var response entities.Attachment
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.FileName != "notes.txt" || response.Size != 5 {
+			t.Errorf("unexpected attachment: %+v", response)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, uploadRequest(t, "/123/attachments", "big.bin", strings.Repeat("x", 100<<10)))
+
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/123/attachments", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		w := a.serve(userID, entities.AccountTypeUser, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("missing example", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, uploadRequest(t, "/999/attachments", "notes.txt", "hello"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		mockUC.UploadFunc = func(ctx context.Context, exampleID, fileName string, body io.Reader) (entities.Attachment, error) {
+			return entities.Attachment{}, attachment.ErrEmpty
+		}
+		w := a.serve(userID, entities.AccountTypeUser, uploadRequest(t, "/123/attachments", "empty.txt", ""))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestListAttachments(t *testing.T) {
+	mockUC := &mocks.AttachmentUseCaseMock{
+		ListFunc: func(ctx context.Context, exampleID string) ([]entities.Attachment, error) {
+			if exampleID != "123" {
+				return nil, domain.ErrNotFound
+			}
+			return nil, nil
+		},
+	}
+	userID := uuid.Must(uuid.NewV4())
+	a := newAttachmentTest(t, mockUC, userID)
+
+	w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/123/attachments", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != `{"attachments":[]}` {
+		t.Errorf("expected an empty list, got %s", got)
+	}
+
+	w = a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/999/attachments", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	mockUC := &mocks.AttachmentUseCaseMock{
+		GetFunc: func(ctx context.Context, exampleID string, attachmentID uuid.UUID) (entities.Attachment, error) {
+			if exampleID != "123" || attachmentID != id {
+				return entities.Attachment{}, domain.ErrNotFound
+			}
+			return entities.Attachment{ID: id, ExampleID: exampleID, DownloadURL: "http://files/private/a?signature=x"}, nil
+		},
+	}
+	userID := uuid.Must(uuid.NewV4())
+	a := newAttachmentTest(t, mockUC, userID)
+
+	t.Run("redirects to a signed link", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/123/attachments/"+id.String()+"/download", nil))
+
+		if w.Code != http.StatusFound {
+			t.Fatalf("expected status %d, got %d", http.StatusFound, w.Code)
+		}
+		if got := w.Header().Get("Location"); got != "http://files/private/a?signature=x" {
+			t.Errorf("unexpected location %q", got)
+		}
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		a.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/123/attachments/"+id.String()+"/download", nil))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		for _, target := range []string{"/123/attachments/" + id.String(), "/123/attachments/" + id.String() + "/download"} {
+			w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, target, nil))
+
+			if w.Code != http.StatusForbidden {
+				t.Errorf("expected status %d for %s, got %d", http.StatusForbidden, target, w.Code)
+			}
+		}
+	})
+
+	t.Run("admins", func(t *testing.T) {
+		w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeAdmin, httptest.NewRequest(http.MethodGet, "/123/attachments/"+id.String(), nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+
+	t.Run("attachment of another example", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/456/attachments/"+id.String()+"/download", nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/123/attachments/nope", nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	uploader := uuid.Must(uuid.NewV4())
+	exampleOwner := uuid.Must(uuid.NewV4())
+	mockUC := &mocks.AttachmentUseCaseMock{
+		GetFunc: func(ctx context.Context, exampleID string, attachmentID uuid.UUID) (entities.Attachment, error) {
+			return entities.Attachment{ID: id, ExampleID: exampleID, UploadedBy: &uploader}, nil
+		},
+	}
+	a := newAttachmentTest(t, mockUC, exampleOwner)
+	target := "/123/attachments/" + id.String()
+
+	w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for other users, got %d", http.StatusForbidden, w.Code)
+	}
+	if len(mockUC.DeleteCalls()) != 0 {
+		t.Fatalf("expected no deletion, got %d", len(mockUC.DeleteCalls()))
+	}
+
+	w = a.serve(uploader, entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for the uploader, got %d", http.StatusNoContent, w.Code)
+	}
+
+	w = a.serve(exampleOwner, entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for the example's creator, got %d", http.StatusNoContent, w.Code)
+	}
+
+	w = a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeAdmin, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for admins, got %d", http.StatusNoContent, w.Code)
+	}
+
+	calls := mockUC.DeleteCalls()
+	if len(calls) != 3 || calls[0].ExampleID != "123" || calls[0].ID != id {
+		t.Errorf("unexpected delete calls: %+v", calls)
+	}
+}
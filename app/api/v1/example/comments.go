@@ -0,0 +1,210 @@
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/comment"
+	"go-template/domain/entities"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type ListCommentsResponse struct {
+	Comments   []entities.Comment `json:"comments"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// CreateComment godoc
+//
+//	@Summary		Comment on an example
+//	@Description	Comment on the example as the caller. The body is trimmed and must have 1 to 5000 characters.
+//	@Tags			examples
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id		path		string					true	"Example ID"
+//	@Param			comment	body		CreateCommentRequest	true	"Comment to create"
+//	@Success		201		{object}	entities.Comment
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/comments [post]
+func (h *ExampleHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("invalid request body"))
+		return
+	}
+
+	created, err := h.comments.Create(r.Context(), id, input.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, comment.ErrEmpty), errors.Is(err, comment.ErrTooLong):
+			common.ErrorResponse(w, r, http.StatusBadRequest, err)
+		default:
+			common.RespondError(w, r, common.NotFound(err, "example"))
+		}
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, created)
+}
+
+// ListComments godoc
+//
+//	@Summary		List an example's comments
+//	@Description	List the example's comments a page at a time, oldest first.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id			path		string	true	"Example ID"
+//	@Param			page		query		int		false	"Page number (default: 1)"
+//	@Param			page_size	query		int		false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	ListCommentsResponse
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/comments [get]
+func (h *ExampleHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	page := 1
+	pageSize := 20
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	comments, total, err := h.comments.List(r.Context(), id, page, pageSize)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return
+	}
+	if comments == nil {
+		comments = []entities.Comment{}
+	}
+
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ListCommentsResponse{
+		Comments:   comments,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	})
+}
+
+// GetComment godoc
+//
+//	@Summary		Get an example's comment
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id			path		string	true	"Example ID"
+//	@Param			commentID	path		string	true	"Comment ID"
+//	@Success		200			{object}	entities.Comment
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/comments/{commentID} [get]
+func (h *ExampleHandler) GetComment(w http.ResponseWriter, r *http.Request) {
+	found, ok := h.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, found)
+}
+
+// DeleteComment godoc
+//
+//	@Summary		Delete an example's comment
+//	@Description	Delete a comment. Only its author and admins can delete it, unless the authorization policy allows others to write examples.
+//	@Tags			examples
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id			path	string	true	"Example ID"
+//	@Param			commentID	path	string	true	"Comment ID"
+//	@Success		204
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/comments/{commentID} [delete]
+func (h *ExampleHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	found, ok := h.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	var author uuid.UUID
+	if found.AuthorID != nil {
+		author = *found.AuthorID
+	}
+	principal, _ := middleware.PrincipalFromContext(r.Context())
+	if !principal.IsAdmin() {
+		allowed, err := h.mw.Allowed(r, "example", "write", author)
+		if err != nil {
+			common.RespondError(w, r, err)
+			return
+		}
+		if !allowed {
+			common.ErrorResponse(w, r, http.StatusForbidden, errors.New("only the author can delete this comment"))
+			return
+		}
+	}
+
+	if err := h.comments.Delete(r.Context(), found.ExampleID, found.ID); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "comment"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findComment loads the comment named in the URL, answering the request
+// itself when it can't.
+func (h *ExampleHandler) findComment(w http.ResponseWriter, r *http.Request) (entities.Comment, bool) {
+	commentID, err := uuid.FromString(chi.URLParam(r, "commentID"))
+	if err != nil {
+		common.ErrorResponse(w, r, http.StatusNotFound, errors.New("comment not found"))
+		return entities.Comment{}, false
+	}
+
+	found, err := h.comments.Get(r.Context(), chi.URLParam(r, "id"), commentID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "comment"))
+		return entities.Comment{}, false
+	}
+	return found, true
+}
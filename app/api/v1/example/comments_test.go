@@ -0,0 +1,163 @@
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"go-template/app/api/middleware"
+	"go-template/app/api/v1/example/mocks"
+	"go-template/domain"
+	"go-template/domain/comment"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// newCommentTest serves the example routes with comments, as
+// attachmentTest does for attachments.
+func newCommentTest(t *testing.T, comments *mocks.CommentUseCaseMock) *attachmentTest {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	h := NewExampleHandler(&mocks.ExampleUseCaseMock{}, middleware.NewAuthMiddleware(jwtService))
+	h.SetComments(comments)
+	return &attachmentTest{t: t, jwtService: jwtService, handler: h.Routes()}
+}
+
+func TestCreateComment(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	mockUC := &mocks.CommentUseCaseMock{
+		CreateFunc: func(ctx context.Context, exampleID, body string) (entities.Comment, error) {
+			switch {
+			case exampleID != "123":
+				return entities.Comment{}, domain.ErrNotFound
+			case body == "":
+				return entities.Comment{}, comment.ErrEmpty
+			}
+			actor, _ := domain.ActorFromContext(ctx)
+			return entities.Comment{ID: uuid.Must(uuid.NewV4()), ExampleID: exampleID, AuthorID: &actor, Body: body}, nil
+		},
+	}
+	a := newCommentTest(t, mockUC)
+
+	t.Run("successful comment", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodPost, "/123/comments", strings.NewReader(`{"body":"Nice"}`)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+		var got entities.Comment
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if got.Body != "Nice" || got.AuthorID == nil || *got.AuthorID != userID {
+			t.Errorf("expected the caller's comment, got %+v", got)
+		}
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodPost, "/123/comments", strings.NewReader(`{"body":""}`)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodPost, "/123/comments", strings.NewReader(`{`)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("missing example", func(t *testing.T) {
+		w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodPost, "/999/comments", strings.NewReader(`{"body":"Nice"}`)))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		a.handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/123/comments", strings.NewReader(`{"body":"Nice"}`)))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+}
+
+func TestListComments(t *testing.T) {
+	mockUC := &mocks.CommentUseCaseMock{
+		ListFunc: func(ctx context.Context, exampleID string, page, pageSize int) ([]entities.Comment, int64, error) {
+			if exampleID != "123" {
+				return nil, 0, domain.ErrNotFound
+			}
+			return []entities.Comment{{Body: "first"}}, 41, nil
+		},
+	}
+	a := newCommentTest(t, mockUC)
+	userID := uuid.Must(uuid.NewV4())
+
+	w := a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/123/comments?page=2&page_size=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var got ListCommentsResponse
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Page != 2 || got.PageSize != 10 || got.Total != 41 || got.TotalPages != 5 || len(got.Comments) != 1 {
+		t.Errorf("unexpected page: %+v", got)
+	}
+	if call := mockUC.ListCalls()[0]; call.Page != 2 || call.PageSize != 10 {
+		t.Errorf("expected page 2 of 10, got %+v", call)
+	}
+
+	w = a.serve(userID, entities.AccountTypeUser, httptest.NewRequest(http.MethodGet, "/999/comments", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	author := uuid.Must(uuid.NewV4())
+	mockUC := &mocks.CommentUseCaseMock{
+		GetFunc: func(ctx context.Context, exampleID string, commentID uuid.UUID) (entities.Comment, error) {
+			if exampleID != "123" || commentID != id {
+				return entities.Comment{}, domain.ErrNotFound
+			}
+			return entities.Comment{ID: id, ExampleID: exampleID, AuthorID: &author}, nil
+		},
+	}
+	a := newCommentTest(t, mockUC)
+	target := "/123/comments/" + id.String()
+
+	w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status %d for other users, got %d", http.StatusForbidden, w.Code)
+	}
+	if len(mockUC.DeleteCalls()) != 0 {
+		t.Fatalf("expected no deletion, got %d", len(mockUC.DeleteCalls()))
+	}
+
+	w = a.serve(author, entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, "/999/comments/"+id.String(), nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a comment of another example, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = a.serve(author, entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for the author, got %d", http.StatusNoContent, w.Code)
+	}
+
+	w = a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeAdmin, httptest.NewRequest(http.MethodDelete, target, nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for admins, got %d", http.StatusNoContent, w.Code)
+	}
+
+	calls := mockUC.DeleteCalls()
+	if len(calls) != 2 || calls[0].ExampleID != "123" || calls[0].ID != id {
+		t.Errorf("unexpected delete calls: %+v", calls)
+	}
+}
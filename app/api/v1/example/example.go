@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"go-template/app/api/common"
+	"go-template/app/api/middleware"
 	"go-template/domain"
 	"go-template/domain/entities"
-	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
 )
 
 type CreateExampleRequest struct {
@@ -22,6 +25,22 @@ type CreateExampleResponse struct {
 	ID string `json:"id"`
 }
 
+// UpdateExampleRequest replaces an example's title and content. UpdatedAt
+// is the updated_at the example had when it was read.
+type UpdateExampleRequest struct {
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListExamplesResponse struct {
+	Examples   []entities.Example `json:"examples"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
 // CreateExample godoc
 //
 //	@Summary		Create a new example
@@ -30,6 +49,7 @@ type CreateExampleResponse struct {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Param			example	body	CreateExampleRequest	true	"Example to create"
 //	@Success		201	{object}	CreateExampleResponse
 //	@Failure		400	{object}	map[string]string
@@ -56,24 +76,11 @@ func (h *ExampleHandler) CreateExample(w http.ResponseWriter, r *http.Request) {
 
 	id, err := h.uc.CreateExample(r.Context(), example)
 	if err != nil {
-		slog.Error("failed to create example", "error", err, "input", input)
-		switch {
-		case errors.Is(err, domain.ErrMalformedParameters):
-			common.ErrorResponse(w, r, http.StatusBadRequest, err)
-			return
-		case errors.Is(err, domain.ErrConflict):
-			common.ErrorResponse(w, r, http.StatusConflict, err)
-			return
-		case errors.Is(err, domain.ErrDuplicateKey):
-			common.ErrorResponse(w, r, http.StatusConflict, err)
-			return
-		default:
-			common.UnknownErrorResponse(w, r)
-			return
-		}
+		common.RespondError(w, r, err)
+		return
 	}
 
-	slog.Info("example created successfully", "id", id)
+	domain.Logger(r.Context()).Info("example created successfully", "id", id)
 	render.Status(r, http.StatusCreated)
 	render.JSON(w, r, CreateExampleResponse{ID: id})
 }
@@ -86,6 +93,7 @@ func (h *ExampleHandler) CreateExample(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Param			id	path	string	true	"Example ID"
 //	@Success		200	{object}	entities.Example
 //	@Failure		400	{object}	map[string]string
@@ -102,26 +110,296 @@ func (h *ExampleHandler) GetExampleByID(w http.ResponseWriter, r *http.Request)
 
 	example, err := h.uc.GetExampleByID(r.Context(), id)
 	if err != nil {
-		slog.Error("failed to get example", "error", err, "id", id)
-		switch {
-		case errors.Is(err, domain.ErrNotFound):
-			common.ErrorResponse(w, r, http.StatusNotFound, errors.New("example not found"))
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return
+	}
+
+	if example.ID == "" {
+		common.ErrorResponse(w, r, http.StatusNotFound, errors.New("example not found"))
+		return
+	}
+
+	domain.Logger(r.Context()).Info("example retrieved successfully", "id", id)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, example)
+}
+
+// ListExamples godoc
+//
+//	@Summary		List examples
+//	@Description	List examples a page at a time, sorted by created_at, newest first unless order is asc. Archived examples are left out; admins can include them with include_archived=true.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			page				query		int		false	"Page number (default: 1)"
+//	@Param			page_size			query		int		false	"Page size (default: 20, max: 100)"
+//	@Param			order				query		string	false	"asc or desc (default: desc)"
+//	@Param			include_archived	query		bool	false	"Include archived examples (admins only)"
+//	@Success		200					{object}	ListExamplesResponse
+//	@Failure		400					{object}	map[string]string
+//	@Failure		401					{object}	map[string]string
+//	@Failure		403					{object}	map[string]string
+//	@Failure		500					{object}	map[string]string
+//	@Router			/api/v1/examples [get]
+func (h *ExampleHandler) ListExamples(w http.ResponseWriter, r *http.Request) {
+	page := 1
+	pageSize := 20
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	var desc bool
+	switch r.URL.Query().Get("order") {
+	case "", "desc":
+		desc = true
+	case "asc":
+	default:
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("order must be asc or desc"))
+		return
+	}
+
+	includeArchived := false
+	if s := r.URL.Query().Get("include_archived"); s != "" {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("include_archived must be true or false"))
 			return
-		case errors.Is(err, domain.ErrMalformedParameters):
-			common.ErrorResponse(w, r, http.StatusBadRequest, err)
+		}
+		includeArchived = v
+	}
+	if includeArchived {
+		// API keys act as plain users, so only admin tokens get here
+		principal, ok := middleware.PrincipalFromContext(r.Context())
+		if !ok || !principal.IsAdmin() {
+			common.ErrorResponse(w, r, http.StatusForbidden, errors.New("only admins can include archived examples"))
 			return
-		default:
-			common.UnknownErrorResponse(w, r)
+		}
+	}
+
+	examples, total, err := h.uc.ListExamples(r.Context(), page, pageSize, desc, includeArchived)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+	if examples == nil {
+		examples = []entities.Example{}
+	}
+
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ListExamplesResponse{
+		Examples:   examples,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	})
+}
+
+// UpdateExample godoc
+//
+//	@Summary		Update an example
+//	@Description	Replace an example's title and content. Only its creator, admins and callers holding the example:write permission may. updated_at must be the example's updated_at when it was read; if the example was changed since, the update is refused with 409 so it isn't overwritten. Read it again and retry.
+//	@Tags			examples
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id		path		string					true	"Example ID"
+//	@Param			example	body		UpdateExampleRequest	true	"New title and content, and the updated_at they were read at"
+//	@Success		200		{object}	entities.Example
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/examples/{id} [put]
+func (h *ExampleHandler) UpdateExample(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("id is required"))
+		return
+	}
+
+	var input UpdateExampleRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("invalid request body"))
+		return
+	}
+
+	if input.Title == "" {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("title is required"))
+		return
+	}
+	if input.UpdatedAt.IsZero() {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("updated_at is required"))
+		return
+	}
+	if !h.authorizeOwner(w, r, id) {
+		return
+	}
+
+	example, err := h.uc.UpdateExample(r.Context(), entities.Example{
+		ID:        id,
+		Title:     input.Title,
+		Content:   input.Content,
+		UpdatedAt: input.UpdatedAt,
+	})
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return
+	}
+
+	domain.Logger(r.Context()).Info("example updated successfully", "id", id)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, example)
+}
+
+// DeleteExample godoc
+//
+//	@Summary		Delete an example
+//	@Description	Delete an example. Only its creator, admins and callers holding the example:write permission may. With updated_at, the example's updated_at when it was read, it is only deleted if it wasn't changed since, and 409 is returned otherwise.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id			path	string	true	"Example ID"
+//	@Param			updated_at	query	string	false	"updated_at the example was read at (RFC 3339)"
+//	@Success		204
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id} [delete]
+func (h *ExampleHandler) DeleteExample(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("id is required"))
+		return
+	}
+
+	var expectedUpdatedAt *time.Time
+	if s := r.URL.Query().Get("updated_at"); s != "" {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("updated_at must be an RFC 3339 time"))
 			return
 		}
+		expectedUpdatedAt = &t
+	}
+	if !h.authorizeOwner(w, r, id) {
+		return
 	}
 
-	if example.ID == "" {
-		common.ErrorResponse(w, r, http.StatusNotFound, errors.New("example not found"))
+	if err := h.uc.DeleteExample(r.Context(), id, expectedUpdatedAt); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
 		return
 	}
 
-	slog.Info("example retrieved successfully", "id", id)
+	domain.Logger(r.Context()).Info("example deleted successfully", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ArchiveExample godoc
+//
+//	@Summary		Archive an example
+//	@Description	Archive an example, leaving it out of lists until it is unarchived. It can still be read by ID. Examples archived for longer than EXAMPLE_ARCHIVE_RETENTION_DAYS are deleted. Archiving an archived example leaves it as it was.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id	path		string	true	"Example ID"
+//	@Success		200	{object}	entities.Example
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/archive [post]
+func (h *ExampleHandler) ArchiveExample(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+// UnarchiveExample godoc
+//
+//	@Summary		Unarchive an example
+//	@Description	Bring an archived example back into lists.
+//	@Tags			examples
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			id	path		string	true	"Example ID"
+//	@Success		200	{object}	entities.Example
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/examples/{id}/unarchive [post]
+func (h *ExampleHandler) UnarchiveExample(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *ExampleHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		common.ErrorResponse(w, r, http.StatusBadRequest, errors.New("id is required"))
+		return
+	}
+
+	action := h.uc.UnarchiveExample
+	if archived {
+		action = h.uc.ArchiveExample
+	}
+	example, err := action(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return
+	}
+
+	domain.Logger(r.Context()).Info("example archival changed successfully", "id", id, "archived", archived)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, example)
 }
+
+// authorizeOwner tells whether the caller may change the example with id:
+// its creator, an admin or one holding the example:write permission. It
+// answers the request itself when not.
+func (h *ExampleHandler) authorizeOwner(w http.ResponseWriter, r *http.Request, id string) bool {
+	found, err := h.uc.GetExampleByID(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "example"))
+		return false
+	}
+
+	principal, _ := middleware.PrincipalFromContext(r.Context())
+	if principal.IsAdmin() {
+		return true
+	}
+	var owner uuid.UUID
+	if found.CreatedBy != nil {
+		owner = *found.CreatedBy
+	}
+	allowed, err := h.mw.Allowed(r, "example", "write", owner)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return false
+	}
+	if !allowed {
+		common.ErrorResponse(w, r, http.StatusForbidden, errors.New("only the creator can change this example"))
+		return false
+	}
+	return true
+}
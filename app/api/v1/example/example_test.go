@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
 	"go-template/app/api/v1/example/mocks"
 	"go-template/domain"
 	"go-template/domain/entities"
+	"go-template/internal/jwt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
 )
 
 func TestCreateExample(t *testing.T) {
@@ -179,3 +183,399 @@ func TestGetExampleByID(t *testing.T) {
 		}
 	})
 }
+
+func TestListExamples(t *testing.T) {
+	t.Run("successful listing", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			ListExamplesFunc: func(ctx context.Context, page, pageSize int, desc, includeArchived bool) ([]entities.Example, int64, error) {
+				return []entities.Example{{ID: "123", Title: "Test Title"}}, 21, nil
+			},
+		}
+
+		h := &ExampleHandler{
+			uc: mockUC,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/examples?page=2&page_size=10&order=asc", nil)
+		w := httptest.NewRecorder()
+
+		h.ListExamples(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		calls := mockUC.ListExamplesCalls()
+		if len(calls) != 1 || calls[0].Page != 2 || calls[0].PageSize != 10 || calls[0].Desc {
+			t.Errorf("unexpected list calls: %+v", calls)
+		}
+
+		var response ListExamplesResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if len(response.Examples) != 1 || response.Total != 21 || response.TotalPages != 3 {
+			t.Errorf("unexpected response: %+v", response)
+		}
+	})
+
+	t.Run("newest first by default", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{}
+
+		h := &ExampleHandler{
+			uc: mockUC,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/examples", nil)
+		w := httptest.NewRecorder()
+
+		h.ListExamples(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if calls := mockUC.ListExamplesCalls(); len(calls) != 1 || calls[0].Page != 1 || calls[0].PageSize != 20 || !calls[0].Desc {
+			t.Errorf("unexpected list calls: %+v", calls)
+		}
+		if !bytes.Contains(w.Body.Bytes(), []byte(`"examples":[]`)) {
+			t.Errorf("expected an empty list, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("archived only for admins", func(t *testing.T) {
+		jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+		mockUC := &mocks.ExampleUseCaseMock{}
+		h := NewExampleHandler(mockUC, apiMiddleware.NewAuthMiddleware(jwtService))
+
+		serve := func(accountType entities.AccountType, target string) *httptest.ResponseRecorder {
+			token, err := jwtService.GenerateToken(uuid.Must(uuid.NewV4()).String(), "someone@x.com", accountType.String())
+			if err != nil {
+				t.Fatalf("generating token: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			h.Routes().ServeHTTP(w, req)
+			return w
+		}
+
+		if w := serve(entities.AccountTypeUser, "/?include_archived=true"); w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d for users, got %d", http.StatusForbidden, w.Code)
+		}
+		if w := serve(entities.AccountTypeUser, "/"); w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if w := serve(entities.AccountTypeAdmin, "/?include_archived=true"); w.Code != http.StatusOK {
+			t.Errorf("expected status %d for admins, got %d", http.StatusOK, w.Code)
+		}
+		if w := serve(entities.AccountTypeAdmin, "/?include_archived=maybe"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+
+		calls := mockUC.ListExamplesCalls()
+		if len(calls) != 2 || calls[0].IncludeArchived || !calls[1].IncludeArchived {
+			t.Errorf("unexpected list calls: %+v", calls)
+		}
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		h := &ExampleHandler{
+			uc: &mocks.ExampleUseCaseMock{},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/examples?order=sideways", nil)
+		w := httptest.NewRecorder()
+
+		h.ListExamples(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+// newExampleTest serves the example routes, as attachmentTest does for
+// attachments, with the examples owned by owner unless uc loads them
+// itself.
+func newExampleTest(t *testing.T, uc *mocks.ExampleUseCaseMock, owner uuid.UUID) *attachmentTest {
+	if uc.GetExampleByIDFunc == nil {
+		uc.GetExampleByIDFunc = func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{ID: id, CreatedBy: &owner}, nil
+		}
+	}
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	h := NewExampleHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+	return &attachmentTest{t: t, jwtService: jwtService, handler: h.Routes()}
+}
+
+func TestUpdateExample(t *testing.T) {
+	readAt := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	owner := uuid.Must(uuid.NewV4())
+
+	serve := func(uc *mocks.ExampleUseCaseMock, id string, body any) *httptest.ResponseRecorder {
+		bodyJSON, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPut, "/"+id, bytes.NewBuffer(bodyJSON))
+		return newExampleTest(t, uc, owner).serve(owner, entities.AccountTypeUser, req)
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			UpdateExampleFunc: func(ctx context.Context, example entities.Example) (entities.Example, error) {
+				example.UpdatedAt = example.UpdatedAt.Add(time.Minute)
+				return example, nil
+			},
+		}
+
+		w := serve(mockUC, "123", UpdateExampleRequest{Title: "New Title", Content: "New Content", UpdatedAt: readAt})
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		calls := mockUC.UpdateExampleCalls()
+		if len(calls) != 1 || calls[0].Example.ID != "123" || !calls[0].Example.UpdatedAt.Equal(readAt) {
+			t.Errorf("unexpected update calls: %+v", calls)
+		}
+
+		var response entities.Example
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.Title != "New Title" || !response.UpdatedAt.After(readAt) {
+			t.Errorf("unexpected response: %+v", response)
+		}
+	})
+
+	t.Run("missing updated_at", func(t *testing.T) {
+		w := serve(&mocks.ExampleUseCaseMock{}, "123", UpdateExampleRequest{Title: "New Title"})
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("missing title", func(t *testing.T) {
+		w := serve(&mocks.ExampleUseCaseMock{}, "123", UpdateExampleRequest{UpdatedAt: readAt})
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("changed since read", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			UpdateExampleFunc: func(ctx context.Context, example entities.Example) (entities.Example, error) {
+				return entities.Example{}, domain.ErrConflict
+			},
+		}
+
+		w := serve(mockUC, "123", UpdateExampleRequest{Title: "New Title", UpdatedAt: readAt})
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			UpdateExampleFunc: func(ctx context.Context, example entities.Example) (entities.Example, error) {
+				return entities.Example{}, domain.ErrNotFound
+			},
+		}
+
+		w := serve(mockUC, "999", UpdateExampleRequest{Title: "New Title", UpdatedAt: readAt})
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{}
+		a := newExampleTest(t, mockUC, owner)
+		body, _ := json.Marshal(UpdateExampleRequest{Title: "New Title", UpdatedAt: readAt})
+
+		w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeUser, httptest.NewRequest(http.MethodPut, "/123", bytes.NewBuffer(body)))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d for other users, got %d", http.StatusForbidden, w.Code)
+		}
+		if calls := mockUC.UpdateExampleCalls(); len(calls) != 0 {
+			t.Fatalf("expected no update, got %d", len(calls))
+		}
+
+		w = a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeAdmin, httptest.NewRequest(http.MethodPut, "/123", bytes.NewBuffer(body)))
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d for admins, got %d", http.StatusOK, w.Code)
+		}
+	})
+}
+
+func TestDeleteExample(t *testing.T) {
+	owner := uuid.Must(uuid.NewV4())
+
+	serve := func(uc *mocks.ExampleUseCaseMock, id, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/"+id+query, nil)
+		return newExampleTest(t, uc, owner).serve(owner, entities.AccountTypeUser, req)
+	}
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{}
+
+		w := serve(mockUC, "123", "?updated_at=2026-01-02T03:04:05.123456Z")
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+		}
+
+		want := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+		calls := mockUC.DeleteExampleCalls()
+		if len(calls) != 1 || calls[0].ExpectedUpdatedAt == nil || !calls[0].ExpectedUpdatedAt.Equal(want) {
+			t.Errorf("unexpected delete calls: %+v", calls)
+		}
+	})
+
+	t.Run("unconditional deletion", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{}
+
+		w := serve(mockUC, "123", "")
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, w.Code)
+		}
+		if calls := mockUC.DeleteExampleCalls(); len(calls) != 1 || calls[0].ExpectedUpdatedAt != nil {
+			t.Errorf("unexpected delete calls: %+v", calls)
+		}
+	})
+
+	t.Run("invalid updated_at", func(t *testing.T) {
+		w := serve(&mocks.ExampleUseCaseMock{}, "123", "?updated_at=yesterday")
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("changed since read", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			DeleteExampleFunc: func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+				return domain.ErrConflict
+			},
+		}
+
+		w := serve(mockUC, "123", "?updated_at=2026-01-02T03:04:05Z")
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			DeleteExampleFunc: func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+				return domain.ErrNotFound
+			},
+		}
+
+		w := serve(mockUC, "999", "")
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{}
+		a := newExampleTest(t, mockUC, owner)
+
+		w := a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeUser, httptest.NewRequest(http.MethodDelete, "/123", nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d for other users, got %d", http.StatusForbidden, w.Code)
+		}
+		if calls := mockUC.DeleteExampleCalls(); len(calls) != 0 {
+			t.Fatalf("expected no deletion, got %d", len(calls))
+		}
+
+		w = a.serve(uuid.Must(uuid.NewV4()), entities.AccountTypeAdmin, httptest.NewRequest(http.MethodDelete, "/123", nil))
+		if w.Code != http.StatusNoContent {
+			t.Errorf("expected status %d for admins, got %d", http.StatusNoContent, w.Code)
+		}
+	})
+
+	t.Run("example without a creator", func(t *testing.T) {
+		mockUC := &mocks.ExampleUseCaseMock{
+			GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+				return entities.Example{ID: id}, nil
+			},
+		}
+
+		w := serve(mockUC, "123", "")
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, w.Code)
+		}
+	})
+}
+
+func TestArchiveExample(t *testing.T) {
+	archivedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	serve := func(uc *mocks.ExampleUseCaseMock, id string, archive bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/examples/"+id+"/archive", nil)
+		w := httptest.NewRecorder()
+
+		// Setup chi router context
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		h := &ExampleHandler{
+			uc: uc,
+		}
+		if archive {
+			h.ArchiveExample(w, req)
+		} else {
+			h.UnarchiveExample(w, req)
+		}
+		return w
+	}
+
+	mockUC := &mocks.ExampleUseCaseMock{
+		ArchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			if id != "123" {
+				return entities.Example{}, domain.ErrNotFound
+			}
+			return entities.Example{ID: id, ArchivedAt: &archivedAt}, nil
+		},
+		UnarchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{ID: id}, nil
+		},
+	}
+
+	t.Run("archive", func(t *testing.T) {
+		w := serve(mockUC, "123", true)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var response entities.Example
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.ArchivedAt == nil || !response.ArchivedAt.Equal(archivedAt) {
+			t.Errorf("expected the example to be archived, got %+v", response)
+		}
+	})
+
+	t.Run("unarchive", func(t *testing.T) {
+		w := serve(mockUC, "123", false)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if len(mockUC.UnarchiveExampleCalls()) != 1 {
+			t.Errorf("expected one unarchive call, got %d", len(mockUC.UnarchiveExampleCalls()))
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		w := serve(mockUC, "999", true)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
@@ -4,19 +4,46 @@ import (
 	"context"
 	"go-template/app/api/middleware"
 	"go-template/domain/entities"
+	"io"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
 )
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/example_uc.go . ExampleUseCase
 type ExampleUseCase interface {
 	CreateExample(ctx context.Context, example entities.Example) (string, error)
 	GetExampleByID(ctx context.Context, id string) (entities.Example, error)
+	ListExamples(ctx context.Context, page, pageSize int, desc, includeArchived bool) ([]entities.Example, int64, error)
+	UpdateExample(ctx context.Context, example entities.Example) (entities.Example, error)
+	DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error
+	ArchiveExample(ctx context.Context, id string) (entities.Example, error)
+	UnarchiveExample(ctx context.Context, id string) (entities.Example, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/attachment_uc.go . AttachmentUseCase
+type AttachmentUseCase interface {
+	MaxSize() int64
+	Upload(ctx context.Context, exampleID, fileName string, body io.Reader) (entities.Attachment, error)
+	List(ctx context.Context, exampleID string) ([]entities.Attachment, error)
+	Get(ctx context.Context, exampleID string, id uuid.UUID) (entities.Attachment, error)
+	Delete(ctx context.Context, exampleID string, id uuid.UUID) error
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/comment_uc.go . CommentUseCase
+type CommentUseCase interface {
+	Create(ctx context.Context, exampleID, body string) (entities.Comment, error)
+	List(ctx context.Context, exampleID string, page, pageSize int) ([]entities.Comment, int64, error)
+	Get(ctx context.Context, exampleID string, id uuid.UUID) (entities.Comment, error)
+	Delete(ctx context.Context, exampleID string, id uuid.UUID) error
 }
 
 type ExampleHandler struct {
-	uc ExampleUseCase
-	mw *middleware.AuthMiddleware
+	uc          ExampleUseCase
+	mw          *middleware.AuthMiddleware
+	attachments AttachmentUseCase
+	comments    CommentUseCase
 }
 
 func NewExampleHandler(uc ExampleUseCase, mw *middleware.AuthMiddleware) *ExampleHandler {
@@ -26,13 +53,42 @@ func NewExampleHandler(uc ExampleUseCase, mw *middleware.AuthMiddleware) *Exampl
 	}
 }
 
+// SetAttachments lets files be attached to examples.
+func (h *ExampleHandler) SetAttachments(uc AttachmentUseCase) {
+	h.attachments = uc
+}
+
+// SetComments lets examples be commented on.
+func (h *ExampleHandler) SetComments(uc CommentUseCase) {
+	h.comments = uc
+}
+
 func (h *ExampleHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
-	r.Use(h.mw.RequireAuth)
+	// Machine clients can call these with an API key holding the scope
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Post("/", h.CreateExample)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/", h.ListExamples)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}", h.GetExampleByID)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Put("/{id}", h.UpdateExample)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Delete("/{id}", h.DeleteExample)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Post("/{id}/archive", h.ArchiveExample)
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Post("/{id}/unarchive", h.UnarchiveExample)
 
-	r.Post("/", h.CreateExample)
-	r.Get("/{id}", h.GetExampleByID)
+	if h.attachments != nil {
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Post("/{id}/attachments", h.UploadAttachment)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}/attachments", h.ListAttachments)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}/attachments/{attachmentID}", h.GetAttachment)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}/attachments/{attachmentID}/download", h.DownloadAttachment)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Delete("/{id}/attachments/{attachmentID}", h.DeleteAttachment)
+	}
+
+	if h.comments != nil {
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Post("/{id}/comments", h.CreateComment)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}/comments", h.ListComments)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/{id}/comments/{commentID}", h.GetComment)
+		r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleWrite)).Delete("/{id}/comments/{commentID}", h.DeleteComment)
+	}
 
 	return r
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"go-template/domain/entities"
 	"sync"
+	"time"
 )
 
 // ExampleUseCaseMock is a mock implementation of example.ExampleUseCase.
@@ -15,12 +16,27 @@ import (
 //
 //		// make and configure a mocked example.ExampleUseCase
 //		mockedExampleUseCase := &ExampleUseCaseMock{
+//			ArchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+//				panic("mock out the ArchiveExample method")
+//			},
 //			CreateExampleFunc: func(ctx context.Context, example entities.Example) (string, error) {
 //				panic("mock out the CreateExample method")
 //			},
+//			DeleteExampleFunc: func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+//				panic("mock out the DeleteExample method")
+//			},
 //			GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
 //				panic("mock out the GetExampleByID method")
 //			},
+//			ListExamplesFunc: func(ctx context.Context, page int, pageSize int, desc bool, includeArchived bool) ([]entities.Example, int64, error) {
+//				panic("mock out the ListExamples method")
+//			},
+//			UnarchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+//				panic("mock out the UnarchiveExample method")
+//			},
+//			UpdateExampleFunc: func(ctx context.Context, example entities.Example) (entities.Example, error) {
+//				panic("mock out the UpdateExample method")
+//			},
 //		}
 //
 //		// use mockedExampleUseCase in code that requires example.ExampleUseCase
@@ -28,14 +44,36 @@ import (
 //
 //	}
 type ExampleUseCaseMock struct {
+	// ArchiveExampleFunc mocks the ArchiveExample method.
+	ArchiveExampleFunc func(ctx context.Context, id string) (entities.Example, error)
+
 	// CreateExampleFunc mocks the CreateExample method.
 	CreateExampleFunc func(ctx context.Context, example entities.Example) (string, error)
 
+	// DeleteExampleFunc mocks the DeleteExample method.
+	DeleteExampleFunc func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error
+
 	// GetExampleByIDFunc mocks the GetExampleByID method.
 	GetExampleByIDFunc func(ctx context.Context, id string) (entities.Example, error)
 
+	// ListExamplesFunc mocks the ListExamples method.
+	ListExamplesFunc func(ctx context.Context, page int, pageSize int, desc bool, includeArchived bool) ([]entities.Example, int64, error)
+
+	// UnarchiveExampleFunc mocks the UnarchiveExample method.
+	UnarchiveExampleFunc func(ctx context.Context, id string) (entities.Example, error)
+
+	// UpdateExampleFunc mocks the UpdateExample method.
+	UpdateExampleFunc func(ctx context.Context, example entities.Example) (entities.Example, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ArchiveExample holds details about calls to the ArchiveExample method.
+		ArchiveExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// CreateExample holds details about calls to the CreateExample method.
 		CreateExample []struct {
 			// Ctx is the ctx argument value.
@@ -43,6 +81,15 @@ type ExampleUseCaseMock struct {
 			// Example is the example argument value.
 			Example entities.Example
 		}
+		// DeleteExample holds details about calls to the DeleteExample method.
+		DeleteExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// ExpectedUpdatedAt is the expectedUpdatedAt argument value.
+			ExpectedUpdatedAt *time.Time
+		}
 		// GetExampleByID holds details about calls to the GetExampleByID method.
 		GetExampleByID []struct {
 			// Ctx is the ctx argument value.
@@ -50,9 +97,81 @@ type ExampleUseCaseMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// ListExamples holds details about calls to the ListExamples method.
+		ListExamples []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// PageSize is the pageSize argument value.
+			PageSize int
+			// Desc is the desc argument value.
+			Desc bool
+			// IncludeArchived is the includeArchived argument value.
+			IncludeArchived bool
+		}
+		// UnarchiveExample holds details about calls to the UnarchiveExample method.
+		UnarchiveExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// UpdateExample holds details about calls to the UpdateExample method.
+		UpdateExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Example is the example argument value.
+			Example entities.Example
+		}
+	}
+	lockArchiveExample   sync.RWMutex
+	lockCreateExample    sync.RWMutex
+	lockDeleteExample    sync.RWMutex
+	lockGetExampleByID   sync.RWMutex
+	lockListExamples     sync.RWMutex
+	lockUnarchiveExample sync.RWMutex
+	lockUpdateExample    sync.RWMutex
+}
+
+// ArchiveExample calls ArchiveExampleFunc.
+func (mock *ExampleUseCaseMock) ArchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
 	}
-	lockCreateExample  sync.RWMutex
-	lockGetExampleByID sync.RWMutex
+	mock.lockArchiveExample.Lock()
+	mock.calls.ArchiveExample = append(mock.calls.ArchiveExample, callInfo)
+	mock.lockArchiveExample.Unlock()
+	if mock.ArchiveExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.ArchiveExampleFunc(ctx, id)
+}
+
+// ArchiveExampleCalls gets all the calls that were made to ArchiveExample.
+// Check the length with:
+//
+//	len(mockedExampleUseCase.ArchiveExampleCalls())
+func (mock *ExampleUseCaseMock) ArchiveExampleCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockArchiveExample.RLock()
+	calls = mock.calls.ArchiveExample
+	mock.lockArchiveExample.RUnlock()
+	return calls
 }
 
 // CreateExample calls CreateExampleFunc.
@@ -95,6 +214,49 @@ func (mock *ExampleUseCaseMock) CreateExampleCalls() []struct {
 	return calls
 }
 
+// DeleteExample calls DeleteExampleFunc.
+func (mock *ExampleUseCaseMock) DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+	callInfo := struct {
+		Ctx               context.Context
+		ID                string
+		ExpectedUpdatedAt *time.Time
+	}{
+		Ctx:               ctx,
+		ID:                id,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	}
+	mock.lockDeleteExample.Lock()
+	mock.calls.DeleteExample = append(mock.calls.DeleteExample, callInfo)
+	mock.lockDeleteExample.Unlock()
+	if mock.DeleteExampleFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DeleteExampleFunc(ctx, id, expectedUpdatedAt)
+}
+
+// DeleteExampleCalls gets all the calls that were made to DeleteExample.
+// Check the length with:
+//
+//	len(mockedExampleUseCase.DeleteExampleCalls())
+func (mock *ExampleUseCaseMock) DeleteExampleCalls() []struct {
+	Ctx               context.Context
+	ID                string
+	ExpectedUpdatedAt *time.Time
+} {
+	var calls []struct {
+		Ctx               context.Context
+		ID                string
+		ExpectedUpdatedAt *time.Time
+	}
+	mock.lockDeleteExample.RLock()
+	calls = mock.calls.DeleteExample
+	mock.lockDeleteExample.RUnlock()
+	return calls
+}
+
 // GetExampleByID calls GetExampleByIDFunc.
 func (mock *ExampleUseCaseMock) GetExampleByID(ctx context.Context, id string) (entities.Example, error) {
 	callInfo := struct {
@@ -134,3 +296,136 @@ func (mock *ExampleUseCaseMock) GetExampleByIDCalls() []struct {
 	mock.lockGetExampleByID.RUnlock()
 	return calls
 }
+
+// ListExamples calls ListExamplesFunc.
+func (mock *ExampleUseCaseMock) ListExamples(ctx context.Context, page int, pageSize int, desc bool, includeArchived bool) ([]entities.Example, int64, error) {
+	callInfo := struct {
+		Ctx             context.Context
+		Page            int
+		PageSize        int
+		Desc            bool
+		IncludeArchived bool
+	}{
+		Ctx:             ctx,
+		Page:            page,
+		PageSize:        pageSize,
+		Desc:            desc,
+		IncludeArchived: includeArchived,
+	}
+	mock.lockListExamples.Lock()
+	mock.calls.ListExamples = append(mock.calls.ListExamples, callInfo)
+	mock.lockListExamples.Unlock()
+	if mock.ListExamplesFunc == nil {
+		var (
+			examplesOut []entities.Example
+			nOut        int64
+			errOut      error
+		)
+		return examplesOut, nOut, errOut
+	}
+	return mock.ListExamplesFunc(ctx, page, pageSize, desc, includeArchived)
+}
+
+// ListExamplesCalls gets all the calls that were made to ListExamples.
+// Check the length with:
+//
+//	len(mockedExampleUseCase.ListExamplesCalls())
+func (mock *ExampleUseCaseMock) ListExamplesCalls() []struct {
+	Ctx             context.Context
+	Page            int
+	PageSize        int
+	Desc            bool
+	IncludeArchived bool
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Page            int
+		PageSize        int
+		Desc            bool
+		IncludeArchived bool
+	}
+	mock.lockListExamples.RLock()
+	calls = mock.calls.ListExamples
+	mock.lockListExamples.RUnlock()
+	return calls
+}
+
+// UnarchiveExample calls UnarchiveExampleFunc.
+func (mock *ExampleUseCaseMock) UnarchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockUnarchiveExample.Lock()
+	mock.calls.UnarchiveExample = append(mock.calls.UnarchiveExample, callInfo)
+	mock.lockUnarchiveExample.Unlock()
+	if mock.UnarchiveExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.UnarchiveExampleFunc(ctx, id)
+}
+
+// UnarchiveExampleCalls gets all the calls that were made to UnarchiveExample.
+// Check the length with:
+//
+//	len(mockedExampleUseCase.UnarchiveExampleCalls())
+func (mock *ExampleUseCaseMock) UnarchiveExampleCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockUnarchiveExample.RLock()
+	calls = mock.calls.UnarchiveExample
+	mock.lockUnarchiveExample.RUnlock()
+	return calls
+}
+
+// UpdateExample calls UpdateExampleFunc.
+func (mock *ExampleUseCaseMock) UpdateExample(ctx context.Context, example entities.Example) (entities.Example, error) {
+	callInfo := struct {
+		Ctx     context.Context
+		Example entities.Example
+	}{
+		Ctx:     ctx,
+		Example: example,
+	}
+	mock.lockUpdateExample.Lock()
+	mock.calls.UpdateExample = append(mock.calls.UpdateExample, callInfo)
+	mock.lockUpdateExample.Unlock()
+	if mock.UpdateExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.UpdateExampleFunc(ctx, example)
+}
+
+// UpdateExampleCalls gets all the calls that were made to UpdateExample.
+// Check the length with:
+//
+//	len(mockedExampleUseCase.UpdateExampleCalls())
+func (mock *ExampleUseCaseMock) UpdateExampleCalls() []struct {
+	Ctx     context.Context
+	Example entities.Example
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Example entities.Example
+	}
+	mock.lockUpdateExample.RLock()
+	calls = mock.calls.UpdateExample
+	mock.lockUpdateExample.RUnlock()
+	return calls
+}
@@ -0,0 +1,82 @@
+package exports
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain/exportjob"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// CreateExport godoc
+//
+//	@Summary		Queue an export
+//	@Description	Queue an export of the users matching the same search, account_type and filter parameters as the users list, as CSV (the default) or XLSX, for exports too large to stream with GET /admin/v1/users/export. Poll the job's Location until it has succeeded, then download the file from its download_url.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		exportjob.CreateRequest	true	"Kind, format and users list parameters"
+//	@Success		202		{object}	entities.ExportJob
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/exports [post]
+func (h *ExportHandler) CreateExport(w http.ResponseWriter, r *http.Request) {
+	var req exportjob.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	job, err := h.uc.Create(r.Context(), req)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/admin/v1/exports/"+job.ID.String())
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, job)
+}
+
+// GetExport godoc
+//
+//	@Summary		Get an export
+//	@Description	Get an export job's status: pending, running, succeeded or failed. Succeeded jobs come with a download_url that works until download_url_expires_at; get the job again for a fresh one. Jobs and their files are removed once EXPORT_RETENTION has passed since they finished.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Export job ID"
+//	@Success		200	{object}	entities.ExportJob
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/exports/{id} [get]
+func (h *ExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid export job ID",
+		})
+		return
+	}
+
+	job, err := h.uc.Get(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "export job"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+}
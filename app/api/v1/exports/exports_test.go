@@ -0,0 +1,96 @@
+package exports
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/exports/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/exportjob"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestExportHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	jobID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.ExportJobUseCaseMock{
+		CreateFunc: func(ctx context.Context, req exportjob.CreateRequest) (entities.ExportJob, error) {
+			if req.Format == "pdf" {
+				return entities.ExportJob{}, domain.ErrMalformedParameters
+			}
+			return entities.ExportJob{ID: jobID, Kind: entities.ExportKindUsers, Format: req.Format, Status: entities.ExportJobPending}, nil
+		},
+		GetFunc: func(ctx context.Context, id uuid.UUID) (entities.ExportJob, error) {
+			if id != jobID {
+				return entities.ExportJob{}, domain.ErrNotFound
+			}
+			return entities.ExportJob{ID: jobID, Status: entities.ExportJobSucceeded, FileKey: "private/exports/x.csv", DownloadURL: "/files/private/exports/x.csv?signature=x"}, nil
+		},
+	}
+	h := NewExportHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target, body string) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeUser, http.MethodPost, "/", `{}`); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for users, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{"format":"xlsx","filter":"status:active"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Location"); got != "/admin/v1/exports/"+jobID.String() {
+		t.Fatalf("unexpected Location %q", got)
+	}
+	if calls := uc.CreateCalls(); len(calls) != 1 || calls[0].Req.Format != "xlsx" || calls[0].Req.Filter != "status:active" {
+		t.Fatalf("unexpected create calls: %+v", calls)
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{"format":"pdf"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown format, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a malformed body, got %d", w.Code)
+	}
+
+	w = serve(entities.AccountTypeAdmin, http.MethodGet, "/"+jobID.String(), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "file_key") {
+		t.Fatalf("the storage key leaked: %s", w.Body.String())
+	}
+	var job entities.ExportJob
+	if err := json.NewDecoder(w.Body).Decode(&job); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if job.Status != entities.ExportJobSucceeded || job.DownloadURL == "" {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodGet, "/"+uuid.Must(uuid.NewV4()).String(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeAdmin, http.MethodGet, "/nope", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad ID, got %d", w.Code)
+	}
+}
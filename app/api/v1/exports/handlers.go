@@ -0,0 +1,43 @@
+package exports
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/domain/exportjob"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/export_job_uc.go . ExportJobUseCase
+type ExportJobUseCase interface {
+	Create(ctx context.Context, req exportjob.CreateRequest) (entities.ExportJob, error)
+	Get(ctx context.Context, id uuid.UUID) (entities.ExportJob, error)
+}
+
+type ExportHandler struct {
+	uc ExportJobUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewExportHandler(uc ExportJobUseCase, mw *middleware.AuthMiddleware) *ExportHandler {
+	return &ExportHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// AdminRoutes returns the endpoints admins queue exports and poll them with,
+// mounted at /admin/v1/exports
+func (h *ExportHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAdmin)
+	r.Use(middleware.DryRun)
+
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersRead)).Post("/", h.CreateExport)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersRead)).Get("/{id}", h.GetExport)
+
+	return r
+}
@@ -3,13 +3,59 @@ package v1
 import (
 	"go-template/app/api/middleware"
 	"go-template/app/api/v1/admin"
+	"go-template/app/api/v1/announcements"
+	"go-template/app/api/v1/apikeys"
+	"go-template/app/api/v1/audit"
 	"go-template/app/api/v1/auth"
+	"go-template/app/api/v1/backups"
+	"go-template/app/api/v1/breakglass"
+	"go-template/app/api/v1/emails"
 	"go-template/app/api/v1/example"
+	"go-template/app/api/v1/exports"
+	"go-template/app/api/v1/invitations"
+	"go-template/app/api/v1/jobs"
+	"go-template/app/api/v1/notifications"
+	"go-template/app/api/v1/oidc"
+	"go-template/app/api/v1/outgoingwebhooks"
+	"go-template/app/api/v1/permissions"
+	"go-template/app/api/v1/preferences"
+	"go-template/app/api/v1/search"
+	"go-template/app/api/v1/system"
+	"go-template/app/api/v1/uploads"
+	"go-template/app/api/v1/webhooks"
+	"go-template/app/api/v1/websocket"
+	"go-template/domain/alerting"
+	"go-template/domain/announcement"
+	"go-template/domain/apikey"
+	"go-template/domain/attachment"
+	auditDomain "go-template/domain/audit"
 	authDomain "go-template/domain/auth"
+	"go-template/domain/backup"
+	"go-template/domain/comment"
+	"go-template/domain/deletion"
+	"go-template/domain/exportjob"
+	"go-template/domain/invitation"
+	"go-template/domain/job"
+	"go-template/domain/loginhistory"
+	"go-template/domain/notification"
+	preferencesDomain "go-template/domain/preferences"
+	"go-template/domain/reconciliation"
+	"go-template/domain/revocation"
+	searchDomain "go-template/domain/search"
+	"go-template/domain/session"
 	"go-template/domain/settings"
+	"go-template/domain/upload"
 	"go-template/domain/user"
+	"go-template/domain/webhook"
+	"go-template/internal/botdetect"
+	"go-template/internal/health"
 	"go-template/internal/jwt"
+	"go-template/internal/metrics"
+	"go-template/internal/profiling"
+	"go-template/internal/ratelimit"
+	"go-template/internal/ws"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -22,33 +68,260 @@ type ApiHandlers struct {
 	SettingsUseCase *settings.UseCase
 	AuthMiddleware  *middleware.AuthMiddleware
 	JWTService      jwt.Service
+	OIDCUseCase     oidc.OIDCUseCase
+	PermissionsUC   permissions.PermissionsUseCase
+	BreakGlassUC    breakglass.BreakGlassUseCase
+	ReadOnly        middleware.ReadOnlyChecker
+	BotDetector     *botdetect.Detector
+	LoginLimiter    *ratelimit.Limiter
+	LogSource       system.LogSource
+	LogLevel        system.LogLevel
+	Readiness       *health.Checker
+	RevocationUC    *revocation.UseCase
+	SessionUC       *session.UseCase
+	APIKeyUC        *apikey.UseCase
+	AuditUC         *auditDomain.UseCase
+	DeletionUC      *deletion.UseCase
+	PreferencesUC   *preferencesDomain.UseCase
+	NotificationUC  *notification.UseCase
+	Hub             *ws.Hub
+	LoginHistoryUC  *loginhistory.UseCase
+	InvitationUC    *invitation.UseCase
+	AnnouncementUC  *announcement.UseCase
+	SearchUC        *searchDomain.UseCase
+	ExportJobUC     *exportjob.UseCase
+	BackupUC        *backup.UseCase
+	JobQueue        *job.Queue
+	AttachmentUC    *attachment.UseCase
+	CommentUC       *comment.UseCase
+	UploadUC        *upload.UseCase
+	WebhookUC       *webhook.UseCase
+	AlertingUC      *alerting.UseCase
+	EmailPreviewer  emails.EmailPreviewer
+
+	// Auth provider reconciliation. The Supabase webhook receiver is only
+	// mounted when SupabaseWebhookSecret is set.
+	ReconciliationUC      *reconciliation.UseCase
+	SupabaseWebhookSecret string
+
+	// Serve pprof and expvar to super admins at /admin/v1/debug
+	DebugEndpoints bool
 }
 
 func (h *ApiHandlers) Routes(r chi.Router) {
+	// Reject writes while the database is in read-only mode, except on the
+	// routes registered with middleware.WritableInReadOnly
+	r.Use(middleware.ReadOnly(h.ReadOnly, r))
+
 	// Health check
 	r.Get("/health", h.Health)
 
+	// Kubernetes probes: /live while the process serves requests, /ready
+	// while the dependencies it needs are up
+	r.Get("/live", health.Live)
+	if h.Readiness != nil {
+		r.Get("/ready", h.Readiness.Ready)
+	}
+
+	// Prometheus metrics
+	r.Handle("/metrics", metrics.Handler())
+
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
 		// Auth routes (mixed public/protected)
 		authHandler := auth.NewAuthHandler(h.AuthUseCase, h.UserUseCase, h.JWTService, h.AuthMiddleware)
+		authHandler.SetBotDetector(h.BotDetector)
+		authHandler.SetLoginLimiter(h.LoginLimiter)
+		if h.RevocationUC != nil {
+			authHandler.SetTokenRevoker(h.RevocationUC)
+		}
+		if h.SessionUC != nil {
+			authHandler.SetSessionUseCase(h.SessionUC)
+		}
+		if h.DeletionUC != nil {
+			authHandler.SetAccountDeletion(h.DeletionUC)
+		}
 		r.Mount("/auth", authHandler.Routes())
 
 		// Example routes (protected)
 		exampleHandler := example.NewExampleHandler(h.ExampleUseCase, h.AuthMiddleware)
+		if h.AttachmentUC != nil {
+			exampleHandler.SetAttachments(h.AttachmentUC)
+		}
+		if h.CommentUC != nil {
+			exampleHandler.SetComments(h.CommentUC)
+		}
 		r.Mount("/example", exampleHandler.Routes())
+
+		// API keys for machine clients
+		if h.APIKeyUC != nil {
+			apiKeyHandler := apikeys.NewAPIKeyHandler(h.APIKeyUC, h.AuthMiddleware)
+			r.Mount("/keys", apiKeyHandler.Routes())
+		}
+
+		// Per-user preferences
+		if h.PreferencesUC != nil {
+			preferencesHandler := preferences.NewPreferencesHandler(h.PreferencesUC, h.AuthMiddleware)
+			r.Mount("/users/me/preferences", preferencesHandler.Routes())
+		}
+
+		// Per-user notifications
+		if h.NotificationUC != nil {
+			notificationHandler := notifications.NewNotificationHandler(h.NotificationUC, h.AuthMiddleware)
+			r.Mount("/notifications", notificationHandler.Routes())
+		}
+
+		// Real-time messages, such as notifications, over WebSocket
+		if h.Hub != nil {
+			webSocketHandler := websocket.NewWebSocketHandler(h.Hub, h.AuthMiddleware)
+			r.Mount("/ws", webSocketHandler.Routes())
+		}
+
+		// Announcements broadcast by admins
+		if h.AnnouncementUC != nil {
+			announcementHandler := announcements.NewAnnouncementHandler(h.AnnouncementUC, h.AuthMiddleware)
+			r.Mount("/announcements", announcementHandler.Routes())
+		}
+
+		// Full-text search
+		if h.SearchUC != nil {
+			searchHandler := search.NewSearchHandler(h.SearchUC, h.AuthMiddleware)
+			r.Mount("/search", searchHandler.Routes())
+		}
+
+		// Uploads made straight to file storage
+		if h.UploadUC != nil {
+			uploadHandler := uploads.NewUploadHandler(h.UploadUC, h.AuthMiddleware)
+			r.Mount("/uploads", uploadHandler.Routes())
+		}
 	})
 
 	// Admin routes (protected)
 	adminHandler := admin.NewAdminHandler(h.AuthUseCase, h.UserUseCase, h.SettingsUseCase, h.JWTService, h.AuthMiddleware)
+	adminHandler.SetLoginLimiter(h.LoginLimiter)
+	if h.RevocationUC != nil {
+		adminHandler.SetTokenRevoker(h.RevocationUC)
+	}
+	if h.SessionUC != nil {
+		adminHandler.SetSessionCounter(h.SessionUC)
+		adminHandler.SetSessionRevoker(h.SessionUC)
+	}
+	if h.LoginHistoryUC != nil {
+		adminHandler.SetLoginHistory(h.LoginHistoryUC)
+	}
+	if h.AlertingUC != nil {
+		adminHandler.SetAlertCounter(h.AlertingUC)
+	}
 	r.Mount("/admin/v1", adminHandler.Routes())
 
+	// Delegated admin permissions
+	permissionsHandler := permissions.NewPermissionsHandler(h.PermissionsUC, h.AuthMiddleware)
+	r.Mount("/admin/v1/permissions", permissionsHandler.AdminRoutes())
+	r.Mount("/admin/v1/roles", permissionsHandler.RoleRoutes())
+
+	// API key oversight
+	if h.APIKeyUC != nil {
+		apiKeyHandler := apikeys.NewAPIKeyHandler(h.APIKeyUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/api-keys", apiKeyHandler.AdminRoutes())
+	}
+
+	// Invitation codes for invite-only registration
+	if h.InvitationUC != nil {
+		invitationHandler := invitations.NewInvitationHandler(h.InvitationUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/invitations", invitationHandler.AdminRoutes())
+	}
+
+	// Announcements broadcast to everyone using the apps
+	if h.AnnouncementUC != nil {
+		announcementHandler := announcements.NewAnnouncementHandler(h.AnnouncementUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/announcements", announcementHandler.AdminRoutes())
+	}
+
+	// Exports produced in the background
+	if h.ExportJobUC != nil {
+		exportHandler := exports.NewExportHandler(h.ExportJobUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/exports", exportHandler.AdminRoutes())
+	}
+
+	// Database backups kept in file storage
+	if h.BackupUC != nil {
+		backupHandler := backups.NewBackupHandler(h.BackupUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/backups", backupHandler.AdminRoutes())
+	}
+
+	// Background jobs, for debugging their processing
+	if h.JobQueue != nil {
+		jobHandler := jobs.NewJobHandler(h.JobQueue, h.AuthMiddleware)
+		r.Mount("/admin/v1/jobs", jobHandler.AdminRoutes())
+	}
+
+	// Outgoing webhooks and their deliveries
+	if h.WebhookUC != nil {
+		outgoingHandler := outgoingwebhooks.NewWebhookHandler(h.WebhookUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/webhooks", outgoingHandler.AdminRoutes())
+	}
+
+	// Transactional email previews for designers
+	if h.EmailPreviewer != nil {
+		emailHandler := emails.NewEmailHandler(h.EmailPreviewer, h.AuthMiddleware)
+		r.Mount("/admin/v1/emails", emailHandler.AdminRoutes())
+	}
+
+	// Break-glass emergency access
+	breakGlassHandler := breakglass.NewBreakGlassHandler(h.BreakGlassUC)
+	r.Mount("/admin/v1/break-glass", breakGlassHandler.AdminRoutes())
+
+	// Audit log search
+	if h.AuditUC != nil {
+		auditHandler := audit.NewAuditHandler(h.AuditUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/audit", auditHandler.AdminRoutes())
+	}
+
+	// Full-text search across users and examples
+	if h.SearchUC != nil {
+		searchHandler := search.NewSearchHandler(h.SearchUC, h.AuthMiddleware)
+		r.Mount("/admin/v1/search", searchHandler.AdminRoutes())
+	}
+
+	// Operational endpoints
+	systemHandler := system.NewSystemHandler(h.LogSource, h.ReconciliationUC, h.LogLevel, h.AlertingUC, h.AuthMiddleware)
+	r.Mount("/admin/v1/system", systemHandler.AdminRoutes())
+	if h.DebugEndpoints {
+		// The router times requests out after 60s, cutting longer profiles
+		// short
+		r.With(h.AuthMiddleware.RequireSuperAdmin).
+			Mount("/admin/v1/debug", http.StripPrefix("/admin/v1", profiling.LimitSeconds(profiling.Handler(), 50*time.Second)))
+	}
+
+	// Auth provider webhooks
+	if h.SupabaseWebhookSecret != "" {
+		webhookHandler := webhooks.NewWebhookHandler(h.ReconciliationUC, h.SupabaseWebhookSecret)
+		r.Mount("/webhooks", webhookHandler.Routes())
+	}
+
+	// OpenID Connect provider
+	oidcHandler := oidc.NewOIDCHandler(h.OIDCUseCase, h.AuthMiddleware)
+	r.Get("/.well-known/openid-configuration", oidcHandler.Discovery)
+	r.Get("/.well-known/jwks.json", oidcHandler.JWKS)
+	r.Mount("/oauth2", oidcHandler.Routes())
+	r.Get("/userinfo", oidcHandler.UserInfo)
+	r.Post("/userinfo", oidcHandler.UserInfo)
+	r.Mount("/admin/v1/oauth-clients", oidcHandler.AdminRoutes())
 }
 
 func (h *ApiHandlers) Health(w http.ResponseWriter, r *http.Request) {
+	mode := "read-write"
+	if h.ReadOnly != nil && h.ReadOnly.ReadOnly() {
+		mode = "read-only"
+	}
+
 	response := map[string]string{
 		"status":  "ok",
 		"service": "go-template-api",
+		"mode":    mode,
+	}
+	if h.LogLevel != nil {
+		response["log_level"] = h.LogLevel.Level().String()
 	}
 
 	render.Status(r, http.StatusOK)
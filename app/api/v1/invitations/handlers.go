@@ -0,0 +1,50 @@
+package invitations
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/domain/invitation"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/invitation_uc.go . InvitationUseCase
+type InvitationUseCase interface {
+	Create(ctx context.Context, adminID uuid.UUID, req invitation.CreateRequest) (entities.Invitation, string, error)
+	Invite(ctx context.Context, adminID uuid.UUID, req invitation.InviteRequest) (entities.Invitation, error)
+	List(ctx context.Context) ([]entities.Invitation, error)
+	Revoke(ctx context.Context, id, adminID uuid.UUID) error
+}
+
+type InvitationHandler struct {
+	uc        InvitationUseCase
+	mw        *middleware.AuthMiddleware
+	validator *validator.Validate
+}
+
+func NewInvitationHandler(uc InvitationUseCase, mw *middleware.AuthMiddleware) *InvitationHandler {
+	return &InvitationHandler{
+		uc:        uc,
+		mw:        mw,
+		validator: validator.New(),
+	}
+}
+
+// AdminRoutes returns the endpoints admins hand out invitation codes with,
+// mounted at /admin/v1/invitations
+func (h *InvitationHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAdmin)
+	r.Use(middleware.DryRun)
+
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersRead)).Get("/", h.ListInvitations)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersWrite)).Post("/", h.CreateInvitation)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersWrite)).Post("/email", h.SendInvitation)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersWrite)).Delete("/{id}", h.RevokeInvitation)
+
+	return r
+}
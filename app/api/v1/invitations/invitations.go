@@ -0,0 +1,216 @@
+package invitations
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/invitation"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type CreateInvitationResponse struct {
+	entities.Invitation
+	Code string `json:"code"`
+}
+
+// ListInvitations godoc
+//
+//	@Summary		List invitations
+//	@Description	List every invitation code, including used up, expired and revoked ones, newest first
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.Invitation
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/invitations [get]
+func (h *InvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.uc.List(r.Context())
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list invitations", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list invitations",
+		})
+		return
+	}
+	if invitations == nil {
+		invitations = []entities.Invitation{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, invitations)
+}
+
+// CreateInvitation godoc
+//
+//	@Summary		Create an invitation
+//	@Description	Create an invitation code people can register with while registration is disabled. Users registering with it get its account type. Codes without max_uses can be used any number of times, and those without expires_at expire after 7 days. Regular admins can only invite user accounts. The code is only returned once.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		invitation.CreateRequest	true	"Account type, uses, expiry and note"
+//	@Success		201		{object}	CreateInvitationResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/invitations [post]
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req invitation.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if principal.AccountType == entities.AccountTypeAdmin && req.AccountType != "" && req.AccountType != entities.AccountTypeUser {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, map[string]string{
+			"error": "regular admins can only invite user accounts",
+		})
+		return
+	}
+
+	created, code, err := h.uc.Create(r.Context(), principal.UserID, req)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, CreateInvitationResponse{
+		Invitation: created,
+		Code:       code,
+	})
+}
+
+// SendInvitation godoc
+//
+//	@Summary		Invite a user by email
+//	@Description	Email a link to accept an invitation, where the invitee chooses their password and their account is created. The invitation can be used once, only by that address, and expires after 7 days without expires_at. Regular admins can only invite user accounts.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		invitation.InviteRequest	true	"Email, account type, expiry and note"
+//	@Success		201		{object}	entities.Invitation
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/invitations/email [post]
+func (h *InvitationHandler) SendInvitation(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req invitation.InviteRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	if principal.AccountType == entities.AccountTypeAdmin && req.AccountType != "" && req.AccountType != entities.AccountTypeUser {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, map[string]string{
+			"error": "regular admins can only invite user accounts",
+		})
+		return
+	}
+
+	sent, err := h.uc.Invite(r.Context(), principal.UserID, req)
+	if err != nil {
+		if errors.Is(err, invitation.ErrEmailInvitesDisabled) {
+			common.ErrorResponse(w, r, http.StatusNotFound, err)
+			return
+		}
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, sent)
+}
+
+// RevokeInvitation godoc
+//
+//	@Summary		Revoke an invitation
+//	@Description	Stop an invitation code from being registered with. Users who already registered with it are kept.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Invitation ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid invitation ID",
+		})
+		return
+	}
+
+	err = h.uc.Revoke(r.Context(), id, principal.UserID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "invitation"))
+		return
+	}
+
+	message := "invitation revoked"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: " + message
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": message,
+	})
+}
@@ -0,0 +1,150 @@
+package invitations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/invitations/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/invitation"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestInvitationHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	invitationID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.InvitationUseCaseMock{
+		CreateFunc: func(ctx context.Context, by uuid.UUID, req invitation.CreateRequest) (entities.Invitation, string, error) {
+			if req.MaxUses != nil && *req.MaxUses < 1 {
+				return entities.Invitation{}, "", domain.ErrMalformedParameters
+			}
+			accountType := req.AccountType
+			if accountType == "" {
+				accountType = entities.AccountTypeUser
+			}
+			return entities.Invitation{ID: invitationID, AccountType: accountType, MaxUses: req.MaxUses, CreatedBy: &by}, "ABCDEFGHJKLMNPQR", nil
+		},
+		ListFunc: func(ctx context.Context) ([]entities.Invitation, error) {
+			return []entities.Invitation{{ID: invitationID}}, nil
+		},
+		RevokeFunc: func(ctx context.Context, id, by uuid.UUID) error {
+			if id != invitationID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewInvitationHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target, body string) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeUser, http.MethodGet, "/", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for users, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{"max_uses":5}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created CreateInvitationResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if created.Code == "" || created.AccountType != entities.AccountTypeUser || created.MaxUses == nil || *created.MaxUses != 5 {
+		t.Fatalf("unexpected invitation: %+v", created)
+	}
+	if calls := uc.CreateCalls(); len(calls) != 1 || calls[0].AdminID != adminID {
+		t.Fatalf("expected the invitation to be made by the admin, got %+v", calls)
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{"account_type":"admin"}`); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for regular admins inviting admins, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/", `{"account_type":"admin"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for super admins inviting admins, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeAdmin, http.MethodPost, "/", `{"max_uses":0}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	w = serve(entities.AccountTypeAdmin, http.MethodGet, "/", "")
+	var invitations []entities.Invitation
+	if err := json.NewDecoder(w.Body).Decode(&invitations); err != nil || len(invitations) != 1 {
+		t.Fatalf("expected the invitations, got %d %+v (%v)", w.Code, invitations, err)
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodDelete, "/"+invitationID.String(), ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeAdmin, http.MethodDelete, "/"+uuid.Must(uuid.NewV4()).String(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeAdmin, http.MethodDelete, "/not-a-uuid", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestInvitationHandler_SendInvitation(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		body        string
+		err         error
+		wantStatus  int
+	}{
+		{name: "sent", accountType: entities.AccountTypeAdmin, body: `{"email":"new@x.com"}`, wantStatus: http.StatusCreated},
+		{name: "invalid email", accountType: entities.AccountTypeAdmin, body: `{"email":"nope"}`, wantStatus: http.StatusBadRequest},
+		{name: "admin inviting admin", accountType: entities.AccountTypeAdmin, body: `{"email":"new@x.com","account_type":"admin"}`, wantStatus: http.StatusForbidden},
+		{name: "super admin inviting admin", accountType: entities.AccountTypeSuperAdmin, body: `{"email":"new@x.com","account_type":"admin"}`, wantStatus: http.StatusCreated},
+		{name: "no email provider", accountType: entities.AccountTypeAdmin, body: `{"email":"new@x.com"}`, err: invitation.ErrEmailInvitesDisabled, wantStatus: http.StatusNotFound},
+		{name: "mailer failed", accountType: entities.AccountTypeAdmin, body: `{"email":"new@x.com"}`, err: errors.New("smtp down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.InvitationUseCaseMock{
+				InviteFunc: func(ctx context.Context, adminID uuid.UUID, req invitation.InviteRequest) (entities.Invitation, error) {
+					if tt.err != nil {
+						return entities.Invitation{}, tt.err
+					}
+					return entities.Invitation{ID: uuid.Must(uuid.NewV4()), Email: req.Email, AccountType: req.AccountType}, nil
+				},
+			}
+			h := NewInvitationHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+			token, err := jwtService.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", tt.accountType.String())
+			if err != nil {
+				t.Fatalf("generating token: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/email", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			h.AdminRoutes().ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
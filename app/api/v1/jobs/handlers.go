@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/job_uc.go . JobUseCase
+type JobUseCase interface {
+	List(ctx context.Context, filter entities.JobFilter) (entities.JobListResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (entities.Job, error)
+	Requeue(ctx context.Context, id uuid.UUID) (entities.Job, error)
+	Cancel(ctx context.Context, id uuid.UUID) (entities.Job, error)
+}
+
+type JobHandler struct {
+	uc JobUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewJobHandler(uc JobUseCase, mw *middleware.AuthMiddleware) *JobHandler {
+	return &JobHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// AdminRoutes returns the endpoints following, requeueing and canceling
+// background jobs, mounted at /admin/v1/jobs. Job arguments and errors may
+// hold any user's data, so they are limited to super admins.
+func (h *JobHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireSuperAdmin)
+	r.Use(middleware.DryRun)
+
+	r.Get("/", h.ListJobs)
+	r.Get("/{id}", h.GetJob)
+	r.Post("/{id}/requeue", h.RequeueJob)
+	r.Post("/{id}/cancel", h.CancelJob)
+
+	return r
+}
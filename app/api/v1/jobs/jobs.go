@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain/entities"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListJobs godoc
+//
+//	@Summary		List jobs
+//	@Description	List the background jobs a page at a time, newest first, with how many jobs there are of each status. Jobs that succeeded are removed after JOB_RETENTION; dead-lettered and canceled ones are kept.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string	false	"Filter by status"	Enums(queued, running, succeeded, dead, canceled)
+//	@Param			kind		query		string	false	"Filter by kind, such as backup.run"
+//	@Param			page		query		int		false	"Page number (default: 1)"
+//	@Param			page_size	query		int		false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	entities.JobListResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/jobs [get]
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	list, err := h.uc.List(r.Context(), entities.JobFilter{
+		Status:   entities.JobStatus(query.Get("status")),
+		Kind:     query.Get("kind"),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// GetJob godoc
+//
+//	@Summary		Get a job
+//	@Description	Get a background job with its arguments, attempts and the error of its last failed attempt. Jobs that ran out of attempts, or failed for good, are dead-lettered with the status dead.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{object}	entities.Job
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/jobs/{id} [get]
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.uc.Get(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "job"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+}
+
+// RequeueJob godoc
+//
+//	@Summary		Requeue a job
+//	@Description	Queue a dead-lettered or canceled job again, due at once and with all its attempts. Its last error is kept until it runs. Handlers must not mind running a job again.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{object}	entities.Job
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/jobs/{id}/requeue [post]
+func (h *JobHandler) RequeueJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.uc.Requeue(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "job"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+}
+
+// CancelJob godoc
+//
+//	@Summary		Cancel a job
+//	@Description	Keep a queued job from running. Jobs already running can't be canceled.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{object}	entities.Job
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/jobs/{id}/cancel [post]
+func (h *JobHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := jobID(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.uc.Cancel(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "job"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+}
+
+func jobID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid job ID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
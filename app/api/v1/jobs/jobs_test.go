@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/jobs/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestJobHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	jobID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.JobUseCaseMock{
+		ListFunc: func(ctx context.Context, filter entities.JobFilter) (entities.JobListResponse, error) {
+			if filter.Status == "lost" {
+				return entities.JobListResponse{}, fmt.Errorf("%w: unknown job status", domain.ErrMalformedParameters)
+			}
+			return entities.JobListResponse{Jobs: []entities.Job{{ID: jobID}}, Total: 1, Page: filter.Page, PageSize: filter.PageSize}, nil
+		},
+		GetFunc: func(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+			if id != jobID {
+				return entities.Job{}, domain.ErrNotFound
+			}
+			return entities.Job{ID: jobID, Status: entities.JobDead}, nil
+		},
+		RequeueFunc: func(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+			return entities.Job{ID: id, Status: entities.JobQueued}, nil
+		},
+		CancelFunc: func(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+			return entities.Job{}, fmt.Errorf("%w: only queued jobs can be canceled", domain.ErrConflict)
+		},
+	}
+	h := NewJobHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target string) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodGet, "/"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for admins, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/?status=dead&kind=backup.run&page=2&page_size=10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := entities.JobFilter{Status: entities.JobDead, Kind: "backup.run", Page: 2, PageSize: 10}
+	if calls := uc.ListCalls(); len(calls) != 1 || calls[0].Filter != want {
+		t.Fatalf("unexpected list calls: %+v", calls)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/?status=lost"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown status, got %d", w.Code)
+	}
+
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+jobID.String()); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+uuid.Must(uuid.NewV4()).String()); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/nope/requeue"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad ID, got %d", w.Code)
+	}
+
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/"+jobID.String()+"/requeue"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls := uc.RequeueCalls(); len(calls) != 1 || calls[0].ID != jobID {
+		t.Fatalf("unexpected requeue calls: %+v", calls)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/"+jobID.String()+"/cancel"); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a job that isn't queued, got %d", w.Code)
+	}
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/notification_uc.go . NotificationUseCase
+type NotificationUseCase interface {
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, page, pageSize int) (entities.NotificationListResponse, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	Subscribe(ctx context.Context, userID uuid.UUID) <-chan entities.Notification
+}
+
+type NotificationHandler struct {
+	uc NotificationUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewNotificationHandler(uc NotificationUseCase, mw *middleware.AuthMiddleware) *NotificationHandler {
+	return &NotificationHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the endpoints users read their notifications with,
+// mounted at /api/v1/notifications.
+func (h *NotificationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAuth)
+
+	r.Get("/", h.ListNotifications)
+	r.Get("/stream", h.StreamNotifications)
+	r.Post("/read", h.MarkAllNotificationsRead)
+	r.Post("/{id}/read", h.MarkNotificationRead)
+
+	return r
+}
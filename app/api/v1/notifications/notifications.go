@@ -0,0 +1,199 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// streamHeartbeat is how often an idle stream gets a comment, so proxies
+// don't close it.
+const streamHeartbeat = 30 * time.Second
+
+// MarkAllReadResponse tells how many notifications were marked read.
+type MarkAllReadResponse struct {
+	Marked int64 `json:"marked"`
+}
+
+// ListNotifications godoc
+//
+//	@Summary		List the current user's notifications
+//	@Description	List the signed in user's notifications a page at a time, newest first, with how many are unread.
+//	@Tags			notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unread		query		bool	false	"Only unread notifications"
+//	@Param			page		query		int		false	"Page number (default: 1)"
+//	@Param			page_size	query		int		false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	entities.NotificationListResponse
+//	@Failure		401			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/notifications [get]
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	unreadOnly, _ := strconv.ParseBool(query.Get("unread"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	list, err := h.uc.List(r.Context(), principal.UserID, unreadOnly, page, pageSize)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to list notifications", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list notifications",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// StreamNotifications godoc
+//
+//	@Summary		Stream the current user's notifications
+//	@Description	Stream the signed in user's notifications as server-sent events as they arrive. Each "notification" event carries one as JSON. Only notifications emitted by the instance serving the stream are sent, so clients should still list them after reconnecting.
+//	@Tags			notifications
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.Notification
+//	@Failure		401	{object}	map[string]string
+//	@Router			/api/v1/notifications/stream [get]
+func (h *NotificationHandler) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	notifications := h.uc.Subscribe(r.Context(), principal.UserID)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case n, ok := <-notifications:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(n)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: notification\ndata: %s\n\n", n.ID, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// MarkNotificationRead godoc
+//
+//	@Summary		Mark a notification read
+//	@Description	Mark one of the signed in user's notifications read. Notifications read already keep the time they were first read.
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Notification ID"
+//	@Success		204
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, map[string]string{
+			"error": "notification not found",
+		})
+		return
+	}
+
+	if err := h.uc.MarkRead(r.Context(), principal.UserID, id); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "notification"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead godoc
+//
+//	@Summary		Mark all notifications read
+//	@Description	Mark all the signed in user's notifications read.
+//	@Tags			notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	MarkAllReadResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/notifications/read [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	marked, err := h.uc.MarkAllRead(r.Context(), principal.UserID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to mark notifications read", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to mark notifications read",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, MarkAllReadResponse{Marked: marked})
+}
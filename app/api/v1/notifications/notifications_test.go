@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/notifications/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestNotificationHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	notificationID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	uc := &mocks.NotificationUseCaseMock{
+		ListFunc: func(ctx context.Context, id uuid.UUID, unreadOnly bool, page, pageSize int) (entities.NotificationListResponse, error) {
+			return entities.NotificationListResponse{
+				Notifications: []entities.Notification{{ID: notificationID, UserID: id, Kind: entities.NotificationExportReady, Title: "Your export is ready"}},
+				Unread:        1,
+				Total:         1,
+				Page:          page,
+				PageSize:      pageSize,
+				TotalPages:    1,
+			}, nil
+		},
+		MarkReadFunc: func(ctx context.Context, id, nid uuid.UUID) error {
+			if id != userID || nid != notificationID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+		MarkAllReadFunc: func(ctx context.Context, id uuid.UUID) (int64, error) {
+			return 4, nil
+		},
+		SubscribeFunc: func(ctx context.Context, id uuid.UUID) <-chan entities.Notification {
+			ch := make(chan entities.Notification, 1)
+			ch <- entities.Notification{ID: notificationID, UserID: id, Kind: entities.NotificationExportReady, Title: "Your export is ready"}
+			close(ch)
+			return ch
+		},
+	}
+	h := NewNotificationHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(method, target string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodGet, "/", false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", w.Code)
+	}
+
+	w := serve(http.MethodGet, "/?unread=true&page=2&page_size=5", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	calls := uc.ListCalls()
+	if len(calls) != 1 || calls[0].UserID != userID || !calls[0].UnreadOnly || calls[0].Page != 2 || calls[0].PageSize != 5 {
+		t.Fatalf("unexpected list calls: %+v", calls)
+	}
+	var list entities.NotificationListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Unread != 1 || len(list.Notifications) != 1 || list.Notifications[0].ID != notificationID {
+		t.Fatalf("unexpected response: %+v", list)
+	}
+
+	if w := serve(http.MethodPost, "/"+notificationID.String()+"/read", true); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serve(http.MethodPost, "/"+uuid.Must(uuid.NewV4()).String()+"/read", true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for another notification, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/not-an-id/read", true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an invalid ID, got %d", w.Code)
+	}
+
+	w = serve(http.MethodPost, "/read", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var marked MarkAllReadResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &marked)
+	if marked.Marked != 4 {
+		t.Fatalf("unexpected response: %+v", marked)
+	}
+
+	w = serve(http.MethodGet, "/stream", true)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls := uc.SubscribeCalls(); len(calls) != 1 || calls[0].UserID != userID {
+		t.Fatalf("unexpected subscribe calls: %+v", calls)
+	}
+	if !strings.Contains(w.Body.String(), "event: notification\ndata: {\"id\":\""+notificationID.String()) {
+		t.Fatalf("notification not streamed: %s", w.Body.String())
+	}
+}
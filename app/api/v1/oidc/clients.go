@@ -0,0 +1,115 @@
+package oidc
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type RegisterClientRequest struct {
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+}
+
+type RegisterClientResponse struct {
+	entities.OAuthClient
+	ClientSecret string `json:"client_secret"`
+}
+
+// ListClients godoc
+//
+//	@Summary		List OIDC clients
+//	@Description	List client applications registered with the OpenID Connect provider
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.OAuthClient
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/oauth-clients [get]
+func (h *OIDCHandler) ListClients(w http.ResponseWriter, r *http.Request) {
+	clients, err := h.uc.ListClients(r.Context())
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list clients",
+		})
+		return
+	}
+	if clients == nil {
+		clients = []entities.OAuthClient{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, clients)
+}
+
+// RegisterClient godoc
+//
+//	@Summary		Register an OIDC client
+//	@Description	Register a client application. The client secret is only returned once.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	RegisterClientRequest	true	"Client registration"
+//	@Success		201	{object}	RegisterClientResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/oauth-clients [post]
+func (h *OIDCHandler) RegisterClient(w http.ResponseWriter, r *http.Request) {
+	var req RegisterClientRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	client, secret, err := h.uc.RegisterClient(r.Context(), req.Name, req.RedirectURIs)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, RegisterClientResponse{
+		OAuthClient:  client,
+		ClientSecret: secret,
+	})
+}
+
+// DeleteClient godoc
+//
+//	@Summary		Delete an OIDC client
+//	@Description	Remove a client application and its pending authorization codes
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			clientID	path	string	true	"Client ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/oauth-clients/{clientID} [delete]
+func (h *OIDCHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	clientID := chi.URLParam(r, "clientID")
+
+	if err := h.uc.DeleteClient(r.Context(), clientID); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "client"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "client deleted successfully",
+	})
+}
@@ -0,0 +1,69 @@
+package oidc
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/domain/oidc"
+	"go-template/internal/jwt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/oidc_uc.go . OIDCUseCase
+type OIDCUseCase interface {
+	Discovery() oidc.Discovery
+	JWKS() jwt.JWKS
+	Authorize(ctx context.Context, userID uuid.UUID, req oidc.AuthorizeRequest) (string, error)
+	Consent(ctx context.Context, userID uuid.UUID, req oidc.AuthorizeRequest) (oidc.ConsentPrompt, error)
+	Exchange(ctx context.Context, req oidc.TokenRequest) (oidc.TokenResponse, error)
+	UserInfo(ctx context.Context, accessToken string) (oidc.UserInfo, error)
+
+	// Client management
+	RegisterClient(ctx context.Context, name string, redirectURIs []string) (entities.OAuthClient, string, error)
+	ListClients(ctx context.Context) ([]entities.OAuthClient, error)
+	DeleteClient(ctx context.Context, clientID string) error
+}
+
+type OIDCHandler struct {
+	uc OIDCUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewOIDCHandler(uc OIDCUseCase, mw *middleware.AuthMiddleware) *OIDCHandler {
+	return &OIDCHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the OAuth2 endpoints, mounted at /oauth2
+func (h *OIDCHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/token", h.Token)
+
+	// The authorization endpoint and its consent screen are driven by the
+	// web app on behalf of the signed-in user
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireAuth)
+		r.Post("/authorize", h.Authorize)
+		r.Post("/authorize/consent", h.Consent)
+	})
+
+	return r
+}
+
+// AdminRoutes returns client registration endpoints, mounted at /admin/v1/oauth-clients
+func (h *OIDCHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireSuperAdmin)
+
+	r.Get("/", h.ListClients)
+	r.Post("/", h.RegisterClient)
+	r.Delete("/{clientID}", h.DeleteClient)
+
+	return r
+}
@@ -0,0 +1,254 @@
+package oidc
+
+import (
+	"errors"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/oidc"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// Discovery godoc
+//
+//	@Summary		OpenID Connect discovery
+//	@Description	OpenID provider metadata
+//	@Tags			oidc
+//	@Produce		json
+//	@Success		200	{object}	oidc.Discovery
+//	@Router			/.well-known/openid-configuration [get]
+func (h *OIDCHandler) Discovery(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.uc.Discovery())
+}
+
+// JWKS godoc
+//
+//	@Summary		JSON Web Key Set
+//	@Description	Public keys used to verify ID tokens and, when signing keys are configured, access tokens
+//	@Tags			oidc
+//	@Produce		json
+//	@Success		200	{object}	jwt.JWKS
+//	@Router			/.well-known/jwks.json [get]
+func (h *OIDCHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.uc.JWKS())
+}
+
+// Authorize godoc
+//
+//	@Summary		Issue an authorization code
+//	@Description	Issues an authorization code for the authenticated user and returns where to redirect the user agent. Called by the web app's /oauth2/authorize page. The request carries the user's decision on the consent screen, unless they consented to the scopes before.
+//	@Tags			oidc
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	oidc.AuthorizeRequest	true	"Authorization request"
+//	@Success		200	{object}	AuthorizeResponse
+//	@Failure		400	{object}	oidc.Error
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/oauth2/authorize [post]
+func (h *OIDCHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	var req oidc.AuthorizeRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	userID, err := uuid.FromString(claims.UserID)
+	if err != nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID in token",
+		})
+		return
+	}
+
+	redirectTo, err := h.uc.Authorize(r.Context(), userID, req)
+	if err != nil {
+		h.oauthError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, AuthorizeResponse{RedirectTo: redirectTo})
+}
+
+// Consent godoc
+//
+//	@Summary		Describe the consent screen
+//	@Description	Returns the client and scopes of an authorization request, and whether the authenticated user has to consent to them. Called by the web app's /oauth2/authorize page before it shows the consent screen.
+//	@Tags			oidc
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	oidc.AuthorizeRequest	true	"Authorization request"
+//	@Success		200	{object}	oidc.ConsentPrompt
+//	@Failure		400	{object}	oidc.Error
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/oauth2/authorize/consent [post]
+func (h *OIDCHandler) Consent(w http.ResponseWriter, r *http.Request) {
+	var req oidc.AuthorizeRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	claims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	userID, err := uuid.FromString(claims.UserID)
+	if err != nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID in token",
+		})
+		return
+	}
+
+	prompt, err := h.uc.Consent(r.Context(), userID, req)
+	if err != nil {
+		h.oauthError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, prompt)
+}
+
+// Token godoc
+//
+//	@Summary		Token endpoint
+//	@Description	Exchanges an authorization code for an access token and ID token. Clients authenticate with HTTP Basic or client_id/client_secret form fields.
+//	@Tags			oidc
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			grant_type		formData	string	true	"authorization_code"
+//	@Param			code			formData	string	true	"Authorization code"
+//	@Param			redirect_uri	formData	string	true	"Redirect URI used in the authorization request"
+//	@Param			code_verifier	formData	string	false	"PKCE code verifier"
+//	@Success		200	{object}	oidc.TokenResponse
+//	@Failure		400	{object}	oidc.Error
+//	@Failure		401	{object}	oidc.Error
+//	@Router			/oauth2/token [post]
+func (h *OIDCHandler) Token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := r.ParseForm(); err != nil {
+		h.oauthError(w, r, &oidc.Error{Code: oidc.CodeInvalidRequest, Description: "malformed form body"})
+		return
+	}
+
+	req := oidc.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+	}
+	if id, secret, ok := r.BasicAuth(); ok {
+		req.ClientID = id
+		req.ClientSecret = secret
+	}
+
+	resp, err := h.uc.Exchange(r.Context(), req)
+	if err != nil {
+		h.oauthError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// UserInfo godoc
+//
+//	@Summary		UserInfo endpoint
+//	@Description	Returns claims about the user an OIDC access token was issued for
+//	@Tags			oidc
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	oidc.UserInfo
+//	@Failure		401	{object}	oidc.Error
+//	@Router			/userinfo [get]
+func (h *OIDCHandler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	token := ""
+	if parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2); len(parts) == 2 && strings.EqualFold(parts[0], "bearer") {
+		token = parts[1]
+	}
+	if token == "" && r.Method == http.MethodPost {
+		token = r.PostFormValue("access_token")
+	}
+	if token == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_request"`)
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, oidc.Error{Code: oidc.CodeInvalidRequest, Description: "missing access token"})
+		return
+	}
+
+	info, err := h.uc.UserInfo(r.Context(), token)
+	if err != nil {
+		h.oauthError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, info)
+}
+
+// oauthError writes err using the OAuth 2.0 error response format
+func (h *OIDCHandler) oauthError(w http.ResponseWriter, r *http.Request, err error) {
+	var oauthErr *oidc.Error
+	if !errors.As(err, &oauthErr) {
+		domain.Logger(r.Context()).Error("oidc request failed", "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, oidc.Error{Code: oidc.CodeServerError})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case oidc.CodeInvalidClient:
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	case oidc.CodeInvalidToken:
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, oauthErr)
+}
@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"go-template/app/api/middleware"
+	"go-template/app/api/v1/oidc/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/oidc"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestToken(t *testing.T) {
+	t.Run("basic auth credentials are used", func(t *testing.T) {
+		var got oidc.TokenRequest
+		h := &OIDCHandler{
+			uc: &mocks.OIDCUseCaseMock{
+				ExchangeFunc: func(ctx context.Context, req oidc.TokenRequest) (oidc.TokenResponse, error) {
+					got = req
+					return oidc.TokenResponse{AccessToken: "at", IDToken: "id", TokenType: "Bearer"}, nil
+				},
+			},
+		}
+
+		form := url.Values{"grant_type": {"authorization_code"}, "code": {"abc"}, "redirect_uri": {"https://app/cb"}}
+		req := httptest.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("client-1", "secret")
+		w := httptest.NewRecorder()
+
+		h.Token(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if got.ClientID != "client-1" || got.ClientSecret != "secret" || got.Code != "abc" {
+			t.Fatalf("unexpected token request: %+v", got)
+		}
+		if w.Header().Get("Cache-Control") != "no-store" {
+			t.Fatalf("expected Cache-Control no-store")
+		}
+	})
+
+	t.Run("oauth errors map to status codes", func(t *testing.T) {
+		tests := []struct {
+			err    error
+			status int
+		}{
+			{&oidc.Error{Code: oidc.CodeInvalidGrant}, http.StatusBadRequest},
+			{&oidc.Error{Code: oidc.CodeInvalidClient}, http.StatusUnauthorized},
+			{errors.New("db down"), http.StatusInternalServerError},
+		}
+
+		for _, tt := range tests {
+			h := &OIDCHandler{
+				uc: &mocks.OIDCUseCaseMock{
+					ExchangeFunc: func(ctx context.Context, req oidc.TokenRequest) (oidc.TokenResponse, error) {
+						return oidc.TokenResponse{}, tt.err
+					},
+				},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader("grant_type=authorization_code"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			h.Token(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("%v: expected %d, got %d", tt.err, tt.status, w.Code)
+			}
+		}
+	})
+}
+
+func TestConsent(t *testing.T) {
+	userID := "4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11"
+	h := &OIDCHandler{
+		uc: &mocks.OIDCUseCaseMock{
+			ConsentFunc: func(ctx context.Context, id uuid.UUID, req oidc.AuthorizeRequest) (oidc.ConsentPrompt, error) {
+				if req.ClientID != "client-1" {
+					return oidc.ConsentPrompt{}, &oidc.Error{Code: oidc.CodeInvalidClient}
+				}
+				return oidc.ConsentPrompt{ClientName: "Example App", Scopes: []string{"openid"}, Required: true}, nil
+			},
+		},
+	}
+	serve := func(body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/oauth2/authorize/consent", strings.NewReader(body))
+		if auth {
+			req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &jwt.Claims{UserID: userID}))
+		}
+		w := httptest.NewRecorder()
+		h.Consent(w, req)
+		return w
+	}
+
+	if w := serve(`{"client_id":"client-1"}`, false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+
+	w := serve(`{"client_id":"client-1","scope":"openid"}`, true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var prompt oidc.ConsentPrompt
+	json.Unmarshal(w.Body.Bytes(), &prompt)
+	if prompt.ClientName != "Example App" || !prompt.Required {
+		t.Fatalf("unexpected prompt: %+v", prompt)
+	}
+
+	if w := serve(`{"client_id":"missing"}`, true); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for an unknown client, got %d", w.Code)
+	}
+}
+
+func TestUserInfo(t *testing.T) {
+	t.Run("missing token", func(t *testing.T) {
+		h := &OIDCHandler{uc: &mocks.OIDCUseCaseMock{}}
+
+		req := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		w := httptest.NewRecorder()
+
+		h.UserInfo(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		h := &OIDCHandler{
+			uc: &mocks.OIDCUseCaseMock{
+				UserInfoFunc: func(ctx context.Context, accessToken string) (oidc.UserInfo, error) {
+					if accessToken != "token" {
+						t.Fatalf("unexpected token %q", accessToken)
+					}
+					return oidc.UserInfo{Subject: "user-1", Email: "user@example.com"}, nil
+				},
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+
+		h.UserInfo(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var info oidc.UserInfo
+		json.Unmarshal(w.Body.Bytes(), &info)
+		if info.Subject != "user-1" {
+			t.Fatalf("unexpected userinfo: %+v", info)
+		}
+	})
+}
+
+func TestRegisterClient(t *testing.T) {
+	t.Run("returns secret once", func(t *testing.T) {
+		h := &OIDCHandler{
+			uc: &mocks.OIDCUseCaseMock{
+				RegisterClientFunc: func(ctx context.Context, name string, redirectURIs []string) (entities.OAuthClient, string, error) {
+					return entities.OAuthClient{ClientID: "client-1", Name: name, RedirectURIs: redirectURIs}, "secret", nil
+				},
+			},
+		}
+
+		body, _ := json.Marshal(RegisterClientRequest{Name: "App", RedirectURIs: []string{"https://app/cb"}})
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		h.RegisterClient(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d", w.Code)
+		}
+		var resp RegisterClientResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		if resp.ClientID != "client-1" || resp.ClientSecret != "secret" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		h := &OIDCHandler{
+			uc: &mocks.OIDCUseCaseMock{
+				RegisterClientFunc: func(ctx context.Context, name string, redirectURIs []string) (entities.OAuthClient, string, error) {
+					return entities.OAuthClient{}, "", domain.ErrMalformedParameters
+				},
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":""}`))
+		w := httptest.NewRecorder()
+
+		h.RegisterClient(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestDeleteClient_NotFound(t *testing.T) {
+	h := &OIDCHandler{
+		uc: &mocks.OIDCUseCaseMock{
+			DeleteClientFunc: func(ctx context.Context, clientID string) error {
+				return domain.ErrNotFound
+			},
+		},
+	}
+
+	r := chi.NewRouter()
+	r.Delete("/{clientID}", h.DeleteClient)
+
+	req := httptest.NewRequest(http.MethodDelete, "/missing", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,57 @@
+package outgoingwebhooks
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/domain/webhook"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/webhook_uc.go . WebhookUseCase
+type WebhookUseCase interface {
+	Events() []string
+	List(ctx context.Context) ([]entities.Webhook, error)
+	Get(ctx context.Context, id uuid.UUID) (entities.Webhook, error)
+	Create(ctx context.Context, req webhook.CreateRequest) (entities.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus, page, pageSize int) (entities.WebhookDeliveryListResponse, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID uuid.UUID) (entities.WebhookDelivery, error)
+}
+
+// WebhookHandler manages the outgoing webhooks, the endpoints events are
+// posted to.
+type WebhookHandler struct {
+	uc WebhookUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewWebhookHandler(uc WebhookUseCase, mw *middleware.AuthMiddleware) *WebhookHandler {
+	return &WebhookHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// AdminRoutes returns the endpoints registering outgoing webhooks and
+// inspecting and redelivering their deliveries, mounted at
+// /admin/v1/webhooks. Deliveries carry any user's data, so they are limited
+// to super admins.
+func (h *WebhookHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireSuperAdmin)
+	r.Use(middleware.DryRun)
+
+	r.Get("/", h.ListWebhooks)
+	r.Post("/", h.CreateWebhook)
+	r.Get("/events", h.ListEvents)
+	r.Get("/{id}", h.GetWebhook)
+	r.Delete("/{id}", h.DeleteWebhook)
+	r.Get("/{id}/deliveries", h.ListDeliveries)
+	r.Post("/{id}/deliveries/{deliveryID}/redeliver", h.Redeliver)
+
+	return r
+}
@@ -0,0 +1,235 @@
+package outgoingwebhooks
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain/entities"
+	"go-template/domain/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListWebhooks godoc
+//
+//	@Summary		List webhooks
+//	@Description	List the outgoing webhooks, newest first. Their secrets are only returned when they are created.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.Webhook
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.uc.List(r.Context())
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, webhooks)
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		Create a webhook
+//	@Description	Register an http or https URL the events are posted to. Deliveries are signed in the X-Webhook-Signature header with "sha256=" and the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body, keyed with the secret, which is only returned once.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		webhook.CreateRequest	true	"Webhook"
+//	@Success		201		{object}	entities.Webhook
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhook.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	created, err := h.uc.Create(r.Context(), req)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, created)
+}
+
+// ListEvents godoc
+//
+//	@Summary		List webhook events
+//	@Description	List the events webhooks can subscribe to
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Router			/admin/v1/webhooks/events [get]
+func (h *WebhookHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.uc.Events())
+}
+
+// GetWebhook godoc
+//
+//	@Summary		Get a webhook
+//	@Description	Get an outgoing webhook, without its secret
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{object}	entities.Webhook
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id", "webhook")
+	if !ok {
+		return
+	}
+
+	found, err := h.uc.Get(r.Context(), id)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "webhook"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, found)
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Remove an outgoing webhook and its deliveries. Deliveries still queued are dropped.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id", "webhook")
+	if !ok {
+		return
+	}
+
+	if err := h.uc.Delete(r.Context(), id); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "webhook"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "webhook deleted successfully",
+	})
+}
+
+// ListDeliveries godoc
+//
+//	@Summary		List webhook deliveries
+//	@Description	List a webhook's delivery attempts a page at a time, newest first, with the status code, latency and start of the answer of each. Failed deliveries are retried following the job queue's policy, each retry being another attempt.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Webhook ID"
+//	@Param			status		query		string	false	"Filter by status"	Enums(succeeded, failed)
+//	@Param			page		query		int		false	"Page number (default: 1)"
+//	@Param			page_size	query		int		false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	entities.WebhookDeliveryListResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id", "webhook")
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+
+	list, err := h.uc.ListDeliveries(r.Context(), id, entities.WebhookDeliveryStatus(query.Get("status")), page, pageSize)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "webhook"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// Redeliver godoc
+//
+//	@Summary		Redeliver a webhook delivery
+//	@Description	Post a delivery's event to its webhook again, right away, and return the new attempt. The attempt failing isn't an error: it is returned with the failed status.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Webhook ID"
+//	@Param			deliveryID	path		string	true	"Delivery ID"
+//	@Success		200			{object}	entities.WebhookDelivery
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/webhooks/{id}/deliveries/{deliveryID}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id", "webhook")
+	if !ok {
+		return
+	}
+	deliveryID, ok := pathID(w, r, "deliveryID", "delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.uc.Redeliver(r.Context(), id, deliveryID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "delivery"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, delivery)
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, param, what string) (uuid.UUID, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, param))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid " + what + " ID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
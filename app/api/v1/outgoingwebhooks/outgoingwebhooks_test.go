@@ -0,0 +1,101 @@
+package outgoingwebhooks
+
+import (
+	"context"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/outgoingwebhooks/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/webhook"
+	"go-template/internal/jwt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestWebhookHandler_AdminRoutes(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	webhookID := uuid.Must(uuid.NewV4())
+	deliveryID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+
+	uc := &mocks.WebhookUseCaseMock{
+		CreateFunc: func(ctx context.Context, req webhook.CreateRequest) (entities.Webhook, error) {
+			if len(req.Events) == 0 {
+				return entities.Webhook{}, fmt.Errorf("at least one event is required: %w", domain.ErrMalformedParameters)
+			}
+			return entities.Webhook{ID: webhookID, URL: req.URL, Events: req.Events, Secret: "whsec_x"}, nil
+		},
+		ListDeliveriesFunc: func(ctx context.Context, id uuid.UUID, status entities.WebhookDeliveryStatus, page, pageSize int) (entities.WebhookDeliveryListResponse, error) {
+			if id != webhookID {
+				return entities.WebhookDeliveryListResponse{}, domain.ErrNotFound
+			}
+			return entities.WebhookDeliveryListResponse{Deliveries: []entities.WebhookDelivery{{ID: deliveryID}}, Total: 1, Page: page, PageSize: pageSize}, nil
+		},
+		RedeliverFunc: func(ctx context.Context, id, delivery uuid.UUID) (entities.WebhookDelivery, error) {
+			if delivery != deliveryID {
+				return entities.WebhookDelivery{}, domain.ErrNotFound
+			}
+			return entities.WebhookDelivery{ID: uuid.Must(uuid.NewV4()), WebhookID: id, Status: entities.WebhookDeliveryFailed, StatusCode: 500}, nil
+		},
+	}
+	h := NewWebhookHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(accountType entities.AccountType, method, target string, body io.Reader) *httptest.ResponseRecorder {
+		token, err := jwtService.GenerateToken(adminID.String(), "admin@x.com", accountType.String())
+		if err != nil {
+			t.Fatalf("generating token: %v", err)
+		}
+		req := httptest.NewRequest(method, target, body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.AdminRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(entities.AccountTypeAdmin, http.MethodGet, "/", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for admins, got %d", w.Code)
+	}
+
+	w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/", strings.NewReader(`{"url":"https://example.com/hook","events":["user.created"]}`))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "whsec_x") {
+		t.Fatalf("expected the secret to be returned on creation, got %s", w.Body.String())
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/", strings.NewReader(`{"url":"https://example.com/hook"}`)); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without events, got %d", w.Code)
+	}
+
+	w = serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+webhookID.String()+"/deliveries?status=failed&page=2&page_size=10", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	calls := uc.ListDeliveriesCalls()
+	if len(calls) != 1 || calls[0].WebhookID != webhookID || calls[0].Status != entities.WebhookDeliveryFailed || calls[0].Page != 2 || calls[0].PageSize != 10 {
+		t.Fatalf("unexpected list deliveries calls: %+v", calls)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/"+uuid.Must(uuid.NewV4()).String()+"/deliveries", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown webhook, got %d", w.Code)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodGet, "/nope/deliveries", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad ID, got %d", w.Code)
+	}
+
+	w = serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/"+webhookID.String()+"/deliveries/"+deliveryID.String()+"/redeliver", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a failed redelivery, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls := uc.RedeliverCalls(); len(calls) != 1 || calls[0].WebhookID != webhookID || calls[0].DeliveryID != deliveryID {
+		t.Fatalf("unexpected redeliver calls: %+v", calls)
+	}
+	if w := serve(entities.AccountTypeSuperAdmin, http.MethodPost, "/"+webhookID.String()+"/deliveries/"+uuid.Must(uuid.NewV4()).String()+"/redeliver", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown delivery, got %d", w.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package permissions
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/authz"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/permissions_uc.go . PermissionsUseCase
+type PermissionsUseCase interface {
+	Permissions(ctx context.Context, userID uuid.UUID, accountType entities.AccountType) (entities.AdminPermissions, error)
+	GetAdminPermissions(ctx context.Context, userID uuid.UUID) (entities.AdminPermissions, error)
+	SetAdminPermissions(ctx context.Context, userID uuid.UUID, permissions []entities.Permission, updatedBy uuid.UUID) (entities.AdminPermissions, error)
+	ResetAdminPermissions(ctx context.Context, userID uuid.UUID) error
+	ListRoles(ctx context.Context) ([]entities.Role, error)
+	CreateRole(ctx context.Context, req authz.RoleRequest, createdBy uuid.UUID) (entities.Role, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req authz.RoleRequest, updatedBy uuid.UUID) (entities.Role, error)
+	DeleteRole(ctx context.Context, id, deletedBy uuid.UUID) error
+	UserRoles(ctx context.Context, userID uuid.UUID) ([]entities.Role, error)
+	AssignRole(ctx context.Context, userID, roleID, assignedBy uuid.UUID) error
+	UnassignRole(ctx context.Context, userID, roleID, unassignedBy uuid.UUID) error
+}
+
+type PermissionsHandler struct {
+	uc PermissionsUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewPermissionsHandler(uc PermissionsUseCase, mw *middleware.AuthMiddleware) *PermissionsHandler {
+	return &PermissionsHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// AdminRoutes returns the delegated permission endpoints, mounted at /admin/v1/permissions
+func (h *PermissionsHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	// Every admin can see what they are allowed to do
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireAdmin)
+		r.Get("/me", h.GetMyPermissions)
+	})
+
+	// Only super admins delegate permissions
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireSuperAdmin)
+		r.Get("/users/{id}", h.GetUserPermissions)
+		r.Put("/users/{id}", h.SetUserPermissions)
+		r.Delete("/users/{id}", h.ResetUserPermissions)
+	})
+
+	return r
+}
+
+// RoleRoutes returns the role management endpoints, mounted at /admin/v1/roles
+func (h *PermissionsHandler) RoleRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	// Only super admins manage roles
+	r.Use(h.mw.RequireSuperAdmin)
+	r.Get("/", h.ListRoles)
+	r.Post("/", h.CreateRole)
+	r.Put("/{id}", h.UpdateRole)
+	r.Delete("/{id}", h.DeleteRole)
+	r.Get("/users/{userID}", h.GetUserRoles)
+	r.Put("/{id}/users/{userID}", h.AssignRole)
+	r.Delete("/{id}/users/{userID}", h.UnassignRole)
+
+	return r
+}
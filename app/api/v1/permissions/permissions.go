@@ -0,0 +1,178 @@
+package permissions
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+type SetPermissionsRequest struct {
+	Permissions []entities.Permission `json:"permissions"`
+}
+
+// GetMyPermissions godoc
+//
+//	@Summary		Get own admin permissions
+//	@Description	Get the permissions of the authenticated admin
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.AdminPermissions
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/permissions/me [get]
+func (h *PermissionsHandler) GetMyPermissions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return
+	}
+
+	perms, err := h.uc.Permissions(r.Context(), principal.UserID, principal.AccountType)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to get permissions",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, perms)
+}
+
+// GetUserPermissions godoc
+//
+//	@Summary		Get admin permissions
+//	@Description	Get the effective permissions of an admin (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	entities.AdminPermissions
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/permissions/users/{id} [get]
+func (h *PermissionsHandler) GetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID",
+		})
+		return
+	}
+
+	perms, err := h.uc.GetAdminPermissions(r.Context(), userID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, perms)
+}
+
+// SetUserPermissions godoc
+//
+//	@Summary		Restrict admin permissions
+//	@Description	Restrict an admin to the given subset of permissions (super admin only)
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"User ID"
+//	@Param			request	body		SetPermissionsRequest	true	"Granted permissions"
+//	@Success		200		{object}	entities.AdminPermissions
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/permissions/users/{id} [put]
+func (h *PermissionsHandler) SetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return
+	}
+	updatedBy := principal.UserID
+
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID",
+		})
+		return
+	}
+
+	var req SetPermissionsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	perms, err := h.uc.SetAdminPermissions(r.Context(), userID, req.Permissions, updatedBy)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, perms)
+}
+
+// ResetUserPermissions godoc
+//
+//	@Summary		Reset admin permissions
+//	@Description	Lift all restrictions from an admin (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/permissions/users/{id} [delete]
+func (h *PermissionsHandler) ResetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID",
+		})
+		return
+	}
+
+	if err := h.uc.ResetAdminPermissions(r.Context(), userID); err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "permissions reset successfully",
+	})
+}
@@ -0,0 +1,119 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/permissions/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestJWT() jwt.Service {
+	return jwt.NewService("test-secret", "test-issuer", "1h")
+}
+
+func newTestRoutes(uc PermissionsUseCase) (http.Handler, jwt.Service) {
+	jh := newTestJWT()
+	h := NewPermissionsHandler(uc, apiMiddleware.NewAuthMiddleware(jh))
+	return h.AdminRoutes(), jh
+}
+
+func TestGetMyPermissions(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	uc := &mocks.PermissionsUseCaseMock{
+		PermissionsFunc: func(ctx context.Context, userID uuid.UUID, accountType entities.AccountType) (entities.AdminPermissions, error) {
+			if userID != adminID || accountType != entities.AccountTypeAdmin {
+				t.Fatalf("unexpected account %s %s", userID, accountType)
+			}
+			return entities.AdminPermissions{UserID: userID, Permissions: []entities.Permission{entities.PermissionUsersRead}, Restricted: true}, nil
+		},
+	}
+	routes, jh := newTestRoutes(uc)
+	token, _ := jh.GenerateToken(adminID.String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got entities.AdminPermissions
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if !got.Restricted || !got.Has(entities.PermissionUsersRead) || got.Has(entities.PermissionUsersWrite) {
+		t.Fatalf("unexpected permissions: %+v", got)
+	}
+}
+
+func TestSetUserPermissions(t *testing.T) {
+	superAdminID := uuid.Must(uuid.NewV4())
+	targetID := uuid.Must(uuid.NewV4())
+
+	t.Run("admins cannot delegate", func(t *testing.T) {
+		routes, jh := newTestRoutes(&mocks.PermissionsUseCaseMock{})
+		token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@x.com", entities.AccountTypeAdmin.String())
+
+		req := httptest.NewRequest(http.MethodPut, "/users/"+targetID.String(), bytes.NewBufferString(`{"permissions":[]}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("super admin restricts an admin", func(t *testing.T) {
+		uc := &mocks.PermissionsUseCaseMock{
+			SetAdminPermissionsFunc: func(ctx context.Context, userID uuid.UUID, perms []entities.Permission, updatedBy uuid.UUID) (entities.AdminPermissions, error) {
+				if userID != targetID || updatedBy != superAdminID {
+					t.Fatalf("unexpected ids %s %s", userID, updatedBy)
+				}
+				return entities.AdminPermissions{UserID: userID, Permissions: perms, Restricted: true}, nil
+			},
+		}
+		routes, jh := newTestRoutes(uc)
+		token, _ := jh.GenerateToken(superAdminID.String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+		req := httptest.NewRequest(http.MethodPut, "/users/"+targetID.String(), bytes.NewBufferString(`{"permissions":["users:read"]}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		calls := uc.SetAdminPermissionsCalls()
+		if len(calls) != 1 || len(calls[0].Permissions) != 1 || calls[0].Permissions[0] != entities.PermissionUsersRead {
+			t.Fatalf("unexpected calls: %+v", calls)
+		}
+	})
+
+	t.Run("invalid permission", func(t *testing.T) {
+		uc := &mocks.PermissionsUseCaseMock{
+			SetAdminPermissionsFunc: func(ctx context.Context, userID uuid.UUID, perms []entities.Permission, updatedBy uuid.UUID) (entities.AdminPermissions, error) {
+				return entities.AdminPermissions{}, domain.ErrMalformedParameters
+			},
+		}
+		routes, jh := newTestRoutes(uc)
+		token, _ := jh.GenerateToken(superAdminID.String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+		req := httptest.NewRequest(http.MethodPut, "/users/"+targetID.String(), bytes.NewBufferString(`{"permissions":["nope"]}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,334 @@
+package permissions
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"go-template/domain/authz"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List the builtin roles followed by the custom ones (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		entities.Role
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/roles [get]
+func (h *PermissionsHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.uc.ListRoles(r.Context())
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to list roles",
+		})
+		return
+	}
+	if roles == nil {
+		roles = []entities.Role{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, roles)
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a role
+//	@Description	Create a custom role granting permissions to the users it is assigned to (super admin only)
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		authz.RoleRequest	true	"Role name, description and permissions"
+//	@Success		201		{object}	entities.Role
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/roles [post]
+func (h *PermissionsHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return
+	}
+
+	var req authz.RoleRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	role, err := h.uc.CreateRole(r.Context(), req, principal.UserID)
+	if err != nil {
+		h.renderRoleError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, role)
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a role
+//	@Description	Replace the description and permissions of a custom role (super admin only)
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string				true	"Role ID"
+//	@Param			request	body		authz.RoleRequest	true	"Description and permissions; the name is ignored"
+//	@Success		200		{object}	entities.Role
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/roles/{id} [put]
+func (h *PermissionsHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid role ID",
+		})
+		return
+	}
+
+	var req authz.RoleRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	role, err := h.uc.UpdateRole(r.Context(), id, req, principal.UserID)
+	if err != nil {
+		h.renderRoleError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, role)
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a role
+//	@Description	Delete a custom role, taking its permissions away from its users (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Role ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/roles/{id} [delete]
+func (h *PermissionsHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid role ID",
+		})
+		return
+	}
+
+	if err := h.uc.DeleteRole(r.Context(), id, principal.UserID); err != nil {
+		h.renderRoleError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "role deleted",
+	})
+}
+
+// GetUserRoles godoc
+//
+//	@Summary		Get user roles
+//	@Description	Get the roles a user holds, including the builtin role of their account type (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userID	path		string	true	"User ID"
+//	@Success		200		{array}		entities.Role
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/roles/users/{userID} [get]
+func (h *PermissionsHandler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.FromString(chi.URLParam(r, "userID"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID",
+		})
+		return
+	}
+
+	roles, err := h.uc.UserRoles(r.Context(), userID)
+	if err != nil {
+		common.RespondError(w, r, common.NotFound(err, "user"))
+		return
+	}
+	if roles == nil {
+		roles = []entities.Role{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, roles)
+}
+
+// AssignRole godoc
+//
+//	@Summary		Assign a role
+//	@Description	Give a user a custom role (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Role ID"
+//	@Param			userID	path		string	true	"User ID"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/roles/{id}/users/{userID} [put]
+func (h *PermissionsHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	principal, roleID, userID, ok := h.roleAssignment(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.AssignRole(r.Context(), userID, roleID, principal.UserID); err != nil {
+		h.renderRoleError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "role assigned",
+	})
+}
+
+// UnassignRole godoc
+//
+//	@Summary		Unassign a role
+//	@Description	Take a custom role away from a user (super admin only)
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Role ID"
+//	@Param			userID	path		string	true	"User ID"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/roles/{id}/users/{userID} [delete]
+func (h *PermissionsHandler) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	principal, roleID, userID, ok := h.roleAssignment(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.UnassignRole(r.Context(), userID, roleID, principal.UserID); err != nil {
+		h.renderRoleError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "role unassigned",
+	})
+}
+
+// roleAssignment reads the caller, role and user of an assignment request,
+// rendering the error when one is missing.
+func (h *PermissionsHandler) roleAssignment(w http.ResponseWriter, r *http.Request) (middleware.Principal, uuid.UUID, uuid.UUID, bool) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "user not found in context",
+		})
+		return middleware.Principal{}, uuid.Nil, uuid.Nil, false
+	}
+
+	roleID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid role ID",
+		})
+		return middleware.Principal{}, uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.FromString(chi.URLParam(r, "userID"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid user ID",
+		})
+		return middleware.Principal{}, uuid.Nil, uuid.Nil, false
+	}
+
+	return principal, roleID, userID, true
+}
+
+func (h *PermissionsHandler) renderRoleError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrDuplicateKey) {
+		common.ErrorResponse(w, r, http.StatusConflict, errors.New("a role with this name already exists"))
+		return
+	}
+	common.RespondError(w, r, common.NotFound(err, "role or user"))
+}
@@ -0,0 +1,80 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/permissions/mocks"
+	"go-template/domain"
+	"go-template/domain/authz"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRoleRoutes(t *testing.T) {
+	superAdminID := uuid.Must(uuid.NewV4())
+	roleID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+
+	uc := &mocks.PermissionsUseCaseMock{
+		CreateRoleFunc: func(ctx context.Context, req authz.RoleRequest, createdBy uuid.UUID) (entities.Role, error) {
+			if req.Name == "support" {
+				return entities.Role{}, domain.ErrDuplicateKey
+			}
+			return entities.Role{ID: roleID, Name: req.Name, Permissions: req.Permissions}, nil
+		},
+		AssignRoleFunc: func(ctx context.Context, user, role, assignedBy uuid.UUID) error {
+			if role != roleID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	jh := newTestJWT()
+	routes := NewPermissionsHandler(uc, apiMiddleware.NewAuthMiddleware(jh)).RoleRoutes()
+
+	serve := func(method, target, body string, accountType entities.AccountType) *httptest.ResponseRecorder {
+		token, _ := jh.GenerateToken(superAdminID.String(), "root@x.com", accountType.String())
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodPost, "/", `{"name":"billing"}`, entities.AccountTypeAdmin); w.Code != http.StatusForbidden {
+		t.Fatalf("expected admins to be rejected, got %d", w.Code)
+	}
+
+	w := serve(http.MethodPost, "/", `{"name":"billing","permissions":["users:read"]}`, entities.AccountTypeSuperAdmin)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var role entities.Role
+	_ = json.Unmarshal(w.Body.Bytes(), &role)
+	if role.Name != "billing" || !role.Has(entities.PermissionUsersRead) {
+		t.Fatalf("unexpected role: %+v", role)
+	}
+	if calls := uc.CreateRoleCalls(); calls[len(calls)-1].CreatedBy != superAdminID {
+		t.Fatalf("expected the role to be created by the super admin, got %+v", calls)
+	}
+
+	if w := serve(http.MethodPost, "/", `{"name":"support"}`, entities.AccountTypeSuperAdmin); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a taken name, got %d", w.Code)
+	}
+
+	if w := serve(http.MethodPut, "/"+roleID.String()+"/users/"+userID.String(), "", entities.AccountTypeSuperAdmin); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := serve(http.MethodPut, "/"+uuid.Must(uuid.NewV4()).String()+"/users/"+userID.String(), "", entities.AccountTypeSuperAdmin); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown role, got %d", w.Code)
+	}
+	if w := serve(http.MethodPut, "/"+roleID.String()+"/users/nope", "", entities.AccountTypeSuperAdmin); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package preferences
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/preferences_uc.go . PreferencesUseCase
+type PreferencesUseCase interface {
+	Get(ctx context.Context, userID uuid.UUID) (entities.Preferences, error)
+	Update(ctx context.Context, userID uuid.UUID, patch map[string]any) (entities.Preferences, error)
+}
+
+type PreferencesHandler struct {
+	uc PreferencesUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewPreferencesHandler(uc PreferencesUseCase, mw *middleware.AuthMiddleware) *PreferencesHandler {
+	return &PreferencesHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the endpoints users keep their preferences with, mounted
+// at /api/v1/users/me/preferences.
+func (h *PreferencesHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAuth)
+
+	r.Get("/", h.GetPreferences)
+	r.Patch("/", h.UpdatePreferences)
+
+	return r
+}
@@ -0,0 +1,88 @@
+package preferences
+
+import (
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetPreferences godoc
+//
+//	@Summary		Get the current user's preferences
+//	@Description	Return the signed in user's preferences as a JSON object, empty when they never saved any. "theme" is light, dark or system; "email_security_alerts" and "email_product_updates" are booleans. Other keys are kept as clients saved them.
+//	@Tags			preferences
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]any
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/users/me/preferences [get]
+func (h *PreferencesHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	prefs, err := h.uc.Get(r.Context(), principal.UserID)
+	if err != nil {
+		domain.Logger(r.Context()).Error("failed to get preferences", "user_id", principal.UserID, "error", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to get preferences",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, prefs)
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		Update the current user's preferences
+//	@Description	Merge the given keys into the signed in user's preferences, like a JSON merge patch: keys set to null are removed, the others replace what was there. Returns the resulting preferences.
+//	@Tags			preferences
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		map[string]any	true	"Preferences to change"
+//	@Success		200		{object}	map[string]any
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/users/me/preferences [patch]
+func (h *PreferencesHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var patch map[string]any
+	if err := render.DecodeJSON(r.Body, &patch); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	prefs, err := h.uc.Update(r.Context(), principal.UserID, patch)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, prefs)
+}
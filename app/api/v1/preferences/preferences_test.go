@@ -0,0 +1,80 @@
+package preferences
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/preferences/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestPreferencesHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	stored := entities.Preferences{"theme": "dark"}
+	uc := &mocks.PreferencesUseCaseMock{
+		GetFunc: func(ctx context.Context, id uuid.UUID) (entities.Preferences, error) {
+			return stored, nil
+		},
+		UpdateFunc: func(ctx context.Context, id uuid.UUID, patch map[string]any) (entities.Preferences, error) {
+			if patch["theme"] == "neon" {
+				return nil, domain.ErrMalformedParameters
+			}
+			for k, v := range patch {
+				stored[k] = v
+			}
+			return stored, nil
+		},
+	}
+	h := NewPreferencesHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(method, body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodGet, "", false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if w := serve(http.MethodPatch, `["theme"]`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a body that isn't an object, got %d", w.Code)
+	}
+	if w := serve(http.MethodPatch, `{"theme":"neon"}`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown theme, got %d", w.Code)
+	}
+
+	w := serve(http.MethodPatch, `{"email_product_updates":true}`, true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = serve(http.MethodGet, "", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got entities.Preferences
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Theme() != entities.ThemeDark || !got.EmailProductUpdates() {
+		t.Fatalf("unexpected preferences %v", got)
+	}
+}
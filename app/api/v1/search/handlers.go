@@ -0,0 +1,47 @@
+package search
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/search_uc.go . SearchUseCase
+type SearchUseCase interface {
+	Search(ctx context.Context, q entities.SearchQuery) (entities.SearchResponse, error)
+}
+
+type SearchHandler struct {
+	uc SearchUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewSearchHandler(uc SearchUseCase, mw *middleware.AuthMiddleware) *SearchHandler {
+	return &SearchHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the search users and machine clients run over examples,
+// mounted at /api/v1/search
+func (h *SearchHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(h.mw.RequireAuthOrAPIKey(entities.APIKeyScopeExampleRead)).Get("/", h.Search)
+
+	return r
+}
+
+// AdminRoutes returns the search across every kind of record, users
+// included, mounted at /admin/v1/search
+func (h *SearchHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAdmin)
+	r.With(h.mw.RequireAdminPermission(entities.PermissionUsersRead)).Get("/", h.AdminSearch)
+
+	return r
+}
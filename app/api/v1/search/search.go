@@ -0,0 +1,96 @@
+package search
+
+import (
+	"fmt"
+	"go-template/app/api/common"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+)
+
+// Search godoc
+//
+//	@Summary		Search examples
+//	@Description	Full-text search over examples, best matches first. The query takes web search syntax: quoted phrases, OR, and -word to exclude a word. Highlights are HTML with the matched words in mark tags.
+//	@Tags			search
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Param			q		query		string	true	"Search query"
+//	@Param			limit	query		int		false	"Most results to return (default 20, max 100)"
+//	@Success		200		{object}	entities.SearchResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q, err := parseSearchQuery(r.URL.Query())
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+	q.Kinds = []entities.SearchKind{entities.SearchKindExample}
+
+	h.search(w, r, q)
+}
+
+// AdminSearch godoc
+//
+//	@Summary		Search users and examples
+//	@Description	Full-text search across users and examples in one ranked list, best matches first. The query takes web search syntax: quoted phrases, OR, and -word to exclude a word. Highlights are HTML with the matched words in mark tags.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			q		query		string	true	"Search query"
+//	@Param			kind	query		string	false	"Comma-separated kinds to search: user, example (default all)"
+//	@Param			limit	query		int		false	"Most results to return (default 20, max 100)"
+//	@Success		200		{object}	entities.SearchResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/search [get]
+func (h *SearchHandler) AdminSearch(w http.ResponseWriter, r *http.Request) {
+	q, err := parseSearchQuery(r.URL.Query())
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+	for _, kind := range strings.Split(r.URL.Query().Get("kind"), ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			q.Kinds = append(q.Kinds, entities.SearchKind(kind))
+		}
+	}
+
+	h.search(w, r, q)
+}
+
+func (h *SearchHandler) search(w http.ResponseWriter, r *http.Request, q entities.SearchQuery) {
+	resp, err := h.uc.Search(r.Context(), q)
+	if err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+func parseSearchQuery(query url.Values) (entities.SearchQuery, error) {
+	q := entities.SearchQuery{Query: query.Get("q")}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return q, fmt.Errorf("%w: invalid limit", domain.ErrMalformedParameters)
+		}
+		q.Limit = limit
+	}
+
+	return q, nil
+}
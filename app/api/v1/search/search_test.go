@@ -0,0 +1,120 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/search/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestHandler(uc SearchUseCase) (*SearchHandler, jwt.Service) {
+	jh := jwt.NewService("test-secret", "test-issuer", "1h")
+	return NewSearchHandler(uc, apiMiddleware.NewAuthMiddleware(jh)), jh
+}
+
+func serve(t *testing.T, routes http.Handler, token, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+	return w
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	var got entities.SearchQuery
+	uc := &mocks.SearchUseCaseMock{
+		SearchFunc: func(ctx context.Context, q entities.SearchQuery) (entities.SearchResponse, error) {
+			got = q
+			return entities.SearchResponse{Query: q.Query, Results: []entities.SearchResult{{Kind: entities.SearchKindExample, ID: "e-1", Title: "Hello"}}}, nil
+		},
+	}
+	h, jh := newTestHandler(uc)
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "a@b.com", entities.AccountTypeUser.String())
+
+	// Users only search examples, whatever they ask for
+	w := serve(t, h.Routes(), token, "/?q=hello&limit=5&kind=user")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got.Query != "hello" || got.Limit != 5 || !slices.Equal(got.Kinds, []entities.SearchKind{entities.SearchKindExample}) {
+		t.Fatalf("unexpected query: %+v", got)
+	}
+	var resp entities.SearchResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ID != "e-1" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if w := serve(t, h.Routes(), "", "/?q=hello"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", w.Code)
+	}
+}
+
+func TestSearchHandler_AdminSearch(t *testing.T) {
+	var got entities.SearchQuery
+	uc := &mocks.SearchUseCaseMock{
+		SearchFunc: func(ctx context.Context, q entities.SearchQuery) (entities.SearchResponse, error) {
+			got = q
+			return entities.SearchResponse{Query: q.Query, Results: []entities.SearchResult{}}, nil
+		},
+	}
+	h, jh := newTestHandler(uc)
+	admin, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "admin@b.com", entities.AccountTypeAdmin.String())
+	user, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "a@b.com", entities.AccountTypeUser.String())
+
+	w := serve(t, h.AdminRoutes(), admin, "/?q=ada&kind=user,+example")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !slices.Equal(got.Kinds, []entities.SearchKind{entities.SearchKindUser, entities.SearchKindExample}) {
+		t.Fatalf("unexpected kinds: %v", got.Kinds)
+	}
+
+	if w := serve(t, h.AdminRoutes(), user, "/?q=ada"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a regular user, got %d", w.Code)
+	}
+}
+
+func TestSearchHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid limit", target: "/?q=hello&limit=abc", wantStatus: http.StatusBadRequest},
+		{name: "invalid query", target: "/?q=", err: fmt.Errorf("%w: query is required", domain.ErrMalformedParameters), wantStatus: http.StatusBadRequest},
+		{name: "failed", target: "/?q=hello", err: fmt.Errorf("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.SearchUseCaseMock{
+				SearchFunc: func(ctx context.Context, q entities.SearchQuery) (entities.SearchResponse, error) {
+					return entities.SearchResponse{}, tt.err
+				},
+			}
+			h, jh := newTestHandler(uc)
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "a@b.com", entities.AccountTypeUser.String())
+
+			if w := serve(t, h.Routes(), token, tt.target); w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
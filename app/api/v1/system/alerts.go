@@ -0,0 +1,111 @@
+package system
+
+import (
+	"go-template/app/api/common"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetAlertSettings godoc
+//
+//	@Summary		Get the alert settings
+//	@Description	Get the Slack, PagerDuty and webhook channels system alerts are delivered to, with the minimum severity each one gets
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.AlertSettings
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/system/alerts [get]
+func (h *SystemHandler) GetAlertSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.alerts.GetSettings(r.Context())
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to get alert settings",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, settings)
+}
+
+// UpdateAlertSettings godoc
+//
+//	@Summary		Update the alert settings
+//	@Description	Set the channels system alerts are delivered to. A channel is off while its URL or routing key is empty, and only gets the alerts at or above its minimum severity (info, warning or critical; empty for all).
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		entities.AlertSettings	true	"Alert channels"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/admin/v1/system/alerts [put]
+func (h *SystemHandler) UpdateAlertSettings(w http.ResponseWriter, r *http.Request) {
+	var req entities.AlertSettings
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.alerts.UpdateSettings(r.Context(), req); err != nil {
+		common.RespondError(w, r, err)
+		return
+	}
+
+	message := "alert settings updated successfully"
+	if domain.IsDryRun(r.Context()) {
+		message = "dry run: alert settings are valid and were not saved"
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": message,
+	})
+}
+
+// SendTestAlert godoc
+//
+//	@Summary		Send a test alert
+//	@Description	Deliver a test alert to every configured channel, whatever its minimum severity
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		502	{object}	map[string]string
+//	@Router			/admin/v1/system/alerts/test [post]
+func (h *SystemHandler) SendTestAlert(w http.ResponseWriter, r *http.Request) {
+	if err := h.alerts.SendTest(r.Context()); err != nil {
+		// Without channels, it's a settings error. Anything else, timeouts
+		// included, is the channels' own, naming them without their targets.
+		if common.ErrorStatus(err) == http.StatusBadRequest {
+			common.RespondError(w, r, err)
+			return
+		}
+		render.Status(r, http.StatusBadGateway)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to deliver the test alert: " + err.Error(),
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{
+		"message": "test alert sent",
+	})
+}
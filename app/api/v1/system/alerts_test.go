@@ -0,0 +1,85 @@
+package system
+
+import (
+	"context"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/system/mocks"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestUpdateAlertSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		body        string
+		err         error
+		want        int
+	}{
+		{name: "success", accountType: entities.AccountTypeSuperAdmin, body: `{"slack_webhook_url":"https://hooks.slack.com/services/x"}`, want: http.StatusOK},
+		{name: "admins cannot change the channels", accountType: entities.AccountTypeAdmin, body: `{}`, want: http.StatusForbidden},
+		{name: "invalid settings", accountType: entities.AccountTypeSuperAdmin, body: `{"webhook_url":"nope"}`, err: entities.ErrInvalidSettingValue{Field: "webhook_url", Message: "must be an http or https URL"}, want: http.StatusBadRequest},
+		{name: "invalid body", accountType: entities.AccountTypeSuperAdmin, body: `{`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := &mocks.AlertingUseCaseMock{
+				UpdateSettingsFunc: func(ctx context.Context, settings entities.AlertSettings) error { return tt.err },
+			}
+			jh := jwt.NewService("test-secret", "test-issuer", "1h")
+			routes := NewSystemHandler(&mocks.LogSourceMock{}, &mocks.ReconciliationUseCaseMock{}, &mocks.LogLevelMock{}, alerts, apiMiddleware.NewAuthMiddleware(jh)).AdminRoutes()
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", tt.accountType.String())
+
+			req := httptest.NewRequest(http.MethodPut, "/alerts", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK && alerts.UpdateSettingsCalls()[0].Settings.SlackWebhookURL != "https://hooks.slack.com/services/x" {
+				t.Fatalf("unexpected settings: %+v", alerts.UpdateSettingsCalls()[0].Settings)
+			}
+		})
+	}
+}
+
+func TestSendTestAlert(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", want: http.StatusOK},
+		{name: "no channel configured", err: entities.ErrInvalidSettingValue{Field: "alert_settings", Message: "no alert channel is configured"}, want: http.StatusBadRequest},
+		{name: "delivery failed", err: context.DeadlineExceeded, want: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := &mocks.AlertingUseCaseMock{
+				SendTestFunc: func(ctx context.Context) error { return tt.err },
+			}
+			jh := jwt.NewService("test-secret", "test-issuer", "1h")
+			routes := NewSystemHandler(&mocks.LogSourceMock{}, &mocks.ReconciliationUseCaseMock{}, &mocks.LogLevelMock{}, alerts, apiMiddleware.NewAuthMiddleware(jh)).AdminRoutes()
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+			req := httptest.NewRequest(http.MethodPost, "/alerts/test", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
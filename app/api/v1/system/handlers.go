@@ -0,0 +1,78 @@
+package system
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/log_source.go . LogSource
+type LogSource interface {
+	Logs(ctx context.Context, filter entities.LogFilter) (entities.LogListResponse, error)
+}
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/reconciliation_uc.go . ReconciliationUseCase
+type ReconciliationUseCase interface {
+	Run(ctx context.Context) (entities.ReconciliationReport, error)
+	Report(ctx context.Context) (entities.ReconciliationReport, error)
+}
+
+// LogLevel is the level the service logs at, a *slog.LevelVar.
+//
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/log_level.go . LogLevel
+type LogLevel interface {
+	Level() slog.Level
+	Set(level slog.Level)
+}
+
+// AlertingUseCase manages the channels system alerts are delivered to.
+//
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/alerting_uc.go . AlertingUseCase
+type AlertingUseCase interface {
+	GetSettings(ctx context.Context) (entities.AlertSettings, error)
+	UpdateSettings(ctx context.Context, settings entities.AlertSettings) error
+	SendTest(ctx context.Context) error
+}
+
+type SystemHandler struct {
+	logs      LogSource
+	reconcile ReconciliationUseCase
+	level     LogLevel
+	alerts    AlertingUseCase
+	mw        *middleware.AuthMiddleware
+}
+
+func NewSystemHandler(logs LogSource, reconcile ReconciliationUseCase, level LogLevel, alerts AlertingUseCase, mw *middleware.AuthMiddleware) *SystemHandler {
+	return &SystemHandler{
+		logs:      logs,
+		reconcile: reconcile,
+		level:     level,
+		alerts:    alerts,
+		mw:        mw,
+	}
+}
+
+// AdminRoutes returns the operational endpoints, mounted at /admin/v1/system.
+// Logs and drift reports carry user data, and the log level decides what is
+// logged and audited, and the alert settings hold webhook URLs and routing
+// keys, so they are limited to super admins.
+func (h *SystemHandler) AdminRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Group(func(r chi.Router) {
+		r.Use(h.mw.RequireSuperAdmin)
+		r.Get("/logs", h.GetLogs)
+		r.Get("/reconciliation", h.GetReconciliation)
+		r.Post("/reconciliation", h.RunReconciliation)
+		r.Get("/log-level", h.GetLogLevel)
+		r.Put("/log-level", h.SetLogLevel)
+		r.Get("/alerts", h.GetAlertSettings)
+		r.Put("/alerts", h.UpdateAlertSettings)
+		r.Post("/alerts/test", h.SendTestAlert)
+	})
+
+	return r
+}
@@ -0,0 +1,69 @@
+package system
+
+import (
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/logbuffer"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetLogLevel godoc
+//
+//	@Summary		Get the log level
+//	@Description	Get the minimum level of the records the API logs
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.LogLevel
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Router			/admin/v1/system/log-level [get]
+func (h *SystemHandler) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entities.LogLevel{Level: h.level.Level().String()})
+}
+
+// SetLogLevel godoc
+//
+//	@Summary		Set the log level
+//	@Description	Change the minimum level of the records the API logs, until it restarts or the level is changed again. Records below it are neither written nor kept for the log viewer, and audit events are logged at info, so a level above info stops auditing.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		entities.LogLevel	true	"New level (debug, info, warn, error)"
+//	@Success		200		{object}	entities.LogLevel
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Router			/admin/v1/system/log-level [put]
+func (h *SystemHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req entities.LogLevel
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	level, ok := logbuffer.ParseLevel(req.Level)
+	if !ok {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid level",
+		})
+		return
+	}
+
+	from := h.level.Level()
+	h.level.Set(level)
+	// Logged at warn so the change is seen at any level up to warn
+	domain.Logger(r.Context()).WarnContext(r.Context(), "log level changed", "audit", true,
+		"resource", entities.AuditResourceSystem, "from", from.String(), "to", level.String())
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, entities.LogLevel{Level: level.String()})
+}
@@ -0,0 +1,83 @@
+package system
+
+import (
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/system/mocks"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		body        string
+		want        int
+		wantLevel   string
+	}{
+		{name: "success", accountType: entities.AccountTypeSuperAdmin, body: `{"level":"debug"}`, want: http.StatusOK, wantLevel: "DEBUG"},
+		{name: "admins cannot change the level", accountType: entities.AccountTypeAdmin, body: `{"level":"debug"}`, want: http.StatusForbidden},
+		{name: "invalid level", accountType: entities.AccountTypeSuperAdmin, body: `{"level":"loud"}`, want: http.StatusBadRequest},
+		{name: "invalid body", accountType: entities.AccountTypeSuperAdmin, body: `{`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var level slog.LevelVar
+			jh := jwt.NewService("test-secret", "test-issuer", "1h")
+			routes := NewSystemHandler(&mocks.LogSourceMock{}, &mocks.ReconciliationUseCaseMock{}, &level, &mocks.AlertingUseCaseMock{}, apiMiddleware.NewAuthMiddleware(jh)).AdminRoutes()
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", tt.accountType.String())
+
+			req := httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.wantLevel == "" {
+				if level.Level() != slog.LevelInfo {
+					t.Fatalf("level should not change, got %s", level.Level())
+				}
+				return
+			}
+			var got entities.LogLevel
+			_ = json.Unmarshal(w.Body.Bytes(), &got)
+			if got.Level != tt.wantLevel || level.Level().String() != tt.wantLevel {
+				t.Fatalf("unexpected level: %+v, %s", got, level.Level())
+			}
+		})
+	}
+}
+
+func TestGetLogLevel(t *testing.T) {
+	level := &mocks.LogLevelMock{
+		LevelFunc: func() slog.Level { return slog.LevelWarn },
+	}
+	jh := jwt.NewService("test-secret", "test-issuer", "1h")
+	routes := NewSystemHandler(&mocks.LogSourceMock{}, &mocks.ReconciliationUseCaseMock{}, level, &mocks.AlertingUseCaseMock{}, apiMiddleware.NewAuthMiddleware(jh)).AdminRoutes()
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/log-level", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var got entities.LogLevel
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Level != "WARN" {
+		t.Fatalf("unexpected level: %+v", got)
+	}
+}
@@ -0,0 +1,87 @@
+package system
+
+import (
+	"go-template/domain/entities"
+	"go-template/internal/logbuffer"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+)
+
+const (
+	defaultLogLimit = 200
+	maxLogLimit     = 1000
+)
+
+// GetLogs godoc
+//
+//	@Summary		Tail recent logs
+//	@Description	Get the most recent structured log entries kept in memory by the API. Poll with after set to the returned last_id to tail new entries.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			level		query		string	false	"Minimum level (debug, info, warn, error)"
+//	@Param			request_id	query		string	false	"Only entries logged for this request"
+//	@Param			after		query		int		false	"Only entries with a greater ID"
+//	@Param			limit		query		int		false	"Maximum number of entries (default 200, max 1000)"
+//	@Success		200			{object}	entities.LogListResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/admin/v1/system/logs [get]
+func (h *SystemHandler) GetLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entities.LogFilter{
+		Level:     query.Get("level"),
+		RequestID: query.Get("request_id"),
+		Limit:     defaultLogLimit,
+	}
+
+	if filter.Level != "" {
+		if _, ok := logbuffer.ParseLevel(filter.Level); !ok {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid level",
+			})
+			return
+		}
+	}
+
+	if v := query.Get("after"); v != "" {
+		after, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid after",
+			})
+			return
+		}
+		filter.After = after
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{
+				"error": "invalid limit",
+			})
+			return
+		}
+		filter.Limit = min(limit, maxLogLimit)
+	}
+
+	logs, err := h.logs.Logs(r.Context(), filter)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to get logs",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, logs)
+}
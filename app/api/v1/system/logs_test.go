@@ -0,0 +1,88 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/system/mocks"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func newTestRoutes(logs LogSource) (http.Handler, jwt.Service) {
+	jh := jwt.NewService("test-secret", "test-issuer", "1h")
+	h := NewSystemHandler(logs, &mocks.ReconciliationUseCaseMock{}, &mocks.LogLevelMock{}, &mocks.AlertingUseCaseMock{}, apiMiddleware.NewAuthMiddleware(jh))
+	return h.AdminRoutes(), jh
+}
+
+func TestGetLogs(t *testing.T) {
+	var gotFilter entities.LogFilter
+	logs := &mocks.LogSourceMock{
+		LogsFunc: func(ctx context.Context, filter entities.LogFilter) (entities.LogListResponse, error) {
+			gotFilter = filter
+			return entities.LogListResponse{
+				Entries: []entities.LogEntry{{ID: 8, Level: "ERROR", Message: "boom", RequestID: "req-1"}},
+				LastID:  9,
+			}, nil
+		},
+	}
+	routes, jh := newTestRoutes(logs)
+	token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/logs?level=warn&request_id=req-1&after=7&limit=5000", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := entities.LogFilter{Level: "warn", RequestID: "req-1", After: 7, Limit: maxLogLimit}
+	if gotFilter != want {
+		t.Fatalf("unexpected filter: %+v", gotFilter)
+	}
+	var got entities.LogListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.LastID != 9 || len(got.Entries) != 1 || got.Entries[0].Message != "boom" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
+
+func TestGetLogs_Rejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		query       string
+		want        int
+	}{
+		{name: "admins cannot read logs", accountType: entities.AccountTypeAdmin, want: http.StatusForbidden},
+		{name: "invalid level", accountType: entities.AccountTypeSuperAdmin, query: "?level=loud", want: http.StatusBadRequest},
+		{name: "invalid after", accountType: entities.AccountTypeSuperAdmin, query: "?after=-1", want: http.StatusBadRequest},
+		{name: "invalid limit", accountType: entities.AccountTypeSuperAdmin, query: "?limit=0", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := &mocks.LogSourceMock{}
+			routes, jh := newTestRoutes(logs)
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "a@x.com", tt.accountType.String())
+
+			req := httptest.NewRequest(http.MethodGet, "/logs"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, w.Code)
+			}
+			if len(logs.LogsCalls()) != 0 {
+				t.Fatal("logs should not be read")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// GetReconciliation godoc
+//
+//	@Summary		Get the user reconciliation report
+//	@Description	Get the drift found between the users table and the auth provider by the last reconciliation run and by provider webhooks since
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.ReconciliationReport
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/admin/v1/system/reconciliation [get]
+func (h *SystemHandler) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	report, err := h.reconcile.Report(r.Context())
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to get reconciliation report",
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, report)
+}
+
+// RunReconciliation godoc
+//
+//	@Summary		Run user reconciliation
+//	@Description	Compare the users table with the auth provider now and return the new report. A failed run still returns its partial report with the error set.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	entities.ReconciliationReport
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	entities.ReconciliationReport
+//	@Router			/admin/v1/system/reconciliation [post]
+func (h *SystemHandler) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	report, err := h.reconcile.Run(r.Context())
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, report)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, report)
+}
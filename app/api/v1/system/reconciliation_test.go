@@ -0,0 +1,61 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/system/mocks"
+	"go-template/domain/entities"
+	"go-template/domain/reconciliation"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRunReconciliation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "success", wantCode: http.StatusOK},
+		{name: "failed run returns the partial report", err: reconciliation.ErrEmptyProvider, wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.ReconciliationUseCaseMock{
+				RunFunc: func(ctx context.Context) (entities.ReconciliationReport, error) {
+					report := entities.ReconciliationReport{Provider: "supabase", LocalUsers: 2}
+					if tt.err != nil {
+						report.Error = tt.err.Error()
+					}
+					return report, tt.err
+				},
+			}
+			jh := jwt.NewService("test-secret", "test-issuer", "1h")
+			routes := NewSystemHandler(&mocks.LogSourceMock{}, uc, &mocks.LogLevelMock{}, &mocks.AlertingUseCaseMock{}, apiMiddleware.NewAuthMiddleware(jh)).AdminRoutes()
+			token, _ := jh.GenerateToken(uuid.Must(uuid.NewV4()).String(), "root@x.com", entities.AccountTypeSuperAdmin.String())
+
+			req := httptest.NewRequest(http.MethodPost, "/reconciliation", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+			var got entities.ReconciliationReport
+			_ = json.Unmarshal(w.Body.Bytes(), &got)
+			if got.Provider != "supabase" || got.LocalUsers != 2 {
+				t.Fatalf("unexpected report: %+v", got)
+			}
+			if tt.err != nil && got.Error == "" {
+				t.Fatal("expected the error in the report")
+			}
+		})
+	}
+}
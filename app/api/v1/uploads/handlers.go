@@ -0,0 +1,44 @@
+package uploads
+
+import (
+	"context"
+	"go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/domain/upload"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/upload_uc.go . UploadUseCase
+type UploadUseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, req upload.CreateRequest) (entities.Upload, error)
+	Get(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error)
+	Complete(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error)
+}
+
+type UploadHandler struct {
+	uc UploadUseCase
+	mw *middleware.AuthMiddleware
+}
+
+func NewUploadHandler(uc UploadUseCase, mw *middleware.AuthMiddleware) *UploadHandler {
+	return &UploadHandler{
+		uc: uc,
+		mw: mw,
+	}
+}
+
+// Routes returns the endpoints users upload files straight to file storage
+// with, mounted at /api/v1/uploads.
+func (h *UploadHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(h.mw.RequireAuth)
+
+	r.Post("/", h.CreateUpload)
+	r.Get("/{id}", h.GetUpload)
+	r.Post("/{id}/complete", h.CompleteUpload)
+
+	return r
+}
@@ -0,0 +1,164 @@
+package uploads
+
+import (
+	"errors"
+	"go-template/app/api/common"
+	"go-template/app/api/middleware"
+	"go-template/domain/upload"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gofrs/uuid/v5"
+)
+
+// CreateUpload godoc
+//
+//	@Summary		Start an upload
+//	@Description	Register a pending upload of a file of the given size and return a presigned upload_url to PUT the file to, until upload_url_expires_at. The PUT must send exactly size bytes. Once it succeeds, confirm the upload with POST /api/v1/uploads/{id}/complete; pending uploads never confirmed are removed with their file.
+//	@Tags			uploads
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		upload.CreateRequest	true	"File to upload"
+//	@Success		201		{object}	entities.Upload
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		413		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/uploads [post]
+func (h *UploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	var req upload.CreateRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	created, err := h.uc.Create(r.Context(), principal.UserID, req)
+	if err != nil {
+		writeUploadError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, created)
+}
+
+// GetUpload godoc
+//
+//	@Summary		Get an upload
+//	@Description	Return one of the current user's uploads. Completed uploads come with a fresh download_url.
+//	@Tags			uploads
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Upload ID"
+//	@Success		200	{object}	entities.Upload
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/uploads/{id} [get]
+func (h *UploadHandler) GetUpload(w http.ResponseWriter, r *http.Request) {
+	principal, id, ok := uploadParams(w, r)
+	if !ok {
+		return
+	}
+
+	got, err := h.uc.Get(r.Context(), principal.UserID, id)
+	if err != nil {
+		writeUploadError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, got)
+}
+
+// CompleteUpload godoc
+//
+//	@Summary		Confirm an upload
+//	@Description	Confirm the file was PUT to the upload's upload_url, and return the completed upload with a download_url. Confirming a completed upload returns it again. Answers 409 while the file isn't stored yet, and 422, removing the file, when it isn't the size announced; the file can then be PUT again while upload_url is valid.
+//	@Tags			uploads
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Upload ID"
+//	@Success		200	{object}	entities.Upload
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		410	{object}	map[string]string
+//	@Failure		422	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/uploads/{id}/complete [post]
+func (h *UploadHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	principal, id, ok := uploadParams(w, r)
+	if !ok {
+		return
+	}
+
+	completed, err := h.uc.Complete(r.Context(), principal.UserID, id)
+	if err != nil {
+		writeUploadError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, completed)
+}
+
+// uploadParams returns the caller and the upload ID in the path, answering
+// the request itself when either is missing.
+func uploadParams(w http.ResponseWriter, r *http.Request) (middleware.Principal, uuid.UUID, bool) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return middleware.Principal{}, uuid.Nil, false
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid upload ID",
+		})
+		return middleware.Principal{}, uuid.Nil, false
+	}
+	return principal, id, true
+}
+
+func writeUploadError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, upload.ErrInvalidFileName), errors.Is(err, upload.ErrEmpty):
+		status = http.StatusBadRequest
+	case errors.Is(err, upload.ErrTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, upload.ErrNotUploaded):
+		status = http.StatusConflict
+	case errors.Is(err, upload.ErrSizeMismatch):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, upload.ErrExpired):
+		status = http.StatusGone
+	default:
+		common.RespondError(w, r, common.NotFound(err, "upload"))
+		return
+	}
+
+	common.ErrorResponse(w, r, status, err)
+}
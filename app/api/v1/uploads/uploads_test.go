@@ -0,0 +1,104 @@
+package uploads
+
+import (
+	"context"
+	"encoding/json"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/app/api/v1/uploads/mocks"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/upload"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestUploadHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	uploadID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	uc := &mocks.UploadUseCaseMock{
+		CreateFunc: func(ctx context.Context, id uuid.UUID, req upload.CreateRequest) (entities.Upload, error) {
+			if req.Size > 100 {
+				return entities.Upload{}, upload.ErrTooLarge
+			}
+			return entities.Upload{ID: uploadID, UserID: &id, FileName: req.FileName, Size: req.Size, Status: entities.UploadPending, UploadURL: "https://files/upload"}, nil
+		},
+		GetFunc: func(ctx context.Context, id, uploadID uuid.UUID) (entities.Upload, error) {
+			return entities.Upload{}, domain.ErrNotFound
+		},
+		CompleteFunc: func(ctx context.Context, id, gotID uuid.UUID) (entities.Upload, error) {
+			if gotID != uploadID {
+				return entities.Upload{}, upload.ErrNotUploaded
+			}
+			return entities.Upload{ID: uploadID, Status: entities.UploadCompleted, DownloadURL: "https://files/download"}, nil
+		},
+	}
+	h := NewUploadHandler(uc, apiMiddleware.NewAuthMiddleware(jwtService))
+
+	serve := func(method, target, body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.Routes().ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(http.MethodPost, "/", `{"file_name":"a.bin","size":10}`, false); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/", `{`, true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid JSON, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/", `{"file_name":"a.bin","size":1000}`, true); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", w.Code)
+	}
+
+	w := serve(http.MethodPost, "/", `{"file_name":"a.bin","content_type":"text/plain","size":10}`, true)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created entities.Upload
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if created.UploadURL == "" || created.Status != entities.UploadPending {
+		t.Fatalf("expected a pending upload with its link, got %+v", created)
+	}
+	if call := uc.CreateCalls()[len(uc.CreateCalls())-1]; call.UserID != userID || call.Req.ContentType != "text/plain" {
+		t.Fatalf("unexpected create call %+v", call)
+	}
+
+	if w := serve(http.MethodGet, "/not-a-uuid", "", true); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid ID, got %d", w.Code)
+	}
+	if w := serve(http.MethodGet, "/"+uploadID.String(), "", true); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/"+uuid.Must(uuid.NewV4()).String()+"/complete", "", true); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 before the file is uploaded, got %d", w.Code)
+	}
+
+	w = serve(http.MethodPost, "/"+uploadID.String()+"/complete", "", true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var completed entities.Upload
+	if err := json.NewDecoder(w.Body).Decode(&completed); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if completed.Status != entities.UploadCompleted || completed.DownloadURL == "" {
+		t.Fatalf("expected a completed upload with its link, got %+v", completed)
+	}
+}
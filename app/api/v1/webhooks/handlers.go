@@ -0,0 +1,36 @@
+package webhooks
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/go-chi/chi/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/reconciliation_uc.go . ReconciliationUseCase
+type ReconciliationUseCase interface {
+	HandleEvent(ctx context.Context, event entities.ProviderEvent) error
+}
+
+type WebhookHandler struct {
+	reconcile      ReconciliationUseCase
+	supabaseSecret string
+}
+
+// NewWebhookHandler creates the receiver for auth provider webhooks. Calls
+// must carry supabaseSecret as a bearer token.
+func NewWebhookHandler(reconcile ReconciliationUseCase, supabaseSecret string) *WebhookHandler {
+	return &WebhookHandler{
+		reconcile:      reconcile,
+		supabaseSecret: supabaseSecret,
+	}
+}
+
+// Routes returns the webhook receivers, mounted at /webhooks.
+func (h *WebhookHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/supabase", h.Supabase)
+
+	return r
+}
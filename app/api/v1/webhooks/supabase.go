@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"go-template/domain/entities"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+)
+
+// SupabaseUserRecord is the part of an auth.users row the receiver reads.
+type SupabaseUserRecord struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// SupabaseWebhookPayload is sent by a Supabase database webhook on the
+// auth.users table. Record is null on DELETE.
+type SupabaseWebhookPayload struct {
+	Type      string              `json:"type"`
+	Table     string              `json:"table"`
+	Schema    string              `json:"schema"`
+	Record    *SupabaseUserRecord `json:"record"`
+	OldRecord *SupabaseUserRecord `json:"old_record"`
+}
+
+var supabaseEventTypes = map[string]entities.ProviderEventType{
+	"INSERT": entities.ProviderEventCreated,
+	"UPDATE": entities.ProviderEventUpdated,
+	"DELETE": entities.ProviderEventDeleted,
+}
+
+// Supabase godoc
+//
+//	@Summary		Receive Supabase user changes
+//	@Description	Database webhook for the auth.users table. Users changed or deleted directly in Supabase are checked against the users table right away instead of waiting for the next reconciliation run.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer followed by SUPABASE_WEBHOOK_SECRET"
+//	@Param			request			body		SupabaseWebhookPayload	true	"Webhook payload"
+//	@Success		204
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/webhooks/supabase [post]
+func (h *WebhookHandler) Supabase(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.supabaseSecret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.supabaseSecret)) != 1 {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid webhook secret",
+		})
+		return
+	}
+
+	var payload SupabaseWebhookPayload
+	if err := render.DecodeJSON(r.Body, &payload); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "invalid request body",
+		})
+		return
+	}
+
+	eventType, ok := supabaseEventTypes[payload.Type]
+	record := payload.Record
+	if eventType == entities.ProviderEventDeleted {
+		record = payload.OldRecord
+	}
+	if !ok || payload.Schema != "auth" || payload.Table != "users" || record == nil || record.ID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, map[string]string{
+			"error": "unsupported webhook payload",
+		})
+		return
+	}
+
+	err := h.reconcile.HandleEvent(r.Context(), entities.ProviderEvent{
+		Provider: "supabase",
+		Type:     eventType,
+		User: entities.ProviderUser{
+			ID:    record.ID,
+			Email: record.Email,
+		},
+	})
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{
+			"error": "failed to process webhook",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"go-template/app/api/v1/webhooks/mocks"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSupabaseWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		body      string
+		wantCode  int
+		wantEvent *entities.ProviderEvent
+	}{
+		{
+			name:     "update",
+			secret:   "s3cret",
+			body:     `{"type":"UPDATE","schema":"auth","table":"users","record":{"id":"p-1","email":"new@x.com"},"old_record":{"id":"p-1","email":"old@x.com"}}`,
+			wantCode: http.StatusNoContent,
+			wantEvent: &entities.ProviderEvent{
+				Provider: "supabase",
+				Type:     entities.ProviderEventUpdated,
+				User:     entities.ProviderUser{ID: "p-1", Email: "new@x.com"},
+			},
+		},
+		{
+			name:     "delete reads the old record",
+			secret:   "s3cret",
+			body:     `{"type":"DELETE","schema":"auth","table":"users","record":null,"old_record":{"id":"p-1","email":"a@x.com"}}`,
+			wantCode: http.StatusNoContent,
+			wantEvent: &entities.ProviderEvent{
+				Provider: "supabase",
+				Type:     entities.ProviderEventDeleted,
+				User:     entities.ProviderUser{ID: "p-1", Email: "a@x.com"},
+			},
+		},
+		{
+			name:     "wrong secret",
+			secret:   "wrong",
+			body:     `{"type":"DELETE","schema":"auth","table":"users","old_record":{"id":"p-1"}}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "other table",
+			secret:   "s3cret",
+			body:     `{"type":"UPDATE","schema":"public","table":"profiles","record":{"id":"p-1"}}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid body",
+			secret:   "s3cret",
+			body:     `{`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mocks.ReconciliationUseCaseMock{
+				HandleEventFunc: func(ctx context.Context, event entities.ProviderEvent) error {
+					return nil
+				},
+			}
+			routes := NewWebhookHandler(uc, "s3cret").Routes()
+
+			req := httptest.NewRequest(http.MethodPost, "/supabase", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tt.secret)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			calls := uc.HandleEventCalls()
+			if tt.wantEvent == nil {
+				if len(calls) != 0 {
+					t.Fatalf("expected no event, got %+v", calls)
+				}
+				return
+			}
+			if len(calls) != 1 || calls[0].Event != *tt.wantEvent {
+				t.Fatalf("unexpected events: %+v", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package websocket
+
+import (
+	"go-template/app/api/middleware"
+	"go-template/internal/ws"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Hub keeps the users' WebSocket connections.
+type Hub interface {
+	Serve(w http.ResponseWriter, r *http.Request, userID uuid.UUID) error
+}
+
+type WebSocketHandler struct {
+	hub Hub
+	mw  *middleware.AuthMiddleware
+}
+
+func NewWebSocketHandler(hub Hub, mw *middleware.AuthMiddleware) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub: hub,
+		mw:  mw,
+	}
+}
+
+// Routes returns the endpoint users open their WebSocket connection with,
+// mounted at /api/v1/ws.
+func (h *WebSocketHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(ws.BearerProtocol)
+	r.Use(h.mw.RequireAuth)
+
+	r.Get("/", h.Connect)
+
+	return r
+}
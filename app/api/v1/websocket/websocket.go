@@ -0,0 +1,31 @@
+package websocket
+
+import (
+	"go-template/app/api/middleware"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// Connect godoc
+//
+//	@Summary		Open a WebSocket connection
+//	@Description	Upgrade to a WebSocket connection the signed in user gets real-time messages on, as JSON objects with a "type" and its "data", such as "notification" messages carrying a notification as it arrives. Browsers, which can't set the Authorization header, offer the subprotocols "bearer" and their access token instead. Only messages emitted by the instance serving the connection are sent, so clients should still list notifications after reconnecting.
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Success		101
+//	@Failure		401	{object}	map[string]string
+//	@Router			/api/v1/ws [get]
+func (h *WebSocketHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, map[string]string{
+			"error": "unauthorized",
+		})
+		return
+	}
+
+	// Serve answers requests it can't upgrade itself
+	_ = h.hub.Serve(w, r, principal.UserID)
+}
@@ -0,0 +1,65 @@
+package websocket
+
+import (
+	"context"
+	apiMiddleware "go-template/app/api/middleware"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"go-template/internal/ws"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestWebSocketHandler_Routes(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	jwtService := jwt.NewService("test-secret", "test-issuer", "1h")
+	token, err := jwtService.GenerateToken(userID.String(), "a@b.com", entities.AccountTypeUser.String())
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	hub := ws.NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer hub.Close()
+	h := NewWebSocketHandler(hub, apiMiddleware.NewAuthMiddleware(jwtService))
+	srv := httptest.NewServer(h.Routes())
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, resp, err := websocket.Dial(ctx, url, nil)
+	if err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %v", err)
+	}
+
+	// Browsers offer the token as a subprotocol
+	c, resp, err := websocket.Dial(ctx, url, &websocket.DialOptions{Subprotocols: []string{"bearer", token}})
+	if err != nil {
+		t.Fatalf("dialing: %v", err)
+	}
+	defer c.CloseNow()
+	if c.Subprotocol() != "bearer" {
+		t.Fatalf("expected the bearer subprotocol, got %q", resp.Header.Get("Sec-WebSocket-Protocol"))
+	}
+
+	for hub.Connections(userID) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	hub.Send(userID, ws.Message{Type: "notification", Data: "hello"})
+	_, data, err := c.Read(ctx)
+	if err != nil {
+		t.Fatalf("reading: %v", err)
+	}
+	if string(data) != `{"type":"notification","data":"hello"}` {
+		t.Fatalf("unexpected message: %s", data)
+	}
+}
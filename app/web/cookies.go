@@ -2,18 +2,32 @@ package web
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
+	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
 )
 
 const (
-	CookieToken       = "token"
-	CookieUserID      = "user_id"
-	CookieUserEmail   = "user_email"
-	CookieAccountType = "account_type"
+	CookieToken        = "token"
+	CookieTokenExpires = "token_expires"
+	CookieRefreshToken = "refresh_token"
+	CookieUserID       = "user_id"
+	CookieUserEmail    = "user_email"
+	CookieAccountType  = "account_type"
+	CookieSocialState  = "social_state"
+	CookieMFAToken     = "mfa_token"
+	CookieTheme        = "theme"
 )
 
+// socialStateMaxAge is how long a social login may take before the state
+// cookie expires.
+const socialStateMaxAge = 10 * 60
+
+// mfaTokenMaxAge matches how long the API accepts a two-factor challenge.
+const mfaTokenMaxAge = 5 * 60
+
 // Cookie management methods
 
 func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AuthResponse) {
@@ -37,6 +51,35 @@ func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AuthRe
 		Domain:   domain,
 	})
 
+	// The refresh token and the access token's expiry let the middleware
+	// renew the session before the access token runs out
+	if resp.RefreshToken != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieRefreshToken,
+			Value:    resp.RefreshToken,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   m.cookieSecure,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   maxAge,
+			Expires:  time.Now().Add(time.Duration(maxAge) * time.Second),
+			Domain:   domain,
+		})
+	}
+	if resp.ExpiresIn > 0 {
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieTokenExpires,
+			Value:    strconv.FormatInt(time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second).Unix(), 10),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   m.cookieSecure,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   maxAge,
+			Expires:  time.Now().Add(time.Duration(maxAge) * time.Second),
+			Domain:   domain,
+		})
+	}
+
 	http.SetCookie(w, &http.Cookie{
 		Name:     CookieUserID,
 		Value:    resp.User.ID.String(),
@@ -63,7 +106,7 @@ func (m *AuthMiddleware) setAuthCookies(w http.ResponseWriter, resp *gweb.AuthRe
 }
 
 func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
-	cookieNames := []string{CookieToken, CookieUserID, CookieUserEmail}
+	cookieNames := []string{CookieToken, CookieTokenExpires, CookieRefreshToken, CookieUserID, CookieUserEmail}
 
 	// Don't set domain for localhost in development
 	var domain string
@@ -76,7 +119,7 @@ func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
 			Name:     name,
 			Value:    "",
 			Path:     "/",
-			HttpOnly: name == CookieToken,
+			HttpOnly: name == CookieToken || name == CookieTokenExpires || name == CookieRefreshToken,
 			Secure:   m.cookieSecure,
 			SameSite: http.SameSiteLaxMode,
 			MaxAge:   -1,
@@ -86,6 +129,87 @@ func (m *AuthMiddleware) clearAuthCookies(w http.ResponseWriter) {
 	}
 }
 
+// setSocialStateCookie remembers the state sent to the social login provider
+// until it redirects back to the callback.
+func (m *AuthMiddleware) setSocialStateCookie(w http.ResponseWriter, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieSocialState,
+		Value:    value,
+		Path:     "/auth/",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   socialStateMaxAge,
+	})
+}
+
+func (m *AuthMiddleware) clearSocialStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieSocialState,
+		Value:    "",
+		Path:     "/auth/",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
+
+// setMFATokenCookie keeps the challenge from a two-factor login, and where to
+// go afterwards, until the user enters their code.
+func (m *AuthMiddleware) setMFATokenCookie(w http.ResponseWriter, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieMFAToken,
+		Value:    value,
+		Path:     "/login/2fa",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   mfaTokenMaxAge,
+	})
+}
+
+func (m *AuthMiddleware) clearMFATokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieMFAToken,
+		Value:    "",
+		Path:     "/login/2fa",
+		HttpOnly: true,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+	})
+}
+
+// setThemeCookie mirrors the user's theme preference for the layout's script,
+// which applies it before the page paints. It is readable from JavaScript and
+// kept for a year; the preference itself lives in the API.
+func (m *AuthMiddleware) setThemeCookie(w http.ResponseWriter, theme entities.Theme) {
+	const maxAge = 365 * 24 * 60 * 60
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieTheme,
+		Value:    string(theme),
+		Path:     "/",
+		HttpOnly: false,
+		Secure:   m.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+		Expires:  time.Now().Add(maxAge * time.Second),
+	})
+}
+
+// tokenExpiresSoon reports whether the access token expires within
+// refreshWindow. Sessions from before the expiry was stored never do.
+func tokenExpiresSoon(r *http.Request) bool {
+	expires, err := strconv.ParseInt(getCookieValue(r, CookieTokenExpires), 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.Until(time.Unix(expires, 0)) < refreshWindow
+}
+
 func getCookieValue(r *http.Request, name string) string {
 	c, err := r.Cookie(name)
 	if err != nil {
@@ -1,8 +1,10 @@
 package docs
 
 import (
+	"html"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"strings"
 
 	rootdocs "go-template/docs"
@@ -12,14 +14,21 @@ import (
 
 // Handler provides documentation endpoints
 type Handler struct {
-	docsFS fs.FS
+	docsFS     fs.FS
+	apiBaseURL *url.URL
 }
 
-// NewHandler creates a new documentation handler
-func NewHandler() *Handler {
-	return &Handler{
+// NewHandler creates a new documentation handler. The specs are served
+// pointing at apiBaseURL; they keep their own servers when it is empty or
+// invalid.
+func NewHandler(apiBaseURL string) *Handler {
+	h := &Handler{
 		docsFS: rootdocs.FS(),
 	}
+	if u, err := url.Parse(apiBaseURL); err == nil && u.Host != "" {
+		h.apiBaseURL = u
+	}
+	return h
 }
 
 // Routes sets up the documentation routes
@@ -29,6 +38,11 @@ func (h *Handler) Routes() chi.Router {
 	// Serve static documentation files
 	r.Handle("/*", h.fileServer())
 
+	// Specs pointed at the API of the current environment
+	r.Get("/openapi.yaml", h.ServeFile("openapi.yaml"))
+	r.Get("/openapi-generated.yaml", h.ServeFile("openapi-generated.yaml"))
+	r.Get("/openapi-generated.json", h.ServeFile("openapi-generated.json"))
+
 	// Specific routes for better UX
 	r.Get("/", h.indexPage)
 	r.Get("/redoc", h.redirectToRedocHTML)
@@ -183,7 +197,7 @@ func (h *Handler) indexPage(w http.ResponseWriter, r *http.Request) {
         
         <h2>🛠 API Information</h2>
         <ul>
-            <li><strong>Base URL:</strong> <code>http://localhost:8080</code></li>
+            <li><strong>Base URL:</strong> <code>{{API_BASE_URL}}</code></li>
             <li><strong>Version:</strong> 1.0.0</li>
             <li><strong>Authentication:</strong> Bearer JWT Token</li>
             <li><strong>Content Type:</strong> application/json</li>
@@ -206,6 +220,12 @@ func (h *Handler) indexPage(w http.ResponseWriter, r *http.Request) {
 </body>
 </html>`
 
+	baseURL := "http://localhost:8080"
+	if h.apiBaseURL != nil {
+		baseURL = html.EscapeString(h.apiBaseURL.String())
+	}
+	indexHTML = strings.Replace(indexHTML, "{{API_BASE_URL}}", baseURL, 1)
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(indexHTML))
@@ -230,6 +250,13 @@ func (h *Handler) ServeFile(filename string) http.HandlerFunc {
 			return
 		}
 
+		if h.apiBaseURL != nil {
+			if data, err = rewriteSpec(filename, data, h.apiBaseURL); err != nil {
+				http.Error(w, "failed to render spec", http.StatusInternalServerError)
+				return
+			}
+		}
+
 		// Set appropriate content type
 		switch {
 		case strings.HasSuffix(filename, ".yaml"), strings.HasSuffix(filename, ".yml"):
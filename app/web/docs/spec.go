@@ -0,0 +1,50 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	serversBlock = regexp.MustCompile(`(?m)^servers:\n(?:(?:[ \t].*)?\n)*`)
+	hostLine     = regexp.MustCompile(`(?m)^host: .*$`)
+	basePathLine = regexp.MustCompile(`(?m)^basePath: .*$`)
+)
+
+// rewriteSpec points a spec at baseURL so "Try it out" requests reach the API
+// of the current environment. filename picks the spec format.
+func rewriteSpec(filename string, data []byte, baseURL *url.URL) ([]byte, error) {
+	basePath := baseURL.Path
+	if basePath == "" {
+		basePath = "/"
+	}
+
+	switch filename {
+	case "openapi.yaml":
+		// OpenAPI 3 lists servers
+		servers := fmt.Sprintf("servers:\n  - url: %s\n\n", strings.TrimSuffix(baseURL.String(), "/"))
+		return serversBlock.ReplaceAll(data, []byte(servers)), nil
+	case "openapi-generated.yaml":
+		// Swagger 2 splits the URL into host, basePath and schemes
+		out := hostLine.ReplaceAll(data, []byte("host: "+baseURL.Host))
+		out = basePathLine.ReplaceAll(out, []byte("basePath: "+basePath))
+		if !strings.Contains(string(out), "\nschemes:") {
+			out = append(out, []byte("schemes:\n- "+baseURL.Scheme+"\n")...)
+		}
+		return out, nil
+	case "openapi-generated.json":
+		var spec map[string]any
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, err
+		}
+		spec["host"] = baseURL.Host
+		spec["basePath"] = basePath
+		spec["schemes"] = []string{baseURL.Scheme}
+		return json.MarshalIndent(spec, "", "    ")
+	default:
+		return data, nil
+	}
+}
@@ -2,13 +2,23 @@ package web
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"go-template/app/web/templates"
+	"go-template/domain"
+	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
+	"go-template/internal/botdetect"
+	"go-template/internal/jwt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -18,19 +28,32 @@ type Handlers struct {
 	client     *gweb.Client
 	logger     *slog.Logger
 	auth       *AuthMiddleware
+	bots       *botdetect.Detector
 	fileServer http.Handler
+	baseURL    string
+
+	// liveNotifications relays the user's notification stream, for the
+	// bell to refresh as notifications arrive.
+	liveNotifications bool
 }
 
 // NewHandlers creates a new Handlers instance
-func NewHandlers(client *gweb.Client, logger *slog.Logger, auth *AuthMiddleware, staticPath string) *Handlers {
+func NewHandlers(client *gweb.Client, logger *slog.Logger, auth *AuthMiddleware, bots *botdetect.Detector, staticPath, baseURL string) *Handlers {
 	return &Handlers{
 		client:     client,
 		logger:     logger,
 		auth:       auth,
+		bots:       bots,
 		fileServer: http.FileServer(http.Dir(staticPath)),
+		baseURL:    strings.TrimRight(baseURL, "/"),
 	}
 }
 
+// log returns the logger of the request, which names it and its user.
+func (h *Handlers) log(r *http.Request) *slog.Logger {
+	return domain.LoggerOr(r.Context(), h.logger)
+}
+
 // HomePage renders the home/landing page
 func (h *Handlers) HomePage(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
@@ -47,7 +70,7 @@ func (h *Handlers) HomePage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := renderTemplate(w, "home.templ", data); err != nil {
-		h.logger.Error("failed to render home template", slog.String("error", err.Error()))
+		h.log(r).Error("failed to render home template", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
@@ -64,14 +87,22 @@ func (h *Handlers) LoginPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The page still works without social login if the API is unavailable
+	providers, err := h.client.SocialProviders()
+	if err != nil {
+		h.log(r).Warn("failed to list social login providers", slog.String("error", err.Error()))
+	}
+
 	data := map[string]interface{}{
-		"Title":    "Login",
-		"Error":    r.URL.Query().Get("error"),
-		"Redirect": r.URL.Query().Get("redirect"),
+		"Title":           "Login",
+		"Error":           r.URL.Query().Get("error"),
+		"Redirect":        r.URL.Query().Get("redirect"),
+		"SocialProviders": providers,
+		"Captcha":         h.captchaData(),
 	}
 
 	if err := renderTemplate(w, "login.templ", data); err != nil {
-		h.logger.Error("failed to render login template", slog.String("error", err.Error()))
+		h.log(r).Error("failed to render login template", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
@@ -93,14 +124,30 @@ func (h *Handlers) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	loginReq := gweb.LoginRequest{
-		Email:    email,
-		Password: password,
+		Email:        email,
+		Password:     password,
+		Audience:     jwt.AudienceWeb,
+		CaptchaToken: captchaResponse(r),
 	}
 
 	resp, err := h.client.Login(loginReq)
 	if err != nil {
-		h.logger.Error("login failed", slog.String("error", err.Error()), slog.String("email", email))
+		h.log(r).Error("login failed", slog.String("error", err.Error()), slog.String("email", email))
 		redirectURL := "/login?error=invalid_credentials"
+		switch {
+		case strings.Contains(err.Error(), "account suspended"):
+			redirectURL = "/login?error=account_suspended"
+		case strings.Contains(err.Error(), "account pending"):
+			redirectURL = "/login?error=account_pending"
+		case strings.Contains(err.Error(), "403"):
+			redirectURL = "/login?error=email_not_verified"
+		}
+		if strings.Contains(err.Error(), "503") {
+			redirectURL = "/login?error=service_unavailable"
+		}
+		if strings.Contains(err.Error(), "captcha") {
+			redirectURL = "/login?error=captcha_failed"
+		}
 		if redirectTo != "" {
 			redirectURL += "&redirect=" + url.QueryEscape(redirectTo)
 		}
@@ -108,7 +155,14 @@ func (h *Handlers) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Info("login successful", slog.String("email", email), slog.String("user_id", resp.User.ID.String()))
+	// Users with two-factor authentication enter their code next
+	if resp.MFARequired {
+		h.auth.setMFATokenCookie(w, resp.MFAToken+"|"+redirectTo)
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+
+	h.log(r).Info("login successful", slog.String("email", email), slog.String("user_id", resp.User.ID.String()))
 
 	// Set auth cookies
 	h.auth.setAuthCookies(w, resp)
@@ -117,7 +171,64 @@ func (h *Handlers) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 	if redirectTo == "" {
 		redirectTo = "/dashboard"
 	}
-	h.logger.Info("redirecting after login", slog.String("redirect_to", redirectTo))
+	h.log(r).Info("redirecting after login", slog.String("redirect_to", redirectTo))
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// TwoFactorPage asks for the authenticator code after the first factor
+func (h *Handlers) TwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	if getCookieValue(r, CookieMFAToken) == "" {
+		http.Redirect(w, r, "/login?error=session_expired", http.StatusFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title": "Two-Factor Authentication",
+		"Error": r.URL.Query().Get("error"),
+	}
+
+	if err := renderTemplate(w, "two_factor.templ", data); err != nil {
+		h.log(r).Error("failed to render two-factor template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// TwoFactorSubmit completes the login with the authenticator code or a
+// recovery code
+func (h *Handlers) TwoFactorSubmit(w http.ResponseWriter, r *http.Request) {
+	mfaToken, redirectTo, _ := strings.Cut(getCookieValue(r, CookieMFAToken), "|")
+	if mfaToken == "" {
+		http.Redirect(w, r, "/login?error=session_expired", http.StatusSeeOther)
+		return
+	}
+
+	code := r.FormValue("code")
+	recoveryCode := r.FormValue("recovery_code")
+	if code == "" && recoveryCode == "" {
+		http.Redirect(w, r, "/login/2fa?error=missing_code", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.client.TwoFactorChallenge(gweb.TwoFactorChallengeRequest{
+		MFAToken:     mfaToken,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+		Audience:     jwt.AudienceWeb,
+	})
+	if err != nil {
+		h.log(r).Warn("two-factor challenge failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/login/2fa?error=invalid_code", http.StatusSeeOther)
+		return
+	}
+
+	h.log(r).Info("login successful", slog.String("user_id", resp.User.ID.String()))
+
+	h.auth.clearMFATokenCookie(w)
+	h.auth.setAuthCookies(w, resp)
+
+	if redirectTo == "" || !isLocalPath(redirectTo) {
+		redirectTo = "/dashboard"
+	}
 	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
 }
 
@@ -130,12 +241,19 @@ func (h *Handlers) RegisterPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := map[string]interface{}{
-		"Title": "Register",
-		"Error": r.URL.Query().Get("error"),
+		"Title":      "Register",
+		"Error":      r.URL.Query().Get("error"),
+		"Invitation": r.URL.Query().Get("invite"),
+		"BotFields": templates.BotFieldsData{
+			HoneypotField: h.bots.HoneypotField(),
+			TokenField:    h.bots.TokenField(),
+			Token:         h.bots.Token(),
+		},
+		"Captcha": h.captchaData(),
 	}
 
 	if err := renderTemplate(w, "register.templ", data); err != nil {
-		h.logger.Error("failed to render register template", slog.String("error", err.Error()))
+		h.log(r).Error("failed to render register template", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
@@ -150,30 +268,73 @@ func (h *Handlers) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 	email := r.FormValue("email")
 	password := r.FormValue("password")
 	confirmPassword := r.FormValue("confirm_password")
+	invitationCode := strings.TrimSpace(r.FormValue("invitation_code"))
+
+	// Keep the invitation code across error redirects
+	invite := ""
+	if invitationCode != "" {
+		invite = "&invite=" + url.QueryEscape(invitationCode)
+	}
 
 	if email == "" || password == "" {
-		http.Redirect(w, r, "/register?error=missing_credentials", http.StatusSeeOther)
+		http.Redirect(w, r, "/register?error=missing_credentials"+invite, http.StatusSeeOther)
 		return
 	}
 
 	if password != confirmPassword {
-		http.Redirect(w, r, "/register?error=password_mismatch", http.StatusSeeOther)
+		http.Redirect(w, r, "/register?error=password_mismatch"+invite, http.StatusSeeOther)
 		return
 	}
 
 	registerReq := gweb.RegisterRequest{
-		Email:    email,
-		Password: password,
+		Email:          email,
+		Password:       password,
+		Audience:       jwt.AudienceWeb,
+		CaptchaToken:   captchaResponse(r),
+		InvitationCode: invitationCode,
 	}
 
 	resp, err := h.client.Register(registerReq)
 	if err != nil {
-		h.logger.Error("registration failed", slog.String("error", err.Error()))
+		h.log(r).Error("registration failed", slog.String("error", err.Error()))
 		errorType := "registration_failed"
 		if strings.Contains(err.Error(), "409") {
 			errorType = "email_exists"
 		}
-		http.Redirect(w, r, "/register?error="+errorType, http.StatusSeeOther)
+		if strings.Contains(err.Error(), "503") {
+			errorType = "service_unavailable"
+		}
+		if strings.Contains(err.Error(), "captcha") {
+			errorType = "captcha_failed"
+		}
+		if strings.Contains(err.Error(), "403") {
+			errorType = "registration_closed"
+			if strings.Contains(err.Error(), "invitation") {
+				errorType = "invitation_required"
+			}
+		}
+		if strings.Contains(err.Error(), "invitation code") && strings.Contains(err.Error(), "400") {
+			errorType = "invalid_invitation"
+		}
+		if strings.Contains(err.Error(), "password policy") {
+			errorType = "weak_password"
+		}
+		http.Redirect(w, r, "/register?error="+errorType+invite, http.StatusSeeOther)
+		return
+	}
+
+	// New accounts waiting for an admin, or that must verify their email,
+	// get no session yet
+	if resp.ApprovalRequired {
+		redirectURL := "/register/pending"
+		if resp.EmailVerificationRequired {
+			redirectURL += "?verify=1"
+		}
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+	if resp.EmailVerificationRequired {
+		http.Redirect(w, r, "/verify-email", http.StatusSeeOther)
 		return
 	}
 
@@ -184,6 +345,267 @@ func (h *Handlers) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 }
 
+// RegisterPendingPage tells new users their account waits for an admin's
+// approval.
+func (h *Handlers) RegisterPendingPage(w http.ResponseWriter, r *http.Request) {
+	data := map[string]interface{}{
+		"Title":  "Registration received",
+		"Verify": r.URL.Query().Get("verify") != "",
+	}
+
+	if err := renderTemplate(w, "register_pending.templ", data); err != nil {
+		h.log(r).Error("failed to render register pending template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// captchaData returns the CAPTCHA widget registration and login forms render.
+// Forms render without one if the API can't be asked; it then rejects the
+// submission if a CAPTCHA was required.
+func (h *Handlers) captchaData() templates.CaptchaData {
+	cfg, err := h.client.Captcha()
+	if err != nil {
+		h.logger.Warn("failed to get captcha config", slog.String("error", err.Error()))
+		return templates.CaptchaData{}
+	}
+	if !cfg.Required {
+		return templates.CaptchaData{}
+	}
+	return templates.CaptchaData{Provider: cfg.Provider, SiteKey: cfg.SiteKey}
+}
+
+// captchaResponse returns the response the hCaptcha or Turnstile widget added
+// to the form
+func captchaResponse(r *http.Request) string {
+	if token := r.FormValue("h-captcha-response"); token != "" {
+		return token
+	}
+	return r.FormValue("cf-turnstile-response")
+}
+
+// BotBlocked answers public form submissions flagged as automated with the
+// generic failure message, so bots can't tell which check caught them.
+func (h *Handlers) BotBlocked(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/register?error=registration_failed", http.StatusSeeOther)
+}
+
+// ForgotPasswordPage renders the form that requests a password reset email
+func (h *Handlers) ForgotPasswordPage(w http.ResponseWriter, r *http.Request) {
+	data := map[string]interface{}{
+		"Title": "Forgot Password",
+		"Error": r.URL.Query().Get("error"),
+		"Sent":  r.URL.Query().Get("sent") != "",
+		"BotFields": templates.BotFieldsData{
+			HoneypotField: h.bots.HoneypotField(),
+			TokenField:    h.bots.TokenField(),
+			Token:         h.bots.Token(),
+		},
+	}
+
+	if err := renderTemplate(w, "forgot_password.templ", data); err != nil {
+		h.log(r).Error("failed to render forgot password template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ForgotPasswordSubmit asks the API to email a reset link. The page looks the
+// same whether or not the email is registered.
+func (h *Handlers) ForgotPasswordSubmit(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("email")
+	if email == "" {
+		http.Redirect(w, r, "/forgot-password?error=missing_email", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.client.ForgotPassword(email); err != nil {
+		h.log(r).Error("password reset request failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/forgot-password?error=request_failed", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/forgot-password?sent=1", http.StatusSeeOther)
+}
+
+// ForgotPasswordBotBlocked answers flagged reset requests as if the email was
+// sent.
+func (h *Handlers) ForgotPasswordBotBlocked(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/forgot-password?sent=1", http.StatusSeeOther)
+}
+
+// ResetPasswordPage renders the new password form for the token in the reset
+// link
+func (h *Handlers) ResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	errorMsg := r.URL.Query().Get("error")
+	if token == "" && errorMsg == "" && r.URL.Query().Get("done") == "" {
+		errorMsg = "invalid_token"
+	}
+
+	data := map[string]interface{}{
+		"Title": "Reset Password",
+		"Token": token,
+		"Error": errorMsg,
+		"Done":  r.URL.Query().Get("done") != "",
+	}
+
+	if err := renderTemplate(w, "reset_password.templ", data); err != nil {
+		h.log(r).Error("failed to render reset password template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ResetPasswordSubmit sets the new password
+func (h *Handlers) ResetPasswordSubmit(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	confirmPassword := r.FormValue("confirm_password")
+
+	retry := "/reset-password?token=" + url.QueryEscape(token) + "&error="
+	if password == "" {
+		http.Redirect(w, r, retry+"missing_password", http.StatusSeeOther)
+		return
+	}
+	if password != confirmPassword {
+		http.Redirect(w, r, retry+"password_mismatch", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.client.ResetPassword(token, password); err != nil {
+		h.log(r).Warn("password reset failed", slog.String("error", err.Error()))
+		if strings.Contains(err.Error(), "password policy") {
+			http.Redirect(w, r, retry+"weak_password", http.StatusSeeOther)
+			return
+		}
+		if strings.Contains(err.Error(), "400") {
+			http.Redirect(w, r, "/reset-password?error=invalid_token", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, retry+"reset_failed", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/reset-password?done=1", http.StatusSeeOther)
+}
+
+// AcceptInvitationPage renders the password form for the token in an
+// invitation link
+func (h *Handlers) AcceptInvitationPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	errorMsg := r.URL.Query().Get("error")
+	if token == "" && errorMsg == "" {
+		errorMsg = "invalid_token"
+	}
+
+	data := map[string]interface{}{
+		"Title": "Accept Invitation",
+		"Token": token,
+		"Error": errorMsg,
+	}
+
+	if err := renderTemplate(w, "invitation.templ", data); err != nil {
+		h.log(r).Error("failed to render invitation template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// AcceptInvitationSubmit creates the invited account and signs the user in
+func (h *Handlers) AcceptInvitationSubmit(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	confirmPassword := r.FormValue("confirm_password")
+
+	retry := "/accept-invitation?token=" + url.QueryEscape(token) + "&error="
+	if password == "" {
+		http.Redirect(w, r, retry+"missing_password", http.StatusSeeOther)
+		return
+	}
+	if password != confirmPassword {
+		http.Redirect(w, r, retry+"password_mismatch", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.client.AcceptInvitation(token, password, jwt.AudienceWeb)
+	if err != nil {
+		h.log(r).Warn("accepting invitation failed", slog.String("error", err.Error()))
+		switch {
+		case strings.Contains(err.Error(), "password policy"):
+			http.Redirect(w, r, retry+"weak_password", http.StatusSeeOther)
+		case strings.Contains(err.Error(), "400") && strings.Contains(err.Error(), "invitation"),
+			strings.Contains(err.Error(), "403"):
+			http.Redirect(w, r, "/accept-invitation?error=invalid_token", http.StatusSeeOther)
+		case strings.Contains(err.Error(), "409"):
+			http.Redirect(w, r, "/accept-invitation?error=email_exists", http.StatusSeeOther)
+		case strings.Contains(err.Error(), "503"):
+			http.Redirect(w, r, retry+"service_unavailable", http.StatusSeeOther)
+		default:
+			http.Redirect(w, r, retry+"accept_failed", http.StatusSeeOther)
+		}
+		return
+	}
+
+	h.auth.setAuthCookies(w, resp)
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// VerifyEmailPage confirms the token in a verification link, or asks the user
+// to check their inbox and offers to resend the link
+func (h *Handlers) VerifyEmailPage(w http.ResponseWriter, r *http.Request) {
+	errorMsg := r.URL.Query().Get("error")
+	done := false
+	if token := r.URL.Query().Get("token"); token != "" {
+		if err := h.client.VerifyEmail(token); err != nil {
+			h.log(r).Warn("email verification failed", slog.String("error", err.Error()))
+			errorMsg = "verification_failed"
+			if strings.Contains(err.Error(), "400") {
+				errorMsg = "invalid_token"
+			}
+		} else {
+			done = true
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title": "Verify Email",
+		"Error": errorMsg,
+		"Sent":  r.URL.Query().Get("sent") != "",
+		"Done":  done,
+		"BotFields": templates.BotFieldsData{
+			HoneypotField: h.bots.HoneypotField(),
+			TokenField:    h.bots.TokenField(),
+			Token:         h.bots.Token(),
+		},
+	}
+
+	if err := renderTemplate(w, "verify_email.templ", data); err != nil {
+		h.log(r).Error("failed to render verify email template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// ResendVerificationSubmit asks the API to email a new verification link. The
+// page looks the same whether or not the account needed one.
+func (h *Handlers) ResendVerificationSubmit(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("email")
+	if email == "" {
+		http.Redirect(w, r, "/verify-email?error=missing_email", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.client.ResendVerificationEmail(email); err != nil {
+		h.log(r).Error("verification email request failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/verify-email?error=request_failed", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/verify-email?sent=1", http.StatusSeeOther)
+}
+
+// ResendVerificationBotBlocked answers flagged resend requests as if the
+// email was sent.
+func (h *Handlers) ResendVerificationBotBlocked(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/verify-email?sent=1", http.StatusSeeOther)
+}
+
 // Dashboard renders the user dashboard
 func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
@@ -198,11 +620,409 @@ func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := renderTemplate(w, "dashboard.templ", data); err != nil {
-		h.logger.Error("failed to render dashboard template", slog.String("error", err.Error()))
+		h.log(r).Error("failed to render dashboard template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// Search renders the example search page and its results
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?redirect=/search", http.StatusFound)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	var results *entities.SearchResponse
+	var errMsg string
+	if query != "" {
+		var err error
+		results, err = h.client.Search(query, 20)
+		if err != nil {
+			h.log(r).Warn("failed to search", slog.String("error", err.Error()))
+			errMsg = "Search failed. Please try a different query."
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Search",
+		"User":    user,
+		"Query":   query,
+		"Results": results,
+		"Error":   errMsg,
+	}
+
+	if err := renderTemplate(w, "search.templ", data); err != nil {
+		h.log(r).Error("failed to render search template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// examplesPageSize is how many examples the examples page lists at a time.
+const examplesPageSize = 20
+
+// Examples lists the examples, with a form to create one
+func (h *Handlers) Examples(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?redirect=/examples", http.StatusFound)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	msg := r.URL.Query().Get("msg")
+	var examples []entities.Example
+	totalPages := 0
+	resp, err := h.client.ListExamples(page, examplesPageSize)
+	if err != nil {
+		h.log(r).Warn("failed to list examples", slog.String("error", err.Error()))
+		msg = "list_failed"
+	} else {
+		examples = resp.Examples
+		totalPages = resp.TotalPages
+	}
+
+	data := map[string]interface{}{
+		"Title":      "Examples",
+		"User":       user,
+		"Examples":   examples,
+		"Page":       page,
+		"TotalPages": totalPages,
+		"Msg":        msg,
+	}
+
+	if err := renderTemplate(w, "examples.templ", data); err != nil {
+		h.log(r).Error("failed to render examples template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// CreateExampleSubmit creates an example from the examples page form
+func (h *Handlers) CreateExampleSubmit(w http.ResponseWriter, r *http.Request) {
+	title := strings.TrimSpace(r.FormValue("title"))
+	if title == "" {
+		http.Redirect(w, r, "/examples?msg=invalid", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := h.client.CreateExample(title, r.FormValue("content")); err != nil {
+		h.log(r).Warn("example creation failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/examples?msg="+exampleErrorCode(err), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/examples?msg=created", http.StatusSeeOther)
+}
+
+// ExampleRow answers with an example's row, to leave its edit form
+func (h *Handlers) ExampleRow(w http.ResponseWriter, r *http.Request) {
+	h.renderExample(w, r, false, "")
+}
+
+// EditExample answers with the form editing an example in place of its row
+func (h *Handlers) EditExample(w http.ResponseWriter, r *http.Request) {
+	h.renderExample(w, r, true, "")
+}
+
+// UpdateExampleSubmit saves an example edited in place. When it was
+// changed since the form was opened, the form comes back with the latest
+// version to review.
+func (h *Handlers) UpdateExampleSubmit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	title := strings.TrimSpace(r.FormValue("title"))
+	updatedAt, err := time.Parse(time.RFC3339Nano, r.FormValue("updated_at"))
+	if err != nil || title == "" {
+		h.renderExampleForm(w, r, entities.Example{
+			ID:        id,
+			Title:     title,
+			Content:   r.FormValue("content"),
+			UpdatedAt: updatedAt,
+		}, "invalid")
+		return
+	}
+
+	example, err := h.client.UpdateExample(id, title, r.FormValue("content"), updatedAt)
+	if err != nil {
+		h.log(r).Warn("example update failed", slog.String("example_id", id), slog.String("error", err.Error()))
+		code := exampleErrorCode(err)
+		if code == "conflict" {
+			h.renderExample(w, r, true, code)
+			return
+		}
+		h.renderExampleForm(w, r, entities.Example{
+			ID:        id,
+			Title:     title,
+			Content:   r.FormValue("content"),
+			UpdatedAt: updatedAt,
+		}, code)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/examples", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.ExampleRow(*example, "").Render(r.Context(), w)
+}
+
+// DeleteExampleSubmit deletes an example, unless it was changed since the
+// list was shown. The row is removed, or shows why it wasn't.
+func (h *Handlers) DeleteExampleSubmit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var updatedAt *time.Time
+	if t, err := time.Parse(time.RFC3339Nano, r.FormValue("updated_at")); err == nil {
+		updatedAt = &t
+	}
+
+	err := h.client.DeleteExample(id, updatedAt)
+	if err != nil && strings.Contains(err.Error(), "404") {
+		// Already gone
+		err = nil
+	}
+	if err != nil {
+		h.log(r).Warn("example deletion failed", slog.String("example_id", id), slog.String("error", err.Error()))
+		h.renderExample(w, r, false, "delete_"+exampleErrorCode(err))
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/examples", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+}
+
+// renderExample answers with the example's latest version as a row, or as
+// its edit form, showing why the last change failed if msg says so.
+// Requests not made by HTMX go back to the examples page.
+func (h *Handlers) renderExample(w http.ResponseWriter, r *http.Request, edit bool, msg string) {
+	if r.Header.Get("HX-Request") != "true" {
+		target := "/examples"
+		if msg != "" {
+			target += "?msg=" + url.QueryEscape(msg)
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	example, err := h.client.GetExample(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			// The example is gone; its row goes too
+			w.Header().Set("Content-Type", "text/html")
+			return
+		}
+		h.log(r).Warn("failed to get example", slog.String("example_id", id), slog.String("error", err.Error()))
+		http.Error(w, "Failed to load the example", http.StatusInternalServerError)
+		return
+	}
+
+	errMsg := ""
+	if msg != "" {
+		errMsg = templates.ExampleErrorMessage(msg)
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if edit {
+		_ = templates.ExampleEditRow(*example, errMsg).Render(r.Context(), w)
+		return
+	}
+	_ = templates.ExampleRow(*example, errMsg).Render(r.Context(), w)
+}
+
+// renderExampleForm answers with the edit form as it was submitted, showing
+// why it wasn't saved.
+func (h *Handlers) renderExampleForm(w http.ResponseWriter, r *http.Request, example entities.Example, msg string) {
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/examples?msg="+url.QueryEscape(msg), http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.ExampleEditRow(example, templates.ExampleErrorMessage(msg)).Render(r.Context(), w)
+}
+
+// exampleErrorCode names the reason an example API call failed, for the
+// examples page messages.
+func exampleErrorCode(err error) string {
+	switch {
+	case strings.Contains(err.Error(), "(400)"):
+		return "invalid"
+	case strings.Contains(err.Error(), "(404)"):
+		return "not_found"
+	case strings.Contains(err.Error(), "(409)") && strings.Contains(err.Error(), "exists"):
+		return "duplicate"
+	case strings.Contains(err.Error(), "(409)"):
+		return "conflict"
+	default:
+		return "failed"
+	}
+}
+
+// notificationsPageSize is how many notifications the notifications page
+// lists at a time, and notificationBellSize how many of the latest the
+// bell's menu shows.
+const (
+	notificationsPageSize = 20
+	notificationBellSize  = 5
+)
+
+// Notifications lists the user's notifications, or only the unread ones
+func (h *Handlers) Notifications(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?redirect=/notifications", http.StatusFound)
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	var errMsg string
+	list, err := h.client.ListNotifications(unreadOnly, page, notificationsPageSize)
+	if err != nil {
+		h.log(r).Warn("failed to list notifications", slog.String("error", err.Error()))
+		errMsg = "Your notifications couldn't be loaded. Please try again."
+	}
+
+	data := map[string]interface{}{
+		"Title":         "Notifications",
+		"User":          user,
+		"Notifications": list,
+		"UnreadOnly":    unreadOnly,
+		"Error":         errMsg,
+	}
+
+	if err := renderTemplate(w, "notifications.templ", data); err != nil {
+		h.log(r).Error("failed to render notifications template", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
 
+// NotificationBell answers with the navbar's notification bell
+func (h *Handlers) NotificationBell(w http.ResponseWriter, r *http.Request) {
+	h.renderNotificationBell(w, r, false)
+}
+
+// MarkNotificationReadSubmit marks a notification read. HTMX requests from
+// the bell get it back with the menu open; forms go back to the page they
+// were sent from.
+func (h *Handlers) MarkNotificationReadSubmit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	err := h.client.MarkNotificationRead(id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		h.log(r).Warn("failed to mark notification read", slog.String("notification_id", id), slog.String("error", err.Error()))
+	}
+	h.notificationsMarked(w, r)
+}
+
+// MarkAllNotificationsReadSubmit marks all the user's notifications read
+func (h *Handlers) MarkAllNotificationsReadSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := h.client.MarkAllNotificationsRead(); err != nil {
+		h.log(r).Warn("failed to mark notifications read", slog.String("error", err.Error()))
+	}
+	h.notificationsMarked(w, r)
+}
+
+func (h *Handlers) notificationsMarked(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("HX-Request") == "true" {
+		h.renderNotificationBell(w, r, true)
+		return
+	}
+	redirectTo := r.FormValue("redirect")
+	if !isLocalPath(redirectTo) {
+		redirectTo = "/notifications"
+	}
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// renderNotificationBell answers with the bell and the latest
+// notifications. When they can't be listed, the bell shows no count.
+func (h *Handlers) renderNotificationBell(w http.ResponseWriter, r *http.Request, open bool) {
+	list, err := h.client.ListNotifications(false, 1, notificationBellSize)
+	if err != nil {
+		h.log(r).Warn("failed to list notifications", slog.String("error", err.Error()))
+	}
+	stream := ""
+	if h.liveNotifications {
+		stream = "/notifications/stream"
+	}
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.NotificationBell(list, stream, open).Render(r.Context(), w)
+}
+
+// AnnouncementBanner answers with the announcements shown now, for the
+// layout to load above the navbar. When they can't be listed, none are shown.
+func (h *Handlers) AnnouncementBanner(w http.ResponseWriter, r *http.Request) {
+	signedIn := GetUserFromContext(r) != nil
+	announcements, err := h.client.ListAnnouncements(signedIn)
+	if err != nil {
+		h.log(r).Warn("failed to list announcements", slog.String("error", err.Error()))
+	}
+	w.Header().Set("Content-Type", "text/html")
+	_ = templates.AnnouncementBanner(announcements, signedIn).Render(r.Context(), w)
+}
+
+// DismissAnnouncementSubmit hides an announcement for the user. The banner
+// swaps the announcement for the empty response.
+func (h *Handlers) DismissAnnouncementSubmit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	err := h.client.DismissAnnouncement(id)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		h.log(r).Warn("failed to dismiss announcement", slog.String("announcement_id", id), slog.String("error", err.Error()))
+		http.Error(w, "Failed to dismiss announcement", http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// NotificationStream relays the user's notification events from the API as
+// server-sent events, until the browser or the API ends the stream.
+func (h *Handlers) NotificationStream(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.StreamNotifications(r.Context())
+	if err != nil {
+		h.log(r).Warn("failed to open notification stream", slog.String("error", err.Error()))
+		http.Error(w, "Notifications stream unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 // Profile renders the user profile page
 func (h *Handlers) Profile(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r)
@@ -211,25 +1031,468 @@ func (h *Handlers) Profile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The page still renders when the sessions can't be listed
+	sessions, err := h.client.ListSessions()
+	if err != nil {
+		h.log(r).Warn("failed to list sessions", slog.String("error", err.Error()))
+	}
+
+	// No pending deletion is a 404
+	deletion, err := h.client.GetAccountDeletion()
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		h.log(r).Warn("failed to get account deletion", slog.String("error", err.Error()))
+	}
+
+	// Without saved preferences the defaults are shown
+	prefs, err := h.client.GetPreferences()
+	if err != nil {
+		h.log(r).Warn("failed to get preferences", slog.String("error", err.Error()))
+		prefs = entities.Preferences{}
+	} else if getCookieValue(r, CookieTheme) != string(prefs.Theme()) {
+		h.auth.setThemeCookie(w, prefs.Theme())
+	}
+
 	data := map[string]interface{}{
-		"Title": "Profile",
-		"User":  user,
+		"Title":          "Profile",
+		"User":           user,
+		"ProfileMsg":     r.URL.Query().Get("profile"),
+		"AvatarMsg":      r.URL.Query().Get("avatar"),
+		"Preferences":    prefs,
+		"PreferencesMsg": r.URL.Query().Get("preferences"),
+		"EmailChange":    r.URL.Query().Get("email_change"),
+		"Sessions":       sessions,
+		"SessionsMsg":    r.URL.Query().Get("sessions"),
+		"Deletion":       deletion,
+		"DeletionMsg":    r.URL.Query().Get("deletion"),
 	}
 
 	if err := renderTemplate(w, "profile.templ", data); err != nil {
-		h.logger.Error("failed to render profile template", slog.String("error", err.Error()))
+		h.log(r).Error("failed to render profile template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// UpdateProfileSubmit saves the user's names, timezone and language
+func (h *Handlers) UpdateProfileSubmit(w http.ResponseWriter, r *http.Request) {
+	profile := entities.UserProfile{
+		FirstName:   r.FormValue("first_name"),
+		LastName:    r.FormValue("last_name"),
+		DisplayName: r.FormValue("display_name"),
+		Timezone:    r.FormValue("timezone"),
+		Locale:      r.FormValue("locale"),
+	}
+
+	if _, err := h.client.UpdateProfile(profile); err != nil {
+		h.log(r).Warn("profile update failed", slog.String("error", err.Error()))
+		msg := "failed"
+		if strings.Contains(err.Error(), "400") {
+			msg = "invalid"
+		}
+		http.Redirect(w, r, "/profile?profile="+msg, http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/profile?profile=saved", http.StatusSeeOther)
+}
+
+// UpdatePreferencesSubmit saves the theme and email choices. Unticked boxes
+// aren't posted, so both email options are always sent.
+func (h *Handlers) UpdatePreferencesSubmit(w http.ResponseWriter, r *http.Request) {
+	patch := map[string]any{
+		entities.PreferenceTheme:               r.FormValue("theme"),
+		entities.PreferenceEmailSecurityAlerts: r.FormValue("email_security_alerts") == "on",
+		entities.PreferenceEmailProductUpdates: r.FormValue("email_product_updates") == "on",
+	}
+
+	prefs, err := h.client.UpdatePreferences(patch)
+	if err != nil {
+		h.log(r).Warn("preferences update failed", slog.String("error", err.Error()))
+		msg := "failed"
+		if strings.Contains(err.Error(), "400") {
+			msg = "invalid"
+		}
+		http.Redirect(w, r, "/profile?preferences="+msg, http.StatusSeeOther)
+		return
+	}
+
+	h.auth.setThemeCookie(w, prefs.Theme())
+	http.Redirect(w, r, "/profile?preferences=saved", http.StatusSeeOther)
+}
+
+// avatarUploadLimit is a little over the API's 2 MiB avatar limit, leaving
+// room for the form around the image
+const avatarUploadLimit = 3 << 20
+
+// UploadAvatarSubmit passes the uploaded picture on to the API
+func (h *Handlers) UploadAvatarSubmit(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, avatarUploadLimit)
+	file, header, err := r.FormFile("avatar")
+	if err != nil {
+		msg := "missing"
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			msg = "too_large"
+		}
+		http.Redirect(w, r, "/profile?avatar="+msg, http.StatusSeeOther)
+		return
+	}
+	defer file.Close()
+
+	if _, err := h.client.UploadAvatar(header.Filename, file); err != nil {
+		h.log(r).Warn("avatar upload failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?avatar="+avatarErrorCode(err), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/profile?avatar=saved", http.StatusSeeOther)
+}
+
+// DeleteAvatarSubmit removes the user's picture
+func (h *Handlers) DeleteAvatarSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := h.client.DeleteAvatar(); err != nil {
+		h.log(r).Warn("avatar removal failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?avatar="+avatarErrorCode(err), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/profile?avatar=removed", http.StatusSeeOther)
+}
+
+func avatarErrorCode(err error) string {
+	switch {
+	case strings.Contains(err.Error(), "413"):
+		return "too_large"
+	case strings.Contains(err.Error(), "415"):
+		return "invalid_type"
+	case strings.Contains(err.Error(), "404"):
+		return "unavailable"
+	default:
+		return "failed"
+	}
+}
+
+// ChangeEmailSubmit asks the API to send a confirmation link to the new
+// address
+func (h *Handlers) ChangeEmailSubmit(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("new_email")
+	if email == "" {
+		http.Redirect(w, r, "/profile?email_change=missing_email", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.client.RequestEmailChange(email); err != nil {
+		h.log(r).Warn("email change request failed", slog.String("error", err.Error()))
+		errorType := "request_failed"
+		switch {
+		case strings.Contains(err.Error(), "409"):
+			errorType = "email_in_use"
+		case strings.Contains(err.Error(), "429"):
+			errorType = "too_soon"
+		case strings.Contains(err.Error(), "404"):
+			errorType = "unavailable"
+		case strings.Contains(err.Error(), "400") && strings.Contains(err.Error(), "same"):
+			errorType = "email_unchanged"
+		}
+		http.Redirect(w, r, "/profile?email_change="+errorType, http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/profile?email_change=sent", http.StatusSeeOther)
+}
+
+// RevokeSessionSubmit signs the user out of one of their other sessions
+func (h *Handlers) RevokeSessionSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := h.client.RevokeSession(chi.URLParam(r, "id")); err != nil {
+		h.log(r).Warn("session revocation failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?sessions=revoke_failed", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/profile?sessions=revoked", http.StatusSeeOther)
+}
+
+// RevokeOtherSessionsSubmit signs the user out everywhere but this browser
+func (h *Handlers) RevokeOtherSessionsSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := h.client.RevokeOtherSessions(); err != nil {
+		h.log(r).Warn("revoking other sessions failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?sessions=revoke_failed", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/profile?sessions=others_revoked", http.StatusSeeOther)
+}
+
+// RequestAccountDeletionSubmit schedules the user's account for deletion
+func (h *Handlers) RequestAccountDeletionSubmit(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.client.RequestAccountDeletion(); err != nil {
+		h.log(r).Warn("account deletion request failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?deletion=request_failed", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/profile?deletion=requested", http.StatusSeeOther)
+}
+
+// CancelAccountDeletionSubmit keeps the user's account
+func (h *Handlers) CancelAccountDeletionSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := h.client.CancelAccountDeletion(); err != nil {
+		h.log(r).Warn("account deletion cancel failed", slog.String("error", err.Error()))
+		http.Redirect(w, r, "/profile?deletion=cancel_failed", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/profile?deletion=canceled", http.StatusSeeOther)
+}
+
+// ConfirmEmailChangePage confirms the token in an email change link
+func (h *Handlers) ConfirmEmailChangePage(w http.ResponseWriter, r *http.Request) {
+	errorMsg := "invalid_token"
+	if token := r.URL.Query().Get("token"); token != "" {
+		err := h.client.ConfirmEmailChange(token)
+		switch {
+		case err == nil:
+			errorMsg = ""
+		case strings.Contains(err.Error(), "409"):
+			errorMsg = "email_in_use"
+		case !strings.Contains(err.Error(), "400"):
+			errorMsg = "confirm_failed"
+		}
+		if err != nil {
+			h.log(r).Warn("email change confirmation failed", slog.String("error", err.Error()))
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title": "Confirm Email Change",
+		"Error": errorMsg,
+		"Done":  errorMsg == "",
+	}
+
+	if err := renderTemplate(w, "confirm_email_change.templ", data); err != nil {
+		h.log(r).Error("failed to render confirm email change template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// oauthParams are the authorization request parameters the consent screen
+// posts back with the user's decision.
+var oauthParams = []string{
+	"response_type", "client_id", "redirect_uri", "scope", "state",
+	"nonce", "code_challenge", "code_challenge_method",
+}
+
+func oauthAuthorizeRequest(values url.Values) gweb.OAuthAuthorizeRequest {
+	return gweb.OAuthAuthorizeRequest{
+		ResponseType:        values.Get("response_type"),
+		ClientID:            values.Get("client_id"),
+		RedirectURI:         values.Get("redirect_uri"),
+		Scope:               values.Get("scope"),
+		State:               values.Get("state"),
+		Nonce:               values.Get("nonce"),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+		Prompt:              values.Get("prompt"),
+	}
+}
+
+// OAuthAuthorize is the OpenID Connect authorization endpoint. The signed-in
+// user is asked to allow the client application, unless they already did, and
+// sent back to it with an authorization code.
+func (h *Handlers) OAuthAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := oauthAuthorizeRequest(q)
+
+	prompt, err := h.client.OAuthConsent(req)
+	if err != nil {
+		h.log(r).Error("oauth consent lookup failed", slog.String("error", err.Error()), slog.String("client_id", req.ClientID))
+		http.Error(w, "Invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	if !prompt.Required {
+		h.oauthRedirect(w, r, req)
+		return
+	}
+
+	params := make([]templates.OAuthParam, 0, len(oauthParams))
+	for _, name := range oauthParams {
+		if q.Has(name) {
+			params = append(params, templates.OAuthParam{Name: name, Value: q.Get(name)})
+		}
+	}
+
+	data := map[string]interface{}{
+		"Consent": templates.OAuthConsentData{
+			ClientName: prompt.ClientName,
+			Scopes:     prompt.Scopes,
+			Params:     params,
+		},
+	}
+
+	if err := renderTemplate(w, "oauth_consent.templ", data); err != nil {
+		h.log(r).Error("failed to render oauth consent template", slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// OAuthAuthorizeSubmit handles the consent screen and sends the user back to
+// the client application with the outcome.
+func (h *Handlers) OAuthAuthorizeSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := oauthAuthorizeRequest(r.PostForm)
+	req.Decision = r.PostForm.Get("decision")
+	h.oauthRedirect(w, r, req)
+}
+
+func (h *Handlers) oauthRedirect(w http.ResponseWriter, r *http.Request, req gweb.OAuthAuthorizeRequest) {
+	redirectTo, err := h.client.OAuthAuthorize(req)
+	if err != nil {
+		h.log(r).Error("oauth authorization failed", slog.String("error", err.Error()), slog.String("client_id", req.ClientID))
+		http.Error(w, "Invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// SocialLogin sends the user to the social login provider. A random state
+// is kept in a cookie and checked on the callback to prevent login CSRF.
+func (h *Handlers) SocialLogin(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+
+	state, err := randomState()
+	if err != nil {
+		h.log(r).Error("failed to generate social login state", slog.String("error", err.Error()))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	authURL, err := h.client.SocialAuthURL(provider, state, h.socialRedirectURI(provider))
+	if err != nil {
+		h.log(r).Error("failed to start social login", slog.String("error", err.Error()), slog.String("provider", provider))
+		http.Redirect(w, r, "/login?error=social_failed", http.StatusSeeOther)
+		return
+	}
+
+	// Only local paths are kept, so the callback can't redirect off-site
+	value := state
+	if redirectTo := r.URL.Query().Get("redirect"); isLocalPath(redirectTo) {
+		value += "|" + redirectTo
 	}
+	h.auth.setSocialStateCookie(w, value)
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// SocialCallback completes a social login after the provider redirects back.
+func (h *Handlers) SocialCallback(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	q := r.URL.Query()
+
+	stored := getCookieValue(r, CookieSocialState)
+	h.auth.clearSocialStateCookie(w)
+
+	state, redirectTo, _ := strings.Cut(stored, "|")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(q.Get("state"))) != 1 {
+		h.log(r).Warn("social login state mismatch", slog.String("provider", provider))
+		http.Redirect(w, r, "/login?error=social_failed", http.StatusSeeOther)
+		return
+	}
+
+	// The user denied access or the provider failed
+	if q.Get("error") != "" || q.Get("code") == "" {
+		h.log(r).Warn("social login not completed", slog.String("provider", provider), slog.String("error", q.Get("error")))
+		http.Redirect(w, r, "/login?error=social_failed", http.StatusSeeOther)
+		return
+	}
+
+	resp, err := h.client.SocialLogin(provider, gweb.SocialLoginRequest{
+		Code:        q.Get("code"),
+		RedirectURI: h.socialRedirectURI(provider),
+		Audience:    jwt.AudienceWeb,
+	})
+	if err != nil {
+		h.log(r).Error("social login failed", slog.String("error", err.Error()), slog.String("provider", provider))
+		http.Redirect(w, r, "/login?error=social_failed", http.StatusSeeOther)
+		return
+	}
+
+	if resp.MFARequired {
+		h.auth.setMFATokenCookie(w, resp.MFAToken+"|"+redirectTo)
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+
+	h.log(r).Info("social login successful", slog.String("provider", provider), slog.String("user_id", resp.User.ID.String()))
+
+	h.auth.setAuthCookies(w, resp)
+
+	if redirectTo == "" {
+		redirectTo = "/dashboard"
+	}
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+func (h *Handlers) socialRedirectURI(provider string) string {
+	return h.baseURL + "/auth/" + url.PathEscape(provider) + "/callback"
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// isLocalPath reports whether p is a path on this site, not a URL elsewhere.
+func isLocalPath(p string) bool {
+	return strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "//") && !strings.HasPrefix(p, "/\\")
 }
 
 // Logout handles user logout
 func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	// Revoke the tokens so a copy of the cookies stops working too
+	token, refreshToken := getCookieValue(r, CookieToken), getCookieValue(r, CookieRefreshToken)
+	if token != "" || refreshToken != "" {
+		h.client.SetAuthToken(token)
+		if err := h.client.Logout(refreshToken); err != nil {
+			h.log(r).Warn("failed to revoke token on logout", slog.String("error", err.Error()))
+		}
+	}
+
 	// Clear auth cookies
 	h.auth.clearAuthCookies(w)
 
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
+// ImpersonateSubmit signs the browser in with the token the Admin app got to
+// impersonate a user. Only impersonation tokens are accepted, so the form
+// can't be used to plant a regular session. Any session the browser had is
+// dropped, so it doesn't come back through its refresh token.
+func (h *Handlers) ImpersonateSubmit(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	if token == "" {
+		http.Redirect(w, r, "/login?error=impersonation_failed", http.StatusSeeOther)
+		return
+	}
+
+	h.client.SetAuthToken(token)
+	user, err := h.client.GetCurrentUser()
+	if err != nil || user.ImpersonatedBy == "" {
+		if err != nil {
+			h.log(r).Warn("impersonation token rejected", slog.String("error", err.Error()))
+		}
+		http.Redirect(w, r, "/login?error=impersonation_failed", http.StatusSeeOther)
+		return
+	}
+
+	h.auth.clearAuthCookies(w)
+	h.auth.setAuthCookies(w, &gweb.AuthResponse{Token: token, User: *user})
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
 // DocsProxy proxies requests to the API service documentation
 func (h *Handlers) DocsProxy(w http.ResponseWriter, r *http.Request) {
 	// Extract the path after /docs
@@ -240,7 +1503,7 @@ func (h *Handlers) DocsProxy(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.client.ProxyDocsRequest(path)
 	if err != nil {
-		h.logger.Error("failed to proxy docs request", slog.String("error", err.Error()))
+		h.log(r).Error("failed to proxy docs request", slog.String("error", err.Error()))
 		http.Error(w, "Documentation temporarily unavailable", http.StatusServiceUnavailable)
 		return
 	}
@@ -258,7 +1521,7 @@ func (h *Handlers) DocsProxy(w http.ResponseWriter, r *http.Request) {
 
 	// Copy response body
 	if _, err := io.Copy(w, resp.Body); err != nil {
-		h.logger.Error("failed to copy response body", slog.String("error", err.Error()))
+		h.log(r).Error("failed to copy response body", slog.String("error", err.Error()))
 	}
 }
 
@@ -272,16 +1535,82 @@ func renderTemplate(w http.ResponseWriter, templateName string, data map[string]
 	case "login.templ":
 		errorMsg, _ := data["Error"].(string)
 		redirect, _ := data["Redirect"].(string)
-		return templates.Login(errorMsg, redirect).Render(context.Background(), w)
+		providers, _ := data["SocialProviders"].([]string)
+		captcha, _ := data["Captcha"].(templates.CaptchaData)
+		return templates.Login(errorMsg, redirect, providers, captcha).Render(context.Background(), w)
+	case "two_factor.templ":
+		errorMsg, _ := data["Error"].(string)
+		return templates.TwoFactor(errorMsg).Render(context.Background(), w)
 	case "register.templ":
 		errorMsg, _ := data["Error"].(string)
-		return templates.Register(errorMsg).Render(context.Background(), w)
+		invitation, _ := data["Invitation"].(string)
+		botFields, _ := data["BotFields"].(templates.BotFieldsData)
+		captcha, _ := data["Captcha"].(templates.CaptchaData)
+		return templates.Register(errorMsg, invitation, botFields, captcha).Render(context.Background(), w)
+	case "forgot_password.templ":
+		errorMsg, _ := data["Error"].(string)
+		sent, _ := data["Sent"].(bool)
+		botFields, _ := data["BotFields"].(templates.BotFieldsData)
+		return templates.ForgotPassword(errorMsg, sent, botFields).Render(context.Background(), w)
+	case "invitation.templ":
+		token, _ := data["Token"].(string)
+		errorMsg, _ := data["Error"].(string)
+		return templates.AcceptInvitation(token, errorMsg).Render(context.Background(), w)
+	case "reset_password.templ":
+		token, _ := data["Token"].(string)
+		errorMsg, _ := data["Error"].(string)
+		done, _ := data["Done"].(bool)
+		return templates.ResetPassword(token, errorMsg, done).Render(context.Background(), w)
+	case "register_pending.templ":
+		verify, _ := data["Verify"].(bool)
+		return templates.RegisterPending(verify).Render(context.Background(), w)
+	case "verify_email.templ":
+		errorMsg, _ := data["Error"].(string)
+		sent, _ := data["Sent"].(bool)
+		done, _ := data["Done"].(bool)
+		botFields, _ := data["BotFields"].(templates.BotFieldsData)
+		return templates.VerifyEmail(errorMsg, sent, done, botFields).Render(context.Background(), w)
+	case "confirm_email_change.templ":
+		errorMsg, _ := data["Error"].(string)
+		done, _ := data["Done"].(bool)
+		return templates.ConfirmEmailChange(errorMsg, done).Render(context.Background(), w)
+	case "search.templ":
+		user, _ := data["User"].(*entities.User)
+		query, _ := data["Query"].(string)
+		results, _ := data["Results"].(*entities.SearchResponse)
+		errorMsg, _ := data["Error"].(string)
+		return templates.Search(user, query, results, errorMsg).Render(context.Background(), w)
+	case "notifications.templ":
+		user, _ := data["User"].(*entities.User)
+		list, _ := data["Notifications"].(*entities.NotificationListResponse)
+		unreadOnly, _ := data["UnreadOnly"].(bool)
+		errorMsg, _ := data["Error"].(string)
+		return templates.Notifications(user, list, unreadOnly, errorMsg).Render(context.Background(), w)
+	case "examples.templ":
+		user, _ := data["User"].(*entities.User)
+		examples, _ := data["Examples"].([]entities.Example)
+		page, _ := data["Page"].(int)
+		totalPages, _ := data["TotalPages"].(int)
+		msg, _ := data["Msg"].(string)
+		return templates.Examples(user, examples, page, totalPages, msg).Render(context.Background(), w)
 	case "dashboard.templ":
 		user := data["User"]
 		return templates.Dashboard(user).Render(context.Background(), w)
 	case "profile.templ":
 		user := data["User"]
-		return templates.Profile(user).Render(context.Background(), w)
+		profileMsg, _ := data["ProfileMsg"].(string)
+		avatarMsg, _ := data["AvatarMsg"].(string)
+		prefs, _ := data["Preferences"].(entities.Preferences)
+		prefsMsg, _ := data["PreferencesMsg"].(string)
+		emailChange, _ := data["EmailChange"].(string)
+		sessions, _ := data["Sessions"].([]entities.SessionInfo)
+		sessionsMsg, _ := data["SessionsMsg"].(string)
+		deletion, _ := data["Deletion"].(*entities.DeletionRequest)
+		deletionMsg, _ := data["DeletionMsg"].(string)
+		return templates.Profile(user, profileMsg, avatarMsg, prefs, prefsMsg, emailChange, sessions, sessionsMsg, deletion, deletionMsg).Render(context.Background(), w)
+	case "oauth_consent.templ":
+		consent, _ := data["Consent"].(templates.OAuthConsentData)
+		return templates.OAuthConsent(consent).Render(context.Background(), w)
 	default:
 		http.Error(w, "Template not found", http.StatusNotFound)
 		return nil
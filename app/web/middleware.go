@@ -4,13 +4,20 @@ import (
 	"context"
 	"go-template/domain/entities"
 	gweb "go-template/gateways/web"
+	"go-template/internal/reqlog"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type contextKey string
 
 const userContextKey contextKey = "user"
 
+// refreshWindow is how long before the access token expires the session is
+// refreshed, so requests never carry a token that runs out on the way.
+const refreshWindow = time.Minute
+
 // AuthMiddleware handles user authentication for protected routes
 type AuthMiddleware struct {
 	client       *gweb.Client
@@ -32,9 +39,9 @@ func NewAuthMiddleware(client *gweb.Client, cookieSecure bool, cookieDomain stri
 // RequireAuth middleware that requires user authentication
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := getCookieValue(r, CookieToken)
+		token := m.sessionToken(w, r)
 		if token == "" {
-			http.Redirect(w, r, "/login?redirect="+r.URL.Path, http.StatusFound)
+			http.Redirect(w, r, "/login?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
 			return
 		}
 
@@ -45,20 +52,34 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			// Clear invalid token cookies
 			m.clearAuthCookies(w)
 
-			http.Redirect(w, r, "/login?error=session_expired&redirect="+r.URL.Path, http.StatusFound)
+			http.Redirect(w, r, "/login?error=session_expired&redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
 			return
 		}
 
 		// Add user to context
 		ctx := context.WithValue(r.Context(), userContextKey, user)
+		ctx = reqlog.WithUser(ctx, user.ID.String())
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// RequireAdmin middleware that requires an authenticated admin or super admin
+func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return m.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r)
+		if user == nil || (user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin) {
+			http.Error(w, "Access denied: admin privileges required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}))
+}
+
 // OptionalAuth middleware that adds user to context if authenticated, but doesn't require it
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := getCookieValue(r, CookieToken)
+		token := m.sessionToken(w, r)
 		if token != "" {
 			// Set token in client and try to get user
 			m.client.SetAuthToken(token)
@@ -66,6 +87,7 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 			if err == nil && user != nil {
 				// Add user to context if valid
 				ctx := context.WithValue(r.Context(), userContextKey, user)
+				ctx = reqlog.WithUser(ctx, user.ID.String())
 				r = r.WithContext(ctx)
 			} else {
 				// Clear invalid token cookies
@@ -77,6 +99,25 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	})
 }
 
+// sessionToken returns the access token to call the API with. When the token
+// is gone or about to expire and there is a refresh token, the session is
+// refreshed first and the new tokens are stored in the cookies.
+func (m *AuthMiddleware) sessionToken(w http.ResponseWriter, r *http.Request) string {
+	token := getCookieValue(r, CookieToken)
+	refreshToken := getCookieValue(r, CookieRefreshToken)
+	if refreshToken == "" || (token != "" && !tokenExpiresSoon(r)) {
+		return token
+	}
+
+	resp, err := m.client.RefreshSession(refreshToken)
+	if err != nil {
+		// Leave it to the API to decide whether the old token still works
+		return token
+	}
+	m.setAuthCookies(w, resp)
+	return resp.Token
+}
+
 // GetUserFromContext extracts the user from the request context
 func GetUserFromContext(r *http.Request) *entities.User {
 	if user, ok := r.Context().Value(userContextKey).(*entities.User); ok {
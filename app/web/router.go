@@ -4,9 +4,14 @@ import (
 	"go-template/app/web/docs"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"time"
 
 	gweb "go-template/gateways/web"
+	"go-template/internal/botdetect"
+	"go-template/internal/clientip"
+	"go-template/internal/metrics"
+	"go-template/internal/reqlog"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -14,6 +19,13 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
+// Documentation access modes
+const (
+	DocsPublic   = "public"
+	DocsAdmin    = "admin"
+	DocsDisabled = "disabled"
+)
+
 // Config holds the configuration for the web application
 type Config struct {
 	APIBaseURL     string
@@ -22,6 +34,30 @@ type Config struct {
 	CookieDomain   string
 	SessionTimeout int
 	StaticPath     string
+
+	// BaseURL is the public URL of the web app. Social login providers
+	// redirect back to it.
+	BaseURL string
+
+	// DocsMode is public, admin (admins only) or disabled. DocsAPIBaseURL is
+	// the API the specs send "Try it out" requests to.
+	DocsMode       string
+	DocsAPIBaseURL string
+
+	// Bot detection on public forms. A random form secret is used when
+	// BotFormSecret is empty.
+	BotFormSecret    string
+	BotMinSubmitTime time.Duration
+	BotMaxSubmitTime time.Duration
+	BotReputation    botdetect.ReputationChecker
+
+	// NotificationsLive streams notifications to the navbar's bell as they
+	// arrive, instead of showing them on the next page load.
+	NotificationsLive bool
+
+	// TrustedProxies forward the client's address in X-Forwarded-For,
+	// X-Real-IP or True-Client-IP. Other callers' headers are ignored.
+	TrustedProxies []netip.Prefix
 }
 
 // WebApp represents the web application
@@ -30,6 +66,7 @@ type WebApp struct {
 	client   *gweb.Client
 	handlers *Handlers
 	auth     *AuthMiddleware
+	bots     *botdetect.Detector
 	logger   *slog.Logger
 }
 
@@ -37,13 +74,29 @@ type WebApp struct {
 func New(config Config, logger *slog.Logger) *WebApp {
 	client := gweb.NewClient(config.APIBaseURL)
 	auth := NewAuthMiddleware(client, config.CookieSecure, config.CookieDomain, config.CookieMaxAge)
-	handlers := NewHandlers(client, logger, auth, config.StaticPath)
+
+	secret := []byte(config.BotFormSecret)
+	if len(secret) == 0 {
+		logger.Warn("bot form secret not set, using a random one; forms rendered before a restart or by other instances will be rejected")
+		secret = botdetect.RandomSecret()
+	}
+	bots := botdetect.New(botdetect.Config{
+		Secret:        secret,
+		RequireToken:  true,
+		MinSubmitTime: config.BotMinSubmitTime,
+		MaxSubmitTime: config.BotMaxSubmitTime,
+		Reputation:    config.BotReputation,
+	}, logger)
+
+	handlers := NewHandlers(client, logger, auth, bots, config.StaticPath, config.BaseURL)
+	handlers.liveNotifications = config.NotificationsLive
 
 	return &WebApp{
 		config:   config,
 		client:   client,
 		handlers: handlers,
 		auth:     auth,
+		bots:     bots,
 		logger:   logger,
 	}
 }
@@ -54,8 +107,8 @@ func (app *WebApp) Routes() chi.Router {
 
 	// Middleware stack
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(clientip.Middleware(app.config.TrustedProxies))
+	r.Use(reqlog.Middleware(app.logger))
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 	r.Use(middleware.Timeout(60 * time.Second))
@@ -81,18 +134,44 @@ func (app *WebApp) Routes() chi.Router {
 	// Authentication routes
 	r.Get("/login", app.handlers.LoginPage)
 	r.Post("/login", app.handlers.LoginSubmit)
+	r.Get("/login/2fa", app.handlers.TwoFactorPage)
+	r.Post("/login/2fa", app.handlers.TwoFactorSubmit)
 	r.Get("/register", app.handlers.RegisterPage)
-	r.Post("/register", app.handlers.RegisterSubmit)
+	r.With(app.bots.Middleware("register", app.handlers.BotBlocked)).Post("/register", app.handlers.RegisterSubmit)
+	r.Get("/register/pending", app.handlers.RegisterPendingPage)
+	r.Get("/forgot-password", app.handlers.ForgotPasswordPage)
+	r.With(app.bots.Middleware("forgot_password", app.handlers.ForgotPasswordBotBlocked)).Post("/forgot-password", app.handlers.ForgotPasswordSubmit)
+	r.Get("/reset-password", app.handlers.ResetPasswordPage)
+	r.Post("/reset-password", app.handlers.ResetPasswordSubmit)
+	r.Get("/accept-invitation", app.handlers.AcceptInvitationPage)
+	r.Post("/accept-invitation", app.handlers.AcceptInvitationSubmit)
+	r.Get("/verify-email", app.handlers.VerifyEmailPage)
+	r.With(app.bots.Middleware("verify_email", app.handlers.ResendVerificationBotBlocked)).Post("/verify-email/resend", app.handlers.ResendVerificationSubmit)
+	r.Get("/confirm-email", app.handlers.ConfirmEmailChangePage)
 	r.Post("/logout", app.handlers.Logout)
+	r.Post("/impersonate", app.handlers.ImpersonateSubmit)
+	r.Get("/auth/{provider}", app.handlers.SocialLogin)
+	r.Get("/auth/{provider}/callback", app.handlers.SocialCallback)
 
-	// Documentation routes (moved from service API)
-	docsHandler := docs.NewHandler()
-	r.Mount("/docs", docsHandler.Routes())
+	// Announcements banner, shown to everyone
+	r.Get("/announcements/banner", app.handlers.AnnouncementBanner)
 
-	// Generated Swagger UI (from code annotations) - now served locally
-	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("/docs/openapi-generated.json"),
-	))
+	// Documentation routes (moved from service API)
+	if app.config.DocsMode != DocsDisabled {
+		r.Group(func(r chi.Router) {
+			if app.config.DocsMode == DocsAdmin {
+				r.Use(app.auth.RequireAdmin)
+			}
+
+			docsHandler := docs.NewHandler(app.config.DocsAPIBaseURL)
+			r.Mount("/docs", docsHandler.Routes())
+
+			// Generated Swagger UI (from code annotations) - now served locally
+			r.Get("/swagger/*", httpSwagger.Handler(
+				httpSwagger.URL("/docs/openapi-generated.json"),
+			))
+		})
+	}
 
 	// Protected routes (require authentication)
 	r.Group(func(r chi.Router) {
@@ -100,13 +179,50 @@ func (app *WebApp) Routes() chi.Router {
 
 		// User dashboard and profile
 		r.Get("/dashboard", app.handlers.Dashboard)
+		r.Get("/search", app.handlers.Search)
+
+		// Examples, edited in place with HTMX
+		r.Get("/examples", app.handlers.Examples)
+		r.Post("/examples", app.handlers.CreateExampleSubmit)
+		r.Get("/examples/{id}/row", app.handlers.ExampleRow)
+		r.Get("/examples/{id}/edit", app.handlers.EditExample)
+		r.Post("/examples/{id}", app.handlers.UpdateExampleSubmit)
+		r.Post("/examples/{id}/delete", app.handlers.DeleteExampleSubmit)
+
+		// Notifications, with the navbar's bell loaded with HTMX
+		r.Get("/notifications", app.handlers.Notifications)
+		r.Get("/notifications/bell", app.handlers.NotificationBell)
+		r.Post("/notifications/read", app.handlers.MarkAllNotificationsReadSubmit)
+		r.Post("/notifications/{id}/read", app.handlers.MarkNotificationReadSubmit)
+		if app.config.NotificationsLive {
+			r.Get("/notifications/stream", app.handlers.NotificationStream)
+		}
+
+		r.Post("/announcements/{id}/dismiss", app.handlers.DismissAnnouncementSubmit)
+
 		r.Get("/profile", app.handlers.Profile)
+		r.Post("/profile", app.handlers.UpdateProfileSubmit)
+		r.Post("/profile/avatar", app.handlers.UploadAvatarSubmit)
+		r.Post("/profile/avatar/delete", app.handlers.DeleteAvatarSubmit)
+		r.Post("/profile/preferences", app.handlers.UpdatePreferencesSubmit)
+		r.Post("/profile/email", app.handlers.ChangeEmailSubmit)
+		r.Post("/profile/sessions/revoke-others", app.handlers.RevokeOtherSessionsSubmit)
+		r.Post("/profile/sessions/{id}/revoke", app.handlers.RevokeSessionSubmit)
+		r.Post("/profile/deletion", app.handlers.RequestAccountDeletionSubmit)
+		r.Post("/profile/deletion/cancel", app.handlers.CancelAccountDeletionSubmit)
+
+		// OpenID Connect authorization endpoint
+		r.Get("/oauth2/authorize", app.handlers.OAuthAuthorize)
+		r.Post("/oauth2/authorize", app.handlers.OAuthAuthorizeSubmit)
 
 		// Additional protected routes can be added here
 		// r.Get("/settings", app.handlers.Settings)
 		// r.Get("/help", app.handlers.Help)
 	})
 
+	// Prometheus metrics (blocked form submissions)
+	r.Handle("/metrics", metrics.Handler())
+
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
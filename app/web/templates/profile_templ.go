@@ -10,7 +10,7 @@ import templruntime "github.com/a-h/templ/runtime"
 
 import "go-template/domain/entities"
 
-func Profile(user interface{}) templ.Component {
+func Profile(user interface{}, profileMsg string, avatarMsg string, prefs entities.Preferences, prefsMsg string, emailChange string, sessions []entities.SessionInfo, sessionsMsg string, deletion *entities.DeletionRequest, deletionMsg string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -43,85 +43,531 @@ func Profile(user interface{}) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-3xl mx-auto px-4 sm:px-6 lg:px-8 py-8\"><!-- Header --><div class=\"mb-8\"><h1 class=\"text-2xl font-bold text-gray-900 sm:text-3xl\">Profile Settings</h1><p class=\"mt-2 text-gray-600\">Manage your account information and preferences.</p></div><!-- Profile Information --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Personal Information</h3><form class=\"space-y-6\" method=\"POST\" action=\"/profile\"><div class=\"grid grid-cols-1 gap-6 sm:grid-cols-2\"><div class=\"sm:col-span-2\"><label for=\"email\" class=\"block text-sm font-medium text-gray-700\">Email address</label><div class=\"mt-1 relative\"><input type=\"email\" name=\"email\" id=\"email\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-3xl mx-auto px-4 sm:px-6 lg:px-8 py-8\"><!-- Header --><div class=\"mb-8\"><h1 class=\"text-2xl font-bold text-gray-900 sm:text-3xl\">Profile Settings</h1><p class=\"mt-2 text-gray-600\">Manage your account information and preferences.</p></div><!-- Profile Information --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Personal Information</h3><!-- Avatar --><div class=\"mb-6\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			switch avatarMsg {
+			case "":
+			case "saved":
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">Your picture has been updated.</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			case "removed":
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">Your picture has been removed.</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			default:
+				templ_7745c5c3_Err = ErrorAlert(getAvatarErrorMessage(avatarMsg)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<div class=\"flex items-center space-x-4\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = Avatar(user.(*entities.User), "h-16 w-16 text-2xl").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<form method=\"POST\" action=\"/profile/avatar\" enctype=\"multipart/form-data\" class=\"flex items-center space-x-3\"><input type=\"file\" name=\"avatar\" accept=\"image/png,image/jpeg,image/gif,image/webp\" required class=\"block text-sm text-gray-700 file:mr-3 file:py-2 file:px-3 file:rounded-md file:border-0 file:text-sm file:font-medium file:bg-brand-50 file:text-brand-700 hover:file:bg-brand-100\"> <button type=\"submit\" class=\"bg-white border border-gray-300 rounded-md shadow-sm py-2 px-3 text-sm font-medium text-gray-700 hover:bg-gray-50\">Upload</button></form>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if user.(*entities.User).AvatarURL != "" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<form method=\"POST\" action=\"/profile/avatar/delete\"><button type=\"submit\" class=\"text-sm font-medium text-red-600 hover:text-red-500\">Remove</button></form>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div><p class=\"mt-2 text-xs text-gray-500\">PNG, JPEG, GIF or WebP, up to 2 MB.</p></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if profileMsg == "saved" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">Your profile has been saved.</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else if profileMsg != "" {
+				templ_7745c5c3_Err = ErrorAlert(getProfileErrorMessage(profileMsg)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "<form class=\"space-y-6\" method=\"POST\" action=\"/profile\"><div class=\"grid grid-cols-1 gap-6 sm:grid-cols-2\"><div><label for=\"first_name\" class=\"block text-sm font-medium text-gray-700\">First name</label><div class=\"mt-1\"><input type=\"text\" name=\"first_name\" id=\"first_name\" autocomplete=\"given-name\" maxlength=\"100\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var3 string
-			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).Email)
+			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).FirstName)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 32, Col: 45}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 83, Col: 49}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled><div class=\"absolute inset-y-0 right-0 pr-3 flex items-center\"><svg class=\"h-5 w-5 text-gray-400\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M5 9V7a5 5 0 0110 0v2a2 2 0 012 2v5a2 2 0 01-2 2H5a2 2 0 01-2-2v-5a2 2 0 012-2zm8-2v2H7V7a3 3 0 016 0z\" clip-rule=\"evenodd\"></path></svg></div></div><p class=\"mt-1 text-xs text-gray-500\">Email cannot be changed for security reasons.</p></div><div><label for=\"account_type\" class=\"block text-sm font-medium text-gray-700\">Account Type</label><div class=\"mt-1\"><input type=\"text\" name=\"account_type\" id=\"account_type\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div></div><div><label for=\"last_name\" class=\"block text-sm font-medium text-gray-700\">Last name</label><div class=\"mt-1\"><input type=\"text\" name=\"last_name\" id=\"last_name\" autocomplete=\"family-name\" maxlength=\"100\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var4 string
-			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(string(user.(*entities.User).AccountType))
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).LastName)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 53, Col: 59}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 99, Col: 48}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div><p class=\"mt-1 text-xs text-gray-500\">Contact support to change account type.</p></div><div><label for=\"auth_provider\" class=\"block text-sm font-medium text-gray-700\">Authentication Provider</label><div class=\"mt-1\"><input type=\"text\" name=\"auth_provider\" id=\"auth_provider\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div></div><div class=\"sm:col-span-2\"><label for=\"display_name\" class=\"block text-sm font-medium text-gray-700\">Display name</label><div class=\"mt-1\"><input type=\"text\" name=\"display_name\" id=\"display_name\" autocomplete=\"nickname\" maxlength=\"100\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var5 string
-			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).AuthProvider)
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).DisplayName)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 69, Col: 52}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 115, Col: 51}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div></div><div><label for=\"created_at\" class=\"block text-sm font-medium text-gray-700\">Member Since</label><div class=\"mt-1\"><input type=\"text\" name=\"created_at\" id=\"created_at\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-1 text-xs text-gray-500\">How we greet you. Your full name is used when left empty.</p></div><div><label for=\"timezone\" class=\"block text-sm font-medium text-gray-700\">Timezone</label><div class=\"mt-1\"><input type=\"text\" name=\"timezone\" id=\"timezone\" placeholder=\"Europe/Lisbon\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var6 string
-			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).CreatedAt.Format("January 2, 2006"))
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).Timezone)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 84, Col: 75}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 131, Col: 48}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div></div><div><label for=\"user_id\" class=\"block text-sm font-medium text-gray-700\">User ID</label><div class=\"mt-1 relative\"><input type=\"text\" name=\"user_id\" id=\"user_id\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-1 text-xs text-gray-500\"><button type=\"button\" onclick=\"document.getElementById('timezone').value = Intl.DateTimeFormat().resolvedOptions().timeZone\" class=\"text-brand-600 hover:text-brand-500\">Use this device's timezone</button></p></div><div><label for=\"locale\" class=\"block text-sm font-medium text-gray-700\">Language</label><div class=\"mt-1\"><input type=\"text\" name=\"locale\" id=\"locale\" placeholder=\"en-US\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var7 string
-			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).ID.String())
+			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).Locale)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 99, Col: 51}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 149, Col: 46}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50 font-mono text-xs\" disabled> <button type=\"button\" onclick=\"copyToClipboard(this.previousElementSibling.value)\" class=\"absolute inset-y-0 right-0 pr-3 flex items-center text-gray-400 hover:text-gray-600\"><svg class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M8 16H6a2 2 0 01-2-2V6a2 2 0 012-2h8a2 2 0 012 2v2m-6 12h8a2 2 0 002-2v-8a2 2 0 00-2-2h-8a2 2 0 00-2 2v8a2 2 0 002 2z\"></path></svg></button></div><p class=\"mt-1 text-xs text-gray-500\">Click the copy button to copy to clipboard.</p></div></div></form></div></div><!-- Security Section --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Security</h3><div class=\"space-y-6\"><div class=\"flex items-start justify-between\"><div class=\"flex-1\"><h4 class=\"text-sm font-medium text-gray-900\">Password</h4><p class=\"text-sm text-gray-500 mt-1\">Your password is managed through ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div><p class=\"mt-1 text-xs text-gray-500\">A language tag, such as en-US or pt-BR.</p></div><div class=\"sm:col-span-2\"><label for=\"email\" class=\"block text-sm font-medium text-gray-700\">Email address</label><div class=\"mt-1 relative\"><input type=\"email\" name=\"email\" id=\"email\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var8 string
-			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).AuthProvider)
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).Email)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 128, Col: 78}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 164, Col: 45}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, ".  To change your password, please visit their platform.</p></div><button type=\"button\" disabled class=\"ml-5 bg-gray-100 border border-gray-300 rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-gray-400 cursor-not-allowed\">Managed Externally</button></div><div class=\"border-t border-gray-200 pt-6\"><div class=\"flex items-start justify-between\"><div class=\"flex-1\"><h4 class=\"text-sm font-medium text-gray-900\">Account Deletion</h4><p class=\"text-sm text-gray-500 mt-1\">Permanently delete your account and all associated data. This action cannot be undone.</p></div><button type=\"button\" onclick=\"confirmAccountDeletion()\" class=\"ml-5 bg-red-600 border border-transparent rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-white hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-red-500\">Delete Account</button></div></div></div></div></div><!-- API Access --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">API Access</h3><div class=\"space-y-4\"><div><p class=\"text-sm text-gray-500\">Use these resources to integrate with our API:</p></div><div class=\"grid grid-cols-1 gap-3 sm:grid-cols-2\"><a href=\"/docs\" class=\"relative block p-3 bg-gray-50 rounded-lg hover:bg-gray-100 transition-colors\"><div class=\"flex items-start\"><div class=\"flex-shrink-0\"><svg class=\"h-5 w-5 text-brand-500\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12h6m-6 4h6m2 5H7a2 2 0 01-2-2V5a2 2 0 012-2h5.586a1 1 0 01.707.293l5.414 5.414a1 1 0 01.293.707V19a2 2 0 01-2 2z\"></path></svg></div><div class=\"ml-3\"><p class=\"text-sm font-medium text-gray-900\">API Documentation</p><p class=\"text-sm text-gray-500\">Complete API reference</p></div></div></a> <a href=\"/docs/swagger-ui.html\" class=\"relative block p-3 bg-gray-50 rounded-lg hover:bg-gray-100 transition-colors\"><div class=\"flex items-start\"><div class=\"flex-shrink-0\"><svg class=\"h-5 w-5 text-brand-500\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M14.828 14.828a4 4 0 01-5.656 0M9 10h1.586a1 1 0 01.707.293l2.414 2.414a1 1 0 00.707.293H15M13 16h-3a2 2 0 01-2-2V9a2 2 0 012-2h3m7 11V8a2 2 0 00-2-2h-4l-2-2H9a2 2 0 00-2 2v11a2 2 0 002 2h10a2 2 0 002-2z\"></path></svg></div><div class=\"ml-3\"><p class=\"text-sm font-medium text-gray-900\">Interactive API</p><p class=\"text-sm text-gray-500\">Test endpoints directly</p></div></div></a></div></div></div></div></div><!-- Account Deletion Modal --> <div id=\"deleteModal\" class=\"hidden fixed inset-0 bg-gray-600 bg-opacity-50 overflow-y-auto h-full w-full z-50\"><div class=\"relative top-20 mx-auto p-5 border w-96 shadow-lg rounded-md bg-white\"><div class=\"mt-3 text-center\"><div class=\"mx-auto flex items-center justify-center h-12 w-12 rounded-full bg-red-100\"><svg class=\"h-6 w-6 text-red-600\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M12 9v2m0 4h.01m-6.938 4h13.856c1.54 0 2.502-1.667 1.732-2.5L13.732 4c-.77-.833-1.964-.833-2.732 0L3.732 16.5c-.77.833.192 2.5 1.732 2.5z\"></path></svg></div><h3 class=\"text-lg font-medium text-gray-900 mt-5\">Delete Account</h3><div class=\"mt-2 px-7 py-3\"><p class=\"text-sm text-gray-500\">Are you sure you want to delete your account? This action cannot be undone and all your data will be permanently removed.</p></div><div class=\"items-center px-4 py-3\"><button id=\"confirmDelete\" class=\"px-4 py-2 bg-red-600 text-white text-base font-medium rounded-md shadow-sm hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-red-500 mr-2\">Delete Account</button> <button onclick=\"closeDeleteModal()\" class=\"px-4 py-2 bg-gray-300 text-gray-800 text-base font-medium rounded-md shadow-sm hover:bg-gray-400 focus:outline-none focus:ring-2 focus:ring-gray-300\">Cancel</button></div></div></div></div><script>\n\t\t\tfunction copyToClipboard(text) {\n\t\t\t\tnavigator.clipboard.writeText(text).then(function() {\n\t\t\t\t\t// You could add a toast notification here\n\t\t\t\t\talert('Copied to clipboard!');\n\t\t\t\t}).catch(function(err) {\n\t\t\t\t\tconsole.error('Failed to copy: ', err);\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction confirmAccountDeletion() {\n\t\t\t\tdocument.getElementById('deleteModal').classList.remove('hidden');\n\t\t\t}\n\n\t\t\tfunction closeDeleteModal() {\n\t\t\t\tdocument.getElementById('deleteModal').classList.add('hidden');\n\t\t\t}\n\n\t\t\t// Add event listener for confirm delete (you would implement the actual deletion logic)\n\t\t\tdocument.getElementById('confirmDelete').addEventListener('click', function() {\n\t\t\t\t// Implement account deletion logic here\n\t\t\t\talert('Account deletion would be implemented here');\n\t\t\t\tcloseDeleteModal();\n\t\t\t});\n\n\t\t\t// Close modal when clicking outside\n\t\t\tdocument.getElementById('deleteModal').addEventListener('click', function(e) {\n\t\t\t\tif (e.target === this) {\n\t\t\t\t\tcloseDeleteModal();\n\t\t\t\t}\n\t\t\t});\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled><div class=\"absolute inset-y-0 right-0 pr-3 flex items-center\"><svg class=\"h-5 w-5 text-gray-400\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M5 9V7a5 5 0 0110 0v2a2 2 0 012 2v5a2 2 0 01-2 2H5a2 2 0 01-2-2v-5a2 2 0 012-2zm8-2v2H7V7a3 3 0 016 0z\" clip-rule=\"evenodd\"></path></svg></div></div><p class=\"mt-1 text-xs text-gray-500\">Use the form below to change your email.</p></div><div><label for=\"account_type\" class=\"block text-sm font-medium text-gray-700\">Account Type</label><div class=\"mt-1\"><input type=\"text\" name=\"account_type\" id=\"account_type\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var9 string
+			templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(string(user.(*entities.User).AccountType))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 185, Col: 59}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div><p class=\"mt-1 text-xs text-gray-500\">Contact support to change account type.</p></div><div><label for=\"auth_provider\" class=\"block text-sm font-medium text-gray-700\">Authentication Provider</label><div class=\"mt-1\"><input type=\"text\" name=\"auth_provider\" id=\"auth_provider\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var10 string
+			templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).AuthProvider)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 201, Col: 52}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div></div><div><label for=\"created_at\" class=\"block text-sm font-medium text-gray-700\">Member Since</label><div class=\"mt-1\"><input type=\"text\" name=\"created_at\" id=\"created_at\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var11 string
+			templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).CreatedAt.Format("January 2, 2006"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 216, Col: 75}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50\" disabled></div></div><div><label for=\"user_id\" class=\"block text-sm font-medium text-gray-700\">User ID</label><div class=\"mt-1 relative\"><input type=\"text\" name=\"user_id\" id=\"user_id\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var12 string
+			templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).ID.String())
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 231, Col: 51}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md bg-gray-50 font-mono text-xs\" disabled> <button type=\"button\" onclick=\"copyToClipboard(this.previousElementSibling.value)\" class=\"absolute inset-y-0 right-0 pr-3 flex items-center text-gray-400 hover:text-gray-600\"><svg class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M8 16H6a2 2 0 01-2-2V6a2 2 0 012-2h8a2 2 0 012 2v2m-6 12h8a2 2 0 002-2v-8a2 2 0 00-2-2h-8a2 2 0 00-2 2v8a2 2 0 002 2z\"></path></svg></button></div><p class=\"mt-1 text-xs text-gray-500\">Click the copy button to copy to clipboard.</p></div></div><div class=\"flex justify-end\"><button type=\"submit\" class=\"bg-brand-600 border border-transparent rounded-md shadow-sm py-2 px-4 text-sm font-medium text-white hover:bg-brand-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500\">Save profile</button></div></form></div></div><!-- Preferences --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Preferences</h3>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefsMsg == "saved" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">Your preferences have been saved.</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else if prefsMsg != "" {
+				templ_7745c5c3_Err = ErrorAlert(getPreferencesErrorMessage(prefsMsg)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "<form class=\"space-y-6\" method=\"POST\" action=\"/profile/preferences\"><div><label for=\"theme\" class=\"block text-sm font-medium text-gray-700\">Theme</label><div class=\"mt-1\"><select name=\"theme\" id=\"theme\" class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:max-w-xs sm:text-sm border-gray-300 rounded-md\"><option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var13 string
+			templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(string(entities.ThemeLight))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 281, Col: 52}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefs.Theme() == entities.ThemeLight {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, ">Light</option> <option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var14 string
+			templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(string(entities.ThemeDark))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 282, Col: 51}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefs.Theme() == entities.ThemeDark {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, ">Dark</option> <option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var15 string
+			templ_7745c5c3_Var15, templ_7745c5c3_Err = templ.JoinStringErrs(string(entities.ThemeSystem))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 283, Col: 53}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var15))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefs.Theme() == entities.ThemeSystem {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, " selected")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, ">Same as this device</option></select></div></div><fieldset><legend class=\"block text-sm font-medium text-gray-700\">Email me about</legend><div class=\"mt-2 space-y-2\"><label class=\"flex items-center text-sm text-gray-700\"><input type=\"checkbox\" name=\"email_security_alerts\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefs.EmailSecurityAlerts() {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, " class=\"h-4 w-4 text-brand-600 focus:ring-brand-500 border-gray-300 rounded\"> <span class=\"ml-2\">Security alerts, such as new sign-ins</span></label> <label class=\"flex items-center text-sm text-gray-700\"><input type=\"checkbox\" name=\"email_product_updates\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if prefs.EmailProductUpdates() {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, " checked")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, " class=\"h-4 w-4 text-brand-600 focus:ring-brand-500 border-gray-300 rounded\"> <span class=\"ml-2\">Product news and updates</span></label></div></fieldset><div class=\"flex justify-end\"><button type=\"submit\" class=\"bg-brand-600 border border-transparent rounded-md shadow-sm py-2 px-4 text-sm font-medium text-white hover:bg-brand-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500\">Save preferences</button></div></form></div></div><!-- Change Email --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Change Email</h3>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if emailChange == "sent" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">We sent a confirmation link to your new address. Your email changes once you open it.</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else if emailChange != "" {
+				templ_7745c5c3_Err = ErrorAlert(getEmailChangeErrorMessage(emailChange)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "<form class=\"sm:flex sm:items-end sm:space-x-4\" method=\"POST\" action=\"/profile/email\"><div class=\"flex-1\"><label for=\"new_email\" class=\"block text-sm font-medium text-gray-700\">New email address</label><div class=\"mt-1\"><input type=\"email\" name=\"new_email\" id=\"new_email\" autocomplete=\"email\" required class=\"shadow-sm focus:ring-brand-500 focus:border-brand-500 block w-full sm:text-sm border-gray-300 rounded-md\"></div></div><button type=\"submit\" class=\"mt-3 sm:mt-0 bg-brand-600 border border-transparent rounded-md shadow-sm py-2 px-4 text-sm font-medium text-white hover:bg-brand-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500\">Send confirmation link</button></form></div></div><!-- Security Section --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">Security</h3><div class=\"space-y-6\"><div class=\"flex items-start justify-between\"><div class=\"flex-1\"><h4 class=\"text-sm font-medium text-gray-900\">Password</h4><p class=\"text-sm text-gray-500 mt-1\">Your password is managed through ")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var16 string
+			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(user.(*entities.User).AuthProvider)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 370, Col: 78}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, ".  To change your password, please visit their platform.</p></div><button type=\"button\" disabled class=\"ml-5 bg-gray-100 border border-gray-300 rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-gray-400 cursor-not-allowed\">Managed Externally</button></div><div class=\"border-t border-gray-200 pt-6\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if deletionMsg == "requested" || deletionMsg == "canceled" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var17 string
+				templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(getDeletionMessage(deletionMsg))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 386, Col: 43}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else if deletionMsg != "" {
+				templ_7745c5c3_Err = ErrorAlert(getDeletionMessage(deletionMsg)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "<div class=\"flex items-start justify-between\"><div class=\"flex-1\"><h4 class=\"text-sm font-medium text-gray-900\">Account Deletion</h4>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if deletion != nil {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "<p class=\"text-sm text-red-700 mt-1\">Your account will be deleted on ")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var18 string
+				templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(deletion.ScheduledFor.Format("January 2, 2006 15:04"))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 397, Col: 98}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, ". Until then you can change your mind and keep it.</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "<p class=\"text-sm text-gray-500 mt-1\">Delete your account after a grace period, during which you can still sign in and cancel. Once deleted, your personal data is removed and your activity is anonymized.</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if deletion != nil {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "<form method=\"POST\" action=\"/profile/deletion/cancel\"><button type=\"submit\" class=\"ml-5 bg-white border border-gray-300 rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-gray-700 hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500\">Keep My Account</button></form>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, "<button type=\"button\" onclick=\"confirmAccountDeletion()\" class=\"ml-5 bg-red-600 border border-transparent rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-white hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-red-500\">Delete Account</button>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "</div></div></div></div></div><!-- Sessions --><div class=\"bg-white shadow rounded-lg mb-8\"><div class=\"px-4 py-5 sm:p-6\"><div class=\"flex items-start justify-between mb-4\"><div><h3 class=\"text-lg leading-6 font-medium text-gray-900\">Sessions</h3><p class=\"text-sm text-gray-500 mt-1\">Devices signed in to your account. Sign out any you don't recognize.</p></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if len(sessions) > 1 {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "<form method=\"POST\" action=\"/profile/sessions/revoke-others\"><button type=\"submit\" class=\"ml-5 bg-white border border-gray-300 rounded-md shadow-sm py-2 px-3 text-sm leading-4 font-medium text-gray-700 hover:bg-gray-50 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500\">Sign out other sessions</button></form>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if sessionsMsg == "revoked" || sessionsMsg == "others_revoked" {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "<div class=\"mb-4 rounded-md bg-green-50 p-4\"><p class=\"text-sm font-medium text-green-800\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var19 string
+				templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(getSessionsMessage(sessionsMsg))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 453, Col: 41}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, "</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else if sessionsMsg != "" {
+				templ_7745c5c3_Err = ErrorAlert(getSessionsMessage(sessionsMsg)).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, "<ul class=\"divide-y divide-gray-200\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			for _, session := range sessions {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "<li class=\"py-4 flex items-center justify-between\"><div><p class=\"text-sm font-medium text-gray-900\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var20 string
+				templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(session.Device)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 465, Col: 26}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, " ")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				if session.Current {
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "<span class=\"ml-2 inline-flex items-center px-2 py-0.5 rounded text-xs font-medium bg-green-100 text-green-800\">This device</span>")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "</p><p class=\"text-sm text-gray-500\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				if session.IPAddress != "" {
+					var templ_7745c5c3_Var21 string
+					templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(session.IPAddress)
+					if templ_7745c5c3_Err != nil {
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 472, Col: 30}
+					}
+					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, " ·  ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, "Last active ")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var22 string
+				templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(session.LastSeenAt.Format("January 2, 2006 15:04"))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 474, Col: 74}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				if !session.Current {
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "<form method=\"POST\" action=\"")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					var templ_7745c5c3_Var23 templ.SafeURL
+					templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/profile/sessions/" + session.ID + "/revoke"))
+					if templ_7745c5c3_Err != nil {
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `app/web/templates/profile.templ`, Line: 478, Col: 98}
+					}
+					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "\"><button type=\"submit\" class=\"text-sm font-medium text-red-600 hover:text-red-500\">Sign out</button></form>")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "</li>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "</ul>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if len(sessions) == 0 {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "<p class=\"text-sm text-gray-500\">Your sessions can't be shown right now.</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "</div></div><!-- API Access --><div class=\"bg-white shadow rounded-lg\"><div class=\"px-4 py-5 sm:p-6\"><h3 class=\"text-lg leading-6 font-medium text-gray-900 mb-4\">API Access</h3><div class=\"space-y-4\"><div><p class=\"text-sm text-gray-500\">Use these resources to integrate with our API:</p></div><div class=\"grid grid-cols-1 gap-3 sm:grid-cols-2\"><a href=\"/docs\" class=\"relative block p-3 bg-gray-50 rounded-lg hover:bg-gray-100 transition-colors\"><div class=\"flex items-start\"><div class=\"flex-shrink-0\"><svg class=\"h-5 w-5 text-brand-500\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12h6m-6 4h6m2 5H7a2 2 0 01-2-2V5a2 2 0 012-2h5.586a1 1 0 01.707.293l5.414 5.414a1 1 0 01.293.707V19a2 2 0 01-2 2z\"></path></svg></div><div class=\"ml-3\"><p class=\"text-sm font-medium text-gray-900\">API Documentation</p><p class=\"text-sm text-gray-500\">Complete API reference</p></div></div></a> <a href=\"/docs/swagger-ui.html\" class=\"relative block p-3 bg-gray-50 rounded-lg hover:bg-gray-100 transition-colors\"><div class=\"flex items-start\"><div class=\"flex-shrink-0\"><svg class=\"h-5 w-5 text-brand-500\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M14.828 14.828a4 4 0 01-5.656 0M9 10h1.586a1 1 0 01.707.293l2.414 2.414a1 1 0 00.707.293H15M13 16h-3a2 2 0 01-2-2V9a2 2 0 012-2h3m7 11V8a2 2 0 00-2-2h-4l-2-2H9a2 2 0 00-2 2v11a2 2 0 002 2h10a2 2 0 002-2z\"></path></svg></div><div class=\"ml-3\"><p class=\"text-sm font-medium text-gray-900\">Interactive API</p><p class=\"text-sm text-gray-500\">Test endpoints directly</p></div></div></a></div></div></div></div></div><!-- Account Deletion Modal --> <div id=\"deleteModal\" class=\"hidden fixed inset-0 bg-gray-600 bg-opacity-50 overflow-y-auto h-full w-full z-50\"><div class=\"relative top-20 mx-auto p-5 border w-96 shadow-lg rounded-md bg-white\"><div class=\"mt-3 text-center\"><div class=\"mx-auto flex items-center justify-center h-12 w-12 rounded-full bg-red-100\"><svg class=\"h-6 w-6 text-red-600\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M12 9v2m0 4h.01m-6.938 4h13.856c1.54 0 2.502-1.667 1.732-2.5L13.732 4c-.77-.833-1.964-.833-2.732 0L3.732 16.5c-.77.833.192 2.5 1.732 2.5z\"></path></svg></div><h3 class=\"text-lg font-medium text-gray-900 mt-5\">Delete Account</h3><div class=\"mt-2 px-7 py-3\"><p class=\"text-sm text-gray-500\">Are you sure you want to delete your account? It will be deleted when the grace period ends, and can't be recovered after that.</p></div><form class=\"items-center px-4 py-3\" method=\"POST\" action=\"/profile/deletion\"><button type=\"submit\" class=\"px-4 py-2 bg-red-600 text-white text-base font-medium rounded-md shadow-sm hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-red-500 mr-2\">Delete Account</button> <button type=\"button\" onclick=\"closeDeleteModal()\" class=\"px-4 py-2 bg-gray-300 text-gray-800 text-base font-medium rounded-md shadow-sm hover:bg-gray-400 focus:outline-none focus:ring-2 focus:ring-gray-300\">Cancel</button></form></div></div></div><script>\n\t\t\tfunction copyToClipboard(text) {\n\t\t\t\tnavigator.clipboard.writeText(text).then(function() {\n\t\t\t\t\t// You could add a toast notification here\n\t\t\t\t\talert('Copied to clipboard!');\n\t\t\t\t}).catch(function(err) {\n\t\t\t\t\tconsole.error('Failed to copy: ', err);\n\t\t\t\t});\n\t\t\t}\n\n\t\t\tfunction confirmAccountDeletion() {\n\t\t\t\tdocument.getElementById('deleteModal').classList.remove('hidden');\n\t\t\t}\n\n\t\t\tfunction closeDeleteModal() {\n\t\t\t\tdocument.getElementById('deleteModal').classList.add('hidden');\n\t\t\t}\n\n\t\t\t// Close modal when clicking outside\n\t\t\tdocument.getElementById('deleteModal').addEventListener('click', function(e) {\n\t\t\t\tif (e.target === this) {\n\t\t\t\t\tcloseDeleteModal();\n\t\t\t\t}\n\t\t\t});\n\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -135,4 +581,61 @@ func Profile(user interface{}) templ.Component {
 	})
 }
 
+func getAvatarErrorMessage(msg string) string {
+	switch msg {
+	case "missing":
+		return "Choose a picture to upload."
+	case "too_large":
+		return "That picture is too large. Pictures can be up to 2 MB."
+	case "invalid_type":
+		return "Pictures must be PNG, JPEG, GIF or WebP images."
+	case "unavailable":
+		return "Profile pictures are not available."
+	default:
+		return "Your picture could not be updated. Please try again."
+	}
+}
+
+func getProfileErrorMessage(msg string) string {
+	switch msg {
+	case "invalid":
+		return "Check your names, timezone and language, then try again."
+	default:
+		return "Your profile could not be saved. Please try again."
+	}
+}
+
+func getPreferencesErrorMessage(msg string) string {
+	switch msg {
+	case "invalid":
+		return "Those preferences could not be saved. Check your choices and try again."
+	default:
+		return "Your preferences could not be saved. Please try again."
+	}
+}
+
+func getDeletionMessage(msg string) string {
+	switch msg {
+	case "requested":
+		return "Your account is scheduled for deletion."
+	case "canceled":
+		return "Your account will not be deleted."
+	case "cancel_failed":
+		return "The deletion could not be canceled. Please try again."
+	default:
+		return "The deletion could not be requested. Please try again."
+	}
+}
+
+func getSessionsMessage(msg string) string {
+	switch msg {
+	case "revoked":
+		return "The session has been signed out."
+	case "others_revoked":
+		return "All other sessions have been signed out."
+	default:
+		return "The session could not be signed out. Please try again."
+	}
+}
+
 var _ = templruntime.GeneratedTemplate
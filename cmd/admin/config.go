@@ -15,6 +15,9 @@ type Config struct {
 	// API service configuration
 	ApiBaseURL string `conf:"env:API_BASE_URL,default:http://localhost:3000"`
 
+	// Public URL of the Web app, where admins impersonating a user are sent
+	WebBaseURL string `conf:"env:WEB_BASE_URL,default:http://localhost:8080"`
+
 	// Session configuration
 	CookieMaxAge   int    `conf:"env:COOKIE_MAX_AGE,default:86400"` // 24 hours
 	CookieDomain   string `conf:"env:COOKIE_DOMAIN,default:localhost"`
@@ -23,6 +26,10 @@ type Config struct {
 
 	// Static files
 	StaticPath string `conf:"env:STATIC_PATH,default:web/static"`
+
+	// Proxies, as IP addresses or CIDR networks, whose X-Forwarded-For,
+	// X-Real-IP and True-Client-IP headers give the client's address
+	TrustedProxies []string `conf:"env:TRUSTED_PROXIES"`
 }
 
 func (c *Config) Load(prefix string) error {
@@ -1,14 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"go-template/app/admin"
+	"go-template/internal/clientip"
+	"go-template/internal/loglevel"
 	"log/slog"
 	"os"
 
 	httpPkg "github.com/guilhermebr/gox/http"
-
-	"github.com/guilhermebr/gox/logger"
 )
 
 // Injected on build time by ldflags.
@@ -24,7 +25,7 @@ func main() {
 	}
 
 	// Logger
-	log, err := logger.NewLogger("")
+	log, level, err := loglevel.NewLogger("")
 	if err != nil {
 		panic(fmt.Errorf("creating logger: %w", err))
 	}
@@ -36,13 +37,23 @@ func main() {
 		slog.String("build_time", BuildTime),
 	)
 
+	// Toggle debug logs on SIGHUP
+	go loglevel.Watch(context.Background(), level, log)
+
+	proxies, err := clientip.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		panic(fmt.Errorf("loading config: %w", err))
+	}
+
 	app := admin.New(admin.Config{
 		APIBaseURL:     cfg.ApiBaseURL,
+		WebBaseURL:     cfg.WebBaseURL,
 		CookieMaxAge:   cfg.CookieMaxAge,
 		CookieSecure:   cfg.CookieSecure,
 		CookieDomain:   cfg.CookieDomain,
 		SessionTimeout: cfg.SessionTimeout,
 		StaticPath:     cfg.StaticPath,
+		TrustedProxies: proxies,
 	}, log)
 
 	// Create admin server
@@ -15,6 +15,10 @@
 //	@in							header
 //	@name						Authorization
 //	@description				Type "Bearer" followed by a space and JWT token.
+//	@securityDefinitions.apikey	APIKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				API key of a machine client, created at /api/v1/keys.
 //	@schemes					http https
 package main
 
@@ -22,25 +26,24 @@ import (
 	"context"
 	"fmt"
 	"go-template/app/api"
-	appMiddleware "go-template/app/api/middleware"
 	v1 "go-template/app/api/v1"
-	"go-template/domain/auth"
-	"go-template/domain/example"
-	"go-template/domain/settings"
-	"go-template/domain/user"
-	"go-template/gateways/repository/pg"
-	"go-template/internal/jwt"
+	"go-template/gateways/storage"
+	"go-template/internal/auditlog"
+	"go-template/internal/bootstrap"
+	"go-template/internal/clientip"
+	"go-template/internal/errreport"
+	"go-template/internal/logbuffer"
+	"go-template/internal/loglevel"
+	"go-template/internal/profiling"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
-
-	"github.com/go-playground/validator/v10"
+	"strings"
+	"time"
 
 	httpPkg "github.com/guilhermebr/gox/http"
 
-	"github.com/guilhermebr/gox/logger"
-	"github.com/guilhermebr/gox/postgres"
-	"github.com/jackc/pgx/v5/pgxpool"
-
 	// Import generated docs for swagger integration
 	_ "go-template/docs"
 )
@@ -51,100 +54,43 @@ var (
 	BuildTime   = "undefined"
 )
 
-// Dependencies holds all application dependencies
-type Dependencies struct {
-	// Database
-	DB   *pgxpool.Pool
-	Repo *pg.Repository
-
-	// Use Cases
-	UserUseCase     *user.UseCase
-	AuthUseCase     *auth.UseCase
-	ExampleUseCase  example.UseCase
-	SettingsUseCase *settings.UseCase
-
-	// Services
-	JWTService jwt.Service
-	Validator  *validator.Validate
-
-	// Middleware
-	AuthMiddleware *appMiddleware.AuthMiddleware
-
-	// Server
-	Server *httpPkg.Server
-}
-
-// setupDependencies initializes all application dependencies
-func setupDependencies(ctx context.Context, cfg Config, log *slog.Logger) (*Dependencies, error) {
-	// Database
-	conn, err := postgres.New(ctx, "")
-	if err != nil {
-		return nil, fmt.Errorf("setting up database: %w", err)
-	}
-
-	if err := conn.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("connecting to database: %w", err)
-	}
-
-	repo := pg.NewRepository(conn)
-
-	// Services
-	jwtService := jwt.NewService(cfg.AuthSecretKey, cfg.AuthProvider, cfg.AuthTokenTTL)
-	validator := validator.New()
-
-	// Auth setup
-	authConfigs := map[string]auth.AuthConfig{
-		"supabase": {
-			Provider: "supabase",
-			Supabase: auth.SupabaseConfig{
-				URL:    cfg.SupabaseURL,
-				APIKey: cfg.SupabaseAPIKey,
-			},
-		},
-	}
-
-	authFactory := auth.NewProviderFactory(authConfigs)
-	authProvider, err := authFactory.CreateProvider(cfg.AuthProvider)
-	if err != nil {
-		return nil, fmt.Errorf("creating auth provider: %w", err)
-	}
-
-	// Use Cases
-	userUC := user.NewUseCase(repo.UserRepo, authFactory, cfg.AuthProvider)
-	authUC := auth.NewUseCase(repo.UserRepo, authProvider, jwtService)
-	exampleUC := example.New(repo.ExampleRepo)
-	settingsUC := settings.NewUseCase(repo.SettingsRepo, log)
-
-	// Middleware
-	authMiddleware := appMiddleware.NewAuthMiddleware(jwtService)
-
-	return &Dependencies{
-		DB:              conn,
-		Repo:            repo,
-		UserUseCase:     userUC,
-		AuthUseCase:     authUC,
-		ExampleUseCase:  exampleUC,
-		SettingsUseCase: settingsUC,
-		JWTService:      jwtService,
-		Validator:       validator,
-		AuthMiddleware:  authMiddleware,
-	}, nil
-}
-
 func main() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	var cfg Config
+	var cfg bootstrap.Config
 	if err := cfg.Load(""); err != nil {
 		panic(fmt.Errorf("loading config: %w", err))
 	}
 
 	// Logger
-	log, err := logger.NewLogger("")
+	log, level, err := loglevel.NewLogger("")
 	if err != nil {
 		panic(fmt.Errorf("creating logger: %w", err))
 	}
 
+	// Keep recent entries in memory for the admin log viewer
+	logs := logbuffer.New(cfg.LogBufferSize)
+	log = slog.New(logs.Handler(log.Handler()))
+
+	// Store records logged as audit events
+	auditEvents := auditlog.New(cfg.AuditQueueSize)
+	log = slog.New(auditEvents.Handler(log.Handler()))
+
+	// Report the errors logged to Sentry, when SENTRY_DSN is set
+	reporter, err := errreport.New(errreport.Config{
+		DSN:         cfg.SentryDSN,
+		Environment: cfg.Environment,
+		App:         "service",
+		BuildCommit: BuildCommit,
+		BuildTime:   BuildTime,
+	})
+	if err != nil {
+		panic(fmt.Errorf("creating error reporter: %w", err))
+	}
+	defer reporter.Flush(2 * time.Second)
+	log = slog.New(reporter.Handler(log.Handler()))
+
 	log = log.With(
 		slog.String("environment", cfg.Environment),
 		slog.String("app", "service"),
@@ -152,8 +98,15 @@ func main() {
 		slog.String("build_time", BuildTime),
 	)
 
+	// Much of the code logs through the default logger, which has to reach
+	// the same handlers for its audit events to be stored
+	slog.SetDefault(log)
+
+	// Toggle debug logs on SIGHUP
+	go loglevel.Watch(ctx, level, log)
+
 	// Setup dependencies
-	deps, err := setupDependencies(ctx, cfg, log)
+	deps, err := bootstrap.SetupDependencies(ctx, cfg, log)
 	if err != nil {
 		log.Error("failed to setup dependencies",
 			slog.String("error", err.Error()),
@@ -162,19 +115,98 @@ func main() {
 	}
 	defer deps.DB.Close()
 
+	// Watch for database failover
+	go deps.DB.Monitor(ctx)
+
+	// Serve pprof and expvar on a port of their own
+	if cfg.DebugAddr != "" {
+		go profiling.Serve(ctx, cfg.DebugAddr, log)
+	}
+
+	// Store audit events
+	go deps.AuditUseCase.Start(ctx, auditEvents.Events())
+
+	// Run the background jobs, unless cmd/worker does
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		if cfg.WorkerEmbedded {
+			deps.RunBackgroundJobs(ctx, cfg)
+		}
+	}()
+
 	// Handlers V1 and their dependencies
 	apiV1 := v1.ApiHandlers{
 		ExampleUseCase:  deps.ExampleUseCase,
 		AuthUseCase:     deps.AuthUseCase,
 		UserUseCase:     deps.UserUseCase,
 		SettingsUseCase: deps.SettingsUseCase,
+		OIDCUseCase:     deps.OIDCUseCase,
+		PermissionsUC:   deps.AuthzUseCase,
+		BreakGlassUC:    deps.BreakGlassUC,
 		AuthMiddleware:  deps.AuthMiddleware,
 		JWTService:      deps.JWTService,
+		ReadOnly:        deps.DB,
+		BotDetector:     deps.BotDetector,
+		LoginLimiter:    deps.LoginLimiter,
+		LogSource:       logs,
+		LogLevel:        level,
+		Readiness:       deps.Readiness,
+		RevocationUC:    deps.RevocationUC,
+		SessionUC:       deps.SessionUC,
+		APIKeyUC:        deps.APIKeyUC,
+		AuditUC:         deps.AuditUseCase,
+		DeletionUC:      deps.DeletionUseCase,
+		PreferencesUC:   deps.PreferencesUC,
+		NotificationUC:  deps.NotificationUC,
+		Hub:             deps.Hub,
+		LoginHistoryUC:  deps.LoginHistoryUC,
+		InvitationUC:    deps.InvitationUC,
+		AnnouncementUC:  deps.AnnouncementUC,
+		SearchUC:        deps.SearchUC,
+		ExportJobUC:     deps.ExportJobUC,
+		BackupUC:        deps.BackupUC,
+		JobQueue:        deps.JobQueue,
+		AttachmentUC:    deps.AttachmentUC,
+		CommentUC:       deps.CommentUC,
+		UploadUC:        deps.UploadUC,
+		WebhookUC:       deps.WebhookUC,
+		AlertingUC:      deps.AlertingUC,
+
+		ReconciliationUC:      deps.ReconciliationUseCase,
+		SupabaseWebhookSecret: cfg.SupabaseWebhookSecret,
+
+		DebugEndpoints: cfg.DebugEndpoints,
 	}
 
 	// Setup router with middleware
-	router := api.Router()
+	if deps.Mailer != nil {
+		apiV1.EmailPreviewer = deps.Mailer
+	}
+
+	proxies, err := clientip.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Error("invalid TRUSTED_PROXIES",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+	router := api.Router(log, proxies)
+	router.Use(reporter.Middleware)
 	apiV1.Routes(router)
+	// Serve files kept on local disk at STORAGE_PUBLIC_URL
+	if local, ok := deps.Files.(*storage.Local); ok {
+		filesURL, err := url.Parse(cfg.StoragePublicURL)
+		filesPath := ""
+		if err == nil {
+			filesPath = strings.TrimSuffix(filesURL.Path, "/")
+		}
+		if filesPath == "" {
+			log.Error("STORAGE_PUBLIC_URL must be a URL with a path, such as http://localhost:3000/files")
+			os.Exit(1)
+		}
+		router.Mount(filesPath, http.StripPrefix(filesPath, local.Handler()))
+	}
 
 	server, err := httpPkg.NewServer("api", router, log)
 	if err != nil {
@@ -191,4 +223,10 @@ func main() {
 		)
 		os.Exit(1)
 	}
+
+	// Stop the background jobs, and let the events being written to Kafka
+	// or NATS reach them
+	cancel()
+	<-jobsDone
+	deps.Close(cfg, log)
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ardanlabs/conf/v3"
 	_ "github.com/joho/godotenv/autoload"
@@ -15,12 +16,33 @@ type Config struct {
 	// API Configuration
 	APIBaseURL string `conf:"env:API_BASE_URL,default:http://localhost:3000"`
 
+	// Public URL of the web app, used for social login callbacks
+	BaseURL string `conf:"env:BASE_URL,default:http://localhost:8080"`
+
 	// Cookie Configuration
 	CookieMaxAge   int    `conf:"env:COOKIE_MAX_AGE,default:86400"`    // 24 hours in seconds
 	CookieSecure   bool   `conf:"env:COOKIE_SECURE,default:false"`     // Set to true in production with HTTPS
 	CookieDomain   string `conf:"env:COOKIE_DOMAIN,default:localhost"` // Set to your domain in production
 	SessionTimeout int    `conf:"env:SESSION_TIMEOUT,default:1440"`    // Session timeout in minutes (24 hours)
 	StaticPath     string `conf:"env:STATIC_PATH,default:web/static"`
+
+	// Documentation: public, admin or disabled. Defaults to disabled in
+	// production and public elsewhere.
+	DocsMode       string `conf:"env:DOCS_MODE"`
+	DocsAPIBaseURL string `conf:"env:DOCS_API_BASE_URL"` // Defaults to API_BASE_URL
+
+	// Bot detection on public forms
+	BotFormSecret    string        `conf:"env:BOT_FORM_SECRET"`
+	BotMinSubmitTime time.Duration `conf:"env:BOT_MIN_SUBMIT_TIME,default:3s"`
+	BotMaxSubmitTime time.Duration `conf:"env:BOT_MAX_SUBMIT_TIME,default:1h"`
+	BotDNSBLZones    []string      `conf:"env:BOT_DNSBL_ZONES"`
+
+	// Stream notifications to the navbar's bell as they arrive
+	NotificationsLive bool `conf:"env:NOTIFICATIONS_LIVE,default:false"`
+
+	// Proxies, as IP addresses or CIDR networks, whose X-Forwarded-For,
+	// X-Real-IP and True-Client-IP headers give the client's address
+	TrustedProxies []string `conf:"env:TRUSTED_PROXIES"`
 }
 
 func (c *Config) Load(prefix string) error {
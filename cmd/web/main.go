@@ -1,14 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"go-template/app/web"
+	"go-template/gateways/reputation"
+	"go-template/internal/clientip"
+	"go-template/internal/loglevel"
 	"log/slog"
 	"os"
 
 	httpPkg "github.com/guilhermebr/gox/http"
-
-	"github.com/guilhermebr/gox/logger"
 )
 
 // Injected on build time by ldflags.
@@ -24,7 +26,7 @@ func main() {
 	}
 
 	// Logger
-	log, err := logger.NewLogger("WEB")
+	log, level, err := loglevel.NewLogger("WEB")
 	if err != nil {
 		panic(fmt.Errorf("creating logger: %w", err))
 	}
@@ -36,15 +38,44 @@ func main() {
 		slog.String("build_time", BuildTime),
 	)
 
+	// Toggle debug logs on SIGHUP
+	go loglevel.Watch(context.Background(), level, log)
+
 	// Web Application Setup
 	// ------------------------------------------
-	webApp := web.New(web.Config{
-		APIBaseURL:     cfg.APIBaseURL,
-		CookieMaxAge:   cfg.CookieMaxAge,
-		CookieSecure:   cfg.CookieSecure,
-		CookieDomain:   cfg.CookieDomain,
-		SessionTimeout: cfg.SessionTimeout,
-	}, log)
+	docs, err := docsMode(cfg)
+	if err != nil {
+		panic(fmt.Errorf("loading config: %w", err))
+	}
+
+	proxies, err := clientip.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		panic(fmt.Errorf("loading config: %w", err))
+	}
+
+	webCfg := web.Config{
+		APIBaseURL:       cfg.APIBaseURL,
+		BaseURL:          cfg.BaseURL,
+		CookieMaxAge:     cfg.CookieMaxAge,
+		CookieSecure:     cfg.CookieSecure,
+		CookieDomain:     cfg.CookieDomain,
+		SessionTimeout:   cfg.SessionTimeout,
+		DocsMode:         docs,
+		DocsAPIBaseURL:   cfg.DocsAPIBaseURL,
+		BotFormSecret:    cfg.BotFormSecret,
+		BotMinSubmitTime: cfg.BotMinSubmitTime,
+		BotMaxSubmitTime: cfg.BotMaxSubmitTime,
+
+		NotificationsLive: cfg.NotificationsLive,
+		TrustedProxies:    proxies,
+	}
+	if webCfg.DocsAPIBaseURL == "" {
+		webCfg.DocsAPIBaseURL = cfg.APIBaseURL
+	}
+	if len(cfg.BotDNSBLZones) > 0 {
+		webCfg.BotReputation = reputation.NewDNSBL(cfg.BotDNSBLZones...)
+	}
+	webApp := web.New(webCfg, log)
 
 	router := webApp.Routes()
 
@@ -65,3 +96,19 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// docsMode keeps the documentation private in production unless configured
+// otherwise.
+func docsMode(cfg Config) (string, error) {
+	switch cfg.DocsMode {
+	case web.DocsPublic, web.DocsAdmin, web.DocsDisabled:
+		return cfg.DocsMode, nil
+	case "":
+		if cfg.Environment == "production" {
+			return web.DocsDisabled, nil
+		}
+		return web.DocsPublic, nil
+	default:
+		return "", fmt.Errorf("invalid DOCS_MODE %q: use public, admin or disabled", cfg.DocsMode)
+	}
+}
@@ -0,0 +1,103 @@
+// Package main runs the background jobs of the API service, such as
+// sending emails, producing exports and cleanups, out of the API's
+// process. It shares the API's configuration; set WORKER_EMBEDDED=false on
+// the API so the jobs only run here.
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-template/internal/auditlog"
+	"go-template/internal/bootstrap"
+	"go-template/internal/errreport"
+	"go-template/internal/loglevel"
+	"go-template/internal/profiling"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Injected on build time by ldflags.
+var (
+	BuildCommit = "undefined"
+	BuildTime   = "undefined"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var cfg bootstrap.Config
+	if err := cfg.Load(""); err != nil {
+		panic(fmt.Errorf("loading config: %w", err))
+	}
+
+	// Logger
+	log, level, err := loglevel.NewLogger("")
+	if err != nil {
+		panic(fmt.Errorf("creating logger: %w", err))
+	}
+
+	// Store records logged as audit events
+	auditEvents := auditlog.New(cfg.AuditQueueSize)
+	log = slog.New(auditEvents.Handler(log.Handler()))
+
+	// Report the errors logged to Sentry, when SENTRY_DSN is set
+	reporter, err := errreport.New(errreport.Config{
+		DSN:         cfg.SentryDSN,
+		Environment: cfg.Environment,
+		App:         "worker",
+		BuildCommit: BuildCommit,
+		BuildTime:   BuildTime,
+	})
+	if err != nil {
+		panic(fmt.Errorf("creating error reporter: %w", err))
+	}
+	defer reporter.Flush(2 * time.Second)
+	log = slog.New(reporter.Handler(log.Handler()))
+
+	log = log.With(
+		slog.String("environment", cfg.Environment),
+		slog.String("app", "worker"),
+		slog.String("build_commit", BuildCommit),
+		slog.String("build_time", BuildTime),
+	)
+	slog.SetDefault(log)
+
+	// Toggle debug logs on SIGHUP
+	go loglevel.Watch(ctx, level, log)
+
+	// Setup dependencies
+	deps, err := bootstrap.SetupDependencies(ctx, cfg, log)
+	if err != nil {
+		log.Error("failed to setup dependencies",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+	defer deps.DB.Close()
+
+	// Watch for database failover
+	go deps.DB.Monitor(ctx)
+
+	// Serve pprof and expvar on a port of their own
+	if cfg.DebugAddr != "" {
+		go profiling.Serve(ctx, cfg.DebugAddr, log)
+	}
+
+	// Store audit events
+	go deps.AuditUseCase.Start(ctx, auditEvents.Events())
+
+	if cfg.WorkerEmbedded {
+		log.Info("the API runs the background jobs too, set WORKER_EMBEDDED=false on it to run them here only")
+	}
+	log.Info("worker started", slog.Int("job_workers", cfg.JobWorkers))
+
+	// Run until interrupted, then record the jobs cut short and let the
+	// events being written to Kafka or NATS reach them
+	deps.RunBackgroundJobs(ctx, cfg)
+	log.Info("worker stopped")
+	deps.Close(cfg, log)
+}
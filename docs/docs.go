@@ -31,7 +31,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve admin dashboard statistics including user counts and system alerts",
+                "description": "Retrieve admin dashboard statistics: user counts, active sessions and the system alerts raised in the last 24 hours",
                 "produces": [
                     "application/json"
                 ],
@@ -655,7 +655,7 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 8
+                    "minLength": 6
                 }
             }
         },
@@ -0,0 +1,34 @@
+package domain
+
+import "go-template/domain/entities"
+
+// AccountSuspendedError is returned for suspended users, with the reason an
+// admin gave. It matches ErrAccountSuspended with errors.Is.
+type AccountSuspendedError struct {
+	Reason string
+}
+
+func (e *AccountSuspendedError) Error() string {
+	if e.Reason == "" {
+		return ErrAccountSuspended.Error()
+	}
+	return ErrAccountSuspended.Error() + ": " + e.Reason
+}
+
+func (e *AccountSuspendedError) Unwrap() error {
+	return ErrAccountSuspended
+}
+
+// CheckAccountStatus returns an *AccountSuspendedError for suspended users
+// and ErrAccountPending for pending ones. Users stored before statuses
+// existed have none and are active.
+func CheckAccountStatus(user entities.User) error {
+	switch user.Status {
+	case entities.UserStatusSuspended:
+		return &AccountSuspendedError{Reason: user.SuspendedReason}
+	case entities.UserStatusPending:
+		return ErrAccountPending
+	default:
+		return nil
+	}
+}
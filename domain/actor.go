@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type actorKey struct{}
+
+// WithActor records the user a request is made by, so the audit trail can
+// name them even when a use case only knows the user it acts on.
+func WithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the user recorded with WithActor.
+func ActorFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(actorKey{}).(uuid.UUID)
+	return userID, ok && userID != uuid.Nil
+}
@@ -0,0 +1,30 @@
+package alerting
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Channel
+
+type Repository interface {
+	// GetAlertSettings returns empty settings until they are saved.
+	GetAlertSettings(ctx context.Context) (entities.AlertSettings, error)
+	UpdateAlertSettings(ctx context.Context, settings entities.AlertSettings) error
+	// RecordAlert keeps an alert raised at raisedAt, for CountAlertsSince.
+	RecordAlert(ctx context.Context, alert entities.Alert, raisedAt time.Time) error
+	CountAlertsSince(ctx context.Context, since time.Time) (int64, error)
+}
+
+// Channel delivers alerts to operators, such as a Slack channel.
+type Channel interface {
+	Alert(ctx context.Context, alert entities.Alert) error
+}
+
+// Channels creates the channels of the alert settings from their target.
+type Channels struct {
+	Slack     func(webhookURL string) Channel
+	PagerDuty func(routingKey string) Channel
+	Webhook   func(url string) Channel
+}
@@ -0,0 +1,210 @@
+// Package alerting delivers system alerts, such as break-glass access or
+// dead-lettered jobs, to the channels super admins configure in the alert
+// settings: Slack, PagerDuty and a generic webhook. Each channel only gets
+// the alerts at or above its minimum severity.
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// RecentAlertsWindow is how far back CountRecent counts alerts.
+const RecentAlertsWindow = 24 * time.Hour
+
+// route is a channel and the lowest severity it gets.
+type route struct {
+	name        string
+	channel     Channel
+	minSeverity entities.AlertSeverity
+}
+
+type UseCase struct {
+	repo     Repository
+	channels Channels
+	// static are the channels set up at startup, such as ALERT_WEBHOOK_URL
+	static []route
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, channels Channels, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		channels: channels,
+		logger:   logger,
+	}
+}
+
+// AddChannel delivers the alerts at or above minSeverity to channel, along
+// with the channels in the settings.
+func (uc *UseCase) AddChannel(name string, channel Channel, minSeverity entities.AlertSeverity) {
+	uc.static = append(uc.static, route{name: name, channel: channel, minSeverity: minSeverity})
+}
+
+// Alert delivers alert to every channel taking its severity, at the same
+// time. A failed channel doesn't keep the others from getting it; the
+// errors are logged and returned together. An alert no channel takes is
+// logged instead. Either way it's recorded for CountRecent.
+func (uc *UseCase) Alert(ctx context.Context, alert entities.Alert) error {
+	// Failing to record the alert mustn't keep it from being delivered
+	if err := uc.repo.RecordAlert(ctx, alert, time.Now()); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to record alert", "title", alert.Title, "error", err)
+	}
+
+	routes := uc.routesTaking(ctx, alert.Severity)
+	if len(routes) == 0 {
+		domain.LoggerOr(ctx, uc.logger).Warn("no alert channel takes the alert, it was only logged",
+			"title", alert.Title, "severity", alert.Severity, "message", alert.Message)
+		return nil
+	}
+	return uc.deliver(ctx, routes, alert)
+}
+
+// HasChannel reports whether a channel takes alerts of severity, for
+// features that mustn't run without being alerted on.
+func (uc *UseCase) HasChannel(ctx context.Context, severity entities.AlertSeverity) bool {
+	return len(uc.routesTaking(ctx, severity)) > 0
+}
+
+// CountRecent returns how many alerts were raised in the last
+// RecentAlertsWindow, test alerts aside.
+func (uc *UseCase) CountRecent(ctx context.Context) (int64, error) {
+	n, err := uc.repo.CountAlertsSince(ctx, time.Now().Add(-RecentAlertsWindow))
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to count recent alerts", "error", err)
+		return 0, err
+	}
+	return n, nil
+}
+
+// SendTest delivers a test alert to every channel, whatever their minimum
+// severity, so super admins can check the settings.
+func (uc *UseCase) SendTest(ctx context.Context) error {
+	routes := uc.routes(ctx)
+	if len(routes) == 0 {
+		return entities.ErrInvalidSettingValue{Field: "alert_settings", Message: "no alert channel is configured"}
+	}
+	return uc.deliver(ctx, routes, entities.Alert{
+		Title:    "Test alert",
+		Message:  "This is a test alert sent from the admin settings. Alerts are delivered to this channel.",
+		Severity: entities.AlertSeverityInfo,
+	})
+}
+
+func (uc *UseCase) GetSettings(ctx context.Context) (entities.AlertSettings, error) {
+	settings, err := uc.repo.GetAlertSettings(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get alert settings", "error", err)
+		return entities.AlertSettings{}, err
+	}
+	return settings, nil
+}
+
+func (uc *UseCase) UpdateSettings(ctx context.Context, settings entities.AlertSettings) error {
+	if err := validateSettings(settings); err != nil {
+		return err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: alert settings not updated")
+		return nil
+	}
+
+	if err := uc.repo.UpdateAlertSettings(ctx, settings); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to update alert settings", "error", err)
+		return err
+	}
+	// The targets are secrets, so only which channels are on is audited
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "alert settings updated", "audit", true, "resource", entities.AuditResourceSettings,
+		"slack", settings.SlackWebhookURL != "", "slack_min_severity", settings.SlackMinSeverity,
+		"pagerduty", settings.PagerDutyRoutingKey != "", "pagerduty_min_severity", settings.PagerDutyMinSeverity,
+		"webhook", settings.WebhookURL != "", "webhook_min_severity", settings.WebhookMinSeverity)
+	return nil
+}
+
+// routes returns the static channels and those in the settings. The static
+// ones still get the alerts when the settings can't be read.
+func (uc *UseCase) routes(ctx context.Context) []route {
+	routes := append([]route(nil), uc.static...)
+
+	settings, err := uc.repo.GetAlertSettings(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get alert settings, alerting the static channels only", "error", err)
+		return routes
+	}
+	if settings.SlackWebhookURL != "" && uc.channels.Slack != nil {
+		routes = append(routes, route{name: "slack", channel: uc.channels.Slack(settings.SlackWebhookURL), minSeverity: settings.SlackMinSeverity})
+	}
+	if settings.PagerDutyRoutingKey != "" && uc.channels.PagerDuty != nil {
+		routes = append(routes, route{name: "pagerduty", channel: uc.channels.PagerDuty(settings.PagerDutyRoutingKey), minSeverity: settings.PagerDutyMinSeverity})
+	}
+	if settings.WebhookURL != "" && uc.channels.Webhook != nil {
+		routes = append(routes, route{name: "webhook", channel: uc.channels.Webhook(settings.WebhookURL), minSeverity: settings.WebhookMinSeverity})
+	}
+	return routes
+}
+
+func (uc *UseCase) routesTaking(ctx context.Context, severity entities.AlertSeverity) []route {
+	var routes []route
+	for _, r := range uc.routes(ctx) {
+		if severity.AtLeast(r.minSeverity) {
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+func (uc *UseCase) deliver(ctx context.Context, routes []route, alert entities.Alert) error {
+	errs := make([]error, len(routes))
+	var wg sync.WaitGroup
+	for i, r := range routes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.channel.Alert(ctx, alert); err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to deliver alert", "channel", r.name, "title", alert.Title, "error", err)
+				errs[i] = fmt.Errorf("%s: %w", r.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func validateSettings(settings entities.AlertSettings) error {
+	urls := []struct{ field, value string }{
+		{"slack_webhook_url", settings.SlackWebhookURL},
+		{"webhook_url", settings.WebhookURL},
+	}
+	for _, u := range urls {
+		if u.value == "" {
+			continue
+		}
+		parsed, err := url.Parse(u.value)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return entities.ErrInvalidSettingValue{Field: u.field, Message: "must be an http or https URL"}
+		}
+	}
+
+	severities := []struct {
+		field string
+		value entities.AlertSeverity
+	}{
+		{"slack_min_severity", settings.SlackMinSeverity},
+		{"pagerduty_min_severity", settings.PagerDutyMinSeverity},
+		{"webhook_min_severity", settings.WebhookMinSeverity},
+	}
+	for _, s := range severities {
+		if s.value != "" && !s.value.Valid() {
+			return entities.ErrInvalidSettingValue{Field: s.field, Message: "must be info, warning or critical"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/alerting/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannels builds each channel as a mock, kept by its target.
+func testChannels(built map[string]*mocks.ChannelMock) Channels {
+	channel := func(target string) Channel {
+		ch := &mocks.ChannelMock{}
+		built[target] = ch
+		return ch
+	}
+	return Channels{Slack: channel, PagerDuty: channel, Webhook: channel}
+}
+
+func newTestUseCase(repo *mocks.RepositoryMock, built map[string]*mocks.ChannelMock) *UseCase {
+	return NewUseCase(repo, testChannels(built), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Alert(t *testing.T) {
+	settings := entities.AlertSettings{
+		SlackWebhookURL:      "https://hooks.slack.com/services/x",
+		PagerDutyRoutingKey:  "routing-key",
+		PagerDutyMinSeverity: entities.AlertSeverityCritical,
+		WebhookURL:           "https://example.com/alerts",
+		WebhookMinSeverity:   entities.AlertSeverityWarning,
+	}
+
+	tests := []struct {
+		name     string
+		severity entities.AlertSeverity
+		want     []string
+	}{
+		{name: "info goes to channels without a minimum", severity: entities.AlertSeverityInfo, want: []string{settings.SlackWebhookURL}},
+		{name: "warning", severity: entities.AlertSeverityWarning, want: []string{settings.SlackWebhookURL, settings.WebhookURL}},
+		{name: "critical goes everywhere", severity: entities.AlertSeverityCritical, want: []string{settings.SlackWebhookURL, settings.PagerDutyRoutingKey, settings.WebhookURL}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				GetAlertSettingsFunc: func(ctx context.Context) (entities.AlertSettings, error) { return settings, nil },
+			}
+			built := map[string]*mocks.ChannelMock{}
+			uc := newTestUseCase(repo, built)
+
+			alert := entities.Alert{Title: "t", Message: "m", Severity: tt.severity}
+			require.NoError(t, uc.Alert(context.Background(), alert))
+
+			var alerted []string
+			for target, ch := range built {
+				if len(ch.AlertCalls()) > 0 {
+					assert.Equal(t, alert, ch.AlertCalls()[0].Alert)
+					alerted = append(alerted, target)
+				}
+			}
+			assert.ElementsMatch(t, tt.want, alerted)
+		})
+	}
+}
+
+func TestUseCase_Alert_Failures(t *testing.T) {
+	t.Run("a failed channel doesn't stop the others", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+		failing := &mocks.ChannelMock{
+			AlertFunc: func(ctx context.Context, alert entities.Alert) error { return errors.New("status 500") },
+		}
+		working := &mocks.ChannelMock{}
+		uc.AddChannel("failing", failing, "")
+		uc.AddChannel("working", working, "")
+
+		err := uc.Alert(context.Background(), entities.Alert{Title: "t", Severity: entities.AlertSeverityWarning})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failing")
+		assert.Len(t, working.AlertCalls(), 1)
+	})
+
+	t.Run("static channels still alert when the settings can't be read", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			GetAlertSettingsFunc: func(ctx context.Context) (entities.AlertSettings, error) {
+				return entities.AlertSettings{}, errors.New("database down")
+			},
+		}
+		uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+		static := &mocks.ChannelMock{}
+		uc.AddChannel("alert_webhook", static, "")
+
+		require.NoError(t, uc.Alert(context.Background(), entities.Alert{Title: "t", Severity: entities.AlertSeverityCritical}))
+		assert.Len(t, static.AlertCalls(), 1)
+	})
+
+	t.Run("an alert no channel takes is only logged", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+		require.NoError(t, uc.Alert(context.Background(), entities.Alert{Title: "t", Severity: entities.AlertSeverityCritical}))
+		assert.Len(t, repo.RecordAlertCalls(), 1, "still recorded")
+	})
+
+	t.Run("an alert that can't be recorded is still delivered", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			RecordAlertFunc: func(ctx context.Context, alert entities.Alert, raisedAt time.Time) error {
+				return errors.New("database down")
+			},
+		}
+		uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+		static := &mocks.ChannelMock{}
+		uc.AddChannel("alert_webhook", static, "")
+
+		require.NoError(t, uc.Alert(context.Background(), entities.Alert{Title: "t", Severity: entities.AlertSeverityWarning}))
+		assert.Len(t, static.AlertCalls(), 1)
+	})
+}
+
+func TestUseCase_CountRecent(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		CountAlertsSinceFunc: func(ctx context.Context, since time.Time) (int64, error) { return 3, nil },
+	}
+	uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+
+	n, err := uc.CountRecent(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.Len(t, repo.CountAlertsSinceCalls(), 1)
+	assert.WithinDuration(t, time.Now().Add(-RecentAlertsWindow), repo.CountAlertsSinceCalls()[0].Since, time.Minute)
+}
+
+func TestUseCase_SendTest(t *testing.T) {
+	t.Run("ignores the minimum severity", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			GetAlertSettingsFunc: func(ctx context.Context) (entities.AlertSettings, error) {
+				return entities.AlertSettings{PagerDutyRoutingKey: "routing-key", PagerDutyMinSeverity: entities.AlertSeverityCritical}, nil
+			},
+		}
+		built := map[string]*mocks.ChannelMock{}
+		uc := newTestUseCase(repo, built)
+
+		require.NoError(t, uc.SendTest(context.Background()))
+		require.Contains(t, built, "routing-key")
+		assert.Len(t, built["routing-key"].AlertCalls(), 1)
+		assert.Empty(t, repo.RecordAlertCalls(), "test alerts aren't counted")
+	})
+
+	t.Run("fails without channels", func(t *testing.T) {
+		uc := newTestUseCase(&mocks.RepositoryMock{}, map[string]*mocks.ChannelMock{})
+
+		var invalid entities.ErrInvalidSettingValue
+		assert.ErrorAs(t, uc.SendTest(context.Background()), &invalid)
+	})
+}
+
+func TestUseCase_HasChannel(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetAlertSettingsFunc: func(ctx context.Context) (entities.AlertSettings, error) {
+			return entities.AlertSettings{WebhookURL: "https://example.com/alerts", WebhookMinSeverity: entities.AlertSeverityCritical}, nil
+		},
+	}
+	uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+
+	assert.True(t, uc.HasChannel(context.Background(), entities.AlertSeverityCritical))
+	assert.False(t, uc.HasChannel(context.Background(), entities.AlertSeverityWarning))
+	assert.False(t, newTestUseCase(&mocks.RepositoryMock{}, nil).HasChannel(context.Background(), entities.AlertSeverityCritical))
+}
+
+func TestUseCase_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings entities.AlertSettings
+		wantErr  string
+	}{
+		{name: "valid", settings: entities.AlertSettings{SlackWebhookURL: "https://hooks.slack.com/services/x", SlackMinSeverity: entities.AlertSeverityWarning}},
+		{name: "all channels off", settings: entities.AlertSettings{}},
+		{name: "not a URL", settings: entities.AlertSettings{WebhookURL: "example.com/alerts"}, wantErr: "webhook_url"},
+		{name: "not http", settings: entities.AlertSettings{SlackWebhookURL: "ftp://hooks.slack.com"}, wantErr: "slack_webhook_url"},
+		{name: "unknown severity", settings: entities.AlertSettings{PagerDutyMinSeverity: "urgent"}, wantErr: "pagerduty_min_severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+
+			err := uc.UpdateSettings(context.Background(), tt.settings)
+			if tt.wantErr != "" {
+				var invalid entities.ErrInvalidSettingValue
+				require.ErrorAs(t, err, &invalid)
+				assert.Equal(t, tt.wantErr, invalid.Field)
+				assert.Empty(t, repo.UpdateAlertSettingsCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.UpdateAlertSettingsCalls(), 1)
+			assert.Equal(t, tt.settings, repo.UpdateAlertSettingsCalls()[0].Settings)
+		})
+	}
+
+	t.Run("dry run saves nothing", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, map[string]*mocks.ChannelMock{})
+
+		require.NoError(t, uc.UpdateSettings(domain.WithDryRun(context.Background()), entities.AlertSettings{}))
+		assert.Empty(t, repo.UpdateAlertSettingsCalls())
+	})
+}
@@ -0,0 +1,32 @@
+package announcement
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	CreateAnnouncement(ctx context.Context, announcement entities.Announcement) error
+	// GetAnnouncement returns domain.ErrNotFound for unknown announcements.
+	GetAnnouncement(ctx context.Context, id uuid.UUID) (entities.Announcement, error)
+	// ListAnnouncements returns scheduled and ended announcements too,
+	// latest first.
+	ListAnnouncements(ctx context.Context) ([]entities.Announcement, error)
+	// ListActiveAnnouncements returns the announcements shown at now that
+	// the user hasn't dismissed, latest first. uuid.Nil lists them all.
+	ListActiveAnnouncements(ctx context.Context, now time.Time, userID uuid.UUID) ([]entities.Announcement, error)
+	// UpdateAnnouncement returns domain.ErrNotFound for unknown
+	// announcements.
+	UpdateAnnouncement(ctx context.Context, announcement entities.Announcement) error
+	// DeleteAnnouncement returns domain.ErrNotFound for unknown
+	// announcements.
+	DeleteAnnouncement(ctx context.Context, id uuid.UUID) error
+	// DismissAnnouncement returns domain.ErrNotFound for unknown
+	// announcements. Dismissing one twice keeps the first time.
+	DismissAnnouncement(ctx context.Context, id, userID uuid.UUID, dismissedAt time.Time) error
+}
@@ -0,0 +1,184 @@
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const maxMessageLength = 1000
+
+// Request describes an announcement to create, or what to replace one
+// with. StartsAt defaults to now and Level to info; announcements without
+// EndsAt are shown until they are deleted.
+type Request struct {
+	Message  string                     `json:"message"`
+	Level    entities.AnnouncementLevel `json:"level,omitempty"`
+	StartsAt *time.Time                 `json:"starts_at,omitempty"`
+	EndsAt   *time.Time                 `json:"ends_at,omitempty"`
+}
+
+// UseCase manages the announcements admins broadcast to everyone using the
+// apps, and the users who dismissed them.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Create stores a new announcement made by the admin adminID.
+func (uc *UseCase) Create(ctx context.Context, adminID uuid.UUID, req Request) (entities.Announcement, error) {
+	now := uc.now().UTC()
+	announcement := entities.Announcement{
+		ID:        uuid.Must(uuid.NewV4()),
+		CreatedBy: &adminID,
+		CreatedAt: now,
+	}
+	if err := apply(&announcement, req, now); err != nil {
+		return entities.Announcement{}, err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: announcement not created", "admin_id", adminID)
+		return announcement, nil
+	}
+
+	if err := uc.repo.CreateAnnouncement(ctx, announcement); err != nil {
+		return entities.Announcement{}, fmt.Errorf("creating announcement: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("announcement created", "audit", true, "admin_id", adminID,
+		"resource", entities.AuditResourceAnnouncement, "resource_id", announcement.ID, "level", announcement.Level)
+	return announcement, nil
+}
+
+// Update replaces the announcement's message, level and schedule on behalf
+// of the admin adminID. Users who dismissed it don't see it again.
+func (uc *UseCase) Update(ctx context.Context, adminID, id uuid.UUID, req Request) (entities.Announcement, error) {
+	announcement, err := uc.repo.GetAnnouncement(ctx, id)
+	if err != nil {
+		return entities.Announcement{}, fmt.Errorf("getting announcement: %w", err)
+	}
+	now := uc.now().UTC()
+	if req.StartsAt == nil {
+		// Keep the schedule it had
+		req.StartsAt = &announcement.StartsAt
+	}
+	if err := apply(&announcement, req, now); err != nil {
+		return entities.Announcement{}, err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: announcement not updated", "admin_id", adminID, "announcement_id", id)
+		return announcement, nil
+	}
+
+	if err := uc.repo.UpdateAnnouncement(ctx, announcement); err != nil {
+		return entities.Announcement{}, fmt.Errorf("updating announcement: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("announcement updated", "audit", true, "admin_id", adminID,
+		"resource", entities.AuditResourceAnnouncement, "resource_id", id, "level", announcement.Level)
+	return announcement, nil
+}
+
+// apply validates req and sets it on the announcement, as changed at now.
+func apply(announcement *entities.Announcement, req Request, now time.Time) error {
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return fmt.Errorf("message is required: %w", domain.ErrMalformedParameters)
+	}
+	if len(message) > maxMessageLength {
+		return fmt.Errorf("message is longer than %d characters: %w", maxMessageLength, domain.ErrMalformedParameters)
+	}
+	level := req.Level
+	if level == "" {
+		level = entities.AnnouncementInfo
+	}
+	if !level.Valid() {
+		return fmt.Errorf("unknown level %q: %w", level, domain.ErrMalformedParameters)
+	}
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt.UTC()
+	}
+	var endsAt *time.Time
+	if req.EndsAt != nil {
+		end := req.EndsAt.UTC()
+		if !end.After(startsAt) {
+			return fmt.Errorf("end must be after the start: %w", domain.ErrMalformedParameters)
+		}
+		endsAt = &end
+	}
+
+	announcement.Message = message
+	announcement.Level = level
+	announcement.StartsAt = startsAt
+	announcement.EndsAt = endsAt
+	announcement.UpdatedAt = now
+	return nil
+}
+
+// List returns every announcement, latest first.
+func (uc *UseCase) List(ctx context.Context) ([]entities.Announcement, error) {
+	announcements, err := uc.repo.ListAnnouncements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if announcements == nil {
+		announcements = []entities.Announcement{}
+	}
+	return announcements, nil
+}
+
+// Delete removes the announcement on behalf of the admin adminID.
+func (uc *UseCase) Delete(ctx context.Context, adminID, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: announcement not deleted", "admin_id", adminID, "announcement_id", id)
+		return nil
+	}
+
+	if err := uc.repo.DeleteAnnouncement(ctx, id); err != nil {
+		return fmt.Errorf("deleting announcement: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("announcement deleted", "audit", true, "admin_id", adminID, "resource", entities.AuditResourceAnnouncement, "resource_id", id)
+	return nil
+}
+
+// Active returns the announcements shown now, latest first. Those the user
+// dismissed are left out; uuid.Nil, for people not signed in, gets them all.
+func (uc *UseCase) Active(ctx context.Context, userID uuid.UUID) ([]entities.Announcement, error) {
+	announcements, err := uc.repo.ListActiveAnnouncements(ctx, uc.now().UTC(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if announcements == nil {
+		announcements = []entities.Announcement{}
+	}
+	return announcements, nil
+}
+
+// Dismiss stops showing the announcement to the user. It returns
+// domain.ErrNotFound for unknown announcements.
+func (uc *UseCase) Dismiss(ctx context.Context, userID, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: announcement not dismissed", "user_id", userID, "announcement_id", id)
+		return nil
+	}
+	return uc.repo.DismissAnnouncement(ctx, id, userID, uc.now().UTC())
+}
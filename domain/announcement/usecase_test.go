@@ -0,0 +1,155 @@
+package announcement
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/announcement/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, now time.Time) *UseCase {
+	uc := NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	uc.now = func() time.Time { return now }
+	return uc
+}
+
+func TestUseCase_Create(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		req      Request
+		wantErr  error
+		level    entities.AnnouncementLevel
+		startsAt time.Time
+		endsAt   *time.Time
+	}{
+		{
+			name:     "defaults to an info announcement starting now",
+			req:      Request{Message: "  Maintenance tonight "},
+			level:    entities.AnnouncementInfo,
+			startsAt: now,
+		},
+		{
+			name:     "keeps the level and schedule asked for",
+			req:      Request{Message: "Maintenance", Level: entities.AnnouncementWarning, StartsAt: &earlier, EndsAt: &later},
+			level:    entities.AnnouncementWarning,
+			startsAt: earlier,
+			endsAt:   &later,
+		},
+		{
+			name:    "rejects empty messages",
+			req:     Request{Message: " "},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects long messages",
+			req:     Request{Message: strings.Repeat("a", maxMessageLength+1)},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects unknown levels",
+			req:     Request{Message: "Maintenance", Level: "loud"},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects ends before the start",
+			req:     Request{Message: "Maintenance", StartsAt: &later, EndsAt: &earlier},
+			wantErr: domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo, now)
+
+			got, err := uc.Create(context.Background(), adminID, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.CreateAnnouncementCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tt.req.Message), got.Message)
+			assert.Equal(t, tt.level, got.Level)
+			assert.Equal(t, tt.startsAt, got.StartsAt)
+			assert.Equal(t, tt.endsAt, got.EndsAt)
+			assert.Equal(t, &adminID, got.CreatedBy)
+			require.Len(t, repo.CreateAnnouncementCalls(), 1)
+			assert.Equal(t, got, repo.CreateAnnouncementCalls()[0].Announcement)
+		})
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, now)
+
+		_, err := uc.Create(domain.WithDryRun(context.Background()), adminID, Request{Message: "Maintenance"})
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateAnnouncementCalls())
+	})
+}
+
+func TestUseCase_Update(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	existing := entities.Announcement{
+		ID:        uuid.Must(uuid.NewV4()),
+		Message:   "Maintenance",
+		Level:     entities.AnnouncementInfo,
+		StartsAt:  now.Add(-24 * time.Hour),
+		CreatedAt: now.Add(-48 * time.Hour),
+	}
+	repo := &mocks.RepositoryMock{
+		GetAnnouncementFunc: func(ctx context.Context, id uuid.UUID) (entities.Announcement, error) {
+			if id != existing.ID {
+				return entities.Announcement{}, domain.ErrNotFound
+			}
+			return existing, nil
+		},
+	}
+	uc := newTestUseCase(repo, now)
+
+	got, err := uc.Update(context.Background(), adminID, existing.ID, Request{Message: "Maintenance moved", Level: entities.AnnouncementCritical})
+	require.NoError(t, err)
+	assert.Equal(t, "Maintenance moved", got.Message)
+	assert.Equal(t, entities.AnnouncementCritical, got.Level)
+	assert.Equal(t, existing.StartsAt, got.StartsAt, "keeps the schedule it had")
+	assert.Equal(t, existing.CreatedAt, got.CreatedAt)
+	assert.Equal(t, now, got.UpdatedAt)
+	require.Len(t, repo.UpdateAnnouncementCalls(), 1)
+
+	_, err = uc.Update(context.Background(), adminID, uuid.Must(uuid.NewV4()), Request{Message: "Hi"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUseCase_Active(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo, now)
+
+	got, err := uc.Active(context.Background(), userID)
+	require.NoError(t, err)
+	assert.NotNil(t, got, "an empty list, not null")
+	require.Len(t, repo.ListActiveAnnouncementsCalls(), 1)
+	assert.Equal(t, now, repo.ListActiveAnnouncementsCalls()[0].Now)
+	assert.Equal(t, userID, repo.ListActiveAnnouncementsCalls()[0].UserID)
+
+	require.NoError(t, uc.Dismiss(context.Background(), userID, uuid.Must(uuid.NewV4())))
+	require.Len(t, repo.DismissAnnouncementCalls(), 1)
+	assert.Equal(t, userID, repo.DismissAnnouncementCalls()[0].UserID)
+}
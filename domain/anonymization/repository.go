@@ -0,0 +1,23 @@
+package anonymization
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Scrubber
+
+type Repository interface {
+	ListPendingTombstones(ctx context.Context, limit int32) ([]entities.UserTombstone, error)
+	MarkAnonymized(ctx context.Context, tombstoneID uuid.UUID) error
+}
+
+// Scrubber removes a deleted user's personal data from one store. Records the
+// store must keep, like audit entries, are re-pointed at the tombstone ID.
+// Scrub must be idempotent because a failed run is retried from the start.
+type Scrubber interface {
+	Name() string
+	Scrub(ctx context.Context, userID, tombstoneID uuid.UUID) error
+}
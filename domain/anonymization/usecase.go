@@ -0,0 +1,88 @@
+package anonymization
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const batchSize = 100
+
+// UseCase anonymizes deleted users. Deleting a user leaves a tombstone behind;
+// the pipeline runs every registered Scrubber for it and then forgets the
+// original user ID, so only the tombstone's aggregate facts remain.
+type UseCase struct {
+	repo      Repository
+	scrubbers []Scrubber
+	logger    *slog.Logger
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger, scrubbers ...Scrubber) *UseCase {
+	return &UseCase{
+		repo:      repo,
+		scrubbers: scrubbers,
+		logger:    logger,
+	}
+}
+
+// Run anonymizes one batch of pending tombstones and returns how many were
+// completed. Tombstones whose scrubbers fail stay pending for the next run.
+func (uc *UseCase) Run(ctx context.Context) (int, error) {
+	tombstones, err := uc.repo.ListPendingTombstones(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("listing pending tombstones: %w", err)
+	}
+
+	done := 0
+	for _, t := range tombstones {
+		if t.UserID == nil {
+			continue
+		}
+
+		if err := uc.scrub(ctx, *t.UserID, t.ID); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to anonymize deleted user", "tombstone_id", t.ID, "error", err)
+			continue
+		}
+
+		if err := uc.repo.MarkAnonymized(ctx, t.ID); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to mark tombstone anonymized", "tombstone_id", t.ID, "error", err)
+			continue
+		}
+
+		domain.LoggerOr(ctx, uc.logger).Info("deleted user anonymized", "tombstone_id", t.ID)
+		done++
+	}
+
+	return done, nil
+}
+
+// Start runs the pipeline every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.Run(ctx); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("anonymization run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *UseCase) scrub(ctx context.Context, userID, tombstoneID uuid.UUID) error {
+	for _, s := range uc.scrubbers {
+		if err := s.Scrub(ctx, userID, tombstoneID); err != nil {
+			return fmt.Errorf("scrubbing %s: %w", s.Name(), err)
+		}
+	}
+	return nil
+}
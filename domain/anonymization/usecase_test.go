@@ -0,0 +1,54 @@
+package anonymization
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/anonymization/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_Run(t *testing.T) {
+	okUser := uuid.Must(uuid.NewV4())
+	failingUser := uuid.Must(uuid.NewV4())
+	okTombstone := entities.UserTombstone{ID: uuid.Must(uuid.NewV4()), UserID: &okUser}
+	failingTombstone := entities.UserTombstone{ID: uuid.Must(uuid.NewV4()), UserID: &failingUser}
+
+	repo := &mocks.RepositoryMock{
+		ListPendingTombstonesFunc: func(ctx context.Context, limit int32) ([]entities.UserTombstone, error) {
+			return []entities.UserTombstone{okTombstone, failingTombstone}, nil
+		},
+	}
+	audit := &mocks.ScrubberMock{
+		NameFunc: func() string { return "audit" },
+	}
+	history := &mocks.ScrubberMock{
+		NameFunc: func() string { return "login_history" },
+		ScrubFunc: func(ctx context.Context, userID, tombstoneID uuid.UUID) error {
+			if userID == failingUser {
+				return errors.New("db down")
+			}
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)), audit, history)
+
+	done, err := uc.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, done)
+
+	// Every scrubber sees the user with its tombstone
+	require.Len(t, audit.ScrubCalls(), 2)
+	assert.Equal(t, okUser, audit.ScrubCalls()[0].UserID)
+	assert.Equal(t, okTombstone.ID, audit.ScrubCalls()[0].TombstoneID)
+
+	// Only the fully scrubbed tombstone forgets the user
+	require.Len(t, repo.MarkAnonymizedCalls(), 1)
+	assert.Equal(t, okTombstone.ID, repo.MarkAnonymizedCalls()[0].TombstoneID)
+}
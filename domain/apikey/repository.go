@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	CreateAPIKey(ctx context.Context, key entities.APIKey) error
+	// GetAPIKey and GetAPIKeyByHash return domain.ErrNotFound for unknown
+	// keys.
+	GetAPIKey(ctx context.Context, id uuid.UUID) (entities.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (entities.APIKey, error)
+	// ListAPIKeys and ListUserAPIKeys return revoked and expired keys too,
+	// newest first.
+	ListAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+	ListUserAPIKeys(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error)
+	// RevokeAPIKey returns domain.ErrNotFound for unknown keys and keys that
+	// were already revoked.
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	// TouchAPIKey records that the key was just used.
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,204 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// KeyPrefix starts every key, so leaked keys are easy to spot
+	KeyPrefix = "gtk_"
+
+	// displayPrefixLength is how much of a key is kept to tell keys apart
+	displayPrefixLength = len(KeyPrefix) + 8
+
+	// touchInterval is how often a key's last use is written
+	touchInterval = time.Minute
+)
+
+// ErrInvalidKey is returned by Authenticate for keys that are unknown,
+// revoked or expired.
+var ErrInvalidKey = errors.New("invalid api key")
+
+// CreateRequest describes a new key. Keys without ExpiresAt never expire.
+type CreateRequest struct {
+	Name      string                 `json:"name"`
+	Scopes    []entities.APIKeyScope `json:"scopes"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+}
+
+// UseCase manages the API keys users create for machine clients. A key acts
+// as its owner, limited to its scopes, until it expires or is revoked by the
+// owner or an admin.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Create stores a new key for the user and returns it together with the
+// plain-text key, which is not stored and can't be retrieved again.
+func (uc *UseCase) Create(ctx context.Context, userID uuid.UUID, req CreateRequest) (entities.APIKey, string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return entities.APIKey{}, "", fmt.Errorf("missing name: %w", domain.ErrMalformedParameters)
+	}
+	if len(req.Scopes) == 0 {
+		return entities.APIKey{}, "", fmt.Errorf("at least one scope is required: %w", domain.ErrMalformedParameters)
+	}
+	scopes := make([]entities.APIKeyScope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !scope.IsValid() {
+			return entities.APIKey{}, "", fmt.Errorf("unknown scope %q: %w", scope, domain.ErrMalformedParameters)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	now := uc.now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return entities.APIKey{}, "", fmt.Errorf("expiry must be in the future: %w", domain.ErrMalformedParameters)
+	}
+
+	plain, err := generateKey()
+	if err != nil {
+		return entities.APIKey{}, "", err
+	}
+
+	key := entities.APIKey{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:displayPrefixLength],
+		KeyHash:   hashKey(plain),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+	if err := uc.repo.CreateAPIKey(ctx, key); err != nil {
+		return entities.APIKey{}, "", fmt.Errorf("creating api key: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("api key created", "audit", true, "user_id", userID, "key_id", key.ID, "scopes", scopes)
+	return key, plain, nil
+}
+
+// List returns the user's keys, newest first.
+func (uc *UseCase) List(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error) {
+	return uc.repo.ListUserAPIKeys(ctx, userID)
+}
+
+// ListAll returns every user's keys, newest first.
+func (uc *UseCase) ListAll(ctx context.Context) ([]entities.APIKey, error) {
+	return uc.repo.ListAPIKeys(ctx)
+}
+
+// Revoke revokes one of the user's keys. Keys of other users are reported as
+// domain.ErrNotFound.
+func (uc *UseCase) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	key, err := uc.repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting api key: %w", err)
+	}
+	if key.UserID != userID {
+		return fmt.Errorf("getting api key: %w", domain.ErrNotFound)
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	if err := uc.repo.RevokeAPIKey(ctx, id); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("revoking api key: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("api key revoked", "audit", true, "user_id", userID, "key_id", id)
+	return nil
+}
+
+// RevokeAny revokes any user's key on behalf of the admin adminID.
+func (uc *UseCase) RevokeAny(ctx context.Context, id, adminID uuid.UUID) error {
+	key, err := uc.repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting api key: %w", err)
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: api key not revoked", "admin_id", adminID, "key_id", id)
+		return nil
+	}
+
+	if err := uc.repo.RevokeAPIKey(ctx, id); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("revoking api key: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("api key revoked by admin", "audit", true, "admin_id", adminID, "user_id", key.UserID, "key_id", id)
+	return nil
+}
+
+// Authenticate returns the key plain is the plain-text form of, as long as it
+// can still be used. Its last use is recorded at most once a minute; failing
+// to do so doesn't fail the call.
+func (uc *UseCase) Authenticate(ctx context.Context, plain string) (entities.APIKey, error) {
+	if !strings.HasPrefix(plain, KeyPrefix) {
+		return entities.APIKey{}, ErrInvalidKey
+	}
+
+	key, err := uc.repo.GetAPIKeyByHash(ctx, hashKey(plain))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.APIKey{}, ErrInvalidKey
+		}
+		return entities.APIKey{}, fmt.Errorf("getting api key: %w", err)
+	}
+
+	now := uc.now()
+	if !key.Active(now) {
+		return entities.APIKey{}, ErrInvalidKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := uc.repo.TouchAPIKey(ctx, key.ID); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Warn("failed to record api key use", "key_id", key.ID, "error", err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+	return key, nil
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating api key: %w", err)
+	}
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,203 @@
+package apikey
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/apikey/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Create(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		req     CreateRequest
+		wantErr error
+	}{
+		{
+			name: "stores the hash of a new key",
+			req: CreateRequest{
+				Name:   "ci",
+				Scopes: []entities.APIKeyScope{entities.APIKeyScopeExampleRead, entities.APIKeyScopeExampleRead},
+			},
+		},
+		{
+			name:    "requires a name",
+			req:     CreateRequest{Name: "  ", Scopes: []entities.APIKeyScope{entities.APIKeyScopeExampleRead}},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "requires a scope",
+			req:     CreateRequest{Name: "ci"},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects unknown scopes",
+			req:     CreateRequest{Name: "ci", Scopes: []entities.APIKeyScope{"users:delete"}},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects expiries in the past",
+			req:     CreateRequest{Name: "ci", Scopes: []entities.APIKeyScope{entities.APIKeyScopeExampleRead}, ExpiresAt: &past},
+			wantErr: domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo)
+
+			key, plain, err := uc.Create(context.Background(), userID, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.CreateAPIKeyCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.CreateAPIKeyCalls(), 1)
+
+			stored := repo.CreateAPIKeyCalls()[0].Key
+			assert.True(t, strings.HasPrefix(plain, KeyPrefix))
+			assert.True(t, strings.HasPrefix(plain, stored.Prefix))
+			assert.Equal(t, hashKey(plain), stored.KeyHash)
+			assert.Equal(t, userID, stored.UserID)
+			assert.Equal(t, []entities.APIKeyScope{entities.APIKeyScopeExampleRead}, stored.Scopes)
+			assert.Equal(t, stored, key)
+		})
+	}
+}
+
+func TestUseCase_Authenticate(t *testing.T) {
+	plain, err := generateKey()
+	require.NoError(t, err)
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	recently := now.Add(-10 * time.Second)
+
+	tests := []struct {
+		name    string
+		plain   string
+		key     entities.APIKey
+		lookErr error
+		wantErr error
+		touched bool
+	}{
+		{
+			name:    "accepts an active key and records its use",
+			plain:   plain,
+			key:     entities.APIKey{ID: uuid.Must(uuid.NewV4())},
+			touched: true,
+		},
+		{
+			name:  "keys used recently aren't touched again",
+			plain: plain,
+			key:   entities.APIKey{ID: uuid.Must(uuid.NewV4()), LastUsedAt: &recently},
+		},
+		{
+			name:    "rejects keys without the prefix",
+			plain:   "not-a-key",
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "rejects unknown keys",
+			plain:   plain,
+			lookErr: domain.ErrNotFound,
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "rejects revoked keys",
+			plain:   plain,
+			key:     entities.APIKey{ID: uuid.Must(uuid.NewV4()), RevokedAt: &recently},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "rejects expired keys",
+			plain:   plain,
+			key:     entities.APIKey{ID: uuid.Must(uuid.NewV4()), ExpiresAt: &expired},
+			wantErr: ErrInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				GetAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (entities.APIKey, error) {
+					assert.Equal(t, hashKey(tt.plain), keyHash)
+					return tt.key, tt.lookErr
+				},
+			}
+			uc := newTestUseCase(repo)
+			uc.now = func() time.Time { return now }
+
+			key, err := uc.Authenticate(context.Background(), tt.plain)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.key.ID, key.ID)
+			if tt.touched {
+				require.Len(t, repo.TouchAPIKeyCalls(), 1)
+				assert.Equal(t, tt.key.ID, repo.TouchAPIKeyCalls()[0].ID)
+			} else {
+				assert.Empty(t, repo.TouchAPIKeyCalls())
+			}
+		})
+	}
+}
+
+func TestUseCase_Revoke(t *testing.T) {
+	owner := uuid.Must(uuid.NewV4())
+	key := entities.APIKey{ID: uuid.Must(uuid.NewV4()), UserID: owner}
+	repo := &mocks.RepositoryMock{
+		GetAPIKeyFunc: func(ctx context.Context, id uuid.UUID) (entities.APIKey, error) {
+			if id != key.ID {
+				return entities.APIKey{}, domain.ErrNotFound
+			}
+			return key, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	// Other users' keys look like they don't exist
+	err := uc.Revoke(context.Background(), uuid.Must(uuid.NewV4()), key.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Empty(t, repo.RevokeAPIKeyCalls())
+
+	require.NoError(t, uc.Revoke(context.Background(), owner, key.ID))
+	require.Len(t, repo.RevokeAPIKeyCalls(), 1)
+	assert.Equal(t, key.ID, repo.RevokeAPIKeyCalls()[0].ID)
+}
+
+func TestUseCase_RevokeAny(t *testing.T) {
+	key := entities.APIKey{ID: uuid.Must(uuid.NewV4()), UserID: uuid.Must(uuid.NewV4())}
+	repo := &mocks.RepositoryMock{
+		GetAPIKeyFunc: func(ctx context.Context, id uuid.UUID) (entities.APIKey, error) {
+			return key, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+	adminID := uuid.Must(uuid.NewV4())
+
+	require.NoError(t, uc.RevokeAny(domain.WithDryRun(context.Background()), key.ID, adminID))
+	assert.Empty(t, repo.RevokeAPIKeyCalls())
+
+	require.NoError(t, uc.RevokeAny(context.Background(), key.ID, adminID))
+	assert.Len(t, repo.RevokeAPIKeyCalls(), 1)
+}
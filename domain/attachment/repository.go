@@ -0,0 +1,41 @@
+package attachment
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository ExampleGetter FileStorage
+
+type Repository interface {
+	// CreateAttachment returns domain.ErrNotFound when the example is gone.
+	CreateAttachment(ctx context.Context, attachment entities.Attachment) error
+	// GetAttachment returns domain.ErrNotFound when the example has no such
+	// attachment.
+	GetAttachment(ctx context.Context, exampleID string, id uuid.UUID) (entities.Attachment, error)
+	ListAttachments(ctx context.Context, exampleID string) ([]entities.Attachment, error)
+	// DetachAttachment leaves the attachment without an example, for
+	// removal with the orphans. It returns domain.ErrNotFound when the
+	// example has no such attachment.
+	DetachAttachment(ctx context.Context, exampleID string, id uuid.UUID) error
+	// ListOrphanedAttachments returns up to limit attachments left without
+	// an example, oldest first.
+	ListOrphanedAttachments(ctx context.Context, limit int32) ([]entities.Attachment, error)
+	DeleteAttachment(ctx context.Context, id uuid.UUID) error
+}
+
+// ExampleGetter finds the examples files are attached to.
+type ExampleGetter interface {
+	GetExampleByID(ctx context.Context, id string) (entities.Example, error)
+}
+
+// FileStorage stores the attached files and links to them.
+type FileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
@@ -0,0 +1,251 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	batchSize = 100
+	// privatePrefix keeps the attached files from being served without a
+	// signed link.
+	privatePrefix = "private/"
+	// maxFileNameLength is the most bytes of a file name kept.
+	maxFileNameLength = 255
+)
+
+var (
+	ErrTooLarge = errors.New("attachment is too large")
+	ErrEmpty    = errors.New("attachment is empty")
+)
+
+// UseCase attaches files to examples. The files are kept private in file
+// storage and downloaded through short lived signed links. Files whose
+// example or attachment was deleted are removed by Run.
+type UseCase struct {
+	repo     Repository
+	examples ExampleGetter
+	files    FileStorage
+	maxSize  int64
+	urlTTL   time.Duration
+	logger   *slog.Logger
+}
+
+func NewUseCase(repo Repository, examples ExampleGetter, files FileStorage, maxSize int64, urlTTL time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		examples: examples,
+		files:    files,
+		maxSize:  maxSize,
+		urlTTL:   urlTTL,
+		logger:   logger,
+	}
+}
+
+// MaxSize is the largest file accepted, in bytes.
+func (uc *UseCase) MaxSize() int64 {
+	return uc.maxSize
+}
+
+// Upload attaches the file read from body to the example, uploaded by the
+// actor in ctx. The content type is told from the content, whatever the
+// client claimed it to be. It returns domain.ErrNotFound when there is no
+// such example.
+func (uc *UseCase) Upload(ctx context.Context, exampleID, fileName string, body io.Reader) (entities.Attachment, error) {
+	parsed, err := uuid.FromString(exampleID)
+	if err != nil {
+		return entities.Attachment{}, domain.ErrNotFound
+	}
+	// The ID is part of the file's key, so only its canonical form is used
+	exampleID = parsed.String()
+
+	data, err := io.ReadAll(io.LimitReader(body, uc.maxSize+1))
+	if err != nil {
+		return entities.Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if int64(len(data)) > uc.maxSize {
+		return entities.Attachment{}, ErrTooLarge
+	}
+	if len(data) == 0 {
+		return entities.Attachment{}, ErrEmpty
+	}
+
+	if _, err := uc.examples.GetExampleByID(ctx, exampleID); err != nil {
+		return entities.Attachment{}, err
+	}
+
+	id := uuid.Must(uuid.NewV4())
+	fileName = cleanFileName(fileName)
+	attachment := entities.Attachment{
+		ID:          id,
+		ExampleID:   exampleID,
+		FileName:    fileName,
+		ContentType: http.DetectContentType(data),
+		Size:        int64(len(data)),
+		FileKey:     fmt.Sprintf("%sattachments/%s/%s%s", privatePrefix, exampleID, id, fileExt(fileName)),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		attachment.UploadedBy = &actor
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: attachment not uploaded", "example_id", exampleID)
+		return attachment, nil
+	}
+
+	// The row goes first, so a file stored for an example deleted meanwhile
+	// is still found and removed as an orphan
+	if err := uc.repo.CreateAttachment(ctx, attachment); err != nil {
+		return entities.Attachment{}, err
+	}
+	if err := uc.files.Put(ctx, attachment.FileKey, bytes.NewReader(data), attachment.ContentType); err != nil {
+		if err := uc.repo.DeleteAttachment(ctx, id); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Warn("failed to remove attachment without a file", "attachment_id", id, "error", err)
+		}
+		return entities.Attachment{}, fmt.Errorf("storing attachment: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "attachment uploaded", "audit", true, "resource", "example", "resource_id", exampleID, "attachment_id", id, "size", attachment.Size)
+	return attachment, nil
+}
+
+// List returns the example's attachments, oldest first, or
+// domain.ErrNotFound when there is no such example.
+func (uc *UseCase) List(ctx context.Context, exampleID string) ([]entities.Attachment, error) {
+	if _, err := uc.examples.GetExampleByID(ctx, exampleID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListAttachments(ctx, exampleID)
+}
+
+// Get returns the attachment with a fresh download link, or
+// domain.ErrNotFound when the example has no such attachment.
+func (uc *UseCase) Get(ctx context.Context, exampleID string, id uuid.UUID) (entities.Attachment, error) {
+	attachment, err := uc.repo.GetAttachment(ctx, exampleID, id)
+	if err != nil {
+		return entities.Attachment{}, err
+	}
+
+	expiresAt := time.Now().UTC().Add(uc.urlTTL)
+	url, err := uc.files.SignedURL(attachment.FileKey, uc.urlTTL)
+	if err != nil {
+		return entities.Attachment{}, fmt.Errorf("signing attachment download link: %w", err)
+	}
+	attachment.DownloadURL = url
+	attachment.DownloadURLExpiresAt = &expiresAt
+	return attachment, nil
+}
+
+// Delete removes the attachment from the example at once; its file is
+// removed by the next Run. It returns domain.ErrNotFound when the example
+// has no such attachment.
+func (uc *UseCase) Delete(ctx context.Context, exampleID string, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		if _, err := uc.repo.GetAttachment(ctx, exampleID, id); err != nil {
+			return err
+		}
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: attachment not deleted", "example_id", exampleID, "attachment_id", id)
+		return nil
+	}
+
+	if err := uc.repo.DetachAttachment(ctx, exampleID, id); err != nil {
+		return err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "attachment deleted", "audit", true, "resource", "example", "resource_id", exampleID, "attachment_id", id)
+	return nil
+}
+
+// Run removes a batch of attachments left without an example, and their
+// files. It returns how many it removed.
+func (uc *UseCase) Run(ctx context.Context) (int, error) {
+	orphans, err := uc.repo.ListOrphanedAttachments(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("listing orphaned attachments: %w", err)
+	}
+
+	removed := 0
+	for _, attachment := range orphans {
+		if err := uc.files.Delete(ctx, attachment.FileKey); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to delete attachment file", "attachment_id", attachment.ID, "error", err)
+			continue
+		}
+		if err := uc.repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Start removes orphaned attachments every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("attachment cleanup failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("removed orphaned attachments", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanFileName keeps the base name the client sent, without control
+// characters and cut to maxFileNameLength bytes.
+func cleanFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(name)
+	for len(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+// fileExt is the file name's extension when it is a plain one, so the
+// stored file keeps it.
+func fileExt(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
@@ -0,0 +1,218 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/attachment/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, examples ExampleGetter, files FileStorage) *UseCase {
+	return NewUseCase(repo, examples, files, 16, 5*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func existingExamples() *mocks.ExampleGetterMock {
+	return &mocks.ExampleGetterMock{
+		GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{ID: id}, nil
+		},
+	}
+}
+
+func TestUseCase_Upload(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	exampleID := uuid.Must(uuid.NewV4()).String()
+	ctx := domain.WithActor(context.Background(), actor)
+
+	t.Run("stores the file", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		files := &mocks.FileStorageMock{}
+		stored := ""
+		files.PutFunc = func(ctx context.Context, key string, r io.Reader, contentType string) error {
+			data, err := io.ReadAll(r)
+			stored = string(data)
+			return err
+		}
+		uc := newTestUseCase(repo, existingExamples(), files)
+
+		got, err := uc.Upload(ctx, strings.ToUpper(exampleID), "../../notes/Report.TXT", strings.NewReader("hello"))
+		require.NoError(t, err)
+
+		assert.Equal(t, exampleID, got.ExampleID, "the example ID is canonical")
+		assert.Equal(t, "Report.TXT", got.FileName)
+		assert.Equal(t, "text/plain; charset=utf-8", got.ContentType)
+		assert.Equal(t, int64(5), got.Size)
+		assert.Equal(t, &actor, got.UploadedBy)
+		assert.Equal(t, "private/attachments/"+exampleID+"/"+got.ID.String()+".txt", got.FileKey)
+		require.Len(t, repo.CreateAttachmentCalls(), 1)
+		assert.Equal(t, got, repo.CreateAttachmentCalls()[0].Attachment)
+		require.Len(t, files.PutCalls(), 1)
+		assert.Equal(t, got.FileKey, files.PutCalls()[0].Key)
+		assert.Equal(t, "hello", stored)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, existingExamples(), &mocks.FileStorageMock{})
+
+		_, err := uc.Upload(ctx, exampleID, "big.bin", strings.NewReader(strings.Repeat("x", 17)))
+		assert.ErrorIs(t, err, ErrTooLarge)
+		assert.Empty(t, repo.CreateAttachmentCalls())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		uc := newTestUseCase(&mocks.RepositoryMock{}, existingExamples(), &mocks.FileStorageMock{})
+
+		_, err := uc.Upload(ctx, exampleID, "empty.txt", strings.NewReader(""))
+		assert.ErrorIs(t, err, ErrEmpty)
+	})
+
+	t.Run("missing example", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		examples := &mocks.ExampleGetterMock{
+			GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+				return entities.Example{}, domain.ErrNotFound
+			},
+		}
+		uc := newTestUseCase(repo, examples, &mocks.FileStorageMock{})
+
+		_, err := uc.Upload(ctx, exampleID, "a.txt", strings.NewReader("a"))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = uc.Upload(ctx, "not-a-uuid", "a.txt", strings.NewReader("a"))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, repo.CreateAttachmentCalls())
+	})
+
+	t.Run("storage failure removes the row", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		files := &mocks.FileStorageMock{
+			PutFunc: func(ctx context.Context, key string, r io.Reader, contentType string) error {
+				return errors.New("disk full")
+			},
+		}
+		uc := newTestUseCase(repo, existingExamples(), files)
+
+		_, err := uc.Upload(ctx, exampleID, "a.txt", strings.NewReader("a"))
+		assert.Error(t, err)
+		require.Len(t, repo.DeleteAttachmentCalls(), 1)
+		assert.Equal(t, repo.CreateAttachmentCalls()[0].Attachment.ID, repo.DeleteAttachmentCalls()[0].ID)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		files := &mocks.FileStorageMock{}
+		uc := newTestUseCase(repo, existingExamples(), files)
+
+		_, err := uc.Upload(domain.WithDryRun(ctx), exampleID, "a.txt", strings.NewReader("a"))
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateAttachmentCalls())
+		assert.Empty(t, files.PutCalls())
+	})
+}
+
+func TestUseCase_Get(t *testing.T) {
+	exampleID := uuid.Must(uuid.NewV4()).String()
+	id := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		GetAttachmentFunc: func(ctx context.Context, gotExampleID string, gotID uuid.UUID) (entities.Attachment, error) {
+			if gotExampleID != exampleID || gotID != id {
+				return entities.Attachment{}, domain.ErrNotFound
+			}
+			return entities.Attachment{ID: id, ExampleID: exampleID, FileKey: "private/attachments/a"}, nil
+		},
+	}
+	files := &mocks.FileStorageMock{
+		SignedURLFunc: func(key string, ttl time.Duration) (string, error) {
+			return "https://files/" + key + "?signature=x", nil
+		},
+	}
+	uc := newTestUseCase(repo, existingExamples(), files)
+
+	got, err := uc.Get(context.Background(), exampleID, id)
+	require.NoError(t, err)
+	assert.Equal(t, "https://files/private/attachments/a?signature=x", got.DownloadURL)
+	require.NotNil(t, got.DownloadURLExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), *got.DownloadURLExpiresAt, time.Minute)
+	assert.Equal(t, 5*time.Minute, files.SignedURLCalls()[0].TTL)
+
+	_, err = uc.Get(context.Background(), uuid.Must(uuid.NewV4()).String(), id)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "attachments are only found through their example")
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	exampleID := uuid.Must(uuid.NewV4()).String()
+	id := uuid.Must(uuid.NewV4())
+
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo, existingExamples(), &mocks.FileStorageMock{})
+
+	require.NoError(t, uc.Delete(domain.WithDryRun(context.Background()), exampleID, id))
+	assert.Empty(t, repo.DetachAttachmentCalls())
+
+	require.NoError(t, uc.Delete(context.Background(), exampleID, id))
+	require.Len(t, repo.DetachAttachmentCalls(), 1)
+	assert.Equal(t, id, repo.DetachAttachmentCalls()[0].ID)
+
+	repo.DetachAttachmentFunc = func(ctx context.Context, exampleID string, id uuid.UUID) error {
+		return domain.ErrNotFound
+	}
+	assert.ErrorIs(t, uc.Delete(context.Background(), exampleID, id), domain.ErrNotFound)
+}
+
+func TestUseCase_Run(t *testing.T) {
+	kept := entities.Attachment{ID: uuid.Must(uuid.NewV4()), FileKey: "private/attachments/kept"}
+	gone := entities.Attachment{ID: uuid.Must(uuid.NewV4()), FileKey: "private/attachments/gone"}
+	repo := &mocks.RepositoryMock{
+		ListOrphanedAttachmentsFunc: func(ctx context.Context, limit int32) ([]entities.Attachment, error) {
+			return []entities.Attachment{kept, gone}, nil
+		},
+	}
+	files := &mocks.FileStorageMock{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			if key == kept.FileKey {
+				return errors.New("storage unavailable")
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, existingExamples(), files)
+
+	n, err := uc.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, repo.DeleteAttachmentCalls(), 1)
+	assert.Equal(t, gone.ID, repo.DeleteAttachmentCalls()[0].ID, "rows are kept until their file is gone")
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "report.pdf", want: "report.pdf"},
+		{name: `C:\Users\me\report.pdf`, want: "report.pdf"},
+		{name: "../../etc/passwd", want: "passwd"},
+		{name: "  spaced name.txt ", want: "spaced name.txt"},
+		{name: "bad\x00\nname.txt", want: "badname.txt"},
+		{name: "", want: "attachment"},
+		{name: "dir/..", want: "attachment"},
+		{name: strings.Repeat("é", 200), want: strings.Repeat("é", 127)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cleanFileName(tt.name), tt.name)
+	}
+
+	assert.Equal(t, ".pdf", fileExt("Report.PDF"))
+	assert.Empty(t, fileExt("script.sh;rm"))
+	assert.Empty(t, fileExt("noext"))
+}
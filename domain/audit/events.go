@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+)
+
+// UserCreated audits a new user account, subscribed to events.UserCreated.
+// Users signing up on their own are the actor.
+func (uc *UseCase) UserCreated(ctx context.Context, event events.UserCreated) error {
+	user := event.User
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user created", "audit", true, "user_id", user.ID, "email", user.Email,
+		"account_type", user.AccountType, "auth_provider", user.AuthProvider, "auth_provider_id", user.AuthProviderID, "status", user.Status)
+	return nil
+}
+
+// UserDeleted audits a deleted user account, subscribed to
+// events.UserDeleted.
+func (uc *UseCase) UserDeleted(ctx context.Context, event events.UserDeleted) error {
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user deleted", "audit", true, "user_id", event.User.ID, "email", event.User.Email)
+	return nil
+}
+
+// SettingsUpdated audits a change to the system settings, with the settings
+// as saved, subscribed to events.SettingsUpdated.
+func (uc *UseCase) SettingsUpdated(ctx context.Context, event events.SettingsUpdated) error {
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "system settings updated", "audit", true, "resource", entities.AuditResourceSettings, "settings", event.Settings)
+	return nil
+}
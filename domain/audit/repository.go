@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	CreateAuditEvent(ctx context.Context, event entities.AuditEvent) error
+	// ListAuditEvents returns the page of events matching filter, newest
+	// first. CountAuditEvents ignores the page.
+	ListAuditEvents(ctx context.Context, filter entities.AuditFilter) ([]entities.AuditEvent, error)
+	CountAuditEvents(ctx context.Context, filter entities.AuditFilter) (int64, error)
+	// ReassignAuditEvents points the events a user made or was the resource
+	// of at tombstoneID, and drops the email from their details.
+	ReassignAuditEvents(ctx context.Context, userID, tombstoneID uuid.UUID) error
+}
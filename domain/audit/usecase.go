@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// UseCase stores audit events and lets admins search them. Events come from
+// log records marked as audit events (see internal/auditlog), so they are
+// kept even when the user they are about is deleted; anonymization re-points
+// them at the user's tombstone.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// List returns a page of the events matching filter, newest first.
+func (uc *UseCase) List(ctx context.Context, filter entities.AuditFilter) (entities.AuditEventListResponse, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return entities.AuditEventListResponse{}, fmt.Errorf("%w: from must be before to", domain.ErrMalformedParameters)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > maxPageSize {
+		filter.PageSize = defaultPageSize
+	}
+
+	events, err := uc.repo.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return entities.AuditEventListResponse{}, err
+	}
+	if events == nil {
+		events = []entities.AuditEvent{}
+	}
+
+	total, err := uc.repo.CountAuditEvents(ctx, filter)
+	if err != nil {
+		return entities.AuditEventListResponse{}, err
+	}
+
+	return entities.AuditEventListResponse{
+		Events:     events,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+	}, nil
+}
+
+// Start stores the events received on events until ctx is done. An event
+// that can't be stored is logged with the error and skipped.
+func (uc *UseCase) Start(ctx context.Context, events <-chan entities.AuditEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if err := uc.repo.CreateAuditEvent(ctx, event); err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to store audit event", "action", event.Action, "error", err)
+			}
+		}
+	}
+}
+
+// Name and Scrub make the audit log an anonymization.Scrubber.
+func (uc *UseCase) Name() string {
+	return "audit_events"
+}
+
+func (uc *UseCase) Scrub(ctx context.Context, userID, tombstoneID uuid.UUID) error {
+	return uc.repo.ReassignAuditEvents(ctx, userID, tombstoneID)
+}
@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/audit/mocks"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"go-template/internal/auditlog"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_List(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		filter       entities.AuditFilter
+		wantPage     int
+		wantPageSize int
+		wantErr      error
+	}{
+		{
+			name:         "defaults the page",
+			filter:       entities.AuditFilter{Action: "user deleted"},
+			wantPage:     1,
+			wantPageSize: defaultPageSize,
+		},
+		{
+			name:         "caps the page size",
+			filter:       entities.AuditFilter{Page: 3, PageSize: 1000},
+			wantPage:     3,
+			wantPageSize: defaultPageSize,
+		},
+		{
+			name:         "time range",
+			filter:       entities.AuditFilter{From: &earlier, To: &now, PageSize: 10},
+			wantPage:     1,
+			wantPageSize: 10,
+		},
+		{
+			name:    "range ending before it starts",
+			filter:  entities.AuditFilter{From: &now, To: &earlier},
+			wantErr: domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				ListAuditEventsFunc: func(ctx context.Context, filter entities.AuditFilter) ([]entities.AuditEvent, error) {
+					return []entities.AuditEvent{{ID: 1, Action: "user deleted"}}, nil
+				},
+				CountAuditEventsFunc: func(ctx context.Context, filter entities.AuditFilter) (int64, error) {
+					return 101, nil
+				},
+			}
+
+			resp, err := newTestUseCase(repo).List(context.Background(), tt.filter)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.ListAuditEventsCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			calls := repo.ListAuditEventsCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, tt.wantPage, calls[0].Filter.Page)
+			assert.Equal(t, tt.wantPageSize, calls[0].Filter.PageSize)
+			assert.Equal(t, tt.filter.Action, calls[0].Filter.Action)
+
+			assert.Len(t, resp.Events, 1)
+			assert.Equal(t, int64(101), resp.Total)
+			assert.Equal(t, tt.wantPage, resp.Page)
+			assert.Equal(t, (101+tt.wantPageSize-1)/tt.wantPageSize, resp.TotalPages)
+		})
+	}
+}
+
+func TestUseCase_Start(t *testing.T) {
+	stored := make(chan entities.AuditEvent, 2)
+	repo := &mocks.RepositoryMock{
+		CreateAuditEventFunc: func(ctx context.Context, event entities.AuditEvent) error {
+			stored <- event
+			if event.Action == "broken" {
+				return errors.New("db down")
+			}
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan entities.AuditEvent, 2)
+	go newTestUseCase(repo).Start(ctx, events)
+
+	events <- entities.AuditEvent{Action: "broken"}
+	events <- entities.AuditEvent{Action: "user deleted"}
+
+	assert.Equal(t, "broken", (<-stored).Action)
+	assert.Equal(t, "user deleted", (<-stored).Action, "a failed event doesn't stop the others")
+}
+
+func TestUseCase_Scrub(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	tombstoneID := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{}
+
+	require.NoError(t, newTestUseCase(repo).Scrub(context.Background(), userID, tombstoneID))
+
+	calls := repo.ReassignAuditEventsCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, userID, calls[0].UserID)
+	assert.Equal(t, tombstoneID, calls[0].TombstoneID)
+}
+
+func TestUseCase_Subscribers(t *testing.T) {
+	recorder := auditlog.New(10)
+	uc := NewUseCase(&mocks.RepositoryMock{}, slog.New(recorder.Handler(slog.NewTextHandler(io.Discard, nil))))
+	bus := events.NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	events.Subscribe(bus, uc.UserCreated)
+	events.Subscribe(bus, uc.UserDeleted)
+	events.Subscribe(bus, uc.SettingsUpdated)
+
+	adminID := uuid.Must(uuid.NewV4())
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ada@example.com", AccountType: entities.AccountTypeUser}
+	ctx := domain.WithActor(context.Background(), adminID)
+	bus.Publish(context.Background(), events.UserCreated{User: user, Registered: true})
+	bus.Publish(ctx, events.UserDeleted{User: user})
+	bus.Publish(ctx, events.SettingsUpdated{Settings: entities.SystemSettings{RegistrationEnabled: true}})
+
+	require.Len(t, recorder.Events(), 3)
+	created := <-recorder.Events()
+	assert.Equal(t, "user created", created.Action)
+	assert.Equal(t, &user.ID, created.ActorID, "users signing up are the actor")
+	assert.Equal(t, user.ID.String(), created.ResourceID)
+	assert.Equal(t, "ada@example.com", created.Details["email"])
+
+	deleted := <-recorder.Events()
+	assert.Equal(t, "user deleted", deleted.Action)
+	assert.Equal(t, &adminID, deleted.ActorID)
+	assert.Equal(t, entities.AuditResourceUser, deleted.Resource)
+
+	updated := <-recorder.Events()
+	assert.Equal(t, "system settings updated", updated.Action)
+	assert.Equal(t, &adminID, updated.ActorID)
+	assert.Equal(t, entities.AuditResourceSettings, updated.Resource)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+)
+
+// SetRegistrationApproval makes users created on their first login, with a
+// social provider or an auth provider account made elsewhere, wait for an
+// admin's approval while the RequireApproval setting is on. They are turned
+// away with domain.ErrAccountPending until approved.
+func (uc *UseCase) SetRegistrationApproval(settings SettingsReader) {
+	uc.approvalSettings = settings
+}
+
+// newUserStatus is the status users created on their first login start
+// with.
+func (uc *UseCase) newUserStatus(ctx context.Context) (entities.UserStatus, error) {
+	if uc.approvalSettings == nil {
+		return entities.UserStatusActive, nil
+	}
+	settings, err := uc.approvalSettings.GetSettings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get settings: %w", err)
+	}
+	if settings.RequireApproval {
+		return entities.UserStatusPending, nil
+	}
+	return entities.UserStatusActive, nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+)
+
+var (
+	// ErrCaptchaRequired is returned when the RequireCaptcha setting is on
+	// and no CAPTCHA response was sent.
+	ErrCaptchaRequired = errors.New("captcha is required")
+	// ErrInvalidCaptcha is returned for CAPTCHA responses the provider
+	// rejects.
+	ErrInvalidCaptcha = errors.New("captcha verification failed")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/captcha.go . CaptchaVerifier
+
+// CaptchaVerifier checks the response of a CAPTCHA widget with its provider.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token string) (bool, error)
+}
+
+// CaptchaConfig tells clients which CAPTCHA widget to render.
+type CaptchaConfig struct {
+	Required bool `json:"required"`
+	// Provider is hcaptcha or turnstile
+	Provider string `json:"provider,omitempty"`
+	SiteKey  string `json:"site_key,omitempty"`
+}
+
+type captcha struct {
+	verifier CaptchaVerifier
+	settings SettingsReader
+	provider string
+	siteKey  string
+}
+
+// SetCaptcha enables CAPTCHA checks on registration and login. They only
+// apply while the RequireCaptcha setting is on. provider and siteKey are
+// handed to clients to render the widget.
+func (uc *UseCase) SetCaptcha(verifier CaptchaVerifier, settings SettingsReader, provider, siteKey string) {
+	uc.captcha = &captcha{verifier: verifier, settings: settings, provider: provider, siteKey: siteKey}
+}
+
+// Captcha returns the widget clients must render, if any.
+func (uc *UseCase) Captcha(ctx context.Context) (CaptchaConfig, error) {
+	if uc.captcha == nil {
+		return CaptchaConfig{}, nil
+	}
+	settings, err := uc.captcha.settings.GetSettings(ctx)
+	if err != nil {
+		return CaptchaConfig{}, fmt.Errorf("failed to get settings: %w", err)
+	}
+	if !settings.RequireCaptcha {
+		return CaptchaConfig{}, nil
+	}
+	return CaptchaConfig{
+		Required: true,
+		Provider: uc.captcha.provider,
+		SiteKey:  uc.captcha.siteKey,
+	}, nil
+}
+
+// VerifyCaptcha checks token while the RequireCaptcha setting is on, and
+// accepts anything otherwise.
+func (uc *UseCase) VerifyCaptcha(ctx context.Context, token string) error {
+	cfg, err := uc.Captcha(ctx)
+	if err != nil || !cfg.Required {
+		return err
+	}
+	if token == "" {
+		return ErrCaptchaRequired
+	}
+
+	ok, err := uc.captcha.verifier.Verify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		domain.LoggerOr(ctx, uc.logger).Warn("captcha verification failed", "audit", true)
+		return ErrInvalidCaptcha
+	}
+	return nil
+}
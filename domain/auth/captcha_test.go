@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"testing"
+)
+
+// fakeCaptcha accepts the token "good"
+type fakeCaptcha struct {
+	calls int
+}
+
+func (f *fakeCaptcha) Verify(ctx context.Context, token string) (bool, error) {
+	f.calls++
+	return token == "good", nil
+}
+
+func TestUseCase_VerifyCaptcha(t *testing.T) {
+	ctx := context.Background()
+	verifier := &fakeCaptcha{}
+	uc := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+
+	// Nothing is checked without a verifier
+	if err := uc.VerifyCaptcha(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// or while the setting is off
+	uc.SetCaptcha(verifier, staticSettings(entities.SystemSettings{}), "turnstile", "site-key")
+	if err := uc.VerifyCaptcha(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg, _ := uc.Captcha(ctx); cfg.Required || cfg.SiteKey != "" {
+		t.Fatalf("expected no captcha, got %+v", cfg)
+	}
+
+	uc.SetCaptcha(verifier, staticSettings(entities.SystemSettings{RequireCaptcha: true}), "turnstile", "site-key")
+	cfg, err := uc.Captcha(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Required || cfg.Provider != "turnstile" || cfg.SiteKey != "site-key" {
+		t.Fatalf("unexpected captcha config: %+v", cfg)
+	}
+
+	if err := uc.VerifyCaptcha(ctx, ""); !errors.Is(err, ErrCaptchaRequired) {
+		t.Fatalf("expected ErrCaptchaRequired, got %v", err)
+	}
+	if err := uc.VerifyCaptcha(ctx, "bad"); !errors.Is(err, ErrInvalidCaptcha) {
+		t.Fatalf("expected ErrInvalidCaptcha, got %v", err)
+	}
+	if err := uc.VerifyCaptcha(ctx, "good"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verifier.calls != 2 {
+		t.Fatalf("expected 2 verifications, got %d", verifier.calls)
+	}
+}
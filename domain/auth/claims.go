@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+)
+
+// ClaimsEnricher adds claims, such as roles, permissions or a tenant, to the
+// access tokens issued to user. It runs on every login and refresh, so the
+// claims follow changes to the user within one access token lifetime.
+type ClaimsEnricher interface {
+	EnrichClaims(ctx context.Context, user entities.User, claims *jwt.Claims) error
+}
+
+// AddClaimsEnricher runs enricher on every access token issued from now on,
+// after the enrichers added before it. A failing enricher fails the login or
+// refresh, so no token goes out without the claims services rely on.
+func (uc *UseCase) AddClaimsEnricher(enricher ClaimsEnricher) {
+	uc.enrichers = append(uc.enrichers, enricher)
+}
+
+func (uc *UseCase) enrichClaims(ctx context.Context, user entities.User, claims *jwt.Claims) error {
+	for _, enricher := range uc.enrichers {
+		if err := enricher.EnrichClaims(ctx, user, claims); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to enrich token claims", "user_id", user.ID, "error", err)
+			return fmt.Errorf("failed to enrich token claims: %w", err)
+		}
+	}
+	return nil
+}
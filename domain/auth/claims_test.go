@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"testing"
+)
+
+type claimsEnricherFunc func(ctx context.Context, user entities.User, claims *jwt.Claims) error
+
+func (f claimsEnricherFunc) EnrichClaims(ctx context.Context, user entities.User, claims *jwt.Claims) error {
+	return f(ctx, user, claims)
+}
+
+func TestUseCase_IssueTokens_EnrichesClaims(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	uc.AddClaimsEnricher(claimsEnricherFunc(func(ctx context.Context, u entities.User, claims *jwt.Claims) error {
+		claims.Roles = append(claims.Roles, string(u.AccountType))
+		claims.OrgID = "org-1"
+		return nil
+	}))
+	uc.AddClaimsEnricher(claimsEnricherFunc(func(ctx context.Context, u entities.User, claims *jwt.Claims) error {
+		claims.SetCustom("plan", "pro")
+		return nil
+	}))
+
+	issued, err := uc.IssueTokens(context.Background(), user, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := newJWT().ValidateToken(issued.Token)
+	if err != nil {
+		t.Fatalf("validating token: %v", err)
+	}
+	if !claims.HasRole(string(entities.AccountTypeUser)) {
+		t.Fatalf("expected the user role, got %v", claims.Roles)
+	}
+	if claims.OrgID != "org-1" {
+		t.Fatalf("expected org-1, got %q", claims.OrgID)
+	}
+	if claims.Custom["plan"] != "pro" {
+		t.Fatalf("expected the custom claim, got %v", claims.Custom)
+	}
+}
+
+func TestUseCase_IssueTokens_EnricherError(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	enrichErr := errors.New("boom")
+	uc.AddClaimsEnricher(claimsEnricherFunc(func(ctx context.Context, u entities.User, claims *jwt.Claims) error {
+		return enrichErr
+	}))
+
+	if _, err := uc.IssueTokens(context.Background(), user, ""); !errors.Is(err, enrichErr) {
+		t.Fatalf("expected the enricher error, got %v", err)
+	}
+}
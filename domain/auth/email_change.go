@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	// ErrEmailChangeDisabled is returned when no email sender is configured,
+	// or when the user's auth provider can't change emails.
+	ErrEmailChangeDisabled = errors.New("email change is not available")
+	// ErrInvalidEmailChangeToken is returned for unknown, expired or used
+	// tokens.
+	ErrInvalidEmailChangeToken = errors.New("invalid or expired email change token")
+	// ErrEmailUnchanged is returned when the new email is the user's current
+	// address.
+	ErrEmailUnchanged = errors.New("new email is the same as the current one")
+	// ErrEmailInUse is returned when the new email belongs to another user.
+	ErrEmailInUse = errors.New("email address already in use")
+	// ErrEmailChangeRequestTooSoon is returned when another change is
+	// requested before the resend interval has passed.
+	ErrEmailChangeRequestTooSoon = errors.New("an email change was requested recently, try again later")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/email_change.go . EmailChangeRepository
+
+type EmailChangeRepository interface {
+	// CreateEmailChangeToken stores the token and invalidates the user's
+	// earlier unused tokens.
+	CreateEmailChangeToken(ctx context.Context, token entities.EmailChangeToken) error
+	// GetEmailChangeToken returns the token with the given hash, or
+	// domain.ErrNotFound when there is none.
+	GetEmailChangeToken(ctx context.Context, tokenHash string) (entities.EmailChangeToken, error)
+	// GetLatestEmailChangeToken returns the user's newest token, or
+	// domain.ErrNotFound when there is none.
+	GetLatestEmailChangeToken(ctx context.Context, userID uuid.UUID) (entities.EmailChangeToken, error)
+	// UseEmailChangeToken marks an unused token as used, or returns
+	// domain.ErrNotFound when it was already used.
+	UseEmailChangeToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// EmailUpdater is implemented by auth providers that can change the email a
+// user signs in with, which email change needs for their users.
+type EmailUpdater interface {
+	UpdateEmail(ctx context.Context, authProviderID, email string) error
+}
+
+// EmailChangeConfig controls email change tokens.
+type EmailChangeConfig struct {
+	// ConfirmURL is the page the emailed link points to. The token is added
+	// as the token query parameter.
+	ConfirmURL string
+	// TTL is how long a token is valid.
+	TTL time.Duration
+	// ResendInterval is the minimum time between two change requests by the
+	// same user.
+	ResendInterval time.Duration
+}
+
+// DefaultEmailChangeConfig is used for zero EmailChangeConfig fields.
+var DefaultEmailChangeConfig = EmailChangeConfig{
+	TTL:            time.Hour,
+	ResendInterval: time.Minute,
+}
+
+type ChangeEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type emailChange struct {
+	tokens EmailChangeRepository
+	email  EmailSender
+	cfg    EmailChangeConfig
+}
+
+// SetEmailChange enables users to change their email, confirmed with
+// single-use tokens emailed by email to the new address.
+func (uc *UseCase) SetEmailChange(tokens EmailChangeRepository, email EmailSender, cfg EmailChangeConfig) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultEmailChangeConfig.TTL
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = DefaultEmailChangeConfig.ResendInterval
+	}
+	uc.emailChange = &emailChange{tokens: tokens, email: email, cfg: cfg}
+}
+
+// RequestEmailChange emails a confirmation link to newEmail. The user's
+// email stays the same until the link is opened.
+func (uc *UseCase) RequestEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error {
+	if uc.emailChange == nil {
+		return ErrEmailChangeDisabled
+	}
+	newEmail = strings.TrimSpace(newEmail)
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if _, ok := uc.emailUpdater(user); !ok {
+		return ErrEmailChangeDisabled
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return ErrEmailUnchanged
+	}
+	if err := uc.checkEmailAvailable(ctx, user.ID, newEmail); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	latest, err := uc.emailChange.tokens.GetLatestEmailChangeToken(ctx, user.ID)
+	switch {
+	case err == nil && now.Sub(latest.CreatedAt) < uc.emailChange.cfg.ResendInterval:
+		return ErrEmailChangeRequestTooSoon
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get email change token: %w", err)
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return err
+	}
+
+	err = uc.emailChange.tokens.CreateEmailChangeToken(ctx, entities.EmailChangeToken{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(uc.emailChange.cfg.TTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save email change token: %w", err)
+	}
+
+	link, err := tokenLink(uc.emailChange.cfg.ConfirmURL, token)
+	if err != nil {
+		return err
+	}
+	err = uc.emailChange.email.SendEmail(ctx, entities.Email{
+		To:       newEmail,
+		Template: entities.EmailChange,
+		Locale:   user.Locale,
+		Data: map[string]any{
+			"Link":             link,
+			"ExpiresInMinutes": int(uc.emailChange.cfg.TTL.Minutes()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email change email: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("email change requested", "audit", true, "user_id", user.ID)
+	return nil
+}
+
+// ConfirmEmailChange replaces the user's email with the address a token sent
+// by RequestEmailChange was sent to, at the auth provider first and then in
+// the application. The new address counts as verified, and the old address
+// is told about the change.
+func (uc *UseCase) ConfirmEmailChange(ctx context.Context, token string) (entities.User, error) {
+	if uc.emailChange == nil {
+		return entities.User{}, ErrEmailChangeDisabled
+	}
+
+	stored, err := uc.emailChange.tokens.GetEmailChangeToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidEmailChangeToken
+		}
+		return entities.User{}, fmt.Errorf("failed to get email change token: %w", err)
+	}
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return entities.User{}, ErrInvalidEmailChangeToken
+	}
+
+	user, err := uc.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidEmailChangeToken
+		}
+		return entities.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	updater, ok := uc.emailUpdater(user)
+	if !ok {
+		return entities.User{}, ErrEmailChangeDisabled
+	}
+	// The address may have been taken since the link was sent
+	if err := uc.checkEmailAvailable(ctx, user.ID, stored.NewEmail); err != nil {
+		return entities.User{}, err
+	}
+
+	now := time.Now()
+	if err := uc.emailChange.tokens.UseEmailChangeToken(ctx, stored.ID, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidEmailChangeToken
+		}
+		return entities.User{}, fmt.Errorf("failed to use email change token: %w", err)
+	}
+
+	if updater != nil {
+		if err := updater.UpdateEmail(ctx, user.AuthProviderID, stored.NewEmail); err != nil {
+			if errors.Is(err, domain.ErrDuplicateKey) {
+				return entities.User{}, ErrEmailInUse
+			}
+			return entities.User{}, fmt.Errorf("failed to update email at auth provider: %w", err)
+		}
+	}
+	if err := uc.repo.SetEmail(ctx, user.ID, stored.NewEmail, now); err != nil {
+		// Put the provider back so the user can still sign in
+		if updater != nil {
+			if rbErr := updater.UpdateEmail(ctx, user.AuthProviderID, user.Email); rbErr != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to restore email at auth provider", "user_id", user.ID, "error", rbErr)
+			}
+		}
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return entities.User{}, ErrEmailInUse
+		}
+		return entities.User{}, fmt.Errorf("failed to set email: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user email changed", "audit", true, "user_id", user.ID)
+
+	err = uc.emailChange.email.SendEmail(ctx, entities.Email{
+		To:       user.Email,
+		Template: entities.EmailChanged,
+		Locale:   user.Locale,
+		Data:     map[string]any{"NewEmail": stored.NewEmail},
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to send email change notice", "user_id", user.ID, "error", err)
+	}
+
+	return uc.repo.GetByID(ctx, user.ID)
+}
+
+// emailUpdater returns the provider that has to change the user's email
+// too, or nil for users of social providers, whose email is only kept here.
+// It reports false when the user's provider can't change emails.
+func (uc *UseCase) emailUpdater(user entities.User) (EmailUpdater, bool) {
+	if user.AuthProvider != uc.authProvider.Provider() {
+		return nil, true
+	}
+	updater, ok := providerAs[EmailUpdater](uc.authProvider)
+	return updater, ok
+}
+
+func (uc *UseCase) checkEmailAvailable(ctx context.Context, userID uuid.UUID, email string) error {
+	owner, err := uc.repo.GetByEmail(ctx, email)
+	switch {
+	case err == nil && owner.ID != userID:
+		return ErrEmailInUse
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memEmailChanges is an in-memory EmailChangeRepository
+type memEmailChanges struct {
+	mu     sync.Mutex
+	tokens map[string]entities.EmailChangeToken
+}
+
+func newMemEmailChanges() *memEmailChanges {
+	return &memEmailChanges{tokens: map[string]entities.EmailChangeToken{}}
+}
+
+func (m *memEmailChanges) CreateEmailChangeToken(ctx context.Context, token entities.EmailChangeToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.UserID == token.UserID && t.UsedAt == nil {
+			t.UsedAt = &token.CreatedAt
+			m.tokens[hash] = t
+		}
+	}
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *memEmailChanges) GetEmailChangeToken(ctx context.Context, tokenHash string) (entities.EmailChangeToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return entities.EmailChangeToken{}, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (m *memEmailChanges) GetLatestEmailChangeToken(ctx context.Context, userID uuid.UUID) (entities.EmailChangeToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var latest *entities.EmailChangeToken
+	for _, t := range m.tokens {
+		if t.UserID == userID && (latest == nil || t.CreatedAt.After(latest.CreatedAt)) {
+			latest = &t
+		}
+	}
+	if latest == nil {
+		return entities.EmailChangeToken{}, domain.ErrNotFound
+	}
+	return *latest, nil
+}
+
+func (m *memEmailChanges) UseEmailChangeToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.ID == id && t.UsedAt == nil {
+			t.UsedAt = &usedAt
+			m.tokens[hash] = t
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// emailProvider is a mockProvider that can change emails
+type emailProvider struct {
+	mockProvider
+	emails map[string]string
+}
+
+func (p *emailProvider) UpdateEmail(ctx context.Context, authProviderID, email string) error {
+	p.emails[authProviderID] = email
+	return nil
+}
+
+// newEmailChangeTestUseCase returns a use case whose repository holds user
+// and other, another user.
+func newEmailChangeTestUseCase(user *entities.User, other entities.User) (*UseCase, *memEmail, *emailProvider) {
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			switch email {
+			case user.Email:
+				return *user, nil
+			case other.Email:
+				return other, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return *user, nil
+		},
+		setEmailFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+			user.Email = email
+			user.EmailVerified = true
+			return nil
+		},
+	}
+	email := &memEmail{}
+	provider := &emailProvider{emails: map[string]string{}}
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
+	uc.SetEmailChange(newMemEmailChanges(), email, EmailChangeConfig{ConfirmURL: "https://app.test/confirm-email"})
+	return uc, email, provider
+}
+
+func TestUseCase_ConfirmEmailChange(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "old@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, provider := newEmailChangeTestUseCase(user, entities.User{})
+	ctx := context.Background()
+
+	if err := uc.RequestEmailChange(ctx, user.ID, "new@b.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.to) != 1 || email.to[0] != "new@b.com" {
+		t.Fatalf("expected the link to go to the new address, got %v", email.to)
+	}
+	if user.Email != "old@b.com" {
+		t.Fatalf("expected the email to stay until confirmed, got %s", user.Email)
+	}
+
+	token := email.lastLinkToken(t)
+	updated, err := uc.ConfirmEmailChange(ctx, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Email != "new@b.com" || !updated.EmailVerified {
+		t.Fatalf("unexpected user: %+v", updated)
+	}
+	if provider.emails["prov-123"] != "new@b.com" {
+		t.Fatalf("expected the provider email to change, got %q", provider.emails["prov-123"])
+	}
+	if email.to[len(email.to)-1] != "old@b.com" {
+		t.Fatalf("expected a notice to the old address, got %v", email.to)
+	}
+
+	// Tokens are single use
+	if _, err := uc.ConfirmEmailChange(ctx, token); !errors.Is(err, ErrInvalidEmailChangeToken) {
+		t.Fatalf("expected ErrInvalidEmailChangeToken on reuse, got %v", err)
+	}
+}
+
+func TestUseCase_RequestEmailChange_Validation(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "old@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	other := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "taken@b.com"}
+	uc, email, _ := newEmailChangeTestUseCase(user, other)
+	ctx := context.Background()
+
+	if err := uc.RequestEmailChange(ctx, user.ID, "OLD@b.com"); !errors.Is(err, ErrEmailUnchanged) {
+		t.Fatalf("expected ErrEmailUnchanged, got %v", err)
+	}
+	if err := uc.RequestEmailChange(ctx, user.ID, other.Email); !errors.Is(err, ErrEmailInUse) {
+		t.Fatalf("expected ErrEmailInUse, got %v", err)
+	}
+
+	if err := uc.RequestEmailChange(ctx, user.ID, "new@b.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.RequestEmailChange(ctx, user.ID, "newer@b.com"); !errors.Is(err, ErrEmailChangeRequestTooSoon) {
+		t.Fatalf("expected ErrEmailChangeRequestTooSoon, got %v", err)
+	}
+	if len(email.sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(email.sent))
+	}
+
+	// Providers that can't change emails can't serve their users
+	repo := &mockRepository{getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return *user, nil }}
+	noUpdate := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	noUpdate.SetEmailChange(newMemEmailChanges(), email, EmailChangeConfig{})
+	if err := noUpdate.RequestEmailChange(ctx, user.ID, "new@b.com"); !errors.Is(err, ErrEmailChangeDisabled) {
+		t.Fatalf("expected ErrEmailChangeDisabled, got %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	// ErrEmailVerificationDisabled is returned when no email sender is
+	// configured.
+	ErrEmailVerificationDisabled = errors.New("email verification is not enabled")
+	// ErrInvalidVerificationToken is returned for unknown, expired or used
+	// tokens, and for tokens sent to an address the user no longer has.
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	// ErrEmailNotVerified is returned on login while the
+	// RequireEmailVerification setting is on and the user's email is not
+	// verified.
+	ErrEmailNotVerified = errors.New("email address is not verified")
+	// ErrEmailAlreadyVerified is returned when a verified user asks for a
+	// verification email.
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	// ErrVerificationRequestTooSoon is returned when a new verification email
+	// is requested before the resend interval has passed.
+	ErrVerificationRequestTooSoon = errors.New("a verification email was sent recently, try again later")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/email_verification.go . EmailVerificationRepository
+
+type EmailVerificationRepository interface {
+	// CreateEmailVerificationToken stores the token and invalidates the
+	// user's earlier unused tokens.
+	CreateEmailVerificationToken(ctx context.Context, token entities.EmailVerificationToken) error
+	// GetEmailVerificationToken returns the token with the given hash, or
+	// domain.ErrNotFound when there is none.
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (entities.EmailVerificationToken, error)
+	// GetLatestEmailVerificationToken returns the user's newest token, or
+	// domain.ErrNotFound when there is none.
+	GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (entities.EmailVerificationToken, error)
+	// UseEmailVerificationToken marks an unused token as used, or returns
+	// domain.ErrNotFound when it was already used.
+	UseEmailVerificationToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// EmailVerificationConfig controls email verification tokens.
+type EmailVerificationConfig struct {
+	// VerifyURL is the page the emailed link points to. The token is added
+	// as the token query parameter.
+	VerifyURL string
+	// TTL is how long a token is valid.
+	TTL time.Duration
+	// ResendInterval is the minimum time between two emails to the same
+	// user.
+	ResendInterval time.Duration
+}
+
+// DefaultEmailVerificationConfig is used for zero EmailVerificationConfig
+// fields.
+var DefaultEmailVerificationConfig = EmailVerificationConfig{
+	TTL:            24 * time.Hour,
+	ResendInterval: time.Minute,
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type emailVerification struct {
+	tokens   EmailVerificationRepository
+	email    EmailSender
+	settings SettingsReader
+	cfg      EmailVerificationConfig
+}
+
+// SetEmailVerification enables email verification with single-use tokens
+// emailed by email. settings decides whether logins require a verified
+// email.
+func (uc *UseCase) SetEmailVerification(tokens EmailVerificationRepository, email EmailSender, settings SettingsReader, cfg EmailVerificationConfig) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultEmailVerificationConfig.TTL
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = DefaultEmailVerificationConfig.ResendInterval
+	}
+	uc.emailVerification = &emailVerification{tokens: tokens, email: email, settings: settings, cfg: cfg}
+}
+
+// EmailVerificationRequired reports whether the RequireEmailVerification
+// setting is on. It is always off without an email sender, since nobody
+// could verify their address.
+func (uc *UseCase) EmailVerificationRequired(ctx context.Context) (bool, error) {
+	if uc.emailVerification == nil || uc.emailVerification.settings == nil {
+		return false, nil
+	}
+	settings, err := uc.emailVerification.settings.GetSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return settings.RequireEmailVerification, nil
+}
+
+// SendVerificationEmail emails a verification link to the user's address.
+func (uc *UseCase) SendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	if uc.emailVerification == nil {
+		return ErrEmailVerificationDisabled
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	return uc.sendVerificationEmail(ctx, user, entities.EmailVerification)
+}
+
+// SendWelcomeEmail greets a newly registered user. The welcome email carries
+// the verification link, so it's sent in place of the first verification
+// email.
+func (uc *UseCase) SendWelcomeEmail(ctx context.Context, userID uuid.UUID) error {
+	if uc.emailVerification == nil {
+		return ErrEmailVerificationDisabled
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	return uc.sendVerificationEmail(ctx, user, entities.EmailWelcome)
+}
+
+// WelcomeRegisteredUser sends the welcome email to users who signed up with
+// the registration form, subscribed to events.UserCreated. Without email
+// verification nothing is sent.
+func (uc *UseCase) WelcomeRegisteredUser(ctx context.Context, event events.UserCreated) error {
+	if !event.Registered {
+		return nil
+	}
+	err := uc.SendWelcomeEmail(ctx, event.User.ID)
+	if errors.Is(err, ErrEmailVerificationDisabled) {
+		return nil
+	}
+	return err
+}
+
+// ResendVerificationEmail emails a new verification link to an unverified
+// user who can't sign in to ask for one. Unknown and verified emails, and
+// requests within the resend interval, get no email but no error either, so
+// the endpoint can't be used to discover registered addresses.
+func (uc *UseCase) ResendVerificationEmail(ctx context.Context, email string) error {
+	if uc.emailVerification == nil {
+		return ErrEmailVerificationDisabled
+	}
+
+	user, err := uc.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			domain.LoggerOr(ctx, uc.logger).Info("verification email requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	err = uc.sendVerificationEmail(ctx, user, entities.EmailVerification)
+	if errors.Is(err, ErrVerificationRequestTooSoon) {
+		domain.LoggerOr(ctx, uc.logger).Info("verification email requested too soon", "user_id", user.ID)
+		return nil
+	}
+	return err
+}
+
+// VerifyEmail marks the user's email as verified with a token sent by
+// SendVerificationEmail. The token only counts while the address it was sent
+// to is still the user's email.
+func (uc *UseCase) VerifyEmail(ctx context.Context, token string) (entities.User, error) {
+	if uc.emailVerification == nil {
+		return entities.User{}, ErrEmailVerificationDisabled
+	}
+
+	stored, err := uc.emailVerification.tokens.GetEmailVerificationToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidVerificationToken
+		}
+		return entities.User{}, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return entities.User{}, ErrInvalidVerificationToken
+	}
+
+	now := time.Now()
+	if err := uc.emailVerification.tokens.UseEmailVerificationToken(ctx, stored.ID, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidVerificationToken
+		}
+		return entities.User{}, fmt.Errorf("failed to use email verification token: %w", err)
+	}
+
+	if err := uc.repo.SetEmailVerified(ctx, stored.UserID, stored.Email, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.User{}, ErrInvalidVerificationToken
+		}
+		return entities.User{}, fmt.Errorf("failed to set email verified: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user email verified", "audit", true, "user_id", stored.UserID)
+	return uc.repo.GetByID(ctx, stored.UserID)
+}
+
+func (uc *UseCase) sendVerificationEmail(ctx context.Context, user entities.User, template entities.EmailTemplate) error {
+	now := time.Now()
+
+	latest, err := uc.emailVerification.tokens.GetLatestEmailVerificationToken(ctx, user.ID)
+	switch {
+	case err == nil && now.Sub(latest.CreatedAt) < uc.emailVerification.cfg.ResendInterval:
+		return ErrVerificationRequestTooSoon
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return err
+	}
+
+	err = uc.emailVerification.tokens.CreateEmailVerificationToken(ctx, entities.EmailVerificationToken{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(uc.emailVerification.cfg.TTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save email verification token: %w", err)
+	}
+
+	link, err := tokenLink(uc.emailVerification.cfg.VerifyURL, token)
+	if err != nil {
+		return err
+	}
+	err = uc.emailVerification.email.SendEmail(ctx, entities.Email{
+		To:       user.Email,
+		Template: template,
+		Locale:   user.Locale,
+		Data: map[string]any{
+			"Link":           link,
+			"ExpiresInHours": int(uc.emailVerification.cfg.TTL.Hours()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("verification email sent", "user_id", user.ID)
+	return nil
+}
+
+// checkEmailVerified returns ErrEmailNotVerified for users with an
+// unverified email while the RequireEmailVerification setting is on. Admins
+// are created by other admins and are not held back.
+func (uc *UseCase) checkEmailVerified(ctx context.Context, user entities.User) error {
+	if user.EmailVerified || user.AccountType == entities.AccountTypeAdmin || user.AccountType == entities.AccountTypeSuperAdmin {
+		return nil
+	}
+	required, err := uc.EmailVerificationRequired(ctx)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memEmailVerifications is an in-memory EmailVerificationRepository
+type memEmailVerifications struct {
+	mu     sync.Mutex
+	tokens map[string]entities.EmailVerificationToken
+}
+
+func newMemEmailVerifications() *memEmailVerifications {
+	return &memEmailVerifications{tokens: map[string]entities.EmailVerificationToken{}}
+}
+
+func (m *memEmailVerifications) CreateEmailVerificationToken(ctx context.Context, token entities.EmailVerificationToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.UserID == token.UserID && t.UsedAt == nil {
+			t.UsedAt = &token.CreatedAt
+			m.tokens[hash] = t
+		}
+	}
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *memEmailVerifications) GetEmailVerificationToken(ctx context.Context, tokenHash string) (entities.EmailVerificationToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return entities.EmailVerificationToken{}, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (m *memEmailVerifications) GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (entities.EmailVerificationToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var latest *entities.EmailVerificationToken
+	for _, t := range m.tokens {
+		if t.UserID == userID && (latest == nil || t.CreatedAt.After(latest.CreatedAt)) {
+			latest = &t
+		}
+	}
+	if latest == nil {
+		return entities.EmailVerificationToken{}, domain.ErrNotFound
+	}
+	return *latest, nil
+}
+
+func (m *memEmailVerifications) UseEmailVerificationToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.ID == id && t.UsedAt == nil {
+			t.UsedAt = &usedAt
+			m.tokens[hash] = t
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// newEmailVerificationTestUseCase returns a use case whose repository keeps
+// user in memory, so verifying the email shows up in later lookups.
+func newEmailVerificationTestUseCase(user *entities.User, settings entities.SystemSettings) (*UseCase, *memEmail) {
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email == user.Email {
+				return *user, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return *user, nil
+		},
+		setEmailVerifiedFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+			if id != user.ID || email != user.Email {
+				return domain.ErrNotFound
+			}
+			user.EmailVerified = true
+			user.EmailVerifiedAt = &verifiedAt
+			return nil
+		},
+	}
+	email := &memEmail{}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetEmailVerification(newMemEmailVerifications(), email, staticSettings(settings), EmailVerificationConfig{VerifyURL: "https://app.test/verify-email"})
+	return uc, email
+}
+
+func TestUseCase_VerifyEmail(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc, email := newEmailVerificationTestUseCase(user, entities.SystemSettings{RequireEmailVerification: true})
+	ctx := context.Background()
+
+	// Unverified users can't sign in while verification is required
+	if _, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"}); !errors.Is(err, ErrEmailNotVerified) {
+		t.Fatalf("expected ErrEmailNotVerified, got %v", err)
+	}
+
+	if err := uc.SendVerificationEmail(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := email.lastLinkToken(t)
+
+	verified, err := uc.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !verified.EmailVerified || verified.EmailVerifiedAt == nil {
+		t.Fatalf("expected a verified email, got %+v", verified)
+	}
+
+	// Tokens are single use
+	if _, err := uc.VerifyEmail(ctx, token); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("expected ErrInvalidVerificationToken on reuse, got %v", err)
+	}
+
+	if _, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.SendVerificationEmail(ctx, user.ID); !errors.Is(err, ErrEmailAlreadyVerified) {
+		t.Fatalf("expected ErrEmailAlreadyVerified, got %v", err)
+	}
+}
+
+func TestUseCase_VerifyEmail_ChangedEmail(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com"}
+	uc, email := newEmailVerificationTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	if err := uc.SendVerificationEmail(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	user.Email = "new@b.com"
+
+	// The token verifies the address it was sent to, not the new one
+	if _, err := uc.VerifyEmail(ctx, email.lastLinkToken(t)); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("expected ErrInvalidVerificationToken, got %v", err)
+	}
+	if user.EmailVerified {
+		t.Fatalf("expected the new email to stay unverified")
+	}
+}
+
+func TestUseCase_SendWelcomeEmail(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com"}
+	user.Locale = "pt-BR"
+	uc, email := newEmailVerificationTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	if err := uc.SendWelcomeEmail(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.sent) != 1 || email.sent[0].Template != entities.EmailWelcome || email.sent[0].Locale != "pt-BR" {
+		t.Fatalf("expected a welcome email in the user's locale, got %+v", email.sent)
+	}
+
+	// The welcome email's link verifies the address
+	if _, err := uc.VerifyEmail(ctx, email.lastLinkToken(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.SendWelcomeEmail(ctx, user.ID); !errors.Is(err, ErrEmailAlreadyVerified) {
+		t.Fatalf("expected ErrEmailAlreadyVerified, got %v", err)
+	}
+}
+
+func TestUseCase_WelcomeRegisteredUser(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com"}
+	uc, email := newEmailVerificationTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	// Only users who signed up on their own are welcomed
+	if err := uc.WelcomeRegisteredUser(ctx, events.UserCreated{User: *user}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.sent) != 0 {
+		t.Fatalf("expected no email, got %+v", email.sent)
+	}
+
+	if err := uc.WelcomeRegisteredUser(ctx, events.UserCreated{User: *user, Registered: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.sent) != 1 || email.sent[0].Template != entities.EmailWelcome {
+		t.Fatalf("expected a welcome email, got %+v", email.sent)
+	}
+
+	// Without email verification there is nothing to send
+	disabled := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	if err := disabled.WelcomeRegisteredUser(ctx, events.UserCreated{User: *user, Registered: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUseCase_SendVerificationEmail_Throttled(t *testing.T) {
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com"}
+	uc, email := newEmailVerificationTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	if err := uc.SendVerificationEmail(ctx, user.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.SendVerificationEmail(ctx, user.ID); !errors.Is(err, ErrVerificationRequestTooSoon) {
+		t.Fatalf("expected ErrVerificationRequestTooSoon, got %v", err)
+	}
+
+	// The public resend hides the throttling, and unknown emails
+	if err := uc.ResendVerificationEmail(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ResendVerificationEmail(ctx, "nobody@b.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.sent) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(email.sent))
+	}
+}
+
+func TestUseCase_Login_EmailVerificationNotRequired(t *testing.T) {
+	admin := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "admin@b.com", AccountType: entities.AccountTypeAdmin}
+	uc, _ := newEmailVerificationTestUseCase(admin, entities.SystemSettings{RequireEmailVerification: true})
+	ctx := context.Background()
+
+	// Admins are not held back by the setting
+	if _, err := uc.Login(ctx, LoginRequest{Email: admin.Email, Password: "secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user := &entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc, _ = newEmailVerificationTestUseCase(user, entities.SystemSettings{})
+	if _, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
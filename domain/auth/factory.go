@@ -1,21 +1,50 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"go-template/domain/entities"
+	"go-template/gateways/auth/auth0"
+	"go-template/gateways/auth/cognito"
+	"go-template/gateways/auth/dev"
+	"go-template/gateways/auth/local"
 	"go-template/gateways/auth/supabase"
+	"go-template/internal/breaker"
+	"slices"
+	"strings"
+	"sync"
 )
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/auth_provider_factory.go . AuthProviderFactory
 
+// ErrProviderDisabled is returned for auth providers the
+// AvailableAuthProviders setting doesn't enable.
+var ErrProviderDisabled = errors.New("auth provider is disabled")
+
 // AuthProviderFactory creates auth provider instances by name
 type AuthProviderFactory interface {
 	CreateProvider(providerName string) (Provider, error)
 	GetSupportedProviders() []string
+	// AvailableProviders returns the providers users can currently sign up
+	// and log in with.
+	AvailableProviders(ctx context.Context) ([]string, error)
+	// DefaultProvider returns the provider new users are registered with.
+	DefaultProvider(ctx context.Context) (string, error)
+	// CreateAvailableProvider is CreateProvider for available providers. It
+	// returns ErrProviderDisabled for the others.
+	CreateAvailableProvider(ctx context.Context, providerName string) (Provider, error)
 }
 
 // ProviderFactory implements AuthProviderFactory
 type ProviderFactory struct {
-	configs map[string]AuthConfig
+	configs  map[string]AuthConfig
+	settings SettingsReader
+	fallback string
+
+	guard      *GuardConfig
+	breakersMu sync.Mutex
+	breakers   map[string]*breaker.Breaker
 }
 
 // NewProviderFactory creates a new provider factory with auth configurations
@@ -25,29 +54,186 @@ func NewProviderFactory(configs map[string]AuthConfig) *ProviderFactory {
 	}
 }
 
-// CreateProvider creates an auth provider instance by name
+// CreateProvider creates an auth provider instance by name with the
+// constructor it was registered with. Providers without a configuration get
+// an empty one.
 func (f *ProviderFactory) CreateProvider(providerName string) (Provider, error) {
+	ctor, ok := lookupProvider(providerName)
+	if !ok {
+		return nil, fmt.Errorf("unsupported auth provider: %s (supported: %s)", providerName, strings.Join(RegisteredProviders(), ", "))
+	}
+
 	config, exists := f.configs[providerName]
 	if !exists {
-		return nil, fmt.Errorf("no configuration found for provider: %s", providerName)
+		config = AuthConfig{Provider: providerName}
 	}
+	provider, err := ctor(config)
+	if err != nil || f.guard == nil {
+		return provider, err
+	}
+	return &guardedProvider{
+		next:    provider,
+		breaker: f.breaker(providerName),
+		timeout: f.guard.Timeout,
+	}, nil
+}
 
-	switch providerName {
-	case "supabase":
-		if config.Supabase.URL == "" || config.Supabase.APIKey == "" {
-			return nil, fmt.Errorf("supabase configuration missing: url and api_key required")
-		}
-		return supabase.NewSupabaseProvider(config.Supabase.URL, config.Supabase.APIKey), nil
-	default:
-		return nil, fmt.Errorf("unsupported auth provider: %s (supported: supabase)", providerName)
+// SetGuard puts the providers the factory creates behind a per-call timeout
+// and a circuit breaker. Providers with the same name share the breaker, so
+// an outage is noticed across requests.
+func (f *ProviderFactory) SetGuard(cfg GuardConfig) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultGuardConfig.Timeout
 	}
+	f.guard = &cfg
+	f.breakers = map[string]*breaker.Breaker{}
 }
 
-// GetSupportedProviders returns list of supported provider names
+func (f *ProviderFactory) breaker(name string) *breaker.Breaker {
+	f.breakersMu.Lock()
+	defer f.breakersMu.Unlock()
+	b, ok := f.breakers[name]
+	if !ok {
+		b = newProviderBreaker(name, f.guard.Breaker)
+		f.breakers[name] = b
+	}
+	return b
+}
+
+// GetSupportedProviders returns the registered providers that have a
+// configuration, sorted
 func (f *ProviderFactory) GetSupportedProviders() []string {
 	var providers []string
 	for name := range f.configs {
-		providers = append(providers, name)
+		if _, ok := lookupProvider(name); ok {
+			providers = append(providers, name)
+		}
 	}
+	slices.Sort(providers)
 	return providers
 }
+
+// SetSettings makes the factory follow the AvailableAuthProviders and
+// DefaultAuthProvider settings, so admins can change them without a restart.
+// fallback is the provider the service was started with. It stays available
+// and is the default while the settings don't name a usable one, so a
+// settings change can't lock everyone out.
+func (f *ProviderFactory) SetSettings(settings SettingsReader, fallback string) {
+	f.settings = settings
+	f.fallback = fallback
+}
+
+// AvailableProviders returns the configured providers the settings enable,
+// sorted. Without settings, or when the setting is empty, every configured
+// provider is available.
+func (f *ProviderFactory) AvailableProviders(ctx context.Context) ([]string, error) {
+	if f.settings == nil {
+		return f.GetSupportedProviders(), nil
+	}
+	settings, err := f.settings.GetSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return f.availableProviders(settings), nil
+}
+
+// DefaultProvider returns the DefaultAuthProvider setting when that provider
+// is available, and the fallback given to SetSettings otherwise.
+func (f *ProviderFactory) DefaultProvider(ctx context.Context) (string, error) {
+	if f.settings == nil {
+		return f.fallback, nil
+	}
+	settings, err := f.settings.GetSettings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get settings: %w", err)
+	}
+	if slices.Contains(f.availableProviders(settings), settings.DefaultAuthProvider) {
+		return settings.DefaultAuthProvider, nil
+	}
+	return f.fallback, nil
+}
+
+// CreateAvailableProvider creates the provider when AvailableProviders lists
+// it.
+func (f *ProviderFactory) CreateAvailableProvider(ctx context.Context, providerName string) (Provider, error) {
+	available, err := f.AvailableProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(available, providerName) {
+		return nil, fmt.Errorf("%w: %s", ErrProviderDisabled, providerName)
+	}
+	return f.CreateProvider(providerName)
+}
+
+func (f *ProviderFactory) availableProviders(settings *entities.SystemSettings) []string {
+	configured := f.GetSupportedProviders()
+	if len(settings.AvailableAuthProviders) == 0 {
+		return configured
+	}
+
+	var available []string
+	for _, name := range configured {
+		if name == f.fallback || slices.Contains(settings.AvailableAuthProviders, name) {
+			available = append(available, name)
+		}
+	}
+	return available
+}
+
+// The providers that ship with the template
+func init() {
+	Register(supabase.ProviderName, newSupabaseProvider)
+	Register(local.ProviderName, newLocalProvider)
+	Register(cognito.ProviderName, newCognitoProvider)
+	Register(auth0.ProviderName, newAuth0Provider)
+	Register(dev.ProviderName, newDevProvider)
+}
+
+func newSupabaseProvider(config AuthConfig) (Provider, error) {
+	if config.Supabase.URL == "" || config.Supabase.APIKey == "" {
+		return nil, fmt.Errorf("supabase configuration missing: url and api_key required")
+	}
+	return supabase.NewSupabaseProvider(config.Supabase.URL, config.Supabase.APIKey), nil
+}
+
+func newLocalProvider(config AuthConfig) (Provider, error) {
+	if config.Local.Store == nil {
+		return nil, fmt.Errorf("local configuration missing: store required")
+	}
+	return local.NewProvider(config.Local.Store, config.Local.Tokens), nil
+}
+
+// newDevProvider creates the provider that accepts any password. It shares
+// the local provider's store.
+func newDevProvider(config AuthConfig) (Provider, error) {
+	if config.Local.Store == nil {
+		return nil, fmt.Errorf("dev configuration missing: store required")
+	}
+	return dev.NewProvider(config.Local.Store, config.Local.Tokens), nil
+}
+
+func newCognitoProvider(config AuthConfig) (Provider, error) {
+	if config.Cognito.Region == "" || config.Cognito.UserPoolID == "" || config.Cognito.ClientID == "" {
+		return nil, fmt.Errorf("cognito configuration missing: region, user_pool_id and client_id required")
+	}
+	return cognito.NewProvider(context.Background(), cognito.Config{
+		Region:       config.Cognito.Region,
+		UserPoolID:   config.Cognito.UserPoolID,
+		ClientID:     config.Cognito.ClientID,
+		ClientSecret: config.Cognito.ClientSecret,
+	})
+}
+
+func newAuth0Provider(config AuthConfig) (Provider, error) {
+	if config.Auth0.Domain == "" || config.Auth0.ClientID == "" || config.Auth0.ClientSecret == "" {
+		return nil, fmt.Errorf("auth0 configuration missing: domain, client_id and client_secret required")
+	}
+	return auth0.NewProvider(auth0.Config{
+		Domain:       config.Auth0.Domain,
+		ClientID:     config.Auth0.ClientID,
+		ClientSecret: config.Auth0.ClientSecret,
+		Connection:   config.Auth0.Connection,
+		Audience:     config.Auth0.Audience,
+	}), nil
+}
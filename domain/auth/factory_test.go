@@ -1,9 +1,21 @@
 package auth
 
 import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/gateways/auth/local"
+	"slices"
 	"testing"
+
+	"github.com/gofrs/uuid/v5"
 )
 
+// stubLocalStore satisfies local.Store for tests that never reach the store
+type stubLocalStore struct {
+	local.Store
+}
+
 func TestProviderFactory_CreateProvider_Supabase_Success(t *testing.T) {
 	configs := map[string]AuthConfig{
 		"supabase": {
@@ -43,6 +55,45 @@ func TestProviderFactory_CreateProvider_Supabase_MissingConfig(t *testing.T) {
 	}
 }
 
+func TestProviderFactory_CreateProvider_Cognito_MissingConfig(t *testing.T) {
+	configs := []map[string]AuthConfig{
+		{"cognito": {Provider: "cognito", Cognito: CognitoConfig{UserPoolID: "p", ClientID: "c"}}},
+		{"cognito": {Provider: "cognito", Cognito: CognitoConfig{Region: "r", ClientID: "c"}}},
+		{"cognito": {Provider: "cognito", Cognito: CognitoConfig{Region: "r", UserPoolID: "p"}}},
+	}
+
+	for _, configMap := range configs {
+		factory := NewProviderFactory(configMap)
+		if _, err := factory.CreateProvider("cognito"); err == nil {
+			t.Fatalf("expected error for missing cognito config, got nil")
+		}
+	}
+}
+
+func TestProviderFactory_CreateProvider_Auth0(t *testing.T) {
+	valid := Auth0Config{Domain: "tenant.auth0.com", ClientID: "c", ClientSecret: "s"}
+	factory := NewProviderFactory(map[string]AuthConfig{"auth0": {Provider: "auth0", Auth0: valid}})
+	p, err := factory.CreateProvider("auth0")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := p.Provider(); got != "auth0" {
+		t.Fatalf("expected provider name 'auth0', got %q", got)
+	}
+
+	missing := []Auth0Config{
+		{ClientID: "c", ClientSecret: "s"},
+		{Domain: "tenant.auth0.com", ClientSecret: "s"},
+		{Domain: "tenant.auth0.com", ClientID: "c"},
+	}
+	for _, cfg := range missing {
+		factory := NewProviderFactory(map[string]AuthConfig{"auth0": {Provider: "auth0", Auth0: cfg}})
+		if _, err := factory.CreateProvider("auth0"); err == nil {
+			t.Fatalf("expected error for missing auth0 config %+v, got nil", cfg)
+		}
+	}
+}
+
 func TestProviderFactory_CreateProvider_Unsupported(t *testing.T) {
 	configs := map[string]AuthConfig{
 		"supabase": {
@@ -79,4 +130,156 @@ func TestProviderFactory_GetSupportedProviders(t *testing.T) {
 	if providers[0] != "supabase" {
 		t.Fatalf("expected 'supabase', got %q", providers[0])
 	}
-}
\ No newline at end of file
+}
+func TestProviderFactory_CreateProvider_Local(t *testing.T) {
+	factory := NewProviderFactory(map[string]AuthConfig{
+		"local": {Provider: "local"},
+	})
+	if _, err := factory.CreateProvider("local"); err == nil {
+		t.Fatalf("expected error without a store")
+	}
+
+	factory = NewProviderFactory(map[string]AuthConfig{
+		"local": {Provider: "local", Local: LocalConfig{Store: stubLocalStore{}}},
+	})
+	p, err := factory.CreateProvider("local")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := p.Provider(); got != "local" {
+		t.Fatalf("expected provider name 'local', got %q", got)
+	}
+}
+
+func TestRegister_CustomProvider(t *testing.T) {
+	var got AuthConfig
+	Register("custom-test", func(cfg AuthConfig) (Provider, error) {
+		got = cfg
+		return &mockProvider{}, nil
+	})
+
+	factory := NewProviderFactory(map[string]AuthConfig{
+		"custom-test": {Provider: "custom-test", Options: map[string]string{"realm": "main"}},
+	})
+	if _, err := factory.CreateProvider("custom-test"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Options["realm"] != "main" {
+		t.Fatalf("expected the provider's configuration, got %+v", got)
+	}
+
+	providers := RegisteredProviders()
+	for _, name := range []string{"auth0", "cognito", "custom-test", "dev", "local", "supabase"} {
+		if !slices.Contains(providers, name) {
+			t.Fatalf("expected %q in registered providers %v", name, providers)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic when registering a name twice")
+		}
+	}()
+	Register("custom-test", func(cfg AuthConfig) (Provider, error) { return nil, nil })
+}
+
+func TestProviderFactory_Settings(t *testing.T) {
+	factory := NewProviderFactory(map[string]AuthConfig{
+		"supabase": {Provider: "supabase", Supabase: SupabaseConfig{URL: "http://localhost:54321", APIKey: "test-api-key"}},
+		"local":    {Provider: "local", Local: LocalConfig{Store: stubLocalStore{}}},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		settings  entities.SystemSettings
+		available []string
+		def       string
+	}{
+		{
+			name:      "settings default",
+			settings:  entities.SystemSettings{AvailableAuthProviders: []string{"supabase"}, DefaultAuthProvider: "supabase"},
+			available: []string{"local", "supabase"},
+			def:       "supabase",
+		},
+		{
+			name:      "default disabled",
+			settings:  entities.SystemSettings{AvailableAuthProviders: []string{"local"}, DefaultAuthProvider: "supabase"},
+			available: []string{"local"},
+			def:       "local",
+		},
+		{
+			name:      "default not configured",
+			settings:  entities.SystemSettings{AvailableAuthProviders: []string{"cognito"}, DefaultAuthProvider: "cognito"},
+			available: []string{"local"},
+			def:       "local",
+		},
+		{
+			name:      "no setting",
+			available: []string{"local", "supabase"},
+			def:       "local",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory.SetSettings(staticSettings(tt.settings), "local")
+
+			available, err := factory.AvailableProviders(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(available, tt.available) {
+				t.Fatalf("expected available %v, got %v", tt.available, available)
+			}
+
+			def, err := factory.DefaultProvider(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if def != tt.def {
+				t.Fatalf("expected default %q, got %q", tt.def, def)
+			}
+
+			_, err = factory.CreateAvailableProvider(ctx, "supabase")
+			if disabled := !slices.Contains(tt.available, "supabase"); disabled != errors.Is(err, ErrProviderDisabled) {
+				t.Fatalf("unexpected error creating supabase: %v", err)
+			}
+		})
+	}
+}
+
+func TestUseCase_Login_ProviderFactory(t *testing.T) {
+	var logins int
+	Register("login-test", func(cfg AuthConfig) (Provider, error) {
+		return &mockProvider{
+			loginFunc: func(ctx context.Context, email, password string) (string, error) {
+				logins++
+				return "ext-1", nil
+			},
+			providerFunc: func() string { return "login-test" },
+		}, nil
+	})
+
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "login-test", AccountType: entities.AccountTypeUser}
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) { return user, nil },
+	}
+	factory := NewProviderFactory(map[string]AuthConfig{"login-test": {Provider: "login-test"}})
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetProviderFactory(factory)
+	req := LoginRequest{Email: user.Email, Password: "pwd"}
+
+	factory.SetSettings(staticSettings(entities.SystemSettings{AvailableAuthProviders: []string{"login-test"}}), "supabase")
+	if _, err := uc.Login(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logins != 1 {
+		t.Fatalf("expected the user's provider to authenticate, got %d logins", logins)
+	}
+
+	// Disabling the provider in the settings takes effect on the next login
+	factory.SetSettings(staticSettings(entities.SystemSettings{AvailableAuthProviders: []string{"supabase"}}), "supabase")
+	if _, err := uc.Login(context.Background(), req); !errors.Is(err, ErrProviderDisabled) {
+		t.Fatalf("expected ErrProviderDisabled, got %v", err)
+	}
+}
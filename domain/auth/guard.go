@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/internal/breaker"
+	"go-template/internal/metrics"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// ErrProviderUnavailable is returned when the auth provider times out, fails
+// with a server error, or is not called at all because its circuit breaker is
+// open. It means the credentials were not checked, unlike an authentication
+// failure.
+var ErrProviderUnavailable = errors.New("auth provider is unavailable")
+
+// GuardConfig bounds the calls to an auth provider. Zero fields take their
+// DefaultGuardConfig value.
+type GuardConfig struct {
+	// Timeout bounds each Login, RegisterUser and ValidateToken call, so a
+	// slow provider can't use up the request's own timeout
+	Timeout time.Duration
+	// Breaker opens after consecutive unavailable calls
+	Breaker breaker.Config
+}
+
+// DefaultGuardConfig is used for zero GuardConfig fields.
+var DefaultGuardConfig = GuardConfig{
+	Timeout: 5 * time.Second,
+	Breaker: breaker.DefaultConfig,
+}
+
+// guardedProvider puts Login, RegisterUser and ValidateToken behind a
+// timeout and a circuit breaker. The other calls go straight through.
+type guardedProvider struct {
+	next    Provider
+	breaker *breaker.Breaker
+	timeout time.Duration
+}
+
+func (g *guardedProvider) Provider() string {
+	return g.next.Provider()
+}
+
+func (g *guardedProvider) Login(ctx context.Context, email, password string) (string, error) {
+	var id string
+	err := g.call(ctx, "login", func(ctx context.Context) (err error) {
+		id, err = g.next.Login(ctx, email, password)
+		return err
+	})
+	return id, err
+}
+
+func (g *guardedProvider) RegisterUser(ctx context.Context, email, password string) (string, error) {
+	var id string
+	err := g.call(ctx, "register_user", func(ctx context.Context) (err error) {
+		id, err = g.next.RegisterUser(ctx, email, password)
+		return err
+	})
+	return id, err
+}
+
+func (g *guardedProvider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	var user *entities.User
+	err := g.call(ctx, "validate_token", func(ctx context.Context) (err error) {
+		user, err = g.next.ValidateToken(ctx, token)
+		return err
+	})
+	return user, err
+}
+
+func (g *guardedProvider) DeleteUser(ctx context.Context, authProviderID string) error {
+	return g.next.DeleteUser(ctx, authProviderID)
+}
+
+func (g *guardedProvider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	return g.next.ListUsers(ctx)
+}
+
+// CheckHealth fails while the circuit is open, without calling the
+// provider, and otherwise checks the provider within the timeout when it can
+// be checked. The result isn't recorded by the breaker.
+func (g *guardedProvider) CheckHealth(ctx context.Context) error {
+	name := g.next.Provider()
+	if g.breaker.State() == breaker.StateOpen {
+		return fmt.Errorf("%w: %s: %w", ErrProviderUnavailable, name, breaker.ErrOpen)
+	}
+	checker, ok := g.next.(HealthChecker)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	return checker.CheckHealth(ctx)
+}
+
+// Unwrap returns the guarded provider, for the optional interfaces it
+// implements.
+func (g *guardedProvider) Unwrap() Provider {
+	return g.next
+}
+
+func (g *guardedProvider) call(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	name := g.next.Provider()
+	if err := g.breaker.Allow(); err != nil {
+		metrics.RecordAuthProviderUnavailable(name, method)
+		return fmt.Errorf("%w: %s: %w", ErrProviderUnavailable, name, err)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	err := fn(callCtx)
+
+	// A caller that went away says nothing about the provider
+	if ctx.Err() != nil {
+		g.breaker.Abort()
+		return err
+	}
+
+	unavailable := providerUnavailable(err)
+	g.breaker.Record(unavailable)
+	if unavailable {
+		metrics.RecordAuthProviderUnavailable(name, method)
+		return fmt.Errorf("%w: %s: %w", ErrProviderUnavailable, name, err)
+	}
+	return err
+}
+
+// HealthChecker is implemented by auth providers that can tell whether
+// they are up, for the readiness probe.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckProvider checks the auth provider users sign in with can be reached.
+// Providers that can't be checked, such as local, are taken to be up.
+func (uc *UseCase) CheckProvider(ctx context.Context) error {
+	checker, ok := uc.authProvider.(HealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckHealth(ctx)
+}
+
+// providerUnavailable reports whether err means the provider couldn't
+// answer, as opposed to rejecting the request. Provider errors can say so
+// with an Unavailable method.
+func providerUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var unavailable interface{ Unavailable() bool }
+	if errors.As(err, &unavailable) {
+		return unavailable.Unavailable()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// newProviderBreaker creates the breaker shared by the providers named name.
+func newProviderBreaker(name string, cfg breaker.Config) *breaker.Breaker {
+	cfg.OnStateChange = func(from, to breaker.State) {
+		metrics.SetAuthProviderCircuitOpen(name, to == breaker.StateOpen)
+		if to == breaker.StateOpen {
+			slog.Warn("auth provider circuit opened", "provider", name, "from", string(from))
+		} else {
+			slog.Info("auth provider circuit state changed", "provider", name, "from", string(from), "to", string(to))
+		}
+	}
+	return breaker.New(cfg)
+}
+
+// providerAs finds an optional interface such as PasswordUpdater on p,
+// looking through the guard.
+func providerAs[T any](p Provider) (T, bool) {
+	for {
+		if t, ok := p.(T); ok {
+			return t, true
+		}
+		u, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		p = u.Unwrap()
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/internal/breaker"
+	"testing"
+	"time"
+)
+
+func TestGuardedProvider(t *testing.T) {
+	var calls int
+	slow := true
+	provider := &passwordProvider{mockProvider: mockProvider{
+		loginFunc: func(ctx context.Context, email, password string) (string, error) {
+			calls++
+			if slow {
+				<-ctx.Done()
+				return "", ctx.Err()
+			}
+			return "", errors.New("invalid login credentials")
+		},
+	}}
+	g := &guardedProvider{
+		next:    provider,
+		breaker: breaker.New(breaker.Config{Threshold: 2, Cooldown: time.Hour}),
+		timeout: time.Millisecond,
+	}
+	ctx := context.Background()
+
+	// Timeouts count against the provider
+	for range 2 {
+		if _, err := g.Login(ctx, "a@b.com", "pwd"); !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("expected ErrProviderUnavailable, got %v", err)
+		}
+	}
+
+	// The open circuit fails fast without calling the provider
+	if _, err := g.Login(ctx, "a@b.com", "pwd"); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected ErrProviderUnavailable, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 provider calls, got %d", calls)
+	}
+
+	// Rejected credentials are not an outage
+	g.breaker = breaker.New(breaker.Config{Threshold: 1, Cooldown: time.Hour})
+	slow = false
+	for range 2 {
+		if _, err := g.Login(ctx, "a@b.com", "pwd"); err == nil || errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("expected the provider's error, got %v", err)
+		}
+	}
+
+	if _, ok := providerAs[PasswordUpdater](g); !ok {
+		t.Fatalf("expected the guard to expose the provider's PasswordUpdater")
+	}
+	if _, ok := providerAs[EmailUpdater](g); ok {
+		t.Fatalf("expected no EmailUpdater")
+	}
+}
+
+func TestGuardedProvider_CheckHealth(t *testing.T) {
+	provider := &mockProvider{
+		loginFunc: func(ctx context.Context, email, password string) (string, error) {
+			return "", context.DeadlineExceeded
+		},
+	}
+	g := &guardedProvider{
+		next:    provider,
+		breaker: breaker.New(breaker.Config{Threshold: 1, Cooldown: time.Hour}),
+		timeout: time.Second,
+	}
+	uc := NewUseCase(&mockRepository{}, newMemRefreshTokens(), g, newJWT(), 0, discardLogger())
+	ctx := context.Background()
+
+	// Providers that can't be checked are up while their circuit is closed
+	if err := uc.CheckProvider(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, _ = g.Login(ctx, "a@b.com", "pwd")
+	if err := uc.CheckProvider(ctx); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected ErrProviderUnavailable, got %v", err)
+	}
+}
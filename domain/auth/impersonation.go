@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DefaultImpersonationTTL is how long impersonation tokens last unless
+// SetImpersonationTTL says otherwise.
+const DefaultImpersonationTTL = 15 * time.Minute
+
+// ErrImpersonationNotAllowed is returned for accounts that can't be
+// impersonated: admins, and the admin asking.
+var ErrImpersonationNotAllowed = errors.New("user can't be impersonated")
+
+// SetImpersonationTTL sets how long impersonation tokens last.
+func (uc *UseCase) SetImpersonationTTL(ttl time.Duration) {
+	uc.impersonationTTL = ttl
+}
+
+// Impersonate issues a Web app token that acts as the user on behalf of
+// admin, who is recorded in the token's act claim. There is no refresh
+// token: the admin has to start over once it expires. Only user accounts can
+// be impersonated.
+func (uc *UseCase) Impersonate(ctx context.Context, admin jwt.Actor, userID uuid.UUID) (AuthResponse, error) {
+	if admin.Subject == userID.String() {
+		return AuthResponse{}, ErrImpersonationNotAllowed
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("getting user: %w", err)
+	}
+	if user.AccountType != entities.AccountTypeUser {
+		return AuthResponse{}, ErrImpersonationNotAllowed
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: impersonation token not issued", "admin_id", admin.Subject, "user_id", user.ID)
+		return AuthResponse{User: user}, nil
+	}
+
+	ttl := uc.impersonationTTL
+	if ttl <= 0 {
+		ttl = DefaultImpersonationTTL
+	}
+	tokens := uc.jwtService.WithAudience(jwt.AudienceWeb)
+	claims := tokens.NewClaims(user.ID.String(), user.Email, user.AccountType.String(), ttl)
+	claims.Impersonator = &admin
+	if err := uc.enrichClaims(ctx, user, claims); err != nil {
+		return AuthResponse{}, err
+	}
+	token, err := tokens.Sign(claims)
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user impersonation started", "audit", true,
+		"admin_id", admin.Subject,
+		"admin_email", admin.Email,
+		"user_id", user.ID,
+		"jti", claims.ID,
+		"expires_at", claims.ExpiresAt.Time,
+	)
+
+	return AuthResponse{
+		Token:     token,
+		ExpiresIn: int(ttl.Seconds()),
+		User:      user,
+	}, nil
+}
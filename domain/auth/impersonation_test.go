@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestUseCase_Impersonate(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	uc.SetImpersonationTTL(10 * time.Minute)
+	admin := jwt.Actor{Subject: uuid.Must(uuid.NewV4()).String(), Email: "admin@b.com"}
+	ctx := context.Background()
+
+	resp, err := uc.Impersonate(ctx, admin, user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.RefreshToken != "" {
+		t.Fatal("expected no refresh token")
+	}
+	if resp.ExpiresIn != 600 {
+		t.Fatalf("expected the token to last 600s, got %d", resp.ExpiresIn)
+	}
+
+	claims, err := newJWT().ValidateToken(resp.Token)
+	if err != nil {
+		t.Fatalf("validating token: %v", err)
+	}
+	if claims.UserID != user.ID.String() || !claims.HasAudience(jwt.AudienceWeb) {
+		t.Fatalf("expected a web token for the user, got %+v", claims)
+	}
+	if !claims.Impersonated() || *claims.Impersonator != admin {
+		t.Fatalf("expected the admin in the act claim, got %+v", claims.Impersonator)
+	}
+
+	// Nothing is issued on a dry run
+	resp, err = uc.Impersonate(domain.WithDryRun(ctx), admin, user.ID)
+	if err != nil || resp.Token != "" {
+		t.Fatalf("expected no token on a dry run, got %q, %v", resp.Token, err)
+	}
+
+	if _, err := uc.Impersonate(ctx, admin, uuid.Must(uuid.NewV4())); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	self := jwt.Actor{Subject: user.ID.String()}
+	if _, err := uc.Impersonate(ctx, self, user.ID); !errors.Is(err, ErrImpersonationNotAllowed) {
+		t.Fatalf("expected ErrImpersonationNotAllowed for the admin themselves, got %v", err)
+	}
+}
+
+func TestUseCase_Impersonate_RejectsAdmins(t *testing.T) {
+	adminUser := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "other@b.com", AccountType: entities.AccountTypeAdmin}
+	repo := &mockRepository{}
+	repo.getByIDFunc = func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+		return adminUser, nil
+	}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), time.Hour, discardLogger())
+
+	_, err := uc.Impersonate(context.Background(), jwt.Actor{Subject: uuid.Must(uuid.NewV4()).String()}, adminUser.ID)
+	if !errors.Is(err, ErrImpersonationNotAllowed) {
+		t.Fatalf("expected ErrImpersonationNotAllowed, got %v", err)
+	}
+}
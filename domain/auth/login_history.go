@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Providers recorded in the login history for logins that don't go through
+// an auth or social provider
+const (
+	loginProviderSMS  = "sms"
+	loginProviderTOTP = "totp"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/login_recorder.go . LoginRecorder
+
+// LoginRecorder keeps the login history. Record must not fail the login, so
+// it reports nothing back.
+type LoginRecorder interface {
+	Record(ctx context.Context, event entities.LoginEvent)
+}
+
+// SetLoginRecorder records every attempt to sign in to a known account:
+// password, SMS and social logins and two-factor challenges. Logins waiting
+// on a two-factor challenge are recorded once it is completed.
+func (uc *UseCase) SetLoginRecorder(recorder LoginRecorder) {
+	uc.logins = recorder
+}
+
+// recordLogin records the outcome of completeLogin, or of a step before it
+// that failed with err.
+func (uc *UseCase) recordLogin(ctx context.Context, userID uuid.UUID, provider string, response AuthResponse, err error) {
+	if uc.logins == nil || (err == nil && response.MFARequired) {
+		return
+	}
+	event := entities.LoginEvent{
+		UserID:   userID,
+		Success:  err == nil,
+		Provider: provider,
+	}
+	if err != nil {
+		event.Reason = loginFailureReason(err)
+	}
+	uc.logins.Record(ctx, event)
+}
+
+// recordFailedLogin records a failed attempt on the account lookup finds
+// for key, an email or phone number. Attempts on unknown accounts aren't
+// recorded.
+func (uc *UseCase) recordFailedLogin(ctx context.Context, lookup func(context.Context, string) (entities.User, error), key, provider string, err error) {
+	if uc.logins == nil {
+		return
+	}
+	user, lookupErr := lookup(ctx, key)
+	if lookupErr != nil {
+		return
+	}
+	uc.recordLogin(ctx, user.ID, provider, AuthResponse{}, err)
+}
+
+func loginFailureReason(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrAccountSuspended):
+		return entities.LoginReasonAccountSuspended
+	case errors.Is(err, domain.ErrAccountPending):
+		return entities.LoginReasonAccountPending
+	case errors.Is(err, ErrEmailNotVerified):
+		return entities.LoginReasonEmailNotVerified
+	case errors.Is(err, ErrInvalidOTP):
+		return entities.LoginReasonInvalidCode
+	case errors.Is(err, ErrInvalidTOTP):
+		return entities.LoginReasonInvalidSecondFactor
+	case errors.Is(err, ErrTwoFactorLocked):
+		return entities.LoginReasonTwoFactorLocked
+	case errors.Is(err, ErrProviderUnavailable):
+		return entities.LoginReasonProviderUnavailable
+	default:
+		return entities.LoginReasonInvalidCredentials
+	}
+}
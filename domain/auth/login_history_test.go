@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// fakeLoginRecorder keeps the attempts it is given
+type fakeLoginRecorder struct {
+	events []entities.LoginEvent
+}
+
+func (f *fakeLoginRecorder) Record(ctx context.Context, event entities.LoginEvent) {
+	f.events = append(f.events, event)
+}
+
+func TestUseCase_Login_RecordsHistory(t *testing.T) {
+	user := entities.User{
+		ID:           uuid.Must(uuid.NewV4()),
+		Email:        "a@b.com",
+		AuthProvider: "supabase",
+		AccountType:  entities.AccountTypeUser,
+	}
+	password := "right"
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email != user.Email {
+				return entities.User{}, domain.ErrNotFound
+			}
+			return user, nil
+		},
+	}
+	provider := &mockProvider{
+		loginFunc: func(ctx context.Context, email, pw string) (string, error) {
+			if pw != password {
+				return "", errors.New("invalid login credentials")
+			}
+			return "prov-123", nil
+		},
+		providerFunc: func() string { return "supabase" },
+	}
+	recorder := &fakeLoginRecorder{}
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
+	uc.SetLoginRecorder(recorder)
+
+	if _, err := uc.Login(context.Background(), LoginRequest{Email: user.Email, Password: "wrong"}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if _, err := uc.Login(context.Background(), LoginRequest{Email: "nobody@b.com", Password: "wrong"}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if _, err := uc.Login(context.Background(), LoginRequest{Email: user.Email, Password: password}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected 2 recorded attempts, unknown accounts left out; got %d", len(recorder.events))
+	}
+	failed, succeeded := recorder.events[0], recorder.events[1]
+	if failed.UserID != user.ID || failed.Success || failed.Reason != entities.LoginReasonInvalidCredentials || failed.Provider != "supabase" {
+		t.Fatalf("unexpected failed attempt: %+v", failed)
+	}
+	if succeeded.UserID != user.ID || !succeeded.Success || succeeded.Reason != "" {
+		t.Fatalf("unexpected successful attempt: %+v", succeeded)
+	}
+}
+
+func TestUseCase_Login_RecordsSuspendedAccount(t *testing.T) {
+	user := entities.User{
+		ID:     uuid.Must(uuid.NewV4()),
+		Email:  "a@b.com",
+		Status: entities.UserStatusSuspended,
+	}
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) { return user, nil },
+	}
+	provider := &mockProvider{
+		loginFunc:    func(ctx context.Context, email, pw string) (string, error) { return "prov-123", nil },
+		providerFunc: func() string { return "supabase" },
+	}
+	recorder := &fakeLoginRecorder{}
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
+	uc.SetLoginRecorder(recorder)
+
+	if _, err := uc.Login(context.Background(), LoginRequest{Email: user.Email, Password: "pw"}); !errors.Is(err, domain.ErrAccountSuspended) {
+		t.Fatalf("expected ErrAccountSuspended, got %v", err)
+	}
+
+	events := recorder.events
+	if len(events) != 1 || events[0].Success || events[0].Reason != entities.LoginReasonAccountSuspended {
+		t.Fatalf("unexpected recorded attempts: %+v", events)
+	}
+}
@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	"context"
 	"go-template/domain/auth"
 	"sync"
 )
@@ -14,9 +15,18 @@ import (
 //
 //		// make and configure a mocked auth.AuthProviderFactory
 //		mockedAuthProviderFactory := &AuthProviderFactoryMock{
+//			AvailableProvidersFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the AvailableProviders method")
+//			},
+//			CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+//				panic("mock out the CreateAvailableProvider method")
+//			},
 //			CreateProviderFunc: func(providerName string) (auth.Provider, error) {
 //				panic("mock out the CreateProvider method")
 //			},
+//			DefaultProviderFunc: func(ctx context.Context) (string, error) {
+//				panic("mock out the DefaultProvider method")
+//			},
 //			GetSupportedProvidersFunc: func() []string {
 //				panic("mock out the GetSupportedProviders method")
 //			},
@@ -27,25 +37,130 @@ import (
 //
 //	}
 type AuthProviderFactoryMock struct {
+	// AvailableProvidersFunc mocks the AvailableProviders method.
+	AvailableProvidersFunc func(ctx context.Context) ([]string, error)
+
+	// CreateAvailableProviderFunc mocks the CreateAvailableProvider method.
+	CreateAvailableProviderFunc func(ctx context.Context, providerName string) (auth.Provider, error)
+
 	// CreateProviderFunc mocks the CreateProvider method.
 	CreateProviderFunc func(providerName string) (auth.Provider, error)
 
+	// DefaultProviderFunc mocks the DefaultProvider method.
+	DefaultProviderFunc func(ctx context.Context) (string, error)
+
 	// GetSupportedProvidersFunc mocks the GetSupportedProviders method.
 	GetSupportedProvidersFunc func() []string
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AvailableProviders holds details about calls to the AvailableProviders method.
+		AvailableProviders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// CreateAvailableProvider holds details about calls to the CreateAvailableProvider method.
+		CreateAvailableProvider []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProviderName is the providerName argument value.
+			ProviderName string
+		}
 		// CreateProvider holds details about calls to the CreateProvider method.
 		CreateProvider []struct {
 			// ProviderName is the providerName argument value.
 			ProviderName string
 		}
+		// DefaultProvider holds details about calls to the DefaultProvider method.
+		DefaultProvider []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetSupportedProviders holds details about calls to the GetSupportedProviders method.
 		GetSupportedProviders []struct {
 		}
 	}
-	lockCreateProvider        sync.RWMutex
-	lockGetSupportedProviders sync.RWMutex
+	lockAvailableProviders      sync.RWMutex
+	lockCreateAvailableProvider sync.RWMutex
+	lockCreateProvider          sync.RWMutex
+	lockDefaultProvider         sync.RWMutex
+	lockGetSupportedProviders   sync.RWMutex
+}
+
+// AvailableProviders calls AvailableProvidersFunc.
+func (mock *AuthProviderFactoryMock) AvailableProviders(ctx context.Context) ([]string, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockAvailableProviders.Lock()
+	mock.calls.AvailableProviders = append(mock.calls.AvailableProviders, callInfo)
+	mock.lockAvailableProviders.Unlock()
+	if mock.AvailableProvidersFunc == nil {
+		var (
+			stringsOut []string
+			errOut     error
+		)
+		return stringsOut, errOut
+	}
+	return mock.AvailableProvidersFunc(ctx)
+}
+
+// AvailableProvidersCalls gets all the calls that were made to AvailableProviders.
+// Check the length with:
+//
+//	len(mockedAuthProviderFactory.AvailableProvidersCalls())
+func (mock *AuthProviderFactoryMock) AvailableProvidersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockAvailableProviders.RLock()
+	calls = mock.calls.AvailableProviders
+	mock.lockAvailableProviders.RUnlock()
+	return calls
+}
+
+// CreateAvailableProvider calls CreateAvailableProviderFunc.
+func (mock *AuthProviderFactoryMock) CreateAvailableProvider(ctx context.Context, providerName string) (auth.Provider, error) {
+	callInfo := struct {
+		Ctx          context.Context
+		ProviderName string
+	}{
+		Ctx:          ctx,
+		ProviderName: providerName,
+	}
+	mock.lockCreateAvailableProvider.Lock()
+	mock.calls.CreateAvailableProvider = append(mock.calls.CreateAvailableProvider, callInfo)
+	mock.lockCreateAvailableProvider.Unlock()
+	if mock.CreateAvailableProviderFunc == nil {
+		var (
+			providerOut auth.Provider
+			errOut      error
+		)
+		return providerOut, errOut
+	}
+	return mock.CreateAvailableProviderFunc(ctx, providerName)
+}
+
+// CreateAvailableProviderCalls gets all the calls that were made to CreateAvailableProvider.
+// Check the length with:
+//
+//	len(mockedAuthProviderFactory.CreateAvailableProviderCalls())
+func (mock *AuthProviderFactoryMock) CreateAvailableProviderCalls() []struct {
+	Ctx          context.Context
+	ProviderName string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		ProviderName string
+	}
+	mock.lockCreateAvailableProvider.RLock()
+	calls = mock.calls.CreateAvailableProvider
+	mock.lockCreateAvailableProvider.RUnlock()
+	return calls
 }
 
 // CreateProvider calls CreateProviderFunc.
@@ -84,6 +199,42 @@ func (mock *AuthProviderFactoryMock) CreateProviderCalls() []struct {
 	return calls
 }
 
+// DefaultProvider calls DefaultProviderFunc.
+func (mock *AuthProviderFactoryMock) DefaultProvider(ctx context.Context) (string, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDefaultProvider.Lock()
+	mock.calls.DefaultProvider = append(mock.calls.DefaultProvider, callInfo)
+	mock.lockDefaultProvider.Unlock()
+	if mock.DefaultProviderFunc == nil {
+		var (
+			sOut   string
+			errOut error
+		)
+		return sOut, errOut
+	}
+	return mock.DefaultProviderFunc(ctx)
+}
+
+// DefaultProviderCalls gets all the calls that were made to DefaultProvider.
+// Check the length with:
+//
+//	len(mockedAuthProviderFactory.DefaultProviderCalls())
+func (mock *AuthProviderFactoryMock) DefaultProviderCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDefaultProvider.RLock()
+	calls = mock.calls.DefaultProvider
+	mock.lockDefaultProvider.RUnlock()
+	return calls
+}
+
 // GetSupportedProviders calls GetSupportedProvidersFunc.
 func (mock *AuthProviderFactoryMock) GetSupportedProviders() []string {
 	callInfo := struct {
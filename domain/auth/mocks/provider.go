@@ -18,6 +18,9 @@ import (
 //			DeleteUserFunc: func(ctx context.Context, authProviderID string) error {
 //				panic("mock out the DeleteUser method")
 //			},
+//			ListUsersFunc: func(ctx context.Context) ([]entities.ProviderUser, error) {
+//				panic("mock out the ListUsers method")
+//			},
 //			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
 //				panic("mock out the Login method")
 //			},
@@ -40,6 +43,9 @@ type ProviderMock struct {
 	// DeleteUserFunc mocks the DeleteUser method.
 	DeleteUserFunc func(ctx context.Context, authProviderID string) error
 
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context) ([]entities.ProviderUser, error)
+
 	// LoginFunc mocks the Login method.
 	LoginFunc func(ctx context.Context, email string, password string) (string, error)
 
@@ -61,6 +67,11 @@ type ProviderMock struct {
 			// AuthProviderID is the authProviderID argument value.
 			AuthProviderID string
 		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
 			// Ctx is the ctx argument value.
@@ -91,6 +102,7 @@ type ProviderMock struct {
 		}
 	}
 	lockDeleteUser    sync.RWMutex
+	lockListUsers     sync.RWMutex
 	lockLogin         sync.RWMutex
 	lockProvider      sync.RWMutex
 	lockRegisterUser  sync.RWMutex
@@ -136,6 +148,42 @@ func (mock *ProviderMock) DeleteUserCalls() []struct {
 	return calls
 }
 
+// ListUsers calls ListUsersFunc.
+func (mock *ProviderMock) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	if mock.ListUsersFunc == nil {
+		var (
+			providerUsersOut []entities.ProviderUser
+			errOut           error
+		)
+		return providerUsersOut, errOut
+	}
+	return mock.ListUsersFunc(ctx)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedProvider.ListUsersCalls())
+func (mock *ProviderMock) ListUsersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
 // Login calls LoginFunc.
 func (mock *ProviderMock) Login(ctx context.Context, email string, password string) (string, error) {
 	callInfo := struct {
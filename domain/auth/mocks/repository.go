@@ -5,8 +5,10 @@ package mocks
 
 import (
 	"context"
+	"github.com/gofrs/uuid/v5"
 	"go-template/domain/entities"
 	"sync"
+	"time"
 )
 
 // RepositoryMock is a mock implementation of auth.Repository.
@@ -18,9 +20,27 @@ import (
 //			CreateFunc: func(ctx context.Context, user entities.User) error {
 //				panic("mock out the Create method")
 //			},
+//			GetByAuthProviderIDFunc: func(ctx context.Context, provider string, providerID string) (entities.User, error) {
+//				panic("mock out the GetByAuthProviderID method")
+//			},
 //			GetByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
 //				panic("mock out the GetByEmail method")
 //			},
+//			GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+//				panic("mock out the GetByID method")
+//			},
+//			GetByPhoneFunc: func(ctx context.Context, phone string) (entities.User, error) {
+//				panic("mock out the GetByPhone method")
+//			},
+//			SetEmailFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+//				panic("mock out the SetEmail method")
+//			},
+//			SetEmailVerifiedFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+//				panic("mock out the SetEmailVerified method")
+//			},
+//			SetPhoneFunc: func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+//				panic("mock out the SetPhone method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires auth.Repository
@@ -31,9 +51,27 @@ type RepositoryMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, user entities.User) error
 
+	// GetByAuthProviderIDFunc mocks the GetByAuthProviderID method.
+	GetByAuthProviderIDFunc func(ctx context.Context, provider string, providerID string) (entities.User, error)
+
 	// GetByEmailFunc mocks the GetByEmail method.
 	GetByEmailFunc func(ctx context.Context, email string) (entities.User, error)
 
+	// GetByIDFunc mocks the GetByID method.
+	GetByIDFunc func(ctx context.Context, id uuid.UUID) (entities.User, error)
+
+	// GetByPhoneFunc mocks the GetByPhone method.
+	GetByPhoneFunc func(ctx context.Context, phone string) (entities.User, error)
+
+	// SetEmailFunc mocks the SetEmail method.
+	SetEmailFunc func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+
+	// SetEmailVerifiedFunc mocks the SetEmailVerified method.
+	SetEmailVerifiedFunc func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+
+	// SetPhoneFunc mocks the SetPhone method.
+	SetPhoneFunc func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -43,6 +81,15 @@ type RepositoryMock struct {
 			// User is the user argument value.
 			User entities.User
 		}
+		// GetByAuthProviderID holds details about calls to the GetByAuthProviderID method.
+		GetByAuthProviderID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// ProviderID is the providerID argument value.
+			ProviderID string
+		}
 		// GetByEmail holds details about calls to the GetByEmail method.
 		GetByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -50,9 +97,62 @@ type RepositoryMock struct {
 			// Email is the email argument value.
 			Email string
 		}
+		// GetByID holds details about calls to the GetByID method.
+		GetByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetByPhone holds details about calls to the GetByPhone method.
+		GetByPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// SetEmail holds details about calls to the SetEmail method.
+		SetEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Email is the email argument value.
+			Email string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+		// SetEmailVerified holds details about calls to the SetEmailVerified method.
+		SetEmailVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Email is the email argument value.
+			Email string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+		// SetPhone holds details about calls to the SetPhone method.
+		SetPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Phone is the phone argument value.
+			Phone string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
 	}
-	lockCreate     sync.RWMutex
-	lockGetByEmail sync.RWMutex
+	lockCreate              sync.RWMutex
+	lockGetByAuthProviderID sync.RWMutex
+	lockGetByEmail          sync.RWMutex
+	lockGetByID             sync.RWMutex
+	lockGetByPhone          sync.RWMutex
+	lockSetEmail            sync.RWMutex
+	lockSetEmailVerified    sync.RWMutex
+	lockSetPhone            sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -94,6 +194,50 @@ func (mock *RepositoryMock) CreateCalls() []struct {
 	return calls
 }
 
+// GetByAuthProviderID calls GetByAuthProviderIDFunc.
+func (mock *RepositoryMock) GetByAuthProviderID(ctx context.Context, provider string, providerID string) (entities.User, error) {
+	callInfo := struct {
+		Ctx        context.Context
+		Provider   string
+		ProviderID string
+	}{
+		Ctx:        ctx,
+		Provider:   provider,
+		ProviderID: providerID,
+	}
+	mock.lockGetByAuthProviderID.Lock()
+	mock.calls.GetByAuthProviderID = append(mock.calls.GetByAuthProviderID, callInfo)
+	mock.lockGetByAuthProviderID.Unlock()
+	if mock.GetByAuthProviderIDFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.GetByAuthProviderIDFunc(ctx, provider, providerID)
+}
+
+// GetByAuthProviderIDCalls gets all the calls that were made to GetByAuthProviderID.
+// Check the length with:
+//
+//	len(mockedRepository.GetByAuthProviderIDCalls())
+func (mock *RepositoryMock) GetByAuthProviderIDCalls() []struct {
+	Ctx        context.Context
+	Provider   string
+	ProviderID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Provider   string
+		ProviderID string
+	}
+	mock.lockGetByAuthProviderID.RLock()
+	calls = mock.calls.GetByAuthProviderID
+	mock.lockGetByAuthProviderID.RUnlock()
+	return calls
+}
+
 // GetByEmail calls GetByEmailFunc.
 func (mock *RepositoryMock) GetByEmail(ctx context.Context, email string) (entities.User, error) {
 	callInfo := struct {
@@ -133,3 +277,508 @@ func (mock *RepositoryMock) GetByEmailCalls() []struct {
 	mock.lockGetByEmail.RUnlock()
 	return calls
 }
+
+// GetByID calls GetByIDFunc.
+func (mock *RepositoryMock) GetByID(ctx context.Context, id uuid.UUID) (entities.User, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetByID.Lock()
+	mock.calls.GetByID = append(mock.calls.GetByID, callInfo)
+	mock.lockGetByID.Unlock()
+	if mock.GetByIDFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.GetByIDFunc(ctx, id)
+}
+
+// GetByIDCalls gets all the calls that were made to GetByID.
+// Check the length with:
+//
+//	len(mockedRepository.GetByIDCalls())
+func (mock *RepositoryMock) GetByIDCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetByID.RLock()
+	calls = mock.calls.GetByID
+	mock.lockGetByID.RUnlock()
+	return calls
+}
+
+// GetByPhone calls GetByPhoneFunc.
+func (mock *RepositoryMock) GetByPhone(ctx context.Context, phone string) (entities.User, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+	}
+	mock.lockGetByPhone.Lock()
+	mock.calls.GetByPhone = append(mock.calls.GetByPhone, callInfo)
+	mock.lockGetByPhone.Unlock()
+	if mock.GetByPhoneFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.GetByPhoneFunc(ctx, phone)
+}
+
+// GetByPhoneCalls gets all the calls that were made to GetByPhone.
+// Check the length with:
+//
+//	len(mockedRepository.GetByPhoneCalls())
+func (mock *RepositoryMock) GetByPhoneCalls() []struct {
+	Ctx   context.Context
+	Phone string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+	}
+	mock.lockGetByPhone.RLock()
+	calls = mock.calls.GetByPhone
+	mock.lockGetByPhone.RUnlock()
+	return calls
+}
+
+// SetEmail calls SetEmailFunc.
+func (mock *RepositoryMock) SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Email:      email,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetEmail.Lock()
+	mock.calls.SetEmail = append(mock.calls.SetEmail, callInfo)
+	mock.lockSetEmail.Unlock()
+	if mock.SetEmailFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetEmailFunc(ctx, id, email, verifiedAt)
+}
+
+// SetEmailCalls gets all the calls that were made to SetEmail.
+// Check the length with:
+//
+//	len(mockedRepository.SetEmailCalls())
+func (mock *RepositoryMock) SetEmailCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Email      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetEmail.RLock()
+	calls = mock.calls.SetEmail
+	mock.lockSetEmail.RUnlock()
+	return calls
+}
+
+// SetEmailVerified calls SetEmailVerifiedFunc.
+func (mock *RepositoryMock) SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Email:      email,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetEmailVerified.Lock()
+	mock.calls.SetEmailVerified = append(mock.calls.SetEmailVerified, callInfo)
+	mock.lockSetEmailVerified.Unlock()
+	if mock.SetEmailVerifiedFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetEmailVerifiedFunc(ctx, id, email, verifiedAt)
+}
+
+// SetEmailVerifiedCalls gets all the calls that were made to SetEmailVerified.
+// Check the length with:
+//
+//	len(mockedRepository.SetEmailVerifiedCalls())
+func (mock *RepositoryMock) SetEmailVerifiedCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Email      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetEmailVerified.RLock()
+	calls = mock.calls.SetEmailVerified
+	mock.lockSetEmailVerified.RUnlock()
+	return calls
+}
+
+// SetPhone calls SetPhoneFunc.
+func (mock *RepositoryMock) SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Phone      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Phone:      phone,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetPhone.Lock()
+	mock.calls.SetPhone = append(mock.calls.SetPhone, callInfo)
+	mock.lockSetPhone.Unlock()
+	if mock.SetPhoneFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetPhoneFunc(ctx, id, phone, verifiedAt)
+}
+
+// SetPhoneCalls gets all the calls that were made to SetPhone.
+// Check the length with:
+//
+//	len(mockedRepository.SetPhoneCalls())
+func (mock *RepositoryMock) SetPhoneCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Phone      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Phone      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetPhone.RLock()
+	calls = mock.calls.SetPhone
+	mock.lockSetPhone.RUnlock()
+	return calls
+}
+
+// RefreshTokenRepositoryMock is a mock implementation of auth.RefreshTokenRepository.
+//
+//	func TestSomethingThatUsesRefreshTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked auth.RefreshTokenRepository
+//		mockedRefreshTokenRepository := &RefreshTokenRepositoryMock{
+//			CreateRefreshTokenFunc: func(ctx context.Context, token entities.RefreshToken) error {
+//				panic("mock out the CreateRefreshToken method")
+//			},
+//			GetRefreshTokenByHashFunc: func(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+//				panic("mock out the GetRefreshTokenByHash method")
+//			},
+//			RevokeRefreshTokenFamilyFunc: func(ctx context.Context, familyID uuid.UUID) error {
+//				panic("mock out the RevokeRefreshTokenFamily method")
+//			},
+//			RevokeUserRefreshTokensFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the RevokeUserRefreshTokens method")
+//			},
+//			UseRefreshTokenFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the UseRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenRepository in code that requires auth.RefreshTokenRepository
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenRepositoryMock struct {
+	// CreateRefreshTokenFunc mocks the CreateRefreshToken method.
+	CreateRefreshTokenFunc func(ctx context.Context, token entities.RefreshToken) error
+
+	// GetRefreshTokenByHashFunc mocks the GetRefreshTokenByHash method.
+	GetRefreshTokenByHashFunc func(ctx context.Context, tokenHash string) (entities.RefreshToken, error)
+
+	// RevokeRefreshTokenFamilyFunc mocks the RevokeRefreshTokenFamily method.
+	RevokeRefreshTokenFamilyFunc func(ctx context.Context, familyID uuid.UUID) error
+
+	// RevokeUserRefreshTokensFunc mocks the RevokeUserRefreshTokens method.
+	RevokeUserRefreshTokensFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// UseRefreshTokenFunc mocks the UseRefreshToken method.
+	UseRefreshTokenFunc func(ctx context.Context, id uuid.UUID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateRefreshToken holds details about calls to the CreateRefreshToken method.
+		CreateRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token entities.RefreshToken
+		}
+		// GetRefreshTokenByHash holds details about calls to the GetRefreshTokenByHash method.
+		GetRefreshTokenByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+		// RevokeRefreshTokenFamily holds details about calls to the RevokeRefreshTokenFamily method.
+		RevokeRefreshTokenFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FamilyID is the familyID argument value.
+			FamilyID uuid.UUID
+		}
+		// RevokeUserRefreshTokens holds details about calls to the RevokeUserRefreshTokens method.
+		RevokeUserRefreshTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// UseRefreshToken holds details about calls to the UseRefreshToken method.
+		UseRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+	}
+	lockCreateRefreshToken       sync.RWMutex
+	lockGetRefreshTokenByHash    sync.RWMutex
+	lockRevokeRefreshTokenFamily sync.RWMutex
+	lockRevokeUserRefreshTokens  sync.RWMutex
+	lockUseRefreshToken          sync.RWMutex
+}
+
+// CreateRefreshToken calls CreateRefreshTokenFunc.
+func (mock *RefreshTokenRepositoryMock) CreateRefreshToken(ctx context.Context, token entities.RefreshToken) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Token entities.RefreshToken
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockCreateRefreshToken.Lock()
+	mock.calls.CreateRefreshToken = append(mock.calls.CreateRefreshToken, callInfo)
+	mock.lockCreateRefreshToken.Unlock()
+	if mock.CreateRefreshTokenFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.CreateRefreshTokenFunc(ctx, token)
+}
+
+// CreateRefreshTokenCalls gets all the calls that were made to CreateRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.CreateRefreshTokenCalls())
+func (mock *RefreshTokenRepositoryMock) CreateRefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token entities.RefreshToken
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token entities.RefreshToken
+	}
+	mock.lockCreateRefreshToken.RLock()
+	calls = mock.calls.CreateRefreshToken
+	mock.lockCreateRefreshToken.RUnlock()
+	return calls
+}
+
+// GetRefreshTokenByHash calls GetRefreshTokenByHashFunc.
+func (mock *RefreshTokenRepositoryMock) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		TokenHash string
+	}{
+		Ctx:       ctx,
+		TokenHash: tokenHash,
+	}
+	mock.lockGetRefreshTokenByHash.Lock()
+	mock.calls.GetRefreshTokenByHash = append(mock.calls.GetRefreshTokenByHash, callInfo)
+	mock.lockGetRefreshTokenByHash.Unlock()
+	if mock.GetRefreshTokenByHashFunc == nil {
+		var (
+			refreshTokenOut entities.RefreshToken
+			errOut          error
+		)
+		return refreshTokenOut, errOut
+	}
+	return mock.GetRefreshTokenByHashFunc(ctx, tokenHash)
+}
+
+// GetRefreshTokenByHashCalls gets all the calls that were made to GetRefreshTokenByHash.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.GetRefreshTokenByHashCalls())
+func (mock *RefreshTokenRepositoryMock) GetRefreshTokenByHashCalls() []struct {
+	Ctx       context.Context
+	TokenHash string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenHash string
+	}
+	mock.lockGetRefreshTokenByHash.RLock()
+	calls = mock.calls.GetRefreshTokenByHash
+	mock.lockGetRefreshTokenByHash.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokenFamily calls RevokeRefreshTokenFamilyFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	callInfo := struct {
+		Ctx      context.Context
+		FamilyID uuid.UUID
+	}{
+		Ctx:      ctx,
+		FamilyID: familyID,
+	}
+	mock.lockRevokeRefreshTokenFamily.Lock()
+	mock.calls.RevokeRefreshTokenFamily = append(mock.calls.RevokeRefreshTokenFamily, callInfo)
+	mock.lockRevokeRefreshTokenFamily.Unlock()
+	if mock.RevokeRefreshTokenFamilyFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RevokeRefreshTokenFamilyFunc(ctx, familyID)
+}
+
+// RevokeRefreshTokenFamilyCalls gets all the calls that were made to RevokeRefreshTokenFamily.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokenFamilyCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokenFamilyCalls() []struct {
+	Ctx      context.Context
+	FamilyID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FamilyID uuid.UUID
+	}
+	mock.lockRevokeRefreshTokenFamily.RLock()
+	calls = mock.calls.RevokeRefreshTokenFamily
+	mock.lockRevokeRefreshTokenFamily.RUnlock()
+	return calls
+}
+
+// RevokeUserRefreshTokens calls RevokeUserRefreshTokensFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserRefreshTokens.Lock()
+	mock.calls.RevokeUserRefreshTokens = append(mock.calls.RevokeUserRefreshTokens, callInfo)
+	mock.lockRevokeUserRefreshTokens.Unlock()
+	if mock.RevokeUserRefreshTokensFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RevokeUserRefreshTokensFunc(ctx, userID)
+}
+
+// RevokeUserRefreshTokensCalls gets all the calls that were made to RevokeUserRefreshTokens.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeUserRefreshTokensCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeUserRefreshTokensCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRevokeUserRefreshTokens.RLock()
+	calls = mock.calls.RevokeUserRefreshTokens
+	mock.lockRevokeUserRefreshTokens.RUnlock()
+	return calls
+}
+
+// UseRefreshToken calls UseRefreshTokenFunc.
+func (mock *RefreshTokenRepositoryMock) UseRefreshToken(ctx context.Context, id uuid.UUID) error {
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockUseRefreshToken.Lock()
+	mock.calls.UseRefreshToken = append(mock.calls.UseRefreshToken, callInfo)
+	mock.lockUseRefreshToken.Unlock()
+	if mock.UseRefreshTokenFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.UseRefreshTokenFunc(ctx, id)
+}
+
+// UseRefreshTokenCalls gets all the calls that were made to UseRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.UseRefreshTokenCalls())
+func (mock *RefreshTokenRepositoryMock) UseRefreshTokenCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockUseRefreshToken.RLock()
+	calls = mock.calls.UseRefreshToken
+	mock.lockUseRefreshToken.RUnlock()
+	return calls
+}
@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/metrics"
+	"math/big"
+	"regexp"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Purposes of one-time codes. A code is only accepted for the purpose it was
+// sent for.
+const (
+	OTPPurposeLogin       = "login"
+	OTPPurposeVerifyPhone = "verify_phone"
+)
+
+const otpDigits = 6
+
+var (
+	// ErrSMSLoginDisabled is returned when no SMS gateway is configured.
+	ErrSMSLoginDisabled = errors.New("sms login is not enabled")
+	// ErrInvalidPhone is returned for phone numbers not in E.164 format.
+	ErrInvalidPhone = errors.New("phone number must be in E.164 format")
+	// ErrInvalidOTP is returned for wrong, expired, used or exhausted codes.
+	ErrInvalidOTP = errors.New("invalid or expired code")
+	// ErrOTPRequestTooSoon is returned when a new code is requested before
+	// the resend interval has passed.
+	ErrOTPRequestTooSoon = errors.New("a code was sent recently, try again later")
+	// ErrPhoneInUse is returned when the phone number belongs to another user.
+	ErrPhoneInUse = errors.New("phone number already in use")
+)
+
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/otp.go . OTPCodeRepository SMSSender
+
+type OTPCodeRepository interface {
+	// SaveOTPCode stores the code, replacing any earlier code for the same
+	// phone and purpose.
+	SaveOTPCode(ctx context.Context, code entities.OTPCode) error
+	// GetOTPCode returns the code for the phone and purpose, or
+	// domain.ErrNotFound when there is none.
+	GetOTPCode(ctx context.Context, phone, purpose string) (entities.OTPCode, error)
+	IncrementOTPCodeAttempts(ctx context.Context, id uuid.UUID) error
+	// ConsumeOTPCode marks an unused code as used, or returns
+	// domain.ErrNotFound when it was already used.
+	ConsumeOTPCode(ctx context.Context, id uuid.UUID) error
+}
+
+// SMSSender delivers text messages to phone numbers in E.164 format.
+type SMSSender interface {
+	Send(ctx context.Context, to, body string) error
+}
+
+// OTPConfig controls one-time codes sent by SMS.
+type OTPConfig struct {
+	// TTL is how long a code is valid.
+	TTL time.Duration
+	// MaxAttempts is how many wrong guesses burn a code.
+	MaxAttempts int
+	// ResendInterval is the minimum time between two codes to the same
+	// phone number.
+	ResendInterval time.Duration
+}
+
+// DefaultOTPConfig is used for zero OTPConfig fields.
+var DefaultOTPConfig = OTPConfig{
+	TTL:            5 * time.Minute,
+	MaxAttempts:    5,
+	ResendInterval: time.Minute,
+}
+
+type OTPRequest struct {
+	Phone string `json:"phone" validate:"required"`
+}
+
+type OTPLoginRequest struct {
+	Phone string `json:"phone" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+	// Audience is the application the token is for. Defaults to api.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+}
+
+type VerifyPhoneRequest struct {
+	Phone string `json:"phone" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+}
+
+type smsLogin struct {
+	codes OTPCodeRepository
+	sms   SMSSender
+	cfg   OTPConfig
+}
+
+// SetSMSLogin enables login and phone verification with one-time codes
+// delivered by sms.
+func (uc *UseCase) SetSMSLogin(codes OTPCodeRepository, sms SMSSender, cfg OTPConfig) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultOTPConfig.TTL
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultOTPConfig.MaxAttempts
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = DefaultOTPConfig.ResendInterval
+	}
+	uc.smsLogin = &smsLogin{codes: codes, sms: sms, cfg: cfg}
+}
+
+// SMSLoginEnabled reports whether an SMS gateway is configured.
+func (uc *UseCase) SMSLoginEnabled() bool {
+	return uc.smsLogin != nil
+}
+
+// RequestLoginCode sends a login code to phone. Numbers that don't belong to
+// any user get no code but no error either, so the endpoint can't be used to
+// discover registered numbers.
+func (uc *UseCase) RequestLoginCode(ctx context.Context, phone string) error {
+	if uc.smsLogin == nil {
+		return ErrSMSLoginDisabled
+	}
+	if !e164.MatchString(phone) {
+		return ErrInvalidPhone
+	}
+
+	user, err := uc.repo.GetByPhone(ctx, phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			domain.LoggerOr(ctx, uc.logger).Info("login code requested for unknown phone")
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return uc.sendOTP(ctx, phone, OTPPurposeLogin, &user.ID)
+}
+
+// LoginWithCode signs in the user owning the phone number with a code sent
+// by RequestLoginCode.
+func (uc *UseCase) LoginWithCode(ctx context.Context, req OTPLoginRequest) (AuthResponse, error) {
+	if uc.smsLogin == nil {
+		return AuthResponse{}, ErrSMSLoginDisabled
+	}
+
+	code, err := uc.verifyOTP(ctx, req.Phone, OTPPurposeLogin, req.Code)
+	if err != nil {
+		metrics.RecordLogin("", false)
+		uc.recordFailedLogin(ctx, uc.repo.GetByPhone, req.Phone, loginProviderSMS, err)
+		return AuthResponse{}, err
+	}
+
+	user, err := uc.repo.GetByPhone(ctx, req.Phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			metrics.RecordLogin("", false)
+			return AuthResponse{}, ErrInvalidOTP
+		}
+		return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	// The number may have moved to another user since the code was sent
+	if code.UserID == nil || *code.UserID != user.ID {
+		metrics.RecordLogin("", false)
+		return AuthResponse{}, ErrInvalidOTP
+	}
+
+	response, err := uc.completeLogin(ctx, user, req.Audience)
+	uc.recordLogin(ctx, user.ID, loginProviderSMS, response, err)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user sms login successful", "user_id", user.ID)
+	metrics.RecordLogin(user.ID.String(), true)
+
+	return response, nil
+}
+
+// RequestPhoneVerification sends a code to phone, which becomes the user's
+// number once confirmed with VerifyPhone.
+func (uc *UseCase) RequestPhoneVerification(ctx context.Context, userID uuid.UUID, phone string) error {
+	if uc.smsLogin == nil {
+		return ErrSMSLoginDisabled
+	}
+	if !e164.MatchString(phone) {
+		return ErrInvalidPhone
+	}
+
+	owner, err := uc.repo.GetByPhone(ctx, phone)
+	switch {
+	case err == nil && owner.ID != userID:
+		return ErrPhoneInUse
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return uc.sendOTP(ctx, phone, OTPPurposeVerifyPhone, &userID)
+}
+
+// VerifyPhone checks a code sent by RequestPhoneVerification and stores phone
+// as the user's verified number.
+func (uc *UseCase) VerifyPhone(ctx context.Context, userID uuid.UUID, phone, code string) (entities.User, error) {
+	if uc.smsLogin == nil {
+		return entities.User{}, ErrSMSLoginDisabled
+	}
+
+	otp, err := uc.verifyOTP(ctx, phone, OTPPurposeVerifyPhone, code)
+	if err != nil {
+		return entities.User{}, err
+	}
+	if otp.UserID == nil || *otp.UserID != userID {
+		return entities.User{}, ErrInvalidOTP
+	}
+
+	if err := uc.repo.SetPhone(ctx, userID, phone, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return entities.User{}, ErrPhoneInUse
+		}
+		return entities.User{}, fmt.Errorf("failed to set phone: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user phone verified", "user_id", userID)
+	return uc.repo.GetByID(ctx, userID)
+}
+
+func (uc *UseCase) sendOTP(ctx context.Context, phone, purpose string, userID *uuid.UUID) error {
+	now := time.Now()
+
+	previous, err := uc.smsLogin.codes.GetOTPCode(ctx, phone, purpose)
+	switch {
+	case err == nil && now.Sub(previous.CreatedAt) < uc.smsLogin.cfg.ResendInterval:
+		return ErrOTPRequestTooSoon
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get otp code: %w", err)
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return err
+	}
+
+	err = uc.smsLogin.codes.SaveOTPCode(ctx, entities.OTPCode{
+		ID:        uuid.Must(uuid.NewV4()),
+		Phone:     phone,
+		Purpose:   purpose,
+		CodeHash:  hashOTP(phone, purpose, code),
+		UserID:    userID,
+		ExpiresAt: now.Add(uc.smsLogin.cfg.TTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save otp code: %w", err)
+	}
+
+	body := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(uc.smsLogin.cfg.TTL.Minutes()))
+	if err := uc.smsLogin.sms.Send(ctx, phone, body); err != nil {
+		return fmt.Errorf("failed to send otp code: %w", err)
+	}
+	return nil
+}
+
+// verifyOTP checks code against the outstanding code for phone and purpose
+// and consumes it. Every wrong guess counts against the code's attempts.
+func (uc *UseCase) verifyOTP(ctx context.Context, phone, purpose, code string) (entities.OTPCode, error) {
+	otp, err := uc.smsLogin.codes.GetOTPCode(ctx, phone, purpose)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.OTPCode{}, ErrInvalidOTP
+		}
+		return entities.OTPCode{}, fmt.Errorf("failed to get otp code: %w", err)
+	}
+	if otp.ConsumedAt != nil || time.Now().After(otp.ExpiresAt) || otp.Attempts >= uc.smsLogin.cfg.MaxAttempts {
+		return entities.OTPCode{}, ErrInvalidOTP
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashOTP(phone, purpose, code)), []byte(otp.CodeHash)) != 1 {
+		if err := uc.smsLogin.codes.IncrementOTPCodeAttempts(ctx, otp.ID); err != nil {
+			return entities.OTPCode{}, fmt.Errorf("failed to record otp attempt: %w", err)
+		}
+		return entities.OTPCode{}, ErrInvalidOTP
+	}
+
+	if err := uc.smsLogin.codes.ConsumeOTPCode(ctx, otp.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.OTPCode{}, ErrInvalidOTP
+		}
+		return entities.OTPCode{}, fmt.Errorf("failed to consume otp code: %w", err)
+	}
+	return otp, nil
+}
+
+func generateOTP() (string, error) {
+	max := big.NewInt(1)
+	for range otpDigits {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate otp code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n), nil
+}
+
+func hashOTP(phone, purpose, code string) string {
+	sum := sha256.Sum256([]byte(phone + "|" + purpose + "|" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memOTPCodes is an in-memory OTPCodeRepository
+type memOTPCodes struct {
+	mu    sync.Mutex
+	codes map[string]entities.OTPCode
+}
+
+func newMemOTPCodes() *memOTPCodes {
+	return &memOTPCodes{codes: map[string]entities.OTPCode{}}
+}
+
+func (m *memOTPCodes) SaveOTPCode(ctx context.Context, code entities.OTPCode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.codes[code.Phone+"|"+code.Purpose] = code
+	return nil
+}
+
+func (m *memOTPCodes) GetOTPCode(ctx context.Context, phone, purpose string) (entities.OTPCode, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code, ok := m.codes[phone+"|"+purpose]
+	if !ok {
+		return entities.OTPCode{}, domain.ErrNotFound
+	}
+	return code, nil
+}
+
+func (m *memOTPCodes) IncrementOTPCodeAttempts(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, code := range m.codes {
+		if code.ID == id {
+			code.Attempts++
+			m.codes[key] = code
+		}
+	}
+	return nil
+}
+
+func (m *memOTPCodes) ConsumeOTPCode(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, code := range m.codes {
+		if code.ID == id && code.ConsumedAt == nil {
+			now := time.Now()
+			code.ConsumedAt = &now
+			m.codes[key] = code
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// memSMS records sent messages
+type memSMS struct {
+	sent []string
+}
+
+func (m *memSMS) Send(ctx context.Context, to, body string) error {
+	m.sent = append(m.sent, body)
+	return nil
+}
+
+var otpPattern = regexp.MustCompile(`[0-9]{6}`)
+
+func (m *memSMS) lastCode(t *testing.T) string {
+	t.Helper()
+	if len(m.sent) == 0 {
+		t.Fatalf("expected an sms to be sent")
+	}
+	return otpPattern.FindString(m.sent[len(m.sent)-1])
+}
+
+const testPhone = "+15550001111"
+
+func newOTPTestUseCase(user entities.User) (*UseCase, *memSMS) {
+	repo := &mockRepository{
+		getByPhoneFunc: func(ctx context.Context, phone string) (entities.User, error) {
+			if phone == user.Phone {
+				return user, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return user, nil
+		},
+	}
+	sms := &memSMS{}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetSMSLogin(newMemOTPCodes(), sms, OTPConfig{})
+	return uc, sms
+}
+
+func TestUseCase_LoginWithCode(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", Phone: testPhone, AccountType: entities.AccountTypeUser}
+	uc, sms := newOTPTestUseCase(user)
+	ctx := context.Background()
+
+	if err := uc.RequestLoginCode(ctx, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := uc.LoginWithCode(ctx, OTPLoginRequest{Phone: testPhone, Code: sms.lastCode(t)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Token == "" || resp.User.ID != user.ID {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	// Codes are single use
+	if _, err := uc.LoginWithCode(ctx, OTPLoginRequest{Phone: testPhone, Code: sms.lastCode(t)}); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("expected ErrInvalidOTP on reuse, got %v", err)
+	}
+}
+
+func TestUseCase_RequestLoginCode_UnknownPhone(t *testing.T) {
+	uc, sms := newOTPTestUseCase(entities.User{ID: uuid.Must(uuid.NewV4()), Phone: testPhone})
+
+	if err := uc.RequestLoginCode(context.Background(), "+15559999999"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sms.sent) != 0 {
+		t.Fatalf("expected no sms for unknown phone, got %d", len(sms.sent))
+	}
+}
+
+func TestUseCase_RequestLoginCode_Validation(t *testing.T) {
+	uc, _ := newOTPTestUseCase(entities.User{ID: uuid.Must(uuid.NewV4()), Phone: testPhone})
+	ctx := context.Background()
+
+	if err := uc.RequestLoginCode(ctx, "5550001111"); !errors.Is(err, ErrInvalidPhone) {
+		t.Fatalf("expected ErrInvalidPhone, got %v", err)
+	}
+
+	if err := uc.RequestLoginCode(ctx, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.RequestLoginCode(ctx, testPhone); !errors.Is(err, ErrOTPRequestTooSoon) {
+		t.Fatalf("expected ErrOTPRequestTooSoon, got %v", err)
+	}
+
+	disabled := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	if err := disabled.RequestLoginCode(ctx, testPhone); !errors.Is(err, ErrSMSLoginDisabled) {
+		t.Fatalf("expected ErrSMSLoginDisabled, got %v", err)
+	}
+}
+
+func TestUseCase_LoginWithCode_AttemptLimit(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Phone: testPhone}
+	uc, sms := newOTPTestUseCase(user)
+	ctx := context.Background()
+
+	if err := uc.RequestLoginCode(ctx, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := sms.lastCode(t)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for range DefaultOTPConfig.MaxAttempts {
+		if _, err := uc.LoginWithCode(ctx, OTPLoginRequest{Phone: testPhone, Code: wrong}); !errors.Is(err, ErrInvalidOTP) {
+			t.Fatalf("expected ErrInvalidOTP, got %v", err)
+		}
+	}
+
+	// The right code no longer works once the attempts are used up
+	if _, err := uc.LoginWithCode(ctx, OTPLoginRequest{Phone: testPhone, Code: code}); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("expected ErrInvalidOTP after too many attempts, got %v", err)
+	}
+}
+
+func TestUseCase_LoginWithCode_Expired(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Phone: testPhone}
+	uc, sms := newOTPTestUseCase(user)
+	ctx := context.Background()
+
+	if err := uc.RequestLoginCode(ctx, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	codes := uc.smsLogin.codes.(*memOTPCodes)
+	otp, _ := codes.GetOTPCode(ctx, testPhone, OTPPurposeLogin)
+	otp.ExpiresAt = time.Now().Add(-time.Second)
+	codes.SaveOTPCode(ctx, otp)
+
+	if _, err := uc.LoginWithCode(ctx, OTPLoginRequest{Phone: testPhone, Code: sms.lastCode(t)}); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("expected ErrInvalidOTP, got %v", err)
+	}
+}
+
+func TestUseCase_VerifyPhone(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	var stored string
+	repo := &mockRepository{
+		setPhoneFunc: func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+			if id != userID {
+				t.Fatalf("unexpected user %s", id)
+			}
+			stored = phone
+			return nil
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return entities.User{ID: id, Phone: stored}, nil
+		},
+	}
+	sms := &memSMS{}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetSMSLogin(newMemOTPCodes(), sms, OTPConfig{})
+	ctx := context.Background()
+
+	if err := uc.RequestPhoneVerification(ctx, userID, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A code sent to one user can't verify the number for another
+	if _, err := uc.VerifyPhone(ctx, uuid.Must(uuid.NewV4()), testPhone, sms.lastCode(t)); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("expected ErrInvalidOTP, got %v", err)
+	}
+
+	// The mismatched attempt burned the code, so request another one
+	uc.smsLogin.cfg.ResendInterval = 0
+	if err := uc.RequestPhoneVerification(ctx, userID, testPhone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	user, err := uc.VerifyPhone(ctx, userID, testPhone, sms.lastCode(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Phone != testPhone {
+		t.Fatalf("expected phone %s, got %q", testPhone, user.Phone)
+	}
+}
+
+func TestUseCase_RequestPhoneVerification_PhoneInUse(t *testing.T) {
+	uc, sms := newOTPTestUseCase(entities.User{ID: uuid.Must(uuid.NewV4()), Phone: testPhone})
+
+	err := uc.RequestPhoneVerification(context.Background(), uuid.Must(uuid.NewV4()), testPhone)
+	if !errors.Is(err, ErrPhoneInUse) {
+		t.Fatalf("expected ErrPhoneInUse, got %v", err)
+	}
+	if len(sms.sent) != 0 {
+		t.Fatalf("expected no sms, got %d", len(sms.sent))
+	}
+}
@@ -0,0 +1,289 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	// ErrPasswordResetDisabled is returned when neither the application nor
+	// the auth provider can reset passwords.
+	ErrPasswordResetDisabled = errors.New("password reset is not available")
+	// ErrInvalidResetToken is returned for unknown, expired or used tokens.
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/password_reset.go . PasswordResetRepository EmailSender
+
+type PasswordResetRepository interface {
+	// CreatePasswordResetToken stores the token and invalidates the user's
+	// earlier unused tokens.
+	CreatePasswordResetToken(ctx context.Context, token entities.PasswordResetToken) error
+	// GetPasswordResetToken returns the token with the given hash, or
+	// domain.ErrNotFound when there is none.
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (entities.PasswordResetToken, error)
+	// GetLatestPasswordResetToken returns the user's newest token, or
+	// domain.ErrNotFound when there is none.
+	GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (entities.PasswordResetToken, error)
+	// UsePasswordResetToken marks an unused token as used, or returns
+	// domain.ErrNotFound when it was already used.
+	UsePasswordResetToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// EmailSender delivers transactional emails, rendered from the email's
+// template.
+type EmailSender interface {
+	SendEmail(ctx context.Context, email entities.Email) error
+}
+
+// PasswordUpdater is implemented by auth providers that can set a user's
+// password, which password reset needs.
+type PasswordUpdater interface {
+	UpdatePassword(ctx context.Context, authProviderID, password string) error
+}
+
+// PasswordRecoverer is implemented by auth providers that email their own
+// password recovery links. It is used when password reset is not set up in
+// the application.
+type PasswordRecoverer interface {
+	RecoverPassword(ctx context.Context, email string) error
+}
+
+// PasswordResetConfig controls password reset tokens.
+type PasswordResetConfig struct {
+	// ResetURL is the page the emailed link points to. The token is added as
+	// the token query parameter.
+	ResetURL string
+	// TTL is how long a token is valid.
+	TTL time.Duration
+	// ResendInterval is the minimum time between two emails to the same
+	// user.
+	ResendInterval time.Duration
+}
+
+// DefaultPasswordResetConfig is used for zero PasswordResetConfig fields.
+var DefaultPasswordResetConfig = PasswordResetConfig{
+	TTL:            time.Hour,
+	ResendInterval: time.Minute,
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+type passwordReset struct {
+	tokens PasswordResetRepository
+	email  EmailSender
+	cfg    PasswordResetConfig
+}
+
+// SetPasswordReset enables password reset with single-use tokens emailed by
+// email.
+func (uc *UseCase) SetPasswordReset(tokens PasswordResetRepository, email EmailSender, cfg PasswordResetConfig) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultPasswordResetConfig.TTL
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = DefaultPasswordResetConfig.ResendInterval
+	}
+	uc.passwordReset = &passwordReset{tokens: tokens, email: email, cfg: cfg}
+}
+
+// ForgotPassword emails a password reset link to the user. Unknown emails
+// and accounts without a password get no email but no error either, so the
+// endpoint can't be used to discover registered addresses. Without password
+// reset set up, providers that send their own recovery emails are asked to.
+func (uc *UseCase) ForgotPassword(ctx context.Context, email string) error {
+	if uc.passwordReset == nil {
+		recoverer, ok := providerAs[PasswordRecoverer](uc.authProvider)
+		if !ok {
+			return ErrPasswordResetDisabled
+		}
+		if err := recoverer.RecoverPassword(ctx, email); err != nil {
+			return fmt.Errorf("failed to recover password: %w", err)
+		}
+		return nil
+	}
+	if _, ok := providerAs[PasswordUpdater](uc.authProvider); !ok {
+		return ErrPasswordResetDisabled
+	}
+
+	user, err := uc.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			domain.LoggerOr(ctx, uc.logger).Info("password reset requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	// Users who sign in with a social provider have no password here
+	if user.AuthProvider != uc.authProvider.Provider() {
+		domain.LoggerOr(ctx, uc.logger).Info("password reset requested for account without a password", "user_id", user.ID)
+		return nil
+	}
+
+	now := time.Now()
+	latest, err := uc.passwordReset.tokens.GetLatestPasswordResetToken(ctx, user.ID)
+	switch {
+	case err == nil && now.Sub(latest.CreatedAt) < uc.passwordReset.cfg.ResendInterval:
+		domain.LoggerOr(ctx, uc.logger).Info("password reset requested too soon", "user_id", user.ID)
+		return nil
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return err
+	}
+
+	err = uc.passwordReset.tokens.CreatePasswordResetToken(ctx, entities.PasswordResetToken{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(uc.passwordReset.cfg.TTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	link, err := tokenLink(uc.passwordReset.cfg.ResetURL, token)
+	if err != nil {
+		return err
+	}
+	err = uc.passwordReset.email.SendEmail(ctx, entities.Email{
+		To:       user.Email,
+		Template: entities.EmailPasswordReset,
+		Locale:   user.Locale,
+		Data: map[string]any{
+			"Link":             link,
+			"ExpiresInMinutes": int(uc.passwordReset.cfg.TTL.Minutes()),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("password reset requested", "audit", true, "user_id", user.ID)
+	return nil
+}
+
+// PasswordValidator checks new passwords against the password policy.
+type PasswordValidator interface {
+	Validate(ctx context.Context, password string) error
+}
+
+// SessionRevoker ends every session of a user, revoking its refresh and
+// access tokens.
+type SessionRevoker interface {
+	RevokeUser(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// SetSessionRevoker makes ResetPassword end the user's sessions, so access
+// tokens issued before the reset are rejected too. Without it only refresh
+// tokens are revoked.
+func (uc *UseCase) SetSessionRevoker(sessions SessionRevoker) {
+	uc.sessions = sessions
+}
+
+// SetPasswordPolicy makes ResetPassword reject passwords that break the
+// policy, leaving the token usable for another try.
+func (uc *UseCase) SetPasswordPolicy(policy PasswordValidator) {
+	uc.passwordPolicy = policy
+}
+
+// ResetPassword sets a new password with a token sent by ForgotPassword. The
+// token is used up once the password is set, so a failed update leaves it
+// usable, and the user's sessions are ended so they have to sign in with the
+// new password.
+func (uc *UseCase) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	if uc.passwordReset == nil {
+		return ErrPasswordResetDisabled
+	}
+	updater, ok := providerAs[PasswordUpdater](uc.authProvider)
+	if !ok {
+		return ErrPasswordResetDisabled
+	}
+
+	token, err := uc.passwordReset.tokens.GetPasswordResetToken(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+	if uc.passwordPolicy != nil {
+		if err := uc.passwordPolicy.Validate(ctx, req.Password); err != nil {
+			return err
+		}
+	}
+
+	user, err := uc.repo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := updater.UpdatePassword(ctx, user.AuthProviderID, req.Password); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := uc.passwordReset.tokens.UsePasswordResetToken(ctx, token.ID, time.Now()); err != nil {
+		// Another reset with the same token got there first
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to use password reset token: %w", err)
+	}
+
+	if uc.sessions != nil {
+		if _, err := uc.sessions.RevokeUser(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to end sessions: %w", err)
+		}
+	} else if err := uc.refreshTokens.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("password reset", "audit", true, "user_id", user.ID)
+	return nil
+}
+
+// generateLinkToken returns a random token for a link sent by email.
+func generateLinkToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// tokenLink adds token to pageURL as the token query parameter.
+func tokenLink(pageURL, token string) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid link url: %w", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
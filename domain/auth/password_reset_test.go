@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memPasswordResets is an in-memory PasswordResetRepository
+type memPasswordResets struct {
+	mu     sync.Mutex
+	tokens map[string]entities.PasswordResetToken
+}
+
+func newMemPasswordResets() *memPasswordResets {
+	return &memPasswordResets{tokens: map[string]entities.PasswordResetToken{}}
+}
+
+func (m *memPasswordResets) CreatePasswordResetToken(ctx context.Context, token entities.PasswordResetToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.UserID == token.UserID && t.UsedAt == nil {
+			t.UsedAt = &token.CreatedAt
+			m.tokens[hash] = t
+		}
+	}
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *memPasswordResets) GetPasswordResetToken(ctx context.Context, tokenHash string) (entities.PasswordResetToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return entities.PasswordResetToken{}, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (m *memPasswordResets) GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (entities.PasswordResetToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var latest *entities.PasswordResetToken
+	for _, t := range m.tokens {
+		if t.UserID == userID && (latest == nil || t.CreatedAt.After(latest.CreatedAt)) {
+			latest = &t
+		}
+	}
+	if latest == nil {
+		return entities.PasswordResetToken{}, domain.ErrNotFound
+	}
+	return *latest, nil
+}
+
+func (m *memPasswordResets) UsePasswordResetToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, t := range m.tokens {
+		if t.ID == id && t.UsedAt == nil {
+			t.UsedAt = &usedAt
+			m.tokens[hash] = t
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// memEmail records sent emails
+type memEmail struct {
+	sent []entities.Email
+	to   []string
+}
+
+func (m *memEmail) SendEmail(ctx context.Context, e entities.Email) error {
+	m.sent = append(m.sent, e)
+	m.to = append(m.to, e.To)
+	return nil
+}
+
+func (m *memEmail) lastLinkToken(t *testing.T) string {
+	t.Helper()
+	if len(m.sent) == 0 {
+		t.Fatalf("expected an email to be sent")
+	}
+	link, _ := m.sent[len(m.sent)-1].Data["Link"].(string)
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return u.Query().Get("token")
+}
+
+// passwordProvider is a mockProvider that can update passwords
+type passwordProvider struct {
+	mockProvider
+	passwords map[string]string
+	err       error
+}
+
+func (p *passwordProvider) UpdatePassword(ctx context.Context, authProviderID, password string) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.passwords[authProviderID] = password
+	return nil
+}
+
+type sessionRevokerFunc func(ctx context.Context, userID uuid.UUID) (int64, error)
+
+func (f sessionRevokerFunc) RevokeUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return f(ctx, userID)
+}
+
+func newPasswordResetTestUseCase(user entities.User) (*UseCase, *memEmail, *passwordProvider) {
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email == user.Email {
+				return user, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return user, nil
+		},
+	}
+	email := &memEmail{}
+	provider := &passwordProvider{passwords: map[string]string{}}
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
+	uc.SetPasswordReset(newMemPasswordResets(), email, PasswordResetConfig{ResetURL: "https://app.test/reset-password"})
+	return uc, email, provider
+}
+
+func TestUseCase_ResetPassword(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, provider := newPasswordResetTestUseCase(user)
+	ctx := context.Background()
+
+	session, err := uc.IssueTokens(ctx, user, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := email.lastLinkToken(t)
+
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "n3w-secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.passwords[user.AuthProviderID] != "n3w-secret" {
+		t.Fatalf("expected the provider password to be updated")
+	}
+
+	// Tokens are single use
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "other"}); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("expected ErrInvalidResetToken on reuse, got %v", err)
+	}
+
+	// Existing sessions end with the old password
+	if _, err := uc.Refresh(ctx, session.RefreshToken); err == nil {
+		t.Fatalf("expected refresh to fail after a password reset")
+	}
+}
+
+func TestUseCase_ForgotPassword_NoEmail(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "google", AuthProviderID: "g-1"}
+	uc, email, _ := newPasswordResetTestUseCase(user)
+	ctx := context.Background()
+
+	// Unknown emails and social accounts look the same as a sent email
+	if err := uc.ForgotPassword(ctx, "nobody@b.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(email.sent) != 0 {
+		t.Fatalf("expected no email, got %d", len(email.sent))
+	}
+}
+
+func TestUseCase_ForgotPassword_ResendInterval(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, _ := newPasswordResetTestUseCase(user)
+	ctx := context.Background()
+
+	for range 2 {
+		if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(email.sent) != 1 {
+		t.Fatalf("expected one email, got %d", len(email.sent))
+	}
+
+	// A newer token replaces the earlier one
+	first := email.lastLinkToken(t)
+	uc.passwordReset.cfg.ResendInterval = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: first, Password: "n3w-secret"}); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("expected ErrInvalidResetToken for a replaced token, got %v", err)
+	}
+}
+
+// passwordPolicyFunc adapts a function to PasswordValidator
+type passwordPolicyFunc func(ctx context.Context, password string) error
+
+func (f passwordPolicyFunc) Validate(ctx context.Context, password string) error {
+	return f(ctx, password)
+}
+
+func TestUseCase_ResetPassword_PasswordPolicy(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, provider := newPasswordResetTestUseCase(user)
+	errWeak := errors.New("password is too weak")
+	uc.SetPasswordPolicy(passwordPolicyFunc(func(ctx context.Context, password string) error {
+		if password == "weak" {
+			return errWeak
+		}
+		return nil
+	}))
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := email.lastLinkToken(t)
+
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "weak"}); !errors.Is(err, errWeak) {
+		t.Fatalf("expected the policy error, got %v", err)
+	}
+	if _, ok := provider.passwords[user.AuthProviderID]; ok {
+		t.Fatalf("expected the password to stay unchanged")
+	}
+
+	// The token is still usable for a stronger password
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "n3w-secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUseCase_ResetPassword_UpdateFails(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, provider := newPasswordResetTestUseCase(user)
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := email.lastLinkToken(t)
+
+	provider.err = errors.New("provider unavailable")
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "n3w-secret"}); !errors.Is(err, provider.err) {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+
+	// The link still works once the provider is back
+	provider.err = nil
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "n3w-secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUseCase_ResetPassword_EndsSessions(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, _ := newPasswordResetTestUseCase(user)
+	var revoked uuid.UUID
+	uc.SetSessionRevoker(sessionRevokerFunc(func(ctx context.Context, userID uuid.UUID) (int64, error) {
+		revoked = userID
+		return 1, nil
+	}))
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: email.lastLinkToken(t), Password: "n3w-secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked != user.ID {
+		t.Fatalf("expected the user's sessions to be ended")
+	}
+}
+
+func TestUseCase_ResetPassword_Expired(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AuthProvider: "supabase", AuthProviderID: "prov-123"}
+	uc, email, _ := newPasswordResetTestUseCase(user)
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, user.Email); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := email.lastLinkToken(t)
+
+	tokens := uc.passwordReset.tokens.(*memPasswordResets)
+	stored, _ := tokens.GetPasswordResetToken(ctx, hashToken(token))
+	stored.ExpiresAt = time.Now().Add(-time.Second)
+	tokens.tokens[stored.TokenHash] = stored
+
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "n3w-secret"}); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("expected ErrInvalidResetToken, got %v", err)
+	}
+}
+
+func TestUseCase_ForgotPassword_Disabled(t *testing.T) {
+	uc := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, "a@b.com"); !errors.Is(err, ErrPasswordResetDisabled) {
+		t.Fatalf("expected ErrPasswordResetDisabled, got %v", err)
+	}
+	if err := uc.ResetPassword(ctx, ResetPasswordRequest{Token: "x", Password: "secret"}); !errors.Is(err, ErrPasswordResetDisabled) {
+		t.Fatalf("expected ErrPasswordResetDisabled, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"go-template/domain/entities"
+	"go-template/gateways/auth/local"
 )
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/provider.go . Provider
@@ -12,14 +13,48 @@ type Provider interface {
 	Login(ctx context.Context, email, password string) (string, error)
 	ValidateToken(ctx context.Context, token string) (*entities.User, error)
 	DeleteUser(ctx context.Context, authProviderID string) error
+	ListUsers(ctx context.Context) ([]entities.ProviderUser, error)
 }
 
 type AuthConfig struct {
 	Provider string
 	Supabase SupabaseConfig
+	Local    LocalConfig
+	Cognito  CognitoConfig
+	Auth0    Auth0Config
+	// Options holds settings for providers registered outside this package
+	Options map[string]string
 }
 
 type SupabaseConfig struct {
 	URL    string `conf:"required"`
 	APIKey string `conf:"required"`
 }
+
+// LocalConfig configures the built-in provider that keeps password hashes in
+// the application database, and the dev provider that shares its store.
+// Tokens validates the application's own tokens.
+type LocalConfig struct {
+	Store  local.Store
+	Tokens local.TokenValidator
+}
+
+// CognitoConfig identifies the AWS Cognito user pool and app client.
+// ClientSecret is only needed for app clients that have one.
+type CognitoConfig struct {
+	Region       string
+	UserPoolID   string
+	ClientID     string
+	ClientSecret string
+}
+
+// Auth0Config identifies the Auth0 tenant, application and database
+// connection. Connection defaults to Username-Password-Authentication.
+// Audience is the API identifier access tokens are accepted for.
+type Auth0Config struct {
+	Domain       string
+	ClientID     string
+	ClientSecret string
+	Connection   string
+	Audience     string
+}
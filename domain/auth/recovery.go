@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// RecoveryCodeCount is how many recovery codes a user gets at a time.
+	RecoveryCodeCount = 10
+
+	// Recovery codes are recoveryCodeLength characters from the base32
+	// alphabet, shown as two dash-separated halves.
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+type TwoFactorRecoveryCodes struct {
+	// Codes is the new set of recovery codes, each usable once
+	Codes []string `json:"recovery_codes"`
+}
+
+// RecoveryCodesRemaining returns how many unused recovery codes the user has.
+// The codes themselves are only stored hashed and can't be shown again.
+func (uc *UseCase) RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error) {
+	if uc.twoFactor == nil {
+		return 0, ErrTwoFactorUnavailable
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !cred.Enabled()) {
+		return 0, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	remaining, err := uc.twoFactor.totp.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return remaining, nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking
+// a current authenticator code. Codes from the old set stop working.
+func (uc *UseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (TwoFactorRecoveryCodes, error) {
+	if uc.twoFactor == nil {
+		return TwoFactorRecoveryCodes{}, ErrTwoFactorUnavailable
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !cred.Enabled()) {
+		return TwoFactorRecoveryCodes{}, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return TwoFactorRecoveryCodes{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	if err := uc.verifyTOTP(ctx, cred, code); err != nil {
+		return TwoFactorRecoveryCodes{}, err
+	}
+
+	codes, err := uc.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return TwoFactorRecoveryCodes{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("two-factor recovery codes regenerated", "audit", true, "user_id", userID)
+	return TwoFactorRecoveryCodes{Codes: codes}, nil
+}
+
+// replaceRecoveryCodes generates a new set of codes and stores their hashes.
+func (uc *UseCase) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	if err := uc.twoFactor.totp.ReplaceRecoveryCodes(ctx, userID, hashes, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// verifyRecoveryCode consumes one of the user's recovery codes. Wrong codes
+// count towards the same lockout as wrong authenticator codes.
+func (uc *UseCase) verifyRecoveryCode(ctx context.Context, cred entities.TOTPCredential, code string) error {
+	if totpLocked(cred) {
+		return ErrTwoFactorLocked
+	}
+
+	err := uc.twoFactor.totp.UseRecoveryCode(ctx, cred.UserID, hashRecoveryCode(code), time.Now())
+	if errors.Is(err, domain.ErrNotFound) {
+		if err := uc.twoFactor.totp.RecordTOTPFailure(ctx, cred.UserID); err != nil {
+			return fmt.Errorf("failed to record totp failure: %w", err)
+		}
+		return ErrInvalidTOTP
+	}
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("two-factor recovery code used", "audit", true, "user_id", cred.UserID)
+	return nil
+}
+
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	for i := range b {
+		b[i] = recoveryCodeAlphabet[int(b[i])%len(recoveryCodeAlphabet)]
+	}
+	return string(b[:recoveryCodeLength/2]) + "-" + string(b[recoveryCodeLength/2:]), nil
+}
+
+// hashRecoveryCode hashes code ignoring case, dashes and spaces, so codes
+// can be typed the way they're read.
+func hashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestUseCase_RecoveryCodes(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	enrollment, _ := uc.EnrollTOTP(ctx, user.ID)
+	enabled, err := uc.EnableTOTP(ctx, user.ID, code(t, enrollment.Secret, -1), jwt.AudienceWeb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(enabled.RecoveryCodes) != RecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", RecoveryCodeCount, len(enabled.RecoveryCodes))
+	}
+
+	login, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Recovery codes can be typed in upper case and without the dash
+	typed := strings.ToUpper(strings.ReplaceAll(enabled.RecoveryCodes[0], "-", ""))
+	done, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: login.MFAToken, RecoveryCode: typed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims, err := newJWT().ValidateToken(done.Token); err != nil || !claims.MFA() {
+		t.Fatalf("expected an mfa access token, got %v", err)
+	}
+
+	// Each code works once
+	if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: login.MFAToken, RecoveryCode: enabled.RecoveryCodes[0]}); !errors.Is(err, ErrInvalidTOTP) {
+		t.Fatalf("expected ErrInvalidTOTP on reuse, got %v", err)
+	}
+
+	remaining, err := uc.RecoveryCodesRemaining(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining != RecoveryCodeCount-1 {
+		t.Fatalf("expected %d remaining codes, got %d", RecoveryCodeCount-1, remaining)
+	}
+
+	// Regenerating invalidates the old set
+	regenerated, err := uc.RegenerateRecoveryCodes(ctx, user.ID, code(t, enrollment.Secret, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(regenerated.Codes) != RecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", RecoveryCodeCount, len(regenerated.Codes))
+	}
+	if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: login.MFAToken, RecoveryCode: enabled.RecoveryCodes[1]}); !errors.Is(err, ErrInvalidTOTP) {
+		t.Fatalf("expected ErrInvalidTOTP for an old code, got %v", err)
+	}
+
+	// A recovery code also disables two-factor authentication
+	if err := uc.DisableTOTP(ctx, user.ID, "", regenerated.Codes[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.RecoveryCodesRemaining(ctx, user.ID); !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		t.Fatalf("expected ErrTwoFactorNotEnrolled, got %v", err)
+	}
+}
+
+func TestUseCase_RecoveryCodes_Lockout(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	enrollment, _ := uc.EnrollTOTP(ctx, user.ID)
+	enabled, err := uc.EnableTOTP(ctx, user.ID, code(t, enrollment.Secret, -1), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	login, _ := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"})
+
+	for range totpMaxFailures {
+		if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: login.MFAToken, RecoveryCode: "aaaaa-aaaaa"}); !errors.Is(err, ErrInvalidTOTP) {
+			t.Fatalf("expected ErrInvalidTOTP, got %v", err)
+		}
+	}
+	if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: login.MFAToken, RecoveryCode: enabled.RecoveryCodes[0]}); !errors.Is(err, ErrTwoFactorLocked) {
+		t.Fatalf("expected ErrTwoFactorLocked, got %v", err)
+	}
+}
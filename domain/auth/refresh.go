@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DefaultRefreshTokenTTL is how long a refresh token lasts when no TTL is
+// configured.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// refreshTokenPrefix makes leaked refresh tokens easy to spot in logs and
+// scanners.
+const refreshTokenPrefix = "rt_"
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, expired or revoked
+	// refresh tokens.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token
+	// is presented again. The whole token family is revoked when it happens.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// IssueTokens issues an access token and starts a new refresh token family
+// for user. audience defaults to api.
+func (uc *UseCase) IssueTokens(ctx context.Context, user entities.User, audience string) (AuthResponse, error) {
+	if audience == "" {
+		audience = jwt.AudienceAPI
+	}
+	return uc.issueTokens(ctx, user, audience, uuid.Must(uuid.NewV4()), false)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token pair.
+// Each refresh token can be used once; presenting it again is treated as
+// theft and revokes every token issued from the same login.
+func (uc *UseCase) Refresh(ctx context.Context, refreshToken string) (AuthResponse, error) {
+	token, err := uc.refreshTokens.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get refresh token", "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		return AuthResponse{}, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return AuthResponse{}, uc.revokeReused(ctx, token)
+	}
+
+	// Losing this race means another request rotated the token first
+	err = uc.refreshTokens.UseRefreshToken(ctx, token.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, uc.revokeReused(ctx, token)
+	}
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to use refresh token", "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+
+	// Reload the user so the new access token carries current claims
+	user, err := uc.repo.GetByID(ctx, token.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for refresh", "user_id", token.UserID, "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	// Sessions of suspended users, and from before the
+	// RequireEmailVerification setting was turned on, end at their next
+	// refresh
+	if err := domain.CheckAccountStatus(user); err != nil {
+		return AuthResponse{}, err
+	}
+	if err := uc.checkEmailVerified(ctx, user); err != nil {
+		return AuthResponse{}, err
+	}
+
+	return uc.issueTokens(ctx, user, token.Audience, token.FamilyID, token.MFA)
+}
+
+// Logout revokes the refresh token family the token belongs to. Unknown
+// tokens are ignored so logging out twice succeeds.
+func (uc *UseCase) Logout(ctx context.Context, refreshToken string) error {
+	token, err := uc.refreshTokens.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get refresh token", "error", err)
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if err := uc.refreshTokens.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to revoke refresh tokens", "user_id", token.UserID, "error", err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user logged out", "user_id", token.UserID)
+	return nil
+}
+
+// issueTokens issues an access token and a refresh token in familyID, which
+// is also the access token's session ID. With mfa set both record that the
+// login passed a second factor.
+func (uc *UseCase) issueTokens(ctx context.Context, user entities.User, audience string, familyID uuid.UUID, mfa bool) (AuthResponse, error) {
+	tokens := uc.jwtService.WithAudience(audience)
+	if mfa {
+		tokens = tokens.WithAMR(jwt.AMROneTimePassword, jwt.AMRMultiFactor)
+	}
+	claims := tokens.NewClaims(user.ID.String(), user.Email, user.AccountType.String(), tokens.Expiry())
+	claims.SessionID = familyID.String()
+	if err := uc.enrichClaims(ctx, user, claims); err != nil {
+		return AuthResponse{}, err
+	}
+	accessToken, err := tokens.Sign(claims)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to generate JWT token", "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := refreshTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	err = uc.refreshTokens.CreateRefreshToken(ctx, entities.RefreshToken{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		Audience:  audience,
+		ExpiresAt: now.Add(uc.sessionTTL(ctx)),
+		CreatedAt: now,
+		MFA:       mfa,
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to store refresh token", "user_id", user.ID, "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return AuthResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(tokens.Expiry().Seconds()),
+		User:         user,
+	}, nil
+}
+
+// sessionTTL returns how long a new refresh token lasts.
+func (uc *UseCase) sessionTTL(ctx context.Context) time.Duration {
+	if uc.sessionSettings == nil {
+		return uc.refreshTTL
+	}
+	settings, err := uc.sessionSettings.GetSettings(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Warn("failed to read session timeout, using the configured refresh token TTL", "error", err)
+		return uc.refreshTTL
+	}
+	if settings.SessionTimeout <= 0 {
+		return uc.refreshTTL
+	}
+	return time.Duration(settings.SessionTimeout) * time.Minute
+}
+
+// revokeReused revokes the family of a refresh token that was presented after
+// being rotated.
+func (uc *UseCase) revokeReused(ctx context.Context, token entities.RefreshToken) error {
+	domain.LoggerOr(ctx, uc.logger).Warn("refresh token reuse detected",
+		"audit", true,
+		"user_id", token.UserID,
+		"family_id", token.FamilyID,
+	)
+	if err := uc.refreshTokens.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to revoke reused refresh token family", "family_id", token.FamilyID, "error", err)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// hashToken returns the hash refresh tokens and the tokens of emailed links
+// are stored and looked up by.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
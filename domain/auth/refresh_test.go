@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memRefreshTokens is an in-memory RefreshTokenRepository with the same
+// single-use semantics as the pg one.
+type memRefreshTokens struct {
+	mu     sync.Mutex
+	tokens map[string]entities.RefreshToken
+}
+
+func newMemRefreshTokens() *memRefreshTokens {
+	return &memRefreshTokens{tokens: map[string]entities.RefreshToken{}}
+}
+
+func (m *memRefreshTokens) CreateRefreshToken(ctx context.Context, token entities.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *memRefreshTokens) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return entities.RefreshToken{}, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (m *memRefreshTokens) UseRefreshToken(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, token := range m.tokens {
+		if token.ID == id && token.UsedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			m.tokens[hash] = token
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (m *memRefreshTokens) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for hash, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			m.tokens[hash] = token
+		}
+	}
+	return nil
+}
+
+func (m *memRefreshTokens) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for hash, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			m.tokens[hash] = token
+		}
+	}
+	return nil
+}
+
+func newRefreshTestUseCase(t *testing.T) (*UseCase, entities.User) {
+	t.Helper()
+	user := entities.User{
+		ID:          uuid.Must(uuid.NewV4()),
+		Email:       "a@b.com",
+		AccountType: entities.AccountTypeUser,
+	}
+	repo := &mockRepository{}
+	repo.getByIDFunc = func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+		if id != user.ID {
+			return entities.User{}, domain.ErrNotFound
+		}
+		return user, nil
+	}
+	return NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), time.Hour, discardLogger()), user
+}
+
+func TestUseCase_Refresh_Rotates(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	ctx := context.Background()
+
+	issued, err := uc.IssueTokens(ctx, user, jwt.AudienceWeb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issued.Token == "" || issued.RefreshToken == "" {
+		t.Fatalf("expected token pair, got %+v", issued)
+	}
+
+	refreshed, err := uc.Refresh(ctx, issued.RefreshToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == issued.RefreshToken {
+		t.Fatalf("expected a new refresh token, got %q", refreshed.RefreshToken)
+	}
+	if refreshed.User.ID != user.ID {
+		t.Fatalf("unexpected user payload: %+v", refreshed.User)
+	}
+
+	claims, err := newJWT().ValidateToken(refreshed.Token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !claims.HasAudience(jwt.AudienceWeb) {
+		t.Fatalf("expected the original audience, got %v", claims.Audience)
+	}
+
+	// Both access tokens belong to the same session
+	first, err := newJWT().ValidateToken(issued.Token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.SessionID == "" || claims.SessionID != first.SessionID {
+		t.Fatalf("expected the session ID to survive the refresh, got %q and %q", first.SessionID, claims.SessionID)
+	}
+}
+
+func TestUseCase_Refresh_ReuseRevokesFamily(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	ctx := context.Background()
+
+	issued, _ := uc.IssueTokens(ctx, user, "")
+	refreshed, err := uc.Refresh(ctx, issued.RefreshToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.Refresh(ctx, issued.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	// The token rotated from the reused one is revoked along with it
+	if _, err := uc.Refresh(ctx, refreshed.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected ErrInvalidRefreshToken, got %v", err)
+	}
+}
+
+func TestUseCase_Refresh_Invalid(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	ctx := context.Background()
+
+	if _, err := uc.Refresh(ctx, "rt_unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected ErrInvalidRefreshToken, got %v", err)
+	}
+
+	uc.refreshTTL = -time.Minute
+	expired, _ := uc.IssueTokens(ctx, user, "")
+	if _, err := uc.Refresh(ctx, expired.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected ErrInvalidRefreshToken for expired token, got %v", err)
+	}
+}
+
+func TestUseCase_IssueTokens_SessionTimeout(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	refreshTokens := newMemRefreshTokens()
+	uc.refreshTokens = refreshTokens
+	uc.SetSessionSettings(staticSettings(entities.SystemSettings{SessionTimeout: 30}))
+
+	issued, err := uc.IssueTokens(context.Background(), user, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issued.ExpiresIn != int(time.Hour.Seconds()) {
+		t.Fatalf("expected the access token TTL in expires_in, got %d", issued.ExpiresIn)
+	}
+
+	stored := refreshTokens.tokens[hashToken(issued.RefreshToken)]
+	if ttl := time.Until(stored.ExpiresAt); ttl > 30*time.Minute || ttl < 29*time.Minute {
+		t.Fatalf("expected the refresh token to last the session timeout, got %s", ttl)
+	}
+}
+
+func TestUseCase_Logout(t *testing.T) {
+	uc, user := newRefreshTestUseCase(t)
+	ctx := context.Background()
+
+	issued, _ := uc.IssueTokens(ctx, user, "")
+	if err := uc.Logout(ctx, issued.RefreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Refresh(ctx, issued.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected ErrInvalidRefreshToken after logout, got %v", err)
+	}
+	if err := uc.Logout(ctx, issued.RefreshToken); err != nil {
+		t.Fatalf("expected logging out twice to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ProviderConstructor creates a provider from its configuration. It returns
+// an error when settings the provider needs are missing.
+type ProviderConstructor func(cfg AuthConfig) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderConstructor{}
+)
+
+// Register makes a provider available under name. Providers register from an
+// init function, so projects can add their own without touching the
+// factory:
+//
+//	func init() {
+//		auth.Register("keycloak", func(cfg auth.AuthConfig) (auth.Provider, error) {
+//			return keycloak.New(cfg.Options["url"], cfg.Options["realm"])
+//		})
+//	}
+//
+// Register panics when ctor is nil or name is already taken.
+func Register(name string, ctor ProviderConstructor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if ctor == nil {
+		panic("auth: Register constructor is nil for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("auth: Register called twice for provider %s", name))
+	}
+	registry[name] = ctor
+}
+
+// RegisteredProviders returns the names of all registered providers, sorted.
+func RegisteredProviders() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func lookupProvider(name string) (ProviderConstructor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ctor, ok := registry[name]
+	return ctor, ok
+}
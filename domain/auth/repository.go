@@ -3,11 +3,40 @@ package auth
 import (
 	"context"
 	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
 )
 
-//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository RefreshTokenRepository
 
 type Repository interface {
 	Create(ctx context.Context, user entities.User) error
+	GetByID(ctx context.Context, id uuid.UUID) (entities.User, error)
 	GetByEmail(ctx context.Context, email string) (entities.User, error)
+	GetByAuthProviderID(ctx context.Context, provider, providerID string) (entities.User, error)
+	GetByPhone(ctx context.Context, phone string) (entities.User, error)
+	// SetPhone stores a verified phone number, or removes it when phone is
+	// empty. Returns domain.ErrDuplicateKey when another user has it.
+	SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+	// SetEmail replaces the user's email with a confirmed address. Returns
+	// domain.ErrDuplicateKey when another user has it.
+	SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	// SetEmailVerified marks email as the user's verified address. Returns
+	// domain.ErrNotFound when the user's email has changed since.
+	SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+}
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token entities.RefreshToken) error
+	// GetRefreshTokenByHash returns the token with the given hash, or
+	// domain.ErrNotFound when there is none.
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (entities.RefreshToken, error)
+	// UseRefreshToken marks an active token as used, or returns
+	// domain.ErrNotFound when it was already used or revoked.
+	UseRefreshToken(ctx context.Context, id uuid.UUID) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	// RevokeUserRefreshTokens revokes every active token of the user, e.g.
+	// after a password reset.
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
 }
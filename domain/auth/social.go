@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/metrics"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	// ErrUnknownSocialProvider is returned for social login providers that
+	// are not configured.
+	ErrUnknownSocialProvider = errors.New("unknown social login provider")
+	// ErrSocialEmailUnverified is returned when the provider account has no
+	// verified email, since accounts are linked by email.
+	ErrSocialEmailUnverified = errors.New("social login email not verified")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/social_provider.go . SocialProvider
+
+// SocialProvider signs users in with an external account using the OAuth2
+// authorization code flow.
+type SocialProvider interface {
+	Provider() string
+	AuthCodeURL(state, redirectURI string) string
+	Exchange(ctx context.Context, code, redirectURI string) (entities.SocialIdentity, error)
+}
+
+type SocialLoginRequest struct {
+	Code        string `json:"code" validate:"required"`
+	RedirectURI string `json:"redirect_uri" validate:"required,url"`
+	// Audience is the application the token is for. Defaults to api.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+}
+
+// SetSocialProviders enables social login with the given providers.
+func (uc *UseCase) SetSocialProviders(providers ...SocialProvider) {
+	uc.social = make(map[string]SocialProvider, len(providers))
+	for _, p := range providers {
+		uc.social[p.Provider()] = p
+	}
+}
+
+// SocialProviders returns the names of the enabled social login providers.
+func (uc *UseCase) SocialProviders() []string {
+	names := make([]string, 0, len(uc.social))
+	for name := range uc.social {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SocialAuthURL returns the provider page users are sent to in order to sign
+// in. The provider redirects back to redirectURI with state and a code.
+func (uc *UseCase) SocialAuthURL(provider, state, redirectURI string) (string, error) {
+	p, ok := uc.social[provider]
+	if !ok {
+		return "", ErrUnknownSocialProvider
+	}
+	return p.AuthCodeURL(state, redirectURI), nil
+}
+
+// SocialLogin exchanges the authorization code returned by provider and signs
+// the user in. Users are found by their provider account first and by email
+// second, so signing in with Google links to an existing account with the
+// same email. Unknown users are created.
+func (uc *UseCase) SocialLogin(ctx context.Context, provider string, req SocialLoginRequest) (AuthResponse, error) {
+	p, ok := uc.social[provider]
+	if !ok {
+		return AuthResponse{}, ErrUnknownSocialProvider
+	}
+
+	identity, err := p.Exchange(ctx, req.Code, req.RedirectURI)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("social login exchange failed", "provider", provider, "error", err)
+		metrics.RecordLogin("", false)
+		return AuthResponse{}, fmt.Errorf("authentication failed: %w", err)
+	}
+	if identity.Email == "" || !identity.EmailVerified {
+		metrics.RecordLogin("", false)
+		return AuthResponse{}, ErrSocialEmailUnverified
+	}
+	identity.Email = strings.ToLower(identity.Email)
+
+	user, err := uc.findSocialUser(ctx, identity)
+	if errors.Is(err, domain.ErrNotFound) {
+		status, err := uc.newUserStatus(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to create user during social login", "provider", provider, "error", err)
+			return AuthResponse{}, fmt.Errorf("failed to create user: %w", err)
+		}
+		now := time.Now()
+		user = entities.User{
+			ID:             uuid.Must(uuid.NewV4()),
+			Email:          identity.Email,
+			AuthProvider:   identity.Provider,
+			AuthProviderID: identity.ID,
+			AccountType:    entities.AccountTypeUser,
+			Status:         status,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			// The provider verified the address
+			EmailVerified:   true,
+			EmailVerifiedAt: &now,
+		}
+		if err := uc.createUser(ctx, user); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to create user during social login", "provider", provider, "error", err)
+			return AuthResponse{}, fmt.Errorf("failed to create user: %w", err)
+		}
+		metrics.RecordSignup(user.AuthProvider, entities.AccountTypeUser)
+	} else if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user from database", "error", err)
+		return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
+	} else if !user.EmailVerified && strings.EqualFold(user.Email, identity.Email) {
+		now := time.Now()
+		if err := uc.repo.SetEmailVerified(ctx, user.ID, user.Email, now); err != nil {
+			return AuthResponse{}, fmt.Errorf("failed to set email verified: %w", err)
+		}
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+	}
+
+	response, err := uc.completeLogin(ctx, user, req.Audience)
+	uc.recordLogin(ctx, user.ID, provider, response, err)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("social login successful", "provider", provider, "user_id", user.ID)
+	metrics.RecordLogin(user.ID.String(), true)
+
+	return response, nil
+}
+
+func (uc *UseCase) findSocialUser(ctx context.Context, identity entities.SocialIdentity) (entities.User, error) {
+	user, err := uc.repo.GetByAuthProviderID(ctx, identity.Provider, identity.ID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return user, err
+	}
+	return uc.repo.GetByEmail(ctx, identity.Email)
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type fakeSocialProvider struct {
+	name     string
+	identity entities.SocialIdentity
+	err      error
+}
+
+func (p *fakeSocialProvider) Provider() string { return p.name }
+
+func (p *fakeSocialProvider) AuthCodeURL(state, redirectURI string) string {
+	return "https://provider.test/auth?state=" + state
+}
+
+func (p *fakeSocialProvider) Exchange(ctx context.Context, code, redirectURI string) (entities.SocialIdentity, error) {
+	return p.identity, p.err
+}
+
+func notFoundByProviderID(ctx context.Context, provider, providerID string) (entities.User, error) {
+	return entities.User{}, domain.ErrNotFound
+}
+
+func TestUseCase_SocialLogin_CreatesUser(t *testing.T) {
+	var created entities.User
+	repo := &mockRepository{
+		getByAuthProviderIDFunc: notFoundByProviderID,
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			return entities.User{}, domain.ErrNotFound
+		},
+		createFunc: func(ctx context.Context, user entities.User) error {
+			created = user
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetSocialProviders(&fakeSocialProvider{
+		name:     "github",
+		identity: entities.SocialIdentity{Provider: "github", ID: "42", Email: "A@B.com", EmailVerified: true},
+	})
+
+	resp, err := uc.SocialLogin(context.Background(), "github", SocialLoginRequest{Code: "code", RedirectURI: "http://localhost/cb"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Token == "" || resp.RefreshToken == "" {
+		t.Fatalf("expected tokens, got %+v", resp)
+	}
+	if created.Email != "a@b.com" || created.AuthProvider != "github" || created.AuthProviderID != "42" {
+		t.Fatalf("unexpected user created: %+v", created)
+	}
+}
+
+func TestUseCase_SocialLogin_NewUserPendingApproval(t *testing.T) {
+	var created entities.User
+	repo := &mockRepository{
+		getByAuthProviderIDFunc: notFoundByProviderID,
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			return entities.User{}, domain.ErrNotFound
+		},
+		createFunc: func(ctx context.Context, user entities.User) error {
+			created = user
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetRegistrationApproval(staticSettings(entities.SystemSettings{RequireApproval: true}))
+	uc.SetSocialProviders(&fakeSocialProvider{
+		name:     "github",
+		identity: entities.SocialIdentity{Provider: "github", ID: "42", Email: "a@b.com", EmailVerified: true},
+	})
+
+	_, err := uc.SocialLogin(context.Background(), "github", SocialLoginRequest{Code: "code", RedirectURI: "http://localhost/cb"})
+	if !errors.Is(err, domain.ErrAccountPending) {
+		t.Fatalf("expected ErrAccountPending, got %v", err)
+	}
+	if created.Status != entities.UserStatusPending {
+		t.Fatalf("expected a pending user, got %+v", created)
+	}
+}
+
+func TestUseCase_SocialLogin_LinksExistingUserByEmail(t *testing.T) {
+	existing := entities.User{
+		ID:             uuid.Must(uuid.NewV4()),
+		Email:          "a@b.com",
+		AuthProvider:   "supabase",
+		AuthProviderID: "prov-123",
+		AccountType:    entities.AccountTypeUser,
+	}
+	repo := &mockRepository{
+		getByAuthProviderIDFunc: notFoundByProviderID,
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email != "a@b.com" {
+				t.Fatalf("unexpected email lookup: %s", email)
+			}
+			return existing, nil
+		},
+		createFunc: func(ctx context.Context, user entities.User) error {
+			t.Fatalf("user should not be created")
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetSocialProviders(&fakeSocialProvider{
+		name:     "google",
+		identity: entities.SocialIdentity{Provider: "google", ID: "sub-1", Email: "a@b.com", EmailVerified: true},
+	})
+
+	resp, err := uc.SocialLogin(context.Background(), "google", SocialLoginRequest{Code: "code", RedirectURI: "http://localhost/cb"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.User.ID != existing.ID {
+		t.Fatalf("expected existing user, got %+v", resp.User)
+	}
+}
+
+func TestUseCase_SocialLogin_RequiresVerifiedEmail(t *testing.T) {
+	uc := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	uc.SetSocialProviders(&fakeSocialProvider{
+		name:     "github",
+		identity: entities.SocialIdentity{Provider: "github", ID: "42", Email: "a@b.com"},
+	})
+
+	_, err := uc.SocialLogin(context.Background(), "github", SocialLoginRequest{Code: "code"})
+	if !errors.Is(err, ErrSocialEmailUnverified) {
+		t.Fatalf("expected ErrSocialEmailUnverified, got %v", err)
+	}
+}
+
+func TestUseCase_SocialLogin_UnknownProvider(t *testing.T) {
+	uc := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+
+	if _, err := uc.SocialAuthURL("google", "state", "http://localhost/cb"); !errors.Is(err, ErrUnknownSocialProvider) {
+		t.Fatalf("expected ErrUnknownSocialProvider, got %v", err)
+	}
+	if _, err := uc.SocialLogin(context.Background(), "google", SocialLoginRequest{Code: "code"}); !errors.Is(err, ErrUnknownSocialProvider) {
+		t.Fatalf("expected ErrUnknownSocialProvider, got %v", err)
+	}
+}
@@ -0,0 +1,444 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"go-template/internal/totp"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// MFATokenTTL is how long a pending two-factor challenge can be completed.
+	MFATokenTTL = 5 * time.Minute
+
+	// After totpMaxFailures wrong codes within totpLockout, codes are
+	// refused until the lockout passes.
+	totpMaxFailures = 5
+	totpLockout     = 15 * time.Minute
+)
+
+var (
+	// ErrTwoFactorUnavailable is returned when two-factor authentication is
+	// not configured.
+	ErrTwoFactorUnavailable = errors.New("two-factor authentication is not available")
+	// ErrTwoFactorNotEnrolled is returned when enabling or disabling TOTP
+	// without a matching enrollment.
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication is not set up")
+	// ErrTwoFactorAlreadyEnabled is returned when enrolling a user that
+	// already has TOTP enabled.
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorRequired is returned when an admin tries to disable TOTP
+	// while the Require2FA setting is on.
+	ErrTwoFactorRequired = errors.New("two-factor authentication is required")
+	// ErrInvalidTOTP is returned for wrong or already used codes.
+	ErrInvalidTOTP = errors.New("invalid two-factor code")
+	// ErrTwoFactorLocked is returned after too many wrong codes.
+	ErrTwoFactorLocked = errors.New("too many failed two-factor attempts, try again later")
+	// ErrInvalidMFAToken is returned for unknown or expired challenges.
+	ErrInvalidMFAToken = errors.New("invalid or expired two-factor challenge")
+	// ErrAdminRequired is returned for admin challenges completed by users
+	// who aren't admins.
+	ErrAdminRequired = errors.New("access denied: admin privileges required")
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/totp.go . TOTPRepository SettingsReader
+
+type TOTPRepository interface {
+	// SaveTOTP stores a pending enrollment, replacing any earlier one.
+	SaveTOTP(ctx context.Context, cred entities.TOTPCredential) error
+	// GetTOTP returns the user's enrollment, or domain.ErrNotFound when
+	// there is none.
+	GetTOTP(ctx context.Context, userID uuid.UUID) (entities.TOTPCredential, error)
+	EnableTOTP(ctx context.Context, userID uuid.UUID, enabledAt time.Time) error
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+	// UseTOTPStep records the time step of an accepted code and clears
+	// failures, or returns domain.ErrNotFound when that step or a later one
+	// was already used.
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	RecordTOTPFailure(ctx context.Context, userID uuid.UUID) error
+	// ReplaceRecoveryCodes stores a new set of hashed recovery codes,
+	// dropping the old ones.
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error
+	// UseRecoveryCode marks an unused code as used, or returns
+	// domain.ErrNotFound when there is no such unused code.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error
+	// CountRecoveryCodes returns how many unused codes the user has left.
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// SettingsReader loads the system settings, which decide whether admins must
+// use two-factor authentication.
+type SettingsReader interface {
+	GetSettings(ctx context.Context) (*entities.SystemSettings, error)
+}
+
+// TwoFactorStatus describes the user's second factor.
+type TwoFactorStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is set for admins while the Require2FA setting is on
+	Required bool `json:"required"`
+	// RecoveryCodesRemaining is the number of unused recovery codes
+	RecoveryCodesRemaining int `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorEnrollment is handed to the user to add the account to an
+// authenticator app.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	// URI is the otpauth:// provisioning URI
+	URI string `json:"uri"`
+	// QRCode is the URI as a PNG QR code, encoded as a data URL
+	QRCode string `json:"qr_code"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required_without=RecoveryCode"`
+	// RecoveryCode can be given instead of Code where noted
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+type TwoFactorChallengeRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required_without=RecoveryCode"`
+	// RecoveryCode is used instead of Code when the authenticator app is
+	// not at hand. Each recovery code works once.
+	RecoveryCode string `json:"recovery_code,omitempty"`
+	// Audience is the application the token is for. Defaults to api.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+}
+
+type twoFactor struct {
+	totp     TOTPRepository
+	settings SettingsReader
+	issuer   string
+}
+
+// SetTwoFactor enables TOTP two-factor authentication. issuer names the
+// service in authenticator apps.
+func (uc *UseCase) SetTwoFactor(totp TOTPRepository, settings SettingsReader, issuer string) {
+	uc.twoFactor = &twoFactor{totp: totp, settings: settings, issuer: issuer}
+}
+
+// AdminTwoFactorRequired reports whether the Require2FA setting is on.
+func (uc *UseCase) AdminTwoFactorRequired(ctx context.Context) (bool, error) {
+	if uc.twoFactor == nil || uc.twoFactor.settings == nil {
+		return false, nil
+	}
+	settings, err := uc.twoFactor.settings.GetSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return settings.Require2FA, nil
+}
+
+// TwoFactorStatus reports whether the user has TOTP enabled and whether they
+// must.
+func (uc *UseCase) TwoFactorStatus(ctx context.Context, userID uuid.UUID) (TwoFactorStatus, error) {
+	if uc.twoFactor == nil {
+		return TwoFactorStatus{}, ErrTwoFactorUnavailable
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return TwoFactorStatus{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var status TwoFactorStatus
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	switch {
+	case err == nil:
+		status.Enabled = cred.Enabled()
+	case !errors.Is(err, domain.ErrNotFound):
+		return TwoFactorStatus{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = uc.twoFactor.totp.CountRecoveryCodes(ctx, userID); err != nil {
+			return TwoFactorStatus{}, fmt.Errorf("failed to count recovery codes: %w", err)
+		}
+	}
+
+	if isAdmin(user) {
+		if status.Required, err = uc.AdminTwoFactorRequired(ctx); err != nil {
+			return TwoFactorStatus{}, err
+		}
+	}
+	return status, nil
+}
+
+// EnrollTOTP creates a new secret for the user. It takes effect once
+// confirmed with EnableTOTP; until then logins are unchanged.
+func (uc *UseCase) EnrollTOTP(ctx context.Context, userID uuid.UUID) (TwoFactorEnrollment, error) {
+	if uc.twoFactor == nil {
+		return TwoFactorEnrollment{}, ErrTwoFactorUnavailable
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	switch {
+	case err == nil && cred.Enabled():
+		return TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return TwoFactorEnrollment{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	key, err := totp.Generate(uc.twoFactor.issuer, user.Email)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	err = uc.twoFactor.totp.SaveTOTP(ctx, entities.TOTPCredential{
+		UserID:    userID,
+		Secret:    key.Secret,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("failed to save totp: %w", err)
+	}
+
+	return TwoFactorEnrollment{
+		Secret: key.Secret,
+		URI:    key.URI,
+		QRCode: key.QRCode,
+	}, nil
+}
+
+// EnableTOTP confirms a pending enrollment with a first code. It returns new
+// tokens for audience that record the second factor, so an admin held back
+// by Require2FA can carry on without logging in again, together with a fresh
+// set of recovery codes that is shown only this once.
+func (uc *UseCase) EnableTOTP(ctx context.Context, userID uuid.UUID, code, audience string) (AuthResponse, error) {
+	if uc.twoFactor == nil {
+		return AuthResponse{}, ErrTwoFactorUnavailable
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+	if cred.Enabled() {
+		return AuthResponse{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := uc.verifyTOTP(ctx, cred, code); err != nil {
+		return AuthResponse{}, err
+	}
+	recoveryCodes, err := uc.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	if err := uc.twoFactor.totp.EnableTOTP(ctx, userID, time.Now()); err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	response, err := uc.issueMFATokens(ctx, user, audience)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	response.RecoveryCodes = recoveryCodes
+
+	domain.LoggerOr(ctx, uc.logger).Info("two-factor authentication enabled", "audit", true, "user_id", userID)
+	return response, nil
+}
+
+// DisableTOTP removes the user's second factor after checking a current code,
+// or a recovery code when the authenticator app is lost. Admins can't disable
+// it while Require2FA is on.
+func (uc *UseCase) DisableTOTP(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	if uc.twoFactor == nil {
+		return ErrTwoFactorUnavailable
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !cred.Enabled()) {
+		return ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if isAdmin(user) {
+		required, err := uc.AdminTwoFactorRequired(ctx)
+		if err != nil {
+			return err
+		}
+		if required {
+			return ErrTwoFactorRequired
+		}
+	}
+
+	if err := uc.verifySecondFactor(ctx, cred, code, recoveryCode); err != nil {
+		return err
+	}
+	if err := uc.twoFactor.totp.DeleteTOTP(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("two-factor authentication disabled", "audit", true, "user_id", userID)
+	return nil
+}
+
+// CompleteTwoFactor finishes a login that returned MFARequired. The
+// challenge token is checked together with a code from the user's
+// authenticator app or one of their recovery codes. The account status is
+// checked again, since the user may have been suspended after the password
+// step, and challenges for the admin audience need an admin.
+func (uc *UseCase) CompleteTwoFactor(ctx context.Context, req TwoFactorChallengeRequest) (AuthResponse, error) {
+	if uc.twoFactor == nil {
+		return AuthResponse{}, ErrTwoFactorUnavailable
+	}
+
+	claims, err := uc.jwtService.ValidateToken(req.MFAToken)
+	if err != nil || !claims.HasAudience(jwt.AudienceMFA) {
+		return AuthResponse{}, ErrInvalidMFAToken
+	}
+	userID, err := uuid.FromString(claims.UserID)
+	if err != nil {
+		return AuthResponse{}, ErrInvalidMFAToken
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	if err := uc.verifySecondFactor(ctx, cred, req.Code, req.RecoveryCode); err != nil {
+		if errors.Is(err, ErrInvalidTOTP) {
+			domain.LoggerOr(ctx, uc.logger).Warn("two-factor challenge failed", "audit", true, "user_id", userID)
+		}
+		if errors.Is(err, ErrInvalidTOTP) || errors.Is(err, ErrTwoFactorLocked) {
+			uc.recordLogin(ctx, userID, loginProviderTOTP, AuthResponse{}, err)
+		}
+		return AuthResponse{}, err
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return AuthResponse{}, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := domain.CheckAccountStatus(user); err != nil {
+		return AuthResponse{}, err
+	}
+	if req.Audience == jwt.AudienceAdmin && !isAdmin(user) {
+		return AuthResponse{}, ErrAdminRequired
+	}
+
+	response, err := uc.issueMFATokens(ctx, user, req.Audience)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	uc.recordLogin(ctx, user.ID, loginProviderTOTP, response, nil)
+
+	domain.LoggerOr(ctx, uc.logger).Info("two-factor challenge completed", "user_id", user.ID)
+	return response, nil
+}
+
+// completeLogin issues tokens for a user who passed the first factor, or a
+// two-factor challenge when the user has TOTP enabled. Suspended and pending
+// users are turned away (see domain.CheckAccountStatus), and users who still
+// have to verify their email get ErrEmailNotVerified.
+func (uc *UseCase) completeLogin(ctx context.Context, user entities.User, audience string) (AuthResponse, error) {
+	if err := domain.CheckAccountStatus(user); err != nil {
+		return AuthResponse{}, err
+	}
+	if err := uc.checkEmailVerified(ctx, user); err != nil {
+		return AuthResponse{}, err
+	}
+	if uc.twoFactor == nil {
+		return uc.IssueTokens(ctx, user, audience)
+	}
+
+	cred, err := uc.twoFactor.totp.GetTOTP(ctx, user.ID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !cred.Enabled()) {
+		return uc.IssueTokens(ctx, user, audience)
+	}
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	mfaToken, err := uc.jwtService.WithAudience(jwt.AudienceMFA).GenerateTokenWithTTL(user.ID.String(), user.Email, user.AccountType.String(), MFATokenTTL)
+	if err != nil {
+		return AuthResponse{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return AuthResponse{
+		User:        user,
+		MFARequired: true,
+		MFAToken:    mfaToken,
+	}, nil
+}
+
+func (uc *UseCase) issueMFATokens(ctx context.Context, user entities.User, audience string) (AuthResponse, error) {
+	if audience == "" {
+		audience = jwt.AudienceAPI
+	}
+	return uc.issueTokens(ctx, user, audience, uuid.Must(uuid.NewV4()), true)
+}
+
+// verifySecondFactor checks recoveryCode when given and code otherwise.
+func (uc *UseCase) verifySecondFactor(ctx context.Context, cred entities.TOTPCredential, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		return uc.verifyRecoveryCode(ctx, cred, recoveryCode)
+	}
+	return uc.verifyTOTP(ctx, cred, code)
+}
+
+// verifyTOTP checks code against the user's secret. Each code works once,
+// and wrong codes count towards a temporary lockout.
+func (uc *UseCase) verifyTOTP(ctx context.Context, cred entities.TOTPCredential, code string) error {
+	if totpLocked(cred) {
+		return ErrTwoFactorLocked
+	}
+
+	step, ok := totp.Match(cred.Secret, code, time.Now())
+	if !ok {
+		if err := uc.twoFactor.totp.RecordTOTPFailure(ctx, cred.UserID); err != nil {
+			return fmt.Errorf("failed to record totp failure: %w", err)
+		}
+		return ErrInvalidTOTP
+	}
+
+	err := uc.twoFactor.totp.UseTOTPStep(ctx, cred.UserID, step)
+	if errors.Is(err, domain.ErrNotFound) {
+		return ErrInvalidTOTP
+	}
+	if err != nil {
+		return fmt.Errorf("failed to use totp step: %w", err)
+	}
+	return nil
+}
+
+func totpLocked(cred entities.TOTPCredential) bool {
+	return cred.FailedAttempts >= totpMaxFailures && cred.LastFailedAt != nil && time.Since(*cred.LastFailedAt) < totpLockout
+}
+
+func isAdmin(user entities.User) bool {
+	return user.AccountType == entities.AccountTypeAdmin || user.AccountType == entities.AccountTypeSuperAdmin
+}
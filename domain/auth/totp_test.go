@@ -0,0 +1,333 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"go-template/internal/totp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// memTOTP is an in-memory TOTPRepository
+type memTOTP struct {
+	mu    sync.Mutex
+	creds map[uuid.UUID]entities.TOTPCredential
+	// recovery maps code hashes to whether they were used
+	recovery map[uuid.UUID]map[string]bool
+}
+
+func newMemTOTP() *memTOTP {
+	return &memTOTP{
+		creds:    map[uuid.UUID]entities.TOTPCredential{},
+		recovery: map[uuid.UUID]map[string]bool{},
+	}
+}
+
+func (m *memTOTP) SaveTOTP(ctx context.Context, cred entities.TOTPCredential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.creds[cred.UserID] = cred
+	return nil
+}
+
+func (m *memTOTP) GetTOTP(ctx context.Context, userID uuid.UUID) (entities.TOTPCredential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cred, ok := m.creds[userID]
+	if !ok {
+		return entities.TOTPCredential{}, domain.ErrNotFound
+	}
+	return cred, nil
+}
+
+func (m *memTOTP) EnableTOTP(ctx context.Context, userID uuid.UUID, enabledAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cred := m.creds[userID]
+	cred.EnabledAt = &enabledAt
+	m.creds[userID] = cred
+	return nil
+}
+
+func (m *memTOTP) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.creds, userID)
+	delete(m.recovery, userID)
+	return nil
+}
+
+func (m *memTOTP) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cred, ok := m.creds[userID]
+	if !ok || cred.LastUsedStep >= step {
+		return domain.ErrNotFound
+	}
+	cred.LastUsedStep = step
+	cred.FailedAttempts = 0
+	m.creds[userID] = cred
+	return nil
+}
+
+func (m *memTOTP) RecordTOTPFailure(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cred := m.creds[userID]
+	now := time.Now()
+	cred.FailedAttempts++
+	cred.LastFailedAt = &now
+	m.creds[userID] = cred
+	return nil
+}
+
+func (m *memTOTP) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	codes := map[string]bool{}
+	for _, hash := range codeHashes {
+		codes[hash] = false
+	}
+	m.recovery[userID] = codes
+	return nil
+}
+
+func (m *memTOTP) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	used, ok := m.recovery[userID][codeHash]
+	if !ok || used {
+		return domain.ErrNotFound
+	}
+	m.recovery[userID][codeHash] = true
+	return nil
+}
+
+func (m *memTOTP) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, used := range m.recovery[userID] {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type staticSettings entities.SystemSettings
+
+func (s staticSettings) GetSettings(ctx context.Context) (*entities.SystemSettings, error) {
+	settings := entities.SystemSettings(s)
+	return &settings, nil
+}
+
+func newTwoFactorTestUseCase(user entities.User, settings entities.SystemSettings) *UseCase {
+	repo := &mockRepository{
+		getByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			return user, nil
+		},
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			if id != user.ID {
+				return entities.User{}, domain.ErrNotFound
+			}
+			return user, nil
+		},
+	}
+	uc := NewUseCase(repo, newMemRefreshTokens(), &mockProvider{}, newJWT(), time.Hour, discardLogger())
+	uc.SetTwoFactor(newMemTOTP(), staticSettings(settings), "Test")
+	return uc
+}
+
+// code returns the TOTP code for the step offset steps from now
+func code(t *testing.T, secret string, offset int) string {
+	t.Helper()
+	c, err := totp.Code(secret, time.Now().Add(time.Duration(offset)*totp.Period*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestUseCase_TwoFactorLogin(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	enrollment, err := uc.EnrollTOTP(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enrollment.Secret == "" || enrollment.URI == "" || enrollment.QRCode == "" {
+		t.Fatalf("unexpected enrollment: %+v", enrollment)
+	}
+
+	// Logins are unchanged until the enrollment is confirmed
+	resp, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"})
+	if err != nil || resp.MFARequired || resp.Token == "" {
+		t.Fatalf("unexpected login before enabling: %+v, %v", resp, err)
+	}
+
+	if _, err := uc.EnableTOTP(ctx, user.ID, code(t, enrollment.Secret, -1), jwt.AudienceWeb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err = uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.MFARequired || resp.MFAToken == "" || resp.Token != "" || resp.RefreshToken != "" {
+		t.Fatalf("expected a challenge, got %+v", resp)
+	}
+
+	// The challenge token is not an access token
+	if claims, err := newJWT().ValidateToken(resp.MFAToken); err != nil || !claims.HasAudience(jwt.AudienceMFA) {
+		t.Fatalf("expected an mfa audience token, got %v", err)
+	}
+
+	current := code(t, enrollment.Secret, 0)
+	done, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: resp.MFAToken, Code: current})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := newJWT().ValidateToken(done.Token)
+	if err != nil || !claims.MFA() {
+		t.Fatalf("expected an mfa access token, got %+v, %v", claims, err)
+	}
+
+	// Refreshing keeps the second factor
+	refreshed, err := uc.Refresh(ctx, done.RefreshToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims, _ := newJWT().ValidateToken(refreshed.Token); !claims.MFA() {
+		t.Fatalf("expected refreshed token to keep mfa")
+	}
+
+	// Codes can't be replayed
+	if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: resp.MFAToken, Code: current}); !errors.Is(err, ErrInvalidTOTP) {
+		t.Fatalf("expected ErrInvalidTOTP on replay, got %v", err)
+	}
+}
+
+func TestUseCase_CompleteTwoFactor_Invalid(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	access, _ := newJWT().GenerateToken(user.ID.String(), user.Email, user.AccountType.String())
+	if _, err := uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: access, Code: "123456"}); !errors.Is(err, ErrInvalidMFAToken) {
+		t.Fatalf("expected ErrInvalidMFAToken for an access token, got %v", err)
+	}
+
+	disabled := NewUseCase(&mockRepository{}, newMemRefreshTokens(), &mockProvider{}, newJWT(), 0, discardLogger())
+	if _, err := disabled.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{}); !errors.Is(err, ErrTwoFactorUnavailable) {
+		t.Fatalf("expected ErrTwoFactorUnavailable, got %v", err)
+	}
+}
+
+func TestUseCase_CompleteTwoFactor_ChecksUserBeforeIssuing(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     entities.User
+		audience string
+		want     error
+	}{
+		{
+			name: "suspended after the password step",
+			user: entities.User{AccountType: entities.AccountTypeUser, Status: entities.UserStatusSuspended},
+			want: domain.ErrAccountSuspended,
+		},
+		{
+			name:     "admin challenge for a user",
+			user:     entities.User{AccountType: entities.AccountTypeUser},
+			audience: jwt.AudienceAdmin,
+			want:     ErrAdminRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: tt.user.AccountType}
+			uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+			ctx := context.Background()
+
+			enrollment, _ := uc.EnrollTOTP(ctx, user.ID)
+			if _, err := uc.EnableTOTP(ctx, user.ID, code(t, enrollment.Secret, -1), ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp, err := uc.Login(ctx, LoginRequest{Email: user.Email, Password: "secret"})
+			if err != nil || !resp.MFARequired {
+				t.Fatalf("expected a challenge, got %+v, %v", resp, err)
+			}
+
+			user.Status = tt.user.Status
+			refreshTokens := newMemRefreshTokens()
+			uc.refreshTokens = refreshTokens
+			uc.repo.(*mockRepository).getByIDFunc = func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+				return user, nil
+			}
+
+			_, err = uc.CompleteTwoFactor(ctx, TwoFactorChallengeRequest{MFAToken: resp.MFAToken, Code: code(t, enrollment.Secret, 0), Audience: tt.audience})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if len(refreshTokens.tokens) != 0 {
+				t.Fatalf("expected no refresh tokens to be stored")
+			}
+		})
+	}
+}
+
+func TestUseCase_TwoFactor_Lockout(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@b.com", AccountType: entities.AccountTypeUser}
+	uc := newTwoFactorTestUseCase(user, entities.SystemSettings{})
+	ctx := context.Background()
+
+	enrollment, _ := uc.EnrollTOTP(ctx, user.ID)
+	right := code(t, enrollment.Secret, 0)
+	wrong := "000000"
+	if right == wrong {
+		wrong = "111111"
+	}
+
+	for range totpMaxFailures {
+		if _, err := uc.EnableTOTP(ctx, user.ID, wrong, ""); !errors.Is(err, ErrInvalidTOTP) {
+			t.Fatalf("expected ErrInvalidTOTP, got %v", err)
+		}
+	}
+	if _, err := uc.EnableTOTP(ctx, user.ID, right, ""); !errors.Is(err, ErrTwoFactorLocked) {
+		t.Fatalf("expected ErrTwoFactorLocked, got %v", err)
+	}
+}
+
+func TestUseCase_TwoFactor_RequiredForAdmins(t *testing.T) {
+	admin := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "admin@x.com", AccountType: entities.AccountTypeAdmin}
+	uc := newTwoFactorTestUseCase(admin, entities.SystemSettings{Require2FA: true})
+	ctx := context.Background()
+
+	status, err := uc.TwoFactorStatus(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Enabled || !status.Required {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	enrollment, _ := uc.EnrollTOTP(ctx, admin.ID)
+	if _, err := uc.EnableTOTP(ctx, admin.ID, code(t, enrollment.Secret, -1), jwt.AudienceAdmin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.EnrollTOTP(ctx, admin.ID); !errors.Is(err, ErrTwoFactorAlreadyEnabled) {
+		t.Fatalf("expected ErrTwoFactorAlreadyEnabled, got %v", err)
+	}
+	if err := uc.DisableTOTP(ctx, admin.ID, code(t, enrollment.Secret, 0), ""); !errors.Is(err, ErrTwoFactorRequired) {
+		t.Fatalf("expected ErrTwoFactorRequired, got %v", err)
+	}
+}
@@ -2,10 +2,13 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-template/domain"
 	"go-template/domain/entities"
+	"go-template/domain/events"
 	"go-template/internal/jwt"
+	"go-template/internal/metrics"
 	"log/slog"
 	"time"
 
@@ -15,34 +18,126 @@ import (
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// Audience is the application the token is for. Defaults to api. Admin
+	// tokens are only issued by the admin login.
+	Audience string `json:"audience,omitempty" validate:"omitempty,oneof=api web third-party"`
+	// CaptchaToken is the CAPTCHA widget response, needed while the
+	// RequireCaptcha setting is on.
+	CaptchaToken string `json:"captcha_token,omitempty"`
 }
 
 type AuthResponse struct {
-	Token string        `json:"token"`
-	User  entities.User `json:"user"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         entities.User `json:"user"`
+	// ExpiresIn is the access token lifetime in seconds. Clients refresh the
+	// session with RefreshToken before it runs out.
+	ExpiresIn int `json:"expires_in,omitempty"`
+	// MFARequired is set instead of the tokens when the user has two-factor
+	// authentication enabled. MFAToken is then exchanged for tokens together
+	// with a code from the authenticator app.
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	// EmailVerificationRequired is set instead of the tokens when a new user
+	// has to verify their email before signing in.
+	EmailVerificationRequired bool `json:"email_verification_required,omitempty"`
+	// ApprovalRequired is set instead of the tokens when a new user has to
+	// wait for an admin to approve their registration.
+	ApprovalRequired bool `json:"approval_required,omitempty"`
+	// RecoveryCodes is only set when two-factor authentication is enabled.
+	// The codes are not stored in plain text and can't be shown again.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 type UseCase struct {
-	repo         Repository
-	authProvider Provider
-	jwtService   jwt.Service
+	repo              Repository
+	refreshTokens     RefreshTokenRepository
+	authProvider      Provider
+	providers         AuthProviderFactory
+	jwtService        jwt.Service
+	refreshTTL        time.Duration
+	sessionSettings   SettingsReader
+	enrichers         []ClaimsEnricher
+	social            map[string]SocialProvider
+	smsLogin          *smsLogin
+	twoFactor         *twoFactor
+	passwordReset     *passwordReset
+	emailVerification *emailVerification
+	emailChange       *emailChange
+	captcha           *captcha
+	impersonationTTL  time.Duration
+	logins            LoginRecorder
+	approvalSettings  SettingsReader
+	passwordPolicy    PasswordValidator
+	sessions          SessionRevoker
+	events            events.Publisher
+	logger            *slog.Logger
 }
 
-func NewUseCase(repo Repository, authProvider Provider, jwtService jwt.Service) *UseCase {
+// NewUseCase creates the auth use case. Refresh tokens expire after
+// refreshTTL, or DefaultRefreshTokenTTL when it is not positive.
+func NewUseCase(repo Repository, refreshTokens RefreshTokenRepository, authProvider Provider, jwtService jwt.Service, refreshTTL time.Duration, logger *slog.Logger) *UseCase {
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
 	return &UseCase{
-		repo:         repo,
-		authProvider: authProvider,
-		jwtService:   jwtService,
+		repo:          repo,
+		refreshTokens: refreshTokens,
+		authProvider:  authProvider,
+		jwtService:    jwtService,
+		refreshTTL:    refreshTTL,
+		logger:        logger,
 	}
 }
 
+// SetProviderFactory makes Login authenticate users with the provider they
+// registered with, and unknown users with the default provider, as long as
+// the settings keep that provider available. Without it every login goes to
+// the provider given to NewUseCase.
+func (uc *UseCase) SetProviderFactory(providers AuthProviderFactory) {
+	uc.providers = providers
+}
+
+// SetEvents publishes UserCreated for users created on their first sign-in.
+func (uc *UseCase) SetEvents(events events.Publisher) {
+	uc.events = events
+}
+
+// createUser stores a user signing in for the first time, and publishes
+// UserCreated for them.
+func (uc *UseCase) createUser(ctx context.Context, user entities.User) error {
+	return events.Commit(ctx, uc.events, func(ctx context.Context) ([]events.Event, error) {
+		if err := uc.repo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.UserCreated{User: user}}, nil
+	})
+}
+
+// SetSessionSettings makes refresh tokens last the SessionTimeout of the
+// system settings, so admins can change how long sessions last without a
+// restart. The refreshTTL given to NewUseCase applies when the settings can't
+// be read.
+func (uc *UseCase) SetSessionSettings(settings SettingsReader) {
+	uc.sessionSettings = settings
+}
+
 func (uc *UseCase) Login(ctx context.Context, req LoginRequest) (AuthResponse, error) {
-	slog.Info("starting user login", "email", req.Email)
+	domain.LoggerOr(ctx, uc.logger).Info("starting user login", "email", req.Email)
 
-	// Authenticate with auth provider (Supabase)
-	authProviderID, err := uc.authProvider.Login(ctx, req.Email, req.Password)
+	provider, err := uc.loginProvider(ctx, req.Email)
 	if err != nil {
-		slog.Error("authentication failed", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("authentication failed", "error", err)
+		metrics.RecordLogin("", false)
+		return AuthResponse{}, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Authenticate with auth provider
+	authProviderID, err := provider.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("authentication failed", "error", err)
+		metrics.RecordLogin("", false)
+		uc.recordFailedLogin(ctx, uc.repo.GetByEmail, req.Email, provider.Provider(), err)
 		return AuthResponse{}, fmt.Errorf("authentication failed: %w", err)
 	}
 
@@ -51,37 +146,68 @@ func (uc *UseCase) Login(ctx context.Context, req LoginRequest) (AuthResponse, e
 	if err != nil {
 		if err == domain.ErrNotFound {
 			// User doesn't exist in our database, create them
+			status, err := uc.newUserStatus(ctx)
+			if err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to create user during login", "error", err)
+				return AuthResponse{}, fmt.Errorf("failed to create user: %w", err)
+			}
 			now := time.Now()
 			user = entities.User{
 				ID:             uuid.Must(uuid.NewV4()),
 				Email:          req.Email,
-				AuthProvider:   uc.authProvider.Provider(),
+				AuthProvider:   provider.Provider(),
 				AuthProviderID: authProviderID,
+				Status:         status,
 				CreatedAt:      now,
 				UpdatedAt:      now,
 			}
 
-			if err := uc.repo.Create(ctx, user); err != nil {
-				slog.Error("failed to create user during login", "error", err)
+			if err := uc.createUser(ctx, user); err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to create user during login", "error", err)
 				return AuthResponse{}, fmt.Errorf("failed to create user: %w", err)
 			}
+			metrics.RecordSignup(user.AuthProvider, entities.AccountTypeUser)
 		} else {
-			slog.Error("failed to get user from database", "error", err)
+			domain.LoggerOr(ctx, uc.logger).Error("failed to get user from database", "error", err)
 			return AuthResponse{}, fmt.Errorf("failed to get user: %w", err)
 		}
 	}
 
-	// Generate JWT token
-	token, err := uc.jwtService.GenerateToken(user.ID.String(), user.Email, user.AccountType.String())
+	response, err := uc.completeLogin(ctx, user, req.Audience)
+	uc.recordLogin(ctx, user.ID, provider.Provider(), response, err)
 	if err != nil {
-		slog.Error("failed to generate JWT token", "error", err)
-		return AuthResponse{}, fmt.Errorf("failed to generate token: %w", err)
+		return AuthResponse{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user login successful", "user_id", user.ID)
+	metrics.RecordLogin(user.ID.String(), true)
+
+	return response, nil
+}
+
+// loginProvider returns the provider to authenticate email with.
+func (uc *UseCase) loginProvider(ctx context.Context, email string) (Provider, error) {
+	if uc.providers == nil {
+		return uc.authProvider, nil
 	}
 
-	slog.Info("user login successful", "user_id", user.ID)
+	var name string
+	user, err := uc.repo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		name = user.AuthProvider
+	case !errors.Is(err, domain.ErrNotFound):
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if name == "" {
+		if name, err = uc.providers.DefaultProvider(ctx); err != nil {
+			return nil, err
+		}
+	}
 
-	return AuthResponse{
-		Token: token,
-		User:  user,
-	}, nil
+	// The provider the service started with is always available
+	if name == "" || name == uc.authProvider.Provider() {
+		return uc.authProvider, nil
+	}
+	return uc.providers.CreateAvailableProvider(ctx, name)
 }
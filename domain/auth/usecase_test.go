@@ -6,12 +6,18 @@ import (
 	"go-template/domain"
 	"go-template/domain/entities"
 	"go-template/internal/jwt"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
 
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
 func newJWT() jwt.Service {
 	return jwt.NewService("secret", "test", "1h")
 }
@@ -19,7 +25,14 @@ func newJWT() jwt.Service {
 // Simple mock for Repository
 type mockRepository struct {
 	getByEmailFunc func(ctx context.Context, email string) (entities.User, error)
+	getByIDFunc    func(ctx context.Context, id uuid.UUID) (entities.User, error)
 	createFunc     func(ctx context.Context, user entities.User) error
+
+	getByAuthProviderIDFunc func(ctx context.Context, provider, providerID string) (entities.User, error)
+	getByPhoneFunc          func(ctx context.Context, phone string) (entities.User, error)
+	setPhoneFunc            func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+	setEmailVerifiedFunc    func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	setEmailFunc            func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
 }
 
 func (m *mockRepository) GetByEmail(ctx context.Context, email string) (entities.User, error) {
@@ -37,6 +50,9 @@ func (m *mockRepository) Create(ctx context.Context, user entities.User) error {
 }
 
 func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (entities.User, error) {
+	if m.getByIDFunc != nil {
+		return m.getByIDFunc(ctx, id)
+	}
 	return entities.User{}, nil
 }
 
@@ -61,9 +77,40 @@ func (m *mockRepository) GetUserStats(ctx context.Context) (entities.UserStats,
 }
 
 func (m *mockRepository) GetByAuthProviderID(ctx context.Context, provider, providerID string) (entities.User, error) {
+	if m.getByAuthProviderIDFunc != nil {
+		return m.getByAuthProviderIDFunc(ctx, provider, providerID)
+	}
 	return entities.User{}, nil
 }
 
+func (m *mockRepository) GetByPhone(ctx context.Context, phone string) (entities.User, error) {
+	if m.getByPhoneFunc != nil {
+		return m.getByPhoneFunc(ctx, phone)
+	}
+	return entities.User{}, domain.ErrNotFound
+}
+
+func (m *mockRepository) SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	if m.setPhoneFunc != nil {
+		return m.setPhoneFunc(ctx, id, phone, verifiedAt)
+	}
+	return nil
+}
+
+func (m *mockRepository) SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	if m.setEmailVerifiedFunc != nil {
+		return m.setEmailVerifiedFunc(ctx, id, email, verifiedAt)
+	}
+	return nil
+}
+
+func (m *mockRepository) SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	if m.setEmailFunc != nil {
+		return m.setEmailFunc(ctx, id, email, verifiedAt)
+	}
+	return nil
+}
+
 // Simple mock for Provider
 type mockProvider struct {
 	loginFunc    func(ctx context.Context, email, password string) (string, error)
@@ -96,6 +143,10 @@ func (m *mockProvider) DeleteUser(ctx context.Context, authProviderID string) er
 	return nil
 }
 
+func (m *mockProvider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	return nil, nil
+}
+
 func TestUseCase_Login_Success_UserExists(t *testing.T) {
 	existingUser := entities.User{
 		ID:             uuid.Must(uuid.NewV4()),
@@ -115,7 +166,7 @@ func TestUseCase_Login_Success_UserExists(t *testing.T) {
 		loginFunc:    func(ctx context.Context, email, password string) (string, error) { return "prov-123", nil },
 		providerFunc: func() string { return "supabase" },
 	}
-	uc := NewUseCase(repo, provider, newJWT())
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
 
 	resp, err := uc.Login(context.Background(), LoginRequest{Email: "a@b.com", Password: "123456"})
 	if err != nil {
@@ -140,7 +191,7 @@ func TestUseCase_Login_Success_UserCreatedWhenMissing(t *testing.T) {
 		loginFunc:    func(ctx context.Context, email, password string) (string, error) { return "prov-123", nil },
 		providerFunc: func() string { return "supabase" },
 	}
-	uc := NewUseCase(repo, provider, newJWT())
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
 
 	resp, err := uc.Login(context.Background(), LoginRequest{Email: "a@b.com", Password: "123456"})
 	if err != nil {
@@ -161,10 +212,10 @@ func TestUseCase_Login_AuthError(t *testing.T) {
 			return "", errors.New("auth failed")
 		},
 	}
-	uc := NewUseCase(repo, provider, newJWT())
+	uc := NewUseCase(repo, newMemRefreshTokens(), provider, newJWT(), 0, discardLogger())
 
 	_, err := uc.Login(context.Background(), LoginRequest{Email: "a@b.com", Password: "123456"})
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,70 @@
+package authz
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Subject is the caller a Request is about.
+type Subject struct {
+	UserID      uuid.UUID
+	AccountType entities.AccountType
+	Roles       []string
+	// Permissions are the ones the caller's token was issued with
+	Permissions []entities.Permission
+	OrgID       string
+}
+
+// Request asks whether Subject may perform Action on Object, such as "write"
+// on "users".
+type Request struct {
+	Subject Subject
+	Object  string
+	Action  string
+	// Owner is the user owning the object, when it has one
+	Owner uuid.UUID
+}
+
+// Permission is the permission that grants the request.
+func (r Request) Permission() entities.Permission {
+	return entities.Permission(r.Object + ":" + r.Action)
+}
+
+// Authorizer decides requests. Policies that go beyond permissions, such as
+// resource ownership or organization roles, are expressed by swapping in
+// another implementation rather than by editing handlers.
+type Authorizer interface {
+	Authorize(ctx context.Context, req Request) (bool, error)
+}
+
+// PermissionChecker resolves an account's current permissions, as UseCase
+// does.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error)
+}
+
+// StaticAuthorizer allows owners to act on what they own, and everyone else
+// when they hold the "object:action" permission.
+type StaticAuthorizer struct {
+	permissions PermissionChecker
+}
+
+// NewStaticAuthorizer checks permissions with checker, or against the
+// subject's token permissions when checker is nil.
+func NewStaticAuthorizer(checker PermissionChecker) *StaticAuthorizer {
+	return &StaticAuthorizer{permissions: checker}
+}
+
+func (a *StaticAuthorizer) Authorize(ctx context.Context, req Request) (bool, error) {
+	if req.Owner != uuid.Nil && req.Owner == req.Subject.UserID {
+		return true, nil
+	}
+
+	if a.permissions == nil {
+		return slices.Contains(req.Subject.Permissions, req.Permission()), nil
+	}
+	return a.permissions.HasPermission(ctx, req.Subject.UserID, req.Subject.AccountType, req.Permission())
+}
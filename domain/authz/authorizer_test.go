@@ -0,0 +1,74 @@
+package authz
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type permissionFunc func(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error)
+
+func (f permissionFunc) HasPermission(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+	return f(ctx, userID, accountType, perm)
+}
+
+func TestStaticAuthorizer(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	subject := Subject{
+		UserID:      userID,
+		AccountType: entities.AccountTypeAdmin,
+		Permissions: []entities.Permission{entities.PermissionUsersRead},
+	}
+	onlyWrite := permissionFunc(func(ctx context.Context, id uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+		return perm == entities.PermissionUsersWrite, nil
+	})
+
+	tests := []struct {
+		name    string
+		checker PermissionChecker
+		req     Request
+		want    bool
+	}{
+		{
+			name: "token permission",
+			req:  Request{Subject: subject, Object: "users", Action: "read"},
+			want: true,
+		},
+		{
+			name: "missing token permission",
+			req:  Request{Subject: subject, Object: "users", Action: "write"},
+		},
+		{
+			name: "owners act on what they own",
+			req:  Request{Subject: subject, Object: "example", Action: "write", Owner: userID},
+			want: true,
+		},
+		{
+			name: "others' objects need the permission",
+			req:  Request{Subject: subject, Object: "example", Action: "write", Owner: uuid.Must(uuid.NewV4())},
+		},
+		{
+			name:    "checker overrides token permissions",
+			checker: onlyWrite,
+			req:     Request{Subject: subject, Object: "users", Action: "read"},
+		},
+		{
+			name:    "checker grants",
+			checker: onlyWrite,
+			req:     Request{Subject: subject, Object: "users", Action: "write"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := NewStaticAuthorizer(tt.checker).Authorize(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package authz
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository RoleRepository UserRepository
+
+type Repository interface {
+	GetAdminPermissions(ctx context.Context, userID uuid.UUID) (entities.AdminPermissions, error)
+	SaveAdminPermissions(ctx context.Context, permissions entities.AdminPermissions) error
+	DeleteAdminPermissions(ctx context.Context, userID uuid.UUID) error
+}
+
+type RoleRepository interface {
+	ListRoles(ctx context.Context) ([]entities.Role, error)
+	GetRole(ctx context.Context, id uuid.UUID) (entities.Role, error)
+	// ListUserRoles returns the roles assigned to the user, together with the
+	// builtin role of their account type
+	ListUserRoles(ctx context.Context, userID uuid.UUID, accountType entities.AccountType) ([]entities.Role, error)
+	CreateRole(ctx context.Context, role entities.Role) error
+	UpdateRole(ctx context.Context, role entities.Role) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	AssignRole(ctx context.Context, userID, roleID, assignedBy uuid.UUID) error
+	UnassignRole(ctx context.Context, userID, roleID uuid.UUID) error
+}
+
+type UserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entities.User, error)
+}
@@ -0,0 +1,148 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// RoleRequest describes a custom role. The name can't be changed once the
+// role exists.
+type RoleRequest struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Permissions []entities.Permission `json:"permissions"`
+}
+
+// ListRoles returns the builtin roles followed by the custom ones.
+func (uc *UseCase) ListRoles(ctx context.Context) ([]entities.Role, error) {
+	return uc.roles.ListRoles(ctx)
+}
+
+// CreateRole creates a custom role granting the given permissions.
+func (uc *UseCase) CreateRole(ctx context.Context, req RoleRequest, createdBy uuid.UUID) (entities.Role, error) {
+	name := strings.TrimSpace(req.Name)
+	if !entities.IsValidRoleName(name) {
+		return entities.Role{}, fmt.Errorf("%w: role names are 2 to 64 lowercase letters, digits, '-' or '_', and can't be an account type", domain.ErrMalformedParameters)
+	}
+
+	perms, err := validPermissions(req.Permissions)
+	if err != nil {
+		return entities.Role{}, err
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return entities.Role{}, fmt.Errorf("failed to generate role ID: %w", err)
+	}
+
+	now := time.Now()
+	role := entities.Role{
+		ID:          id,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: perms,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.roles.CreateRole(ctx, role); err != nil {
+		return entities.Role{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("role created", "role", role.Name, "permissions", perms, "created_by", createdBy)
+	return role, nil
+}
+
+// UpdateRole replaces the description and permissions of a custom role.
+func (uc *UseCase) UpdateRole(ctx context.Context, id uuid.UUID, req RoleRequest, updatedBy uuid.UUID) (entities.Role, error) {
+	role, err := uc.customRole(ctx, id)
+	if err != nil {
+		return entities.Role{}, err
+	}
+
+	perms, err := validPermissions(req.Permissions)
+	if err != nil {
+		return entities.Role{}, err
+	}
+
+	role.Description = strings.TrimSpace(req.Description)
+	role.Permissions = perms
+	role.UpdatedAt = time.Now()
+	if err := uc.roles.UpdateRole(ctx, role); err != nil {
+		return entities.Role{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("role updated", "role", role.Name, "permissions", perms, "updated_by", updatedBy)
+	return role, nil
+}
+
+// DeleteRole deletes a custom role, taking its permissions away from the
+// users it was assigned to.
+func (uc *UseCase) DeleteRole(ctx context.Context, id, deletedBy uuid.UUID) error {
+	role, err := uc.customRole(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roles.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("role deleted", "role", role.Name, "deleted_by", deletedBy)
+	return nil
+}
+
+// UserRoles returns the roles the user holds, including the builtin role of
+// their account type.
+func (uc *UseCase) UserRoles(ctx context.Context, userID uuid.UUID) ([]entities.Role, error) {
+	user, err := uc.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.roles.ListUserRoles(ctx, user.ID, user.AccountType)
+}
+
+// AssignRole gives the user a custom role. Builtin roles follow the account
+// type and can't be assigned.
+func (uc *UseCase) AssignRole(ctx context.Context, userID, roleID, assignedBy uuid.UUID) error {
+	role, err := uc.customRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.roles.AssignRole(ctx, userID, roleID, assignedBy); err != nil {
+		return err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("role assigned", "role", role.Name, "user_id", userID, "assigned_by", assignedBy)
+	return nil
+}
+
+// UnassignRole takes a custom role away from the user.
+func (uc *UseCase) UnassignRole(ctx context.Context, userID, roleID, unassignedBy uuid.UUID) error {
+	if err := uc.roles.UnassignRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("role unassigned", "role_id", roleID, "user_id", userID, "unassigned_by", unassignedBy)
+	return nil
+}
+
+func (uc *UseCase) customRole(ctx context.Context, id uuid.UUID) (entities.Role, error) {
+	role, err := uc.roles.GetRole(ctx, id)
+	if err != nil {
+		return entities.Role{}, err
+	}
+	if role.Builtin {
+		return entities.Role{}, fmt.Errorf("%w: builtin roles can't be changed", domain.ErrMalformedParameters)
+	}
+	return role, nil
+}
@@ -0,0 +1,91 @@
+package authz
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/authz/mocks"
+	"go-template/domain/entities"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_CreateRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       RoleRequest
+		wantPerms []entities.Permission
+		wantErr   error
+	}{
+		{
+			name: "normalizes permissions",
+			req: RoleRequest{
+				Name:        "support",
+				Permissions: []entities.Permission{entities.PermissionUsersWrite, entities.PermissionUsersRead, entities.PermissionUsersWrite},
+			},
+			wantPerms: []entities.Permission{entities.PermissionUsersRead, entities.PermissionUsersWrite},
+		},
+		{
+			name:    "rejects invalid names",
+			req:     RoleRequest{Name: "Support Team"},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects account type names",
+			req:     RoleRequest{Name: "admin"},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects unknown permissions",
+			req:     RoleRequest{Name: "support", Permissions: []entities.Permission{"users:delete"}},
+			wantErr: domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := &mocks.RoleRepositoryMock{}
+			uc := newTestUseCase(&mocks.RepositoryMock{}, roles, &mocks.UserRepositoryMock{})
+
+			role, err := uc.CreateRole(context.Background(), tt.req, uuid.Must(uuid.NewV4()))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, roles.CreateRoleCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, roles.CreateRoleCalls(), 1)
+			assert.Equal(t, role, roles.CreateRoleCalls()[0].Role)
+			assert.Equal(t, tt.wantPerms, role.Permissions)
+			assert.False(t, role.Builtin)
+		})
+	}
+}
+
+func TestUseCase_BuiltinRolesAreReadOnly(t *testing.T) {
+	builtin := entities.Role{ID: uuid.Must(uuid.NewV4()), Name: "admin", Builtin: true}
+	roles := &mocks.RoleRepositoryMock{
+		GetRoleFunc: func(ctx context.Context, id uuid.UUID) (entities.Role, error) {
+			return builtin, nil
+		},
+	}
+	users := &mocks.UserRepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return entities.User{ID: id, AccountType: entities.AccountTypeUser}, nil
+		},
+	}
+	uc := newTestUseCase(&mocks.RepositoryMock{}, roles, users)
+	ctx := context.Background()
+	by := uuid.Must(uuid.NewV4())
+
+	_, err := uc.UpdateRole(ctx, builtin.ID, RoleRequest{}, by)
+	assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+	assert.ErrorIs(t, uc.DeleteRole(ctx, builtin.ID, by), domain.ErrMalformedParameters)
+	assert.ErrorIs(t, uc.AssignRole(ctx, uuid.Must(uuid.NewV4()), builtin.ID, by), domain.ErrMalformedParameters)
+
+	assert.Empty(t, roles.UpdateRoleCalls())
+	assert.Empty(t, roles.DeleteRoleCalls())
+	assert.Empty(t, roles.AssignRoleCalls())
+}
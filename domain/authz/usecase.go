@@ -0,0 +1,199 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// UseCase resolves which permissions an account holds. Super admins always
+// hold every permission. Admins hold the permissions of the builtin admin role
+// unless a super admin has restricted them to a subset, and regular users
+// hold none. On top of that, accounts hold the permissions of the custom roles
+// assigned to them.
+type UseCase struct {
+	repo   Repository
+	roles  RoleRepository
+	users  UserRepository
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, roles RoleRepository, users UserRepository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		roles:  roles,
+		users:  users,
+		logger: logger,
+	}
+}
+
+// Permissions returns the effective permissions of the given account.
+func (uc *UseCase) Permissions(ctx context.Context, userID uuid.UUID, accountType entities.AccountType) (entities.AdminPermissions, error) {
+	roles, err := uc.roles.ListUserRoles(ctx, userID, accountType)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to list user roles", "user_id", userID, "error", err)
+		return entities.AdminPermissions{}, err
+	}
+
+	perms := entities.AdminPermissions{UserID: userID}
+	var granted []entities.Permission
+	for _, role := range roles {
+		if role.Builtin {
+			continue
+		}
+		perms.Roles = append(perms.Roles, role.Name)
+		granted = append(granted, role.Permissions...)
+	}
+
+	switch accountType {
+	case entities.AccountTypeSuperAdmin:
+		granted = entities.AdminPermissionSet
+	case entities.AccountTypeAdmin:
+		restricted, err := uc.repo.GetAdminPermissions(ctx, userID)
+		if errors.Is(err, domain.ErrNotFound) {
+			for _, role := range roles {
+				if role.Builtin && role.Name == accountType.String() {
+					granted = append(granted, role.Permissions...)
+				}
+			}
+			break
+		}
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to get admin permissions", "user_id", userID, "error", err)
+			return entities.AdminPermissions{}, err
+		}
+		granted = append(granted, restricted.Permissions...)
+		perms.Restricted = true
+		perms.UpdatedBy = restricted.UpdatedBy
+		perms.UpdatedAt = restricted.UpdatedAt
+	}
+
+	perms.Permissions = canonical(granted)
+	return perms, nil
+}
+
+// HasPermission reports whether the given account holds perm.
+func (uc *UseCase) HasPermission(ctx context.Context, userID uuid.UUID, accountType entities.AccountType, perm entities.Permission) (bool, error) {
+	perms, err := uc.Permissions(ctx, userID, accountType)
+	if err != nil {
+		return false, err
+	}
+	return perms.Has(perm), nil
+}
+
+// EnrichClaims adds the account type and custom roles as roles and the
+// effective permissions to an access token, so other services can authorize
+// without calling back. The API itself still resolves permissions on every
+// request.
+func (uc *UseCase) EnrichClaims(ctx context.Context, user entities.User, claims *jwt.Claims) error {
+	claims.Roles = append(claims.Roles, user.AccountType.String())
+
+	perms, err := uc.Permissions(ctx, user.ID, user.AccountType)
+	if err != nil {
+		return fmt.Errorf("resolving permissions: %w", err)
+	}
+	claims.Roles = append(claims.Roles, perms.Roles...)
+	for _, perm := range perms.Permissions {
+		claims.Permissions = append(claims.Permissions, perm.String())
+	}
+	return nil
+}
+
+// GetAdminPermissions returns the effective permissions of an admin account.
+func (uc *UseCase) GetAdminPermissions(ctx context.Context, userID uuid.UUID) (entities.AdminPermissions, error) {
+	user, err := uc.getAdmin(ctx, userID)
+	if err != nil {
+		return entities.AdminPermissions{}, err
+	}
+	return uc.Permissions(ctx, user.ID, user.AccountType)
+}
+
+// SetAdminPermissions restricts an admin to the given subset of permissions.
+// Super admins cannot be restricted.
+func (uc *UseCase) SetAdminPermissions(ctx context.Context, userID uuid.UUID, permissions []entities.Permission, updatedBy uuid.UUID) (entities.AdminPermissions, error) {
+	user, err := uc.getAdmin(ctx, userID)
+	if err != nil {
+		return entities.AdminPermissions{}, err
+	}
+	if user.AccountType != entities.AccountTypeAdmin {
+		return entities.AdminPermissions{}, fmt.Errorf("%w: only admin accounts can be restricted", domain.ErrMalformedParameters)
+	}
+
+	granted, err := validPermissions(permissions)
+	if err != nil {
+		return entities.AdminPermissions{}, err
+	}
+
+	now := time.Now()
+	perms := entities.AdminPermissions{
+		UserID:      user.ID,
+		Permissions: granted,
+		Restricted:  true,
+		UpdatedBy:   &updatedBy,
+		UpdatedAt:   &now,
+	}
+	if err := uc.repo.SaveAdminPermissions(ctx, perms); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to save admin permissions", "user_id", userID, "error", err)
+		return entities.AdminPermissions{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("admin permissions updated", "user_id", userID, "updated_by", updatedBy, "permissions", granted)
+	return perms, nil
+}
+
+// ResetAdminPermissions lifts all restrictions from an admin.
+func (uc *UseCase) ResetAdminPermissions(ctx context.Context, userID uuid.UUID) error {
+	if _, err := uc.getAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteAdminPermissions(ctx, userID); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to delete admin permissions", "user_id", userID, "error", err)
+		return err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("admin permissions reset", "user_id", userID)
+	return nil
+}
+
+func (uc *UseCase) getAdmin(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	user, err := uc.users.GetByID(ctx, userID)
+	if err != nil {
+		return entities.User{}, err
+	}
+	if user.AccountType != entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeSuperAdmin {
+		return entities.User{}, fmt.Errorf("%w: user is not an admin", domain.ErrMalformedParameters)
+	}
+	return user, nil
+}
+
+// validPermissions rejects unknown permissions and returns the rest in
+// canonical order.
+func validPermissions(permissions []entities.Permission) ([]entities.Permission, error) {
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, fmt.Errorf("%w: unknown permission %q", domain.ErrMalformedParameters, p)
+		}
+	}
+	return canonical(permissions), nil
+}
+
+// canonical keeps the known permissions in the order of AdminPermissionSet,
+// without duplicates.
+func canonical(permissions []entities.Permission) []entities.Permission {
+	perms := make([]entities.Permission, 0, len(permissions))
+	for _, p := range entities.AdminPermissionSet {
+		if slices.Contains(permissions, p) {
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
@@ -0,0 +1,178 @@
+package authz
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/authz/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo *mocks.RepositoryMock, roles *mocks.RoleRepositoryMock, users *mocks.UserRepositoryMock) *UseCase {
+	return NewUseCase(repo, roles, users, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Permissions(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	restricted := entities.AdminPermissions{
+		UserID:      userID,
+		Permissions: []entities.Permission{entities.PermissionUsersRead},
+	}
+	support := entities.Role{
+		Name:        "support",
+		Permissions: []entities.Permission{entities.PermissionUsersWrite, entities.PermissionUsersRead},
+	}
+
+	tests := []struct {
+		name           string
+		accountType    entities.AccountType
+		stored         *entities.AdminPermissions
+		roles          []entities.Role
+		wantPerms      []entities.Permission
+		wantRoles      []string
+		wantRestricted bool
+	}{
+		{
+			name:        "super admin holds everything",
+			accountType: entities.AccountTypeSuperAdmin,
+			stored:      &restricted,
+			wantPerms:   entities.AdminPermissionSet,
+		},
+		{
+			name:        "unrestricted admin holds everything",
+			accountType: entities.AccountTypeAdmin,
+			wantPerms:   entities.AdminPermissionSet,
+		},
+		{
+			name:           "restricted admin",
+			accountType:    entities.AccountTypeAdmin,
+			stored:         &restricted,
+			wantPerms:      []entities.Permission{entities.PermissionUsersRead},
+			wantRestricted: true,
+		},
+		{
+			name:        "regular user holds nothing",
+			accountType: entities.AccountTypeUser,
+			wantPerms:   []entities.Permission{},
+		},
+		{
+			name:        "custom roles grant users their permissions",
+			accountType: entities.AccountTypeUser,
+			roles:       []entities.Role{support},
+			wantPerms:   []entities.Permission{entities.PermissionUsersRead, entities.PermissionUsersWrite},
+			wantRoles:   []string{"support"},
+		},
+		{
+			name:           "custom roles add to an admin's restriction",
+			accountType:    entities.AccountTypeAdmin,
+			stored:         &restricted,
+			roles:          []entities.Role{support},
+			wantPerms:      []entities.Permission{entities.PermissionUsersRead, entities.PermissionUsersWrite},
+			wantRoles:      []string{"support"},
+			wantRestricted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				GetAdminPermissionsFunc: func(ctx context.Context, id uuid.UUID) (entities.AdminPermissions, error) {
+					if tt.stored == nil {
+						return entities.AdminPermissions{}, domain.ErrNotFound
+					}
+					return *tt.stored, nil
+				},
+			}
+			roles := &mocks.RoleRepositoryMock{
+				ListUserRolesFunc: func(ctx context.Context, id uuid.UUID, accountType entities.AccountType) ([]entities.Role, error) {
+					roles := slices.Clone(tt.roles)
+					if accountType != entities.AccountTypeUser {
+						roles = append(roles, entities.Role{Name: accountType.String(), Builtin: true, Permissions: entities.AdminPermissionSet})
+					}
+					return roles, nil
+				},
+			}
+			uc := newTestUseCase(repo, roles, &mocks.UserRepositoryMock{})
+
+			perms, err := uc.Permissions(context.Background(), userID, tt.accountType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPerms, perms.Permissions)
+			assert.Equal(t, tt.wantRoles, perms.Roles)
+			assert.Equal(t, tt.wantRestricted, perms.Restricted)
+		})
+	}
+}
+
+func TestUseCase_SetAdminPermissions(t *testing.T) {
+	superAdminID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name        string
+		accountType entities.AccountType
+		permissions []entities.Permission
+		wantPerms   []entities.Permission
+		wantErr     error
+	}{
+		{
+			name:        "normalizes order and duplicates",
+			accountType: entities.AccountTypeAdmin,
+			permissions: []entities.Permission{entities.PermissionUsersRead, entities.PermissionDashboardRead, entities.PermissionUsersRead},
+			wantPerms:   []entities.Permission{entities.PermissionDashboardRead, entities.PermissionUsersRead},
+		},
+		{
+			name:        "empty set revokes everything",
+			accountType: entities.AccountTypeAdmin,
+			permissions: nil,
+			wantPerms:   []entities.Permission{},
+		},
+		{
+			name:        "unknown permission",
+			accountType: entities.AccountTypeAdmin,
+			permissions: []entities.Permission{"settings:write"},
+			wantErr:     domain.ErrMalformedParameters,
+		},
+		{
+			name:        "super admins cannot be restricted",
+			accountType: entities.AccountTypeSuperAdmin,
+			permissions: []entities.Permission{entities.PermissionUsersRead},
+			wantErr:     domain.ErrMalformedParameters,
+		},
+		{
+			name:        "regular users are rejected",
+			accountType: entities.AccountTypeUser,
+			permissions: []entities.Permission{entities.PermissionUsersRead},
+			wantErr:     domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			users := &mocks.UserRepositoryMock{
+				GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+					return entities.User{ID: id, AccountType: tt.accountType}, nil
+				},
+			}
+			uc := newTestUseCase(repo, &mocks.RoleRepositoryMock{}, users)
+
+			perms, err := uc.SetAdminPermissions(context.Background(), uuid.Must(uuid.NewV4()), tt.permissions, superAdminID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.SaveAdminPermissionsCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPerms, perms.Permissions)
+			assert.True(t, perms.Restricted)
+			require.Len(t, repo.SaveAdminPermissionsCalls(), 1)
+			assert.Equal(t, superAdminID, *repo.SaveAdminPermissionsCalls()[0].Permissions.UpdatedBy)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package backup
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Dumper FileStorage SettingsReader Enqueuer
+
+type Repository interface {
+	CreateBackup(ctx context.Context, backup entities.Backup) error
+	// CreateScheduledBackup stores the scheduled backup unless another was
+	// created after since, and reports whether it did. Concurrent
+	// schedulers never both create one.
+	CreateScheduledBackup(ctx context.Context, backup entities.Backup, since time.Time) (bool, error)
+	// GetBackup returns domain.ErrNotFound when there is no such backup.
+	GetBackup(ctx context.Context, id uuid.UUID) (entities.Backup, error)
+	// ListBackups returns a page of backups, newest first.
+	ListBackups(ctx context.Context, limit, offset int32) ([]entities.Backup, error)
+	CountBackups(ctx context.Context) (int64, error)
+	// StartBackup marks the backup as running since startedAt. It returns
+	// domain.ErrNotFound when there is no such backup, or it has already
+	// succeeded.
+	StartBackup(ctx context.Context, id uuid.UUID, startedAt time.Time) error
+	CompleteBackup(ctx context.Context, id uuid.UUID, fileKey string, size int64, finishedAt time.Time) error
+	FailBackup(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error
+	// FailStaleBackups fails the backups still running since before
+	// startedBefore, and returns how many it failed.
+	FailStaleBackups(ctx context.Context, startedBefore time.Time, message string, finishedAt time.Time) (int64, error)
+	// ListExpiredBackups returns up to limit finished backups created
+	// before createdBefore, oldest first. The latest backup that succeeded
+	// is never among them.
+	ListExpiredBackups(ctx context.Context, createdBefore time.Time, limit int32) ([]entities.Backup, error)
+	DeleteBackup(ctx context.Context, id uuid.UUID) error
+}
+
+// Dumper dumps the database.
+type Dumper interface {
+	// Dump writes a dump of the whole database to w.
+	Dump(ctx context.Context, w io.Writer) error
+}
+
+// FileStorage stores the backup files and links to them.
+type FileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// SettingsReader loads the system settings, which decide whether backups
+// are made daily and how long they are kept.
+type SettingsReader interface {
+	GetSettings(ctx context.Context) (*entities.SystemSettings, error)
+}
+
+// Enqueuer queues the jobs making the backups.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, args job.Args) (entities.Job, error)
+}
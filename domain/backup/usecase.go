@@ -0,0 +1,310 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	batchSize = 100
+	// every is how often a backup is made while the AutoBackup setting is
+	// on.
+	every = 24 * time.Hour
+	// staleAfter is how long a backup can be running before it is taken
+	// as failed, well past the job lease cutting its worker off.
+	staleAfter = 2 * time.Hour
+	// privatePrefix keeps the backups from being served without a signed
+	// link.
+	privatePrefix = "private/"
+	contentType   = "application/octet-stream"
+)
+
+// RunJob makes the pending backup with the given ID.
+type RunJob struct {
+	BackupID uuid.UUID `json:"backup_id"`
+}
+
+func (RunJob) JobKind() string { return "backup.run" }
+
+// UseCase dumps the database to file storage. Admins ask for backups,
+// and one is made daily while the AutoBackup setting is on; the dumps are
+// made by the background workers. Backups older than the
+// BackupRetentionDays setting are removed with their files, except the
+// latest one that succeeded.
+type UseCase struct {
+	repo     Repository
+	dumper   Dumper
+	files    FileStorage
+	settings SettingsReader
+	queue    Enqueuer
+	urlTTL   time.Duration
+	logger   *slog.Logger
+}
+
+func NewUseCase(repo Repository, dumper Dumper, files FileStorage, settings SettingsReader, queue Enqueuer, urlTTL time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		dumper:   dumper,
+		files:    files,
+		settings: settings,
+		queue:    queue,
+		urlTTL:   urlTTL,
+		logger:   logger,
+	}
+}
+
+// Create queues a backup, asked for by the actor in ctx.
+func (uc *UseCase) Create(ctx context.Context) (entities.Backup, error) {
+	backup := entities.Backup{
+		ID:        uuid.Must(uuid.NewV4()),
+		Status:    entities.BackupPending,
+		Trigger:   entities.BackupManual,
+		CreatedAt: time.Now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		backup.CreatedBy = &actor
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: backup not created")
+		return backup, nil
+	}
+
+	if err := uc.repo.CreateBackup(ctx, backup); err != nil {
+		return entities.Backup{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "backup created", "audit", true, "resource", "backup", "resource_id", backup.ID, "trigger", backup.Trigger)
+
+	if err := uc.enqueue(ctx, backup); err != nil {
+		return entities.Backup{}, err
+	}
+	return backup, nil
+}
+
+// enqueue queues the job making the backup, failing the backup when it
+// can't be, so it isn't left pending.
+func (uc *UseCase) enqueue(ctx context.Context, backup entities.Backup) error {
+	_, err := uc.queue.Enqueue(ctx, RunJob{BackupID: backup.ID})
+	if err == nil {
+		return nil
+	}
+	if failErr := uc.repo.FailBackup(ctx, backup.ID, "backup could not be queued", time.Now().UTC()); failErr != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to fail unqueued backup", "backup_id", backup.ID, "error", failErr)
+	}
+	return fmt.Errorf("queueing backup: %w", err)
+}
+
+// List returns a page of backups, newest first.
+func (uc *UseCase) List(ctx context.Context, page, pageSize int) (entities.BackupListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	backups, err := uc.repo.ListBackups(ctx, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return entities.BackupListResponse{}, err
+	}
+	total, err := uc.repo.CountBackups(ctx)
+	if err != nil {
+		return entities.BackupListResponse{}, err
+	}
+
+	if backups == nil {
+		backups = []entities.Backup{}
+	}
+	return entities.BackupListResponse{
+		Backups:    backups,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// Get returns the backup, or domain.ErrNotFound. A succeeded backup comes
+// with a fresh download link.
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (entities.Backup, error) {
+	backup, err := uc.repo.GetBackup(ctx, id)
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	if backup.Status != entities.BackupSucceeded {
+		return backup, nil
+	}
+
+	expiresAt := time.Now().UTC().Add(uc.urlTTL)
+	url, err := uc.files.SignedURL(backup.FileKey, uc.urlTTL)
+	if err != nil {
+		return entities.Backup{}, fmt.Errorf("signing backup download link: %w", err)
+	}
+	backup.DownloadURL = url
+	backup.DownloadURLExpiresAt = &expiresAt
+	return backup, nil
+}
+
+// RunBackup makes the backup, as the job queued for it. Backups that were
+// removed or already succeeded are skipped. A failed dump fails the backup
+// for good; the next one is made on schedule or when asked for.
+func (uc *UseCase) RunBackup(ctx context.Context, args RunJob) error {
+	err := uc.repo.StartBackup(ctx, args.BackupID, time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%sbackups/%s-%s.dump", privatePrefix, time.Now().UTC().Format("20060102T150405Z"), args.BackupID)
+	size, err := uc.dump(ctx, key)
+	finishedAt := time.Now().UTC()
+	recordCtx := context.WithoutCancel(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("backup failed", "backup_id", args.BackupID, "error", err)
+		if err := uc.files.Delete(recordCtx, key); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to delete partial backup file", "backup_id", args.BackupID, "error", err)
+		}
+		if err := uc.repo.FailBackup(recordCtx, args.BackupID, "backup failed", finishedAt); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			// Cut short by the worker stopping; tried again later
+			return err
+		}
+		return fmt.Errorf("%w: %w", job.ErrPermanent, err)
+	}
+
+	if err := uc.repo.CompleteBackup(recordCtx, args.BackupID, key, size, finishedAt); err != nil {
+		return err
+	}
+	domain.LoggerOr(ctx, uc.logger).Info("backup succeeded", "backup_id", args.BackupID, "size", size)
+	return nil
+}
+
+// dump pipes the database dump into file storage under key as it is
+// produced, rather than holding it in memory, and returns its size.
+func (uc *UseCase) dump(ctx context.Context, key string) (int64, error) {
+	pr, pw := io.Pipe()
+	dumped := make(chan error, 1)
+	counter := &countingWriter{w: pw}
+	go func() {
+		err := uc.dumper.Dump(ctx, counter)
+		pw.CloseWithError(err)
+		dumped <- err
+	}()
+
+	putErr := uc.files.Put(ctx, key, pr, contentType)
+	// Unblocks the dump if storage stopped reading early
+	pr.CloseWithError(io.ErrClosedPipe)
+	if err := <-dumped; err != nil {
+		return 0, err
+	}
+	if putErr != nil {
+		return 0, putErr
+	}
+	return counter.n, nil
+}
+
+// Run queues the daily backup when the AutoBackup setting is on and none
+// was made in the last day, fails the backups whose worker died, and
+// removes the backups older than the BackupRetentionDays setting.
+func (uc *UseCase) Run(ctx context.Context) error {
+	settings, err := uc.settings.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("loading settings: %w", err)
+	}
+	now := time.Now().UTC()
+
+	if settings.AutoBackup {
+		backup := entities.Backup{
+			ID:        uuid.Must(uuid.NewV4()),
+			Status:    entities.BackupPending,
+			Trigger:   entities.BackupScheduled,
+			CreatedAt: now,
+		}
+		created, err := uc.repo.CreateScheduledBackup(ctx, backup, now.Add(-every))
+		if err != nil {
+			return fmt.Errorf("scheduling backup: %w", err)
+		}
+		if created {
+			if err := uc.enqueue(ctx, backup); err != nil {
+				return err
+			}
+			domain.LoggerOr(ctx, uc.logger).Info("scheduled backup queued", "backup_id", backup.ID)
+		}
+	}
+
+	n, err := uc.repo.FailStaleBackups(ctx, now.Add(-staleAfter), "backup stopped while running", now)
+	if err != nil {
+		return fmt.Errorf("failing stale backups: %w", err)
+	}
+	if n > 0 {
+		domain.LoggerOr(ctx, uc.logger).Warn("failed stale backups", "count", n)
+	}
+
+	retention := time.Duration(settings.BackupRetentionDays) * 24 * time.Hour
+	return uc.purge(ctx, now.Add(-retention))
+}
+
+// purge removes the backups created before createdBefore, and their files.
+func (uc *UseCase) purge(ctx context.Context, createdBefore time.Time) error {
+	backups, err := uc.repo.ListExpiredBackups(ctx, createdBefore, batchSize)
+	if err != nil {
+		return fmt.Errorf("listing expired backups: %w", err)
+	}
+	for _, backup := range backups {
+		if backup.FileKey != "" {
+			if err := uc.files.Delete(ctx, backup.FileKey); err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to delete backup file", "backup_id", backup.ID, "error", err)
+				continue
+			}
+		}
+		if err := uc.repo.DeleteBackup(ctx, backup.ID); err != nil {
+			return err
+		}
+	}
+	if len(backups) > 0 {
+		domain.LoggerOr(ctx, uc.logger).Info("removed expired backups", "count", len(backups))
+	}
+	return nil
+}
+
+// Start runs Run every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.Run(ctx); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("backup schedule run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,211 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/backup/mocks"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, dumper Dumper, files FileStorage, settings SettingsReader, queue Enqueuer) *UseCase {
+	return NewUseCase(repo, dumper, files, settings, queue, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Create(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{}
+	queue := &mocks.EnqueuerMock{}
+	uc := newTestUseCase(repo, &mocks.DumperMock{}, &mocks.FileStorageMock{}, &mocks.SettingsReaderMock{}, queue)
+	ctx := domain.WithActor(context.Background(), actor)
+
+	backup, err := uc.Create(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entities.BackupPending, backup.Status)
+	assert.Equal(t, entities.BackupManual, backup.Trigger)
+	require.NotNil(t, backup.CreatedBy)
+	assert.Equal(t, actor, *backup.CreatedBy)
+	require.Len(t, repo.CreateBackupCalls(), 1)
+	assert.Equal(t, backup, repo.CreateBackupCalls()[0].Backup)
+	require.Len(t, queue.EnqueueCalls(), 1)
+	assert.Equal(t, RunJob{BackupID: backup.ID}, queue.EnqueueCalls()[0].Args)
+
+	t.Run("dry run stores nothing", func(t *testing.T) {
+		_, err := uc.Create(domain.WithDryRun(ctx))
+		require.NoError(t, err)
+		assert.Len(t, repo.CreateBackupCalls(), 1)
+		assert.Len(t, queue.EnqueueCalls(), 1)
+	})
+
+	t.Run("a backup that can't be queued is failed", func(t *testing.T) {
+		queue.EnqueueFunc = func(ctx context.Context, args job.Args) (entities.Job, error) {
+			return entities.Job{}, errors.New("database down")
+		}
+		_, err := uc.Create(ctx)
+		require.Error(t, err)
+		require.Len(t, repo.FailBackupCalls(), 1)
+		assert.Equal(t, repo.CreateBackupCalls()[1].Backup.ID, repo.FailBackupCalls()[0].ID)
+	})
+}
+
+func TestUseCase_Get(t *testing.T) {
+	pending := entities.Backup{ID: uuid.Must(uuid.NewV4()), Status: entities.BackupPending}
+	done := entities.Backup{ID: uuid.Must(uuid.NewV4()), Status: entities.BackupSucceeded, FileKey: "private/backups/b.dump"}
+	repo := &mocks.RepositoryMock{
+		GetBackupFunc: func(ctx context.Context, id uuid.UUID) (entities.Backup, error) {
+			for _, b := range []entities.Backup{pending, done} {
+				if b.ID == id {
+					return b, nil
+				}
+			}
+			return entities.Backup{}, domain.ErrNotFound
+		},
+	}
+	files := &mocks.FileStorageMock{
+		SignedURLFunc: func(key string, ttl time.Duration) (string, error) {
+			return "/files/" + key + "?signature=x", nil
+		},
+	}
+	uc := newTestUseCase(repo, &mocks.DumperMock{}, files, &mocks.SettingsReaderMock{}, &mocks.EnqueuerMock{})
+
+	got, err := uc.Get(context.Background(), pending.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.DownloadURL, "nothing to download yet")
+
+	got, err = uc.Get(context.Background(), done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/files/private/backups/b.dump?signature=x", got.DownloadURL)
+	require.NotNil(t, got.DownloadURLExpiresAt)
+
+	_, err = uc.Get(context.Background(), uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUseCase_RunBackup(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+
+	t.Run("stores the dump", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		var stored bytes.Buffer
+		files := &mocks.FileStorageMock{
+			PutFunc: func(ctx context.Context, key string, r io.Reader, contentType string) error {
+				_, err := io.Copy(&stored, r)
+				return err
+			},
+		}
+		dumper := &mocks.DumperMock{
+			DumpFunc: func(ctx context.Context, w io.Writer) error {
+				_, err := io.WriteString(w, "dump")
+				return err
+			},
+		}
+		uc := newTestUseCase(repo, dumper, files, &mocks.SettingsReaderMock{}, &mocks.EnqueuerMock{})
+
+		require.NoError(t, uc.RunBackup(context.Background(), RunJob{BackupID: id}))
+		assert.Equal(t, "dump", stored.String())
+		require.Len(t, repo.CompleteBackupCalls(), 1)
+		call := repo.CompleteBackupCalls()[0]
+		assert.Equal(t, id, call.ID)
+		assert.Equal(t, int64(4), call.Size)
+		assert.True(t, strings.HasPrefix(call.FileKey, "private/backups/"), call.FileKey)
+		assert.Equal(t, call.FileKey, files.PutCalls()[0].Key)
+	})
+
+	t.Run("a failed dump fails the backup for good", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		files := &mocks.FileStorageMock{
+			PutFunc: func(ctx context.Context, key string, r io.Reader, contentType string) error {
+				_, err := io.Copy(io.Discard, r)
+				return err
+			},
+		}
+		dumper := &mocks.DumperMock{
+			DumpFunc: func(ctx context.Context, w io.Writer) error {
+				return errors.New("pg_dump: connection refused")
+			},
+		}
+		uc := newTestUseCase(repo, dumper, files, &mocks.SettingsReaderMock{}, &mocks.EnqueuerMock{})
+
+		err := uc.RunBackup(context.Background(), RunJob{BackupID: id})
+		assert.ErrorIs(t, err, job.ErrPermanent)
+		assert.Empty(t, repo.CompleteBackupCalls())
+		require.Len(t, repo.FailBackupCalls(), 1)
+		assert.Len(t, files.DeleteCalls(), 1, "the partial file is removed")
+	})
+
+	t.Run("skips backups already made or removed", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			StartBackupFunc: func(ctx context.Context, id uuid.UUID, startedAt time.Time) error {
+				return domain.ErrNotFound
+			},
+		}
+		dumper := &mocks.DumperMock{}
+		uc := newTestUseCase(repo, dumper, &mocks.FileStorageMock{}, &mocks.SettingsReaderMock{}, &mocks.EnqueuerMock{})
+
+		require.NoError(t, uc.RunBackup(context.Background(), RunJob{BackupID: id}))
+		assert.Empty(t, dumper.DumpCalls())
+	})
+}
+
+func TestUseCase_Run(t *testing.T) {
+	settings := &entities.SystemSettings{AutoBackup: true, BackupRetentionDays: 7}
+	expired := []entities.Backup{
+		{ID: uuid.Must(uuid.NewV4()), Status: entities.BackupSucceeded, FileKey: "private/backups/old.dump"},
+		{ID: uuid.Must(uuid.NewV4()), Status: entities.BackupFailed},
+	}
+	scheduled := true
+	repo := &mocks.RepositoryMock{
+		CreateScheduledBackupFunc: func(ctx context.Context, backup entities.Backup, since time.Time) (bool, error) {
+			return scheduled, nil
+		},
+		ListExpiredBackupsFunc: func(ctx context.Context, createdBefore time.Time, limit int32) ([]entities.Backup, error) {
+			return expired, nil
+		},
+	}
+	files := &mocks.FileStorageMock{}
+	queue := &mocks.EnqueuerMock{}
+	uc := newTestUseCase(repo, &mocks.DumperMock{}, files, &mocks.SettingsReaderMock{
+		GetSettingsFunc: func(ctx context.Context) (*entities.SystemSettings, error) {
+			return settings, nil
+		},
+	}, queue)
+
+	before := time.Now().UTC()
+	require.NoError(t, uc.Run(context.Background()))
+
+	require.Len(t, repo.CreateScheduledBackupCalls(), 1)
+	call := repo.CreateScheduledBackupCalls()[0]
+	assert.Equal(t, entities.BackupScheduled, call.Backup.Trigger)
+	assert.WithinDuration(t, before.Add(-24*time.Hour), call.Since, time.Second)
+	require.Len(t, queue.EnqueueCalls(), 1)
+	assert.Equal(t, RunJob{BackupID: call.Backup.ID}, queue.EnqueueCalls()[0].Args)
+
+	require.Len(t, repo.ListExpiredBackupsCalls(), 1)
+	assert.WithinDuration(t, before.Add(-7*24*time.Hour), repo.ListExpiredBackupsCalls()[0].CreatedBefore, time.Second)
+	require.Len(t, files.DeleteCalls(), 1, "failed backups have no file")
+	assert.Equal(t, "private/backups/old.dump", files.DeleteCalls()[0].Key)
+	assert.Len(t, repo.DeleteBackupCalls(), 2)
+
+	t.Run("nothing is queued when a backup was made today", func(t *testing.T) {
+		scheduled = false
+		require.NoError(t, uc.Run(context.Background()))
+		assert.Len(t, queue.EnqueueCalls(), 1)
+	})
+
+	t.Run("nothing is scheduled with automatic backups off", func(t *testing.T) {
+		settings.AutoBackup = false
+		require.NoError(t, uc.Run(context.Background()))
+		assert.Len(t, repo.CreateScheduledBackupCalls(), 2)
+	})
+}
@@ -0,0 +1,29 @@
+package breakglass
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository TokenIssuer Alerter
+
+type Repository interface {
+	CreateCredential(ctx context.Context, credential entities.BreakGlassCredential) error
+	HasUnusedCredential(ctx context.Context) (bool, error)
+	// RedeemCredential marks the unused credential with the given hash as used
+	// and returns it, or domain.ErrNotFound when there is none.
+	RedeemCredential(ctx context.Context, credentialHash, usedFrom string) (entities.BreakGlassCredential, error)
+}
+
+// TokenIssuer mints session tokens without going through the auth provider.
+type TokenIssuer interface {
+	GenerateTokenWithTTL(userID, email, accountType string, ttl time.Duration) (string, error)
+}
+
+// Alerter notifies operators that emergency access was used.
+type Alerter interface {
+	Alert(ctx context.Context, alert entities.Alert) error
+	// HasChannel reports whether a channel takes alerts of severity.
+	HasChannel(ctx context.Context, severity entities.AlertSeverity) bool
+}
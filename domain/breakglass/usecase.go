@@ -0,0 +1,163 @@
+package breakglass
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DefaultSessionTTL is how long a break-glass session lasts when no TTL is
+// configured.
+const DefaultSessionTTL = 15 * time.Minute
+
+// credentialPrefix makes leaked credentials easy to spot in logs and scanners.
+const credentialPrefix = "bg_"
+
+var (
+	// ErrInvalidCredential is returned for unknown or already used
+	// credentials.
+	ErrInvalidCredential = errors.New("invalid break-glass credential")
+	// ErrNoAlertChannel is returned when no alert channel takes critical
+	// alerts, since every use has to raise one.
+	ErrNoAlertChannel = errors.New("break-glass access needs an alert channel taking critical alerts")
+)
+
+// UseCase manages the sealed break-glass credential. Redeeming it mints a
+// short-lived super admin session without calling the auth provider, so
+// operators can get in while the provider is down. Every use is persisted,
+// logged for audit and alerted on.
+type UseCase struct {
+	repo       Repository
+	tokens     TokenIssuer
+	alerter    Alerter
+	sessionTTL time.Duration
+	logger     *slog.Logger
+}
+
+// NewUseCase creates the break-glass use case. The credential can only be
+// sealed and redeemed while alerter has a channel taking critical alerts.
+func NewUseCase(repo Repository, tokens TokenIssuer, alerter Alerter, sessionTTL time.Duration, logger *slog.Logger) *UseCase {
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSessionTTL
+	}
+	return &UseCase{
+		repo:       repo,
+		tokens:     tokens,
+		alerter:    alerter,
+		sessionTTL: sessionTTL,
+		logger:     logger,
+	}
+}
+
+// Seal generates a new credential when no unused one exists and hands it to
+// deliver before storing its hash, so a credential is never stored without
+// having been handed over. It reports whether a credential was generated. The
+// plain text is never stored and can't be recovered.
+func (uc *UseCase) Seal(ctx context.Context, deliver func(secret string) error) (bool, error) {
+	if !uc.canAlert(ctx) {
+		return false, ErrNoAlertChannel
+	}
+
+	exists, err := uc.repo.HasUnusedCredential(ctx)
+	if err != nil {
+		return false, fmt.Errorf("checking break-glass credential: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return false, fmt.Errorf("generating break-glass credential: %w", err)
+	}
+	secret := credentialPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	if err := deliver(secret); err != nil {
+		return false, fmt.Errorf("delivering break-glass credential: %w", err)
+	}
+
+	credential := entities.BreakGlassCredential{
+		ID:             uuid.Must(uuid.NewV4()),
+		CredentialHash: hashCredential(secret),
+		CreatedAt:      time.Now(),
+	}
+	if err := uc.repo.CreateCredential(ctx, credential); err != nil {
+		return false, fmt.Errorf("storing break-glass credential: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("break-glass credential sealed", "audit", true, "credential_id", credential.ID)
+	return true, nil
+}
+
+// Redeem burns the credential and mints a super admin session for it. from
+// identifies the caller (usually the client IP) in the audit trail. Without
+// an alert channel the credential is refused and left unused.
+func (uc *UseCase) Redeem(ctx context.Context, secret, from string) (entities.BreakGlassSession, error) {
+	if !uc.canAlert(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Error("refused break-glass attempt, no alert channel takes critical alerts", "audit", true, "from", from)
+		return entities.BreakGlassSession{}, ErrNoAlertChannel
+	}
+
+	credential, err := uc.repo.RedeemCredential(ctx, hashCredential(secret), from)
+	if errors.Is(err, domain.ErrNotFound) {
+		domain.LoggerOr(ctx, uc.logger).Warn("rejected break-glass attempt", "audit", true, "from", from)
+		return entities.BreakGlassSession{}, ErrInvalidCredential
+	}
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to redeem break-glass credential", "from", from, "error", err)
+		return entities.BreakGlassSession{}, err
+	}
+
+	// The session belongs to the credential rather than a user account, so it
+	// works even when no super admin can sign in.
+	expiresAt := time.Now().Add(uc.sessionTTL)
+	token, err := uc.tokens.GenerateTokenWithTTL(credential.ID.String(), entities.BreakGlassEmail, entities.AccountTypeSuperAdmin.String(), uc.sessionTTL)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to mint break-glass session", "credential_id", credential.ID, "error", err)
+		return entities.BreakGlassSession{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Warn("break-glass credential used",
+		"audit", true,
+		"credential_id", credential.ID,
+		"from", from,
+		"expires_at", expiresAt,
+	)
+	uc.alert(ctx, credential, from, expiresAt)
+
+	return entities.BreakGlassSession{
+		Token:        token,
+		CredentialID: credential.ID,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (uc *UseCase) canAlert(ctx context.Context) bool {
+	return uc.alerter != nil && uc.alerter.HasChannel(ctx, entities.AlertSeverityCritical)
+}
+
+// alert doesn't block emergency access once a channel is configured; a failed
+// delivery is logged instead.
+func (uc *UseCase) alert(ctx context.Context, credential entities.BreakGlassCredential, from string, expiresAt time.Time) {
+	message := fmt.Sprintf("Break-glass credential %s was used from %s. A super admin session is active until %s. Seal a new credential once the incident is over.",
+		credential.ID, from, expiresAt.UTC().Format(time.RFC3339))
+	alert := entities.Alert{Title: "Break-glass access used", Message: message, Severity: entities.AlertSeverityCritical}
+	if err := uc.alerter.Alert(ctx, alert); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to send break-glass alert", "credential_id", credential.ID, "error", err)
+	}
+}
+
+func hashCredential(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,157 @@
+package breakglass
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/breakglass/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAlerter returns an alerter with a channel taking critical alerts.
+func newAlerter() *mocks.AlerterMock {
+	return &mocks.AlerterMock{
+		HasChannelFunc: func(ctx context.Context, severity entities.AlertSeverity) bool { return true },
+	}
+}
+
+func newTestUseCase(repo *mocks.RepositoryMock, tokens *mocks.TokenIssuerMock, alerter Alerter) *UseCase {
+	return NewUseCase(repo, tokens, alerter, 10*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Seal(t *testing.T) {
+	t.Run("generates a credential and stores its hash", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			HasUnusedCredentialFunc: func(ctx context.Context) (bool, error) { return false, nil },
+		}
+		uc := newTestUseCase(repo, &mocks.TokenIssuerMock{}, newAlerter())
+
+		var secret string
+		sealed, err := uc.Seal(context.Background(), func(s string) error {
+			secret = s
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, sealed)
+		assert.True(t, strings.HasPrefix(secret, credentialPrefix))
+
+		require.Len(t, repo.CreateCredentialCalls(), 1)
+		stored := repo.CreateCredentialCalls()[0].Credential
+		assert.Equal(t, hashCredential(secret), stored.CredentialHash)
+		assert.NotContains(t, stored.CredentialHash, secret)
+	})
+
+	t.Run("keeps the sealed credential", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			HasUnusedCredentialFunc: func(ctx context.Context) (bool, error) { return true, nil },
+		}
+		uc := newTestUseCase(repo, &mocks.TokenIssuerMock{}, newAlerter())
+
+		sealed, err := uc.Seal(context.Background(), func(string) error {
+			t.Fatal("credential delivered twice")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, sealed)
+		assert.Empty(t, repo.CreateCredentialCalls())
+	})
+
+	t.Run("stores nothing when delivery fails", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			HasUnusedCredentialFunc: func(ctx context.Context) (bool, error) { return false, nil },
+		}
+		uc := newTestUseCase(repo, &mocks.TokenIssuerMock{}, newAlerter())
+
+		_, err := uc.Seal(context.Background(), func(string) error { return errors.New("read-only filesystem") })
+		require.Error(t, err)
+		assert.Empty(t, repo.CreateCredentialCalls())
+	})
+
+	t.Run("refuses to seal without an alert channel", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		for _, alerter := range []Alerter{nil, &mocks.AlerterMock{}} {
+			uc := newTestUseCase(repo, &mocks.TokenIssuerMock{}, alerter)
+
+			_, err := uc.Seal(context.Background(), func(string) error {
+				t.Fatal("credential delivered without an alert channel")
+				return nil
+			})
+			assert.ErrorIs(t, err, ErrNoAlertChannel)
+		}
+		assert.Empty(t, repo.CreateCredentialCalls())
+	})
+}
+
+func TestUseCase_Redeem(t *testing.T) {
+	credential := entities.BreakGlassCredential{ID: uuid.Must(uuid.NewV4()), CredentialHash: hashCredential("bg_secret")}
+
+	newRepo := func() *mocks.RepositoryMock {
+		return &mocks.RepositoryMock{
+			RedeemCredentialFunc: func(ctx context.Context, credentialHash, usedFrom string) (entities.BreakGlassCredential, error) {
+				if credentialHash != credential.CredentialHash {
+					return entities.BreakGlassCredential{}, domain.ErrNotFound
+				}
+				return credential, nil
+			},
+		}
+	}
+	tokens := &mocks.TokenIssuerMock{
+		GenerateTokenWithTTLFunc: func(userID, email, accountType string, ttl time.Duration) (string, error) {
+			return "token", nil
+		},
+	}
+
+	t.Run("mints a super admin session and alerts", func(t *testing.T) {
+		alerter := newAlerter()
+		uc := newTestUseCase(newRepo(), tokens, alerter)
+
+		session, err := uc.Redeem(context.Background(), "bg_secret", "203.0.113.7")
+		require.NoError(t, err)
+		assert.Equal(t, "token", session.Token)
+		assert.Equal(t, credential.ID, session.CredentialID)
+
+		call := tokens.GenerateTokenWithTTLCalls()[len(tokens.GenerateTokenWithTTLCalls())-1]
+		assert.Equal(t, entities.AccountTypeSuperAdmin.String(), call.AccountType)
+		assert.Equal(t, 10*time.Minute, call.TTL)
+
+		require.Len(t, alerter.AlertCalls(), 1)
+		assert.Contains(t, alerter.AlertCalls()[0].Alert.Message, "203.0.113.7")
+		assert.Equal(t, entities.AlertSeverityCritical, alerter.AlertCalls()[0].Alert.Severity)
+	})
+
+	t.Run("alert failures don't block access", func(t *testing.T) {
+		alerter := newAlerter()
+		alerter.AlertFunc = func(ctx context.Context, alert entities.Alert) error { return errors.New("webhook down") }
+		uc := newTestUseCase(newRepo(), tokens, alerter)
+
+		_, err := uc.Redeem(context.Background(), "bg_secret", "203.0.113.7")
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses access without an alert channel", func(t *testing.T) {
+		repo := newRepo()
+		uc := newTestUseCase(repo, tokens, &mocks.AlerterMock{})
+
+		_, err := uc.Redeem(context.Background(), "bg_secret", "203.0.113.7")
+		assert.ErrorIs(t, err, ErrNoAlertChannel)
+		assert.Empty(t, repo.RedeemCredentialCalls(), "the credential must stay unused")
+	})
+
+	t.Run("rejects unknown or used credentials", func(t *testing.T) {
+		alerter := newAlerter()
+		uc := newTestUseCase(newRepo(), tokens, alerter)
+
+		_, err := uc.Redeem(context.Background(), "bg_wrong", "203.0.113.7")
+		assert.ErrorIs(t, err, ErrInvalidCredential)
+		assert.Empty(t, alerter.AlertCalls())
+	})
+}
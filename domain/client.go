@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+type clientKey struct{}
+
+// Client is where a request came from, as far as the server can tell.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// WithClient records the client a request came from, for the use cases that
+// keep track of it, like the login history.
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext returns the client recorded with WithClient, or the zero
+// Client.
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientKey{}).(Client)
+	return client
+}
@@ -0,0 +1,29 @@
+package comment
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository ExampleGetter
+
+type Repository interface {
+	// CreateComment returns domain.ErrNotFound when the example is gone.
+	CreateComment(ctx context.Context, comment entities.Comment) error
+	// GetComment returns domain.ErrNotFound when the example has no such
+	// comment.
+	GetComment(ctx context.Context, exampleID string, id uuid.UUID) (entities.Comment, error)
+	// ListComments lists a page of the example's comments, oldest first.
+	ListComments(ctx context.Context, exampleID string, limit, offset int32) ([]entities.Comment, error)
+	CountComments(ctx context.Context, exampleID string) (int64, error)
+	// DeleteComment returns domain.ErrNotFound when the example has no such
+	// comment.
+	DeleteComment(ctx context.Context, exampleID string, id uuid.UUID) error
+}
+
+// ExampleGetter finds the examples commented on.
+type ExampleGetter interface {
+	GetExampleByID(ctx context.Context, id string) (entities.Example, error)
+}
@@ -0,0 +1,132 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// MaxBodyLength is the most characters a comment can have.
+const MaxBodyLength = 5000
+
+var (
+	ErrEmpty   = errors.New("comment is empty")
+	ErrTooLong = fmt.Errorf("comment is longer than %d characters", MaxBodyLength)
+)
+
+// UseCase keeps the comments on examples. Comments are made by the actor in
+// the context; whether someone may delete one is left to the caller.
+type UseCase struct {
+	repo     Repository
+	examples ExampleGetter
+	logger   *slog.Logger
+}
+
+func NewUseCase(repo Repository, examples ExampleGetter, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		examples: examples,
+		logger:   logger,
+	}
+}
+
+// Create comments on the example as the actor in ctx. It returns
+// domain.ErrNotFound when there is no such example.
+func (uc *UseCase) Create(ctx context.Context, exampleID, body string) (entities.Comment, error) {
+	parsed, err := uuid.FromString(exampleID)
+	if err != nil {
+		return entities.Comment{}, domain.ErrNotFound
+	}
+	exampleID = parsed.String()
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return entities.Comment{}, ErrEmpty
+	}
+	if utf8.RuneCountInString(body) > MaxBodyLength {
+		return entities.Comment{}, ErrTooLong
+	}
+
+	if _, err := uc.examples.GetExampleByID(ctx, exampleID); err != nil {
+		return entities.Comment{}, err
+	}
+
+	comment := entities.Comment{
+		ID:        uuid.Must(uuid.NewV4()),
+		ExampleID: exampleID,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		comment.AuthorID = &actor
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: comment not created", "example_id", exampleID)
+		return comment, nil
+	}
+
+	if err := uc.repo.CreateComment(ctx, comment); err != nil {
+		return entities.Comment{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "comment created", "example_id", exampleID, "comment_id", comment.ID)
+	return comment, nil
+}
+
+// List returns a page of the example's comments, oldest first, and how many
+// there are in all. Pages start at 1 and hold 20 comments unless pageSize
+// is between 1 and 100. It returns domain.ErrNotFound when there is no such
+// example.
+func (uc *UseCase) List(ctx context.Context, exampleID string, page, pageSize int) ([]entities.Comment, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	if _, err := uc.examples.GetExampleByID(ctx, exampleID); err != nil {
+		return nil, 0, err
+	}
+
+	comments, err := uc.repo.ListComments(ctx, exampleID, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.repo.CountComments(ctx, exampleID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// Get returns the comment, or domain.ErrNotFound when the example has no
+// such comment.
+func (uc *UseCase) Get(ctx context.Context, exampleID string, id uuid.UUID) (entities.Comment, error) {
+	return uc.repo.GetComment(ctx, exampleID, id)
+}
+
+// Delete removes the comment from the example. It returns
+// domain.ErrNotFound when the example has no such comment.
+func (uc *UseCase) Delete(ctx context.Context, exampleID string, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		if _, err := uc.repo.GetComment(ctx, exampleID, id); err != nil {
+			return err
+		}
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: comment not deleted", "example_id", exampleID, "comment_id", id)
+		return nil
+	}
+
+	if err := uc.repo.DeleteComment(ctx, exampleID, id); err != nil {
+		return err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "comment deleted", "audit", true, "resource", "example", "resource_id", exampleID, "comment_id", id)
+	return nil
+}
@@ -0,0 +1,147 @@
+package comment
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/comment/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, examples ExampleGetter) *UseCase {
+	return NewUseCase(repo, examples, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func existingExamples() *mocks.ExampleGetterMock {
+	return &mocks.ExampleGetterMock{
+		GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{ID: id}, nil
+		},
+	}
+}
+
+func missingExamples() *mocks.ExampleGetterMock {
+	return &mocks.ExampleGetterMock{
+		GetExampleByIDFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			return entities.Example{}, domain.ErrNotFound
+		},
+	}
+}
+
+func TestUseCase_Create(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	exampleID := uuid.Must(uuid.NewV4()).String()
+	ctx := domain.WithActor(context.Background(), actor)
+
+	t.Run("comments as the actor", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, existingExamples())
+
+		got, err := uc.Create(ctx, strings.ToUpper(exampleID), "  Looks good \n")
+		require.NoError(t, err)
+
+		assert.Equal(t, exampleID, got.ExampleID, "the example ID is canonical")
+		assert.Equal(t, "Looks good", got.Body)
+		assert.Equal(t, &actor, got.AuthorID)
+		assert.NotEqual(t, uuid.Nil, got.ID)
+		require.Len(t, repo.CreateCommentCalls(), 1)
+		assert.Equal(t, got, repo.CreateCommentCalls()[0].Comment)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, existingExamples())
+
+		_, err := uc.Create(ctx, exampleID, " \t\n")
+		assert.ErrorIs(t, err, ErrEmpty)
+		_, err = uc.Create(ctx, exampleID, strings.Repeat("é", MaxBodyLength+1))
+		assert.ErrorIs(t, err, ErrTooLong)
+		_, err = uc.Create(ctx, exampleID, strings.Repeat("é", MaxBodyLength))
+		assert.NoError(t, err, "the limit counts characters, not bytes")
+		assert.Len(t, repo.CreateCommentCalls(), 1)
+	})
+
+	t.Run("missing example", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, missingExamples())
+
+		_, err := uc.Create(ctx, exampleID, "hi")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = uc.Create(ctx, "not-a-uuid", "hi")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, repo.CreateCommentCalls())
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, existingExamples())
+
+		_, err := uc.Create(domain.WithDryRun(ctx), exampleID, "hi")
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateCommentCalls())
+	})
+}
+
+func TestUseCase_List(t *testing.T) {
+	exampleID := uuid.Must(uuid.NewV4()).String()
+
+	t.Run("pages", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			ListCommentsFunc: func(ctx context.Context, exampleID string, limit, offset int32) ([]entities.Comment, error) {
+				return []entities.Comment{{ExampleID: exampleID}}, nil
+			},
+			CountCommentsFunc: func(ctx context.Context, exampleID string) (int64, error) {
+				return 41, nil
+			},
+		}
+		uc := newTestUseCase(repo, existingExamples())
+
+		comments, total, err := uc.List(context.Background(), exampleID, 3, 10)
+		require.NoError(t, err)
+		assert.Len(t, comments, 1)
+		assert.Equal(t, int64(41), total)
+		assert.Equal(t, int32(10), repo.ListCommentsCalls()[0].Limit)
+		assert.Equal(t, int32(20), repo.ListCommentsCalls()[0].Offset)
+
+		_, _, err = uc.List(context.Background(), exampleID, 0, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, int32(20), repo.ListCommentsCalls()[1].Limit)
+		assert.Equal(t, int32(0), repo.ListCommentsCalls()[1].Offset)
+	})
+
+	t.Run("missing example", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, missingExamples())
+
+		_, _, err := uc.List(context.Background(), exampleID, 1, 20)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, repo.ListCommentsCalls())
+	})
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	exampleID := uuid.Must(uuid.NewV4()).String()
+	id := uuid.Must(uuid.NewV4())
+
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo, existingExamples())
+
+	require.NoError(t, uc.Delete(domain.WithDryRun(context.Background()), exampleID, id))
+	assert.Empty(t, repo.DeleteCommentCalls())
+
+	require.NoError(t, uc.Delete(context.Background(), exampleID, id))
+	require.Len(t, repo.DeleteCommentCalls(), 1)
+	assert.Equal(t, id, repo.DeleteCommentCalls()[0].ID)
+
+	repo.DeleteCommentFunc = func(ctx context.Context, exampleID string, id uuid.UUID) error {
+		return domain.ErrNotFound
+	}
+	assert.ErrorIs(t, uc.Delete(context.Background(), exampleID, id), domain.ErrNotFound)
+}
@@ -0,0 +1,32 @@
+package deletion
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository UserDeleter
+
+type Repository interface {
+	// CreateDeletionRequest stores the request, or returns the user's pending
+	// one unchanged if they already asked.
+	CreateDeletionRequest(ctx context.Context, req entities.DeletionRequest) (entities.DeletionRequest, error)
+	// GetDeletionRequest returns domain.ErrNotFound when the user has no
+	// pending request.
+	GetDeletionRequest(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error)
+	// DeleteDeletionRequest returns domain.ErrNotFound when the user has no
+	// pending request.
+	DeleteDeletionRequest(ctx context.Context, userID uuid.UUID) error
+	// ListDueDeletionRequests returns up to limit requests scheduled at or
+	// before now, oldest first.
+	ListDueDeletionRequests(ctx context.Context, now time.Time, limit int32) ([]entities.DeletionRequest, error)
+}
+
+// UserDeleter deletes a user from the auth provider and the users table,
+// leaving a tombstone for anonymization.
+type UserDeleter interface {
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,123 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const batchSize = 100
+
+// UseCase lets users delete their own account. A request waits out the
+// grace period, during which the user can still sign in and cancel it; then
+// the background job deletes the account. Deleting leaves a tombstone, so
+// the data that refers to the user is anonymized rather than removed.
+type UseCase struct {
+	repo        Repository
+	users       UserDeleter
+	gracePeriod time.Duration
+	logger      *slog.Logger
+}
+
+func NewUseCase(repo Repository, users UserDeleter, gracePeriod time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:        repo,
+		users:       users,
+		gracePeriod: gracePeriod,
+		logger:      logger,
+	}
+}
+
+// Request schedules the user's account for deletion once the grace period
+// has passed. Asking again returns the pending request unchanged.
+func (uc *UseCase) Request(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error) {
+	now := time.Now().UTC()
+	req := entities.DeletionRequest{
+		UserID:       userID,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(uc.gracePeriod),
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: account deletion not requested", "user_id", userID)
+		return req, nil
+	}
+
+	req, err := uc.repo.CreateDeletionRequest(ctx, req)
+	if err != nil {
+		return entities.DeletionRequest{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "account deletion requested", "audit", true, "user_id", userID, "scheduled_for", req.ScheduledFor)
+	return req, nil
+}
+
+// Get returns the user's pending request, or domain.ErrNotFound.
+func (uc *UseCase) Get(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error) {
+	return uc.repo.GetDeletionRequest(ctx, userID)
+}
+
+// Cancel drops the user's pending request. It returns domain.ErrNotFound
+// when there is none.
+func (uc *UseCase) Cancel(ctx context.Context, userID uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		if _, err := uc.repo.GetDeletionRequest(ctx, userID); err != nil {
+			return err
+		}
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: account deletion not canceled", "user_id", userID)
+		return nil
+	}
+
+	if err := uc.repo.DeleteDeletionRequest(ctx, userID); err != nil {
+		return err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "account deletion canceled", "audit", true, "user_id", userID)
+	return nil
+}
+
+// Run deletes one batch of accounts whose grace period has passed and
+// returns how many were deleted. The request goes with the user; requests
+// whose deletion fails are retried on the next run.
+func (uc *UseCase) Run(ctx context.Context) (int, error) {
+	due, err := uc.repo.ListDueDeletionRequests(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("listing due deletion requests: %w", err)
+	}
+
+	done := 0
+	for _, req := range due {
+		if err := uc.users.DeleteUser(ctx, req.UserID); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to delete account", "user_id", req.UserID, "error", err)
+			continue
+		}
+		done++
+	}
+
+	return done, nil
+}
+
+// Start runs the job every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("account deletion run failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("deleted accounts past their grace period", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/deletion/mocks"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, users UserDeleter) *UseCase {
+	return NewUseCase(repo, users, 30*24*time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Request(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		CreateDeletionRequestFunc: func(ctx context.Context, req entities.DeletionRequest) (entities.DeletionRequest, error) {
+			return req, nil
+		},
+	}
+	uc := newTestUseCase(repo, &mocks.UserDeleterMock{})
+
+	req, err := uc.Request(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, req.UserID)
+	assert.Equal(t, 30*24*time.Hour, req.ScheduledFor.Sub(req.RequestedAt))
+	require.Len(t, repo.CreateDeletionRequestCalls(), 1)
+
+	t.Run("dry run stores nothing", func(t *testing.T) {
+		_, err := uc.Request(domain.WithDryRun(context.Background()), userID)
+		require.NoError(t, err)
+		assert.Len(t, repo.CreateDeletionRequestCalls(), 1)
+	})
+}
+
+func TestUseCase_Cancel(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		DeleteDeletionRequestFunc: func(ctx context.Context, id uuid.UUID) error {
+			if id != userID {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, &mocks.UserDeleterMock{})
+
+	require.NoError(t, uc.Cancel(context.Background(), userID))
+	assert.ErrorIs(t, uc.Cancel(context.Background(), uuid.Must(uuid.NewV4())), domain.ErrNotFound)
+}
+
+func TestUseCase_Run(t *testing.T) {
+	okUser := uuid.Must(uuid.NewV4())
+	failingUser := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		ListDueDeletionRequestsFunc: func(ctx context.Context, now time.Time, limit int32) ([]entities.DeletionRequest, error) {
+			return []entities.DeletionRequest{{UserID: okUser}, {UserID: failingUser}}, nil
+		},
+	}
+	users := &mocks.UserDeleterMock{
+		DeleteUserFunc: func(ctx context.Context, userID uuid.UUID) error {
+			if userID == failingUser {
+				return errors.New("provider down")
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, users)
+
+	done, err := uc.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, done)
+	require.Len(t, users.DeleteUserCalls(), 2)
+	assert.WithinDuration(t, time.Now(), repo.ListDueDeletionRequestsCalls()[0].Now, time.Minute)
+
+	t.Run("listing fails", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			ListDueDeletionRequestsFunc: func(ctx context.Context, now time.Time, limit int32) ([]entities.DeletionRequest, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := newTestUseCase(repo, users).Run(context.Background())
+		assert.Error(t, err)
+	})
+}
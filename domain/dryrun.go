@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+type dryRunKey struct{}
+
+// WithDryRun marks ctx so use cases validate a mutation and return its
+// would-be result without persisting it or calling external services.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was marked with WithDryRun.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
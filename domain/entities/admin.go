@@ -8,11 +8,13 @@ type DashboardStats struct {
 	SystemAlerts   int64 `json:"system_alerts"`
 }
 
-// User List Response
+// User List Response. With cursor pagination only Users, PageSize and
+// NextCursor are set, and NextCursor is empty on the last page.
 type UserListResponse struct {
 	Users      []User `json:"users"`
 	Total      int64  `json:"total"`
 	Page       int    `json:"page"`
 	PageSize   int    `json:"page_size"`
 	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,51 @@
+package entities
+
+import "slices"
+
+// AlertSeverity ranks system alerts, so channels can be given only the
+// ones that matter to them.
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+// AlertSeverities lists the severities from the lowest to the highest.
+var AlertSeverities = []AlertSeverity{AlertSeverityInfo, AlertSeverityWarning, AlertSeverityCritical}
+
+// Valid reports whether s is one of AlertSeverities.
+func (s AlertSeverity) Valid() bool {
+	return slices.Contains(AlertSeverities, s)
+}
+
+// AtLeast reports whether s is min or higher. An empty min lets every
+// severity through.
+func (s AlertSeverity) AtLeast(min AlertSeverity) bool {
+	return slices.Index(AlertSeverities, s) >= slices.Index(AlertSeverities, min)
+}
+
+// Alert tells operators something needs their attention, such as
+// break-glass access or a dead-lettered job.
+type Alert struct {
+	Title    string        `json:"title"`
+	Message  string        `json:"message"`
+	Severity AlertSeverity `json:"severity"`
+}
+
+// AlertSettings are the channels system alerts are delivered to, set by
+// super admins. A channel is off while its target is empty, and only gets
+// the alerts at or above its minimum severity.
+type AlertSettings struct {
+	// SlackWebhookURL is a Slack incoming webhook
+	SlackWebhookURL  string        `json:"slack_webhook_url"`
+	SlackMinSeverity AlertSeverity `json:"slack_min_severity"`
+	// PagerDutyRoutingKey is the integration key of a PagerDuty service
+	// using the Events API v2
+	PagerDutyRoutingKey  string        `json:"pagerduty_routing_key"`
+	PagerDutyMinSeverity AlertSeverity `json:"pagerduty_min_severity"`
+	// WebhookURL receives the alerts as JSON
+	WebhookURL         string        `json:"webhook_url"`
+	WebhookMinSeverity AlertSeverity `json:"webhook_min_severity"`
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// AnnouncementLevel sets how prominently an announcement is shown.
+type AnnouncementLevel string
+
+const (
+	AnnouncementInfo     AnnouncementLevel = "info"
+	AnnouncementWarning  AnnouncementLevel = "warning"
+	AnnouncementCritical AnnouncementLevel = "critical"
+)
+
+// Valid reports whether l is a known level.
+func (l AnnouncementLevel) Valid() bool {
+	switch l {
+	case AnnouncementInfo, AnnouncementWarning, AnnouncementCritical:
+		return true
+	}
+	return false
+}
+
+// Announcement is a message admins broadcast to everyone using the apps.
+// It is shown from StartsAt until EndsAt, or until it is deleted when it has
+// no end, to users who haven't dismissed it.
+type Announcement struct {
+	ID        uuid.UUID         `json:"id"`
+	Message   string            `json:"message"`
+	Level     AnnouncementLevel `json:"level"`
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    *time.Time        `json:"ends_at,omitempty"`
+	CreatedBy *uuid.UUID        `json:"created_by,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Active reports whether the announcement is shown at now.
+func (a Announcement) Active(now time.Time) bool {
+	return !now.Before(a.StartsAt) && (a.EndsAt == nil || now.Before(*a.EndsAt))
+}
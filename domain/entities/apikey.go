@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// APIKeyScope limits what a request authenticated with an API key can do.
+type APIKeyScope string
+
+const (
+	APIKeyScopeExampleRead  APIKeyScope = "example:read"
+	APIKeyScopeExampleWrite APIKeyScope = "example:write"
+)
+
+// APIKeyScopeSet lists every scope an API key can be created with.
+var APIKeyScopeSet = []APIKeyScope{
+	APIKeyScopeExampleRead,
+	APIKeyScopeExampleWrite,
+}
+
+func (s APIKeyScope) String() string {
+	return string(s)
+}
+
+// IsValid reports whether s is a scope API keys can be created with.
+func (s APIKeyScope) IsValid() bool {
+	return slices.Contains(APIKeyScopeSet, s)
+}
+
+// APIKey is a long-lived credential a user creates for a machine client. It
+// acts as the user, limited to its scopes. Only the hash of the key is
+// stored; Prefix is its first characters, so the owner can tell keys apart.
+type APIKey struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	KeyHash    string        `json:"-"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// HasScope reports whether the key was created with scope.
+func (k APIKey) HasScope(scope APIKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// Active reports whether the key can still be used at now.
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Attachment is a file attached to an example. The file itself is private;
+// fetching the attachment gives a signed DownloadURL that works until
+// DownloadURLExpiresAt.
+type Attachment struct {
+	ID                   uuid.UUID  `json:"id"`
+	ExampleID            string     `json:"example_id"`
+	FileName             string     `json:"file_name"`
+	ContentType          string     `json:"content_type"`
+	Size                 int64      `json:"size"`
+	FileKey              string     `json:"-"`
+	UploadedBy           *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	DownloadURL          string     `json:"download_url,omitempty"`
+	DownloadURLExpiresAt *time.Time `json:"download_url_expires_at,omitempty"`
+}
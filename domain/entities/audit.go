@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// AuditResourceUser is the resource of events about a user account.
+const AuditResourceUser = "user"
+
+// AuditResourceInvitation is the resource of events about an invitation code.
+const AuditResourceInvitation = "invitation"
+
+// AuditResourceSettings is the resource of events about the system settings.
+const AuditResourceSettings = "settings"
+
+// AuditResourceAnnouncement is the resource of events about an announcement.
+const AuditResourceAnnouncement = "announcement"
+
+// AuditResourceWebhook is the resource of events about a webhook.
+const AuditResourceWebhook = "webhook"
+
+// AuditResourceSystem is the resource of events about how the service runs,
+// such as its log level.
+const AuditResourceSystem = "system"
+
+// AuditEvent records who did what to which resource. Events are logged with
+// an "audit" attribute and stored for the admin audit log.
+type AuditEvent struct {
+	ID         uint64         `json:"id"`
+	Time       time.Time      `json:"time"`
+	ActorID    *uuid.UUID     `json:"actor_id,omitempty"`
+	Action     string         `json:"action"`
+	Resource   string         `json:"resource,omitempty"`
+	ResourceID string         `json:"resource_id,omitempty"`
+	RequestID  string         `json:"request_id,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// AuditFilter selects audit events. Zero fields match everything; From is
+// inclusive and To exclusive. Expr is a filter expression over
+// AuditFilterFields.
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	Resource   string
+	ResourceID string
+	From       *time.Time
+	To         *time.Time
+	Expr       Filter
+	Page       int
+	PageSize   int
+}
+
+// AuditFilterFields are the audit event fields filter expressions can
+// compare.
+var AuditFilterFields = FilterFields{
+	"actor_id":    {Type: FilterUUID},
+	"action":      {Type: FilterString},
+	"resource":    {Type: FilterString},
+	"resource_id": {Type: FilterString},
+	"request_id":  {Type: FilterString},
+	"occurred_at": {Type: FilterTime},
+}
+
+// AuditEventListResponse is a page of audit events, newest first.
+type AuditEventListResponse struct {
+	Events     []AuditEvent `json:"events"`
+	Total      int64        `json:"total"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"`
+	TotalPages int          `json:"total_pages"`
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// BackupStatus is where a backup is in its life.
+type BackupStatus string
+
+const (
+	BackupPending   BackupStatus = "pending"
+	BackupRunning   BackupStatus = "running"
+	BackupSucceeded BackupStatus = "succeeded"
+	BackupFailed    BackupStatus = "failed"
+)
+
+// BackupTrigger is what started a backup: the daily schedule, when the
+// AutoBackup setting is on, or an admin.
+type BackupTrigger string
+
+const (
+	BackupScheduled BackupTrigger = "scheduled"
+	BackupManual    BackupTrigger = "manual"
+)
+
+// Backup is a dump of the database kept in file storage. Once it has
+// succeeded, the file can be downloaded from DownloadURL until
+// DownloadURLExpiresAt; fetching the backup again gives a fresh link.
+// Backups are removed once they are older than the BackupRetentionDays
+// setting.
+type Backup struct {
+	ID                   uuid.UUID     `json:"id"`
+	Status               BackupStatus  `json:"status"`
+	Trigger              BackupTrigger `json:"trigger"`
+	Error                string        `json:"error,omitempty"`
+	FileKey              string        `json:"-"`
+	Size                 int64         `json:"size"`
+	CreatedBy            *uuid.UUID    `json:"created_by,omitempty"`
+	CreatedAt            time.Time     `json:"created_at"`
+	StartedAt            *time.Time    `json:"started_at,omitempty"`
+	FinishedAt           *time.Time    `json:"finished_at,omitempty"`
+	DownloadURL          string        `json:"download_url,omitempty"`
+	DownloadURLExpiresAt *time.Time    `json:"download_url_expires_at,omitempty"`
+}
+
+// BackupListResponse is a page of backups, newest first.
+type BackupListResponse struct {
+	Backups    []Backup `json:"backups"`
+	Total      int64    `json:"total"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"page_size"`
+	TotalPages int      `json:"total_pages"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// BreakGlassEmail identifies sessions minted from a break-glass credential.
+const BreakGlassEmail = "break-glass@localhost"
+
+// BreakGlassCredential is a sealed, single-use emergency credential. Only its
+// hash is stored.
+type BreakGlassCredential struct {
+	ID             uuid.UUID  `json:"id"`
+	CredentialHash string     `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UsedAt         *time.Time `json:"used_at,omitempty"`
+	UsedFrom       *string    `json:"used_from,omitempty"`
+}
+
+// BreakGlassSession is the short-lived super admin session minted when a
+// break-glass credential is redeemed.
+type BreakGlassSession struct {
+	Token        string    `json:"token"`
+	CredentialID uuid.UUID `json:"credential_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Comment is a comment on an example. AuthorID is left out once the author
+// is deleted.
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	ExampleID string     `json:"example_id"`
+	AuthorID  *uuid.UUID `json:"author_id,omitempty"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+}
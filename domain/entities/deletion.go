@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DeletionRequest is a user's request to delete their account. The account
+// is deleted once ScheduledFor passes, unless the user cancels first.
+type DeletionRequest struct {
+	UserID       uuid.UUID `json:"user_id"`
+	RequestedAt  time.Time `json:"requested_at"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
@@ -0,0 +1,67 @@
+package entities
+
+// EmailTemplate names a transactional email. Its wording lives in the email
+// gateway's templates, so domains only choose the email and its values.
+type EmailTemplate string
+
+const (
+	// EmailWelcome greets a new account, with a verification Link when
+	// the address isn't verified. Data: Link, ExpiresInHours.
+	EmailWelcome EmailTemplate = "welcome"
+	// EmailVerification asks to confirm the account's address. Data: Link,
+	// ExpiresInHours.
+	EmailVerification EmailTemplate = "email_verification"
+	// EmailPasswordReset links to choosing a new password. Data: Link,
+	// ExpiresInMinutes.
+	EmailPasswordReset EmailTemplate = "password_reset"
+	// EmailChange asks the new address to confirm an email change. Data:
+	// Link, ExpiresInMinutes.
+	EmailChange EmailTemplate = "email_change"
+	// EmailChanged tells the old address its account moved. Data: NewEmail.
+	EmailChanged EmailTemplate = "email_changed"
+	// EmailInvitation invites someone to create an account. Data: Link,
+	// ExpiresAt.
+	EmailInvitation EmailTemplate = "invitation"
+	// EmailRegistrationApproved tells a pending user they can sign in.
+	EmailRegistrationApproved EmailTemplate = "registration_approved"
+	// EmailRegistrationDeclined tells a pending user their account was
+	// declined. Data: Reason, which may be empty.
+	EmailRegistrationDeclined EmailTemplate = "registration_declined"
+	// EmailNotification delivers a notification on the email channel.
+	// Data: Title, Body, Link, which may be empty.
+	EmailNotification EmailTemplate = "notification"
+)
+
+// EmailTemplates lists every transactional email, in the order they are
+// shown to designers.
+var EmailTemplates = []EmailTemplate{
+	EmailWelcome,
+	EmailVerification,
+	EmailPasswordReset,
+	EmailChange,
+	EmailChanged,
+	EmailInvitation,
+	EmailRegistrationApproved,
+	EmailRegistrationDeclined,
+	EmailNotification,
+}
+
+// Email is a transactional email to send. Locale is the recipient's
+// language tag, such as pt-BR; templates fall back to the default language
+// when it is empty or has no translation.
+type Email struct {
+	To       string
+	Template EmailTemplate
+	Locale   string
+	Data     map[string]any
+}
+
+// RenderedEmail is a transactional email as its recipient sees it, shown to
+// designers as a preview.
+type RenderedEmail struct {
+	Template EmailTemplate `json:"template"`
+	Locale   string        `json:"locale,omitempty"`
+	Subject  string        `json:"subject"`
+	Text     string        `json:"text"`
+	HTML     string        `json:"html,omitempty"`
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "time"
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
 
 type Example struct {
 	ID        string    `json:"id"`
@@ -8,4 +12,27 @@ type Example struct {
 	Content   string    `json:"content"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// ArchivedAt is set while the example is archived, which leaves it out
+	// of lists until it is unarchived or purged
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	// CreatedBy is the user who created the example, who may change and
+	// delete it. Examples created before owners were recorded, or whose
+	// creator was deleted, have none.
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+}
+
+// ListExamplesParams selects a page of examples, sorted by created_at.
+type ListExamplesParams struct {
+	Limit           int32
+	Offset          int32
+	Desc            bool
+	IncludeArchived bool
+}
+
+// ExampleFilterFields are the example fields filter expressions can compare.
+var ExampleFilterFields = FilterFields{
+	"title":      {Type: FilterString},
+	"content":    {Type: FilterString},
+	"created_at": {Type: FilterTime},
+	"updated_at": {Type: FilterTime},
 }
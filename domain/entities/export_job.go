@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ExportJobStatus is where an export job is in its life.
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobSucceeded ExportJobStatus = "succeeded"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportKind is what an export job exports.
+type ExportKind string
+
+const ExportKindUsers ExportKind = "users"
+
+// ExportJob is an export produced in the background and stored as a file,
+// for exports too large to stream in a request. Search, AccountType and
+// Filter are the parameters of the users list it exports. Once it has
+// succeeded, the file can be downloaded from DownloadURL until
+// DownloadURLExpiresAt; fetching the job again gives a fresh link.
+type ExportJob struct {
+	ID                   uuid.UUID       `json:"id"`
+	Kind                 ExportKind      `json:"kind"`
+	Format               string          `json:"format"`
+	Search               string          `json:"search,omitempty"`
+	AccountType          string          `json:"account_type,omitempty"`
+	Filter               string          `json:"filter,omitempty"`
+	Status               ExportJobStatus `json:"status"`
+	Error                string          `json:"error,omitempty"`
+	FileKey              string          `json:"-"`
+	Rows                 int             `json:"rows"`
+	CreatedBy            *uuid.UUID      `json:"created_by,omitempty"`
+	CreatedAt            time.Time       `json:"created_at"`
+	StartedAt            *time.Time      `json:"started_at,omitempty"`
+	FinishedAt           *time.Time      `json:"finished_at,omitempty"`
+	DownloadURL          string          `json:"download_url,omitempty"`
+	DownloadURLExpiresAt *time.Time      `json:"download_url_expires_at,omitempty"`
+}
@@ -0,0 +1,55 @@
+package entities
+
+// FilterType is the type of a field filter expressions can compare, which
+// decides how its values are parsed and which operators apply to it.
+type FilterType int
+
+const (
+	// FilterString fields take =, != and ~ (contains, ignoring case).
+	FilterString FilterType = iota
+	// FilterEnum fields are strings limited to their FilterField.Values, and
+	// take = and !=.
+	FilterEnum
+	// FilterTime fields take >, >=, < and <= with an RFC 3339 time or a
+	// 2006-01-02 date, which means midnight UTC.
+	FilterTime
+	// FilterBool fields take = and != with true or false.
+	FilterBool
+	// FilterUUID fields take = and != with a UUID.
+	FilterUUID
+)
+
+// FilterField is a field filter expressions can compare.
+type FilterField struct {
+	Type   FilterType
+	Values []string
+}
+
+// FilterFields lists the fields of a resource filter expressions can
+// compare, by the name used in the expression.
+type FilterFields map[string]FilterField
+
+// FilterOp is a filter expression operator.
+type FilterOp string
+
+const (
+	FilterEq       FilterOp = ":"
+	FilterNe       FilterOp = "!:"
+	FilterGt       FilterOp = ">"
+	FilterGte      FilterOp = ">="
+	FilterLt       FilterOp = "<"
+	FilterLte      FilterOp = "<="
+	FilterContains FilterOp = "~"
+)
+
+// FilterPredicate compares a field with a value. Value holds a string,
+// time.Time, bool or uuid.UUID, as the field's type says.
+type FilterPredicate struct {
+	Field string
+	Op    FilterOp
+	Value any
+}
+
+// Filter is a parsed filter expression; it matches what all of its
+// predicates match.
+type Filter []FilterPredicate
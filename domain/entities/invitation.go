@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Invitation is a code people register with while registration is closed.
+// Users registering with it get its AccountType. A code can be used MaxUses
+// times, or any number of times when MaxUses is nil, until it expires or is
+// revoked. Only the hash of the code is stored; Prefix is its first
+// characters, so admins can tell codes apart. Invitations an admin emailed
+// have the Email they were sent to, and only that address can use them.
+type Invitation struct {
+	ID          uuid.UUID   `json:"id"`
+	Prefix      string      `json:"prefix"`
+	CodeHash    string      `json:"-"`
+	AccountType AccountType `json:"account_type"`
+	MaxUses     *int        `json:"max_uses,omitempty"`
+	Uses        int         `json:"uses"`
+	Note        string      `json:"note,omitempty"`
+	Email       string      `json:"email,omitempty"`
+	CreatedBy   *uuid.UUID  `json:"created_by,omitempty"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+	RevokedAt   *time.Time  `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// Active reports whether the code can still be registered with at now.
+func (i Invitation) Active(now time.Time) bool {
+	if i.RevokedAt != nil || !now.Before(i.ExpiresAt) {
+		return false
+	}
+	return i.MaxUses == nil || i.Uses < *i.MaxUses
+}
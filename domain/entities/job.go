@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// JobStatus is where a background job is in its life.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobDead      JobStatus = "dead"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// JobStatuses are the statuses jobs can be listed by.
+var JobStatuses = []JobStatus{JobQueued, JobRunning, JobSucceeded, JobDead, JobCanceled}
+
+// Job is work run in the background by a worker, such as sending an email.
+// Kind names the handler that runs it, and Args are its arguments as JSON.
+// A queued job is due from RunAt; a running one is leased until RunAt, and
+// taken over by another worker after that. Attempts counts the runs
+// started, and a job that failed MaxAttempts times, or failed for good,
+// is dead-lettered: left dead with the error of the last attempt. Admins
+// cancel queued jobs, and requeue dead and canceled ones.
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	Kind        string          `json:"kind"`
+	Args        json.RawMessage `json:"args"`
+	Status      JobStatus       `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	RunAt       time.Time       `json:"run_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// JobFilter narrows the jobs listed to those with a status or of a kind,
+// when set.
+type JobFilter struct {
+	Status   JobStatus
+	Kind     string
+	Page     int
+	PageSize int
+}
+
+// JobListResponse is a page of jobs, newest first, with how many jobs
+// there are of each status.
+type JobListResponse struct {
+	Jobs       []Job               `json:"jobs"`
+	Counts     map[JobStatus]int64 `json:"counts"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	TotalPages int                 `json:"total_pages"`
+}
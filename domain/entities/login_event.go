@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Reasons a login attempt failed, as kept in the login history
+const (
+	LoginReasonInvalidCredentials  = "invalid_credentials"
+	LoginReasonInvalidCode         = "invalid_code"
+	LoginReasonInvalidSecondFactor = "invalid_second_factor"
+	LoginReasonTwoFactorLocked     = "two_factor_locked"
+	LoginReasonAccountSuspended    = "account_suspended"
+	LoginReasonAccountPending      = "account_pending"
+	LoginReasonEmailNotVerified    = "email_not_verified"
+	LoginReasonProviderUnavailable = "provider_unavailable"
+)
+
+// LoginEvent is an attempt to sign in to a known account. Provider is how
+// the user signed in: their auth provider, a social provider, "sms" or
+// "totp" for a two-factor challenge. Reason is only set on failures.
+type LoginEvent struct {
+	ID        int64     `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Success   bool      `json:"success"`
+	Provider  string    `json:"provider"`
+	Reason    string    `json:"reason,omitempty"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Device describes the browser and operating system the attempt was made
+// from, like Session.Device.
+func (e LoginEvent) Device() string {
+	return Session{UserAgent: e.UserAgent}.Device()
+}
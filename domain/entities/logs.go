@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// LogEntry is a structured log record kept in memory for the admin log viewer.
+type LogEntry struct {
+	ID        uint64         `json:"id"`
+	Time      time.Time      `json:"time"`
+	Level     string         `json:"level"`
+	Message   string         `json:"message"`
+	RequestID string         `json:"request_id,omitempty"`
+	Attrs     map[string]any `json:"attrs,omitempty"`
+}
+
+// LogFilter selects log entries. Level is the minimum level, After skips
+// entries up to and including that ID, and Limit keeps the newest matches.
+type LogFilter struct {
+	Level     string
+	RequestID string
+	After     uint64
+	Limit     int
+}
+
+// LogListResponse is returned by the admin log viewer endpoint.
+type LogListResponse struct {
+	Entries []LogEntry `json:"entries"`
+	LastID  uint64     `json:"last_id"`
+}
+
+// LogLevel is the minimum level of the records logged, read and set with
+// the admin log level endpoint.
+type LogLevel struct {
+	Level string `json:"level"`
+}
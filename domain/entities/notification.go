@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// NotificationKind is what a notification is about. Users choose the
+// channels they get each kind on in their preferences.
+type NotificationKind string
+
+const (
+	NotificationExportReady  NotificationKind = "export_ready"
+	NotificationExportFailed NotificationKind = "export_failed"
+)
+
+// NotificationChannel is how a notification reaches the user: listed in the
+// apps, or emailed.
+type NotificationChannel string
+
+const (
+	NotificationInApp NotificationChannel = "in_app"
+	NotificationEmail NotificationChannel = "email"
+)
+
+func (c NotificationChannel) Valid() bool {
+	switch c {
+	case NotificationInApp, NotificationEmail:
+		return true
+	}
+	return false
+}
+
+// Notification tells a user about something that happened. Link, when set,
+// is where to go to see it. ReadAt is set once the user reads it.
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    uuid.UUID        `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Link      string           `json:"link,omitempty"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NotificationListResponse is a page of a user's notifications, newest
+// first, with how many of all their notifications are unread.
+type NotificationListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	Unread        int64          `json:"unread"`
+	Total         int64          `json:"total"`
+	Page          int            `json:"page"`
+	PageSize      int            `json:"page_size"`
+	TotalPages    int            `json:"total_pages"`
+}
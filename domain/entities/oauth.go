@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// OAuthClient is an application registered to sign users in through the
+// service's OpenID Connect provider.
+type OAuthClient struct {
+	ID               uuid.UUID `json:"id"`
+	ClientID         string    `json:"client_id"`
+	ClientSecretHash string    `json:"-"`
+	Name             string    `json:"name"`
+	RedirectURIs     []string  `json:"redirect_uris"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// AuthorizationCode is a single-use code issued by the authorization endpoint.
+// Only the hash of the code is stored.
+type AuthorizationCode struct {
+	CodeHash            string
+	ClientID            string
+	UserID              uuid.UUID
+	RedirectURI         string
+	Scope               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	ExpiresAt           time.Time
+	CreatedAt           time.Time
+}
+
+// OAuthConsent records the scopes a user agreed to share with a client on the
+// consent screen.
+type OAuthConsent struct {
+	UserID    uuid.UUID
+	ClientID  string
+	Scope     string
+	GrantedAt time.Time
+}
+
+// Covers reports whether every scope in the space-separated list was granted.
+func (c OAuthConsent) Covers(scope string) bool {
+	granted := strings.Fields(c.Scope)
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(granted, s) {
+			return false
+		}
+	}
+	return true
+}
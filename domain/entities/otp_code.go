@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// OTPCode is a one-time code sent by SMS. Only its hash is stored, and a
+// phone number has at most one outstanding code per purpose: requesting a new
+// one replaces it.
+type OTPCode struct {
+	ID         uuid.UUID  `json:"id"`
+	Phone      string     `json:"phone"`
+	Purpose    string     `json:"purpose"`
+	CodeHash   string     `json:"-"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"`
+	Attempts   int        `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// OutboxMessage is a domain event recorded in the same transaction as the
+// change it is about, waiting to be relayed to Sink: the event bus or a
+// broker. Key identifies the event rather than the message: an event is
+// recorded once for each sink, and consumers that may see a message more
+// than once can tell repeats by it. Payload is the event as JSON, and
+// ActorID the user who made the change, if known. A message is due from
+// AvailableAt, and is left once PublishedAt is set.
+type OutboxMessage struct {
+	ID          uuid.UUID       `json:"id"`
+	Sink        string          `json:"sink"`
+	Event       string          `json:"event"`
+	Key         string          `json:"key"`
+	Payload     json.RawMessage `json:"payload"`
+	ActorID     *uuid.UUID      `json:"actor_id,omitempty"`
+	Attempts    int             `json:"attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	AvailableAt time.Time       `json:"available_at"`
+	PublishedAt *time.Time      `json:"published_at,omitempty"`
+}
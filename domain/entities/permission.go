@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Permission grants access to one area of the admin API.
+type Permission string
+
+const (
+	PermissionDashboardRead    Permission = "dashboard:read"
+	PermissionUsersRead        Permission = "users:read"
+	PermissionUsersWrite       Permission = "users:write"
+	PermissionUsersImpersonate Permission = "users:impersonate"
+	PermissionSettingsRead     Permission = "settings:read"
+)
+
+// AdminPermissionSet lists every permission that can be delegated to an admin.
+// Admins without restrictions hold all of them.
+var AdminPermissionSet = []Permission{
+	PermissionDashboardRead,
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionUsersImpersonate,
+	PermissionSettingsRead,
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// IsValid reports whether p is a permission that can be delegated to admins.
+func (p Permission) IsValid() bool {
+	return slices.Contains(AdminPermissionSet, p)
+}
+
+// AdminPermissions is the effective set of permissions of an admin. Restricted
+// is false when a super admin has not narrowed the admin's access.
+type AdminPermissions struct {
+	UserID      uuid.UUID    `json:"user_id"`
+	Permissions []Permission `json:"permissions"`
+	Restricted  bool         `json:"restricted"`
+	// Roles are the custom roles the account holds
+	Roles     []string   `json:"roles,omitempty"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Has reports whether perm is part of the set.
+func (p AdminPermissions) Has(perm Permission) bool {
+	return slices.Contains(p.Permissions, perm)
+}
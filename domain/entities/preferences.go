@@ -0,0 +1,79 @@
+package entities
+
+// Preferences are a user's settings, kept as a JSON object. Keys with a
+// known meaning have typed accessors; other keys are kept as given, for
+// clients to use as they see fit.
+type Preferences map[string]any
+
+// Preference keys with a known meaning.
+const (
+	PreferenceTheme               = "theme"
+	PreferenceEmailSecurityAlerts = "email_security_alerts"
+	PreferenceEmailProductUpdates = "email_product_updates"
+	// PreferenceNotificationChannels maps notification kinds to the
+	// channels the user gets them on, such as {"export_ready": ["email"]}.
+	PreferenceNotificationChannels = "notification_channels"
+)
+
+// Theme is the color scheme the user wants the apps in. ThemeSystem follows
+// the device's setting.
+type Theme string
+
+const (
+	ThemeLight  Theme = "light"
+	ThemeDark   Theme = "dark"
+	ThemeSystem Theme = "system"
+)
+
+func (t Theme) Valid() bool {
+	switch t {
+	case ThemeLight, ThemeDark, ThemeSystem:
+		return true
+	}
+	return false
+}
+
+// Theme defaults to ThemeLight.
+func (p Preferences) Theme() Theme {
+	if theme, ok := p[PreferenceTheme].(string); ok && Theme(theme).Valid() {
+		return Theme(theme)
+	}
+	return ThemeLight
+}
+
+// EmailSecurityAlerts tells whether to email the user about sign-ins and
+// account changes. It defaults to true.
+func (p Preferences) EmailSecurityAlerts() bool {
+	return p.bool(PreferenceEmailSecurityAlerts, true)
+}
+
+// EmailProductUpdates tells whether the user wants news about the product.
+// It defaults to false.
+func (p Preferences) EmailProductUpdates() bool {
+	return p.bool(PreferenceEmailProductUpdates, false)
+}
+
+// NotificationChannels returns the channels the user gets notifications of
+// the kind on. Kinds the user didn't choose channels for are only shown in
+// the apps; an empty list turns the kind off.
+func (p Preferences) NotificationChannels(kind NotificationKind) []NotificationChannel {
+	kinds, _ := p[PreferenceNotificationChannels].(map[string]any)
+	chosen, ok := kinds[string(kind)].([]any)
+	if !ok {
+		return []NotificationChannel{NotificationInApp}
+	}
+	channels := make([]NotificationChannel, 0, len(chosen))
+	for _, c := range chosen {
+		if s, ok := c.(string); ok && NotificationChannel(s).Valid() {
+			channels = append(channels, NotificationChannel(s))
+		}
+	}
+	return channels
+}
+
+func (p Preferences) bool(key string, def bool) bool {
+	if v, ok := p[key].(bool); ok {
+		return v
+	}
+	return def
+}
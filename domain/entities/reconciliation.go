@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ProviderUser is an account as the auth provider sees it.
+type ProviderUser struct {
+	ID        string
+	Email     string
+	UpdatedAt time.Time
+}
+
+// ProviderEventType is what happened to an account on the auth provider side.
+type ProviderEventType string
+
+const (
+	ProviderEventCreated ProviderEventType = "created"
+	ProviderEventUpdated ProviderEventType = "updated"
+	ProviderEventDeleted ProviderEventType = "deleted"
+)
+
+// ProviderEvent is a change pushed by the auth provider, e.g. via a webhook.
+type ProviderEvent struct {
+	Provider string
+	Type     ProviderEventType
+	User     ProviderUser
+}
+
+// DriftKind names a mismatch between the users table and the auth provider.
+type DriftKind string
+
+const (
+	// DriftMissingInProvider is a local user whose provider account is gone
+	DriftMissingInProvider DriftKind = "missing_in_provider"
+	// DriftMissingLocally is a provider account without a local user
+	DriftMissingLocally DriftKind = "missing_locally"
+	// DriftEmailMismatch is a local user whose email changed on the provider
+	DriftEmailMismatch DriftKind = "email_mismatch"
+)
+
+// UserDrift is one mismatch found by reconciliation.
+type UserDrift struct {
+	Kind           DriftKind  `json:"kind"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	AuthProviderID string     `json:"auth_provider_id"`
+	LocalEmail     string     `json:"local_email,omitempty"`
+	ProviderEmail  string     `json:"provider_email,omitempty"`
+	Source         string     `json:"source"`
+	Repaired       bool       `json:"repaired"`
+	DetectedAt     time.Time  `json:"detected_at"`
+}
+
+// ReconciliationReport is the outcome of the last reconciliation run, plus
+// any drift reported by provider webhooks since.
+type ReconciliationReport struct {
+	Provider      string      `json:"provider"`
+	StartedAt     time.Time   `json:"started_at"`
+	FinishedAt    time.Time   `json:"finished_at"`
+	LocalUsers    int         `json:"local_users"`
+	ProviderUsers int         `json:"provider_users"`
+	RepairEnabled bool        `json:"repair_enabled"`
+	Drift         []UserDrift `json:"drift"`
+	Error         string      `json:"error,omitempty"`
+}
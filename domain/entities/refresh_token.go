@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// RefreshToken is a single-use token exchanged for a new access token. Only
+// its hash is stored. Every rotation issues a new token in the same family, so
+// reusing a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	FamilyID  uuid.UUID  `json:"family_id"`
+	TokenHash string     `json:"-"`
+	Audience  string     `json:"audience"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// MFA records that the login passed a second factor
+	MFA bool `json:"mfa"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// RevokedToken is an access token revoked before it expired, identified by
+// its jti claim.
+type RevokedToken struct {
+	JTI       string    `json:"jti"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,63}$`)
+
+// Role bundles permissions. Builtin roles describe what an account type holds
+// and are named after it; custom roles are created by super admins and grant
+// their permissions to the users they are assigned to.
+type Role struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	Builtin     bool         `json:"builtin"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Has reports whether the role grants perm.
+func (r Role) Has(perm Permission) bool {
+	return slices.Contains(r.Permissions, perm)
+}
+
+// IsValidRoleName reports whether name can be given to a custom role. Names
+// are lowercase and can't be an account type, which tokens carry as a role.
+func IsValidRoleName(name string) bool {
+	switch AccountType(name) {
+	case AccountTypeUser, AccountTypeAdmin, AccountTypeSuperAdmin:
+		return false
+	}
+	return roleNamePattern.MatchString(name)
+}
@@ -0,0 +1,43 @@
+package entities
+
+import "slices"
+
+// SearchKind is a kind of record the full-text search covers.
+type SearchKind string
+
+const (
+	SearchKindUser    SearchKind = "user"
+	SearchKindExample SearchKind = "example"
+)
+
+// SearchKinds lists every kind of record that can be searched.
+var SearchKinds = []SearchKind{SearchKindUser, SearchKindExample}
+
+// IsValid reports whether k is a kind of record that can be searched.
+func (k SearchKind) IsValid() bool {
+	return slices.Contains(SearchKinds, k)
+}
+
+// SearchQuery is a full-text search. Query takes web search syntax: quoted
+// phrases, OR, and -word to exclude a word. An empty Kinds searches every
+// kind.
+type SearchQuery struct {
+	Query string
+	Kinds []SearchKind
+	Limit int
+}
+
+// SearchResult is a record matching a search. Highlight is an HTML excerpt
+// with the matched words in <mark> tags and everything else escaped.
+type SearchResult struct {
+	Kind      SearchKind `json:"kind"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Highlight string     `json:"highlight"`
+	Rank      float32    `json:"rank"`
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
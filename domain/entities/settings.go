@@ -4,12 +4,30 @@ import "fmt"
 
 // SystemSettings represents system-wide configuration settings
 type SystemSettings struct {
-	MaintenanceMode        bool     `json:"maintenance_mode"`
-	RegistrationEnabled    bool     `json:"registration_enabled"`
-	EmailNotifications     bool     `json:"email_notifications"`
-	SessionTimeout         int      `json:"session_timeout"`        // in minutes
-	MinPasswordLength      int      `json:"min_password_length"`
-	Require2FA             bool     `json:"require_2fa"`
+	MaintenanceMode     bool `json:"maintenance_mode"`
+	RegistrationEnabled bool `json:"registration_enabled"`
+	// InvitationsEnabled lets people register with an invitation code while
+	// registration is disabled
+	InvitationsEnabled bool `json:"invitations_enabled"`
+	EmailNotifications bool `json:"email_notifications"`
+	SessionTimeout     int  `json:"session_timeout"` // in minutes
+	MinPasswordLength  int  `json:"min_password_length"`
+	// PasswordRequireMixedCase, PasswordRequireDigit and PasswordRequireSymbol
+	// add complexity rules to new passwords
+	PasswordRequireMixedCase bool `json:"password_require_mixed_case"`
+	PasswordRequireDigit     bool `json:"password_require_digit"`
+	PasswordRequireSymbol    bool `json:"password_require_symbol"`
+	// PasswordCheckBreached rejects new passwords found in known data breaches
+	PasswordCheckBreached bool `json:"password_check_breached"`
+	Require2FA            bool `json:"require_2fa"`
+	// RequireEmailVerification keeps users from signing in until they
+	// verified their email
+	RequireEmailVerification bool `json:"require_email_verification"`
+	// RequireCaptcha asks for a CAPTCHA on registration and login
+	RequireCaptcha bool `json:"require_captcha"`
+	// RequireApproval leaves self-registered users pending until an admin
+	// approves them
+	RequireApproval        bool     `json:"require_approval"`
 	AutoBackup             bool     `json:"auto_backup"`
 	BackupRetentionDays    int      `json:"backup_retention_days"`
 	AvailableAuthProviders []string `json:"available_auth_providers"`
@@ -24,4 +42,4 @@ type ErrInvalidSettingValue struct {
 
 func (e ErrInvalidSettingValue) Error() string {
 	return fmt.Sprintf("invalid value for %s: %s", e.Field, e.Message)
-}
\ No newline at end of file
+}
@@ -0,0 +1,11 @@
+package entities
+
+// SocialIdentity is the account a user signed in with at a social login
+// provider such as Google or GitHub.
+type SocialIdentity struct {
+	Provider      string `json:"provider"`
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name,omitempty"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// TOTPCredential is a user's authenticator app enrollment. It only protects
+// logins once EnabledAt is set, which happens when the user confirms the
+// enrollment with a first code.
+type TOTPCredential struct {
+	UserID uuid.UUID `json:"user_id"`
+	Secret string    `json:"-"`
+	// LastUsedStep is the time step of the last accepted code, so a code
+	// can't be replayed within its validity window.
+	LastUsedStep   int64      `json:"-"`
+	FailedAttempts int        `json:"-"`
+	LastFailedAt   *time.Time `json:"-"`
+	EnabledAt      *time.Time `json:"enabled_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Enabled reports whether logins require a code.
+func (c TOTPCredential) Enabled() bool {
+	return c.EnabledAt != nil
+}
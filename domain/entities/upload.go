@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// UploadStatus is where an upload is in its life.
+type UploadStatus string
+
+const (
+	UploadPending   UploadStatus = "pending"
+	UploadCompleted UploadStatus = "completed"
+)
+
+// Upload is a file a client uploads straight to file storage. While it is
+// pending, the client PUTs exactly Size bytes to UploadURL until
+// UploadURLExpiresAt, then confirms the upload. Once completed, the file
+// can be downloaded from DownloadURL until DownloadURLExpiresAt.
+type Upload struct {
+	ID                   uuid.UUID    `json:"id"`
+	UserID               *uuid.UUID   `json:"user_id,omitempty"`
+	FileName             string       `json:"file_name"`
+	ContentType          string       `json:"content_type"`
+	Size                 int64        `json:"size"`
+	FileKey              string       `json:"-"`
+	Status               UploadStatus `json:"status"`
+	CreatedAt            time.Time    `json:"created_at"`
+	ExpiresAt            time.Time    `json:"expires_at"`
+	CompletedAt          *time.Time   `json:"completed_at,omitempty"`
+	UploadURL            string       `json:"upload_url,omitempty"`
+	UploadURLExpiresAt   *time.Time   `json:"upload_url_expires_at,omitempty"`
+	DownloadURL          string       `json:"download_url,omitempty"`
+	DownloadURLExpiresAt *time.Time   `json:"download_url_expires_at,omitempty"`
+}
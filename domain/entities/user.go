@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -18,6 +20,20 @@ func (a AccountType) String() string {
 	return string(a)
 }
 
+// UserStatus tells whether the user may use their account. Suspended users
+// keep their data but can't sign in; pending users haven't been let in yet.
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusPending   UserStatus = "pending"
+)
+
+func (s UserStatus) String() string {
+	return string(s)
+}
+
 type User struct {
 	ID             uuid.UUID   `json:"id" db:"id"`
 	Email          string      `json:"email" db:"email"`
@@ -26,6 +42,60 @@ type User struct {
 	AccountType    AccountType `json:"account_type" db:"account_type"`
 	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
+
+	// EmailVerified is set once the user confirmed the address, by a link
+	// sent to it or by signing in with a social provider that verified it
+	EmailVerified   bool       `json:"email_verified" db:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+
+	// Phone is an E.164 number, set only once verified by SMS
+	Phone           string     `json:"phone,omitempty" db:"phone"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty" db:"phone_verified_at"`
+
+	UserProfile
+
+	// AvatarURL is where the user's picture is served from, empty when they
+	// haven't uploaded one
+	AvatarURL string `json:"avatar_url,omitempty" db:"avatar_url"`
+
+	// LastLoginAt and LastLoginIP are from the user's last successful login,
+	// unset until they first sign in
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	LastLoginIP string     `json:"last_login_ip,omitempty" db:"last_login_ip"`
+
+	// Status is set by admins; SuspendedReason is shown to the user when
+	// they are turned away
+	Status          UserStatus `json:"status" db:"status"`
+	SuspendedReason string     `json:"suspended_reason,omitempty" db:"suspended_reason"`
+	SuspendedAt     *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+
+	// ImpersonatedBy is the email of the admin acting as the user. It is
+	// only set on the current user, when the request's token was issued for
+	// impersonation, and is never stored.
+	ImpersonatedBy string `json:"impersonated_by,omitempty" db:"-"`
+}
+
+// UserProfile is what users tell about themselves. Metadata is free-form
+// data the application keeps about the user.
+type UserProfile struct {
+	FirstName   string         `json:"first_name" db:"first_name"`
+	LastName    string         `json:"last_name" db:"last_name"`
+	DisplayName string         `json:"display_name" db:"display_name"`
+	Timezone    string         `json:"timezone" db:"timezone"`
+	Locale      string         `json:"locale" db:"locale"`
+	Metadata    map[string]any `json:"metadata,omitempty" db:"metadata"`
+}
+
+// Name is what the user is called: the display name, their full name, or
+// their email.
+func (u *User) Name() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	return u.Email
 }
 
 func (u *User) IsValid() bool {
@@ -38,9 +108,119 @@ type UserStats struct {
 	SuperAdminUsers int64
 	RegularUsers    int64
 	RecentSignups   int64
+	DeletedUsers    int64
 }
 
 type ListUsersParams struct {
 	Limit  int32
 	Offset int32
+	Sort   UserSort
+}
+
+// UserSortField is a column users can be listed by.
+type UserSortField string
+
+const (
+	UserSortCreatedAt   UserSortField = "created_at"
+	UserSortEmail       UserSortField = "email"
+	UserSortAccountType UserSortField = "account_type"
+)
+
+// UserSortFields lists every field users can be sorted by.
+var UserSortFields = []UserSortField{UserSortCreatedAt, UserSortEmail, UserSortAccountType}
+
+func (f UserSortField) IsValid() bool {
+	return slices.Contains(UserSortFields, f)
+}
+
+// UserSort orders a user listing. The zero value lists the newest first.
+type UserSort struct {
+	Field UserSortField
+	Desc  bool
+}
+
+// UserFilter narrows a user search. Search matches any part of the email,
+// ignoring case, and Expr is a filter expression over UserFilterFields. Zero
+// fields match every user.
+type UserFilter struct {
+	Search      string
+	AccountType AccountType
+	Expr        Filter
+}
+
+// UserFilterFields are the user fields filter expressions can compare.
+var UserFilterFields = FilterFields{
+	"email":          {Type: FilterString},
+	"account_type":   {Type: FilterEnum, Values: []string{string(AccountTypeUser), string(AccountTypeAdmin), string(AccountTypeSuperAdmin)}},
+	"status":         {Type: FilterEnum, Values: []string{string(UserStatusActive), string(UserStatusSuspended), string(UserStatusPending)}},
+	"auth_provider":  {Type: FilterString},
+	"email_verified": {Type: FilterBool},
+	"created_at":     {Type: FilterTime},
+	"last_login_at":  {Type: FilterTime},
+}
+
+// UserCursor is a position in the users list, which is ordered newest first
+// by creation time and then ID. Listing after it resumes right past the user
+// it was taken from.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// BulkUserAction is what a bulk operation does to each of its users.
+type BulkUserAction string
+
+const (
+	BulkUserDelete            BulkUserAction = "delete"
+	BulkUserChangeAccountType BulkUserAction = "change_account_type"
+	BulkUserSuspend           BulkUserAction = "suspend"
+)
+
+// MaxBulkUsers is the most users one bulk operation can act on.
+const MaxBulkUsers = 100
+
+// BulkUserOperation applies Action to every user in UserIDs. AccountType is
+// the new account type of change_account_type, and Reason the suspension
+// reason shown to suspended users.
+type BulkUserOperation struct {
+	Action      BulkUserAction
+	UserIDs     []uuid.UUID
+	AccountType AccountType
+	Reason      string
+}
+
+// BulkUserResult is the outcome of a bulk operation for one user. Error says
+// why the user was skipped; it is empty when the action was applied.
+type BulkUserResult struct {
+	UserID uuid.UUID `json:"user_id"`
+	OK     bool      `json:"ok"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// MaxImportUsers is the most users one import can create.
+const MaxImportUsers = 1000
+
+// UserImportResult is the outcome of one row of a user import. Row is the
+// row's line in the file, counting the header as line 1. Error says why the
+// user wasn't created; UserID is set when it was.
+type UserImportResult struct {
+	Row    int        `json:"row"`
+	Email  string     `json:"email"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	OK     bool       `json:"ok"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// UserTombstone replaces a deleted user. It keeps the non-identifying facts
+// aggregate statistics need, and stands in for the user wherever records must
+// keep pointing at someone. UserID is cleared once the user's personal data
+// has been scrubbed.
+type UserTombstone struct {
+	ID           uuid.UUID
+	UserID       *uuid.UUID
+	AccountType  AccountType
+	AuthProvider string
+	SignedUpAt   time.Time
+	DeletedAt    time.Time
+	AnonymizedAt *time.Time
 }
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Webhook is an endpoint the domain events it subscribed to are posted to.
+// Deliveries are signed with Secret, which is only shown when the webhook
+// is created.
+type Webhook struct {
+	ID        uuid.UUID  `json:"id"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	Secret    string     `json:"secret,omitempty"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveryStatus is how a delivery attempt went.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliverySucceeded is an attempt the endpoint answered with a
+	// 2xx status.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed is an attempt that got another status or no
+	// answer.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an attempt at posting an event to a webhook. Each
+// attempt is kept: retries and redeliveries add their own. StatusCode is 0
+// when the endpoint didn't answer, and Error says why. ResponseBody holds
+// the start of the endpoint's answer.
+type WebhookDelivery struct {
+	ID           uuid.UUID             `json:"id"`
+	WebhookID    uuid.UUID             `json:"webhook_id"`
+	Event        string                `json:"event"`
+	Payload      json.RawMessage       `json:"payload"`
+	Status       WebhookDeliveryStatus `json:"status"`
+	StatusCode   int                   `json:"status_code"`
+	Error        string                `json:"error,omitempty"`
+	LatencyMs    int64                 `json:"latency_ms"`
+	ResponseBody string                `json:"response_body,omitempty"`
+	// RedeliveryOf is the attempt an admin redelivered
+	RedeliveryOf *uuid.UUID `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveryListResponse is a page of a webhook's delivery attempts,
+// newest first.
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int64             `json:"total"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// WebhookResponse is what a webhook endpoint answered a delivery with:
+// its status and the start of its body.
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
@@ -8,4 +8,7 @@ var (
 	ErrMalformedParameters = errors.New("malformed parameters")
 	ErrForbidden           = errors.New("forbidden")
 	ErrDuplicateKey        = errors.New("duplicate key")
+	ErrReadOnly            = errors.New("service is in read-only mode")
+	ErrAccountSuspended    = errors.New("account suspended")
+	ErrAccountPending      = errors.New("account pending approval")
 )
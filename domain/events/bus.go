@@ -0,0 +1,115 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"sync"
+)
+
+type handler func(ctx context.Context, event Event) error
+
+// decoders read the events relayed from the outbox back, by name.
+var decoders = map[string]func(payload []byte) (Event, error){}
+
+func init() {
+	registerDecoder[UserCreated]()
+	registerDecoder[UserDeleted]()
+	registerDecoder[SettingsUpdated]()
+}
+
+func registerDecoder[E Event]() {
+	var zero E
+	decoders[zero.EventName()] = func(payload []byte) (Event, error) {
+		var event E
+		err := json.Unmarshal(payload, &event)
+		return event, err
+	}
+}
+
+// Bus is an in-process publish/subscribe bus, safe for concurrent use.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]handler
+	logger   *slog.Logger
+}
+
+func NewBus(logger *slog.Logger) *Bus {
+	return &Bus{
+		handlers: map[string][]handler{},
+		logger:   logger,
+	}
+}
+
+// Subscribe calls fn with every event of type E published on bus.
+func Subscribe[E Event](bus *Bus, fn func(ctx context.Context, event E) error) {
+	var zero E
+	name := zero.EventName()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.handlers[name] = append(bus.handlers[name], func(ctx context.Context, event Event) error {
+		return fn(ctx, event.(E))
+	})
+}
+
+// Publish hands event to its subscribers, one after the other in the order
+// they subscribed, and returns once they are done. A subscriber that fails
+// or panics is logged, and neither stops the others nor fails the
+// publisher: the change the event is about has already been made. It
+// always returns nil.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := b.call(ctx, h, event); err != nil {
+			domain.LoggerOr(ctx, b.logger).ErrorContext(ctx, "event subscriber failed", "event", event.EventName(), "error", err)
+		}
+	}
+	return nil
+}
+
+// Deliver publishes an event relayed from the outbox, as the user who made
+// the change, with the message's key in the context. It fails only when
+// the event can't be read back; subscribers failing are logged as with
+// Publish.
+func (b *Bus) Deliver(ctx context.Context, msg entities.OutboxMessage) error {
+	decode, ok := decoders[msg.Event]
+	if !ok {
+		return fmt.Errorf("unknown event %q", msg.Event)
+	}
+	event, err := decode(msg.Payload)
+	if err != nil {
+		return fmt.Errorf("decoding %s event: %w", msg.Event, err)
+	}
+
+	if msg.ActorID != nil {
+		ctx = domain.WithActor(ctx, *msg.ActorID)
+	}
+	return b.Publish(context.WithValue(ctx, keyKey{}, msg.Key), event)
+}
+
+type keyKey struct{}
+
+// KeyFromContext returns the key of the outbox message a subscriber is
+// called with, for subscribers that must not act twice on the same event:
+// relayed events are delivered at least once. Events published straight on
+// the Bus have none.
+func KeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(keyKey{}).(string)
+	return key, ok
+}
+
+func (b *Bus) call(ctx context.Context, h handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, event)
+}
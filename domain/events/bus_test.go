@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ada@example.com"}
+
+	var got []string
+	Subscribe(bus, func(ctx context.Context, e UserCreated) error {
+		got = append(got, "first "+e.User.Email)
+		return errors.New("smtp down")
+	})
+	Subscribe(bus, func(ctx context.Context, e UserCreated) error {
+		panic("boom")
+	})
+	Subscribe(bus, func(ctx context.Context, e UserCreated) error {
+		got = append(got, "last "+e.User.Email)
+		return nil
+	})
+	Subscribe(bus, func(ctx context.Context, e UserDeleted) error {
+		got = append(got, "deleted "+e.User.Email)
+		return nil
+	})
+
+	bus.Publish(ctx, UserCreated{User: user, Registered: true})
+	assert.Equal(t, []string{"first ada@example.com", "last ada@example.com"}, got, "in order, past failing subscribers")
+
+	got = nil
+	bus.Publish(ctx, UserDeleted{User: user})
+	bus.Publish(ctx, SettingsUpdated{})
+	assert.Equal(t, []string{"deleted ada@example.com"}, got, "only to the event's subscribers")
+}
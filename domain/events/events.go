@@ -0,0 +1,95 @@
+// Package events lets domains tell each other what happened without
+// calling each other. A use case publishes typed events on a Bus, and the
+// features that react to them, such as auditing or emailing, subscribe in
+// bootstrap.SetupDependencies. With an Outbox, events are recorded in the
+// same transaction as their change and relayed to the Bus afterwards, so
+// they outlive a crash.
+package events
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+)
+
+// Event is something that happened in a domain. Its name tells subscribers
+// which events they get.
+type Event interface {
+	EventName() string
+}
+
+// Keyed is an Event that knows what makes it unique. Recording an event
+// with the key of one recorded already does nothing; events without a key
+// are all unique.
+type Keyed interface {
+	Event
+	EventKey() string
+}
+
+// Publisher hands events to their subscribers. Use cases publish through it
+// with Commit, and are given the Bus or the Outbox.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Transactor runs fn in a database transaction. Repositories called with
+// the context fn is given join it, so either all their changes are made or
+// none is.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Commit makes a change and publishes the events it returns. When p is
+// also a Transactor, as the Outbox is, the events are published in the
+// change's transaction, and failing to publish them undoes the change.
+// Otherwise they are published once the change is made. A nil p, or a dry
+// run, only makes the change.
+func Commit(ctx context.Context, p Publisher, change func(ctx context.Context) ([]Event, error)) error {
+	publish := func(ctx context.Context) error {
+		events, err := change(ctx)
+		if err != nil || p == nil || domain.IsDryRun(ctx) {
+			return err
+		}
+		for _, event := range events {
+			if err := p.Publish(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if tx, ok := p.(Transactor); ok {
+		return tx.InTx(ctx, publish)
+	}
+	return publish(ctx)
+}
+
+// UserCreated is published when a user account is created.
+type UserCreated struct {
+	User entities.User
+	// Registered is set for users who signed up with the registration form,
+	// with or without an invitation code. Users created by admins, imports,
+	// accepted email invitations and first sign-ins are not.
+	Registered bool
+}
+
+func (UserCreated) EventName() string { return "user.created" }
+
+func (e UserCreated) EventKey() string { return "user.created:" + e.User.ID.String() }
+
+// UserDeleted is published when a user account is deleted, with the user as
+// they were.
+type UserDeleted struct {
+	User entities.User
+}
+
+func (UserDeleted) EventName() string { return "user.deleted" }
+
+func (e UserDeleted) EventKey() string { return "user.deleted:" + e.User.ID.String() }
+
+// SettingsUpdated is published when the system settings are saved, with
+// the settings as saved.
+type SettingsUpdated struct {
+	Settings entities.SystemSettings
+}
+
+func (SettingsUpdated) EventName() string { return "settings.updated" }
@@ -0,0 +1,205 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	relayBatchSize = 100
+	// relayLease is how long a claimed message is left to its relay before
+	// another one takes it over, assuming the first died.
+	relayLease = time.Minute
+	// retryBackoff is how long a message that failed to be relayed waits
+	// before its next attempt, doubling with each attempt up to
+	// maxRetryBackoff.
+	retryBackoff    = 10 * time.Second
+	maxRetryBackoff = time.Hour
+	// purgeInterval is how often relayed messages past the retention are
+	// removed.
+	purgeInterval = time.Hour
+)
+
+// Outbox is a Publisher that records events in the transaction of the
+// change they are about, rather than publishing them right away, and
+// relays them to its sinks in the background. An event is relayed if and
+// only if its change was committed, even across crashes, and at least
+// once: subscribers can tell repeats by their key. Each sink gets its own
+// copy of every event, so one that is down only delays its own.
+//
+// Events are relayed as JSON, so fields left out of their JSON, such as a
+// user's auth provider ID, don't reach the subscribers.
+type Outbox struct {
+	repo      OutboxRepository
+	tx        Transactor
+	sinks     map[string]Sink
+	retention time.Duration
+	logger    *slog.Logger
+	purgedAt  time.Time
+	// wake starts the relay on a committed event without waiting for the
+	// next tick
+	wake chan struct{}
+}
+
+// NewOutbox creates an Outbox recording events with repo in transactions
+// started by tx. Relayed messages are removed once retention has passed
+// since.
+func NewOutbox(repo OutboxRepository, tx Transactor, retention time.Duration, logger *slog.Logger) *Outbox {
+	return &Outbox{
+		repo:      repo,
+		tx:        tx,
+		sinks:     map[string]Sink{},
+		retention: retention,
+		logger:    logger,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// AddSink relays the events published from now on to sink as well, under
+// name. Sinks are added while setting up, before events are published.
+// Messages recorded for a sink no longer added are kept, and relayed once
+// it is added back.
+func (o *Outbox) AddSink(name string, sink Sink) {
+	o.sinks[name] = sink
+}
+
+// InTx runs fn in a transaction, and wakes the relay once it is committed.
+func (o *Outbox) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := o.tx.InTx(ctx, fn); err != nil {
+		return err
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Publish records event for each sink, with the user the context says made
+// the change. It is atomic with the change only in a transaction, as
+// Commit does.
+func (o *Outbox) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", event.EventName(), err)
+	}
+
+	now := time.Now().UTC()
+	key := uuid.Must(uuid.NewV4()).String()
+	if keyed, ok := event.(Keyed); ok {
+		key = keyed.EventKey()
+	}
+	var actorID *uuid.UUID
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		actorID = &actor
+	}
+
+	for name := range o.sinks {
+		err := o.repo.AddOutboxMessage(ctx, entities.OutboxMessage{
+			ID:          uuid.Must(uuid.NewV4()),
+			Sink:        name,
+			Event:       event.EventName(),
+			Key:         key,
+			Payload:     payload,
+			ActorID:     actorID,
+			CreatedAt:   now,
+			AvailableAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run removes the messages relayed before the retention, at most every
+// purgeInterval, then relays the due messages, oldest first, until none
+// are left. Messages that fail are tried again later, so they may be
+// relayed out of order. It returns how many messages it relayed.
+func (o *Outbox) Run(ctx context.Context) (int, error) {
+	if o.retention > 0 && time.Since(o.purgedAt) >= purgeInterval {
+		n, err := o.repo.DeletePublishedOutboxMessages(ctx, time.Now().UTC().Add(-o.retention))
+		if err != nil {
+			return 0, fmt.Errorf("removing relayed outbox messages: %w", err)
+		}
+		if n > 0 {
+			domain.LoggerOr(ctx, o.logger).Info("removed relayed outbox messages", "count", n)
+		}
+		o.purgedAt = time.Now()
+	}
+
+	done := 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		msgs, err := o.repo.ClaimOutboxMessages(ctx, now, now.Add(relayLease), relayBatchSize)
+		if err != nil {
+			return done, fmt.Errorf("claiming outbox messages: %w", err)
+		}
+		if len(msgs) == 0 {
+			break
+		}
+
+		for _, msg := range msgs {
+			if err := o.deliver(ctx, msg); err != nil {
+				domain.LoggerOr(ctx, o.logger).Error("failed to relay outbox message", "outbox_message_id", msg.ID, "sink", msg.Sink, "event", msg.Event, "attempts", msg.Attempts+1, "error", err)
+				retryAt := time.Now().UTC().Add(backoff(msg.Attempts))
+				if err := o.repo.RetryOutboxMessage(ctx, msg.ID, err.Error(), retryAt); err != nil {
+					return done, err
+				}
+				continue
+			}
+			if err := o.repo.MarkOutboxMessagePublished(ctx, msg.ID, time.Now().UTC()); err != nil {
+				return done, err
+			}
+			done++
+		}
+	}
+	return done, nil
+}
+
+func (o *Outbox) deliver(ctx context.Context, msg entities.OutboxMessage) error {
+	sink, ok := o.sinks[msg.Sink]
+	if !ok {
+		return fmt.Errorf("no %q sink", msg.Sink)
+	}
+	return sink.Deliver(ctx, msg)
+}
+
+// backoff is how long to wait after a message's attempts failed.
+func backoff(attempts int) time.Duration {
+	wait := retryBackoff
+	for i := 0; i < attempts && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
+
+// Start relays messages as their changes are committed, or every interval,
+// until ctx is done.
+func (o *Outbox) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := o.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, o.logger).Error("outbox relay failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, o.logger).Debug("relayed outbox messages", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// txKey marks the context of the transaction the fake Transactor runs.
+type txKey struct{}
+
+func newTestOutbox(repo OutboxRepository, sink Sink) (*Outbox, *mocks.TransactorMock) {
+	tx := &mocks.TransactorMock{
+		InTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		},
+	}
+	outbox := NewOutbox(repo, tx, time.Hour, discardLogger())
+	outbox.AddSink("bus", sink)
+	return outbox, tx
+}
+
+func TestCommit(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ada@example.com"}
+	actor := uuid.Must(uuid.NewV4())
+	ctx := domain.WithActor(context.Background(), actor)
+	created := func(ctx context.Context) ([]Event, error) {
+		return []Event{UserCreated{User: user, Registered: true}}, nil
+	}
+
+	t.Run("recorded in the change's transaction", func(t *testing.T) {
+		var added []entities.OutboxMessage
+		repo := &mocks.OutboxRepositoryMock{
+			AddOutboxMessageFunc: func(ctx context.Context, msg entities.OutboxMessage) error {
+				assert.Equal(t, true, ctx.Value(txKey{}), "in the transaction")
+				added = append(added, msg)
+				return nil
+			},
+		}
+		outbox, tx := newTestOutbox(repo, &mocks.SinkMock{})
+
+		require.NoError(t, Commit(ctx, outbox, created))
+		assert.Len(t, tx.InTxCalls(), 1)
+		require.Len(t, added, 1)
+		assert.Equal(t, "bus", added[0].Sink)
+		assert.Equal(t, "user.created", added[0].Event)
+		assert.Equal(t, "user.created:"+user.ID.String(), added[0].Key)
+		assert.Equal(t, &actor, added[0].ActorID)
+		assert.JSONEq(t, `true`, string(mustField(t, added[0].Payload, "Registered")))
+	})
+
+	t.Run("a copy for each sink", func(t *testing.T) {
+		repo := &mocks.OutboxRepositoryMock{}
+		outbox, _ := newTestOutbox(repo, &mocks.SinkMock{})
+		outbox.AddSink("kafka", &mocks.SinkMock{})
+
+		require.NoError(t, Commit(ctx, outbox, created))
+		added := repo.AddOutboxMessageCalls()
+		require.Len(t, added, 2)
+		assert.ElementsMatch(t, []string{"bus", "kafka"}, []string{added[0].Msg.Sink, added[1].Msg.Sink})
+		assert.Equal(t, added[0].Msg.Key, added[1].Msg.Key)
+		assert.NotEqual(t, added[0].Msg.ID, added[1].Msg.ID)
+	})
+
+	t.Run("failing to record undoes the change", func(t *testing.T) {
+		repo := &mocks.OutboxRepositoryMock{
+			AddOutboxMessageFunc: func(ctx context.Context, msg entities.OutboxMessage) error {
+				return errors.New("db down")
+			},
+		}
+		outbox, _ := newTestOutbox(repo, &mocks.SinkMock{})
+
+		assert.Error(t, Commit(ctx, outbox, created))
+	})
+
+	t.Run("failed changes publish nothing", func(t *testing.T) {
+		repo := &mocks.OutboxRepositoryMock{}
+		outbox, _ := newTestOutbox(repo, &mocks.SinkMock{})
+
+		err := Commit(ctx, outbox, func(ctx context.Context) ([]Event, error) {
+			return nil, domain.ErrNotFound
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, repo.AddOutboxMessageCalls())
+	})
+
+	t.Run("dry runs publish nothing", func(t *testing.T) {
+		repo := &mocks.OutboxRepositoryMock{}
+		outbox, _ := newTestOutbox(repo, &mocks.SinkMock{})
+
+		require.NoError(t, Commit(domain.WithDryRun(ctx), outbox, created))
+		assert.Empty(t, repo.AddOutboxMessageCalls())
+	})
+
+	t.Run("straight to the bus", func(t *testing.T) {
+		bus := NewBus(discardLogger())
+		var got []UserCreated
+		Subscribe(bus, func(ctx context.Context, e UserCreated) error {
+			got = append(got, e)
+			return nil
+		})
+
+		require.NoError(t, Commit(ctx, bus, created))
+		assert.Equal(t, []UserCreated{{User: user, Registered: true}}, got)
+		require.NoError(t, Commit(ctx, nil, created), "without a publisher")
+	})
+}
+
+func TestOutbox_Run(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ada@example.com"}
+	actor := uuid.Must(uuid.NewV4())
+	var recorded []entities.OutboxMessage
+	recorder, _ := newTestOutbox(&mocks.OutboxRepositoryMock{
+		AddOutboxMessageFunc: func(ctx context.Context, msg entities.OutboxMessage) error {
+			recorded = append(recorded, msg)
+			return nil
+		},
+	}, nil)
+	ctx := domain.WithActor(context.Background(), actor)
+	require.NoError(t, recorder.Publish(ctx, UserCreated{User: user}))
+	require.NoError(t, recorder.Publish(ctx, SettingsUpdated{}))
+	require.NoError(t, recorder.Publish(ctx, UserDeleted{User: user}))
+	recorded[1].Attempts = 2
+	removed := recorded[0]
+	removed.ID = uuid.Must(uuid.NewV4())
+	removed.Sink = "kafka"
+	recorded = append(recorded, removed)
+
+	claims := 0
+	repo := &mocks.OutboxRepositoryMock{
+		ClaimOutboxMessagesFunc: func(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]entities.OutboxMessage, error) {
+			assert.True(t, leaseUntil.After(now))
+			claims++
+			if claims > 1 {
+				return nil, nil
+			}
+			return recorded, nil
+		},
+	}
+
+	bus := NewBus(discardLogger())
+	var got []string
+	Subscribe(bus, func(ctx context.Context, e UserCreated) error {
+		gotActor, _ := domain.ActorFromContext(ctx)
+		key, _ := KeyFromContext(ctx)
+		assert.Equal(t, actor, gotActor, "as the user who made the change")
+		assert.Equal(t, "user.created:"+user.ID.String(), key)
+		got = append(got, "created "+e.User.Email)
+		return nil
+	})
+	Subscribe(bus, func(ctx context.Context, e UserDeleted) error {
+		got = append(got, "deleted "+e.User.Email)
+		return errors.New("subscribers failing are only logged")
+	})
+	sink := &mocks.SinkMock{
+		DeliverFunc: func(ctx context.Context, msg entities.OutboxMessage) error {
+			if msg.Event == "settings.updated" {
+				return errors.New("broker down")
+			}
+			return bus.Deliver(ctx, msg)
+		},
+	}
+	outbox, _ := newTestOutbox(repo, sink)
+
+	start := time.Now()
+	n, err := outbox.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"created ada@example.com", "deleted ada@example.com"}, got, "oldest first")
+	assert.Len(t, repo.DeletePublishedOutboxMessagesCalls(), 1)
+
+	published := repo.MarkOutboxMessagePublishedCalls()
+	require.Len(t, published, 2)
+	assert.Equal(t, recorded[0].ID, published[0].ID)
+	assert.Equal(t, recorded[2].ID, published[1].ID)
+
+	retried := repo.RetryOutboxMessageCalls()
+	require.Len(t, retried, 2)
+	assert.Equal(t, recorded[1].ID, retried[0].ID)
+	assert.Equal(t, "broker down", retried[0].Message)
+	assert.WithinDuration(t, start.Add(40*time.Second), retried[0].RetryAt, 5*time.Second, "backing off with its attempts")
+	assert.Equal(t, removed.ID, retried[1].ID, "kept for sinks no longer added")
+
+	_, err = outbox.Run(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repo.DeletePublishedOutboxMessagesCalls(), 1, "removed at most every purge interval")
+}
+
+func TestBus_Deliver(t *testing.T) {
+	bus := NewBus(discardLogger())
+	err := bus.Deliver(context.Background(), entities.OutboxMessage{Event: "user.renamed", Payload: []byte(`{}`)})
+	assert.Error(t, err, "unknown events are tried again, by an instance that knows them")
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, backoff(0))
+	assert.Equal(t, 20*time.Second, backoff(1))
+	assert.Equal(t, time.Hour, backoff(20))
+}
+
+func mustField(t *testing.T, payload []byte, field string) []byte {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	return fields[field]
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . OutboxRepository Transactor Sink
+
+type OutboxRepository interface {
+	// AddOutboxMessage records msg, in the transaction of the context if
+	// there is one. A message with the key of one recorded already is
+	// dropped.
+	AddOutboxMessage(ctx context.Context, msg entities.OutboxMessage) error
+	// ClaimOutboxMessages returns up to limit unpublished messages due by
+	// now, oldest first, and makes them due again at leaseUntil, so
+	// concurrent relays skip them meanwhile and a relay that died while
+	// relaying them is taken over.
+	ClaimOutboxMessages(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]entities.OutboxMessage, error)
+	MarkOutboxMessagePublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error
+	// RetryOutboxMessage records a failed attempt at relaying the message,
+	// and makes it due again at retryAt.
+	RetryOutboxMessage(ctx context.Context, id uuid.UUID, message string, retryAt time.Time) error
+	// DeletePublishedOutboxMessages removes the messages published before
+	// publishedBefore, and returns how many it removed.
+	DeletePublishedOutboxMessages(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
+
+// Sink is where the Outbox relays messages to: the Bus, or a broker.
+type Sink interface {
+	Deliver(ctx context.Context, msg entities.OutboxMessage) error
+}
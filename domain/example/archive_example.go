@@ -0,0 +1,38 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+)
+
+// ArchiveExample archives the example, leaving it out of lists until it is
+// unarchived. Archived examples are purged once the retention period has
+// passed. Archiving an archived example leaves it as it was.
+func (uc UseCase) ArchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	if len(id) == 0 {
+		return entities.Example{}, fmt.Errorf("missing id: %w", domain.ErrMalformedParameters)
+	}
+
+	example, err := uc.R.ArchiveExample(ctx, id)
+	if err != nil {
+		return entities.Example{}, fmt.Errorf("failed to archive example: %w", err)
+	}
+
+	return example, nil
+}
+
+// UnarchiveExample brings an archived example back into lists.
+func (uc UseCase) UnarchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	if len(id) == 0 {
+		return entities.Example{}, fmt.Errorf("missing id: %w", domain.ErrMalformedParameters)
+	}
+
+	example, err := uc.R.UnarchiveExample(ctx, id)
+	if err != nil {
+		return entities.Example{}, fmt.Errorf("failed to unarchive example: %w", err)
+	}
+
+	return example, nil
+}
@@ -0,0 +1,89 @@
+package example
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/example/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveExample(t *testing.T) {
+	archivedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      string
+		mock    func(*mocks.RepositoryMock)
+		wantErr error
+	}{
+		{
+			name: "success",
+			id:   "123",
+			mock: func(m *mocks.RepositoryMock) {
+				m.ArchiveExampleFunc = func(ctx context.Context, id string) (entities.Example, error) {
+					return entities.Example{ID: id, ArchivedAt: &archivedAt}, nil
+				}
+			},
+		},
+		{
+			name:    "empty id",
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name: "not found",
+			id:   "999",
+			mock: func(m *mocks.RepositoryMock) {
+				m.ArchiveExampleFunc = func(ctx context.Context, id string) (entities.Example, error) {
+					return entities.Example{}, domain.ErrNotFound
+				}
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			tt.mock(repo)
+
+			uc := New(repo)
+			got, err := uc.ArchiveExample(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &archivedAt, got.ArchivedAt)
+			}
+		})
+	}
+}
+
+func TestUnarchiveExample(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		UnarchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+			if id != "123" {
+				return entities.Example{}, domain.ErrNotFound
+			}
+			return entities.Example{ID: id}, nil
+		},
+	}
+	uc := New(repo)
+
+	got, err := uc.UnarchiveExample(context.Background(), "123")
+	assert.NoError(t, err)
+	assert.Nil(t, got.ArchivedAt)
+
+	_, err = uc.UnarchiveExample(context.Background(), "999")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = uc.UnarchiveExample(context.Background(), "")
+	assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+}
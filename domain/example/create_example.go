@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-template/domain"
 	"go-template/domain/entities"
+	"go-template/internal/metrics"
 )
 
 func (uc UseCase) CreateExample(ctx context.Context, input entities.Example) (string, error) {
@@ -12,10 +13,16 @@ func (uc UseCase) CreateExample(ctx context.Context, input entities.Example) (st
 		return "", fmt.Errorf("missing title: %w", domain.ErrMalformedParameters)
 	}
 
+	input.CreatedBy = nil
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		input.CreatedBy = &actor
+	}
+
 	id, err := uc.R.CreateExample(ctx, input)
 	if err != nil {
 		return "", fmt.Errorf("failed to create example: %w", err)
 	}
+	metrics.RecordExampleCreated()
 
 	return id, nil
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"go-template/domain"
 	"go-template/domain/entities"
 	"go-template/domain/example/mocks"
 
+	"github.com/gofrs/uuid/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,3 +60,20 @@ func TestCreateExample(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateExample_CreatedBy(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{}
+	uc := New(repo)
+
+	_, err := uc.CreateExample(domain.WithActor(context.Background(), actor), entities.Example{Title: "Test Title"})
+	assert.NoError(t, err)
+	_, err = uc.CreateExample(context.Background(), entities.Example{Title: "Test Title", CreatedBy: &actor})
+	assert.NoError(t, err)
+
+	calls := repo.CreateExampleCalls()
+	if assert.Len(t, calls, 2) {
+		assert.Equal(t, &actor, calls[0].Example.CreatedBy, "the caller owns the example")
+		assert.Nil(t, calls[1].Example.CreatedBy, "callers can't name another owner")
+	}
+}
@@ -0,0 +1,23 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"time"
+)
+
+// DeleteExample deletes the example. With expectedUpdatedAt, it is only
+// deleted if it wasn't changed since, and domain.ErrConflict is returned
+// otherwise.
+func (uc UseCase) DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+	if len(id) == 0 {
+		return fmt.Errorf("missing id: %w", domain.ErrMalformedParameters)
+	}
+
+	if err := uc.R.DeleteExample(ctx, id, expectedUpdatedAt); err != nil {
+		return fmt.Errorf("failed to delete example: %w", err)
+	}
+
+	return nil
+}
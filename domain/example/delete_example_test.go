@@ -0,0 +1,86 @@
+package example
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-template/domain"
+	"go-template/domain/example/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteExample(t *testing.T) {
+	readAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		id        string
+		updatedAt *time.Time
+		mock      func(*mocks.RepositoryMock)
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			id:        "123",
+			updatedAt: &readAt,
+			mock: func(m *mocks.RepositoryMock) {
+				m.DeleteExampleFunc = func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+					return nil
+				}
+			},
+		},
+		{
+			name:    "unconditional",
+			id:      "123",
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: nil,
+		},
+		{
+			name:    "empty id",
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name: "not found",
+			id:   "999",
+			mock: func(m *mocks.RepositoryMock) {
+				m.DeleteExampleFunc = func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+					return domain.ErrNotFound
+				}
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:      "changed since read",
+			id:        "123",
+			updatedAt: &readAt,
+			mock: func(m *mocks.RepositoryMock) {
+				m.DeleteExampleFunc = func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+					return domain.ErrConflict
+				}
+			},
+			wantErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			tt.mock(repo)
+
+			uc := New(repo)
+			err := uc.DeleteExample(context.Background(), tt.id, tt.updatedAt)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				calls := repo.DeleteExampleCalls()
+				assert.Len(t, calls, 1)
+				assert.Equal(t, tt.updatedAt, calls[0].ExpectedUpdatedAt)
+			}
+		})
+	}
+}
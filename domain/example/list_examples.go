@@ -0,0 +1,36 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+)
+
+// ListExamples returns a page of examples sorted by created_at, newest first
+// when desc is set, and how many examples there are in all. Archived
+// examples are left out unless includeArchived is set. Pages start at 1 and
+// hold 20 examples unless pageSize is between 1 and 100.
+func (uc UseCase) ListExamples(ctx context.Context, page, pageSize int, desc, includeArchived bool) ([]entities.Example, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	examples, err := uc.R.ListExamples(ctx, entities.ListExamplesParams{
+		Limit:           int32(pageSize),
+		Offset:          int32((page - 1) * pageSize),
+		Desc:            desc,
+		IncludeArchived: includeArchived,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list examples: %w", err)
+	}
+	total, err := uc.R.CountExamples(ctx, includeArchived)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count examples: %w", err)
+	}
+
+	return examples, total, nil
+}
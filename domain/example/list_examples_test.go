@@ -0,0 +1,93 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-template/domain/entities"
+	"go-template/domain/example/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListExamples(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		pageSize   int
+		desc       bool
+		archived   bool
+		listErr    error
+		wantLimit  int32
+		wantOffset int32
+		wantErr    bool
+	}{
+		{
+			name:       "first page",
+			page:       1,
+			pageSize:   10,
+			desc:       true,
+			wantLimit:  10,
+			wantOffset: 0,
+		},
+		{
+			name:       "later page",
+			page:       3,
+			pageSize:   10,
+			archived:   true,
+			wantLimit:  10,
+			wantOffset: 20,
+		},
+		{
+			name:       "out of range values fall back to the defaults",
+			page:       0,
+			pageSize:   500,
+			wantLimit:  20,
+			wantOffset: 0,
+		},
+		{
+			name:    "repository error",
+			page:    1,
+			listErr: errors.New("db down"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				ListExamplesFunc: func(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error) {
+					if tt.listErr != nil {
+						return nil, tt.listErr
+					}
+					return []entities.Example{{ID: "1", Title: "First"}}, nil
+				},
+				CountExamplesFunc: func(ctx context.Context, includeArchived bool) (int64, error) {
+					return 21, nil
+				},
+			}
+			uc := New(repo)
+
+			got, total, err := uc.ListExamples(context.Background(), tt.page, tt.pageSize, tt.desc, tt.archived)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, int64(21), total)
+
+			calls := repo.ListExamplesCalls()
+			assert.Len(t, calls, 1)
+			assert.Equal(t, entities.ListExamplesParams{
+				Limit:           tt.wantLimit,
+				Offset:          tt.wantOffset,
+				Desc:            tt.desc,
+				IncludeArchived: tt.archived,
+			}, calls[0].Params)
+			assert.Equal(t, tt.archived, repo.CountExamplesCalls()[0].IncludeArchived)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"go-template/domain/entities"
 	"sync"
+	"time"
 )
 
 // RepositoryMock is a mock implementation of example.Repository.
@@ -15,12 +16,33 @@ import (
 //
 //		// make and configure a mocked example.Repository
 //		mockedRepository := &RepositoryMock{
+//			ArchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+//				panic("mock out the ArchiveExample method")
+//			},
+//			CountExamplesFunc: func(ctx context.Context, includeArchived bool) (int64, error) {
+//				panic("mock out the CountExamples method")
+//			},
 //			CreateExampleFunc: func(contextMoqParam context.Context, example entities.Example) (string, error) {
 //				panic("mock out the CreateExample method")
 //			},
+//			DeleteArchivedExamplesFunc: func(ctx context.Context, archivedBefore time.Time, limit int32) (int, error) {
+//				panic("mock out the DeleteArchivedExamples method")
+//			},
+//			DeleteExampleFunc: func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+//				panic("mock out the DeleteExample method")
+//			},
 //			GetExampleByIDFunc: func(contextMoqParam context.Context, s string) (entities.Example, error) {
 //				panic("mock out the GetExampleByID method")
 //			},
+//			ListExamplesFunc: func(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error) {
+//				panic("mock out the ListExamples method")
+//			},
+//			UnarchiveExampleFunc: func(ctx context.Context, id string) (entities.Example, error) {
+//				panic("mock out the UnarchiveExample method")
+//			},
+//			UpdateExampleFunc: func(ctx context.Context, example entities.Example) (entities.Example, error) {
+//				panic("mock out the UpdateExample method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires example.Repository
@@ -28,14 +50,49 @@ import (
 //
 //	}
 type RepositoryMock struct {
+	// ArchiveExampleFunc mocks the ArchiveExample method.
+	ArchiveExampleFunc func(ctx context.Context, id string) (entities.Example, error)
+
+	// CountExamplesFunc mocks the CountExamples method.
+	CountExamplesFunc func(ctx context.Context, includeArchived bool) (int64, error)
+
 	// CreateExampleFunc mocks the CreateExample method.
 	CreateExampleFunc func(contextMoqParam context.Context, example entities.Example) (string, error)
 
+	// DeleteArchivedExamplesFunc mocks the DeleteArchivedExamples method.
+	DeleteArchivedExamplesFunc func(ctx context.Context, archivedBefore time.Time, limit int32) (int, error)
+
+	// DeleteExampleFunc mocks the DeleteExample method.
+	DeleteExampleFunc func(ctx context.Context, id string, expectedUpdatedAt *time.Time) error
+
 	// GetExampleByIDFunc mocks the GetExampleByID method.
 	GetExampleByIDFunc func(contextMoqParam context.Context, s string) (entities.Example, error)
 
+	// ListExamplesFunc mocks the ListExamples method.
+	ListExamplesFunc func(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error)
+
+	// UnarchiveExampleFunc mocks the UnarchiveExample method.
+	UnarchiveExampleFunc func(ctx context.Context, id string) (entities.Example, error)
+
+	// UpdateExampleFunc mocks the UpdateExample method.
+	UpdateExampleFunc func(ctx context.Context, example entities.Example) (entities.Example, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ArchiveExample holds details about calls to the ArchiveExample method.
+		ArchiveExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// CountExamples holds details about calls to the CountExamples method.
+		CountExamples []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// IncludeArchived is the includeArchived argument value.
+			IncludeArchived bool
+		}
 		// CreateExample holds details about calls to the CreateExample method.
 		CreateExample []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -43,6 +100,24 @@ type RepositoryMock struct {
 			// Example is the example argument value.
 			Example entities.Example
 		}
+		// DeleteArchivedExamples holds details about calls to the DeleteArchivedExamples method.
+		DeleteArchivedExamples []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ArchivedBefore is the archivedBefore argument value.
+			ArchivedBefore time.Time
+			// Limit is the limit argument value.
+			Limit int32
+		}
+		// DeleteExample holds details about calls to the DeleteExample method.
+		DeleteExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// ExpectedUpdatedAt is the expectedUpdatedAt argument value.
+			ExpectedUpdatedAt *time.Time
+		}
 		// GetExampleByID holds details about calls to the GetExampleByID method.
 		GetExampleByID []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -50,9 +125,117 @@ type RepositoryMock struct {
 			// S is the s argument value.
 			S string
 		}
+		// ListExamples holds details about calls to the ListExamples method.
+		ListExamples []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params entities.ListExamplesParams
+		}
+		// UnarchiveExample holds details about calls to the UnarchiveExample method.
+		UnarchiveExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// UpdateExample holds details about calls to the UpdateExample method.
+		UpdateExample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Example is the example argument value.
+			Example entities.Example
+		}
+	}
+	lockArchiveExample         sync.RWMutex
+	lockCountExamples          sync.RWMutex
+	lockCreateExample          sync.RWMutex
+	lockDeleteArchivedExamples sync.RWMutex
+	lockDeleteExample          sync.RWMutex
+	lockGetExampleByID         sync.RWMutex
+	lockListExamples           sync.RWMutex
+	lockUnarchiveExample       sync.RWMutex
+	lockUpdateExample          sync.RWMutex
+}
+
+// ArchiveExample calls ArchiveExampleFunc.
+func (mock *RepositoryMock) ArchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockArchiveExample.Lock()
+	mock.calls.ArchiveExample = append(mock.calls.ArchiveExample, callInfo)
+	mock.lockArchiveExample.Unlock()
+	if mock.ArchiveExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.ArchiveExampleFunc(ctx, id)
+}
+
+// ArchiveExampleCalls gets all the calls that were made to ArchiveExample.
+// Check the length with:
+//
+//	len(mockedRepository.ArchiveExampleCalls())
+func (mock *RepositoryMock) ArchiveExampleCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
 	}
-	lockCreateExample  sync.RWMutex
-	lockGetExampleByID sync.RWMutex
+	mock.lockArchiveExample.RLock()
+	calls = mock.calls.ArchiveExample
+	mock.lockArchiveExample.RUnlock()
+	return calls
+}
+
+// CountExamples calls CountExamplesFunc.
+func (mock *RepositoryMock) CountExamples(ctx context.Context, includeArchived bool) (int64, error) {
+	callInfo := struct {
+		Ctx             context.Context
+		IncludeArchived bool
+	}{
+		Ctx:             ctx,
+		IncludeArchived: includeArchived,
+	}
+	mock.lockCountExamples.Lock()
+	mock.calls.CountExamples = append(mock.calls.CountExamples, callInfo)
+	mock.lockCountExamples.Unlock()
+	if mock.CountExamplesFunc == nil {
+		var (
+			nOut   int64
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.CountExamplesFunc(ctx, includeArchived)
+}
+
+// CountExamplesCalls gets all the calls that were made to CountExamples.
+// Check the length with:
+//
+//	len(mockedRepository.CountExamplesCalls())
+func (mock *RepositoryMock) CountExamplesCalls() []struct {
+	Ctx             context.Context
+	IncludeArchived bool
+} {
+	var calls []struct {
+		Ctx             context.Context
+		IncludeArchived bool
+	}
+	mock.lockCountExamples.RLock()
+	calls = mock.calls.CountExamples
+	mock.lockCountExamples.RUnlock()
+	return calls
 }
 
 // CreateExample calls CreateExampleFunc.
@@ -95,6 +278,93 @@ func (mock *RepositoryMock) CreateExampleCalls() []struct {
 	return calls
 }
 
+// DeleteArchivedExamples calls DeleteArchivedExamplesFunc.
+func (mock *RepositoryMock) DeleteArchivedExamples(ctx context.Context, archivedBefore time.Time, limit int32) (int, error) {
+	callInfo := struct {
+		Ctx            context.Context
+		ArchivedBefore time.Time
+		Limit          int32
+	}{
+		Ctx:            ctx,
+		ArchivedBefore: archivedBefore,
+		Limit:          limit,
+	}
+	mock.lockDeleteArchivedExamples.Lock()
+	mock.calls.DeleteArchivedExamples = append(mock.calls.DeleteArchivedExamples, callInfo)
+	mock.lockDeleteArchivedExamples.Unlock()
+	if mock.DeleteArchivedExamplesFunc == nil {
+		var (
+			nOut   int
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.DeleteArchivedExamplesFunc(ctx, archivedBefore, limit)
+}
+
+// DeleteArchivedExamplesCalls gets all the calls that were made to DeleteArchivedExamples.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteArchivedExamplesCalls())
+func (mock *RepositoryMock) DeleteArchivedExamplesCalls() []struct {
+	Ctx            context.Context
+	ArchivedBefore time.Time
+	Limit          int32
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ArchivedBefore time.Time
+		Limit          int32
+	}
+	mock.lockDeleteArchivedExamples.RLock()
+	calls = mock.calls.DeleteArchivedExamples
+	mock.lockDeleteArchivedExamples.RUnlock()
+	return calls
+}
+
+// DeleteExample calls DeleteExampleFunc.
+func (mock *RepositoryMock) DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+	callInfo := struct {
+		Ctx               context.Context
+		ID                string
+		ExpectedUpdatedAt *time.Time
+	}{
+		Ctx:               ctx,
+		ID:                id,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	}
+	mock.lockDeleteExample.Lock()
+	mock.calls.DeleteExample = append(mock.calls.DeleteExample, callInfo)
+	mock.lockDeleteExample.Unlock()
+	if mock.DeleteExampleFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DeleteExampleFunc(ctx, id, expectedUpdatedAt)
+}
+
+// DeleteExampleCalls gets all the calls that were made to DeleteExample.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteExampleCalls())
+func (mock *RepositoryMock) DeleteExampleCalls() []struct {
+	Ctx               context.Context
+	ID                string
+	ExpectedUpdatedAt *time.Time
+} {
+	var calls []struct {
+		Ctx               context.Context
+		ID                string
+		ExpectedUpdatedAt *time.Time
+	}
+	mock.lockDeleteExample.RLock()
+	calls = mock.calls.DeleteExample
+	mock.lockDeleteExample.RUnlock()
+	return calls
+}
+
 // GetExampleByID calls GetExampleByIDFunc.
 func (mock *RepositoryMock) GetExampleByID(contextMoqParam context.Context, s string) (entities.Example, error) {
 	callInfo := struct {
@@ -134,3 +404,123 @@ func (mock *RepositoryMock) GetExampleByIDCalls() []struct {
 	mock.lockGetExampleByID.RUnlock()
 	return calls
 }
+
+// ListExamples calls ListExamplesFunc.
+func (mock *RepositoryMock) ListExamples(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Params entities.ListExamplesParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListExamples.Lock()
+	mock.calls.ListExamples = append(mock.calls.ListExamples, callInfo)
+	mock.lockListExamples.Unlock()
+	if mock.ListExamplesFunc == nil {
+		var (
+			examplesOut []entities.Example
+			errOut      error
+		)
+		return examplesOut, errOut
+	}
+	return mock.ListExamplesFunc(ctx, params)
+}
+
+// ListExamplesCalls gets all the calls that were made to ListExamples.
+// Check the length with:
+//
+//	len(mockedRepository.ListExamplesCalls())
+func (mock *RepositoryMock) ListExamplesCalls() []struct {
+	Ctx    context.Context
+	Params entities.ListExamplesParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params entities.ListExamplesParams
+	}
+	mock.lockListExamples.RLock()
+	calls = mock.calls.ListExamples
+	mock.lockListExamples.RUnlock()
+	return calls
+}
+
+// UnarchiveExample calls UnarchiveExampleFunc.
+func (mock *RepositoryMock) UnarchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockUnarchiveExample.Lock()
+	mock.calls.UnarchiveExample = append(mock.calls.UnarchiveExample, callInfo)
+	mock.lockUnarchiveExample.Unlock()
+	if mock.UnarchiveExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.UnarchiveExampleFunc(ctx, id)
+}
+
+// UnarchiveExampleCalls gets all the calls that were made to UnarchiveExample.
+// Check the length with:
+//
+//	len(mockedRepository.UnarchiveExampleCalls())
+func (mock *RepositoryMock) UnarchiveExampleCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockUnarchiveExample.RLock()
+	calls = mock.calls.UnarchiveExample
+	mock.lockUnarchiveExample.RUnlock()
+	return calls
+}
+
+// UpdateExample calls UpdateExampleFunc.
+func (mock *RepositoryMock) UpdateExample(ctx context.Context, example entities.Example) (entities.Example, error) {
+	callInfo := struct {
+		Ctx     context.Context
+		Example entities.Example
+	}{
+		Ctx:     ctx,
+		Example: example,
+	}
+	mock.lockUpdateExample.Lock()
+	mock.calls.UpdateExample = append(mock.calls.UpdateExample, callInfo)
+	mock.lockUpdateExample.Unlock()
+	if mock.UpdateExampleFunc == nil {
+		var (
+			exampleOut entities.Example
+			errOut     error
+		)
+		return exampleOut, errOut
+	}
+	return mock.UpdateExampleFunc(ctx, example)
+}
+
+// UpdateExampleCalls gets all the calls that were made to UpdateExample.
+// Check the length with:
+//
+//	len(mockedRepository.UpdateExampleCalls())
+func (mock *RepositoryMock) UpdateExampleCalls() []struct {
+	Ctx     context.Context
+	Example entities.Example
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Example entities.Example
+	}
+	mock.lockUpdateExample.RLock()
+	calls = mock.calls.UpdateExample
+	mock.lockUpdateExample.RUnlock()
+	return calls
+}
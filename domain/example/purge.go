@@ -0,0 +1,58 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"log/slog"
+	"time"
+)
+
+const purgeBatchSize = 100
+
+// ArchivePurger deletes the examples that have been archived for longer
+// than the retention period.
+type ArchivePurger struct {
+	repo      Repository
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+func NewArchivePurger(repo Repository, retention time.Duration, logger *slog.Logger) *ArchivePurger {
+	return &ArchivePurger{
+		repo:      repo,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Run deletes one batch of examples archived before the retention period
+// and returns how many were deleted.
+func (p *ArchivePurger) Run(ctx context.Context) (int, error) {
+	n, err := p.repo.DeleteArchivedExamples(ctx, time.Now().UTC().Add(-p.retention), purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("purging archived examples: %w", err)
+	}
+	return n, nil
+}
+
+// Start runs the purge every interval until ctx is canceled.
+func (p *ArchivePurger) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := p.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, p.logger).Error("archived example purge failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, p.logger).Info("purged archived examples", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
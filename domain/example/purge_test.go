@@ -0,0 +1,38 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go-template/domain/example/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchivePurger_Run(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		DeleteArchivedExamplesFunc: func(ctx context.Context, archivedBefore time.Time, limit int32) (int, error) {
+			return 3, nil
+		},
+	}
+	p := NewArchivePurger(repo, 30*24*time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	n, err := p.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	calls := repo.DeleteArchivedExamplesCalls()
+	assert.Len(t, calls, 1)
+	assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), calls[0].ArchivedBefore, time.Minute)
+	assert.Equal(t, int32(purgeBatchSize), calls[0].Limit)
+
+	repo.DeleteArchivedExamplesFunc = func(ctx context.Context, archivedBefore time.Time, limit int32) (int, error) {
+		return 0, errors.New("db down")
+	}
+	_, err = p.Run(context.Background())
+	assert.Error(t, err)
+}
@@ -3,10 +3,31 @@ package example
 import (
 	"context"
 	"go-template/domain/entities"
+	"time"
 )
 
 //go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
 type Repository interface {
 	CreateExample(context.Context, entities.Example) (string, error)
 	GetExampleByID(context.Context, string) (entities.Example, error)
+	// ListExamples lists a page of examples by created_at, leaving out
+	// archived ones unless params.IncludeArchived is set.
+	ListExamples(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error)
+	CountExamples(ctx context.Context, includeArchived bool) (int64, error)
+	// UpdateExample updates the title and content, provided the example
+	// wasn't changed since the given UpdatedAt. It returns domain.ErrNotFound
+	// for missing examples and domain.ErrConflict for changed ones.
+	UpdateExample(ctx context.Context, example entities.Example) (entities.Example, error)
+	// DeleteExample deletes the example, provided it wasn't changed since
+	// expectedUpdatedAt when given. It returns the same errors as
+	// UpdateExample.
+	DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error
+	// ArchiveExample and UnarchiveExample return domain.ErrNotFound for
+	// missing examples. Archiving an archived example, or unarchiving one
+	// that isn't, leaves it as it is.
+	ArchiveExample(ctx context.Context, id string) (entities.Example, error)
+	UnarchiveExample(ctx context.Context, id string) (entities.Example, error)
+	// DeleteArchivedExamples deletes up to limit examples archived before
+	// archivedBefore and returns how many it deleted.
+	DeleteArchivedExamples(ctx context.Context, archivedBefore time.Time, limit int32) (int, error)
 }
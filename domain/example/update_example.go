@@ -0,0 +1,30 @@
+package example
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+)
+
+// UpdateExample replaces the example's title and content. input.UpdatedAt
+// is when the caller last read the example; if it was changed since, the
+// update is refused with domain.ErrConflict rather than overwrite it.
+func (uc UseCase) UpdateExample(ctx context.Context, input entities.Example) (entities.Example, error) {
+	if len(input.ID) == 0 {
+		return entities.Example{}, fmt.Errorf("missing id: %w", domain.ErrMalformedParameters)
+	}
+	if len(input.Title) == 0 {
+		return entities.Example{}, fmt.Errorf("missing title: %w", domain.ErrMalformedParameters)
+	}
+	if input.UpdatedAt.IsZero() {
+		return entities.Example{}, fmt.Errorf("missing updated_at: %w", domain.ErrMalformedParameters)
+	}
+
+	example, err := uc.R.UpdateExample(ctx, input)
+	if err != nil {
+		return entities.Example{}, fmt.Errorf("failed to update example: %w", err)
+	}
+
+	return example, nil
+}
@@ -0,0 +1,83 @@
+package example
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/example/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateExample(t *testing.T) {
+	readAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   entities.Example
+		mock    func(*mocks.RepositoryMock)
+		wantErr error
+	}{
+		{
+			name:  "success",
+			input: entities.Example{ID: "123", Title: "New Title", UpdatedAt: readAt},
+			mock: func(m *mocks.RepositoryMock) {
+				m.UpdateExampleFunc = func(ctx context.Context, input entities.Example) (entities.Example, error) {
+					input.UpdatedAt = readAt.Add(time.Minute)
+					return input, nil
+				}
+			},
+		},
+		{
+			name:    "empty id",
+			input:   entities.Example{Title: "New Title", UpdatedAt: readAt},
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "empty title",
+			input:   entities.Example{ID: "123", UpdatedAt: readAt},
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "missing updated_at",
+			input:   entities.Example{ID: "123", Title: "New Title"},
+			mock:    func(m *mocks.RepositoryMock) {},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:  "changed since read",
+			input: entities.Example{ID: "123", Title: "New Title", UpdatedAt: readAt},
+			mock: func(m *mocks.RepositoryMock) {
+				m.UpdateExampleFunc = func(ctx context.Context, input entities.Example) (entities.Example, error) {
+					return entities.Example{}, domain.ErrConflict
+				}
+			},
+			wantErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			tt.mock(repo)
+
+			uc := New(repo)
+			got, err := uc.UpdateExample(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input.Title, got.Title)
+				assert.True(t, got.UpdatedAt.After(readAt))
+				assert.Equal(t, readAt, repo.UpdateExampleCalls()[0].Example.UpdatedAt, "the read time is the expected version")
+			}
+		})
+	}
+}
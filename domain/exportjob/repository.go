@@ -0,0 +1,47 @@
+package exportjob
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"go-template/internal/export"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository UserExporter FileStorage Notifier
+
+type Repository interface {
+	CreateExportJob(ctx context.Context, job entities.ExportJob) error
+	// GetExportJob returns domain.ErrNotFound when there is no such job.
+	GetExportJob(ctx context.Context, id uuid.UUID) (entities.ExportJob, error)
+	// ClaimExportJob marks the oldest pending job, or a running one started
+	// before staleBefore, as running since now and returns it. Concurrent
+	// workers never claim the same job. It returns domain.ErrNotFound when
+	// there is nothing to do.
+	ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (entities.ExportJob, error)
+	CompleteExportJob(ctx context.Context, id uuid.UUID, fileKey string, rows int, finishedAt time.Time) error
+	FailExportJob(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error
+	// ListExpiredExportJobs returns up to limit finished jobs that finished
+	// before finishedBefore, oldest first.
+	ListExpiredExportJobs(ctx context.Context, finishedBefore time.Time, limit int32) ([]entities.ExportJob, error)
+	DeleteExportJob(ctx context.Context, id uuid.UUID) error
+}
+
+// UserExporter writes the users matching a filter to an export.
+type UserExporter interface {
+	ExportUsers(ctx context.Context, filter entities.UserFilter, w export.Writer) error
+}
+
+// FileStorage stores the exported files and links to them.
+type FileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// Notifier tells the creators of jobs that their export finished.
+type Notifier interface {
+	Notify(ctx context.Context, notification entities.Notification) (entities.Notification, error)
+}
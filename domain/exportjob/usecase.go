@@ -0,0 +1,310 @@
+package exportjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/export"
+	"go-template/internal/filter"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	batchSize = 100
+	// staleAfter is how long a job can run before another worker takes it
+	// over, assuming the one running it died.
+	staleAfter = time.Hour
+	// privatePrefix keeps the exported files from being served without a
+	// signed link.
+	privatePrefix = "private/"
+)
+
+// UseCase runs exports too large for a request in the background. Creating
+// a job returns at once; the worker writes the export to file storage,
+// where the job's signed link lets its creator download it. Jobs and their
+// files are removed once retention has passed since they finished.
+type UseCase struct {
+	repo      Repository
+	users     UserExporter
+	files     FileStorage
+	urlTTL    time.Duration
+	retention time.Duration
+	notifier  Notifier
+	logger    *slog.Logger
+	// wake starts the worker on a new job without waiting for the next tick
+	wake chan struct{}
+}
+
+func NewUseCase(repo Repository, users UserExporter, files FileStorage, urlTTL, retention time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:      repo,
+		users:     users,
+		files:     files,
+		urlTTL:    urlTTL,
+		retention: retention,
+		logger:    logger,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// SetNotifier notifies the creators of jobs once their export finished.
+func (uc *UseCase) SetNotifier(notifier Notifier) {
+	uc.notifier = notifier
+}
+
+// CreateRequest is what to export: the kind, users by default, the
+// format, CSV by default, and the parameters of the list to export.
+type CreateRequest struct {
+	Kind        entities.ExportKind `json:"kind,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	Search      string              `json:"search,omitempty"`
+	AccountType string              `json:"account_type,omitempty"`
+	Filter      string              `json:"filter,omitempty"`
+}
+
+// Create queues the export, made by the actor in ctx. Unknown kinds,
+// formats and filter expressions are domain.ErrMalformedParameters.
+func (uc *UseCase) Create(ctx context.Context, req CreateRequest) (entities.ExportJob, error) {
+	if req.Kind == "" {
+		req.Kind = entities.ExportKindUsers
+	}
+	if req.Kind != entities.ExportKindUsers {
+		return entities.ExportJob{}, fmt.Errorf("%w: unsupported export kind %q", domain.ErrMalformedParameters, req.Kind)
+	}
+	format, err := export.ParseFormat(req.Format)
+	if err != nil {
+		return entities.ExportJob{}, fmt.Errorf("%w: %v", domain.ErrMalformedParameters, err)
+	}
+	if _, err := filter.Parse(req.Filter, entities.UserFilterFields); err != nil {
+		return entities.ExportJob{}, fmt.Errorf("%w: %v", domain.ErrMalformedParameters, err)
+	}
+
+	job := entities.ExportJob{
+		ID:          uuid.Must(uuid.NewV4()),
+		Kind:        req.Kind,
+		Format:      string(format),
+		Search:      strings.TrimSpace(req.Search),
+		AccountType: req.AccountType,
+		Filter:      req.Filter,
+		Status:      entities.ExportJobPending,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		job.CreatedBy = &actor
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: export job not created", "kind", job.Kind, "format", job.Format)
+		return job, nil
+	}
+
+	if err := uc.repo.CreateExportJob(ctx, job); err != nil {
+		return entities.ExportJob{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "export job created", "audit", true, "resource", "export_job", "resource_id", job.ID, "kind", job.Kind, "format", job.Format)
+
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Get returns the job, or domain.ErrNotFound. A succeeded job comes with a
+// fresh download link.
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (entities.ExportJob, error) {
+	job, err := uc.repo.GetExportJob(ctx, id)
+	if err != nil {
+		return entities.ExportJob{}, err
+	}
+	if job.Status != entities.ExportJobSucceeded {
+		return job, nil
+	}
+
+	expiresAt := time.Now().UTC().Add(uc.urlTTL)
+	url, err := uc.files.SignedURL(job.FileKey, uc.urlTTL)
+	if err != nil {
+		return entities.ExportJob{}, fmt.Errorf("signing export download link: %w", err)
+	}
+	job.DownloadURL = url
+	job.DownloadURLExpiresAt = &expiresAt
+	return job, nil
+}
+
+// Run removes the expired jobs and their files, then runs the queued jobs
+// one at a time until none are left. It returns how many jobs it ran,
+// succeeded or failed.
+func (uc *UseCase) Run(ctx context.Context) (int, error) {
+	if err := uc.purge(ctx); err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		job, err := uc.repo.ClaimExportJob(ctx, now, now.Add(-staleAfter))
+		if errors.Is(err, domain.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return done, fmt.Errorf("claiming export job: %w", err)
+		}
+
+		key, rows, err := uc.process(ctx, job)
+		finishedAt := time.Now().UTC()
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("export job failed", "export_job_id", job.ID, "error", err)
+			if err := uc.repo.FailExportJob(ctx, job.ID, "export failed", finishedAt); err != nil {
+				return done, err
+			}
+			uc.notify(ctx, job, entities.Notification{
+				Kind:  entities.NotificationExportFailed,
+				Title: "Your export failed",
+				Body:  fmt.Sprintf("The %s export you asked for could not be made. Try again, or contact support if it keeps failing.", job.Kind),
+			})
+		} else {
+			if err := uc.repo.CompleteExportJob(ctx, job.ID, key, rows, finishedAt); err != nil {
+				return done, err
+			}
+			uc.notify(ctx, job, entities.Notification{
+				Kind:  entities.NotificationExportReady,
+				Title: "Your export is ready",
+				Body:  fmt.Sprintf("The %s export you asked for is ready to download, with %d rows.", job.Kind, rows),
+			})
+		}
+		done++
+	}
+
+	return done, nil
+}
+
+// notify tells the job's creator it finished. The job stands either way,
+// so failures are only logged.
+func (uc *UseCase) notify(ctx context.Context, job entities.ExportJob, n entities.Notification) {
+	if uc.notifier == nil || job.CreatedBy == nil {
+		return
+	}
+	n.UserID = *job.CreatedBy
+	if _, err := uc.notifier.Notify(ctx, n); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to notify export job creator", "export_job_id", job.ID, "error", err)
+	}
+}
+
+// process writes the job's export to file storage and returns the file's
+// key and how many rows it holds. The export is piped into storage as it is
+// produced rather than held in memory.
+func (uc *UseCase) process(ctx context.Context, job entities.ExportJob) (string, int, error) {
+	format, err := export.ParseFormat(job.Format)
+	if err != nil {
+		return "", 0, err
+	}
+	expr, err := filter.Parse(job.Filter, entities.UserFilterFields)
+	if err != nil {
+		return "", 0, err
+	}
+	userFilter := entities.UserFilter{
+		Search:      job.Search,
+		AccountType: entities.AccountType(job.AccountType),
+		Expr:        expr,
+	}
+	if job.CreatedBy != nil {
+		ctx = domain.WithActor(ctx, *job.CreatedBy)
+	}
+
+	type result struct {
+		rows int
+		err  error
+	}
+	pr, pw := io.Pipe()
+	exported := make(chan result, 1)
+	go func() {
+		w, err := export.NewWriter(pw, format)
+		if err != nil {
+			pw.CloseWithError(err)
+			exported <- result{err: err}
+			return
+		}
+		counter := &countingWriter{Writer: w}
+		err = uc.users.ExportUsers(ctx, userFilter, counter)
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+		// The first row names the columns
+		exported <- result{rows: max(counter.rows-1, 0), err: err}
+	}()
+
+	key := fmt.Sprintf("%sexports/%s.%s", privatePrefix, job.ID, format)
+	putErr := uc.files.Put(ctx, key, pr, format.ContentType())
+	// Unblocks the export if storage stopped reading early
+	pr.CloseWithError(io.ErrClosedPipe)
+	res := <-exported
+	if res.err != nil {
+		return "", 0, res.err
+	}
+	if putErr != nil {
+		return "", 0, putErr
+	}
+	return key, res.rows, nil
+}
+
+// purge removes the jobs that finished before the retention period, and
+// their files.
+func (uc *UseCase) purge(ctx context.Context) error {
+	jobs, err := uc.repo.ListExpiredExportJobs(ctx, time.Now().UTC().Add(-uc.retention), batchSize)
+	if err != nil {
+		return fmt.Errorf("listing expired export jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if job.FileKey != "" {
+			if err := uc.files.Delete(ctx, job.FileKey); err != nil {
+				domain.LoggerOr(ctx, uc.logger).Error("failed to delete export file", "export_job_id", job.ID, "error", err)
+				continue
+			}
+		}
+		if err := uc.repo.DeleteExportJob(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start runs the job every interval, and whenever a job is created, until
+// ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("export job run failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("ran export jobs", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+	}
+}
+
+// countingWriter counts the rows written through it.
+type countingWriter struct {
+	export.Writer
+	rows int
+}
+
+func (c *countingWriter) Write(row []string) error {
+	c.rows++
+	return c.Writer.Write(row)
+}
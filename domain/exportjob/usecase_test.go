@@ -0,0 +1,174 @@
+package exportjob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/exportjob/mocks"
+	"go-template/internal/export"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, users UserExporter, files FileStorage) *UseCase {
+	return NewUseCase(repo, users, files, 15*time.Minute, 24*time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Create(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo, &mocks.UserExporterMock{}, &mocks.FileStorageMock{})
+	ctx := domain.WithActor(context.Background(), actor)
+
+	job, err := uc.Create(ctx, CreateRequest{Search: " ada ", Filter: "status:active"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExportKindUsers, job.Kind)
+	assert.Equal(t, "csv", job.Format)
+	assert.Equal(t, "ada", job.Search)
+	assert.Equal(t, entities.ExportJobPending, job.Status)
+	require.NotNil(t, job.CreatedBy)
+	assert.Equal(t, actor, *job.CreatedBy)
+	require.Len(t, repo.CreateExportJobCalls(), 1)
+	assert.Equal(t, job, repo.CreateExportJobCalls()[0].Job)
+	assert.Len(t, uc.wake, 1, "the worker is woken")
+
+	for name, bad := range map[string]CreateRequest{
+		"kind":   {Kind: "orders"},
+		"format": {Format: "pdf"},
+		"filter": {Filter: "nope:1"},
+	} {
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := uc.Create(ctx, bad)
+			assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+		})
+	}
+
+	t.Run("dry run stores nothing", func(t *testing.T) {
+		_, err := uc.Create(domain.WithDryRun(ctx), CreateRequest{Format: "xlsx"})
+		require.NoError(t, err)
+		assert.Len(t, repo.CreateExportJobCalls(), 1)
+	})
+}
+
+func TestUseCase_Get(t *testing.T) {
+	jobs := map[uuid.UUID]entities.ExportJob{}
+	repo := &mocks.RepositoryMock{
+		GetExportJobFunc: func(ctx context.Context, id uuid.UUID) (entities.ExportJob, error) {
+			job, ok := jobs[id]
+			if !ok {
+				return entities.ExportJob{}, domain.ErrNotFound
+			}
+			return job, nil
+		},
+	}
+	files := &mocks.FileStorageMock{
+		SignedURLFunc: func(key string, ttl time.Duration) (string, error) {
+			return "/files/" + key + "?signature=x", nil
+		},
+	}
+	uc := newTestUseCase(repo, &mocks.UserExporterMock{}, files)
+
+	pending := entities.ExportJob{ID: uuid.Must(uuid.NewV4()), Status: entities.ExportJobPending}
+	done := entities.ExportJob{ID: uuid.Must(uuid.NewV4()), Status: entities.ExportJobSucceeded, FileKey: "private/exports/e.csv"}
+	jobs[pending.ID], jobs[done.ID] = pending, done
+
+	job, err := uc.Get(context.Background(), pending.ID)
+	require.NoError(t, err)
+	assert.Empty(t, job.DownloadURL, "no link until the export succeeded")
+
+	job, err = uc.Get(context.Background(), done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/files/private/exports/e.csv?signature=x", job.DownloadURL)
+	require.NotNil(t, job.DownloadURLExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), *job.DownloadURLExpiresAt, time.Minute)
+	assert.Equal(t, 15*time.Minute, files.SignedURLCalls()[0].TTL)
+
+	_, err = uc.Get(context.Background(), uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUseCase_Run(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	ok := entities.ExportJob{ID: uuid.Must(uuid.NewV4()), Kind: entities.ExportKindUsers, Format: "csv", Filter: "status:active", CreatedBy: &actor}
+	broken := entities.ExportJob{ID: uuid.Must(uuid.NewV4()), Kind: entities.ExportKindUsers, Format: "csv"}
+	expired := entities.ExportJob{ID: uuid.Must(uuid.NewV4()), FileKey: "private/exports/old.csv"}
+
+	queue := []entities.ExportJob{ok, broken}
+	repo := &mocks.RepositoryMock{
+		ListExpiredExportJobsFunc: func(ctx context.Context, finishedBefore time.Time, limit int32) ([]entities.ExportJob, error) {
+			return []entities.ExportJob{expired}, nil
+		},
+		ClaimExportJobFunc: func(ctx context.Context, now, staleBefore time.Time) (entities.ExportJob, error) {
+			if len(queue) == 0 {
+				return entities.ExportJob{}, domain.ErrNotFound
+			}
+			job := queue[0]
+			queue = queue[1:]
+			return job, nil
+		},
+	}
+	users := &mocks.UserExporterMock{
+		ExportUsersFunc: func(ctx context.Context, filter entities.UserFilter, w export.Writer) error {
+			if len(filter.Expr) == 0 {
+				return errors.New("database is down")
+			}
+			if actorID, _ := domain.ActorFromContext(ctx); actorID != actor {
+				return errors.New("export not made as the job's creator")
+			}
+			for _, row := range [][]string{{"email"}, {"a@example.com"}, {"b@example.com"}} {
+				if err := w.Write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	stored := map[string]string{}
+	files := &mocks.FileStorageMock{
+		PutFunc: func(ctx context.Context, key string, r io.Reader, contentType string) error {
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				return err
+			}
+			stored[key] = buf.String()
+			return nil
+		},
+	}
+	notifier := &mocks.NotifierMock{}
+	uc := newTestUseCase(repo, users, files)
+	uc.SetNotifier(notifier)
+
+	n, err := uc.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	key := "private/exports/" + ok.ID.String() + ".csv"
+	assert.Equal(t, "email\na@example.com\nb@example.com\n", stored[key])
+	require.Len(t, repo.CompleteExportJobCalls(), 1)
+	assert.Equal(t, ok.ID, repo.CompleteExportJobCalls()[0].ID)
+	assert.Equal(t, key, repo.CompleteExportJobCalls()[0].FileKey)
+	assert.Equal(t, 2, repo.CompleteExportJobCalls()[0].Rows)
+
+	require.Len(t, repo.FailExportJobCalls(), 1)
+	assert.Equal(t, broken.ID, repo.FailExportJobCalls()[0].ID)
+	assert.NotContains(t, repo.FailExportJobCalls()[0].Message, "database", "internal errors stay in the logs")
+	assert.NotContains(t, stored, "private/exports/"+broken.ID.String()+".csv")
+
+	// Jobs made without a creator, such as broken, notify nobody
+	require.Len(t, notifier.NotifyCalls(), 1)
+	assert.Equal(t, actor, notifier.NotifyCalls()[0].Notification.UserID)
+	assert.Equal(t, entities.NotificationExportReady, notifier.NotifyCalls()[0].Notification.Kind)
+
+	require.Len(t, files.DeleteCalls(), 1)
+	assert.Equal(t, expired.FileKey, files.DeleteCalls()[0].Key)
+	require.Len(t, repo.DeleteExportJobCalls(), 1)
+	assert.Equal(t, expired.ID, repo.DeleteExportJobCalls()[0].ID)
+}
@@ -0,0 +1,140 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrEmailInvitesDisabled is returned by Invite when the application sends
+// no email.
+var ErrEmailInvitesDisabled = errors.New("email invitations are not available")
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/email_sender.go . EmailSender
+
+// EmailSender delivers transactional emails, rendered from the email's
+// template.
+type EmailSender interface {
+	SendEmail(ctx context.Context, email entities.Email) error
+}
+
+// InviteRequest describes an invitation emailed to Email. AccountType
+// defaults to a regular user, and invitations without ExpiresAt expire after
+// DefaultTTL.
+type InviteRequest struct {
+	Email       string               `json:"email" validate:"required,email"`
+	AccountType entities.AccountType `json:"account_type"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	Note        string               `json:"note,omitempty"`
+}
+
+type emailInvites struct {
+	email     EmailSender
+	acceptURL string
+}
+
+// SetEmailInvites lets admins email invitations with Invite. The emailed
+// link opens acceptURL with the code as the token query parameter.
+func (uc *UseCase) SetEmailInvites(email EmailSender, acceptURL string) {
+	uc.emailInvites = &emailInvites{email: email, acceptURL: acceptURL}
+}
+
+// Invite emails req.Email a link to accept an invitation made by the admin
+// adminID, where they choose their password. The invitation can be used
+// once, and only by that address. In a dry run nothing is stored or sent.
+func (uc *UseCase) Invite(ctx context.Context, adminID uuid.UUID, req InviteRequest) (entities.Invitation, error) {
+	if uc.emailInvites == nil {
+		return entities.Invitation{}, ErrEmailInvitesDisabled
+	}
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return entities.Invitation{}, fmt.Errorf("email is required: %w", domain.ErrMalformedParameters)
+	}
+
+	once := 1
+	invitation, plain, err := uc.newInvitation(adminID, CreateRequest{
+		AccountType: req.AccountType,
+		MaxUses:     &once,
+		ExpiresAt:   req.ExpiresAt,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+	invitation.Email = email
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: invitation not sent", "admin_id", adminID, "account_type", invitation.AccountType)
+		return invitation, nil
+	}
+
+	link, err := acceptLink(uc.emailInvites.acceptURL, plain)
+	if err != nil {
+		return entities.Invitation{}, err
+	}
+
+	if err := uc.repo.CreateInvitation(ctx, invitation); err != nil {
+		return entities.Invitation{}, fmt.Errorf("creating invitation: %w", err)
+	}
+
+	err = uc.emailInvites.email.SendEmail(ctx, entities.Email{
+		To:       email,
+		Template: entities.EmailInvitation,
+		Data: map[string]any{
+			"Link":      link,
+			"ExpiresAt": invitation.ExpiresAt,
+		},
+	})
+	if err != nil {
+		// Nobody got the code, so it shouldn't stay usable
+		if revokeErr := uc.repo.RevokeInvitation(ctx, invitation.ID); revokeErr != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to revoke unsent invitation", "invitation_id", invitation.ID, "error", revokeErr)
+		}
+		return entities.Invitation{}, fmt.Errorf("sending invitation email: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("invitation sent", "audit", true, "admin_id", adminID,
+		"resource", entities.AuditResourceInvitation, "resource_id", invitation.ID, "account_type", invitation.AccountType, "email", email)
+	return invitation, nil
+}
+
+// EmailInvitation returns the active invitation that was emailed with the
+// code plain, so the invitee can be told which address they are signing up
+// with. It returns ErrInvalidCode for any other code.
+func (uc *UseCase) EmailInvitation(ctx context.Context, plain string) (entities.Invitation, error) {
+	plain = normalizeCode(plain)
+	if plain == "" {
+		return entities.Invitation{}, ErrInvalidCode
+	}
+
+	invitation, err := uc.repo.GetInvitationByCodeHash(ctx, hashCode(plain))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.Invitation{}, ErrInvalidCode
+		}
+		return entities.Invitation{}, fmt.Errorf("getting invitation: %w", err)
+	}
+	if invitation.Email == "" || !invitation.Active(uc.now()) {
+		return entities.Invitation{}, ErrInvalidCode
+	}
+	return invitation, nil
+}
+
+// acceptLink adds code to pageURL as the token query parameter.
+func acceptLink(pageURL, code string) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid invitation url: %w", err)
+	}
+	q := u.Query()
+	q.Set("token", code)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,32 @@
+package invitation
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	CreateInvitation(ctx context.Context, invitation entities.Invitation) error
+	// GetInvitation returns domain.ErrNotFound for unknown invitations.
+	GetInvitation(ctx context.Context, id uuid.UUID) (entities.Invitation, error)
+	// GetInvitationByCodeHash returns domain.ErrNotFound when no invitation
+	// has the code hash.
+	GetInvitationByCodeHash(ctx context.Context, codeHash string) (entities.Invitation, error)
+	// ListInvitations returns used up, expired and revoked invitations too,
+	// newest first.
+	ListInvitations(ctx context.Context) ([]entities.Invitation, error)
+	// RedeemInvitation uses up one use of the active invitation with the
+	// code hash for a user registering as email, returning
+	// domain.ErrNotFound when there is none or the invitation was sent to
+	// another address. Addresses are compared regardless of case.
+	RedeemInvitation(ctx context.Context, codeHash, email string) (entities.Invitation, error)
+	// ReleaseInvitation gives back a use taken by RedeemInvitation.
+	ReleaseInvitation(ctx context.Context, id uuid.UUID) error
+	// RevokeInvitation returns domain.ErrNotFound for unknown invitations and
+	// invitations that were already revoked.
+	RevokeInvitation(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,205 @@
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// DefaultTTL is how long invitations created without an expiry last
+	DefaultTTL = 7 * 24 * time.Hour
+
+	// displayPrefixLength is how much of a code is kept to tell codes apart
+	displayPrefixLength = 4
+
+	maxNoteLength = 255
+)
+
+// ErrInvalidCode is returned by Redeem for codes that are unknown, used up,
+// expired or revoked.
+var ErrInvalidCode = errors.New("invalid invitation code")
+
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CreateRequest describes a new invitation. Invitations without MaxUses can
+// be used any number of times, and those without ExpiresAt expire after
+// DefaultTTL. AccountType defaults to a regular user.
+type CreateRequest struct {
+	AccountType entities.AccountType `json:"account_type"`
+	MaxUses     *int                 `json:"max_uses,omitempty"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	Note        string               `json:"note,omitempty"`
+}
+
+// UseCase manages the invitation codes admins hand out so people can
+// register while registration is closed.
+type UseCase struct {
+	repo         Repository
+	logger       *slog.Logger
+	now          func() time.Time
+	emailInvites *emailInvites
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Create stores a new invitation made by the admin adminID and returns it
+// together with the plain-text code, which is not stored and can't be
+// retrieved again. In a dry run nothing is stored and no code is returned.
+func (uc *UseCase) Create(ctx context.Context, adminID uuid.UUID, req CreateRequest) (entities.Invitation, string, error) {
+	invitation, plain, err := uc.newInvitation(adminID, req)
+	if err != nil {
+		return entities.Invitation{}, "", err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: invitation not created", "admin_id", adminID, "account_type", invitation.AccountType)
+		return invitation, "", nil
+	}
+
+	if err := uc.repo.CreateInvitation(ctx, invitation); err != nil {
+		return entities.Invitation{}, "", fmt.Errorf("creating invitation: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("invitation created", "audit", true, "admin_id", adminID,
+		"resource", entities.AuditResourceInvitation, "resource_id", invitation.ID, "account_type", invitation.AccountType)
+	return invitation, plain, nil
+}
+
+// newInvitation validates req and returns the invitation it describes with
+// its plain-text code.
+func (uc *UseCase) newInvitation(adminID uuid.UUID, req CreateRequest) (entities.Invitation, string, error) {
+	accountType := req.AccountType
+	switch accountType {
+	case "":
+		accountType = entities.AccountTypeUser
+	case entities.AccountTypeUser, entities.AccountTypeAdmin, entities.AccountTypeSuperAdmin:
+	default:
+		return entities.Invitation{}, "", fmt.Errorf("unknown account type %q: %w", accountType, domain.ErrMalformedParameters)
+	}
+	if req.MaxUses != nil && *req.MaxUses < 1 {
+		return entities.Invitation{}, "", fmt.Errorf("max uses must be at least 1: %w", domain.ErrMalformedParameters)
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > maxNoteLength {
+		return entities.Invitation{}, "", fmt.Errorf("note is longer than %d characters: %w", maxNoteLength, domain.ErrMalformedParameters)
+	}
+	now := uc.now()
+	expiresAt := now.Add(DefaultTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return entities.Invitation{}, "", fmt.Errorf("expiry must be in the future: %w", domain.ErrMalformedParameters)
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	plain, err := generateCode()
+	if err != nil {
+		return entities.Invitation{}, "", err
+	}
+
+	return entities.Invitation{
+		ID:          uuid.Must(uuid.NewV4()),
+		Prefix:      plain[:displayPrefixLength],
+		CodeHash:    hashCode(plain),
+		AccountType: accountType,
+		MaxUses:     req.MaxUses,
+		Note:        note,
+		CreatedBy:   &adminID,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+	}, plain, nil
+}
+
+// List returns every invitation, newest first.
+func (uc *UseCase) List(ctx context.Context) ([]entities.Invitation, error) {
+	return uc.repo.ListInvitations(ctx)
+}
+
+// Revoke stops an invitation from being used on behalf of the admin adminID.
+// Users who already registered with it are kept.
+func (uc *UseCase) Revoke(ctx context.Context, id, adminID uuid.UUID) error {
+	invitation, err := uc.repo.GetInvitation(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting invitation: %w", err)
+	}
+	if invitation.RevokedAt != nil {
+		return nil
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: invitation not revoked", "admin_id", adminID, "invitation_id", id)
+		return nil
+	}
+
+	if err := uc.repo.RevokeInvitation(ctx, id); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("revoking invitation: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("invitation revoked", "audit", true, "admin_id", adminID, "resource", entities.AuditResourceInvitation, "resource_id", id)
+	return nil
+}
+
+// Redeem uses up one use of the invitation plain is the code of, for a user
+// registering as email. Invitations sent to an address can't be used by
+// anyone else. Codes are matched regardless of case and surrounding spaces.
+func (uc *UseCase) Redeem(ctx context.Context, plain, email string) (entities.Invitation, error) {
+	plain = normalizeCode(plain)
+	if plain == "" {
+		return entities.Invitation{}, ErrInvalidCode
+	}
+
+	invitation, err := uc.repo.RedeemInvitation(ctx, hashCode(plain), email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.Invitation{}, ErrInvalidCode
+		}
+		return entities.Invitation{}, fmt.Errorf("redeeming invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// Release gives back the use Redeem took, for registrations that failed.
+func (uc *UseCase) Release(ctx context.Context, id uuid.UUID) error {
+	if err := uc.repo.ReleaseInvitation(ctx, id); err != nil {
+		return fmt.Errorf("releasing invitation: %w", err)
+	}
+	return nil
+}
+
+// normalizeCode undoes the changes people make to a code when typing it.
+func normalizeCode(plain string) string {
+	return strings.ToUpper(strings.TrimSpace(plain))
+}
+
+// generateCode returns a code that is easy to read out and type: 16
+// characters of upper case letters and digits.
+func generateCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating invitation code: %w", err)
+	}
+	return codeEncoding.EncodeToString(b), nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,265 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/invitation/mocks"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Create(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+	zero := 0
+	five := 5
+
+	tests := []struct {
+		name        string
+		req         CreateRequest
+		wantErr     error
+		accountType entities.AccountType
+		expiresAt   time.Time
+	}{
+		{
+			name:        "defaults to a user invitation expiring after DefaultTTL",
+			req:         CreateRequest{Note: "  for the design team "},
+			accountType: entities.AccountTypeUser,
+			expiresAt:   now.Add(DefaultTTL),
+		},
+		{
+			name:        "keeps the account type, uses and expiry asked for",
+			req:         CreateRequest{AccountType: entities.AccountTypeAdmin, MaxUses: &five, ExpiresAt: &later},
+			accountType: entities.AccountTypeAdmin,
+			expiresAt:   later,
+		},
+		{
+			name:    "rejects unknown account types",
+			req:     CreateRequest{AccountType: "owner"},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects less than one use",
+			req:     CreateRequest{MaxUses: &zero},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects expiries in the past",
+			req:     CreateRequest{ExpiresAt: &past},
+			wantErr: domain.ErrMalformedParameters,
+		},
+		{
+			name:    "rejects long notes",
+			req:     CreateRequest{Note: strings.Repeat("a", maxNoteLength+1)},
+			wantErr: domain.ErrMalformedParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo)
+			uc.now = func() time.Time { return now }
+
+			invitation, plain, err := uc.Create(context.Background(), adminID, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.CreateInvitationCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.CreateInvitationCalls(), 1)
+
+			stored := repo.CreateInvitationCalls()[0].Invitation
+			assert.Len(t, plain, 16)
+			assert.True(t, strings.HasPrefix(plain, stored.Prefix))
+			assert.Equal(t, hashCode(plain), stored.CodeHash)
+			assert.Equal(t, tt.accountType, stored.AccountType)
+			assert.Equal(t, tt.req.MaxUses, stored.MaxUses)
+			assert.Equal(t, tt.expiresAt, stored.ExpiresAt)
+			assert.Equal(t, strings.TrimSpace(tt.req.Note), stored.Note)
+			assert.Equal(t, &adminID, stored.CreatedBy)
+			assert.Equal(t, stored, invitation)
+		})
+	}
+}
+
+func TestUseCase_Create_DryRun(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo)
+
+	invitation, plain, err := uc.Create(domain.WithDryRun(context.Background()), uuid.Must(uuid.NewV4()), CreateRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, plain)
+	assert.Equal(t, entities.AccountTypeUser, invitation.AccountType)
+	assert.Empty(t, repo.CreateInvitationCalls())
+}
+
+func TestUseCase_Redeem(t *testing.T) {
+	plain, err := generateCode()
+	require.NoError(t, err)
+	invitation := entities.Invitation{ID: uuid.Must(uuid.NewV4()), AccountType: entities.AccountTypeAdmin}
+
+	repo := &mocks.RepositoryMock{
+		RedeemInvitationFunc: func(ctx context.Context, codeHash, email string) (entities.Invitation, error) {
+			if codeHash != hashCode(plain) {
+				return entities.Invitation{}, domain.ErrNotFound
+			}
+			return invitation, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	got, err := uc.Redeem(context.Background(), " "+strings.ToLower(plain)+"\n", "a@x.com")
+	require.NoError(t, err)
+	assert.Equal(t, invitation, got)
+	assert.Equal(t, "a@x.com", repo.RedeemInvitationCalls()[0].Email)
+
+	_, err = uc.Redeem(context.Background(), "NOTACODE", "a@x.com")
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = uc.Redeem(context.Background(), "  ", "a@x.com")
+	assert.ErrorIs(t, err, ErrInvalidCode)
+	assert.Len(t, repo.RedeemInvitationCalls(), 2)
+}
+
+func TestUseCase_Revoke(t *testing.T) {
+	invitation := entities.Invitation{ID: uuid.Must(uuid.NewV4())}
+	repo := &mocks.RepositoryMock{
+		GetInvitationFunc: func(ctx context.Context, id uuid.UUID) (entities.Invitation, error) {
+			if id != invitation.ID {
+				return entities.Invitation{}, domain.ErrNotFound
+			}
+			return invitation, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+	adminID := uuid.Must(uuid.NewV4())
+
+	err := uc.Revoke(context.Background(), uuid.Must(uuid.NewV4()), adminID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, uc.Revoke(domain.WithDryRun(context.Background()), invitation.ID, adminID))
+	assert.Empty(t, repo.RevokeInvitationCalls())
+
+	require.NoError(t, uc.Revoke(context.Background(), invitation.ID, adminID))
+	require.Len(t, repo.RevokeInvitationCalls(), 1)
+	assert.Equal(t, invitation.ID, repo.RevokeInvitationCalls()[0].ID)
+}
+
+func TestUseCase_Invite(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	var stored entities.Invitation
+	repo := &mocks.RepositoryMock{
+		CreateInvitationFunc: func(ctx context.Context, invitation entities.Invitation) error {
+			stored = invitation
+			return nil
+		},
+	}
+	email := &mocks.EmailSenderMock{}
+	uc := newTestUseCase(repo)
+
+	_, err := uc.Invite(context.Background(), adminID, InviteRequest{Email: "new@x.com"})
+	assert.ErrorIs(t, err, ErrEmailInvitesDisabled)
+
+	uc.SetEmailInvites(email, "https://app.example.com/accept-invitation")
+
+	_, err = uc.Invite(context.Background(), adminID, InviteRequest{Email: " "})
+	assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+
+	_, err = uc.Invite(domain.WithDryRun(context.Background()), adminID, InviteRequest{Email: "new@x.com"})
+	require.NoError(t, err)
+	assert.Empty(t, repo.CreateInvitationCalls())
+	assert.Empty(t, email.SendEmailCalls())
+
+	invitation, err := uc.Invite(context.Background(), adminID, InviteRequest{Email: " new@x.com ", AccountType: entities.AccountTypeAdmin})
+	require.NoError(t, err)
+	assert.Equal(t, "new@x.com", invitation.Email)
+	assert.Equal(t, entities.AccountTypeAdmin, invitation.AccountType)
+	require.NotNil(t, invitation.MaxUses)
+	assert.Equal(t, 1, *invitation.MaxUses)
+	assert.Equal(t, invitation, stored)
+
+	// The emailed link carries the code the invitation was stored with
+	require.Len(t, email.SendEmailCalls(), 1)
+	sent := email.SendEmailCalls()[0].Email
+	assert.Equal(t, "new@x.com", sent.To)
+	assert.Equal(t, entities.EmailInvitation, sent.Template)
+	assert.Equal(t, invitation.ExpiresAt, sent.Data["ExpiresAt"])
+	link, _ := sent.Data["Link"].(string)
+	code, ok := strings.CutPrefix(link, "https://app.example.com/accept-invitation?token=")
+	require.True(t, ok, link)
+	assert.Equal(t, stored.CodeHash, hashCode(code))
+}
+
+func TestUseCase_Invite_SendFails(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	email := &mocks.EmailSenderMock{
+		SendEmailFunc: func(ctx context.Context, e entities.Email) error {
+			return errors.New("smtp down")
+		},
+	}
+	uc := newTestUseCase(repo)
+	uc.SetEmailInvites(email, "https://app.example.com/accept-invitation")
+
+	_, err := uc.Invite(context.Background(), uuid.Must(uuid.NewV4()), InviteRequest{Email: "new@x.com"})
+	require.Error(t, err)
+	require.Len(t, repo.CreateInvitationCalls(), 1)
+	require.Len(t, repo.RevokeInvitationCalls(), 1)
+	assert.Equal(t, repo.CreateInvitationCalls()[0].Invitation.ID, repo.RevokeInvitationCalls()[0].ID)
+}
+
+func TestUseCase_EmailInvitation(t *testing.T) {
+	now := time.Now()
+	invitations := map[string]entities.Invitation{
+		"SENT":    {Email: "new@x.com", ExpiresAt: now.Add(time.Hour)},
+		"CODE":    {ExpiresAt: now.Add(time.Hour)},
+		"EXPIRED": {Email: "old@x.com", ExpiresAt: now.Add(-time.Hour)},
+	}
+	repo := &mocks.RepositoryMock{
+		GetInvitationByCodeHashFunc: func(ctx context.Context, codeHash string) (entities.Invitation, error) {
+			for code, invitation := range invitations {
+				if hashCode(code) == codeHash {
+					return invitation, nil
+				}
+			}
+			return entities.Invitation{}, domain.ErrNotFound
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	got, err := uc.EmailInvitation(context.Background(), "sent")
+	require.NoError(t, err)
+	assert.Equal(t, "new@x.com", got.Email)
+
+	for _, code := range []string{"CODE", "EXPIRED", "UNKNOWN", ""} {
+		_, err := uc.EmailInvitation(context.Background(), code)
+		assert.ErrorIs(t, err, ErrInvalidCode, code)
+	}
+}
+
+func TestInvitation_Active(t *testing.T) {
+	now := time.Now()
+	one := 1
+
+	assert.True(t, entities.Invitation{ExpiresAt: now.Add(time.Hour)}.Active(now))
+	assert.True(t, entities.Invitation{ExpiresAt: now.Add(time.Hour), MaxUses: &one}.Active(now))
+	assert.False(t, entities.Invitation{ExpiresAt: now.Add(time.Hour), MaxUses: &one, Uses: 1}.Active(now))
+	assert.False(t, entities.Invitation{ExpiresAt: now}.Active(now))
+	assert.False(t, entities.Invitation{ExpiresAt: now.Add(time.Hour), RevokedAt: &now}.Active(now))
+}
@@ -0,0 +1,127 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// List returns a page of the jobs matching filter, newest first, so admins
+// can follow the background work.
+func (q *Queue) List(ctx context.Context, filter entities.JobFilter) (entities.JobListResponse, error) {
+	if filter.Status != "" && !slices.Contains(entities.JobStatuses, filter.Status) {
+		return entities.JobListResponse{}, fmt.Errorf("%w: unknown job status %q", domain.ErrMalformedParameters, filter.Status)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	jobs, err := q.repo.ListJobs(ctx, filter.Status, filter.Kind, int32(filter.PageSize), int32((filter.Page-1)*filter.PageSize))
+	if err != nil {
+		return entities.JobListResponse{}, err
+	}
+	total, err := q.repo.CountJobs(ctx, filter.Status, filter.Kind)
+	if err != nil {
+		return entities.JobListResponse{}, err
+	}
+	counts, err := q.repo.CountJobsByStatus(ctx)
+	if err != nil {
+		return entities.JobListResponse{}, err
+	}
+
+	if jobs == nil {
+		jobs = []entities.Job{}
+	}
+	for _, status := range entities.JobStatuses {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	return entities.JobListResponse{
+		Jobs:       jobs,
+		Counts:     counts,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+	}, nil
+}
+
+// Get returns the job, or domain.ErrNotFound.
+func (q *Queue) Get(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+	return q.repo.GetJob(ctx, id)
+}
+
+// Requeue queues a dead or canceled job again, due at once and with all
+// its attempts; its last error is kept until it runs. Other jobs are left
+// as they are, with domain.ErrConflict.
+func (q *Queue) Requeue(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+	job, err := q.repo.GetJob(ctx, id)
+	if err != nil {
+		return entities.Job{}, err
+	}
+	if job.Status != entities.JobDead && job.Status != entities.JobCanceled {
+		return entities.Job{}, fmt.Errorf("%w: only dead or canceled jobs can be requeued, job is %s", domain.ErrConflict, job.Status)
+	}
+
+	now := time.Now().UTC()
+	job.Status = entities.JobQueued
+	job.Attempts = 0
+	job.RunAt = now
+	job.StartedAt = nil
+	job.FinishedAt = nil
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, q.logger).InfoContext(ctx, "dry run: job not requeued", "job_id", id)
+		return job, nil
+	}
+
+	if err := q.repo.RequeueJob(ctx, id, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Requeued or removed since it was read
+			return entities.Job{}, fmt.Errorf("%w: job changed while being requeued", domain.ErrConflict)
+		}
+		return entities.Job{}, err
+	}
+	domain.LoggerOr(ctx, q.logger).InfoContext(ctx, "job requeued", "audit", true, "resource", "job", "resource_id", id, "kind", job.Kind)
+	q.notify()
+	return job, nil
+}
+
+// Cancel keeps a queued job from running. Jobs already running or done are
+// left as they are, with domain.ErrConflict.
+func (q *Queue) Cancel(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+	job, err := q.repo.GetJob(ctx, id)
+	if err != nil {
+		return entities.Job{}, err
+	}
+	if job.Status != entities.JobQueued {
+		return entities.Job{}, fmt.Errorf("%w: only queued jobs can be canceled, job is %s", domain.ErrConflict, job.Status)
+	}
+
+	now := time.Now().UTC()
+	job.Status = entities.JobCanceled
+	job.FinishedAt = &now
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, q.logger).InfoContext(ctx, "dry run: job not canceled", "job_id", id)
+		return job, nil
+	}
+
+	if err := q.repo.CancelJob(ctx, id, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Claimed by a worker or removed since it was read
+			return entities.Job{}, fmt.Errorf("%w: job changed while being canceled", domain.ErrConflict)
+		}
+		return entities.Job{}, err
+	}
+	domain.LoggerOr(ctx, q.logger).InfoContext(ctx, "job canceled", "audit", true, "resource", "job", "resource_id", id, "kind", job.Kind)
+	return job, nil
+}
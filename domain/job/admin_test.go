@@ -0,0 +1,120 @@
+package job
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/job/mocks"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_List(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		CountJobsFunc: func(ctx context.Context, status entities.JobStatus, kind string) (int64, error) {
+			return 45, nil
+		},
+		CountJobsByStatusFunc: func(ctx context.Context) (map[entities.JobStatus]int64, error) {
+			return map[entities.JobStatus]int64{entities.JobDead: 45}, nil
+		},
+	}
+	q := NewQueue(repo, time.Hour, discardLogger())
+
+	resp, err := q.List(context.Background(), entities.JobFilter{Status: entities.JobDead, Kind: "greet", Page: 3})
+	require.NoError(t, err)
+	require.Len(t, repo.ListJobsCalls(), 1)
+	call := repo.ListJobsCalls()[0]
+	assert.Equal(t, entities.JobDead, call.Status)
+	assert.Equal(t, "greet", call.Kind)
+	assert.Equal(t, int32(20), call.Limit)
+	assert.Equal(t, int32(40), call.Offset)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.NotNil(t, resp.Jobs)
+	assert.Equal(t, int64(45), resp.Counts[entities.JobDead])
+	assert.Contains(t, resp.Counts, entities.JobQueued, "every status is counted")
+
+	_, err = q.List(context.Background(), entities.JobFilter{Status: "lost"})
+	assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+}
+
+func TestQueue_Requeue(t *testing.T) {
+	jobs := map[entities.JobStatus]entities.Job{}
+	for _, status := range entities.JobStatuses {
+		jobs[status] = entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "greet", Status: status, Attempts: 5, MaxAttempts: 5}
+	}
+	repo := &mocks.RepositoryMock{
+		GetJobFunc: func(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+			for _, job := range jobs {
+				if job.ID == id {
+					return job, nil
+				}
+			}
+			return entities.Job{}, domain.ErrNotFound
+		},
+	}
+	q := NewQueue(repo, time.Hour, discardLogger())
+	ctx := context.Background()
+
+	job, err := q.Requeue(ctx, jobs[entities.JobDead].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobQueued, job.Status)
+	assert.Zero(t, job.Attempts)
+	require.Len(t, repo.RequeueJobCalls(), 1)
+	assert.Equal(t, job.ID, repo.RequeueJobCalls()[0].ID)
+	assert.Len(t, q.wake, 1, "a worker is woken")
+
+	_, err = q.Requeue(ctx, jobs[entities.JobCanceled].ID)
+	require.NoError(t, err)
+
+	for _, status := range []entities.JobStatus{entities.JobQueued, entities.JobRunning, entities.JobSucceeded} {
+		_, err := q.Requeue(ctx, jobs[status].ID)
+		assert.ErrorIs(t, err, domain.ErrConflict, status)
+	}
+	assert.Len(t, repo.RequeueJobCalls(), 2)
+
+	_, err = q.Requeue(ctx, uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	t.Run("dry run requeues nothing", func(t *testing.T) {
+		_, err := q.Requeue(domain.WithDryRun(ctx), jobs[entities.JobDead].ID)
+		require.NoError(t, err)
+		assert.Len(t, repo.RequeueJobCalls(), 2)
+	})
+
+	t.Run("a job changed meanwhile conflicts", func(t *testing.T) {
+		repo.RequeueJobFunc = func(ctx context.Context, id uuid.UUID, runAt time.Time) error {
+			return domain.ErrNotFound
+		}
+		_, err := q.Requeue(ctx, jobs[entities.JobDead].ID)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	queued := entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "greet", Status: entities.JobQueued}
+	running := entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "greet", Status: entities.JobRunning}
+	repo := &mocks.RepositoryMock{
+		GetJobFunc: func(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+			if id == running.ID {
+				return running, nil
+			}
+			return queued, nil
+		},
+	}
+	q := NewQueue(repo, time.Hour, discardLogger())
+
+	job, err := q.Cancel(context.Background(), queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobCanceled, job.Status)
+	require.NotNil(t, job.FinishedAt)
+	require.Len(t, repo.CancelJobCalls(), 1)
+	assert.Equal(t, queued.ID, repo.CancelJobCalls()[0].ID)
+
+	_, err = q.Cancel(context.Background(), running.ID)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.Len(t, repo.CancelJobCalls(), 1)
+}
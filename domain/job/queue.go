@@ -0,0 +1,360 @@
+// Package job runs work out of the request, such as sending emails, in
+// background workers. Use cases enqueue typed job arguments on a Queue,
+// and the handlers running them are registered in
+// bootstrap.SetupDependencies. Jobs are stored in the database, so they
+// outlive a crash and run on any instance working the queue, in the API
+// or in cmd/worker.
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// defaultMaxAttempts is how many times a job is run before it is
+	// dead-lettered, unless its kind's policy says otherwise.
+	defaultMaxAttempts = 5
+	// lease is how long a job may run before another worker takes it over,
+	// assuming the one running it died. Handlers are cut off after it.
+	lease = 10 * time.Minute
+	// retryBackoff is how long a job that failed waits before its next
+	// attempt, doubling with each attempt up to maxRetryBackoff.
+	retryBackoff    = 10 * time.Second
+	maxRetryBackoff = time.Hour
+	// purgeInterval is how often jobs that succeeded past the retention are
+	// removed.
+	purgeInterval = time.Hour
+	// alertInterval is how often operators are alerted at most of jobs of a
+	// kind being dead-lettered, so an outage doesn't send one per job.
+	alertInterval = 15 * time.Minute
+)
+
+// Args are the arguments of a job, stored as JSON. Their kind names the
+// handler that runs them.
+type Args interface {
+	JobKind() string
+}
+
+// ErrPermanent marks failures that would fail again, such as arguments
+// that can't be used. Handlers wrap them with it, and the job is left
+// failed without further attempts.
+var ErrPermanent = errors.New("permanent job failure")
+
+// Policy is how the jobs of a kind are retried: they are run up to
+// MaxAttempts times, waiting Backoff after the first failed attempt,
+// doubling with each attempt up to MaxBackoff. Fields left zero are taken
+// from the queue's default policy.
+type Policy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultPolicy is the policy of the jobs of kinds without one.
+var DefaultPolicy = Policy{
+	MaxAttempts: defaultMaxAttempts,
+	Backoff:     retryBackoff,
+	MaxBackoff:  maxRetryBackoff,
+}
+
+// backoff is how long to wait after a job's attempts failed.
+func (p Policy) backoff(attempts int) time.Duration {
+	wait := p.Backoff
+	for i := 1; i < attempts && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, p.MaxBackoff)
+}
+
+// orDefault fills the fields left zero from def.
+func (p Policy) orDefault(def Policy) Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = def.Backoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = def.MaxBackoff
+	}
+	return p
+}
+
+type handler func(ctx context.Context, args json.RawMessage) error
+
+// Queue stores jobs to run in the background, and runs those it has a
+// handler for. A job runs at least once: it is run again when its worker
+// dies before recording the outcome, so handlers must not mind repeats.
+// Failed jobs are tried again later following their kind's policy; once
+// they ran out of attempts they are dead-lettered, kept with their last
+// error for admins to requeue, and operators are alerted.
+type Queue struct {
+	repo          Repository
+	handlers      map[string]handler
+	defaultPolicy Policy
+	policies      map[string]Policy
+	alerter       Alerter
+	retention     time.Duration
+	logger        *slog.Logger
+
+	mu       sync.Mutex
+	purgedAt time.Time
+	// alertedAt is when operators were last alerted of each kind
+	alertedAt map[string]time.Time
+	// wake starts a worker on a new job without waiting for the next tick
+	wake chan struct{}
+}
+
+// NewQueue creates a Queue storing jobs with repo. Jobs that succeeded are
+// removed once retention has passed since.
+func NewQueue(repo Repository, retention time.Duration, logger *slog.Logger) *Queue {
+	return &Queue{
+		repo:          repo,
+		handlers:      map[string]handler{},
+		defaultPolicy: DefaultPolicy,
+		policies:      map[string]Policy{},
+		retention:     retention,
+		logger:        logger,
+		alertedAt:     map[string]time.Time{},
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// SetDefaultPolicy sets the policy of the jobs of kinds without one; its
+// fields left zero are taken from DefaultPolicy. Like handlers, policies
+// are set while setting up.
+func (q *Queue) SetDefaultPolicy(p Policy) {
+	q.defaultPolicy = p.orDefault(DefaultPolicy)
+}
+
+// SetPolicy sets the policy of the jobs of the kind. Jobs keep the
+// attempts they were enqueued with.
+func (q *Queue) SetPolicy(kind string, p Policy) {
+	q.policies[kind] = p
+}
+
+func (q *Queue) policy(kind string) Policy {
+	return q.policies[kind].orDefault(q.defaultPolicy)
+}
+
+// SetAlerter alerts operators through a when jobs are dead-lettered. They
+// are only logged without one.
+func (q *Queue) SetAlerter(a Alerter) {
+	q.alerter = a
+}
+
+// Handle runs the jobs with arguments of type A with fn. Handlers are
+// registered while setting up, before the queue is started.
+func Handle[A Args](q *Queue, fn func(ctx context.Context, args A) error) {
+	var zero A
+	q.handlers[zero.JobKind()] = func(ctx context.Context, raw json.RawMessage) error {
+		var args A
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return fmt.Errorf("%w: decoding arguments: %w", ErrPermanent, err)
+		}
+		return fn(ctx, args)
+	}
+}
+
+// Enqueue stores a job to run with args as soon as a worker is free. In a
+// transaction, the job is only run once it is committed.
+func (q *Queue) Enqueue(ctx context.Context, args Args) (entities.Job, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return entities.Job{}, fmt.Errorf("encoding %s job: %w", args.JobKind(), err)
+	}
+
+	now := time.Now().UTC()
+	job := entities.Job{
+		ID:          uuid.Must(uuid.NewV4()),
+		Kind:        args.JobKind(),
+		Args:        raw,
+		Status:      entities.JobQueued,
+		MaxAttempts: q.policy(args.JobKind()).MaxAttempts,
+		CreatedAt:   now,
+		RunAt:       now,
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, q.logger).InfoContext(ctx, "dry run: job not enqueued", "kind", job.Kind)
+		return job, nil
+	}
+	if err := q.repo.EnqueueJob(ctx, job); err != nil {
+		return entities.Job{}, err
+	}
+	q.notify()
+	return job, nil
+}
+
+// notify wakes a worker on this instance for a job that was just queued.
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run removes the jobs that succeeded before the retention, at most every
+// purgeInterval, then runs the due jobs it has a handler for, one at a
+// time, until none are left. It returns how many jobs it ran, succeeded
+// or failed.
+func (q *Queue) Run(ctx context.Context) (int, error) {
+	if err := q.purge(ctx); err != nil {
+		return 0, err
+	}
+
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	done := 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		job, err := q.repo.ClaimJob(ctx, kinds, now, now.Add(lease))
+		if errors.Is(err, domain.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return done, fmt.Errorf("claiming job: %w", err)
+		}
+
+		if err := q.run(ctx, job); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// run runs the job and records the outcome, even when ctx is done and the
+// job was cut short. It fails only when the outcome can't be recorded.
+func (q *Queue) run(ctx context.Context, job entities.Job) error {
+	logger := q.logger.With("job_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// Its last attempt was cut short
+		err = errors.New("worker stopped while running the job")
+	} else {
+		err = q.call(ctx, job)
+	}
+	ctx = context.WithoutCancel(ctx)
+	if err == nil {
+		logger.Debug("job succeeded")
+		return q.repo.CompleteJob(ctx, job.ID, time.Now().UTC())
+	}
+
+	if errors.Is(err, ErrPermanent) || job.Attempts >= job.MaxAttempts {
+		logger.Error("job dead-lettered", "error", err)
+		if err := q.repo.DeadLetterJob(ctx, job.ID, err.Error(), time.Now().UTC()); err != nil {
+			return err
+		}
+		q.alert(ctx, job, err)
+		return nil
+	}
+	logger.Warn("job failed, retrying", "error", err)
+	return q.repo.RetryJob(ctx, job.ID, err.Error(), time.Now().UTC().Add(q.policy(job.Kind).backoff(job.Attempts)))
+}
+
+// alert tells operators the job was dead-lettered, at most every
+// alertInterval for each kind. A failed alert is only logged.
+func (q *Queue) alert(ctx context.Context, job entities.Job, cause error) {
+	if q.alerter == nil {
+		return
+	}
+	q.mu.Lock()
+	if time.Since(q.alertedAt[job.Kind]) < alertInterval {
+		q.mu.Unlock()
+		return
+	}
+	q.alertedAt[job.Kind] = time.Now()
+	q.mu.Unlock()
+
+	message := fmt.Sprintf("Job %s (%s) was dead-lettered after %d attempts: %s. Requeue it from the admin Background Jobs page once the cause is fixed. Further %s jobs dead-lettered in the next %s are only logged.",
+		job.ID, job.Kind, job.Attempts, cause, job.Kind, alertInterval)
+	alert := entities.Alert{Title: "Background job dead-lettered", Message: message, Severity: entities.AlertSeverityWarning}
+	if err := q.alerter.Alert(ctx, alert); err != nil {
+		domain.LoggerOr(ctx, q.logger).Error("failed to send dead-lettered job alert", "job_id", job.ID, "error", err)
+	}
+}
+
+func (q *Queue) call(ctx context.Context, job entities.Job) (err error) {
+	h, ok := q.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("%w: no handler for %q jobs", ErrPermanent, job.Kind)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, lease)
+	defer cancel()
+	return h(ctx, job.Args)
+}
+
+func (q *Queue) purge(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.retention <= 0 || time.Since(q.purgedAt) < purgeInterval {
+		return nil
+	}
+
+	n, err := q.repo.DeleteSucceededJobs(ctx, time.Now().UTC().Add(-q.retention))
+	if err != nil {
+		return fmt.Errorf("removing succeeded jobs: %w", err)
+	}
+	if n > 0 {
+		domain.LoggerOr(ctx, q.logger).Info("removed succeeded jobs", "count", n)
+	}
+	q.purgedAt = time.Now()
+	return nil
+}
+
+// Start runs jobs with workers running one each at a time, as they are
+// enqueued on this instance or every interval, until ctx is done.
+func (q *Queue) Start(ctx context.Context, interval time.Duration, workers int) {
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx, interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := q.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, q.logger).Error("job worker failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, q.logger).Debug("ran jobs", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
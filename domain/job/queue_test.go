@@ -0,0 +1,227 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/job/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type greetArgs struct {
+	Name string `json:"name"`
+}
+
+func (greetArgs) JobKind() string { return "greet" }
+
+func TestQueue_Enqueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the arguments", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		q := NewQueue(repo, time.Hour, discardLogger())
+
+		job, err := q.Enqueue(ctx, greetArgs{Name: "Ada"})
+		require.NoError(t, err)
+		calls := repo.EnqueueJobCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, job, calls[0].Job)
+		assert.Equal(t, "greet", job.Kind)
+		assert.JSONEq(t, `{"name":"Ada"}`, string(job.Args))
+		assert.Equal(t, entities.JobQueued, job.Status)
+		assert.Equal(t, defaultMaxAttempts, job.MaxAttempts)
+		assert.False(t, job.RunAt.After(time.Now()), "due at once")
+	})
+
+	t.Run("dry runs enqueue nothing", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		q := NewQueue(repo, time.Hour, discardLogger())
+
+		_, err := q.Enqueue(domain.WithDryRun(ctx), greetArgs{Name: "Ada"})
+		require.NoError(t, err)
+		assert.Empty(t, repo.EnqueueJobCalls())
+	})
+}
+
+func TestQueue_Run(t *testing.T) {
+	newJob := func(kind, args string, attempts int) entities.Job {
+		return entities.Job{
+			ID:          uuid.Must(uuid.NewV4()),
+			Kind:        kind,
+			Args:        json.RawMessage(args),
+			Status:      entities.JobRunning,
+			Attempts:    attempts,
+			MaxAttempts: 3,
+		}
+	}
+	succeeds := newJob("greet", `{"name":"Ada"}`, 1)
+	retried := newJob("greet", `{"name":"retry"}`, 2)
+	exhausted := newJob("greet", `{"name":"retry"}`, 3)
+	permanent := newJob("greet", `{"name":"permanent"}`, 1)
+	malformed := newJob("greet", `[]`, 1)
+	panics := newJob("greet", `{"name":"panic"}`, 1)
+	abandoned := newJob("greet", `{"name":"Ada"}`, 4)
+	jobs := []entities.Job{succeeds, retried, exhausted, permanent, malformed, panics, abandoned}
+
+	repo := &mocks.RepositoryMock{
+		ClaimJobFunc: func(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error) {
+			assert.Equal(t, []string{"greet"}, kinds, "only kinds with a handler")
+			assert.True(t, leaseUntil.After(now))
+			if len(jobs) == 0 {
+				return entities.Job{}, domain.ErrNotFound
+			}
+			job := jobs[0]
+			jobs = jobs[1:]
+			return job, nil
+		},
+	}
+	q := NewQueue(repo, time.Hour, discardLogger())
+	var greeted []string
+	Handle(q, func(ctx context.Context, args greetArgs) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline, "cut off after the lease")
+		switch args.Name {
+		case "retry":
+			return errors.New("smtp down")
+		case "permanent":
+			return errors.Join(ErrPermanent, errors.New("mailbox not found"))
+		case "panic":
+			panic("boom")
+		}
+		greeted = append(greeted, args.Name)
+		return nil
+	})
+
+	start := time.Now()
+	n, err := q.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, []string{"Ada"}, greeted, "abandoned jobs aren't run again")
+	assert.Len(t, repo.DeleteSucceededJobsCalls(), 1)
+
+	completed := repo.CompleteJobCalls()
+	require.Len(t, completed, 1)
+	assert.Equal(t, succeeds.ID, completed[0].ID)
+
+	retries := repo.RetryJobCalls()
+	require.Len(t, retries, 2)
+	assert.Equal(t, retried.ID, retries[0].ID)
+	assert.Equal(t, "smtp down", retries[0].Message)
+	assert.WithinDuration(t, start.Add(20*time.Second), retries[0].RunAt, 5*time.Second, "backing off with its attempts")
+	assert.Equal(t, panics.ID, retries[1].ID)
+	assert.Equal(t, "panic: boom", retries[1].Message)
+
+	failed := repo.DeadLetterJobCalls()
+	require.Len(t, failed, 4)
+	assert.Equal(t, exhausted.ID, failed[0].ID, "out of attempts")
+	assert.Equal(t, permanent.ID, failed[1].ID)
+	assert.Equal(t, malformed.ID, failed[2].ID)
+	assert.Equal(t, abandoned.ID, failed[3].ID)
+	assert.Equal(t, "worker stopped while running the job", failed[3].Message)
+
+	_, err = q.Run(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repo.DeleteSucceededJobsCalls(), 1, "removed at most every purge interval")
+}
+
+func TestQueue_RunUnknownKind(t *testing.T) {
+	claimed := false
+	repo := &mocks.RepositoryMock{
+		ClaimJobFunc: func(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error) {
+			if claimed {
+				return entities.Job{}, domain.ErrNotFound
+			}
+			claimed = true
+			return entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "other", Attempts: 1, MaxAttempts: 3}, nil
+		},
+	}
+	q := NewQueue(repo, 0, discardLogger())
+
+	_, err := q.Run(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repo.DeadLetterJobCalls(), 1)
+	assert.Empty(t, repo.DeleteSucceededJobsCalls(), "kept without a retention")
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, DefaultPolicy.backoff(1))
+	assert.Equal(t, 20*time.Second, DefaultPolicy.backoff(2))
+	assert.Equal(t, time.Hour, DefaultPolicy.backoff(20))
+}
+
+func TestQueue_Policy(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	q := NewQueue(repo, time.Hour, discardLogger())
+	q.SetDefaultPolicy(Policy{MaxAttempts: 8})
+	q.SetPolicy("greet", Policy{MaxAttempts: 2, Backoff: time.Minute})
+
+	job, err := q.Enqueue(context.Background(), greetArgs{Name: "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, job.MaxAttempts)
+	assert.Equal(t, Policy{MaxAttempts: 2, Backoff: time.Minute, MaxBackoff: maxRetryBackoff}, q.policy("greet"), "unset fields come from the default")
+	assert.Equal(t, Policy{MaxAttempts: 8, Backoff: retryBackoff, MaxBackoff: maxRetryBackoff}, q.policy("other"))
+
+	retried := entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "greet", Args: json.RawMessage(`{}`), Attempts: 1, MaxAttempts: 2}
+	claimed := false
+	repo.ClaimJobFunc = func(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error) {
+		if claimed {
+			return entities.Job{}, domain.ErrNotFound
+		}
+		claimed = true
+		return retried, nil
+	}
+	Handle(q, func(ctx context.Context, args greetArgs) error {
+		return errors.New("smtp down")
+	})
+	start := time.Now()
+	_, err = q.Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repo.RetryJobCalls(), 1)
+	assert.WithinDuration(t, start.Add(time.Minute), repo.RetryJobCalls()[0].RunAt, 5*time.Second, "backing off by the kind's policy")
+}
+
+func TestQueue_DeadLetterAlert(t *testing.T) {
+	var jobs []entities.Job
+	for range 3 {
+		jobs = append(jobs, entities.Job{ID: uuid.Must(uuid.NewV4()), Kind: "greet", Args: json.RawMessage(`{}`), Attempts: 1, MaxAttempts: 1})
+	}
+	repo := &mocks.RepositoryMock{
+		ClaimJobFunc: func(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error) {
+			if len(jobs) == 0 {
+				return entities.Job{}, domain.ErrNotFound
+			}
+			job := jobs[0]
+			jobs = jobs[1:]
+			return job, nil
+		},
+	}
+	alerter := &mocks.AlerterMock{}
+	q := NewQueue(repo, time.Hour, discardLogger())
+	q.SetAlerter(alerter)
+	Handle(q, func(ctx context.Context, args greetArgs) error {
+		return errors.New("smtp down")
+	})
+
+	n, err := q.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	require.Len(t, repo.DeadLetterJobCalls(), 3)
+	assert.Equal(t, "smtp down", repo.DeadLetterJobCalls()[0].Message, "the last error is kept")
+	require.Len(t, alerter.AlertCalls(), 1, "alerted once per kind and interval")
+	assert.Contains(t, alerter.AlertCalls()[0].Alert.Message, "greet")
+	assert.Contains(t, alerter.AlertCalls()[0].Alert.Message, "smtp down")
+	assert.Equal(t, entities.AlertSeverityWarning, alerter.AlertCalls()[0].Alert.Severity)
+}
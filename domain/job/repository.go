@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Alerter
+
+type Repository interface {
+	// EnqueueJob stores the job, in the transaction of the context if
+	// there is one.
+	EnqueueJob(ctx context.Context, job entities.Job) error
+	// ClaimJob takes the job of one of the kinds due first by now, queued
+	// or running past its lease, marks it running and leased until
+	// leaseUntil, counts the attempt and returns it. Concurrent workers
+	// never claim the same job. It returns domain.ErrNotFound when there is
+	// nothing to do.
+	ClaimJob(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error)
+	CompleteJob(ctx context.Context, id uuid.UUID, finishedAt time.Time) error
+	// RetryJob records a failed attempt at the job, and queues it again
+	// from runAt.
+	RetryJob(ctx context.Context, id uuid.UUID, message string, runAt time.Time) error
+	// DeadLetterJob leaves the job dead with the error of its last
+	// attempt.
+	DeadLetterJob(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error
+	// DeleteSucceededJobs removes the jobs that succeeded before
+	// finishedBefore, and returns how many it removed.
+	DeleteSucceededJobs(ctx context.Context, finishedBefore time.Time) (int64, error)
+
+	// GetJob returns domain.ErrNotFound when there is no such job.
+	GetJob(ctx context.Context, id uuid.UUID) (entities.Job, error)
+	// ListJobs returns a page of the jobs with the status and of the kind,
+	// when not empty, newest first.
+	ListJobs(ctx context.Context, status entities.JobStatus, kind string, limit, offset int32) ([]entities.Job, error)
+	CountJobs(ctx context.Context, status entities.JobStatus, kind string) (int64, error)
+	CountJobsByStatus(ctx context.Context) (map[entities.JobStatus]int64, error)
+	// RequeueJob queues the dead or canceled job again from runAt, with
+	// all its attempts and its last error. It returns domain.ErrNotFound
+	// when there is no such job in either status.
+	RequeueJob(ctx context.Context, id uuid.UUID, runAt time.Time) error
+	// CancelJob leaves the queued job canceled. It returns
+	// domain.ErrNotFound when there is no such queued job.
+	CancelJob(ctx context.Context, id uuid.UUID, finishedAt time.Time) error
+}
+
+// Alerter notifies operators that a job was dead-lettered.
+type Alerter interface {
+	Alert(ctx context.Context, alert entities.Alert) error
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// WithLogger records the logger of a request, which names the request, its
+// route and, once authenticated, its user, so every line it logs can be
+// told apart from the lines of other requests.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger returns the logger recorded with WithLogger, or the default
+// logger outside requests.
+func Logger(ctx context.Context) *slog.Logger {
+	return LoggerOr(ctx, slog.Default())
+}
+
+// LoggerOr returns the logger recorded with WithLogger, or fallback outside
+// requests, for code given a logger of its own.
+func LoggerOr(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return fallback
+}
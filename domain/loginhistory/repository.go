@@ -0,0 +1,19 @@
+package loginhistory
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	CreateLoginEvent(ctx context.Context, event entities.LoginEvent) error
+	// SetLastLogin records the user's last successful login on the user.
+	SetLastLogin(ctx context.Context, userID uuid.UUID, at time.Time, ip string) error
+	// ListLoginEvents returns the user's latest attempts, newest first.
+	ListLoginEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]entities.LoginEvent, error)
+}
@@ -0,0 +1,72 @@
+package loginhistory
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// DefaultListLimit is how many attempts List returns when not told.
+	DefaultListLimit = 50
+	// MaxListLimit is the most attempts List returns at once.
+	MaxListLimit = 200
+)
+
+// UseCase keeps the login history: every attempt to sign in to a known
+// account, and the last successful login on the user.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Record stores a login attempt. The client's IP address and user agent are
+// taken from the context (see domain.WithClient) unless the event has them.
+// Failures are logged and never fail the login.
+func (uc *UseCase) Record(ctx context.Context, event entities.LoginEvent) {
+	client := domain.ClientFromContext(ctx)
+	if event.IPAddress == "" {
+		event.IPAddress = client.IP
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = client.UserAgent
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = uc.now().UTC()
+	}
+
+	if err := uc.repo.CreateLoginEvent(ctx, event); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to record login event", "user_id", event.UserID, "error", err)
+	}
+	if !event.Success {
+		return
+	}
+	if err := uc.repo.SetLastLogin(ctx, event.UserID, event.CreatedAt, event.IPAddress); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to record last login", "user_id", event.UserID, "error", err)
+	}
+}
+
+// List returns the user's latest login attempts, newest first. limit is
+// DefaultListLimit when not positive, and at most MaxListLimit.
+func (uc *UseCase) List(ctx context.Context, userID uuid.UUID, limit int) ([]entities.LoginEvent, error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	return uc.repo.ListLoginEvents(ctx, userID, int32(limit))
+}
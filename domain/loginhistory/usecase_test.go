@@ -0,0 +1,90 @@
+package loginhistory
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/loginhistory/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Record(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := domain.WithClient(context.Background(), domain.Client{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+
+	t.Run("success sets the last login", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo)
+		uc.now = func() time.Time { return now }
+
+		uc.Record(ctx, entities.LoginEvent{UserID: userID, Success: true, Provider: "local"})
+
+		require.Len(t, repo.CreateLoginEventCalls(), 1)
+		event := repo.CreateLoginEventCalls()[0].Event
+		assert.Equal(t, "203.0.113.7", event.IPAddress)
+		assert.Equal(t, "curl/8.0", event.UserAgent)
+		assert.Equal(t, now, event.CreatedAt)
+
+		require.Len(t, repo.SetLastLoginCalls(), 1)
+		call := repo.SetLastLoginCalls()[0]
+		assert.Equal(t, userID, call.UserID)
+		assert.Equal(t, now, call.At)
+		assert.Equal(t, "203.0.113.7", call.IP)
+	})
+
+	t.Run("failure leaves the last login", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo)
+
+		uc.Record(ctx, entities.LoginEvent{UserID: userID, Provider: "local", Reason: entities.LoginReasonInvalidCredentials})
+
+		assert.Len(t, repo.CreateLoginEventCalls(), 1)
+		assert.Empty(t, repo.SetLastLoginCalls())
+	})
+
+	t.Run("storage errors are swallowed", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			CreateLoginEventFunc: func(ctx context.Context, event entities.LoginEvent) error {
+				return errors.New("db down")
+			},
+		}
+		uc := newTestUseCase(repo)
+
+		uc.Record(ctx, entities.LoginEvent{UserID: userID, Success: true})
+
+		assert.Len(t, repo.SetLastLoginCalls(), 1)
+	})
+}
+
+func TestUseCase_List(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo)
+	userID := uuid.Must(uuid.NewV4())
+
+	for _, tc := range []struct {
+		limit int
+		want  int32
+	}{
+		{0, DefaultListLimit},
+		{10, 10},
+		{MaxListLimit + 1, MaxListLimit},
+	} {
+		_, err := uc.List(context.Background(), userID, tc.limit)
+		require.NoError(t, err)
+		calls := repo.ListLoginEventsCalls()
+		assert.Equal(t, tc.want, calls[len(calls)-1].Limit)
+	}
+}
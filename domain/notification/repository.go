@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository UserGetter PreferencesGetter EmailSender
+
+type Repository interface {
+	// CreateNotification returns domain.ErrNotFound when the user is gone.
+	CreateNotification(ctx context.Context, notification entities.Notification) error
+	// ListNotifications lists a page of the user's notifications, newest
+	// first, or only the unread ones.
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int32) ([]entities.Notification, error)
+	CountNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) (int64, error)
+	// MarkNotificationRead returns domain.ErrNotFound when the user has no
+	// such notification.
+	MarkNotificationRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error
+	// MarkAllNotificationsRead returns how many notifications were unread.
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+}
+
+// UserGetter finds the address and language notifications are emailed in.
+type UserGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entities.User, error)
+}
+
+// PreferencesGetter reads the channels users chose to be notified on.
+type PreferencesGetter interface {
+	Get(ctx context.Context, userID uuid.UUID) (entities.Preferences, error)
+}
+
+// EmailSender sends transactional emails, rendered from the email's
+// template.
+type EmailSender interface {
+	SendEmail(ctx context.Context, email entities.Email) error
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"sync"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// streamBuffer is how many notifications a subscriber may fall behind by
+// before new ones are dropped for it.
+const streamBuffer = 16
+
+// subscribers hands the notifications kept for the apps to the streams open
+// for their users on this instance.
+type subscribers struct {
+	mu     sync.Mutex
+	byUser map[uuid.UUID]map[chan entities.Notification]struct{}
+}
+
+func (s *subscribers) add(userID uuid.UUID) chan entities.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byUser == nil {
+		s.byUser = map[uuid.UUID]map[chan entities.Notification]struct{}{}
+	}
+	if s.byUser[userID] == nil {
+		s.byUser[userID] = map[chan entities.Notification]struct{}{}
+	}
+	ch := make(chan entities.Notification, streamBuffer)
+	s.byUser[userID][ch] = struct{}{}
+	return ch
+}
+
+func (s *subscribers) remove(userID uuid.UUID, ch chan entities.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.byUser[userID], ch)
+	if len(s.byUser[userID]) == 0 {
+		delete(s.byUser, userID)
+	}
+	close(ch)
+}
+
+func (s *subscribers) publish(n entities.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.byUser[n.UserID] {
+		select {
+		case ch <- n:
+		default:
+			// A slow reader misses it, and sees it when it lists them
+		}
+	}
+}
+
+// Subscribe streams the user's notifications as they are kept for the
+// apps, until ctx is done, when the channel is closed. Only notifications
+// emitted on this instance are streamed.
+func (uc *UseCase) Subscribe(ctx context.Context, userID uuid.UUID) <-chan entities.Notification {
+	ch := uc.subs.add(userID)
+	go func() {
+		<-ctx.Done()
+		uc.subs.remove(userID, ch)
+	}()
+	return ch
+}
@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// UseCase keeps users' notifications. Other domains emit them with Notify,
+// and each one is delivered on the channels its user chose for its kind in
+// their preferences: kept to be listed in the apps, emailed, or both.
+type UseCase struct {
+	repo   Repository
+	users  UserGetter
+	prefs  PreferencesGetter
+	email  EmailSender
+	subs   subscribers
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, users UserGetter, prefs PreferencesGetter, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		users:  users,
+		prefs:  prefs,
+		logger: logger,
+	}
+}
+
+// SetEmail enables the email channel. Without it, notifications users
+// want emailed are only shown in the apps, if they chose that too.
+func (uc *UseCase) SetEmail(email EmailSender) {
+	uc.email = email
+}
+
+// Notify delivers the notification to its user. The ID and creation time
+// are set here. Failing to email it is logged, so emitters only see errors
+// keeping it for the apps.
+func (uc *UseCase) Notify(ctx context.Context, n entities.Notification) (entities.Notification, error) {
+	n.Title = strings.TrimSpace(n.Title)
+	if n.UserID == uuid.Nil || n.Kind == "" || n.Title == "" {
+		return entities.Notification{}, fmt.Errorf("notifications need a user, a kind and a title: %w", domain.ErrMalformedParameters)
+	}
+	n.ID = uuid.Must(uuid.NewV4())
+	n.ReadAt = nil
+	n.CreatedAt = time.Now().UTC()
+
+	channels := uc.channels(ctx, n)
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: notification not sent", "user_id", n.UserID, "kind", n.Kind)
+		return n, nil
+	}
+
+	if slices.Contains(channels, entities.NotificationInApp) {
+		if err := uc.repo.CreateNotification(ctx, n); err != nil {
+			return entities.Notification{}, err
+		}
+		uc.subs.publish(n)
+	}
+	if slices.Contains(channels, entities.NotificationEmail) {
+		uc.sendEmail(ctx, n)
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "notification sent", "user_id", n.UserID, "kind", n.Kind, "notification_id", n.ID, "channels", channels)
+	return n, nil
+}
+
+// channels returns the channels the user chose for the notification's
+// kind. When their preferences can't be read, it is only shown in the apps.
+func (uc *UseCase) channels(ctx context.Context, n entities.Notification) []entities.NotificationChannel {
+	prefs, err := uc.prefs.Get(ctx, n.UserID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get notification preferences", "user_id", n.UserID, "error", err)
+		prefs = entities.Preferences{}
+	}
+	return prefs.NotificationChannels(n.Kind)
+}
+
+func (uc *UseCase) sendEmail(ctx context.Context, n entities.Notification) {
+	if uc.email == nil {
+		domain.LoggerOr(ctx, uc.logger).Warn("notification not emailed, no email provider configured", "user_id", n.UserID, "kind", n.Kind)
+		return
+	}
+	user, err := uc.users.GetByID(ctx, n.UserID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user to email notification", "user_id", n.UserID, "error", err)
+		return
+	}
+	err = uc.email.SendEmail(ctx, entities.Email{
+		To:       user.Email,
+		Template: entities.EmailNotification,
+		Locale:   user.Locale,
+		Data: map[string]any{
+			"Title": n.Title,
+			"Body":  n.Body,
+			"Link":  n.Link,
+		},
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to email notification", "user_id", n.UserID, "notification_id", n.ID, "error", err)
+	}
+}
+
+// List returns a page of the user's notifications, newest first, or of
+// only the unread ones. Pages start at 1 and hold 20 notifications unless
+// pageSize is between 1 and 100.
+func (uc *UseCase) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, page, pageSize int) (entities.NotificationListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	notifications, err := uc.repo.ListNotifications(ctx, userID, unreadOnly, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return entities.NotificationListResponse{}, err
+	}
+	if notifications == nil {
+		notifications = []entities.Notification{}
+	}
+	total, err := uc.repo.CountNotifications(ctx, userID, unreadOnly)
+	if err != nil {
+		return entities.NotificationListResponse{}, err
+	}
+	unread := total
+	if !unreadOnly {
+		unread, err = uc.repo.CountNotifications(ctx, userID, true)
+		if err != nil {
+			return entities.NotificationListResponse{}, err
+		}
+	}
+
+	return entities.NotificationListResponse{
+		Notifications: notifications,
+		Unread:        unread,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// MarkRead marks the user's notification read. Notifications read already
+// stay as they were. It returns domain.ErrNotFound when the user has no
+// such notification.
+func (uc *UseCase) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: notification not marked read", "user_id", userID, "notification_id", id)
+		return nil
+	}
+	return uc.repo.MarkNotificationRead(ctx, userID, id, time.Now().UTC())
+}
+
+// MarkAllRead marks all the user's notifications read, and returns how
+// many were unread.
+func (uc *UseCase) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: notifications not marked read", "user_id", userID)
+		return uc.repo.CountNotifications(ctx, userID, true)
+	}
+	return uc.repo.MarkAllNotificationsRead(ctx, userID, time.Now().UTC())
+}
@@ -0,0 +1,193 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/notification/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, prefs entities.Preferences, email EmailSender) *UseCase {
+	users := &mocks.UserGetterMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			user := entities.User{ID: id, Email: "ada@example.com"}
+			user.Locale = "pt-BR"
+			return user, nil
+		},
+	}
+	preferences := &mocks.PreferencesGetterMock{
+		GetFunc: func(ctx context.Context, userID uuid.UUID) (entities.Preferences, error) {
+			return prefs, nil
+		},
+	}
+	uc := NewUseCase(repo, users, preferences, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if email != nil {
+		uc.SetEmail(email)
+	}
+	return uc
+}
+
+func TestUseCase_Notify(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+	notification := entities.Notification{UserID: userID, Kind: entities.NotificationExportReady, Title: " Your export is ready ", Body: "It has 3 rows.", Link: "https://app.test/exports/1"}
+	channels := func(c ...any) entities.Preferences {
+		return entities.Preferences{"notification_channels": map[string]any{"export_ready": c}}
+	}
+
+	t.Run("in the apps by default", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		email := &mocks.EmailSenderMock{}
+		uc := newTestUseCase(repo, entities.Preferences{}, email)
+
+		got, err := uc.Notify(ctx, notification)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, got.ID)
+		assert.Equal(t, "Your export is ready", got.Title)
+		require.Len(t, repo.CreateNotificationCalls(), 1)
+		assert.Equal(t, got, repo.CreateNotificationCalls()[0].Notification)
+		assert.Empty(t, email.SendEmailCalls())
+	})
+
+	t.Run("emailed when chosen", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		email := &mocks.EmailSenderMock{}
+		uc := newTestUseCase(repo, channels("email"), email)
+
+		_, err := uc.Notify(ctx, notification)
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateNotificationCalls())
+		require.Len(t, email.SendEmailCalls(), 1)
+		sent := email.SendEmailCalls()[0].Email
+		assert.Equal(t, "ada@example.com", sent.To)
+		assert.Equal(t, entities.EmailNotification, sent.Template)
+		assert.Equal(t, "pt-BR", sent.Locale)
+		assert.Equal(t, "Your export is ready", sent.Data["Title"])
+		assert.Equal(t, notification.Link, sent.Data["Link"])
+	})
+
+	t.Run("email failures are logged", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		email := &mocks.EmailSenderMock{
+			SendEmailFunc: func(ctx context.Context, e entities.Email) error {
+				return errors.New("smtp down")
+			},
+		}
+		uc := newTestUseCase(repo, channels("in_app", "email"), email)
+
+		_, err := uc.Notify(ctx, notification)
+		require.NoError(t, err)
+		assert.Len(t, repo.CreateNotificationCalls(), 1)
+		assert.Len(t, email.SendEmailCalls(), 1)
+	})
+
+	t.Run("turned off", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		email := &mocks.EmailSenderMock{}
+		uc := newTestUseCase(repo, channels(), email)
+
+		_, err := uc.Notify(ctx, notification)
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateNotificationCalls())
+		assert.Empty(t, email.SendEmailCalls())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		uc := newTestUseCase(&mocks.RepositoryMock{}, nil, nil)
+
+		_, err := uc.Notify(ctx, entities.Notification{UserID: userID, Kind: entities.NotificationExportReady, Title: " "})
+		assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+		_, err = uc.Notify(ctx, entities.Notification{Kind: entities.NotificationExportReady, Title: "Hi"})
+		assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		email := &mocks.EmailSenderMock{}
+		uc := newTestUseCase(repo, channels("in_app", "email"), email)
+
+		_, err := uc.Notify(domain.WithDryRun(ctx), notification)
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateNotificationCalls())
+		assert.Empty(t, email.SendEmailCalls())
+	})
+}
+
+func TestUseCase_List(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		ListNotificationsFunc: func(ctx context.Context, id uuid.UUID, unreadOnly bool, limit, offset int32) ([]entities.Notification, error) {
+			return []entities.Notification{{ID: uuid.Must(uuid.NewV4()), UserID: id}}, nil
+		},
+		CountNotificationsFunc: func(ctx context.Context, id uuid.UUID, unreadOnly bool) (int64, error) {
+			if unreadOnly {
+				return 3, nil
+			}
+			return 45, nil
+		},
+	}
+	uc := newTestUseCase(repo, nil, nil)
+
+	got, err := uc.List(context.Background(), userID, false, 2, 0)
+	require.NoError(t, err)
+	assert.Len(t, got.Notifications, 1)
+	assert.Equal(t, int64(45), got.Total)
+	assert.Equal(t, int64(3), got.Unread)
+	assert.Equal(t, 2, got.Page)
+	assert.Equal(t, 20, got.PageSize)
+	assert.Equal(t, 3, got.TotalPages)
+	require.Len(t, repo.ListNotificationsCalls(), 1)
+	assert.Equal(t, int32(20), repo.ListNotificationsCalls()[0].Offset)
+}
+
+func TestUseCase_MarkRead(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	id := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		MarkNotificationReadFunc: func(ctx context.Context, gotUserID, gotID uuid.UUID, readAt time.Time) error {
+			if gotUserID != userID || gotID != id {
+				return domain.ErrNotFound
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, nil, nil)
+	ctx := context.Background()
+
+	require.NoError(t, uc.MarkRead(ctx, userID, id))
+	assert.ErrorIs(t, uc.MarkRead(ctx, uuid.Must(uuid.NewV4()), id), domain.ErrNotFound, "only the user's notifications")
+}
+
+func TestUseCase_Subscribe(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	uc := newTestUseCase(&mocks.RepositoryMock{}, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream := uc.Subscribe(ctx, userID)
+	other := uc.Subscribe(ctx, uuid.Must(uuid.NewV4()))
+
+	sent, err := uc.Notify(context.Background(), entities.Notification{UserID: userID, Kind: entities.NotificationExportReady, Title: "Your export is ready"})
+	require.NoError(t, err)
+	select {
+	case got := <-stream:
+		assert.Equal(t, sent, got)
+	case <-time.After(time.Second):
+		t.Fatal("notification not streamed")
+	}
+	assert.Empty(t, other, "only the user's notifications")
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-stream
+		return !open
+	}, time.Second, 10*time.Millisecond, "closed when the context is done")
+}
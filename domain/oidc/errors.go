@@ -0,0 +1,33 @@
+package oidc
+
+// Error is an OAuth 2.0 error response (RFC 6749 section 5.2).
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// OAuth 2.0 / OIDC error codes
+const (
+	CodeInvalidRequest          = "invalid_request"
+	CodeInvalidClient           = "invalid_client"
+	CodeInvalidGrant            = "invalid_grant"
+	CodeUnauthorizedClient      = "unauthorized_client"
+	CodeUnsupportedGrantType    = "unsupported_grant_type"
+	CodeUnsupportedResponseType = "unsupported_response_type"
+	CodeInvalidScope            = "invalid_scope"
+	CodeInvalidToken            = "invalid_token"
+	CodeServerError             = "server_error"
+	CodeAccessDenied            = "access_denied"
+	CodeConsentRequired         = "consent_required"
+)
+
+func newError(code, description string) *Error {
+	return &Error{Code: code, Description: description}
+}
@@ -0,0 +1,29 @@
+package oidc
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository UserRepository
+
+type Repository interface {
+	CreateClient(ctx context.Context, client entities.OAuthClient) error
+	GetClient(ctx context.Context, clientID string) (entities.OAuthClient, error)
+	ListClients(ctx context.Context) ([]entities.OAuthClient, error)
+	DeleteClient(ctx context.Context, clientID string) error
+
+	CreateAuthorizationCode(ctx context.Context, code entities.AuthorizationCode) error
+	ConsumeAuthorizationCode(ctx context.Context, codeHash string) (entities.AuthorizationCode, error)
+
+	// GetConsent returns domain.ErrNotFound when the user never consented to
+	// the client.
+	GetConsent(ctx context.Context, userID uuid.UUID, clientID string) (entities.OAuthConsent, error)
+	SaveConsent(ctx context.Context, consent entities.OAuthConsent) error
+}
+
+type UserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entities.User, error)
+}
@@ -0,0 +1,657 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ScopeOpenID  = "openid"
+	ScopeEmail   = "email"
+	ScopeProfile = "profile"
+
+	CodeChallengeMethodS256  = "S256"
+	CodeChallengeMethodPlain = "plain"
+
+	// PromptConsent asks for consent even when the user gave it before
+	PromptConsent = "consent"
+
+	// Answers on the consent screen
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+
+	tokenUseAccess = "access"
+)
+
+var supportedScopes = []string{ScopeOpenID, ScopeEmail, ScopeProfile}
+
+// Signer signs and verifies tokens issued by the provider
+type Signer interface {
+	Sign(claims gojwt.Claims) (string, error)
+	Parse(token string, claims gojwt.Claims) error
+	JWKS() jwt.JWKS
+}
+
+// KeySet publishes the public keys of another token issuer, such as the
+// service's own access tokens, next to the ID token keys
+type KeySet interface {
+	JWKS() jwt.JWKS
+}
+
+type Config struct {
+	Issuer                string
+	AuthorizationEndpoint string
+	AccessTokenTTL        time.Duration
+	IDTokenTTL            time.Duration
+	CodeTTL               time.Duration
+}
+
+type UseCase struct {
+	repo   Repository
+	users  UserRepository
+	signer Signer
+	cfg    Config
+	logger *slog.Logger
+
+	accessTokenKeys KeySet
+}
+
+func NewUseCase(repo Repository, users UserRepository, signer Signer, cfg Config, logger *slog.Logger) *UseCase {
+	if cfg.AccessTokenTTL <= 0 {
+		cfg.AccessTokenTTL = time.Hour
+	}
+	if cfg.IDTokenTTL <= 0 {
+		cfg.IDTokenTTL = time.Hour
+	}
+	if cfg.CodeTTL <= 0 {
+		cfg.CodeTTL = 5 * time.Minute
+	}
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+
+	return &UseCase{
+		repo:   repo,
+		users:  users,
+		signer: signer,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// SetAccessTokenKeys publishes the keys that sign the service's access
+// tokens in the JWKS, so other services can verify them offline.
+func (uc *UseCase) SetAccessTokenKeys(keys KeySet) {
+	uc.accessTokenKeys = keys
+}
+
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Prompt              string `json:"prompt"`
+	// Decision is the user's answer on the consent screen. Without one, the
+	// user must have consented to the scopes before.
+	Decision string `json:"decision"`
+}
+
+// ConsentPrompt is what the consent screen asks the user about.
+type ConsentPrompt struct {
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+	// Required is false when the user already consented to the scopes, or
+	// when the request is invalid and Authorize reports it to the client
+	Required bool `json:"required"`
+}
+
+type TokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	ClientID     string
+	ClientSecret string
+	CodeVerifier string
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+type UserInfo struct {
+	Subject     string `json:"sub"`
+	Email       string `json:"email,omitempty"`
+	AccountType string `json:"account_type,omitempty"`
+}
+
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+// IDTokenClaims are the claims of an OIDC ID token
+type IDTokenClaims struct {
+	Nonce    string `json:"nonce,omitempty"`
+	Email    string `json:"email,omitempty"`
+	AuthTime int64  `json:"auth_time"`
+	gojwt.RegisteredClaims
+}
+
+// AccessTokenClaims are the claims of an access token accepted by /userinfo
+type AccessTokenClaims struct {
+	TokenUse string `json:"token_use"`
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"`
+	gojwt.RegisteredClaims
+}
+
+// Discovery returns the OpenID provider metadata.
+func (uc *UseCase) Discovery() Discovery {
+	return Discovery{
+		Issuer:                            uc.cfg.Issuer,
+		AuthorizationEndpoint:             uc.cfg.AuthorizationEndpoint,
+		TokenEndpoint:                     uc.cfg.Issuer + "/oauth2/token",
+		UserInfoEndpoint:                  uc.cfg.Issuer + "/userinfo",
+		JWKSURI:                           uc.cfg.Issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{gojwt.SigningMethodRS256.Alg()},
+		ScopesSupported:                   supportedScopes,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "email"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256, CodeChallengeMethodPlain},
+	}
+}
+
+// JWKS returns the keys clients use to verify ID tokens and, when set, the
+// service's access tokens.
+func (uc *UseCase) JWKS() jwt.JWKS {
+	set := uc.signer.JWKS()
+	if uc.accessTokenKeys != nil {
+		set.Keys = append(set.Keys, uc.accessTokenKeys.JWKS().Keys...)
+	}
+	return set
+}
+
+// Authorize issues an authorization code for userID and returns the URL the
+// user agent must be redirected to. The user must allow the request on the
+// consent screen, or have consented to its scopes before; a denied request
+// sends the user back with access_denied. Errors about the request itself are
+// reported to the client through the redirect; an *Error is only returned when
+// the client or redirect URI can't be trusted.
+func (uc *UseCase) Authorize(ctx context.Context, userID uuid.UUID, req AuthorizeRequest) (string, error) {
+	client, err := uc.requestClient(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if oauthErr := validateAuthorizeRequest(req); oauthErr != nil {
+		return redirectURL(req.RedirectURI, url.Values{
+			"error":             {oauthErr.Code},
+			"error_description": {oauthErr.Description},
+			"state":             {req.State},
+		}), nil
+	}
+
+	switch req.Decision {
+	case DecisionDeny:
+		domain.LoggerOr(ctx, uc.logger).Info("authorization denied", slog.String("client_id", client.ClientID), slog.String("user_id", userID.String()))
+		return redirectURL(req.RedirectURI, url.Values{
+			"error":             {CodeAccessDenied},
+			"error_description": {"the user denied the request"},
+			"state":             {req.State},
+		}), nil
+	case DecisionAllow:
+		if err := uc.grantConsent(ctx, userID, client.ClientID, req.Scope); err != nil {
+			return "", err
+		}
+	default:
+		consent, err := uc.consent(ctx, userID, client.ClientID)
+		if err != nil {
+			return "", err
+		}
+		if !consent.Covers(req.Scope) {
+			return redirectURL(req.RedirectURI, url.Values{
+				"error":             {CodeConsentRequired},
+				"error_description": {"the user has not consented to the requested scopes"},
+				"state":             {req.State},
+			}), nil
+		}
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	method := req.CodeChallengeMethod
+	if req.CodeChallenge != "" && method == "" {
+		method = CodeChallengeMethodPlain
+	}
+
+	err = uc.repo.CreateAuthorizationCode(ctx, entities.AuthorizationCode{
+		CodeHash:            hashToken(code),
+		ClientID:            client.ClientID,
+		UserID:              userID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               normalizeScope(req.Scope),
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: method,
+		ExpiresAt:           time.Now().Add(uc.cfg.CodeTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store authorization code: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("issued authorization code", slog.String("client_id", client.ClientID), slog.String("user_id", userID.String()))
+
+	return redirectURL(req.RedirectURI, url.Values{
+		"code":  {code},
+		"state": {req.State},
+	}), nil
+}
+
+// Consent tells the consent screen which client is asking for which scopes,
+// and whether the user has to be asked at all. Like Authorize, it returns an
+// *Error when the client or redirect URI can't be trusted.
+func (uc *UseCase) Consent(ctx context.Context, userID uuid.UUID, req AuthorizeRequest) (ConsentPrompt, error) {
+	client, err := uc.requestClient(ctx, req)
+	if err != nil {
+		return ConsentPrompt{}, err
+	}
+
+	prompt := ConsentPrompt{
+		ClientName: client.Name,
+		Scopes:     strings.Fields(req.Scope),
+	}
+	if validateAuthorizeRequest(req) != nil {
+		return prompt, nil
+	}
+
+	consent, err := uc.consent(ctx, userID, client.ClientID)
+	if err != nil {
+		return ConsentPrompt{}, err
+	}
+	prompt.Required = req.Prompt == PromptConsent || !consent.Covers(req.Scope)
+	return prompt, nil
+}
+
+// requestClient returns the client of an authorization request, as long as
+// the redirect URI is registered for it.
+func (uc *UseCase) requestClient(ctx context.Context, req AuthorizeRequest) (entities.OAuthClient, error) {
+	if req.ClientID == "" {
+		return entities.OAuthClient{}, newError(CodeInvalidRequest, "client_id is required")
+	}
+
+	client, err := uc.repo.GetClient(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.OAuthClient{}, newError(CodeInvalidClient, "unknown client")
+		}
+		return entities.OAuthClient{}, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	if req.RedirectURI == "" || !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return entities.OAuthClient{}, newError(CodeInvalidRequest, "redirect_uri is not registered for this client")
+	}
+	return client, nil
+}
+
+// consent returns what the user consented to share with the client, which is
+// nothing if they never did.
+func (uc *UseCase) consent(ctx context.Context, userID uuid.UUID, clientID string) (entities.OAuthConsent, error) {
+	consent, err := uc.repo.GetConsent(ctx, userID, clientID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return entities.OAuthConsent{}, fmt.Errorf("failed to get consent: %w", err)
+	}
+	return consent, nil
+}
+
+// grantConsent adds scope to what the user consented to share with the client.
+func (uc *UseCase) grantConsent(ctx context.Context, userID uuid.UUID, clientID, scope string) error {
+	consent, err := uc.consent(ctx, userID, clientID)
+	if err != nil {
+		return err
+	}
+
+	granted := strings.Fields(consent.Scope)
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(granted, s) {
+			granted = append(granted, s)
+		}
+	}
+
+	err = uc.repo.SaveConsent(ctx, entities.OAuthConsent{
+		UserID:    userID,
+		ClientID:  clientID,
+		Scope:     strings.Join(granted, " "),
+		GrantedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save consent: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("oauth consent granted", slog.String("client_id", clientID), slog.String("user_id", userID.String()), slog.String("scope", scope))
+	return nil
+}
+
+// Exchange trades an authorization code for an access token and ID token.
+func (uc *UseCase) Exchange(ctx context.Context, req TokenRequest) (TokenResponse, error) {
+	if req.GrantType != "authorization_code" {
+		return TokenResponse{}, newError(CodeUnsupportedGrantType, "only authorization_code is supported")
+	}
+	if req.Code == "" {
+		return TokenResponse{}, newError(CodeInvalidRequest, "code is required")
+	}
+
+	client, err := uc.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	code, err := uc.repo.ConsumeAuthorizationCode(ctx, hashToken(req.Code))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return TokenResponse{}, newError(CodeInvalidGrant, "authorization code is invalid or already used")
+		}
+		return TokenResponse{}, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+
+	if code.ClientID != client.ClientID {
+		return TokenResponse{}, newError(CodeInvalidGrant, "authorization code was issued to another client")
+	}
+	if time.Now().After(code.ExpiresAt) {
+		return TokenResponse{}, newError(CodeInvalidGrant, "authorization code expired")
+	}
+	if code.RedirectURI != req.RedirectURI {
+		return TokenResponse{}, newError(CodeInvalidGrant, "redirect_uri does not match")
+	}
+	if !verifyCodeChallenge(code.CodeChallenge, code.CodeChallengeMethod, req.CodeVerifier) {
+		return TokenResponse{}, newError(CodeInvalidGrant, "code_verifier does not match")
+	}
+
+	user, err := uc.users.GetByID(ctx, code.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return TokenResponse{}, newError(CodeInvalidGrant, "user no longer exists")
+		}
+		return TokenResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := domain.CheckAccountStatus(user); err != nil {
+		return TokenResponse{}, newError(CodeInvalidGrant, "user account is not active")
+	}
+
+	now := time.Now()
+	subject := user.ID.String()
+
+	accessToken, err := uc.signer.Sign(AccessTokenClaims{
+		TokenUse: tokenUseAccess,
+		ClientID: client.ClientID,
+		Scope:    code.Scope,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Issuer:    uc.cfg.Issuer,
+			Subject:   subject,
+			Audience:  gojwt.ClaimStrings{client.ClientID},
+			ExpiresAt: gojwt.NewNumericDate(now.Add(uc.cfg.AccessTokenTTL)),
+			IssuedAt:  gojwt.NewNumericDate(now),
+			ID:        uuid.Must(uuid.NewV4()).String(),
+		},
+	})
+	if err != nil {
+		return TokenResponse{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	idClaims := IDTokenClaims{
+		Nonce:    code.Nonce,
+		AuthTime: code.CreatedAt.Unix(),
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Issuer:    uc.cfg.Issuer,
+			Subject:   subject,
+			Audience:  gojwt.ClaimStrings{client.ClientID},
+			ExpiresAt: gojwt.NewNumericDate(now.Add(uc.cfg.IDTokenTTL)),
+			IssuedAt:  gojwt.NewNumericDate(now),
+		},
+	}
+	if hasScope(code.Scope, ScopeEmail) {
+		idClaims.Email = user.Email
+	}
+
+	idToken, err := uc.signer.Sign(idClaims)
+	if err != nil {
+		return TokenResponse{}, fmt.Errorf("failed to sign id token: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("exchanged authorization code", slog.String("client_id", client.ClientID), slog.String("user_id", subject))
+
+	return TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(uc.cfg.AccessTokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       code.Scope,
+	}, nil
+}
+
+// UserInfo returns the claims of the user an access token was issued for.
+func (uc *UseCase) UserInfo(ctx context.Context, accessToken string) (UserInfo, error) {
+	var claims AccessTokenClaims
+	if err := uc.signer.Parse(accessToken, &claims); err != nil {
+		return UserInfo{}, newError(CodeInvalidToken, "access token is invalid or expired")
+	}
+	if claims.TokenUse != tokenUseAccess || claims.Issuer != uc.cfg.Issuer {
+		return UserInfo{}, newError(CodeInvalidToken, "not an access token issued by this provider")
+	}
+
+	userID, err := uuid.FromString(claims.Subject)
+	if err != nil {
+		return UserInfo{}, newError(CodeInvalidToken, "invalid subject")
+	}
+
+	user, err := uc.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return UserInfo{}, newError(CodeInvalidToken, "user no longer exists")
+		}
+		return UserInfo{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	// Access tokens live on after their user is suspended, so the status is
+	// checked on every use
+	if err := domain.CheckAccountStatus(user); err != nil {
+		return UserInfo{}, newError(CodeInvalidToken, "user account is not active")
+	}
+
+	info := UserInfo{Subject: claims.Subject}
+	if hasScope(claims.Scope, ScopeEmail) {
+		info.Email = user.Email
+	}
+	if hasScope(claims.Scope, ScopeProfile) {
+		info.AccountType = user.AccountType.String()
+	}
+	return info, nil
+}
+
+// RegisterClient creates a client application and returns it together with
+// its plain-text secret, which is not stored and can't be retrieved again.
+func (uc *UseCase) RegisterClient(ctx context.Context, name string, redirectURIs []string) (entities.OAuthClient, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return entities.OAuthClient{}, "", fmt.Errorf("missing name: %w", domain.ErrMalformedParameters)
+	}
+	if len(redirectURIs) == 0 {
+		return entities.OAuthClient{}, "", fmt.Errorf("at least one redirect uri is required: %w", domain.ErrMalformedParameters)
+	}
+	for _, raw := range redirectURIs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Fragment != "" {
+			return entities.OAuthClient{}, "", fmt.Errorf("invalid redirect uri %q: %w", raw, domain.ErrMalformedParameters)
+		}
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return entities.OAuthClient{}, "", err
+	}
+
+	now := time.Now()
+	client := entities.OAuthClient{
+		ID:               uuid.Must(uuid.NewV4()),
+		ClientID:         uuid.Must(uuid.NewV4()).String(),
+		ClientSecretHash: hashToken(secret),
+		Name:             name,
+		RedirectURIs:     redirectURIs,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := uc.repo.CreateClient(ctx, client); err != nil {
+		return entities.OAuthClient{}, "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("registered oauth client", slog.String("client_id", client.ClientID), slog.String("name", name))
+	return client, secret, nil
+}
+
+func (uc *UseCase) ListClients(ctx context.Context) ([]entities.OAuthClient, error) {
+	return uc.repo.ListClients(ctx)
+}
+
+func (uc *UseCase) DeleteClient(ctx context.Context, clientID string) error {
+	if err := uc.repo.DeleteClient(ctx, clientID); err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+	domain.LoggerOr(ctx, uc.logger).Info("deleted oauth client", slog.String("client_id", clientID))
+	return nil
+}
+
+func (uc *UseCase) authenticateClient(ctx context.Context, clientID, clientSecret string) (entities.OAuthClient, error) {
+	if clientID == "" || clientSecret == "" {
+		return entities.OAuthClient{}, newError(CodeInvalidClient, "client authentication required")
+	}
+
+	client, err := uc.repo.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.OAuthClient{}, newError(CodeInvalidClient, "client authentication failed")
+		}
+		return entities.OAuthClient{}, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(clientSecret)), []byte(client.ClientSecretHash)) != 1 {
+		return entities.OAuthClient{}, newError(CodeInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+func validateAuthorizeRequest(req AuthorizeRequest) *Error {
+	if req.ResponseType != "code" {
+		return newError(CodeUnsupportedResponseType, "only the code response type is supported")
+	}
+	if !hasScope(req.Scope, ScopeOpenID) {
+		return newError(CodeInvalidScope, "the openid scope is required")
+	}
+	for _, s := range strings.Fields(req.Scope) {
+		if !slices.Contains(supportedScopes, s) {
+			return newError(CodeInvalidScope, "unsupported scope "+s)
+		}
+	}
+	switch req.CodeChallengeMethod {
+	case "", CodeChallengeMethodS256, CodeChallengeMethodPlain:
+	default:
+		return newError(CodeInvalidRequest, "unsupported code_challenge_method")
+	}
+	if req.CodeChallengeMethod != "" && req.CodeChallenge == "" {
+		return newError(CodeInvalidRequest, "code_challenge is required with code_challenge_method")
+	}
+	return nil
+}
+
+func verifyCodeChallenge(challenge, method, verifier string) bool {
+	if challenge == "" {
+		return true
+	}
+	if verifier == "" {
+		return false
+	}
+
+	expected := verifier
+	if method == CodeChallengeMethodS256 {
+		sum := sha256.Sum256([]byte(verifier))
+		expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+func hasScope(scope, want string) bool {
+	return slices.Contains(strings.Fields(scope), want)
+}
+
+func normalizeScope(scope string) string {
+	return strings.Join(strings.Fields(scope), " ")
+}
+
+func redirectURL(base string, params url.Values) string {
+	for k, v := range params {
+		if len(v) == 0 || v[0] == "" {
+			delete(params, k)
+		}
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
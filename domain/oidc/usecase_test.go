@@ -0,0 +1,416 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	moidc "go-template/domain/oidc/mocks"
+	"go-template/internal/jwt"
+	"io"
+	"log/slog"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURI = "https://app.example.com/callback"
+
+func newTestUseCase(t *testing.T, repo *moidc.RepositoryMock, users *moidc.UserRepositoryMock) *UseCase {
+	t.Helper()
+	key, err := jwt.GenerateRSAKey()
+	require.NoError(t, err)
+
+	return NewUseCase(repo, users, key, Config{
+		Issuer:                "https://id.example.com/",
+		AuthorizationEndpoint: "https://web.example.com/oauth2/authorize",
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func testClient() entities.OAuthClient {
+	return entities.OAuthClient{
+		ClientID:         "client-1",
+		ClientSecretHash: hashToken("secret"),
+		RedirectURIs:     []string{testRedirectURI},
+	}
+}
+
+func TestUseCase_Authorize(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name       string
+		req        AuthorizeRequest
+		consent    string
+		wantErr    string
+		wantParams map[string]string
+	}{
+		{
+			name:    "unknown client",
+			req:     AuthorizeRequest{ClientID: "missing", RedirectURI: testRedirectURI},
+			wantErr: CodeInvalidClient,
+		},
+		{
+			name:    "unregistered redirect uri",
+			req:     AuthorizeRequest{ClientID: "client-1", RedirectURI: "https://evil.example.com"},
+			wantErr: CodeInvalidRequest,
+		},
+		{
+			name:       "missing openid scope is reported to the client",
+			req:        AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "email", State: "xyz"},
+			wantParams: map[string]string{"error": CodeInvalidScope, "state": "xyz"},
+		},
+		{
+			name:       "unsupported response type",
+			req:        AuthorizeRequest{ResponseType: "token", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid"},
+			wantParams: map[string]string{"error": CodeUnsupportedResponseType},
+		},
+		{
+			name:       "allowed on the consent screen",
+			req:        AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email", State: "xyz", Decision: DecisionAllow},
+			wantParams: map[string]string{"state": "xyz"},
+		},
+		{
+			name:       "consented before",
+			req:        AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email", State: "xyz"},
+			consent:    "openid email profile",
+			wantParams: map[string]string{"state": "xyz"},
+		},
+		{
+			name:       "scopes beyond the consent",
+			req:        AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email", State: "xyz"},
+			consent:    "openid",
+			wantParams: map[string]string{"error": CodeConsentRequired, "state": "xyz"},
+		},
+		{
+			name:       "denied on the consent screen",
+			req:        AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email", State: "xyz", Decision: DecisionDeny},
+			consent:    "openid email",
+			wantParams: map[string]string{"error": CodeAccessDenied, "state": "xyz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored *entities.AuthorizationCode
+			repo := &moidc.RepositoryMock{
+				GetClientFunc: func(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+					if clientID != "client-1" {
+						return entities.OAuthClient{}, domain.ErrNotFound
+					}
+					return testClient(), nil
+				},
+				CreateAuthorizationCodeFunc: func(ctx context.Context, code entities.AuthorizationCode) error {
+					stored = &code
+					return nil
+				},
+				GetConsentFunc: func(ctx context.Context, id uuid.UUID, clientID string) (entities.OAuthConsent, error) {
+					if tt.consent == "" {
+						return entities.OAuthConsent{}, domain.ErrNotFound
+					}
+					return entities.OAuthConsent{UserID: id, ClientID: clientID, Scope: tt.consent}, nil
+				},
+			}
+			uc := newTestUseCase(t, repo, &moidc.UserRepositoryMock{})
+
+			redirect, err := uc.Authorize(context.Background(), userID, tt.req)
+			if tt.wantErr != "" {
+				var oauthErr *Error
+				require.ErrorAs(t, err, &oauthErr)
+				assert.Equal(t, tt.wantErr, oauthErr.Code)
+				return
+			}
+			require.NoError(t, err)
+
+			u, err := url.Parse(redirect)
+			require.NoError(t, err)
+			for k, v := range tt.wantParams {
+				assert.Equal(t, v, u.Query().Get(k))
+			}
+
+			if tt.wantParams["error"] == "" {
+				require.NotNil(t, stored)
+				assert.Equal(t, hashToken(u.Query().Get("code")), stored.CodeHash)
+				assert.Equal(t, userID, stored.UserID)
+				assert.Equal(t, "openid email", stored.Scope)
+			} else {
+				assert.Nil(t, stored)
+			}
+
+			if tt.req.Decision == DecisionAllow {
+				require.Len(t, repo.SaveConsentCalls(), 1)
+				assert.Equal(t, "openid email", repo.SaveConsentCalls()[0].Consent.Scope)
+			} else {
+				assert.Empty(t, repo.SaveConsentCalls())
+			}
+		})
+	}
+}
+
+func TestUseCase_Consent(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	req := AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email"}
+
+	tests := []struct {
+		name         string
+		req          AuthorizeRequest
+		consent      string
+		wantRequired bool
+	}{
+		{name: "never consented", req: req, wantRequired: true},
+		{name: "consented to fewer scopes", req: req, consent: "openid", wantRequired: true},
+		{name: "consented to the scopes", req: req, consent: "email openid"},
+		{name: "prompt=consent asks again", req: AuthorizeRequest{ResponseType: "code", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid email", Prompt: PromptConsent}, consent: "openid email", wantRequired: true},
+		{name: "invalid requests go straight to Authorize", req: AuthorizeRequest{ResponseType: "token", ClientID: "client-1", RedirectURI: testRedirectURI, Scope: "openid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &moidc.RepositoryMock{
+				GetClientFunc: func(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+					client := testClient()
+					client.Name = "Example App"
+					return client, nil
+				},
+				GetConsentFunc: func(ctx context.Context, id uuid.UUID, clientID string) (entities.OAuthConsent, error) {
+					if tt.consent == "" {
+						return entities.OAuthConsent{}, domain.ErrNotFound
+					}
+					return entities.OAuthConsent{Scope: tt.consent}, nil
+				},
+			}
+			uc := newTestUseCase(t, repo, &moidc.UserRepositoryMock{})
+
+			prompt, err := uc.Consent(context.Background(), userID, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, "Example App", prompt.ClientName)
+			assert.Equal(t, tt.wantRequired, prompt.Required)
+		})
+	}
+
+	// The client must be trusted before anything is shown
+	uc := newTestUseCase(t, &moidc.RepositoryMock{
+		GetClientFunc: func(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+			return testClient(), nil
+		},
+	}, &moidc.UserRepositoryMock{})
+	_, err := uc.Consent(context.Background(), userID, AuthorizeRequest{ClientID: "client-1", RedirectURI: "https://evil.example.com"})
+	var oauthErr *Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, CodeInvalidRequest, oauthErr.Code)
+}
+
+func TestUseCase_Exchange(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	verifier := "a-long-random-code-verifier-value-for-pkce"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	validCode := entities.AuthorizationCode{
+		CodeHash:            hashToken("code-1"),
+		ClientID:            "client-1",
+		UserID:              userID,
+		RedirectURI:         testRedirectURI,
+		Scope:               "openid email",
+		Nonce:               "n-0S6",
+		CodeChallenge:       challenge,
+		CodeChallengeMethod: CodeChallengeMethodS256,
+		ExpiresAt:           time.Now().Add(time.Minute),
+		CreatedAt:           time.Now(),
+	}
+
+	validReq := TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "code-1",
+		RedirectURI:  testRedirectURI,
+		ClientID:     "client-1",
+		ClientSecret: "secret",
+		CodeVerifier: verifier,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *TokenRequest, c *entities.AuthorizationCode)
+		wantErr string
+	}{
+		{name: "success", mutate: func(*TokenRequest, *entities.AuthorizationCode) {}},
+		{
+			name:    "unsupported grant type",
+			mutate:  func(r *TokenRequest, _ *entities.AuthorizationCode) { r.GrantType = "password" },
+			wantErr: CodeUnsupportedGrantType,
+		},
+		{
+			name:    "wrong client secret",
+			mutate:  func(r *TokenRequest, _ *entities.AuthorizationCode) { r.ClientSecret = "nope" },
+			wantErr: CodeInvalidClient,
+		},
+		{
+			name:    "expired code",
+			mutate:  func(_ *TokenRequest, c *entities.AuthorizationCode) { c.ExpiresAt = time.Now().Add(-time.Second) },
+			wantErr: CodeInvalidGrant,
+		},
+		{
+			name:    "redirect uri mismatch",
+			mutate:  func(r *TokenRequest, _ *entities.AuthorizationCode) { r.RedirectURI = "https://app.example.com/other" },
+			wantErr: CodeInvalidGrant,
+		},
+		{
+			name:    "wrong code verifier",
+			mutate:  func(r *TokenRequest, _ *entities.AuthorizationCode) { r.CodeVerifier = "wrong" },
+			wantErr: CodeInvalidGrant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validReq
+			code := validCode
+			tt.mutate(&req, &code)
+
+			repo := &moidc.RepositoryMock{
+				GetClientFunc: func(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+					return testClient(), nil
+				},
+				ConsumeAuthorizationCodeFunc: func(ctx context.Context, codeHash string) (entities.AuthorizationCode, error) {
+					if codeHash != code.CodeHash {
+						return entities.AuthorizationCode{}, domain.ErrNotFound
+					}
+					return code, nil
+				},
+			}
+			users := &moidc.UserRepositoryMock{
+				GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+					return entities.User{ID: id, Email: "user@example.com", AccountType: entities.AccountTypeUser}, nil
+				},
+			}
+			uc := newTestUseCase(t, repo, users)
+
+			resp, err := uc.Exchange(context.Background(), req)
+			if tt.wantErr != "" {
+				var oauthErr *Error
+				require.ErrorAs(t, err, &oauthErr)
+				assert.Equal(t, tt.wantErr, oauthErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer", resp.TokenType)
+
+			var idClaims IDTokenClaims
+			require.NoError(t, uc.signer.Parse(resp.IDToken, &idClaims))
+			assert.Equal(t, "https://id.example.com", idClaims.Issuer)
+			assert.Equal(t, userID.String(), idClaims.Subject)
+			assert.Equal(t, "n-0S6", idClaims.Nonce)
+			assert.Equal(t, "user@example.com", idClaims.Email)
+			assert.Contains(t, []string(idClaims.Audience), "client-1")
+
+			info, err := uc.UserInfo(context.Background(), resp.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, userID.String(), info.Subject)
+			assert.Equal(t, "user@example.com", info.Email)
+		})
+	}
+}
+
+func TestUseCase_SuspendedUser(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	verifier := "a-long-random-code-verifier-value-for-pkce"
+	sum := sha256.Sum256([]byte(verifier))
+
+	status := entities.UserStatusActive
+	repo := &moidc.RepositoryMock{
+		GetClientFunc: func(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+			return testClient(), nil
+		},
+		ConsumeAuthorizationCodeFunc: func(ctx context.Context, codeHash string) (entities.AuthorizationCode, error) {
+			return entities.AuthorizationCode{
+				CodeHash:            codeHash,
+				ClientID:            "client-1",
+				UserID:              userID,
+				RedirectURI:         testRedirectURI,
+				Scope:               "openid email",
+				CodeChallenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				ExpiresAt:           time.Now().Add(time.Minute),
+				CreatedAt:           time.Now(),
+			}, nil
+		},
+	}
+	users := &moidc.UserRepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return entities.User{ID: id, Email: "user@example.com", AccountType: entities.AccountTypeUser, Status: status}, nil
+		},
+	}
+	uc := newTestUseCase(t, repo, users)
+	req := TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "code-1",
+		RedirectURI:  testRedirectURI,
+		ClientID:     "client-1",
+		ClientSecret: "secret",
+		CodeVerifier: verifier,
+	}
+
+	resp, err := uc.Exchange(context.Background(), req)
+	require.NoError(t, err)
+
+	status = entities.UserStatusSuspended
+
+	_, err = uc.UserInfo(context.Background(), resp.AccessToken)
+	var oauthErr *Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, CodeInvalidToken, oauthErr.Code)
+
+	_, err = uc.Exchange(context.Background(), req)
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, CodeInvalidGrant, oauthErr.Code)
+}
+
+func TestUseCase_UserInfo_RejectsIDToken(t *testing.T) {
+	uc := newTestUseCase(t, &moidc.RepositoryMock{}, &moidc.UserRepositoryMock{})
+
+	idToken, err := uc.signer.Sign(IDTokenClaims{})
+	require.NoError(t, err)
+
+	_, err = uc.UserInfo(context.Background(), idToken)
+	var oauthErr *Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, CodeInvalidToken, oauthErr.Code)
+}
+
+func TestUseCase_RegisterClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientName   string
+		redirectURIs []string
+		wantErr      error
+	}{
+		{name: "missing name", redirectURIs: []string{testRedirectURI}, wantErr: domain.ErrMalformedParameters},
+		{name: "missing redirect uris", clientName: "App", wantErr: domain.ErrMalformedParameters},
+		{name: "relative redirect uri", clientName: "App", redirectURIs: []string{"/callback"}, wantErr: domain.ErrMalformedParameters},
+		{name: "success", clientName: "App", redirectURIs: []string{testRedirectURI}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &moidc.RepositoryMock{}
+			uc := newTestUseCase(t, repo, &moidc.UserRepositoryMock{})
+
+			client, secret, err := uc.RegisterClient(context.Background(), tt.clientName, tt.redirectURIs)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, client.ClientID)
+			assert.NotEmpty(t, secret)
+			assert.Equal(t, hashToken(secret), client.ClientSecretHash)
+			assert.Len(t, repo.CreateClientCalls(), 1)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrWeakPassword is returned by Validate when a password breaks the policy.
+// The wrapped message says which rules it breaks.
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+
+// fallbackMinLength applies when the settings hold no usable minimum length.
+const fallbackMinLength = 8
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/settings_reader.go . SettingsReader
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/breach_checker.go . BreachChecker
+
+// SettingsReader loads the system settings, which hold the password policy.
+type SettingsReader interface {
+	GetSettings(ctx context.Context) (*entities.SystemSettings, error)
+}
+
+// BreachChecker looks passwords up in known data breaches.
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// UseCase checks new passwords against the policy admins set in the system
+// settings: a minimum length, optional complexity rules and, when a breach
+// checker is set, rejecting passwords found in known data breaches.
+type UseCase struct {
+	settings SettingsReader
+	breaches BreachChecker
+	logger   *slog.Logger
+}
+
+func NewUseCase(settings SettingsReader, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// SetBreachChecker enables the PasswordCheckBreached setting.
+func (uc *UseCase) SetBreachChecker(breaches BreachChecker) {
+	uc.breaches = breaches
+}
+
+// Validate returns an error wrapping ErrWeakPassword when password breaks the
+// policy. An unavailable breach check doesn't block the password.
+func (uc *UseCase) Validate(ctx context.Context, password string) error {
+	settings, err := uc.settings.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	if problems := complexityProblems(settings, password); len(problems) > 0 {
+		return fmt.Errorf("%w: it must %s", ErrWeakPassword, strings.Join(problems, ", "))
+	}
+
+	if !settings.PasswordCheckBreached || uc.breaches == nil {
+		return nil
+	}
+	breached, err := uc.breaches.Breached(ctx, password)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Warn("failed to check password against known breaches", "error", err)
+		return nil
+	}
+	if breached {
+		return fmt.Errorf("%w: it has appeared in a data breach, choose a different one", ErrWeakPassword)
+	}
+	return nil
+}
+
+// complexityProblems lists the length and character rules password breaks.
+func complexityProblems(settings *entities.SystemSettings, password string) []string {
+	minLength := settings.MinPasswordLength
+	if minLength <= 0 {
+		minLength = fallbackMinLength
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	var problems []string
+	if utf8.RuneCountInString(password) < minLength {
+		problems = append(problems, fmt.Sprintf("be at least %d characters long", minLength))
+	}
+	if settings.PasswordRequireMixedCase && !(upper && lower) {
+		problems = append(problems, "contain upper and lower case letters")
+	}
+	if settings.PasswordRequireDigit && !digit {
+		problems = append(problems, "contain a digit")
+	}
+	if settings.PasswordRequireSymbol && !symbol {
+		problems = append(problems, "contain a symbol")
+	}
+	return problems
+}
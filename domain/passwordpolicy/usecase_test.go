@@ -0,0 +1,119 @@
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/domain/passwordpolicy/mocks"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(settings entities.SystemSettings) *UseCase {
+	return NewUseCase(&mocks.SettingsReaderMock{
+		GetSettingsFunc: func(ctx context.Context) (*entities.SystemSettings, error) {
+			return &settings, nil
+		},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Validate(t *testing.T) {
+	strict := entities.SystemSettings{
+		MinPasswordLength:        10,
+		PasswordRequireMixedCase: true,
+		PasswordRequireDigit:     true,
+		PasswordRequireSymbol:    true,
+	}
+
+	tests := []struct {
+		name     string
+		settings entities.SystemSettings
+		password string
+		problem  string
+	}{
+		{name: "long enough", settings: entities.SystemSettings{MinPasswordLength: 6}, password: "secret"},
+		{name: "too short", settings: entities.SystemSettings{MinPasswordLength: 8}, password: "secret", problem: "be at least 8 characters long"},
+		{name: "counts characters not bytes", settings: entities.SystemSettings{MinPasswordLength: 6}, password: "sécrét"},
+		{name: "falls back without a minimum", password: "secret", problem: "be at least 8 characters long"},
+		{name: "meets every rule", settings: strict, password: "Correct-Horse-9"},
+		{name: "missing upper case", settings: strict, password: "correct-horse-9", problem: "contain upper and lower case letters"},
+		{name: "missing digit", settings: strict, password: "Correct-Horse", problem: "contain a digit"},
+		{name: "missing symbol", settings: strict, password: "CorrectHorse9", problem: "contain a symbol"},
+		{name: "lists every problem", settings: strict, password: "abc", problem: "be at least 10 characters long, contain upper and lower case letters, contain a digit, contain a symbol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestUseCase(tt.settings).Validate(context.Background(), tt.password)
+			if tt.problem == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrWeakPassword)
+			assert.ErrorContains(t, err, tt.problem)
+		})
+	}
+}
+
+func TestUseCase_Validate_Breached(t *testing.T) {
+	breaches := &mocks.BreachCheckerMock{
+		BreachedFunc: func(ctx context.Context, password string) (bool, error) {
+			return password == "password123", nil
+		},
+	}
+	uc := newTestUseCase(entities.SystemSettings{MinPasswordLength: 8, PasswordCheckBreached: true})
+	uc.SetBreachChecker(breaches)
+
+	err := uc.Validate(context.Background(), "password123")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.ErrorContains(t, err, "data breach")
+
+	assert.NoError(t, uc.Validate(context.Background(), "unlisted-passphrase"))
+}
+
+func TestUseCase_Validate_BreachCheckSkipped(t *testing.T) {
+	breaches := &mocks.BreachCheckerMock{}
+
+	// Disabled in the settings
+	uc := newTestUseCase(entities.SystemSettings{MinPasswordLength: 8})
+	uc.SetBreachChecker(breaches)
+	require.NoError(t, uc.Validate(context.Background(), "password123"))
+	assert.Empty(t, breaches.BreachedCalls())
+
+	// Not checked when the password already breaks the policy
+	uc = newTestUseCase(entities.SystemSettings{MinPasswordLength: 20, PasswordCheckBreached: true})
+	uc.SetBreachChecker(breaches)
+	assert.ErrorIs(t, uc.Validate(context.Background(), "password123"), ErrWeakPassword)
+	assert.Empty(t, breaches.BreachedCalls())
+
+	// Enabled without a checker
+	uc = newTestUseCase(entities.SystemSettings{MinPasswordLength: 8, PasswordCheckBreached: true})
+	assert.NoError(t, uc.Validate(context.Background(), "password123"))
+}
+
+func TestUseCase_Validate_BreachCheckUnavailable(t *testing.T) {
+	uc := newTestUseCase(entities.SystemSettings{MinPasswordLength: 8, PasswordCheckBreached: true})
+	uc.SetBreachChecker(&mocks.BreachCheckerMock{
+		BreachedFunc: func(ctx context.Context, password string) (bool, error) {
+			return false, errors.New("timeout")
+		},
+	})
+
+	assert.NoError(t, uc.Validate(context.Background(), "password123"))
+}
+
+func TestUseCase_Validate_SettingsError(t *testing.T) {
+	uc := NewUseCase(&mocks.SettingsReaderMock{
+		GetSettingsFunc: func(ctx context.Context) (*entities.SystemSettings, error) {
+			return nil, errors.New("connection refused")
+		},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := uc.Validate(context.Background(), "Correct-Horse-9")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrWeakPassword)
+}
@@ -0,0 +1,18 @@
+package preferences
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	// GetPreferences returns domain.ErrNotFound when the user never saved
+	// any.
+	GetPreferences(ctx context.Context, userID uuid.UUID) (entities.Preferences, error)
+	// SavePreferences replaces the user's preferences.
+	SavePreferences(ctx context.Context, userID uuid.UUID, prefs entities.Preferences) error
+}
@@ -0,0 +1,125 @@
+package preferences
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"maps"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// MaxSize is the most a user's preferences take as JSON, in bytes.
+	MaxSize = 16 << 10
+	// MaxKeyLength is the longest preference key, in bytes.
+	MaxKeyLength = 64
+)
+
+// UseCase keeps the settings users choose for themselves, such as the theme
+// of the apps or which emails they get.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Get returns the user's preferences, empty when they never saved any.
+func (uc *UseCase) Get(ctx context.Context, userID uuid.UUID) (entities.Preferences, error) {
+	prefs, err := uc.repo.GetPreferences(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return entities.Preferences{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// Update applies patch to the user's preferences the way a JSON merge patch
+// does at the top level: keys set to nil are removed, and the others
+// replace what was there. Known keys must hold values of their type, and
+// invalid patches are reported with domain.ErrMalformedParameters.
+func (uc *UseCase) Update(ctx context.Context, userID uuid.UUID, patch map[string]any) (entities.Preferences, error) {
+	prefs, err := uc.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := maps.Clone(prefs)
+	if merged == nil {
+		merged = entities.Preferences{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		if err := validate(key, value); err != nil {
+			return nil, err
+		}
+		merged[key] = value
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preferences: %w", domain.ErrMalformedParameters)
+	}
+	if len(b) > MaxSize {
+		return nil, fmt.Errorf("preferences are larger than %d bytes: %w", MaxSize, domain.ErrMalformedParameters)
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: preferences not saved", "user_id", userID)
+		return merged, nil
+	}
+
+	if err := uc.repo.SavePreferences(ctx, userID, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func validate(key string, value any) error {
+	if key == "" || len(key) > MaxKeyLength {
+		return fmt.Errorf("preference keys must be 1 to %d bytes long: %w", MaxKeyLength, domain.ErrMalformedParameters)
+	}
+
+	switch key {
+	case entities.PreferenceTheme:
+		if theme, ok := value.(string); !ok || !entities.Theme(theme).Valid() {
+			return fmt.Errorf("%s must be light, dark or system: %w", key, domain.ErrMalformedParameters)
+		}
+	case entities.PreferenceEmailSecurityAlerts, entities.PreferenceEmailProductUpdates:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be true or false: %w", key, domain.ErrMalformedParameters)
+		}
+	case entities.PreferenceNotificationChannels:
+		kinds, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must map notification kinds to lists of channels: %w", key, domain.ErrMalformedParameters)
+		}
+		for kind, channels := range kinds {
+			list, ok := channels.([]any)
+			if !ok {
+				return fmt.Errorf("%s.%s must be a list of channels: %w", key, kind, domain.ErrMalformedParameters)
+			}
+			for _, c := range list {
+				if s, ok := c.(string); !ok || !entities.NotificationChannel(s).Valid() {
+					return fmt.Errorf("%s.%s channels must be in_app or email: %w", key, kind, domain.ErrMalformedParameters)
+				}
+			}
+		}
+	}
+	return nil
+}
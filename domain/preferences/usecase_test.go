@@ -0,0 +1,91 @@
+package preferences
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/preferences/mocks"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Get(t *testing.T) {
+	uc := newTestUseCase(&mocks.RepositoryMock{
+		GetPreferencesFunc: func(ctx context.Context, userID uuid.UUID) (entities.Preferences, error) {
+			return nil, domain.ErrNotFound
+		},
+	})
+
+	prefs, err := uc.Get(context.Background(), uuid.Must(uuid.NewV4()))
+	require.NoError(t, err)
+	assert.Empty(t, prefs)
+	assert.Equal(t, entities.ThemeLight, prefs.Theme())
+	assert.True(t, prefs.EmailSecurityAlerts())
+	assert.False(t, prefs.EmailProductUpdates())
+}
+
+func TestUseCase_Update(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	stored := entities.Preferences{"theme": "dark", "sidebar": "collapsed"}
+	repo := &mocks.RepositoryMock{
+		GetPreferencesFunc: func(ctx context.Context, id uuid.UUID) (entities.Preferences, error) {
+			return stored, nil
+		},
+		SavePreferencesFunc: func(ctx context.Context, id uuid.UUID, prefs entities.Preferences) error {
+			stored = prefs
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo)
+	ctx := context.Background()
+
+	prefs, err := uc.Update(ctx, userID, map[string]any{"sidebar": nil, "email_product_updates": true})
+	require.NoError(t, err)
+	assert.Equal(t, entities.Preferences{"theme": "dark", "email_product_updates": true}, prefs)
+	assert.Equal(t, prefs, stored)
+	assert.Equal(t, entities.ThemeDark, prefs.Theme())
+	assert.True(t, prefs.EmailProductUpdates())
+
+	for name, patch := range map[string]map[string]any{
+		"unknown theme":   {"theme": "neon"},
+		"non-bool email":  {"email_security_alerts": "yes"},
+		"long key":        {strings.Repeat("k", MaxKeyLength+1): 1},
+		"too large":       {"notes": strings.Repeat("x", MaxSize)},
+		"channels list":   {"notification_channels": []any{"email"}},
+		"unknown channel": {"notification_channels": map[string]any{"export_ready": []any{"sms"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.Update(ctx, userID, patch)
+			assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+		})
+	}
+	assert.Len(t, repo.SavePreferencesCalls(), 1)
+
+	t.Run("dry run saves nothing", func(t *testing.T) {
+		prefs, err := uc.Update(domain.WithDryRun(ctx), userID, map[string]any{"theme": "system"})
+		require.NoError(t, err)
+		assert.Equal(t, entities.ThemeSystem, prefs.Theme())
+		assert.Len(t, repo.SavePreferencesCalls(), 1)
+		assert.Equal(t, entities.ThemeDark, stored.Theme())
+	})
+
+	t.Run("notification channels", func(t *testing.T) {
+		prefs, err := uc.Update(ctx, userID, map[string]any{
+			"notification_channels": map[string]any{"export_ready": []any{"in_app", "email"}, "export_failed": []any{}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []entities.NotificationChannel{entities.NotificationInApp, entities.NotificationEmail}, prefs.NotificationChannels(entities.NotificationExportReady))
+		assert.Empty(t, prefs.NotificationChannels(entities.NotificationExportFailed))
+		assert.Equal(t, []entities.NotificationChannel{entities.NotificationInApp}, prefs.NotificationChannels("other"), "kinds without a choice are shown in the apps")
+	})
+}
@@ -0,0 +1,24 @@
+package reconciliation
+
+import (
+	"context"
+	"go-template/domain/entities"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Provider
+
+// Repository reads and repairs the local users of an auth provider.
+type Repository interface {
+	ListUsersByAuthProvider(ctx context.Context, provider string) ([]entities.User, error)
+	GetByAuthProviderID(ctx context.Context, provider, providerID string) (entities.User, error)
+	Update(ctx context.Context, user entities.User) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Provider lists every account held by the auth provider.
+type Provider interface {
+	Provider() string
+	ListUsers(ctx context.Context) ([]entities.ProviderUser, error)
+}
@@ -0,0 +1,261 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sourceScheduled = "scheduled"
+	sourceWebhook   = "webhook"
+)
+
+// ErrEmptyProvider stops a run that would flag every local user because the
+// provider listed no accounts at all, which is far more likely to be a
+// misconfigured key than a real mass deletion.
+var ErrEmptyProvider = errors.New("auth provider listed no users")
+
+// UseCase keeps the users table in line with the auth provider. Users deleted
+// or modified directly on the provider are flagged as drift and, when repair
+// is enabled, fixed locally: provider emails win and users whose provider
+// account is gone are deleted. Accounts missing locally are only flagged.
+type UseCase struct {
+	repo     Repository
+	provider Provider
+	repair   bool
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	report entities.ReconciliationReport
+}
+
+func NewUseCase(repo Repository, provider Provider, repair bool, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		provider: provider,
+		repair:   repair,
+		logger:   logger,
+		now:      time.Now,
+		report: entities.ReconciliationReport{
+			Provider:      provider.Provider(),
+			RepairEnabled: repair,
+			Drift:         []entities.UserDrift{},
+		},
+	}
+}
+
+// Run compares every local user of the provider with the provider's accounts.
+// The report replaces the previous one, including drift from webhooks.
+func (uc *UseCase) Run(ctx context.Context) (entities.ReconciliationReport, error) {
+	report := entities.ReconciliationReport{
+		Provider:      uc.provider.Provider(),
+		StartedAt:     uc.now(),
+		RepairEnabled: uc.repair,
+		Drift:         []entities.UserDrift{},
+	}
+
+	err := uc.reconcile(ctx, &report)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.FinishedAt = uc.now()
+
+	uc.mu.Lock()
+	uc.report = report
+	uc.mu.Unlock()
+
+	domain.LoggerOr(ctx, uc.logger).Info("user reconciliation finished",
+		slog.String("provider", report.Provider),
+		slog.Int("local_users", report.LocalUsers),
+		slog.Int("provider_users", report.ProviderUsers),
+		slog.Int("drift", len(report.Drift)),
+	)
+
+	return report, err
+}
+
+// Report returns the latest reconciliation report.
+func (uc *UseCase) Report(ctx context.Context) (entities.ReconciliationReport, error) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	report := uc.report
+	report.Drift = append([]entities.UserDrift{}, uc.report.Drift...)
+	return report, nil
+}
+
+// HandleEvent checks a single account pushed by the provider. Creations are
+// ignored since registration writes the local user right after the provider.
+func (uc *UseCase) HandleEvent(ctx context.Context, event entities.ProviderEvent) error {
+	if event.Provider != uc.provider.Provider() || event.Type == entities.ProviderEventCreated {
+		return nil
+	}
+
+	user, err := uc.repo.GetByAuthProviderID(ctx, event.Provider, event.User.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("getting user by provider ID: %w", err)
+	}
+
+	var drift *entities.UserDrift
+	switch event.Type {
+	case entities.ProviderEventDeleted:
+		d := uc.missingInProvider(ctx, user, sourceWebhook)
+		drift = &d
+	case entities.ProviderEventUpdated:
+		if !sameEmail(user.Email, event.User.Email) {
+			d := uc.emailMismatch(ctx, user, event.User, sourceWebhook)
+			drift = &d
+		}
+	}
+
+	if drift != nil {
+		uc.mu.Lock()
+		uc.report.Drift = append(uc.report.Drift, *drift)
+		uc.mu.Unlock()
+	}
+	return nil
+}
+
+// Start reconciles every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.Run(ctx); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("user reconciliation failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *UseCase) reconcile(ctx context.Context, report *entities.ReconciliationReport) error {
+	local, err := uc.repo.ListUsersByAuthProvider(ctx, report.Provider)
+	if err != nil {
+		return fmt.Errorf("listing local users: %w", err)
+	}
+	remote, err := uc.provider.ListUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("listing provider users: %w", err)
+	}
+
+	report.LocalUsers = len(local)
+	report.ProviderUsers = len(remote)
+	if len(remote) == 0 && len(local) > 0 {
+		return ErrEmptyProvider
+	}
+
+	unmatched := make(map[string]entities.ProviderUser, len(remote))
+	for _, p := range remote {
+		unmatched[p.ID] = p
+	}
+
+	for _, user := range local {
+		p, ok := unmatched[user.AuthProviderID]
+		if !ok {
+			report.Drift = append(report.Drift, uc.missingInProvider(ctx, user, sourceScheduled))
+			continue
+		}
+		delete(unmatched, user.AuthProviderID)
+
+		if !sameEmail(user.Email, p.Email) {
+			report.Drift = append(report.Drift, uc.emailMismatch(ctx, user, p, sourceScheduled))
+		}
+	}
+
+	ids := make([]string, 0, len(unmatched))
+	for id := range unmatched {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		drift := entities.UserDrift{
+			Kind:           entities.DriftMissingLocally,
+			AuthProviderID: id,
+			ProviderEmail:  unmatched[id].Email,
+			Source:         sourceScheduled,
+			DetectedAt:     uc.now(),
+		}
+		uc.logDrift(drift)
+		report.Drift = append(report.Drift, drift)
+	}
+
+	return nil
+}
+
+func (uc *UseCase) missingInProvider(ctx context.Context, user entities.User, source string) entities.UserDrift {
+	drift := entities.UserDrift{
+		Kind:           entities.DriftMissingInProvider,
+		UserID:         &user.ID,
+		AuthProviderID: user.AuthProviderID,
+		LocalEmail:     user.Email,
+		Source:         source,
+		DetectedAt:     uc.now(),
+	}
+
+	if uc.repair {
+		if err := uc.repo.Delete(ctx, user.ID); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to delete user missing in provider", "user_id", user.ID, "error", err)
+		} else {
+			drift.Repaired = true
+		}
+	}
+
+	uc.logDrift(drift)
+	return drift
+}
+
+func (uc *UseCase) emailMismatch(ctx context.Context, user entities.User, p entities.ProviderUser, source string) entities.UserDrift {
+	drift := entities.UserDrift{
+		Kind:           entities.DriftEmailMismatch,
+		UserID:         &user.ID,
+		AuthProviderID: user.AuthProviderID,
+		LocalEmail:     user.Email,
+		ProviderEmail:  p.Email,
+		Source:         source,
+		DetectedAt:     uc.now(),
+	}
+
+	if uc.repair {
+		user.Email = p.Email
+		user.UpdatedAt = uc.now()
+		if err := uc.repo.Update(ctx, user); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to sync user email from provider", "user_id", user.ID, "error", err)
+		} else {
+			drift.Repaired = true
+		}
+	}
+
+	uc.logDrift(drift)
+	return drift
+}
+
+func (uc *UseCase) logDrift(drift entities.UserDrift) {
+	uc.logger.Warn("user drift detected",
+		slog.String("kind", string(drift.Kind)),
+		slog.String("auth_provider_id", drift.AuthProviderID),
+		slog.String("source", drift.Source),
+		slog.Bool("repaired", drift.Repaired),
+	)
+}
+
+func sameEmail(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,175 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/reconciliation/mocks"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo *mocks.RepositoryMock, provider *mocks.ProviderMock, repair bool) *UseCase {
+	return NewUseCase(repo, provider, repair, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func newTestProvider(users ...entities.ProviderUser) *mocks.ProviderMock {
+	return &mocks.ProviderMock{
+		ProviderFunc: func() string { return "supabase" },
+		ListUsersFunc: func(ctx context.Context) ([]entities.ProviderUser, error) {
+			return users, nil
+		},
+	}
+}
+
+func TestUseCase_Run(t *testing.T) {
+	inSync := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@x.com", AuthProviderID: "p-1"}
+	renamed := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "old@x.com", AuthProviderID: "p-2"}
+	gone := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "gone@x.com", AuthProviderID: "p-3"}
+
+	provider := newTestProvider(
+		entities.ProviderUser{ID: "p-1", Email: "A@x.com"},
+		entities.ProviderUser{ID: "p-2", Email: "new@x.com"},
+		entities.ProviderUser{ID: "p-4", Email: "orphan@x.com"},
+	)
+
+	for _, repair := range []bool{false, true} {
+		repo := &mocks.RepositoryMock{
+			ListUsersByAuthProviderFunc: func(ctx context.Context, p string) ([]entities.User, error) {
+				assert.Equal(t, "supabase", p)
+				return []entities.User{inSync, renamed, gone}, nil
+			},
+		}
+		uc := newTestUseCase(repo, provider, repair)
+
+		report, err := uc.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.LocalUsers)
+		assert.Equal(t, 3, report.ProviderUsers)
+		require.Len(t, report.Drift, 3)
+
+		assert.Equal(t, entities.DriftEmailMismatch, report.Drift[0].Kind)
+		assert.Equal(t, renamed.ID, *report.Drift[0].UserID)
+		assert.Equal(t, "new@x.com", report.Drift[0].ProviderEmail)
+		assert.Equal(t, entities.DriftMissingInProvider, report.Drift[1].Kind)
+		assert.Equal(t, gone.ID, *report.Drift[1].UserID)
+		assert.Equal(t, entities.DriftMissingLocally, report.Drift[2].Kind)
+		assert.Equal(t, "p-4", report.Drift[2].AuthProviderID)
+		assert.False(t, report.Drift[2].Repaired, "missing local users are never created")
+
+		assert.Equal(t, repair, report.Drift[0].Repaired)
+		assert.Equal(t, repair, report.Drift[1].Repaired)
+		if repair {
+			require.Len(t, repo.UpdateCalls(), 1)
+			assert.Equal(t, "new@x.com", repo.UpdateCalls()[0].User.Email)
+			require.Len(t, repo.DeleteCalls(), 1)
+			assert.Equal(t, gone.ID, repo.DeleteCalls()[0].ID)
+		} else {
+			assert.Empty(t, repo.UpdateCalls())
+			assert.Empty(t, repo.DeleteCalls())
+		}
+
+		stored, err := uc.Report(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, report, stored)
+	}
+}
+
+func TestUseCase_Run_EmptyProvider(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListUsersByAuthProviderFunc: func(ctx context.Context, p string) ([]entities.User, error) {
+			return []entities.User{{ID: uuid.Must(uuid.NewV4()), AuthProviderID: "p-1"}}, nil
+		},
+	}
+	uc := newTestUseCase(repo, newTestProvider(), true)
+
+	report, err := uc.Run(context.Background())
+	assert.ErrorIs(t, err, ErrEmptyProvider)
+	assert.Empty(t, report.Drift)
+	assert.NotEmpty(t, report.Error)
+	assert.Empty(t, repo.DeleteCalls())
+}
+
+func TestUseCase_HandleEvent(t *testing.T) {
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@x.com", AuthProviderID: "p-1"}
+
+	tests := []struct {
+		name      string
+		event     entities.ProviderEvent
+		found     bool
+		wantDrift entities.DriftKind
+	}{
+		{
+			name:      "deleted on the provider",
+			event:     entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventDeleted, User: entities.ProviderUser{ID: "p-1"}},
+			found:     true,
+			wantDrift: entities.DriftMissingInProvider,
+		},
+		{
+			name:      "email changed on the provider",
+			event:     entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventUpdated, User: entities.ProviderUser{ID: "p-1", Email: "b@x.com"}},
+			found:     true,
+			wantDrift: entities.DriftEmailMismatch,
+		},
+		{
+			name:  "update without drift",
+			event: entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventUpdated, User: entities.ProviderUser{ID: "p-1", Email: "a@x.com"}},
+			found: true,
+		},
+		{
+			name:  "unknown user",
+			event: entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventDeleted, User: entities.ProviderUser{ID: "p-9"}},
+		},
+		{
+			name:  "creations are ignored",
+			event: entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventCreated, User: entities.ProviderUser{ID: "p-9"}},
+		},
+		{
+			name:  "other providers are ignored",
+			event: entities.ProviderEvent{Provider: "auth0", Type: entities.ProviderEventDeleted, User: entities.ProviderUser{ID: "p-1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				GetByAuthProviderIDFunc: func(ctx context.Context, provider, providerID string) (entities.User, error) {
+					if !tt.found {
+						return entities.User{}, domain.ErrNotFound
+					}
+					return user, nil
+				},
+			}
+			uc := newTestUseCase(repo, newTestProvider(), false)
+
+			require.NoError(t, uc.HandleEvent(context.Background(), tt.event))
+
+			report, _ := uc.Report(context.Background())
+			if tt.wantDrift == "" {
+				assert.Empty(t, report.Drift)
+				return
+			}
+			require.Len(t, report.Drift, 1)
+			assert.Equal(t, tt.wantDrift, report.Drift[0].Kind)
+			assert.Equal(t, sourceWebhook, report.Drift[0].Source)
+		})
+	}
+}
+
+func TestUseCase_HandleEvent_RepositoryError(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetByAuthProviderIDFunc: func(ctx context.Context, provider, providerID string) (entities.User, error) {
+			return entities.User{}, errors.New("db down")
+		},
+	}
+	uc := newTestUseCase(repo, newTestProvider(), false)
+
+	err := uc.HandleEvent(context.Background(), entities.ProviderEvent{Provider: "supabase", Type: entities.ProviderEventDeleted, User: entities.ProviderUser{ID: "p-1"}})
+	assert.Error(t, err)
+}
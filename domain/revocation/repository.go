@@ -0,0 +1,27 @@
+package revocation
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	// RevokeToken adds the token to the denylist. Revoking a token twice is
+	// not an error.
+	RevokeToken(ctx context.Context, token entities.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeUserTokens denylists every token issued to the user up to
+	// revokedAt. A later revokedAt replaces an earlier one.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+	// IsUserTokenRevoked reports whether a token issued to the user at
+	// issuedAt falls under RevokeUserTokens.
+	IsUserTokenRevoked(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (bool, error)
+	// DeleteExpiredRevokedTokens drops entries for tokens that have expired
+	// on their own and returns how many were removed.
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,131 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"log/slog"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrNotRevocable is returned for tokens without a jti or expiry claim, which
+// the denylist can't track.
+var ErrNotRevocable = errors.New("token can't be revoked")
+
+// UseCase keeps a denylist of access tokens revoked before they expire, e.g.
+// on logout. The auth middleware rejects denylisted tokens, and entries are
+// purged once the token would have expired anyway.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Revoke denylists the token the claims were parsed from.
+func (uc *UseCase) Revoke(ctx context.Context, claims *jwt.Claims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return ErrNotRevocable
+	}
+
+	// Tokens that already expired are rejected anyway
+	if time.Now().After(claims.ExpiresAt.Time) {
+		return nil
+	}
+
+	token := entities.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    uuid.FromStringOrNil(claims.UserID),
+		ExpiresAt: claims.ExpiresAt.Time,
+		RevokedAt: time.Now(),
+	}
+	if err := uc.repo.RevokeToken(ctx, token); err != nil {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("access token revoked", "audit", true, "user_id", claims.UserID, "jti", claims.ID)
+	return nil
+}
+
+// IsRevoked reports whether the token with the given jti was revoked.
+func (uc *UseCase) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	revoked, err := uc.repo.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("checking token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// RevokeUser denylists every access token issued to the user so far, e.g.
+// when an admin forces the user out. Tokens issued afterwards are accepted.
+func (uc *UseCase) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	if err := uc.repo.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return fmt.Errorf("revoking user tokens: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user access tokens revoked", "audit", true, "user_id", userID)
+	return nil
+}
+
+// IsUserRevoked reports whether the token the claims were parsed from was
+// issued before the user's tokens were revoked with RevokeUser. Tokens
+// without an issue time count as issued before.
+func (uc *UseCase) IsUserRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	userID := uuid.FromStringOrNil(claims.UserID)
+	if userID == uuid.Nil {
+		return false, nil
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	revoked, err := uc.repo.IsUserTokenRevoked(ctx, userID, issuedAt)
+	if err != nil {
+		return false, fmt.Errorf("checking user token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// Purge drops denylist entries for tokens that have expired.
+func (uc *UseCase) Purge(ctx context.Context) (int64, error) {
+	n, err := uc.repo.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("purging revoked tokens: %w", err)
+	}
+	return n, nil
+}
+
+// Start purges the denylist every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Purge(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("revoked token purge failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("purged expired revoked tokens", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
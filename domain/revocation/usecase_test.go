@@ -0,0 +1,132 @@
+package revocation
+
+import (
+	"context"
+	"go-template/domain/revocation/mocks"
+	"go-template/internal/jwt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Revoke(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		claims  *jwt.Claims
+		wantErr error
+		stored  bool
+	}{
+		{
+			name: "denylists the jti until the token expires",
+			claims: &jwt.Claims{
+				UserID: userID.String(),
+				RegisteredClaims: jwtlib.RegisteredClaims{
+					ID:        "jti-1",
+					ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+				},
+			},
+			stored: true,
+		},
+		{
+			name: "expired tokens need no entry",
+			claims: &jwt.Claims{
+				RegisteredClaims: jwtlib.RegisteredClaims{
+					ID:        "jti-2",
+					ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(-time.Minute)),
+				},
+			},
+		},
+		{
+			name:    "tokens without a jti can't be tracked",
+			claims:  &jwt.Claims{RegisteredClaims: jwtlib.RegisteredClaims{ExpiresAt: jwtlib.NewNumericDate(expiresAt)}},
+			wantErr: ErrNotRevocable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo)
+
+			err := uc.Revoke(context.Background(), tt.claims)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			if !tt.stored {
+				assert.Empty(t, repo.RevokeTokenCalls())
+				return
+			}
+			require.Len(t, repo.RevokeTokenCalls(), 1)
+			token := repo.RevokeTokenCalls()[0].Token
+			assert.Equal(t, "jti-1", token.JTI)
+			assert.Equal(t, userID, token.UserID)
+			assert.True(t, expiresAt.Equal(token.ExpiresAt))
+		})
+	}
+}
+
+func TestUseCase_IsRevoked(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		IsTokenRevokedFunc: func(ctx context.Context, jti string) (bool, error) {
+			return jti == "jti-1", nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	revoked, err := uc.IsRevoked(context.Background(), "jti-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = uc.IsRevoked(context.Background(), "jti-2")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Tokens without a jti never hit the store
+	revoked, err = uc.IsRevoked(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Len(t, repo.IsTokenRevokedCalls(), 2)
+}
+
+func TestUseCase_IsUserRevoked(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	issuedAt := time.Now().Truncate(time.Second)
+
+	repo := &mocks.RepositoryMock{
+		IsUserTokenRevokedFunc: func(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+			return id == userID && !at.After(issuedAt), nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	revoked, err := uc.IsUserRevoked(context.Background(), &jwt.Claims{
+		UserID:           userID.String(),
+		RegisteredClaims: jwtlib.RegisteredClaims{IssuedAt: jwtlib.NewNumericDate(issuedAt)},
+	})
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Tokens without an issue time count as issued before the cutoff
+	revoked, err = uc.IsUserRevoked(context.Background(), &jwt.Claims{UserID: userID.String()})
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	assert.True(t, repo.IsUserTokenRevokedCalls()[1].IssuedAt.IsZero())
+
+	// Subjects that aren't UUIDs are never looked up
+	revoked, err = uc.IsUserRevoked(context.Background(), &jwt.Claims{UserID: "not-a-uuid"})
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Len(t, repo.IsUserTokenRevokedCalls(), 2)
+}
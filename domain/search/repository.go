@@ -0,0 +1,14 @@
+package search
+
+import (
+	"context"
+	"go-template/domain/entities"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository
+
+type Repository interface {
+	// Search returns up to limit records of kinds matching query, best
+	// first.
+	Search(ctx context.Context, query string, kinds []entities.SearchKind, limit int32) ([]entities.SearchResult, error)
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"strings"
+)
+
+const (
+	// DefaultLimit is how many results Search returns when not told.
+	DefaultLimit = 20
+	// MaxLimit is the most results Search returns at once.
+	MaxLimit = 100
+	// maxQueryLength keeps queries to what a person would type.
+	maxQueryLength = 200
+)
+
+// UseCase runs full-text searches across users and examples. Which kinds a
+// caller may search is up to the caller: users are only for admins.
+type UseCase struct {
+	repo   Repository
+	logger *slog.Logger
+}
+
+func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Search returns the records of the kinds in q matching its query, best
+// first. The limit is DefaultLimit when not positive, and at most MaxLimit.
+func (uc *UseCase) Search(ctx context.Context, q entities.SearchQuery) (entities.SearchResponse, error) {
+	query := strings.TrimSpace(q.Query)
+	if query == "" {
+		return entities.SearchResponse{}, fmt.Errorf("%w: query is required", domain.ErrMalformedParameters)
+	}
+	if len(query) > maxQueryLength {
+		return entities.SearchResponse{}, fmt.Errorf("%w: query is longer than %d characters", domain.ErrMalformedParameters, maxQueryLength)
+	}
+
+	kinds := q.Kinds
+	if len(kinds) == 0 {
+		kinds = entities.SearchKinds
+	}
+	for _, kind := range kinds {
+		if !kind.IsValid() {
+			return entities.SearchResponse{}, fmt.Errorf("%w: unknown kind %q", domain.ErrMalformedParameters, kind)
+		}
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	results, err := uc.repo.Search(ctx, query, kinds, int32(limit))
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to search", "error", err)
+		return entities.SearchResponse{}, err
+	}
+	if results == nil {
+		results = []entities.SearchResult{}
+	}
+
+	return entities.SearchResponse{
+		Query:   query,
+		Results: results,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/search/mocks"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Search(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		SearchFunc: func(ctx context.Context, query string, kinds []entities.SearchKind, limit int32) ([]entities.SearchResult, error) {
+			return []entities.SearchResult{{Kind: entities.SearchKindExample, ID: "1", Title: "Hello"}}, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+
+	resp, err := uc.Search(context.Background(), entities.SearchQuery{Query: "  hello  "})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", resp.Query)
+	assert.Len(t, resp.Results, 1)
+
+	calls := repo.SearchCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "hello", calls[0].Query)
+	assert.Equal(t, entities.SearchKinds, calls[0].Kinds)
+	assert.Equal(t, int32(DefaultLimit), calls[0].Limit)
+}
+
+func TestUseCase_Search_KindsAndLimit(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo)
+
+	resp, err := uc.Search(context.Background(), entities.SearchQuery{
+		Query: "hello",
+		Kinds: []entities.SearchKind{entities.SearchKindExample},
+		Limit: 1000,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Results)
+	assert.Empty(t, resp.Results)
+
+	calls := repo.SearchCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, []entities.SearchKind{entities.SearchKindExample}, calls[0].Kinds)
+	assert.Equal(t, int32(MaxLimit), calls[0].Limit)
+}
+
+func TestUseCase_Search_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query entities.SearchQuery
+	}{
+		{name: "empty query", query: entities.SearchQuery{Query: "   "}},
+		{name: "long query", query: entities.SearchQuery{Query: strings.Repeat("a", maxQueryLength+1)}},
+		{name: "unknown kind", query: entities.SearchQuery{Query: "hello", Kinds: []entities.SearchKind{"invoice"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			_, err := newTestUseCase(repo).Search(context.Background(), tt.query)
+			assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+			assert.Empty(t, repo.SearchCalls())
+		})
+	}
+}
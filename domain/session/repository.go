@@ -0,0 +1,48 @@
+package session
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository RefreshTokenRevoker TokenRevoker
+
+type Repository interface {
+	// UpsertSession records the session, or updates its token and where and
+	// when it was last seen if the session is already known.
+	UpsertSession(ctx context.Context, session entities.Session) error
+	// GetSession returns domain.ErrNotFound for unknown sessions.
+	GetSession(ctx context.Context, id string) (entities.Session, error)
+	// ListUserSessions returns the user's sessions that haven't ended, most
+	// recently used first. A session ends when it is logged out, or when
+	// neither its access token nor its refresh token can be used anymore.
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]entities.Session, error)
+	DeleteSession(ctx context.Context, id string) error
+	// DeleteUserSessions drops every session of the user and returns how
+	// many were removed.
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	// CountActiveSessions counts sessions with an unexpired access token
+	// seen after since.
+	CountActiveSessions(ctx context.Context, since time.Time) (int64, error)
+	// DeleteExpiredSessions drops sessions that have ended and returns how
+	// many were removed.
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+}
+
+// RefreshTokenRevoker ends the refresh token family of a login, or all of
+// them. The family ID is the session ID.
+type RefreshTokenRevoker interface {
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+// TokenRevoker denylists an access token, or every token issued to a user so
+// far, until they expire.
+type TokenRevoker interface {
+	Revoke(ctx context.Context, claims *jwt.Claims) error
+	RevokeUser(ctx context.Context, userID uuid.UUID) error
+}
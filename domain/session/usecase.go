@@ -0,0 +1,238 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+// touchInterval is how often a session's last use is written. Requests in
+// between only update it in memory.
+const touchInterval = time.Minute
+
+// UseCase tracks the sessions in use and lets users end them. The auth
+// middleware touches a session on every authenticated request, and a session
+// counts as active while its access token is unexpired and was used within
+// the active window.
+type UseCase struct {
+	repo          Repository
+	refreshTokens RefreshTokenRevoker
+	tokens        TokenRevoker
+	activeWindow  time.Duration
+	logger        *slog.Logger
+
+	mu      sync.Mutex
+	touched map[string]time.Time
+	now     func() time.Time
+}
+
+func NewUseCase(repo Repository, refreshTokens RefreshTokenRevoker, tokens TokenRevoker, activeWindow time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:          repo,
+		refreshTokens: refreshTokens,
+		tokens:        tokens,
+		activeWindow:  activeWindow,
+		logger:        logger,
+		touched:       make(map[string]time.Time),
+		now:           time.Now,
+	}
+}
+
+// Touch records that the token the claims were parsed from was used from ip
+// with userAgent. Tokens without a jti, expiry or user ID aren't tracked.
+// Failures are logged and never fail the request.
+func (uc *UseCase) Touch(ctx context.Context, claims *jwt.Claims, ip, userAgent string) {
+	userID := uuid.FromStringOrNil(claims.UserID)
+	if claims.ID == "" || claims.ExpiresAt == nil || userID == uuid.Nil {
+		return
+	}
+
+	now := uc.now()
+	uc.mu.Lock()
+	last, seen := uc.touched[claims.ID]
+	if seen && now.Sub(last) < touchInterval {
+		uc.mu.Unlock()
+		return
+	}
+	uc.touched[claims.ID] = now
+	uc.mu.Unlock()
+
+	createdAt := now
+	if claims.IssuedAt != nil {
+		createdAt = claims.IssuedAt.Time
+	}
+	err := uc.repo.UpsertSession(ctx, entities.Session{
+		ID:         claims.Session(),
+		JTI:        claims.ID,
+		UserID:     userID,
+		IPAddress:  ip,
+		UserAgent:  userAgent,
+		CreatedAt:  createdAt,
+		LastSeenAt: now,
+		ExpiresAt:  claims.ExpiresAt.Time,
+	})
+	if err != nil {
+		// Let the next request try again
+		uc.mu.Lock()
+		delete(uc.touched, claims.ID)
+		uc.mu.Unlock()
+		domain.LoggerOr(ctx, uc.logger).Error("failed to record session", "user_id", claims.UserID, "jti", claims.ID, "error", err)
+	}
+}
+
+// List returns the user's sessions, most recently used first.
+func (uc *UseCase) List(ctx context.Context, userID uuid.UUID) ([]entities.Session, error) {
+	sessions, err := uc.repo.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Revoke ends one of the user's sessions: its refresh token stops working
+// and its access token is denylisted. Sessions of other users are reported
+// as domain.ErrNotFound.
+func (uc *UseCase) Revoke(ctx context.Context, userID uuid.UUID, id string) error {
+	session, err := uc.repo.GetSession(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && session.UserID != userID) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("getting session: %w", err)
+	}
+	return uc.end(ctx, session)
+}
+
+// RevokeOthers ends every session of the user except current, and returns
+// how many were ended.
+func (uc *UseCase) RevokeOthers(ctx context.Context, userID uuid.UUID, current string) (int, error) {
+	sessions, err := uc.List(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	ended := 0
+	for _, session := range sessions {
+		if session.ID == current {
+			continue
+		}
+		if err := uc.end(ctx, session); err != nil {
+			return ended, err
+		}
+		ended++
+	}
+	return ended, nil
+}
+
+// RevokeUser ends every session of the user, including tokens the session
+// list hasn't seen yet, and returns how many sessions were ended. Admins use
+// it to force a user out.
+func (uc *UseCase) RevokeUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if domain.IsDryRun(ctx) {
+		sessions, err := uc.List(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user sessions not revoked", "user_id", userID)
+		return int64(len(sessions)), nil
+	}
+
+	if err := uc.refreshTokens.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return 0, fmt.Errorf("revoking refresh tokens: %w", err)
+	}
+	if err := uc.tokens.RevokeUser(ctx, userID); err != nil {
+		return 0, fmt.Errorf("revoking access tokens: %w", err)
+	}
+	n, err := uc.repo.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("deleting sessions: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("user sessions revoked", "audit", true, "user_id", userID, "sessions", n)
+	return n, nil
+}
+
+func (uc *UseCase) end(ctx context.Context, session entities.Session) error {
+	// Sessions of tokens issued outside a login have no refresh tokens
+	if familyID, err := uuid.FromString(session.ID); err == nil {
+		if err := uc.refreshTokens.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+			return fmt.Errorf("revoking refresh tokens: %w", err)
+		}
+	}
+
+	err := uc.tokens.Revoke(ctx, &jwt.Claims{
+		UserID: session.UserID.String(),
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        session.JTI,
+			ExpiresAt: jwtlib.NewNumericDate(session.ExpiresAt),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("revoking access token: %w", err)
+	}
+
+	if err := uc.repo.DeleteSession(ctx, session.ID); err != nil {
+		return fmt.Errorf("deleting session: %w", err)
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("session revoked", "audit", true, "user_id", session.UserID, "session_id", session.ID)
+	return nil
+}
+
+// CountActive counts the sessions whose access token is unexpired and was
+// used within the active window.
+func (uc *UseCase) CountActive(ctx context.Context) (int64, error) {
+	n, err := uc.repo.CountActiveSessions(ctx, uc.now().Add(-uc.activeWindow))
+	if err != nil {
+		return 0, fmt.Errorf("counting active sessions: %w", err)
+	}
+	return n, nil
+}
+
+// Purge drops sessions that have ended.
+func (uc *UseCase) Purge(ctx context.Context) (int64, error) {
+	now := uc.now()
+	uc.mu.Lock()
+	for jti, last := range uc.touched {
+		if now.Sub(last) >= touchInterval {
+			delete(uc.touched, jti)
+		}
+	}
+	uc.mu.Unlock()
+
+	n, err := uc.repo.DeleteExpiredSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("purging sessions: %w", err)
+	}
+	return n, nil
+}
+
+// Start purges ended sessions every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Purge(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("session purge failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("purged expired sessions", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/session/mocks"
+	"go-template/internal/jwt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository) *UseCase {
+	return NewUseCase(repo, &mocks.RefreshTokenRevokerMock{}, &mocks.TokenRevokerMock{}, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestUseCase_Touch(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	claims := &jwt.Claims{
+		UserID:    userID.String(),
+		SessionID: "session-1",
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+		},
+	}
+
+	repo := &mocks.RepositoryMock{}
+	uc := newTestUseCase(repo)
+	now := time.Now()
+	uc.now = func() time.Time { return now }
+
+	uc.Touch(context.Background(), claims, "203.0.113.1", "test-agent")
+	require.Len(t, repo.UpsertSessionCalls(), 1)
+	session := repo.UpsertSessionCalls()[0].Session
+	assert.Equal(t, "session-1", session.ID)
+	assert.Equal(t, "jti-1", session.JTI)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, "203.0.113.1", session.IPAddress)
+	assert.Equal(t, "test-agent", session.UserAgent)
+	assert.True(t, expiresAt.Equal(session.ExpiresAt))
+
+	// Requests within the touch interval aren't written
+	now = now.Add(30 * time.Second)
+	uc.Touch(context.Background(), claims, "203.0.113.1", "test-agent")
+	assert.Len(t, repo.UpsertSessionCalls(), 1)
+
+	now = now.Add(touchInterval)
+	uc.Touch(context.Background(), claims, "203.0.113.2", "test-agent")
+	require.Len(t, repo.UpsertSessionCalls(), 2)
+	assert.Equal(t, "203.0.113.2", repo.UpsertSessionCalls()[1].Session.IPAddress)
+
+	// Tokens without a sid are a session of their own
+	uc.Touch(context.Background(), &jwt.Claims{UserID: userID.String(), RegisteredClaims: jwtlib.RegisteredClaims{
+		ID:        "jti-2",
+		ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+	}}, "", "")
+	require.Len(t, repo.UpsertSessionCalls(), 3)
+	assert.Equal(t, "jti-2", repo.UpsertSessionCalls()[2].Session.ID)
+
+	// Tokens without a jti or user can't be tracked
+	uc.Touch(context.Background(), &jwt.Claims{UserID: userID.String()}, "", "")
+	uc.Touch(context.Background(), &jwt.Claims{RegisteredClaims: claims.RegisteredClaims}, "", "")
+	assert.Len(t, repo.UpsertSessionCalls(), 3)
+}
+
+func TestUseCase_Touch_RetriesAfterFailure(t *testing.T) {
+	fail := true
+	repo := &mocks.RepositoryMock{
+		UpsertSessionFunc: func(ctx context.Context, session entities.Session) error {
+			if fail {
+				return errors.New("db down")
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo)
+	claims := &jwt.Claims{
+		UserID: uuid.Must(uuid.NewV4()).String(),
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	uc.Touch(context.Background(), claims, "", "")
+	fail = false
+	uc.Touch(context.Background(), claims, "", "")
+	assert.Len(t, repo.UpsertSessionCalls(), 2)
+}
+
+func TestUseCase_CountActive(t *testing.T) {
+	now := time.Now()
+	repo := &mocks.RepositoryMock{
+		CountActiveSessionsFunc: func(ctx context.Context, since time.Time) (int64, error) {
+			return 3, nil
+		},
+	}
+	uc := newTestUseCase(repo)
+	uc.now = func() time.Time { return now }
+
+	n, err := uc.CountActive(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	require.Len(t, repo.CountActiveSessionsCalls(), 1)
+	assert.True(t, now.Add(-15*time.Minute).Equal(repo.CountActiveSessionsCalls()[0].Since))
+}
+
+func TestUseCase_Revoke(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	familyID := uuid.Must(uuid.NewV4())
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	stored := entities.Session{ID: familyID.String(), JTI: "jti-1", UserID: userID, ExpiresAt: expiresAt}
+
+	repo := &mocks.RepositoryMock{
+		GetSessionFunc: func(ctx context.Context, id string) (entities.Session, error) {
+			if id != stored.ID {
+				return entities.Session{}, domain.ErrNotFound
+			}
+			return stored, nil
+		},
+	}
+	refreshTokens := &mocks.RefreshTokenRevokerMock{}
+	tokens := &mocks.TokenRevokerMock{}
+	uc := NewUseCase(repo, refreshTokens, tokens, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Other users' sessions look like unknown ones
+	err := uc.Revoke(context.Background(), uuid.Must(uuid.NewV4()), stored.ID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	err = uc.Revoke(context.Background(), userID, "unknown")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Empty(t, repo.DeleteSessionCalls())
+
+	require.NoError(t, uc.Revoke(context.Background(), userID, stored.ID))
+	require.Len(t, refreshTokens.RevokeRefreshTokenFamilyCalls(), 1)
+	assert.Equal(t, familyID, refreshTokens.RevokeRefreshTokenFamilyCalls()[0].FamilyID)
+	require.Len(t, tokens.RevokeCalls(), 1)
+	assert.Equal(t, "jti-1", tokens.RevokeCalls()[0].Claims.ID)
+	assert.True(t, expiresAt.Equal(tokens.RevokeCalls()[0].Claims.ExpiresAt.Time))
+	require.Len(t, repo.DeleteSessionCalls(), 1)
+	assert.Equal(t, stored.ID, repo.DeleteSessionCalls()[0].ID)
+}
+
+func TestUseCase_RevokeOthers(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	sessions := []entities.Session{
+		{ID: "current", JTI: "jti-1", UserID: userID},
+		{ID: uuid.Must(uuid.NewV4()).String(), JTI: "jti-2", UserID: userID},
+		{ID: "jti-3", JTI: "jti-3", UserID: userID},
+	}
+	repo := &mocks.RepositoryMock{
+		ListUserSessionsFunc: func(ctx context.Context, id uuid.UUID) ([]entities.Session, error) {
+			return sessions, nil
+		},
+	}
+	refreshTokens := &mocks.RefreshTokenRevokerMock{}
+	uc := NewUseCase(repo, refreshTokens, &mocks.TokenRevokerMock{}, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ended, err := uc.RevokeOthers(context.Background(), userID, "current")
+	require.NoError(t, err)
+	assert.Equal(t, 2, ended)
+	require.Len(t, repo.DeleteSessionCalls(), 2)
+	assert.Equal(t, sessions[1].ID, repo.DeleteSessionCalls()[0].ID)
+	assert.Equal(t, "jti-3", repo.DeleteSessionCalls()[1].ID)
+	// Only sessions started by a login have a refresh token family
+	assert.Len(t, refreshTokens.RevokeRefreshTokenFamilyCalls(), 1)
+}
+
+func TestUseCase_RevokeUser(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		ListUserSessionsFunc: func(ctx context.Context, id uuid.UUID) ([]entities.Session, error) {
+			return []entities.Session{{ID: "a", UserID: userID}, {ID: "b", UserID: userID}}, nil
+		},
+		DeleteUserSessionsFunc: func(ctx context.Context, id uuid.UUID) (int64, error) {
+			return 2, nil
+		},
+	}
+	refreshTokens := &mocks.RefreshTokenRevokerMock{}
+	tokens := &mocks.TokenRevokerMock{}
+	uc := NewUseCase(repo, refreshTokens, tokens, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// A dry run only counts the sessions
+	n, err := uc.RevokeUser(domain.WithDryRun(context.Background()), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Empty(t, tokens.RevokeUserCalls())
+	assert.Empty(t, repo.DeleteUserSessionsCalls())
+
+	n, err = uc.RevokeUser(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	require.Len(t, refreshTokens.RevokeUserRefreshTokensCalls(), 1)
+	assert.Equal(t, userID, refreshTokens.RevokeUserRefreshTokensCalls()[0].UserID)
+	require.Len(t, tokens.RevokeUserCalls(), 1)
+	assert.Equal(t, userID, tokens.RevokeUserCalls()[0].UserID)
+	require.Len(t, repo.DeleteUserSessionsCalls(), 1)
+
+	// Nothing is deleted when the tokens can't be revoked
+	tokens.RevokeUserFunc = func(ctx context.Context, id uuid.UUID) error {
+		return errors.New("db down")
+	}
+	_, err = uc.RevokeUser(context.Background(), userID)
+	require.Error(t, err)
+	assert.Len(t, repo.DeleteUserSessionsCalls(), 1)
+}
@@ -12,4 +12,4 @@ type Repository interface {
 	UpdateSettings(ctx context.Context, settings *entities.SystemSettings) error
 	GetSetting(ctx context.Context, key string) (any, error)
 	SetSetting(ctx context.Context, key string, value any) error
-}
\ No newline at end of file
+}
@@ -2,13 +2,17 @@ package settings
 
 import (
 	"context"
+	"go-template/domain"
+	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/domain/events"
 	"log/slog"
 	"slices"
 )
 
 type UseCase struct {
 	repo   Repository
+	events events.Publisher
 	logger *slog.Logger
 }
 
@@ -19,36 +23,50 @@ func NewUseCase(repo Repository, logger *slog.Logger) *UseCase {
 	}
 }
 
+// SetEvents publishes SettingsUpdated when the settings are saved.
+func (uc *UseCase) SetEvents(events events.Publisher) {
+	uc.events = events
+}
+
 func (uc *UseCase) GetSettings(ctx context.Context) (*entities.SystemSettings, error) {
 	settings, err := uc.repo.GetSettings(ctx)
 	if err != nil {
-		uc.logger.Error("failed to get settings", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get settings", "error", err)
 		return nil, err
 	}
 
-	uc.logger.Debug("retrieved system settings")
+	domain.LoggerOr(ctx, uc.logger).Debug("retrieved system settings")
 	return settings, nil
 }
 
 func (uc *UseCase) UpdateSettings(ctx context.Context, settings *entities.SystemSettings) error {
 	if err := uc.validateSettings(settings); err != nil {
-		uc.logger.Warn("invalid settings provided", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Warn("invalid settings provided", "error", err)
 		return err
 	}
 
-	if err := uc.repo.UpdateSettings(ctx, settings); err != nil {
-		uc.logger.Error("failed to update settings", "error", err)
-		return err
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: system settings not updated")
+		return nil
 	}
 
-	uc.logger.Info("system settings updated")
+	err := events.Commit(ctx, uc.events, func(ctx context.Context) ([]events.Event, error) {
+		if err := uc.repo.UpdateSettings(ctx, settings); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.SettingsUpdated{Settings: *settings}}, nil
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to update settings", "error", err)
+		return err
+	}
 	return nil
 }
 
 func (uc *UseCase) GetSetting(ctx context.Context, key string) (any, error) {
 	value, err := uc.repo.GetSetting(ctx, key)
 	if err != nil {
-		uc.logger.Error("failed to get setting", "key", key, "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get setting", "key", key, "error", err)
 		return nil, err
 	}
 
@@ -57,11 +75,11 @@ func (uc *UseCase) GetSetting(ctx context.Context, key string) (any, error) {
 
 func (uc *UseCase) SetSetting(ctx context.Context, key string, value any) error {
 	if err := uc.repo.SetSetting(ctx, key, value); err != nil {
-		uc.logger.Error("failed to set setting", "key", key, "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to set setting", "key", key, "error", err)
 		return err
 	}
 
-	uc.logger.Debug("setting updated", "key", key)
+	domain.LoggerOr(ctx, uc.logger).Debug("setting updated", "key", key)
 	return nil
 }
 
@@ -87,13 +105,9 @@ func (uc *UseCase) validateSettings(settings *entities.SystemSettings) error {
 	}
 
 	// Validate supported auth providers
-	supportedProviders := map[string]bool{
-		"supabase": true,
-		// Add more providers here as they're implemented
-	}
-
+	supportedProviders := auth.RegisteredProviders()
 	for _, provider := range settings.AvailableAuthProviders {
-		if !supportedProviders[provider] {
+		if !slices.Contains(supportedProviders, provider) {
 			return entities.ErrInvalidSettingValue{Field: "available_auth_providers", Message: "unsupported provider: " + provider}
 		}
 	}
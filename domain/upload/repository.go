@@ -0,0 +1,33 @@
+package upload
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository FileStorage
+
+type Repository interface {
+	CreateUpload(ctx context.Context, upload entities.Upload) error
+	// GetUpload returns domain.ErrNotFound when the user has no such
+	// upload.
+	GetUpload(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error)
+	// CompleteUpload marks a pending upload completed. It returns
+	// domain.ErrNotFound when the upload isn't pending.
+	CompleteUpload(ctx context.Context, id uuid.UUID, completedAt time.Time) error
+	// ListStaleUploads returns up to limit pending uploads that expired
+	// before now, and uploads whose user was deleted, oldest first.
+	ListStaleUploads(ctx context.Context, now time.Time, limit int32) ([]entities.Upload, error)
+	DeleteUpload(ctx context.Context, id uuid.UUID) error
+}
+
+// FileStorage receives the uploaded files and links to them.
+type FileStorage interface {
+	SignedUploadURL(key string, size int64, ttl time.Duration) (string, error)
+	SignedURL(key string, ttl time.Duration) (string, error)
+	Size(ctx context.Context, key string) (int64, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,255 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"mime"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	batchSize = 100
+	// privatePrefix keeps the uploaded files from being served without a
+	// signed link.
+	privatePrefix = "private/"
+	// maxFileNameLength is the most bytes a file name can have.
+	maxFileNameLength = 255
+	// confirmGrace is how long after its link expires an upload can still be
+	// confirmed, so large uploads started just in time aren't lost.
+	confirmGrace = time.Hour
+)
+
+var (
+	ErrTooLarge        = errors.New("upload is too large")
+	ErrEmpty           = errors.New("upload is empty")
+	ErrInvalidFileName = errors.New("file_name must be 1 to 255 bytes without slashes or control characters")
+	ErrNotUploaded     = errors.New("the file has not been uploaded yet")
+	ErrSizeMismatch    = errors.New("the uploaded file is not the size announced")
+	ErrExpired         = errors.New("upload has expired")
+)
+
+// CreateRequest announces a file the client is about to upload.
+type CreateRequest struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// UseCase lets clients upload files straight to file storage through
+// presigned links, so large files don't go through the API. Uploads are
+// registered pending, and completed once the client confirms the file is
+// stored. Pending uploads that expire, and uploads of deleted users, are
+// removed with their files by Run.
+type UseCase struct {
+	repo    Repository
+	files   FileStorage
+	maxSize int64
+	urlTTL  time.Duration
+	logger  *slog.Logger
+}
+
+func NewUseCase(repo Repository, files FileStorage, maxSize int64, urlTTL time.Duration, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:    repo,
+		files:   files,
+		maxSize: maxSize,
+		urlTTL:  urlTTL,
+		logger:  logger,
+	}
+}
+
+// Create registers a pending upload for the user and returns it with the
+// link the file is PUT to. The link only takes a body of exactly req.Size
+// bytes.
+func (uc *UseCase) Create(ctx context.Context, userID uuid.UUID, req CreateRequest) (entities.Upload, error) {
+	if !validFileName(req.FileName) {
+		return entities.Upload{}, ErrInvalidFileName
+	}
+	if req.Size <= 0 {
+		return entities.Upload{}, ErrEmpty
+	}
+	if req.Size > uc.maxSize {
+		return entities.Upload{}, ErrTooLarge
+	}
+
+	now := time.Now().UTC()
+	id := uuid.Must(uuid.NewV4())
+	urlExpiresAt := now.Add(uc.urlTTL)
+	upload := entities.Upload{
+		ID:          id,
+		UserID:      &userID,
+		FileName:    req.FileName,
+		ContentType: contentType(req.ContentType),
+		Size:        req.Size,
+		FileKey:     fmt.Sprintf("%suploads/%s/%s", privatePrefix, userID, id),
+		Status:      entities.UploadPending,
+		CreatedAt:   now,
+		ExpiresAt:   urlExpiresAt.Add(confirmGrace),
+	}
+
+	url, err := uc.files.SignedUploadURL(upload.FileKey, upload.Size, uc.urlTTL)
+	if err != nil {
+		return entities.Upload{}, fmt.Errorf("signing upload link: %w", err)
+	}
+	upload.UploadURL = url
+	upload.UploadURLExpiresAt = &urlExpiresAt
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: upload not registered", "user_id", userID)
+		return upload, nil
+	}
+	if err := uc.repo.CreateUpload(ctx, upload); err != nil {
+		return entities.Upload{}, err
+	}
+	return upload, nil
+}
+
+// Get returns the user's upload, with a fresh download link once it is
+// completed. It returns domain.ErrNotFound when the user has no such
+// upload.
+func (uc *UseCase) Get(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error) {
+	upload, err := uc.repo.GetUpload(ctx, userID, id)
+	if err != nil {
+		return entities.Upload{}, err
+	}
+	if upload.Status != entities.UploadCompleted {
+		return upload, nil
+	}
+	return uc.withDownloadURL(upload)
+}
+
+// Complete confirms the user's upload once its file is stored, and returns
+// it with a download link. Completing a completed upload returns it as it
+// is. It returns ErrNotUploaded while the file isn't stored yet, and
+// ErrSizeMismatch, removing the file, when it isn't the size announced, so
+// the client can upload it again.
+func (uc *UseCase) Complete(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error) {
+	upload, err := uc.repo.GetUpload(ctx, userID, id)
+	if err != nil {
+		return entities.Upload{}, err
+	}
+	if upload.Status == entities.UploadCompleted {
+		return uc.withDownloadURL(upload)
+	}
+	now := time.Now().UTC()
+	if now.After(upload.ExpiresAt) {
+		return entities.Upload{}, ErrExpired
+	}
+
+	size, err := uc.files.Size(ctx, upload.FileKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return entities.Upload{}, ErrNotUploaded
+		}
+		return entities.Upload{}, fmt.Errorf("checking uploaded file: %w", err)
+	}
+	if size != upload.Size {
+		if err := uc.files.Delete(ctx, upload.FileKey); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Warn("failed to delete upload of the wrong size", "upload_id", id, "error", err)
+		}
+		return entities.Upload{}, ErrSizeMismatch
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: upload not completed", "upload_id", id)
+		return upload, nil
+	}
+	if err := uc.repo.CompleteUpload(ctx, id, now); err != nil {
+		// Completed meanwhile by another request
+		if errors.Is(err, domain.ErrNotFound) {
+			return uc.Get(ctx, userID, id)
+		}
+		return entities.Upload{}, err
+	}
+	upload.Status = entities.UploadCompleted
+	upload.CompletedAt = &now
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "upload completed", "audit", true, "resource", "upload", "resource_id", id, "size", upload.Size)
+	return uc.withDownloadURL(upload)
+}
+
+// Run removes a batch of expired pending uploads and uploads of deleted
+// users, and their files. It returns how many it removed.
+func (uc *UseCase) Run(ctx context.Context) (int, error) {
+	stale, err := uc.repo.ListStaleUploads(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("listing stale uploads: %w", err)
+	}
+
+	removed := 0
+	for _, upload := range stale {
+		if err := uc.files.Delete(ctx, upload.FileKey); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to delete upload file", "upload_id", upload.ID, "error", err)
+			continue
+		}
+		if err := uc.repo.DeleteUpload(ctx, upload.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Start removes stale uploads every interval until ctx is canceled.
+func (uc *UseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Run(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("upload cleanup failed", "error", err)
+		} else if n > 0 {
+			domain.LoggerOr(ctx, uc.logger).Info("removed stale uploads", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *UseCase) withDownloadURL(upload entities.Upload) (entities.Upload, error) {
+	expiresAt := time.Now().UTC().Add(uc.urlTTL)
+	url, err := uc.files.SignedURL(upload.FileKey, uc.urlTTL)
+	if err != nil {
+		return entities.Upload{}, fmt.Errorf("signing upload download link: %w", err)
+	}
+	upload.DownloadURL = url
+	upload.DownloadURLExpiresAt = &expiresAt
+	return upload, nil
+}
+
+// validFileName accepts names a client can show back as they were sent.
+func validFileName(name string) bool {
+	if name == "" || len(name) > maxFileNameLength || !utf8.ValidString(name) {
+		return false
+	}
+	if strings.TrimSpace(name) != name || name == "." || name == ".." {
+		return false
+	}
+	return !strings.ContainsFunc(name, func(r rune) bool {
+		return r == '/' || r == '\\' || unicode.IsControl(r)
+	})
+}
+
+// contentType keeps the announced media type when it parses, and falls back
+// to a generic one otherwise.
+func contentType(announced string) string {
+	mediaType, params, err := mime.ParseMediaType(announced)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mime.FormatMediaType(mediaType, params)
+}
@@ -0,0 +1,217 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/upload/mocks"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo Repository, files FileStorage) *UseCase {
+	return NewUseCase(repo, files, 1<<20, 15*time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func signingFiles() *mocks.FileStorageMock {
+	return &mocks.FileStorageMock{
+		SignedUploadURLFunc: func(key string, size int64, ttl time.Duration) (string, error) {
+			return "https://files/" + key + "?upload", nil
+		},
+		SignedURLFunc: func(key string, ttl time.Duration) (string, error) {
+			return "https://files/" + key + "?download", nil
+		},
+	}
+}
+
+func TestUseCase_Create(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+
+	t.Run("registers a pending upload", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		files := signingFiles()
+		uc := newTestUseCase(repo, files)
+
+		got, err := uc.Create(ctx, userID, CreateRequest{FileName: "video.mp4", ContentType: "video/MP4", Size: 1000})
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.UploadPending, got.Status)
+		assert.Equal(t, "video/mp4", got.ContentType)
+		assert.Equal(t, "private/uploads/"+userID.String()+"/"+got.ID.String(), got.FileKey)
+		assert.Equal(t, "https://files/"+got.FileKey+"?upload", got.UploadURL)
+		require.NotNil(t, got.UploadURLExpiresAt)
+		assert.True(t, got.ExpiresAt.After(*got.UploadURLExpiresAt), "uploads can be confirmed after their link expires")
+		require.Len(t, files.SignedUploadURLCalls(), 1)
+		assert.Equal(t, int64(1000), files.SignedUploadURLCalls()[0].Size)
+		require.Len(t, repo.CreateUploadCalls(), 1)
+		assert.Equal(t, got, repo.CreateUploadCalls()[0].Upload)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, signingFiles())
+
+		tests := []struct {
+			req  CreateRequest
+			want error
+		}{
+			{CreateRequest{FileName: "a.bin", Size: 1<<20 + 1}, ErrTooLarge},
+			{CreateRequest{FileName: "a.bin", Size: 0}, ErrEmpty},
+			{CreateRequest{FileName: "", Size: 1}, ErrInvalidFileName},
+			{CreateRequest{FileName: "../a.bin", Size: 1}, ErrInvalidFileName},
+			{CreateRequest{FileName: "a\nb", Size: 1}, ErrInvalidFileName},
+			{CreateRequest{FileName: strings.Repeat("a", 256), Size: 1}, ErrInvalidFileName},
+		}
+		for _, tt := range tests {
+			_, err := uc.Create(ctx, userID, tt.req)
+			assert.ErrorIs(t, err, tt.want, "%+v", tt.req)
+		}
+		assert.Empty(t, repo.CreateUploadCalls())
+	})
+
+	t.Run("unknown content type", func(t *testing.T) {
+		uc := newTestUseCase(&mocks.RepositoryMock{}, signingFiles())
+
+		got, err := uc.Create(ctx, userID, CreateRequest{FileName: "a.bin", ContentType: "not a type", Size: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "application/octet-stream", got.ContentType)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, signingFiles())
+
+		_, err := uc.Create(domain.WithDryRun(ctx), userID, CreateRequest{FileName: "a.bin", Size: 1})
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateUploadCalls())
+	})
+}
+
+func TestUseCase_Complete(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	id := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+	pending := entities.Upload{
+		ID:        id,
+		UserID:    &userID,
+		Size:      5,
+		FileKey:   "private/uploads/" + userID.String() + "/" + id.String(),
+		Status:    entities.UploadPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	repoWith := func(upload entities.Upload) *mocks.RepositoryMock {
+		return &mocks.RepositoryMock{
+			GetUploadFunc: func(ctx context.Context, gotUserID, gotID uuid.UUID) (entities.Upload, error) {
+				if gotUserID != userID || gotID != id {
+					return entities.Upload{}, domain.ErrNotFound
+				}
+				return upload, nil
+			},
+		}
+	}
+	filesOfSize := func(size int64, err error) *mocks.FileStorageMock {
+		files := signingFiles()
+		files.SizeFunc = func(ctx context.Context, key string) (int64, error) {
+			return size, err
+		}
+		return files
+	}
+
+	t.Run("completes the upload", func(t *testing.T) {
+		repo := repoWith(pending)
+		uc := newTestUseCase(repo, filesOfSize(5, nil))
+
+		got, err := uc.Complete(ctx, userID, id)
+		require.NoError(t, err)
+		assert.Equal(t, entities.UploadCompleted, got.Status)
+		assert.NotNil(t, got.CompletedAt)
+		assert.Equal(t, "https://files/"+pending.FileKey+"?download", got.DownloadURL)
+		require.Len(t, repo.CompleteUploadCalls(), 1)
+		assert.Equal(t, id, repo.CompleteUploadCalls()[0].ID)
+	})
+
+	t.Run("only the user's uploads", func(t *testing.T) {
+		uc := newTestUseCase(repoWith(pending), filesOfSize(5, nil))
+
+		_, err := uc.Complete(ctx, uuid.Must(uuid.NewV4()), id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("not uploaded yet", func(t *testing.T) {
+		repo := repoWith(pending)
+		uc := newTestUseCase(repo, filesOfSize(0, domain.ErrNotFound))
+
+		_, err := uc.Complete(ctx, userID, id)
+		assert.ErrorIs(t, err, ErrNotUploaded)
+		assert.Empty(t, repo.CompleteUploadCalls())
+	})
+
+	t.Run("wrong size removes the file", func(t *testing.T) {
+		repo := repoWith(pending)
+		files := filesOfSize(4, nil)
+		uc := newTestUseCase(repo, files)
+
+		_, err := uc.Complete(ctx, userID, id)
+		assert.ErrorIs(t, err, ErrSizeMismatch)
+		require.Len(t, files.DeleteCalls(), 1)
+		assert.Equal(t, pending.FileKey, files.DeleteCalls()[0].Key)
+		assert.Empty(t, repo.CompleteUploadCalls())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := pending
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		uc := newTestUseCase(repoWith(expired), filesOfSize(5, nil))
+
+		_, err := uc.Complete(ctx, userID, id)
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+
+	t.Run("completed already", func(t *testing.T) {
+		completed := pending
+		completed.Status = entities.UploadCompleted
+		repo := repoWith(completed)
+		files := filesOfSize(0, errors.New("not called"))
+		uc := newTestUseCase(repo, files)
+
+		got, err := uc.Complete(ctx, userID, id)
+		require.NoError(t, err)
+		assert.NotEmpty(t, got.DownloadURL)
+		assert.Empty(t, files.SizeCalls())
+		assert.Empty(t, repo.CompleteUploadCalls())
+	})
+}
+
+func TestUseCase_Run(t *testing.T) {
+	kept := entities.Upload{ID: uuid.Must(uuid.NewV4()), FileKey: "private/uploads/kept"}
+	gone := entities.Upload{ID: uuid.Must(uuid.NewV4()), FileKey: "private/uploads/gone"}
+	repo := &mocks.RepositoryMock{
+		ListStaleUploadsFunc: func(ctx context.Context, now time.Time, limit int32) ([]entities.Upload, error) {
+			return []entities.Upload{kept, gone}, nil
+		},
+	}
+	files := &mocks.FileStorageMock{
+		DeleteFunc: func(ctx context.Context, key string) error {
+			if key == kept.FileKey {
+				return errors.New("storage unavailable")
+			}
+			return nil
+		},
+	}
+	uc := newTestUseCase(repo, files)
+
+	n, err := uc.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, repo.DeleteUploadCalls(), 1)
+	assert.Equal(t, gone.ID, repo.DeleteUploadCalls()[0].ID, "rows are kept until their file is gone")
+}
@@ -0,0 +1,161 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrNotPending is returned when approving or rejecting a user who isn't
+// waiting for approval.
+var ErrNotPending = errors.New("user is not pending approval")
+
+// SettingsReader loads the system settings, which decide whether new users
+// need an admin's approval.
+type SettingsReader interface {
+	GetSettings(ctx context.Context) (*entities.SystemSettings, error)
+}
+
+// EmailSender delivers transactional emails, rendered from the email's
+// template.
+type EmailSender interface {
+	SendEmail(ctx context.Context, email entities.Email) error
+}
+
+type approval struct {
+	settings SettingsReader
+	// email is nil when no email provider is configured, and users are then
+	// never told about the decision.
+	email EmailSender
+}
+
+// SetApproval makes Register leave new users pending while the
+// RequireApproval setting is on, until an admin approves them. Users are
+// emailed the decision through email when asked to, which may be nil.
+func (uc *UseCase) SetApproval(settings SettingsReader, email EmailSender) {
+	uc.approval = &approval{settings: settings, email: email}
+}
+
+// ApprovalRequired reports whether the RequireApproval setting is on.
+func (uc *UseCase) ApprovalRequired(ctx context.Context) (bool, error) {
+	if uc.approval == nil || uc.approval.settings == nil {
+		return false, nil
+	}
+	settings, err := uc.approval.settings.GetSettings(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return settings.RequireApproval, nil
+}
+
+// ListPending lists the users waiting for approval, oldest first.
+func (uc *UseCase) ListPending(ctx context.Context, page, pageSize int) ([]entities.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	users, err := uc.repo.ListUsersByStatus(ctx, entities.UserStatusPending, entities.ListUsersParams{
+		Limit:  int32(pageSize),
+		Offset: int32((page - 1) * pageSize),
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to list pending users", "error", err)
+		return nil, 0, err
+	}
+
+	total, err := uc.repo.CountUsersByStatus(ctx, entities.UserStatusPending)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to count pending users", "error", err)
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Approve lets a pending user in, emailing them when notify is set.
+func (uc *UseCase) Approve(ctx context.Context, userID uuid.UUID, notify bool) (entities.User, error) {
+	user, err := uc.pendingUser(ctx, userID)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	user.Status = entities.UserStatusActive
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user not approved", "user_id", userID)
+		return user, nil
+	}
+
+	if err := uc.repo.SetStatus(ctx, userID, entities.UserStatusActive, "", time.Now()); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to approve user", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "registration approved", "audit", true, "user_id", userID, "notify", notify)
+
+	if notify {
+		uc.notifyDecision(ctx, entities.Email{
+			To:       user.Email,
+			Template: entities.EmailRegistrationApproved,
+			Locale:   user.Locale,
+		})
+	}
+	return user, nil
+}
+
+// Reject turns down a pending user, deleting their account, and emails them
+// when notify is set. The reason is only included in the email.
+func (uc *UseCase) Reject(ctx context.Context, userID uuid.UUID, reason string, notify bool) error {
+	user, err := uc.pendingUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+	if domain.IsDryRun(ctx) {
+		return nil
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "registration rejected", "audit", true, "user_id", userID, "reason", reason, "notify", notify)
+
+	if notify {
+		uc.notifyDecision(ctx, entities.Email{
+			To:       user.Email,
+			Template: entities.EmailRegistrationDeclined,
+			Locale:   user.Locale,
+			Data:     map[string]any{"Reason": reason},
+		})
+	}
+	return nil
+}
+
+func (uc *UseCase) pendingUser(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for approval", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	if user.Status != entities.UserStatusPending {
+		return entities.User{}, ErrNotPending
+	}
+	return user, nil
+}
+
+// notifyDecision emails the user. The decision stands when the email can't
+// be sent, so failures are only logged.
+func (uc *UseCase) notifyDecision(ctx context.Context, email entities.Email) {
+	if uc.approval == nil || uc.approval.email == nil {
+		domain.LoggerOr(ctx, uc.logger).Warn("approval email not sent, no email provider configured")
+		return
+	}
+	if err := uc.approval.email.SendEmail(ctx, email); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to send approval email", "error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/storage.go . FileStorage
+
+// MaxAvatarSize is the largest avatar image accepted, in bytes.
+const MaxAvatarSize = 2 << 20
+
+var (
+	// ErrAvatarsDisabled is returned when no file storage is configured.
+	ErrAvatarsDisabled = errors.New("avatars are not enabled")
+	ErrAvatarTooLarge  = errors.New("avatar is larger than 2 MiB")
+	ErrAvatarType      = errors.New("avatar must be a PNG, JPEG, GIF or WebP image")
+)
+
+// avatarTypes are the image types accepted as avatars, with the extension
+// they are stored with.
+var avatarTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// FileStorage keeps uploaded files under slash separated keys.
+type FileStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL is where the file stored under key is served from.
+	URL(key string) string
+}
+
+// SetAvatarStorage lets users upload avatars, kept in storage.
+func (uc *UseCase) SetAvatarStorage(storage FileStorage) {
+	uc.avatars = storage
+}
+
+// UploadAvatar stores the image read from body as the user's avatar and
+// removes the one it replaces. The image type is told from its content,
+// whatever the client claimed it to be.
+func (uc *UseCase) UploadAvatar(ctx context.Context, userID uuid.UUID, body io.Reader) (entities.User, error) {
+	if uc.avatars == nil {
+		return entities.User{}, ErrAvatarsDisabled
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxAvatarSize+1))
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if len(data) > MaxAvatarSize {
+		return entities.User{}, ErrAvatarTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := avatarTypes[contentType]
+	if !ok {
+		return entities.User{}, ErrAvatarType
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for avatar upload", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: avatar not uploaded", "user_id", userID)
+		return user, nil
+	}
+
+	// Every upload gets a new key, so caches never serve the old picture
+	key := avatarPrefix(userID) + uuid.Must(uuid.NewV4()).String() + ext
+	if err := uc.avatars.Put(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to store avatar", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	url := uc.avatars.URL(key)
+	if err := uc.repo.SetAvatarURL(ctx, userID, url); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to set avatar url", "user_id", userID, "error", err)
+		if err := uc.avatars.Delete(ctx, key); err != nil {
+			domain.LoggerOr(ctx, uc.logger).Warn("failed to remove unused avatar", "key", key, "error", err)
+		}
+		return entities.User{}, err
+	}
+
+	uc.removeAvatar(ctx, user)
+	user.AvatarURL = url
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user avatar updated", "audit", true, "user_id", userID)
+	return user, nil
+}
+
+// DeleteAvatar removes the user's avatar.
+func (uc *UseCase) DeleteAvatar(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	if uc.avatars == nil {
+		return entities.User{}, ErrAvatarsDisabled
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for avatar removal", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	if user.AvatarURL == "" {
+		return user, nil
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: avatar not removed", "user_id", userID)
+		return user, nil
+	}
+
+	if err := uc.repo.SetAvatarURL(ctx, userID, ""); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to clear avatar url", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	uc.removeAvatar(ctx, user)
+	user.AvatarURL = ""
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user avatar removed", "audit", true, "user_id", userID)
+	return user, nil
+}
+
+// removeAvatar deletes the user's avatar file, when it is one of ours. A
+// file left behind only takes space, so failures are just logged.
+func (uc *UseCase) removeAvatar(ctx context.Context, user entities.User) {
+	if uc.avatars == nil || user.AvatarURL == "" {
+		return
+	}
+	prefix := avatarPrefix(user.ID)
+	if !strings.HasPrefix(user.AvatarURL, uc.avatars.URL(prefix)) {
+		return
+	}
+	key := prefix + path.Base(user.AvatarURL)
+	if err := uc.avatars.Delete(ctx, key); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Warn("failed to remove avatar", "user_id", user.ID, "key", key, "error", err)
+	}
+}
+
+func avatarPrefix(userID uuid.UUID) string {
+	return "avatars/" + userID.String() + "/"
+}
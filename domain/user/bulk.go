@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Bulk applies op to each of its users on behalf of an admin of actorType,
+// with the same rules as the single-user operations: admins can't act on
+// themselves, regular admins only act on user accounts and can't grant
+// super_admin, and super admins can't be deleted. Users those rules or a
+// missing account keep out are skipped, and their result says why. The
+// others change in one transaction, so either all of them do or, when it
+// returns an error, none. domain.ErrConflict means some of them were deleted
+// meanwhile. Deleted users are removed from their auth provider once the
+// transaction commits.
+func (uc *UseCase) Bulk(ctx context.Context, op entities.BulkUserOperation, actorType entities.AccountType) ([]entities.BulkUserResult, error) {
+	if err := validateBulkOperation(op); err != nil {
+		return nil, err
+	}
+	if op.Action == entities.BulkUserChangeAccountType && actorType == entities.AccountTypeAdmin && op.AccountType == entities.AccountTypeSuperAdmin {
+		return nil, fmt.Errorf("regular admins can't grant super_admin: %w", domain.ErrForbidden)
+	}
+	actor, _ := domain.ActorFromContext(ctx)
+
+	// Each user once, in the order given
+	seen := make(map[uuid.UUID]bool, len(op.UserIDs))
+	results := make([]entities.BulkUserResult, 0, len(op.UserIDs))
+	var users []entities.User
+	for _, id := range op.UserIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := entities.BulkUserResult{UserID: id}
+		user, err := uc.repo.GetByID(ctx, id)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			result.Error = "user not found"
+		case err != nil:
+			domain.LoggerOr(ctx, uc.logger).Error("failed to get user for bulk operation", "user_id", id, "error", err)
+			return nil, err
+		default:
+			result.Error = bulkTargetError(op.Action, actor, actorType, user)
+		}
+		if result.Error == "" {
+			result.OK = true
+			users = append(users, user)
+		}
+		results = append(results, result)
+	}
+
+	if len(users) == 0 {
+		return results, nil
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: bulk user operation not applied", "action", op.Action, "users", len(users))
+		return results, nil
+	}
+
+	apply := op
+	apply.UserIDs = make([]uuid.UUID, len(users))
+	for i, user := range users {
+		apply.UserIDs[i] = user.ID
+	}
+	err := events.Commit(ctx, uc.events, func(ctx context.Context) ([]events.Event, error) {
+		if err := uc.repo.ApplyBulkUserOperation(ctx, apply, time.Now()); err != nil {
+			return nil, err
+		}
+		var deleted []events.Event
+		if op.Action == entities.BulkUserDelete {
+			for _, user := range users {
+				deleted = append(deleted, events.UserDeleted{User: user})
+			}
+		}
+		return deleted, nil
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to apply bulk user operation", "action", op.Action, "error", err)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("users changed while applying the operation: %w", domain.ErrConflict)
+		}
+		return nil, err
+	}
+	uc.invalidateStats()
+
+	for _, user := range users {
+		switch op.Action {
+		case entities.BulkUserDelete:
+			uc.deleteFromProvider(ctx, user)
+			uc.removeAvatar(ctx, user)
+		case entities.BulkUserChangeAccountType:
+			domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user updated", "audit", true, "bulk", true, "user_id", user.ID, "account_type", op.AccountType)
+		case entities.BulkUserSuspend:
+			domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user suspended", "audit", true, "bulk", true, "user_id", user.ID, "reason", op.Reason)
+		}
+	}
+	return results, nil
+}
+
+func validateBulkOperation(op entities.BulkUserOperation) error {
+	switch op.Action {
+	case entities.BulkUserDelete, entities.BulkUserSuspend:
+	case entities.BulkUserChangeAccountType:
+		switch op.AccountType {
+		case entities.AccountTypeUser, entities.AccountTypeAdmin, entities.AccountTypeSuperAdmin:
+		default:
+			return fmt.Errorf("%w: invalid account type %q", domain.ErrMalformedParameters, op.AccountType)
+		}
+	default:
+		return fmt.Errorf("%w: invalid action %q", domain.ErrMalformedParameters, op.Action)
+	}
+	if len(op.UserIDs) == 0 {
+		return fmt.Errorf("%w: no users", domain.ErrMalformedParameters)
+	}
+	if len(op.UserIDs) > entities.MaxBulkUsers {
+		return fmt.Errorf("%w: more than %d users", domain.ErrMalformedParameters, entities.MaxBulkUsers)
+	}
+	return nil
+}
+
+// bulkTargetError says why the actor can't apply action to user, or returns
+// "" when they can.
+func bulkTargetError(action entities.BulkUserAction, actor uuid.UUID, actorType entities.AccountType, user entities.User) string {
+	switch {
+	case user.ID == actor:
+		return "cannot change your own account"
+	case actorType == entities.AccountTypeAdmin && user.AccountType != entities.AccountTypeUser:
+		return "regular admins can only change user accounts"
+	case action == entities.BulkUserDelete && user.AccountType == entities.AccountTypeSuperAdmin:
+		return "cannot delete super admin accounts"
+	}
+	return ""
+}
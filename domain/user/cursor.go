@@ -0,0 +1,79 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ListUsersAfter is SearchUsers with cursor pagination. cursor is empty for
+// the first page and the returned next cursor for the following ones; the
+// next cursor is empty on the last page. Unlike page numbers, cursors stay
+// fast deep into large tables and don't skip or repeat users created between
+// requests. A cursor that wasn't returned by ListUsersAfter is
+// domain.ErrMalformedParameters.
+func (uc *UseCase) ListUsersAfter(ctx context.Context, cursor string, pageSize int, filter entities.UserFilter) ([]entities.User, string, error) {
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	var after *entities.UserCursor
+	if cursor != "" {
+		c, err := decodeUserCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &c
+	}
+
+	filter.Search = strings.TrimSpace(filter.Search)
+	// One more than the page tells whether another page follows
+	users, err := uc.repo.SearchUsersAfter(ctx, filter, after, int32(pageSize+1))
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to search users", "error", err)
+		return nil, "", err
+	}
+
+	var next string
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		last := users[pageSize-1]
+		next = encodeUserCursor(entities.UserCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return users, next, nil
+}
+
+// encodeUserCursor makes c opaque to clients, so the ordering can change
+// without breaking them.
+func encodeUserCursor(c entities.UserCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeUserCursor(cursor string) (entities.UserCursor, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", domain.ErrMalformedParameters)
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return entities.UserCursor{}, invalid
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return entities.UserCursor{}, invalid
+	}
+	c := entities.UserCursor{}
+	if c.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return entities.UserCursor{}, invalid
+	}
+	if c.ID, err = uuid.FromString(id); err != nil {
+		return entities.UserCursor{}, invalid
+	}
+	return c, nil
+}
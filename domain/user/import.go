@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/auth"
+	"go-template/domain/entities"
+	"go-template/domain/passwordpolicy"
+	"io"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+// importColumns are the columns an import can have. email and password are
+// required; account_type defaults to user and auth_provider to the default
+// provider.
+var importColumns = []string{"email", "password", "account_type", "auth_provider"}
+
+// ImportUsers creates a user for each row of the CSV read from r, on behalf
+// of an admin of actorType. The first row names the columns, in any order.
+// Rows are checked before any user is created: the email must be valid and
+// appear once in the file and not belong to an existing user, the account
+// type must be one the admin may create, and the password is required. Each
+// valid row is then created with CreateUser. Rows that fail either step are
+// skipped, and their result says why. A file that isn't valid CSV, lacks the
+// required columns or has more than entities.MaxImportUsers rows returns
+// domain.ErrMalformedParameters, creating nobody.
+func (uc *UseCase) ImportUsers(ctx context.Context, r io.Reader, actorType entities.AccountType) ([]entities.UserImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: empty file", domain.ErrMalformedParameters)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrMalformedParameters, err)
+	}
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrMalformedParameters, err)
+		}
+		if len(rows) == entities.MaxImportUsers {
+			return nil, fmt.Errorf("%w: more than %d users", domain.ErrMalformedParameters, entities.MaxImportUsers)
+		}
+		rows = append(rows, record)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no users", domain.ErrMalformedParameters)
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	results := make([]entities.UserImportResult, len(rows))
+	firstRow := make(map[string]int, len(rows))
+	for i, record := range rows {
+		email := field(record, "email")
+		results[i] = entities.UserImportResult{Row: i + 2, Email: email}
+		if err := uc.checkImportRow(ctx, email, field(record, "password"), entities.AccountType(field(record, "account_type")), actorType); err != "" {
+			results[i].Error = err
+			continue
+		}
+		key := strings.ToLower(email)
+		if row, ok := firstRow[key]; ok {
+			results[i].Error = fmt.Sprintf("duplicate of row %d", row)
+			continue
+		}
+		firstRow[key] = i + 2
+	}
+
+	created := 0
+	for i, record := range rows {
+		if results[i].Error != "" {
+			continue
+		}
+		user, err := uc.CreateUser(ctx, results[i].Email, field(record, "password"), field(record, "auth_provider"), entities.AccountType(field(record, "account_type")))
+		if err != nil {
+			results[i].Error = importErrorMessage(err)
+			continue
+		}
+		results[i].UserID = &user.ID
+		results[i].OK = true
+		created++
+	}
+
+	if domain.IsDryRun(ctx) {
+		return results, nil
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "users imported", "audit", true, "resource", "user", "rows", len(rows), "created", created)
+	return results, nil
+}
+
+// importColumnIndexes maps the known columns of header to their position,
+// rejecting unknown and missing required ones.
+func importColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i == 0 {
+			// Spreadsheet apps start UTF-8 CSVs with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", domain.ErrMalformedParameters, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", domain.ErrMalformedParameters, name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"email", "password"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", domain.ErrMalformedParameters, required)
+		}
+	}
+	return columns, nil
+}
+
+// checkImportRow says what keeps a row from being imported, or returns ""
+// when nothing does.
+func (uc *UseCase) checkImportRow(ctx context.Context, email, password string, accountType, actorType entities.AccountType) string {
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "invalid email"
+	}
+	switch accountType {
+	case "", entities.AccountTypeUser:
+	case entities.AccountTypeAdmin, entities.AccountTypeSuperAdmin:
+		if actorType == entities.AccountTypeAdmin {
+			return "regular admins can only create user accounts"
+		}
+	default:
+		return fmt.Sprintf("invalid account type %q", accountType)
+	}
+	if password == "" {
+		return "password is required"
+	}
+	if _, err := uc.repo.GetByEmail(ctx, email); err == nil {
+		return "user already exists"
+	}
+	return ""
+}
+
+// importErrorMessage says why CreateUser failed, in words fit for the admin.
+func importErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrDuplicateKey):
+		return "user already exists"
+	case errors.Is(err, passwordpolicy.ErrWeakPassword):
+		return err.Error()
+	case errors.Is(err, auth.ErrProviderDisabled):
+		return "auth provider is not available"
+	case errors.Is(err, auth.ErrProviderUnavailable):
+		return "authentication service unavailable"
+	}
+	return "failed to create user"
+}
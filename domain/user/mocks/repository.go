@@ -8,6 +8,7 @@ import (
 	"github.com/gofrs/uuid/v5"
 	"go-template/domain/entities"
 	"sync"
+	"time"
 )
 
 // RepositoryMock is a mock implementation of user.Repository.
@@ -16,30 +17,75 @@ import (
 //
 //		// make and configure a mocked user.Repository
 //		mockedRepository := &RepositoryMock{
+//			ApplyBulkUserOperationFunc: func(ctx context.Context, op entities.BulkUserOperation, at time.Time) error {
+//				panic("mock out the ApplyBulkUserOperation method")
+//			},
+//			CountSearchUsersFunc: func(ctx context.Context, filter entities.UserFilter) (int64, error) {
+//				panic("mock out the CountSearchUsers method")
+//			},
 //			CountUsersFunc: func(ctx context.Context) (int64, error) {
 //				panic("mock out the CountUsers method")
 //			},
 //			CountUsersByAccountTypeFunc: func(ctx context.Context, accountType entities.AccountType) (int64, error) {
 //				panic("mock out the CountUsersByAccountType method")
 //			},
+//			CountUsersByStatusFunc: func(ctx context.Context, status entities.UserStatus) (int64, error) {
+//				panic("mock out the CountUsersByStatus method")
+//			},
 //			CreateFunc: func(ctx context.Context, user entities.User) error {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
 //				panic("mock out the Delete method")
 //			},
+//			GetByAuthProviderIDFunc: func(ctx context.Context, provider string, providerID string) (entities.User, error) {
+//				panic("mock out the GetByAuthProviderID method")
+//			},
 //			GetByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
 //				panic("mock out the GetByEmail method")
 //			},
 //			GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
 //				panic("mock out the GetByID method")
 //			},
+//			GetByPhoneFunc: func(ctx context.Context, phone string) (entities.User, error) {
+//				panic("mock out the GetByPhone method")
+//			},
 //			GetUserStatsFunc: func(ctx context.Context) (entities.UserStats, error) {
 //				panic("mock out the GetUserStats method")
 //			},
 //			ListUsersFunc: func(ctx context.Context, params entities.ListUsersParams) ([]entities.User, error) {
 //				panic("mock out the ListUsers method")
 //			},
+//			ListUsersByStatusFunc: func(ctx context.Context, status entities.UserStatus, params entities.ListUsersParams) ([]entities.User, error) {
+//				panic("mock out the ListUsersByStatus method")
+//			},
+//			ListUsersWithTotalFunc: func(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error) {
+//				panic("mock out the ListUsersWithTotal method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			SearchUsersAfterFunc: func(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error) {
+//				panic("mock out the SearchUsersAfter method")
+//			},
+//			SetAvatarURLFunc: func(ctx context.Context, id uuid.UUID, url string) error {
+//				panic("mock out the SetAvatarURL method")
+//			},
+//			SetEmailFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+//				panic("mock out the SetEmail method")
+//			},
+//			SetEmailVerifiedFunc: func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+//				panic("mock out the SetEmailVerified method")
+//			},
+//			SetPhoneFunc: func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+//				panic("mock out the SetPhone method")
+//			},
+//			SetProfileFunc: func(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error {
+//				panic("mock out the SetProfile method")
+//			},
+//			SetStatusFunc: func(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error {
+//				panic("mock out the SetStatus method")
+//			},
 //			UpdateFunc: func(ctx context.Context, user entities.User) error {
 //				panic("mock out the Update method")
 //			},
@@ -50,35 +96,96 @@ import (
 //
 //	}
 type RepositoryMock struct {
+	// ApplyBulkUserOperationFunc mocks the ApplyBulkUserOperation method.
+	ApplyBulkUserOperationFunc func(ctx context.Context, op entities.BulkUserOperation, at time.Time) error
+
+	// CountSearchUsersFunc mocks the CountSearchUsers method.
+	CountSearchUsersFunc func(ctx context.Context, filter entities.UserFilter) (int64, error)
+
 	// CountUsersFunc mocks the CountUsers method.
 	CountUsersFunc func(ctx context.Context) (int64, error)
 
 	// CountUsersByAccountTypeFunc mocks the CountUsersByAccountType method.
 	CountUsersByAccountTypeFunc func(ctx context.Context, accountType entities.AccountType) (int64, error)
 
+	// CountUsersByStatusFunc mocks the CountUsersByStatus method.
+	CountUsersByStatusFunc func(ctx context.Context, status entities.UserStatus) (int64, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, user entities.User) error
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, id uuid.UUID) error
 
+	// GetByAuthProviderIDFunc mocks the GetByAuthProviderID method.
+	GetByAuthProviderIDFunc func(ctx context.Context, provider string, providerID string) (entities.User, error)
+
 	// GetByEmailFunc mocks the GetByEmail method.
 	GetByEmailFunc func(ctx context.Context, email string) (entities.User, error)
 
 	// GetByIDFunc mocks the GetByID method.
 	GetByIDFunc func(ctx context.Context, id uuid.UUID) (entities.User, error)
 
+	// GetByPhoneFunc mocks the GetByPhone method.
+	GetByPhoneFunc func(ctx context.Context, phone string) (entities.User, error)
+
 	// GetUserStatsFunc mocks the GetUserStats method.
 	GetUserStatsFunc func(ctx context.Context) (entities.UserStats, error)
 
 	// ListUsersFunc mocks the ListUsers method.
 	ListUsersFunc func(ctx context.Context, params entities.ListUsersParams) ([]entities.User, error)
 
+	// ListUsersByStatusFunc mocks the ListUsersByStatus method.
+	ListUsersByStatusFunc func(ctx context.Context, status entities.UserStatus, params entities.ListUsersParams) ([]entities.User, error)
+
+	// ListUsersWithTotalFunc mocks the ListUsersWithTotal method.
+	ListUsersWithTotalFunc func(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error)
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error)
+
+	// SearchUsersAfterFunc mocks the SearchUsersAfter method.
+	SearchUsersAfterFunc func(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error)
+
+	// SetAvatarURLFunc mocks the SetAvatarURL method.
+	SetAvatarURLFunc func(ctx context.Context, id uuid.UUID, url string) error
+
+	// SetEmailFunc mocks the SetEmail method.
+	SetEmailFunc func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+
+	// SetEmailVerifiedFunc mocks the SetEmailVerified method.
+	SetEmailVerifiedFunc func(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+
+	// SetPhoneFunc mocks the SetPhone method.
+	SetPhoneFunc func(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+
+	// SetProfileFunc mocks the SetProfile method.
+	SetProfileFunc func(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error
+
+	// SetStatusFunc mocks the SetStatus method.
+	SetStatusFunc func(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, user entities.User) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ApplyBulkUserOperation holds details about calls to the ApplyBulkUserOperation method.
+		ApplyBulkUserOperation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Op is the op argument value.
+			Op entities.BulkUserOperation
+			// At is the at argument value.
+			At time.Time
+		}
+		// CountSearchUsers holds details about calls to the CountSearchUsers method.
+		CountSearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+		}
 		// CountUsers holds details about calls to the CountUsers method.
 		CountUsers []struct {
 			// Ctx is the ctx argument value.
@@ -91,6 +198,13 @@ type RepositoryMock struct {
 			// AccountType is the accountType argument value.
 			AccountType entities.AccountType
 		}
+		// CountUsersByStatus holds details about calls to the CountUsersByStatus method.
+		CountUsersByStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status entities.UserStatus
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -105,6 +219,15 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// GetByAuthProviderID holds details about calls to the GetByAuthProviderID method.
+		GetByAuthProviderID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// ProviderID is the providerID argument value.
+			ProviderID string
+		}
 		// GetByEmail holds details about calls to the GetByEmail method.
 		GetByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -119,6 +242,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// GetByPhone holds details about calls to the GetByPhone method.
+		GetByPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+		}
 		// GetUserStats holds details about calls to the GetUserStats method.
 		GetUserStats []struct {
 			// Ctx is the ctx argument value.
@@ -131,6 +261,106 @@ type RepositoryMock struct {
 			// Params is the params argument value.
 			Params entities.ListUsersParams
 		}
+		// ListUsersByStatus holds details about calls to the ListUsersByStatus method.
+		ListUsersByStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status entities.UserStatus
+			// Params is the params argument value.
+			Params entities.ListUsersParams
+		}
+		// ListUsersWithTotal holds details about calls to the ListUsersWithTotal method.
+		ListUsersWithTotal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params entities.ListUsersParams
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+			// Params is the params argument value.
+			Params entities.ListUsersParams
+		}
+		// SearchUsersAfter holds details about calls to the SearchUsersAfter method.
+		SearchUsersAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter entities.UserFilter
+			// After is the after argument value.
+			After *entities.UserCursor
+			// Limit is the limit argument value.
+			Limit int32
+		}
+		// SetAvatarURL holds details about calls to the SetAvatarURL method.
+		SetAvatarURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// URL is the url argument value.
+			URL string
+		}
+		// SetEmail holds details about calls to the SetEmail method.
+		SetEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Email is the email argument value.
+			Email string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+		// SetEmailVerified holds details about calls to the SetEmailVerified method.
+		SetEmailVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Email is the email argument value.
+			Email string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+		// SetPhone holds details about calls to the SetPhone method.
+		SetPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Phone is the phone argument value.
+			Phone string
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+		// SetProfile holds details about calls to the SetProfile method.
+		SetProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Profile is the profile argument value.
+			Profile entities.UserProfile
+		}
+		// SetStatus holds details about calls to the SetStatus method.
+		SetStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Status is the status argument value.
+			Status entities.UserStatus
+			// Reason is the reason argument value.
+			Reason string
+			// At is the at argument value.
+			At time.Time
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -139,17 +369,115 @@ type RepositoryMock struct {
 			User entities.User
 		}
 	}
+	lockApplyBulkUserOperation  sync.RWMutex
+	lockCountSearchUsers        sync.RWMutex
 	lockCountUsers              sync.RWMutex
 	lockCountUsersByAccountType sync.RWMutex
+	lockCountUsersByStatus      sync.RWMutex
 	lockCreate                  sync.RWMutex
 	lockDelete                  sync.RWMutex
+	lockGetByAuthProviderID     sync.RWMutex
 	lockGetByEmail              sync.RWMutex
 	lockGetByID                 sync.RWMutex
+	lockGetByPhone              sync.RWMutex
 	lockGetUserStats            sync.RWMutex
 	lockListUsers               sync.RWMutex
+	lockListUsersByStatus       sync.RWMutex
+	lockListUsersWithTotal      sync.RWMutex
+	lockSearchUsers             sync.RWMutex
+	lockSearchUsersAfter        sync.RWMutex
+	lockSetAvatarURL            sync.RWMutex
+	lockSetEmail                sync.RWMutex
+	lockSetEmailVerified        sync.RWMutex
+	lockSetPhone                sync.RWMutex
+	lockSetProfile              sync.RWMutex
+	lockSetStatus               sync.RWMutex
 	lockUpdate                  sync.RWMutex
 }
 
+// ApplyBulkUserOperation calls ApplyBulkUserOperationFunc.
+func (mock *RepositoryMock) ApplyBulkUserOperation(ctx context.Context, op entities.BulkUserOperation, at time.Time) error {
+	callInfo := struct {
+		Ctx context.Context
+		Op  entities.BulkUserOperation
+		At  time.Time
+	}{
+		Ctx: ctx,
+		Op:  op,
+		At:  at,
+	}
+	mock.lockApplyBulkUserOperation.Lock()
+	mock.calls.ApplyBulkUserOperation = append(mock.calls.ApplyBulkUserOperation, callInfo)
+	mock.lockApplyBulkUserOperation.Unlock()
+	if mock.ApplyBulkUserOperationFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.ApplyBulkUserOperationFunc(ctx, op, at)
+}
+
+// ApplyBulkUserOperationCalls gets all the calls that were made to ApplyBulkUserOperation.
+// Check the length with:
+//
+//	len(mockedRepository.ApplyBulkUserOperationCalls())
+func (mock *RepositoryMock) ApplyBulkUserOperationCalls() []struct {
+	Ctx context.Context
+	Op  entities.BulkUserOperation
+	At  time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Op  entities.BulkUserOperation
+		At  time.Time
+	}
+	mock.lockApplyBulkUserOperation.RLock()
+	calls = mock.calls.ApplyBulkUserOperation
+	mock.lockApplyBulkUserOperation.RUnlock()
+	return calls
+}
+
+// CountSearchUsers calls CountSearchUsersFunc.
+func (mock *RepositoryMock) CountSearchUsers(ctx context.Context, filter entities.UserFilter) (int64, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockCountSearchUsers.Lock()
+	mock.calls.CountSearchUsers = append(mock.calls.CountSearchUsers, callInfo)
+	mock.lockCountSearchUsers.Unlock()
+	if mock.CountSearchUsersFunc == nil {
+		var (
+			nOut   int64
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.CountSearchUsersFunc(ctx, filter)
+}
+
+// CountSearchUsersCalls gets all the calls that were made to CountSearchUsers.
+// Check the length with:
+//
+//	len(mockedRepository.CountSearchUsersCalls())
+func (mock *RepositoryMock) CountSearchUsersCalls() []struct {
+	Ctx    context.Context
+	Filter entities.UserFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+	}
+	mock.lockCountSearchUsers.RLock()
+	calls = mock.calls.CountSearchUsers
+	mock.lockCountSearchUsers.RUnlock()
+	return calls
+}
+
 // CountUsers calls CountUsersFunc.
 func (mock *RepositoryMock) CountUsers(ctx context.Context) (int64, error) {
 	callInfo := struct {
@@ -226,6 +554,46 @@ func (mock *RepositoryMock) CountUsersByAccountTypeCalls() []struct {
 	return calls
 }
 
+// CountUsersByStatus calls CountUsersByStatusFunc.
+func (mock *RepositoryMock) CountUsersByStatus(ctx context.Context, status entities.UserStatus) (int64, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Status entities.UserStatus
+	}{
+		Ctx:    ctx,
+		Status: status,
+	}
+	mock.lockCountUsersByStatus.Lock()
+	mock.calls.CountUsersByStatus = append(mock.calls.CountUsersByStatus, callInfo)
+	mock.lockCountUsersByStatus.Unlock()
+	if mock.CountUsersByStatusFunc == nil {
+		var (
+			nOut   int64
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.CountUsersByStatusFunc(ctx, status)
+}
+
+// CountUsersByStatusCalls gets all the calls that were made to CountUsersByStatus.
+// Check the length with:
+//
+//	len(mockedRepository.CountUsersByStatusCalls())
+func (mock *RepositoryMock) CountUsersByStatusCalls() []struct {
+	Ctx    context.Context
+	Status entities.UserStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status entities.UserStatus
+	}
+	mock.lockCountUsersByStatus.RLock()
+	calls = mock.calls.CountUsersByStatus
+	mock.lockCountUsersByStatus.RUnlock()
+	return calls
+}
+
 // Create calls CreateFunc.
 func (mock *RepositoryMock) Create(ctx context.Context, user entities.User) error {
 	callInfo := struct {
@@ -304,6 +672,50 @@ func (mock *RepositoryMock) DeleteCalls() []struct {
 	return calls
 }
 
+// GetByAuthProviderID calls GetByAuthProviderIDFunc.
+func (mock *RepositoryMock) GetByAuthProviderID(ctx context.Context, provider string, providerID string) (entities.User, error) {
+	callInfo := struct {
+		Ctx        context.Context
+		Provider   string
+		ProviderID string
+	}{
+		Ctx:        ctx,
+		Provider:   provider,
+		ProviderID: providerID,
+	}
+	mock.lockGetByAuthProviderID.Lock()
+	mock.calls.GetByAuthProviderID = append(mock.calls.GetByAuthProviderID, callInfo)
+	mock.lockGetByAuthProviderID.Unlock()
+	if mock.GetByAuthProviderIDFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.GetByAuthProviderIDFunc(ctx, provider, providerID)
+}
+
+// GetByAuthProviderIDCalls gets all the calls that were made to GetByAuthProviderID.
+// Check the length with:
+//
+//	len(mockedRepository.GetByAuthProviderIDCalls())
+func (mock *RepositoryMock) GetByAuthProviderIDCalls() []struct {
+	Ctx        context.Context
+	Provider   string
+	ProviderID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Provider   string
+		ProviderID string
+	}
+	mock.lockGetByAuthProviderID.RLock()
+	calls = mock.calls.GetByAuthProviderID
+	mock.lockGetByAuthProviderID.RUnlock()
+	return calls
+}
+
 // GetByEmail calls GetByEmailFunc.
 func (mock *RepositoryMock) GetByEmail(ctx context.Context, email string) (entities.User, error) {
 	callInfo := struct {
@@ -384,6 +796,46 @@ func (mock *RepositoryMock) GetByIDCalls() []struct {
 	return calls
 }
 
+// GetByPhone calls GetByPhoneFunc.
+func (mock *RepositoryMock) GetByPhone(ctx context.Context, phone string) (entities.User, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+	}
+	mock.lockGetByPhone.Lock()
+	mock.calls.GetByPhone = append(mock.calls.GetByPhone, callInfo)
+	mock.lockGetByPhone.Unlock()
+	if mock.GetByPhoneFunc == nil {
+		var (
+			userOut entities.User
+			errOut  error
+		)
+		return userOut, errOut
+	}
+	return mock.GetByPhoneFunc(ctx, phone)
+}
+
+// GetByPhoneCalls gets all the calls that were made to GetByPhone.
+// Check the length with:
+//
+//	len(mockedRepository.GetByPhoneCalls())
+func (mock *RepositoryMock) GetByPhoneCalls() []struct {
+	Ctx   context.Context
+	Phone string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+	}
+	mock.lockGetByPhone.RLock()
+	calls = mock.calls.GetByPhone
+	mock.lockGetByPhone.RUnlock()
+	return calls
+}
+
 // GetUserStats calls GetUserStatsFunc.
 func (mock *RepositoryMock) GetUserStats(ctx context.Context) (entities.UserStats, error) {
 	callInfo := struct {
@@ -460,6 +912,461 @@ func (mock *RepositoryMock) ListUsersCalls() []struct {
 	return calls
 }
 
+// ListUsersByStatus calls ListUsersByStatusFunc.
+func (mock *RepositoryMock) ListUsersByStatus(ctx context.Context, status entities.UserStatus, params entities.ListUsersParams) ([]entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Status entities.UserStatus
+		Params entities.ListUsersParams
+	}{
+		Ctx:    ctx,
+		Status: status,
+		Params: params,
+	}
+	mock.lockListUsersByStatus.Lock()
+	mock.calls.ListUsersByStatus = append(mock.calls.ListUsersByStatus, callInfo)
+	mock.lockListUsersByStatus.Unlock()
+	if mock.ListUsersByStatusFunc == nil {
+		var (
+			usersOut []entities.User
+			errOut   error
+		)
+		return usersOut, errOut
+	}
+	return mock.ListUsersByStatusFunc(ctx, status, params)
+}
+
+// ListUsersByStatusCalls gets all the calls that were made to ListUsersByStatus.
+// Check the length with:
+//
+//	len(mockedRepository.ListUsersByStatusCalls())
+func (mock *RepositoryMock) ListUsersByStatusCalls() []struct {
+	Ctx    context.Context
+	Status entities.UserStatus
+	Params entities.ListUsersParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status entities.UserStatus
+		Params entities.ListUsersParams
+	}
+	mock.lockListUsersByStatus.RLock()
+	calls = mock.calls.ListUsersByStatus
+	mock.lockListUsersByStatus.RUnlock()
+	return calls
+}
+
+// ListUsersWithTotal calls ListUsersWithTotalFunc.
+func (mock *RepositoryMock) ListUsersWithTotal(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Params entities.ListUsersParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListUsersWithTotal.Lock()
+	mock.calls.ListUsersWithTotal = append(mock.calls.ListUsersWithTotal, callInfo)
+	mock.lockListUsersWithTotal.Unlock()
+	if mock.ListUsersWithTotalFunc == nil {
+		var (
+			usersOut []entities.User
+			nOut     int64
+			errOut   error
+		)
+		return usersOut, nOut, errOut
+	}
+	return mock.ListUsersWithTotalFunc(ctx, params)
+}
+
+// ListUsersWithTotalCalls gets all the calls that were made to ListUsersWithTotal.
+// Check the length with:
+//
+//	len(mockedRepository.ListUsersWithTotalCalls())
+func (mock *RepositoryMock) ListUsersWithTotalCalls() []struct {
+	Ctx    context.Context
+	Params entities.ListUsersParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params entities.ListUsersParams
+	}
+	mock.lockListUsersWithTotal.RLock()
+	calls = mock.calls.ListUsersWithTotal
+	mock.lockListUsersWithTotal.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *RepositoryMock) SearchUsers(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		Params entities.ListUsersParams
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Params: params,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	if mock.SearchUsersFunc == nil {
+		var (
+			usersOut []entities.User
+			errOut   error
+		)
+		return usersOut, errOut
+	}
+	return mock.SearchUsersFunc(ctx, filter, params)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedRepository.SearchUsersCalls())
+func (mock *RepositoryMock) SearchUsersCalls() []struct {
+	Ctx    context.Context
+	Filter entities.UserFilter
+	Params entities.ListUsersParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		Params entities.ListUsersParams
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
+// SearchUsersAfter calls SearchUsersAfterFunc.
+func (mock *RepositoryMock) SearchUsersAfter(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		After  *entities.UserCursor
+		Limit  int32
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		After:  after,
+		Limit:  limit,
+	}
+	mock.lockSearchUsersAfter.Lock()
+	mock.calls.SearchUsersAfter = append(mock.calls.SearchUsersAfter, callInfo)
+	mock.lockSearchUsersAfter.Unlock()
+	if mock.SearchUsersAfterFunc == nil {
+		var (
+			usersOut []entities.User
+			errOut   error
+		)
+		return usersOut, errOut
+	}
+	return mock.SearchUsersAfterFunc(ctx, filter, after, limit)
+}
+
+// SearchUsersAfterCalls gets all the calls that were made to SearchUsersAfter.
+// Check the length with:
+//
+//	len(mockedRepository.SearchUsersAfterCalls())
+func (mock *RepositoryMock) SearchUsersAfterCalls() []struct {
+	Ctx    context.Context
+	Filter entities.UserFilter
+	After  *entities.UserCursor
+	Limit  int32
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter entities.UserFilter
+		After  *entities.UserCursor
+		Limit  int32
+	}
+	mock.lockSearchUsersAfter.RLock()
+	calls = mock.calls.SearchUsersAfter
+	mock.lockSearchUsersAfter.RUnlock()
+	return calls
+}
+
+// SetAvatarURL calls SetAvatarURLFunc.
+func (mock *RepositoryMock) SetAvatarURL(ctx context.Context, id uuid.UUID, url string) error {
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		URL string
+	}{
+		Ctx: ctx,
+		ID:  id,
+		URL: url,
+	}
+	mock.lockSetAvatarURL.Lock()
+	mock.calls.SetAvatarURL = append(mock.calls.SetAvatarURL, callInfo)
+	mock.lockSetAvatarURL.Unlock()
+	if mock.SetAvatarURLFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetAvatarURLFunc(ctx, id, url)
+}
+
+// SetAvatarURLCalls gets all the calls that were made to SetAvatarURL.
+// Check the length with:
+//
+//	len(mockedRepository.SetAvatarURLCalls())
+func (mock *RepositoryMock) SetAvatarURLCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	URL string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		URL string
+	}
+	mock.lockSetAvatarURL.RLock()
+	calls = mock.calls.SetAvatarURL
+	mock.lockSetAvatarURL.RUnlock()
+	return calls
+}
+
+// SetEmail calls SetEmailFunc.
+func (mock *RepositoryMock) SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Email:      email,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetEmail.Lock()
+	mock.calls.SetEmail = append(mock.calls.SetEmail, callInfo)
+	mock.lockSetEmail.Unlock()
+	if mock.SetEmailFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetEmailFunc(ctx, id, email, verifiedAt)
+}
+
+// SetEmailCalls gets all the calls that were made to SetEmail.
+// Check the length with:
+//
+//	len(mockedRepository.SetEmailCalls())
+func (mock *RepositoryMock) SetEmailCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Email      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetEmail.RLock()
+	calls = mock.calls.SetEmail
+	mock.lockSetEmail.RUnlock()
+	return calls
+}
+
+// SetEmailVerified calls SetEmailVerifiedFunc.
+func (mock *RepositoryMock) SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Email:      email,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetEmailVerified.Lock()
+	mock.calls.SetEmailVerified = append(mock.calls.SetEmailVerified, callInfo)
+	mock.lockSetEmailVerified.Unlock()
+	if mock.SetEmailVerifiedFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetEmailVerifiedFunc(ctx, id, email, verifiedAt)
+}
+
+// SetEmailVerifiedCalls gets all the calls that were made to SetEmailVerified.
+// Check the length with:
+//
+//	len(mockedRepository.SetEmailVerifiedCalls())
+func (mock *RepositoryMock) SetEmailVerifiedCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Email      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Email      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetEmailVerified.RLock()
+	calls = mock.calls.SetEmailVerified
+	mock.lockSetEmailVerified.RUnlock()
+	return calls
+}
+
+// SetPhone calls SetPhoneFunc.
+func (mock *RepositoryMock) SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Phone      string
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		Phone:      phone,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockSetPhone.Lock()
+	mock.calls.SetPhone = append(mock.calls.SetPhone, callInfo)
+	mock.lockSetPhone.Unlock()
+	if mock.SetPhoneFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetPhoneFunc(ctx, id, phone, verifiedAt)
+}
+
+// SetPhoneCalls gets all the calls that were made to SetPhone.
+// Check the length with:
+//
+//	len(mockedRepository.SetPhoneCalls())
+func (mock *RepositoryMock) SetPhoneCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	Phone      string
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		Phone      string
+		VerifiedAt time.Time
+	}
+	mock.lockSetPhone.RLock()
+	calls = mock.calls.SetPhone
+	mock.lockSetPhone.RUnlock()
+	return calls
+}
+
+// SetProfile calls SetProfileFunc.
+func (mock *RepositoryMock) SetProfile(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		Profile entities.UserProfile
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Profile: profile,
+	}
+	mock.lockSetProfile.Lock()
+	mock.calls.SetProfile = append(mock.calls.SetProfile, callInfo)
+	mock.lockSetProfile.Unlock()
+	if mock.SetProfileFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetProfileFunc(ctx, id, profile)
+}
+
+// SetProfileCalls gets all the calls that were made to SetProfile.
+// Check the length with:
+//
+//	len(mockedRepository.SetProfileCalls())
+func (mock *RepositoryMock) SetProfileCalls() []struct {
+	Ctx     context.Context
+	ID      uuid.UUID
+	Profile entities.UserProfile
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		Profile entities.UserProfile
+	}
+	mock.lockSetProfile.RLock()
+	calls = mock.calls.SetProfile
+	mock.lockSetProfile.RUnlock()
+	return calls
+}
+
+// SetStatus calls SetStatusFunc.
+func (mock *RepositoryMock) SetStatus(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error {
+	callInfo := struct {
+		Ctx    context.Context
+		ID     uuid.UUID
+		Status entities.UserStatus
+		Reason string
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Status: status,
+		Reason: reason,
+		At:     at,
+	}
+	mock.lockSetStatus.Lock()
+	mock.calls.SetStatus = append(mock.calls.SetStatus, callInfo)
+	mock.lockSetStatus.Unlock()
+	if mock.SetStatusFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SetStatusFunc(ctx, id, status, reason, at)
+}
+
+// SetStatusCalls gets all the calls that were made to SetStatus.
+// Check the length with:
+//
+//	len(mockedRepository.SetStatusCalls())
+func (mock *RepositoryMock) SetStatusCalls() []struct {
+	Ctx    context.Context
+	ID     uuid.UUID
+	Status entities.UserStatus
+	Reason string
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     uuid.UUID
+		Status entities.UserStatus
+		Reason string
+		At     time.Time
+	}
+	mock.lockSetStatus.RLock()
+	calls = mock.calls.SetStatus
+	mock.lockSetStatus.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *RepositoryMock) Update(ctx context.Context, user entities.User) error {
 	callInfo := struct {
@@ -0,0 +1,22 @@
+package user
+
+import "context"
+
+// PasswordValidator checks new passwords against the password policy.
+type PasswordValidator interface {
+	Validate(ctx context.Context, password string) error
+}
+
+// SetPasswordPolicy makes every way of creating a user reject passwords
+// that break the policy.
+func (uc *UseCase) SetPasswordPolicy(policy PasswordValidator) {
+	uc.passwordPolicy = policy
+}
+
+// validatePassword returns the policy's error for password, if any.
+func (uc *UseCase) validatePassword(ctx context.Context, password string) error {
+	if uc.passwordPolicy == nil {
+		return nil
+	}
+	return uc.passwordPolicy.Validate(ctx, password)
+}
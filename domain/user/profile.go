@@ -0,0 +1,106 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"strings"
+	"time"
+	// Time zones are checked against the embedded database, so they don't
+	// depend on the host having one
+	_ "time/tzdata"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+	"golang.org/x/text/language"
+)
+
+const (
+	// MaxNameLength is the longest first, last or display name, in
+	// characters.
+	MaxNameLength = 100
+	// MaxMetadataSize is the most a user's metadata takes as JSON, in bytes.
+	MaxMetadataSize = 16 << 10
+)
+
+// UpdateProfile replaces the user's profile. Names are trimmed, and the
+// locale is stored in its canonical form. A nil Metadata keeps the user's
+// metadata; an empty one clears it. Invalid values are reported with
+// domain.ErrMalformedParameters.
+func (uc *UseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, profile entities.UserProfile) (entities.User, error) {
+	profile, err := normalizeProfile(profile)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for profile update", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+	if profile.Metadata == nil {
+		profile.Metadata = user.Metadata
+	}
+	user.UserProfile = profile
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user profile not updated", "user_id", userID)
+		return user, nil
+	}
+
+	if err := uc.repo.SetProfile(ctx, userID, profile); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to set user profile", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user profile updated", "audit", true, "user_id", userID)
+	return user, nil
+}
+
+func normalizeProfile(profile entities.UserProfile) (entities.UserProfile, error) {
+	profile.FirstName = strings.TrimSpace(profile.FirstName)
+	profile.LastName = strings.TrimSpace(profile.LastName)
+	profile.DisplayName = strings.TrimSpace(profile.DisplayName)
+	profile.Timezone = strings.TrimSpace(profile.Timezone)
+	profile.Locale = strings.TrimSpace(profile.Locale)
+
+	for field, name := range map[string]string{
+		"first_name":   profile.FirstName,
+		"last_name":    profile.LastName,
+		"display_name": profile.DisplayName,
+	} {
+		if utf8.RuneCountInString(name) > MaxNameLength {
+			return profile, fmt.Errorf("%s is longer than %d characters: %w", field, MaxNameLength, domain.ErrMalformedParameters)
+		}
+	}
+
+	if profile.Timezone != "" {
+		// LoadLocation takes "Local" for the server's zone, which means
+		// nothing to the user
+		if _, err := time.LoadLocation(profile.Timezone); err != nil || profile.Timezone == "Local" {
+			return profile, fmt.Errorf("unknown timezone %q: %w", profile.Timezone, domain.ErrMalformedParameters)
+		}
+	}
+
+	if profile.Locale != "" {
+		tag, err := language.Parse(profile.Locale)
+		if err != nil {
+			return profile, fmt.Errorf("invalid locale %q: %w", profile.Locale, domain.ErrMalformedParameters)
+		}
+		profile.Locale = tag.String()
+	}
+
+	if profile.Metadata != nil {
+		b, err := json.Marshal(profile.Metadata)
+		if err != nil {
+			return profile, fmt.Errorf("invalid metadata: %w", domain.ErrMalformedParameters)
+		}
+		if len(b) > MaxMetadataSize {
+			return profile, fmt.Errorf("metadata is larger than %d bytes: %w", MaxMetadataSize, domain.ErrMalformedParameters)
+		}
+	}
+
+	return profile, nil
+}
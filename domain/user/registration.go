@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	// ErrRegistrationDisabled is returned by Register while the
+	// RegistrationEnabled setting is off and invitations aren't allowed.
+	ErrRegistrationDisabled = errors.New("registration is disabled")
+	// ErrInvitationRequired is returned by Register without an invitation
+	// code while registration is only open to invited people.
+	ErrInvitationRequired = errors.New("an invitation code is required")
+)
+
+// Invitations redeems the invitation codes people register with.
+type Invitations interface {
+	// Redeem uses up one use of the invitation with the code for a user
+	// registering as email.
+	Redeem(ctx context.Context, code, email string) (entities.Invitation, error)
+	// EmailInvitation returns the active invitation an admin emailed with
+	// the code.
+	EmailInvitation(ctx context.Context, code string) (entities.Invitation, error)
+	// Release gives back the use Redeem took.
+	Release(ctx context.Context, id uuid.UUID) error
+}
+
+type registration struct {
+	settings    SettingsReader
+	invitations Invitations
+}
+
+// SetRegistration makes Register follow the RegistrationEnabled and
+// InvitationsEnabled settings: while registration is disabled, only people
+// with an invitation code can register, if invitations are allowed at all.
+// Without it registration is always open.
+func (uc *UseCase) SetRegistration(settings SettingsReader, invitations Invitations) {
+	uc.registration = &registration{settings: settings, invitations: invitations}
+}
+
+// Register creates a user signing up on their own, with the default auth
+// provider. A valid invitation code gives the user the invitation's account
+// type, and is required while registration is disabled. Users without one
+// are pending while approval is required, and can't sign in until approved;
+// invited users were vetted by the admin who invited them.
+func (uc *UseCase) Register(ctx context.Context, email, password, invitationCode string) (entities.User, error) {
+	invitation, err := uc.redeemInvitation(ctx, invitationCode, email)
+	if err != nil {
+		return entities.User{}, err
+	}
+	if invitation != nil {
+		return uc.createInvitedUser(ctx, email, password, *invitation, userCreated(true))
+	}
+
+	required, err := uc.ApprovalRequired(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to check approval setting", "error", err)
+		return entities.User{}, err
+	}
+
+	status := entities.UserStatusActive
+	if required {
+		status = entities.UserStatusPending
+	}
+	return uc.createUser(ctx, email, password, "", entities.AccountTypeUser, status, userCreated(true))
+}
+
+// AcceptInvitation creates the user an admin emailed an invitation to, with
+// the password they chose. The address is verified by the invitee having
+// the code, and admins send these invitations whatever the registration
+// settings are.
+func (uc *UseCase) AcceptInvitation(ctx context.Context, code, password string) (entities.User, error) {
+	if uc.registration == nil || uc.registration.invitations == nil {
+		return entities.User{}, ErrRegistrationDisabled
+	}
+
+	sent, err := uc.registration.invitations.EmailInvitation(ctx, code)
+	if err != nil {
+		return entities.User{}, err
+	}
+	invitation, err := uc.registration.invitations.Redeem(ctx, code, sent.Email)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	return uc.createInvitedUser(ctx, invitation.Email, password, invitation, func(ctx context.Context, user *entities.User) events.Event {
+		now := time.Now()
+		if err := uc.repo.SetEmailVerified(ctx, user.ID, user.Email, now); err != nil {
+			// The user can still verify the address the usual way
+			domain.LoggerOr(ctx, uc.logger).Error("failed to mark invited user's email verified", "user_id", user.ID, "error", err)
+		} else {
+			user.EmailVerified = true
+			user.EmailVerifiedAt = &now
+		}
+		return events.UserCreated{User: *user}
+	})
+}
+
+// createInvitedUser creates an active user with the invitation's account
+// type, giving the invitation's use back when that fails.
+func (uc *UseCase) createInvitedUser(ctx context.Context, email, password string, invitation entities.Invitation, created func(ctx context.Context, user *entities.User) events.Event) (entities.User, error) {
+	user, err := uc.createUser(ctx, email, password, "", invitation.AccountType, entities.UserStatusActive, created)
+	if err != nil {
+		if releaseErr := uc.registration.invitations.Release(ctx, invitation.ID); releaseErr != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to release invitation", "invitation_id", invitation.ID, "error", releaseErr)
+		}
+		return entities.User{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "invitation redeemed", "audit", true, "user_id", user.ID,
+		"resource", entities.AuditResourceInvitation, "resource_id", invitation.ID, "account_type", invitation.AccountType)
+	return user, nil
+}
+
+// redeemInvitation checks whether the user registering as email may
+// register, redeeming the invitation code when there is one. It returns nil
+// without a code.
+func (uc *UseCase) redeemInvitation(ctx context.Context, code, email string) (*entities.Invitation, error) {
+	if uc.registration == nil {
+		return nil, nil
+	}
+
+	settings, err := uc.registration.settings.GetSettings(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to check registration settings", "error", err)
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	invitationsEnabled := settings.InvitationsEnabled && uc.registration.invitations != nil
+	if !settings.RegistrationEnabled && !invitationsEnabled {
+		return nil, ErrRegistrationDisabled
+	}
+	if code == "" {
+		if !settings.RegistrationEnabled {
+			return nil, ErrInvitationRequired
+		}
+		return nil, nil
+	}
+	if !invitationsEnabled {
+		// Codes mean nothing while invitations are off
+		return nil, nil
+	}
+
+	invitation, err := uc.registration.invitations.Redeem(ctx, code, email)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
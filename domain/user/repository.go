@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"go-template/domain/entities"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
@@ -13,12 +14,47 @@ type Repository interface {
 	Create(ctx context.Context, user entities.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (entities.User, error)
 	GetByEmail(ctx context.Context, email string) (entities.User, error)
+	GetByAuthProviderID(ctx context.Context, provider, providerID string) (entities.User, error)
+	GetByPhone(ctx context.Context, phone string) (entities.User, error)
+	// SetPhone stores a verified phone number, or removes it when phone is
+	// empty. Returns domain.ErrDuplicateKey when another user has it.
+	SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error
+	// SetEmail replaces the user's email with a confirmed address. Returns
+	// domain.ErrDuplicateKey when another user has it.
+	SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	// SetEmailVerified marks email as the user's verified address. Returns
+	// domain.ErrNotFound when the user's email has changed since.
+	SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	// SetStatus changes the user's status, keeping reason and at only when
+	// suspending. Returns domain.ErrNotFound when there is no such user.
+	SetStatus(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error
+	// SetProfile replaces the user's profile. Returns domain.ErrNotFound
+	// when there is no such user.
+	SetProfile(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error
+	// SetAvatarURL replaces the user's avatar URL, or removes it when url is
+	// empty. Returns domain.ErrNotFound when there is no such user.
+	SetAvatarURL(ctx context.Context, id uuid.UUID, url string) error
 	Update(ctx context.Context, user entities.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Admin-specific methods
 	ListUsers(ctx context.Context, params entities.ListUsersParams) ([]entities.User, error)
+	// ListUsersWithTotal is ListUsers and CountUsers in one query.
+	ListUsersWithTotal(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CountUsersByAccountType(ctx context.Context, accountType entities.AccountType) (int64, error)
+	// SearchUsers lists the users matching filter, newest first.
+	SearchUsers(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error)
+	CountSearchUsers(ctx context.Context, filter entities.UserFilter) (int64, error)
+	// SearchUsersAfter lists up to limit users matching filter that come
+	// after the cursor, newest first, or from the newest when after is nil.
+	SearchUsersAfter(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error)
+	// ListUsersByStatus lists the users with status, oldest first.
+	ListUsersByStatus(ctx context.Context, status entities.UserStatus, params entities.ListUsersParams) ([]entities.User, error)
+	CountUsersByStatus(ctx context.Context, status entities.UserStatus) (int64, error)
 	GetUserStats(ctx context.Context) (entities.UserStats, error)
+	// ApplyBulkUserOperation applies op to all of its users in one
+	// transaction, at the given time. Returns domain.ErrNotFound, changing
+	// none of them, when any is missing.
+	ApplyBulkUserOperation(ctx context.Context, op entities.BulkUserOperation, at time.Time) error
 }
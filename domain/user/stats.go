@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"sync"
+	"time"
+)
+
+// statsCache keeps the user statistics for a while, since the dashboard and
+// the metrics endpoint ask for them far more often than they change.
+type statsCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	stats     entities.UserStats
+	expiresAt time.Time
+	// generation changes on every invalidation, so a query that was running
+	// when users changed doesn't cache stats that missed the change
+	generation uint64
+}
+
+// SetStatsCacheTTL makes GetUserStats reuse the statistics for ttl. Users
+// created, updated or deleted through this UseCase clear them right away;
+// other changes, such as logins creating users, show up once they expire. A
+// ttl of zero queries the statistics every time.
+func (uc *UseCase) SetStatsCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		uc.stats = nil
+		return
+	}
+	uc.stats = &statsCache{ttl: ttl}
+}
+
+func (uc *UseCase) GetUserStats(ctx context.Context) (entities.UserStats, error) {
+	var generation uint64
+	if uc.stats != nil {
+		uc.stats.mu.Lock()
+		stats, fresh := uc.stats.stats, time.Now().Before(uc.stats.expiresAt)
+		generation = uc.stats.generation
+		uc.stats.mu.Unlock()
+		if fresh {
+			return stats, nil
+		}
+	}
+
+	stats, err := uc.repo.GetUserStats(ctx)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user stats", "error", err)
+		return entities.UserStats{}, err
+	}
+
+	if uc.stats != nil {
+		uc.stats.mu.Lock()
+		if uc.stats.generation == generation {
+			uc.stats.stats = stats
+			uc.stats.expiresAt = time.Now().Add(uc.stats.ttl)
+		}
+		uc.stats.mu.Unlock()
+	}
+	return stats, nil
+}
+
+// invalidateStats drops the cached statistics after users change.
+func (uc *UseCase) invalidateStats() {
+	if uc.stats == nil {
+		return
+	}
+	uc.stats.mu.Lock()
+	uc.stats.expiresAt = time.Time{}
+	uc.stats.generation++
+	uc.stats.mu.Unlock()
+}
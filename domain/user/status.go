@@ -0,0 +1,70 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Suspend keeps the user from signing in and from using the tokens they
+// already hold, until reactivated. The reason is shown to the user. Admins
+// can't suspend themselves.
+func (uc *UseCase) Suspend(ctx context.Context, userID uuid.UUID, reason string) (entities.User, error) {
+	if actor, ok := domain.ActorFromContext(ctx); ok && actor == userID {
+		return entities.User{}, fmt.Errorf("can't suspend your own account: %w", domain.ErrForbidden)
+	}
+	return uc.setStatus(ctx, userID, entities.UserStatusSuspended, reason)
+}
+
+// Reactivate lets a suspended or pending user back in.
+func (uc *UseCase) Reactivate(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	return uc.setStatus(ctx, userID, entities.UserStatusActive, "")
+}
+
+func (uc *UseCase) setStatus(ctx context.Context, userID uuid.UUID, status entities.UserStatus, reason string) (entities.User, error) {
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for status change", "user_id", userID, "error", err)
+		return entities.User{}, err
+	}
+
+	now := time.Now()
+	user.Status = status
+	user.SuspendedReason = ""
+	user.SuspendedAt = nil
+	if status == entities.UserStatusSuspended {
+		user.SuspendedReason = reason
+		user.SuspendedAt = &now
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user status not changed", "user_id", userID, "status", status)
+		return user, nil
+	}
+
+	if err := uc.repo.SetStatus(ctx, userID, status, reason, now); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to set user status", "user_id", userID, "status", status, "error", err)
+		return entities.User{}, err
+	}
+
+	if status == entities.UserStatusSuspended {
+		domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user suspended", "audit", true, "user_id", userID, "reason", reason)
+	} else {
+		domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user reactivated", "audit", true, "user_id", userID)
+	}
+	return user, nil
+}
+
+// CheckStatus returns the error keeping the user out, see
+// domain.CheckAccountStatus.
+func (uc *UseCase) CheckStatus(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return domain.CheckAccountStatus(user)
+}
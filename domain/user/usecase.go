@@ -3,32 +3,49 @@ package user
 import (
 	"context"
 	"fmt"
+	"go-template/domain"
 	"go-template/domain/auth"
 	"go-template/domain/entities"
+	"go-template/domain/events"
+	"go-template/internal/metrics"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
 
 type UseCase struct {
-	repo           Repository
-	authFactory    auth.AuthProviderFactory
+	repo            Repository
+	authFactory     auth.AuthProviderFactory
 	defaultProvider string
+	avatars         FileStorage
+	approval        *approval
+	registration    *registration
+	passwordPolicy  PasswordValidator
+	stats           *statsCache
+	events          events.Publisher
+	logger          *slog.Logger
 }
 
-func NewUseCase(repo Repository, authFactory auth.AuthProviderFactory, defaultProvider string) *UseCase {
+func NewUseCase(repo Repository, authFactory auth.AuthProviderFactory, defaultProvider string, logger *slog.Logger) *UseCase {
 	return &UseCase{
-		repo:           repo,
-		authFactory:    authFactory,
+		repo:            repo,
+		authFactory:     authFactory,
 		defaultProvider: defaultProvider,
+		logger:          logger,
 	}
 }
 
+// SetEvents publishes UserCreated and UserDeleted on events.
+func (uc *UseCase) SetEvents(events events.Publisher) {
+	uc.events = events
+}
+
 func (uc *UseCase) GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error) {
 	user, err := uc.repo.GetByID(ctx, userID)
 	if err != nil {
-		slog.Error("failed to get user by ID", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user by ID", "error", err)
 		return entities.User{}, err
 	}
 
@@ -40,7 +57,10 @@ func (uc *UseCase) GetMe(ctx context.Context, userID uuid.UUID) (entities.User,
 }
 
 // Admin use cases
-func (uc *UseCase) ListUsers(ctx context.Context, page, pageSize int) ([]entities.User, int64, error) {
+func (uc *UseCase) ListUsers(ctx context.Context, page, pageSize int, sort entities.UserSort) ([]entities.User, int64, error) {
+	if err := validateUserSort(sort); err != nil {
+		return nil, 0, err
+	}
 	if page < 1 {
 		page = 1
 	}
@@ -51,18 +71,13 @@ func (uc *UseCase) ListUsers(ctx context.Context, page, pageSize int) ([]entitie
 	offset := int32((page - 1) * pageSize)
 	limit := int32(pageSize)
 
-	users, err := uc.repo.ListUsers(ctx, entities.ListUsersParams{
+	users, total, err := uc.repo.ListUsersWithTotal(ctx, entities.ListUsersParams{
 		Limit:  limit,
 		Offset: offset,
+		Sort:   sort,
 	})
 	if err != nil {
-		slog.Error("failed to list users", "error", err)
-		return nil, 0, err
-	}
-
-	total, err := uc.repo.CountUsers(ctx)
-	if err != nil {
-		slog.Error("failed to count users", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to list users", "error", err)
 		return nil, 0, err
 	}
 
@@ -70,12 +85,19 @@ func (uc *UseCase) ListUsers(ctx context.Context, page, pageSize int) ([]entitie
 }
 
 func (uc *UseCase) UpdateUser(ctx context.Context, user entities.User) error {
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user not updated", "user_id", user.ID)
+		return nil
+	}
+
 	err := uc.repo.Update(ctx, user)
 	if err != nil {
-		slog.Error("failed to update user", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to update user", "error", err)
 		return err
 	}
+	uc.invalidateStats()
 
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "user updated", "audit", true, "user_id", user.ID, "account_type", user.AccountType)
 	return nil
 }
 
@@ -83,71 +105,109 @@ func (uc *UseCase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	// First get the user to obtain auth provider information
 	user, err := uc.repo.GetByID(ctx, userID)
 	if err != nil {
-		slog.Error("failed to get user for deletion", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to get user for deletion", "error", err)
 		return err
 	}
 
-	// Delete from external auth provider if we have provider info
-	if user.AuthProvider != "" && user.AuthProviderID != "" {
-		provider, err := uc.authFactory.CreateProvider(user.AuthProvider)
-		if err != nil {
-			slog.Error("failed to create auth provider for deletion", "provider", user.AuthProvider, "error", err)
-			// Continue with local deletion even if auth provider fails
-		} else {
-			if err := provider.DeleteUser(ctx, user.AuthProviderID); err != nil {
-				slog.Error("failed to delete user from auth provider", "provider", user.AuthProvider, "auth_provider_id", user.AuthProviderID, "error", err)
-				// Continue with local deletion even if auth provider deletion fails
-			} else {
-				slog.Info("successfully deleted user from auth provider", "provider", user.AuthProvider, "auth_provider_id", user.AuthProviderID)
-			}
-		}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: user not deleted", "user_id", userID, "email", user.Email)
+		return nil
 	}
 
+	// Continue with local deletion even if auth provider deletion fails
+	uc.deleteFromProvider(ctx, user)
+
 	// Delete from local database
-	err = uc.repo.Delete(ctx, userID)
+	err = events.Commit(ctx, uc.events, func(ctx context.Context) ([]events.Event, error) {
+		if err := uc.repo.Delete(ctx, userID); err != nil {
+			return nil, err
+		}
+		return []events.Event{events.UserDeleted{User: user}}, nil
+	})
 	if err != nil {
-		slog.Error("failed to delete user from local database", "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to delete user from local database", "error", err)
 		return err
 	}
-
-	slog.Info("user deleted successfully", "user_id", userID, "email", user.Email)
+	uc.invalidateStats()
+	uc.removeAvatar(ctx, user)
 	return nil
 }
 
-func (uc *UseCase) GetUserStats(ctx context.Context) (entities.UserStats, error) {
-	stats, err := uc.repo.GetUserStats(ctx)
+// deleteFromProvider deletes the user from their external auth provider, if
+// they have one. Failures are only logged.
+func (uc *UseCase) deleteFromProvider(ctx context.Context, user entities.User) {
+	if user.AuthProvider == "" || user.AuthProviderID == "" {
+		return
+	}
+	provider, err := uc.authFactory.CreateProvider(user.AuthProvider)
 	if err != nil {
-		slog.Error("failed to get user stats", "error", err)
-		return entities.UserStats{}, err
+		domain.LoggerOr(ctx, uc.logger).Error("failed to create auth provider for deletion", "provider", user.AuthProvider, "error", err)
+		return
 	}
-
-	return stats, nil
+	if err := provider.DeleteUser(ctx, user.AuthProviderID); err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to delete user from auth provider", "provider", user.AuthProvider, "auth_provider_id", user.AuthProviderID, "error", err)
+		return
+	}
+	domain.LoggerOr(ctx, uc.logger).Info("successfully deleted user from auth provider", "provider", user.AuthProvider, "auth_provider_id", user.AuthProviderID)
 }
 
 func (uc *UseCase) CreateUser(ctx context.Context, email, password, authProvider string, accountType entities.AccountType) (entities.User, error) {
-	// Use default provider if none specified
+	return uc.createUser(ctx, email, password, authProvider, accountType, entities.UserStatusActive, userCreated(false))
+}
+
+// userCreated returns the UserCreated event for users created by
+// createUser, Registered when they signed up with the registration form.
+func userCreated(registered bool) func(ctx context.Context, user *entities.User) events.Event {
+	return func(ctx context.Context, user *entities.User) events.Event {
+		return events.UserCreated{User: *user, Registered: registered}
+	}
+}
+
+// createUser registers the user with the auth provider and stores them.
+// created is called once they are stored, in the same transaction, and
+// returns the event saying so; it may change the user it is given.
+func (uc *UseCase) createUser(ctx context.Context, email, password, authProvider string, accountType entities.AccountType, status entities.UserStatus, created func(ctx context.Context, user *entities.User) events.Event) (entities.User, error) {
+	// Use default provider if none specified. The default can be changed in
+	// the settings at runtime.
+	if authProvider == "" {
+		defaultProvider, err := uc.authFactory.DefaultProvider(ctx)
+		if err != nil {
+			domain.LoggerOr(ctx, uc.logger).Error("failed to get default auth provider", "error", err)
+			return entities.User{}, err
+		}
+		authProvider = defaultProvider
+	}
 	if authProvider == "" {
 		authProvider = uc.defaultProvider
 	}
-	
+
 	// Use default account type if none specified (for API registration)
 	if accountType == "" {
 		accountType = entities.AccountTypeUser
 	}
 
-	slog.Info("starting user creation", "email", email, "auth_provider", authProvider, "account_type", accountType)
+	domain.LoggerOr(ctx, uc.logger).Info("starting user creation", "email", email, "auth_provider", authProvider, "account_type", accountType)
 
-	// Create auth provider instance
-	provider, err := uc.authFactory.CreateProvider(authProvider)
+	if err := uc.validatePassword(ctx, password); err != nil {
+		return entities.User{}, err
+	}
+
+	// Create auth provider instance. Providers disabled in the settings
+	// can't take new users.
+	provider, err := uc.authFactory.CreateAvailableProvider(ctx, authProvider)
 	if err != nil {
-		slog.Error("failed to create auth provider", "provider", authProvider, "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to create auth provider", "provider", authProvider, "error", err)
 		return entities.User{}, fmt.Errorf("unsupported auth provider %s: %w", authProvider, err)
 	}
 
+	if domain.IsDryRun(ctx) {
+		return uc.dryRunCreateUser(ctx, email, authProvider, accountType, status)
+	}
+
 	// Register with external auth provider
 	authProviderID, err := provider.RegisterUser(ctx, email, password)
 	if err != nil {
-		slog.Error("failed to register with auth provider", "provider", authProvider, "error", err)
+		domain.LoggerOr(ctx, uc.logger).Error("failed to register with auth provider", "provider", authProvider, "error", err)
 		return entities.User{}, fmt.Errorf("failed to register with %s: %w", authProvider, err)
 	}
 
@@ -159,22 +219,59 @@ func (uc *UseCase) CreateUser(ctx context.Context, email, password, authProvider
 		AuthProvider:   authProvider,
 		AuthProviderID: authProviderID,
 		AccountType:    accountType,
+		Status:         status,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 
 	// Store user in local database
-	if err := uc.repo.Create(ctx, user); err != nil {
-		slog.Error("failed to create user locally after external registration", "error", err, "auth_provider_id", authProviderID)
+	err = events.Commit(ctx, uc.events, func(ctx context.Context) ([]events.Event, error) {
+		if err := uc.repo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		return []events.Event{created(ctx, &user)}, nil
+	})
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to create user locally after external registration", "error", err, "auth_provider_id", authProviderID)
 		// TODO: Consider rollback from external provider if supported
 		return entities.User{}, fmt.Errorf("failed to create user locally: %w", err)
 	}
+	uc.invalidateStats()
 
-	slog.Info("user created successfully", "email", email, "account_type", accountType, "auth_provider", authProvider, "auth_provider_id", authProviderID)
+	metrics.RecordSignup(authProvider, accountType)
 	return user, nil
 }
 
-func (uc *UseCase) SearchUsers(ctx context.Context, page, pageSize int, search, accountType string) ([]entities.User, int64, error) {
+// dryRunCreateUser returns the user CreateUser would store, rejecting emails
+// that are already registered, without touching the auth provider or database.
+func (uc *UseCase) dryRunCreateUser(ctx context.Context, email, authProvider string, accountType entities.AccountType, status entities.UserStatus) (entities.User, error) {
+	if _, err := uc.repo.GetByEmail(ctx, email); err == nil {
+		return entities.User{}, fmt.Errorf("user '%s' already exists: %w", email, domain.ErrDuplicateKey)
+	}
+
+	now := time.Now()
+	user := entities.User{
+		ID:           uuid.Must(uuid.NewV4()),
+		Email:        email,
+		AuthProvider: authProvider,
+		AccountType:  accountType,
+		Status:       status,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	domain.LoggerOr(ctx, uc.logger).Info("dry run: user not created", "email", email, "account_type", accountType, "auth_provider", authProvider)
+	return user, nil
+}
+
+// SearchUsers lists the users matching filter, in sort order: those whose
+// email contains its Search, ignoring case, who have its AccountType when it
+// isn't empty, and who match its filter expression. The total counts every
+// match, not just the page.
+func (uc *UseCase) SearchUsers(ctx context.Context, page, pageSize int, filter entities.UserFilter, sort entities.UserSort) ([]entities.User, int64, error) {
+	if err := validateUserSort(sort); err != nil {
+		return nil, 0, err
+	}
 	if page < 1 {
 		page = 1
 	}
@@ -182,39 +279,31 @@ func (uc *UseCase) SearchUsers(ctx context.Context, page, pageSize int, search,
 		pageSize = 20
 	}
 
-	// For now, use the same ListUsers method
-	// In a real implementation, you would add search functionality to the repository
-	users, _, err := uc.ListUsers(ctx, page, pageSize)
+	filter.Search = strings.TrimSpace(filter.Search)
+	users, err := uc.repo.SearchUsers(ctx, filter, entities.ListUsersParams{
+		Limit:  int32(pageSize),
+		Offset: int32((page - 1) * pageSize),
+		Sort:   sort,
+	})
 	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to search users", "error", err)
 		return nil, 0, err
 	}
 
-	// Simple client-side filtering for demo purposes
-	// In production, this should be done at the database level
-	var filteredUsers []entities.User
-	for _, user := range users {
-		// Filter by search term (email)
-		if search != "" && !contains(user.Email, search) {
-			continue
-		}
-
-		// Filter by account type
-		if accountType != "" && string(user.AccountType) != accountType {
-			continue
-		}
-
-		filteredUsers = append(filteredUsers, user)
+	total, err := uc.repo.CountSearchUsers(ctx, filter)
+	if err != nil {
+		domain.LoggerOr(ctx, uc.logger).Error("failed to count users", "error", err)
+		return nil, 0, err
 	}
 
-	return filteredUsers, int64(len(filteredUsers)), nil
+	return users, total, nil
 }
 
-// Helper function for case-insensitive string contains
-func contains(s, substr string) bool {
-	// Simple case-insensitive contains check
-	// In production, you might want to use a proper text search
-	return len(s) >= len(substr) && (s == substr ||
-		// Simple implementation - could be improved
-		s[0:len(substr)] == substr ||
-		(len(s) > len(substr) && contains(s[1:], substr)))
+// validateUserSort only lets through the fields users can be sorted by, or
+// none for the default order.
+func validateUserSort(sort entities.UserSort) error {
+	if sort.Field != "" && !sort.Field.IsValid() {
+		return fmt.Errorf("%w: cannot sort users by %q", domain.ErrMalformedParameters, sort.Field)
+	}
+	return nil
 }
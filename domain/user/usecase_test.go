@@ -1,15 +1,29 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"go-template/domain"
 	"go-template/domain/auth"
+	mauth "go-template/domain/auth/mocks"
 	"go-template/domain/entities"
+	"go-template/domain/events"
 	muser "go-template/domain/user/mocks"
+	"go-template/internal/export"
+	"io"
+	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 )
 
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
 // Simple mock auth factory for testing
 type mockAuthFactory struct{}
 
@@ -21,13 +35,47 @@ func (m *mockAuthFactory) GetSupportedProviders() []string {
 	return []string{"supabase"}
 }
 
+func (m *mockAuthFactory) AvailableProviders(ctx context.Context) ([]string, error) {
+	return m.GetSupportedProviders(), nil
+}
+
+func (m *mockAuthFactory) DefaultProvider(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (m *mockAuthFactory) CreateAvailableProvider(ctx context.Context, providerName string) (auth.Provider, error) {
+	return m.CreateProvider(providerName)
+}
+
+func TestUseCase_Logger(t *testing.T) {
+	var own, request bytes.Buffer
+	uc := NewUseCase(&muser.RepositoryMock{}, &mockAuthFactory{}, "supabase", slog.New(slog.NewTextHandler(&own, nil)))
+	user := entities.User{ID: uuid.Must(uuid.NewV4())}
+
+	if err := uc.UpdateUser(context.Background(), user); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if !strings.Contains(own.String(), "user updated") {
+		t.Fatalf("expected the use case's logger to get the line, got %q", own.String())
+	}
+
+	own.Reset()
+	ctx := domain.WithLogger(context.Background(), slog.New(slog.NewTextHandler(&request, nil)).With("request_id", "req-1"))
+	if err := uc.UpdateUser(ctx, user); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if own.Len() != 0 || !strings.Contains(request.String(), "request_id=req-1") {
+		t.Fatalf("expected the request's logger to get the line, got %q and %q", own.String(), request.String())
+	}
+}
+
 func TestUseCase_GetUserByID(t *testing.T) {
 	u := entities.User{ID: uuid.Must(uuid.NewV4())}
 	repo := &muser.RepositoryMock{
 		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return u, nil },
 	}
 	authFactory := &mockAuthFactory{}
-	uc := NewUseCase(repo, authFactory, "supabase")
+	uc := NewUseCase(repo, authFactory, "supabase", discardLogger())
 
 	got, err := uc.GetUserByID(context.Background(), u.ID)
 	if err != nil {
@@ -37,3 +85,970 @@ func TestUseCase_GetUserByID(t *testing.T) {
 		t.Fatalf("expected id %s, got %s", u.ID, got.ID)
 	}
 }
+
+func TestUseCase_DryRun(t *testing.T) {
+	ctx := domain.WithDryRun(context.Background())
+	existing := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "taken@x.com", AuthProvider: "supabase", AuthProviderID: "ext-1"}
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return existing, nil },
+		GetByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email == existing.Email {
+				return existing, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+
+	user, err := uc.CreateUser(ctx, "new@x.com", "pwd", "", entities.AccountTypeAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Email != "new@x.com" || user.AccountType != entities.AccountTypeAdmin || user.AuthProvider != "supabase" {
+		t.Fatalf("unexpected would-be user: %+v", user)
+	}
+
+	if _, err := uc.CreateUser(ctx, existing.Email, "pwd", "", ""); !errors.Is(err, domain.ErrDuplicateKey) {
+		t.Fatalf("expected duplicate key error, got %v", err)
+	}
+
+	if err := uc.UpdateUser(ctx, existing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.DeleteUser(ctx, existing.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.CreateCalls()) != 0 || len(repo.UpdateCalls()) != 0 || len(repo.DeleteCalls()) != 0 {
+		t.Fatalf("dry run must not persist anything")
+	}
+}
+
+func TestUseCase_CreateUser_SettingsDefaultProvider(t *testing.T) {
+	var requested string
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			requested = providerName
+			return nil, auth.ErrProviderDisabled
+		},
+	}
+	uc := NewUseCase(&muser.RepositoryMock{}, factory, "supabase", discardLogger())
+
+	_, err := uc.CreateUser(context.Background(), "new@x.com", "pwd", "", entities.AccountTypeUser)
+	if !errors.Is(err, auth.ErrProviderDisabled) {
+		t.Fatalf("expected ErrProviderDisabled, got %v", err)
+	}
+	if requested != "local" {
+		t.Fatalf("expected the settings default provider, got %q", requested)
+	}
+}
+
+// passwordPolicyFunc adapts a function to PasswordValidator
+type passwordPolicyFunc func(ctx context.Context, password string) error
+
+func (f passwordPolicyFunc) Validate(ctx context.Context, password string) error {
+	return f(ctx, password)
+}
+
+func TestUseCase_CreateUser_PasswordPolicy(t *testing.T) {
+	errWeak := errors.New("password is too weak")
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "supabase", nil },
+	}
+	uc := NewUseCase(&muser.RepositoryMock{}, factory, "supabase", discardLogger())
+	uc.SetPasswordPolicy(passwordPolicyFunc(func(ctx context.Context, password string) error {
+		if password == "pwd" {
+			return errWeak
+		}
+		return nil
+	}))
+
+	_, err := uc.CreateUser(context.Background(), "new@x.com", "pwd", "", entities.AccountTypeUser)
+	if !errors.Is(err, errWeak) {
+		t.Fatalf("expected the policy error, got %v", err)
+	}
+	if len(factory.CreateAvailableProviderCalls()) != 0 {
+		t.Fatalf("expected no provider registration for a weak password")
+	}
+}
+
+func TestUseCase_ListUsers(t *testing.T) {
+	var gotParams entities.ListUsersParams
+	repo := &muser.RepositoryMock{
+		ListUsersWithTotalFunc: func(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error) {
+			gotParams = params
+			return []entities.User{{Email: "jane@example.com"}}, 42, nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+
+	users, total, err := uc.ListUsers(context.Background(), 2, 500, entities.UserSort{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 || total != 42 {
+		t.Fatalf("expected one user of 42, got %d of %d", len(users), total)
+	}
+	if gotParams.Limit != 20 || gotParams.Offset != 20 {
+		t.Fatalf("unexpected page: %+v", gotParams)
+	}
+	// The total comes with the page, not from a second query
+	if len(repo.CountUsersCalls()) != 0 || len(repo.ListUsersCalls()) != 0 {
+		t.Fatalf("expected a single query")
+	}
+}
+
+func TestUseCase_GetUserStats_Cache(t *testing.T) {
+	total := int64(1)
+	repo := &muser.RepositoryMock{
+		GetUserStatsFunc: func(ctx context.Context) (entities.UserStats, error) {
+			return entities.UserStats{TotalUsers: total}, nil
+		},
+		UpdateFunc: func(ctx context.Context, user entities.User) error {
+			total++
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	uc.SetStatsCacheTTL(time.Hour)
+	ctx := context.Background()
+
+	for range 3 {
+		stats, err := uc.GetUserStats(ctx)
+		if err != nil || stats.TotalUsers != 1 {
+			t.Fatalf("expected 1 user, got %d (%v)", stats.TotalUsers, err)
+		}
+	}
+	if n := len(repo.GetUserStatsCalls()); n != 1 {
+		t.Fatalf("expected the stats to be queried once, got %d", n)
+	}
+
+	// Changing a user counts them again
+	if err := uc.UpdateUser(ctx, entities.User{ID: uuid.Must(uuid.NewV4())}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats, err := uc.GetUserStats(ctx)
+	if err != nil || stats.TotalUsers != 2 {
+		t.Fatalf("expected 2 users, got %d (%v)", stats.TotalUsers, err)
+	}
+
+	// Without a TTL every call queries
+	uc.SetStatsCacheTTL(0)
+	_, _ = uc.GetUserStats(ctx)
+	_, _ = uc.GetUserStats(ctx)
+	if n := len(repo.GetUserStatsCalls()); n != 4 {
+		t.Fatalf("expected 4 queries, got %d", n)
+	}
+}
+
+func TestUseCase_SearchUsers(t *testing.T) {
+	var gotFilter entities.UserFilter
+	var gotParams entities.ListUsersParams
+	repo := &muser.RepositoryMock{
+		SearchUsersFunc: func(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error) {
+			gotFilter, gotParams = filter, params
+			return []entities.User{{Email: "jane@example.com"}}, nil
+		},
+		CountSearchUsersFunc: func(ctx context.Context, filter entities.UserFilter) (int64, error) {
+			return 42, nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+
+	sort := entities.UserSort{Field: entities.UserSortEmail}
+	users, total, err := uc.SearchUsers(context.Background(), 3, 10, entities.UserFilter{Search: "  jane ", AccountType: entities.AccountTypeAdmin}, sort)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 || total != 42 {
+		t.Fatalf("expected one user of 42 matches, got %d of %d", len(users), total)
+	}
+	if gotFilter.Search != "jane" || gotFilter.AccountType != entities.AccountTypeAdmin {
+		t.Fatalf("unexpected filter: %+v", gotFilter)
+	}
+	if gotParams.Limit != 10 || gotParams.Offset != 20 || gotParams.Sort != sort {
+		t.Fatalf("unexpected page: %+v", gotParams)
+	}
+
+	// Only allowlisted fields can be sorted by
+	if _, _, err := uc.SearchUsers(context.Background(), 1, 10, entities.UserFilter{}, entities.UserSort{Field: "password"}); !errors.Is(err, domain.ErrMalformedParameters) {
+		t.Fatalf("expected malformed parameters, got %v", err)
+	}
+	if _, _, err := uc.ListUsers(context.Background(), 1, 10, entities.UserSort{Field: "email; DROP TABLE users"}); !errors.Is(err, domain.ErrMalformedParameters) {
+		t.Fatalf("expected malformed parameters, got %v", err)
+	}
+}
+
+func TestUseCase_ListUsersAfter(t *testing.T) {
+	now := time.Now().UTC()
+	all := make([]entities.User, 5)
+	for i := range all {
+		all[i] = entities.User{ID: uuid.Must(uuid.NewV4()), CreatedAt: now.Add(-time.Duration(i) * time.Minute)}
+	}
+	var gotFilter entities.UserFilter
+	repo := &muser.RepositoryMock{
+		SearchUsersAfterFunc: func(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error) {
+			gotFilter = filter
+			start := 0
+			if after != nil {
+				for i, u := range all {
+					if u.ID == after.ID && u.CreatedAt.Equal(after.CreatedAt) {
+						start = i + 1
+					}
+				}
+			}
+			end := min(start+int(limit), len(all))
+			return all[start:end], nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	ctx := context.Background()
+
+	var seen []uuid.UUID
+	cursor := ""
+	for range 3 {
+		users, next, err := uc.ListUsersAfter(ctx, cursor, 2, entities.UserFilter{Search: " jane ", AccountType: entities.AccountTypeUser})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, u := range users {
+			seen = append(seen, u.ID)
+		}
+		cursor = next
+		if cursor == "" {
+			break
+		}
+	}
+	if cursor != "" {
+		t.Fatalf("expected no cursor after the last page, got %q", cursor)
+	}
+	if len(seen) != len(all) {
+		t.Fatalf("expected all %d users once, got %d", len(all), len(seen))
+	}
+	for i, u := range all {
+		if seen[i] != u.ID {
+			t.Fatalf("user %d out of order", i)
+		}
+	}
+	if gotFilter.Search != "jane" || gotFilter.AccountType != entities.AccountTypeUser {
+		t.Fatalf("unexpected filter: %+v", gotFilter)
+	}
+
+	for _, bad := range []string{"not base64!", "bm9waXBl", encodeUserCursor(entities.UserCursor{CreatedAt: now})[:10]} {
+		if _, _, err := uc.ListUsersAfter(ctx, bad, 2, entities.UserFilter{}); !errors.Is(err, domain.ErrMalformedParameters) {
+			t.Fatalf("expected malformed parameters for cursor %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestUseCase_ExportUsers(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	firstPage := make([]entities.User, exportPageSize)
+	for i := range firstPage {
+		firstPage[i] = entities.User{ID: uuid.Must(uuid.NewV4()), Email: "user@example.com", CreatedAt: created}
+	}
+	last := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "=cmd@example.com", AccountType: entities.AccountTypeAdmin, CreatedAt: created}
+
+	var afters []*entities.UserCursor
+	repo := &muser.RepositoryMock{
+		SearchUsersAfterFunc: func(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error) {
+			if filter.Search != "example" {
+				t.Fatalf("expected the trimmed search, got %q", filter.Search)
+			}
+			afters = append(afters, after)
+			if after == nil {
+				return firstPage, nil
+			}
+			return []entities.User{last}, nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+
+	var buf bytes.Buffer
+	w, err := export.NewWriter(&buf, export.CSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ExportUsers(context.Background(), entities.UserFilter{Search: " example "}, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The second page starts after the last user of the first, and being
+	// short, is the last one
+	if len(afters) != 2 || afters[1] == nil || afters[1].ID != firstPage[exportPageSize-1].ID {
+		t.Fatalf("unexpected pages: %v", afters)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != exportPageSize+2 {
+		t.Fatalf("expected a header and %d rows, got %d lines", exportPageSize+1, len(lines))
+	}
+	if lines[0] != "id,email,first_name,last_name,display_name,account_type,status,auth_provider,email_verified,phone,created_at,last_login_at" {
+		t.Fatalf("unexpected header: %s", lines[0])
+	}
+	want := last.ID.String() + ",'=cmd@example.com,,,,admin,,,false,,2024-01-02T03:04:05Z,"
+	if lines[len(lines)-1] != want {
+		t.Fatalf("expected %s, got %s", want, lines[len(lines)-1])
+	}
+}
+
+func TestUseCase_ImportUsers(t *testing.T) {
+	var created []entities.User
+	repo := &muser.RepositoryMock{
+		GetByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			if email == "taken@x.com" {
+				return entities.User{Email: email}, nil
+			}
+			return entities.User{}, domain.ErrNotFound
+		},
+		CreateFunc: func(ctx context.Context, user entities.User) error {
+			if user.Email == "race@x.com" {
+				return domain.ErrDuplicateKey
+			}
+			created = append(created, user)
+			return nil
+		},
+	}
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			return &mauth.ProviderMock{
+				RegisterUserFunc: func(ctx context.Context, email, password string) (string, error) { return "ext-" + email, nil },
+			}, nil
+		},
+	}
+	uc := NewUseCase(repo, factory, "supabase", discardLogger())
+
+	file := "\ufeffEmail,Password,Account_Type\n" +
+		"a@x.com,secret,\n" +
+		"b@x.com,secret,admin\n" +
+		"not-an-email,secret,user\n" +
+		"A@x.com,secret,user\n" +
+		"taken@x.com,secret,user\n" +
+		"c@x.com,,user\n" +
+		"d@x.com,secret,owner\n" +
+		"race@x.com,secret,user\n"
+	results, err := uc.ImportUsers(context.Background(), strings.NewReader(file), entities.AccountTypeAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"",
+		"regular admins can only create user accounts",
+		"invalid email",
+		"duplicate of row 2",
+		"user already exists",
+		"password is required",
+		`invalid account type "owner"`,
+		"user already exists",
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(results))
+	}
+	for i, result := range results {
+		if result.Row != i+2 || result.Error != want[i] || result.OK != (want[i] == "") {
+			t.Fatalf("row %d: expected %q, got %+v", i+2, want[i], result)
+		}
+	}
+	if len(created) != 1 || created[0].Email != "a@x.com" || created[0].AccountType != entities.AccountTypeUser || results[0].UserID == nil || *results[0].UserID != created[0].ID {
+		t.Fatalf("expected only a@x.com to be created, got %+v", created)
+	}
+
+	for name, file := range map[string]string{
+		"empty":          "",
+		"no rows":        "email,password\n",
+		"missing column": "email\na@x.com\n",
+		"unknown column": "email,password,role\na@x.com,secret,admin\n",
+		"bad csv":        "email,password\n\"a@x.com,secret\n",
+		"too many":       "email,password\n" + strings.Repeat("a@x.com,secret\n", entities.MaxImportUsers+1),
+	} {
+		if _, err := uc.ImportUsers(context.Background(), strings.NewReader(file), entities.AccountTypeSuperAdmin); !errors.Is(err, domain.ErrMalformedParameters) {
+			t.Fatalf("%s: expected ErrMalformedParameters, got %v", name, err)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected malformed files to create nobody")
+	}
+}
+
+func TestUseCase_Bulk(t *testing.T) {
+	actor := uuid.Must(uuid.NewV4())
+	users := map[uuid.UUID]entities.User{}
+	add := func(accountType entities.AccountType) uuid.UUID {
+		id := uuid.Must(uuid.NewV4())
+		users[id] = entities.User{ID: id, AccountType: accountType}
+		return id
+	}
+	regular, other, admin, superAdmin := add(entities.AccountTypeUser), add(entities.AccountTypeUser), add(entities.AccountTypeAdmin), add(entities.AccountTypeSuperAdmin)
+	users[actor] = entities.User{ID: actor, AccountType: entities.AccountTypeAdmin}
+	missing := uuid.Must(uuid.NewV4())
+
+	var applied []entities.BulkUserOperation
+	var applyErr error
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			u, ok := users[id]
+			if !ok {
+				return entities.User{}, domain.ErrNotFound
+			}
+			return u, nil
+		},
+		ApplyBulkUserOperationFunc: func(ctx context.Context, op entities.BulkUserOperation, at time.Time) error {
+			applied = append(applied, op)
+			return applyErr
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	ctx := domain.WithActor(context.Background(), actor)
+
+	// A regular admin only reaches user accounts, and never their own
+	op := entities.BulkUserOperation{Action: entities.BulkUserSuspend, UserIDs: []uuid.UUID{regular, admin, missing, actor, regular, other}, Reason: "spam"}
+	results, err := uc.Bulk(ctx, op, entities.AccountTypeAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantOK := []bool{true, false, false, false, true}
+	if len(results) != len(wantOK) {
+		t.Fatalf("expected %d results, got %+v", len(wantOK), results)
+	}
+	for i, ok := range wantOK {
+		if results[i].OK != ok || (results[i].Error == "") != ok {
+			t.Fatalf("result %d: expected ok=%v, got %+v", i, ok, results[i])
+		}
+	}
+	if len(applied) != 1 || len(applied[0].UserIDs) != 2 || applied[0].UserIDs[0] != regular || applied[0].UserIDs[1] != other || applied[0].Reason != "spam" {
+		t.Fatalf("expected one operation on the two users, got %+v", applied)
+	}
+
+	// Super admins can't be deleted, even by super admins
+	applied = nil
+	results, err = uc.Bulk(ctx, entities.BulkUserOperation{Action: entities.BulkUserDelete, UserIDs: []uuid.UUID{superAdmin, admin}}, entities.AccountTypeSuperAdmin)
+	if err != nil || results[0].OK || !results[1].OK {
+		t.Fatalf("unexpected results %+v (%v)", results, err)
+	}
+	if len(applied) != 1 || len(applied[0].UserIDs) != 1 || applied[0].UserIDs[0] != admin {
+		t.Fatalf("expected the admin to be deleted, got %+v", applied)
+	}
+
+	// Nothing is written on a dry run, or when nobody is left
+	applied = nil
+	if _, err := uc.Bulk(domain.WithDryRun(ctx), op, entities.AccountTypeAdmin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.Bulk(ctx, entities.BulkUserOperation{Action: entities.BulkUserDelete, UserIDs: []uuid.UUID{missing}}, entities.AccountTypeAdmin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("expected no writes, got %+v", applied)
+	}
+
+	// Users deleted meanwhile roll the whole operation back
+	applyErr = domain.ErrNotFound
+	if _, err := uc.Bulk(ctx, op, entities.AccountTypeAdmin); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		op   entities.BulkUserOperation
+		want error
+	}{
+		{name: "unknown action", op: entities.BulkUserOperation{Action: "promote", UserIDs: []uuid.UUID{regular}}, want: domain.ErrMalformedParameters},
+		{name: "no users", op: entities.BulkUserOperation{Action: entities.BulkUserDelete}, want: domain.ErrMalformedParameters},
+		{name: "too many users", op: entities.BulkUserOperation{Action: entities.BulkUserDelete, UserIDs: make([]uuid.UUID, entities.MaxBulkUsers+1)}, want: domain.ErrMalformedParameters},
+		{name: "invalid account type", op: entities.BulkUserOperation{Action: entities.BulkUserChangeAccountType, UserIDs: []uuid.UUID{regular}, AccountType: "root"}, want: domain.ErrMalformedParameters},
+		{name: "granting super admin", op: entities.BulkUserOperation{Action: entities.BulkUserChangeAccountType, UserIDs: []uuid.UUID{regular}, AccountType: entities.AccountTypeSuperAdmin}, want: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Bulk(ctx, tt.op, entities.AccountTypeAdmin); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUseCase_SuspendAndReactivate(t *testing.T) {
+	u := entities.User{ID: uuid.Must(uuid.NewV4()), Status: entities.UserStatusActive}
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return u, nil },
+		SetStatusFunc: func(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error {
+			u.Status = status
+			u.SuspendedReason = ""
+			if status == entities.UserStatusSuspended {
+				u.SuspendedReason = reason
+			}
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	ctx := context.Background()
+
+	got, err := uc.Suspend(ctx, u.ID, "spam")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != entities.UserStatusSuspended || got.SuspendedReason != "spam" || got.SuspendedAt == nil {
+		t.Fatalf("unexpected suspended user: %+v", got)
+	}
+
+	var suspended *domain.AccountSuspendedError
+	if err := uc.CheckStatus(ctx, u.ID); !errors.As(err, &suspended) || suspended.Reason != "spam" {
+		t.Fatalf("expected suspended error with reason, got %v", err)
+	}
+
+	got, err = uc.Reactivate(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != entities.UserStatusActive || got.SuspendedReason != "" || got.SuspendedAt != nil {
+		t.Fatalf("unexpected reactivated user: %+v", got)
+	}
+	if err := uc.CheckStatus(ctx, u.ID); err != nil {
+		t.Fatalf("expected active user, got %v", err)
+	}
+
+	if _, err := uc.Suspend(domain.WithActor(ctx, u.ID), u.ID, ""); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("expected forbidden for self suspension, got %v", err)
+	}
+	if len(repo.SetStatusCalls()) != 2 {
+		t.Fatalf("expected 2 status changes, got %d", len(repo.SetStatusCalls()))
+	}
+}
+
+type settingsFunc func(ctx context.Context) (*entities.SystemSettings, error)
+
+func (f settingsFunc) GetSettings(ctx context.Context) (*entities.SystemSettings, error) {
+	return f(ctx)
+}
+
+type fakeEmailSender struct{ sent []entities.Email }
+
+func (s *fakeEmailSender) SendEmail(ctx context.Context, email entities.Email) error {
+	s.sent = append(s.sent, email)
+	return nil
+}
+
+func TestUseCase_Register_PendingApproval(t *testing.T) {
+	var created entities.User
+	repo := &muser.RepositoryMock{
+		CreateFunc: func(ctx context.Context, user entities.User) error {
+			created = user
+			return nil
+		},
+	}
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			return &mauth.ProviderMock{
+				RegisterUserFunc: func(ctx context.Context, email, password string) (string, error) { return "ext-1", nil },
+			}, nil
+		},
+	}
+	uc := NewUseCase(repo, factory, "supabase", discardLogger())
+	required := false
+	uc.SetApproval(settingsFunc(func(ctx context.Context) (*entities.SystemSettings, error) {
+		return &entities.SystemSettings{RequireApproval: required}, nil
+	}), nil)
+	ctx := context.Background()
+
+	user, err := uc.Register(ctx, "open@x.com", "pwd", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Status != entities.UserStatusActive || created.Status != entities.UserStatusActive {
+		t.Fatalf("expected an active user, got %+v", created)
+	}
+
+	required = true
+	user, err = uc.Register(ctx, "queued@x.com", "pwd", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Status != entities.UserStatusPending || created.Status != entities.UserStatusPending || created.AccountType != entities.AccountTypeUser {
+		t.Fatalf("expected a pending user, got %+v", created)
+	}
+}
+
+type fakeInvitations struct {
+	invitation entities.Invitation
+	redeemed   []string
+	released   []uuid.UUID
+}
+
+func (f *fakeInvitations) Redeem(ctx context.Context, code, email string) (entities.Invitation, error) {
+	f.redeemed = append(f.redeemed, code)
+	if code != "GOODCODE" || (f.invitation.Email != "" && f.invitation.Email != email) {
+		return entities.Invitation{}, errors.New("invalid invitation code")
+	}
+	return f.invitation, nil
+}
+
+func (f *fakeInvitations) EmailInvitation(ctx context.Context, code string) (entities.Invitation, error) {
+	if code != "GOODCODE" || f.invitation.Email == "" {
+		return entities.Invitation{}, errors.New("invalid invitation code")
+	}
+	return f.invitation, nil
+}
+
+func (f *fakeInvitations) Release(ctx context.Context, id uuid.UUID) error {
+	f.released = append(f.released, id)
+	return nil
+}
+
+func TestUseCase_Register_Invitations(t *testing.T) {
+	var created entities.User
+	repo := &muser.RepositoryMock{
+		CreateFunc: func(ctx context.Context, user entities.User) error {
+			if user.Email == "taken@x.com" {
+				return domain.ErrDuplicateKey
+			}
+			created = user
+			return nil
+		},
+	}
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			return &mauth.ProviderMock{
+				RegisterUserFunc: func(ctx context.Context, email, password string) (string, error) { return "ext-1", nil },
+			}, nil
+		},
+	}
+	settings := &entities.SystemSettings{RegistrationEnabled: false, InvitationsEnabled: true, RequireApproval: true}
+	reader := settingsFunc(func(ctx context.Context) (*entities.SystemSettings, error) { return settings, nil })
+	invitations := &fakeInvitations{invitation: entities.Invitation{ID: uuid.Must(uuid.NewV4()), AccountType: entities.AccountTypeAdmin}}
+	uc := NewUseCase(repo, factory, "supabase", discardLogger())
+	uc.SetApproval(reader, nil)
+	uc.SetRegistration(reader, invitations)
+	ctx := context.Background()
+
+	if _, err := uc.Register(ctx, "a@x.com", "pwd", ""); !errors.Is(err, ErrInvitationRequired) {
+		t.Fatalf("expected ErrInvitationRequired, got %v", err)
+	}
+	if _, err := uc.Register(ctx, "a@x.com", "pwd", "BADCODE"); err == nil {
+		t.Fatal("expected an invalid code to fail")
+	}
+
+	// Invited users get the invitation's account type and skip approval
+	user, err := uc.Register(ctx, "a@x.com", "pwd", "GOODCODE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.AccountType != entities.AccountTypeAdmin || created.Status != entities.UserStatusActive {
+		t.Fatalf("expected an active admin, got %+v", created)
+	}
+
+	// A registration that fails gives the use back
+	if _, err := uc.Register(ctx, "taken@x.com", "pwd", "GOODCODE"); !errors.Is(err, domain.ErrDuplicateKey) {
+		t.Fatalf("expected ErrDuplicateKey, got %v", err)
+	}
+	if len(invitations.released) != 1 || invitations.released[0] != invitations.invitation.ID {
+		t.Fatalf("expected the invitation to be released, got %v", invitations.released)
+	}
+
+	settings.InvitationsEnabled = false
+	if _, err := uc.Register(ctx, "b@x.com", "pwd", "GOODCODE"); !errors.Is(err, ErrRegistrationDisabled) {
+		t.Fatalf("expected ErrRegistrationDisabled, got %v", err)
+	}
+
+	// Open registration ignores codes while invitations are off
+	settings.RegistrationEnabled = true
+	redeemed := len(invitations.redeemed)
+	user, err = uc.Register(ctx, "c@x.com", "pwd", "GOODCODE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.AccountType != entities.AccountTypeUser || user.Status != entities.UserStatusPending || len(invitations.redeemed) != redeemed {
+		t.Fatalf("expected a pending user without redeeming, got %+v", user)
+	}
+}
+
+func TestUseCase_AcceptInvitation(t *testing.T) {
+	var created entities.User
+	repo := &muser.RepositoryMock{
+		CreateFunc: func(ctx context.Context, user entities.User) error {
+			created = user
+			return nil
+		},
+	}
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			return &mauth.ProviderMock{
+				RegisterUserFunc: func(ctx context.Context, email, password string) (string, error) { return "ext-1", nil },
+			}, nil
+		},
+	}
+	// Emailed invitations work while registration and invitation codes are off
+	settings := &entities.SystemSettings{RequireApproval: true}
+	reader := settingsFunc(func(ctx context.Context) (*entities.SystemSettings, error) { return settings, nil })
+	invitations := &fakeInvitations{invitation: entities.Invitation{ID: uuid.Must(uuid.NewV4()), AccountType: entities.AccountTypeUser}}
+	uc := NewUseCase(repo, factory, "supabase", discardLogger())
+	ctx := context.Background()
+
+	if _, err := uc.AcceptInvitation(ctx, "GOODCODE", "pwd"); !errors.Is(err, ErrRegistrationDisabled) {
+		t.Fatalf("expected ErrRegistrationDisabled without invitations, got %v", err)
+	}
+
+	uc.SetApproval(reader, nil)
+	uc.SetRegistration(reader, invitations)
+	if _, err := uc.AcceptInvitation(ctx, "GOODCODE", "pwd"); err == nil {
+		t.Fatal("expected a code that wasn't emailed to fail")
+	}
+
+	invitations.invitation.Email = "invited@x.com"
+	user, err := uc.AcceptInvitation(ctx, "GOODCODE", "pwd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Email != "invited@x.com" || created.Status != entities.UserStatusActive || !user.EmailVerified {
+		t.Fatalf("expected an active, verified invited user, got %+v", user)
+	}
+	if calls := repo.SetEmailVerifiedCalls(); len(calls) != 1 || calls[0].Email != "invited@x.com" {
+		t.Fatalf("expected the email to be marked verified, got %v", calls)
+	}
+
+	// Emailed codes only work for the address they were sent to
+	settings.InvitationsEnabled = true
+	if _, err := uc.Register(ctx, "other@x.com", "pwd", "GOODCODE"); err == nil || errors.Is(err, ErrRegistrationDisabled) {
+		t.Fatalf("expected another address to be refused, got %v", err)
+	}
+}
+
+func TestUseCase_ApproveAndReject(t *testing.T) {
+	pending := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "p@x.com", Status: entities.UserStatusPending}
+	active := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "a@x.com", Status: entities.UserStatusActive}
+	users := map[uuid.UUID]entities.User{pending.ID: pending, active.ID: active}
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			u, ok := users[id]
+			if !ok {
+				return entities.User{}, domain.ErrNotFound
+			}
+			return u, nil
+		},
+		SetStatusFunc: func(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error {
+			u := users[id]
+			u.Status = status
+			users[id] = u
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+			delete(users, id)
+			return nil
+		},
+	}
+	email := &fakeEmailSender{}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	uc.SetApproval(settingsFunc(func(ctx context.Context) (*entities.SystemSettings, error) {
+		return &entities.SystemSettings{RequireApproval: true}, nil
+	}), email)
+	ctx := context.Background()
+
+	if _, err := uc.Approve(ctx, active.ID, true); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("expected ErrNotPending, got %v", err)
+	}
+	if err := uc.Reject(ctx, active.ID, "", true); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("expected ErrNotPending, got %v", err)
+	}
+
+	if _, err := uc.Approve(domain.WithDryRun(ctx), pending.ID, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if users[pending.ID].Status != entities.UserStatusPending || len(email.sent) != 0 {
+		t.Fatal("dry run should not approve the user")
+	}
+
+	got, err := uc.Approve(ctx, pending.ID, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != entities.UserStatusActive || users[pending.ID].Status != entities.UserStatusActive {
+		t.Fatalf("expected the user to be approved, got %+v", got)
+	}
+	if len(email.sent) != 0 {
+		t.Fatalf("expected no email without notify, got %v", email.sent)
+	}
+
+	other := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "o@x.com", Status: entities.UserStatusPending}
+	users[other.ID] = other
+	if err := uc.Reject(ctx, other.ID, "unknown company", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := users[other.ID]; ok {
+		t.Fatal("expected the rejected user to be deleted")
+	}
+	if len(email.sent) != 1 || email.sent[0].To != other.Email || email.sent[0].Data["Reason"] != "unknown company" {
+		t.Fatalf("expected a rejection email with the reason, got %v", email.sent)
+	}
+}
+
+func TestUseCase_UpdateProfile(t *testing.T) {
+	u := entities.User{ID: uuid.Must(uuid.NewV4()), UserProfile: entities.UserProfile{Metadata: map[string]any{"plan": "pro"}}}
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return u, nil },
+		SetProfileFunc: func(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error {
+			u.UserProfile = profile
+			return nil
+		},
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	ctx := context.Background()
+
+	got, err := uc.UpdateProfile(ctx, u.ID, entities.UserProfile{
+		FirstName: "  Ada ",
+		LastName:  "Lovelace",
+		Timezone:  "Europe/London",
+		Locale:    "en-gb",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FirstName != "Ada" || got.Locale != "en-GB" || got.Name() != "Ada Lovelace" {
+		t.Fatalf("unexpected profile: %+v", got.UserProfile)
+	}
+	if got.Metadata["plan"] != "pro" {
+		t.Fatalf("expected metadata to be kept, got %v", got.Metadata)
+	}
+
+	got, err = uc.UpdateProfile(ctx, u.ID, entities.UserProfile{Metadata: map[string]any{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Metadata) != 0 {
+		t.Fatalf("expected metadata to be cleared, got %v", got.Metadata)
+	}
+
+	invalid := []entities.UserProfile{
+		{Timezone: "Mars/Olympus"},
+		{Timezone: "Local"},
+		{Locale: "not a locale"},
+		{DisplayName: strings.Repeat("a", MaxNameLength+1)},
+		{Metadata: map[string]any{"blob": strings.Repeat("a", MaxMetadataSize)}},
+	}
+	for _, profile := range invalid {
+		if _, err := uc.UpdateProfile(ctx, u.ID, profile); !errors.Is(err, domain.ErrMalformedParameters) {
+			t.Fatalf("expected malformed parameters for %+v, got %v", profile, err)
+		}
+	}
+	if len(repo.SetProfileCalls()) != 2 {
+		t.Fatalf("expected 2 profile updates, got %d", len(repo.SetProfileCalls()))
+	}
+}
+
+func TestUseCase_UploadAvatar(t *testing.T) {
+	u := entities.User{ID: uuid.Must(uuid.NewV4())}
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) { return u, nil },
+		SetAvatarURLFunc: func(ctx context.Context, id uuid.UUID, url string) error {
+			u.AvatarURL = url
+			return nil
+		},
+	}
+	stored := map[string]string{}
+	storage := &muser.FileStorageMock{
+		PutFunc: func(ctx context.Context, key string, body io.Reader, contentType string) error {
+			stored[key] = contentType
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			delete(stored, key)
+			return nil
+		},
+		URLFunc: func(key string) string { return "https://files.example.com/" + key },
+	}
+	uc := NewUseCase(repo, &mockAuthFactory{}, "supabase", discardLogger())
+	ctx := context.Background()
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	if _, err := uc.UploadAvatar(ctx, u.ID, bytes.NewReader(png)); !errors.Is(err, ErrAvatarsDisabled) {
+		t.Fatalf("expected ErrAvatarsDisabled, got %v", err)
+	}
+	uc.SetAvatarStorage(storage)
+
+	if _, err := uc.UploadAvatar(ctx, u.ID, strings.NewReader("<svg></svg>")); !errors.Is(err, ErrAvatarType) {
+		t.Fatalf("expected ErrAvatarType, got %v", err)
+	}
+	if _, err := uc.UploadAvatar(ctx, u.ID, bytes.NewReader(make([]byte, MaxAvatarSize+1))); !errors.Is(err, ErrAvatarTooLarge) {
+		t.Fatalf("expected ErrAvatarTooLarge, got %v", err)
+	}
+
+	first, err := uc.UploadAvatar(ctx, u.ID, bytes.NewReader(png))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(first.AvatarURL, "https://files.example.com/avatars/"+u.ID.String()+"/") || !strings.HasSuffix(first.AvatarURL, ".png") {
+		t.Fatalf("unexpected avatar url %q", first.AvatarURL)
+	}
+
+	second, err := uc.UploadAvatar(ctx, u.ID, bytes.NewReader(png))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.AvatarURL == first.AvatarURL || len(stored) != 1 {
+		t.Fatalf("expected the new avatar to replace the old one, stored %v", stored)
+	}
+
+	got, err := uc.DeleteAvatar(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.AvatarURL != "" || u.AvatarURL != "" || len(stored) != 0 {
+		t.Fatalf("expected the avatar to be removed, stored %v", stored)
+	}
+}
+
+func TestUseCase_Events(t *testing.T) {
+	repo := &muser.RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (entities.User, error) {
+			return entities.User{ID: id, Email: "gone@x.com"}, nil
+		},
+		GetByEmailFunc: func(ctx context.Context, email string) (entities.User, error) {
+			return entities.User{}, domain.ErrNotFound
+		},
+	}
+	factory := &mauth.AuthProviderFactoryMock{
+		DefaultProviderFunc: func(ctx context.Context) (string, error) { return "local", nil },
+		CreateAvailableProviderFunc: func(ctx context.Context, providerName string) (auth.Provider, error) {
+			return &mauth.ProviderMock{
+				RegisterUserFunc: func(ctx context.Context, email, password string) (string, error) { return "ext-1", nil },
+			}, nil
+		},
+	}
+	uc := NewUseCase(repo, factory, "local", discardLogger())
+	bus := events.NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var created []events.UserCreated
+	var deleted []events.UserDeleted
+	events.Subscribe(bus, func(ctx context.Context, e events.UserCreated) error {
+		created = append(created, e)
+		return nil
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.UserDeleted) error {
+		deleted = append(deleted, e)
+		return nil
+	})
+	uc.SetEvents(bus)
+	ctx := context.Background()
+
+	if _, err := uc.Register(ctx, "self@x.com", "pwd", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateUser(ctx, "admin-made@x.com", "pwd", "", entities.AccountTypeUser); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uc.CreateUser(domain.WithDryRun(ctx), "dry@x.com", "pwd", "", entities.AccountTypeUser); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 2 || !created[0].Registered || created[0].User.Email != "self@x.com" || created[1].Registered {
+		t.Fatalf("expected only registered users to be marked so, and nothing for dry runs, got %+v", created)
+	}
+
+	if err := uc.DeleteUser(ctx, uuid.Must(uuid.NewV4())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].User.Email != "gone@x.com" {
+		t.Fatalf("expected the deleted user to be published, got %+v", deleted)
+	}
+}
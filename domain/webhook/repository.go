@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/repository.go . Repository Sender Enqueuer
+
+type Repository interface {
+	CreateWebhook(ctx context.Context, webhook entities.Webhook) error
+	// GetWebhook returns domain.ErrNotFound when there is no such webhook.
+	GetWebhook(ctx context.Context, id uuid.UUID) (entities.Webhook, error)
+	// ListWebhooks returns every webhook, newest first.
+	ListWebhooks(ctx context.Context) ([]entities.Webhook, error)
+	// ListWebhooksForEvent returns the webhooks subscribed to the event.
+	ListWebhooksForEvent(ctx context.Context, event string) ([]entities.Webhook, error)
+	// DeleteWebhook removes the webhook with its deliveries, or returns
+	// domain.ErrNotFound when there is no such webhook.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error
+	// GetWebhookDelivery returns domain.ErrNotFound when there is no such
+	// delivery.
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error)
+	// ListWebhookDeliveries returns a page of the webhook's deliveries with
+	// the status, or any status when it is empty, newest first.
+	ListWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus, limit, offset int32) ([]entities.WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus) (int64, error)
+}
+
+// Sender posts deliveries to webhook endpoints.
+type Sender interface {
+	// Send posts body with the headers to url. It fails only when the
+	// endpoint didn't answer; any status is a response.
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (entities.WebhookResponse, error)
+}
+
+// Enqueuer queues the jobs delivering the events.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, args job.Args) (entities.Job, error)
+}
@@ -0,0 +1,380 @@
+// Package webhook posts domain events to the endpoints super admins
+// register. Each event is delivered by a background job, retried following
+// the job queue's policy, and every attempt is kept with the endpoint's
+// answer so admins can see what went wrong and redeliver it.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// EventHeader names the event a delivery carries.
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader is the ID of the delivery attempt.
+	DeliveryHeader = "X-Webhook-Delivery"
+	// TimestampHeader is when the delivery was sent, in Unix seconds.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader is "sha256=" followed by the hex HMAC-SHA256 of
+	// TimestampHeader's value, a dot and the body, keyed with the webhook's
+	// secret. Receivers should refuse timestamps a few minutes away from
+	// their clock, so a captured delivery can't be replayed later.
+	SignatureHeader = "X-Webhook-Signature"
+
+	secretPrefix = "whsec_"
+)
+
+// DeliverJob posts an event to a webhook.
+type DeliverJob struct {
+	WebhookID uuid.UUID       `json:"webhook_id"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (DeliverJob) JobKind() string { return "webhook.deliver" }
+
+// CreateRequest describes a webhook to register: the http or https URL the
+// events are posted to, and which events.
+type CreateRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// payload is the body of a delivery, with the event as published in Data.
+type payload struct {
+	Event      string       `json:"event"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Data       events.Event `json:"data"`
+}
+
+type UseCase struct {
+	repo   Repository
+	sender Sender
+	queue  Enqueuer
+	// events are those webhooks can subscribe to, added by Subscribe
+	events []string
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewUseCase(repo Repository, sender Sender, queue Enqueuer, logger *slog.Logger) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		sender: sender,
+		queue:  queue,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Subscribe delivers the events of type E published on bus to the webhooks
+// subscribed to them. Webhooks can only subscribe to the events subscribed
+// this way, while setting up.
+func Subscribe[E events.Event](bus *events.Bus, uc *UseCase) {
+	var zero E
+	uc.events = append(uc.events, zero.EventName())
+	events.Subscribe(bus, func(ctx context.Context, event E) error {
+		return uc.Dispatch(ctx, event)
+	})
+}
+
+// Events returns the names of the events webhooks can subscribe to.
+func (uc *UseCase) Events() []string {
+	names := slices.Clone(uc.events)
+	slices.Sort(names)
+	return names
+}
+
+// Create registers a webhook, asked for by the actor in ctx. It is returned
+// with its secret, which isn't shown again.
+func (uc *UseCase) Create(ctx context.Context, req CreateRequest) (entities.Webhook, error) {
+	if err := uc.validate(req); err != nil {
+		return entities.Webhook{}, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	webhook := entities.Webhook{
+		ID:        uuid.Must(uuid.NewV4()),
+		URL:       req.URL,
+		Events:    slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		Secret:    secret,
+		CreatedAt: uc.now().UTC(),
+	}
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		webhook.CreatedBy = &actor
+	}
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: webhook not created")
+		return webhook, nil
+	}
+
+	if err := uc.repo.CreateWebhook(ctx, webhook); err != nil {
+		return entities.Webhook{}, fmt.Errorf("creating webhook: %w", err)
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "webhook created", "audit", true, "resource", entities.AuditResourceWebhook,
+		"resource_id", webhook.ID, "url", webhook.URL, "events", webhook.Events)
+	return webhook, nil
+}
+
+func (uc *UseCase) validate(req CreateRequest) error {
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("url must be an http or https URL: %w", domain.ErrMalformedParameters)
+	}
+	if len(req.Events) == 0 {
+		return fmt.Errorf("at least one event is required: %w", domain.ErrMalformedParameters)
+	}
+	for _, event := range req.Events {
+		if !slices.Contains(uc.events, event) {
+			return fmt.Errorf("unknown event %q: %w", event, domain.ErrMalformedParameters)
+		}
+	}
+	return nil
+}
+
+// List returns every webhook, newest first, without their secrets.
+func (uc *UseCase) List(ctx context.Context) ([]entities.Webhook, error) {
+	webhooks, err := uc.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []entities.Webhook{}
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+// Get returns the webhook without its secret, or domain.ErrNotFound.
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (entities.Webhook, error) {
+	webhook, err := uc.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Delete removes the webhook and its deliveries. Deliveries still queued
+// are dropped.
+func (uc *UseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if domain.IsDryRun(ctx) {
+		if _, err := uc.repo.GetWebhook(ctx, id); err != nil {
+			return err
+		}
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: webhook not deleted", "webhook_id", id)
+		return nil
+	}
+
+	if err := uc.repo.DeleteWebhook(ctx, id); err != nil {
+		return err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "webhook deleted", "audit", true, "resource", entities.AuditResourceWebhook, "resource_id", id)
+	return nil
+}
+
+// Dispatch queues a delivery of event to every webhook subscribed to it.
+func (uc *UseCase) Dispatch(ctx context.Context, event events.Event) error {
+	webhooks, err := uc.repo.ListWebhooksForEvent(ctx, event.EventName())
+	if err != nil {
+		return fmt.Errorf("listing webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload{Event: event.EventName(), OccurredAt: uc.now().UTC(), Data: event})
+	if err != nil {
+		return fmt.Errorf("encoding %s webhook payload: %w", event.EventName(), err)
+	}
+	var errs []error
+	for _, webhook := range webhooks {
+		if _, err := uc.queue.Enqueue(ctx, DeliverJob{WebhookID: webhook.ID, Event: event.EventName(), Payload: body}); err != nil {
+			errs = append(errs, fmt.Errorf("queueing delivery to webhook %s: %w", webhook.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Deliver posts the event to the webhook, as the job queued for it. A
+// failed attempt fails the job, so it is tried again; deliveries to
+// webhooks deleted since are dropped.
+func (uc *UseCase) Deliver(ctx context.Context, args DeliverJob) error {
+	webhook, err := uc.repo.GetWebhook(ctx, args.WebhookID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	delivery, err := uc.send(ctx, webhook, args.Event, args.Payload, nil)
+	if err != nil {
+		return err
+	}
+	if delivery.Status == entities.WebhookDeliveryFailed {
+		return fmt.Errorf("delivering %s to webhook %s: %s", args.Event, webhook.ID, delivery.Error)
+	}
+	return nil
+}
+
+// ListDeliveries returns a page of the webhook's delivery attempts, newest
+// first, with the status only when it is set.
+func (uc *UseCase) ListDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus, page, pageSize int) (entities.WebhookDeliveryListResponse, error) {
+	if status != "" && status != entities.WebhookDeliverySucceeded && status != entities.WebhookDeliveryFailed {
+		return entities.WebhookDeliveryListResponse{}, fmt.Errorf("%w: unknown delivery status %q", domain.ErrMalformedParameters, status)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	if _, err := uc.repo.GetWebhook(ctx, webhookID); err != nil {
+		return entities.WebhookDeliveryListResponse{}, err
+	}
+
+	deliveries, err := uc.repo.ListWebhookDeliveries(ctx, webhookID, status, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return entities.WebhookDeliveryListResponse{}, err
+	}
+	total, err := uc.repo.CountWebhookDeliveries(ctx, webhookID, status)
+	if err != nil {
+		return entities.WebhookDeliveryListResponse{}, err
+	}
+
+	if deliveries == nil {
+		deliveries = []entities.WebhookDelivery{}
+	}
+	return entities.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// Redeliver posts a delivery's event to its webhook again, right away, and
+// returns the new attempt. The attempt failing is not an error: it is
+// recorded, and returned with the failed status.
+func (uc *UseCase) Redeliver(ctx context.Context, webhookID, deliveryID uuid.UUID) (entities.WebhookDelivery, error) {
+	previous, err := uc.repo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+	if previous.WebhookID != webhookID {
+		return entities.WebhookDelivery{}, domain.ErrNotFound
+	}
+	webhook, err := uc.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+
+	if domain.IsDryRun(ctx) {
+		domain.LoggerOr(ctx, uc.logger).Info("dry run: delivery not redelivered", "webhook_id", webhookID, "delivery_id", deliveryID)
+		return entities.WebhookDelivery{
+			ID:           uuid.Must(uuid.NewV4()),
+			WebhookID:    webhookID,
+			Event:        previous.Event,
+			Payload:      previous.Payload,
+			RedeliveryOf: &previous.ID,
+			CreatedAt:    uc.now().UTC(),
+		}, nil
+	}
+
+	delivery, err := uc.send(ctx, webhook, previous.Event, previous.Payload, &previous.ID)
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+	domain.LoggerOr(ctx, uc.logger).InfoContext(ctx, "webhook delivery redelivered", "audit", true, "resource", entities.AuditResourceWebhook,
+		"resource_id", webhookID, "delivery_id", deliveryID, "status", delivery.Status)
+	return delivery, nil
+}
+
+// send posts the event's payload to the webhook, signed with its secret,
+// and records the attempt.
+func (uc *UseCase) send(ctx context.Context, webhook entities.Webhook, event string, body []byte, redeliveryOf *uuid.UUID) (entities.WebhookDelivery, error) {
+	delivery := entities.WebhookDelivery{
+		ID:           uuid.Must(uuid.NewV4()),
+		WebhookID:    webhook.ID,
+		Event:        event,
+		Payload:      body,
+		RedeliveryOf: redeliveryOf,
+		CreatedAt:    uc.now().UTC(),
+	}
+	start := uc.now()
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		EventHeader:     event,
+		DeliveryHeader:  delivery.ID.String(),
+		TimestampHeader: timestamp,
+		SignatureHeader: Sign(webhook.Secret, timestamp, body),
+	}
+
+	resp, err := uc.sender.Send(ctx, webhook.URL, headers, body)
+	delivery.LatencyMs = uc.now().Sub(start).Milliseconds()
+	delivery.StatusCode = resp.StatusCode
+	delivery.ResponseBody = resp.Body
+	switch {
+	case err != nil:
+		delivery.Status = entities.WebhookDeliveryFailed
+		delivery.Error = err.Error()
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		delivery.Status = entities.WebhookDeliveryFailed
+		delivery.Error = fmt.Sprintf("endpoint answered with status %d", resp.StatusCode)
+	default:
+		delivery.Status = entities.WebhookDeliverySucceeded
+	}
+
+	// The attempt is recorded even when the worker is stopping
+	if err := uc.repo.CreateWebhookDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		return entities.WebhookDelivery{}, fmt.Errorf("recording webhook delivery: %w", err)
+	}
+	if delivery.Status == entities.WebhookDeliveryFailed {
+		domain.LoggerOr(ctx, uc.logger).Warn("webhook delivery failed", "webhook_id", webhook.ID, "delivery_id", delivery.ID,
+			"event", event, "status_code", delivery.StatusCode, "error", delivery.Error)
+	}
+	return delivery, nil
+}
+
+// Sign returns the SignatureHeader value of body sent at timestamp, the
+// TimestampHeader value, for a webhook with the secret, so receivers can
+// check deliveries came from here.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"go-template/domain/webhook/mocks"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUseCase(repo *mocks.RepositoryMock, sender *mocks.SenderMock, queue *mocks.EnqueuerMock) *UseCase {
+	uc := NewUseCase(repo, sender, queue, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	bus := events.NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	Subscribe[events.UserCreated](bus, uc)
+	Subscribe[events.UserDeleted](bus, uc)
+	return uc
+}
+
+func TestUseCase_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CreateRequest
+		wantErr bool
+	}{
+		{name: "valid", req: CreateRequest{URL: "https://example.com/hook", Events: []string{"user.deleted", "user.created", "user.deleted"}}},
+		{name: "not a URL", req: CreateRequest{URL: "example.com/hook", Events: []string{"user.created"}}, wantErr: true},
+		{name: "not http", req: CreateRequest{URL: "ftp://example.com/hook", Events: []string{"user.created"}}, wantErr: true},
+		{name: "no events", req: CreateRequest{URL: "https://example.com/hook"}, wantErr: true},
+		{name: "unknown event", req: CreateRequest{URL: "https://example.com/hook", Events: []string{"settings.updated"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{}
+			uc := newTestUseCase(repo, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+			webhook, err := uc.Create(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+				assert.Empty(t, repo.CreateWebhookCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"user.created", "user.deleted"}, webhook.Events)
+			assert.Contains(t, webhook.Secret, secretPrefix, "the secret is shown once")
+			require.Len(t, repo.CreateWebhookCalls(), 1)
+			assert.Equal(t, webhook, repo.CreateWebhookCalls()[0].Webhook)
+		})
+	}
+
+	t.Run("dry run saves nothing", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{}
+		uc := newTestUseCase(repo, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+		_, err := uc.Create(domain.WithDryRun(context.Background()), CreateRequest{URL: "https://example.com/hook", Events: []string{"user.created"}})
+		require.NoError(t, err)
+		assert.Empty(t, repo.CreateWebhookCalls())
+	})
+}
+
+func TestUseCase_List(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListWebhooksFunc: func(ctx context.Context) ([]entities.Webhook, error) {
+			return []entities.Webhook{{ID: uuid.Must(uuid.NewV4()), Secret: "whsec_x"}}, nil
+		},
+	}
+	uc := newTestUseCase(repo, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+	webhooks, err := uc.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Empty(t, webhooks[0].Secret)
+}
+
+func TestUseCase_Dispatch(t *testing.T) {
+	first, second := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	repo := &mocks.RepositoryMock{
+		ListWebhooksForEventFunc: func(ctx context.Context, event string) ([]entities.Webhook, error) {
+			return []entities.Webhook{{ID: first}, {ID: second}}, nil
+		},
+	}
+	queue := &mocks.EnqueuerMock{}
+	uc := newTestUseCase(repo, &mocks.SenderMock{}, queue)
+
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "user@example.com"}
+	require.NoError(t, uc.Dispatch(context.Background(), events.UserCreated{User: user}))
+
+	assert.Equal(t, "user.created", repo.ListWebhooksForEventCalls()[0].Event)
+	require.Len(t, queue.EnqueueCalls(), 2)
+	args := queue.EnqueueCalls()[0].Args.(DeliverJob)
+	assert.Equal(t, first, args.WebhookID)
+	assert.Equal(t, "user.created", args.Event)
+
+	var body struct {
+		Event string             `json:"event"`
+		Data  events.UserCreated `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(args.Payload, &body))
+	assert.Equal(t, "user.created", body.Event)
+	assert.Equal(t, user.Email, body.Data.User.Email)
+	assert.Equal(t, second, queue.EnqueueCalls()[1].Args.(DeliverJob).WebhookID)
+}
+
+func TestUseCase_Deliver(t *testing.T) {
+	webhook := entities.Webhook{ID: uuid.Must(uuid.NewV4()), URL: "https://example.com/hook", Secret: "whsec_x"}
+	args := DeliverJob{WebhookID: webhook.ID, Event: "user.created", Payload: json.RawMessage(`{"event":"user.created"}`)}
+
+	tests := []struct {
+		name       string
+		resp       entities.WebhookResponse
+		sendErr    error
+		wantStatus entities.WebhookDeliveryStatus
+		wantErr    bool
+	}{
+		{name: "succeeded", resp: entities.WebhookResponse{StatusCode: 204}, wantStatus: entities.WebhookDeliverySucceeded},
+		{name: "error status is retried", resp: entities.WebhookResponse{StatusCode: 500, Body: "oops"}, wantStatus: entities.WebhookDeliveryFailed, wantErr: true},
+		{name: "unreachable endpoint is retried", sendErr: errors.New("connection refused"), wantStatus: entities.WebhookDeliveryFailed, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.RepositoryMock{
+				GetWebhookFunc: func(ctx context.Context, id uuid.UUID) (entities.Webhook, error) { return webhook, nil },
+			}
+			sender := &mocks.SenderMock{
+				SendFunc: func(ctx context.Context, url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+					return tt.resp, tt.sendErr
+				},
+			}
+			uc := newTestUseCase(repo, sender, &mocks.EnqueuerMock{})
+
+			err := uc.Deliver(context.Background(), args)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.Len(t, sender.SendCalls(), 1)
+			call := sender.SendCalls()[0]
+			assert.Equal(t, webhook.URL, call.URL)
+			assert.NotEmpty(t, call.Headers[TimestampHeader])
+			assert.Equal(t, Sign(webhook.Secret, call.Headers[TimestampHeader], args.Payload), call.Headers[SignatureHeader])
+			assert.Equal(t, "user.created", call.Headers[EventHeader])
+
+			require.Len(t, repo.CreateWebhookDeliveryCalls(), 1)
+			delivery := repo.CreateWebhookDeliveryCalls()[0].Delivery
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			assert.Equal(t, tt.resp.StatusCode, delivery.StatusCode)
+			assert.Equal(t, tt.resp.Body, delivery.ResponseBody)
+			assert.Equal(t, delivery.ID.String(), call.Headers[DeliveryHeader])
+		})
+	}
+
+	t.Run("deleted webhook is dropped", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			GetWebhookFunc: func(ctx context.Context, id uuid.UUID) (entities.Webhook, error) {
+				return entities.Webhook{}, domain.ErrNotFound
+			},
+		}
+		sender := &mocks.SenderMock{}
+		uc := newTestUseCase(repo, sender, &mocks.EnqueuerMock{})
+
+		require.NoError(t, uc.Deliver(context.Background(), args))
+		assert.Empty(t, sender.SendCalls())
+	})
+}
+
+func TestUseCase_ListDeliveries(t *testing.T) {
+	webhookID := uuid.Must(uuid.NewV4())
+
+	t.Run("paginates", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			CountWebhookDeliveriesFunc: func(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus) (int64, error) {
+				return 45, nil
+			},
+		}
+		uc := newTestUseCase(repo, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+		resp, err := uc.ListDeliveries(context.Background(), webhookID, entities.WebhookDeliveryFailed, 3, 20)
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.TotalPages)
+		assert.NotNil(t, resp.Deliveries)
+		call := repo.ListWebhookDeliveriesCalls()[0]
+		assert.Equal(t, entities.WebhookDeliveryFailed, call.Status)
+		assert.Equal(t, int32(20), call.Limit)
+		assert.Equal(t, int32(40), call.Offset)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		uc := newTestUseCase(&mocks.RepositoryMock{}, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+		_, err := uc.ListDeliveries(context.Background(), webhookID, "pending", 1, 20)
+		assert.ErrorIs(t, err, domain.ErrMalformedParameters)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		repo := &mocks.RepositoryMock{
+			GetWebhookFunc: func(ctx context.Context, id uuid.UUID) (entities.Webhook, error) {
+				return entities.Webhook{}, domain.ErrNotFound
+			},
+		}
+		uc := newTestUseCase(repo, &mocks.SenderMock{}, &mocks.EnqueuerMock{})
+
+		_, err := uc.ListDeliveries(context.Background(), webhookID, "", 1, 20)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestUseCase_Redeliver(t *testing.T) {
+	webhook := entities.Webhook{ID: uuid.Must(uuid.NewV4()), URL: "https://example.com/hook", Secret: "whsec_x"}
+	previous := entities.WebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: webhook.ID,
+		Event:     "user.deleted",
+		Payload:   json.RawMessage(`{"event":"user.deleted"}`),
+		Status:    entities.WebhookDeliveryFailed,
+	}
+	newRepo := func() *mocks.RepositoryMock {
+		return &mocks.RepositoryMock{
+			GetWebhookFunc: func(ctx context.Context, id uuid.UUID) (entities.Webhook, error) { return webhook, nil },
+			GetWebhookDeliveryFunc: func(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error) {
+				return previous, nil
+			},
+		}
+	}
+
+	t.Run("sends the payload again", func(t *testing.T) {
+		repo := newRepo()
+		sender := &mocks.SenderMock{
+			SendFunc: func(ctx context.Context, url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+				return entities.WebhookResponse{StatusCode: 200}, nil
+			},
+		}
+		uc := newTestUseCase(repo, sender, &mocks.EnqueuerMock{})
+
+		delivery, err := uc.Redeliver(context.Background(), webhook.ID, previous.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, &previous.ID, delivery.RedeliveryOf)
+		assert.NotEqual(t, previous.ID, delivery.ID)
+		require.Len(t, sender.SendCalls(), 1)
+		assert.JSONEq(t, string(previous.Payload), string(sender.SendCalls()[0].Body))
+		assert.Len(t, repo.CreateWebhookDeliveryCalls(), 1)
+	})
+
+	t.Run("a failed attempt is returned, not an error", func(t *testing.T) {
+		sender := &mocks.SenderMock{
+			SendFunc: func(ctx context.Context, url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+				return entities.WebhookResponse{StatusCode: 502}, nil
+			},
+		}
+		uc := newTestUseCase(newRepo(), sender, &mocks.EnqueuerMock{})
+
+		delivery, err := uc.Redeliver(context.Background(), webhook.ID, previous.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, 502, delivery.StatusCode)
+	})
+
+	t.Run("delivery of another webhook", func(t *testing.T) {
+		sender := &mocks.SenderMock{}
+		uc := newTestUseCase(newRepo(), sender, &mocks.EnqueuerMock{})
+
+		_, err := uc.Redeliver(context.Background(), uuid.Must(uuid.NewV4()), previous.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, sender.SendCalls())
+	})
+
+	t.Run("dry run sends nothing", func(t *testing.T) {
+		repo := newRepo()
+		sender := &mocks.SenderMock{}
+		uc := newTestUseCase(repo, sender, &mocks.EnqueuerMock{})
+
+		_, err := uc.Redeliver(domain.WithDryRun(context.Background()), webhook.ID, previous.ID)
+		require.NoError(t, err)
+		assert.Empty(t, sender.SendCalls())
+		assert.Empty(t, repo.CreateWebhookDeliveryCalls())
+	})
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", "1700000000", []byte("{}")))
+	assert.NotEqual(t, Sign("secret", "1700000000", []byte("{}")), Sign("other", "1700000000", []byte("{}")))
+	assert.NotEqual(t, Sign("secret", "1700000000", []byte("{}")), Sign("secret", "1700000001", []byte("{}")), "the timestamp is signed")
+}
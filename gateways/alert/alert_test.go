@@ -0,0 +1,106 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"go-template/domain/entities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAlert = entities.Alert{
+	Title:    "Break-glass access used",
+	Message:  "Emergency session started from 10.0.0.1",
+	Severity: entities.AlertSeverityCritical,
+}
+
+// capture serves status and decodes the posted JSON into body.
+func capture(t *testing.T, status int, body any) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWebhook_Alert(t *testing.T) {
+	var got map[string]string
+	srv := capture(t, http.StatusOK, &got)
+
+	require.NoError(t, NewWebhook(srv.URL).Alert(context.Background(), testAlert))
+	assert.Equal(t, "*Break-glass access used*\nEmergency session started from 10.0.0.1", got["text"])
+	assert.Equal(t, testAlert.Title, got["title"])
+	assert.Equal(t, testAlert.Message, got["message"])
+	assert.Equal(t, "critical", got["severity"])
+}
+
+func TestSlack_Alert(t *testing.T) {
+	var got struct {
+		Text        string `json:"text"`
+		Attachments []struct {
+			Color string `json:"color"`
+			Title string `json:"title"`
+			Text  string `json:"text"`
+		} `json:"attachments"`
+	}
+	srv := capture(t, http.StatusOK, &got)
+
+	require.NoError(t, NewSlack(srv.URL).Alert(context.Background(), testAlert))
+	assert.Equal(t, testAlert.Title, got.Text)
+	require.Len(t, got.Attachments, 1)
+	assert.Equal(t, "danger", got.Attachments[0].Color)
+	assert.Equal(t, testAlert.Message, got.Attachments[0].Text)
+}
+
+func TestPagerDuty_Alert(t *testing.T) {
+	var got struct {
+		RoutingKey  string `json:"routing_key"`
+		EventAction string `json:"event_action"`
+		Payload     struct {
+			Summary       string            `json:"summary"`
+			Source        string            `json:"source"`
+			Severity      string            `json:"severity"`
+			CustomDetails map[string]string `json:"custom_details"`
+		} `json:"payload"`
+	}
+	srv := capture(t, http.StatusAccepted, &got)
+
+	pd := NewPagerDuty("routing-key")
+	pd.url = srv.URL
+	alert := testAlert
+	alert.Severity = entities.AlertSeverityWarning
+
+	require.NoError(t, pd.Alert(context.Background(), alert))
+	assert.Equal(t, "routing-key", got.RoutingKey)
+	assert.Equal(t, "trigger", got.EventAction)
+	assert.Equal(t, testAlert.Title, got.Payload.Summary)
+	assert.Equal(t, "go-template", got.Payload.Source)
+	assert.Equal(t, "warning", got.Payload.Severity)
+	assert.Equal(t, testAlert.Message, got.Payload.CustomDetails["message"])
+}
+
+func TestAlert_Errors(t *testing.T) {
+	t.Run("non-2xx status", func(t *testing.T) {
+		var got map[string]any
+		srv := capture(t, http.StatusBadRequest, &got)
+
+		err := NewSlack(srv.URL).Alert(context.Background(), testAlert)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "400")
+	})
+
+	t.Run("the error leaves out the URL", func(t *testing.T) {
+		url := "http://127.0.0.1:1/services/T000/B000/secret"
+
+		err := NewSlack(url).Alert(context.Background(), testAlert)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret")
+	})
+}
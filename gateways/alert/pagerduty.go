@@ -0,0 +1,54 @@
+package alert
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"net/http"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty triggers an incident on the PagerDuty service whose integration
+// key is routingKey, through the Events API v2.
+type PagerDuty struct {
+	routingKey string
+	url        string
+	client     *http.Client
+}
+
+func NewPagerDuty(routingKey string) *PagerDuty {
+	return &PagerDuty{
+		routingKey: routingKey,
+		url:        PagerDutyEventsURL,
+		client:     newClient(),
+	}
+}
+
+func (p *PagerDuty) Alert(ctx context.Context, alert entities.Alert) error {
+	return post(ctx, p.client, p.url, map[string]any{
+		"routing_key":  p.routingKey,
+		"event_action": "trigger",
+		"payload": map[string]any{
+			"summary":  alert.Title,
+			"source":   "go-template",
+			"severity": pagerDutySeverity(alert.Severity),
+			"custom_details": map[string]string{
+				"message": alert.Message,
+			},
+		},
+	})
+}
+
+// pagerDutySeverity maps alert severities to the ones the Events API takes:
+// critical, error, warning and info.
+func pagerDutySeverity(s entities.AlertSeverity) string {
+	switch s {
+	case entities.AlertSeverityCritical:
+		return "critical"
+	case entities.AlertSeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
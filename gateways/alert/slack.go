@@ -0,0 +1,41 @@
+package alert
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"net/http"
+)
+
+// slackColors color the attachment's side bar by severity.
+var slackColors = map[entities.AlertSeverity]string{
+	entities.AlertSeverityInfo:     "#439FE0",
+	entities.AlertSeverityWarning:  "warning",
+	entities.AlertSeverityCritical: "danger",
+}
+
+// Slack posts alerts to a Slack incoming webhook, as an attachment colored
+// by severity.
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{
+		webhookURL: webhookURL,
+		client:     newClient(),
+	}
+}
+
+func (s *Slack) Alert(ctx context.Context, alert entities.Alert) error {
+	return post(ctx, s.client, s.webhookURL, map[string]any{
+		// text is what notifications show
+		"text": alert.Title,
+		"attachments": []map[string]any{{
+			"color":  slackColors[alert.Severity],
+			"title":  alert.Title,
+			"text":   alert.Message,
+			"footer": "severity: " + string(alert.Severity),
+		}},
+	})
+}
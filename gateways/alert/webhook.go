@@ -0,0 +1,72 @@
+// Package alert delivers operator alerts to external channels.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"net/http"
+	neturl "net/url"
+	"time"
+)
+
+// Webhook posts alerts as JSON to an incoming webhook URL. The payload has a
+// "text" field, which Slack, Mattermost and most chat tools accept, along
+// with the alert's own fields for anything parsing it.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: newClient(),
+	}
+}
+
+func (w *Webhook) Alert(ctx context.Context, alert entities.Alert) error {
+	return post(ctx, w.client, w.url, map[string]string{
+		"text":     fmt.Sprintf("*%s*\n%s", alert.Title, alert.Message),
+		"title":    alert.Title,
+		"message":  alert.Message,
+		"severity": string(alert.Severity),
+	})
+}
+
+func newClient() *http.Client {
+	return &http.Client{Timeout: 5 * time.Second}
+}
+
+// post sends payload as JSON to url and fails on any non-2xx status.
+func post(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Webhook URLs are secrets, so the error leaves out the URL
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package auth0
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+// ProviderName is the name the Auth0 provider is selected by.
+const ProviderName = "auth0"
+
+// DefaultConnection is the database connection Auth0 tenants start with.
+const DefaultConnection = "Username-Password-Authentication"
+
+// listUsersPageSize is the largest page the management API returns
+const listUsersPageSize = 100
+
+// Config identifies the Auth0 tenant and application. The application needs
+// the Password grant for login and the Client Credentials grant, authorized
+// for the Management API with the read:users and delete:users scopes, for
+// DeleteUser and ListUsers. Audience is the API identifier access tokens are
+// issued for, if any.
+type Config struct {
+	Domain       string
+	ClientID     string
+	ClientSecret string
+	Connection   string
+	Audience     string
+}
+
+// Provider authenticates users against an Auth0 database connection through
+// the Authentication API and manages them through the Management API.
+type Provider struct {
+	config     Config
+	baseURL    string
+	issuer     string
+	keys       *jwt.RemoteKeySet
+	httpClient *http.Client
+
+	mu              sync.Mutex
+	mgmtToken       string
+	mgmtTokenExpiry time.Time
+}
+
+func NewProvider(cfg Config) *Provider {
+	if cfg.Connection == "" {
+		cfg.Connection = DefaultConnection
+	}
+	baseURL := "https://" + strings.TrimSuffix(strings.TrimPrefix(cfg.Domain, "https://"), "/")
+	return &Provider{
+		config:     cfg,
+		baseURL:    baseURL,
+		issuer:     baseURL + "/",
+		keys:       jwt.NewRemoteKeySet(baseURL + "/.well-known/jwks.json"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+func (p *Provider) RegisterUser(ctx context.Context, email, password string) (string, error) {
+	var resp struct {
+		ID string `json:"_id"`
+	}
+	err := p.postJSON(ctx, "/dbconnections/signup", map[string]string{
+		"client_id":  p.config.ClientID,
+		"email":      email,
+		"password":   password,
+		"connection": p.config.Connection,
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to register user: %w", err)
+	}
+
+	if resp.ID == "" {
+		return "", fmt.Errorf("no user ID received from Auth0")
+	}
+
+	// Management API and token subjects prefix database users with "auth0|"
+	return "auth0|" + resp.ID, nil
+}
+
+// Login authenticates with the password realm grant against the configured
+// connection and returns the user's ID from the ID token.
+func (p *Provider) Login(ctx context.Context, email, password string) (string, error) {
+	var resp struct {
+		IDToken string `json:"id_token"`
+	}
+	err := p.postJSON(ctx, "/oauth/token", map[string]string{
+		"grant_type":    "http://auth0.com/oauth/grant-type/password-realm",
+		"realm":         p.config.Connection,
+		"username":      email,
+		"password":      password,
+		"client_id":     p.config.ClientID,
+		"client_secret": p.config.ClientSecret,
+		"scope":         "openid email",
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with Auth0: %w", err)
+	}
+
+	if resp.IDToken == "" {
+		return "", fmt.Errorf("no ID token received from Auth0")
+	}
+
+	user, err := p.ValidateToken(ctx, resp.IDToken)
+	if err != nil {
+		return "", err
+	}
+	return user.AuthProviderID, nil
+}
+
+type claims struct {
+	Email string `json:"email"`
+	jwtlib.RegisteredClaims
+}
+
+// ValidateToken verifies an RS256 ID token issued to the application, or an
+// access token issued for the configured audience. Access tokens carry no
+// email.
+func (p *Provider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	var c claims
+	if err := p.keys.Parse(ctx, token, &c, jwtlib.WithIssuer(p.issuer), jwtlib.WithExpirationRequired()); err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	if !slices.Contains(c.Audience, p.config.ClientID) && (p.config.Audience == "" || !slices.Contains(c.Audience, p.config.Audience)) {
+		return nil, errors.New("invalid token: issued for another audience")
+	}
+	if c.Subject == "" {
+		return nil, errors.New("invalid token: no subject")
+	}
+
+	return &entities.User{
+		Email:          c.Email,
+		AuthProvider:   ProviderName,
+		AuthProviderID: c.Subject,
+	}, nil
+}
+
+func (p *Provider) DeleteUser(ctx context.Context, authProviderID string) error {
+	req, err := p.managementRequest(ctx, http.MethodDelete, "/api/v2/users/"+url.PathEscape(authProviderID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete user from Auth0: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to delete user from Auth0: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ListUsers pages through the users of the configured connection. The
+// management API stops paging at 1000 users; larger tenants need a user
+// export job instead.
+func (p *Provider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	var users []entities.ProviderUser
+	for page := 0; ; page++ {
+		batch, err := p.listUsersPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range batch {
+			users = append(users, entities.ProviderUser{
+				ID:        u.UserID,
+				Email:     u.Email,
+				UpdatedAt: u.UpdatedAt,
+			})
+		}
+
+		if len(batch) < listUsersPageSize {
+			return users, nil
+		}
+	}
+}
+
+type managementUser struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (p *Provider) listUsersPage(ctx context.Context, page int) ([]managementUser, error) {
+	params := url.Values{}
+	params.Set("page", fmt.Sprint(page))
+	params.Set("per_page", fmt.Sprint(listUsersPageSize))
+	params.Set("q", fmt.Sprintf("identities.connection:%q", p.config.Connection))
+	params.Set("search_engine", "v3")
+
+	req, err := p.managementRequest(ctx, http.MethodGet, "/api/v2/users?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users from Auth0: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list users from Auth0: status %d", resp.StatusCode)
+	}
+
+	var users []managementUser
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("decoding Auth0 users: %w", err)
+	}
+	return users, nil
+}
+
+func (p *Provider) managementRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	token, err := p.managementToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating management request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req, nil
+}
+
+// managementToken returns a Management API token from the client credentials
+// grant, reused until shortly before it expires.
+func (p *Provider) managementToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.mgmtToken != "" && time.Now().Before(p.mgmtTokenExpiry) {
+		return p.mgmtToken, nil
+	}
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	err := p.postJSON(ctx, "/oauth/token", map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     p.config.ClientID,
+		"client_secret": p.config.ClientSecret,
+		"audience":      p.baseURL + "/api/v2/",
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Auth0 management token: %w", err)
+	}
+
+	p.mgmtToken = resp.AccessToken
+	p.mgmtTokenExpiry = time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second - time.Minute)
+	return p.mgmtToken, nil
+}
+
+func (p *Provider) postJSON(ctx context.Context, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshaling request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		// Auth0 errors use either error_description or description
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+			Description      string `json:"description"`
+		}
+		_ = json.Unmarshal(respBody, &errResp)
+		msg := errResp.ErrorDescription
+		if msg == "" {
+			msg = errResp.Description
+		}
+		if msg == "" {
+			msg = errResp.Error
+		}
+		return fmt.Errorf("status %d: %s", resp.StatusCode, msg)
+	}
+
+	return json.Unmarshal(respBody, result)
+}
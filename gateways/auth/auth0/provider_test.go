@@ -0,0 +1,161 @@
+package auth0
+
+import (
+	"context"
+	"encoding/json"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTenant struct {
+	provider *Provider
+	key      *jwt.RSAKey
+	issuer   string
+
+	tokenRequests []map[string]string
+	deleted       []string
+}
+
+// newTestTenant fakes the Auth0 endpoints the provider calls.
+func newTestTenant(t *testing.T) *testTenant {
+	t.Helper()
+
+	key, err := jwt.GenerateRSAKey()
+	require.NoError(t, err)
+	tenant := &testTenant{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(key.JWKS())
+	})
+	mux.HandleFunc("POST /dbconnections/signup", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, DefaultConnection, body["connection"])
+		json.NewEncoder(w).Encode(map[string]string{"_id": "abc123", "email": body["email"]})
+	})
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		tenant.tokenRequests = append(tenant.tokenRequests, body)
+
+		switch body["grant_type"] {
+		case "client_credentials":
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "mgmt-token", "expires_in": 86400})
+		default:
+			if body["password"] != "correct" {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "Wrong email or password."})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id_token": tenant.sign(t, tenant.idClaims())})
+		}
+	})
+	mux.HandleFunc("DELETE /api/v2/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer mgmt-token", r.Header.Get("Authorization"))
+		tenant.deleted = append(tenant.deleted, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /api/v2/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer mgmt-token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"user_id": "auth0|abc123", "email": "a@example.com", "updated_at": time.Now()},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	p := NewProvider(Config{Domain: "tenant.auth0.com", ClientID: "client", ClientSecret: "secret"})
+	p.baseURL = srv.URL
+	p.issuer = srv.URL + "/"
+	p.keys = jwt.NewRemoteKeySet(srv.URL + "/.well-known/jwks.json")
+
+	tenant.provider = p
+	tenant.issuer = p.issuer
+	return tenant
+}
+
+func (tt *testTenant) idClaims() jwtlib.MapClaims {
+	return jwtlib.MapClaims{
+		"iss":   tt.issuer,
+		"sub":   "auth0|abc123",
+		"aud":   "client",
+		"email": "a@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (tt *testTenant) sign(t *testing.T, claims jwtlib.MapClaims) string {
+	token, err := tt.key.Sign(claims)
+	require.NoError(t, err)
+	return token
+}
+
+func TestProvider_RegisterUser(t *testing.T) {
+	tenant := newTestTenant(t)
+
+	id, err := tenant.provider.RegisterUser(context.Background(), "a@example.com", "correct")
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|abc123", id)
+}
+
+func TestProvider_Login(t *testing.T) {
+	tenant := newTestTenant(t)
+
+	id, err := tenant.provider.Login(context.Background(), "a@example.com", "correct")
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|abc123", id)
+	assert.Equal(t, DefaultConnection, tenant.tokenRequests[0]["realm"])
+
+	_, err = tenant.provider.Login(context.Background(), "a@example.com", "wrong")
+	assert.ErrorContains(t, err, "Wrong email or password.")
+}
+
+func TestProvider_ValidateToken(t *testing.T) {
+	tenant := newTestTenant(t)
+	p := tenant.provider
+
+	user, err := p.ValidateToken(context.Background(), tenant.sign(t, tenant.idClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|abc123", user.AuthProviderID)
+	assert.Equal(t, "a@example.com", user.Email)
+
+	otherAudience := tenant.idClaims()
+	otherAudience["aud"] = "other-client"
+	_, err = p.ValidateToken(context.Background(), tenant.sign(t, otherAudience))
+	assert.Error(t, err, "token for another audience")
+
+	p.config.Audience = "https://api.example.com"
+	apiToken := tenant.idClaims()
+	apiToken["aud"] = []string{"https://api.example.com", tenant.issuer + "userinfo"}
+	_, err = p.ValidateToken(context.Background(), tenant.sign(t, apiToken))
+	assert.NoError(t, err, "access token for the configured audience")
+
+	otherIssuer := tenant.idClaims()
+	otherIssuer["iss"] = "https://evil.auth0.com/"
+	_, err = p.ValidateToken(context.Background(), tenant.sign(t, otherIssuer))
+	assert.Error(t, err, "token from another tenant")
+}
+
+func TestProvider_ManagementAPI(t *testing.T) {
+	tenant := newTestTenant(t)
+	p := tenant.provider
+
+	require.NoError(t, p.DeleteUser(context.Background(), "auth0|abc123"))
+	assert.Equal(t, []string{"auth0|abc123"}, tenant.deleted)
+
+	users, err := p.ListUsers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "auth0|abc123", users[0].ID)
+
+	// The management token is fetched once and reused
+	assert.Len(t, tenant.tokenRequests, 1)
+}
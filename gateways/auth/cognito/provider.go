@@ -0,0 +1,247 @@
+package cognito
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+// ProviderName is the name the Cognito provider is selected by.
+const ProviderName = "cognito"
+
+// listUsersPageSize is the largest page Cognito's ListUsers returns
+const listUsersPageSize = 60
+
+// Config identifies the user pool and app client. ClientSecret is only set
+// for app clients that have a secret.
+type Config struct {
+	Region       string
+	UserPoolID   string
+	ClientID     string
+	ClientSecret string
+}
+
+// api is the part of the Cognito client the provider uses.
+type api interface {
+	SignUp(ctx context.Context, params *cip.SignUpInput, optFns ...func(*cip.Options)) (*cip.SignUpOutput, error)
+	InitiateAuth(ctx context.Context, params *cip.InitiateAuthInput, optFns ...func(*cip.Options)) (*cip.InitiateAuthOutput, error)
+	AdminDeleteUser(ctx context.Context, params *cip.AdminDeleteUserInput, optFns ...func(*cip.Options)) (*cip.AdminDeleteUserOutput, error)
+	ListUsers(ctx context.Context, params *cip.ListUsersInput, optFns ...func(*cip.Options)) (*cip.ListUsersOutput, error)
+}
+
+// Provider authenticates users against an AWS Cognito user pool. Sign up and
+// login use the app client; DeleteUser and ListUsers call the admin API and
+// need AWS credentials from the default chain (environment, shared config or
+// instance role) allowed to manage the pool.
+type Provider struct {
+	client api
+	config Config
+	issuer string
+	keys   *jwt.RemoteKeySet
+}
+
+// NewProvider creates the Cognito provider.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	issuer := fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", cfg.Region, cfg.UserPoolID)
+	return newProvider(cip.NewFromConfig(awsCfg), cfg, issuer, jwt.NewRemoteKeySet(issuer+"/.well-known/jwks.json")), nil
+}
+
+func newProvider(client api, cfg Config, issuer string, keys *jwt.RemoteKeySet) *Provider {
+	return &Provider{
+		client: client,
+		config: cfg,
+		issuer: issuer,
+		keys:   keys,
+	}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+func (p *Provider) RegisterUser(ctx context.Context, email, password string) (string, error) {
+	resp, err := p.client.SignUp(ctx, &cip.SignUpInput{
+		ClientId:   aws.String(p.config.ClientID),
+		Username:   aws.String(email),
+		Password:   aws.String(password),
+		SecretHash: p.secretHash(email),
+		UserAttributes: []types.AttributeType{
+			{Name: aws.String("email"), Value: aws.String(email)},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to register user: %w", err)
+	}
+
+	if aws.ToString(resp.UserSub) == "" {
+		return "", fmt.Errorf("no user ID received from Cognito")
+	}
+
+	return aws.ToString(resp.UserSub), nil
+}
+
+// Login authenticates with the USER_PASSWORD_AUTH flow, which must be enabled
+// on the app client, and returns the user's sub.
+func (p *Provider) Login(ctx context.Context, email, password string) (string, error) {
+	params := map[string]string{
+		"USERNAME": email,
+		"PASSWORD": password,
+	}
+	if hash := p.secretHash(email); hash != nil {
+		params["SECRET_HASH"] = *hash
+	}
+
+	resp, err := p.client.InitiateAuth(ctx, &cip.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		ClientId:       aws.String(p.config.ClientID),
+		AuthParameters: params,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with Cognito: %w", err)
+	}
+
+	// Challenges such as MFA or a forced password change aren't supported
+	if resp.ChallengeName != "" {
+		return "", fmt.Errorf("unsupported Cognito challenge: %s", resp.ChallengeName)
+	}
+	if resp.AuthenticationResult == nil || aws.ToString(resp.AuthenticationResult.IdToken) == "" {
+		return "", fmt.Errorf("no ID token received from Cognito")
+	}
+
+	user, err := p.ValidateToken(ctx, aws.ToString(resp.AuthenticationResult.IdToken))
+	if err != nil {
+		return "", err
+	}
+	return user.AuthProviderID, nil
+}
+
+type claims struct {
+	TokenUse string `json:"token_use"`
+	ClientID string `json:"client_id"`
+	Email    string `json:"email"`
+	jwtlib.RegisteredClaims
+}
+
+// ValidateToken verifies an ID or access token issued by the user pool to
+// this app client. Access tokens carry no email.
+func (p *Provider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	var c claims
+	if err := p.keys.Parse(ctx, token, &c, jwtlib.WithIssuer(p.issuer), jwtlib.WithExpirationRequired()); err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	switch c.TokenUse {
+	case "id":
+		if !slices.Contains(c.Audience, p.config.ClientID) {
+			return nil, errors.New("invalid token: issued to another client")
+		}
+	case "access":
+		if c.ClientID != p.config.ClientID {
+			return nil, errors.New("invalid token: issued to another client")
+		}
+	default:
+		return nil, fmt.Errorf("invalid token: unexpected token_use %q", c.TokenUse)
+	}
+
+	if c.Subject == "" {
+		return nil, errors.New("invalid token: no subject")
+	}
+
+	return &entities.User{
+		Email:          c.Email,
+		AuthProvider:   ProviderName,
+		AuthProviderID: c.Subject,
+	}, nil
+}
+
+// DeleteUser deletes the user with the given sub. Cognito deletes by
+// username, which is looked up first since it isn't always the sub.
+func (p *Provider) DeleteUser(ctx context.Context, authProviderID string) error {
+	resp, err := p.client.ListUsers(ctx, &cip.ListUsersInput{
+		UserPoolId: aws.String(p.config.UserPoolID),
+		Filter:     aws.String(fmt.Sprintf("sub = %q", authProviderID)),
+		Limit:      aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find user in Cognito: %w", err)
+	}
+	if len(resp.Users) == 0 {
+		return fmt.Errorf("user %s not found in Cognito", authProviderID)
+	}
+
+	_, err = p.client.AdminDeleteUser(ctx, &cip.AdminDeleteUserInput{
+		UserPoolId: aws.String(p.config.UserPoolID),
+		Username:   resp.Users[0].Username,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete user from Cognito: %w", err)
+	}
+
+	return nil
+}
+
+// ListUsers pages through every user in the pool.
+func (p *Provider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	var users []entities.ProviderUser
+	var token *string
+	for {
+		resp, err := p.client.ListUsers(ctx, &cip.ListUsersInput{
+			UserPoolId:      aws.String(p.config.UserPoolID),
+			Limit:           aws.Int32(listUsersPageSize),
+			PaginationToken: token,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users from Cognito: %w", err)
+		}
+
+		for _, u := range resp.Users {
+			users = append(users, entities.ProviderUser{
+				ID:        attribute(u.Attributes, "sub"),
+				Email:     attribute(u.Attributes, "email"),
+				UpdatedAt: aws.ToTime(u.UserLastModifiedDate),
+			})
+		}
+
+		if aws.ToString(resp.PaginationToken) == "" {
+			return users, nil
+		}
+		token = resp.PaginationToken
+	}
+}
+
+// secretHash is required by app clients that have a secret. It is nil for
+// clients without one.
+func (p *Provider) secretHash(username string) *string {
+	if p.config.ClientSecret == "" {
+		return nil
+	}
+	mac := hmac.New(sha256.New, []byte(p.config.ClientSecret))
+	mac.Write([]byte(username + p.config.ClientID))
+	return aws.String(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+func attribute(attrs []types.AttributeType, name string) string {
+	for _, a := range attrs {
+		if aws.ToString(a.Name) == name {
+			return aws.ToString(a.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+package cognito
+
+import (
+	"context"
+	"encoding/json"
+	"go-template/internal/jwt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_test"
+
+type fakeAPI struct {
+	idToken   string
+	signUpIn  *cip.SignUpInput
+	initIn    *cip.InitiateAuthInput
+	deletedIn *cip.AdminDeleteUserInput
+	userPages [][]types.UserType
+	listCalls []*cip.ListUsersInput
+}
+
+func (f *fakeAPI) SignUp(ctx context.Context, params *cip.SignUpInput, optFns ...func(*cip.Options)) (*cip.SignUpOutput, error) {
+	f.signUpIn = params
+	return &cip.SignUpOutput{UserSub: aws.String("sub-1")}, nil
+}
+
+func (f *fakeAPI) InitiateAuth(ctx context.Context, params *cip.InitiateAuthInput, optFns ...func(*cip.Options)) (*cip.InitiateAuthOutput, error) {
+	f.initIn = params
+	return &cip.InitiateAuthOutput{
+		AuthenticationResult: &types.AuthenticationResultType{IdToken: aws.String(f.idToken)},
+	}, nil
+}
+
+func (f *fakeAPI) AdminDeleteUser(ctx context.Context, params *cip.AdminDeleteUserInput, optFns ...func(*cip.Options)) (*cip.AdminDeleteUserOutput, error) {
+	f.deletedIn = params
+	return &cip.AdminDeleteUserOutput{}, nil
+}
+
+func (f *fakeAPI) ListUsers(ctx context.Context, params *cip.ListUsersInput, optFns ...func(*cip.Options)) (*cip.ListUsersOutput, error) {
+	f.listCalls = append(f.listCalls, params)
+	page := len(f.listCalls) - 1
+	out := &cip.ListUsersOutput{Users: f.userPages[page]}
+	if page < len(f.userPages)-1 {
+		out.PaginationToken = aws.String("next")
+	}
+	return out, nil
+}
+
+func user(username, sub, email string) types.UserType {
+	return types.UserType{
+		Username: aws.String(username),
+		Attributes: []types.AttributeType{
+			{Name: aws.String("sub"), Value: aws.String(sub)},
+			{Name: aws.String("email"), Value: aws.String(email)},
+		},
+	}
+}
+
+// newTestProvider serves the JWKS of a fresh key and returns a signer for
+// tokens the provider accepts.
+func newTestProvider(t *testing.T, api *fakeAPI, cfg Config) (*Provider, func(claims jwtlib.MapClaims) string) {
+	t.Helper()
+
+	key, err := jwt.GenerateRSAKey()
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(key.JWKS())
+	}))
+	t.Cleanup(srv.Close)
+
+	sign := func(claims jwtlib.MapClaims) string {
+		token, err := key.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+	return newProvider(api, cfg, testIssuer, jwt.NewRemoteKeySet(srv.URL)), sign
+}
+
+func idClaims(aud string) jwtlib.MapClaims {
+	return jwtlib.MapClaims{
+		"iss":       testIssuer,
+		"sub":       "sub-1",
+		"aud":       aud,
+		"token_use": "id",
+		"email":     "a@example.com",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestProvider_Login(t *testing.T) {
+	api := &fakeAPI{}
+	p, sign := newTestProvider(t, api, Config{ClientID: "client", ClientSecret: "secret"})
+	api.idToken = sign(idClaims("client"))
+
+	sub, err := p.Login(context.Background(), "a@example.com", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", sub)
+	assert.Equal(t, types.AuthFlowTypeUserPasswordAuth, api.initIn.AuthFlow)
+	assert.Equal(t, "a@example.com", api.initIn.AuthParameters["USERNAME"])
+	assert.NotEmpty(t, api.initIn.AuthParameters["SECRET_HASH"])
+}
+
+func TestProvider_RegisterUser(t *testing.T) {
+	api := &fakeAPI{}
+	p, _ := newTestProvider(t, api, Config{ClientID: "client"})
+
+	sub, err := p.RegisterUser(context.Background(), "a@example.com", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", sub)
+	assert.Equal(t, "a@example.com", aws.ToString(api.signUpIn.Username))
+	assert.Nil(t, api.signUpIn.SecretHash)
+}
+
+func TestProvider_ValidateToken(t *testing.T) {
+	p, sign := newTestProvider(t, &fakeAPI{}, Config{ClientID: "client"})
+
+	user, err := p.ValidateToken(context.Background(), sign(idClaims("client")))
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", user.AuthProviderID)
+	assert.Equal(t, "a@example.com", user.Email)
+	assert.Equal(t, ProviderName, user.AuthProvider)
+
+	access := jwtlib.MapClaims{
+		"iss":       testIssuer,
+		"sub":       "sub-1",
+		"client_id": "client",
+		"token_use": "access",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+	_, err = p.ValidateToken(context.Background(), sign(access))
+	assert.NoError(t, err)
+
+	_, err = p.ValidateToken(context.Background(), sign(idClaims("other-client")))
+	assert.Error(t, err, "token for another client")
+
+	wrongIssuer := idClaims("client")
+	wrongIssuer["iss"] = "https://cognito-idp.us-east-1.amazonaws.com/other"
+	_, err = p.ValidateToken(context.Background(), sign(wrongIssuer))
+	assert.Error(t, err, "token from another pool")
+
+	expired := idClaims("client")
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, err = p.ValidateToken(context.Background(), sign(expired))
+	assert.Error(t, err, "expired token")
+}
+
+func TestProvider_DeleteUser(t *testing.T) {
+	api := &fakeAPI{userPages: [][]types.UserType{{user("alice", "sub-1", "a@example.com")}}}
+	p, _ := newTestProvider(t, api, Config{UserPoolID: "pool", ClientID: "client"})
+
+	require.NoError(t, p.DeleteUser(context.Background(), "sub-1"))
+	assert.Equal(t, `sub = "sub-1"`, aws.ToString(api.listCalls[0].Filter))
+	assert.Equal(t, "alice", aws.ToString(api.deletedIn.Username))
+}
+
+func TestProvider_ListUsers(t *testing.T) {
+	api := &fakeAPI{userPages: [][]types.UserType{
+		{user("alice", "sub-1", "a@example.com")},
+		{user("bob", "sub-2", "b@example.com")},
+	}}
+	p, _ := newTestProvider(t, api, Config{UserPoolID: "pool", ClientID: "client"})
+
+	users, err := p.ListUsers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "sub-2", users[1].ID)
+	assert.Equal(t, "b@example.com", users[1].Email)
+	assert.Equal(t, "next", aws.ToString(api.listCalls[1].PaginationToken))
+}
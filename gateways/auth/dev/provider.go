@@ -0,0 +1,129 @@
+// Package dev is an auth provider for running the stack on a workstation
+// without an identity service. It accepts any password: the first login with
+// an email creates the account. It must never run in production.
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/auth/local"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ProviderName is the name the dev provider is selected by.
+const ProviderName = "dev"
+
+// Provider keeps accounts in the local credentials table, so users created
+// with it can later sign in with the local provider using the password they
+// registered with.
+type Provider struct {
+	store  local.Store
+	tokens local.TokenValidator
+}
+
+// NewProvider creates the dev provider. tokens may be nil, in which case
+// ValidateToken always fails.
+func NewProvider(store local.Store, tokens local.TokenValidator) *Provider {
+	return &Provider{store: store, tokens: tokens}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+func (p *Provider) RegisterUser(ctx context.Context, email, password string) (string, error) {
+	hash, err := local.HashPassword(password, local.DefaultParams)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	credential := entities.LocalCredential{
+		ID:           uuid.Must(uuid.NewV4()),
+		Email:        normalizeEmail(email),
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := p.store.CreateLocalCredential(ctx, credential); err != nil {
+		return "", fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return credential.ID.String(), nil
+}
+
+// Login accepts any password. Unknown emails are registered on the spot.
+func (p *Provider) Login(ctx context.Context, email, password string) (string, error) {
+	credential, err := p.store.GetLocalCredentialByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, domain.ErrNotFound) {
+		return p.RegisterUser(ctx, email, password)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get credential: %w", err)
+	}
+	return credential.ID.String(), nil
+}
+
+func (p *Provider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	if p.tokens == nil {
+		return nil, fmt.Errorf("dev provider has no token validator")
+	}
+
+	claims, err := p.tokens.ValidateToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	credential, err := p.store.GetLocalCredentialByEmail(ctx, normalizeEmail(claims.Email))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return &entities.User{
+		ID:             uuid.FromStringOrNil(claims.UserID),
+		Email:          credential.Email,
+		AuthProvider:   ProviderName,
+		AuthProviderID: credential.ID.String(),
+		CreatedAt:      credential.CreatedAt,
+		UpdatedAt:      credential.UpdatedAt,
+	}, nil
+}
+
+func (p *Provider) DeleteUser(ctx context.Context, authProviderID string) error {
+	id, err := uuid.FromString(authProviderID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if err := p.store.DeleteLocalCredential(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	credentials, err := p.store.ListLocalCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]entities.ProviderUser, 0, len(credentials))
+	for _, c := range credentials {
+		users = append(users, entities.ProviderUser{
+			ID:        c.ID.String(),
+			Email:     c.Email,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	return users, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,56 @@
+package dev
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/auth/local"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	local.Store
+	credentials map[string]entities.LocalCredential
+}
+
+func newMemStore() *memStore {
+	return &memStore{credentials: map[string]entities.LocalCredential{}}
+}
+
+func (m *memStore) CreateLocalCredential(ctx context.Context, credential entities.LocalCredential) error {
+	if _, ok := m.credentials[credential.Email]; ok {
+		return domain.ErrDuplicateKey
+	}
+	m.credentials[credential.Email] = credential
+	return nil
+}
+
+func (m *memStore) GetLocalCredentialByEmail(ctx context.Context, email string) (entities.LocalCredential, error) {
+	credential, ok := m.credentials[email]
+	if !ok {
+		return entities.LocalCredential{}, domain.ErrNotFound
+	}
+	return credential, nil
+}
+
+func TestProvider_LoginAcceptsAnyPassword(t *testing.T) {
+	store := newMemStore()
+	p := NewProvider(store, nil)
+	ctx := context.Background()
+
+	id, err := p.Login(ctx, " Dev@Example.com", "anything")
+	require.NoError(t, err)
+	assert.Contains(t, store.credentials, "dev@example.com")
+
+	again, err := p.Login(ctx, "dev@example.com", "something else")
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	// The password given first works with the local provider later on
+	ok, err := local.VerifyPassword("anything", store.credentials["dev@example.com"].PasswordHash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
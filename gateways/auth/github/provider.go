@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain/entities"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// ProviderName is the name GitHub social login is selected by.
+const ProviderName = "github"
+
+const apiURL = "https://api.github.com"
+
+// Provider signs users in with their GitHub account using the OAuth2
+// authorization code flow.
+type Provider struct {
+	config oauth2.Config
+	apiURL string
+}
+
+func NewProvider(clientID, clientSecret string) *Provider {
+	return &Provider{
+		config: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     endpoints.GitHub,
+			Scopes:       []string{"read:user", "user:email"},
+		},
+		apiURL: apiURL,
+	}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+// AuthCodeURL returns the GitHub authorization page users are sent to.
+func (p *Provider) AuthCodeURL(state, redirectURI string) string {
+	config := p.config
+	config.RedirectURL = redirectURI
+	return config.AuthCodeURL(state)
+}
+
+// Exchange trades the authorization code for a token and reads the account
+// it belongs to. The email is the account's primary email, which GitHub only
+// returns from the emails endpoint when it is private.
+func (p *Provider) Exchange(ctx context.Context, code, redirectURI string) (entities.SocialIdentity, error) {
+	config := p.config
+	config.RedirectURL = redirectURI
+
+	token, err := config.Exchange(ctx, code)
+	if err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	client := config.Client(ctx, token)
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.ID == 0 {
+		return entities.SocialIdentity{}, fmt.Errorf("no user ID received from GitHub")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to get user emails: %w", err)
+	}
+
+	identity := entities.SocialIdentity{
+		Provider: ProviderName,
+		ID:       strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) get(ctx context.Context, client *http.Client, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newTestProvider(t *testing.T, emails []map[string]any) *Provider {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "code-1", r.Form.Get("code"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{"id": 42, "login": "octocat"})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(emails)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	p := NewProvider("client", "secret")
+	p.config.Endpoint = oauth2.Endpoint{
+		AuthURL:  srv.URL + "/login/oauth/authorize",
+		TokenURL: srv.URL + "/login/oauth/access_token",
+	}
+	p.apiURL = srv.URL
+	return p
+}
+
+func TestProvider_Exchange_UsesPrimaryEmail(t *testing.T) {
+	p := newTestProvider(t, []map[string]any{
+		{"email": "other@example.com", "primary": false, "verified": true},
+		{"email": "octo@example.com", "primary": true, "verified": true},
+	})
+
+	identity, err := p.Exchange(context.Background(), "code-1", "http://localhost:8080/auth/github/callback")
+	require.NoError(t, err)
+	assert.Equal(t, ProviderName, identity.Provider)
+	assert.Equal(t, "42", identity.ID)
+	assert.Equal(t, "octo@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "octocat", identity.Name)
+}
+
+func TestProvider_Exchange_UnverifiedPrimaryEmail(t *testing.T) {
+	p := newTestProvider(t, []map[string]any{
+		{"email": "octo@example.com", "primary": true, "verified": false},
+	})
+
+	identity, err := p.Exchange(context.Background(), "code-1", "http://localhost:8080/auth/github/callback")
+	require.NoError(t, err)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	p := NewProvider("client", "secret")
+
+	u := p.AuthCodeURL("state-1", "http://localhost:8080/auth/github/callback")
+	assert.Contains(t, u, "https://github.com/login/oauth/authorize?")
+	assert.Contains(t, u, "state=state-1")
+	assert.Contains(t, u, "client_id=client")
+}
@@ -0,0 +1,94 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain/entities"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// ProviderName is the name Google social login is selected by.
+const ProviderName = "google"
+
+const userInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+
+// Provider signs users in with their Google account using the OAuth2
+// authorization code flow.
+type Provider struct {
+	config      oauth2.Config
+	userInfoURL string
+}
+
+func NewProvider(clientID, clientSecret string) *Provider {
+	return &Provider{
+		config: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     endpoints.Google,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		userInfoURL: userInfoURL,
+	}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+// AuthCodeURL returns the Google consent page users are sent to.
+func (p *Provider) AuthCodeURL(state, redirectURI string) string {
+	config := p.config
+	config.RedirectURL = redirectURI
+	return config.AuthCodeURL(state)
+}
+
+// Exchange trades the authorization code for a token and reads the account
+// it belongs to.
+func (p *Provider) Exchange(ctx context.Context, code, redirectURI string) (entities.SocialIdentity, error) {
+	config := p.config
+	config.RedirectURL = redirectURI
+
+	token, err := config.Exchange(ctx, code)
+	if err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.userInfoURL, nil)
+	if err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to create user info request: %w", err)
+	}
+	resp, err := config.Client(ctx, token).Do(req)
+	if err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to get user info: status %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return entities.SocialIdentity{}, fmt.Errorf("failed to decode user info: %w", err)
+	}
+	if info.Sub == "" {
+		return entities.SocialIdentity{}, fmt.Errorf("no subject received from Google")
+	}
+
+	return entities.SocialIdentity{
+		Provider:      ProviderName,
+		ID:            info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestProvider_Exchange(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "code-1", r.Form.Get("code"))
+		assert.Equal(t, "http://localhost:8080/auth/google/callback", r.Form.Get("redirect_uri"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "g-token", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer g-token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"sub":            "1234",
+			"email":          "a@example.com",
+			"email_verified": true,
+			"name":           "Ada",
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := NewProvider("client", "secret")
+	p.config.Endpoint = oauth2.Endpoint{AuthURL: srv.URL + "/auth", TokenURL: srv.URL + "/token"}
+	p.userInfoURL = srv.URL + "/userinfo"
+
+	identity, err := p.Exchange(context.Background(), "code-1", "http://localhost:8080/auth/google/callback")
+	require.NoError(t, err)
+	assert.Equal(t, ProviderName, identity.Provider)
+	assert.Equal(t, "1234", identity.ID)
+	assert.Equal(t, "a@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "Ada", identity.Name)
+}
@@ -0,0 +1,205 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ProviderName is the name the local provider is selected by.
+const ProviderName = "local"
+
+// ErrInvalidCredentials is returned for an unknown email or wrong password.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// Store persists local credentials.
+type Store interface {
+	CreateLocalCredential(ctx context.Context, credential entities.LocalCredential) error
+	// GetLocalCredentialByEmail returns domain.ErrNotFound when there is no
+	// credential for email.
+	GetLocalCredentialByEmail(ctx context.Context, email string) (entities.LocalCredential, error)
+	DeleteLocalCredential(ctx context.Context, id uuid.UUID) error
+	// UpdateLocalCredentialPassword returns domain.ErrNotFound when there is
+	// no credential with id.
+	UpdateLocalCredentialPassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error
+	// UpdateLocalCredentialEmail returns domain.ErrNotFound when there is no
+	// credential with id, and domain.ErrDuplicateKey when another credential
+	// has email.
+	UpdateLocalCredentialEmail(ctx context.Context, id uuid.UUID, email string, updatedAt time.Time) error
+	ListLocalCredentials(ctx context.Context) ([]entities.LocalCredential, error)
+}
+
+// TokenValidator validates the application's own access tokens.
+type TokenValidator interface {
+	ValidateToken(token string) (*jwt.Claims, error)
+}
+
+// Provider is an auth provider that keeps argon2id password hashes in the
+// application database, for development and self-hosted deployments without
+// an external identity service. It issues no tokens of its own; the tokens it
+// validates are the ones the application issues after Login.
+type Provider struct {
+	store  Store
+	tokens TokenValidator
+	params Params
+
+	// dummyHash is verified against when the email is unknown, so a login
+	// takes as long whether or not the account exists
+	dummyHash string
+}
+
+// NewProvider creates the local provider. tokens may be nil, in which case
+// ValidateToken always fails.
+func NewProvider(store Store, tokens TokenValidator) *Provider {
+	dummy, _ := HashPassword("", DefaultParams)
+	return &Provider{
+		store:     store,
+		tokens:    tokens,
+		params:    DefaultParams,
+		dummyHash: dummy,
+	}
+}
+
+func (p *Provider) Provider() string {
+	return ProviderName
+}
+
+func (p *Provider) RegisterUser(ctx context.Context, email, password string) (string, error) {
+	hash, err := HashPassword(password, p.params)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	credential := entities.LocalCredential{
+		ID:           uuid.Must(uuid.NewV4()),
+		Email:        normalizeEmail(email),
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := p.store.CreateLocalCredential(ctx, credential); err != nil {
+		return "", fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return credential.ID.String(), nil
+}
+
+func (p *Provider) Login(ctx context.Context, email, password string) (string, error) {
+	credential, err := p.store.GetLocalCredentialByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, domain.ErrNotFound) {
+		_, _ = VerifyPassword(password, p.dummyHash)
+		return "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	ok, err := VerifyPassword(password, credential.PasswordHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
+		return "", ErrInvalidCredentials
+	}
+
+	return credential.ID.String(), nil
+}
+
+func (p *Provider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	if p.tokens == nil {
+		return nil, fmt.Errorf("local provider has no token validator")
+	}
+
+	claims, err := p.tokens.ValidateToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	credential, err := p.store.GetLocalCredentialByEmail(ctx, normalizeEmail(claims.Email))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return &entities.User{
+		ID:             uuid.FromStringOrNil(claims.UserID),
+		Email:          credential.Email,
+		AuthProvider:   ProviderName,
+		AuthProviderID: credential.ID.String(),
+		CreatedAt:      credential.CreatedAt,
+		UpdatedAt:      credential.UpdatedAt,
+	}, nil
+}
+
+func (p *Provider) DeleteUser(ctx context.Context, authProviderID string) error {
+	id, err := uuid.FromString(authProviderID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if err := p.store.DeleteLocalCredential(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+// UpdatePassword replaces the password of the credential with
+// authProviderID, e.g. after a password reset.
+func (p *Provider) UpdatePassword(ctx context.Context, authProviderID, password string) error {
+	id, err := uuid.FromString(authProviderID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	hash, err := HashPassword(password, p.params)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := p.store.UpdateLocalCredentialPassword(ctx, id, hash, time.Now()); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
+
+// UpdateEmail changes the email the credential with authProviderID signs in
+// with, e.g. after a confirmed email change.
+func (p *Provider) UpdateEmail(ctx context.Context, authProviderID, email string) error {
+	id, err := uuid.FromString(authProviderID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if err := p.store.UpdateLocalCredentialEmail(ctx, id, normalizeEmail(email), time.Now()); err != nil {
+		return fmt.Errorf("failed to update email: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	credentials, err := p.store.ListLocalCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]entities.ProviderUser, 0, len(credentials))
+	for _, c := range credentials {
+		users = append(users, entities.ProviderUser{
+			ID:        c.ID.String(),
+			Email:     c.Email,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	return users, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,160 @@
+package local
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/internal/jwt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	credentials map[string]entities.LocalCredential
+}
+
+func newMemStore() *memStore {
+	return &memStore{credentials: map[string]entities.LocalCredential{}}
+}
+
+func (m *memStore) CreateLocalCredential(ctx context.Context, credential entities.LocalCredential) error {
+	if _, ok := m.credentials[credential.Email]; ok {
+		return domain.ErrDuplicateKey
+	}
+	m.credentials[credential.Email] = credential
+	return nil
+}
+
+func (m *memStore) GetLocalCredentialByEmail(ctx context.Context, email string) (entities.LocalCredential, error) {
+	credential, ok := m.credentials[email]
+	if !ok {
+		return entities.LocalCredential{}, domain.ErrNotFound
+	}
+	return credential, nil
+}
+
+func (m *memStore) DeleteLocalCredential(ctx context.Context, id uuid.UUID) error {
+	for email, c := range m.credentials {
+		if c.ID == id {
+			delete(m.credentials, email)
+		}
+	}
+	return nil
+}
+
+func (m *memStore) UpdateLocalCredentialPassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	for email, c := range m.credentials {
+		if c.ID == id {
+			c.PasswordHash = passwordHash
+			c.UpdatedAt = updatedAt
+			m.credentials[email] = c
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (m *memStore) UpdateLocalCredentialEmail(ctx context.Context, id uuid.UUID, email string, updatedAt time.Time) error {
+	if _, ok := m.credentials[email]; ok {
+		return domain.ErrDuplicateKey
+	}
+	for old, c := range m.credentials {
+		if c.ID == id {
+			c.Email = email
+			c.UpdatedAt = updatedAt
+			delete(m.credentials, old)
+			m.credentials[email] = c
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (m *memStore) ListLocalCredentials(ctx context.Context) ([]entities.LocalCredential, error) {
+	var credentials []entities.LocalCredential
+	for _, c := range m.credentials {
+		credentials = append(credentials, c)
+	}
+	return credentials, nil
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("s3cret", DefaultParams)
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=19456,t=2,p=1$")
+
+	ok, err := VerifyPassword("s3cret", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyPassword("wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Hashes made with other parameters still verify
+	cheap := Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 16}
+	hash, err = HashPassword("s3cret", cheap)
+	require.NoError(t, err)
+	ok, err = VerifyPassword("s3cret", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = VerifyPassword("s3cret", "$2a$10$bcrypt")
+	assert.Error(t, err)
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	tokens := jwt.NewService("secret", "test", "1h")
+	p := NewProvider(store, tokens)
+
+	id, err := p.RegisterUser(ctx, "User@Example.com", "s3cret")
+	require.NoError(t, err)
+
+	_, err = p.RegisterUser(ctx, "user@example.com", "other")
+	assert.ErrorIs(t, err, domain.ErrDuplicateKey, "emails are case insensitive")
+
+	got, err := p.Login(ctx, "user@example.com", "s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	_, err = p.Login(ctx, "user@example.com", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = p.Login(ctx, "nobody@example.com", "s3cret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	token, err := tokens.GenerateToken(uuid.Must(uuid.NewV4()).String(), "user@example.com", "user")
+	require.NoError(t, err)
+	user, err := p.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, id, user.AuthProviderID)
+	assert.Equal(t, ProviderName, user.AuthProvider)
+
+	require.NoError(t, p.UpdatePassword(ctx, id, "n3w-secret"))
+	_, err = p.Login(ctx, "user@example.com", "s3cret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	got, err = p.Login(ctx, "user@example.com", "n3w-secret")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	require.NoError(t, p.UpdateEmail(ctx, id, "New@Example.com"))
+	_, err = p.Login(ctx, "user@example.com", "n3w-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	got, err = p.Login(ctx, "new@example.com", "n3w-secret")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	users, err := p.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "new@example.com", users[0].Email)
+
+	require.NoError(t, p.DeleteUser(ctx, id))
+	_, err = p.Login(ctx, "new@example.com", "n3w-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
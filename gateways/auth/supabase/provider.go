@@ -1,116 +1,364 @@
 package supabase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go-template/domain/entities"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	googleUUID "github.com/google/uuid"
 	"github.com/supabase-community/gotrue-go/types"
+)
+
+// ProviderName is the name the Supabase provider is selected by.
+const ProviderName = "supabase"
+
+// listUsersPageSize is the largest page GoTrue's admin API returns
+const listUsersPageSize = 1000
 
-	"github.com/supabase-community/supabase-go"
+// Calls that fail with a network error, or with a status GoTrue returns while
+// it is overloaded or restarting, are retried with exponential backoff.
+const (
+	maxAttempts  = 3
+	retryBackoff = 200 * time.Millisecond
 )
 
+// APIError is a response from GoTrue with an error status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("supabase: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("supabase: status %d: %s", e.StatusCode, e.Message)
+}
+
+// Unavailable reports whether GoTrue failed rather than rejected the request.
+func (e *APIError) Unavailable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Session is a Supabase session, as returned by SignIn and RefreshSession.
+type Session struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	UserID       string
+}
+
+// SupabaseProvider talks to the GoTrue API of a Supabase project. apiKey must
+// be the service role key for the admin calls: DeleteUser, UpdatePassword,
+// UpdateEmail and ListUsers.
 type SupabaseProvider struct {
-	client *supabase.Client
+	url        string
+	apiKey     string
+	httpClient *http.Client
+	backoff    time.Duration
 }
 
 func NewSupabaseProvider(url, apiKey string) *SupabaseProvider {
-	client, _ := supabase.NewClient(url, apiKey, nil)
 	return &SupabaseProvider{
-		client: client,
+		url:        strings.TrimRight(url, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		backoff:    retryBackoff,
 	}
 }
 
 func (p *SupabaseProvider) Provider() string {
-	return "supabase"
+	return ProviderName
 }
 
 func (p *SupabaseProvider) RegisterUser(ctx context.Context, email, password string) (string, error) {
-	resp, err := p.client.Auth.Signup(types.SignupRequest{
-		Email:    email,
-		Password: password,
-	})
+	var resp types.SignupResponse
+	err := p.call(ctx, http.MethodPost, "/signup", "", map[string]string{
+		"email":    email,
+		"password": password,
+	}, &resp)
 	if err != nil {
 		return "", fmt.Errorf("failed to register user: %w", err)
 	}
 
-	if resp.User.ID.String() == "" {
+	// The user is at the top level while email confirmation is on, and in
+	// the session when signups are confirmed automatically
+	id := resp.User.ID
+	if id == googleUUID.Nil {
+		id = resp.Session.User.ID
+	}
+	if id == googleUUID.Nil {
 		return "", fmt.Errorf("no user ID received from Supabase")
 	}
 
-	return resp.User.ID.String(), nil
+	return id.String(), nil
 }
 
+// Login checks the password and returns the Supabase user ID.
 func (p *SupabaseProvider) Login(ctx context.Context, email, password string) (string, error) {
-	if p.client == nil {
-		return "", fmt.Errorf("supabase client not initialized")
+	session, err := p.SignIn(ctx, email, password)
+	if err != nil {
+		return "", err
 	}
+	return session.UserID, nil
+}
 
-	// Use the Auth client SignInWithEmailPassword method
-	resp, err := p.client.Auth.SignInWithEmailPassword(email, password)
+// SignIn starts a Supabase session with the password grant.
+func (p *SupabaseProvider) SignIn(ctx context.Context, email, password string) (Session, error) {
+	session, err := p.token(ctx, "password", map[string]string{
+		"email":    email,
+		"password": password,
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to authenticate with Supabase: %w", err)
+		return Session{}, fmt.Errorf("failed to authenticate with Supabase: %w", err)
 	}
+	return session, nil
+}
 
-	if resp.AccessToken == "" {
-		return "", fmt.Errorf("no access token received from Supabase")
+// RefreshSession exchanges a Supabase refresh token for a new session.
+// Refresh tokens are single use; the returned session carries the next one.
+func (p *SupabaseProvider) RefreshSession(ctx context.Context, refreshToken string) (Session, error) {
+	session, err := p.token(ctx, "refresh_token", map[string]string{
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to refresh Supabase session: %w", err)
 	}
-
-	return resp.AccessToken, nil
+	return session, nil
 }
 
-func (p *SupabaseProvider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
-	if p.client == nil {
-		return nil, fmt.Errorf("supabase client not initialized")
+func (p *SupabaseProvider) token(ctx context.Context, grantType string, body map[string]string) (Session, error) {
+	var resp types.TokenResponse
+	if err := p.call(ctx, http.MethodPost, "/token?grant_type="+grantType, "", body, &resp); err != nil {
+		return Session{}, err
 	}
-
-	// Update the auth session with the token
-	session := types.Session{
-		AccessToken: token,
+	if resp.AccessToken == "" {
+		return Session{}, fmt.Errorf("no access token received from Supabase")
 	}
-	p.client.UpdateAuthSession(session)
 
-	user, err := p.client.Auth.GetUser()
-	if err != nil {
+	return Session{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    time.Unix(resp.ExpiresAt, 0),
+		UserID:       resp.User.ID.String(),
+	}, nil
+}
+
+func (p *SupabaseProvider) ValidateToken(ctx context.Context, token string) (*entities.User, error) {
+	var user types.UserResponse
+	if err := p.call(ctx, http.MethodGet, "/user", token, nil, &user); err != nil {
 		return nil, fmt.Errorf("failed to validate token: %w", err)
 	}
-
-	if user == nil {
+	if user.ID == googleUUID.Nil {
 		return nil, fmt.Errorf("invalid token: no user found")
 	}
 
 	return &entities.User{
 		ID:             uuid.Nil,
 		Email:          user.Email,
-		AuthProvider:   "supabase",
+		AuthProvider:   ProviderName,
 		AuthProviderID: user.ID.String(),
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
 	}, nil
 }
 
+// DeleteUser removes the account through the admin API. Accounts that are
+// already gone count as deleted, so a retried delete doesn't fail.
 func (p *SupabaseProvider) DeleteUser(ctx context.Context, authProviderID string) error {
-	if p.client == nil {
-		return fmt.Errorf("supabase client not initialized")
+	id, err := googleUUID.Parse(authProviderID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	err = p.call(ctx, http.MethodDelete, "/admin/users/"+id.String(), "", nil, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete user from Supabase: %w", err)
+	}
+	return nil
+}
+
+// UpdatePassword sets a new password through the admin API, e.g. after a
+// password reset.
+func (p *SupabaseProvider) UpdatePassword(ctx context.Context, authProviderID, password string) error {
+	if err := p.updateUser(ctx, authProviderID, types.AdminUpdateUserRequest{Password: password}); err != nil {
+		return fmt.Errorf("failed to update password in Supabase: %w", err)
+	}
+	return nil
+}
+
+// UpdateEmail sets a new email through the admin API once the application
+// has confirmed it, so Supabase sends no confirmation of its own.
+func (p *SupabaseProvider) UpdateEmail(ctx context.Context, authProviderID, email string) error {
+	if err := p.updateUser(ctx, authProviderID, types.AdminUpdateUserRequest{Email: email, EmailConfirm: true}); err != nil {
+		return fmt.Errorf("failed to update email in Supabase: %w", err)
 	}
+	return nil
+}
 
-	// Parse the user ID from string to Google UUID (required by Supabase)
-	googleUserID, err := googleUUID.Parse(authProviderID)
+func (p *SupabaseProvider) updateUser(ctx context.Context, authProviderID string, req types.AdminUpdateUserRequest) error {
+	id, err := googleUUID.Parse(authProviderID)
 	if err != nil {
 		return fmt.Errorf("invalid user ID format: %w", err)
 	}
+	return p.call(ctx, http.MethodPut, "/admin/users/"+id.String(), "", req, nil)
+}
+
+// RecoverPassword asks Supabase to email the user its own password recovery
+// link. It is used when the application has no way to send email itself.
+func (p *SupabaseProvider) RecoverPassword(ctx context.Context, email string) error {
+	if err := p.call(ctx, http.MethodPost, "/recover", "", map[string]string{"email": email}, nil); err != nil {
+		return fmt.Errorf("failed to request password recovery from Supabase: %w", err)
+	}
+	return nil
+}
+
+// ListUsers pages through every account in the Supabase project.
+func (p *SupabaseProvider) ListUsers(ctx context.Context) ([]entities.ProviderUser, error) {
+	var users []entities.ProviderUser
+	for page := 1; ; page++ {
+		var body types.AdminListUsersResponse
+		path := fmt.Sprintf("/admin/users?page=%d&per_page=%d", page, listUsersPageSize)
+		if err := p.call(ctx, http.MethodGet, path, "", nil, &body); err != nil {
+			return nil, fmt.Errorf("failed to list users from Supabase: %w", err)
+		}
+
+		for _, u := range body.Users {
+			users = append(users, entities.ProviderUser{
+				ID:        u.ID.String(),
+				Email:     u.Email,
+				UpdatedAt: u.UpdatedAt,
+			})
+		}
+
+		if len(body.Users) < listUsersPageSize {
+			return users, nil
+		}
+	}
+}
+
+// call sends a request to the project's GoTrue API and decodes the response
+// into out, unless out is nil. The request is authorized with token, or with
+// the API key when token is empty. Transient failures are retried.
+// CheckHealth asks GoTrue whether it is up, once and without retrying.
+func (p *SupabaseProvider) CheckHealth(ctx context.Context) error {
+	if err := p.do(ctx, http.MethodGet, "/health", "", nil, nil); err != nil {
+		return fmt.Errorf("checking Supabase health: %w", err)
+	}
+	return nil
+}
+
+func (p *SupabaseProvider) call(ctx context.Context, method, path, token string, in, out any) error {
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+	}
+	if token == "" {
+		token = p.apiKey
+	}
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.backoff << (attempt - 1)):
+			}
+		}
+
+		err = p.do(ctx, method, path, token, payload, out)
+		if !retryable(ctx, err) {
+			return err
+		}
+	}
+	return err
+}
 
-	// Delete the user from Supabase
-	// Note: This requires admin privileges and the client must be configured with service role key
-	deleteReq := types.AdminDeleteUserRequest{
-		UserID: googleUserID,
+func (p *SupabaseProvider) do(ctx context.Context, method, path, token string, payload []byte, out any) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
 	}
-	err = p.client.Auth.AdminDeleteUser(deleteReq)
+	req, err := http.NewRequestWithContext(ctx, method, p.url+"/auth/v1"+path, body)
 	if err != nil {
-		return fmt.Errorf("failed to delete user from Supabase: %w", err)
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("apikey", p.apiKey)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
 	return nil
 }
+
+// decodeError reads the message from GoTrue's error body, whose field names
+// differ between endpoints and versions.
+func decodeError(resp *http.Response) error {
+	var body struct {
+		Msg              string `json:"msg"`
+		Message          string `json:"message"`
+		ErrorDescription string `json:"error_description"`
+		Error            string `json:"error"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&body)
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	for _, msg := range []string{body.Msg, body.Message, body.ErrorDescription, body.Error} {
+		if msg != "" {
+			apiErr.Message = msg
+			break
+		}
+	}
+	return apiErr
+}
+
+func retryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
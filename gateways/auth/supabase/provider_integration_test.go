@@ -0,0 +1,186 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gotrueJWTSecret = "super-secret-jwt-token-with-at-least-32-characters"
+
+// gotrueInitSQL prepares a plain Postgres the way Supabase images come, with
+// the role and schema GoTrue migrates into.
+const gotrueInitSQL = `
+CREATE ROLE anon NOLOGIN;
+CREATE ROLE authenticated NOLOGIN;
+CREATE ROLE service_role NOLOGIN BYPASSRLS;
+CREATE USER supabase_admin LOGIN CREATEROLE CREATEDB REPLICATION BYPASSRLS;
+CREATE USER supabase_auth_admin NOINHERIT CREATEROLE LOGIN NOREPLICATION PASSWORD 'root';
+CREATE SCHEMA IF NOT EXISTS auth AUTHORIZATION supabase_auth_admin;
+GRANT CREATE ON DATABASE postgres TO supabase_auth_admin;
+ALTER USER supabase_auth_admin SET search_path = 'auth';
+`
+
+// startGoTrue runs GoTrue and its database in docker and returns a provider
+// for it. Tests are skipped when docker is not available.
+func startGoTrue(t *testing.T) *SupabaseProvider {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping GoTrue integration test in short mode")
+	}
+
+	pool, err := dockertest.NewPool("")
+	if err == nil {
+		err = pool.Client.Ping()
+	}
+	if err != nil {
+		t.Skipf("docker is not available: %v", err)
+	}
+	pool.MaxWait = 2 * time.Minute
+
+	suffix := uuid.Must(uuid.NewV4()).String()[:8]
+	network, err := pool.CreateNetwork("gotrue-test-" + suffix)
+	require.NoError(t, err)
+	t.Cleanup(func() { network.Close() })
+
+	dbName := "gotrue-db-" + suffix
+	db, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Name:       dbName,
+		Repository: "postgres",
+		Tag:        "15",
+		Env:        []string{"POSTGRES_PASSWORD=postgres", "POSTGRES_DB=postgres"},
+		Networks:   []*dockertest.Network{network},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Purge(db) })
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s/postgres?sslmode=disable", db.GetHostPort("5432/tcp"))
+	require.NoError(t, pool.Retry(func() error {
+		conn, err := pgx.Connect(context.Background(), dsn)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+		return conn.Ping(context.Background())
+	}))
+	conn, err := pgx.Connect(context.Background(), dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(context.Background(), gotrueInitSQL)
+	conn.Close(context.Background())
+	require.NoError(t, err)
+
+	gotrue, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "supabase/gotrue",
+		Tag:        "v2.151.0",
+		Env: []string{
+			"GOTRUE_API_HOST=0.0.0.0",
+			"PORT=9999",
+			"API_EXTERNAL_URL=http://localhost:9999",
+			"GOTRUE_DB_DRIVER=postgres",
+			"GOTRUE_DB_DATABASE_URL=postgres://supabase_auth_admin:root@" + dbName + ":5432/postgres?sslmode=disable",
+			"GOTRUE_SITE_URL=http://localhost:3000",
+			"GOTRUE_JWT_SECRET=" + gotrueJWTSecret,
+			"GOTRUE_JWT_EXP=3600",
+			"GOTRUE_JWT_AUD=authenticated",
+			"GOTRUE_JWT_ADMIN_ROLES=service_role",
+			"GOTRUE_JWT_DEFAULT_GROUP_NAME=authenticated",
+			"GOTRUE_EXTERNAL_EMAIL_ENABLED=true",
+			"GOTRUE_MAILER_AUTOCONFIRM=true",
+			"GOTRUE_DISABLE_SIGNUP=false",
+		},
+		ExposedPorts: []string{"9999/tcp"},
+		Networks:     []*dockertest.Network{network},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Purge(gotrue) })
+
+	// GoTrue serves at the root, Supabase projects under /auth/v1
+	target, err := url.Parse("http://" + gotrue.GetHostPort("9999/tcp"))
+	require.NoError(t, err)
+	proxy := httptest.NewServer(http.StripPrefix("/auth/v1", httputil.NewSingleHostReverseProxy(target)))
+	t.Cleanup(proxy.Close)
+
+	require.NoError(t, pool.Retry(func() error {
+		resp, err := http.Get(proxy.URL + "/auth/v1/health")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("gotrue health: status %d", resp.StatusCode)
+		}
+		return nil
+	}))
+
+	serviceKey, err := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{
+		"role": "service_role",
+		"iss":  "supabase",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(gotrueJWTSecret))
+	require.NoError(t, err)
+
+	return NewSupabaseProvider(proxy.URL, serviceKey)
+}
+
+func TestSupabaseProvider_GoTrue(t *testing.T) {
+	p := startGoTrue(t)
+	ctx := context.Background()
+	email := "user-" + strings.ToLower(uuid.Must(uuid.NewV4()).String()[:8]) + "@example.com"
+
+	id, err := p.RegisterUser(ctx, email, "first-password")
+	require.NoError(t, err)
+
+	loginID, err := p.Login(ctx, email, "first-password")
+	require.NoError(t, err)
+	assert.Equal(t, id, loginID)
+
+	_, err = p.Login(ctx, email, "wrong-password")
+	require.Error(t, err)
+
+	session, err := p.SignIn(ctx, email, "first-password")
+	require.NoError(t, err)
+	refreshed, err := p.RefreshSession(ctx, session.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, session.RefreshToken, refreshed.RefreshToken)
+
+	user, err := p.ValidateToken(ctx, refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, id, user.AuthProviderID)
+	assert.Equal(t, email, user.Email)
+
+	require.NoError(t, p.UpdatePassword(ctx, id, "second-password"))
+	_, err = p.Login(ctx, email, "second-password")
+	require.NoError(t, err)
+
+	newEmail := "changed-" + email
+	require.NoError(t, p.UpdateEmail(ctx, id, newEmail))
+
+	users, err := p.ListUsers(ctx)
+	require.NoError(t, err)
+	var found bool
+	for _, u := range users {
+		if u.ID == id {
+			found = true
+			assert.Equal(t, newEmail, u.Email)
+		}
+	}
+	assert.True(t, found, "expected the user in the list")
+
+	require.NoError(t, p.DeleteUser(ctx, id))
+	require.NoError(t, p.DeleteUser(ctx, id))
+	_, err = p.Login(ctx, newEmail, "second-password")
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *SupabaseProvider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	p := NewSupabaseProvider(srv.URL, "service-key")
+	p.backoff = 0
+	return p
+}
+
+func TestSupabaseProvider_RetriesTransientFailures(t *testing.T) {
+	var calls int
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < maxAttempts {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "/auth/v1/token", r.URL.Path)
+		assert.Equal(t, "refresh_token", r.URL.Query().Get("grant_type"))
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "old-refresh", body["refresh_token"])
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"refresh_token": "new-refresh",
+			"expires_at":    1700000000,
+			"user":          map[string]any{"id": "6f1d3f8e-9c0a-4f39-9a43-6d5d2f1c7b10"},
+		})
+	})
+
+	session, err := p.RefreshSession(context.Background(), "old-refresh")
+	require.NoError(t, err)
+	assert.Equal(t, maxAttempts, calls)
+	assert.Equal(t, "access", session.AccessToken)
+	assert.Equal(t, "new-refresh", session.RefreshToken)
+	assert.Equal(t, "6f1d3f8e-9c0a-4f39-9a43-6d5d2f1c7b10", session.UserID)
+	assert.EqualValues(t, 1700000000, session.ExpiresAt.Unix())
+}
+
+func TestSupabaseProvider_NoRetryOnClientErrors(t *testing.T) {
+	var calls int
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error_description": "Invalid login credentials"})
+	})
+
+	_, err := p.Login(context.Background(), "a@b.com", "wrong")
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, err.Error(), "Invalid login credentials")
+}
+
+func TestSupabaseProvider_DeleteUser(t *testing.T) {
+	const id = "6f1d3f8e-9c0a-4f39-9a43-6d5d2f1c7b10"
+	status := http.StatusOK
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/auth/v1/admin/users/"+id, r.URL.Path)
+		assert.Equal(t, "Bearer service-key", r.Header.Get("Authorization"))
+		w.WriteHeader(status)
+	})
+
+	require.NoError(t, p.DeleteUser(context.Background(), id))
+
+	// Users that are already gone count as deleted
+	status = http.StatusNotFound
+	require.NoError(t, p.DeleteUser(context.Background(), id))
+
+	status = http.StatusForbidden
+	require.Error(t, p.DeleteUser(context.Background(), id))
+
+	require.Error(t, p.DeleteUser(context.Background(), "not-a-uuid"))
+}
+
+func TestSupabaseProvider_CheckHealth(t *testing.T) {
+	calls := 0
+	status := http.StatusOK
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/auth/v1/health", r.URL.Path)
+		w.WriteHeader(status)
+	})
+
+	require.NoError(t, p.CheckHealth(context.Background()))
+
+	// A failed check isn't retried
+	status = http.StatusServiceUnavailable
+	calls = 0
+	require.Error(t, p.CheckHealth(context.Background()))
+	assert.Equal(t, 1, calls)
+}
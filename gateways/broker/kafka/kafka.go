@@ -0,0 +1,163 @@
+// Package kafka relays domain events from the outbox to Kafka topics.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"sync"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Config says where events are written. Brokers are the bootstrap
+// brokers' host:port addresses. Topics maps event names to the topic
+// they are written to; other events go to DefaultTopic, or aren't written
+// when it is empty.
+type Config struct {
+	Brokers      []string
+	Topics       map[string]string
+	DefaultTopic string
+	// WriteTimeout bounds how long a write waits for the brokers, including
+	// when draining on Close.
+	WriteTimeout time.Duration
+}
+
+// messageWriter is the part of *kafkago.Writer the publisher uses.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// Publisher writes domain events relayed from the outbox to Kafka, as an
+// events.Sink. Messages are keyed by the event's key, so copies of an
+// event land on the same partition and consumers can drop repeats. Headers
+// carry the event name, the outbox message ID and the actor, if any.
+// Writes wait for all in-sync replicas.
+type Publisher struct {
+	writer       messageWriter
+	brokers      []string
+	topics       map[string]string
+	defaultTopic string
+
+	mu       sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+func NewPublisher(cfg Config) (*Publisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka needs at least one broker")
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+	writer := &kafkago.Writer{
+		Addr:         kafkago.TCP(cfg.Brokers...),
+		Balancer:     &kafkago.Hash{},
+		RequiredAcks: kafkago.RequireAll,
+		// Messages are written one at a time, as they are relayed
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+	return newPublisher(writer, cfg), nil
+}
+
+func newPublisher(writer messageWriter, cfg Config) *Publisher {
+	return &Publisher{
+		writer:       writer,
+		brokers:      cfg.Brokers,
+		topics:       cfg.Topics,
+		defaultTopic: cfg.DefaultTopic,
+	}
+}
+
+// Deliver writes msg to its event's topic, and returns once the brokers
+// have it. Events without a topic are skipped. A write under way is
+// finished even when ctx is canceled, so shutting down doesn't abandon it;
+// Close waits for it.
+func (p *Publisher) Deliver(ctx context.Context, msg entities.OutboxMessage) error {
+	topic := p.topic(msg.Event)
+	if topic == "" {
+		return nil
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return errors.New("kafka publisher closed")
+	}
+	p.inFlight.Add(1)
+	p.mu.Unlock()
+	defer p.inFlight.Done()
+
+	headers := []kafkago.Header{
+		{Key: "event", Value: []byte(msg.Event)},
+		{Key: "message_id", Value: []byte(msg.ID.String())},
+	}
+	if msg.ActorID != nil {
+		headers = append(headers, kafkago.Header{Key: "actor_id", Value: []byte(msg.ActorID.String())})
+	}
+	err := p.writer.WriteMessages(context.WithoutCancel(ctx), kafkago.Message{
+		Topic:   topic,
+		Key:     []byte(msg.Key),
+		Value:   msg.Payload,
+		Headers: headers,
+		Time:    msg.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("writing %s event to kafka topic %s: %w", msg.Event, topic, err)
+	}
+	return nil
+}
+
+func (p *Publisher) topic(event string) string {
+	if topic, ok := p.topics[event]; ok {
+		return topic
+	}
+	return p.defaultTopic
+}
+
+// Ping asks the brokers for the cluster's brokers, and succeeds as soon as
+// one of them answers.
+func (p *Publisher) Ping(ctx context.Context) error {
+	err := errors.New("kafka has no brokers")
+	for _, addr := range p.brokers {
+		var conn *kafkago.Conn
+		conn, err = kafkago.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			continue
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		_, err = conn.Brokers()
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("pinging kafka: %w", err)
+}
+
+// Close refuses new messages, waits for the writes under way until ctx is
+// done, then flushes and closes the connections to the brokers. Messages
+// whose write was cut short stay in the outbox and are relayed again.
+func (p *Publisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+	}
+	return p.writer.Close()
+}
@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWriter records the messages written, holding each write until
+// release is closed when it is set.
+type fakeWriter struct {
+	mu       sync.Mutex
+	messages []kafkago.Message
+	err      error
+	release  chan struct{}
+	started  chan struct{}
+	closed   bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	if w.started != nil {
+		close(w.started)
+	}
+	if w.release != nil {
+		<-w.release
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func newMessage(event string) entities.OutboxMessage {
+	return entities.OutboxMessage{
+		ID:        uuid.Must(uuid.NewV4()),
+		Sink:      "kafka",
+		Event:     event,
+		Key:       event + ":1",
+		Payload:   []byte(`{"User":{"email":"ada@example.com"}}`),
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	_, err := NewPublisher(Config{})
+	assert.Error(t, err, "brokers are required")
+
+	p, err := NewPublisher(Config{Brokers: []string{"localhost:9092"}})
+	require.NoError(t, err)
+	assert.NoError(t, p.Close(context.Background()))
+}
+
+func TestPublisher_Deliver(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		Topics:       map[string]string{"user.created": "users", "settings.updated": ""},
+		DefaultTopic: "events",
+	}
+
+	t.Run("writes to the event's topic", func(t *testing.T) {
+		writer := &fakeWriter{}
+		p := newPublisher(writer, cfg)
+
+		actor := uuid.Must(uuid.NewV4())
+		msg := newMessage("user.created")
+		msg.ActorID = &actor
+		require.NoError(t, p.Deliver(ctx, msg))
+		require.NoError(t, p.Deliver(ctx, newMessage("user.deleted")))
+
+		require.Len(t, writer.messages, 2)
+		written := writer.messages[0]
+		assert.Equal(t, "users", written.Topic)
+		assert.Equal(t, "user.created:1", string(written.Key))
+		assert.JSONEq(t, string(msg.Payload), string(written.Value))
+		assert.Equal(t, msg.CreatedAt, written.Time)
+		assert.Equal(t, []kafkago.Header{
+			{Key: "event", Value: []byte("user.created")},
+			{Key: "message_id", Value: []byte(msg.ID.String())},
+			{Key: "actor_id", Value: []byte(actor.String())},
+		}, written.Headers)
+		assert.Equal(t, "events", writer.messages[1].Topic, "other events go to the default topic")
+	})
+
+	t.Run("skips events without a topic", func(t *testing.T) {
+		writer := &fakeWriter{}
+		require.NoError(t, newPublisher(writer, cfg).Deliver(ctx, newMessage("settings.updated")))
+		require.NoError(t, newPublisher(writer, Config{}).Deliver(ctx, newMessage("user.created")))
+		assert.Empty(t, writer.messages)
+	})
+
+	t.Run("returns write errors", func(t *testing.T) {
+		unavailable := errors.New("leader not available")
+		writer := &fakeWriter{err: unavailable}
+		err := newPublisher(writer, cfg).Deliver(ctx, newMessage("user.created"))
+		assert.ErrorIs(t, err, unavailable)
+	})
+
+	t.Run("finishes writes when ctx is canceled", func(t *testing.T) {
+		writer := &fakeWriter{}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		require.NoError(t, newPublisher(writer, cfg).Deliver(ctx, newMessage("user.created")))
+		assert.Len(t, writer.messages, 1)
+	})
+}
+
+func TestPublisher_Close(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{DefaultTopic: "events"}
+
+	t.Run("waits for writes under way", func(t *testing.T) {
+		writer := &fakeWriter{release: make(chan struct{}), started: make(chan struct{})}
+		p := newPublisher(writer, cfg)
+
+		delivered := make(chan error)
+		go func() { delivered <- p.Deliver(ctx, newMessage("user.created")) }()
+		<-writer.started
+
+		closed := make(chan error)
+		go func() { closed <- p.Close(ctx) }()
+		select {
+		case <-closed:
+			t.Fatal("closed before the write finished")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(writer.release)
+		require.NoError(t, <-delivered)
+		require.NoError(t, <-closed)
+		assert.Len(t, writer.messages, 1)
+		assert.True(t, writer.closed)
+
+		assert.Error(t, p.Deliver(ctx, newMessage("user.created")), "closed publishers refuse messages")
+	})
+
+	t.Run("stops waiting when ctx is done", func(t *testing.T) {
+		writer := &fakeWriter{release: make(chan struct{}), started: make(chan struct{})}
+		defer close(writer.release)
+		p := newPublisher(writer, cfg)
+
+		go p.Deliver(ctx, newMessage("user.created"))
+		<-writer.started
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.NoError(t, p.Close(ctx))
+		assert.True(t, writer.closed)
+	})
+}
+
+func TestPublisher_Ping(t *testing.T) {
+	// A port nothing listens on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	p, err := NewPublisher(Config{Brokers: []string{addr}})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, p.Ping(ctx))
+}
@@ -0,0 +1,285 @@
+// Package nats relays domain events from the outbox to a NATS JetStream
+// stream, and consumes them from it.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/domain/events"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// Redeliveries of a message its consumer failed to handle back off
+	// from retryBackoff, doubling up to maxRetryBackoff.
+	retryBackoff    = 10 * time.Second
+	maxRetryBackoff = time.Hour
+)
+
+// Config says where events are written. Events are published to the
+// subject SubjectPrefix.<event name>, as in "events.user.created", and
+// kept in Stream for MaxAge, or until the stream's limits if 0.
+// Duplicates is how long JetStream drops copies of an event it already
+// has, such as the outbox relaying it again after a crash.
+type Config struct {
+	URL           string
+	Stream        string
+	SubjectPrefix string
+	MaxAge        time.Duration
+	Duplicates    time.Duration
+	// WriteTimeout bounds how long a publish waits for the stream to store
+	// the event.
+	WriteTimeout time.Duration
+}
+
+// streamPublisher is the part of jetstream.JetStream the broker publishes
+// with.
+type streamPublisher interface {
+	PublishMsg(ctx context.Context, msg *natsgo.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// Broker writes domain events relayed from the outbox to a JetStream
+// stream, as an events.Sink, and hands the events in the stream to a
+// consumer's sink. The event's key is the message ID, so JetStream stores
+// an event once however often it is relayed, and headers carry the event
+// name, the outbox message ID and the actor, if any.
+type Broker struct {
+	conn          *natsgo.Conn
+	js            jetstream.JetStream
+	publisher     streamPublisher
+	stream        string
+	subjectPrefix string
+	writeTimeout  time.Duration
+	logger        *slog.Logger
+
+	mu       sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+// Connect connects to the NATS server at cfg.URL, and creates the stream
+// or updates its configuration.
+func Connect(ctx context.Context, cfg Config, logger *slog.Logger) (*Broker, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("nats needs a server URL")
+	}
+	if cfg.Stream == "" {
+		cfg.Stream = "EVENTS"
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = "events"
+	}
+
+	conn, err := natsgo.Connect(cfg.URL, natsgo.Name("go-template"), natsgo.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("creating jetstream context: %w", err)
+	}
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       cfg.Stream,
+		Subjects:   []string{cfg.SubjectPrefix + ".>"},
+		Storage:    jetstream.FileStorage,
+		MaxAge:     cfg.MaxAge,
+		Duplicates: cfg.Duplicates,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", cfg.Stream, err)
+	}
+
+	b := newBroker(js, cfg, logger)
+	b.conn = conn
+	b.js = js
+	return b, nil
+}
+
+func newBroker(publisher streamPublisher, cfg Config, logger *slog.Logger) *Broker {
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+	return &Broker{
+		publisher:     publisher,
+		stream:        cfg.Stream,
+		subjectPrefix: cfg.SubjectPrefix,
+		writeTimeout:  cfg.WriteTimeout,
+		logger:        logger,
+	}
+}
+
+// Deliver publishes msg to its event's subject, and returns once the
+// stream has stored it. A publish under way is finished even when ctx is
+// canceled, so shutting down doesn't abandon it; Close waits for it.
+func (b *Broker) Deliver(ctx context.Context, msg entities.OutboxMessage) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return errors.New("nats broker closed")
+	}
+	b.inFlight.Add(1)
+	b.mu.Unlock()
+	defer b.inFlight.Done()
+
+	m := natsgo.NewMsg(b.subjectPrefix + "." + msg.Event)
+	m.Data = msg.Payload
+	m.Header.Set(jetstream.MsgIDHeader, msg.Key)
+	m.Header.Set("event", msg.Event)
+	m.Header.Set("message_id", msg.ID.String())
+	if msg.ActorID != nil {
+		m.Header.Set("actor_id", msg.ActorID.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), b.writeTimeout)
+	defer cancel()
+	if _, err := b.publisher.PublishMsg(ctx, m); err != nil {
+		return fmt.Errorf("publishing %s event to nats subject %s: %w", msg.Event, m.Subject, err)
+	}
+	return nil
+}
+
+// Consume hands the events in the stream to sink, such as the event bus
+// of a worker, until ctx is done; events already received are handled
+// before it returns. Consumers are durable: each name sees every event,
+// shared among the processes consuming under it, and picks up where it
+// left off. Delivery is at least once, and the event key in the context
+// tells repeats. An event the sink fails to handle is redelivered with
+// backoff; one that can't be read is dropped.
+func (b *Broker) Consume(ctx context.Context, name string, sink events.Sink) error {
+	if b.js == nil {
+		return errors.New("nats broker not connected")
+	}
+	consumer, err := b.js.CreateOrUpdateConsumer(ctx, b.stream, jetstream.ConsumerConfig{
+		Durable:       name,
+		FilterSubject: b.subjectPrefix + ".>",
+		AckPolicy:     jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("creating consumer %s: %w", name, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(m jetstream.Msg) {
+		b.handle(context.WithoutCancel(ctx), m, sink)
+	})
+	if err != nil {
+		return fmt.Errorf("consuming %s: %w", name, err)
+	}
+	<-ctx.Done()
+	consumeCtx.Drain()
+	<-consumeCtx.Closed()
+	return nil
+}
+
+// handle hands m to sink, and acknowledges it once handled.
+func (b *Broker) handle(ctx context.Context, m jetstream.Msg, sink events.Sink) {
+	msg, err := b.outboxMessage(m)
+	if err != nil {
+		b.logger.ErrorContext(ctx, "dropping unreadable event", "subject", m.Subject(), "error", err)
+		if err := m.Term(); err != nil {
+			b.logger.ErrorContext(ctx, "failed to drop event", "subject", m.Subject(), "error", err)
+		}
+		return
+	}
+
+	if err := sink.Deliver(ctx, msg); err != nil {
+		var delivered uint64 = 1
+		if meta, err := m.Metadata(); err == nil {
+			delivered = meta.NumDelivered
+		}
+		b.logger.ErrorContext(ctx, "failed to handle event", "event", msg.Event, "key", msg.Key, "deliveries", delivered, "error", err)
+		if err := m.NakWithDelay(backoff(delivered)); err != nil {
+			b.logger.ErrorContext(ctx, "failed to retry event", "event", msg.Event, "key", msg.Key, "error", err)
+		}
+		return
+	}
+	if err := m.Ack(); err != nil {
+		b.logger.ErrorContext(ctx, "failed to acknowledge event", "event", msg.Event, "key", msg.Key, "error", err)
+	}
+}
+
+// outboxMessage reads back the outbox message Deliver published as m.
+func (b *Broker) outboxMessage(m jetstream.Msg) (entities.OutboxMessage, error) {
+	headers := m.Headers()
+	event := headers.Get("event")
+	if event == "" {
+		event = strings.TrimPrefix(m.Subject(), b.subjectPrefix+".")
+	}
+	msg := entities.OutboxMessage{
+		Event:   event,
+		Key:     headers.Get(jetstream.MsgIDHeader),
+		Payload: m.Data(),
+	}
+
+	var err error
+	if id := headers.Get("message_id"); id != "" {
+		if msg.ID, err = uuid.FromString(id); err != nil {
+			return msg, fmt.Errorf("parsing message ID: %w", err)
+		}
+	}
+	if actor := headers.Get("actor_id"); actor != "" {
+		actorID, err := uuid.FromString(actor)
+		if err != nil {
+			return msg, fmt.Errorf("parsing actor ID: %w", err)
+		}
+		msg.ActorID = &actorID
+	}
+	if meta, err := m.Metadata(); err == nil {
+		msg.CreatedAt = meta.Timestamp
+	}
+	return msg, nil
+}
+
+// backoff is how long to wait before redelivering a message delivered
+// the given number of times.
+func backoff(delivered uint64) time.Duration {
+	wait := retryBackoff
+	for i := uint64(1); i < delivered && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
+
+// Ping makes a round trip to the NATS server.
+func (b *Broker) Ping(ctx context.Context) error {
+	if b.conn == nil {
+		return nil
+	}
+	if err := b.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("pinging nats: %w", err)
+	}
+	return nil
+}
+
+// Close refuses new events, waits for the publishes under way until ctx
+// is done, then drains the connection. Events whose publish was cut short
+// stay in the outbox and are relayed again.
+func (b *Broker) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+	}
+	if b.conn == nil {
+		return nil
+	}
+	return b.conn.Drain()
+}
@@ -0,0 +1,177 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePublisher keeps the messages published.
+type fakePublisher struct {
+	msgs []*natsgo.Msg
+	err  error
+}
+
+func (p *fakePublisher) PublishMsg(ctx context.Context, msg *natsgo.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.msgs = append(p.msgs, msg)
+	return &jetstream.PubAck{}, nil
+}
+
+// fakeMsg is a message received from the stream, recording how it was
+// acknowledged.
+type fakeMsg struct {
+	jetstream.Msg
+	subject   string
+	data      []byte
+	headers   natsgo.Header
+	delivered uint64
+
+	acked    bool
+	termed   bool
+	nakDelay time.Duration
+}
+
+func (m *fakeMsg) Subject() string        { return m.subject }
+func (m *fakeMsg) Data() []byte           { return m.data }
+func (m *fakeMsg) Headers() natsgo.Header { return m.headers }
+func (m *fakeMsg) Ack() error             { m.acked = true; return nil }
+func (m *fakeMsg) Term() error            { m.termed = true; return nil }
+func (m *fakeMsg) NakWithDelay(delay time.Duration) error {
+	m.nakDelay = delay
+	return nil
+}
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumDelivered: m.delivered, Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}, nil
+}
+
+// sinkFunc lets a function be an events.Sink.
+type sinkFunc func(ctx context.Context, msg entities.OutboxMessage) error
+
+func (f sinkFunc) Deliver(ctx context.Context, msg entities.OutboxMessage) error {
+	return f(ctx, msg)
+}
+
+func newTestBroker(publisher streamPublisher) *Broker {
+	return newBroker(publisher, Config{Stream: "EVENTS", SubjectPrefix: "events"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func newMessage(event string) entities.OutboxMessage {
+	actor := uuid.Must(uuid.NewV4())
+	return entities.OutboxMessage{
+		ID:      uuid.Must(uuid.NewV4()),
+		Sink:    "nats",
+		Event:   event,
+		Key:     event + ":1",
+		Payload: []byte(`{"User":{"email":"ada@example.com"}}`),
+		ActorID: &actor,
+	}
+}
+
+func TestBroker_Deliver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("publishes to the event's subject", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		msg := newMessage("user.created")
+		require.NoError(t, newTestBroker(publisher).Deliver(ctx, msg))
+
+		require.Len(t, publisher.msgs, 1)
+		published := publisher.msgs[0]
+		assert.Equal(t, "events.user.created", published.Subject)
+		assert.JSONEq(t, string(msg.Payload), string(published.Data))
+		assert.Equal(t, "user.created:1", published.Header.Get(jetstream.MsgIDHeader), "repeats are dropped by key")
+		assert.Equal(t, "user.created", published.Header.Get("event"))
+		assert.Equal(t, msg.ID.String(), published.Header.Get("message_id"))
+		assert.Equal(t, msg.ActorID.String(), published.Header.Get("actor_id"))
+	})
+
+	t.Run("returns publish errors", func(t *testing.T) {
+		unavailable := errors.New("no responders")
+		err := newTestBroker(&fakePublisher{err: unavailable}).Deliver(ctx, newMessage("user.created"))
+		assert.ErrorIs(t, err, unavailable)
+	})
+
+	t.Run("refuses events once closed", func(t *testing.T) {
+		b := newTestBroker(&fakePublisher{})
+		require.NoError(t, b.Close(ctx))
+		assert.Error(t, b.Deliver(ctx, newMessage("user.created")))
+	})
+}
+
+func TestBroker_handle(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBroker(&fakePublisher{})
+	newReceived := func(msg entities.OutboxMessage, delivered uint64) *fakeMsg {
+		return &fakeMsg{
+			subject: "events." + msg.Event,
+			data:    msg.Payload,
+			headers: natsgo.Header{
+				"event":               {msg.Event},
+				"message_id":          {msg.ID.String()},
+				"actor_id":            {msg.ActorID.String()},
+				jetstream.MsgIDHeader: {msg.Key},
+			},
+			delivered: delivered,
+		}
+	}
+
+	t.Run("hands the event to the sink", func(t *testing.T) {
+		msg := newMessage("user.created")
+		received := newReceived(msg, 1)
+
+		var got entities.OutboxMessage
+		b.handle(ctx, received, sinkFunc(func(ctx context.Context, m entities.OutboxMessage) error {
+			got = m
+			return nil
+		}))
+
+		assert.True(t, received.acked)
+		assert.Equal(t, msg.ID, got.ID)
+		assert.Equal(t, msg.Event, got.Event)
+		assert.Equal(t, msg.Key, got.Key)
+		assert.Equal(t, msg.ActorID, got.ActorID)
+		assert.JSONEq(t, string(msg.Payload), string(got.Payload))
+		assert.False(t, got.CreatedAt.IsZero())
+	})
+
+	t.Run("redelivers events the sink fails with backoff", func(t *testing.T) {
+		received := newReceived(newMessage("user.created"), 3)
+		b.handle(ctx, received, sinkFunc(func(ctx context.Context, m entities.OutboxMessage) error {
+			return errors.New("failed")
+		}))
+
+		assert.False(t, received.acked)
+		assert.Equal(t, 40*time.Second, received.nakDelay)
+	})
+
+	t.Run("drops unreadable events", func(t *testing.T) {
+		received := newReceived(newMessage("user.created"), 1)
+		received.headers.Set("actor_id", "nobody")
+		b.handle(ctx, received, sinkFunc(func(ctx context.Context, m entities.OutboxMessage) error {
+			t.Fatal("unreadable event handed to the sink")
+			return nil
+		}))
+
+		assert.True(t, received.termed)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, retryBackoff, backoff(0))
+	assert.Equal(t, retryBackoff, backoff(1))
+	assert.Equal(t, 2*retryBackoff, backoff(2))
+	assert.Equal(t, maxRetryBackoff, backoff(100))
+}
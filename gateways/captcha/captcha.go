@@ -0,0 +1,88 @@
+// Package captcha verifies CAPTCHA responses with hCaptcha or Cloudflare
+// Turnstile.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported providers
+const (
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderTurnstile = "turnstile"
+)
+
+const (
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+// Verifier checks widget responses against a provider's siteverify
+// endpoint. hCaptcha and Turnstile share the same request and response shape.
+type Verifier struct {
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+// New creates a verifier for provider with the site's secret key.
+func New(provider, secret string) (*Verifier, error) {
+	var verifyURL string
+	switch provider {
+	case ProviderHCaptcha:
+		verifyURL = hcaptchaVerifyURL
+	case ProviderTurnstile:
+		verifyURL = turnstileVerifyURL
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider: %s", provider)
+	}
+	return &Verifier{
+		secret:    secret,
+		verifyURL: verifyURL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Verify reports whether token is a valid, unused widget response.
+func (v *Verifier) Verify(ctx context.Context, token string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return false, fmt.Errorf("captcha provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+	// A wrong secret is a configuration problem, not a failed challenge
+	for _, code := range result.ErrorCodes {
+		if code == "invalid-input-secret" || code == "missing-input-secret" {
+			return false, fmt.Errorf("captcha provider rejected the secret: %s", code)
+		}
+	}
+	return result.Success, nil
+}
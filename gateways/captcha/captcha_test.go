@@ -0,0 +1,53 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVerifier(t *testing.T, handler http.HandlerFunc) *Verifier {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	v, err := New(ProviderTurnstile, "secret")
+	require.NoError(t, err)
+	v.verifyURL = srv.URL
+	return v
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	v := newTestVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.Form.Get("secret"))
+		json.NewEncoder(w).Encode(map[string]any{"success": r.Form.Get("response") == "good"})
+	})
+
+	ok, err := v.Verify(context.Background(), "good")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = v.Verify(context.Background(), "bad")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerifier_Verify_InvalidSecret(t *testing.T) {
+	v := newTestVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error-codes": []string{"invalid-input-secret"}})
+	})
+
+	_, err := v.Verify(context.Background(), "good")
+	assert.ErrorContains(t, err, "invalid-input-secret")
+}
+
+func TestNew_UnsupportedProvider(t *testing.T) {
+	_, err := New("recaptcha", "secret")
+	assert.Error(t, err)
+}
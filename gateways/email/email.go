@@ -0,0 +1,72 @@
+// Package email delivers emails through mail servers and email APIs.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"time"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/sender.go . Sender
+
+// Message is an email ready to send. HTML is optional; Text is always sent,
+// as the alternative for clients that don't show HTML.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Sender sends messages. Every driver implements it.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ErrTemporary marks failures worth retrying, such as a server that is
+// unavailable or rate limiting. Drivers wrap them with it; other failures,
+// such as a rejected address, would fail again.
+var ErrTemporary = errors.New("temporary email failure")
+
+func temporary(err error) error {
+	return fmt.Errorf("%w: %w", ErrTemporary, err)
+}
+
+// Retry sends through a driver, trying again after temporary failures.
+type Retry struct {
+	sender   Sender
+	attempts int
+	backoff  time.Duration
+}
+
+// NewRetry makes up to attempts tries at each email, waiting backoff before
+// the second and twice as long before each next one.
+func NewRetry(sender Sender, attempts int, backoff time.Duration) *Retry {
+	return &Retry{
+		sender:   sender,
+		attempts: max(attempts, 1),
+		backoff:  backoff,
+	}
+}
+
+func (r *Retry) Send(ctx context.Context, msg Message) error {
+	wait := r.backoff
+	for attempt := 1; ; attempt++ {
+		err := r.sender.Send(ctx, msg)
+		if err == nil || !errors.Is(err, ErrTemporary) || attempt == r.attempts {
+			return err
+		}
+		domain.Logger(ctx).WarnContext(ctx, "email not sent, retrying", "attempt", attempt, "error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
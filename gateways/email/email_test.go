@@ -0,0 +1,60 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingSender fails its first failures sends with err.
+type failingSender struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (s *failingSender) Send(ctx context.Context, msg Message) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func TestRetry_Send(t *testing.T) {
+	ctx := context.Background()
+	msg := Message{To: "a@example.com", Subject: "Hi", Text: "Hello"}
+
+	t.Run("retries temporary failures", func(t *testing.T) {
+		sender := &failingSender{failures: 5, err: temporary(errors.New("unavailable"))}
+		err := NewRetry(sender, 3, time.Millisecond).Send(ctx, msg)
+		assert.ErrorIs(t, err, ErrTemporary)
+		assert.Equal(t, 3, sender.calls)
+	})
+
+	t.Run("stops once sent", func(t *testing.T) {
+		sender := &failingSender{failures: 1, err: temporary(errors.New("unavailable"))}
+		require.NoError(t, NewRetry(sender, 3, time.Millisecond).Send(ctx, msg))
+		assert.Equal(t, 2, sender.calls)
+	})
+
+	t.Run("gives up on permanent failures", func(t *testing.T) {
+		sender := &failingSender{failures: 5, err: errors.New("rejected")}
+		err := NewRetry(sender, 3, time.Millisecond).Send(ctx, msg)
+		assert.EqualError(t, err, "rejected")
+		assert.Equal(t, 1, sender.calls)
+	})
+
+	t.Run("stops waiting when canceled", func(t *testing.T) {
+		sender := &failingSender{failures: 5, err: temporary(errors.New("unavailable"))}
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := NewRetry(sender, 3, time.Hour).Send(ctx, msg)
+		assert.ErrorIs(t, err, ErrTemporary)
+		assert.Equal(t, 1, sender.calls)
+	})
+}
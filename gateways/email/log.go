@@ -0,0 +1,19 @@
+package email
+
+import (
+	"context"
+	"go-template/domain"
+)
+
+// Log writes emails to the log instead of sending them. It is meant for
+// local development, where no mail server is available.
+type Log struct{}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (Log) Send(ctx context.Context, msg Message) error {
+	domain.Logger(ctx).InfoContext(ctx, "email not sent, logging instead", "to", msg.To, "subject", msg.Subject, "body", msg.Text)
+	return nil
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+)
+
+// Mailer renders transactional emails with Templates and sends them with a
+// driver. The domains send their emails through it.
+type Mailer struct {
+	sender    Sender
+	templates *Templates
+	queue     Enqueuer
+}
+
+// Enqueuer stores jobs for the background workers.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, args job.Args) (entities.Job, error)
+}
+
+func NewMailer(sender Sender, templates *Templates) *Mailer {
+	return &Mailer{
+		sender:    sender,
+		templates: templates,
+	}
+}
+
+// SetQueue sends emails from the background workers rather than in the
+// request: SendEmail renders them and queues a SendJob, which the workers
+// run with Deliver.
+func (m *Mailer) SetQueue(queue Enqueuer) {
+	m.queue = queue
+}
+
+// SendJob is a rendered email a worker sends.
+type SendJob struct {
+	Message Message `json:"message"`
+}
+
+func (SendJob) JobKind() string { return "email.send" }
+
+func (m *Mailer) SendEmail(ctx context.Context, e entities.Email) error {
+	rendered, err := m.templates.Render(e.Template, e.Locale, e.Data)
+	if err != nil {
+		return err
+	}
+	msg := Message{
+		To:      e.To,
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	}
+	if m.queue != nil {
+		if _, err := m.queue.Enqueue(ctx, SendJob{Message: msg}); err != nil {
+			return fmt.Errorf("queueing %s email: %w", e.Template, err)
+		}
+		return nil
+	}
+	return m.sender.Send(ctx, msg)
+}
+
+// Deliver sends a queued email. Only temporary failures are tried again.
+func (m *Mailer) Deliver(ctx context.Context, j SendJob) error {
+	err := m.sender.Send(ctx, j.Message)
+	if err != nil && !errors.Is(err, ErrTemporary) {
+		return fmt.Errorf("%w: %w", job.ErrPermanent, err)
+	}
+	return err
+}
+
+// PreviewEmail renders the email with its sample data, as designers see it
+// in the admin app. It returns domain.ErrNotFound for unknown emails.
+func (m *Mailer) PreviewEmail(name entities.EmailTemplate, locale string) (entities.RenderedEmail, error) {
+	rendered, err := m.templates.Render(name, locale, Sample(name))
+	if err != nil {
+		return entities.RenderedEmail{}, fmt.Errorf("previewing %s: %w", name, err)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,57 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"go-template/domain/job"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queueFunc func(ctx context.Context, args job.Args) (entities.Job, error)
+
+func (f queueFunc) Enqueue(ctx context.Context, args job.Args) (entities.Job, error) {
+	return f(ctx, args)
+}
+
+func TestMailer_Queue(t *testing.T) {
+	ctx := context.Background()
+	templates, err := NewTemplates("", "Acme")
+	require.NoError(t, err)
+	email := entities.Email{
+		To:       "ada@example.com",
+		Template: entities.EmailPasswordReset,
+		Data:     map[string]any{"Link": "https://app.example.com/reset", "ExpiresInMinutes": 30},
+	}
+
+	sender := &failingSender{}
+	mailer := NewMailer(sender, templates)
+	var queued []job.Args
+	mailer.SetQueue(queueFunc(func(ctx context.Context, args job.Args) (entities.Job, error) {
+		queued = append(queued, args)
+		return entities.Job{}, nil
+	}))
+
+	require.NoError(t, mailer.SendEmail(ctx, email))
+	assert.Zero(t, sender.calls, "sent by a worker")
+	require.Len(t, queued, 1)
+	sendJob := queued[0].(SendJob)
+	assert.Equal(t, "ada@example.com", sendJob.Message.To)
+	assert.Equal(t, "Reset your password", sendJob.Message.Subject, "rendered when queued")
+
+	require.NoError(t, mailer.Deliver(ctx, sendJob))
+	assert.Equal(t, 1, sender.calls)
+
+	t.Run("only temporary failures are tried again", func(t *testing.T) {
+		mailer := NewMailer(&failingSender{failures: 1, err: temporary(errors.New("unavailable"))}, templates)
+		err := mailer.Deliver(ctx, sendJob)
+		assert.ErrorIs(t, err, ErrTemporary)
+		assert.NotErrorIs(t, err, job.ErrPermanent)
+
+		mailer = NewMailer(&failingSender{failures: 1, err: errors.New("mailbox not found")}, templates)
+		assert.ErrorIs(t, mailer.Deliver(ctx, sendJob), job.ErrPermanent)
+	})
+}
@@ -0,0 +1,48 @@
+package email
+
+import (
+	"go-template/domain/entities"
+	"time"
+)
+
+// samples are the values each email is previewed with. They must have
+// every key the domains send, since templates fail on missing keys.
+var samples = map[entities.EmailTemplate]map[string]any{
+	entities.EmailWelcome: {
+		"Link":           "https://app.example.com/verify-email?token=sample",
+		"ExpiresInHours": 24,
+	},
+	entities.EmailVerification: {
+		"Link":           "https://app.example.com/verify-email?token=sample",
+		"ExpiresInHours": 24,
+	},
+	entities.EmailPasswordReset: {
+		"Link":             "https://app.example.com/reset-password?token=sample",
+		"ExpiresInMinutes": 60,
+	},
+	entities.EmailChange: {
+		"Link":             "https://app.example.com/confirm-email?token=sample",
+		"ExpiresInMinutes": 60,
+	},
+	entities.EmailChanged: {
+		"NewEmail": "new.address@example.com",
+	},
+	entities.EmailInvitation: {
+		"Link":      "https://app.example.com/accept-invitation?token=sample",
+		"ExpiresAt": time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC),
+	},
+	entities.EmailRegistrationApproved: {},
+	entities.EmailRegistrationDeclined: {
+		"Reason": "We couldn't confirm your company.",
+	},
+	entities.EmailNotification: {
+		"Title": "Your export is ready",
+		"Body":  "The users export you asked for has 1,204 rows.",
+		"Link":  "https://app.example.com/exports/sample",
+	},
+}
+
+// Sample returns the values the email is previewed with.
+func Sample(name entities.EmailTemplate) map[string]any {
+	return samples[name]
+}
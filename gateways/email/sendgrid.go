@@ -0,0 +1,113 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+const sendGridAPIURL = "https://api.sendgrid.com"
+
+// SendGrid sends emails with the SendGrid v3 Mail Send API.
+type SendGrid struct {
+	apiKey string
+	from   *mail.Address
+	apiURL string
+	client *http.Client
+}
+
+// NewSendGrid creates a sender for the API key. from must be a verified
+// sender of the account.
+func NewSendGrid(apiKey, from string) (*SendGrid, error) {
+	if apiKey == "" {
+		return nil, errors.New("sendgrid email needs an API key")
+	}
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	return &SendGrid{
+		apiKey: apiKey,
+		from:   addr,
+		apiURL: sendGridAPIURL,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridMessage struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (s *SendGrid) Send(ctx context.Context, msg Message) error {
+	// SendGrid wants the plain text first
+	content := []sendGridContent{{Type: "text/plain", Value: msg.Text}}
+	if msg.HTML != "" {
+		content = append(content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+	payload, err := json.Marshal(sendGridMessage{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: s.from.Address, Name: s.from.Name},
+		Subject:          msg.Subject,
+		Content:          content,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+"/v3/mail/send", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return temporary(fmt.Errorf("failed to send email: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		err := sendGridError(resp)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return temporary(err)
+		}
+		return err
+	}
+	return nil
+}
+
+func sendGridError(resp *http.Response) error {
+	var apiErr struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(body, &apiErr) == nil && len(apiErr.Errors) > 0 {
+		return fmt.Errorf("sendgrid returned status %d: %s", resp.StatusCode, apiErr.Errors[0].Message)
+	}
+	return fmt.Errorf("sendgrid returned status %d", resp.StatusCode)
+}
@@ -0,0 +1,57 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendGrid_Send(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mail/send", r.URL.Path)
+		assert.Equal(t, "Bearer SG.key", r.Header.Get("Authorization"))
+
+		var msg sendGridMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, sendGridAddress{Email: "no-reply@example.com", Name: "Go Template"}, msg.From)
+		require.Len(t, msg.Personalizations, 1)
+		assert.Equal(t, []sendGridAddress{{Email: "ada@example.com"}}, msg.Personalizations[0].To)
+		assert.Equal(t, "Hi", msg.Subject)
+		assert.Equal(t, []sendGridContent{{Type: "text/plain", Value: "Hello"}}, msg.Content)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sg, err := NewSendGrid("SG.key", "Go Template <no-reply@example.com>")
+	require.NoError(t, err)
+	sg.apiURL = srv.URL
+
+	require.NoError(t, sg.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}))
+}
+
+func TestSendGrid_Send_Errors(t *testing.T) {
+	status := http.StatusBadRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"message": "The from address does not match a verified Sender Identity."}}})
+	}))
+	defer srv.Close()
+
+	sg, err := NewSendGrid("SG.key", "no-reply@example.com")
+	require.NoError(t, err)
+	sg.apiURL = srv.URL
+
+	err = sg.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTemporary)
+	assert.Contains(t, err.Error(), "verified Sender Identity")
+
+	status = http.StatusTooManyRequests
+	assert.ErrorIs(t, sg.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}), ErrTemporary)
+}
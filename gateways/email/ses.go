@@ -0,0 +1,88 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// SESConfig locates the SES account. Without AccessKeyID the default AWS
+// credential chain is used. Endpoint is for tests and SES compatible
+// services.
+type SESConfig struct {
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	From            string
+}
+
+// SES sends emails with the Amazon SES v2 API.
+type SES struct {
+	client *sesv2.Client
+	from   string
+}
+
+// NewSES creates the account's client. cfg.From must be a verified identity
+// of the account.
+func NewSES(ctx context.Context, cfg SESConfig) (*SES, error) {
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	opts := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	client := sesv2.NewFromConfig(awsCfg, func(o *sesv2.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		// Failures are retried by Retry, the same way for every driver
+		o.RetryMaxAttempts = 1
+	})
+	return &SES{client: client, from: cfg.From}, nil
+}
+
+func (s *SES) Send(ctx context.Context, msg Message) error {
+	body := &types.Body{
+		Text: &types.Content{Data: aws.String(msg.Text), Charset: aws.String("UTF-8")},
+	}
+	if msg.HTML != "" {
+		body.Html = &types.Content{Data: aws.String(msg.HTML), Charset: aws.String("UTF-8")}
+	}
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(s.from),
+		Destination:      &types.Destination{ToAddresses: []string{msg.To}},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(msg.Subject), Charset: aws.String("UTF-8")},
+				Body:    body,
+			},
+		},
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to send email: %w", err)
+		var throttled *types.TooManyRequestsException
+		if errors.As(err, &throttled) || retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary {
+			return temporary(err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSES(t *testing.T, handler http.HandlerFunc) *SES {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s, err := NewSES(context.Background(), SESConfig{
+		Region:          "us-east-1",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		From:            "no-reply@example.com",
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestSES_Send(t *testing.T) {
+	s := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=AKID/")
+
+		var body struct {
+			FromEmailAddress string
+			Destination      struct{ ToAddresses []string }
+			Content          struct {
+				Simple struct {
+					Subject struct{ Data string }
+					Body    struct{ Text struct{ Data string } }
+				}
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "no-reply@example.com", body.FromEmailAddress)
+		assert.Equal(t, []string{"ada@example.com"}, body.Destination.ToAddresses)
+		assert.Equal(t, "Hi", body.Content.Simple.Subject.Data)
+		assert.Equal(t, "Hello", body.Content.Simple.Body.Text.Data)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"MessageId":"m1"}`))
+	})
+
+	require.NoError(t, s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}))
+}
+
+func TestSES_Send_Errors(t *testing.T) {
+	reply := func(status int, errType string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Amzn-ErrorType", errType)
+			w.WriteHeader(status)
+			w.Write([]byte(`{"message":"nope"}`))
+		}
+	}
+
+	s := newTestSES(t, reply(http.StatusBadRequest, "MessageRejected"))
+	err := s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTemporary)
+
+	s = newTestSES(t, reply(http.StatusTooManyRequests, "TooManyRequestsException"))
+	assert.ErrorIs(t, s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}), ErrTemporary)
+
+	s = newTestSES(t, reply(http.StatusServiceUnavailable, "InternalFailure"))
+	assert.ErrorIs(t, s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}), ErrTemporary)
+}
@@ -0,0 +1,176 @@
+package email
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP accepts one message per connection, answering RCPT with
+// rcptReply.
+type fakeSMTP struct {
+	listener  net.Listener
+	rcptReply string
+	messages  chan string
+}
+
+func newFakeSMTP(t *testing.T, rcptReply string) *fakeSMTP {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeSMTP{listener: l, rcptReply: rcptReply, messages: make(chan string, 1)}
+	go f.serve()
+	t.Cleanup(func() { l.Close() })
+	return f
+}
+
+func (f *fakeSMTP) port() int {
+	return f.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSMTP) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(textproto.NewConn(conn))
+	}
+}
+
+func (f *fakeSMTP) handle(c *textproto.Conn) {
+	defer c.Close()
+	c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO", "HELO":
+			c.PrintfLine("250-localhost")
+			c.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			c.PrintfLine("250 OK")
+		case "RCPT":
+			c.PrintfLine("%s", f.rcptReply)
+		case "DATA":
+			c.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(c.DotReader())
+			if err != nil {
+				return
+			}
+			f.messages <- string(data)
+			c.PrintfLine("250 queued")
+		case "QUIT":
+			c.PrintfLine("221 bye")
+			return
+		default:
+			c.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	server := newFakeSMTP(t, "250 OK")
+	s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "Go Template <no-reply@example.com>"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Réinitialiser", Text: "Hello,\nclick the link.\n"}))
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-server.messages))
+	require.NoError(t, err)
+	assert.Equal(t, `"Go Template" <no-reply@example.com>`, msg.Header.Get("From"))
+	assert.Equal(t, "<ada@example.com>", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Réinitialiser", subject)
+	assert.Equal(t, "quoted-printable", msg.Header.Get("Content-Transfer-Encoding"))
+	assert.Contains(t, msg.Header.Get("Message-ID"), "@example.com>")
+}
+
+func TestSMTP_Send_HTML(t *testing.T) {
+	server := newFakeSMTP(t, "250 OK")
+	s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "no-reply@example.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), Message{To: "ada@example.com", Subject: "Hi", Text: "Hello", HTML: "<p>Hello</p>"}))
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-server.messages))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", "Hello"},
+		{"text/html; charset=utf-8", "<p>Hello</p>"},
+	} {
+		part, err := parts.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, want.contentType, part.Header.Get("Content-Type"))
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, want.body, string(body))
+	}
+}
+
+func TestSMTP_Send_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deferred", func(t *testing.T) {
+		server := newFakeSMTP(t, "451 try again later")
+		s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "no-reply@example.com"})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, s.Send(ctx, Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}), ErrTemporary)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		server := newFakeSMTP(t, "550 no such user")
+		s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "no-reply@example.com"})
+		require.NoError(t, err)
+
+		err = s.Send(ctx, Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTemporary)
+		assert.Contains(t, err.Error(), "no such user")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := l.Addr().(*net.TCPAddr).Port
+		l.Close()
+		s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: port, From: "no-reply@example.com"})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, s.Send(ctx, Message{To: "ada@example.com", Subject: "Hi", Text: "Hello"}), ErrTemporary)
+	})
+
+	t.Run("header injection", func(t *testing.T) {
+		s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: 25, From: "no-reply@example.com"})
+		require.NoError(t, err)
+
+		assert.Error(t, s.Send(ctx, Message{To: "ada@example.com", Subject: "Hi\r\nBcc: eve@example.com", Text: "Hello"}))
+		assert.Error(t, s.Send(ctx, Message{To: "not an address", Subject: "Hi", Text: "Hello"}))
+	})
+}
+
+func TestNewSMTP(t *testing.T) {
+	_, err := NewSMTP(SMTPConfig{Port: 25, From: "no-reply@example.com"})
+	assert.Error(t, err)
+	_, err = NewSMTP(SMTPConfig{Host: "smtp.example.com", Port: 25, From: "nope"})
+	assert.Error(t, err)
+	_, err = NewSMTP(SMTPConfig{Host: "smtp.example.com", Port: 587, From: "no-reply@example.com"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,212 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+// Templates renders transactional emails. Each email is a <name>.txt file
+// defining its "subject" and "text", and optionally a <name>.html file
+// defining its "html"; layout.txt and layout.html wrap them. Translations
+// are the same files under a directory named after the language tag, such
+// as pt-BR/ or pt/, and any file missing there comes from the default
+// language.
+type Templates struct {
+	fsys    fs.FS
+	appName string
+}
+
+// NewTemplates loads the built-in templates. Files in dir, when set,
+// replace the built-in ones of the same path, so designers can change
+// emails without a new build. Every email is rendered once with its sample
+// data, so broken templates fail at startup.
+func NewTemplates(dir, appName string) (*Templates, error) {
+	base, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+	fsys := base
+	if dir != "" {
+		fsys = overlayFS{upper: os.DirFS(dir), lower: base}
+	}
+	return newTemplates(fsys, appName)
+}
+
+func newTemplates(fsys fs.FS, appName string) (*Templates, error) {
+	t := &Templates{fsys: fsys, appName: appName}
+	for _, name := range entities.EmailTemplates {
+		if _, err := t.Render(name, "", Sample(name)); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Render renders the email in the locale's language. Data is given to the
+// templates with AppName and Locale added; templates fail on keys data
+// doesn't have. It returns domain.ErrNotFound for unknown emails.
+func (t *Templates) Render(name entities.EmailTemplate, locale string, data map[string]any) (entities.RenderedEmail, error) {
+	if !knownTemplate(name) {
+		return entities.RenderedEmail{}, fmt.Errorf("email template %q: %w", name, domain.ErrNotFound)
+	}
+
+	values := make(map[string]any, len(data)+3)
+	for k, v := range data {
+		values[k] = v
+	}
+	values["AppName"] = t.appName
+	values["Locale"] = locale
+
+	text, err := t.parseText(name, locale)
+	if err != nil {
+		return entities.RenderedEmail{}, err
+	}
+	subject, err := executeText(text, "subject", values)
+	if err != nil {
+		return entities.RenderedEmail{}, fmt.Errorf("rendering %s subject: %w", name, err)
+	}
+	subject = strings.Join(strings.Fields(subject), " ")
+	values["Subject"] = subject
+
+	body, err := executeText(text, "layout", values)
+	if err != nil {
+		return entities.RenderedEmail{}, fmt.Errorf("rendering %s text: %w", name, err)
+	}
+
+	rendered := entities.RenderedEmail{
+		Template: name,
+		Locale:   locale,
+		Subject:  subject,
+		Text:     strings.TrimSpace(body) + "\n",
+	}
+
+	html, err := t.parseHTML(name, locale)
+	if err != nil {
+		return entities.RenderedEmail{}, err
+	}
+	if html != nil {
+		var buf bytes.Buffer
+		if err := html.ExecuteTemplate(&buf, "layout", values); err != nil {
+			return entities.RenderedEmail{}, fmt.Errorf("rendering %s html: %w", name, err)
+		}
+		rendered.HTML = buf.String()
+	}
+	return rendered, nil
+}
+
+func (t *Templates) parseText(name entities.EmailTemplate, locale string) (*texttemplate.Template, error) {
+	layout, err := t.read("layout.txt", locale)
+	if err != nil {
+		return nil, err
+	}
+	src, err := t.read(string(name)+".txt", locale)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := texttemplate.New("layout").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return nil, fmt.Errorf("parsing layout.txt: %w", err)
+	}
+	if _, err := tmpl.New(string(name) + ".txt").Parse(src); err != nil {
+		return nil, fmt.Errorf("parsing %s.txt: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// parseHTML returns nil when the email has no HTML version.
+func (t *Templates) parseHTML(name entities.EmailTemplate, locale string) (*htmltemplate.Template, error) {
+	src, err := t.read(string(name)+".html", locale)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	layout, err := t.read("layout.html", locale)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := htmltemplate.New("layout").Option("missingkey=error").Parse(layout)
+	if err != nil {
+		return nil, fmt.Errorf("parsing layout.html: %w", err)
+	}
+	if _, err := tmpl.New(string(name) + ".html").Parse(src); err != nil {
+		return nil, fmt.Errorf("parsing %s.html: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// read returns the file in the locale's language: pt-BR/file, then
+// pt/file, then file.
+func (t *Templates) read(file, locale string) (string, error) {
+	for _, dir := range localeDirs(locale) {
+		b, err := fs.ReadFile(t.fsys, path.Join(dir, file))
+		if err == nil {
+			return string(b), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("email template %s: %w", file, fs.ErrNotExist)
+}
+
+// localeDirs lists where a locale's files are looked for, most specific
+// first. Tags that aren't plain language tags only get the default.
+func localeDirs(locale string) []string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" || !fs.ValidPath(locale) || strings.ContainsAny(locale, "/.") {
+		return []string{"."}
+	}
+	dirs := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		dirs = append(dirs, lang)
+	}
+	return append(dirs, ".")
+}
+
+func executeText(tmpl *texttemplate.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func knownTemplate(name entities.EmailTemplate) bool {
+	for _, known := range entities.EmailTemplates {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
+
+// overlayFS reads files from upper when it has them, and from lower
+// otherwise.
+type overlayFS struct {
+	upper, lower fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.upper.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.lower.Open(name)
+	}
+	return f, err
+}
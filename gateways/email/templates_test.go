@@ -0,0 +1,93 @@
+package email
+
+import (
+	"go-template/domain"
+	"go-template/domain/entities"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	tmpl, err := NewTemplates("", "Acme")
+	require.NoError(t, err)
+
+	got, err := tmpl.Render(entities.EmailPasswordReset, "", map[string]any{
+		"Link":             "https://app.example.com/reset-password?token=abc&x=<y>",
+		"ExpiresInMinutes": 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Reset your password", got.Subject)
+	assert.Contains(t, got.Text, "within 30 minutes:\n\nhttps://app.example.com/reset-password?token=abc&x=<y>\n")
+	assert.Contains(t, got.Text, "\n--\nAcme\n")
+	assert.Contains(t, got.HTML, "<title>Reset your password</title>")
+	assert.Contains(t, got.HTML, `href="https://app.example.com/reset-password?token=abc&amp;x=%3cy%3e"`, "links are escaped in HTML")
+
+	// Verified addresses aren't asked to confirm
+	got, err = tmpl.Render(entities.EmailWelcome, "", map[string]any{"Link": "", "ExpiresInHours": 0})
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to Acme", got.Subject)
+	assert.NotContains(t, got.Text, "confirm")
+	assert.NotContains(t, got.HTML, "Confirm")
+
+	_, err = tmpl.Render(entities.EmailPasswordReset, "", map[string]any{"Link": "https://x"})
+	assert.Error(t, err, "templates fail on missing keys")
+
+	_, err = tmpl.Render("nope", "", nil)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTemplates_Locale(t *testing.T) {
+	base, err := fs.Sub(embedded, "templates")
+	require.NoError(t, err)
+	fsys := overlayFS{
+		upper: fstest.MapFS{
+			"pt/password_reset.txt": {Data: []byte(`{{define "subject"}}Redefina sua senha{{end}}{{define "text"}}Abra {{.Link}}{{end}}`)},
+			"pt-BR/layout.txt":      {Data: []byte(`{{template "text" .}} (pt-BR)`)},
+		},
+		lower: base,
+	}
+	tmpl, err := newTemplates(fsys, "Acme")
+	require.NoError(t, err)
+	data := map[string]any{"Link": "https://x", "ExpiresInMinutes": 60}
+
+	got, err := tmpl.Render(entities.EmailPasswordReset, "pt-BR", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Redefina sua senha", got.Subject, "falls back to the language")
+	assert.Equal(t, "Abra https://x (pt-BR)\n", got.Text, "takes the most specific file")
+	assert.Contains(t, got.HTML, `lang="pt-BR"`)
+	assert.Contains(t, got.HTML, "Choose a new password", "untranslated files come from the default")
+
+	got, err = tmpl.Render(entities.EmailPasswordReset, "pt_PT", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Redefina sua senha", got.Subject)
+
+	for _, locale := range []string{"fr", "../..", "en/../pt"} {
+		got, err = tmpl.Render(entities.EmailPasswordReset, locale, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Reset your password", got.Subject, locale)
+	}
+}
+
+func TestNewTemplates_Dir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "registration_approved.txt"),
+		[]byte(`{{define "subject"}}You're in{{end}}{{define "text"}}Sign in at {{.AppName}}.{{end}}`), 0o644))
+
+	tmpl, err := NewTemplates(dir, "Acme")
+	require.NoError(t, err)
+	got, err := tmpl.Render(entities.EmailRegistrationApproved, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "You're in", got.Subject)
+	assert.Equal(t, "Sign in at Acme.\n\n--\nAcme\n", got.Text)
+
+	// Broken templates fail at startup
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation.txt"), []byte(`{{define "subject"}}{{.Nope}}{{end}}`), 0o644))
+	_, err = NewTemplates(dir, "Acme")
+	assert.Error(t, err)
+}
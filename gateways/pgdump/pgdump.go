@@ -0,0 +1,86 @@
+// Package pgdump dumps Postgres databases with the pg_dump client.
+package pgdump
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// maxStderr is how much of pg_dump's error output is kept for the error
+// returned when it fails.
+const maxStderr = 4096
+
+// Config is where the database to dump is, and the pg_dump binary to run,
+// found in PATH unless it is a path.
+type Config struct {
+	Bin      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+	SSLMode  string
+}
+
+// Dumper dumps the database in pg_dump's custom format, compressed and
+// restored with pg_restore.
+type Dumper struct {
+	cfg Config
+}
+
+func New(cfg Config) *Dumper {
+	if cfg.Bin == "" {
+		cfg.Bin = "pg_dump"
+	}
+	return &Dumper{cfg: cfg}
+}
+
+// Available reports whether the pg_dump binary can be found.
+func (d *Dumper) Available() bool {
+	_, err := exec.LookPath(d.cfg.Bin)
+	return err == nil
+}
+
+// Dump writes the dump to w. The connection is passed through the
+// environment, keeping the password out of the process list.
+func (d *Dumper) Dump(ctx context.Context, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, d.cfg.Bin, "--format=custom", "--no-owner", "--no-privileges")
+	cmd.Env = append(os.Environ(),
+		"PGHOST="+d.cfg.Host,
+		"PGPORT="+d.cfg.Port,
+		"PGUSER="+d.cfg.User,
+		"PGPASSWORD="+d.cfg.Password,
+		"PGDATABASE="+d.cfg.Database,
+		"PGSSLMODE="+d.cfg.SSLMode,
+	)
+	stderr := &tailBuffer{max: maxStderr}
+	cmd.Stdout = w
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("running pg_dump: %w: %s", err, msg)
+		}
+		return fmt.Errorf("running pg_dump: %w", err)
+	}
+	return nil
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	n, _ := b.Buffer.Write(p)
+	if over := b.Len() - b.max; over > 0 {
+		b.Next(over)
+	}
+	return n, nil
+}
@@ -0,0 +1,51 @@
+package pgdump
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePgDump writes a script standing in for pg_dump.
+func fakePgDump(t *testing.T, script string) string {
+	bin := filepath.Join(t.TempDir(), "pg_dump")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755))
+	return bin
+}
+
+func TestDumper_Dump(t *testing.T) {
+	t.Run("writes the dump", func(t *testing.T) {
+		d := New(Config{
+			Bin:      fakePgDump(t, `echo "$PGUSER@$PGHOST:$PGPORT/$PGDATABASE $PGPASSWORD $*"`),
+			Host:     "db",
+			Port:     "5432",
+			User:     "app",
+			Password: "secret",
+			Database: "app",
+		})
+		require.True(t, d.Available())
+
+		var out bytes.Buffer
+		require.NoError(t, d.Dump(context.Background(), &out))
+		assert.Equal(t, "app@db:5432/app secret --format=custom --no-owner --no-privileges\n", out.String())
+	})
+
+	t.Run("fails with pg_dump's error", func(t *testing.T) {
+		d := New(Config{Bin: fakePgDump(t, `echo 'connection refused' >&2; exit 1`)})
+
+		err := d.Dump(context.Background(), &bytes.Buffer{})
+		require.Error(t, err)
+		assert.True(t, strings.HasSuffix(err.Error(), "connection refused"), err.Error())
+	})
+
+	t.Run("reports a missing binary", func(t *testing.T) {
+		d := New(Config{Bin: filepath.Join(t.TempDir(), "pg_dump")})
+		assert.False(t, d.Available())
+	})
+}
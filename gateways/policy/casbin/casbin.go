@@ -0,0 +1,85 @@
+// Package casbin decides authorization requests with a Casbin policy.
+//
+// Callers are matched as "user:<id>", and as "role:<name>" for their account
+// type and each of their roles. The default model allows a request when one
+// of them, or a group they are in, has a matching "p, subject, object,
+// action" line; "*" matches any object or action, and the "owner" subject
+// matches callers acting on what they own:
+//
+//	p, role:admin, users, read
+//	p, owner, example, *
+//	g, user:4b0f8f2e-7c5e-4f3b-9a52-2d7c6e1f0a11, role:support
+package casbin
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"go-template/domain/authz"
+
+	gocasbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/gofrs/uuid/v5"
+)
+
+//go:embed model.conf
+var defaultModel string
+
+// Authorizer is an authz.Authorizer backed by a Casbin enforcer.
+type Authorizer struct {
+	enforcer *gocasbin.Enforcer
+}
+
+// New loads the policy in policyFile, a Casbin CSV file, with the model in
+// modelFile, or the default model when modelFile is empty.
+func New(modelFile, policyFile string) (*Authorizer, error) {
+	var (
+		m   model.Model
+		err error
+	)
+	if modelFile == "" {
+		m, err = model.NewModelFromString(defaultModel)
+	} else {
+		m, err = model.NewModelFromFile(modelFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading casbin model: %w", err)
+	}
+
+	enforcer, err := gocasbin.NewEnforcer(m, fileadapter.NewAdapter(policyFile))
+	if err != nil {
+		return nil, fmt.Errorf("loading casbin policy: %w", err)
+	}
+	return &Authorizer{enforcer: enforcer}, nil
+}
+
+func (a *Authorizer) Authorize(ctx context.Context, req authz.Request) (bool, error) {
+	owner := ""
+	if req.Owner != uuid.Nil {
+		owner = userSubject(req.Owner)
+	}
+
+	for _, sub := range subjects(req.Subject) {
+		allowed, err := a.enforcer.Enforce(sub, req.Object, req.Action, owner)
+		if err != nil {
+			return false, fmt.Errorf("enforcing casbin policy: %w", err)
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func subjects(s authz.Subject) []string {
+	subs := []string{userSubject(s.UserID), "role:" + s.AccountType.String()}
+	for _, role := range s.Roles {
+		subs = append(subs, "role:"+role)
+	}
+	return subs
+}
+
+func userSubject(id uuid.UUID) string {
+	return "user:" + id.String()
+}
@@ -0,0 +1,84 @@
+package casbin
+
+import (
+	"context"
+	"go-template/domain/authz"
+	"go-template/domain/entities"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	supportID := uuid.Must(uuid.NewV4())
+	policy := "p, role:admin, users, read\n" +
+		"p, role:super_admin, *, *\n" +
+		"p, role:billing, invoices, *\n" +
+		"p, owner, example, write\n" +
+		"g, user:" + supportID.String() + ", role:support\n" +
+		"p, role:support, users, read\n"
+	policyFile := filepath.Join(t.TempDir(), "policy.csv")
+	require.NoError(t, os.WriteFile(policyFile, []byte(policy), 0o600))
+
+	a, err := New("", policyFile)
+	require.NoError(t, err)
+
+	userID := uuid.Must(uuid.NewV4())
+	user := authz.Subject{UserID: userID, AccountType: entities.AccountTypeUser}
+
+	tests := []struct {
+		name string
+		req  authz.Request
+		want bool
+	}{
+		{
+			name: "account type role",
+			req:  authz.Request{Subject: authz.Subject{UserID: userID, AccountType: entities.AccountTypeAdmin}, Object: "users", Action: "read"},
+			want: true,
+		},
+		{
+			name: "account type role without the action",
+			req:  authz.Request{Subject: authz.Subject{UserID: userID, AccountType: entities.AccountTypeAdmin}, Object: "users", Action: "write"},
+		},
+		{
+			name: "wildcards",
+			req:  authz.Request{Subject: authz.Subject{UserID: userID, AccountType: entities.AccountTypeSuperAdmin}, Object: "settings", Action: "write"},
+			want: true,
+		},
+		{
+			name: "role from the token",
+			req:  authz.Request{Subject: authz.Subject{UserID: userID, AccountType: entities.AccountTypeUser, Roles: []string{"billing"}}, Object: "invoices", Action: "read"},
+			want: true,
+		},
+		{
+			name: "role granted in the policy",
+			req:  authz.Request{Subject: authz.Subject{UserID: supportID, AccountType: entities.AccountTypeUser}, Object: "users", Action: "read"},
+			want: true,
+		},
+		{
+			name: "owner",
+			req:  authz.Request{Subject: user, Object: "example", Action: "write", Owner: userID},
+			want: true,
+		},
+		{
+			name: "someone else's object",
+			req:  authz.Request{Subject: user, Object: "example", Action: "write", Owner: uuid.Must(uuid.NewV4())},
+		},
+		{
+			name: "no policy",
+			req:  authz.Request{Subject: user, Object: "users", Action: "read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := a.Authorize(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
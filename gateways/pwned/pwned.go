@@ -0,0 +1,70 @@
+// Package pwned checks passwords against the Have I Been Pwned Pwned
+// Passwords range API. Only the first five characters of the password's
+// SHA-1 hash are sent (k-anonymity), and responses are padded so their size
+// doesn't give the prefix away.
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultURL is the public Pwned Passwords API.
+const DefaultURL = "https://api.pwnedpasswords.com"
+
+// Checker looks passwords up in the Pwned Passwords corpus.
+type Checker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// New creates a checker for the range API at baseURL.
+func New(baseURL string) *Checker {
+	return &Checker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Breached reports whether password appears in a known data breach.
+func (c *Checker) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to check password: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned passwords returned status %d", resp.StatusCode)
+	}
+
+	// Each line is SUFFIX:COUNT; padding entries have a count of 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		return count != "0", nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read pwned passwords response: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,63 @@
+package pwned
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+func newTestChecker(t *testing.T, handler http.HandlerFunc) *Checker {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(srv.URL + "/")
+}
+
+func TestChecker_Breached(t *testing.T) {
+	c := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/range/5BAA6", r.URL.Path)
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n%s:9545824\r\n", passwordSuffix)
+	})
+
+	breached, err := c.Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+}
+
+func TestChecker_Breached_NotFound(t *testing.T) {
+	c := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n")
+	})
+
+	breached, err := c.Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestChecker_Breached_Padding(t *testing.T) {
+	c := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s:0\r\n", passwordSuffix)
+	})
+
+	breached, err := c.Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestChecker_Breached_Error(t *testing.T) {
+	c := newTestChecker(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.Breached(context.Background(), "password")
+	assert.ErrorContains(t, err, "503")
+}
@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// AdminPermissionsRepository stores the permission restrictions super admins
+// place on individual admins.
+type AdminPermissionsRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewAdminPermissionsRepository creates a new AdminPermissionsRepository instance.
+func NewAdminPermissionsRepository(db DBTX) *AdminPermissionsRepository {
+	return &AdminPermissionsRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *AdminPermissionsRepository) GetAdminPermissions(ctx context.Context, userID uuid.UUID) (entities.AdminPermissions, error) {
+	row, err := r.queries.GetAdminPermissions(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.AdminPermissions{}, domain.ErrNotFound
+		}
+		return entities.AdminPermissions{}, fmt.Errorf("failed to get admin permissions: %w", err)
+	}
+
+	perms := make([]entities.Permission, len(row.Permissions))
+	for i, p := range row.Permissions {
+		perms[i] = entities.Permission(p)
+	}
+
+	return entities.AdminPermissions{
+		UserID:      row.UserID,
+		Permissions: perms,
+		UpdatedBy:   row.UpdatedBy,
+		UpdatedAt:   &row.UpdatedAt,
+	}, nil
+}
+
+func (r *AdminPermissionsRepository) SaveAdminPermissions(ctx context.Context, permissions entities.AdminPermissions) error {
+	perms := make([]string, len(permissions.Permissions))
+	for i, p := range permissions.Permissions {
+		perms[i] = string(p)
+	}
+
+	updatedAt := time.Now()
+	if permissions.UpdatedAt != nil {
+		updatedAt = *permissions.UpdatedAt
+	}
+
+	if err := r.queries.UpsertAdminPermissions(ctx, permissions.UserID, perms, permissions.UpdatedBy, updatedAt); err != nil {
+		return fmt.Errorf("failed to save admin permissions: %w", err)
+	}
+	return nil
+}
+
+func (r *AdminPermissionsRepository) DeleteAdminPermissions(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteAdminPermissions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete admin permissions: %w", err)
+	}
+	return nil
+}
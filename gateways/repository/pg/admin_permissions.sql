@@ -0,0 +1,13 @@
+-- name: GetAdminPermissions :one
+SELECT * FROM admin_permissions WHERE user_id = $1;
+
+-- name: UpsertAdminPermissions :exec
+INSERT INTO admin_permissions (user_id, permissions, updated_by, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE SET
+    permissions = EXCLUDED.permissions,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteAdminPermissions :exec
+DELETE FROM admin_permissions WHERE user_id = $1;
@@ -3,11 +3,18 @@ package pg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-template/domain/entities"
 	"go-template/gateways/repository/pg/gen"
+
+	"github.com/jackc/pgx/v5"
 )
 
+// alertSettingsKey holds the alert channels, apart from the system settings
+// as they carry secrets only super admins may see.
+const alertSettingsKey = "alert_settings"
+
 type AdminSettingsRepository struct {
 	queries *gen.Queries
 	db      DBTX
@@ -51,6 +58,11 @@ func (r *AdminSettingsRepository) GetSettings(ctx context.Context) (*entities.Sy
 			if err := json.Unmarshal(setting.Value, &value); err == nil {
 				result.RegistrationEnabled = value
 			}
+		case "invitations_enabled":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.InvitationsEnabled = value
+			}
 		case "email_notifications":
 			var value bool
 			if err := json.Unmarshal(setting.Value, &value); err == nil {
@@ -66,11 +78,46 @@ func (r *AdminSettingsRepository) GetSettings(ctx context.Context) (*entities.Sy
 			if err := json.Unmarshal(setting.Value, &value); err == nil {
 				result.MinPasswordLength = value
 			}
+		case "password_require_mixed_case":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.PasswordRequireMixedCase = value
+			}
+		case "password_require_digit":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.PasswordRequireDigit = value
+			}
+		case "password_require_symbol":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.PasswordRequireSymbol = value
+			}
+		case "password_check_breached":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.PasswordCheckBreached = value
+			}
 		case "require_2fa":
 			var value bool
 			if err := json.Unmarshal(setting.Value, &value); err == nil {
 				result.Require2FA = value
 			}
+		case "require_email_verification":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.RequireEmailVerification = value
+			}
+		case "require_captcha":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.RequireCaptcha = value
+			}
+		case "require_approval":
+			var value bool
+			if err := json.Unmarshal(setting.Value, &value); err == nil {
+				result.RequireApproval = value
+			}
 		case "auto_backup":
 			var value bool
 			if err := json.Unmarshal(setting.Value, &value); err == nil {
@@ -92,10 +139,18 @@ func (r *AdminSettingsRepository) UpdateSettings(ctx context.Context, settings *
 	settingUpdates := map[string]any{
 		"maintenance_mode":      settings.MaintenanceMode,
 		"registration_enabled":  settings.RegistrationEnabled,
+		"invitations_enabled":  settings.InvitationsEnabled,
 		"email_notifications":   settings.EmailNotifications,
 		"session_timeout":       settings.SessionTimeout,
 		"min_password_length":   settings.MinPasswordLength,
+		"password_require_mixed_case": settings.PasswordRequireMixedCase,
+		"password_require_digit": settings.PasswordRequireDigit,
+		"password_require_symbol": settings.PasswordRequireSymbol,
+		"password_check_breached": settings.PasswordCheckBreached,
 		"require_2fa":          settings.Require2FA,
+		"require_email_verification": settings.RequireEmailVerification,
+		"require_captcha":      settings.RequireCaptcha,
+		"require_approval":     settings.RequireApproval,
 		"auto_backup":          settings.AutoBackup,
 		"backup_retention_days": settings.BackupRetentionDays,
 	}
@@ -140,4 +195,25 @@ func (r *AdminSettingsRepository) SetSetting(ctx context.Context, key string, va
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// GetAlertSettings returns the alert channels, none until they are saved.
+func (r *AdminSettingsRepository) GetAlertSettings(ctx context.Context) (entities.AlertSettings, error) {
+	var settings entities.AlertSettings
+	setting, err := r.queries.GetAdminSetting(ctx, alertSettingsKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return settings, nil
+		}
+		return settings, fmt.Errorf("failed to get alert settings: %w", err)
+	}
+
+	if err := json.Unmarshal(setting.Value, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal alert settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (r *AdminSettingsRepository) UpdateAlertSettings(ctx context.Context, settings entities.AlertSettings) error {
+	return r.SetSetting(ctx, alertSettingsKey, settings)
+}
@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AnnouncementRepository stores the announcements admins broadcast, and
+// who dismissed them.
+type AnnouncementRepository struct {
+	queries *gen.Queries
+}
+
+// NewAnnouncementRepository creates a new AnnouncementRepository instance.
+func NewAnnouncementRepository(db DBTX) *AnnouncementRepository {
+	return &AnnouncementRepository{queries: gen.New(db)}
+}
+
+func (r *AnnouncementRepository) CreateAnnouncement(ctx context.Context, announcement entities.Announcement) error {
+	err := r.queries.CreateAnnouncement(ctx, gen.CreateAnnouncementParams{
+		ID:        announcement.ID,
+		Message:   announcement.Message,
+		Level:     string(announcement.Level),
+		StartsAt:  announcement.StartsAt,
+		EndsAt:    announcement.EndsAt,
+		CreatedBy: announcement.CreatedBy,
+		CreatedAt: announcement.CreatedAt,
+		UpdatedAt: announcement.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return nil
+}
+
+func (r *AnnouncementRepository) GetAnnouncement(ctx context.Context, id uuid.UUID) (entities.Announcement, error) {
+	row, err := r.queries.GetAnnouncement(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Announcement{}, domain.ErrNotFound
+		}
+		return entities.Announcement{}, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return announcementFromRow(row), nil
+}
+
+func (r *AnnouncementRepository) ListAnnouncements(ctx context.Context) ([]entities.Announcement, error) {
+	rows, err := r.queries.ListAnnouncements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	return announcementsFromRows(rows), nil
+}
+
+func (r *AnnouncementRepository) ListActiveAnnouncements(ctx context.Context, now time.Time, userID uuid.UUID) ([]entities.Announcement, error) {
+	rows, err := r.queries.ListActiveAnnouncements(ctx, now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active announcements: %w", err)
+	}
+	return announcementsFromRows(rows), nil
+}
+
+func (r *AnnouncementRepository) UpdateAnnouncement(ctx context.Context, announcement entities.Announcement) error {
+	n, err := r.queries.UpdateAnnouncement(ctx, gen.UpdateAnnouncementParams{
+		ID:        announcement.ID,
+		Message:   announcement.Message,
+		Level:     string(announcement.Level),
+		StartsAt:  announcement.StartsAt,
+		EndsAt:    announcement.EndsAt,
+		UpdatedAt: announcement.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update announcement: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AnnouncementRepository) DeleteAnnouncement(ctx context.Context, id uuid.UUID) error {
+	n, err := r.queries.DeleteAnnouncement(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AnnouncementRepository) DismissAnnouncement(ctx context.Context, id, userID uuid.UUID, dismissedAt time.Time) error {
+	if err := r.queries.DismissAnnouncement(ctx, id, userID, dismissedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to dismiss announcement: %w", err)
+	}
+	return nil
+}
+
+func announcementsFromRows(rows []gen.Announcement) []entities.Announcement {
+	announcements := make([]entities.Announcement, len(rows))
+	for i, row := range rows {
+		announcements[i] = announcementFromRow(row)
+	}
+	return announcements
+}
+
+func announcementFromRow(row gen.Announcement) entities.Announcement {
+	return entities.Announcement{
+		ID:        row.ID,
+		Message:   row.Message,
+		Level:     entities.AnnouncementLevel(row.Level),
+		StartsAt:  row.StartsAt,
+		EndsAt:    row.EndsAt,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
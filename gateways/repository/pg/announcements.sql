@@ -0,0 +1,33 @@
+-- name: CreateAnnouncement :exec
+INSERT INTO announcements (id, message, level, starts_at, ends_at, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAnnouncement :one
+SELECT * FROM announcements WHERE id = $1;
+
+-- name: ListAnnouncements :many
+SELECT * FROM announcements
+ORDER BY starts_at DESC, id DESC;
+
+-- name: ListActiveAnnouncements :many
+-- Announcements the user dismissed are left out.
+SELECT * FROM announcements a
+WHERE a.starts_at <= @now AND (a.ends_at IS NULL OR a.ends_at > @now)
+  AND NOT EXISTS (
+    SELECT 1 FROM announcement_dismissals d
+    WHERE d.announcement_id = a.id AND d.user_id = @user_id
+  )
+ORDER BY a.starts_at DESC, a.id DESC;
+
+-- name: UpdateAnnouncement :execrows
+UPDATE announcements
+SET message = $2, level = $3, starts_at = $4, ends_at = $5, updated_at = $6
+WHERE id = $1;
+
+-- name: DeleteAnnouncement :execrows
+DELETE FROM announcements WHERE id = $1;
+
+-- name: DismissAnnouncement :exec
+INSERT INTO announcement_dismissals (announcement_id, user_id, dismissed_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (announcement_id, user_id) DO NOTHING;
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	users := NewUserRepository(pool)
+	repo := NewAnnouncementRepository(pool)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	admin := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "announcer@example.com", AuthProvider: "supabase", AuthProviderID: "prov-announcer", AccountType: entities.AccountTypeSuperAdmin, CreatedAt: now, UpdatedAt: now}
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "reader@example.com", AuthProvider: "supabase", AuthProviderID: "prov-reader", AccountType: entities.AccountTypeUser, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, users.Create(ctx, admin))
+	require.NoError(t, users.Create(ctx, user))
+
+	newAnnouncement := func(startsAt time.Time, endsAt *time.Time) entities.Announcement {
+		return entities.Announcement{
+			ID:        uuid.Must(uuid.NewV4()),
+			Message:   "Maintenance tonight",
+			Level:     entities.AnnouncementWarning,
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			CreatedBy: &admin.ID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	ended := now.Add(-time.Minute)
+	active := newAnnouncement(now.Add(-time.Hour), nil)
+	scheduled := newAnnouncement(now.Add(time.Hour), nil)
+	past := newAnnouncement(now.Add(-2*time.Hour), &ended)
+	for _, a := range []entities.Announcement{active, scheduled, past} {
+		require.NoError(t, repo.CreateAnnouncement(ctx, a))
+	}
+
+	got, err := repo.GetAnnouncement(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, active, got)
+	_, err = repo.GetAnnouncement(ctx, uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	all, err := repo.ListAnnouncements(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Announcement{scheduled, active, past}, all, "latest first")
+
+	shown, err := repo.ListActiveAnnouncements(ctx, now, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Announcement{active}, shown)
+
+	require.NoError(t, repo.DismissAnnouncement(ctx, active.ID, user.ID, now))
+	require.NoError(t, repo.DismissAnnouncement(ctx, active.ID, user.ID, now), "dismissed twice")
+	assert.ErrorIs(t, repo.DismissAnnouncement(ctx, uuid.Must(uuid.NewV4()), user.ID, now), domain.ErrNotFound)
+	shown, err = repo.ListActiveAnnouncements(ctx, now, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, shown, "dismissed by the user")
+	shown, err = repo.ListActiveAnnouncements(ctx, now, uuid.Nil)
+	require.NoError(t, err)
+	assert.Len(t, shown, 1, "still shown to everyone else")
+
+	active.Message = "Maintenance moved to tomorrow"
+	active.Level = entities.AnnouncementCritical
+	require.NoError(t, repo.UpdateAnnouncement(ctx, active))
+	got, err = repo.GetAnnouncement(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, active, got)
+
+	require.NoError(t, repo.DeleteAnnouncement(ctx, active.ID))
+	assert.ErrorIs(t, repo.DeleteAnnouncement(ctx, active.ID), domain.ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateAnnouncement(ctx, active), domain.ErrNotFound)
+}
@@ -0,0 +1,135 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// APIKeyRepository stores the API keys of machine clients.
+type APIKeyRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository instance.
+func NewAPIKeyRepository(db DBTX) *APIKeyRepository {
+	return &APIKeyRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key entities.APIKey) error {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	err := r.queries.CreateAPIKey(ctx, gen.CreateAPIKeyParams{
+		ID:        key.ID,
+		UserID:    key.UserID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   key.KeyHash,
+		Scopes:    scopes,
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id uuid.UUID) (entities.APIKey, error) {
+	row, err := r.queries.GetAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.APIKey{}, domain.ErrNotFound
+		}
+		return entities.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return apiKeyFromRow(row), nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (entities.APIKey, error) {
+	row, err := r.queries.GetAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.APIKey{}, domain.ErrNotFound
+		}
+		return entities.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return apiKeyFromRow(row), nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	rows, err := r.queries.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return apiKeysFromRows(rows), nil
+}
+
+func (r *APIKeyRepository) ListUserAPIKeys(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error) {
+	rows, err := r.queries.ListUserAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return apiKeysFromRows(rows), nil
+}
+
+// RevokeAPIKey returns domain.ErrNotFound for unknown keys and keys that were
+// already revoked.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.queries.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.TouchAPIKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+	return nil
+}
+
+func apiKeysFromRows(rows []gen.ApiKey) []entities.APIKey {
+	keys := make([]entities.APIKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, apiKeyFromRow(row))
+	}
+	return keys
+}
+
+func apiKeyFromRow(row gen.ApiKey) entities.APIKey {
+	scopes := make([]entities.APIKeyScope, 0, len(row.Scopes))
+	for _, scope := range row.Scopes {
+		scopes = append(scopes, entities.APIKeyScope(scope))
+	}
+
+	return entities.APIKey{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		KeyHash:    row.KeyHash,
+		Scopes:     scopes,
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
@@ -0,0 +1,21 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys WHERE id = $1;
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_keys WHERE key_hash = $1;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_keys ORDER BY created_at DESC;
+
+-- name: ListUserAPIKeys :many
+SELECT * FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = NOW() WHERE id = $1;
@@ -0,0 +1,115 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AttachmentRepository stores the files attached to examples.
+type AttachmentRepository struct {
+	queries *gen.Queries
+}
+
+// NewAttachmentRepository creates a new AttachmentRepository instance.
+func NewAttachmentRepository(db DBTX) *AttachmentRepository {
+	return &AttachmentRepository{queries: gen.New(db)}
+}
+
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment entities.Attachment) error {
+	exampleID := uuid.FromStringOrNil(attachment.ExampleID)
+	err := r.queries.CreateAttachment(ctx, gen.CreateAttachmentParams{
+		ID:          attachment.ID,
+		ExampleID:   &exampleID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        uint64(attachment.Size),
+		FileKey:     attachment.FileKey,
+		UploadedBy:  attachment.UploadedBy,
+		CreatedAt:   attachment.CreatedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, exampleID string, id uuid.UUID) (entities.Attachment, error) {
+	row, err := r.queries.GetAttachment(ctx, id, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Attachment{}, domain.ErrNotFound
+		}
+		return entities.Attachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachmentFromRow(row), nil
+}
+
+func (r *AttachmentRepository) ListAttachments(ctx context.Context, exampleID string) ([]entities.Attachment, error) {
+	rows, err := r.queries.ListAttachments(ctx, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return attachmentsFromRows(rows), nil
+}
+
+func (r *AttachmentRepository) DetachAttachment(ctx context.Context, exampleID string, id uuid.UUID) error {
+	n, err := r.queries.DetachAttachment(ctx, id, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		return fmt.Errorf("failed to detach attachment: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AttachmentRepository) ListOrphanedAttachments(ctx context.Context, limit int32) ([]entities.Attachment, error) {
+	rows, err := r.queries.ListOrphanedAttachments(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphaned attachments: %w", err)
+	}
+	return attachmentsFromRows(rows), nil
+}
+
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteAttachment(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+func attachmentsFromRows(rows []gen.Attachment) []entities.Attachment {
+	attachments := make([]entities.Attachment, len(rows))
+	for i, row := range rows {
+		attachments[i] = attachmentFromRow(row)
+	}
+	return attachments
+}
+
+func attachmentFromRow(row gen.Attachment) entities.Attachment {
+	attachment := entities.Attachment{
+		ID:          row.ID,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Size:        int64(row.Size),
+		FileKey:     row.FileKey,
+		UploadedBy:  row.UploadedBy,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.ExampleID != nil {
+		attachment.ExampleID = row.ExampleID.String()
+	}
+	return attachment
+}
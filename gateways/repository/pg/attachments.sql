@@ -0,0 +1,21 @@
+-- name: CreateAttachment :exec
+INSERT INTO attachments (id, example_id, file_name, content_type, size, file_key, uploaded_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAttachment :one
+SELECT * FROM attachments WHERE id = @id AND example_id = @example_id::uuid;
+
+-- name: ListAttachments :many
+SELECT * FROM attachments WHERE example_id = @example_id::uuid ORDER BY created_at, id;
+
+-- name: DetachAttachment :execrows
+UPDATE attachments SET example_id = NULL WHERE id = @id AND example_id = @example_id::uuid;
+
+-- name: ListOrphanedAttachments :many
+SELECT * FROM attachments
+WHERE example_id IS NULL
+ORDER BY created_at
+LIMIT @page_limit;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments WHERE id = $1;
@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	examples := NewExampleRepository(pool)
+	repo := NewAttachmentRepository(pool)
+	ctx := context.Background()
+
+	exampleID, err := examples.CreateExample(ctx, entities.Example{Title: "Attach " + uuid.Must(uuid.NewV4()).String()})
+	require.NoError(t, err)
+
+	attachment := entities.Attachment{
+		ID:          uuid.Must(uuid.NewV4()),
+		ExampleID:   exampleID,
+		FileName:    "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        5,
+		FileKey:     "private/attachments/" + exampleID + "/notes.txt",
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+	require.NoError(t, repo.CreateAttachment(ctx, attachment))
+
+	got, err := repo.GetAttachment(ctx, exampleID, attachment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, attachment.FileName, got.FileName)
+	assert.Equal(t, attachment.Size, got.Size)
+	assert.Equal(t, exampleID, got.ExampleID)
+
+	_, err = repo.GetAttachment(ctx, uuid.Must(uuid.NewV4()).String(), attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "attachments are only found through their example")
+
+	listed, err := repo.ListAttachments(ctx, exampleID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	missing := attachment
+	missing.ID = uuid.Must(uuid.NewV4())
+	missing.ExampleID = uuid.Must(uuid.NewV4()).String()
+	assert.ErrorIs(t, repo.CreateAttachment(ctx, missing), domain.ErrNotFound)
+
+	// Deleting the example leaves the attachment to the cleanup
+	require.NoError(t, examples.DeleteExample(ctx, exampleID, nil))
+	orphans, err := repo.ListOrphanedAttachments(ctx, 1000)
+	require.NoError(t, err)
+	assert.Contains(t, attachmentIDs(orphans), attachment.ID)
+
+	require.NoError(t, repo.DeleteAttachment(ctx, attachment.ID))
+	orphans, err = repo.ListOrphanedAttachments(ctx, 1000)
+	require.NoError(t, err)
+	assert.NotContains(t, attachmentIDs(orphans), attachment.ID)
+	assert.ErrorIs(t, repo.DetachAttachment(ctx, exampleID, attachment.ID), domain.ErrNotFound)
+}
+
+func attachmentIDs(attachments []entities.Attachment) []uuid.UUID {
+	ids := make([]uuid.UUID, len(attachments))
+	for i, a := range attachments {
+		ids[i] = a.ID
+	}
+	return ids
+}
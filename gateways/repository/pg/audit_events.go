@@ -0,0 +1,204 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuditEventRepository stores the audit log.
+type AuditEventRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewAuditEventRepository creates a new AuditEventRepository instance.
+func NewAuditEventRepository(db DBTX) *AuditEventRepository {
+	return &AuditEventRepository{queries: gen.New(db), db: db}
+}
+
+func (r *AuditEventRepository) CreateAuditEvent(ctx context.Context, event entities.AuditEvent) error {
+	details := []byte("{}")
+	if len(event.Details) > 0 {
+		var err error
+		details, err = json.Marshal(event.Details)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit event details: %w", err)
+		}
+	}
+
+	err := r.queries.CreateAuditEvent(ctx, gen.CreateAuditEventParams{
+		OccurredAt: event.Time,
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		Resource:   event.Resource,
+		ResourceID: event.ResourceID,
+		RequestID:  event.RequestID,
+		Details:    details,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditEventRepository) ListAuditEvents(ctx context.Context, filter entities.AuditFilter) ([]entities.AuditEvent, error) {
+	if len(filter.Expr) > 0 {
+		return r.queryAuditEvents(ctx, filter)
+	}
+
+	where := auditWhere(filter)
+	rows, err := r.queries.ListAuditEvents(ctx, gen.ListAuditEventsParams{
+		ActorID:      where.ActorID,
+		Action:       where.Action,
+		Resource:     where.Resource,
+		ResourceID:   where.ResourceID,
+		OccurredFrom: where.OccurredFrom,
+		OccurredTo:   where.OccurredTo,
+		PageLimit:    int32(filter.PageSize),
+		PageOffset:   int32((filter.Page - 1) * filter.PageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return auditEventsFromRows(rows)
+}
+
+func (r *AuditEventRepository) CountAuditEvents(ctx context.Context, filter entities.AuditFilter) (int64, error) {
+	if len(filter.Expr) > 0 {
+		return r.countAuditEvents(ctx, filter)
+	}
+
+	count, err := r.queries.CountAuditEvents(ctx, auditWhere(filter))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	return count, nil
+}
+
+func (r *AuditEventRepository) ReassignAuditEvents(ctx context.Context, userID, tombstoneID uuid.UUID) error {
+	if err := r.queries.ReassignAuditEvents(ctx, userID, tombstoneID); err != nil {
+		return fmt.Errorf("failed to reassign audit events: %w", err)
+	}
+	return nil
+}
+
+// auditWhere turns the zero fields of filter into NULLs, which match any
+// event.
+func auditWhere(filter entities.AuditFilter) gen.CountAuditEventsParams {
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	return gen.CountAuditEventsParams{
+		ActorID:      filter.ActorID,
+		Action:       optional(filter.Action),
+		Resource:     optional(filter.Resource),
+		ResourceID:   optional(filter.ResourceID),
+		OccurredFrom: filter.From,
+		OccurredTo:   filter.To,
+	}
+}
+
+// auditEventsFromRows converts rows of the audit log into events.
+func auditEventsFromRows(rows []gen.AuditEvent) ([]entities.AuditEvent, error) {
+	events := make([]entities.AuditEvent, len(rows))
+	for i, row := range rows {
+		events[i] = entities.AuditEvent{
+			ID:         row.ID,
+			Time:       row.OccurredAt,
+			ActorID:    row.ActorID,
+			Action:     row.Action,
+			Resource:   row.Resource,
+			ResourceID: row.ResourceID,
+			RequestID:  row.RequestID,
+		}
+		if err := json.Unmarshal(row.Details, &events[i].Details); err != nil {
+			return nil, fmt.Errorf("failed to decode audit event details: %w", err)
+		}
+		if len(events[i].Details) == 0 {
+			events[i].Details = nil
+		}
+	}
+	return events, nil
+}
+
+// auditFilterColumns are the columns behind entities.AuditFilterFields.
+var auditFilterColumns = filterColumns{
+	"actor_id":    "actor_id",
+	"action":      "action",
+	"resource":    "resource",
+	"resource_id": "resource_id",
+	"request_id":  "request_id",
+	"occurred_at": "occurred_at",
+}
+
+// queryAuditEvents is ListAuditEvents for filters with an expression.
+func (r *AuditEventRepository) queryAuditEvents(ctx context.Context, filter entities.AuditFilter) ([]entities.AuditEvent, error) {
+	where, err := auditExprWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT id, occurred_at, actor_id, action, resource, resource_id, request_id, details FROM audit_events " +
+		where.String() + " ORDER BY occurred_at DESC, id DESC" +
+		" LIMIT " + where.arg(int32(filter.PageSize)) + " OFFSET " + where.arg(int32((filter.Page-1)*filter.PageSize))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	found, err := pgx.CollectRows(rows, pgx.RowToStructByPos[gen.AuditEvent])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return auditEventsFromRows(found)
+}
+
+// countAuditEvents is CountAuditEvents for filters with an expression.
+func (r *AuditEventRepository) countAuditEvents(ctx context.Context, filter entities.AuditFilter) (int64, error) {
+	where, err := auditExprWhere(filter)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM audit_events "+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	return count, nil
+}
+
+// auditExprWhere is auditWhere as a WHERE clause, with the filter expression
+// added.
+func auditExprWhere(filter entities.AuditFilter) (*sqlWhere, error) {
+	where := &sqlWhere{}
+	if filter.ActorID != nil {
+		where.add("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		where.add("action = ?", filter.Action)
+	}
+	if filter.Resource != "" {
+		where.add("resource = ?", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		where.add("resource_id = ?", filter.ResourceID)
+	}
+	if filter.From != nil {
+		where.add("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		where.add("occurred_at < ?", *filter.To)
+	}
+	if err := where.addFilter(filter.Expr, auditFilterColumns); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrMalformedParameters, err)
+	}
+	return where, nil
+}
@@ -0,0 +1,33 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (occurred_at, actor_id, action, resource, resource_id, request_id, details)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListAuditEvents :many
+SELECT id, occurred_at, actor_id, action, resource, resource_id, request_id, details
+FROM audit_events
+WHERE (sqlc.narg('actor_id')::UUID IS NULL OR actor_id = sqlc.narg('actor_id'))
+    AND (sqlc.narg('action')::TEXT IS NULL OR action = sqlc.narg('action'))
+    AND (sqlc.narg('resource')::TEXT IS NULL OR resource = sqlc.narg('resource'))
+    AND (sqlc.narg('resource_id')::TEXT IS NULL OR resource_id = sqlc.narg('resource_id'))
+    AND (sqlc.narg('occurred_from')::TIMESTAMPTZ IS NULL OR occurred_at >= sqlc.narg('occurred_from'))
+    AND (sqlc.narg('occurred_to')::TIMESTAMPTZ IS NULL OR occurred_at < sqlc.narg('occurred_to'))
+ORDER BY occurred_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*)
+FROM audit_events
+WHERE (sqlc.narg('actor_id')::UUID IS NULL OR actor_id = sqlc.narg('actor_id'))
+    AND (sqlc.narg('action')::TEXT IS NULL OR action = sqlc.narg('action'))
+    AND (sqlc.narg('resource')::TEXT IS NULL OR resource = sqlc.narg('resource'))
+    AND (sqlc.narg('resource_id')::TEXT IS NULL OR resource_id = sqlc.narg('resource_id'))
+    AND (sqlc.narg('occurred_from')::TIMESTAMPTZ IS NULL OR occurred_at >= sqlc.narg('occurred_from'))
+    AND (sqlc.narg('occurred_to')::TIMESTAMPTZ IS NULL OR occurred_at < sqlc.narg('occurred_to'));
+
+-- name: ReassignAuditEvents :exec
+UPDATE audit_events
+SET actor_id = CASE WHEN actor_id = @user_id::UUID THEN @tombstone_id::UUID ELSE actor_id END,
+    resource_id = CASE WHEN resource = 'user' AND resource_id = @user_id::UUID::TEXT THEN @tombstone_id::UUID::TEXT ELSE resource_id END,
+    details = details - 'email'
+WHERE actor_id = @user_id::UUID
+    OR (resource = 'user' AND resource_id = @user_id::UUID::TEXT);
@@ -0,0 +1,137 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// BackupRepository stores the database backups kept in file storage.
+type BackupRepository struct {
+	queries *gen.Queries
+}
+
+// NewBackupRepository creates a new BackupRepository instance.
+func NewBackupRepository(db DBTX) *BackupRepository {
+	return &BackupRepository{queries: gen.New(db)}
+}
+
+func (r *BackupRepository) CreateBackup(ctx context.Context, backup entities.Backup) error {
+	if err := r.queries.CreateBackup(ctx, backup.ID, string(backup.Trigger), backup.CreatedBy, backup.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	return nil
+}
+
+func (r *BackupRepository) CreateScheduledBackup(ctx context.Context, backup entities.Backup, since time.Time) (bool, error) {
+	n, err := r.queries.CreateScheduledBackup(ctx, backup.ID, backup.CreatedAt, since)
+	if err != nil {
+		return false, fmt.Errorf("failed to create scheduled backup: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *BackupRepository) GetBackup(ctx context.Context, id uuid.UUID) (entities.Backup, error) {
+	row, err := r.queries.GetBackup(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Backup{}, domain.ErrNotFound
+		}
+		return entities.Backup{}, fmt.Errorf("failed to get backup: %w", err)
+	}
+	return backupFromRow(row), nil
+}
+
+func (r *BackupRepository) ListBackups(ctx context.Context, limit, offset int32) ([]entities.Backup, error) {
+	rows, err := r.queries.ListBackups(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	return backupsFromRows(rows), nil
+}
+
+func (r *BackupRepository) CountBackups(ctx context.Context) (int64, error) {
+	count, err := r.queries.CountBackups(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count backups: %w", err)
+	}
+	return count, nil
+}
+
+func (r *BackupRepository) StartBackup(ctx context.Context, id uuid.UUID, startedAt time.Time) error {
+	n, err := r.queries.StartBackup(ctx, startedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to start backup: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BackupRepository) CompleteBackup(ctx context.Context, id uuid.UUID, fileKey string, size int64, finishedAt time.Time) error {
+	if err := r.queries.CompleteBackup(ctx, fileKey, size, finishedAt, id); err != nil {
+		return fmt.Errorf("failed to complete backup: %w", err)
+	}
+	return nil
+}
+
+func (r *BackupRepository) FailBackup(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error {
+	if err := r.queries.FailBackup(ctx, message, finishedAt, id); err != nil {
+		return fmt.Errorf("failed to fail backup: %w", err)
+	}
+	return nil
+}
+
+func (r *BackupRepository) FailStaleBackups(ctx context.Context, startedBefore time.Time, message string, finishedAt time.Time) (int64, error) {
+	n, err := r.queries.FailStaleBackups(ctx, message, finishedAt, startedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale backups: %w", err)
+	}
+	return n, nil
+}
+
+func (r *BackupRepository) ListExpiredBackups(ctx context.Context, createdBefore time.Time, limit int32) ([]entities.Backup, error) {
+	rows, err := r.queries.ListExpiredBackups(ctx, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired backups: %w", err)
+	}
+	return backupsFromRows(rows), nil
+}
+
+func (r *BackupRepository) DeleteBackup(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteBackup(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete backup: %w", err)
+	}
+	return nil
+}
+
+func backupsFromRows(rows []gen.Backup) []entities.Backup {
+	backups := make([]entities.Backup, len(rows))
+	for i, row := range rows {
+		backups[i] = backupFromRow(row)
+	}
+	return backups
+}
+
+func backupFromRow(row gen.Backup) entities.Backup {
+	return entities.Backup{
+		ID:         row.ID,
+		Status:     entities.BackupStatus(row.Status),
+		Trigger:    entities.BackupTrigger(row.Trigger),
+		Error:      row.Error,
+		FileKey:    row.FileKey,
+		Size:       row.Size,
+		CreatedBy:  row.CreatedBy,
+		CreatedAt:  row.CreatedAt,
+		StartedAt:  row.StartedAt,
+		FinishedAt: row.FinishedAt,
+	}
+}
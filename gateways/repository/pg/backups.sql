@@ -0,0 +1,58 @@
+-- name: CreateBackup :exec
+INSERT INTO backups (id, trigger, created_by, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: CreateScheduledBackup :execrows
+INSERT INTO backups (id, trigger, created_at)
+SELECT @id::uuid, 'scheduled', @created_at::timestamptz
+WHERE NOT EXISTS (
+    SELECT 1 FROM backups
+    WHERE trigger = 'scheduled' AND created_at > @since::timestamptz
+);
+
+-- name: GetBackup :one
+SELECT * FROM backups WHERE id = $1;
+
+-- name: ListBackups :many
+SELECT * FROM backups
+ORDER BY created_at DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountBackups :one
+SELECT COUNT(*) FROM backups;
+
+-- name: StartBackup :execrows
+UPDATE backups
+SET status = 'running', error = '', started_at = @started_at::timestamptz, finished_at = NULL
+WHERE id = @id AND status <> 'succeeded';
+
+-- name: CompleteBackup :exec
+UPDATE backups
+SET status = 'succeeded', file_key = @file_key, size = @size, finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: FailBackup :exec
+UPDATE backups
+SET status = 'failed', error = @message, finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: FailStaleBackups :execrows
+UPDATE backups
+SET status = 'failed', error = @message, finished_at = @finished_at::timestamptz
+WHERE status = 'running' AND started_at < @started_before::timestamptz;
+
+-- name: ListExpiredBackups :many
+SELECT * FROM backups
+WHERE status IN ('succeeded', 'failed')
+  AND created_at < @created_before::timestamptz
+  AND id IS DISTINCT FROM (
+      SELECT id FROM backups
+      WHERE status = 'succeeded'
+      ORDER BY created_at DESC
+      LIMIT 1
+  )
+ORDER BY created_at
+LIMIT @page_limit;
+
+-- name: DeleteBackup :exec
+DELETE FROM backups WHERE id = $1;
@@ -0,0 +1,61 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BreakGlassRepository stores hashed break-glass credentials.
+type BreakGlassRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewBreakGlassRepository creates a new BreakGlassRepository instance.
+func NewBreakGlassRepository(db DBTX) *BreakGlassRepository {
+	return &BreakGlassRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *BreakGlassRepository) CreateCredential(ctx context.Context, credential entities.BreakGlassCredential) error {
+	if err := r.queries.CreateBreakGlassCredential(ctx, credential.ID, credential.CredentialHash, credential.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create break-glass credential: %w", err)
+	}
+	return nil
+}
+
+func (r *BreakGlassRepository) HasUnusedCredential(ctx context.Context) (bool, error) {
+	exists, err := r.queries.HasUnusedBreakGlassCredential(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check break-glass credentials: %w", err)
+	}
+	return exists, nil
+}
+
+// RedeemCredential marks the credential as used in a single statement, so
+// concurrent attempts can't both succeed.
+func (r *BreakGlassRepository) RedeemCredential(ctx context.Context, credentialHash, usedFrom string) (entities.BreakGlassCredential, error) {
+	row, err := r.queries.RedeemBreakGlassCredential(ctx, credentialHash, &usedFrom)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.BreakGlassCredential{}, domain.ErrNotFound
+		}
+		return entities.BreakGlassCredential{}, fmt.Errorf("failed to redeem break-glass credential: %w", err)
+	}
+
+	return entities.BreakGlassCredential{
+		ID:             row.ID,
+		CredentialHash: row.CredentialHash,
+		CreatedAt:      row.CreatedAt,
+		UsedAt:         row.UsedAt,
+		UsedFrom:       row.UsedFrom,
+	}, nil
+}
@@ -0,0 +1,12 @@
+-- name: CreateBreakGlassCredential :exec
+INSERT INTO break_glass_credentials (id, credential_hash, created_at)
+VALUES ($1, $2, $3);
+
+-- name: HasUnusedBreakGlassCredential :one
+SELECT EXISTS(SELECT 1 FROM break_glass_credentials WHERE used_at IS NULL);
+
+-- name: RedeemBreakGlassCredential :one
+UPDATE break_glass_credentials
+SET used_at = NOW(), used_from = $2
+WHERE credential_hash = $1 AND used_at IS NULL
+RETURNING *;
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CommentRepository stores the comments on examples.
+type CommentRepository struct {
+	queries *gen.Queries
+}
+
+// NewCommentRepository creates a new CommentRepository instance.
+func NewCommentRepository(db DBTX) *CommentRepository {
+	return &CommentRepository{queries: gen.New(db)}
+}
+
+func (r *CommentRepository) CreateComment(ctx context.Context, comment entities.Comment) error {
+	err := r.queries.CreateComment(ctx, gen.CreateCommentParams{
+		ID:        comment.ID,
+		ExampleID: uuid.FromStringOrNil(comment.ExampleID),
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+func (r *CommentRepository) GetComment(ctx context.Context, exampleID string, id uuid.UUID) (entities.Comment, error) {
+	row, err := r.queries.GetComment(ctx, id, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Comment{}, domain.ErrNotFound
+		}
+		return entities.Comment{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return commentFromRow(row), nil
+}
+
+func (r *CommentRepository) ListComments(ctx context.Context, exampleID string, limit, offset int32) ([]entities.Comment, error) {
+	rows, err := r.queries.ListComments(ctx, uuid.FromStringOrNil(exampleID), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	comments := make([]entities.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = commentFromRow(row)
+	}
+	return comments, nil
+}
+
+func (r *CommentRepository) CountComments(ctx context.Context, exampleID string) (int64, error) {
+	count, err := r.queries.CountComments(ctx, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	return count, nil
+}
+
+func (r *CommentRepository) DeleteComment(ctx context.Context, exampleID string, id uuid.UUID) error {
+	n, err := r.queries.DeleteComment(ctx, id, uuid.FromStringOrNil(exampleID))
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func commentFromRow(row gen.Comment) entities.Comment {
+	return entities.Comment{
+		ID:        row.ID,
+		ExampleID: row.ExampleID.String(),
+		AuthorID:  row.AuthorID,
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt,
+	}
+}
@@ -0,0 +1,19 @@
+-- name: CreateComment :exec
+INSERT INTO comments (id, example_id, author_id, body, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetComment :one
+SELECT * FROM comments WHERE id = @id AND example_id = @example_id::uuid;
+
+-- name: ListComments :many
+-- Comments are listed oldest first, so they read as a conversation.
+SELECT * FROM comments
+WHERE example_id = @example_id::uuid
+ORDER BY created_at, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountComments :one
+SELECT COUNT(*) FROM comments WHERE example_id = @example_id::uuid;
+
+-- name: DeleteComment :execrows
+DELETE FROM comments WHERE id = @id AND example_id = @example_id::uuid;
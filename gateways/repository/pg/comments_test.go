@@ -0,0 +1,64 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	examples := NewExampleRepository(pool)
+	repo := NewCommentRepository(pool)
+	ctx := context.Background()
+
+	exampleID, err := examples.CreateExample(ctx, entities.Example{Title: "Comment " + uuid.Must(uuid.NewV4()).String()})
+	require.NoError(t, err)
+
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	first := entities.Comment{ID: uuid.Must(uuid.NewV4()), ExampleID: exampleID, Body: "first", CreatedAt: createdAt}
+	second := entities.Comment{ID: uuid.Must(uuid.NewV4()), ExampleID: exampleID, Body: "second", CreatedAt: createdAt.Add(time.Second)}
+	require.NoError(t, repo.CreateComment(ctx, second))
+	require.NoError(t, repo.CreateComment(ctx, first))
+
+	got, err := repo.GetComment(ctx, exampleID, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, got)
+
+	_, err = repo.GetComment(ctx, uuid.Must(uuid.NewV4()).String(), first.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "comments are only found through their example")
+
+	page, err := repo.ListComments(ctx, exampleID, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first.ID, page[0].ID, "oldest first")
+	page, err = repo.ListComments(ctx, exampleID, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, second.ID, page[0].ID)
+
+	total, err := repo.CountComments(ctx, exampleID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	missing := first
+	missing.ID = uuid.Must(uuid.NewV4())
+	missing.ExampleID = uuid.Must(uuid.NewV4()).String()
+	assert.ErrorIs(t, repo.CreateComment(ctx, missing), domain.ErrNotFound)
+
+	require.NoError(t, repo.DeleteComment(ctx, exampleID, first.ID))
+	assert.ErrorIs(t, repo.DeleteComment(ctx, exampleID, first.ID), domain.ErrNotFound)
+
+	// Comments go with their example
+	require.NoError(t, examples.DeleteExample(ctx, exampleID, nil))
+	_, err = repo.GetComment(ctx, exampleID, second.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
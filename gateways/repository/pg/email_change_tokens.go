@@ -0,0 +1,92 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailChangeRepository stores hashed email change tokens.
+type EmailChangeRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewEmailChangeRepository creates a new EmailChangeRepository instance.
+func NewEmailChangeRepository(db DBTX) *EmailChangeRepository {
+	return &EmailChangeRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// CreateEmailChangeToken stores the token and invalidates the user's
+// earlier unused tokens in the same statement.
+func (r *EmailChangeRepository) CreateEmailChangeToken(ctx context.Context, token entities.EmailChangeToken) error {
+	err := r.queries.CreateEmailChangeToken(ctx, gen.CreateEmailChangeTokenParams{
+		CreatedAt: token.CreatedAt,
+		UserID:    token.UserID,
+		ID:        token.ID,
+		NewEmail:  token.NewEmail,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email change token: %w", err)
+	}
+	return nil
+}
+
+func (r *EmailChangeRepository) GetEmailChangeToken(ctx context.Context, tokenHash string) (entities.EmailChangeToken, error) {
+	row, err := r.queries.GetEmailChangeTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.EmailChangeToken{}, domain.ErrNotFound
+		}
+		return entities.EmailChangeToken{}, fmt.Errorf("failed to get email change token: %w", err)
+	}
+	return emailChangeTokenFromRow(row), nil
+}
+
+func (r *EmailChangeRepository) GetLatestEmailChangeToken(ctx context.Context, userID uuid.UUID) (entities.EmailChangeToken, error) {
+	row, err := r.queries.GetLatestEmailChangeToken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.EmailChangeToken{}, domain.ErrNotFound
+		}
+		return entities.EmailChangeToken{}, fmt.Errorf("failed to get email change token: %w", err)
+	}
+	return emailChangeTokenFromRow(row), nil
+}
+
+// UseEmailChangeToken marks the token as used in a single statement,
+// so the same token can't be used twice concurrently.
+func (r *EmailChangeRepository) UseEmailChangeToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	n, err := r.queries.UseEmailChangeToken(ctx, id, &usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to use email change token: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func emailChangeTokenFromRow(row gen.EmailChangeToken) entities.EmailChangeToken {
+	return entities.EmailChangeToken{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		NewEmail:  row.NewEmail,
+		TokenHash: row.TokenHash,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+		UsedAt:    row.UsedAt,
+	}
+}
@@ -0,0 +1,22 @@
+-- name: CreateEmailChangeToken :exec
+WITH invalidated AS (
+    UPDATE email_change_tokens
+    SET used_at = @created_at
+    WHERE user_id = @user_id AND used_at IS NULL
+)
+INSERT INTO email_change_tokens (id, user_id, new_email, token_hash, expires_at, created_at)
+VALUES (@id, @user_id, @new_email, @token_hash, @expires_at, @created_at);
+
+-- name: GetEmailChangeTokenByHash :one
+SELECT * FROM email_change_tokens WHERE token_hash = $1;
+
+-- name: GetLatestEmailChangeToken :one
+SELECT * FROM email_change_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UseEmailChangeToken :execrows
+UPDATE email_change_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
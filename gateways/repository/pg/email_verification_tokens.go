@@ -0,0 +1,93 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailVerificationRepository stores hashed email verification tokens.
+type EmailVerificationRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository
+// instance.
+func NewEmailVerificationRepository(db DBTX) *EmailVerificationRepository {
+	return &EmailVerificationRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// CreateEmailVerificationToken stores the token and invalidates the user's
+// earlier unused tokens in the same statement.
+func (r *EmailVerificationRepository) CreateEmailVerificationToken(ctx context.Context, token entities.EmailVerificationToken) error {
+	err := r.queries.CreateEmailVerificationToken(ctx, gen.CreateEmailVerificationTokenParams{
+		CreatedAt: token.CreatedAt,
+		UserID:    token.UserID,
+		ID:        token.ID,
+		Email:     token.Email,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+	return nil
+}
+
+func (r *EmailVerificationRepository) GetEmailVerificationToken(ctx context.Context, tokenHash string) (entities.EmailVerificationToken, error) {
+	row, err := r.queries.GetEmailVerificationTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.EmailVerificationToken{}, domain.ErrNotFound
+		}
+		return entities.EmailVerificationToken{}, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+	return emailVerificationTokenFromRow(row), nil
+}
+
+func (r *EmailVerificationRepository) GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (entities.EmailVerificationToken, error) {
+	row, err := r.queries.GetLatestEmailVerificationToken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.EmailVerificationToken{}, domain.ErrNotFound
+		}
+		return entities.EmailVerificationToken{}, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+	return emailVerificationTokenFromRow(row), nil
+}
+
+// UseEmailVerificationToken marks the token as used in a single statement,
+// so the same token can't be used twice concurrently.
+func (r *EmailVerificationRepository) UseEmailVerificationToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	n, err := r.queries.UseEmailVerificationToken(ctx, id, &usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to use email verification token: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func emailVerificationTokenFromRow(row gen.EmailVerificationToken) entities.EmailVerificationToken {
+	return entities.EmailVerificationToken{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Email:     row.Email,
+		TokenHash: row.TokenHash,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+		UsedAt:    row.UsedAt,
+	}
+}
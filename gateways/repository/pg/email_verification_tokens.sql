@@ -0,0 +1,22 @@
+-- name: CreateEmailVerificationToken :exec
+WITH invalidated AS (
+    UPDATE email_verification_tokens
+    SET used_at = @created_at
+    WHERE user_id = @user_id AND used_at IS NULL
+)
+INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at, created_at)
+VALUES (@id, @user_id, @email, @token_hash, @expires_at, @created_at);
+
+-- name: GetEmailVerificationTokenByHash :one
+SELECT * FROM email_verification_tokens WHERE token_hash = $1;
+
+-- name: GetLatestEmailVerificationToken :one
+SELECT * FROM email_verification_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UseEmailVerificationToken :execrows
+UPDATE email_verification_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
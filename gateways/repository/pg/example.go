@@ -2,14 +2,15 @@ package pg
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"go-template/domain"
 	"go-template/domain/entities"
 	"go-template/gateways/repository/pg/gen"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -29,7 +30,7 @@ func NewExampleRepository(db DBTX) *ExampleRepository {
 
 // CreateExample creates a new example in the database.
 func (r *ExampleRepository) CreateExample(ctx context.Context, input entities.Example) (string, error) {
-	out, err := r.queries.CreateExample(ctx, input.Title, input.Content)
+	out, err := r.queries.CreateExample(ctx, input.Title, input.Content, input.CreatedBy)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -45,17 +46,131 @@ func (r *ExampleRepository) CreateExample(ctx context.Context, input entities.Ex
 func (r *ExampleRepository) GetExampleByID(ctx context.Context, id string) (entities.Example, error) {
 	out, err := r.queries.GetExampleByID(ctx, uuid.FromStringOrNil(id))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return entities.Example{}, nil
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Example{}, domain.ErrNotFound
 		}
 		return entities.Example{}, err
 	}
 
+	return exampleFromRow(out), nil
+}
+
+// ListExamples lists a page of examples by created_at.
+func (r *ExampleRepository) ListExamples(ctx context.Context, params entities.ListExamplesParams) ([]entities.Example, error) {
+	rows, err := r.queries.ListExamples(ctx, params.IncludeArchived, params.Desc, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list examples: %w", err)
+	}
+
+	examples := make([]entities.Example, len(rows))
+	for i, row := range rows {
+		examples[i] = exampleFromRow(row)
+	}
+	return examples, nil
+}
+
+// CountExamples returns how many examples there are, archived ones only
+// when includeArchived is set.
+func (r *ExampleRepository) CountExamples(ctx context.Context, includeArchived bool) (int64, error) {
+	count, err := r.queries.CountExamples(ctx, includeArchived)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count examples: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateExample updates the example's title and content, provided it wasn't
+// changed since input.UpdatedAt. It returns domain.ErrNotFound when there
+// is no such example and domain.ErrConflict when it was changed since.
+func (r *ExampleRepository) UpdateExample(ctx context.Context, input entities.Example) (entities.Example, error) {
+	id := uuid.FromStringOrNil(input.ID)
+	out, err := r.queries.UpdateExample(ctx, input.Title, input.Content, id, input.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Example{}, r.missingOrChanged(ctx, id)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return entities.Example{}, fmt.Errorf("example with title '%s' already exists: %w", input.Title, domain.ErrDuplicateKey)
+		}
+		return entities.Example{}, fmt.Errorf("failed to update example: %w", err)
+	}
+
+	return exampleFromRow(out), nil
+}
+
+// DeleteExample deletes the example, provided it wasn't changed since
+// expectedUpdatedAt when given. It returns domain.ErrNotFound when there is
+// no such example and domain.ErrConflict when it was changed since.
+func (r *ExampleRepository) DeleteExample(ctx context.Context, id string, expectedUpdatedAt *time.Time) error {
+	exampleID := uuid.FromStringOrNil(id)
+	n, err := r.queries.DeleteExample(ctx, exampleID, expectedUpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete example: %w", err)
+	}
+	if n == 0 {
+		return r.missingOrChanged(ctx, exampleID)
+	}
+	return nil
+}
+
+// ArchiveExample archives the example, or returns it as it is when it was
+// already archived. It returns domain.ErrNotFound when there is no such
+// example.
+func (r *ExampleRepository) ArchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	out, err := r.queries.ArchiveExample(ctx, uuid.FromStringOrNil(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Example{}, domain.ErrNotFound
+		}
+		return entities.Example{}, fmt.Errorf("failed to archive example: %w", err)
+	}
+	return exampleFromRow(out), nil
+}
+
+// UnarchiveExample brings the example back into lists. It returns
+// domain.ErrNotFound when there is no such example.
+func (r *ExampleRepository) UnarchiveExample(ctx context.Context, id string) (entities.Example, error) {
+	out, err := r.queries.UnarchiveExample(ctx, uuid.FromStringOrNil(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Example{}, domain.ErrNotFound
+		}
+		return entities.Example{}, fmt.Errorf("failed to unarchive example: %w", err)
+	}
+	return exampleFromRow(out), nil
+}
+
+// DeleteArchivedExamples deletes up to limit examples archived before
+// archivedBefore, oldest first, and returns how many it deleted.
+func (r *ExampleRepository) DeleteArchivedExamples(ctx context.Context, archivedBefore time.Time, limit int32) (int, error) {
+	n, err := r.queries.DeleteArchivedExamples(ctx, archivedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete archived examples: %w", err)
+	}
+	return int(n), nil
+}
+
+// missingOrChanged tells why a conditional write matched no example.
+func (r *ExampleRepository) missingOrChanged(ctx context.Context, id uuid.UUID) error {
+	_, err := r.queries.GetExampleByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get example: %w", err)
+	}
+	return fmt.Errorf("example was changed since it was read: %w", domain.ErrConflict)
+}
+
+func exampleFromRow(row gen.Example) entities.Example {
 	return entities.Example{
-		ID:        out.ID.String(),
-		Title:     out.Title,
-		Content:   out.Content,
-		CreatedAt: out.CreatedAt,
-		UpdatedAt: out.UpdatedAt,
-	}, nil
+		ID:         row.ID.String(),
+		Title:      row.Title,
+		Content:    row.Content,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		ArchivedAt: row.ArchivedAt,
+		CreatedBy:  row.CreatedBy,
+	}
 }
@@ -2,4 +2,57 @@
 SELECT * FROM examples WHERE id = $1;
 
 -- name: CreateExample :one
-INSERT INTO examples (title, content) VALUES ($1, $2) RETURNING id;
+INSERT INTO examples (title, content, created_by) VALUES ($1, $2, $3) RETURNING id;
+
+-- name: ListExamples :many
+-- Examples are listed by created_at, newest first unless sort_desc is false.
+-- Archived examples are left out unless include_archived is set.
+SELECT * FROM examples
+WHERE (@include_archived::BOOLEAN OR archived_at IS NULL)
+ORDER BY
+    CASE WHEN NOT @sort_desc::BOOLEAN THEN created_at END ASC,
+    CASE WHEN NOT @sort_desc::BOOLEAN THEN id END ASC,
+    created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountExamples :one
+SELECT COUNT(*) FROM examples
+WHERE (@include_archived::BOOLEAN OR archived_at IS NULL);
+
+-- name: UpdateExample :one
+-- Only updates the example if it wasn't changed since expected_updated_at.
+UPDATE examples
+SET title = @title, content = @content, updated_at = NOW()
+WHERE id = @id AND updated_at = @expected_updated_at
+RETURNING *;
+
+-- name: DeleteExample :execrows
+-- Without expected_updated_at, the example is deleted however recent it is.
+DELETE FROM examples
+WHERE id = @id
+    AND (sqlc.narg('expected_updated_at')::TIMESTAMPTZ IS NULL OR updated_at = sqlc.narg('expected_updated_at'));
+
+-- name: ArchiveExample :one
+-- Archiving an archived example leaves it as it was.
+UPDATE examples
+SET archived_at = COALESCE(archived_at, NOW()),
+    updated_at = CASE WHEN archived_at IS NULL THEN NOW() ELSE updated_at END
+WHERE id = $1
+RETURNING *;
+
+-- name: UnarchiveExample :one
+UPDATE examples
+SET archived_at = NULL,
+    updated_at = CASE WHEN archived_at IS NOT NULL THEN NOW() ELSE updated_at END
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteArchivedExamples :execrows
+-- Deletes up to batch_size examples archived before archived_before.
+DELETE FROM examples
+WHERE id IN (
+    SELECT id FROM examples
+    WHERE archived_at < @archived_before::TIMESTAMPTZ
+    ORDER BY archived_at
+    LIMIT @batch_size
+);
@@ -2,11 +2,14 @@ package pg
 
 import (
 	"context"
+	"go-template/domain"
 	"go-template/domain/entities"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExampleRepository_CreateExample(t *testing.T) {
@@ -47,3 +50,130 @@ func TestExampleRepository_CreateExample(t *testing.T) {
 		})
 	}
 }
+
+func TestExampleRepository_ListExamples(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	repo := NewExampleRepository(pool)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.CreateExample(ctx, entities.Example{Title: "List " + uuid.Must(uuid.NewV4()).String()})
+		require.NoError(t, err)
+	}
+
+	total, err := repo.CountExamples(ctx, false)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, total, int64(3))
+
+	newest, err := repo.ListExamples(ctx, entities.ListExamplesParams{Limit: 100, Desc: true})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(newest), 3)
+	for i := 1; i < len(newest); i++ {
+		assert.False(t, newest[i].CreatedAt.After(newest[i-1].CreatedAt), "newest first")
+	}
+
+	oldest, err := repo.ListExamples(ctx, entities.ListExamplesParams{Limit: 100})
+	require.NoError(t, err)
+	for i := 1; i < len(oldest); i++ {
+		assert.False(t, oldest[i].CreatedAt.Before(oldest[i-1].CreatedAt), "oldest first")
+	}
+
+	page, err := repo.ListExamples(ctx, entities.ListExamplesParams{Limit: 1, Offset: 1, Desc: true})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, newest[1].ID, page[0].ID, "offset skips the first")
+}
+
+func TestExampleRepository_UpdateAndDeleteExample(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	repo := NewExampleRepository(pool)
+	ctx := context.Background()
+
+	id, err := repo.CreateExample(ctx, entities.Example{Title: "Update " + uuid.Must(uuid.NewV4()).String()})
+	require.NoError(t, err)
+	read, err := repo.GetExampleByID(ctx, id)
+	require.NoError(t, err)
+
+	read.Title = "Updated " + uuid.Must(uuid.NewV4()).String()
+	updated, err := repo.UpdateExample(ctx, read)
+	require.NoError(t, err)
+	assert.Equal(t, read.Title, updated.Title)
+	assert.True(t, updated.UpdatedAt.After(read.UpdatedAt))
+
+	_, err = repo.UpdateExample(ctx, read)
+	assert.ErrorIs(t, err, domain.ErrConflict, "the example was changed since read")
+	assert.ErrorIs(t, repo.DeleteExample(ctx, id, &read.UpdatedAt), domain.ErrConflict)
+
+	missing := entities.Example{ID: uuid.Must(uuid.NewV4()).String(), Title: "x", UpdatedAt: read.UpdatedAt}
+	_, err = repo.UpdateExample(ctx, missing)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.DeleteExample(ctx, id, &updated.UpdatedAt))
+	_, err = repo.GetExampleByID(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteExample(ctx, id, nil), domain.ErrNotFound)
+}
+
+func TestExampleRepository_ArchiveExample(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	repo := NewExampleRepository(pool)
+	ctx := context.Background()
+
+	id, err := repo.CreateExample(ctx, entities.Example{Title: "Archive " + uuid.Must(uuid.NewV4()).String()})
+	require.NoError(t, err)
+
+	listed := func(includeArchived bool) bool {
+		examples, err := repo.ListExamples(ctx, entities.ListExamplesParams{Limit: 1000, Desc: true, IncludeArchived: includeArchived})
+		require.NoError(t, err)
+		for _, e := range examples {
+			if e.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	active, err := repo.CountExamples(ctx, false)
+	require.NoError(t, err)
+
+	archived, err := repo.ArchiveExample(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, archived.ArchivedAt)
+	assert.False(t, listed(false), "archived examples are left out")
+	assert.True(t, listed(true))
+
+	count, err := repo.CountExamples(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, active-1, count)
+
+	again, err := repo.ArchiveExample(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, again.ArchivedAt.Equal(*archived.ArchivedAt), "archiving twice keeps the first time")
+	assert.True(t, again.UpdatedAt.Equal(archived.UpdatedAt))
+
+	// Not yet past retention
+	deleted, err := repo.DeleteArchivedExamples(ctx, archived.ArchivedAt.Add(-time.Second), 100)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	restored, err := repo.UnarchiveExample(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, restored.ArchivedAt)
+	assert.True(t, listed(false))
+
+	_, err = repo.ArchiveExample(ctx, id)
+	require.NoError(t, err)
+	_, err = repo.DeleteArchivedExamples(ctx, time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	_, err = repo.GetExampleByID(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.ArchiveExample(ctx, uuid.Must(uuid.NewV4()).String())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,116 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExportJobRepository stores the exports produced in the background.
+type ExportJobRepository struct {
+	queries *gen.Queries
+}
+
+// NewExportJobRepository creates a new ExportJobRepository instance.
+func NewExportJobRepository(db DBTX) *ExportJobRepository {
+	return &ExportJobRepository{queries: gen.New(db)}
+}
+
+func (r *ExportJobRepository) CreateExportJob(ctx context.Context, job entities.ExportJob) error {
+	err := r.queries.CreateExportJob(ctx, gen.CreateExportJobParams{
+		ID:          job.ID,
+		Kind:        string(job.Kind),
+		Format:      job.Format,
+		Search:      job.Search,
+		AccountType: job.AccountType,
+		Filter:      job.Filter,
+		CreatedBy:   job.CreatedBy,
+		CreatedAt:   job.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+	return nil
+}
+
+func (r *ExportJobRepository) GetExportJob(ctx context.Context, id uuid.UUID) (entities.ExportJob, error) {
+	row, err := r.queries.GetExportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.ExportJob{}, domain.ErrNotFound
+		}
+		return entities.ExportJob{}, fmt.Errorf("failed to get export job: %w", err)
+	}
+	return exportJobFromRow(row), nil
+}
+
+func (r *ExportJobRepository) ClaimExportJob(ctx context.Context, now, staleBefore time.Time) (entities.ExportJob, error) {
+	row, err := r.queries.ClaimExportJob(ctx, now, staleBefore)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.ExportJob{}, domain.ErrNotFound
+		}
+		return entities.ExportJob{}, fmt.Errorf("failed to claim export job: %w", err)
+	}
+	return exportJobFromRow(row), nil
+}
+
+func (r *ExportJobRepository) CompleteExportJob(ctx context.Context, id uuid.UUID, fileKey string, rows int, finishedAt time.Time) error {
+	if err := r.queries.CompleteExportJob(ctx, fileKey, int32(rows), finishedAt, id); err != nil {
+		return fmt.Errorf("failed to complete export job: %w", err)
+	}
+	return nil
+}
+
+func (r *ExportJobRepository) FailExportJob(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error {
+	if err := r.queries.FailExportJob(ctx, message, finishedAt, id); err != nil {
+		return fmt.Errorf("failed to fail export job: %w", err)
+	}
+	return nil
+}
+
+func (r *ExportJobRepository) ListExpiredExportJobs(ctx context.Context, finishedBefore time.Time, limit int32) ([]entities.ExportJob, error) {
+	rows, err := r.queries.ListExpiredExportJobs(ctx, finishedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired export jobs: %w", err)
+	}
+
+	jobs := make([]entities.ExportJob, len(rows))
+	for i, row := range rows {
+		jobs[i] = exportJobFromRow(row)
+	}
+	return jobs, nil
+}
+
+func (r *ExportJobRepository) DeleteExportJob(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteExportJob(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete export job: %w", err)
+	}
+	return nil
+}
+
+func exportJobFromRow(row gen.ExportJob) entities.ExportJob {
+	return entities.ExportJob{
+		ID:          row.ID,
+		Kind:        entities.ExportKind(row.Kind),
+		Format:      row.Format,
+		Search:      row.Search,
+		AccountType: row.AccountType,
+		Filter:      row.Filter,
+		Status:      entities.ExportJobStatus(row.Status),
+		Error:       row.Error,
+		FileKey:     row.FileKey,
+		Rows:        int(row.RowCount),
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+		StartedAt:   row.StartedAt,
+		FinishedAt:  row.FinishedAt,
+	}
+}
@@ -0,0 +1,39 @@
+-- name: CreateExportJob :exec
+INSERT INTO export_jobs (id, kind, format, search, account_type, filter, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetExportJob :one
+SELECT * FROM export_jobs WHERE id = $1;
+
+-- name: ClaimExportJob :one
+UPDATE export_jobs
+SET status = 'running', started_at = @now::timestamptz
+WHERE id = (
+    SELECT id FROM export_jobs
+    WHERE status = 'pending'
+       OR (status = 'running' AND started_at < @stale_before::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteExportJob :exec
+UPDATE export_jobs
+SET status = 'succeeded', file_key = @file_key, row_count = @row_count, finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: FailExportJob :exec
+UPDATE export_jobs
+SET status = 'failed', error = @message, finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: ListExpiredExportJobs :many
+SELECT * FROM export_jobs
+WHERE status IN ('succeeded', 'failed')
+  AND finished_at < @finished_before::timestamptz
+ORDER BY finished_at
+LIMIT @page_limit;
+
+-- name: DeleteExportJob :exec
+DELETE FROM export_jobs WHERE id = $1;
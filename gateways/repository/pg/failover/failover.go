@@ -0,0 +1,383 @@
+// Package failover keeps a pgx pool usable across a primary failover: it
+// rebuilds the pool, flags read-only mode while writes are refused and
+// retries idempotent reads.
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolFactory builds a fresh connection pool. It is called on startup and
+// every time the pool has to be rebuilt after a failover.
+type PoolFactory func(ctx context.Context) (*pgxpool.Pool, error)
+
+// Config controls health monitoring and retry behavior.
+type Config struct {
+	CheckInterval time.Duration
+	CheckTimeout  time.Duration
+	ReadRetries   int
+	RetryBackoff  time.Duration
+}
+
+// ResilientDB wraps a pgx pool, detects primary failover and rebuilds the pool
+// when it happens. While the database refuses writes the service is flagged as
+// read-only; idempotent reads are transparently retried on the new pool.
+type ResilientDB struct {
+	pool     atomic.Pointer[pgxpool.Pool]
+	readOnly atomic.Bool
+	factory  PoolFactory
+	cfg      Config
+	logger   *slog.Logger
+
+	rebuildMu sync.Mutex
+}
+
+// NewResilientDB creates the initial pool using factory.
+func NewResilientDB(ctx context.Context, factory PoolFactory, cfg Config, logger *slog.Logger) (*ResilientDB, error) {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = 5 * time.Second
+	}
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = 2 * time.Second
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 200 * time.Millisecond
+	}
+
+	pool, err := factory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &ResilientDB{
+		factory: factory,
+		cfg:     cfg,
+		logger:  logger,
+	}
+	db.pool.Store(pool)
+	return db, nil
+}
+
+// Pool returns the pool currently in use.
+func (db *ResilientDB) Pool() *pgxpool.Pool {
+	return db.pool.Load()
+}
+
+// ReadOnly reports whether writes are currently being refused by the database.
+func (db *ResilientDB) ReadOnly() bool {
+	return db.readOnly.Load()
+}
+
+// Ping verifies the current pool can reach the database.
+func (db *ResilientDB) Ping(ctx context.Context) error {
+	return db.Pool().Ping(ctx)
+}
+
+// Close closes the current pool.
+func (db *ResilientDB) Close() {
+	db.Pool().Close()
+}
+
+func (db *ResilientDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	tag, err := db.Pool().Exec(ctx, sql, args...)
+	if err != nil {
+		return tag, db.handleWriteError(err)
+	}
+	db.setReadOnly(false)
+	return tag, nil
+}
+
+func (db *ResilientDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	idempotent := isIdempotentQuery(sql)
+	for attempt := 0; ; attempt++ {
+		rows, err := db.Pool().Query(ctx, sql, args...)
+		if err == nil {
+			return rows, nil
+		}
+		if !idempotent {
+			return nil, db.handleWriteError(err)
+		}
+		if !isFailoverError(err) || attempt >= db.cfg.ReadRetries {
+			return nil, err
+		}
+		db.recover(ctx, err)
+		if err := db.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (db *ResilientDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return &retryRow{db: db, ctx: ctx, sql: sql, args: args}
+}
+
+// Begin starts a transaction whose statements and commit flag read-only mode
+// like Exec does when the database refuses them.
+func (db *ResilientDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := db.Pool().Begin(ctx)
+	if err != nil {
+		if isFailoverError(err) {
+			db.recover(ctx, err)
+		}
+		return nil, err
+	}
+	return &resilientTx{Tx: tx, db: db}, nil
+}
+
+// Monitor periodically checks the database role and connectivity until ctx is
+// cancelled. A failed ping or a primary that has been demoted to a standby
+// triggers a pool rebuild; read-only mode is cleared once writes are accepted.
+func (db *ResilientDB) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(db.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			db.check(ctx)
+		}
+	}
+}
+
+func (db *ResilientDB) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, db.cfg.CheckTimeout)
+	defer cancel()
+
+	var inRecovery, txReadOnly bool
+	err := db.Pool().QueryRow(checkCtx,
+		"SELECT pg_is_in_recovery(), current_setting('transaction_read_only') = 'on'",
+	).Scan(&inRecovery, &txReadOnly)
+	if err != nil {
+		db.logger.Warn("database health check failed", slog.String("error", err.Error()))
+		db.setReadOnly(true)
+		db.rebuild(ctx)
+		return
+	}
+
+	if inRecovery || txReadOnly {
+		if !db.ReadOnly() {
+			db.logger.Warn("connected database is not accepting writes, assuming failover")
+		}
+		db.setReadOnly(true)
+		db.rebuild(ctx)
+		return
+	}
+
+	db.setReadOnly(false)
+}
+
+// handleWriteError flags read-only mode when the database rejected a write
+// because of a failover and schedules a pool rebuild.
+func (db *ResilientDB) handleWriteError(err error) error {
+	if !isFailoverError(err) {
+		return err
+	}
+	db.setReadOnly(true)
+	go db.rebuild(context.Background())
+	return fmt.Errorf("%w: %w", domain.ErrReadOnly, err)
+}
+
+func (db *ResilientDB) recover(ctx context.Context, err error) {
+	db.logger.Warn("database connection lost, rebuilding pool", slog.String("error", err.Error()))
+	db.rebuild(ctx)
+}
+
+// rebuild swaps in a fresh pool and drains the old one. Concurrent callers
+// share a single rebuild.
+func (db *ResilientDB) rebuild(ctx context.Context) {
+	if !db.rebuildMu.TryLock() {
+		return
+	}
+	defer db.rebuildMu.Unlock()
+
+	pool, err := db.factory(ctx)
+	if err != nil {
+		db.logger.Error("failed to rebuild database pool", slog.String("error", err.Error()))
+		return
+	}
+
+	old := db.pool.Swap(pool)
+	// Close blocks until acquired connections are released, draining
+	// in-flight queries without holding up the caller.
+	go old.Close()
+
+	db.logger.Info("database pool rebuilt")
+}
+
+func (db *ResilientDB) setReadOnly(readOnly bool) {
+	if db.readOnly.Swap(readOnly) != readOnly {
+		db.logger.Warn("database read-only mode changed", slog.Bool("read_only", readOnly))
+	}
+}
+
+func (db *ResilientDB) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(db.cfg.RetryBackoff):
+		return nil
+	}
+}
+
+// retryRow defers execution until Scan so reads can be retried after failover.
+type retryRow struct {
+	db   *ResilientDB
+	ctx  context.Context
+	sql  string
+	args []interface{}
+}
+
+func (r *retryRow) Scan(dest ...any) error {
+	idempotent := isIdempotentQuery(r.sql)
+	for attempt := 0; ; attempt++ {
+		err := r.db.Pool().QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+		if err == nil {
+			if !idempotent {
+				r.db.setReadOnly(false)
+			}
+			return nil
+		}
+		if !idempotent {
+			return r.db.handleWriteError(err)
+		}
+		if !isFailoverError(err) || attempt >= r.db.cfg.ReadRetries {
+			return err
+		}
+		r.db.recover(r.ctx, err)
+		if err := r.db.wait(r.ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// resilientTx routes the errors of a transaction's statements through
+// handleWriteError. Reads in a transaction aren't retried, since the
+// transaction is lost with its connection.
+type resilientTx struct {
+	pgx.Tx
+	db *ResilientDB
+}
+
+func (tx *resilientTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	nested, err := tx.Tx.Begin(ctx)
+	if err != nil {
+		return nil, tx.db.handleWriteError(err)
+	}
+	return &resilientTx{Tx: nested, db: tx.db}, nil
+}
+
+func (tx *resilientTx) Commit(ctx context.Context) error {
+	if err := tx.Tx.Commit(ctx); err != nil {
+		return tx.db.handleWriteError(err)
+	}
+	return nil
+}
+
+func (tx *resilientTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	tag, err := tx.Tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return tag, tx.db.handleWriteError(err)
+	}
+	return tag, nil
+}
+
+func (tx *resilientTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := tx.Tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, tx.db.handleWriteError(err)
+	}
+	return rows, nil
+}
+
+func (tx *resilientTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return txRow{row: tx.Tx.QueryRow(ctx, sql, args...), db: tx.db}
+}
+
+type txRow struct {
+	row pgx.Row
+	db  *ResilientDB
+}
+
+func (r txRow) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != nil {
+		return r.db.handleWriteError(err)
+	}
+	return nil
+}
+
+// isFailoverError reports whether err is caused by a lost connection or by a
+// server that is (no longer) the primary.
+func isFailoverError(err error) bool {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	// A slow query or a client that went away says nothing about the
+	// database, and context.DeadlineExceeded is a net.Error too.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "25006", // read_only_sql_transaction
+			"57P01", // admin_shutdown
+			"57P02", // crash_shutdown
+			"57P03": // cannot_connect_now
+			return true
+		}
+		// Class 08: connection exceptions
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return pgconn.SafeToRetry(err) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isIdempotentQuery reports whether sql is a plain read that is safe to retry.
+// Leading comments (such as sqlc's "-- name:" header) are ignored.
+func isIdempotentQuery(sql string) bool {
+	for {
+		sql = strings.TrimSpace(sql)
+		if !strings.HasPrefix(sql, "--") {
+			break
+		}
+		idx := strings.Index(sql, "\n")
+		if idx < 0 {
+			return false
+		}
+		sql = sql[idx+1:]
+	}
+
+	fields := strings.Fields(sql)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "SELECT") {
+		return false
+	}
+	upper := strings.ToUpper(sql)
+	return !strings.Contains(upper, "FOR UPDATE") && !strings.Contains(upper, "FOR SHARE")
+}
@@ -0,0 +1,67 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFailoverError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "no rows", err: pgx.ErrNoRows, want: false},
+		{name: "context canceled", err: context.Canceled, want: false},
+		{name: "wrapped context canceled", err: fmt.Errorf("query: %w", context.Canceled), want: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: false},
+		{name: "wrapped deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: false},
+		{name: "network timeout", err: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "read only transaction", err: &pgconn.PgError{Code: "25006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "wrapped read only", err: fmt.Errorf("query: %w", &pgconn.PgError{Code: "25006"}), want: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, want: true},
+		{name: "generic error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isFailoverError(tt.err))
+		})
+	}
+}
+
+func TestIsIdempotentQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want bool
+	}{
+		{name: "select", sql: "SELECT 1", want: true},
+		{name: "sqlc select", sql: "-- name: GetUserByID :one\nSELECT id FROM users WHERE id = $1", want: true},
+		{name: "lowercase select", sql: "  select * from examples", want: true},
+		{name: "select for update", sql: "SELECT id FROM users FOR UPDATE", want: false},
+		{name: "insert", sql: "-- name: CreateUser :one\nINSERT INTO users (email) VALUES ($1) RETURNING id", want: false},
+		{name: "update", sql: "UPDATE users SET email = $1", want: false},
+		{name: "comment only", sql: "-- nothing", want: false},
+		{name: "empty", sql: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isIdempotentQuery(tt.sql))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pg
+
+import (
+	"fmt"
+	"go-template/domain/entities"
+	"strconv"
+	"strings"
+)
+
+// sqlWhere builds the WHERE clause of the queries sqlc can't express, those
+// with a filter expression. Conditions take their arguments as ?, which are
+// numbered and bound in order, so values never end up in the SQL.
+type sqlWhere struct {
+	conds []string
+	args  []any
+}
+
+// add adds a condition, binding args to its ?s.
+func (w *sqlWhere) add(cond string, args ...any) {
+	for _, arg := range args {
+		cond = strings.Replace(cond, "?", w.arg(arg), 1)
+	}
+	w.conds = append(w.conds, cond)
+}
+
+// arg binds v outside of a condition, such as a LIMIT, and returns its
+// placeholder.
+func (w *sqlWhere) arg(v any) string {
+	w.args = append(w.args, v)
+	return "$" + strconv.Itoa(len(w.args))
+}
+
+func (w *sqlWhere) String() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(w.conds, " AND ")
+}
+
+// filterColumns maps the fields of an entities.FilterFields to the SQL
+// expressions they compare. Only these expressions reach the query.
+type filterColumns map[string]string
+
+// addFilter adds a condition for each predicate of f.
+func (w *sqlWhere) addFilter(f entities.Filter, columns filterColumns) error {
+	for _, p := range f {
+		column, ok := columns[p.Field]
+		if !ok {
+			return fmt.Errorf("cannot filter by %q", p.Field)
+		}
+		switch p.Op {
+		case entities.FilterEq:
+			w.add(column+" = ?", p.Value)
+		case entities.FilterNe:
+			w.add(column+" IS DISTINCT FROM ?", p.Value)
+		case entities.FilterGt, entities.FilterGte, entities.FilterLt, entities.FilterLte:
+			w.add(column+" "+string(p.Op)+" ?", p.Value)
+		case entities.FilterContains:
+			s, _ := p.Value.(string)
+			w.add(column+" ILIKE ?", "%"+likeEscaper.Replace(s)+"%")
+		default:
+			return fmt.Errorf("unknown filter operator %q", p.Op)
+		}
+	}
+	return nil
+}
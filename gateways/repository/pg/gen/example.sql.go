@@ -7,23 +7,94 @@ package gen
 
 import (
 	"context"
+	"time"
 
 	uuid "github.com/gofrs/uuid/v5"
 )
 
+const archiveExample = `-- name: ArchiveExample :one
+UPDATE examples
+SET archived_at = COALESCE(archived_at, NOW()),
+    updated_at = CASE WHEN archived_at IS NULL THEN NOW() ELSE updated_at END
+WHERE id = $1
+RETURNING id, title, content, created_at, updated_at, archived_at, created_by
+`
+
+// Archiving an archived example leaves it as it was.
+func (q *Queries) ArchiveExample(ctx context.Context, id uuid.UUID) (Example, error) {
+	row := q.db.QueryRow(ctx, archiveExample, id)
+	var i Example
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const countExamples = `-- name: CountExamples :one
+SELECT COUNT(*) FROM examples
+WHERE ($1::BOOLEAN OR archived_at IS NULL)
+`
+
+func (q *Queries) CountExamples(ctx context.Context, includeArchived bool) (int64, error) {
+	row := q.db.QueryRow(ctx, countExamples, includeArchived)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createExample = `-- name: CreateExample :one
-INSERT INTO examples (title, content) VALUES ($1, $2) RETURNING id
+INSERT INTO examples (title, content, created_by) VALUES ($1, $2, $3) RETURNING id
 `
 
-func (q *Queries) CreateExample(ctx context.Context, title string, content string) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createExample, title, content)
+func (q *Queries) CreateExample(ctx context.Context, title string, content string, createdBy *uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createExample, title, content, createdBy)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const deleteArchivedExamples = `-- name: DeleteArchivedExamples :execrows
+DELETE FROM examples
+WHERE id IN (
+    SELECT id FROM examples
+    WHERE archived_at < $1::TIMESTAMPTZ
+    ORDER BY archived_at
+    LIMIT $2
+)
+`
+
+// Deletes up to batch_size examples archived before archived_before.
+func (q *Queries) DeleteArchivedExamples(ctx context.Context, archivedBefore time.Time, batchSize int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteArchivedExamples, archivedBefore, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExample = `-- name: DeleteExample :execrows
+DELETE FROM examples
+WHERE id = $1
+    AND ($2::TIMESTAMPTZ IS NULL OR updated_at = $2)
+`
+
+// Without expected_updated_at, the example is deleted however recent it is.
+func (q *Queries) DeleteExample(ctx context.Context, id uuid.UUID, expectedUpdatedAt *time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExample, id, expectedUpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getExampleByID = `-- name: GetExampleByID :one
-SELECT id, title, content, created_at, updated_at FROM examples WHERE id = $1
+SELECT id, title, content, created_at, updated_at, archived_at, created_by FROM examples WHERE id = $1
 `
 
 func (q *Queries) GetExampleByID(ctx context.Context, id uuid.UUID) (Example, error) {
@@ -35,6 +106,94 @@ func (q *Queries) GetExampleByID(ctx context.Context, id uuid.UUID) (Example, er
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const listExamples = `-- name: ListExamples :many
+SELECT id, title, content, created_at, updated_at, archived_at, created_by FROM examples
+WHERE ($1::BOOLEAN OR archived_at IS NULL)
+ORDER BY
+    CASE WHEN NOT $2::BOOLEAN THEN created_at END ASC,
+    CASE WHEN NOT $2::BOOLEAN THEN id END ASC,
+    created_at DESC, id DESC
+LIMIT $3 OFFSET $4
+`
+
+// Examples are listed by created_at, newest first unless sort_desc is false.
+// Archived examples are left out unless include_archived is set.
+func (q *Queries) ListExamples(ctx context.Context, includeArchived bool, sortDesc bool, pageLimit int32, pageOffset int32) ([]Example, error) {
+	rows, err := q.db.Query(ctx, listExamples, includeArchived, sortDesc, pageLimit, pageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Example
+	for rows.Next() {
+		var i Example
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ArchivedAt,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unarchiveExample = `-- name: UnarchiveExample :one
+UPDATE examples
+SET archived_at = NULL,
+    updated_at = CASE WHEN archived_at IS NOT NULL THEN NOW() ELSE updated_at END
+WHERE id = $1
+RETURNING id, title, content, created_at, updated_at, archived_at, created_by
+`
+
+func (q *Queries) UnarchiveExample(ctx context.Context, id uuid.UUID) (Example, error) {
+	row := q.db.QueryRow(ctx, unarchiveExample, id)
+	var i Example
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const updateExample = `-- name: UpdateExample :one
+UPDATE examples
+SET title = $1, content = $2, updated_at = NOW()
+WHERE id = $3 AND updated_at = $4
+RETURNING id, title, content, created_at, updated_at, archived_at, created_by
+`
+
+// Only updates the example if it wasn't changed since expected_updated_at.
+func (q *Queries) UpdateExample(ctx context.Context, title string, content string, id uuid.UUID, expectedUpdatedAt time.Time) (Example, error) {
+	row := q.db.QueryRow(ctx, updateExample, title, content, id, expectedUpdatedAt)
+	var i Example
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ArchivedAt,
+		&i.CreatedBy,
 	)
 	return i, err
 }
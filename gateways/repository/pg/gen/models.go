@@ -55,6 +55,56 @@ func (ns NullAccountType) Value() (driver.Value, error) {
 	return string(ns.AccountType), nil
 }
 
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusPending   UserStatus = "pending"
+)
+
+func (e *UserStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserStatus(s)
+	case string:
+		*e = UserStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserStatus: %T", src)
+	}
+	return nil
+}
+
+type NullUserStatus struct {
+	UserStatus UserStatus `json:"userStatus"`
+	Valid      bool       `json:"valid"` // Valid is true if UserStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserStatus), nil
+}
+
+type AdminPermission struct {
+	UserID      uuid.UUID  `json:"userId"`
+	Permissions []string   `json:"permissions"`
+	UpdatedBy   *uuid.UUID `json:"updatedBy"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
 type AdminSetting struct {
 	Key       string     `json:"key"`
 	Value     []byte     `json:"value"`
@@ -62,20 +112,435 @@ type AdminSetting struct {
 	UpdatedAt *time.Time `json:"updatedAt"`
 }
 
+type Announcement struct {
+	ID        uuid.UUID  `json:"id"`
+	Message   string     `json:"message"`
+	Level     string     `json:"level"`
+	StartsAt  time.Time  `json:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt"`
+	CreatedBy *uuid.UUID `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type AnnouncementDismissal struct {
+	AnnouncementID uuid.UUID `json:"announcementId"`
+	UserID         uuid.UUID `json:"userId"`
+	DismissedAt    time.Time `json:"dismissedAt"`
+}
+
+type ApiKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"userId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"keyHash"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type Attachment struct {
+	ID          uuid.UUID  `json:"id"`
+	ExampleID   *uuid.UUID `json:"exampleId"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        uint64     `json:"size"`
+	FileKey     string     `json:"fileKey"`
+	UploadedBy  *uuid.UUID `json:"uploadedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type AuditEvent struct {
+	ID         uint64     `json:"id"`
+	OccurredAt time.Time  `json:"occurredAt"`
+	ActorID    *uuid.UUID `json:"actorId"`
+	Action     string     `json:"action"`
+	Resource   string     `json:"resource"`
+	ResourceID string     `json:"resourceId"`
+	RequestID  string     `json:"requestId"`
+	Details    []byte     `json:"details"`
+}
+
+type Backup struct {
+	ID         uuid.UUID  `json:"id"`
+	Status     string     `json:"status"`
+	Trigger    string     `json:"trigger"`
+	Error      string     `json:"error"`
+	FileKey    string     `json:"fileKey"`
+	Size       int64      `json:"size"`
+	CreatedBy  *uuid.UUID `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt"`
+}
+
+type BreakGlassCredential struct {
+	ID             uuid.UUID  `json:"id"`
+	CredentialHash string     `json:"credentialHash"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UsedAt         *time.Time `json:"usedAt"`
+	UsedFrom       *string    `json:"usedFrom"`
+}
+
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	ExampleID uuid.UUID  `json:"exampleId"`
+	AuthorID  *uuid.UUID `json:"authorId"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type EmailChangeToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	NewEmail  string     `json:"newEmail"`
+	TokenHash string     `json:"tokenHash"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
+type EmailVerificationToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	Email     string     `json:"email"`
+	TokenHash string     `json:"tokenHash"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
 type Example struct {
-	ID        uuid.UUID `json:"id"`
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Content    string     `json:"content"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	ArchivedAt *time.Time `json:"archivedAt"`
+	CreatedBy  *uuid.UUID `json:"createdBy"`
+}
+
+type ExportJob struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Format      string     `json:"format"`
+	Search      string     `json:"search"`
+	AccountType string     `json:"accountType"`
+	Filter      string     `json:"filter"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error"`
+	FileKey     string     `json:"fileKey"`
+	RowCount    int32      `json:"rowCount"`
+	CreatedBy   *uuid.UUID `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+type Invitation struct {
+	ID          uuid.UUID   `json:"id"`
+	Prefix      string      `json:"prefix"`
+	CodeHash    string      `json:"codeHash"`
+	AccountType AccountType `json:"accountType"`
+	MaxUses     *int32      `json:"maxUses"`
+	Uses        int32       `json:"uses"`
+	Note        string      `json:"note"`
+	CreatedBy   *uuid.UUID  `json:"createdBy"`
+	ExpiresAt   time.Time   `json:"expiresAt"`
+	RevokedAt   *time.Time  `json:"revokedAt"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	Email       string      `json:"email"`
+}
+
+type Job struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Args        []byte     `json:"args"`
+	Status      string     `json:"status"`
+	Attempts    int32      `json:"attempts"`
+	MaxAttempts int32      `json:"maxAttempts"`
+	LastError   string     `json:"lastError"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RunAt       time.Time  `json:"runAt"`
+	StartedAt   *time.Time `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+type LocalCredential struct {
+	ID           uuid.UUID `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"passwordHash"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type LoginEvent struct {
+	ID        int64     `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	Success   bool      `json:"success"`
+	Provider  string    `json:"provider"`
+	Reason    string    `json:"reason"`
+	IpAddress string    `json:"ipAddress"`
+	UserAgent string    `json:"userAgent"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	Kind      string     `json:"kind"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type OauthAuthorizationCode struct {
+	CodeHash            string    `json:"codeHash"`
+	ClientID            string    `json:"clientId"`
+	UserID              uuid.UUID `json:"userId"`
+	RedirectUri         string    `json:"redirectUri"`
+	Scope               string    `json:"scope"`
+	Nonce               string    `json:"nonce"`
+	CodeChallenge       string    `json:"codeChallenge"`
+	CodeChallengeMethod string    `json:"codeChallengeMethod"`
+	ExpiresAt           time.Time `json:"expiresAt"`
+	CreatedAt           time.Time `json:"createdAt"`
+}
+
+type OauthClient struct {
+	ID               uuid.UUID `json:"id"`
+	ClientID         string    `json:"clientId"`
+	ClientSecretHash string    `json:"clientSecretHash"`
+	Name             string    `json:"name"`
+	RedirectUris     []string  `json:"redirectUris"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type OauthConsent struct {
+	UserID    uuid.UUID `json:"userId"`
+	ClientID  string    `json:"clientId"`
+	Scope     string    `json:"scope"`
+	GrantedAt time.Time `json:"grantedAt"`
+}
+
+type OtpCode struct {
+	ID         uuid.UUID  `json:"id"`
+	Phone      string     `json:"phone"`
+	Purpose    string     `json:"purpose"`
+	CodeHash   string     `json:"codeHash"`
+	UserID     *uuid.UUID `json:"userId"`
+	Attempts   int32      `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ConsumedAt *time.Time `json:"consumedAt"`
+}
+
+type OutboxMessage struct {
+	ID          uuid.UUID  `json:"id"`
+	Event       string     `json:"event"`
+	Key         string     `json:"key"`
+	Payload     []byte     `json:"payload"`
+	ActorID     *uuid.UUID `json:"actorId"`
+	Attempts    int32      `json:"attempts"`
+	LastError   string     `json:"lastError"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	AvailableAt time.Time  `json:"availableAt"`
+	PublishedAt *time.Time `json:"publishedAt"`
+	Sink        string     `json:"sink"`
+}
+
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	TokenHash string     `json:"tokenHash"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	FamilyID  uuid.UUID  `json:"familyId"`
+	TokenHash string     `json:"tokenHash"`
+	Audience  string     `json:"audience"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	Mfa       bool       `json:"mfa"`
+}
+
+type RevokedToken struct {
+	Jti       string    `json:"jti"`
+	UserID    uuid.UUID `json:"userId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	RevokedAt time.Time `json:"revokedAt"`
+}
+
+type RevokedUser struct {
+	UserID    uuid.UUID `json:"userId"`
+	RevokedAt time.Time `json:"revokedAt"`
+}
+
+type Role struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Builtin     bool      `json:"builtin"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type RolePermission struct {
+	RoleID     uuid.UUID `json:"roleId"`
+	Permission string    `json:"permission"`
+}
+
+type SearchDocument struct {
+	Kind      string      `json:"kind"`
+	ID        uuid.UUID   `json:"id"`
+	Title     string      `json:"title"`
+	Body      string      `json:"body"`
+	Document  interface{} `json:"document"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type Session struct {
+	Jti        string    `json:"jti"`
+	UserID     uuid.UUID `json:"userId"`
+	IpAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	SessionID  string    `json:"sessionId"`
+}
+
+type SystemAlert struct {
+	ID        int64     `json:"id"`
 	Title     string    `json:"title"`
-	Content   string    `json:"content"`
+	Message   string    `json:"message"`
+	Severity  string    `json:"severity"`
 	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type TotpRecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userId"`
+	CodeHash  string     `json:"codeHash"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type Upload struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      *uuid.UUID `json:"userId"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        uint64     `json:"size"`
+	FileKey     string     `json:"fileKey"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CompletedAt *time.Time `json:"completedAt"`
 }
 
 type User struct {
-	ID             uuid.UUID   `json:"id"`
-	Email          string      `json:"email"`
-	AuthProvider   string      `json:"authProvider"`
-	AuthProviderID *string     `json:"authProviderId"`
-	AccountType    AccountType `json:"accountType"`
-	CreatedAt      *time.Time  `json:"createdAt"`
-	UpdatedAt      *time.Time  `json:"updatedAt"`
+	ID              uuid.UUID   `json:"id"`
+	Email           string      `json:"email"`
+	AuthProvider    string      `json:"authProvider"`
+	AuthProviderID  *string     `json:"authProviderId"`
+	AccountType     AccountType `json:"accountType"`
+	CreatedAt       *time.Time  `json:"createdAt"`
+	UpdatedAt       *time.Time  `json:"updatedAt"`
+	Phone           *string     `json:"phone"`
+	PhoneVerifiedAt *time.Time  `json:"phoneVerifiedAt"`
+	EmailVerified   bool        `json:"emailVerified"`
+	EmailVerifiedAt *time.Time  `json:"emailVerifiedAt"`
+	Status          UserStatus  `json:"status"`
+	SuspendedReason *string     `json:"suspendedReason"`
+	SuspendedAt     *time.Time  `json:"suspendedAt"`
+	FirstName       string      `json:"firstName"`
+	LastName        string      `json:"lastName"`
+	DisplayName     string      `json:"displayName"`
+	Timezone        string      `json:"timezone"`
+	Locale          string      `json:"locale"`
+	Metadata        []byte      `json:"metadata"`
+	AvatarUrl       string      `json:"avatarUrl"`
+	LastLoginAt     *time.Time  `json:"lastLoginAt"`
+	LastLoginIp     string      `json:"lastLoginIp"`
+}
+
+type UserDeletionRequest struct {
+	UserID       uuid.UUID `json:"userId"`
+	RequestedAt  time.Time `json:"requestedAt"`
+	ScheduledFor time.Time `json:"scheduledFor"`
+}
+
+type UserPreference struct {
+	UserID      uuid.UUID `json:"userId"`
+	Preferences []byte    `json:"preferences"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type UserRole struct {
+	UserID     uuid.UUID  `json:"userId"`
+	RoleID     uuid.UUID  `json:"roleId"`
+	AssignedBy *uuid.UUID `json:"assignedBy"`
+	AssignedAt time.Time  `json:"assignedAt"`
+}
+
+type UserTombstone struct {
+	ID           uuid.UUID   `json:"id"`
+	UserID       *uuid.UUID  `json:"userId"`
+	AccountType  AccountType `json:"accountType"`
+	AuthProvider string      `json:"authProvider"`
+	SignedUpAt   time.Time   `json:"signedUpAt"`
+	DeletedAt    time.Time   `json:"deletedAt"`
+	AnonymizedAt *time.Time  `json:"anonymizedAt"`
+}
+
+type UserTotp struct {
+	UserID         uuid.UUID  `json:"userId"`
+	Secret         string     `json:"secret"`
+	EnabledAt      *time.Time `json:"enabledAt"`
+	LastUsedStep   int64      `json:"lastUsedStep"`
+	FailedAttempts int32      `json:"failedAttempts"`
+	LastFailedAt   *time.Time `json:"lastFailedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type Webhook struct {
+	ID        uuid.UUID  `json:"id"`
+	Url       string     `json:"url"`
+	Secret    string     `json:"secret"`
+	Events    []string   `json:"events"`
+	CreatedBy *uuid.UUID `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID           uuid.UUID  `json:"id"`
+	WebhookID    uuid.UUID  `json:"webhookId"`
+	Event        string     `json:"event"`
+	Payload      []byte     `json:"payload"`
+	Status       string     `json:"status"`
+	StatusCode   int32      `json:"statusCode"`
+	Error        string     `json:"error"`
+	LatencyMs    int32      `json:"latencyMs"`
+	ResponseBody string     `json:"responseBody"`
+	RedeliveryOf *uuid.UUID `json:"redeliveryOf"`
+	CreatedAt    time.Time  `json:"createdAt"`
 }
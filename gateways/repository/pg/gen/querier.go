@@ -6,28 +6,244 @@ package gen
 
 import (
 	"context"
+	"time"
 
 	uuid "github.com/gofrs/uuid/v5"
 )
 
 type Querier interface {
+	AddOutboxMessage(ctx context.Context, arg AddOutboxMessageParams) error
+	ArchiveExample(ctx context.Context, id uuid.UUID) (Example, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID, assignedBy *uuid.UUID, assignedAt time.Time) error
 	BulkUpsertAdminSettings(ctx context.Context, column1 []string, column2 [][]byte) error
+	CancelJob(ctx context.Context, finishedAt time.Time, id uuid.UUID) (int64, error)
+	ClaimExportJob(ctx context.Context, now time.Time, staleBefore time.Time) (ExportJob, error)
+	ClaimJob(ctx context.Context, now time.Time, leaseUntil time.Time, kinds []string) (Job, error)
+	ClaimOutboxMessages(ctx context.Context, leaseUntil time.Time, now time.Time, pageLimit int32) ([]OutboxMessage, error)
+	CompleteBackup(ctx context.Context, fileKey string, size int64, finishedAt time.Time, id uuid.UUID) error
+	CompleteExportJob(ctx context.Context, fileKey string, rowCount int32, finishedAt time.Time, id uuid.UUID) error
+	CompleteJob(ctx context.Context, finishedAt time.Time, id uuid.UUID) error
+	CompleteUpload(ctx context.Context, completedAt time.Time, id uuid.UUID) (int64, error)
+	ConsumeOAuthAuthorizationCode(ctx context.Context, codeHash string) (OauthAuthorizationCode, error)
+	ConsumeOTPCode(ctx context.Context, id uuid.UUID) (int64, error)
+	CountActiveSessions(ctx context.Context, lastSeenAt time.Time) (int64, error)
+	CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error)
+	CountBackups(ctx context.Context) (int64, error)
+	CountComments(ctx context.Context, exampleID uuid.UUID) (int64, error)
+	CountExamples(ctx context.Context, includeArchived bool) (int64, error)
+	CountJobs(ctx context.Context, status *string, kind *string) (int64, error)
+	CountJobsByStatus(ctx context.Context) ([]CountJobsByStatusRow, error)
+	CountNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) (int64, error)
+	CountUnusedTOTPRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountSearchUsers(ctx context.Context, emailPattern *string, accountType *string) (int64, error)
+	CountSystemAlertsSince(ctx context.Context, createdAt time.Time) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CountUsersByAccountType(ctx context.Context, accountType AccountType) (int64, error)
-	CreateExample(ctx context.Context, title string, content string) (uuid.UUID, error)
+	CountUsersByStatus(ctx context.Context, status UserStatus) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status *string) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CreateAnnouncement(ctx context.Context, arg CreateAnnouncementParams) error
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) error
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateBackup(ctx context.Context, id uuid.UUID, trigger string, createdBy *uuid.UUID, createdAt time.Time) error
+	CreateBreakGlassCredential(ctx context.Context, id uuid.UUID, credentialHash string, createdAt time.Time) error
+	CreateComment(ctx context.Context, arg CreateCommentParams) error
+	CreateEmailChangeToken(ctx context.Context, arg CreateEmailChangeTokenParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error
+	CreateExample(ctx context.Context, title string, content string, createdBy *uuid.UUID) (uuid.UUID, error)
+	CreateExportJob(ctx context.Context, arg CreateExportJobParams) error
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) error
+	CreateLocalCredential(ctx context.Context, arg CreateLocalCredentialParams) error
+	CreateLoginEvent(ctx context.Context, arg CreateLoginEventParams) error
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) error
+	CreateOAuthAuthorizationCode(ctx context.Context, arg CreateOAuthAuthorizationCodeParams) error
+	CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) error
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateRole(ctx context.Context, arg CreateRoleParams) error
+	CreateScheduledBackup(ctx context.Context, id uuid.UUID, createdAt time.Time, since time.Time) (int64, error)
+	CreateSystemAlert(ctx context.Context, title string, message string, severity string, createdAt time.Time) error
+	CreateUpload(ctx context.Context, arg CreateUploadParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
+	CreateUserDeletionRequest(ctx context.Context, userID uuid.UUID, requestedAt time.Time, scheduledFor time.Time) (UserDeletionRequest, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DeleteAdminPermissions(ctx context.Context, userID uuid.UUID) error
 	DeleteAdminSetting(ctx context.Context, key string) error
+	DeleteAnnouncement(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteArchivedExamples(ctx context.Context, archivedBefore time.Time, batchSize int32) (int64, error)
+	DeleteAttachment(ctx context.Context, id uuid.UUID) error
+	DeleteBackup(ctx context.Context, id uuid.UUID) error
+	DeleteComment(ctx context.Context, id uuid.UUID, exampleID uuid.UUID) (int64, error)
+	DeleteExample(ctx context.Context, id uuid.UUID, expectedUpdatedAt *time.Time) (int64, error)
+	DeleteExpiredOAuthAuthorizationCodes(ctx context.Context) error
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	DeleteExportJob(ctx context.Context, id uuid.UUID) error
+	DeleteLocalCredential(ctx context.Context, id uuid.UUID) error
+	DeleteOAuthClient(ctx context.Context, clientID string) (int64, error)
+	DeletePublishedOutboxMessages(ctx context.Context, publishedBefore time.Time) (int64, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteSession(ctx context.Context, sessionID string) (int64, error)
+	DeadLetterJob(ctx context.Context, message string, finishedAt time.Time, id uuid.UUID) error
+	DeleteSucceededJobs(ctx context.Context, finishedBefore time.Time) (int64, error)
+	DeleteUpload(ctx context.Context, id uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserDeletionRequest(ctx context.Context, userID uuid.UUID) (int64, error)
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	DeleteUserTOTP(ctx context.Context, userID uuid.UUID) error
+	DeleteUsers(ctx context.Context, ids []uuid.UUID) (int64, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error)
+	DetachAttachment(ctx context.Context, id uuid.UUID, exampleID uuid.UUID) (int64, error)
+	DismissAnnouncement(ctx context.Context, announcementID uuid.UUID, userID uuid.UUID, dismissedAt time.Time) error
+	EnableUserTOTP(ctx context.Context, userID uuid.UUID, enabledAt *time.Time) error
+	EnqueueJob(ctx context.Context, arg EnqueueJobParams) error
+	FailBackup(ctx context.Context, message string, finishedAt time.Time, id uuid.UUID) error
+	FailExportJob(ctx context.Context, message string, finishedAt time.Time, id uuid.UUID) error
+	FailStaleBackups(ctx context.Context, message string, finishedAt time.Time, startedBefore time.Time) (int64, error)
+	GetAPIKey(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetAdminPermissions(ctx context.Context, userID uuid.UUID) (AdminPermission, error)
 	GetAdminSetting(ctx context.Context, key string) (AdminSetting, error)
 	GetAllAdminSettings(ctx context.Context) ([]AdminSetting, error)
+	GetAnnouncement(ctx context.Context, id uuid.UUID) (Announcement, error)
+	GetAttachment(ctx context.Context, id uuid.UUID, exampleID uuid.UUID) (Attachment, error)
+	GetBackup(ctx context.Context, id uuid.UUID) (Backup, error)
+	GetComment(ctx context.Context, id uuid.UUID, exampleID uuid.UUID) (Comment, error)
+	GetEmailChangeTokenByHash(ctx context.Context, tokenHash string) (EmailChangeToken, error)
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
 	GetExampleByID(ctx context.Context, id uuid.UUID) (Example, error)
+	GetExportJob(ctx context.Context, id uuid.UUID) (ExportJob, error)
+	GetInvitation(ctx context.Context, id uuid.UUID) (Invitation, error)
+	GetInvitationByCodeHash(ctx context.Context, codeHash string) (Invitation, error)
+	GetJob(ctx context.Context, id uuid.UUID) (Job, error)
+	GetLatestEmailChangeToken(ctx context.Context, userID uuid.UUID) (EmailChangeToken, error)
+	GetLatestEmailVerificationToken(ctx context.Context, userID uuid.UUID) (EmailVerificationToken, error)
+	GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (PasswordResetToken, error)
+	GetLocalCredentialByEmail(ctx context.Context, email string) (LocalCredential, error)
+	GetOAuthClient(ctx context.Context, clientID string) (OauthClient, error)
+	GetOAuthConsent(ctx context.Context, userID uuid.UUID, clientID string) (OauthConsent, error)
+	GetOTPCode(ctx context.Context, phone string, purpose string) (OtpCode, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetRole(ctx context.Context, id uuid.UUID) (GetRoleRow, error)
+	GetSession(ctx context.Context, sessionID string) (Session, error)
+	GetUpload(ctx context.Context, id uuid.UUID, userID uuid.UUID) (Upload, error)
 	GetUserByAuthProviderID(ctx context.Context, authProvider string, authProviderID *string) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	GetUserByPhone(ctx context.Context, phone *string) (User, error)
+	GetUserDeletionRequest(ctx context.Context, userID uuid.UUID) (UserDeletionRequest, error)
+	GetUserPreferences(ctx context.Context, userID uuid.UUID) ([]byte, error)
 	GetUserStats(ctx context.Context) (GetUserStatsRow, error)
-	ListUsers(ctx context.Context, limit int32, offset int32) ([]User, error)
+	GetUserTOTP(ctx context.Context, userID uuid.UUID) (UserTotp, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	HasUnusedBreakGlassCredential(ctx context.Context) (bool, error)
+	IncrementOTPCodeAttempts(ctx context.Context, id uuid.UUID) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	IsUserTokenRevoked(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (bool, error)
+	ListAPIKeys(ctx context.Context) ([]ApiKey, error)
+	// Announcements the user dismissed are left out.
+	ListActiveAnnouncements(ctx context.Context, now time.Time, userID uuid.UUID) ([]Announcement, error)
+	ListAnnouncements(ctx context.Context) ([]Announcement, error)
+	ListAttachments(ctx context.Context, exampleID uuid.UUID) ([]Attachment, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	// Comments are listed oldest first, so they read as a conversation.
+	ListBackups(ctx context.Context, pageLimit int32, pageOffset int32) ([]Backup, error)
+	ListComments(ctx context.Context, exampleID uuid.UUID, pageLimit int32, pageOffset int32) ([]Comment, error)
+	ListDueUserDeletionRequests(ctx context.Context, scheduledFor time.Time, limit int32) ([]UserDeletionRequest, error)
+	ListExamples(ctx context.Context, includeArchived bool, sortDesc bool, pageLimit int32, pageOffset int32) ([]Example, error)
+	ListExpiredBackups(ctx context.Context, createdBefore time.Time, pageLimit int32) ([]Backup, error)
+	ListExpiredExportJobs(ctx context.Context, finishedBefore time.Time, pageLimit int32) ([]ExportJob, error)
+	ListInvitations(ctx context.Context) ([]Invitation, error)
+	ListJobs(ctx context.Context, status *string, kind *string, pageLimit int32, pageOffset int32) ([]Job, error)
+	ListLocalCredentials(ctx context.Context) ([]LocalCredential, error)
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, pageLimit int32, pageOffset int32) ([]Notification, error)
+	ListOAuthClients(ctx context.Context) ([]OauthClient, error)
+	ListOrphanedAttachments(ctx context.Context, pageLimit int32) ([]Attachment, error)
+	ListPendingUserTombstones(ctx context.Context, limit int32) ([]UserTombstone, error)
+	ListRoles(ctx context.Context) ([]ListRolesRow, error)
+	// Pending uploads that expired, and uploads whose user was deleted.
+	ListStaleUploads(ctx context.Context, now time.Time, pageLimit int32) ([]Upload, error)
+	ListUserAPIKeys(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
+	ListUserLoginEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]LoginEvent, error)
+	ListUserRoles(ctx context.Context, userID uuid.UUID, name string) ([]ListUserRolesRow, error)
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	// sort_by is email, account_type or created_at; anything else sorts by
+	// created_at. Ties, and the default, are newest first.
+	ListUsers(ctx context.Context, sortBy string, sortDesc bool, pageLimit int32, pageOffset int32) ([]User, error)
+	ListUsersByAuthProvider(ctx context.Context, authProvider string) ([]User, error)
+	ListUsersByStatus(ctx context.Context, status UserStatus, limit int32, offset int32) ([]User, error)
+	// ListUsers with the number of users on every row, which saves counting
+	// them in a second round trip. Pages past the end have no rows to carry it.
+	ListUsersWithTotal(ctx context.Context, sortBy string, sortDesc bool, pageLimit int32, pageOffset int32) ([]ListUsersWithTotalRow, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status *string, pageLimit int32, pageOffset int32) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	ListWebhooksForEvent(ctx context.Context, event string) ([]Webhook, error)
+	MarkAllNotificationsRead(ctx context.Context, readAt time.Time, userID uuid.UUID) (int64, error)
+	// Notifications read already keep the time they were first read.
+	MarkNotificationRead(ctx context.Context, readAt time.Time, id uuid.UUID, userID uuid.UUID) (int64, error)
+	MarkOutboxMessagePublished(ctx context.Context, publishedAt time.Time, id uuid.UUID) error
+	MarkUserTombstoneAnonymized(ctx context.Context, id uuid.UUID) (int64, error)
+	ReassignAuditEvents(ctx context.Context, userID uuid.UUID, tombstoneID uuid.UUID) error
+	RecordUserTOTPFailure(ctx context.Context, userID uuid.UUID) error
+	RedeemBreakGlassCredential(ctx context.Context, credentialHash string, usedFrom *string) (BreakGlassCredential, error)
+	RedeemInvitation(ctx context.Context, codeHash string, email string) (Invitation, error)
+	ReleaseInvitation(ctx context.Context, id uuid.UUID) error
+	ReplaceTOTPRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error
+	RequeueJob(ctx context.Context, runAt time.Time, id uuid.UUID) (int64, error)
+	RetryJob(ctx context.Context, message string, runAt time.Time, id uuid.UUID) error
+	RetryOutboxMessage(ctx context.Context, message string, retryAt time.Time, id uuid.UUID) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeInvitation(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time, revokedAt time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+	// Matches use web search syntax: quoted phrases, OR, and -word to exclude.
+	// Matched words in the highlight are wrapped in \x02 and \x03, which the
+	// repository turns into HTML.
+	SearchDocuments(ctx context.Context, query string, kinds []string, pageLimit int32) ([]SearchDocumentsRow, error)
+	// sort_by is email, account_type or created_at; anything else sorts by
+	// created_at. Ties, and the default, are newest first.
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	// SearchUsersAfter pages through SearchUsers by keyset instead of offset: it
+	// lists the users that come after (cursor_created_at, cursor_id), or from the
+	// newest when the cursor is NULL.
+	SearchUsersAfter(ctx context.Context, arg SearchUsersAfterParams) ([]User, error)
+	SetUserAvatarURL(ctx context.Context, id uuid.UUID, avatarUrl string) (int64, error)
+	SetUserEmail(ctx context.Context, id uuid.UUID, email string, emailVerifiedAt *time.Time) (int64, error)
+	SetUserEmailVerified(ctx context.Context, id uuid.UUID, email string, emailVerifiedAt *time.Time) (int64, error)
+	SetUserLastLogin(ctx context.Context, id uuid.UUID, lastLoginAt *time.Time, lastLoginIp string) error
+	SetUserPhone(ctx context.Context, id uuid.UUID, phone *string, phoneVerifiedAt *time.Time) error
+	SetUserProfile(ctx context.Context, arg SetUserProfileParams) (int64, error)
+	SetUserStatus(ctx context.Context, id uuid.UUID, status UserStatus, suspendedReason *string, suspendedAt *time.Time) (int64, error)
+	SetUsersAccountType(ctx context.Context, accountType AccountType, updatedAt *time.Time, ids []uuid.UUID) (int64, error)
+	SetUsersStatus(ctx context.Context, status UserStatus, suspendedReason *string, suspendedAt *time.Time, ids []uuid.UUID) (int64, error)
+	StartBackup(ctx context.Context, startedAt time.Time, id uuid.UUID) (int64, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+	UnarchiveExample(ctx context.Context, id uuid.UUID) (Example, error)
+	UnassignRole(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) (int64, error)
+	UpdateAnnouncement(ctx context.Context, arg UpdateAnnouncementParams) (int64, error)
+	UpdateExample(ctx context.Context, title string, content string, id uuid.UUID, expectedUpdatedAt time.Time) (Example, error)
+	UpdateLocalCredentialEmail(ctx context.Context, id uuid.UUID, email string, updatedAt time.Time) (int64, error)
+	UpdateLocalCredentialPassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) (int64, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, permissions []string, description string, updatedAt time.Time) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpsertAdminPermissions(ctx context.Context, userID uuid.UUID, permissions []string, updatedBy *uuid.UUID, updatedAt time.Time) error
 	UpsertAdminSetting(ctx context.Context, key string, value []byte) error
+	UpsertOAuthConsent(ctx context.Context, userID uuid.UUID, clientID string, scope string, grantedAt time.Time) error
+	UpsertOTPCode(ctx context.Context, arg UpsertOTPCodeParams) error
+	UpsertSession(ctx context.Context, arg UpsertSessionParams) error
+	UpsertUserPreferences(ctx context.Context, userID uuid.UUID, preferences []byte) error
+	UpsertUserTOTP(ctx context.Context, userID uuid.UUID, secret string, createdAt time.Time) error
+	UseEmailChangeToken(ctx context.Context, id uuid.UUID, usedAt *time.Time) (int64, error)
+	UseEmailVerificationToken(ctx context.Context, id uuid.UUID, usedAt *time.Time) (int64, error)
+	UsePasswordResetToken(ctx context.Context, id uuid.UUID, usedAt *time.Time) (int64, error)
+	UseRefreshToken(ctx context.Context, id uuid.UUID) (int64, error)
+	UseTOTPRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt *time.Time) (int64, error)
+	UseUserTOTPStep(ctx context.Context, userID uuid.UUID, lastUsedStep int64) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,6 +12,20 @@ import (
 	uuid "github.com/gofrs/uuid/v5"
 )
 
+const countSearchUsers = `-- name: CountSearchUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE ($1::TEXT IS NULL OR email ILIKE $1)
+    AND ($2::TEXT IS NULL OR account_type::TEXT = $2)
+`
+
+func (q *Queries) CountSearchUsers(ctx context.Context, emailPattern *string, accountType *string) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchUsers, emailPattern, accountType)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 `
@@ -34,19 +48,33 @@ func (q *Queries) CountUsersByAccountType(ctx context.Context, accountType Accou
 	return count, err
 }
 
+const countUsersByStatus = `-- name: CountUsersByStatus :one
+SELECT COUNT(*) FROM users WHERE status = $1
+`
+
+func (q *Queries) CountUsersByStatus(ctx context.Context, status UserStatus) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO users (id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, email_verified, email_verified_at, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 `
 
 type CreateUserParams struct {
-	ID             uuid.UUID   `json:"id"`
-	Email          string      `json:"email"`
-	AuthProvider   string      `json:"authProvider"`
-	AuthProviderID *string     `json:"authProviderId"`
-	AccountType    AccountType `json:"accountType"`
-	CreatedAt      *time.Time  `json:"createdAt"`
-	UpdatedAt      *time.Time  `json:"updatedAt"`
+	ID              uuid.UUID   `json:"id"`
+	Email           string      `json:"email"`
+	AuthProvider    string      `json:"authProvider"`
+	AuthProviderID  *string     `json:"authProviderId"`
+	AccountType     AccountType `json:"accountType"`
+	CreatedAt       *time.Time  `json:"createdAt"`
+	UpdatedAt       *time.Time  `json:"updatedAt"`
+	EmailVerified   bool        `json:"emailVerified"`
+	EmailVerifiedAt *time.Time  `json:"emailVerifiedAt"`
+	Status          UserStatus  `json:"status"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
@@ -58,13 +86,22 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 		arg.AccountType,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.EmailVerified,
+		arg.EmailVerifiedAt,
+		arg.Status,
 	)
 	return err
 }
 
 const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users
-WHERE id = $1
+WITH deleted AS (
+    DELETE FROM users
+    WHERE id = $1
+    RETURNING id, account_type, auth_provider, created_at
+)
+INSERT INTO user_tombstones (user_id, account_type, auth_provider, signed_up_at)
+SELECT id, account_type, auth_provider, COALESCE(created_at, NOW())
+FROM deleted
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
@@ -72,8 +109,28 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteUsers = `-- name: DeleteUsers :execrows
+WITH deleted AS (
+    DELETE FROM users
+    WHERE id = ANY($1::UUID[])
+    RETURNING id, account_type, auth_provider, created_at
+)
+INSERT INTO user_tombstones (user_id, account_type, auth_provider, signed_up_at)
+SELECT id, account_type, auth_provider, COALESCE(created_at, NOW())
+FROM deleted
+`
+
+func (q *Queries) DeleteUsers(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUsers, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUserByAuthProviderID = `-- name: GetUserByAuthProviderID :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE auth_provider = $1 AND auth_provider_id = $2
 `
@@ -89,12 +146,29 @@ func (q *Queries) GetUserByAuthProviderID(ctx context.Context, authProvider stri
 		&i.AccountType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.Status,
+		&i.SuspendedReason,
+		&i.SuspendedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.DisplayName,
+		&i.Timezone,
+		&i.Locale,
+		&i.Metadata,
+		&i.AvatarUrl,
+		&i.LastLoginAt,
+		&i.LastLoginIp,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE email = $1
 `
@@ -110,12 +184,29 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.AccountType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.Status,
+		&i.SuspendedReason,
+		&i.SuspendedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.DisplayName,
+		&i.Timezone,
+		&i.Locale,
+		&i.Metadata,
+		&i.AvatarUrl,
+		&i.LastLoginAt,
+		&i.LastLoginIp,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE id = $1
 `
@@ -131,6 +222,60 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.AccountType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.Status,
+		&i.SuspendedReason,
+		&i.SuspendedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.DisplayName,
+		&i.Timezone,
+		&i.Locale,
+		&i.Metadata,
+		&i.AvatarUrl,
+		&i.LastLoginAt,
+		&i.LastLoginIp,
+	)
+	return i, err
+}
+
+const getUserByPhone = `-- name: GetUserByPhone :one
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE phone = $1
+`
+
+func (q *Queries) GetUserByPhone(ctx context.Context, phone *string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByPhone, phone)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.AuthProvider,
+		&i.AuthProviderID,
+		&i.AccountType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.EmailVerified,
+		&i.EmailVerifiedAt,
+		&i.Status,
+		&i.SuspendedReason,
+		&i.SuspendedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.DisplayName,
+		&i.Timezone,
+		&i.Locale,
+		&i.Metadata,
+		&i.AvatarUrl,
+		&i.LastLoginAt,
+		&i.LastLoginIp,
 	)
 	return i, err
 }
@@ -141,7 +286,9 @@ SELECT
     COUNT(CASE WHEN account_type = 'admin' THEN 1 END) as admin_users,
     COUNT(CASE WHEN account_type = 'super_admin' THEN 1 END) as super_admin_users,
     COUNT(CASE WHEN account_type = 'user' THEN 1 END) as regular_users,
-    COUNT(CASE WHEN created_at >= NOW() - INTERVAL '7 days' THEN 1 END) as recent_signups
+    COUNT(CASE WHEN created_at >= NOW() - INTERVAL '7 days' THEN 1 END)
+        + (SELECT COUNT(*) FROM user_tombstones WHERE signed_up_at >= NOW() - INTERVAL '7 days') as recent_signups,
+    (SELECT COUNT(*) FROM user_tombstones) as deleted_users
 FROM users
 `
 
@@ -151,6 +298,7 @@ type GetUserStatsRow struct {
 	SuperAdminUsers int64 `json:"superAdminUsers"`
 	RegularUsers    int64 `json:"regularUsers"`
 	RecentSignups   int64 `json:"recentSignups"`
+	DeletedUsers    int64 `json:"deletedUsers"`
 }
 
 func (q *Queries) GetUserStats(ctx context.Context) (GetUserStatsRow, error) {
@@ -162,19 +310,134 @@ func (q *Queries) GetUserStats(ctx context.Context) (GetUserStatsRow, error) {
 		&i.SuperAdminUsers,
 		&i.RegularUsers,
 		&i.RecentSignups,
+		&i.DeletedUsers,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+ORDER BY
+    CASE WHEN $1::TEXT = 'email' AND NOT $2::BOOLEAN THEN email END ASC,
+    CASE WHEN $1::TEXT = 'email' AND $2::BOOLEAN THEN email END DESC,
+    CASE WHEN $1::TEXT = 'account_type' AND NOT $2::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN $1::TEXT = 'account_type' AND $2::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN $1::TEXT = 'created_at' AND NOT $2::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT $3 OFFSET $4
+`
+
+// sort_by is email, account_type or created_at; anything else sorts by
+// created_at. Ties, and the default, are newest first.
+func (q *Queries) ListUsers(ctx context.Context, sortBy string, sortDesc bool, pageLimit int32, pageOffset int32) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers, sortBy, sortDesc, pageLimit, pageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.AuthProvider,
+			&i.AuthProviderID,
+			&i.AccountType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.EmailVerified,
+			&i.EmailVerifiedAt,
+			&i.Status,
+			&i.SuspendedReason,
+			&i.SuspendedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.DisplayName,
+			&i.Timezone,
+			&i.Locale,
+			&i.Metadata,
+			&i.AvatarUrl,
+			&i.LastLoginAt,
+			&i.LastLoginIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByAuthProvider = `-- name: ListUsersByAuthProvider :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE auth_provider = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListUsersByAuthProvider(ctx context.Context, authProvider string) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByAuthProvider, authProvider)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.AuthProvider,
+			&i.AuthProviderID,
+			&i.AccountType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.EmailVerified,
+			&i.EmailVerifiedAt,
+			&i.Status,
+			&i.SuspendedReason,
+			&i.SuspendedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.DisplayName,
+			&i.Timezone,
+			&i.Locale,
+			&i.Metadata,
+			&i.AvatarUrl,
+			&i.LastLoginAt,
+			&i.LastLoginIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByStatus = `-- name: ListUsersByStatus :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3
 `
 
-func (q *Queries) ListUsers(ctx context.Context, limit int32, offset int32) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers, limit, offset)
+func (q *Queries) ListUsersByStatus(ctx context.Context, status UserStatus, limit int32, offset int32) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByStatus, status, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +453,22 @@ func (q *Queries) ListUsers(ctx context.Context, limit int32, offset int32) ([]U
 			&i.AccountType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.EmailVerified,
+			&i.EmailVerifiedAt,
+			&i.Status,
+			&i.SuspendedReason,
+			&i.SuspendedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.DisplayName,
+			&i.Timezone,
+			&i.Locale,
+			&i.Metadata,
+			&i.AvatarUrl,
+			&i.LastLoginAt,
+			&i.LastLoginIp,
 		); err != nil {
 			return nil, err
 		}
@@ -201,9 +480,365 @@ func (q *Queries) ListUsers(ctx context.Context, limit int32, offset int32) ([]U
 	return items, nil
 }
 
+const listUsersWithTotal = `-- name: ListUsersWithTotal :many
+SELECT users.id, users.email, users.auth_provider, users.auth_provider_id, users.account_type, users.created_at, users.updated_at, users.phone, users.phone_verified_at, users.email_verified, users.email_verified_at, users.status, users.suspended_reason, users.suspended_at, users.first_name, users.last_name, users.display_name, users.timezone, users.locale, users.metadata, users.avatar_url, users.last_login_at, users.last_login_ip, COUNT(*) OVER() AS total
+FROM users
+ORDER BY
+    CASE WHEN $1::TEXT = 'email' AND NOT $2::BOOLEAN THEN email END ASC,
+    CASE WHEN $1::TEXT = 'email' AND $2::BOOLEAN THEN email END DESC,
+    CASE WHEN $1::TEXT = 'account_type' AND NOT $2::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN $1::TEXT = 'account_type' AND $2::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN $1::TEXT = 'created_at' AND NOT $2::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListUsersWithTotalRow struct {
+	User  User  `json:"user"`
+	Total int64 `json:"total"`
+}
+
+// ListUsers with the number of users on every row, which saves counting
+// them in a second round trip. Pages past the end have no rows to carry it.
+func (q *Queries) ListUsersWithTotal(ctx context.Context, sortBy string, sortDesc bool, pageLimit int32, pageOffset int32) ([]ListUsersWithTotalRow, error) {
+	rows, err := q.db.Query(ctx, listUsersWithTotal, sortBy, sortDesc, pageLimit, pageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersWithTotalRow
+	for rows.Next() {
+		var i ListUsersWithTotalRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Email,
+			&i.User.AuthProvider,
+			&i.User.AuthProviderID,
+			&i.User.AccountType,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.User.Phone,
+			&i.User.PhoneVerifiedAt,
+			&i.User.EmailVerified,
+			&i.User.EmailVerifiedAt,
+			&i.User.Status,
+			&i.User.SuspendedReason,
+			&i.User.SuspendedAt,
+			&i.User.FirstName,
+			&i.User.LastName,
+			&i.User.DisplayName,
+			&i.User.Timezone,
+			&i.User.Locale,
+			&i.User.Metadata,
+			&i.User.AvatarUrl,
+			&i.User.LastLoginAt,
+			&i.User.LastLoginIp,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE ($1::TEXT IS NULL OR email ILIKE $1)
+    AND ($2::TEXT IS NULL OR account_type::TEXT = $2)
+ORDER BY
+    CASE WHEN $3::TEXT = 'email' AND NOT $4::BOOLEAN THEN email END ASC,
+    CASE WHEN $3::TEXT = 'email' AND $4::BOOLEAN THEN email END DESC,
+    CASE WHEN $3::TEXT = 'account_type' AND NOT $4::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN $3::TEXT = 'account_type' AND $4::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN $3::TEXT = 'created_at' AND NOT $4::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT $5 OFFSET $6
+`
+
+type SearchUsersParams struct {
+	EmailPattern *string `json:"emailPattern"`
+	AccountType  *string `json:"accountType"`
+	SortBy       string  `json:"sortBy"`
+	SortDesc     bool    `json:"sortDesc"`
+	PageLimit    int32   `json:"pageLimit"`
+	PageOffset   int32   `json:"pageOffset"`
+}
+
+// sort_by is email, account_type or created_at; anything else sorts by
+// created_at. Ties, and the default, are newest first.
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers,
+		arg.EmailPattern,
+		arg.AccountType,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.AuthProvider,
+			&i.AuthProviderID,
+			&i.AccountType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.EmailVerified,
+			&i.EmailVerifiedAt,
+			&i.Status,
+			&i.SuspendedReason,
+			&i.SuspendedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.DisplayName,
+			&i.Timezone,
+			&i.Locale,
+			&i.Metadata,
+			&i.AvatarUrl,
+			&i.LastLoginAt,
+			&i.LastLoginIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsersAfter = `-- name: SearchUsersAfter :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE ($1::TEXT IS NULL OR email ILIKE $1)
+    AND ($2::TEXT IS NULL OR account_type::TEXT = $2)
+    AND ($3::TIMESTAMPTZ IS NULL OR (created_at, id) < ($3, $4::UUID))
+ORDER BY created_at DESC, id DESC
+LIMIT $5
+`
+
+type SearchUsersAfterParams struct {
+	EmailPattern    *string    `json:"emailPattern"`
+	AccountType     *string    `json:"accountType"`
+	CursorCreatedAt *time.Time `json:"cursorCreatedAt"`
+	CursorID        *uuid.UUID `json:"cursorId"`
+	PageLimit       int32      `json:"pageLimit"`
+}
+
+// SearchUsersAfter pages through SearchUsers by keyset instead of offset: it
+// lists the users that come after (cursor_created_at, cursor_id), or from the
+// newest when the cursor is NULL.
+func (q *Queries) SearchUsersAfter(ctx context.Context, arg SearchUsersAfterParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsersAfter,
+		arg.EmailPattern,
+		arg.AccountType,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.AuthProvider,
+			&i.AuthProviderID,
+			&i.AccountType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.EmailVerified,
+			&i.EmailVerifiedAt,
+			&i.Status,
+			&i.SuspendedReason,
+			&i.SuspendedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.DisplayName,
+			&i.Timezone,
+			&i.Locale,
+			&i.Metadata,
+			&i.AvatarUrl,
+			&i.LastLoginAt,
+			&i.LastLoginIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserAvatarURL = `-- name: SetUserAvatarURL :execrows
+UPDATE users
+SET avatar_url = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetUserAvatarURL(ctx context.Context, id uuid.UUID, avatarUrl string) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserAvatarURL, id, avatarUrl)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUserEmail = `-- name: SetUserEmail :execrows
+UPDATE users
+SET email = $2, email_verified = TRUE, email_verified_at = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetUserEmail(ctx context.Context, id uuid.UUID, email string, emailVerifiedAt *time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserEmail, id, email, emailVerifiedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUserEmailVerified = `-- name: SetUserEmailVerified :execrows
+UPDATE users
+SET email_verified = TRUE, email_verified_at = $3, updated_at = NOW()
+WHERE id = $1 AND email = $2
+`
+
+func (q *Queries) SetUserEmailVerified(ctx context.Context, id uuid.UUID, email string, emailVerifiedAt *time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserEmailVerified, id, email, emailVerifiedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUserLastLogin = `-- name: SetUserLastLogin :exec
+UPDATE users
+SET last_login_at = $2, last_login_ip = $3
+WHERE id = $1
+`
+
+func (q *Queries) SetUserLastLogin(ctx context.Context, id uuid.UUID, lastLoginAt *time.Time, lastLoginIp string) error {
+	_, err := q.db.Exec(ctx, setUserLastLogin, id, lastLoginAt, lastLoginIp)
+	return err
+}
+
+const setUserPhone = `-- name: SetUserPhone :exec
+UPDATE users
+SET phone = $2, phone_verified_at = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetUserPhone(ctx context.Context, id uuid.UUID, phone *string, phoneVerifiedAt *time.Time) error {
+	_, err := q.db.Exec(ctx, setUserPhone, id, phone, phoneVerifiedAt)
+	return err
+}
+
+const setUserProfile = `-- name: SetUserProfile :execrows
+UPDATE users
+SET first_name = $2, last_name = $3, display_name = $4, timezone = $5, locale = $6, metadata = $7, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetUserProfileParams struct {
+	ID          uuid.UUID `json:"id"`
+	FirstName   string    `json:"firstName"`
+	LastName    string    `json:"lastName"`
+	DisplayName string    `json:"displayName"`
+	Timezone    string    `json:"timezone"`
+	Locale      string    `json:"locale"`
+	Metadata    []byte    `json:"metadata"`
+}
+
+func (q *Queries) SetUserProfile(ctx context.Context, arg SetUserProfileParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserProfile,
+		arg.ID,
+		arg.FirstName,
+		arg.LastName,
+		arg.DisplayName,
+		arg.Timezone,
+		arg.Locale,
+		arg.Metadata,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUserStatus = `-- name: SetUserStatus :execrows
+UPDATE users
+SET status = $2, suspended_reason = $3, suspended_at = $4, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetUserStatus(ctx context.Context, id uuid.UUID, status UserStatus, suspendedReason *string, suspendedAt *time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserStatus, id, status, suspendedReason, suspendedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUsersAccountType = `-- name: SetUsersAccountType :execrows
+UPDATE users
+SET account_type = $1, updated_at = $2
+WHERE id = ANY($3::UUID[])
+`
+
+func (q *Queries) SetUsersAccountType(ctx context.Context, accountType AccountType, updatedAt *time.Time, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, setUsersAccountType, accountType, updatedAt, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUsersStatus = `-- name: SetUsersStatus :execrows
+UPDATE users
+SET status = $1, suspended_reason = $2, suspended_at = $3, updated_at = NOW()
+WHERE id = ANY($4::UUID[])
+`
+
+func (q *Queries) SetUsersStatus(ctx context.Context, status UserStatus, suspendedReason *string, suspendedAt *time.Time, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, setUsersStatus, status, suspendedReason, suspendedAt, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
-SET email = $2, auth_provider = $3, auth_provider_id = $4, account_type = $5, updated_at = $6
+SET email = $2, auth_provider = $3, auth_provider_id = $4, account_type = $5, updated_at = $6,
+    -- A new address has to be verified again
+    email_verified = email_verified AND email = $2,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END
 WHERE id = $1
 `
 
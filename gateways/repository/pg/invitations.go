@@ -0,0 +1,143 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// InvitationRepository stores the invitation codes people register with
+// while registration is closed.
+type InvitationRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewInvitationRepository creates a new InvitationRepository instance.
+func NewInvitationRepository(db DBTX) *InvitationRepository {
+	return &InvitationRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation entities.Invitation) error {
+	var maxUses *int32
+	if invitation.MaxUses != nil {
+		n := int32(*invitation.MaxUses)
+		maxUses = &n
+	}
+
+	err := r.queries.CreateInvitation(ctx, gen.CreateInvitationParams{
+		ID:          invitation.ID,
+		Prefix:      invitation.Prefix,
+		CodeHash:    invitation.CodeHash,
+		AccountType: gen.AccountType(invitation.AccountType),
+		MaxUses:     maxUses,
+		Note:        invitation.Note,
+		Email:       invitation.Email,
+		CreatedBy:   invitation.CreatedBy,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *InvitationRepository) GetInvitation(ctx context.Context, id uuid.UUID) (entities.Invitation, error) {
+	row, err := r.queries.GetInvitation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Invitation{}, domain.ErrNotFound
+		}
+		return entities.Invitation{}, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return invitationFromRow(row), nil
+}
+
+func (r *InvitationRepository) GetInvitationByCodeHash(ctx context.Context, codeHash string) (entities.Invitation, error) {
+	row, err := r.queries.GetInvitationByCodeHash(ctx, codeHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Invitation{}, domain.ErrNotFound
+		}
+		return entities.Invitation{}, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return invitationFromRow(row), nil
+}
+
+func (r *InvitationRepository) ListInvitations(ctx context.Context) ([]entities.Invitation, error) {
+	rows, err := r.queries.ListInvitations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	invitations := make([]entities.Invitation, 0, len(rows))
+	for _, row := range rows {
+		invitations = append(invitations, invitationFromRow(row))
+	}
+	return invitations, nil
+}
+
+// RedeemInvitation returns domain.ErrNotFound when no active invitation has
+// the code hash, or the invitation was sent to another address.
+func (r *InvitationRepository) RedeemInvitation(ctx context.Context, codeHash, email string) (entities.Invitation, error) {
+	row, err := r.queries.RedeemInvitation(ctx, codeHash, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Invitation{}, domain.ErrNotFound
+		}
+		return entities.Invitation{}, fmt.Errorf("failed to redeem invitation: %w", err)
+	}
+	return invitationFromRow(row), nil
+}
+
+func (r *InvitationRepository) ReleaseInvitation(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.ReleaseInvitation(ctx, id); err != nil {
+		return fmt.Errorf("failed to release invitation: %w", err)
+	}
+	return nil
+}
+
+// RevokeInvitation returns domain.ErrNotFound for unknown invitations and
+// invitations that were already revoked.
+func (r *InvitationRepository) RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.queries.RevokeInvitation(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func invitationFromRow(row gen.Invitation) entities.Invitation {
+	var maxUses *int
+	if row.MaxUses != nil {
+		n := int(*row.MaxUses)
+		maxUses = &n
+	}
+
+	return entities.Invitation{
+		ID:          row.ID,
+		Prefix:      row.Prefix,
+		CodeHash:    row.CodeHash,
+		AccountType: entities.AccountType(row.AccountType),
+		MaxUses:     maxUses,
+		Uses:        int(row.Uses),
+		Note:        row.Note,
+		Email:       row.Email,
+		CreatedBy:   row.CreatedBy,
+		ExpiresAt:   row.ExpiresAt,
+		RevokedAt:   row.RevokedAt,
+		CreatedAt:   row.CreatedAt,
+	}
+}
@@ -0,0 +1,27 @@
+-- name: CreateInvitation :exec
+INSERT INTO invitations (id, prefix, code_hash, account_type, max_uses, note, email, created_by, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetInvitation :one
+SELECT * FROM invitations WHERE id = $1;
+
+-- name: GetInvitationByCodeHash :one
+SELECT * FROM invitations WHERE code_hash = $1;
+
+-- name: ListInvitations :many
+SELECT * FROM invitations ORDER BY created_at DESC;
+
+-- name: RedeemInvitation :one
+UPDATE invitations SET uses = uses + 1
+WHERE code_hash = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+  AND (max_uses IS NULL OR uses < max_uses)
+  AND (email = '' OR lower(email) = lower(sqlc.arg(email)::text))
+RETURNING *;
+
+-- name: ReleaseInvitation :exec
+UPDATE invitations SET uses = uses - 1 WHERE id = $1 AND uses > 0;
+
+-- name: RevokeInvitation :execrows
+UPDATE invitations SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,177 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// JobRepository stores the background jobs workers run.
+type JobRepository struct {
+	queries *gen.Queries
+}
+
+// NewJobRepository creates a new JobRepository instance.
+func NewJobRepository(db DBTX) *JobRepository {
+	return &JobRepository{queries: gen.New(db)}
+}
+
+func (r *JobRepository) EnqueueJob(ctx context.Context, job entities.Job) error {
+	err := r.queries.EnqueueJob(ctx, gen.EnqueueJobParams{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Args:        job.Args,
+		Status:      string(job.Status),
+		MaxAttempts: int32(job.MaxAttempts),
+		CreatedAt:   job.CreatedAt,
+		RunAt:       job.RunAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) ClaimJob(ctx context.Context, kinds []string, now, leaseUntil time.Time) (entities.Job, error) {
+	row, err := r.queries.ClaimJob(ctx, now, leaseUntil, kinds)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Job{}, domain.ErrNotFound
+		}
+		return entities.Job{}, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return jobFromRow(row), nil
+}
+
+func (r *JobRepository) CompleteJob(ctx context.Context, id uuid.UUID, finishedAt time.Time) error {
+	if err := r.queries.CompleteJob(ctx, finishedAt, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) RetryJob(ctx context.Context, id uuid.UUID, message string, runAt time.Time) error {
+	if err := r.queries.RetryJob(ctx, message, runAt, id); err != nil {
+		return fmt.Errorf("failed to retry job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) DeadLetterJob(ctx context.Context, id uuid.UUID, message string, finishedAt time.Time) error {
+	if err := r.queries.DeadLetterJob(ctx, message, finishedAt, id); err != nil {
+		return fmt.Errorf("failed to dead-letter job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) DeleteSucceededJobs(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	n, err := r.queries.DeleteSucceededJobs(ctx, finishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete succeeded jobs: %w", err)
+	}
+	return n, nil
+}
+
+func (r *JobRepository) GetJob(ctx context.Context, id uuid.UUID) (entities.Job, error) {
+	row, err := r.queries.GetJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Job{}, domain.ErrNotFound
+		}
+		return entities.Job{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	return jobFromRow(row), nil
+}
+
+func (r *JobRepository) ListJobs(ctx context.Context, status entities.JobStatus, kind string, limit, offset int32) ([]entities.Job, error) {
+	statusArg, kindArg := jobFilterArgs(status, kind)
+	rows, err := r.queries.ListJobs(ctx, statusArg, kindArg, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	jobs := make([]entities.Job, len(rows))
+	for i, row := range rows {
+		jobs[i] = jobFromRow(row)
+	}
+	return jobs, nil
+}
+
+func (r *JobRepository) CountJobs(ctx context.Context, status entities.JobStatus, kind string) (int64, error) {
+	statusArg, kindArg := jobFilterArgs(status, kind)
+	count, err := r.queries.CountJobs(ctx, statusArg, kindArg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *JobRepository) CountJobsByStatus(ctx context.Context) (map[entities.JobStatus]int64, error) {
+	rows, err := r.queries.CountJobsByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs by status: %w", err)
+	}
+
+	counts := make(map[entities.JobStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[entities.JobStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *JobRepository) RequeueJob(ctx context.Context, id uuid.UUID, runAt time.Time) error {
+	n, err := r.queries.RequeueJob(ctx, runAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to requeue job: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *JobRepository) CancelJob(ctx context.Context, id uuid.UUID, finishedAt time.Time) error {
+	n, err := r.queries.CancelJob(ctx, finishedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// jobFilterArgs leaves the filters that aren't set out of the query.
+func jobFilterArgs(status entities.JobStatus, kind string) (statusArg, kindArg *string) {
+	if status != "" {
+		s := string(status)
+		statusArg = &s
+	}
+	if kind != "" {
+		kindArg = &kind
+	}
+	return statusArg, kindArg
+}
+
+func jobFromRow(row gen.Job) entities.Job {
+	return entities.Job{
+		ID:          row.ID,
+		Kind:        row.Kind,
+		Args:        row.Args,
+		Status:      entities.JobStatus(row.Status),
+		Attempts:    int(row.Attempts),
+		MaxAttempts: int(row.MaxAttempts),
+		LastError:   row.LastError,
+		CreatedAt:   row.CreatedAt,
+		RunAt:       row.RunAt,
+		StartedAt:   row.StartedAt,
+		FinishedAt:  row.FinishedAt,
+	}
+}
@@ -0,0 +1,62 @@
+-- name: EnqueueJob :exec
+INSERT INTO jobs (id, kind, args, status, max_attempts, created_at, run_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ClaimJob :one
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, started_at = @now::timestamptz, run_at = @lease_until::timestamptz
+WHERE id = (
+    SELECT id FROM jobs
+    WHERE status IN ('queued', 'running') AND run_at <= @now::timestamptz AND kind = ANY(@kinds::text[])
+    ORDER BY run_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded', last_error = '', finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: RetryJob :exec
+UPDATE jobs
+SET status = 'queued', last_error = @message, run_at = @run_at::timestamptz
+WHERE id = @id;
+
+-- name: DeadLetterJob :exec
+UPDATE jobs
+SET status = 'dead', last_error = @message, finished_at = @finished_at::timestamptz
+WHERE id = @id;
+
+-- name: DeleteSucceededJobs :execrows
+DELETE FROM jobs
+WHERE status = 'succeeded' AND finished_at < @finished_before::timestamptz;
+
+-- name: GetJob :one
+SELECT * FROM jobs WHERE id = $1;
+
+-- name: ListJobs :many
+SELECT * FROM jobs
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('kind')::text IS NULL OR kind = sqlc.narg('kind'))
+ORDER BY created_at DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountJobs :one
+SELECT COUNT(*) FROM jobs
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('kind')::text IS NULL OR kind = sqlc.narg('kind'));
+
+-- name: CountJobsByStatus :many
+SELECT status, COUNT(*) AS count FROM jobs GROUP BY status;
+
+-- name: RequeueJob :execrows
+UPDATE jobs
+SET status = 'queued', attempts = 0, run_at = @run_at::timestamptz, started_at = NULL, finished_at = NULL
+WHERE id = @id AND status IN ('dead', 'canceled');
+
+-- name: CancelJob :execrows
+UPDATE jobs
+SET status = 'canceled', finished_at = @finished_at::timestamptz
+WHERE id = @id AND status = 'queued';
@@ -0,0 +1,120 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// LocalCredentialRepository stores the password hashes of the local auth
+// provider.
+type LocalCredentialRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewLocalCredentialRepository creates a new LocalCredentialRepository instance.
+func NewLocalCredentialRepository(db DBTX) *LocalCredentialRepository {
+	return &LocalCredentialRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *LocalCredentialRepository) CreateLocalCredential(ctx context.Context, credential entities.LocalCredential) error {
+	err := r.queries.CreateLocalCredential(ctx, gen.CreateLocalCredentialParams{
+		ID:           credential.ID,
+		Email:        credential.Email,
+		PasswordHash: credential.PasswordHash,
+		CreatedAt:    credential.CreatedAt,
+		UpdatedAt:    credential.UpdatedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("credential for '%s' already exists: %w", credential.Email, domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create local credential: %w", err)
+	}
+	return nil
+}
+
+func (r *LocalCredentialRepository) GetLocalCredentialByEmail(ctx context.Context, email string) (entities.LocalCredential, error) {
+	row, err := r.queries.GetLocalCredentialByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.LocalCredential{}, domain.ErrNotFound
+		}
+		return entities.LocalCredential{}, fmt.Errorf("failed to get local credential: %w", err)
+	}
+	return localCredentialFromRow(row), nil
+}
+
+func (r *LocalCredentialRepository) DeleteLocalCredential(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteLocalCredential(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete local credential: %w", err)
+	}
+	return nil
+}
+
+// UpdateLocalCredentialPassword replaces the password hash, or returns
+// domain.ErrNotFound when there is no credential with id.
+func (r *LocalCredentialRepository) UpdateLocalCredentialPassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	n, err := r.queries.UpdateLocalCredentialPassword(ctx, id, passwordHash, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update local credential: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateLocalCredentialEmail changes the email a credential signs in with.
+// Returns domain.ErrNotFound when there is no credential with id, and
+// domain.ErrDuplicateKey when another credential has email.
+func (r *LocalCredentialRepository) UpdateLocalCredentialEmail(ctx context.Context, id uuid.UUID, email string, updatedAt time.Time) error {
+	n, err := r.queries.UpdateLocalCredentialEmail(ctx, id, email, updatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("credential for '%s' already exists: %w", email, domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to update local credential: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *LocalCredentialRepository) ListLocalCredentials(ctx context.Context) ([]entities.LocalCredential, error) {
+	rows, err := r.queries.ListLocalCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local credentials: %w", err)
+	}
+
+	credentials := make([]entities.LocalCredential, 0, len(rows))
+	for _, row := range rows {
+		credentials = append(credentials, localCredentialFromRow(row))
+	}
+	return credentials, nil
+}
+
+func localCredentialFromRow(row gen.LocalCredential) entities.LocalCredential {
+	return entities.LocalCredential{
+		ID:           row.ID,
+		Email:        row.Email,
+		PasswordHash: row.PasswordHash,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+-- name: CreateLocalCredential :exec
+INSERT INTO local_credentials (id, email, password_hash, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetLocalCredentialByEmail :one
+SELECT * FROM local_credentials WHERE email = $1;
+
+-- name: DeleteLocalCredential :exec
+DELETE FROM local_credentials WHERE id = $1;
+
+-- name: ListLocalCredentials :many
+SELECT * FROM local_credentials ORDER BY created_at;
+
+-- name: UpdateLocalCredentialPassword :execrows
+UPDATE local_credentials
+SET password_hash = $2, updated_at = $3
+WHERE id = $1;
+
+-- name: UpdateLocalCredentialEmail :execrows
+UPDATE local_credentials
+SET email = $2, updated_at = $3
+WHERE id = $1;
@@ -0,0 +1,66 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// LoginEventRepository keeps the login history.
+type LoginEventRepository struct {
+	queries *gen.Queries
+}
+
+// NewLoginEventRepository creates a new LoginEventRepository instance.
+func NewLoginEventRepository(db DBTX) *LoginEventRepository {
+	return &LoginEventRepository{queries: gen.New(db)}
+}
+
+func (r *LoginEventRepository) CreateLoginEvent(ctx context.Context, event entities.LoginEvent) error {
+	err := r.queries.CreateLoginEvent(ctx, gen.CreateLoginEventParams{
+		UserID:    event.UserID,
+		Success:   event.Success,
+		Provider:  event.Provider,
+		Reason:    event.Reason,
+		IpAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		CreatedAt: event.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create login event: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginEventRepository) SetLastLogin(ctx context.Context, userID uuid.UUID, at time.Time, ip string) error {
+	if err := r.queries.SetUserLastLogin(ctx, userID, &at, ip); err != nil {
+		return fmt.Errorf("failed to set last login: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginEventRepository) ListLoginEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]entities.LoginEvent, error) {
+	rows, err := r.queries.ListUserLoginEvents(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+
+	events := make([]entities.LoginEvent, len(rows))
+	for i, row := range rows {
+		events[i] = entities.LoginEvent{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			Success:   row.Success,
+			Provider:  row.Provider,
+			Reason:    row.Reason,
+			IPAddress: row.IpAddress,
+			UserAgent: row.UserAgent,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+	return events, nil
+}
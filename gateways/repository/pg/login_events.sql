@@ -0,0 +1,9 @@
+-- name: CreateLoginEvent :exec
+INSERT INTO login_events (user_id, success, provider, reason, ip_address, user_agent, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListUserLoginEvents :many
+SELECT * FROM login_events
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
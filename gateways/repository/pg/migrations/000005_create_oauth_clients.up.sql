@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    "client_id" VARCHAR(255) NOT NULL UNIQUE,
+    "client_secret_hash" VARCHAR(255) NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "redirect_uris" TEXT[] NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    "code_hash" VARCHAR(64) NOT NULL PRIMARY KEY,
+    "client_id" VARCHAR(255) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "redirect_uri" TEXT NOT NULL,
+    "scope" TEXT NOT NULL,
+    "nonce" TEXT NOT NULL DEFAULT '',
+    "code_challenge" TEXT NOT NULL DEFAULT '',
+    "code_challenge_method" VARCHAR(10) NOT NULL DEFAULT '',
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes(expires_at);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS admin_permissions (
+    "user_id" UUID NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "permissions" TEXT[] NOT NULL DEFAULT '{}',
+    "updated_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
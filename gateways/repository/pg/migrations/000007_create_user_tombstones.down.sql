@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_tombstones_pending;
+DROP TABLE IF EXISTS user_tombstones;
@@ -0,0 +1,13 @@
+-- Deleted users leave a tombstone holding only what aggregate statistics need.
+-- user_id is kept until the anonymization pipeline has scrubbed the user's data.
+CREATE TABLE IF NOT EXISTS user_tombstones (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    "user_id" UUID UNIQUE,
+    "account_type" account_type NOT NULL,
+    "auth_provider" VARCHAR(50) NOT NULL,
+    "signed_up_at" TIMESTAMPTZ NOT NULL,
+    "deleted_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "anonymized_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_user_tombstones_pending ON user_tombstones(deleted_at) WHERE anonymized_at IS NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS break_glass_credentials (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "credential_hash" TEXT NOT NULL UNIQUE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "used_at" TIMESTAMPTZ,
+    "used_from" TEXT
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "family_id" UUID NOT NULL,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "audience" VARCHAR(50) NOT NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "used_at" TIMESTAMPTZ,
+    "revoked_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
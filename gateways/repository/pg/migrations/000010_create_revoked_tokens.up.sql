@@ -0,0 +1,10 @@
+-- Access tokens revoked before they expire. Rows can go once the token has
+-- expired on its own.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    "jti" TEXT NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "revoked_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
@@ -0,0 +1,8 @@
+-- Accounts of the built-in local auth provider. Emails are stored lower case.
+CREATE TABLE IF NOT EXISTS local_credentials (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "email" VARCHAR(255) NOT NULL UNIQUE,
+    "password_hash" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Phone numbers are stored in E.164 format and only once verified by SMS.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS "phone" VARCHAR(16) UNIQUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS "phone_verified_at" TIMESTAMPTZ;
@@ -0,0 +1,15 @@
+-- One-time codes sent by SMS. Only a hash of the code is stored, and a phone
+-- number has at most one outstanding code per purpose.
+CREATE TABLE IF NOT EXISTS otp_codes (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "phone" VARCHAR(16) NOT NULL,
+    "purpose" VARCHAR(32) NOT NULL,
+    "code_hash" TEXT NOT NULL,
+    "user_id" UUID REFERENCES users(id) ON DELETE CASCADE,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "consumed_at" TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_otp_codes_phone_purpose ON otp_codes(phone, purpose);
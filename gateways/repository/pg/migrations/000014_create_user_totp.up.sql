@@ -0,0 +1,12 @@
+-- TOTP second factor of each user. The secret is kept in the clear because
+-- codes are computed from it. enabled_at stays NULL until the user confirms
+-- enrollment with a first code.
+CREATE TABLE IF NOT EXISTS user_totp (
+    "user_id" UUID NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "secret" TEXT NOT NULL,
+    "enabled_at" TIMESTAMPTZ,
+    "last_used_step" BIGINT NOT NULL DEFAULT 0,
+    "failed_attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_failed_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
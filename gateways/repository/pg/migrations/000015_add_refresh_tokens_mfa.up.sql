@@ -0,0 +1,3 @@
+-- Whether the login that started the token family passed a second factor, so
+-- refreshed access tokens keep it.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS "mfa" BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,12 @@
+-- One-time recovery codes for users who lose their authenticator. Only a
+-- hash of each code is stored. Codes belong to the TOTP enrollment, so they
+-- go away when two-factor authentication is disabled.
+CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    "user_id" UUID NOT NULL REFERENCES user_totp(user_id) ON DELETE CASCADE,
+    "code_hash" TEXT NOT NULL,
+    "used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_totp_recovery_codes_user_id ON totp_recovery_codes(user_id);
@@ -0,0 +1,12 @@
+-- Single-use tokens for resetting a forgotten password. Only a hash of the
+-- token is stored, and requesting a new token invalidates the earlier ones.
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "used_at" TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
@@ -0,0 +1,4 @@
+-- Emails stay unverified until the user opens a verification link or signs
+-- in with a social provider that verified the address.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS "email_verified" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS "email_verified_at" TIMESTAMPTZ;
@@ -0,0 +1,14 @@
+-- Single-use tokens emailed to confirm an address. Only a hash of the token
+-- is stored, and requesting a new token invalidates the earlier ones. A
+-- token only verifies the email it was sent to.
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "email" VARCHAR(255) NOT NULL,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "used_at" TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
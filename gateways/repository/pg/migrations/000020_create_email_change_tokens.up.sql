@@ -0,0 +1,15 @@
+-- Single-use tokens emailed to a new address to confirm an email change.
+-- Only a hash of the token is stored, and requesting a new change
+-- invalidates the earlier ones. The user's email is only replaced once the
+-- token is used.
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "new_email" VARCHAR(255) NOT NULL,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "used_at" TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_change_tokens_user_id ON email_change_tokens(user_id);
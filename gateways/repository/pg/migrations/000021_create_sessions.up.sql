@@ -0,0 +1,15 @@
+-- Access tokens in use, recorded by the auth middleware. A session is active
+-- while its token is unexpired and was seen recently. Rows can go once the
+-- token has expired.
+CREATE TABLE IF NOT EXISTS sessions (
+    "jti" TEXT NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "ip_address" TEXT NOT NULL DEFAULT '',
+    "user_agent" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "last_seen_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "expires_at" TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
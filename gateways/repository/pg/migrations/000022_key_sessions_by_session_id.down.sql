@@ -0,0 +1,3 @@
+ALTER TABLE sessions DROP CONSTRAINT sessions_pkey;
+ALTER TABLE sessions ADD PRIMARY KEY (jti);
+ALTER TABLE sessions DROP COLUMN IF EXISTS session_id;
@@ -0,0 +1,8 @@
+-- Sessions are tracked per login instead of per access token, so a session
+-- keeps its row across refreshes. Tokens without a sid claim are a session of
+-- their own, keyed by their jti.
+ALTER TABLE sessions ADD COLUMN "session_id" TEXT;
+UPDATE sessions SET session_id = jti;
+ALTER TABLE sessions ALTER COLUMN session_id SET NOT NULL;
+ALTER TABLE sessions DROP CONSTRAINT sessions_pkey;
+ALTER TABLE sessions ADD PRIMARY KEY (session_id);
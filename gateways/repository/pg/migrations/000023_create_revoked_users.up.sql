@@ -0,0 +1,6 @@
+-- Users whose access tokens were all revoked at once, e.g. by an admin
+-- forcing them out. Tokens issued to the user up to revoked_at are rejected.
+CREATE TABLE IF NOT EXISTS revoked_users (
+    "user_id" UUID NOT NULL PRIMARY KEY,
+    "revoked_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
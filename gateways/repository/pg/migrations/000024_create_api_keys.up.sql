@@ -0,0 +1,16 @@
+-- Keys machine clients authenticate with instead of a user's token. Only the
+-- hash of a key is stored; the prefix lets its owner tell keys apart.
+CREATE TABLE IF NOT EXISTS api_keys (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "name" VARCHAR(255) NOT NULL,
+    "prefix" VARCHAR(32) NOT NULL,
+    "key_hash" VARCHAR(64) NOT NULL UNIQUE,
+    "scopes" TEXT[] NOT NULL DEFAULT '{}',
+    "expires_at" TIMESTAMPTZ,
+    "last_used_at" TIMESTAMPTZ,
+    "revoked_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
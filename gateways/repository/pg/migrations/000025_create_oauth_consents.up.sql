@@ -0,0 +1,9 @@
+-- Scopes a user agreed to share with a client application on the consent
+-- screen. Users aren't asked again while a request stays within them.
+CREATE TABLE IF NOT EXISTS oauth_consents (
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "client_id" VARCHAR(255) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    "scope" TEXT NOT NULL,
+    "granted_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "client_id")
+);
@@ -0,0 +1,47 @@
+-- Roles bundle permissions. The builtin roles describe what each admin
+-- account type holds; custom roles grant more permissions to the users they
+-- are assigned to.
+CREATE TABLE IF NOT EXISTS permissions (
+    "name" VARCHAR(64) NOT NULL PRIMARY KEY,
+    "description" TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "name" VARCHAR(64) NOT NULL UNIQUE,
+    "description" TEXT NOT NULL DEFAULT '',
+    "builtin" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    "role_id" UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    "permission" VARCHAR(64) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "role_id" UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    "assigned_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "assigned_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('dashboard:read', 'View the admin dashboard'),
+    ('users:read', 'View users'),
+    ('users:write', 'Create, update and delete users'),
+    ('users:impersonate', 'Sign in as a user'),
+    ('settings:read', 'View admin settings');
+
+-- Admins and super admins keep holding every permission
+INSERT INTO roles (id, name, description, builtin) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'super_admin', 'Every permission, always', TRUE),
+    ('00000000-0000-0000-0000-000000000002', 'admin', 'Every permission, unless a super admin restricts the admin', TRUE);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.name FROM roles r CROSS JOIN permissions p;
@@ -0,0 +1,17 @@
+-- Audit events outlive the users they name: actor_id has no foreign key, and
+-- anonymization re-points a deleted user's events at their tombstone.
+CREATE TABLE IF NOT EXISTS audit_events (
+    "id" BIGSERIAL PRIMARY KEY,
+    "occurred_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "actor_id" UUID,
+    "action" VARCHAR(255) NOT NULL,
+    "resource" VARCHAR(64) NOT NULL DEFAULT '',
+    "resource_id" VARCHAR(255) NOT NULL DEFAULT '',
+    "request_id" VARCHAR(255) NOT NULL DEFAULT '',
+    "details" JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_audit_events_occurred_at ON audit_events(occurred_at DESC);
+CREATE INDEX idx_audit_events_actor_id ON audit_events(actor_id, occurred_at DESC);
+CREATE INDEX idx_audit_events_resource ON audit_events(resource, resource_id, occurred_at DESC);
+CREATE INDEX idx_audit_events_action ON audit_events(action, occurred_at DESC);
@@ -0,0 +1,9 @@
+-- Accounts their users asked to delete, kept until the grace period ends.
+-- The request goes away with the user.
+CREATE TABLE IF NOT EXISTS user_deletion_requests (
+    "user_id" UUID NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "requested_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "scheduled_for" TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_deletion_requests_scheduled_for ON user_deletion_requests(scheduled_for);
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS "suspended_at",
+    DROP COLUMN IF EXISTS "suspended_reason",
+    DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS user_status;
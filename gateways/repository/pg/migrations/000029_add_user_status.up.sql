@@ -0,0 +1,8 @@
+-- Suspended users keep their account and data but can't sign in or use
+-- their tokens; pending users haven't been let in yet.
+CREATE TYPE user_status AS ENUM ('active', 'suspended', 'pending');
+
+ALTER TABLE users
+    ADD COLUMN "status" user_status NOT NULL DEFAULT 'active',
+    ADD COLUMN "suspended_reason" TEXT,
+    ADD COLUMN "suspended_at" TIMESTAMPTZ;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS "metadata",
+    DROP COLUMN IF EXISTS "locale",
+    DROP COLUMN IF EXISTS "timezone",
+    DROP COLUMN IF EXISTS "display_name",
+    DROP COLUMN IF EXISTS "last_name",
+    DROP COLUMN IF EXISTS "first_name";
@@ -0,0 +1,10 @@
+ALTER TABLE users
+    ADD COLUMN "first_name" TEXT NOT NULL DEFAULT '',
+    ADD COLUMN "last_name" TEXT NOT NULL DEFAULT '',
+    ADD COLUMN "display_name" TEXT NOT NULL DEFAULT '',
+    -- IANA time zone name, such as Europe/Lisbon
+    ADD COLUMN "timezone" TEXT NOT NULL DEFAULT '',
+    -- BCP 47 language tag, such as pt-BR
+    ADD COLUMN "locale" TEXT NOT NULL DEFAULT '',
+    -- Free-form data the application keeps about the user
+    ADD COLUMN "metadata" JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    -- Where the user's avatar is served from; empty when they have none
+    ADD COLUMN "avatar_url" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- Settings users choose for themselves, as a JSON object. They go away with
+-- the user.
+CREATE TABLE IF NOT EXISTS user_preferences (
+    "user_id" UUID NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    "preferences" JSONB NOT NULL DEFAULT '{}',
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
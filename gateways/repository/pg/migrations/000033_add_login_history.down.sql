@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS login_events;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS "last_login_ip",
+    DROP COLUMN IF EXISTS "last_login_at";
@@ -0,0 +1,20 @@
+ALTER TABLE users
+    -- The last successful login; NULL until the user first signs in
+    ADD COLUMN "last_login_at" TIMESTAMPTZ,
+    ADD COLUMN "last_login_ip" TEXT NOT NULL DEFAULT '';
+
+-- Login attempts on known accounts, successful or not. They go away with the
+-- user; attempts on unknown emails are only logged.
+CREATE TABLE IF NOT EXISTS login_events (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "success" BOOLEAN NOT NULL,
+    "provider" VARCHAR(64) NOT NULL DEFAULT '',
+    "reason" VARCHAR(64) NOT NULL DEFAULT '',
+    "ip_address" TEXT NOT NULL DEFAULT '',
+    "user_agent" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_events_user_id ON login_events(user_id, created_at DESC);
+CREATE INDEX idx_login_events_created_at ON login_events(created_at);
@@ -0,0 +1,16 @@
+-- Invitation codes let people register while registration is closed. Only
+-- the hash of a code is stored; the prefix lets admins tell codes apart.
+-- Codes without max_uses can be used any number of times until they expire.
+CREATE TABLE IF NOT EXISTS invitations (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "prefix" VARCHAR(32) NOT NULL,
+    "code_hash" VARCHAR(64) NOT NULL UNIQUE,
+    "account_type" account_type NOT NULL DEFAULT 'user',
+    "max_uses" INTEGER,
+    "uses" INTEGER NOT NULL DEFAULT 0,
+    "note" VARCHAR(255) NOT NULL DEFAULT '',
+    "created_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "revoked_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE invitations
+    -- The address an admin emailed the invitation to; only that address can
+    -- register with it. Empty for codes handed out any other way.
+    ADD COLUMN "email" VARCHAR(255) NOT NULL DEFAULT '';
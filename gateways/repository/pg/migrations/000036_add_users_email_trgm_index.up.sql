@@ -0,0 +1,5 @@
+-- Trigram index so admin searches for any part of an email don't scan the
+-- whole table
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS examples_search_documents ON examples;
+DROP TRIGGER IF EXISTS users_search_documents ON users;
+DROP FUNCTION IF EXISTS search_documents_index_example();
+DROP FUNCTION IF EXISTS search_documents_index_user();
+DROP TABLE IF EXISTS search_documents;
@@ -0,0 +1,68 @@
+-- One row per searchable record, kept up to date by triggers on the tables
+-- they come from, so a single query ranks users and examples together.
+-- The 'simple' configuration neither stems nor drops stop words, which suits
+-- names and emails and doesn't assume a language for the rest.
+CREATE TABLE IF NOT EXISTS search_documents (
+    "kind" TEXT NOT NULL,
+    "id" UUID NOT NULL,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL DEFAULT '',
+    "document" TSVECTOR NOT NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("kind", "id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_documents_document ON search_documents USING gin (document);
+
+-- Emails are split on their punctuation so each part matches on its own
+CREATE OR REPLACE FUNCTION search_documents_index_user() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE kind = 'user' AND id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO search_documents (kind, id, title, body, document, updated_at)
+    VALUES (
+        'user', NEW.id, NEW.email,
+        concat_ws(' ', NULLIF(NEW.display_name, ''), NULLIF(NEW.first_name, ''), NULLIF(NEW.last_name, '')),
+        setweight(to_tsvector('simple', translate(NEW.email, '@._-+', '     ')), 'A')
+            || setweight(to_tsvector('simple', concat_ws(' ', NEW.display_name, NEW.first_name, NEW.last_name)), 'A'),
+        NOW()
+    )
+    ON CONFLICT (kind, id) DO UPDATE
+    SET title = EXCLUDED.title, body = EXCLUDED.body, document = EXCLUDED.document, updated_at = EXCLUDED.updated_at;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION search_documents_index_example() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE kind = 'example' AND id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    INSERT INTO search_documents (kind, id, title, body, document, updated_at)
+    VALUES (
+        'example', NEW.id, NEW.title, NEW.content,
+        setweight(to_tsvector('simple', NEW.title), 'A') || setweight(to_tsvector('simple', NEW.content), 'B'),
+        NOW()
+    )
+    ON CONFLICT (kind, id) DO UPDATE
+    SET title = EXCLUDED.title, body = EXCLUDED.body, document = EXCLUDED.document, updated_at = EXCLUDED.updated_at;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_search_documents
+    AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION search_documents_index_user();
+
+CREATE TRIGGER examples_search_documents
+    AFTER INSERT OR UPDATE OR DELETE ON examples
+    FOR EACH ROW EXECUTE FUNCTION search_documents_index_example();
+
+-- Index what is already there; updating a row reruns its trigger
+UPDATE users SET id = id;
+UPDATE examples SET id = id;
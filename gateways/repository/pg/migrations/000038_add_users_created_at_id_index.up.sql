@@ -0,0 +1,3 @@
+-- Keyset pagination walks users newest first by (created_at, id), the id
+-- breaking ties between users created in the same microsecond
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at DESC, id DESC);
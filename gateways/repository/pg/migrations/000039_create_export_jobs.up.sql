@@ -0,0 +1,21 @@
+-- Exports produced in the background. The worker claims pending jobs, and
+-- running ones it finds stale, and stores the file under file_key; jobs and
+-- their files are removed once the retention period ends.
+CREATE TABLE IF NOT EXISTS export_jobs (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "kind" VARCHAR(32) NOT NULL,
+    "format" VARCHAR(16) NOT NULL,
+    "search" VARCHAR(255) NOT NULL DEFAULT '',
+    "account_type" VARCHAR(32) NOT NULL DEFAULT '',
+    "filter" TEXT NOT NULL DEFAULT '',
+    "status" VARCHAR(16) NOT NULL DEFAULT 'pending',
+    "error" TEXT NOT NULL DEFAULT '',
+    "file_key" VARCHAR(255) NOT NULL DEFAULT '',
+    "row_count" INTEGER NOT NULL DEFAULT 0,
+    "created_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "started_at" TIMESTAMPTZ,
+    "finished_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_export_jobs_status_created_at ON export_jobs(status, created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_examples_archived_at;
+ALTER TABLE examples DROP COLUMN IF EXISTS "archived_at";
@@ -0,0 +1,5 @@
+-- Archived examples are left out of lists until they are unarchived, and
+-- purged once the retention period has passed.
+ALTER TABLE examples ADD COLUMN "archived_at" TIMESTAMPTZ;
+
+CREATE INDEX idx_examples_archived_at ON examples(archived_at) WHERE archived_at IS NOT NULL;
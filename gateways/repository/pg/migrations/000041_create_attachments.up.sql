@@ -0,0 +1,16 @@
+-- Files attached to examples, stored under file_key. Deleting an example
+-- leaves its attachments without one, and so does deleting an attachment;
+-- the cleanup job then removes their files and rows.
+CREATE TABLE IF NOT EXISTS attachments (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "example_id" UUID REFERENCES examples(id) ON DELETE SET NULL,
+    "file_name" VARCHAR(255) NOT NULL,
+    "content_type" VARCHAR(255) NOT NULL,
+    "size" BIGINT NOT NULL,
+    "file_key" VARCHAR(255) NOT NULL,
+    "uploaded_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_example_id ON attachments(example_id, created_at);
+CREATE INDEX idx_attachments_orphaned ON attachments(created_at) WHERE example_id IS NULL;
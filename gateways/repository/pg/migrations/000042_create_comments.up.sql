@@ -0,0 +1,11 @@
+-- Comments on examples. They go with their example, and outlive their
+-- author without one.
+CREATE TABLE IF NOT EXISTS comments (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "example_id" UUID NOT NULL REFERENCES examples(id) ON DELETE CASCADE,
+    "author_id" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "body" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_comments_example_id ON comments(example_id, created_at, id);
@@ -0,0 +1,18 @@
+-- Files clients upload straight to file storage through a presigned link.
+-- An upload is pending until the client confirms it; pending uploads that
+-- expire, and uploads left without a user, are removed with their files.
+CREATE TABLE IF NOT EXISTS uploads (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "user_id" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "file_name" VARCHAR(255) NOT NULL,
+    "content_type" VARCHAR(255) NOT NULL,
+    "size" BIGINT NOT NULL,
+    "file_key" VARCHAR(255) NOT NULL,
+    "status" VARCHAR(16) NOT NULL DEFAULT 'pending',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "completed_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_uploads_pending_expires_at ON uploads(expires_at) WHERE status = 'pending';
+CREATE INDEX idx_uploads_orphaned ON uploads(created_at) WHERE user_id IS NULL;
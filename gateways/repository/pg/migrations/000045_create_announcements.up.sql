@@ -0,0 +1,23 @@
+-- Announcements admins broadcast to everyone using the apps, and the users
+-- who dismissed them.
+CREATE TABLE IF NOT EXISTS announcements (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "message" TEXT NOT NULL,
+    "level" VARCHAR(16) NOT NULL DEFAULT 'info',
+    "starts_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "ends_at" TIMESTAMPTZ,
+    "created_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_announcements_starts_at ON announcements(starts_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS announcement_dismissals (
+    "announcement_id" UUID NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "dismissed_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (announcement_id, user_id)
+);
+
+CREATE INDEX idx_announcement_dismissals_user_id ON announcement_dismissals(user_id);
@@ -0,0 +1,28 @@
+-- Endpoints the domain events they subscribed to are posted to. The secret
+-- signs the deliveries, so it is kept as it is.
+CREATE TABLE IF NOT EXISTS webhooks (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "url" TEXT NOT NULL,
+    "secret" TEXT NOT NULL,
+    "events" TEXT[] NOT NULL DEFAULT '{}',
+    "created_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every attempt at delivering an event to a webhook, retries and
+-- redeliveries included. They go away with their webhook.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "webhook_id" UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    "event" VARCHAR(64) NOT NULL,
+    "payload" JSONB NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "status_code" INTEGER NOT NULL DEFAULT 0,
+    "error" TEXT NOT NULL DEFAULT '',
+    "latency_ms" INTEGER NOT NULL DEFAULT 0,
+    "response_body" TEXT NOT NULL DEFAULT '',
+    "redelivery_of" UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at DESC);
@@ -0,0 +1,17 @@
+-- Domain events recorded in the same transaction as their change, waiting
+-- to be relayed. The key identifies the event, so it is recorded once.
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "event" VARCHAR(64) NOT NULL,
+    "key" TEXT NOT NULL UNIQUE,
+    "payload" JSONB NOT NULL,
+    "actor_id" UUID,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "available_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "published_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_messages_due ON outbox_messages(available_at, created_at) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_messages_published_at ON outbox_messages(published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DELETE FROM outbox_messages WHERE sink <> 'bus';
+ALTER TABLE outbox_messages DROP CONSTRAINT outbox_messages_sink_key_key;
+ALTER TABLE outbox_messages ADD CONSTRAINT outbox_messages_key_key UNIQUE (key);
+ALTER TABLE outbox_messages DROP COLUMN "sink";
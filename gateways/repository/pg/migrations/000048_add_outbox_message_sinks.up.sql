@@ -0,0 +1,5 @@
+-- Each event is recorded once per sink it is relayed to, so a sink that is
+-- down doesn't hold up, or repeat, the others.
+ALTER TABLE outbox_messages ADD COLUMN "sink" VARCHAR(32) NOT NULL DEFAULT 'bus';
+ALTER TABLE outbox_messages DROP CONSTRAINT outbox_messages_key_key;
+ALTER TABLE outbox_messages ADD CONSTRAINT outbox_messages_sink_key_key UNIQUE (sink, key);
@@ -0,0 +1,19 @@
+-- Background jobs run by workers. A queued job is due from run_at; a
+-- running one is leased until run_at, and taken over by another worker
+-- after that, assuming the one running it died.
+CREATE TABLE IF NOT EXISTS jobs (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "kind" VARCHAR(64) NOT NULL,
+    "args" JSONB NOT NULL,
+    "status" VARCHAR(16) NOT NULL DEFAULT 'queued',
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "max_attempts" INTEGER NOT NULL,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "run_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "started_at" TIMESTAMPTZ,
+    "finished_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_jobs_due ON jobs(run_at, created_at) WHERE status IN ('queued', 'running');
+CREATE INDEX idx_jobs_finished_at ON jobs(finished_at) WHERE finished_at IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Dumps of the database kept in file storage under file_key. A backup job
+-- dumps pending ones; backups older than the backup_retention_days setting
+-- are removed with their files.
+CREATE TABLE IF NOT EXISTS backups (
+    "id" UUID NOT NULL PRIMARY KEY,
+    "status" VARCHAR(16) NOT NULL DEFAULT 'pending',
+    "trigger" VARCHAR(16) NOT NULL,
+    "error" TEXT NOT NULL DEFAULT '',
+    "file_key" VARCHAR(255) NOT NULL DEFAULT '',
+    "size" BIGINT NOT NULL DEFAULT 0,
+    "created_by" UUID REFERENCES users(id) ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "started_at" TIMESTAMPTZ,
+    "finished_at" TIMESTAMPTZ
+);
+
+CREATE INDEX idx_backups_created_at ON backups(created_at);
@@ -0,0 +1,2 @@
+-- Lists jobs by status for the admin job monitor
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at ON jobs(status, created_at);
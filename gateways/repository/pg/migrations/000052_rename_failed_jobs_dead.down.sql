@@ -0,0 +1 @@
+UPDATE jobs SET status = 'failed' WHERE status = 'dead';
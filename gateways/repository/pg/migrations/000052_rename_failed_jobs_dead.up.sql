@@ -0,0 +1,2 @@
+-- Jobs out of attempts are dead-lettered rather than failed
+UPDATE jobs SET status = 'dead' WHERE status = 'failed';
@@ -0,0 +1,11 @@
+-- Alerts raised by the system, kept so the admin dashboard can count the
+-- recent ones. They are recorded whether or not a channel took them.
+CREATE TABLE IF NOT EXISTS system_alerts (
+    "id" BIGSERIAL PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "message" TEXT NOT NULL DEFAULT '',
+    "severity" VARCHAR(16) NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_system_alerts_created_at ON system_alerts(created_at);
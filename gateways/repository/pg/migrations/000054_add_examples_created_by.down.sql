@@ -0,0 +1 @@
+ALTER TABLE examples DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,3 @@
+-- Examples are owned by the user who created them, who may change and
+-- delete them. They outlive their owner without one.
+ALTER TABLE examples ADD COLUMN "created_by" UUID REFERENCES users(id) ON DELETE SET NULL;
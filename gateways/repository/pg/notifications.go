@@ -0,0 +1,97 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// NotificationRepository stores the notifications shown to users in the
+// apps.
+type NotificationRepository struct {
+	queries *gen.Queries
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance.
+func NewNotificationRepository(db DBTX) *NotificationRepository {
+	return &NotificationRepository{queries: gen.New(db)}
+}
+
+func (r *NotificationRepository) CreateNotification(ctx context.Context, notification entities.Notification) error {
+	err := r.queries.CreateNotification(ctx, gen.CreateNotificationParams{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Kind:      string(notification.Kind),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Link:      notification.Link,
+		CreatedAt: notification.CreatedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int32) ([]entities.Notification, error) {
+	rows, err := r.queries.ListNotifications(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	notifications := make([]entities.Notification, len(rows))
+	for i, row := range rows {
+		notifications[i] = notificationFromRow(row)
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) (int64, error) {
+	count, err := r.queries.CountNotifications(ctx, userID, unreadOnly)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (r *NotificationRepository) MarkNotificationRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error {
+	n, err := r.queries.MarkNotificationRead(ctx, readAt, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	n, err := r.queries.MarkAllNotificationsRead(ctx, readAt, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return n, nil
+}
+
+func notificationFromRow(row gen.Notification) entities.Notification {
+	return entities.Notification{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Kind:      entities.NotificationKind(row.Kind),
+		Title:     row.Title,
+		Body:      row.Body,
+		Link:      row.Link,
+		ReadAt:    row.ReadAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+-- name: CreateNotification :exec
+INSERT INTO notifications (id, user_id, kind, title, body, link, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListNotifications :many
+SELECT * FROM notifications
+WHERE user_id = @user_id AND (NOT @unread_only::boolean OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = @user_id AND (NOT @unread_only::boolean OR read_at IS NULL);
+
+-- name: MarkNotificationRead :execrows
+-- Notifications read already keep the time they were first read.
+UPDATE notifications SET read_at = COALESCE(read_at, @read_at::timestamptz)
+WHERE id = @id AND user_id = @user_id;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications SET read_at = @read_at::timestamptz
+WHERE user_id = @user_id AND read_at IS NULL;
@@ -0,0 +1,77 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	users := NewUserRepository(pool)
+	repo := NewNotificationRepository(pool)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "notified@example.com", AuthProvider: "supabase", AuthProviderID: "prov-notified", AccountType: entities.AccountTypeUser, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, users.Create(ctx, user))
+
+	newNotification := func(createdAt time.Time) entities.Notification {
+		return entities.Notification{
+			ID:        uuid.Must(uuid.NewV4()),
+			UserID:    user.ID,
+			Kind:      entities.NotificationExportReady,
+			Title:     "Your export is ready",
+			Body:      "It has 3 rows.",
+			CreatedAt: createdAt,
+		}
+	}
+	older := newNotification(now.Add(-time.Minute))
+	newer := newNotification(now)
+	require.NoError(t, repo.CreateNotification(ctx, older))
+	require.NoError(t, repo.CreateNotification(ctx, newer))
+
+	orphan := newNotification(now)
+	orphan.UserID = uuid.Must(uuid.NewV4())
+	assert.ErrorIs(t, repo.CreateNotification(ctx, orphan), domain.ErrNotFound)
+
+	got, err := repo.ListNotifications(ctx, user.ID, false, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Notification{newer, older}, got, "newest first")
+
+	readAt := now.Add(time.Second)
+	require.NoError(t, repo.MarkNotificationRead(ctx, user.ID, older.ID, readAt))
+	require.NoError(t, repo.MarkNotificationRead(ctx, user.ID, older.ID, readAt.Add(time.Hour)), "read twice")
+	assert.ErrorIs(t, repo.MarkNotificationRead(ctx, uuid.Must(uuid.NewV4()), older.ID, readAt), domain.ErrNotFound, "only the user's notifications")
+
+	unread, err := repo.ListNotifications(ctx, user.ID, true, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, unread, 1)
+	assert.Equal(t, newer.ID, unread[0].ID)
+	count, err := repo.CountNotifications(ctx, user.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	all, err := repo.ListNotifications(ctx, user.ID, false, 10, 0)
+	require.NoError(t, err)
+	require.NotNil(t, all[1].ReadAt)
+	assert.True(t, readAt.Equal(*all[1].ReadAt), "the first read is kept")
+
+	n, err := repo.MarkAllNotificationsRead(ctx, user.ID, readAt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	count, err = repo.CountNotifications(ctx, user.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = repo.CountNotifications(ctx, user.ID, true)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,172 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// OAuthRepository stores OIDC client registrations and authorization codes.
+type OAuthRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewOAuthRepository creates a new OAuthRepository instance.
+func NewOAuthRepository(db DBTX) *OAuthRepository {
+	return &OAuthRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *OAuthRepository) CreateClient(ctx context.Context, client entities.OAuthClient) error {
+	err := r.queries.CreateOAuthClient(ctx, gen.CreateOAuthClientParams{
+		ID:               client.ID,
+		ClientID:         client.ClientID,
+		ClientSecretHash: client.ClientSecretHash,
+		Name:             client.Name,
+		RedirectUris:     client.RedirectURIs,
+		CreatedAt:        client.CreatedAt,
+		UpdatedAt:        client.UpdatedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("oauth client '%s' already exists: %w", client.ClientID, domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create oauth client: %w", err)
+	}
+	return nil
+}
+
+func (r *OAuthRepository) GetClient(ctx context.Context, clientID string) (entities.OAuthClient, error) {
+	client, err := r.queries.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.OAuthClient{}, domain.ErrNotFound
+		}
+		return entities.OAuthClient{}, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	return toOAuthClient(client), nil
+}
+
+func (r *OAuthRepository) ListClients(ctx context.Context) ([]entities.OAuthClient, error) {
+	rows, err := r.queries.ListOAuthClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+
+	clients := make([]entities.OAuthClient, len(rows))
+	for i, row := range rows {
+		clients[i] = toOAuthClient(row)
+	}
+	return clients, nil
+}
+
+func (r *OAuthRepository) DeleteClient(ctx context.Context, clientID string) error {
+	affected, err := r.queries.DeleteOAuthClient(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OAuthRepository) CreateAuthorizationCode(ctx context.Context, code entities.AuthorizationCode) error {
+	err := r.queries.CreateOAuthAuthorizationCode(ctx, gen.CreateOAuthAuthorizationCodeParams{
+		CodeHash:            code.CodeHash,
+		ClientID:            code.ClientID,
+		UserID:              code.UserID,
+		RedirectUri:         code.RedirectURI,
+		Scope:               code.Scope,
+		Nonce:               code.Nonce,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		ExpiresAt:           code.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create authorization code: %w", err)
+	}
+	return nil
+}
+
+// ConsumeAuthorizationCode deletes and returns the code so it can only be
+// exchanged once.
+func (r *OAuthRepository) ConsumeAuthorizationCode(ctx context.Context, codeHash string) (entities.AuthorizationCode, error) {
+	code, err := r.queries.ConsumeOAuthAuthorizationCode(ctx, codeHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.AuthorizationCode{}, domain.ErrNotFound
+		}
+		return entities.AuthorizationCode{}, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+
+	return entities.AuthorizationCode{
+		CodeHash:            code.CodeHash,
+		ClientID:            code.ClientID,
+		UserID:              code.UserID,
+		RedirectURI:         code.RedirectUri,
+		Scope:               code.Scope,
+		Nonce:               code.Nonce,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		ExpiresAt:           code.ExpiresAt,
+		CreatedAt:           code.CreatedAt,
+	}, nil
+}
+
+func (r *OAuthRepository) DeleteExpiredAuthorizationCodes(ctx context.Context) error {
+	if err := r.queries.DeleteExpiredOAuthAuthorizationCodes(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired authorization codes: %w", err)
+	}
+	return nil
+}
+
+// GetConsent returns domain.ErrNotFound when the user never consented to the
+// client.
+func (r *OAuthRepository) GetConsent(ctx context.Context, userID uuid.UUID, clientID string) (entities.OAuthConsent, error) {
+	consent, err := r.queries.GetOAuthConsent(ctx, userID, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.OAuthConsent{}, domain.ErrNotFound
+		}
+		return entities.OAuthConsent{}, fmt.Errorf("failed to get oauth consent: %w", err)
+	}
+
+	return entities.OAuthConsent{
+		UserID:    consent.UserID,
+		ClientID:  consent.ClientID,
+		Scope:     consent.Scope,
+		GrantedAt: consent.GrantedAt,
+	}, nil
+}
+
+// SaveConsent replaces what the user consented to share with the client.
+func (r *OAuthRepository) SaveConsent(ctx context.Context, consent entities.OAuthConsent) error {
+	if err := r.queries.UpsertOAuthConsent(ctx, consent.UserID, consent.ClientID, consent.Scope, consent.GrantedAt); err != nil {
+		return fmt.Errorf("failed to save oauth consent: %w", err)
+	}
+	return nil
+}
+
+func toOAuthClient(c gen.OauthClient) entities.OAuthClient {
+	return entities.OAuthClient{
+		ID:               c.ID,
+		ClientID:         c.ClientID,
+		ClientSecretHash: c.ClientSecretHash,
+		Name:             c.Name,
+		RedirectURIs:     c.RedirectUris,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
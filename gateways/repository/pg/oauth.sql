@@ -0,0 +1,30 @@
+-- name: CreateOAuthClient :exec
+INSERT INTO oauth_clients (id, client_id, client_secret_hash, name, redirect_uris, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetOAuthClient :one
+SELECT * FROM oauth_clients WHERE client_id = $1;
+
+-- name: ListOAuthClients :many
+SELECT * FROM oauth_clients ORDER BY created_at DESC;
+
+-- name: DeleteOAuthClient :execrows
+DELETE FROM oauth_clients WHERE client_id = $1;
+
+-- name: CreateOAuthAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (code_hash, client_id, user_id, redirect_uri, scope, nonce, code_challenge, code_challenge_method, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ConsumeOAuthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes WHERE code_hash = $1 RETURNING *;
+
+-- name: DeleteExpiredOAuthAuthorizationCodes :exec
+DELETE FROM oauth_authorization_codes WHERE expires_at < NOW();
+
+-- name: UpsertOAuthConsent :exec
+INSERT INTO oauth_consents (user_id, client_id, scope, granted_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, client_id) DO UPDATE SET scope = EXCLUDED.scope, granted_at = EXCLUDED.granted_at;
+
+-- name: GetOAuthConsent :one
+SELECT * FROM oauth_consents WHERE user_id = $1 AND client_id = $2;
@@ -0,0 +1,87 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// OTPCodeRepository stores hashed one-time codes sent by SMS.
+type OTPCodeRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewOTPCodeRepository creates a new OTPCodeRepository instance.
+func NewOTPCodeRepository(db DBTX) *OTPCodeRepository {
+	return &OTPCodeRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// SaveOTPCode stores the code, replacing any earlier code for the same phone
+// and purpose.
+func (r *OTPCodeRepository) SaveOTPCode(ctx context.Context, code entities.OTPCode) error {
+	err := r.queries.UpsertOTPCode(ctx, gen.UpsertOTPCodeParams{
+		ID:        code.ID,
+		Phone:     code.Phone,
+		Purpose:   code.Purpose,
+		CodeHash:  code.CodeHash,
+		UserID:    code.UserID,
+		ExpiresAt: code.ExpiresAt,
+		CreatedAt: code.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save otp code: %w", err)
+	}
+	return nil
+}
+
+func (r *OTPCodeRepository) GetOTPCode(ctx context.Context, phone, purpose string) (entities.OTPCode, error) {
+	row, err := r.queries.GetOTPCode(ctx, phone, purpose)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.OTPCode{}, domain.ErrNotFound
+		}
+		return entities.OTPCode{}, fmt.Errorf("failed to get otp code: %w", err)
+	}
+
+	return entities.OTPCode{
+		ID:         row.ID,
+		Phone:      row.Phone,
+		Purpose:    row.Purpose,
+		CodeHash:   row.CodeHash,
+		UserID:     row.UserID,
+		Attempts:   int(row.Attempts),
+		ExpiresAt:  row.ExpiresAt,
+		CreatedAt:  row.CreatedAt,
+		ConsumedAt: row.ConsumedAt,
+	}, nil
+}
+
+func (r *OTPCodeRepository) IncrementOTPCodeAttempts(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.IncrementOTPCodeAttempts(ctx, id); err != nil {
+		return fmt.Errorf("failed to record otp attempt: %w", err)
+	}
+	return nil
+}
+
+// ConsumeOTPCode marks the code as used in a single statement, so concurrent
+// verifications of the same code can't both succeed.
+func (r *OTPCodeRepository) ConsumeOTPCode(ctx context.Context, id uuid.UUID) error {
+	n, err := r.queries.ConsumeOTPCode(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to consume otp code: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+-- name: UpsertOTPCode :exec
+INSERT INTO otp_codes (id, phone, purpose, code_hash, user_id, attempts, expires_at, created_at, consumed_at)
+VALUES ($1, $2, $3, $4, $5, 0, $6, $7, NULL)
+ON CONFLICT (phone, purpose) DO UPDATE
+SET id = EXCLUDED.id,
+    code_hash = EXCLUDED.code_hash,
+    user_id = EXCLUDED.user_id,
+    attempts = 0,
+    expires_at = EXCLUDED.expires_at,
+    created_at = EXCLUDED.created_at,
+    consumed_at = NULL;
+
+-- name: GetOTPCode :one
+SELECT * FROM otp_codes WHERE phone = $1 AND purpose = $2;
+
+-- name: IncrementOTPCodeAttempts :exec
+UPDATE otp_codes SET attempts = attempts + 1 WHERE id = $1;
+
+-- name: ConsumeOTPCode :execrows
+UPDATE otp_codes SET consumed_at = NOW()
+WHERE id = $1 AND consumed_at IS NULL;
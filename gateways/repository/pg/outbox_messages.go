@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// OutboxMessageRepository stores the domain events waiting to be relayed.
+type OutboxMessageRepository struct {
+	queries *gen.Queries
+}
+
+// NewOutboxMessageRepository creates a new OutboxMessageRepository instance.
+func NewOutboxMessageRepository(db DBTX) *OutboxMessageRepository {
+	return &OutboxMessageRepository{queries: gen.New(db)}
+}
+
+func (r *OutboxMessageRepository) AddOutboxMessage(ctx context.Context, msg entities.OutboxMessage) error {
+	err := r.queries.AddOutboxMessage(ctx, gen.AddOutboxMessageParams{
+		ID:          msg.ID,
+		Sink:        msg.Sink,
+		Event:       msg.Event,
+		Key:         msg.Key,
+		Payload:     msg.Payload,
+		ActorID:     msg.ActorID,
+		CreatedAt:   msg.CreatedAt,
+		AvailableAt: msg.AvailableAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add outbox message: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxMessageRepository) ClaimOutboxMessages(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]entities.OutboxMessage, error) {
+	rows, err := r.queries.ClaimOutboxMessages(ctx, leaseUntil, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	msgs := make([]entities.OutboxMessage, len(rows))
+	for i, row := range rows {
+		msgs[i] = outboxMessageFromRow(row)
+	}
+	// UPDATE ... RETURNING doesn't keep the subquery's order
+	slices.SortFunc(msgs, func(a, b entities.OutboxMessage) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return msgs, nil
+}
+
+func (r *OutboxMessageRepository) MarkOutboxMessagePublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	if err := r.queries.MarkOutboxMessagePublished(ctx, publishedAt, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message published: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxMessageRepository) RetryOutboxMessage(ctx context.Context, id uuid.UUID, message string, retryAt time.Time) error {
+	if err := r.queries.RetryOutboxMessage(ctx, message, retryAt, id); err != nil {
+		return fmt.Errorf("failed to retry outbox message: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxMessageRepository) DeletePublishedOutboxMessages(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	n, err := r.queries.DeletePublishedOutboxMessages(ctx, publishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox messages: %w", err)
+	}
+	return n, nil
+}
+
+func outboxMessageFromRow(row gen.OutboxMessage) entities.OutboxMessage {
+	return entities.OutboxMessage{
+		ID:          row.ID,
+		Sink:        row.Sink,
+		Event:       row.Event,
+		Key:         row.Key,
+		Payload:     row.Payload,
+		ActorID:     row.ActorID,
+		Attempts:    int(row.Attempts),
+		LastError:   row.LastError,
+		CreatedAt:   row.CreatedAt,
+		AvailableAt: row.AvailableAt,
+		PublishedAt: row.PublishedAt,
+	}
+}
@@ -0,0 +1,30 @@
+-- name: AddOutboxMessage :exec
+INSERT INTO outbox_messages (id, sink, event, key, payload, actor_id, created_at, available_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (sink, key) DO NOTHING;
+
+-- name: ClaimOutboxMessages :many
+UPDATE outbox_messages
+SET available_at = @lease_until::timestamptz
+WHERE id IN (
+    SELECT id FROM outbox_messages
+    WHERE published_at IS NULL AND available_at <= @now::timestamptz
+    ORDER BY created_at
+    LIMIT @page_limit
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxMessagePublished :exec
+UPDATE outbox_messages
+SET published_at = @published_at::timestamptz
+WHERE id = @id;
+
+-- name: RetryOutboxMessage :exec
+UPDATE outbox_messages
+SET attempts = attempts + 1, last_error = @message, available_at = @retry_at::timestamptz
+WHERE id = @id;
+
+-- name: DeletePublishedOutboxMessages :execrows
+DELETE FROM outbox_messages
+WHERE published_at < @published_before::timestamptz;
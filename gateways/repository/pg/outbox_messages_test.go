@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxMessageRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	repo := NewRepository(pool)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	newMessage := func(key string, createdAt time.Time) entities.OutboxMessage {
+		return entities.OutboxMessage{
+			ID:          uuid.Must(uuid.NewV4()),
+			Sink:        "bus",
+			Event:       "user.created",
+			Key:         key,
+			Payload:     []byte(`{"User":{"email":"ada@example.com"}}`),
+			CreatedAt:   createdAt,
+			AvailableAt: createdAt,
+		}
+	}
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "outbox@example.com", AuthProvider: "supabase", AuthProviderID: "prov-outbox", AccountType: entities.AccountTypeUser, CreatedAt: now, UpdatedAt: now}
+
+	// A failed change records neither the change nor its event
+	failed := errors.New("failed")
+	err := repo.InTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, repo.UserRepo.Create(ctx, user))
+		require.NoError(t, repo.OutboxRepo.AddOutboxMessage(ctx, newMessage("rolled-back", now)))
+		return failed
+	})
+	require.ErrorIs(t, err, failed)
+	_, err = repo.UserRepo.GetByID(ctx, user.ID)
+	assert.Error(t, err, "user rolled back")
+
+	first := newMessage("user.created:1", now.Add(-time.Minute))
+	second := newMessage("user.created:2", now)
+	require.NoError(t, repo.InTx(ctx, func(ctx context.Context) error {
+		if err := repo.UserRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := repo.OutboxRepo.AddOutboxMessage(ctx, second); err != nil {
+			return err
+		}
+		return repo.OutboxRepo.AddOutboxMessage(ctx, first)
+	}))
+	require.NoError(t, repo.OutboxRepo.AddOutboxMessage(ctx, newMessage("user.created:1", now)), "repeats are dropped")
+	forKafka := newMessage("user.created:1", now.Add(time.Minute))
+	forKafka.Sink = "kafka"
+	require.NoError(t, repo.OutboxRepo.AddOutboxMessage(ctx, forKafka), "each sink gets its copy")
+
+	claimed, err := repo.OutboxRepo.ClaimOutboxMessages(ctx, now.Add(time.Minute), now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+	assert.Equal(t, first.ID, claimed[0].ID, "oldest first")
+	assert.Equal(t, second.ID, claimed[1].ID)
+	assert.Equal(t, forKafka.ID, claimed[2].ID)
+	assert.Equal(t, "kafka", claimed[2].Sink)
+	assert.JSONEq(t, string(first.Payload), string(claimed[0].Payload))
+	require.NoError(t, repo.OutboxRepo.MarkOutboxMessagePublished(ctx, forKafka.ID, now))
+
+	again, err := repo.OutboxRepo.ClaimOutboxMessages(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, again, "claimed messages are held until their lease ends")
+
+	require.NoError(t, repo.OutboxRepo.MarkOutboxMessagePublished(ctx, first.ID, now))
+	require.NoError(t, repo.OutboxRepo.RetryOutboxMessage(ctx, second.ID, "broker down", now.Add(time.Hour)))
+
+	later := now.Add(2 * time.Minute)
+	claimed, err = repo.OutboxRepo.ClaimOutboxMessages(ctx, later, later.Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "published messages are left, and retries wait")
+
+	later = now.Add(2 * time.Hour)
+	claimed, err = repo.OutboxRepo.ClaimOutboxMessages(ctx, later, later.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, second.ID, claimed[0].ID)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.Equal(t, "broker down", claimed[0].LastError)
+
+	n, err := repo.OutboxRepo.DeletePublishedOutboxMessages(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
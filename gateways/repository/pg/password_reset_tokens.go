@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// PasswordResetRepository stores hashed password reset tokens.
+type PasswordResetRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository instance.
+func NewPasswordResetRepository(db DBTX) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// CreatePasswordResetToken stores the token and invalidates the user's
+// earlier unused tokens in the same statement.
+func (r *PasswordResetRepository) CreatePasswordResetToken(ctx context.Context, token entities.PasswordResetToken) error {
+	err := r.queries.CreatePasswordResetToken(ctx, gen.CreatePasswordResetTokenParams{
+		CreatedAt: token.CreatedAt,
+		UserID:    token.UserID,
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+	return nil
+}
+
+func (r *PasswordResetRepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (entities.PasswordResetToken, error) {
+	row, err := r.queries.GetPasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PasswordResetToken{}, domain.ErrNotFound
+		}
+		return entities.PasswordResetToken{}, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	return passwordResetTokenFromRow(row), nil
+}
+
+func (r *PasswordResetRepository) GetLatestPasswordResetToken(ctx context.Context, userID uuid.UUID) (entities.PasswordResetToken, error) {
+	row, err := r.queries.GetLatestPasswordResetToken(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PasswordResetToken{}, domain.ErrNotFound
+		}
+		return entities.PasswordResetToken{}, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	return passwordResetTokenFromRow(row), nil
+}
+
+// UsePasswordResetToken marks the token as used in a single statement, so
+// concurrent resets with the same token can't both succeed.
+func (r *PasswordResetRepository) UsePasswordResetToken(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	n, err := r.queries.UsePasswordResetToken(ctx, id, &usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to use password reset token: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func passwordResetTokenFromRow(row gen.PasswordResetToken) entities.PasswordResetToken {
+	return entities.PasswordResetToken{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		TokenHash: row.TokenHash,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+		UsedAt:    row.UsedAt,
+	}
+}
@@ -0,0 +1,22 @@
+-- name: CreatePasswordResetToken :exec
+WITH invalidated AS (
+    UPDATE password_reset_tokens
+    SET used_at = @created_at
+    WHERE user_id = @user_id AND used_at IS NULL
+)
+INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+VALUES (@id, @user_id, @token_hash, @expires_at, @created_at);
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT * FROM password_reset_tokens WHERE token_hash = $1;
+
+-- name: GetLatestPasswordResetToken :one
+SELECT * FROM password_reset_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
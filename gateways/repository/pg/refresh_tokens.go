@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// RefreshTokenRepository stores hashed refresh tokens.
+type RefreshTokenRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository instance.
+func NewRefreshTokenRepository(db DBTX) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token entities.RefreshToken) error {
+	err := r.queries.CreateRefreshToken(ctx, gen.CreateRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		Audience:  token.Audience,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+		Mfa:       token.MFA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (entities.RefreshToken, error) {
+	row, err := r.queries.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.RefreshToken{}, domain.ErrNotFound
+		}
+		return entities.RefreshToken{}, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return entities.RefreshToken{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		FamilyID:  row.FamilyID,
+		TokenHash: row.TokenHash,
+		Audience:  row.Audience,
+		ExpiresAt: row.ExpiresAt,
+		CreatedAt: row.CreatedAt,
+		UsedAt:    row.UsedAt,
+		RevokedAt: row.RevokedAt,
+		MFA:       row.Mfa,
+	}, nil
+}
+
+// UseRefreshToken marks the token as used in a single statement, so
+// concurrent refreshes with the same token can't both succeed.
+func (r *RefreshTokenRepository) UseRefreshToken(ctx context.Context, id uuid.UUID) error {
+	n, err := r.queries.UseRefreshToken(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to use refresh token: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := r.queries.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
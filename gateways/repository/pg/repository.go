@@ -2,13 +2,39 @@ package pg
 
 import (
 	"context"
+	"go-template/domain/alerting"
+	"go-template/domain/announcement"
+	"go-template/domain/anonymization"
+	"go-template/domain/apikey"
+	"go-template/domain/attachment"
+	"go-template/domain/audit"
+	"go-template/domain/auth"
+	"go-template/domain/authz"
+	"go-template/domain/backup"
+	"go-template/domain/breakglass"
+	"go-template/domain/comment"
+	"go-template/domain/deletion"
+	"go-template/domain/events"
 	"go-template/domain/example"
+	"go-template/domain/exportjob"
+	"go-template/domain/invitation"
+	"go-template/domain/job"
+	"go-template/domain/loginhistory"
+	"go-template/domain/notification"
+	"go-template/domain/oidc"
+	"go-template/domain/preferences"
+	"go-template/domain/reconciliation"
+	"go-template/domain/revocation"
+	"go-template/domain/search"
+	"go-template/domain/session"
 	"go-template/domain/settings"
+	"go-template/domain/upload"
 	"go-template/domain/user"
+	"go-template/domain/webhook"
+	"go-template/gateways/auth/local"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // DBTX represents a database transaction interface
@@ -18,31 +44,139 @@ type DBTX interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
+// Conn is a DBTX that can also start transactions, satisfied by both
+// *pgxpool.Pool and *failover.ResilientDB
+type Conn interface {
+	DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // Repository aggregates all repositories and provides transaction support
 type Repository struct {
-	db           *pgxpool.Pool
-	ExampleRepo  example.Repository
-	UserRepo     user.Repository
-	SettingsRepo settings.Repository
+	db                Conn
+	ExampleRepo       example.Repository
+	UserRepo          user.Repository
+	SettingsRepo      settings.Repository
+	OAuthRepo         oidc.Repository
+	PermissionsRepo   authz.Repository
+	RoleRepo          authz.RoleRepository
+	TombstoneRepo     anonymization.Repository
+	BreakGlassRepo    breakglass.Repository
+	ReconcileRepo     reconciliation.Repository
+	RefreshTokenRepo  auth.RefreshTokenRepository
+	RevocationRepo    revocation.Repository
+	SessionRepo       session.Repository
+	APIKeyRepo        apikey.Repository
+	LocalAuthRepo     local.Store
+	OTPCodeRepo       auth.OTPCodeRepository
+	TOTPRepo          auth.TOTPRepository
+	PasswordResetRepo auth.PasswordResetRepository
+	EmailVerifyRepo   auth.EmailVerificationRepository
+	EmailChangeRepo   auth.EmailChangeRepository
+	AuditRepo         audit.Repository
+	DeletionRepo      deletion.Repository
+	PreferencesRepo   preferences.Repository
+	LoginEventRepo    loginhistory.Repository
+	InvitationRepo    invitation.Repository
+	SearchRepo        search.Repository
+	ExportJobRepo     exportjob.Repository
+	AttachmentRepo    attachment.Repository
+	CommentRepo       comment.Repository
+	UploadRepo        upload.Repository
+	NotificationRepo  notification.Repository
+	AnnouncementRepo  announcement.Repository
+	OutboxRepo        events.OutboxRepository
+	JobRepo           job.Repository
+	BackupRepo        backup.Repository
+	WebhookRepo       webhook.Repository
+	AlertRepo         alerting.Repository
 }
 
-// NewRepository creates a new Repository instance with all sub-repositories
-func NewRepository(db *pgxpool.Pool) *Repository {
+// NewRepository creates a new Repository instance with all sub-repositories.
+// They all run their statements in the transaction InTx gives them.
+func NewRepository(db Conn) *Repository {
+	db = txConn{db}
 	return &Repository{
-		db:           db,
-		ExampleRepo:  NewExampleRepository(db),
-		UserRepo:     NewUserRepository(db),
-		SettingsRepo: NewAdminSettingsRepository(db),
+		db:                db,
+		ExampleRepo:       NewExampleRepository(db),
+		UserRepo:          NewUserRepository(db),
+		SettingsRepo:      NewAdminSettingsRepository(db),
+		OAuthRepo:         NewOAuthRepository(db),
+		PermissionsRepo:   NewAdminPermissionsRepository(db),
+		RoleRepo:          NewRoleRepository(db),
+		TombstoneRepo:     NewUserTombstoneRepository(db),
+		BreakGlassRepo:    NewBreakGlassRepository(db),
+		ReconcileRepo:     NewUserRepository(db),
+		RefreshTokenRepo:  NewRefreshTokenRepository(db),
+		RevocationRepo:    NewRevokedTokenRepository(db),
+		SessionRepo:       NewSessionRepository(db),
+		APIKeyRepo:        NewAPIKeyRepository(db),
+		LocalAuthRepo:     NewLocalCredentialRepository(db),
+		OTPCodeRepo:       NewOTPCodeRepository(db),
+		TOTPRepo:          NewTOTPRepository(db),
+		PasswordResetRepo: NewPasswordResetRepository(db),
+		EmailVerifyRepo:   NewEmailVerificationRepository(db),
+		EmailChangeRepo:   NewEmailChangeRepository(db),
+		AuditRepo:         NewAuditEventRepository(db),
+		DeletionRepo:      NewUserDeletionRequestRepository(db),
+		PreferencesRepo:   NewUserPreferencesRepository(db),
+		LoginEventRepo:    NewLoginEventRepository(db),
+		InvitationRepo:    NewInvitationRepository(db),
+		SearchRepo:        NewSearchDocumentRepository(db),
+		ExportJobRepo:     NewExportJobRepository(db),
+		AttachmentRepo:    NewAttachmentRepository(db),
+		CommentRepo:       NewCommentRepository(db),
+		UploadRepo:        NewUploadRepository(db),
+		NotificationRepo:  NewNotificationRepository(db),
+		AnnouncementRepo:  NewAnnouncementRepository(db),
+		OutboxRepo:        NewOutboxMessageRepository(db),
+		JobRepo:           NewJobRepository(db),
+		BackupRepo:        NewBackupRepository(db),
+		WebhookRepo:       NewWebhookRepository(db),
+		AlertRepo:         NewAlertRepository(db),
 	}
 }
 
 // WithTx creates repository instances that use the provided transaction
 func (r *Repository) WithTx(tx pgx.Tx) *Repository {
 	return &Repository{
-		db:           r.db,
-		ExampleRepo:  NewExampleRepository(tx),
-		UserRepo:     NewUserRepository(tx),
-		SettingsRepo: NewAdminSettingsRepository(tx),
+		db:                r.db,
+		ExampleRepo:       NewExampleRepository(tx),
+		UserRepo:          NewUserRepository(tx),
+		SettingsRepo:      NewAdminSettingsRepository(tx),
+		OAuthRepo:         NewOAuthRepository(tx),
+		PermissionsRepo:   NewAdminPermissionsRepository(tx),
+		RoleRepo:          NewRoleRepository(tx),
+		TombstoneRepo:     NewUserTombstoneRepository(tx),
+		BreakGlassRepo:    NewBreakGlassRepository(tx),
+		ReconcileRepo:     NewUserRepository(tx),
+		RefreshTokenRepo:  NewRefreshTokenRepository(tx),
+		RevocationRepo:    NewRevokedTokenRepository(tx),
+		SessionRepo:       NewSessionRepository(tx),
+		APIKeyRepo:        NewAPIKeyRepository(tx),
+		LocalAuthRepo:     NewLocalCredentialRepository(tx),
+		OTPCodeRepo:       NewOTPCodeRepository(tx),
+		TOTPRepo:          NewTOTPRepository(tx),
+		PasswordResetRepo: NewPasswordResetRepository(tx),
+		EmailVerifyRepo:   NewEmailVerificationRepository(tx),
+		EmailChangeRepo:   NewEmailChangeRepository(tx),
+		AuditRepo:         NewAuditEventRepository(tx),
+		DeletionRepo:      NewUserDeletionRequestRepository(tx),
+		PreferencesRepo:   NewUserPreferencesRepository(tx),
+		LoginEventRepo:    NewLoginEventRepository(tx),
+		InvitationRepo:    NewInvitationRepository(tx),
+		SearchRepo:        NewSearchDocumentRepository(tx),
+		ExportJobRepo:     NewExportJobRepository(tx),
+		AttachmentRepo:    NewAttachmentRepository(tx),
+		CommentRepo:       NewCommentRepository(tx),
+		UploadRepo:        NewUploadRepository(tx),
+		NotificationRepo:  NewNotificationRepository(tx),
+		AnnouncementRepo:  NewAnnouncementRepository(tx),
+		OutboxRepo:        NewOutboxMessageRepository(tx),
+		JobRepo:           NewJobRepository(tx),
+		BackupRepo:        NewBackupRepository(tx),
+		WebhookRepo:       NewWebhookRepository(tx),
+		AlertRepo:         NewAlertRepository(tx),
 	}
 }
 
@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// RevokedTokenRepository stores the access token denylist.
+type RevokedTokenRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewRevokedTokenRepository creates a new RevokedTokenRepository instance.
+func NewRevokedTokenRepository(db DBTX) *RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, token entities.RevokedToken) error {
+	if err := r.queries.RevokeToken(ctx, token.JTI, token.UserID, token.ExpiresAt, token.RevokedAt); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, err := r.queries.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+	return revoked, nil
+}
+
+func (r *RevokedTokenRepository) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	n, err := r.queries.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+	return n, nil
+}
+
+func (r *RevokedTokenRepository) RevokeUserTokens(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	if err := r.queries.RevokeUserTokens(ctx, userID, revokedAt); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *RevokedTokenRepository) IsUserTokenRevoked(ctx context.Context, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	revoked, err := r.queries.IsUserTokenRevoked(ctx, userID, issuedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked user tokens: %w", err)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,18 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1);
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens WHERE expires_at < NOW();
+
+-- name: RevokeUserTokens :exec
+INSERT INTO revoked_users (user_id, revoked_at)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET revoked_at = GREATEST(revoked_users.revoked_at, EXCLUDED.revoked_at);
+
+-- name: IsUserTokenRevoked :one
+SELECT EXISTS(SELECT 1 FROM revoked_users WHERE user_id = $1 AND revoked_at >= $2);
@@ -0,0 +1,150 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RoleRepository stores roles, the permissions they grant and the users they
+// are assigned to.
+type RoleRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewRoleRepository creates a new RoleRepository instance.
+func NewRoleRepository(db DBTX) *RoleRepository {
+	return &RoleRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *RoleRepository) ListRoles(ctx context.Context) ([]entities.Role, error) {
+	rows, err := r.queries.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	roles := make([]entities.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = toRole(gen.GetRoleRow(row))
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) GetRole(ctx context.Context, id uuid.UUID) (entities.Role, error) {
+	row, err := r.queries.GetRole(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Role{}, domain.ErrNotFound
+		}
+		return entities.Role{}, fmt.Errorf("failed to get role: %w", err)
+	}
+	return toRole(row), nil
+}
+
+func (r *RoleRepository) ListUserRoles(ctx context.Context, userID uuid.UUID, accountType entities.AccountType) ([]entities.Role, error) {
+	rows, err := r.queries.ListUserRoles(ctx, userID, accountType.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+
+	roles := make([]entities.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = toRole(gen.GetRoleRow(row))
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) CreateRole(ctx context.Context, role entities.Role) error {
+	err := r.queries.CreateRole(ctx, gen.CreateRoleParams{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		Permissions: permissionNames(role.Permissions),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("role '%s' already exists: %w", role.Name, domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+func (r *RoleRepository) UpdateRole(ctx context.Context, role entities.Role) error {
+	rows, err := r.queries.UpdateRole(ctx, role.ID, permissionNames(role.Permissions), role.Description, role.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *RoleRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.DeleteRole(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *RoleRepository) AssignRole(ctx context.Context, userID, roleID, assignedBy uuid.UUID) error {
+	if err := r.queries.AssignRole(ctx, userID, roleID, &assignedBy, time.Now()); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
+}
+
+func (r *RoleRepository) UnassignRole(ctx context.Context, userID, roleID uuid.UUID) error {
+	rows, err := r.queries.UnassignRole(ctx, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func toRole(row gen.GetRoleRow) entities.Role {
+	perms := make([]entities.Permission, len(row.Permissions))
+	for i, p := range row.Permissions {
+		perms[i] = entities.Permission(p)
+	}
+
+	return entities.Role{
+		ID:          row.ID,
+		Name:        row.Name,
+		Description: row.Description,
+		Permissions: perms,
+		Builtin:     row.Builtin,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func permissionNames(perms []entities.Permission) []string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = p.String()
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+-- name: ListRoles :many
+SELECT r.id, r.name, r.description, r.builtin, r.created_at, r.updated_at,
+    COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')::TEXT[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role_id = r.id
+GROUP BY r.id
+ORDER BY r.builtin DESC, r.name;
+
+-- name: GetRole :one
+SELECT r.id, r.name, r.description, r.builtin, r.created_at, r.updated_at,
+    COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')::TEXT[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role_id = r.id
+WHERE r.id = $1
+GROUP BY r.id;
+
+-- name: ListUserRoles :many
+SELECT r.id, r.name, r.description, r.builtin, r.created_at, r.updated_at,
+    COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')::TEXT[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role_id = r.id
+WHERE r.id IN (SELECT role_id FROM user_roles WHERE user_id = $1)
+    OR (r.builtin AND r.name = $2)
+GROUP BY r.id
+ORDER BY r.builtin DESC, r.name;
+
+-- name: CreateRole :exec
+WITH role AS (
+    INSERT INTO roles (id, name, description, created_at, updated_at)
+    VALUES (@id, @name, @description, @created_at, @created_at)
+    RETURNING id
+)
+INSERT INTO role_permissions (role_id, permission)
+SELECT role.id, unnest(@permissions::TEXT[]) FROM role;
+
+-- name: UpdateRole :execrows
+WITH removed AS (
+    DELETE FROM role_permissions
+    WHERE role_id = @id AND permission <> ALL(@permissions::TEXT[])
+), added AS (
+    INSERT INTO role_permissions (role_id, permission)
+    SELECT @id, unnest(@permissions::TEXT[])
+    ON CONFLICT DO NOTHING
+)
+UPDATE roles SET description = @description, updated_at = @updated_at
+WHERE id = @id;
+
+-- name: DeleteRole :execrows
+DELETE FROM roles WHERE id = $1;
+
+-- name: AssignRole :exec
+INSERT INTO user_roles (user_id, role_id, assigned_by, assigned_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, role_id) DO NOTHING;
+
+-- name: UnassignRole :execrows
+DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2;
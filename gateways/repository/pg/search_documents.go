@@ -0,0 +1,53 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"html"
+	"strings"
+)
+
+// SearchDocumentRepository runs full-text searches over the search_documents
+// index, which triggers keep in step with the users and examples tables.
+type SearchDocumentRepository struct {
+	queries *gen.Queries
+}
+
+// NewSearchDocumentRepository creates a new SearchDocumentRepository instance.
+func NewSearchDocumentRepository(db DBTX) *SearchDocumentRepository {
+	return &SearchDocumentRepository{queries: gen.New(db)}
+}
+
+func (r *SearchDocumentRepository) Search(ctx context.Context, query string, kinds []entities.SearchKind, limit int32) ([]entities.SearchResult, error) {
+	kindNames := make([]string, len(kinds))
+	for i, kind := range kinds {
+		kindNames[i] = string(kind)
+	}
+
+	rows, err := r.queries.SearchDocuments(ctx, query, kindNames, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	results := make([]entities.SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = entities.SearchResult{
+			Kind:      entities.SearchKind(row.Kind),
+			ID:        row.ID.String(),
+			Title:     row.Title,
+			Highlight: highlightHTML(row.Highlight),
+			Rank:      row.Rank,
+		}
+	}
+	return results, nil
+}
+
+// highlightMarks turns the \x02 and \x03 the query wraps matched words in
+// into <mark> tags, once the rest of the excerpt is escaped.
+var highlightMarks = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func highlightHTML(headline string) string {
+	return highlightMarks.Replace(html.EscapeString(headline))
+}
@@ -0,0 +1,13 @@
+-- name: SearchDocuments :many
+-- Matches use web search syntax: quoted phrases, OR, and -word to exclude.
+-- Matched words in the highlight are wrapped in \x02 and \x03, which the
+-- repository turns into HTML.
+SELECT kind, id, title,
+    ts_headline('simple', concat_ws(' ', title, body), websearch_to_tsquery('simple', @query::TEXT),
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "')::TEXT AS highlight,
+    ts_rank(document, websearch_to_tsquery('simple', @query::TEXT))::REAL AS rank
+FROM search_documents
+WHERE document @@ websearch_to_tsquery('simple', @query::TEXT)
+    AND kind = ANY(@kinds::TEXT[])
+ORDER BY rank DESC, updated_at DESC
+LIMIT @page_limit;
@@ -0,0 +1,50 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchDocumentRepository_Search(t *testing.T) {
+	pool := setupTestDB(t)
+	users := NewUserRepository(pool)
+	examples := NewExampleRepository(pool)
+	repo := NewSearchDocumentRepository(pool)
+	ctx := context.Background()
+
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ada.lovelace@example.com", AuthProvider: "supabase", AuthProviderID: "prov-ada", AccountType: entities.AccountTypeUser, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	require.NoError(t, users.Create(ctx, user))
+	exampleID, err := examples.CreateExample(ctx, entities.Example{Title: "Analytical engine", Content: "Notes by Lovelace on <b>the</b> engine"})
+	require.NoError(t, err)
+
+	// Users and examples rank together, titles first
+	results, err := repo.Search(ctx, "lovelace", entities.SearchKinds, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, entities.SearchKindUser, results[0].Kind)
+	require.Equal(t, user.ID.String(), results[0].ID)
+	require.Equal(t, exampleID, results[1].ID)
+	require.Contains(t, results[1].Highlight, "<mark>Lovelace</mark>")
+	require.Contains(t, results[1].Highlight, "&lt;b&gt;")
+
+	// Only the kinds asked for
+	results, err = repo.Search(ctx, "lovelace", []entities.SearchKind{entities.SearchKindExample}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, entities.SearchKindExample, results[0].Kind)
+
+	// The index follows profile changes and deletions
+	require.NoError(t, users.SetProfile(ctx, user.ID, entities.UserProfile{FirstName: "Augusta"}))
+	results, err = repo.Search(ctx, "augusta", entities.SearchKinds, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NoError(t, users.Delete(ctx, user.ID))
+	results, err = repo.Search(ctx, "augusta", entities.SearchKinds, 10)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
@@ -0,0 +1,115 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// SessionRepository stores the logins seen in use.
+type SessionRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewSessionRepository creates a new SessionRepository instance.
+func NewSessionRepository(db DBTX) *SessionRepository {
+	return &SessionRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// UpsertSession keeps the session on its newest access token; touches with
+// the token it was refreshed from are ignored.
+func (r *SessionRepository) UpsertSession(ctx context.Context, session entities.Session) error {
+	err := r.queries.UpsertSession(ctx, gen.UpsertSessionParams{
+		SessionID:  session.ID,
+		Jti:        session.JTI,
+		UserID:     session.UserID,
+		IpAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetSession(ctx context.Context, id string) (entities.Session, error) {
+	row, err := r.queries.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Session{}, domain.ErrNotFound
+		}
+		return entities.Session{}, fmt.Errorf("failed to get session: %w", err)
+	}
+	return sessionFromRow(row), nil
+}
+
+func (r *SessionRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]entities.Session, error) {
+	rows, err := r.queries.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]entities.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, sessionFromRow(row))
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) DeleteSession(ctx context.Context, id string) error {
+	if _, err := r.queries.DeleteSession(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	n, err := r.queries.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return n, nil
+}
+
+func (r *SessionRepository) CountActiveSessions(ctx context.Context, since time.Time) (int64, error) {
+	n, err := r.queries.CountActiveSessions(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+	return n, nil
+}
+
+func (r *SessionRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	n, err := r.queries.DeleteExpiredSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return n, nil
+}
+
+func sessionFromRow(row gen.Session) entities.Session {
+	return entities.Session{
+		ID:         row.SessionID,
+		JTI:        row.Jti,
+		UserID:     row.UserID,
+		IPAddress:  row.IpAddress,
+		UserAgent:  row.UserAgent,
+		CreatedAt:  row.CreatedAt,
+		LastSeenAt: row.LastSeenAt,
+		ExpiresAt:  row.ExpiresAt,
+	}
+}
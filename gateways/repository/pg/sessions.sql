@@ -0,0 +1,46 @@
+-- name: UpsertSession :exec
+INSERT INTO sessions (session_id, jti, user_id, ip_address, user_agent, created_at, last_seen_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (session_id) DO UPDATE SET
+    jti = EXCLUDED.jti,
+    ip_address = EXCLUDED.ip_address,
+    user_agent = EXCLUDED.user_agent,
+    last_seen_at = EXCLUDED.last_seen_at,
+    expires_at = EXCLUDED.expires_at
+WHERE sessions.expires_at <= EXCLUDED.expires_at;
+
+-- name: GetSession :one
+SELECT * FROM sessions WHERE session_id = $1;
+
+-- name: ListUserSessions :many
+SELECT * FROM sessions
+WHERE user_id = $1
+  AND NOT EXISTS (SELECT 1 FROM revoked_tokens WHERE revoked_tokens.jti = sessions.jti)
+  AND (expires_at > NOW() OR EXISTS (
+      SELECT 1 FROM refresh_tokens
+      WHERE refresh_tokens.family_id::text = sessions.session_id
+        AND refresh_tokens.used_at IS NULL
+        AND refresh_tokens.revoked_at IS NULL
+        AND refresh_tokens.expires_at > NOW()
+  ))
+ORDER BY last_seen_at DESC;
+
+-- name: DeleteSession :execrows
+DELETE FROM sessions WHERE session_id = $1;
+
+-- name: CountActiveSessions :one
+SELECT COUNT(*) FROM sessions WHERE expires_at > NOW() AND last_seen_at > $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < NOW()
+  AND NOT EXISTS (
+      SELECT 1 FROM refresh_tokens
+      WHERE refresh_tokens.family_id::text = sessions.session_id
+        AND refresh_tokens.used_at IS NULL
+        AND refresh_tokens.revoked_at IS NULL
+        AND refresh_tokens.expires_at > NOW()
+  );
+
+-- name: DeleteUserSessions :execrows
+DELETE FROM sessions WHERE user_id = $1;
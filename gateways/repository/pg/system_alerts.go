@@ -0,0 +1,33 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain/entities"
+	"time"
+)
+
+// AlertRepository keeps the alert settings and the alerts raised.
+type AlertRepository struct {
+	*AdminSettingsRepository
+}
+
+// NewAlertRepository creates a new AlertRepository instance.
+func NewAlertRepository(db DBTX) *AlertRepository {
+	return &AlertRepository{AdminSettingsRepository: NewAdminSettingsRepository(db)}
+}
+
+func (r *AlertRepository) RecordAlert(ctx context.Context, alert entities.Alert, raisedAt time.Time) error {
+	if err := r.queries.CreateSystemAlert(ctx, alert.Title, alert.Message, string(alert.Severity), raisedAt); err != nil {
+		return fmt.Errorf("failed to record alert: %w", err)
+	}
+	return nil
+}
+
+func (r *AlertRepository) CountAlertsSince(ctx context.Context, since time.Time) (int64, error) {
+	n, err := r.queries.CountSystemAlertsSince(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+	return n, nil
+}
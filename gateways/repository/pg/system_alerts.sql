@@ -0,0 +1,6 @@
+-- name: CreateSystemAlert :exec
+INSERT INTO system_alerts (title, message, severity, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: CountSystemAlertsSince :one
+SELECT COUNT(*) FROM system_alerts WHERE created_at >= $1;
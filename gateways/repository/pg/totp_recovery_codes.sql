@@ -0,0 +1,15 @@
+-- name: ReplaceTOTPRecoveryCodes :exec
+WITH deleted AS (
+    DELETE FROM totp_recovery_codes WHERE user_id = @user_id::uuid
+)
+INSERT INTO totp_recovery_codes (user_id, code_hash, created_at)
+SELECT @user_id::uuid, unnest(@code_hashes::text[]), @created_at::timestamptz;
+
+-- name: UseTOTPRecoveryCode :execrows
+UPDATE totp_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedTOTPRecoveryCodes :one
+SELECT COUNT(*) FROM totp_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
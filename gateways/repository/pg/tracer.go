@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/internal/metrics"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// unnamedQuery labels queries that weren't generated by sqlc, such as
+// transaction statements and pings.
+const unnamedQuery = "unnamed"
+
+type traceKey struct{}
+
+type queryTrace struct {
+	name  string
+	sql   string
+	args  []any
+	start time.Time
+}
+
+// QueryTracer times every query run on a connection and records it by its
+// sqlc query name. Queries taking longer than the threshold are logged with
+// their SQL; the values bound to them are left out, since they may hold
+// emails, tokens and other personal data.
+type QueryTracer struct {
+	threshold time.Duration
+	logger    *slog.Logger
+}
+
+// NewQueryTracer creates a tracer logging queries slower than threshold; a
+// zero threshold only records metrics.
+func NewQueryTracer(threshold time.Duration, logger *slog.Logger) *QueryTracer {
+	return &QueryTracer{threshold: threshold, logger: logger}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, traceKey{}, queryTrace{
+		name:  queryName(data.SQL),
+		sql:   data.SQL,
+		args:  data.Args,
+		start: time.Now(),
+	})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(traceKey{}).(queryTrace)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(trace.start)
+	metrics.RecordDBQuery(trace.name, elapsed, data.Err != nil)
+
+	if t.threshold <= 0 || elapsed < t.threshold {
+		return
+	}
+	metrics.RecordDBSlowQuery(trace.name)
+	attrs := []any{
+		slog.String("query", trace.name),
+		slog.Duration("duration", elapsed),
+		slog.String("sql", trace.sql),
+		slog.Any("args", redactArgs(trace.args)),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, slog.String("error", data.Err.Error()))
+	}
+	t.logger.WarnContext(ctx, "slow query", attrs...)
+}
+
+// queryName returns the name sqlc gives a query in the comment it starts
+// with, as in "-- name: GetUserByID :one".
+func queryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name: ")
+	if !ok {
+		return unnamedQuery
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	if name == "" {
+		return unnamedQuery
+	}
+	return name
+}
+
+// redactArgs replaces the values bound to a query with their types.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("$%d=%T", i+1, arg)
+	}
+	return redacted
+}
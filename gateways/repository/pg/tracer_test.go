@@ -0,0 +1,30 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "-- name: GetUserByID :one\nSELECT id FROM users WHERE id = $1", want: "GetUserByID"},
+		{sql: "\n-- name: DeadLetterJob :exec\nUPDATE jobs SET status = 'dead'", want: "DeadLetterJob"},
+		{sql: "begin", want: unnamedQuery},
+		{sql: "-- ping", want: unnamedQuery},
+		{sql: "", want: unnamedQuery},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, queryName(tt.sql), tt.sql)
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := redactArgs([]any{"ada@example.com", uuid.Nil, 3})
+	assert.Equal(t, []string{"$1=string", "$2=uuid.UUID", "$3=int"}, args)
+}
@@ -0,0 +1,62 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+func txFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txKey{}).(pgx.Tx)
+	return tx
+}
+
+// txConn runs statements in the transaction InTx put in their context, if
+// any, so repositories join it without being made for it. Transactions
+// begun in it are savepoints.
+type txConn struct {
+	Conn
+}
+
+func (c txConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Exec(ctx, sql, args...)
+	}
+	return c.Conn.Exec(ctx, sql, args...)
+}
+
+func (c txConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Query(ctx, sql, args...)
+	}
+	return c.Conn.Query(ctx, sql, args...)
+}
+
+func (c txConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return c.Conn.QueryRow(ctx, sql, args...)
+}
+
+func (c txConn) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Begin(ctx)
+	}
+	return c.Conn.Begin(ctx)
+}
+
+// InTx runs fn in a transaction, committed when fn returns nil and rolled
+// back otherwise. The repositories run their statements in it when called
+// with the context fn is given. Calls within fn join its transaction.
+func (r *Repository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
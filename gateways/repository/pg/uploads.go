@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// UploadRepository stores the uploads made straight to file storage.
+type UploadRepository struct {
+	queries *gen.Queries
+}
+
+// NewUploadRepository creates a new UploadRepository instance.
+func NewUploadRepository(db DBTX) *UploadRepository {
+	return &UploadRepository{queries: gen.New(db)}
+}
+
+func (r *UploadRepository) CreateUpload(ctx context.Context, upload entities.Upload) error {
+	err := r.queries.CreateUpload(ctx, gen.CreateUploadParams{
+		ID:          upload.ID,
+		UserID:      upload.UserID,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		Size:        uint64(upload.Size),
+		FileKey:     upload.FileKey,
+		CreatedAt:   upload.CreatedAt,
+		ExpiresAt:   upload.ExpiresAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to create upload: %w", err)
+	}
+	return nil
+}
+
+func (r *UploadRepository) GetUpload(ctx context.Context, userID, id uuid.UUID) (entities.Upload, error) {
+	row, err := r.queries.GetUpload(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Upload{}, domain.ErrNotFound
+		}
+		return entities.Upload{}, fmt.Errorf("failed to get upload: %w", err)
+	}
+	return uploadFromRow(row), nil
+}
+
+func (r *UploadRepository) CompleteUpload(ctx context.Context, id uuid.UUID, completedAt time.Time) error {
+	n, err := r.queries.CompleteUpload(ctx, completedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UploadRepository) ListStaleUploads(ctx context.Context, now time.Time, limit int32) ([]entities.Upload, error) {
+	rows, err := r.queries.ListStaleUploads(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale uploads: %w", err)
+	}
+	uploads := make([]entities.Upload, len(rows))
+	for i, row := range rows {
+		uploads[i] = uploadFromRow(row)
+	}
+	return uploads, nil
+}
+
+func (r *UploadRepository) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteUpload(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	return nil
+}
+
+func uploadFromRow(row gen.Upload) entities.Upload {
+	return entities.Upload{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Size:        int64(row.Size),
+		FileKey:     row.FileKey,
+		Status:      entities.UploadStatus(row.Status),
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+		CompletedAt: row.CompletedAt,
+	}
+}
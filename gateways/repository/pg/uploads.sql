@@ -0,0 +1,22 @@
+-- name: CreateUpload :exec
+INSERT INTO uploads (id, user_id, file_name, content_type, size, file_key, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetUpload :one
+SELECT * FROM uploads WHERE id = @id AND user_id = @user_id::uuid;
+
+-- name: CompleteUpload :execrows
+UPDATE uploads
+SET status = 'completed', completed_at = @completed_at::timestamptz
+WHERE id = @id AND status = 'pending';
+
+-- name: ListStaleUploads :many
+-- Pending uploads that expired, and uploads whose user was deleted.
+SELECT * FROM uploads
+WHERE (status = 'pending' AND expires_at < @now::timestamptz)
+   OR user_id IS NULL
+ORDER BY created_at
+LIMIT @page_limit;
+
+-- name: DeleteUpload :exec
+DELETE FROM uploads WHERE id = $1;
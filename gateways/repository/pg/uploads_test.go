@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadRepository(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	users := NewUserRepository(pool)
+	repo := NewUploadRepository(pool)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	user := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "uploader@example.com", AuthProvider: "supabase", AuthProviderID: "prov-uploader", AccountType: entities.AccountTypeUser, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, users.Create(ctx, user))
+
+	newUpload := func(expiresAt time.Time) entities.Upload {
+		id := uuid.Must(uuid.NewV4())
+		return entities.Upload{
+			ID:          id,
+			UserID:      &user.ID,
+			FileName:    "video.mp4",
+			ContentType: "video/mp4",
+			Size:        5 << 30,
+			FileKey:     "private/uploads/" + user.ID.String() + "/" + id.String(),
+			Status:      entities.UploadPending,
+			CreatedAt:   now,
+			ExpiresAt:   expiresAt,
+		}
+	}
+	fresh := newUpload(now.Add(time.Hour))
+	expired := newUpload(now.Add(-time.Minute))
+	require.NoError(t, repo.CreateUpload(ctx, fresh))
+	require.NoError(t, repo.CreateUpload(ctx, expired))
+
+	got, err := repo.GetUpload(ctx, user.ID, fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fresh, got)
+
+	_, err = repo.GetUpload(ctx, uuid.Must(uuid.NewV4()), fresh.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "uploads are only found by their user")
+
+	orphan := newUpload(now.Add(time.Hour))
+	unknownUser := uuid.Must(uuid.NewV4())
+	orphan.UserID = &unknownUser
+	assert.ErrorIs(t, repo.CreateUpload(ctx, orphan), domain.ErrNotFound)
+
+	completedAt := now.Add(time.Second)
+	require.NoError(t, repo.CompleteUpload(ctx, fresh.ID, completedAt))
+	assert.ErrorIs(t, repo.CompleteUpload(ctx, fresh.ID, completedAt), domain.ErrNotFound, "only pending uploads complete")
+	got, err = repo.GetUpload(ctx, user.ID, fresh.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.UploadCompleted, got.Status)
+	require.NotNil(t, got.CompletedAt)
+	assert.True(t, completedAt.Equal(*got.CompletedAt))
+
+	stale, err := repo.ListStaleUploads(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, expired.ID, stale[0].ID)
+
+	// Uploads outlive their user until their files are removed
+	require.NoError(t, users.Delete(ctx, user.ID))
+	stale, err = repo.ListStaleUploads(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Len(t, stale, 2)
+
+	require.NoError(t, repo.DeleteUpload(ctx, expired.ID))
+	require.NoError(t, repo.DeleteUpload(ctx, fresh.ID))
+	stale, err = repo.ListStaleUploads(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
@@ -2,13 +2,17 @@ package pg
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go-template/domain"
 	"go-template/domain/entities"
 	"go-template/gateways/repository/pg/gen"
+	"strings"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -25,14 +29,21 @@ func NewUserRepository(db DBTX) *UserRepository {
 }
 
 func (r *UserRepository) Create(ctx context.Context, user entities.User) error {
+	status := user.Status
+	if status == "" {
+		status = entities.UserStatusActive
+	}
 	err := r.queries.CreateUser(ctx, gen.CreateUserParams{
-		ID:             user.ID,
-		Email:          user.Email,
-		AuthProvider:   user.AuthProvider,
-		AuthProviderID: &user.AuthProviderID,
-		AccountType:    gen.AccountType(user.AccountType),
-		CreatedAt:      &user.CreatedAt,
-		UpdatedAt:      &user.UpdatedAt,
+		ID:              user.ID,
+		Email:           user.Email,
+		AuthProvider:    user.AuthProvider,
+		AuthProviderID:  &user.AuthProviderID,
+		AccountType:     gen.AccountType(user.AccountType),
+		CreatedAt:       &user.CreatedAt,
+		UpdatedAt:       &user.UpdatedAt,
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Status:          gen.UserStatus(status),
 	})
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
@@ -48,41 +59,191 @@ func (r *UserRepository) Create(ctx context.Context, user entities.User) error {
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (entities.User, error) {
 	user, err := r.queries.GetUserByID(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.User{}, domain.ErrNotFound
 		}
 		return entities.User{}, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
-	return entities.User{
-		ID:             user.ID,
-		Email:          user.Email,
-		AuthProvider:   user.AuthProvider,
-		AuthProviderID: *user.AuthProviderID,
-		AccountType:    entities.AccountType(user.AccountType),
-		CreatedAt:      *user.CreatedAt,
-		UpdatedAt:      *user.UpdatedAt,
-	}, nil
+	return userFromRow(user), nil
 }
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (entities.User, error) {
 	user, err := r.queries.GetUserByEmail(ctx, email)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.User{}, domain.ErrNotFound
 		}
 		return entities.User{}, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
-	return entities.User{
-		ID:             user.ID,
-		Email:          user.Email,
-		AuthProvider:   user.AuthProvider,
-		AuthProviderID: *user.AuthProviderID,
-		AccountType:    entities.AccountType(user.AccountType),
-		CreatedAt:      *user.CreatedAt,
-		UpdatedAt:      *user.UpdatedAt,
-	}, nil
+	return userFromRow(user), nil
+}
+
+func (r *UserRepository) GetByPhone(ctx context.Context, phone string) (entities.User, error) {
+	user, err := r.queries.GetUserByPhone(ctx, &phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.User{}, domain.ErrNotFound
+		}
+		return entities.User{}, fmt.Errorf("failed to get user by phone: %w", err)
+	}
+
+	return userFromRow(user), nil
+}
+
+// SetPhone stores a verified phone number for the user. An empty phone
+// removes it.
+func (r *UserRepository) SetPhone(ctx context.Context, id uuid.UUID, phone string, verifiedAt time.Time) error {
+	var (
+		phoneArg      *string
+		verifiedAtArg *time.Time
+	)
+	if phone != "" {
+		phoneArg = &phone
+		verifiedAtArg = &verifiedAt
+	}
+
+	err := r.queries.SetUserPhone(ctx, id, phoneArg, verifiedAtArg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("phone number already in use: %w", domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to set user phone: %w", err)
+	}
+	return nil
+}
+
+// SetEmail replaces the user's email with a confirmed address. Returns
+// domain.ErrDuplicateKey when another user has email.
+func (r *UserRepository) SetEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	n, err := r.queries.SetUserEmail(ctx, id, email, &verifiedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("user with email '%s' already exists: %w", email, domain.ErrDuplicateKey)
+		}
+		return fmt.Errorf("failed to set user email: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetEmailVerified marks email as verified for the user. Returns
+// domain.ErrNotFound when email is no longer the user's address.
+func (r *UserRepository) SetEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	n, err := r.queries.SetUserEmailVerified(ctx, id, email, &verifiedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set user email verified: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetStatus changes the user's status. The reason is kept only while the
+// user is suspended.
+func (r *UserRepository) SetStatus(ctx context.Context, id uuid.UUID, status entities.UserStatus, reason string, at time.Time) error {
+	var (
+		reasonArg      *string
+		suspendedAtArg *time.Time
+	)
+	if status == entities.UserStatusSuspended {
+		reasonArg = &reason
+		suspendedAtArg = &at
+	}
+
+	n, err := r.queries.SetUserStatus(ctx, id, gen.UserStatus(status), reasonArg, suspendedAtArg)
+	if err != nil {
+		return fmt.Errorf("failed to set user status: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) ApplyBulkUserOperation(ctx context.Context, op entities.BulkUserOperation, at time.Time) error {
+	db, ok := r.db.(interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+	})
+	if !ok {
+		return errors.New("failed to apply bulk user operation: repository can't start transactions")
+	}
+
+	err := pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		queries := r.queries.WithTx(tx)
+		var n int64
+		var err error
+		switch op.Action {
+		case entities.BulkUserDelete:
+			n, err = queries.DeleteUsers(ctx, op.UserIDs)
+		case entities.BulkUserChangeAccountType:
+			n, err = queries.SetUsersAccountType(ctx, gen.AccountType(op.AccountType), &at, op.UserIDs)
+		case entities.BulkUserSuspend:
+			n, err = queries.SetUsersStatus(ctx, gen.UserStatusSuspended, &op.Reason, &at, op.UserIDs)
+		default:
+			return fmt.Errorf("unknown bulk user action %q", op.Action)
+		}
+		if err != nil {
+			return err
+		}
+		// Another request removed some of the users; change none of them
+		if n != int64(len(op.UserIDs)) {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to apply bulk user operation: %w", err)
+	}
+	return err
+}
+
+// SetAvatarURL replaces the user's avatar URL, or removes it when url is
+// empty.
+func (r *UserRepository) SetAvatarURL(ctx context.Context, id uuid.UUID, url string) error {
+	n, err := r.queries.SetUserAvatarURL(ctx, id, url)
+	if err != nil {
+		return fmt.Errorf("failed to set user avatar: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetProfile replaces the user's profile.
+func (r *UserRepository) SetProfile(ctx context.Context, id uuid.UUID, profile entities.UserProfile) error {
+	metadata := []byte("{}")
+	if len(profile.Metadata) > 0 {
+		var err error
+		metadata, err = json.Marshal(profile.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode user metadata: %w", err)
+		}
+	}
+
+	n, err := r.queries.SetUserProfile(ctx, gen.SetUserProfileParams{
+		ID:          id,
+		FirstName:   profile.FirstName,
+		LastName:    profile.LastName,
+		DisplayName: profile.DisplayName,
+		Timezone:    profile.Timezone,
+		Locale:      profile.Locale,
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set user profile: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
 }
 
 func (r *UserRepository) Update(ctx context.Context, user entities.User) error {
@@ -103,21 +264,27 @@ func (r *UserRepository) Update(ctx context.Context, user entities.User) error {
 func (r *UserRepository) GetByAuthProviderID(ctx context.Context, provider, providerID string) (entities.User, error) {
 	user, err := r.queries.GetUserByAuthProviderID(ctx, provider, &providerID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.User{}, domain.ErrNotFound
 		}
 		return entities.User{}, fmt.Errorf("failed to get user by auth provider ID: %w", err)
 	}
 
-	return entities.User{
-		ID:             user.ID,
-		Email:          user.Email,
-		AuthProvider:   user.AuthProvider,
-		AuthProviderID: *user.AuthProviderID,
-		AccountType:    entities.AccountType(user.AccountType),
-		CreatedAt:      *user.CreatedAt,
-		UpdatedAt:      *user.UpdatedAt,
-	}, nil
+	return userFromRow(user), nil
+}
+
+func (r *UserRepository) ListUsersByAuthProvider(ctx context.Context, provider string) ([]entities.User, error) {
+	rows, err := r.queries.ListUsersByAuthProvider(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by auth provider: %w", err)
+	}
+
+	users := make([]entities.User, len(rows))
+	for i, row := range rows {
+		users[i] = userFromRow(row)
+	}
+
+	return users, nil
 }
 
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -129,27 +296,229 @@ func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *UserRepository) ListUsers(ctx context.Context, params entities.ListUsersParams) ([]entities.User, error) {
-	rows, err := r.queries.ListUsers(ctx, params.Limit, params.Offset)
+	rows, err := r.queries.ListUsers(ctx, string(params.Sort.Field), params.Sort.Desc, params.Limit, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
 	users := make([]entities.User, len(rows))
 	for i, row := range rows {
-		users[i] = entities.User{
-			ID:             row.ID,
-			Email:          row.Email,
-			AuthProvider:   row.AuthProvider,
-			AuthProviderID: *row.AuthProviderID,
-			AccountType:    entities.AccountType(row.AccountType),
-			CreatedAt:      *row.CreatedAt,
-			UpdatedAt:      *row.UpdatedAt,
+		users[i] = userFromRow(row)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) ListUsersWithTotal(ctx context.Context, params entities.ListUsersParams) ([]entities.User, int64, error) {
+	rows, err := r.queries.ListUsersWithTotal(ctx, string(params.Sort.Field), params.Sort.Desc, params.Limit, params.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	if len(rows) == 0 && params.Offset > 0 {
+		// Past the last page there's no row to carry the total
+		total, err := r.CountUsers(ctx)
+		return []entities.User{}, total, err
+	}
+
+	users := make([]entities.User, len(rows))
+	var total int64
+	for i, row := range rows {
+		users[i] = userFromRow(row.User)
+		total = row.Total
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepository) ListUsersByStatus(ctx context.Context, status entities.UserStatus, params entities.ListUsersParams) ([]entities.User, error) {
+	rows, err := r.queries.ListUsersByStatus(ctx, gen.UserStatus(status), params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by status: %w", err)
+	}
+
+	users := make([]entities.User, len(rows))
+	for i, row := range rows {
+		users[i] = userFromRow(row)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) SearchUsers(ctx context.Context, filter entities.UserFilter, params entities.ListUsersParams) ([]entities.User, error) {
+	if len(filter.Expr) > 0 {
+		return r.queryUsers(ctx, filter, nil, params.Sort, params.Limit, params.Offset)
+	}
+
+	emailPattern, accountType := userSearchArgs(filter)
+	rows, err := r.queries.SearchUsers(ctx, gen.SearchUsersParams{
+		EmailPattern: emailPattern,
+		AccountType:  accountType,
+		SortBy:       string(params.Sort.Field),
+		SortDesc:     params.Sort.Desc,
+		PageLimit:    params.Limit,
+		PageOffset:   params.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	users := make([]entities.User, len(rows))
+	for i, row := range rows {
+		users[i] = userFromRow(row)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) SearchUsersAfter(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, limit int32) ([]entities.User, error) {
+	if len(filter.Expr) > 0 {
+		return r.queryUsers(ctx, filter, after, entities.UserSort{}, limit, 0)
+	}
+
+	emailPattern, accountType := userSearchArgs(filter)
+	arg := gen.SearchUsersAfterParams{
+		EmailPattern: emailPattern,
+		AccountType:  accountType,
+		PageLimit:    limit,
+	}
+	if after != nil {
+		arg.CursorCreatedAt = &after.CreatedAt
+		arg.CursorID = &after.ID
+	}
+	rows, err := r.queries.SearchUsersAfter(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	users := make([]entities.User, len(rows))
+	for i, row := range rows {
+		users[i] = userFromRow(row)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) CountSearchUsers(ctx context.Context, filter entities.UserFilter) (int64, error) {
+	if len(filter.Expr) > 0 {
+		return r.countUsers(ctx, filter)
+	}
+
+	emailPattern, accountType := userSearchArgs(filter)
+	count, err := r.queries.CountSearchUsers(ctx, emailPattern, accountType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// userSearchArgs turns filter into query arguments. The search becomes an
+// ILIKE pattern with its wildcards escaped, and zero fields become NULLs,
+// which match any user.
+func userSearchArgs(filter entities.UserFilter) (emailPattern, accountType *string) {
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		emailPattern = &pattern
+	}
+	if filter.AccountType != "" {
+		at := string(filter.AccountType)
+		accountType = &at
+	}
+	return emailPattern, accountType
+}
+
+// userColumns are the columns of gen.User, in order.
+const userColumns = `id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip`
+
+// userFilterColumns are the columns behind entities.UserFilterFields.
+var userFilterColumns = filterColumns{
+	"email":          "email",
+	"account_type":   "account_type::TEXT",
+	"status":         "status::TEXT",
+	"auth_provider":  "auth_provider",
+	"email_verified": "email_verified",
+	"created_at":     "created_at",
+	"last_login_at":  "last_login_at",
+}
+
+// userSortColumns are the columns behind entities.UserSortFields.
+var userSortColumns = map[entities.UserSortField]string{
+	entities.UserSortCreatedAt:   "created_at",
+	entities.UserSortEmail:       "email",
+	entities.UserSortAccountType: "account_type::TEXT",
+}
+
+// queryUsers is SearchUsers and SearchUsersAfter for filters with an
+// expression, which sqlc's static queries can't take. It orders users the
+// same way.
+func (r *UserRepository) queryUsers(ctx context.Context, filter entities.UserFilter, after *entities.UserCursor, sort entities.UserSort, limit, offset int32) ([]entities.User, error) {
+	where, err := userWhere(filter, after)
+	if err != nil {
+		return nil, err
+	}
+	order := "created_at DESC, id DESC"
+	if column, ok := userSortColumns[sort.Field]; ok {
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
 		}
+		order = column + " " + direction + ", " + order
+	}
+	clause := where.String()
+	query := "SELECT " + userColumns + " FROM users " + clause + " ORDER BY " + order +
+		" LIMIT " + where.arg(limit) + " OFFSET " + where.arg(offset)
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	found, err := pgx.CollectRows(rows, pgx.RowToStructByPos[gen.User])
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
 	}
 
+	users := make([]entities.User, len(found))
+	for i, row := range found {
+		users[i] = userFromRow(row)
+	}
 	return users, nil
 }
 
+// countUsers is CountSearchUsers for filters with an expression.
+func (r *UserRepository) countUsers(ctx context.Context, filter entities.UserFilter) (int64, error) {
+	where, err := userWhere(filter, nil)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users "+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// userWhere selects the users matching filter that come after the cursor,
+// when there is one.
+func userWhere(filter entities.UserFilter, after *entities.UserCursor) (*sqlWhere, error) {
+	where := &sqlWhere{}
+	emailPattern, accountType := userSearchArgs(filter)
+	if emailPattern != nil {
+		where.add("email ILIKE ?", *emailPattern)
+	}
+	if accountType != nil {
+		where.add("account_type::TEXT = ?", *accountType)
+	}
+	if after != nil {
+		where.add("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+	if err := where.addFilter(filter.Expr, userFilterColumns); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrMalformedParameters, err)
+	}
+	return where, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (r *UserRepository) CountUsers(ctx context.Context) (int64, error) {
 	count, err := r.queries.CountUsers(ctx)
 	if err != nil {
@@ -166,6 +535,14 @@ func (r *UserRepository) CountUsersByAccountType(ctx context.Context, accountTyp
 	return count, nil
 }
 
+func (r *UserRepository) CountUsersByStatus(ctx context.Context, status entities.UserStatus) (int64, error) {
+	count, err := r.queries.CountUsersByStatus(ctx, gen.UserStatus(status))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users by status: %w", err)
+	}
+	return count, nil
+}
+
 func (r *UserRepository) GetUserStats(ctx context.Context) (entities.UserStats, error) {
 	stats, err := r.queries.GetUserStats(ctx)
 	if err != nil {
@@ -178,5 +555,45 @@ func (r *UserRepository) GetUserStats(ctx context.Context) (entities.UserStats,
 		SuperAdminUsers: stats.SuperAdminUsers,
 		RegularUsers:    stats.RegularUsers,
 		RecentSignups:   stats.RecentSignups,
+		DeletedUsers:    stats.DeletedUsers,
 	}, nil
 }
+
+func userFromRow(row gen.User) entities.User {
+	user := entities.User{
+		ID:              row.ID,
+		Email:           row.Email,
+		AuthProvider:    row.AuthProvider,
+		AuthProviderID:  *row.AuthProviderID,
+		AccountType:     entities.AccountType(row.AccountType),
+		CreatedAt:       *row.CreatedAt,
+		UpdatedAt:       *row.UpdatedAt,
+		EmailVerified:   row.EmailVerified,
+		EmailVerifiedAt: row.EmailVerifiedAt,
+		PhoneVerifiedAt: row.PhoneVerifiedAt,
+		AvatarURL:       row.AvatarUrl,
+		LastLoginAt:     row.LastLoginAt,
+		LastLoginIP:     row.LastLoginIp,
+		Status:          entities.UserStatus(row.Status),
+		SuspendedAt:     row.SuspendedAt,
+		UserProfile: entities.UserProfile{
+			FirstName:   row.FirstName,
+			LastName:    row.LastName,
+			DisplayName: row.DisplayName,
+			Timezone:    row.Timezone,
+			Locale:      row.Locale,
+		},
+	}
+	if row.Phone != nil {
+		user.Phone = *row.Phone
+	}
+	if row.SuspendedReason != nil {
+		user.SuspendedReason = *row.SuspendedReason
+	}
+	if len(row.Metadata) > 0 {
+		// The column only holds JSON objects; a row that can't be read
+		// shows no metadata rather than failing every user lookup
+		_ = json.Unmarshal(row.Metadata, &user.Metadata)
+	}
+	return user
+}
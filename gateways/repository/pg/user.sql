@@ -1,36 +1,183 @@
 -- name: CreateUser :exec
-INSERT INTO users (id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO users (id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, email_verified, email_verified_at, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
 
 -- name: GetUserByID :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE email = $1;
 
 -- name: GetUserByAuthProviderID :one
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
 WHERE auth_provider = $1 AND auth_provider_id = $2;
 
+-- name: GetUserByPhone :one
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE phone = $1;
+
+-- name: SetUserAvatarURL :execrows
+UPDATE users
+SET avatar_url = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetUserEmail :execrows
+UPDATE users
+SET email = $2, email_verified = TRUE, email_verified_at = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetUserEmailVerified :execrows
+UPDATE users
+SET email_verified = TRUE, email_verified_at = $3, updated_at = NOW()
+WHERE id = $1 AND email = $2;
+
+-- name: SetUserLastLogin :exec
+UPDATE users
+SET last_login_at = $2, last_login_ip = $3
+WHERE id = $1;
+
+-- name: SetUserPhone :exec
+UPDATE users
+SET phone = $2, phone_verified_at = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetUserProfile :execrows
+UPDATE users
+SET first_name = $2, last_name = $3, display_name = $4, timezone = $5, locale = $6, metadata = $7, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetUserStatus :execrows
+UPDATE users
+SET status = $2, suspended_reason = $3, suspended_at = $4, updated_at = NOW()
+WHERE id = $1;
+
 -- name: UpdateUser :exec
 UPDATE users
-SET email = $2, auth_provider = $3, auth_provider_id = $4, account_type = $5, updated_at = $6
+SET email = $2, auth_provider = $3, auth_provider_id = $4, account_type = $5, updated_at = $6,
+    -- A new address has to be verified again
+    email_verified = email_verified AND email = $2,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END
 WHERE id = $1;
 
 -- name: DeleteUser :exec
-DELETE FROM users
-WHERE id = $1;
+WITH deleted AS (
+    DELETE FROM users
+    WHERE id = $1
+    RETURNING id, account_type, auth_provider, created_at
+)
+INSERT INTO user_tombstones (user_id, account_type, auth_provider, signed_up_at)
+SELECT id, account_type, auth_provider, COALESCE(created_at, NOW())
+FROM deleted;
+
+-- name: DeleteUsers :execrows
+WITH deleted AS (
+    DELETE FROM users
+    WHERE id = ANY(@ids::UUID[])
+    RETURNING id, account_type, auth_provider, created_at
+)
+INSERT INTO user_tombstones (user_id, account_type, auth_provider, signed_up_at)
+SELECT id, account_type, auth_provider, COALESCE(created_at, NOW())
+FROM deleted;
+
+-- name: SetUsersAccountType :execrows
+UPDATE users
+SET account_type = @account_type, updated_at = @updated_at
+WHERE id = ANY(@ids::UUID[]);
+
+-- name: SetUsersStatus :execrows
+UPDATE users
+SET status = @status, suspended_reason = @suspended_reason, suspended_at = @suspended_at, updated_at = NOW()
+WHERE id = ANY(@ids::UUID[]);
 
 -- name: ListUsers :many
-SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at
+-- sort_by is email, account_type or created_at; anything else sorts by
+-- created_at. Ties, and the default, are newest first.
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+ORDER BY
+    CASE WHEN @sort_by::TEXT = 'email' AND NOT @sort_desc::BOOLEAN THEN email END ASC,
+    CASE WHEN @sort_by::TEXT = 'email' AND @sort_desc::BOOLEAN THEN email END DESC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND NOT @sort_desc::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND @sort_desc::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN @sort_by::TEXT = 'created_at' AND NOT @sort_desc::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: ListUsersWithTotal :many
+-- ListUsers with the number of users on every row, which saves counting
+-- them in a second round trip. Pages past the end have no rows to carry it.
+SELECT sqlc.embed(users), COUNT(*) OVER() AS total
+FROM users
+ORDER BY
+    CASE WHEN @sort_by::TEXT = 'email' AND NOT @sort_desc::BOOLEAN THEN email END ASC,
+    CASE WHEN @sort_by::TEXT = 'email' AND @sort_desc::BOOLEAN THEN email END DESC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND NOT @sort_desc::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND @sort_desc::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN @sort_by::TEXT = 'created_at' AND NOT @sort_desc::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: ListUsersByAuthProvider :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE auth_provider = $1
+ORDER BY created_at;
+
+-- name: ListUsersByStatus :many
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: SearchUsers :many
+-- sort_by is email, account_type or created_at; anything else sorts by
+-- created_at. Ties, and the default, are newest first.
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
 FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+WHERE (sqlc.narg('email_pattern')::TEXT IS NULL OR email ILIKE sqlc.narg('email_pattern'))
+    AND (sqlc.narg('account_type')::TEXT IS NULL OR account_type::TEXT = sqlc.narg('account_type'))
+ORDER BY
+    CASE WHEN @sort_by::TEXT = 'email' AND NOT @sort_desc::BOOLEAN THEN email END ASC,
+    CASE WHEN @sort_by::TEXT = 'email' AND @sort_desc::BOOLEAN THEN email END DESC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND NOT @sort_desc::BOOLEAN THEN account_type::TEXT END ASC,
+    CASE WHEN @sort_by::TEXT = 'account_type' AND @sort_desc::BOOLEAN THEN account_type::TEXT END DESC,
+    CASE WHEN @sort_by::TEXT = 'created_at' AND NOT @sort_desc::BOOLEAN THEN created_at END ASC,
+    created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: SearchUsersAfter :many
+-- SearchUsersAfter pages through SearchUsers by keyset instead of offset: it
+-- lists the users that come after (cursor_created_at, cursor_id), or from the
+-- newest when the cursor is NULL.
+SELECT id, email, auth_provider, auth_provider_id, account_type, created_at, updated_at, phone, phone_verified_at, email_verified, email_verified_at, status, suspended_reason, suspended_at,
+       first_name, last_name, display_name, timezone, locale, metadata, avatar_url, last_login_at, last_login_ip
+FROM users
+WHERE (sqlc.narg('email_pattern')::TEXT IS NULL OR email ILIKE sqlc.narg('email_pattern'))
+    AND (sqlc.narg('account_type')::TEXT IS NULL OR account_type::TEXT = sqlc.narg('account_type'))
+    AND (sqlc.narg('cursor_created_at')::TIMESTAMPTZ IS NULL OR (created_at, id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::UUID))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: CountSearchUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE (sqlc.narg('email_pattern')::TEXT IS NULL OR email ILIKE sqlc.narg('email_pattern'))
+    AND (sqlc.narg('account_type')::TEXT IS NULL OR account_type::TEXT = sqlc.narg('account_type'));
 
 -- name: CountUsers :one
 SELECT COUNT(*) FROM users;
@@ -38,11 +185,16 @@ SELECT COUNT(*) FROM users;
 -- name: CountUsersByAccountType :one
 SELECT COUNT(*) FROM users WHERE account_type = $1;
 
+-- name: CountUsersByStatus :one
+SELECT COUNT(*) FROM users WHERE status = $1;
+
 -- name: GetUserStats :one
 SELECT 
     COUNT(*) as total_users,
     COUNT(CASE WHEN account_type = 'admin' THEN 1 END) as admin_users,
     COUNT(CASE WHEN account_type = 'super_admin' THEN 1 END) as super_admin_users,
     COUNT(CASE WHEN account_type = 'user' THEN 1 END) as regular_users,
-    COUNT(CASE WHEN created_at >= NOW() - INTERVAL '7 days' THEN 1 END) as recent_signups
+    COUNT(CASE WHEN created_at >= NOW() - INTERVAL '7 days' THEN 1 END)
+        + (SELECT COUNT(*) FROM user_tombstones WHERE signed_up_at >= NOW() - INTERVAL '7 days') as recent_signups,
+    (SELECT COUNT(*) FROM user_tombstones) as deleted_users
 FROM users;  
\ No newline at end of file
@@ -0,0 +1,68 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserDeletionRequestRepository stores accounts waiting out their deletion
+// grace period.
+type UserDeletionRequestRepository struct {
+	queries *gen.Queries
+}
+
+// NewUserDeletionRequestRepository creates a new UserDeletionRequestRepository instance.
+func NewUserDeletionRequestRepository(db DBTX) *UserDeletionRequestRepository {
+	return &UserDeletionRequestRepository{queries: gen.New(db)}
+}
+
+func (r *UserDeletionRequestRepository) CreateDeletionRequest(ctx context.Context, req entities.DeletionRequest) (entities.DeletionRequest, error) {
+	row, err := r.queries.CreateUserDeletionRequest(ctx, req.UserID, req.RequestedAt, req.ScheduledFor)
+	if err != nil {
+		return entities.DeletionRequest{}, fmt.Errorf("failed to create deletion request: %w", err)
+	}
+	return entities.DeletionRequest(row), nil
+}
+
+func (r *UserDeletionRequestRepository) GetDeletionRequest(ctx context.Context, userID uuid.UUID) (entities.DeletionRequest, error) {
+	row, err := r.queries.GetUserDeletionRequest(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.DeletionRequest{}, domain.ErrNotFound
+		}
+		return entities.DeletionRequest{}, fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	return entities.DeletionRequest(row), nil
+}
+
+func (r *UserDeletionRequestRepository) DeleteDeletionRequest(ctx context.Context, userID uuid.UUID) error {
+	n, err := r.queries.DeleteUserDeletionRequest(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete deletion request: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserDeletionRequestRepository) ListDueDeletionRequests(ctx context.Context, now time.Time, limit int32) ([]entities.DeletionRequest, error) {
+	rows, err := r.queries.ListDueUserDeletionRequests(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due deletion requests: %w", err)
+	}
+
+	reqs := make([]entities.DeletionRequest, len(rows))
+	for i, row := range rows {
+		reqs[i] = entities.DeletionRequest(row)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,17 @@
+-- name: CreateUserDeletionRequest :one
+INSERT INTO user_deletion_requests (user_id, requested_at, scheduled_for)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+RETURNING *;
+
+-- name: GetUserDeletionRequest :one
+SELECT * FROM user_deletion_requests WHERE user_id = $1;
+
+-- name: DeleteUserDeletionRequest :execrows
+DELETE FROM user_deletion_requests WHERE user_id = $1;
+
+-- name: ListDueUserDeletionRequests :many
+SELECT * FROM user_deletion_requests
+WHERE scheduled_for <= $1
+ORDER BY scheduled_for
+LIMIT $2;
@@ -0,0 +1,55 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserPreferencesRepository stores the settings users choose for
+// themselves.
+type UserPreferencesRepository struct {
+	queries *gen.Queries
+}
+
+// NewUserPreferencesRepository creates a new UserPreferencesRepository instance.
+func NewUserPreferencesRepository(db DBTX) *UserPreferencesRepository {
+	return &UserPreferencesRepository{queries: gen.New(db)}
+}
+
+func (r *UserPreferencesRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (entities.Preferences, error) {
+	data, err := r.queries.GetUserPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	prefs := entities.Preferences{}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return nil, fmt.Errorf("failed to decode preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+func (r *UserPreferencesRepository) SavePreferences(ctx context.Context, userID uuid.UUID, prefs entities.Preferences) error {
+	if prefs == nil {
+		prefs = entities.Preferences{}
+	}
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+	if err := r.queries.UpsertUserPreferences(ctx, userID, data); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+-- name: GetUserPreferences :one
+SELECT preferences FROM user_preferences WHERE user_id = $1;
+
+-- name: UpsertUserPreferences :exec
+INSERT INTO user_preferences (user_id, preferences, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id) DO UPDATE SET preferences = EXCLUDED.preferences, updated_at = NOW();
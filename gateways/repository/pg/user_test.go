@@ -2,8 +2,11 @@ package pg
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
+	"go-template/domain"
 	"go-template/domain/entities"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,6 +52,169 @@ func TestUserRepository_CRUD(t *testing.T) {
 	got4, err := repo.GetByID(ctx, user.ID)
 	require.NoError(t, err)
 	require.Equal(t, "johnny.doe@example.com", got4.Email)
+	require.Equal(t, entities.UserStatusActive, got4.Status)
+
+	// SetStatus
+	require.NoError(t, repo.SetStatus(ctx, user.ID, entities.UserStatusSuspended, "spam", time.Now().UTC()))
+	got5, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.UserStatusSuspended, got5.Status)
+	require.Equal(t, "spam", got5.SuspendedReason)
+	require.NotNil(t, got5.SuspendedAt)
+	require.NoError(t, repo.SetStatus(ctx, user.ID, entities.UserStatusActive, "", time.Now().UTC()))
+	got6, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.UserStatusActive, got6.Status)
+	require.Empty(t, got6.SuspendedReason)
+	require.Nil(t, got6.SuspendedAt)
+
+	// Pending users
+	pending := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "pending@example.com", AuthProvider: "supabase", AuthProviderID: "prov-pending", AccountType: entities.AccountTypeUser, Status: entities.UserStatusPending, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	require.NoError(t, repo.Create(ctx, pending))
+	pendingUsers, err := repo.ListUsersByStatus(ctx, entities.UserStatusPending, entities.ListUsersParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, pendingUsers, 1)
+	require.Equal(t, pending.ID, pendingUsers[0].ID)
+	pendingCount, err := repo.CountUsersByStatus(ctx, entities.UserStatusPending)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pendingCount)
+
+	// SetProfile
+	profile := entities.UserProfile{
+		FirstName:   "Johnny",
+		LastName:    "Doe",
+		DisplayName: "JD",
+		Timezone:    "Europe/Lisbon",
+		Locale:      "pt-PT",
+		Metadata:    map[string]any{"team": "core"},
+	}
+	require.NoError(t, repo.SetProfile(ctx, user.ID, profile))
+	got7, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, profile, got7.UserProfile)
+	require.Equal(t, "JD", got7.Name())
+
+	// SetAvatarURL
+	require.NoError(t, repo.SetAvatarURL(ctx, user.ID, "http://localhost:3000/files/avatars/a.png"))
+	got8, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:3000/files/avatars/a.png", got8.AvatarURL)
+
+	// Login history
+	logins := NewLoginEventRepository(pool)
+	loginAt := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, logins.CreateLoginEvent(ctx, entities.LoginEvent{UserID: user.ID, Provider: "supabase", Reason: entities.LoginReasonInvalidCredentials, CreatedAt: loginAt.Add(-time.Minute)}))
+	require.NoError(t, logins.CreateLoginEvent(ctx, entities.LoginEvent{UserID: user.ID, Success: true, Provider: "supabase", IPAddress: "203.0.113.7", CreatedAt: loginAt}))
+	require.NoError(t, logins.SetLastLogin(ctx, user.ID, loginAt, "203.0.113.7"))
+	events, err := logins.ListLoginEvents(ctx, user.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.True(t, events[0].Success)
+	require.Equal(t, entities.LoginReasonInvalidCredentials, events[1].Reason)
+	got9, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got9.LastLoginAt)
+	require.True(t, loginAt.Equal(*got9.LastLoginAt))
+	require.Equal(t, "203.0.113.7", got9.LastLoginIP)
+
+	// SearchUsers
+	admin := entities.User{ID: uuid.Must(uuid.NewV4()), Email: "ops_admin@example.com", AuthProvider: "supabase", AuthProviderID: "prov-admin", AccountType: entities.AccountTypeAdmin, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	require.NoError(t, repo.Create(ctx, admin))
+	found, err := repo.SearchUsers(ctx, entities.UserFilter{Search: "DOE"}, entities.ListUsersParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, user.ID, found[0].ID)
+	found, err = repo.SearchUsers(ctx, entities.UserFilter{AccountType: entities.AccountTypeAdmin}, entities.ListUsersParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, admin.ID, found[0].ID)
+	found, err = repo.SearchUsers(ctx, entities.UserFilter{Search: "example.com"}, entities.ListUsersParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, admin.ID, found[0].ID)
+	matches, err := repo.CountSearchUsers(ctx, entities.UserFilter{Search: "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), matches)
+	// Wildcards are matched literally
+	matches, err = repo.CountSearchUsers(ctx, entities.UserFilter{Search: "%"})
+	require.NoError(t, err)
+	require.Zero(t, matches)
+	matches, err = repo.CountSearchUsers(ctx, entities.UserFilter{Search: "s_admin"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), matches)
+	matches, err = repo.CountSearchUsers(ctx, entities.UserFilter{Search: "s_admin", AccountType: entities.AccountTypeUser})
+	require.NoError(t, err)
+	require.Zero(t, matches)
+
+	// Sorting
+	found, err = repo.SearchUsers(ctx, entities.UserFilter{Search: "example.com"}, entities.ListUsersParams{Limit: 10, Sort: entities.UserSort{Field: entities.UserSortEmail}})
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	require.True(t, slices.IsSortedFunc(found, func(a, b entities.User) int { return strings.Compare(a.Email, b.Email) }))
+	found, err = repo.ListUsers(ctx, entities.ListUsersParams{Limit: 10, Sort: entities.UserSort{Field: entities.UserSortEmail, Desc: true}})
+	require.NoError(t, err)
+	require.True(t, slices.IsSortedFunc(found, func(a, b entities.User) int { return strings.Compare(b.Email, a.Email) }))
+	found, err = repo.ListUsers(ctx, entities.ListUsersParams{Limit: 10, Sort: entities.UserSort{Field: entities.UserSortCreatedAt}})
+	require.NoError(t, err)
+	require.True(t, slices.IsSortedFunc(found, func(a, b entities.User) int { return a.CreatedAt.Compare(b.CreatedAt) }))
+
+	// ListUsersWithTotal counts every user on each page, even past the end
+	all, err := repo.CountUsers(ctx)
+	require.NoError(t, err)
+	found, total, err := repo.ListUsersWithTotal(ctx, entities.ListUsersParams{Limit: 1, Sort: entities.UserSort{Field: entities.UserSortEmail}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, all, total)
+	listed, err := repo.ListUsers(ctx, entities.ListUsersParams{Limit: 1, Sort: entities.UserSort{Field: entities.UserSortEmail}})
+	require.NoError(t, err)
+	require.Equal(t, listed[0].ID, found[0].ID)
+	found, total, err = repo.ListUsersWithTotal(ctx, entities.ListUsersParams{Limit: 10, Offset: int32(all)})
+	require.NoError(t, err)
+	require.Empty(t, found)
+	require.Equal(t, all, total)
+
+	// Filter expressions
+	expr := entities.Filter{
+		{Field: "account_type", Op: entities.FilterNe, Value: "user"},
+		{Field: "email", Op: entities.FilterContains, Value: "ADMIN"},
+		{Field: "created_at", Op: entities.FilterGte, Value: admin.CreatedAt.Add(-time.Minute)},
+	}
+	found, err = repo.SearchUsers(ctx, entities.UserFilter{Search: "example.com", Expr: expr}, entities.ListUsersParams{Limit: 10, Sort: entities.UserSort{Field: entities.UserSortEmail}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, admin.ID, found[0].ID)
+	matches, err = repo.CountSearchUsers(ctx, entities.UserFilter{Expr: expr})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), matches)
+	found, err = repo.SearchUsersAfter(ctx, entities.UserFilter{Expr: entities.Filter{{Field: "email_verified", Op: entities.FilterEq, Value: false}}}, &entities.UserCursor{CreatedAt: admin.CreatedAt, ID: admin.ID}, 10)
+	require.NoError(t, err)
+	for _, u := range found {
+		require.NotEqual(t, admin.ID, u.ID)
+		require.True(t, u.CreatedAt.Before(admin.CreatedAt) || u.CreatedAt.Equal(admin.CreatedAt))
+	}
+	_, err = repo.SearchUsers(ctx, entities.UserFilter{Expr: entities.Filter{{Field: "password", Op: entities.FilterEq, Value: "x"}}}, entities.ListUsersParams{Limit: 10})
+	require.ErrorIs(t, err, domain.ErrMalformedParameters)
+
+	// SearchUsersAfter walks every match once, newest first
+	var walked []uuid.UUID
+	var after *entities.UserCursor
+	for {
+		page, err := repo.SearchUsersAfter(ctx, entities.UserFilter{Search: "example.com"}, after, 2)
+		require.NoError(t, err)
+		for _, u := range page {
+			walked = append(walked, u.ID)
+		}
+		if len(page) < 2 {
+			break
+		}
+		last := page[len(page)-1]
+		after = &entities.UserCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	require.Len(t, walked, 3)
+	require.Equal(t, admin.ID, walked[0])
+	found, err = repo.SearchUsersAfter(ctx, entities.UserFilter{AccountType: entities.AccountTypeAdmin}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
 
 	// Duplicate email should error with duplicate key
 	user2 := entities.User{
@@ -61,8 +227,83 @@ func TestUserRepository_CRUD(t *testing.T) {
 	err = repo.Create(ctx, user2)
 	require.Error(t, err)
 
+	// Bulk operations change every user or, when one is missing, none
+	bulkUser := entities.User{
+		ID:             uuid.Must(uuid.NewV4()),
+		Email:          "bulk@example.com",
+		AuthProvider:   "supabase",
+		AuthProviderID: "prov-bulk",
+		AccountType:    entities.AccountTypeUser,
+	}
+	require.NoError(t, repo.Create(ctx, bulkUser))
+	err = repo.ApplyBulkUserOperation(ctx, entities.BulkUserOperation{
+		Action:  entities.BulkUserSuspend,
+		UserIDs: []uuid.UUID{bulkUser.ID, uuid.Must(uuid.NewV4())},
+	}, time.Now())
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	got, err = repo.GetByID(ctx, bulkUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.UserStatusActive, got.Status)
+
+	require.NoError(t, repo.ApplyBulkUserOperation(ctx, entities.BulkUserOperation{
+		Action:      entities.BulkUserChangeAccountType,
+		UserIDs:     []uuid.UUID{bulkUser.ID},
+		AccountType: entities.AccountTypeAdmin,
+	}, time.Now()))
+	got, err = repo.GetByID(ctx, bulkUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.AccountTypeAdmin, got.AccountType)
+
+	require.NoError(t, repo.ApplyBulkUserOperation(ctx, entities.BulkUserOperation{
+		Action:  entities.BulkUserDelete,
+		UserIDs: []uuid.UUID{bulkUser.ID},
+	}, time.Now()))
+	_, err = repo.GetByID(ctx, bulkUser.ID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
 	// Delete
 	require.NoError(t, repo.Delete(ctx, user.ID))
 	_, err = repo.GetByID(ctx, user.ID)
-	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// BenchmarkListUsers compares a page of users and its total in two queries,
+// ListUsers and CountUsers, with the one query of ListUsersWithTotal.
+func BenchmarkListUsers(b *testing.B) {
+	pool := setupTestDB(b)
+	repo := NewUserRepository(pool)
+	ctx := context.Background()
+
+	for i := range 1000 {
+		now := time.Now().UTC()
+		require.NoError(b, repo.Create(ctx, entities.User{
+			ID:             uuid.Must(uuid.NewV4()),
+			Email:          fmt.Sprintf("bench%d@example.com", i),
+			AuthProvider:   "supabase",
+			AuthProviderID: fmt.Sprintf("bench-%d", i),
+			AccountType:    entities.AccountTypeUser,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}))
+	}
+	params := entities.ListUsersParams{Limit: 20, Offset: 100}
+
+	b.Run("list and count", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := repo.ListUsers(ctx, params); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := repo.CountUsers(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("with total", func(b *testing.B) {
+		for b.Loop() {
+			if _, _, err := repo.ListUsersWithTotal(ctx, params); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
@@ -0,0 +1,57 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// UserTombstoneRepository tracks deleted users awaiting anonymization.
+type UserTombstoneRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewUserTombstoneRepository creates a new UserTombstoneRepository instance.
+func NewUserTombstoneRepository(db DBTX) *UserTombstoneRepository {
+	return &UserTombstoneRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+func (r *UserTombstoneRepository) ListPendingTombstones(ctx context.Context, limit int32) ([]entities.UserTombstone, error) {
+	rows, err := r.queries.ListPendingUserTombstones(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending user tombstones: %w", err)
+	}
+
+	tombstones := make([]entities.UserTombstone, len(rows))
+	for i, row := range rows {
+		tombstones[i] = entities.UserTombstone{
+			ID:           row.ID,
+			UserID:       row.UserID,
+			AccountType:  entities.AccountType(row.AccountType),
+			AuthProvider: row.AuthProvider,
+			SignedUpAt:   row.SignedUpAt,
+			DeletedAt:    row.DeletedAt,
+			AnonymizedAt: row.AnonymizedAt,
+		}
+	}
+	return tombstones, nil
+}
+
+func (r *UserTombstoneRepository) MarkAnonymized(ctx context.Context, tombstoneID uuid.UUID) error {
+	affected, err := r.queries.MarkUserTombstoneAnonymized(ctx, tombstoneID)
+	if err != nil {
+		return fmt.Errorf("failed to mark user tombstone anonymized: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
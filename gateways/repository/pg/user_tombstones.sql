@@ -0,0 +1,10 @@
+-- name: ListPendingUserTombstones :many
+SELECT * FROM user_tombstones
+WHERE anonymized_at IS NULL
+ORDER BY deleted_at
+LIMIT $1;
+
+-- name: MarkUserTombstoneAnonymized :execrows
+UPDATE user_tombstones
+SET user_id = NULL, anonymized_at = NOW()
+WHERE id = $1;
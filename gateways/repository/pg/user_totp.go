@@ -0,0 +1,120 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// TOTPRepository stores the TOTP second factor of users.
+type TOTPRepository struct {
+	queries *gen.Queries
+	db      DBTX
+}
+
+// NewTOTPRepository creates a new TOTPRepository instance.
+func NewTOTPRepository(db DBTX) *TOTPRepository {
+	return &TOTPRepository{
+		queries: gen.New(db),
+		db:      db,
+	}
+}
+
+// SaveTOTP stores a pending enrollment, replacing any earlier one.
+func (r *TOTPRepository) SaveTOTP(ctx context.Context, cred entities.TOTPCredential) error {
+	if err := r.queries.UpsertUserTOTP(ctx, cred.UserID, cred.Secret, cred.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save totp: %w", err)
+	}
+	return nil
+}
+
+func (r *TOTPRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (entities.TOTPCredential, error) {
+	row, err := r.queries.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.TOTPCredential{}, domain.ErrNotFound
+		}
+		return entities.TOTPCredential{}, fmt.Errorf("failed to get totp: %w", err)
+	}
+
+	return entities.TOTPCredential{
+		UserID:         row.UserID,
+		Secret:         row.Secret,
+		LastUsedStep:   row.LastUsedStep,
+		FailedAttempts: int(row.FailedAttempts),
+		LastFailedAt:   row.LastFailedAt,
+		EnabledAt:      row.EnabledAt,
+		CreatedAt:      row.CreatedAt,
+	}, nil
+}
+
+func (r *TOTPRepository) EnableTOTP(ctx context.Context, userID uuid.UUID, enabledAt time.Time) error {
+	if err := r.queries.EnableUserTOTP(ctx, userID, &enabledAt); err != nil {
+		return fmt.Errorf("failed to enable totp: %w", err)
+	}
+	return nil
+}
+
+func (r *TOTPRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteUserTOTP(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+	return nil
+}
+
+// UseTOTPStep records an accepted code in a single statement, so the same
+// code can't be used twice even by concurrent requests.
+func (r *TOTPRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	n, err := r.queries.UseUserTOTPStep(ctx, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to use totp step: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TOTPRepository) RecordTOTPFailure(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.RecordUserTOTPFailure(ctx, userID); err != nil {
+		return fmt.Errorf("failed to record totp failure: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes swaps the user's recovery codes for a new set in a
+// single statement.
+func (r *TOTPRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error {
+	if err := r.queries.ReplaceTOTPRecoveryCodes(ctx, userID, codeHashes, createdAt); err != nil {
+		return fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused code as used, or returns domain.ErrNotFound
+// when there is no such code or it was already used.
+func (r *TOTPRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	n, err := r.queries.UseTOTPRecoveryCode(ctx, userID, codeHash, &usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TOTPRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	n, err := r.queries.CountUnusedTOTPRecoveryCodes(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,33 @@
+-- name: UpsertUserTOTP :exec
+INSERT INTO user_totp (user_id, secret, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    enabled_at = NULL,
+    last_used_step = 0,
+    failed_attempts = 0,
+    last_failed_at = NULL,
+    created_at = EXCLUDED.created_at;
+
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1;
+
+-- name: EnableUserTOTP :exec
+UPDATE user_totp SET enabled_at = $2 WHERE user_id = $1;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: UseUserTOTPStep :execrows
+UPDATE user_totp
+SET last_used_step = $2, failed_attempts = 0, last_failed_at = NULL
+WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: RecordUserTOTPFailure :exec
+UPDATE user_totp
+SET failed_attempts = CASE
+        WHEN last_failed_at < NOW() - INTERVAL '15 minutes' THEN 1
+        ELSE failed_attempts + 1
+    END,
+    last_failed_at = NOW()
+WHERE user_id = $1;
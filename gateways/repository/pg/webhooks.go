@@ -0,0 +1,173 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"go-template/gateways/repository/pg/gen"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// WebhookRepository stores the outgoing webhooks and their delivery
+// attempts.
+type WebhookRepository struct {
+	queries *gen.Queries
+}
+
+// NewWebhookRepository creates a new WebhookRepository instance.
+func NewWebhookRepository(db DBTX) *WebhookRepository {
+	return &WebhookRepository{queries: gen.New(db)}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook entities.Webhook) error {
+	err := r.queries.CreateWebhook(ctx, gen.CreateWebhookParams{
+		ID:        webhook.ID,
+		Url:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    webhook.Events,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: webhook.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id uuid.UUID) (entities.Webhook, error) {
+	row, err := r.queries.GetWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Webhook{}, domain.ErrNotFound
+		}
+		return entities.Webhook{}, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhookFromRow(row), nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]entities.Webhook, error) {
+	rows, err := r.queries.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooksFromRows(rows), nil
+}
+
+func (r *WebhookRepository) ListWebhooksForEvent(ctx context.Context, event string) ([]entities.Webhook, error) {
+	rows, err := r.queries.ListWebhooksForEvent(ctx, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks for event: %w", err)
+	}
+	return webhooksFromRows(rows), nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	n, err := r.queries.DeleteWebhook(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	err := r.queries.CreateWebhookDelivery(ctx, gen.CreateWebhookDeliveryParams{
+		ID:           delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		Status:       string(delivery.Status),
+		StatusCode:   int32(delivery.StatusCode),
+		Error:        delivery.Error,
+		LatencyMs:    int32(delivery.LatencyMs),
+		ResponseBody: delivery.ResponseBody,
+		RedeliveryOf: delivery.RedeliveryOf,
+		CreatedAt:    delivery.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error) {
+	row, err := r.queries.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.WebhookDelivery{}, domain.ErrNotFound
+		}
+		return entities.WebhookDelivery{}, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return webhookDeliveryFromRow(row), nil
+}
+
+func (r *WebhookRepository) ListWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus, limit, offset int32) ([]entities.WebhookDelivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, webhookID, deliveryStatusArg(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	deliveries := make([]entities.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = webhookDeliveryFromRow(row)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) CountWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, status entities.WebhookDeliveryStatus) (int64, error) {
+	count, err := r.queries.CountWebhookDeliveries(ctx, webhookID, deliveryStatusArg(status))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	return count, nil
+}
+
+// deliveryStatusArg leaves the status filter out of the query when it isn't
+// set.
+func deliveryStatusArg(status entities.WebhookDeliveryStatus) *string {
+	if status == "" {
+		return nil
+	}
+	s := string(status)
+	return &s
+}
+
+func webhooksFromRows(rows []gen.Webhook) []entities.Webhook {
+	webhooks := make([]entities.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = webhookFromRow(row)
+	}
+	return webhooks
+}
+
+func webhookFromRow(row gen.Webhook) entities.Webhook {
+	return entities.Webhook{
+		ID:        row.ID,
+		URL:       row.Url,
+		Events:    row.Events,
+		Secret:    row.Secret,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func webhookDeliveryFromRow(row gen.WebhookDelivery) entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		ID:           row.ID,
+		WebhookID:    row.WebhookID,
+		Event:        row.Event,
+		Payload:      row.Payload,
+		Status:       entities.WebhookDeliveryStatus(row.Status),
+		StatusCode:   int(row.StatusCode),
+		Error:        row.Error,
+		LatencyMs:    int64(row.LatencyMs),
+		ResponseBody: row.ResponseBody,
+		RedeliveryOf: row.RedeliveryOf,
+		CreatedAt:    row.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+-- name: CreateWebhook :exec
+INSERT INTO webhooks (id, url, secret, events, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks ORDER BY created_at DESC;
+
+-- name: ListWebhooksForEvent :many
+SELECT * FROM webhooks WHERE @event::text = ANY(events) ORDER BY created_at;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, event, payload, status, status_code, error, latency_ms, response_body, redelivery_of, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = @webhook_id
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC, id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE webhook_id = @webhook_id
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'));
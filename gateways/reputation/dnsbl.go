@@ -0,0 +1,65 @@
+// Package reputation looks up IP addresses in abuse blocklists.
+package reputation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DNSBL checks IP addresses against DNS-based blocklists such as
+// zen.spamhaus.org. An address is listed when any zone resolves it.
+type DNSBL struct {
+	zones    []string
+	resolver *net.Resolver
+}
+
+func NewDNSBL(zones ...string) *DNSBL {
+	return &DNSBL{
+		zones:    zones,
+		resolver: net.DefaultResolver,
+	}
+}
+
+func (d *DNSBL) IsListed(ctx context.Context, ip string) (bool, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false, fmt.Errorf("invalid ip address %q", ip)
+	}
+	// Blocklists only know about public addresses
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsLinkLocalUnicast() {
+		return false, nil
+	}
+
+	query := reverse(addr)
+	for _, zone := range d.zones {
+		_, err := d.resolver.LookupHost(ctx, query+"."+zone)
+		if err == nil {
+			return true, nil
+		}
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			continue
+		}
+		return false, fmt.Errorf("failed to query %s: %w", zone, err)
+	}
+	return false, nil
+}
+
+// reverse returns the address in blocklist query order: reversed octets for
+// IPv4 and reversed nibbles for IPv6.
+func reverse(addr net.IP) string {
+	if v4 := addr.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d", v4[3], v4[2], v4[1], v4[0])
+	}
+
+	const hex = "0123456789abcdef"
+	v6 := addr.To16()
+	nibbles := make([]string, 0, 32)
+	for i := len(v6) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, string(hex[v6[i]&0x0f]), string(hex[v6[i]>>4]))
+	}
+	return strings.Join(nibbles, ".")
+}
@@ -0,0 +1,19 @@
+package sms
+
+import (
+	"context"
+	"go-template/domain"
+)
+
+// Log writes messages to the log instead of sending them. It is meant for
+// local development, where no SMS gateway is available.
+type Log struct{}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (Log) Send(ctx context.Context, to, body string) error {
+	domain.Logger(ctx).InfoContext(ctx, "sms not sent, logging instead", "to", to, "body", body)
+	return nil
+}
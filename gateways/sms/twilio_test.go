@@ -0,0 +1,66 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwilio_Send(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/Accounts/AC123/Messages.json", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "token", pass)
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "+15550001111", r.Form.Get("To"))
+		assert.Equal(t, "+15559998888", r.Form.Get("From"))
+		assert.Equal(t, "hello", r.Form.Get("Body"))
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"sid": "SM1"})
+	}))
+	defer srv.Close()
+
+	tw := NewTwilio("AC123", "token", "+15559998888")
+	tw.apiURL = srv.URL
+
+	require.NoError(t, tw.Send(context.Background(), "+15550001111", "hello"))
+}
+
+func TestTwilio_Send_MessagingService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "MG123", r.Form.Get("MessagingServiceSid"))
+		assert.Empty(t, r.Form.Get("From"))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	tw := NewTwilio("AC123", "token", "MG123")
+	tw.apiURL = srv.URL
+
+	require.NoError(t, tw.Send(context.Background(), "+15550001111", "hello"))
+}
+
+func TestTwilio_Send_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{"code": 21211, "message": "Invalid 'To' Phone Number"})
+	}))
+	defer srv.Close()
+
+	tw := NewTwilio("AC123", "token", "+15559998888")
+	tw.apiURL = srv.URL
+
+	err := tw.Send(context.Background(), "+1", "hello")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid 'To' Phone Number")
+}
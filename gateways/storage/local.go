@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Local stores files on disk under a directory. It suits development and
+// single instance deployments; Handler serves the files at the base URL.
+type Local struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocal creates the directory when missing. baseURL is where Handler is
+// mounted, such as http://localhost:8080/files.
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &Local{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put writes the file to a temporary name first, so readers never see it
+// half written. The content type is left to the extension.
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens the file, or returns ErrNotFound when there is none.
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	return f, nil
+}
+
+// Size returns the file's size, or ErrNotFound when there is none.
+func (l *Local) Size(ctx context.Context, key string) (int64, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+	return info.Size(), nil
+}
+
+// Delete removes the file. Removing a missing file is not an error.
+func (l *Local) Delete(ctx context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.baseURL + "/" + key
+}
+
+// SetSigningKey lets SignedURL link to files, private ones included.
+func (l *Local) SetSigningKey(key []byte) {
+	l.signingKey = key
+}
+
+// SignedURL links to the file under key until ttl passes. Handler checks the
+// link's signature, so it works for files under PrivatePrefix too.
+func (l *Local) SignedURL(key string, ttl time.Duration) (string, error) {
+	if len(l.signingKey) == 0 {
+		return "", errors.New("storage has no signing key")
+	}
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(ttl).Unix()
+	return fmt.Sprintf("%s/%s?expires=%d&signature=%s", l.baseURL, key, expires, l.sign(key, expires)), nil
+}
+
+// SignedUploadURL lets a client PUT a file of exactly size bytes under key
+// until ttl passes. Handler checks the link's signature and the size.
+func (l *Local) SignedUploadURL(key string, size int64, ttl time.Duration) (string, error) {
+	if len(l.signingKey) == 0 {
+		return "", errors.New("storage has no signing key")
+	}
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(ttl).Unix()
+	return fmt.Sprintf("%s/%s?expires=%d&size=%d&signature=%s", l.baseURL, key, expires, size, l.signUpload(key, size, expires)), nil
+}
+
+func (l *Local) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	fmt.Fprintf(mac, "%s\n%d", key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signUpload signs an upload link apart from download links, so neither can
+// be used as the other.
+func (l *Local) signUpload(key string, size, expires int64) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	fmt.Fprintf(mac, "PUT\n%s\n%d\n%d", key, size, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validSignature tells whether the request carries an unexpired signed link
+// to key.
+func (l *Local) validSignature(r *http.Request, key string) bool {
+	if len(l.signingKey) == 0 {
+		return false
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(r.URL.Query().Get("signature")), []byte(l.sign(key, expires)))
+}
+
+// Handler serves the stored files, without listing directories. Files under
+// PrivatePrefix are only served through a SignedURL, and as downloads, since
+// they may hold anything users uploaded. PUT stores a file through a
+// SignedUploadURL. Mount it with the base URL's path stripped.
+func (l *Local) Handler() http.Handler {
+	files := http.FileServer(http.Dir(l.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			l.receive(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/") || strings.HasPrefix(path.Base(r.URL.Path), ".") {
+			http.NotFound(w, r)
+			return
+		}
+		if key := strings.TrimPrefix(path.Clean(r.URL.Path), "/"); strings.HasPrefix(key, PrivatePrefix) {
+			if !l.validSignature(r, key) {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Cache-Control", "private, no-store")
+			w.Header().Set("Content-Disposition", "attachment")
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// receive stores the body of a PUT to a SignedUploadURL, which must be
+// exactly the size the link was signed for.
+func (l *Local) receive(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if checkKey(key) != nil || len(l.signingKey) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	q := r.URL.Query()
+	expires, err := strconv.ParseInt(q.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		http.NotFound(w, r)
+		return
+	}
+	size, err := strconv.ParseInt(q.Get("size"), 10, 64)
+	if err != nil || !hmac.Equal([]byte(q.Get("signature")), []byte(l.signUpload(key, size, expires))) {
+		http.NotFound(w, r)
+		return
+	}
+	if r.ContentLength != size {
+		http.Error(w, fmt.Sprintf("the upload must be %d bytes", size), http.StatusBadRequest)
+		return
+	}
+
+	if err := l.Put(r.Context(), key, http.MaxBytesReader(w, r.Body, size), r.Header.Get("Content-Type")); err != nil {
+		http.Error(w, "failed to store the upload", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// path maps key to a file under the directory, refusing keys that would
+// escape it.
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
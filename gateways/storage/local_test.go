@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewLocal(filepath.Join(dir, "uploads"), "http://localhost:8080/files/")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, l.Put(ctx, "avatars/u1/a.png", strings.NewReader("png"), "image/png"))
+	assert.Equal(t, "http://localhost:8080/files/avatars/u1/a.png", l.URL("avatars/u1/a.png"))
+
+	srv := httptest.NewServer(http.StripPrefix("/files", l.Handler()))
+	t.Cleanup(srv.Close)
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	code, body := get("/files/avatars/u1/a.png")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "png", body)
+	code, _ = get("/files/avatars/u1/")
+	assert.Equal(t, http.StatusNotFound, code, "directories are not listed")
+
+	for _, key := range []string{"", "../escape", "avatars/../../escape", "/abs", "avatars//a"} {
+		assert.Error(t, l.Put(ctx, key, strings.NewReader("x"), ""), "key %q", key)
+	}
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	assert.True(t, os.IsNotExist(err), "no file escapes the storage directory")
+
+	r, err := l.Get(ctx, "avatars/u1/a.png")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "png", string(data))
+
+	require.NoError(t, l.Delete(ctx, "avatars/u1/a.png"))
+	require.NoError(t, l.Delete(ctx, "avatars/u1/a.png"), "deleting a missing file")
+	_, err = l.Get(ctx, "avatars/u1/a.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+	code, _ = get("/files/avatars/u1/a.png")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestLocal_SignedURL(t *testing.T) {
+	l, err := NewLocal(t.TempDir(), "/files")
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, l.Put(ctx, "private/exports/e1.csv", strings.NewReader("a,b"), "text/csv"))
+
+	_, err = l.SignedURL("private/exports/e1.csv", time.Minute)
+	assert.Error(t, err, "signing needs a key")
+	l.SetSigningKey([]byte("secret"))
+
+	srv := httptest.NewServer(http.StripPrefix("/files", l.Handler()))
+	t.Cleanup(srv.Close)
+	get := func(url string) *http.Response {
+		resp, err := http.Get(srv.URL + url)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/files/private/exports/e1.csv").StatusCode, "private files need a signature")
+
+	url, err := l.SignedURL("private/exports/e1.csv", time.Minute)
+	require.NoError(t, err)
+	resp := get(url)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "private, no-store", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, "attachment", resp.Header.Get("Content-Disposition"))
+
+	assert.Equal(t, http.StatusNotFound, get(strings.Replace(url, "e1.csv", "e2.csv", 1)).StatusCode, "signature is bound to the key")
+	tampered := url[:len(url)-1] + map[bool]string{true: "1", false: "0"}[strings.HasSuffix(url, "0")]
+	assert.Equal(t, http.StatusNotFound, get(tampered).StatusCode, "tampered signature")
+
+	expired, err := l.SignedURL("private/exports/e1.csv", -time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, get(expired).StatusCode, "expired link")
+
+	_, err = l.SignedURL("../escape", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestLocal_SignedUploadURL(t *testing.T) {
+	l, err := NewLocal(t.TempDir(), "/files")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = l.SignedUploadURL("private/uploads/u1/f.txt", 5, time.Minute)
+	assert.Error(t, err, "signing needs a key")
+	l.SetSigningKey([]byte("secret"))
+
+	srv := httptest.NewServer(http.StripPrefix("/files", l.Handler()))
+	t.Cleanup(srv.Close)
+	put := func(url, body string) int {
+		req, err := http.NewRequest(http.MethodPut, srv.URL+url, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	url, err := l.SignedUploadURL("private/uploads/u1/f.txt", 5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, put(url, "too long"), "the size is signed")
+	_, err = l.Size(ctx, "private/uploads/u1/f.txt")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Equal(t, http.StatusOK, put(url, "hello"))
+	size, err := l.Size(ctx, "private/uploads/u1/f.txt")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	assert.Equal(t, http.StatusNotFound, put(strings.Replace(url, "f.txt", "g.txt", 1), "hello"), "signature is bound to the key")
+	assert.Equal(t, http.StatusNotFound, put(strings.Replace(url, "size=5", "size=8", 1), "too long"), "signature is bound to the size")
+	assert.Equal(t, http.StatusNotFound, put("/files/private/uploads/u1/h.txt", "hello"), "uploads need a signature")
+
+	download, err := l.SignedURL("private/uploads/u1/f.txt", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, put(download, "hello"), "download links can't upload")
+
+	expired, err := l.SignedUploadURL("private/uploads/u1/f.txt", 5, -time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, put(expired, "hello"), "expired link")
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Config locates the bucket. Endpoint and UsePathStyle are for S3
+// compatible stores such as MinIO; without AccessKeyID the default AWS
+// credential chain is used. PublicURL is where the bucket's public files are
+// served, such as the bucket's website or a CDN in front of it.
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+	PublicURL       string
+}
+
+// S3 stores files in an S3 compatible bucket, for deployments with more than
+// one instance. The bucket must keep objects under PrivatePrefix private;
+// SignedURL presigns links to them.
+type S3 struct {
+	client    *s3.Client
+	presign   *s3.PresignClient
+	bucket    string
+	publicURL string
+}
+
+// NewS3 creates the bucket's client. It doesn't check the bucket exists.
+func NewS3(ctx context.Context, cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 storage needs a bucket")
+	}
+
+	opts := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+	return &S3{
+		client:    client,
+		presign:   s3.NewPresignClient(client),
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimSuffix(cfg.PublicURL, "/"),
+	}, nil
+}
+
+// Put uploads the file. Bodies that can't be rewound, such as pipes, are
+// spooled to a temporary file first, since the upload must know its length
+// to be signed.
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok {
+		tmp, err := os.CreateTemp("", "upload-*")
+		if err != nil {
+			return fmt.Errorf("failed to buffer %s: %w", key, err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		if _, err := io.Copy(tmp, body); err != nil {
+			return fmt.Errorf("failed to buffer %s: %w", key, err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to buffer %s: %w", key, err)
+		}
+		seeker = tmp
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   seeker,
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if _, err := s.client.PutObject(ctx, input); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens the file, or returns ErrNotFound when there is none.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+// Size returns the file's size, or ErrNotFound when there is none.
+func (s *S3) Size(ctx context.Context, key string) (int64, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+	return aws.ToInt64(out.ContentLength), nil
+}
+
+// Delete removes the file. Removing a missing file is not an error.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// SignedURL presigns a link to the file under key until ttl passes. Files
+// under PrivatePrefix are downloaded as attachments, since they may hold
+// anything users uploaded.
+func (s *S3) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if strings.HasPrefix(key, PrivatePrefix) {
+		input.ResponseContentDisposition = aws.String("attachment")
+	}
+	// Presigning is done locally, without calling the store
+	req, err := s.presign.PresignGetObject(context.Background(), input, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign link to %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+// SignedUploadURL presigns a PUT of the file under key until ttl passes.
+// The length is part of the signature, so the store refuses bodies of any
+// other size.
+func (s *S3) SignedUploadURL(key string, size int64, ttl time.Duration) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	req, err := s.presign.PresignPutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign upload link to %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+// isNotFound tells whether err says there is no such object. Some S3
+// compatible stores answer a bare 404 rather than NoSuchKey.
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var respErr *awshttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 keeps the objects of path style requests in memory.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet, http.MethodHead:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3(t *testing.T) {
+	fake := &fakeS3{objects: map[string]string{}, types: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3(context.Background(), S3Config{
+		Bucket:          "uploads",
+		Region:          "us-east-1",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+		PublicURL:       "https://cdn.example.com/",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "avatars/u1/a.png", strings.NewReader("png"), "image/png"))
+	assert.Equal(t, "png", fake.objects["/uploads/avatars/u1/a.png"])
+	assert.Equal(t, "image/png", fake.types["/uploads/avatars/u1/a.png"])
+	assert.Equal(t, "https://cdn.example.com/avatars/u1/a.png", s.URL("avatars/u1/a.png"))
+
+	// Streams that can't be rewound are buffered before the upload
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, "a,b")
+		pw.Close()
+	}()
+	require.NoError(t, s.Put(ctx, "private/exports/e1.csv", pr, "text/csv"))
+
+	r, err := s.Get(ctx, "private/exports/e1.csv")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "a,b", string(data))
+	size, err := s.Size(ctx, "private/exports/e1.csv")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), size)
+
+	require.NoError(t, s.Delete(ctx, "private/exports/e1.csv"))
+	_, err = s.Get(ctx, "private/exports/e1.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.Size(ctx, "private/exports/e1.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "../escape", "avatars//a"} {
+		assert.Error(t, s.Put(ctx, key, strings.NewReader("x"), ""), "key %q", key)
+	}
+}
+
+func TestS3_SignedURL(t *testing.T) {
+	s, err := NewS3(context.Background(), S3Config{
+		Bucket:          "uploads",
+		Region:          "us-east-1",
+		Endpoint:        "http://minio:9000",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	link, err := s.SignedURL("private/exports/e1.csv", 15*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "minio:9000", u.Host)
+	assert.Equal(t, "/uploads/private/exports/e1.csv", u.Path)
+	assert.Equal(t, "900", u.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+	assert.Equal(t, "attachment", u.Query().Get("response-content-disposition"), "private files are downloaded")
+
+	link, err = s.SignedURL("avatars/u1/a.png", time.Minute)
+	require.NoError(t, err)
+	assert.NotContains(t, link, "response-content-disposition")
+
+	_, err = s.SignedURL("../escape", time.Minute)
+	assert.Error(t, err)
+
+	link, err = s.SignedUploadURL("private/uploads/u1/f.bin", 42, time.Minute)
+	require.NoError(t, err)
+	u, err = url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/private/uploads/u1/f.bin", u.Path)
+	assert.Contains(t, u.Query().Get("X-Amz-SignedHeaders"), "content-length", "the size is part of the signature")
+}
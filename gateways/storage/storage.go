@@ -0,0 +1,51 @@
+// Package storage keeps uploaded files, on local disk or in an S3
+// compatible object store.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"io"
+	"path"
+	"time"
+)
+
+//go:generate moq -skip-ensure -stub -pkg mocks -out mocks/storage.go . Storage
+
+// PrivatePrefix starts the keys of files only served through a SignedURL,
+// such as exports.
+const PrivatePrefix = "private/"
+
+// ErrNotFound is returned by Get and Size when no file is stored under the
+// key. It is a domain.ErrNotFound, so use cases can tell it apart.
+var ErrNotFound = fmt.Errorf("file %w", domain.ErrNotFound)
+
+// Storage keeps files under slash separated keys. Files are served publicly
+// at URL, except those under PrivatePrefix, which are only reachable
+// through a SignedURL.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file. Removing a missing file is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL is where the file stored under key is served from.
+	URL(key string) string
+	// SignedURL links to the file under key until ttl passes.
+	SignedURL(key string, ttl time.Duration) (string, error)
+	// SignedUploadURL lets a client PUT a file of exactly size bytes under
+	// key until ttl passes, without going through this service.
+	SignedUploadURL(key string, size int64, ttl time.Duration) (string, error)
+	// Size returns the stored file's size, or ErrNotFound when there is
+	// none.
+	Size(ctx context.Context, key string) (int64, error)
+}
+
+// checkKey refuses keys that aren't clean relative paths, which could
+// escape the directory or bucket prefix files are kept under.
+func checkKey(key string) error {
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	return nil
+}
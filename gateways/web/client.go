@@ -2,25 +2,45 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"go-template/domain/entities"
+	filterExpr "go-template/internal/filter"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// refreshReuseWindow is how long the outcome of a session refresh is handed
+// to other requests that present the same refresh token.
+const refreshReuseWindow = 30 * time.Second
+
 // Client provides HTTP methods for both public web and admin endpoints.
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	authToken  string
+
+	refreshMu sync.Mutex
+	refreshed map[string]refreshedSession
+}
+
+type refreshedSession struct {
+	resp *AuthResponse
+	at   time.Time
 }
 
 func NewClient(baseURL string) *Client {
 	return &Client{
 		baseURL:    baseURL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
+		refreshed:  map[string]refreshedSession{},
 	}
 }
 
@@ -45,6 +65,11 @@ func (c *Client) doRequest(method, endpoint string, body any, requireAuth bool,
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	return c.send(req, requireAuth, result)
+}
+
+// send executes req, decoding a JSON response into result.
+func (c *Client) send(req *http.Request, requireAuth bool, result any) error {
 	if requireAuth && c.authToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.authToken)
 	}
@@ -61,14 +86,7 @@ func (c *Client) doRequest(method, endpoint string, body any, requireAuth bool,
 	}
 
 	if resp.StatusCode >= 400 {
-		// Try to surface structured error messages if present
-		var errorResp map[string]any
-		if err := json.Unmarshal(respBody, &errorResp); err == nil {
-			if msg, ok := errorResp["error"].(string); ok {
-				return fmt.Errorf("API error (%d): %s", resp.StatusCode, msg)
-			}
-		}
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return apiError(resp.StatusCode, respBody)
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -80,23 +98,53 @@ func (c *Client) doRequest(method, endpoint string, body any, requireAuth bool,
 	return nil
 }
 
+// apiError describes an error response, surfacing its structured message
+// when present.
+func apiError(status int, body []byte) error {
+	var errorResp map[string]any
+	if err := json.Unmarshal(body, &errorResp); err == nil {
+		if msg, ok := errorResp["error"].(string); ok {
+			return fmt.Errorf("API error (%d): %s", status, msg)
+		}
+	}
+	return fmt.Errorf("API error (%d): %s", status, string(body))
+}
+
 // =========================
 // Public Web API
 // =========================
 
 type AuthResponse struct {
-	Token string        `json:"token"`
-	User  entities.User `json:"user"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         entities.User `json:"user"`
+	// ExpiresIn is the access token lifetime in seconds
+	ExpiresIn int `json:"expires_in,omitempty"`
+	// MFARequired is set instead of Token when the user has two-factor
+	// authentication enabled; finish with TwoFactorChallenge
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	// EmailVerificationRequired is set instead of Token when a new account
+	// must verify its email before signing in
+	EmailVerificationRequired bool `json:"email_verification_required,omitempty"`
+	// ApprovalRequired is set instead of Token when a new account waits for
+	// an admin to approve it
+	ApprovalRequired bool `json:"approval_required,omitempty"`
 }
 
 type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	Audience       string `json:"audience,omitempty"`
+	CaptchaToken   string `json:"captcha_token,omitempty"`
+	InvitationCode string `json:"invitation_code,omitempty"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	Audience     string `json:"audience,omitempty"`
+	CaptchaToken string `json:"captcha_token,omitempty"`
 }
 
 func (c *Client) Register(req RegisterRequest) (*AuthResponse, error) {
@@ -115,6 +163,281 @@ func (c *Client) Login(req LoginRequest) (*AuthResponse, error) {
 	return &response, nil
 }
 
+// RefreshSession exchanges a refresh token for a new token pair. A page load
+// sends several requests with the same single-use refresh token, and the API
+// would end the session on the second one, so refreshes are serialized and a
+// recent outcome is reused for the same token.
+func (c *Client) RefreshSession(refreshToken string) (*AuthResponse, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	for token, s := range c.refreshed {
+		if time.Since(s.at) > refreshReuseWindow {
+			delete(c.refreshed, token)
+		}
+	}
+	if s, ok := c.refreshed[refreshToken]; ok {
+		return s.resp, nil
+	}
+
+	var response AuthResponse
+	req := map[string]string{"refresh_token": refreshToken}
+	if err := c.doRequest(http.MethodPost, "/api/v1/auth/refresh", req, false, &response); err != nil {
+		return nil, err
+	}
+	c.refreshed[refreshToken] = refreshedSession{resp: &response, at: time.Now()}
+	return &response, nil
+}
+
+type TwoFactorChallengeRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code,omitempty"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
+	Audience     string `json:"audience,omitempty"`
+}
+
+// TwoFactorChallenge completes a login that returned MFARequired.
+func (c *Client) TwoFactorChallenge(req TwoFactorChallengeRequest) (*AuthResponse, error) {
+	var response AuthResponse
+	if err := c.doRequest(http.MethodPost, "/api/v1/auth/2fa/challenge", req, false, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ForgotPassword asks the API to email a password reset link. It succeeds
+// whether or not the email belongs to a user.
+func (c *Client) ForgotPassword(email string) error {
+	req := map[string]string{"email": email}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/forgot-password", req, false, nil)
+}
+
+// ResetPassword sets a new password with the token from a reset email.
+func (c *Client) ResetPassword(token, password string) error {
+	req := map[string]string{"token": token, "password": password}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/reset-password", req, false, nil)
+}
+
+// AcceptInvitation creates the account an admin invited by email with the
+// password the invitee chose, and signs them in.
+func (c *Client) AcceptInvitation(token, password, audience string) (*AuthResponse, error) {
+	req := map[string]string{"token": token, "password": password, "audience": audience}
+	var response AuthResponse
+	if err := c.doRequest(http.MethodPost, "/api/v1/auth/invitations/accept", req, false, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// VerifyEmail confirms the email address with the token from a verification
+// email.
+func (c *Client) VerifyEmail(token string) error {
+	req := map[string]string{"token": token}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/verify-email", req, false, nil)
+}
+
+// ResendVerificationEmail asks the API to send a new verification link.
+func (c *Client) ResendVerificationEmail(email string) error {
+	req := map[string]string{"email": email}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/verify-email/resend", req, false, nil)
+}
+
+// RequestEmailChange asks the API to send a confirmation link to the new
+// address of the current user.
+func (c *Client) RequestEmailChange(email string) error {
+	req := map[string]string{"email": email}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/me/email", req, true, nil)
+}
+
+// ConfirmEmailChange changes the email with the token from a confirmation
+// email.
+func (c *Client) ConfirmEmailChange(token string) error {
+	req := map[string]string{"token": token}
+	return c.doRequest(http.MethodPost, "/api/v1/auth/email-change/confirm", req, false, nil)
+}
+
+// ListSessions returns the current user's sessions, most recently used first.
+func (c *Client) ListSessions() ([]entities.SessionInfo, error) {
+	var sessions []entities.SessionInfo
+	if err := c.doRequest(http.MethodGet, "/api/v1/auth/sessions", nil, true, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs the current user out of one of their sessions.
+func (c *Client) RevokeSession(id string) error {
+	return c.doRequest(http.MethodDelete, "/api/v1/auth/sessions/"+url.PathEscape(id), nil, true, nil)
+}
+
+// RevokeOtherSessions signs the current user out of every session but the
+// one the client is authenticated with.
+func (c *Client) RevokeOtherSessions() error {
+	return c.doRequest(http.MethodDelete, "/api/v1/auth/sessions", nil, true, nil)
+}
+
+// UploadAvatar replaces the current user's avatar with the image read from
+// r.
+func (c *Client) UploadAvatar(filename string, r io.Reader) (*entities.User, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("avatar", filename)
+	if err != nil {
+		return nil, fmt.Errorf("creating form: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("reading avatar: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("creating form: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, c.baseURL+"/api/v1/auth/me/avatar", &body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	var user entities.User
+	if err := c.send(req, true, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteAvatar removes the current user's avatar.
+func (c *Client) DeleteAvatar() error {
+	return c.doRequest(http.MethodDelete, "/api/v1/auth/me/avatar", nil, true, nil)
+}
+
+// UpdateProfile replaces the current user's profile. Metadata left nil is
+// kept.
+func (c *Client) UpdateProfile(profile entities.UserProfile) (*entities.User, error) {
+	var user entities.User
+	if err := c.doRequest(http.MethodPut, "/api/v1/auth/me/profile", profile, true, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetPreferences returns the current user's preferences.
+func (c *Client) GetPreferences() (entities.Preferences, error) {
+	var prefs entities.Preferences
+	if err := c.doRequest(http.MethodGet, "/api/v1/users/me/preferences", nil, true, &prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences merges patch into the current user's preferences; keys
+// set to nil are removed. It returns the preferences as saved.
+func (c *Client) UpdatePreferences(patch map[string]any) (entities.Preferences, error) {
+	var prefs entities.Preferences
+	if err := c.doRequest(http.MethodPatch, "/api/v1/users/me/preferences", patch, true, &prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// ListNotifications returns a page of the current user's notifications,
+// newest first, or of only the unread ones.
+func (c *Client) ListNotifications(unreadOnly bool, page, pageSize int) (*entities.NotificationListResponse, error) {
+	params := url.Values{
+		"page":      {strconv.Itoa(page)},
+		"page_size": {strconv.Itoa(pageSize)},
+	}
+	if unreadOnly {
+		params.Set("unread", "true")
+	}
+
+	var resp entities.NotificationListResponse
+	if err := c.doRequest(http.MethodGet, "/api/v1/notifications?"+params.Encode(), nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// MarkNotificationRead marks one of the current user's notifications read.
+func (c *Client) MarkNotificationRead(id string) error {
+	return c.doRequest(http.MethodPost, "/api/v1/notifications/"+url.PathEscape(id)+"/read", nil, true, nil)
+}
+
+// MarkAllNotificationsRead marks all the current user's notifications read.
+func (c *Client) MarkAllNotificationsRead() error {
+	return c.doRequest(http.MethodPost, "/api/v1/notifications/read", nil, true, nil)
+}
+
+// StreamNotifications opens the current user's notification event stream.
+// It stays open until ctx is done or the API ends it; the caller closes the
+// response body.
+func (c *Client) StreamNotifications(ctx context.Context) (*http.Response, error) {
+	return c.openStream(ctx, "/api/v1/notifications/stream")
+}
+
+// openStream opens the server-sent event stream at endpoint.
+func (c *Client) openStream(ctx context.Context, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// Without the client timeout, which would cut the stream short
+	stream := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := stream.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, apiError(resp.StatusCode, body)
+	}
+	return resp, nil
+}
+
+// ListAnnouncements returns the announcements shown now, latest first.
+// When signedIn, those the current user dismissed are left out.
+func (c *Client) ListAnnouncements(signedIn bool) ([]entities.Announcement, error) {
+	var announcements []entities.Announcement
+	if err := c.doRequest(http.MethodGet, "/api/v1/announcements", nil, signedIn, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// DismissAnnouncement stops showing an announcement to the current user.
+func (c *Client) DismissAnnouncement(id string) error {
+	return c.doRequest(http.MethodPost, "/api/v1/announcements/"+url.PathEscape(id)+"/dismiss", nil, true, nil)
+}
+
+// GetAccountDeletion returns the current user's pending account deletion.
+// The API answers 404 when there is none.
+func (c *Client) GetAccountDeletion() (*entities.DeletionRequest, error) {
+	var req entities.DeletionRequest
+	if err := c.doRequest(http.MethodGet, "/api/v1/auth/me/deletion", nil, true, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// RequestAccountDeletion schedules the current user's account for deletion.
+func (c *Client) RequestAccountDeletion() (*entities.DeletionRequest, error) {
+	var req entities.DeletionRequest
+	if err := c.doRequest(http.MethodPost, "/api/v1/auth/me/deletion", nil, true, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// CancelAccountDeletion keeps the current user's account.
+func (c *Client) CancelAccountDeletion() error {
+	return c.doRequest(http.MethodDelete, "/api/v1/auth/me/deletion", nil, true, nil)
+}
+
 func (c *Client) GetCurrentUser() (*entities.User, error) {
 	var user entities.User
 	if err := c.doRequest(http.MethodGet, "/api/v1/auth/me", nil, true, &user); err != nil {
@@ -136,6 +459,105 @@ func (c *Client) ProxyDocsRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Prompt              string `json:"prompt"`
+	Decision            string `json:"decision"`
+}
+
+type OAuthConsentPrompt struct {
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+	Required   bool     `json:"required"`
+}
+
+// OAuthConsent returns what the consent screen must ask the current user
+// about an authorization request.
+func (c *Client) OAuthConsent(req OAuthAuthorizeRequest) (*OAuthConsentPrompt, error) {
+	var response OAuthConsentPrompt
+	if err := c.doRequest(http.MethodPost, "/oauth2/authorize/consent", req, true, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// OAuthAuthorize asks the API to issue an authorization code for the current
+// user, or report the user's refusal, and returns the client redirect URL.
+func (c *Client) OAuthAuthorize(req OAuthAuthorizeRequest) (string, error) {
+	var response struct {
+		RedirectTo string `json:"redirect_to"`
+	}
+	if err := c.doRequest(http.MethodPost, "/oauth2/authorize", req, true, &response); err != nil {
+		return "", err
+	}
+	return response.RedirectTo, nil
+}
+
+// SocialProviders returns the enabled social login providers.
+func (c *Client) SocialProviders() ([]string, error) {
+	var response struct {
+		Providers []string `json:"providers"`
+	}
+	if err := c.doRequest(http.MethodGet, "/api/v1/auth/social", nil, false, &response); err != nil {
+		return nil, err
+	}
+	return response.Providers, nil
+}
+
+type CaptchaConfig struct {
+	Required bool   `json:"required"`
+	Provider string `json:"provider,omitempty"`
+	SiteKey  string `json:"site_key,omitempty"`
+}
+
+// Captcha returns the CAPTCHA widget registration and login forms must
+// render, if any.
+func (c *Client) Captcha() (*CaptchaConfig, error) {
+	var response CaptchaConfig
+	if err := c.doRequest(http.MethodGet, "/api/v1/auth/captcha", nil, false, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SocialAuthURL returns the provider page that starts a social login.
+func (c *Client) SocialAuthURL(provider, state, redirectURI string) (string, error) {
+	params := url.Values{}
+	params.Set("state", state)
+	params.Set("redirect_uri", redirectURI)
+
+	var response struct {
+		URL string `json:"url"`
+	}
+	endpoint := "/api/v1/auth/social/" + url.PathEscape(provider) + "?" + params.Encode()
+	if err := c.doRequest(http.MethodGet, endpoint, nil, false, &response); err != nil {
+		return "", err
+	}
+	return response.URL, nil
+}
+
+type SocialLoginRequest struct {
+	Code        string `json:"code"`
+	RedirectURI string `json:"redirect_uri"`
+	Audience    string `json:"audience,omitempty"`
+}
+
+// SocialLogin completes a social login with the code the provider returned.
+func (c *Client) SocialLogin(provider string, req SocialLoginRequest) (*AuthResponse, error) {
+	var response AuthResponse
+	if err := c.doRequest(http.MethodPost, "/api/v1/auth/social/"+url.PathEscape(provider)+"/callback", req, false, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
 func (c *Client) ValidateToken() error {
 	_, err := c.GetCurrentUser()
 	return err
@@ -151,10 +573,30 @@ type AdminLoginRequest struct {
 }
 
 type AdminLoginResponse struct {
-	Token       string        `json:"token"`
-	User        entities.User `json:"user"`
-	AccountType string        `json:"account_type"`
-	ExpiresAt   time.Time     `json:"expires_at"`
+	Token        string        `json:"token"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         entities.User `json:"user"`
+	AccountType  string        `json:"account_type"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+
+	MFARequired            bool   `json:"mfa_required,omitempty"`
+	MFAToken               string `json:"mfa_token,omitempty"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+	// RecoveryCodes is only set by AdminEnableTOTP
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type AdminVerifyResponse struct {
+	Valid bool `json:"valid"`
+	// TwoFactorSetupRequired is set when Require2FA is on and the session
+	// has no second factor
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode string `json:"qr_code"`
 }
 
 func (c *Client) AdminLogin(email, password string) (*AdminLoginResponse, error) {
@@ -166,12 +608,135 @@ func (c *Client) AdminLogin(email, password string) (*AdminLoginResponse, error)
 	return &resp, nil
 }
 
-func (c *Client) AdminLogout() error {
-	return c.doRequest(http.MethodPost, "/admin/v1/logout", nil, true, nil)
+// BreakGlass redeems the break-glass credential for a short-lived super admin
+// session.
+func (c *Client) BreakGlass(credential string) (*AdminLoginResponse, error) {
+	req := map[string]string{"credential": credential}
+	var resp AdminLoginResponse
+	if err := c.doRequest(http.MethodPost, "/admin/v1/break-glass", req, false, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Logout revokes the current access token, and the session of refreshToken
+// when it is set.
+func (c *Client) Logout(refreshToken string) error {
+	return c.doRequest(http.MethodPost, "/api/v1/auth/logout", logoutBody(refreshToken), true, nil)
+}
+
+func (c *Client) AdminLogout(refreshToken string) error {
+	return c.doRequest(http.MethodPost, "/admin/v1/logout", logoutBody(refreshToken), true, nil)
+}
+
+func logoutBody(refreshToken string) any {
+	if refreshToken == "" {
+		return nil
+	}
+	return map[string]string{"refresh_token": refreshToken}
+}
+
+func (c *Client) VerifyToken() (*AdminVerifyResponse, error) {
+	var resp AdminVerifyResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/verify", nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AdminTwoFactorChallenge completes an admin login that returned MFARequired
+// with either an authenticator code or a recovery code.
+func (c *Client) AdminTwoFactorChallenge(mfaToken, code, recoveryCode string) (*AdminLoginResponse, error) {
+	req := map[string]string{"mfa_token": mfaToken, "code": code, "recovery_code": recoveryCode}
+	var resp AdminLoginResponse
+	if err := c.doRequest(http.MethodPost, "/admin/v1/2fa/challenge", req, false, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AdminEnrollTOTP starts two-factor enrollment for the signed in admin.
+func (c *Client) AdminEnrollTOTP() (*TwoFactorEnrollment, error) {
+	var resp TwoFactorEnrollment
+	if err := c.doRequest(http.MethodPost, "/admin/v1/2fa/enroll", nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AdminEnableTOTP confirms the enrollment and returns a session that passes
+// the Require2FA check.
+func (c *Client) AdminEnableTOTP(code string) (*AdminLoginResponse, error) {
+	req := map[string]string{"code": code}
+	var resp AdminLoginResponse
+	if err := c.doRequest(http.MethodPost, "/admin/v1/2fa/enable", req, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) GetMyPermissions() (*entities.AdminPermissions, error) {
+	var perms entities.AdminPermissions
+	if err := c.doRequest(http.MethodGet, "/admin/v1/permissions/me", nil, true, &perms); err != nil {
+		return nil, err
+	}
+	return &perms, nil
+}
+
+// RoleRequest creates a custom role or replaces one's name, description and
+// permissions.
+type RoleRequest struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Permissions []entities.Permission `json:"permissions"`
+}
+
+// ListRoles returns the builtin roles followed by the custom ones.
+func (c *Client) ListRoles() ([]entities.Role, error) {
+	var roles []entities.Role
+	if err := c.doRequest(http.MethodGet, "/admin/v1/roles", nil, true, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (c *Client) CreateRole(req RoleRequest) (*entities.Role, error) {
+	var role entities.Role
+	if err := c.doRequest(http.MethodPost, "/admin/v1/roles", req, true, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (c *Client) UpdateRole(roleID string, req RoleRequest) (*entities.Role, error) {
+	var role entities.Role
+	if err := c.doRequest(http.MethodPut, "/admin/v1/roles/"+url.PathEscape(roleID), req, true, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (c *Client) DeleteRole(roleID string) error {
+	return c.doRequest(http.MethodDelete, "/admin/v1/roles/"+url.PathEscape(roleID), nil, true, nil)
 }
 
-func (c *Client) VerifyToken() error {
-	return c.doRequest(http.MethodGet, "/admin/v1/verify", nil, true, nil)
+// GetUserRoles returns the roles a user holds, their builtin one included.
+func (c *Client) GetUserRoles(userID string) ([]entities.Role, error) {
+	var roles []entities.Role
+	if err := c.doRequest(http.MethodGet, "/admin/v1/roles/users/"+url.PathEscape(userID), nil, true, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (c *Client) AssignRole(roleID, userID string) error {
+	endpoint := fmt.Sprintf("/admin/v1/roles/%s/users/%s", url.PathEscape(roleID), url.PathEscape(userID))
+	return c.doRequest(http.MethodPut, endpoint, nil, true, nil)
+}
+
+func (c *Client) UnassignRole(roleID, userID string) error {
+	endpoint := fmt.Sprintf("/admin/v1/roles/%s/users/%s", url.PathEscape(roleID), url.PathEscape(userID))
+	return c.doRequest(http.MethodDelete, endpoint, nil, true, nil)
 }
 
 func (c *Client) GetDashboardStats() (*entities.DashboardStats, error) {
@@ -182,6 +747,12 @@ func (c *Client) GetDashboardStats() (*entities.DashboardStats, error) {
 	return &stats, nil
 }
 
+// StreamDashboardStats opens the dashboard stats event stream. It stays open
+// until ctx is done or the API ends it; the caller closes the response body.
+func (c *Client) StreamDashboardStats(ctx context.Context) (*http.Response, error) {
+	return c.openStream(ctx, "/admin/v1/dashboard/stream")
+}
+
 func (c *Client) ListUsers(page, pageSize int) (*entities.UserListResponse, error) {
 	endpoint := fmt.Sprintf("/admin/v1/users?page=%d&page_size=%d", page, pageSize)
 	var resp entities.UserListResponse
@@ -191,16 +762,84 @@ func (c *Client) ListUsers(page, pageSize int) (*entities.UserListResponse, erro
 	return &resp, nil
 }
 
-func (c *Client) ListUsersWithFilter(page, pageSize int, search, accountType string) (*entities.UserListResponse, error) {
-	endpoint := fmt.Sprintf("/admin/v1/users?page=%d&page_size=%d", page, pageSize)
+// ListUsersWithFilter lists a page of users matching search and accountType,
+// in sort order; the zero sort lists the newest first.
+func (c *Client) ListUsersWithFilter(page, pageSize int, search, accountType string, sort entities.UserSort) (*entities.UserListResponse, error) {
+	params := url.Values{
+		"page":      {strconv.Itoa(page)},
+		"page_size": {strconv.Itoa(pageSize)},
+	}
 	if search != "" {
-		endpoint += fmt.Sprintf("&search=%s", search)
+		params.Set("search", search)
 	}
 	if accountType != "" {
-		endpoint += fmt.Sprintf("&account_type=%s", accountType)
+		params.Set("account_type", accountType)
+	}
+	if sort.Field != "" {
+		params.Set("sort", string(sort.Field))
+		params.Set("order", "asc")
+		if sort.Desc {
+			params.Set("order", "desc")
+		}
 	}
 	var resp entities.UserListResponse
-	if err := c.doRequest(http.MethodGet, endpoint, nil, true, &resp); err != nil {
+	if err := c.doRequest(http.MethodGet, "/admin/v1/users?"+params.Encode(), nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExportUsers streams the users matching search and accountType as a
+// spreadsheet in format, csv or xlsx. The caller copies the response on,
+// headers included, and closes its body.
+func (c *Client) ExportUsers(format, search, accountType string) (*http.Response, error) {
+	params := url.Values{"format": {format}}
+	if search != "" {
+		params.Set("search", search)
+	}
+	if accountType != "" {
+		params.Set("account_type", accountType)
+	}
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/admin/v1/users/export?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// Large exports take longer to stream than the client's timeout allows
+	client := *c.httpClient
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, apiError(resp.StatusCode, body)
+	}
+	return resp, nil
+}
+
+// ListUsersAfter lists users by cursor instead of page number. Pass an empty
+// cursor for the first page and the response's NextCursor for the next ones;
+// NextCursor is empty on the last page.
+func (c *Client) ListUsersAfter(cursor string, pageSize int, search, accountType string) (*entities.UserListResponse, error) {
+	params := url.Values{"cursor": {cursor}}
+	if pageSize > 0 {
+		params.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if search != "" {
+		params.Set("search", search)
+	}
+	if accountType != "" {
+		params.Set("account_type", accountType)
+	}
+
+	var resp entities.UserListResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/users?"+params.Encode(), nil, true, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -249,6 +888,237 @@ func (c *Client) DeleteUser(userID string) error {
 	return c.doRequest(http.MethodDelete, endpoint, nil, true, nil)
 }
 
+// ImpersonateUser returns a Web app token acting as the user, for the
+// calling admin.
+func (c *Client) ImpersonateUser(userID string) (*AuthResponse, error) {
+	var resp AuthResponse
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/impersonate", userID)
+	if err := c.doRequest(http.MethodPost, endpoint, nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RevokeUserSessions forces the user out of every session.
+func (c *Client) RevokeUserSessions(userID string) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/revoke-sessions", userID)
+	return c.doRequest(http.MethodPost, endpoint, nil, true, nil)
+}
+
+// SuspendUser keeps the user out until reactivated. The reason is shown to
+// them.
+func (c *Client) SuspendUser(userID, reason string) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/suspend", userID)
+	body := map[string]string{"reason": reason}
+	return c.doRequest(http.MethodPost, endpoint, body, true, nil)
+}
+
+// ReactivateUser lets a suspended user back in.
+func (c *Client) ReactivateUser(userID string) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/reactivate", userID)
+	return c.doRequest(http.MethodPost, endpoint, nil, true, nil)
+}
+
+// BulkUsersRequest applies one action to several users at once. AccountType
+// is for change_account_type and Reason for suspend.
+type BulkUsersRequest struct {
+	Action      entities.BulkUserAction `json:"action"`
+	UserIDs     []string                `json:"user_ids"`
+	AccountType entities.AccountType    `json:"account_type,omitempty"`
+	Reason      string                  `json:"reason,omitempty"`
+}
+
+type BulkUsersResponse struct {
+	Results   []entities.BulkUserResult `json:"results"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+}
+
+// BulkUsers applies the action to the users in one transaction. Users the
+// admin can't act on are skipped, and their result says why.
+func (c *Client) BulkUsers(req BulkUsersRequest) (*BulkUsersResponse, error) {
+	var resp BulkUsersResponse
+	if err := c.doRequest(http.MethodPost, "/admin/v1/users/bulk", req, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+type UserImportResponse struct {
+	Results []entities.UserImportResult `json:"results"`
+	Created int                         `json:"created"`
+	Failed  int                         `json:"failed"`
+}
+
+// ImportUsers creates the users listed in the CSV file read from r. Rows
+// that can't be imported are skipped, and their result says why.
+func (c *Client) ImportUsers(filename string, r io.Reader) (*UserImportResponse, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("creating form: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("creating form: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/admin/v1/users/import", &body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	var resp UserImportResponse
+	if err := c.send(req, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListPendingUsers returns the users waiting for approval, oldest first.
+func (c *Client) ListPendingUsers(page, pageSize int) (*entities.UserListResponse, error) {
+	endpoint := fmt.Sprintf("/admin/v1/users/pending?page=%d&page_size=%d", page, pageSize)
+	var resp entities.UserListResponse
+	if err := c.doRequest(http.MethodGet, endpoint, nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ApproveUser lets a pending user in, emailing them when notify is set.
+func (c *Client) ApproveUser(userID string, notify bool) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/approve", userID)
+	body := map[string]bool{"notify": notify}
+	return c.doRequest(http.MethodPost, endpoint, body, true, nil)
+}
+
+// RejectUser turns down a pending user, deleting their account. The reason
+// is only sent to the user, when notify is set.
+func (c *Client) RejectUser(userID, reason string, notify bool) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/reject", userID)
+	body := map[string]any{"reason": reason, "notify": notify}
+	return c.doRequest(http.MethodPost, endpoint, body, true, nil)
+}
+
+// UpdateUserProfile replaces the user's profile. Metadata left nil is kept,
+// and an empty map clears it.
+func (c *Client) UpdateUserProfile(userID string, profile entities.UserProfile) error {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/profile", userID)
+	body := struct {
+		entities.UserProfile
+		Metadata map[string]any `json:"metadata"`
+	}{profile, profile.Metadata}
+	return c.doRequest(http.MethodPut, endpoint, body, true, nil)
+}
+
+// ListUserLogins returns the user's latest login attempts, newest first.
+func (c *Client) ListUserLogins(userID string) ([]entities.LoginEvent, error) {
+	endpoint := fmt.Sprintf("/admin/v1/users/%s/logins", userID)
+	var events []entities.LoginEvent
+	if err := c.doRequest(http.MethodGet, endpoint, nil, true, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// InvitationRequest creates an invitation code. Codes without MaxUses can be
+// used any number of times, and those without ExpiresAt expire after 7 days.
+type InvitationRequest struct {
+	AccountType entities.AccountType `json:"account_type"`
+	MaxUses     *int                 `json:"max_uses,omitempty"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	Note        string               `json:"note,omitempty"`
+}
+
+// ListInvitations returns every invitation, newest first.
+func (c *Client) ListInvitations() ([]entities.Invitation, error) {
+	var invitations []entities.Invitation
+	if err := c.doRequest(http.MethodGet, "/admin/v1/invitations", nil, true, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// CreateInvitation returns the new invitation and its code, which can't be
+// retrieved again.
+func (c *Client) CreateInvitation(req InvitationRequest) (*entities.Invitation, string, error) {
+	var resp struct {
+		entities.Invitation
+		Code string `json:"code"`
+	}
+	if err := c.doRequest(http.MethodPost, "/admin/v1/invitations", req, true, &resp); err != nil {
+		return nil, "", err
+	}
+	return &resp.Invitation, resp.Code, nil
+}
+
+// InviteRequest emails an invitation to Email. Invitations without
+// ExpiresAt expire after 7 days.
+type InviteRequest struct {
+	Email       string               `json:"email"`
+	AccountType entities.AccountType `json:"account_type"`
+	ExpiresAt   *time.Time           `json:"expires_at,omitempty"`
+	Note        string               `json:"note,omitempty"`
+}
+
+// SendInvitation emails a link to accept an invitation to req.Email.
+func (c *Client) SendInvitation(req InviteRequest) (*entities.Invitation, error) {
+	var invitation entities.Invitation
+	if err := c.doRequest(http.MethodPost, "/admin/v1/invitations/email", req, true, &invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// RevokeInvitation stops an invitation from being registered with.
+func (c *Client) RevokeInvitation(invitationID string) error {
+	return c.doRequest(http.MethodDelete, "/admin/v1/invitations/"+url.PathEscape(invitationID), nil, true, nil)
+}
+
+// AnnouncementRequest creates an announcement, or replaces one. StartsAt
+// defaults to now, or to the current start when updating, and Level to
+// info; announcements without EndsAt are shown until they are deleted.
+type AnnouncementRequest struct {
+	Message  string                     `json:"message"`
+	Level    entities.AnnouncementLevel `json:"level,omitempty"`
+	StartsAt *time.Time                 `json:"starts_at,omitempty"`
+	EndsAt   *time.Time                 `json:"ends_at,omitempty"`
+}
+
+// ListAllAnnouncements returns every announcement, including scheduled and
+// ended ones, latest first.
+func (c *Client) ListAllAnnouncements() ([]entities.Announcement, error) {
+	var announcements []entities.Announcement
+	if err := c.doRequest(http.MethodGet, "/admin/v1/announcements", nil, true, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+func (c *Client) CreateAnnouncement(req AnnouncementRequest) (*entities.Announcement, error) {
+	var announcement entities.Announcement
+	if err := c.doRequest(http.MethodPost, "/admin/v1/announcements", req, true, &announcement); err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (c *Client) UpdateAnnouncement(id string, req AnnouncementRequest) (*entities.Announcement, error) {
+	var announcement entities.Announcement
+	if err := c.doRequest(http.MethodPut, "/admin/v1/announcements/"+url.PathEscape(id), req, true, &announcement); err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (c *Client) DeleteAnnouncement(id string) error {
+	return c.doRequest(http.MethodDelete, "/admin/v1/announcements/"+url.PathEscape(id), nil, true, nil)
+}
+
 func (c *Client) GetSettings() (*entities.SystemSettings, error) {
 	var settings entities.SystemSettings
 	if err := c.doRequest(http.MethodGet, "/admin/v1/settings", nil, true, &settings); err != nil {
@@ -268,3 +1138,338 @@ func (c *Client) GetAuthProviders() (map[string]any, error) {
 	}
 	return response, nil
 }
+
+// GetSystemLogs returns recent API log entries matching filter.
+func (c *Client) GetSystemLogs(filter entities.LogFilter) (*entities.LogListResponse, error) {
+	params := url.Values{}
+	if filter.Level != "" {
+		params.Set("level", filter.Level)
+	}
+	if filter.RequestID != "" {
+		params.Set("request_id", filter.RequestID)
+	}
+	if filter.After > 0 {
+		params.Set("after", strconv.FormatUint(filter.After, 10))
+	}
+	if filter.Limit > 0 {
+		params.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var logs entities.LogListResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/system/logs?"+params.Encode(), nil, true, &logs); err != nil {
+		return nil, err
+	}
+	return &logs, nil
+}
+
+// ListAuditEvents returns a page of the audit events matching filter.
+func (c *Client) ListAuditEvents(filter entities.AuditFilter) (*entities.AuditEventListResponse, error) {
+	params := url.Values{}
+	if filter.ActorID != nil {
+		params.Set("actor_id", filter.ActorID.String())
+	}
+	if filter.Action != "" {
+		params.Set("action", filter.Action)
+	}
+	if filter.Resource != "" {
+		params.Set("resource", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		params.Set("resource_id", filter.ResourceID)
+	}
+	if filter.From != nil {
+		params.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if filter.To != nil {
+		params.Set("to", filter.To.Format(time.RFC3339))
+	}
+	if len(filter.Expr) > 0 {
+		params.Set("filter", filterExpr.Format(filter.Expr))
+	}
+	if filter.Page > 0 {
+		params.Set("page", strconv.Itoa(filter.Page))
+	}
+	if filter.PageSize > 0 {
+		params.Set("page_size", strconv.Itoa(filter.PageSize))
+	}
+
+	var events entities.AuditEventListResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/audit?"+params.Encode(), nil, true, &events); err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+// ListEmails returns the transactional emails that can be previewed.
+func (c *Client) ListEmails() ([]entities.EmailTemplate, error) {
+	var names []entities.EmailTemplate
+	if err := c.doRequest(http.MethodGet, "/admin/v1/emails", nil, true, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// PreviewEmail renders the email with sample data in the locale's language.
+func (c *Client) PreviewEmail(name entities.EmailTemplate, locale string) (*entities.RenderedEmail, error) {
+	params := url.Values{}
+	if locale != "" {
+		params.Set("locale", locale)
+	}
+
+	var rendered entities.RenderedEmail
+	path := "/admin/v1/emails/" + url.PathEscape(string(name)) + "/preview?" + params.Encode()
+	if err := c.doRequest(http.MethodGet, path, nil, true, &rendered); err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
+
+// Search runs a full-text search over examples for the signed-in user.
+func (c *Client) Search(query string, limit int) (*entities.SearchResponse, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp entities.SearchResponse
+	if err := c.doRequest(http.MethodGet, "/api/v1/search?"+params.Encode(), nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+type ExamplesPage struct {
+	Examples   []entities.Example `json:"examples"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// ListExamples returns a page of examples, newest first.
+func (c *Client) ListExamples(page, pageSize int) (*ExamplesPage, error) {
+	params := url.Values{
+		"page":      {strconv.Itoa(page)},
+		"page_size": {strconv.Itoa(pageSize)},
+	}
+
+	var resp ExamplesPage
+	if err := c.doRequest(http.MethodGet, "/api/v1/example?"+params.Encode(), nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) GetExample(id string) (*entities.Example, error) {
+	var example entities.Example
+	if err := c.doRequest(http.MethodGet, "/api/v1/example/"+url.PathEscape(id), nil, true, &example); err != nil {
+		return nil, err
+	}
+	return &example, nil
+}
+
+// CreateExample creates an example and returns its ID.
+func (c *Client) CreateExample(title, content string) (string, error) {
+	req := map[string]string{"title": title, "content": content}
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.doRequest(http.MethodPost, "/api/v1/example", req, true, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateExample replaces the example's title and content. updatedAt is the
+// updated_at the example was read with; the API answers 409 when it has
+// changed since.
+func (c *Client) UpdateExample(id, title, content string, updatedAt time.Time) (*entities.Example, error) {
+	req := map[string]any{"title": title, "content": content, "updated_at": updatedAt}
+	var example entities.Example
+	if err := c.doRequest(http.MethodPut, "/api/v1/example/"+url.PathEscape(id), req, true, &example); err != nil {
+		return nil, err
+	}
+	return &example, nil
+}
+
+// DeleteExample deletes the example, only if it is unchanged since
+// updatedAt when given.
+func (c *Client) DeleteExample(id string, updatedAt *time.Time) error {
+	endpoint := "/api/v1/example/" + url.PathEscape(id)
+	if updatedAt != nil {
+		endpoint += "?updated_at=" + url.QueryEscape(updatedAt.Format(time.RFC3339Nano))
+	}
+	return c.doRequest(http.MethodDelete, endpoint, nil, true, nil)
+}
+
+// AdminSearch runs a full-text search over the given kinds of entities, or
+// all of them when kinds is empty.
+func (c *Client) AdminSearch(query string, kinds []entities.SearchKind, limit int) (*entities.SearchResponse, error) {
+	params := url.Values{"q": {query}}
+	if len(kinds) > 0 {
+		names := make([]string, len(kinds))
+		for i, kind := range kinds {
+			names[i] = string(kind)
+		}
+		params.Set("kind", strings.Join(names, ","))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp entities.SearchResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/search?"+params.Encode(), nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetReconciliationReport returns the latest user reconciliation report.
+func (c *Client) GetReconciliationReport() (*entities.ReconciliationReport, error) {
+	var report entities.ReconciliationReport
+	if err := c.doRequest(http.MethodGet, "/admin/v1/system/reconciliation", nil, true, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RunReconciliation reconciles local users with the auth provider now.
+func (c *Client) RunReconciliation() error {
+	return c.doRequest(http.MethodPost, "/admin/v1/system/reconciliation", nil, true, nil)
+}
+
+// ListBackups returns a page of database backups, newest first.
+func (c *Client) ListBackups(page, pageSize int) (*entities.BackupListResponse, error) {
+	endpoint := fmt.Sprintf("/admin/v1/backups?page=%d&page_size=%d", page, pageSize)
+	var list entities.BackupListResponse
+	if err := c.doRequest(http.MethodGet, endpoint, nil, true, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// CreateBackup queues a database backup.
+func (c *Client) CreateBackup() (*entities.Backup, error) {
+	var backup entities.Backup
+	if err := c.doRequest(http.MethodPost, "/admin/v1/backups", nil, true, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// GetBackup returns a backup, with a fresh download link once it has
+// succeeded.
+func (c *Client) GetBackup(id string) (*entities.Backup, error) {
+	var backup entities.Backup
+	if err := c.doRequest(http.MethodGet, "/admin/v1/backups/"+url.PathEscape(id), nil, true, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// ListJobs returns a page of background jobs with the status and of the
+// kind, when not empty, newest first.
+func (c *Client) ListJobs(status, kind string, page, pageSize int) (*entities.JobListResponse, error) {
+	params := url.Values{}
+	if status != "" {
+		params.Set("status", status)
+	}
+	if kind != "" {
+		params.Set("kind", kind)
+	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("page_size", strconv.Itoa(pageSize))
+
+	var list entities.JobListResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/jobs?"+params.Encode(), nil, true, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// RequeueJob queues a dead-lettered or canceled job again.
+func (c *Client) RequeueJob(id string) error {
+	return c.doRequest(http.MethodPost, "/admin/v1/jobs/"+url.PathEscape(id)+"/requeue", nil, true, nil)
+}
+
+// CancelJob keeps a queued job from running.
+func (c *Client) CancelJob(id string) error {
+	return c.doRequest(http.MethodPost, "/admin/v1/jobs/"+url.PathEscape(id)+"/cancel", nil, true, nil)
+}
+
+// WebhookRequest registers a webhook posting the events to URL.
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// ListWebhooks returns the outgoing webhooks, newest first, without their
+// secrets.
+func (c *Client) ListWebhooks() ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	if err := c.doRequest(http.MethodGet, "/admin/v1/webhooks", nil, true, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// ListWebhookEvents returns the events webhooks can subscribe to.
+func (c *Client) ListWebhookEvents() ([]string, error) {
+	var names []string
+	if err := c.doRequest(http.MethodGet, "/admin/v1/webhooks/events", nil, true, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetWebhook returns an outgoing webhook, without its secret.
+func (c *Client) GetWebhook(id string) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+	if err := c.doRequest(http.MethodGet, "/admin/v1/webhooks/"+url.PathEscape(id), nil, true, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// CreateWebhook registers a webhook, returned with its secret, which isn't
+// shown again.
+func (c *Client) CreateWebhook(req WebhookRequest) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+	if err := c.doRequest(http.MethodPost, "/admin/v1/webhooks", req, true, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries.
+func (c *Client) DeleteWebhook(id string) error {
+	return c.doRequest(http.MethodDelete, "/admin/v1/webhooks/"+url.PathEscape(id), nil, true, nil)
+}
+
+// ListWebhookDeliveries returns a page of a webhook's delivery attempts with
+// the status, when not empty, newest first.
+func (c *Client) ListWebhookDeliveries(webhookID, status string, page, pageSize int) (*entities.WebhookDeliveryListResponse, error) {
+	params := url.Values{}
+	if status != "" {
+		params.Set("status", status)
+	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("page_size", strconv.Itoa(pageSize))
+
+	var list entities.WebhookDeliveryListResponse
+	if err := c.doRequest(http.MethodGet, "/admin/v1/webhooks/"+url.PathEscape(webhookID)+"/deliveries?"+params.Encode(), nil, true, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// RedeliverWebhookDelivery posts a delivery's event to its webhook again and
+// returns the new attempt, which may have failed too.
+func (c *Client) RedeliverWebhookDelivery(webhookID, deliveryID string) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	endpoint := "/admin/v1/webhooks/" + url.PathEscape(webhookID) + "/deliveries/" + url.PathEscape(deliveryID) + "/redeliver"
+	if err := c.doRequest(http.MethodPost, endpoint, nil, true, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
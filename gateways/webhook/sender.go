@@ -0,0 +1,92 @@
+// Package webhook posts outgoing webhook deliveries over HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxResponseBody is how much of the endpoint's answer is kept with the
+// delivery.
+const maxResponseBody = 1024
+
+// Sender posts deliveries and returns the endpoint's status and the start of
+// its answer. Redirects aren't followed: they are answers like any other, so
+// a moved endpoint shows up as a failed delivery.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return entities.WebhookResponse{}, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The URL is on the delivery already, so the error leaves it out
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return entities.WebhookResponse{}, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	snippet, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return entities.WebhookResponse{StatusCode: resp.StatusCode}, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+	return entities.WebhookResponse{StatusCode: resp.StatusCode, Body: responseSnippet(snippet)}, nil
+}
+
+// responseSnippet turns the start of an answer into text Postgres stores:
+// valid UTF-8 without NUL bytes, at most maxResponseBody bytes long. A
+// character cut by the limit is dropped rather than shown as invalid.
+func responseSnippet(b []byte) string {
+	if len(b) == maxResponseBody {
+		for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+			if utf8.RuneStart(b[len(b)-i]) {
+				if !utf8.FullRune(b[len(b)-i:]) {
+					b = b[:len(b)-i]
+				}
+				break
+			}
+		}
+	}
+
+	s := strings.ReplaceAll(strings.ToValidUTF8(string(b), "\uFFFD"), "\x00", "")
+	if len(s) > maxResponseBody {
+		// Replacement characters are longer than the bytes they replace
+		cut := maxResponseBody
+		for !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		s = s[:cut]
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	t.Run("posts the body with the headers", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "user.created", r.Header.Get("X-Webhook-Event"))
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"event":"user.created"}`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(strings.Repeat("a", 2*maxResponseBody)))
+		}))
+		t.Cleanup(srv.Close)
+
+		resp, err := NewSender().Send(context.Background(), srv.URL, map[string]string{"X-Webhook-Event": "user.created"}, []byte(`{"event":"user.created"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Len(t, resp.Body, maxResponseBody, "only the start of the answer is kept")
+	})
+
+	t.Run("binary answer", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\xff\xfe"))
+			_, _ = w.Write([]byte(strings.Repeat("\xff\x00", maxResponseBody)))
+		}))
+		t.Cleanup(srv.Close)
+
+		resp, err := NewSender().Send(context.Background(), srv.URL, nil, []byte(`{}`))
+		require.NoError(t, err)
+		assert.True(t, utf8.ValidString(resp.Body), "the snippet is valid UTF-8")
+		assert.NotContains(t, resp.Body, "\x00")
+		assert.LessOrEqual(t, len(resp.Body), maxResponseBody)
+		assert.True(t, strings.HasPrefix(resp.Body, "\uFFFDPNG\r\n\x1a\n\rIHDR\uFFFD"), "unexpected snippet %q", resp.Body)
+	})
+
+	t.Run("keeps whole characters", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("a" + strings.Repeat("é", maxResponseBody)))
+		}))
+		t.Cleanup(srv.Close)
+
+		resp, err := NewSender().Send(context.Background(), srv.URL, nil, []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "a"+strings.Repeat("é", (maxResponseBody-1)/2), resp.Body)
+	})
+
+	t.Run("doesn't follow redirects", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusMovedPermanently)
+		}))
+		t.Cleanup(srv.Close)
+
+		resp, err := NewSender().Send(context.Background(), srv.URL, nil, []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	})
+
+	t.Run("unreachable endpoint", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		_, err := NewSender().Send(context.Background(), srv.URL, nil, []byte(`{}`))
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), srv.URL)
+	})
+}
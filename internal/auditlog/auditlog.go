@@ -0,0 +1,198 @@
+// Package auditlog turns log records marked with an "audit" attribute into
+// audit events. Recorder.Handler wraps the application's slog handler and
+// queues the events for the audit use case to store, so code keeps auditing
+// with a plain log call:
+//
+//	logger.InfoContext(ctx, "user deleted", "audit", true, "user_id", id)
+//
+// The actor is the user in the context (see domain.WithActor), or the
+// "actor_id" or "admin_id" attribute when there is one, and otherwise the
+// "user_id" attribute, for users acting on their own account. The resource is
+// given with "resource" and "resource_id", and defaults to the user of
+// "user_id".
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Key is the attribute marking a record as an audit event.
+const Key = "audit"
+
+const DefaultQueueSize = 1000
+
+// Recorder queues audit events for storage, safe for concurrent use.
+type Recorder struct {
+	events chan entities.AuditEvent
+}
+
+// New creates a Recorder holding up to size events until they are stored.
+func New(size int) *Recorder {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	return &Recorder{events: make(chan entities.AuditEvent, size)}
+}
+
+// Handler returns a slog.Handler that queues audit records and then passes
+// every record on to next. When the queue is full the event is dropped with
+// a warning, rather than holding up the code that logged it. Audit records
+// below the level next handles are not seen.
+func (rec *Recorder) Handler(next slog.Handler) slog.Handler {
+	return &handler{rec: rec, next: next}
+}
+
+// Events is drained by the audit use case.
+func (rec *Recorder) Events() <-chan entities.AuditEvent {
+	return rec.events
+}
+
+type handler struct {
+	rec   *Recorder
+	next  slog.Handler
+	group string
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if event, ok := h.event(ctx, r); ok {
+		select {
+		case h.rec.events <- event:
+		default:
+			dropped := slog.NewRecord(time.Now(), slog.LevelWarn, "audit event dropped", 0)
+			dropped.AddAttrs(slog.String("action", event.Action))
+			_ = h.next.Handle(ctx, dropped)
+		}
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{rec: h.rec, next: h.next.WithAttrs(attrs), group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{rec: h.rec, next: h.next.WithGroup(name), group: h.group + name + "."}
+}
+
+// event builds the audit event of r from the record's own attributes; those
+// the logger was created with describe the process, not the event.
+func (h *handler) event(ctx context.Context, r slog.Record) (entities.AuditEvent, bool) {
+	attrs := make(map[string]any, r.NumAttrs())
+	audit := false
+	r.Attrs(func(a slog.Attr) bool {
+		a.Value = a.Value.Resolve()
+		if a.Key == Key && h.group == "" {
+			audit = a.Value.Kind() == slog.KindBool && a.Value.Bool()
+			return true
+		}
+		addAttr(attrs, h.group, a)
+		return true
+	})
+	if !audit {
+		return entities.AuditEvent{}, false
+	}
+
+	event := entities.AuditEvent{
+		Time:      r.Time,
+		Action:    r.Message,
+		RequestID: middleware.GetReqID(ctx),
+	}
+
+	userID := take(attrs, "user_id")
+	switch {
+	case attrs["actor_id"] != nil:
+		event.ActorID = parseID(take(attrs, "actor_id"))
+	case attrs["admin_id"] != nil:
+		event.ActorID = parseID(take(attrs, "admin_id"))
+	default:
+		if actor, ok := domain.ActorFromContext(ctx); ok {
+			event.ActorID = &actor
+		} else {
+			event.ActorID = parseID(userID)
+		}
+	}
+
+	event.Resource = take(attrs, "resource")
+	event.ResourceID = take(attrs, "resource_id")
+	if event.Resource == "" && userID != "" {
+		event.Resource = entities.AuditResourceUser
+		event.ResourceID = userID
+	}
+	if id := take(attrs, "request_id"); id != "" {
+		event.RequestID = id
+	}
+
+	if len(attrs) > 0 {
+		event.Details = attrs
+	}
+	return event, true
+}
+
+// take removes key from attrs and returns its value as a string.
+func take(attrs map[string]any, key string) string {
+	v, ok := attrs[key]
+	if !ok {
+		return ""
+	}
+	delete(attrs, key)
+	return fmt.Sprint(v)
+}
+
+func parseID(v any) *uuid.UUID {
+	id, err := uuid.FromString(fmt.Sprint(v))
+	if err != nil || id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// addAttr flattens groups into dotted keys and turns values into something
+// that encodes cleanly as JSON.
+func addAttr(attrs map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addAttr(attrs, groupPrefix, ga)
+		}
+		return
+	}
+
+	switch v := a.Value.Any().(type) {
+	case error:
+		attrs[prefix+a.Key] = v.Error()
+	case time.Time:
+		attrs[prefix+a.Key] = v
+	case fmt.Stringer:
+		attrs[prefix+a.Key] = v.String()
+	default:
+		if a.Value.Kind() == slog.KindDuration {
+			attrs[prefix+a.Key] = a.Value.Duration().String()
+			return
+		}
+		attrs[prefix+a.Key] = v
+	}
+}
@@ -0,0 +1,125 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"go-template/domain"
+	"go-template/domain/entities"
+	"log/slog"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(size int) (*slog.Logger, *Recorder, *bytes.Buffer) {
+	var out bytes.Buffer
+	rec := New(size)
+	next := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelInfo})
+	return slog.New(rec.Handler(next)).With(slog.String("app", "service")), rec, &out
+}
+
+func TestRecorder_Handler(t *testing.T) {
+	adminID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+	keyID := uuid.Must(uuid.NewV4())
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	actorCtx := domain.WithActor(ctx, adminID)
+
+	tests := []struct {
+		name string
+		log  func(log *slog.Logger)
+		want entities.AuditEvent
+	}{
+		{
+			name: "actor from the context acting on a user",
+			log: func(log *slog.Logger) {
+				log.InfoContext(actorCtx, "user deleted", "audit", true, "user_id", userID, "email", "a@b.com")
+			},
+			want: entities.AuditEvent{
+				ActorID:    &adminID,
+				Action:     "user deleted",
+				Resource:   entities.AuditResourceUser,
+				ResourceID: userID.String(),
+				RequestID:  "req-1",
+				Details:    map[string]any{"email": "a@b.com"},
+			},
+		},
+		{
+			name: "admin attribute",
+			log: func(log *slog.Logger) {
+				log.Info("api key revoked by admin", "audit", true, "admin_id", adminID, "user_id", userID, "key_id", keyID)
+			},
+			want: entities.AuditEvent{
+				ActorID:    &adminID,
+				Action:     "api key revoked by admin",
+				Resource:   entities.AuditResourceUser,
+				ResourceID: userID.String(),
+				Details:    map[string]any{"key_id": keyID.String()},
+			},
+		},
+		{
+			name: "user acting on their own account",
+			log: func(log *slog.Logger) {
+				log.Info("password reset", "audit", true, "user_id", userID)
+			},
+			want: entities.AuditEvent{
+				ActorID:    &userID,
+				Action:     "password reset",
+				Resource:   entities.AuditResourceUser,
+				ResourceID: userID.String(),
+			},
+		},
+		{
+			name: "explicit resource",
+			log: func(log *slog.Logger) {
+				log.InfoContext(actorCtx, "role deleted", "audit", true, "resource", "role", "resource_id", "support")
+			},
+			want: entities.AuditEvent{
+				ActorID:    &adminID,
+				Action:     "role deleted",
+				Resource:   "role",
+				ResourceID: "support",
+				RequestID:  "req-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, rec, out := newTestLogger(10)
+			tt.log(log)
+
+			assert.Contains(t, out.String(), tt.want.Action, "records still reach the wrapped handler")
+			require.Len(t, rec.Events(), 1)
+			event := <-rec.Events()
+			assert.False(t, event.Time.IsZero())
+			event.Time = tt.want.Time
+			assert.Equal(t, tt.want, event)
+		})
+	}
+}
+
+func TestRecorder_HandlerSkipsOtherRecords(t *testing.T) {
+	log, rec, _ := newTestLogger(10)
+
+	log.Info("user signed in", "user_id", uuid.Must(uuid.NewV4()))
+	log.Info("not audited", "audit", false)
+	log.Debug("below the level", "audit", true)
+
+	assert.Empty(t, rec.Events())
+}
+
+func TestRecorder_HandlerDropsWhenFull(t *testing.T) {
+	log, rec, out := newTestLogger(1)
+
+	log.Info("first", "audit", true)
+	log.Info("second", "audit", true)
+
+	require.Len(t, rec.Events(), 1)
+	assert.Equal(t, "first", (<-rec.Events()).Action)
+	assert.Contains(t, out.String(), "audit event dropped")
+}
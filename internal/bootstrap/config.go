@@ -0,0 +1,356 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ardanlabs/conf/v3"
+	_ "github.com/joho/godotenv/autoload"
+)
+
+// Config is the configuration of the API service and the worker, read from
+// the environment.
+type Config struct {
+	Environment    string `conf:"env:ENVIRONMENT,default:development"`
+	DatabaseEngine string `conf:"env:DATABASE_ENGINE,default:postgres"`
+	ApiAddress     string `conf:"env:API_ADDRESS,default:0.0.0.0:3000"`
+	AuthSecretKey  string `conf:"env:AUTH_SECRET_KEY,default:dev-secret-change-me"`
+	AuthTokenTTL   string `conf:"env:AUTH_TOKEN_TTL,default:15m"`
+	AuthProvider   string `conf:"env:AUTH_PROVIDER,default:supabase"`
+	SupabaseURL    string `conf:"env:SUPABASE_URL"`
+	SupabaseAPIKey string `conf:"env:SUPABASE_API_KEY"`
+
+	// Access tokens name AUTH_TOKEN_ISSUER as their issuer, and only tokens
+	// from that issuer, for one of AUTH_TOKEN_AUDIENCES, are accepted. Clocks
+	// may be off by AUTH_TOKEN_LEEWAY.
+	AuthTokenIssuer    string        `conf:"env:AUTH_TOKEN_ISSUER,default:go-template"`
+	AuthTokenAudiences []string      `conf:"env:AUTH_TOKEN_AUDIENCES,default:api;web;admin;third-party"`
+	AuthTokenLeeway    time.Duration `conf:"env:AUTH_TOKEN_LEEWAY,default:30s"`
+
+	// Access tokens are signed with the first of AUTH_SIGNING_KEY_FILES (PEM
+	// encoded RSA or Ed25519 keys) and verified against all of them, so a key
+	// can be rotated out. Without keys they are signed with AUTH_SECRET_KEY.
+	AuthSigningKeyFiles []string `conf:"env:AUTH_SIGNING_KEY_FILES"`
+
+	// AWS Cognito provider. Admin calls use the default AWS credential chain.
+	CognitoRegion       string `conf:"env:COGNITO_REGION"`
+	CognitoUserPoolID   string `conf:"env:COGNITO_USER_POOL_ID"`
+	CognitoClientID     string `conf:"env:COGNITO_CLIENT_ID"`
+	CognitoClientSecret string `conf:"env:COGNITO_CLIENT_SECRET"`
+
+	// Auth0 provider
+	Auth0Domain       string `conf:"env:AUTH0_DOMAIN"`
+	Auth0ClientID     string `conf:"env:AUTH0_CLIENT_ID"`
+	Auth0ClientSecret string `conf:"env:AUTH0_CLIENT_SECRET"`
+	Auth0Connection   string `conf:"env:AUTH0_CONNECTION,default:Username-Password-Authentication"`
+	Auth0Audience     string `conf:"env:AUTH0_AUDIENCE"`
+
+	// Auth provider calls are cut off after AUTH_PROVIDER_TIMEOUT. After
+	// AUTH_PROVIDER_BREAKER_THRESHOLD timeouts or server errors in a row, the
+	// provider isn't called for AUTH_PROVIDER_BREAKER_COOLDOWN and logins
+	// get a 503.
+	AuthProviderTimeout          time.Duration `conf:"env:AUTH_PROVIDER_TIMEOUT,default:5s"`
+	AuthProviderBreakerThreshold int           `conf:"env:AUTH_PROVIDER_BREAKER_THRESHOLD,default:5"`
+	AuthProviderBreakerCooldown  time.Duration `conf:"env:AUTH_PROVIDER_BREAKER_COOLDOWN,default:30s"`
+
+	// Social login. Each provider is enabled when its client ID is set.
+	GoogleClientID     string `conf:"env:GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string `conf:"env:GOOGLE_CLIENT_SECRET"`
+	GitHubClientID     string `conf:"env:GITHUB_CLIENT_ID"`
+	GitHubClientSecret string `conf:"env:GITHUB_CLIENT_SECRET"`
+
+	// SMS one-time code login. SMS_PROVIDER is twilio, or log to write codes
+	// to the service log during development. Empty disables SMS login.
+	SMSProvider       string        `conf:"env:SMS_PROVIDER"`
+	TwilioAccountSID  string        `conf:"env:TWILIO_ACCOUNT_SID"`
+	TwilioAuthToken   string        `conf:"env:TWILIO_AUTH_TOKEN"`
+	TwilioFromNumber  string        `conf:"env:TWILIO_FROM_NUMBER"`
+	OTPTTL            time.Duration `conf:"env:OTP_TTL,default:5m"`
+	OTPMaxAttempts    int           `conf:"env:OTP_MAX_ATTEMPTS,default:5"`
+	OTPResendInterval time.Duration `conf:"env:OTP_RESEND_INTERVAL,default:1m"`
+
+	// Uploaded files, such as avatars. STORAGE_PROVIDER is local to keep
+	// them under STORAGE_LOCAL_DIR, served by this service at
+	// STORAGE_PUBLIC_URL, or s3 to keep them in an S3 compatible bucket
+	// whose public files are served at STORAGE_PUBLIC_URL. Empty disables
+	// uploads. STORAGE_SIGNING_KEY signs the links to private local files,
+	// such as exports; S3 presigns them with its credentials.
+	StorageProvider   string `conf:"env:STORAGE_PROVIDER"`
+	StorageLocalDir   string `conf:"env:STORAGE_LOCAL_DIR,default:./data/uploads"`
+	StoragePublicURL  string `conf:"env:STORAGE_PUBLIC_URL,default:http://localhost:3000/files"`
+	StorageSigningKey string `conf:"env:STORAGE_SIGNING_KEY"`
+
+	// S3 storage. STORAGE_S3_ENDPOINT and STORAGE_S3_USE_PATH_STYLE point it
+	// at S3 compatible stores such as MinIO. Without an access key the
+	// default AWS credential chain is used.
+	StorageS3Bucket          string `conf:"env:STORAGE_S3_BUCKET"`
+	StorageS3Region          string `conf:"env:STORAGE_S3_REGION,default:us-east-1"`
+	StorageS3Endpoint        string `conf:"env:STORAGE_S3_ENDPOINT"`
+	StorageS3AccessKeyID     string `conf:"env:STORAGE_S3_ACCESS_KEY_ID"`
+	StorageS3SecretAccessKey string `conf:"env:STORAGE_S3_SECRET_ACCESS_KEY"`
+	StorageS3UsePathStyle    bool   `conf:"env:STORAGE_S3_USE_PATH_STYLE"`
+
+	// Exports produced in the background, which need file storage and a
+	// signing key. Jobs are picked up as they are created, or every
+	// interval, and removed with their files once retention has passed.
+	// Download links work for EXPORT_URL_TTL.
+	ExportJobInterval time.Duration `conf:"env:EXPORT_JOB_INTERVAL,default:10s"`
+	ExportRetention   time.Duration `conf:"env:EXPORT_RETENTION,default:24h"`
+	ExportURLTTL      time.Duration `conf:"env:EXPORT_URL_TTL,default:15m"`
+
+	// Database backups made with pg_dump, which need file storage and a
+	// signing key. Whether one is made daily, and how long they are kept,
+	// are the auto_backup and backup_retention_days settings, checked every
+	// interval. Download links work for BACKUP_URL_TTL.
+	BackupPgDump   string        `conf:"env:BACKUP_PG_DUMP,default:pg_dump"`
+	BackupInterval time.Duration `conf:"env:BACKUP_INTERVAL,default:1h"`
+	BackupURLTTL   time.Duration `conf:"env:BACKUP_URL_TTL,default:15m"`
+
+	// Files attached to examples, which need file storage and a signing
+	// key. Download links work for ATTACHMENT_URL_TTL; files whose example
+	// or attachment was deleted are removed every cleanup interval.
+	AttachmentMaxSize         int64         `conf:"env:ATTACHMENT_MAX_SIZE,default:10485760"`
+	AttachmentURLTTL          time.Duration `conf:"env:ATTACHMENT_URL_TTL,default:5m"`
+	AttachmentCleanupInterval time.Duration `conf:"env:ATTACHMENT_CLEANUP_INTERVAL,default:1h"`
+
+	// Files clients PUT straight to file storage through presigned links,
+	// which need file storage and a signing key. Links work for
+	// UPLOAD_URL_TTL; uploads never confirmed, and uploads of deleted users,
+	// are removed with their files every cleanup interval.
+	UploadMaxSize         int64         `conf:"env:UPLOAD_MAX_SIZE,default:104857600"`
+	UploadURLTTL          time.Duration `conf:"env:UPLOAD_URL_TTL,default:15m"`
+	UploadCleanupInterval time.Duration `conf:"env:UPLOAD_CLEANUP_INTERVAL,default:1h"`
+
+	// TOTP two-factor authentication. TOTPIssuer names the service in
+	// authenticator apps.
+	TOTPIssuer string `conf:"env:TOTP_ISSUER,default:Go Template"`
+
+	// Email gateway. EMAIL_PROVIDER is smtp, ses or sendgrid, or log to
+	// write emails to the service log during development. Empty sends no
+	// email. Emails come from EmailFrom, and temporary failures are tried
+	// EmailRetryAttempts times in all, backing off from EmailRetryBackoff.
+	// Emails are rendered from the built-in templates; files in
+	// EmailTemplatesDir replace them, and EmailAppName signs every email.
+	EmailProvider      string        `conf:"env:EMAIL_PROVIDER"`
+	EmailFrom          string        `conf:"env:EMAIL_FROM,default:Go Template <no-reply@localhost>"`
+	EmailRetryAttempts int           `conf:"env:EMAIL_RETRY_ATTEMPTS,default:3"`
+	EmailRetryBackoff  time.Duration `conf:"env:EMAIL_RETRY_BACKOFF,default:1s"`
+	EmailTemplatesDir  string        `conf:"env:EMAIL_TEMPLATES_DIR"`
+	EmailAppName       string        `conf:"env:EMAIL_APP_NAME,default:Go Template"`
+	SMTPHost           string        `conf:"env:SMTP_HOST"`
+	SMTPPort           int           `conf:"env:SMTP_PORT,default:587"`
+	SMTPUsername       string        `conf:"env:SMTP_USERNAME"`
+	SMTPPassword       string        `conf:"env:SMTP_PASSWORD"`
+	SESRegion          string        `conf:"env:SES_REGION,default:us-east-1"`
+	SESAccessKeyID     string        `conf:"env:SES_ACCESS_KEY_ID"`
+	SESSecretAccessKey string        `conf:"env:SES_SECRET_ACCESS_KEY"`
+	SendGridAPIKey     string        `conf:"env:SENDGRID_API_KEY"`
+
+	// Password reset, sent with the EMAIL_PROVIDER gateway. Without one,
+	// password reset is left to the auth provider, if it sends its own
+	// recovery emails. PasswordResetURL is the web page the emailed link
+	// opens.
+	PasswordResetURL            string        `conf:"env:PASSWORD_RESET_URL,default:http://localhost:8080/reset-password"`
+	PasswordResetTTL            time.Duration `conf:"env:PASSWORD_RESET_TTL,default:1h"`
+	PasswordResetResendInterval time.Duration `conf:"env:PASSWORD_RESET_RESEND_INTERVAL,default:1m"`
+
+	// Email verification, sent with the EMAIL_PROVIDER gateway. Whether
+	// logins require a verified email is an admin setting. EmailVerifyURL is
+	// the web page the emailed link opens.
+	EmailVerifyURL            string        `conf:"env:EMAIL_VERIFY_URL,default:http://localhost:8080/verify-email"`
+	EmailVerifyTTL            time.Duration `conf:"env:EMAIL_VERIFY_TTL,default:24h"`
+	EmailVerifyResendInterval time.Duration `conf:"env:EMAIL_VERIFY_RESEND_INTERVAL,default:1m"`
+
+	// Email change, confirmed from a link sent to the new address with the
+	// EMAIL_PROVIDER gateway. EmailChangeURL is the web page the link opens.
+	EmailChangeURL string        `conf:"env:EMAIL_CHANGE_URL,default:http://localhost:8080/confirm-email"`
+	EmailChangeTTL time.Duration `conf:"env:EMAIL_CHANGE_TTL,default:1h"`
+
+	// Invitations admins email with the EMAIL_PROVIDER gateway.
+	// InvitationAcceptURL is the web page the link opens, where the invitee
+	// chooses their password.
+	InvitationAcceptURL string `conf:"env:INVITATION_ACCEPT_URL,default:http://localhost:8080/accept-invitation"`
+
+	// Breached password check, used while the "Reject Breached Passwords"
+	// admin setting is on. Only the first five characters of the password's
+	// SHA-1 hash are sent. Empty disables the check.
+	PasswordBreachCheckURL string `conf:"env:PASSWORD_BREACH_CHECK_URL,default:https://api.pwnedpasswords.com"`
+
+	// CAPTCHA on registration and login. CAPTCHA_PROVIDER is hcaptcha or
+	// turnstile; empty disables it. Whether it is asked for is an admin
+	// setting. The site key is handed to clients to render the widget.
+	CaptchaProvider string `conf:"env:CAPTCHA_PROVIDER"`
+	CaptchaSiteKey  string `conf:"env:CAPTCHA_SITE_KEY"`
+	CaptchaSecret   string `conf:"env:CAPTCHA_SECRET"`
+
+	// Lifetime of refresh tokens issued with every access token, used when
+	// the Session Timeout admin setting can't be read
+	AuthRefreshTokenTTL time.Duration `conf:"env:AUTH_REFRESH_TOKEN_TTL,default:720h"`
+
+	// How often expired entries are dropped from the revoked token denylist
+	RevokedTokenPurgeInterval time.Duration `conf:"env:REVOKED_TOKEN_PURGE_INTERVAL,default:1h"`
+
+	// A session counts as active while its access token is unexpired and was
+	// used within SESSION_ACTIVE_WINDOW. Sessions of expired tokens are
+	// dropped every SESSION_PURGE_INTERVAL.
+	SessionActiveWindow  time.Duration `conf:"env:SESSION_ACTIVE_WINDOW,default:15m"`
+	SessionPurgeInterval time.Duration `conf:"env:SESSION_PURGE_INTERVAL,default:1h"`
+
+	// Lifetime of the Web app tokens admins get to impersonate a user
+	ImpersonationTTL time.Duration `conf:"env:IMPERSONATION_TTL,default:15m"`
+
+	// How long the admin dashboard's user statistics are reused before
+	// being counted again. 0 counts them on every request.
+	UserStatsCacheTTL time.Duration `conf:"env:USER_STATS_CACHE_TTL,default:30s"`
+
+	// Path prefixes each token audience may call, "|" separated
+	AuthAudienceRoutes map[string]string `conf:"env:AUTH_AUDIENCE_ROUTES,default:api:/api/|/oauth2/;web:/api/|/oauth2/;admin:/admin/;third-party:/api/v1/example/"`
+
+	// Database failover
+	DBHealthCheckInterval time.Duration `conf:"env:DB_HEALTH_CHECK_INTERVAL,default:5s"`
+	DBReadRetries         int           `conf:"env:DB_READ_RETRIES,default:2"`
+	DBRetryBackoff        time.Duration `conf:"env:DB_RETRY_BACKOFF,default:200ms"`
+
+	// Queries taking longer are logged, without their bound values; 0 turns
+	// the slow query log off. Query metrics are always recorded.
+	DBSlowQueryThreshold time.Duration `conf:"env:DB_SLOW_QUERY_THRESHOLD,default:500ms"`
+
+	// Bounds each dependency check of the readiness probe, /ready
+	ReadyCheckTimeout time.Duration `conf:"env:READY_CHECK_TIMEOUT,default:2s"`
+
+	// pprof and expvar: DebugEndpoints serves them to super admins at
+	// /admin/v1/debug on the API, and DebugAddr without auth on a separate
+	// port, such as 127.0.0.1:6060, for the API and the worker
+	DebugEndpoints bool   `conf:"env:DEBUG_ENDPOINTS,default:false"`
+	DebugAddr      string `conf:"env:DEBUG_ADDR"`
+
+	// Proxies, as IP addresses or CIDR networks, whose X-Forwarded-For,
+	// X-Real-IP and True-Client-IP headers give the client's address
+	TrustedProxies []string `conf:"env:TRUSTED_PROXIES"`
+
+	// Bot detection on public forms
+	BotFormSecret    string        `conf:"env:BOT_FORM_SECRET"`
+	BotMinSubmitTime time.Duration `conf:"env:BOT_MIN_SUBMIT_TIME,default:3s"`
+	BotMaxSubmitTime time.Duration `conf:"env:BOT_MAX_SUBMIT_TIME,default:1h"`
+	BotDNSBLZones    []string      `conf:"env:BOT_DNSBL_ZONES"`
+
+	// Login rate limiting. Attempts are counted in Redis when REDIS_URL is
+	// set, so every instance shares the limits, and in memory otherwise. A
+	// zero limit disables it.
+	RedisURL                 string        `conf:"env:REDIS_URL"`
+	LoginRateLimitWindow     time.Duration `conf:"env:LOGIN_RATE_LIMIT_WINDOW,default:15m"`
+	LoginRateLimitPerIP      int           `conf:"env:LOGIN_RATE_LIMIT_PER_IP,default:50"`
+	LoginRateLimitPerAccount int           `conf:"env:LOGIN_RATE_LIMIT_PER_ACCOUNT,default:10"`
+
+	// Break-glass emergency access
+	BreakGlassCredentialFile string        `conf:"env:BREAK_GLASS_CREDENTIAL_FILE"`
+	BreakGlassSessionTTL     time.Duration `conf:"env:BREAK_GLASS_SESSION_TTL,default:15m"`
+
+	// Operator alerts
+	AlertWebhookURL string `conf:"env:ALERT_WEBHOOK_URL"`
+
+	// Errors reported to Sentry, or a service taking Sentry DSNs; off
+	// without a DSN
+	SentryDSN string `conf:"env:SENTRY_DSN,mask"`
+
+	// Recent log entries kept in memory for the admin log viewer
+	LogBufferSize int `conf:"env:LOG_BUFFER_SIZE,default:1000"`
+
+	// Audit events waiting to be stored; events logged while the queue is
+	// full are dropped with a warning
+	AuditQueueSize int `conf:"env:AUDIT_QUEUE_SIZE,default:1000"`
+
+	// Domain events. With the outbox they are recorded in the transaction of
+	// their change and relayed to the subscribers in the background, as
+	// their change is committed or every interval, so they survive a crash;
+	// relayed events are removed after the retention. Without it they are
+	// handed to the subscribers right away.
+	EventOutbox          bool          `conf:"env:EVENT_OUTBOX,default:true"`
+	EventRelayInterval   time.Duration `conf:"env:EVENT_RELAY_INTERVAL,default:5s"`
+	EventOutboxRetention time.Duration `conf:"env:EVENT_OUTBOX_RETENTION,default:168h"`
+
+	// Background jobs, such as sending emails. Each worker runs a job at a
+	// time, picking up the jobs queued on its instance at once and the
+	// others every poll interval; jobs that succeeded are removed after the
+	// retention. The API runs the workers, and the other background tasks,
+	// unless WORKER_EMBEDDED is false, for when cmd/worker runs them.
+	WorkerEmbedded  bool          `conf:"env:WORKER_EMBEDDED,default:true"`
+	JobWorkers      int           `conf:"env:JOB_WORKERS,default:4"`
+	JobPollInterval time.Duration `conf:"env:JOB_POLL_INTERVAL,default:5s"`
+	JobRetention    time.Duration `conf:"env:JOB_RETENTION,default:168h"`
+
+	// Retry policy of background jobs: a job is run up to the max attempts,
+	// waiting the backoff after its first failed attempt, doubling with
+	// each attempt up to the max backoff, then dead-lettered. Kinds are
+	// given their own max attempts and backoff, as in
+	// "email.send:10;backup.run:1".
+	JobMaxAttempts     int                      `conf:"env:JOB_MAX_ATTEMPTS,default:5"`
+	JobBackoff         time.Duration            `conf:"env:JOB_BACKOFF,default:10s"`
+	JobMaxBackoff      time.Duration            `conf:"env:JOB_MAX_BACKOFF,default:1h"`
+	JobKindMaxAttempts map[string]int           `conf:"env:JOB_KIND_MAX_ATTEMPTS"`
+	JobKindBackoff     map[string]time.Duration `conf:"env:JOB_KIND_BACKOFF"`
+
+	// Kafka brokers the outbox also relays domain events to, separated by
+	// ";"; empty to keep events in the process. Events are written to the
+	// topic mapped to their name, as in "user.created:users;user.deleted:users",
+	// or to the default topic, and not written when it is empty.
+	KafkaBrokers      []string          `conf:"env:KAFKA_BROKERS"`
+	KafkaTopics       map[string]string `conf:"env:KAFKA_TOPICS"`
+	KafkaTopic        string            `conf:"env:KAFKA_TOPIC,default:go-template.events"`
+	KafkaWriteTimeout time.Duration     `conf:"env:KAFKA_WRITE_TIMEOUT,default:10s"`
+
+	// NATS server the outbox also relays domain events to, into a JetStream
+	// stream, as a lighter option than Kafka; empty to keep events in the
+	// process. Events are published to <prefix>.<event name> and kept for
+	// the max age; the stream drops copies relayed again within the
+	// duplicates window.
+	NatsURL           string        `conf:"env:NATS_URL"`
+	NatsStream        string        `conf:"env:NATS_STREAM,default:EVENTS"`
+	NatsSubjectPrefix string        `conf:"env:NATS_SUBJECT_PREFIX,default:events"`
+	NatsMaxAge        time.Duration `conf:"env:NATS_MAX_AGE,default:168h"`
+	NatsDuplicates    time.Duration `conf:"env:NATS_DUPLICATES,default:10m"`
+	NatsWriteTimeout  time.Duration `conf:"env:NATS_WRITE_TIMEOUT,default:10s"`
+
+	// Reconciliation between the users table and the auth provider. Set the
+	// interval to 0 to only reconcile on demand from the admin API.
+	ReconcileInterval     time.Duration `conf:"env:RECONCILE_INTERVAL,default:1h"`
+	ReconcileRepair       bool          `conf:"env:RECONCILE_REPAIR,default:false"`
+	SupabaseWebhookSecret string        `conf:"env:SUPABASE_WEBHOOK_SECRET"`
+
+	// Deleted user anonymization
+	AnonymizationInterval time.Duration `conf:"env:ANONYMIZATION_INTERVAL,default:1m"`
+
+	// Accounts their users asked to delete are deleted once the grace period
+	// has passed, checked every interval
+	AccountDeletionGracePeriod time.Duration `conf:"env:ACCOUNT_DELETION_GRACE_PERIOD,default:720h"`
+	AccountDeletionInterval    time.Duration `conf:"env:ACCOUNT_DELETION_INTERVAL,default:1h"`
+
+	// Archived examples are deleted once they have been archived for this
+	// many days, checked every interval. Set the days to 0 to keep them.
+	ExampleArchiveRetentionDays int           `conf:"env:EXAMPLE_ARCHIVE_RETENTION_DAYS,default:30"`
+	ExampleArchivePurgeInterval time.Duration `conf:"env:EXAMPLE_ARCHIVE_PURGE_INTERVAL,default:1h"`
+
+	// OpenID Connect provider
+	OIDCIssuer               string        `conf:"env:OIDC_ISSUER,default:http://localhost:3000"`
+	OIDCAuthorizeURL         string        `conf:"env:OIDC_AUTHORIZE_URL,default:http://localhost:8080/oauth2/authorize"`
+	OIDCSigningKeyFile       string        `conf:"env:OIDC_SIGNING_KEY_FILE"`
+	OIDCAccessTokenTTL       time.Duration `conf:"env:OIDC_ACCESS_TOKEN_TTL,default:1h"`
+	OIDCAuthorizationCodeTTL time.Duration `conf:"env:OIDC_AUTHORIZATION_CODE_TTL,default:5m"`
+
+	// Casbin policy consulted by the Authorize middleware instead of plain
+	// permission checks. The model defaults to the built-in one.
+	AuthzCasbinModelFile  string `conf:"env:AUTHZ_CASBIN_MODEL_FILE"`
+	AuthzCasbinPolicyFile string `conf:"env:AUTHZ_CASBIN_POLICY_FILE"`
+}
+
+func (c *Config) Load(prefix string) error {
+	if help, err := conf.Parse(prefix, c); err != nil {
+		if errors.Is(err, conf.ErrHelpWanted) {
+			fmt.Println(help)
+			return err
+		}
+		return err
+	}
+	return nil
+}
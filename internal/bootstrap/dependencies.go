@@ -0,0 +1,982 @@
+// Package bootstrap reads the configuration and wires the dependencies
+// shared by the API service and the worker.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	appMiddleware "go-template/app/api/middleware"
+	"go-template/domain/alerting"
+	"go-template/domain/announcement"
+	"go-template/domain/anonymization"
+	"go-template/domain/apikey"
+	"go-template/domain/attachment"
+	"go-template/domain/audit"
+	"go-template/domain/auth"
+	"go-template/domain/authz"
+	"go-template/domain/backup"
+	"go-template/domain/breakglass"
+	"go-template/domain/comment"
+	"go-template/domain/deletion"
+	"go-template/domain/events"
+	"go-template/domain/example"
+	"go-template/domain/exportjob"
+	"go-template/domain/invitation"
+	"go-template/domain/job"
+	"go-template/domain/loginhistory"
+	"go-template/domain/notification"
+	"go-template/domain/oidc"
+	"go-template/domain/passwordpolicy"
+	"go-template/domain/preferences"
+	"go-template/domain/reconciliation"
+	"go-template/domain/revocation"
+	"go-template/domain/search"
+	"go-template/domain/session"
+	"go-template/domain/settings"
+	"go-template/domain/upload"
+	"go-template/domain/user"
+	"go-template/domain/webhook"
+	"go-template/gateways/alert"
+	"go-template/gateways/auth/dev"
+	"go-template/gateways/auth/github"
+	"go-template/gateways/auth/google"
+	"go-template/gateways/broker/kafka"
+	"go-template/gateways/broker/nats"
+	"go-template/gateways/captcha"
+	"go-template/gateways/email"
+	"go-template/gateways/pgdump"
+	"go-template/gateways/policy/casbin"
+	"go-template/gateways/pwned"
+	"go-template/gateways/repository/pg"
+	"go-template/gateways/repository/pg/failover"
+	"go-template/gateways/reputation"
+	"go-template/gateways/sms"
+	"go-template/gateways/storage"
+	webhookGateway "go-template/gateways/webhook"
+	"go-template/internal/botdetect"
+	"go-template/internal/breaker"
+	"go-template/internal/health"
+	"go-template/internal/jwt"
+	"go-template/internal/metrics"
+	"go-template/internal/ratelimit"
+	"go-template/internal/ws"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ardanlabs/conf/v3"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofrs/uuid/v5"
+
+	httpPkg "github.com/guilhermebr/gox/http"
+	"github.com/guilhermebr/gox/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// Dependencies holds all application dependencies
+type Dependencies struct {
+	// Database
+	DB   *failover.ResilientDB
+	Repo *pg.Repository
+
+	// Use Cases
+	UserUseCase     *user.UseCase
+	AuthUseCase     *auth.UseCase
+	ExampleUseCase  example.UseCase
+	SettingsUseCase *settings.UseCase
+	OIDCUseCase     *oidc.UseCase
+	AuthzUseCase    *authz.UseCase
+	BreakGlassUC    *breakglass.UseCase
+	RevocationUC    *revocation.UseCase
+	SessionUC       *session.UseCase
+	APIKeyUC        *apikey.UseCase
+	AuditUseCase    *audit.UseCase
+	PreferencesUC   *preferences.UseCase
+	NotificationUC  *notification.UseCase
+	Hub             *ws.Hub
+	LoginHistoryUC  *loginhistory.UseCase
+	InvitationUC    *invitation.UseCase
+	AnnouncementUC  *announcement.UseCase
+	SearchUC        *search.UseCase
+	CommentUC       *comment.UseCase
+	WebhookUC       *webhook.UseCase
+	AlertingUC      *alerting.UseCase
+
+	// Background jobs
+	AnonymizationUseCase  *anonymization.UseCase
+	DeletionUseCase       *deletion.UseCase
+	ReconciliationUseCase *reconciliation.UseCase
+	ExamplePurger         *example.ArchivePurger
+	// Relays domain events recorded with their change; nil when events
+	// are published right away
+	Outbox *events.Outbox
+	// Writes relayed events to Kafka; nil without brokers
+	KafkaPublisher *kafka.Publisher
+	// Writes relayed events to a NATS JetStream stream; nil without a server
+	NatsBroker *nats.Broker
+	// Exports produced in the background; nil without file storage and a
+	// signing key
+	ExportJobUC *exportjob.UseCase
+	// Database backups; nil without file storage and a signing key
+	BackupUC *backup.UseCase
+	// Example attachments; nil without file storage and a signing key
+	AttachmentUC *attachment.UseCase
+	// Direct uploads to file storage; nil without file storage and a
+	// signing key
+	UploadUC *upload.UseCase
+	// Transactional emails; nil without an email provider
+	Mailer *email.Mailer
+	// Jobs run by the background workers
+	JobQueue *job.Queue
+
+	// Services
+	JWTService jwt.Service
+	Validator  *validator.Validate
+
+	// Uploaded files; nil when uploads are disabled. Files kept on local
+	// disk are served by the API.
+	Files storage.Storage
+
+	// Middleware
+	AuthMiddleware *appMiddleware.AuthMiddleware
+	BotDetector    *botdetect.Detector
+	LoginLimiter   *ratelimit.Limiter
+
+	// Checks the dependencies for the readiness probe
+	Readiness *health.Checker
+
+	// Server
+	Server *httpPkg.Server
+}
+
+// SetupDependencies initializes all application dependencies
+func SetupDependencies(ctx context.Context, cfg Config, log *slog.Logger) (*Dependencies, error) {
+	// Database
+	conn, err := failover.NewResilientDB(ctx,
+		func(ctx context.Context) (*pgxpool.Pool, error) {
+			return newPool(ctx, cfg, log)
+		},
+		failover.Config{
+			CheckInterval: cfg.DBHealthCheckInterval,
+			ReadRetries:   cfg.DBReadRetries,
+			RetryBackoff:  cfg.DBRetryBackoff,
+		},
+		log,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("setting up database: %w", err)
+	}
+
+	if err := conn.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	repo := pg.NewRepository(conn)
+
+	// Services
+	jwtService := jwt.NewService(cfg.AuthSecretKey, cfg.AuthTokenIssuer, cfg.AuthTokenTTL).
+		WithAcceptedAudiences(cfg.AuthTokenAudiences...).
+		WithLeeway(cfg.AuthTokenLeeway)
+	if len(cfg.AuthSigningKeyFiles) > 0 {
+		keys, err := loadSigningKeys(cfg.AuthSigningKeyFiles)
+		if err != nil {
+			return nil, fmt.Errorf("loading signing keys: %w", err)
+		}
+		jwtService = jwtService.WithSigningKeys(keys...)
+	} else if cfg.Environment == "production" {
+		log.Warn("AUTH_SIGNING_KEY_FILES not set, signing access tokens with AUTH_SECRET_KEY; other services can't verify them offline")
+	}
+	validator := validator.New()
+
+	// Auth setup. Only providers with settings are configured, so admins
+	// can't enable one the service can't create.
+	localAuth := auth.LocalConfig{
+		Store:  repo.LocalAuthRepo,
+		Tokens: jwtService,
+	}
+	authConfigs := map[string]auth.AuthConfig{
+		"local": {
+			Provider: "local",
+			Local:    localAuth,
+		},
+	}
+	// The dev provider accepts any password, so it's only configured when
+	// it's asked for by name in development. Otherwise admins could enable
+	// it in any environment not named production.
+	if cfg.AuthProvider == dev.ProviderName {
+		if cfg.Environment != "development" {
+			return nil, fmt.Errorf("AUTH_PROVIDER=%s accepts any password and can only be used with ENVIRONMENT=development", dev.ProviderName)
+		}
+		authConfigs[dev.ProviderName] = auth.AuthConfig{
+			Provider: dev.ProviderName,
+			Local:    localAuth,
+		}
+	}
+	if cfg.SupabaseURL != "" {
+		authConfigs["supabase"] = auth.AuthConfig{
+			Provider: "supabase",
+			Supabase: auth.SupabaseConfig{
+				URL:    cfg.SupabaseURL,
+				APIKey: cfg.SupabaseAPIKey,
+			},
+		}
+	}
+	if cfg.CognitoUserPoolID != "" {
+		authConfigs["cognito"] = auth.AuthConfig{
+			Provider: "cognito",
+			Cognito: auth.CognitoConfig{
+				Region:       cfg.CognitoRegion,
+				UserPoolID:   cfg.CognitoUserPoolID,
+				ClientID:     cfg.CognitoClientID,
+				ClientSecret: cfg.CognitoClientSecret,
+			},
+		}
+	}
+	if cfg.Auth0Domain != "" {
+		authConfigs["auth0"] = auth.AuthConfig{
+			Provider: "auth0",
+			Auth0: auth.Auth0Config{
+				Domain:       cfg.Auth0Domain,
+				ClientID:     cfg.Auth0ClientID,
+				ClientSecret: cfg.Auth0ClientSecret,
+				Connection:   cfg.Auth0Connection,
+				Audience:     cfg.Auth0Audience,
+			},
+		}
+	}
+
+	authFactory := auth.NewProviderFactory(authConfigs)
+	authFactory.SetGuard(auth.GuardConfig{
+		Timeout: cfg.AuthProviderTimeout,
+		Breaker: breaker.Config{
+			Threshold: cfg.AuthProviderBreakerThreshold,
+			Cooldown:  cfg.AuthProviderBreakerCooldown,
+		},
+	})
+	authProvider, err := authFactory.CreateProvider(cfg.AuthProvider)
+	if err != nil {
+		return nil, fmt.Errorf("creating auth provider: %w", err)
+	}
+
+	// Use Cases
+	userUC := user.NewUseCase(repo.UserRepo, authFactory, cfg.AuthProvider, log)
+	userUC.SetStatsCacheTTL(cfg.UserStatsCacheTTL)
+	files, err := newFileStorage(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if files != nil {
+		userUC.SetAvatarStorage(files)
+	}
+	authUC := auth.NewUseCase(repo.UserRepo, repo.RefreshTokenRepo, authProvider, jwtService, cfg.AuthRefreshTokenTTL, log)
+	authUC.SetSocialProviders(socialProviders(cfg)...)
+	smsSender, err := newSMSSender(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if smsSender != nil {
+		authUC.SetSMSLogin(repo.OTPCodeRepo, smsSender, auth.OTPConfig{
+			TTL:            cfg.OTPTTL,
+			MaxAttempts:    cfg.OTPMaxAttempts,
+			ResendInterval: cfg.OTPResendInterval,
+		})
+	}
+	emailSender, err := newEmailSender(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Work done out of the request, such as sending emails, is queued for
+	// the background workers
+	jobQueue := newJobQueue(cfg, repo.JobRepo, log)
+	if emailSender != nil {
+		emailSender.SetQueue(jobQueue)
+		job.Handle(jobQueue, emailSender.Deliver)
+	}
+	if emailSender != nil {
+		authUC.SetPasswordReset(repo.PasswordResetRepo, emailSender, auth.PasswordResetConfig{
+			ResetURL:       cfg.PasswordResetURL,
+			TTL:            cfg.PasswordResetTTL,
+			ResendInterval: cfg.PasswordResetResendInterval,
+		})
+		authUC.SetEmailChange(repo.EmailChangeRepo, emailSender, auth.EmailChangeConfig{
+			ConfirmURL: cfg.EmailChangeURL,
+			TTL:        cfg.EmailChangeTTL,
+		})
+	}
+	exampleUC := example.New(repo.ExampleRepo)
+	examplePurger := example.NewArchivePurger(repo.ExampleRepo, time.Duration(cfg.ExampleArchiveRetentionDays)*24*time.Hour, log)
+	settingsUC := settings.NewUseCase(repo.SettingsRepo, log)
+	// Admins enable providers and pick the default in the settings; the
+	// boot provider stays available whatever they choose.
+	authFactory.SetSettings(settingsUC, cfg.AuthProvider)
+	authUC.SetProviderFactory(authFactory)
+	authUC.SetSessionSettings(settingsUC)
+	authUC.SetTwoFactor(repo.TOTPRepo, settingsUC, cfg.TOTPIssuer)
+	authUC.SetImpersonationTTL(cfg.ImpersonationTTL)
+	// Self-registered users wait for an admin while RequireApproval is on,
+	// and are emailed the decision when an email provider is configured
+	var approvalEmail user.EmailSender
+	if emailSender != nil {
+		approvalEmail = emailSender
+	}
+	userUC.SetApproval(settingsUC, approvalEmail)
+	authUC.SetRegistrationApproval(settingsUC)
+	// While registration is disabled, people can still register with an
+	// invitation code from an admin if InvitationsEnabled is on
+	invitationUC := invitation.NewUseCase(repo.InvitationRepo, log)
+	userUC.SetRegistration(settingsUC, invitationUC)
+	if emailSender != nil {
+		invitationUC.SetEmailInvites(emailSender, cfg.InvitationAcceptURL)
+	}
+	if emailSender != nil {
+		authUC.SetEmailVerification(repo.EmailVerifyRepo, emailSender, settingsUC, auth.EmailVerificationConfig{
+			VerifyURL:      cfg.EmailVerifyURL,
+			TTL:            cfg.EmailVerifyTTL,
+			ResendInterval: cfg.EmailVerifyResendInterval,
+		})
+	}
+	if cfg.CaptchaProvider != "" {
+		verifier, err := captcha.New(cfg.CaptchaProvider, cfg.CaptchaSecret)
+		if err != nil {
+			return nil, err
+		}
+		authUC.SetCaptcha(verifier, settingsUC, cfg.CaptchaProvider, cfg.CaptchaSiteKey)
+	}
+	// New passwords follow the policy in the settings, wherever they are set
+	passwordPolicy := passwordpolicy.NewUseCase(settingsUC, log)
+	if cfg.PasswordBreachCheckURL != "" {
+		passwordPolicy.SetBreachChecker(pwned.New(cfg.PasswordBreachCheckURL))
+	}
+	userUC.SetPasswordPolicy(passwordPolicy)
+	authUC.SetPasswordPolicy(passwordPolicy)
+	authzUC := authz.NewUseCase(repo.PermissionsRepo, repo.RoleRepo, repo.UserRepo, log)
+	// Access tokens carry the user's role and admin permissions
+	authUC.AddClaimsEnricher(authzUC)
+
+	// Break-glass emergency access and dead-lettered jobs alert operators
+	// on the channels in the alert settings, and on ALERT_WEBHOOK_URL
+	alertingUC := alerting.NewUseCase(repo.AlertRepo, alerting.Channels{
+		Slack:     func(url string) alerting.Channel { return alert.NewSlack(url) },
+		PagerDuty: func(key string) alerting.Channel { return alert.NewPagerDuty(key) },
+		Webhook:   func(url string) alerting.Channel { return alert.NewWebhook(url) },
+	}, log)
+	if cfg.AlertWebhookURL != "" {
+		alertingUC.AddChannel("alert_webhook", alert.NewWebhook(cfg.AlertWebhookURL), "")
+	}
+	jobQueue.SetAlerter(alertingUC)
+	// The break-glass credential stands in for the second factor, so emergency
+	// sessions aren't locked out by Require2FA.
+	breakGlassTokens := jwtService.WithAudience(jwt.AudienceAdmin).WithAMR(jwt.AMRMultiFactor)
+	breakGlassUC := breakglass.NewUseCase(repo.BreakGlassRepo, breakGlassTokens, alertingUC, cfg.BreakGlassSessionTTL, log)
+	if err := sealBreakGlassCredential(ctx, cfg, breakGlassUC, log); err != nil {
+		return nil, fmt.Errorf("sealing break-glass credential: %w", err)
+	}
+
+	// Access tokens revoked on logout are denylisted until they expire, and
+	// all of a user's tokens can be revoked at once
+	revocationUC := revocation.NewUseCase(repo.RevocationRepo, log)
+
+	// Sessions seen by the auth middleware back the active session count,
+	// users can list and revoke their own, and admins can force users out
+	sessionUC := session.NewUseCase(repo.SessionRepo, repo.RefreshTokenRepo, revocationUC, cfg.SessionActiveWindow, log)
+	// A password reset ends the user's sessions, access tokens included
+	authUC.SetSessionRevoker(sessionUC)
+
+	// Machine clients authenticate with API keys users create for them
+	apiKeyUC := apikey.NewUseCase(repo.APIKeyRepo, log)
+
+	// Audit events are stored for the admin audit log
+	auditUC := audit.NewUseCase(repo.AuditRepo, log)
+
+	// Users, auth and settings publish what happened to them on the event
+	// bus, and the features reacting to it subscribe here. With the outbox,
+	// events are recorded with their change and relayed to the bus, and to
+	// Kafka or NATS when they are configured.
+	bus := events.NewBus(log)
+	var publisher events.Publisher = bus
+	var outbox *events.Outbox
+	var kafkaPublisher *kafka.Publisher
+	var natsBroker *nats.Broker
+	if cfg.EventOutbox {
+		outbox = events.NewOutbox(repo.OutboxRepo, repo, cfg.EventOutboxRetention, log)
+		outbox.AddSink("bus", bus)
+		publisher = outbox
+	}
+	if len(cfg.KafkaBrokers) > 0 {
+		if outbox == nil {
+			return nil, fmt.Errorf("KAFKA_BROKERS requires EVENT_OUTBOX")
+		}
+		kafkaPublisher, err = kafka.NewPublisher(kafka.Config{
+			Brokers:      cfg.KafkaBrokers,
+			Topics:       cfg.KafkaTopics,
+			DefaultTopic: cfg.KafkaTopic,
+			WriteTimeout: cfg.KafkaWriteTimeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating kafka publisher: %w", err)
+		}
+		outbox.AddSink("kafka", kafkaPublisher)
+	}
+	if cfg.NatsURL != "" {
+		if outbox == nil {
+			return nil, fmt.Errorf("NATS_URL requires EVENT_OUTBOX")
+		}
+		natsBroker, err = nats.Connect(ctx, nats.Config{
+			URL:           cfg.NatsURL,
+			Stream:        cfg.NatsStream,
+			SubjectPrefix: cfg.NatsSubjectPrefix,
+			MaxAge:        cfg.NatsMaxAge,
+			Duplicates:    cfg.NatsDuplicates,
+			WriteTimeout:  cfg.NatsWriteTimeout,
+		}, log)
+		if err != nil {
+			return nil, fmt.Errorf("creating nats broker: %w", err)
+		}
+		outbox.AddSink("nats", natsBroker)
+	}
+	userUC.SetEvents(publisher)
+	authUC.SetEvents(publisher)
+	settingsUC.SetEvents(publisher)
+	events.Subscribe(bus, auditUC.UserCreated)
+	events.Subscribe(bus, auditUC.UserDeleted)
+	events.Subscribe(bus, auditUC.SettingsUpdated)
+	events.Subscribe(bus, authUC.WelcomeRegisteredUser)
+
+	// Events are posted to the webhooks super admins register, each
+	// delivery by a job so a failed one is retried
+	webhookUC := webhook.NewUseCase(repo.WebhookRepo, webhookGateway.NewSender(), jobQueue, log)
+	webhook.Subscribe[events.UserCreated](bus, webhookUC)
+	webhook.Subscribe[events.UserDeleted](bus, webhookUC)
+	webhook.Subscribe[events.SettingsUpdated](bus, webhookUC)
+	job.Handle(jobQueue, webhookUC.Deliver)
+
+	// Users and examples are kept in a full-text index by database triggers
+	searchUC := search.NewUseCase(repo.SearchRepo, log)
+
+	// Login attempts on known accounts are kept for admins to review
+	loginHistoryUC := loginhistory.NewUseCase(repo.LoginEventRepo, log)
+	authUC.SetLoginRecorder(loginHistoryUC)
+
+	// Users keep their UI and notification preferences server-side
+	preferencesUC := preferences.NewUseCase(repo.PreferencesRepo, log)
+
+	// Other features notify users in the apps, or by email when they chose so
+	notificationUC := notification.NewUseCase(repo.NotificationRepo, repo.UserRepo, preferencesUC, log)
+	if emailSender != nil {
+		notificationUC.SetEmail(emailSender)
+	}
+
+	// Signed in users' WebSocket connections get their notifications as
+	// they arrive
+	hub := ws.NewHub(log)
+	hub.AddFeed(func(ctx context.Context, userID uuid.UUID) <-chan ws.Message {
+		return ws.Forward("notification", notificationUC.Subscribe(ctx, userID))
+	})
+
+	// Admins broadcast announcements shown as a banner in the apps
+	announcementUC := announcement.NewUseCase(repo.AnnouncementRepo, log)
+
+	// Deleted users are anonymized in the background. Features that keep PII
+	// tied to a user register their Scrubber here.
+	anonymizationUC := anonymization.NewUseCase(repo.TombstoneRepo, log, auditUC)
+
+	// Users can ask for their account to be deleted, and have the grace
+	// period to change their mind
+	deletionUC := deletion.NewUseCase(repo.DeletionRepo, userUC, cfg.AccountDeletionGracePeriod, log)
+
+	// Exports too large for a request, files attached to examples and
+	// direct uploads are stored as private files, moved through signed
+	// links. S3 presigns them; local files need STORAGE_SIGNING_KEY.
+	var exportJobUC *exportjob.UseCase
+	var attachmentUC *attachment.UseCase
+	var uploadUC *upload.UseCase
+	if files != nil && (cfg.StorageProvider != "local" || cfg.StorageSigningKey != "") {
+		exportJobUC = exportjob.NewUseCase(repo.ExportJobRepo, userUC, files, cfg.ExportURLTTL, cfg.ExportRetention, log)
+		exportJobUC.SetNotifier(notificationUC)
+		attachmentUC = attachment.NewUseCase(repo.AttachmentRepo, exampleUC, files, cfg.AttachmentMaxSize, cfg.AttachmentURLTTL, log)
+		uploadUC = upload.NewUseCase(repo.UploadRepo, files, cfg.UploadMaxSize, cfg.UploadURLTTL, log)
+	}
+
+	// Database backups are dumped to private files by the job workers
+	var backupUC *backup.UseCase
+	if files != nil && (cfg.StorageProvider != "local" || cfg.StorageSigningKey != "") {
+		dumper, err := newDumper(cfg, log)
+		if err != nil {
+			return nil, fmt.Errorf("setting up backups: %w", err)
+		}
+		backupUC = backup.NewUseCase(repo.BackupRepo, dumper, files, settingsUC, jobQueue, cfg.BackupURLTTL, log)
+		job.Handle(jobQueue, backupUC.RunBackup)
+	}
+
+	// Examples can be commented on
+	commentUC := comment.NewUseCase(repo.CommentRepo, exampleUC, log)
+
+	// Users changed directly on the auth provider drift from the users table
+	reconciliationUC := reconciliation.NewUseCase(repo.ReconcileRepo, authProvider, cfg.ReconcileRepair, log)
+
+	// OpenID Connect provider
+	oidcKey, err := loadOIDCSigningKey(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("loading OIDC signing key: %w", err)
+	}
+	oidcUC := oidc.NewUseCase(repo.OAuthRepo, repo.UserRepo, oidcKey, oidc.Config{
+		Issuer:                cfg.OIDCIssuer,
+		AuthorizationEndpoint: cfg.OIDCAuthorizeURL,
+		AccessTokenTTL:        cfg.OIDCAccessTokenTTL,
+		IDTokenTTL:            cfg.OIDCAccessTokenTTL,
+		CodeTTL:               cfg.OIDCAuthorizationCodeTTL,
+	}, log)
+	oidcUC.SetAccessTokenKeys(jwtService)
+
+	// Business KPIs
+	if err := metrics.RegisterUserStats(userUC.GetUserStats); err != nil {
+		return nil, fmt.Errorf("registering user stats metrics: %w", err)
+	}
+
+	// Middleware
+	authMiddleware := appMiddleware.NewAuthMiddleware(jwtService)
+	authMiddleware.SetPermissionResolver(authzUC)
+	authMiddleware.SetAudienceRoutes(appMiddleware.ParseAudienceRoutes(cfg.AuthAudienceRoutes))
+	authMiddleware.SetRevocationChecker(revocationUC)
+	authMiddleware.SetStatusChecker(userUC)
+	authMiddleware.SetSessionTracker(sessionUC)
+	authMiddleware.SetAPIKeyAuthenticator(apiKeyUC)
+	authMiddleware.SetTwoFactorPolicy(authUC)
+	if cfg.AuthzCasbinPolicyFile != "" {
+		authorizer, err := casbin.New(cfg.AuthzCasbinModelFile, cfg.AuthzCasbinPolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("creating casbin authorizer: %w", err)
+		}
+		authMiddleware.SetAuthorizer(authorizer)
+	}
+	botDetector := newBotDetector(cfg, log)
+	redisClient, err := newRedis(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	loginLimiter := newLoginLimiter(cfg, redisClient, log)
+
+	// Readiness probe
+	readiness := newReadiness(cfg, conn, authUC, redisClient, kafkaPublisher, natsBroker, log)
+
+	return &Dependencies{
+		DB:              conn,
+		Repo:            repo,
+		UserUseCase:     userUC,
+		AuthUseCase:     authUC,
+		ExampleUseCase:  exampleUC,
+		SettingsUseCase: settingsUC,
+		OIDCUseCase:     oidcUC,
+		AuthzUseCase:    authzUC,
+		BreakGlassUC:    breakGlassUC,
+		RevocationUC:    revocationUC,
+		SessionUC:       sessionUC,
+		APIKeyUC:        apiKeyUC,
+		AuditUseCase:    auditUC,
+		PreferencesUC:   preferencesUC,
+		NotificationUC:  notificationUC,
+		Hub:             hub,
+		LoginHistoryUC:  loginHistoryUC,
+		InvitationUC:    invitationUC,
+		AnnouncementUC:  announcementUC,
+		SearchUC:        searchUC,
+		CommentUC:       commentUC,
+		WebhookUC:       webhookUC,
+		AlertingUC:      alertingUC,
+		JWTService:      jwtService,
+		Validator:       validator,
+		Files:           files,
+		AuthMiddleware:  authMiddleware,
+		BotDetector:     botDetector,
+		LoginLimiter:    loginLimiter,
+		Readiness:       readiness,
+
+		AnonymizationUseCase:  anonymizationUC,
+		DeletionUseCase:       deletionUC,
+		ExamplePurger:         examplePurger,
+		Outbox:                outbox,
+		KafkaPublisher:        kafkaPublisher,
+		NatsBroker:            natsBroker,
+		ExportJobUC:           exportJobUC,
+		BackupUC:              backupUC,
+		AttachmentUC:          attachmentUC,
+		UploadUC:              uploadUC,
+		Mailer:                emailSender,
+		JobQueue:              jobQueue,
+		ReconciliationUseCase: reconciliationUC,
+	}, nil
+}
+
+// RunBackgroundJobs runs the job workers and the periodic tasks, such as
+// cleanups, until ctx is done and the jobs running are cut short. The API
+// runs them unless WORKER_EMBEDDED is false, and cmd/worker always does.
+func (d *Dependencies) RunBackgroundJobs(ctx context.Context, cfg Config) {
+	// Drop revoked tokens that have expired
+	go d.RevocationUC.Start(ctx, cfg.RevokedTokenPurgeInterval)
+
+	// Drop sessions whose token has expired
+	go d.SessionUC.Start(ctx, cfg.SessionPurgeInterval)
+
+	// Anonymize deleted users
+	go d.AnonymizationUseCase.Start(ctx, cfg.AnonymizationInterval)
+
+	// Delete accounts whose deletion grace period has passed
+	go d.DeletionUseCase.Start(ctx, cfg.AccountDeletionInterval)
+
+	// Delete examples archived for longer than their retention
+	if cfg.ExampleArchiveRetentionDays > 0 {
+		go d.ExamplePurger.Start(ctx, cfg.ExampleArchivePurgeInterval)
+	}
+
+	// Relay domain events to their subscribers
+	if d.Outbox != nil {
+		go d.Outbox.Start(ctx, cfg.EventRelayInterval)
+	}
+
+	// Produce queued exports
+	if d.ExportJobUC != nil {
+		go d.ExportJobUC.Start(ctx, cfg.ExportJobInterval)
+	}
+
+	// Queue the daily backup, and remove expired ones
+	if d.BackupUC != nil {
+		go d.BackupUC.Start(ctx, cfg.BackupInterval)
+	}
+
+	// Remove files whose example or attachment was deleted
+	if d.AttachmentUC != nil {
+		go d.AttachmentUC.Start(ctx, cfg.AttachmentCleanupInterval)
+	}
+
+	// Remove direct uploads never confirmed
+	if d.UploadUC != nil {
+		go d.UploadUC.Start(ctx, cfg.UploadCleanupInterval)
+	}
+
+	// Reconcile local users with the auth provider
+	if cfg.ReconcileInterval > 0 {
+		go d.ReconciliationUseCase.Start(ctx, cfg.ReconcileInterval)
+	}
+
+	// Run queued jobs, recording those cut short to try them again
+	d.JobQueue.Start(ctx, cfg.JobPollInterval, cfg.JobWorkers)
+}
+
+// Close closes the WebSocket connections, and lets the events being
+// written to Kafka or NATS reach them, once the background jobs stopped.
+func (d *Dependencies) Close(cfg Config, log *slog.Logger) {
+	d.Hub.Close()
+	if d.KafkaPublisher != nil {
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.KafkaWriteTimeout)
+		defer drainCancel()
+		if err := d.KafkaPublisher.Close(drainCtx); err != nil {
+			log.Error("failed to close kafka publisher",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	if d.NatsBroker != nil {
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.NatsWriteTimeout)
+		defer drainCancel()
+		if err := d.NatsBroker.Close(drainCtx); err != nil {
+			log.Error("failed to close nats broker",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// newPool connects to the database, tracing every query for metrics and the
+// slow query log.
+func newPool(ctx context.Context, cfg Config, log *slog.Logger) (*pgxpool.Pool, error) {
+	var dbCfg postgres.Config
+	if _, err := conf.Parse("", &dbCfg); err != nil {
+		return nil, fmt.Errorf("parsing database config: %w", err)
+	}
+	poolCfg, err := pgxpool.ParseConfig(dbCfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("parsing database connection string: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = pg.NewQueryTracer(cfg.DBSlowQueryThreshold, log)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup postgres: %w", err)
+	}
+	return pool, nil
+}
+
+// newDumper dumps the database the pool connects to with pg_dump. The
+// binary only has to be installed where the job workers run.
+func newDumper(cfg Config, log *slog.Logger) (*pgdump.Dumper, error) {
+	var dbCfg postgres.Config
+	if _, err := conf.Parse("", &dbCfg); err != nil {
+		return nil, fmt.Errorf("parsing database config: %w", err)
+	}
+	dumper := pgdump.New(pgdump.Config{
+		Bin:      cfg.BackupPgDump,
+		Host:     dbCfg.DatabaseHost,
+		Port:     dbCfg.DatabasePort,
+		User:     dbCfg.DatabaseUser,
+		Password: dbCfg.DatabasePassword,
+		Database: dbCfg.DatabaseName,
+		SSLMode:  dbCfg.DatabaseSSLMode,
+	})
+	if !dumper.Available() {
+		log.Warn("pg_dump not found, backups fail on the job workers of this instance", "bin", cfg.BackupPgDump)
+	}
+	return dumper, nil
+}
+
+// newJobQueue creates the job queue with the retry policies of the config.
+func newJobQueue(cfg Config, repo job.Repository, log *slog.Logger) *job.Queue {
+	q := job.NewQueue(repo, cfg.JobRetention, log)
+	q.SetDefaultPolicy(job.Policy{
+		MaxAttempts: cfg.JobMaxAttempts,
+		Backoff:     cfg.JobBackoff,
+		MaxBackoff:  cfg.JobMaxBackoff,
+	})
+	policies := map[string]job.Policy{}
+	for kind, attempts := range cfg.JobKindMaxAttempts {
+		p := policies[kind]
+		p.MaxAttempts = attempts
+		policies[kind] = p
+	}
+	for kind, backoff := range cfg.JobKindBackoff {
+		p := policies[kind]
+		p.Backoff = backoff
+		policies[kind] = p
+	}
+	for kind, p := range policies {
+		q.SetPolicy(kind, p)
+	}
+	return q
+}
+
+// newBotDetector screens public API forms. Form tokens are only checked when
+// BOT_FORM_SECRET is set, since API clients don't have to render a form first.
+func newBotDetector(cfg Config, log *slog.Logger) *botdetect.Detector {
+	botCfg := botdetect.Config{
+		Secret:        []byte(cfg.BotFormSecret),
+		MinSubmitTime: cfg.BotMinSubmitTime,
+		MaxSubmitTime: cfg.BotMaxSubmitTime,
+	}
+	if len(cfg.BotDNSBLZones) > 0 {
+		botCfg.Reputation = reputation.NewDNSBL(cfg.BotDNSBLZones...)
+	}
+	return botdetect.New(botCfg, log)
+}
+
+// newRedis connects to REDIS_URL, or returns nil when it is not set.
+func newRedis(ctx context.Context, cfg Config) (*redis.Client, error) {
+	if cfg.RedisURL == "" {
+		return nil, nil
+	}
+
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing REDIS_URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+	return client, nil
+}
+
+// newLoginLimiter limits login attempts. Attempts are kept in Redis when
+// REDIS_URL is set and in memory otherwise.
+func newLoginLimiter(cfg Config, client *redis.Client, log *slog.Logger) *ratelimit.Limiter {
+	limitCfg := ratelimit.Config{
+		PerIP:      ratelimit.Rule{Limit: cfg.LoginRateLimitPerIP, Window: cfg.LoginRateLimitWindow},
+		PerAccount: ratelimit.Rule{Limit: cfg.LoginRateLimitPerAccount, Window: cfg.LoginRateLimitWindow},
+	}
+
+	if client == nil {
+		log.Info("login rate limits kept in memory, set REDIS_URL to share them between instances")
+		return ratelimit.New(limitCfg, ratelimit.NewMemoryStore(), log)
+	}
+	return ratelimit.New(limitCfg, ratelimit.NewRedisStore(client, "ratelimit:"), log)
+}
+
+// newReadiness checks the dependencies that are configured. Only the
+// database is required: the service can't answer without it, while the
+// login limits fail open without Redis, events wait in the outbox for the
+// brokers, and only sign-ins need the auth provider.
+func newReadiness(cfg Config, db *failover.ResilientDB, authUC *auth.UseCase, redisClient *redis.Client, kafkaPublisher *kafka.Publisher, natsBroker *nats.Broker, log *slog.Logger) *health.Checker {
+	readiness := health.New(cfg.ReadyCheckTimeout, log)
+	readiness.Add("postgres", true, db.Ping)
+	readiness.Add("auth_provider", false, authUC.CheckProvider)
+	if redisClient != nil {
+		readiness.Add("redis", false, func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+	if kafkaPublisher != nil {
+		readiness.Add("kafka", false, kafkaPublisher.Ping)
+	}
+	if natsBroker != nil {
+		readiness.Add("nats", false, natsBroker.Ping)
+	}
+	return readiness
+}
+
+// sealBreakGlassCredential writes a new break-glass credential to
+// BREAK_GLASS_CREDENTIAL_FILE when none is sealed yet. Move the file somewhere
+// safe and delete it after the deploy; only the hash stays in the database.
+func sealBreakGlassCredential(ctx context.Context, cfg Config, uc *breakglass.UseCase, log *slog.Logger) error {
+	if cfg.BreakGlassCredentialFile == "" {
+		return nil
+	}
+
+	sealed, err := uc.Seal(ctx, func(secret string) error {
+		return os.WriteFile(cfg.BreakGlassCredentialFile, []byte(secret+"\n"), 0o600)
+	})
+
+	if err != nil {
+		return err
+	}
+	if sealed {
+		log.Warn("new break-glass credential written, move it to a safe place and delete the file",
+			slog.String("file", cfg.BreakGlassCredentialFile),
+		)
+	}
+	return nil
+}
+
+// loadOIDCSigningKey reads the OIDC signing key, generating an ephemeral one
+// when none is configured.
+func loadOIDCSigningKey(cfg Config, log *slog.Logger) (*jwt.RSAKey, error) {
+	if cfg.OIDCSigningKeyFile == "" {
+		log.Warn("OIDC_SIGNING_KEY_FILE not set, using an ephemeral signing key; issued ID tokens won't verify after a restart")
+		return jwt.GenerateRSAKey()
+	}
+
+	pemData, err := os.ReadFile(cfg.OIDCSigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.NewRSAKey(pemData)
+}
+
+// loadSigningKeys reads the access token signing keys. The first one signs.
+func loadSigningKeys(files []string) ([]*jwt.Key, error) {
+	keys := make([]*jwt.Key, 0, len(files))
+	for _, file := range files {
+		pemData, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwt.ParseKey(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// socialProviders returns the social login providers that have a client ID
+// configured.
+func socialProviders(cfg Config) []auth.SocialProvider {
+	var providers []auth.SocialProvider
+	if cfg.GoogleClientID != "" {
+		providers = append(providers, google.NewProvider(cfg.GoogleClientID, cfg.GoogleClientSecret))
+	}
+	if cfg.GitHubClientID != "" {
+		providers = append(providers, github.NewProvider(cfg.GitHubClientID, cfg.GitHubClientSecret))
+	}
+	return providers
+}
+
+// newSMSSender returns the gateway one-time codes are sent with, or nil when
+// SMS login is disabled.
+func newSMSSender(cfg Config) (auth.SMSSender, error) {
+	switch cfg.SMSProvider {
+	case "":
+		return nil, nil
+	case "twilio":
+		if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" || cfg.TwilioFromNumber == "" {
+			return nil, fmt.Errorf("twilio requires TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_FROM_NUMBER")
+		}
+		return sms.NewTwilio(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFromNumber), nil
+	case "log":
+		return sms.NewLog(), nil
+	default:
+		return nil, fmt.Errorf("unsupported sms provider: %s", cfg.SMSProvider)
+	}
+}
+
+// newFileStorage returns where uploaded files are kept, or nil when uploads
+// are disabled.
+func newFileStorage(ctx context.Context, cfg Config) (storage.Storage, error) {
+	switch cfg.StorageProvider {
+	case "":
+		return nil, nil
+	case "local":
+		local, err := storage.NewLocal(cfg.StorageLocalDir, cfg.StoragePublicURL)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.StorageSigningKey != "" {
+			local.SetSigningKey([]byte(cfg.StorageSigningKey))
+		}
+		return local, nil
+	case "s3":
+		return storage.NewS3(ctx, storage.S3Config{
+			Bucket:          cfg.StorageS3Bucket,
+			Region:          cfg.StorageS3Region,
+			Endpoint:        cfg.StorageS3Endpoint,
+			AccessKeyID:     cfg.StorageS3AccessKeyID,
+			SecretAccessKey: cfg.StorageS3SecretAccessKey,
+			UsePathStyle:    cfg.StorageS3UsePathStyle,
+			PublicURL:       cfg.StoragePublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage provider: %s", cfg.StorageProvider)
+	}
+}
+
+// newEmailSender returns the mailer transactional emails are rendered and
+// sent with, or nil when the application sends no email.
+func newEmailSender(ctx context.Context, cfg Config) (*email.Mailer, error) {
+	var sender email.Sender
+	var err error
+	switch cfg.EmailProvider {
+	case "":
+		return nil, nil
+	case "log":
+		sender = email.NewLog()
+	case "smtp":
+		sender, err = email.NewSMTP(email.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+		})
+	case "ses":
+		sender, err = email.NewSES(ctx, email.SESConfig{
+			Region:          cfg.SESRegion,
+			AccessKeyID:     cfg.SESAccessKeyID,
+			SecretAccessKey: cfg.SESSecretAccessKey,
+			From:            cfg.EmailFrom,
+		})
+	case "sendgrid":
+		sender, err = email.NewSendGrid(cfg.SendGridAPIKey, cfg.EmailFrom)
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", cfg.EmailProvider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.EmailProvider != "log" {
+		sender = email.NewRetry(sender, cfg.EmailRetryAttempts, cfg.EmailRetryBackoff)
+	}
+
+	templates, err := email.NewTemplates(cfg.EmailTemplatesDir, cfg.EmailAppName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
+	return email.NewMailer(sender, templates), nil
+}
@@ -0,0 +1,242 @@
+// Package botdetect flags automated submissions of public forms. It combines
+// a honeypot field that humans never see, a signed render timestamp that
+// rejects forms submitted too fast or too late, and an optional IP reputation
+// lookup. Every blocked attempt is counted in the metrics package.
+package botdetect
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"go-template/internal/clientip"
+	"go-template/internal/metrics"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHoneypotField is the form field bots fill in and humans don't see
+	DefaultHoneypotField = "website"
+	// DefaultTokenField carries the form token in HTML forms
+	DefaultTokenField = "form_token"
+	// TokenHeader carries the form token in JSON requests
+	TokenHeader = "X-Form-Token"
+
+	maxBodySize = 1 << 20
+)
+
+// Reason explains why a submission was blocked.
+type Reason string
+
+const (
+	ReasonHoneypot     Reason = "honeypot"
+	ReasonTooFast      Reason = "too_fast"
+	ReasonInvalidToken Reason = "invalid_token"
+	ReasonIPReputation Reason = "ip_reputation"
+)
+
+// ReputationChecker reports whether an IP address is known for abuse.
+type ReputationChecker interface {
+	IsListed(ctx context.Context, ip string) (bool, error)
+}
+
+// Config configures a Detector.
+type Config struct {
+	HoneypotField string
+	TokenField    string
+
+	// Secret signs form tokens. Tokens are only checked when it is set.
+	Secret []byte
+	// RequireToken rejects submissions without a form token. Leave it off for
+	// endpoints that also serve clients that never render the form.
+	RequireToken  bool
+	MinSubmitTime time.Duration
+	MaxSubmitTime time.Duration
+
+	// Reputation is optional. Lookup failures let the submission through.
+	Reputation ReputationChecker
+}
+
+// Submission holds the parts of a request the checks look at.
+type Submission struct {
+	IP       string
+	Honeypot string
+	Token    string
+}
+
+type Detector struct {
+	cfg    Config
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func New(cfg Config, logger *slog.Logger) *Detector {
+	if cfg.HoneypotField == "" {
+		cfg.HoneypotField = DefaultHoneypotField
+	}
+	if cfg.TokenField == "" {
+		cfg.TokenField = DefaultTokenField
+	}
+	return &Detector{
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// RandomSecret returns a secret for signing form tokens. Tokens signed with it
+// don't survive a restart and aren't accepted by other instances.
+func RandomSecret() []byte {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return secret
+}
+
+// HoneypotField returns the name of the honeypot form field.
+func (d *Detector) HoneypotField() string {
+	return d.cfg.HoneypotField
+}
+
+// TokenField returns the name of the form token field.
+func (d *Detector) TokenField() string {
+	return d.cfg.TokenField
+}
+
+// Token returns a form token stamped with the current time. Render it in a
+// hidden field so Check can tell how long the form was open.
+func (d *Detector) Token() string {
+	if len(d.cfg.Secret) == 0 {
+		return ""
+	}
+	ts := strconv.FormatInt(d.now().Unix(), 10)
+	return ts + "." + d.sign(ts)
+}
+
+// Check runs every configured check on sub and returns the reason it was
+// blocked, or an empty reason when it looks human.
+func (d *Detector) Check(ctx context.Context, form string, sub Submission) Reason {
+	reason := d.check(ctx, sub)
+	if reason != "" {
+		metrics.RecordBotBlocked(form, string(reason))
+		d.logger.Warn("blocked automated submission", "form", form, "reason", reason, "ip", sub.IP)
+	}
+	return reason
+}
+
+func (d *Detector) check(ctx context.Context, sub Submission) Reason {
+	if strings.TrimSpace(sub.Honeypot) != "" {
+		return ReasonHoneypot
+	}
+
+	if reason := d.checkToken(sub.Token); reason != "" {
+		return reason
+	}
+
+	if d.cfg.Reputation != nil && sub.IP != "" {
+		listed, err := d.cfg.Reputation.IsListed(ctx, sub.IP)
+		if err != nil {
+			d.logger.Warn("ip reputation lookup failed", "ip", sub.IP, "error", err)
+		} else if listed {
+			return ReasonIPReputation
+		}
+	}
+
+	return ""
+}
+
+func (d *Detector) checkToken(token string) Reason {
+	if len(d.cfg.Secret) == 0 {
+		return ""
+	}
+	if token == "" {
+		if d.cfg.RequireToken {
+			return ReasonInvalidToken
+		}
+		return ""
+	}
+
+	ts, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(d.sign(ts))) {
+		return ReasonInvalidToken
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ReasonInvalidToken
+	}
+
+	elapsed := d.now().Sub(time.Unix(unix, 0))
+	if elapsed < d.cfg.MinSubmitTime {
+		return ReasonTooFast
+	}
+	if d.cfg.MaxSubmitTime > 0 && elapsed > d.cfg.MaxSubmitTime {
+		return ReasonInvalidToken
+	}
+	return ""
+}
+
+func (d *Detector) sign(value string) string {
+	mac := hmac.New(sha256.New, d.cfg.Secret)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Middleware checks submissions of form before they reach the handler and
+// hands blocked ones to onBlocked. It reads HTML forms and JSON bodies; the
+// JSON body is restored for the next handler.
+func (d *Detector) Middleware(form string, onBlocked http.HandlerFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sub, err := d.submission(r)
+			if err != nil {
+				// Leave malformed bodies to the handler's own validation
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if reason := d.Check(r.Context(), form, sub); reason != "" {
+				onBlocked(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (d *Detector) submission(r *http.Request) (Submission, error) {
+	sub := Submission{
+		IP:    clientip.FromRequest(r),
+		Token: r.Header.Get(TokenHeader),
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			return Submission{}, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields map[string]any
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return Submission{}, err
+		}
+		sub.Honeypot, _ = fields[d.cfg.HoneypotField].(string)
+		return sub, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return Submission{}, err
+	}
+	sub.Honeypot = r.PostFormValue(d.cfg.HoneypotField)
+	if token := r.PostFormValue(d.cfg.TokenField); token != "" {
+		sub.Token = token
+	}
+	return sub, nil
+}
@@ -0,0 +1,187 @@
+package botdetect
+
+import (
+	"context"
+	"errors"
+	"go-template/internal/metrics"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReputation struct {
+	listed bool
+	err    error
+}
+
+func (f fakeReputation) IsListed(ctx context.Context, ip string) (bool, error) {
+	return f.listed, f.err
+}
+
+func newTestDetector(cfg Config) *Detector {
+	return New(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestDetector_Check(t *testing.T) {
+	now := time.Now()
+	cfg := Config{
+		Secret:        []byte("secret"),
+		RequireToken:  true,
+		MinSubmitTime: 3 * time.Second,
+		MaxSubmitTime: time.Hour,
+	}
+	tokenAt := func(at time.Time) string {
+		d := newTestDetector(cfg)
+		d.now = func() time.Time { return at }
+		return d.Token()
+	}
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		submission Submission
+		want       Reason
+	}{
+		{
+			name:       "human",
+			cfg:        cfg,
+			submission: Submission{Token: tokenAt(now.Add(-time.Minute))},
+		},
+		{
+			name:       "honeypot filled",
+			cfg:        cfg,
+			submission: Submission{Honeypot: "http://spam.example", Token: tokenAt(now.Add(-time.Minute))},
+			want:       ReasonHoneypot,
+		},
+		{
+			name:       "submitted too fast",
+			cfg:        cfg,
+			submission: Submission{Token: tokenAt(now.Add(-time.Second))},
+			want:       ReasonTooFast,
+		},
+		{
+			name:       "expired token",
+			cfg:        cfg,
+			submission: Submission{Token: tokenAt(now.Add(-2 * time.Hour))},
+			want:       ReasonInvalidToken,
+		},
+		{
+			name:       "forged token",
+			cfg:        cfg,
+			submission: Submission{Token: "1.forged"},
+			want:       ReasonInvalidToken,
+		},
+		{
+			name:       "missing required token",
+			cfg:        cfg,
+			submission: Submission{},
+			want:       ReasonInvalidToken,
+		},
+		{
+			name:       "optional token",
+			cfg:        Config{Secret: []byte("secret")},
+			submission: Submission{},
+		},
+		{
+			name:       "listed ip",
+			cfg:        Config{Reputation: fakeReputation{listed: true}},
+			submission: Submission{IP: "203.0.113.7"},
+			want:       ReasonIPReputation,
+		},
+		{
+			name:       "reputation lookup failure lets the submission through",
+			cfg:        Config{Reputation: fakeReputation{err: errors.New("timeout")}},
+			submission: Submission{IP: "203.0.113.7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDetector(tt.cfg)
+			d.now = func() time.Time { return now }
+
+			assert.Equal(t, tt.want, d.Check(context.Background(), "test", tt.submission))
+		})
+	}
+}
+
+func TestDetector_Middleware(t *testing.T) {
+	d := newTestDetector(Config{})
+	blocked := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+	handler := d.Middleware("test", blocked)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+
+	t.Run("form honeypot", func(t *testing.T) {
+		form := url.Values{"email": {"a@b.com"}, DefaultHoneypotField: {"spam"}}
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("json honeypot", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"email":"a@b.com","website":"spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("json body is restored", func(t *testing.T) {
+		body := `{"email":"a@b.com","website":""}`
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, body, w.Body.String())
+	})
+}
+
+func TestDetector_RecordsBlockedAttempts(t *testing.T) {
+	d := newTestDetector(Config{})
+	before := countBlocked(t, "metrics_test", ReasonHoneypot)
+
+	d.Check(context.Background(), "metrics_test", Submission{Honeypot: "spam"})
+	d.Check(context.Background(), "metrics_test", Submission{})
+
+	assert.Equal(t, before+1, countBlocked(t, "metrics_test", ReasonHoneypot))
+}
+
+func countBlocked(t *testing.T, form string, reason Reason) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "go_template_bot_submissions_blocked_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["form"] == form && labels["reason"] == string(reason) {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
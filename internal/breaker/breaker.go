@@ -0,0 +1,140 @@
+// Package breaker is a circuit breaker for calls to services that can go
+// down. After Threshold consecutive failures the circuit opens and calls fail
+// fast with ErrOpen for Cooldown. Then a single trial call is let through:
+// success closes the circuit, failure opens it for another Cooldown.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the circuit is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of the circuit.
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+// Config configures a Breaker. Zero fields take their DefaultConfig value.
+type Config struct {
+	// Threshold is the number of consecutive failures that opens the circuit
+	Threshold int
+	// Cooldown is how long the circuit stays open before a trial call
+	Cooldown time.Duration
+	// OnStateChange is called, without the lock held, whenever the state
+	// changes
+	OnStateChange func(from, to State)
+}
+
+// DefaultConfig is used for zero Config fields.
+var DefaultConfig = Config{
+	Threshold: 5,
+	Cooldown:  30 * time.Second,
+}
+
+type Breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// trial is set while the half-open trial call is in flight
+	trial bool
+}
+
+func New(cfg Config) *Breaker {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultConfig.Threshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultConfig.Cooldown
+	}
+	return &Breaker{cfg: cfg, now: time.Now, state: StateClosed}
+}
+
+// State returns the current state. An open circuit whose cooldown has passed
+// reports half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.Cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a call may go ahead. Every allowed call must be
+// followed by Record, or by Abort when its outcome says nothing about the
+// service.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.Cooldown {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+	case StateHalfOpen:
+		if b.trial {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		b.trial = true
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return nil
+}
+
+// Record reports the outcome of an allowed call.
+func (b *Breaker) Record(failure bool) {
+	b.mu.Lock()
+	from := b.state
+	b.trial = false
+	switch {
+	case !failure:
+		b.failures = 0
+		b.state = StateClosed
+	case b.state == StateHalfOpen:
+		b.state = StateOpen
+		b.openedAt = b.now()
+	default:
+		b.failures++
+		if b.failures >= b.cfg.Threshold {
+			b.state = StateOpen
+			b.openedAt = b.now()
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+// Abort releases an allowed call without counting it, e.g. when the caller
+// gave up before the service answered.
+func (b *Breaker) Abort() {
+	b.mu.Lock()
+	b.trial = false
+	b.mu.Unlock()
+}
+
+func (b *Breaker) changed(from, to State) {
+	if from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,78 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	var changes []State
+	b := New(Config{
+		Threshold:     2,
+		Cooldown:      time.Minute,
+		OnStateChange: func(from, to State) { changes = append(changes, to) },
+	})
+	b.now = func() time.Time { return now }
+
+	call := func(failure bool) error {
+		if err := b.Allow(); err != nil {
+			return err
+		}
+		b.Record(failure)
+		return nil
+	}
+
+	// A success resets the failure count
+	call(true)
+	call(false)
+	call(true)
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("expected closed, got %s", got)
+	}
+
+	call(true)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("expected open after %d failures, got %s", 2, got)
+	}
+	if err := call(false); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+
+	// After the cooldown a single trial goes through; a failure reopens
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected the trial call to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected ErrOpen during the trial, got %v", err)
+	}
+	b.Record(true)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("expected open after a failed trial, got %s", got)
+	}
+
+	// An aborted trial doesn't count; the next successful one closes
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.Abort()
+	if err := call(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("expected closed after a successful trial, got %s", got)
+	}
+
+	want := []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("expected state changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("expected state changes %v, got %v", want, changes)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Package clientip gives the IP address a request came from, for rate
+// limits, bot checks and the client recorded with the request.
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type ctxKey struct{}
+
+// ParseProxies parses the trusted proxies, each an IP address or a CIDR
+// network such as 10.0.0.0/8. Empty values are skipped.
+func ParseProxies(values []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// Middleware works out the client's address of requests coming through
+// the trusted proxies, from their True-Client-IP, X-Real-IP or
+// X-Forwarded-For headers, for FromRequest. The headers of any other
+// caller are ignored, since they can claim to be anyone; without trusted
+// proxies it does nothing.
+func Middleware(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(trusted) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedFor(r, trusted); ip != "" {
+				r = r.WithContext(context.WithValue(r.Context(), ctxKey{}, ip))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FromRequest returns the request's IP address, without the port: the
+// client's address Middleware found behind a trusted proxy, or the address
+// the connection came from.
+func FromRequest(r *http.Request) string {
+	if ip, ok := r.Context().Value(ctxKey{}).(string); ok {
+		return ip
+	}
+	return socketIP(r)
+}
+
+func socketIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// forwardedFor returns the client's address the proxies in front of r
+// forwarded, or "" when r doesn't come from a trusted proxy.
+func forwardedFor(r *http.Request, trusted []netip.Prefix) string {
+	peer, ok := parseAddr(socketIP(r))
+	if !ok || !isTrusted(peer, trusted) {
+		return ""
+	}
+
+	for _, header := range []string{"True-Client-IP", "X-Real-IP"} {
+		if addr, ok := parseAddr(r.Header.Get(header)); ok {
+			return addr.String()
+		}
+	}
+
+	// Each proxy appends the address it got the request from, so the
+	// client is the last address that isn't one of the trusted proxies
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	client := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, ok := parseAddr(hops[i])
+		if !ok {
+			break
+		}
+		client = addr.String()
+		if !isTrusted(addr, trusted) {
+			break
+		}
+	}
+	return client
+}
+
+func parseAddr(s string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
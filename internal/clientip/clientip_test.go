@@ -0,0 +1,92 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{remoteAddr: "203.0.113.7:5123", want: "203.0.113.7"},
+		{remoteAddr: "[2001:db8::1]:443", want: "2001:db8::1"},
+		{remoteAddr: "203.0.113.7", want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if got := FromRequest(r); got != tt.want {
+				t.Fatalf("FromRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	trusted, err := ParseProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("ParseProxies() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		trusted    bool
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{name: "direct client", trusted: true, remoteAddr: "203.0.113.7:5123", want: "203.0.113.7"},
+		{name: "headers from an untrusted caller", trusted: true, remoteAddr: "203.0.113.7:5123", headers: map[string]string{"X-Forwarded-For": "198.51.100.9", "X-Real-IP": "198.51.100.9", "True-Client-IP": "198.51.100.9"}, want: "203.0.113.7"},
+		{name: "no trusted proxies configured", remoteAddr: "10.0.0.2:5123", headers: map[string]string{"X-Forwarded-For": "198.51.100.9"}, want: "10.0.0.2"},
+		{name: "forwarded by a trusted proxy", trusted: true, remoteAddr: "10.0.0.2:5123", headers: map[string]string{"X-Forwarded-For": "198.51.100.9"}, want: "198.51.100.9"},
+		{name: "spoofed hop before the client", trusted: true, remoteAddr: "10.0.0.2:5123", headers: map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.9, 10.0.0.3"}, want: "198.51.100.9"},
+		{name: "trusted proxy by address", trusted: true, remoteAddr: "192.0.2.1:5123", headers: map[string]string{"X-Forwarded-For": "198.51.100.9"}, want: "198.51.100.9"},
+		{name: "real IP header", trusted: true, remoteAddr: "10.0.0.2:5123", headers: map[string]string{"X-Real-IP": "198.51.100.9", "X-Forwarded-For": "1.2.3.4"}, want: "198.51.100.9"},
+		{name: "true client IP header", trusted: true, remoteAddr: "10.0.0.2:5123", headers: map[string]string{"True-Client-IP": "2001:db8::9"}, want: "2001:db8::9"},
+		{name: "invalid header", trusted: true, remoteAddr: "10.0.0.2:5123", headers: map[string]string{"X-Real-IP": "not-an-ip"}, want: "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxies := trusted
+			if !tt.trusted {
+				proxies = nil
+			}
+			var got string
+			h := Middleware(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = FromRequest(r)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Fatalf("FromRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProxies(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.0/8", " 192.0.2.1 ", "", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("ParseProxies() error = %v", err)
+	}
+	if len(proxies) != 3 {
+		t.Fatalf("ParseProxies() = %v, want 3 proxies", proxies)
+	}
+	if _, err := ParseProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Fatal("expected an error for an invalid network")
+	}
+	if _, err := ParseProxies([]string{"proxy.local"}); err == nil {
+		t.Fatal("expected an error for a host name")
+	}
+}
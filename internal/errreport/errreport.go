@@ -0,0 +1,262 @@
+// Package errreport reports errors to Sentry, or any service taking Sentry
+// DSNs such as GlitchTip. Reporter.Handler wraps the application's slog
+// handler and reports the errors logged at error level, and
+// Reporter.Middleware reports the panics and 5xx responses of HTTP requests,
+// with the request, its ID and the signed in user.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ErrorKey is the attribute holding the error of a log record.
+const ErrorKey = "error"
+
+// Config is where errors are reported and the build they come from.
+type Config struct {
+	// DSN of the Sentry project; reporting is off without one
+	DSN         string
+	Environment string
+	// App names the binary, such as "service" or "worker"
+	App         string
+	BuildCommit string
+	BuildTime   string
+	// Transport replaces the HTTP transport, for tests
+	Transport sentry.Transport
+}
+
+// Reporter sends errors to Sentry. The zero value, and a Reporter made
+// without a DSN, report nothing.
+type Reporter struct {
+	client *sentry.Client
+	tags   map[string]string
+}
+
+// New creates a Reporter with cfg. It reports nothing when cfg.DSN is
+// empty.
+func New(cfg Config) (*Reporter, error) {
+	if cfg.DSN == "" {
+		return &Reporter{}, nil
+	}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: cfg.Environment,
+		Release:     cfg.BuildCommit,
+		Transport:   cfg.Transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating sentry client: %w", err)
+	}
+	return &Reporter{
+		client: client,
+		tags: map[string]string{
+			"app":        cfg.App,
+			"build_time": cfg.BuildTime,
+		},
+	}, nil
+}
+
+// Enabled reports whether errors are sent anywhere.
+func (rep *Reporter) Enabled() bool {
+	return rep != nil && rep.client != nil
+}
+
+// Flush waits up to timeout for the errors reported to be sent, for when
+// the process exits.
+func (rep *Reporter) Flush(timeout time.Duration) {
+	if rep.Enabled() {
+		rep.client.Flush(timeout)
+	}
+}
+
+func (rep *Reporter) event(ctx context.Context, level sentry.Level, message string) *sentry.Event {
+	event := sentry.NewEvent()
+	event.Level = level
+	event.Message = message
+	for k, v := range rep.tags {
+		event.Tags[k] = v
+	}
+	if s := scopeFrom(ctx); s != nil {
+		s.apply(event)
+	}
+	return event
+}
+
+func (rep *Reporter) capture(ctx context.Context, event *sentry.Event) {
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		s.reported = true
+		s.mu.Unlock()
+	}
+	rep.client.CaptureEvent(event, nil, nil)
+}
+
+type scopeKey struct{}
+
+// scope is what an event is told about the request it comes from. The user
+// is only known once the request is authenticated, deeper down the
+// middleware chain.
+type scope struct {
+	mu       sync.Mutex
+	request  *http.Request
+	userID   string
+	reported bool
+}
+
+func scopeFrom(ctx context.Context) *scope {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(scopeKey{}).(*scope)
+	return s
+}
+
+func (s *scope) apply(event *sentry.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event.Request = sentry.NewRequest(s.request)
+	if id := middleware.GetReqID(s.request.Context()); id != "" {
+		event.Tags["request_id"] = id
+	}
+	if rctx := chi.RouteContext(s.request.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			event.Tags["route"] = pattern
+		}
+	}
+	if s.userID != "" {
+		event.User = sentry.User{ID: s.userID}
+	}
+}
+
+// SetUser records the user a request was authenticated as, for the errors
+// it reports. It does nothing outside Middleware.
+func SetUser(ctx context.Context, userID string) {
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		s.userID = userID
+		s.mu.Unlock()
+	}
+}
+
+// Middleware reports the panics of the requests it serves, which it then
+// passes on to the recoverer it runs under, and the 5xx responses whose
+// error wasn't reported while serving them.
+func (rep *Reporter) Middleware(next http.Handler) http.Handler {
+	if !rep.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := &scope{request: r}
+		ctx := context.WithValue(r.Context(), scopeKey{}, s)
+		s.request = r.WithContext(ctx)
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		defer func() {
+			if p := recover(); p != nil {
+				if p != http.ErrAbortHandler {
+					event := rep.event(ctx, sentry.LevelFatal, fmt.Sprint(p))
+					event.Exception = []sentry.Exception{{
+						Type:       "panic",
+						Value:      fmt.Sprint(p),
+						Stacktrace: sentry.NewStacktrace(),
+					}}
+					rep.capture(ctx, event)
+				}
+				panic(p)
+			}
+			s.mu.Lock()
+			reported := s.reported
+			s.mu.Unlock()
+			if status := ww.Status(); status >= http.StatusInternalServerError && !reported {
+				route := r.URL.Path
+				if pattern := chi.RouteContext(ctx).RoutePattern(); pattern != "" {
+					route = pattern
+				}
+				message := fmt.Sprintf("%s %s answered %d %s", r.Method, route, status, http.StatusText(status))
+				rep.capture(ctx, rep.event(ctx, sentry.LevelError, message))
+			}
+		}()
+
+		next.ServeHTTP(ww, s.request)
+	})
+}
+
+// Handler returns a slog.Handler that reports the records logged at error
+// level with an ErrorKey attribute, and then passes every record on to
+// next.
+func (rep *Reporter) Handler(next slog.Handler) slog.Handler {
+	if !rep.Enabled() {
+		return next
+	}
+	return &handler{rep: rep, next: next}
+}
+
+type handler struct {
+	rep   *Reporter
+	next  slog.Handler
+	attrs []slog.Attr
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelError {
+		h.report(ctx, r)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) report(ctx context.Context, r slog.Record) {
+	extra := make(map[string]any, len(h.attrs)+r.NumAttrs())
+	var cause string
+	add := func(a slog.Attr) {
+		if a.Key == ErrorKey {
+			cause = a.Value.String()
+			return
+		}
+		extra[a.Key] = a.Value.Resolve().Any()
+	}
+	for _, a := range h.attrs {
+		add(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		add(a)
+		return true
+	})
+	if cause == "" {
+		return
+	}
+
+	event := h.rep.event(ctx, sentry.LevelError, r.Message)
+	event.Exception = []sentry.Exception{{
+		Type:       r.Message,
+		Value:      cause,
+		Stacktrace: sentry.NewStacktrace(),
+	}}
+	for k, v := range extra {
+		event.Extra[k] = v
+	}
+	h.rep.capture(ctx, event)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	combined := make([]slog.Attr, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(combined, h.attrs)
+	combined = append(combined, attrs...)
+	return &handler{rep: h.rep, next: h.next.WithAttrs(attrs), attrs: combined}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{rep: h.rep, next: h.next.WithGroup(name), attrs: h.attrs}
+}
@@ -0,0 +1,157 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(time.Duration) bool                  { return true }
+func (t *transport) FlushWithContext(ctx context.Context) bool { return true }
+func (t *transport) Configure(sentry.ClientOptions)            {}
+func (t *transport) Close()                                    {}
+
+func (t *transport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *transport) sent() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.events
+}
+
+func newTestReporter(t *testing.T) (*Reporter, *transport) {
+	t.Helper()
+	tr := &transport{}
+	rep, err := New(Config{
+		DSN:         "https://key@sentry.example.com/1",
+		Environment: "test",
+		App:         "service",
+		BuildCommit: "abc123",
+		BuildTime:   "2026-10-16T00:00:00Z",
+		Transport:   tr,
+	})
+	require.NoError(t, err)
+	return rep, tr
+}
+
+func TestNew_WithoutDSN(t *testing.T) {
+	rep, err := New(Config{})
+	require.NoError(t, err)
+	assert.False(t, rep.Enabled())
+
+	next := slog.NewTextHandler(&bytes.Buffer{}, nil)
+	assert.Same(t, next, rep.Handler(next), "nothing is wrapped when reporting is off")
+}
+
+func TestReporter_Handler(t *testing.T) {
+	rep, tr := newTestReporter(t)
+	var out bytes.Buffer
+	log := slog.New(rep.Handler(slog.NewJSONHandler(&out, nil))).With(slog.String("job_id", "42"))
+
+	log.Warn("retrying", slog.String(ErrorKey, "timeout"))
+	log.Error("no error attribute")
+	log.Error("failed to send email", slog.Any(ErrorKey, errors.New("smtp down")))
+
+	assert.Contains(t, out.String(), "failed to send email", "records still reach the wrapped handler")
+	events := tr.sent()
+	require.Len(t, events, 1, "only errors with an error attribute are reported")
+	event := events[0]
+	assert.Equal(t, sentry.LevelError, event.Level)
+	assert.Equal(t, "abc123", event.Release)
+	assert.Equal(t, "test", event.Environment)
+	assert.Equal(t, "service", event.Tags["app"])
+	require.Len(t, event.Exception, 1)
+	assert.Equal(t, "failed to send email", event.Exception[0].Type)
+	assert.Equal(t, "smtp down", event.Exception[0].Value)
+	assert.Equal(t, "42", event.Extra["job_id"])
+}
+
+func TestReporter_Middleware(t *testing.T) {
+	rep, tr := newTestReporter(t)
+	log := slog.New(rep.Handler(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Recoverer)
+	r.Use(rep.Middleware)
+	r.Get("/fail/{id}", func(w http.ResponseWriter, r *http.Request) {
+		SetUser(r.Context(), "user-1")
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	r.Get("/logged", func(w http.ResponseWriter, r *http.Request) {
+		log.ErrorContext(r.Context(), "failed to list", slog.String(ErrorKey, "boom"))
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	})
+	r.Get("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	serve := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("5xx responses", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadGateway, serve("/fail/7"))
+		events := tr.sent()
+		require.Len(t, events, 1)
+		event := events[0]
+		assert.Equal(t, "GET /fail/{id} answered 502 Bad Gateway", event.Message)
+		assert.Equal(t, "user-1", event.User.ID)
+		assert.Equal(t, "/fail/{id}", event.Tags["route"])
+		assert.NotEmpty(t, event.Tags["request_id"])
+		require.NotNil(t, event.Request)
+		assert.NotContains(t, event.Request.Headers, "Authorization", "credentials aren't sent")
+	})
+
+	t.Run("errors logged while serving are reported once", func(t *testing.T) {
+		before := len(tr.sent())
+		serve("/logged")
+		events := tr.sent()[before:]
+		require.Len(t, events, 1)
+		assert.Equal(t, "boom", events[0].Exception[0].Value)
+		assert.Equal(t, "/logged", events[0].Tags["route"])
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		before := len(tr.sent())
+		assert.Equal(t, http.StatusInternalServerError, serve("/panic"), "the recoverer still answers")
+		events := tr.sent()[before:]
+		require.Len(t, events, 1)
+		assert.Equal(t, sentry.LevelFatal, events[0].Level)
+		assert.Equal(t, "nil map", events[0].Exception[0].Value)
+	})
+
+	t.Run("other responses", func(t *testing.T) {
+		before := len(tr.sent())
+		serve("/ok")
+		assert.Len(t, tr.sent()[before:], 0)
+	})
+}
@@ -0,0 +1,140 @@
+// Package export writes rows of text as a CSV or XLSX spreadsheet, for the
+// admin exports. CSV is written as rows come in, so it can be streamed;
+// XLSX is a zip archive, so it is only written out on Close.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is a spreadsheet file format.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// ParseFormat reads a format name, CSV when empty.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q", s)
+}
+
+// ContentType is the media type of files in the format.
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer writes a spreadsheet one row at a time.
+type Writer interface {
+	Write(row []string) error
+	// Flush sends the rows written so far on to the underlying writer, when
+	// the format allows it
+	Flush() error
+	// Close finishes the file. It doesn't close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer of the format on w.
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{csv: csv.NewWriter(w), w: w}, nil
+	case XLSX:
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter("Sheet1")
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to create xlsx sheet: %w", err)
+		}
+		return &xlsxWriter{file: f, sheet: sw, w: w}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvWriter struct {
+	csv *csv.Writer
+	w   io.Writer
+}
+
+func (c *csvWriter) Write(row []string) error {
+	escaped := make([]string, len(row))
+	for i, cell := range row {
+		escaped[i] = escapeFormula(cell)
+	}
+	return c.csv.Write(escaped)
+}
+
+// Flush also flushes the underlying writer when it can, such as an HTTP
+// response, so the rows reach the client.
+func (c *csvWriter) Flush() error {
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return err
+	}
+	if f, ok := c.w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+// escapeFormula keeps spreadsheet apps from running cells as formulas
+// (CSV injection) by starting those that would be read as one with a quote.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+type xlsxWriter struct {
+	file  *excelize.File
+	sheet *excelize.StreamWriter
+	w     io.Writer
+	rows  int
+}
+
+func (x *xlsxWriter) Write(row []string) error {
+	x.rows++
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return x.sheet.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Flush() error { return nil }
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to finish xlsx sheet: %w", err)
+	}
+	if _, err := x.file.WriteTo(x.w); err != nil {
+		return fmt.Errorf("failed to write xlsx file: %w", err)
+	}
+	return nil
+}
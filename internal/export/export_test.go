@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": CSV, "csv": CSV, "XLSX": XLSX} {
+		got, err := ParseFormat(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseFormat("pdf")
+	assert.Error(t, err)
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, CSV)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write([]string{"email", "name"}))
+	require.NoError(t, w.Write([]string{"jane@example.com", `Doe, "Jane"`}))
+	require.NoError(t, w.Write([]string{"=HYPERLINK(\"x\")", "-1"}))
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "email,name\n"+
+		"jane@example.com,\"Doe, \"\"Jane\"\"\"\n"+
+		"\"'=HYPERLINK(\"\"x\"\")\",'-1\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, XLSX)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write([]string{"email", "name"}))
+	require.NoError(t, w.Write([]string{"jane@example.com", "=1+1"}))
+	require.NoError(t, w.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"email", "name"}, {"jane@example.com", "=1+1"}}, rows)
+}
@@ -0,0 +1,204 @@
+// Package filter parses the filter expressions admin list endpoints take,
+// such as account_type:admin,created_at>2024-01-01, into typed predicates.
+//
+// An expression is a comma-separated list of terms, all of which must match.
+// A term is a field, an operator and a value: ":" (equals), "!:" (differs),
+// ">", ">=", "<", "<=" and "~" (contains, ignoring case). Values that hold
+// commas go in double quotes. Each resource declares the fields it can be
+// filtered by and their types (see entities.FilterFields); anything else is
+// rejected, so predicates only ever name known fields and carry values of the
+// right type, ready to be bound as query parameters.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"go-template/domain/entities"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// MaxLength is the longest expression Parse accepts
+	MaxLength = 1000
+	// MaxTerms is the most terms an expression can have
+	MaxTerms = 10
+)
+
+// ErrInvalid is returned, wrapped with the reason, for expressions that
+// can't be parsed or name fields, operators or values the fields don't take.
+var ErrInvalid = errors.New("invalid filter")
+
+// operators are tried in order, so the two-character ones come first.
+var operators = []entities.FilterOp{
+	entities.FilterGte,
+	entities.FilterLte,
+	entities.FilterNe,
+	entities.FilterEq,
+	entities.FilterGt,
+	entities.FilterLt,
+	entities.FilterContains,
+}
+
+// opsByType lists the operators each field type takes.
+var opsByType = map[entities.FilterType][]entities.FilterOp{
+	entities.FilterString: {entities.FilterEq, entities.FilterNe, entities.FilterContains},
+	entities.FilterEnum:   {entities.FilterEq, entities.FilterNe},
+	entities.FilterTime:   {entities.FilterGt, entities.FilterGte, entities.FilterLt, entities.FilterLte},
+	entities.FilterBool:   {entities.FilterEq, entities.FilterNe},
+	entities.FilterUUID:   {entities.FilterEq, entities.FilterNe},
+}
+
+// Parse parses expr against fields. An empty expression is an empty filter,
+// which matches everything.
+func Parse(expr string, fields entities.FilterFields) (entities.Filter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+	if len(expr) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalid, MaxLength)
+	}
+
+	terms, err := split(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) > MaxTerms {
+		return nil, fmt.Errorf("%w: more than %d terms", ErrInvalid, MaxTerms)
+	}
+
+	f := make(entities.Filter, 0, len(terms))
+	for _, term := range terms {
+		p, err := parseTerm(term, fields)
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, p)
+	}
+	return f, nil
+}
+
+// split cuts expr at the commas outside double quotes.
+func split(expr string) ([]string, error) {
+	var terms []string
+	var quoted bool
+	start := 0
+	for i, r := range expr {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			terms = append(terms, expr[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalid)
+	}
+	return append(terms, expr[start:]), nil
+}
+
+func parseTerm(term string, fields entities.FilterFields) (entities.FilterPredicate, error) {
+	term = strings.TrimSpace(term)
+	end := strings.IndexFunc(term, func(r rune) bool {
+		return (r < 'a' || r > 'z') && r != '_'
+	})
+	if end <= 0 {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: %q doesn't start with a field", ErrInvalid, term)
+	}
+	name, rest := term[:end], term[end:]
+
+	field, ok := fields[name]
+	if !ok {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: unknown field %q", ErrInvalid, name)
+	}
+
+	p := entities.FilterPredicate{Field: name}
+	for _, op := range operators {
+		if strings.HasPrefix(rest, string(op)) {
+			p.Op, rest = op, rest[len(op):]
+			break
+		}
+	}
+	if p.Op == "" {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: %q has no operator", ErrInvalid, term)
+	}
+	if !slices.Contains(opsByType[field.Type], p.Op) {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: %s doesn't take %s", ErrInvalid, name, p.Op)
+	}
+
+	raw := strings.TrimSpace(rest)
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		raw = raw[1 : len(raw)-1]
+	} else if strings.Contains(raw, `"`) {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: misplaced quote in %q", ErrInvalid, term)
+	}
+	if raw == "" {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: %s has no value", ErrInvalid, name)
+	}
+
+	value, err := parseValue(raw, field)
+	if err != nil {
+		return entities.FilterPredicate{}, fmt.Errorf("%w: %s: %s", ErrInvalid, name, err)
+	}
+	p.Value = value
+	return p, nil
+}
+
+func parseValue(raw string, field entities.FilterField) (any, error) {
+	switch field.Type {
+	case entities.FilterEnum:
+		if !slices.Contains(field.Values, raw) {
+			return nil, fmt.Errorf("must be one of %s", strings.Join(field.Values, ", "))
+		}
+		return raw, nil
+	case entities.FilterTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, raw); err == nil {
+			return t, nil
+		}
+		return nil, errors.New("must be an RFC 3339 time or a date")
+	case entities.FilterBool:
+		switch raw {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, errors.New("must be true or false")
+	case entities.FilterUUID:
+		id, err := uuid.FromString(raw)
+		if err != nil {
+			return nil, errors.New("must be a UUID")
+		}
+		return id, nil
+	default:
+		return raw, nil
+	}
+}
+
+// Format writes f back as an expression Parse reads, for passing a parsed
+// filter on to another service.
+func Format(f entities.Filter) string {
+	terms := make([]string, len(f))
+	for i, p := range f {
+		var value string
+		switch v := p.Value.(type) {
+		case time.Time:
+			value = v.Format(time.RFC3339)
+		default:
+			value = fmt.Sprint(v)
+		}
+		if strings.ContainsAny(value, ", ") {
+			value = `"` + value + `"`
+		}
+		terms[i] = p.Field + string(p.Op) + value
+	}
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"go-template/domain/entities"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFields = entities.FilterFields{
+	"email":          {Type: entities.FilterString},
+	"account_type":   {Type: entities.FilterEnum, Values: []string{"user", "admin"}},
+	"created_at":     {Type: entities.FilterTime},
+	"email_verified": {Type: entities.FilterBool},
+	"actor_id":       {Type: entities.FilterUUID},
+}
+
+func TestParse(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+
+	f, err := Parse(`account_type:admin, created_at>=2024-01-01,email~"doe, jane",email_verified!:false,actor_id:`+id.String()+`,created_at<2024-06-01T12:00:00Z`, testFields)
+	require.NoError(t, err)
+	assert.Equal(t, entities.Filter{
+		{Field: "account_type", Op: entities.FilterEq, Value: "admin"},
+		{Field: "created_at", Op: entities.FilterGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Field: "email", Op: entities.FilterContains, Value: "doe, jane"},
+		{Field: "email_verified", Op: entities.FilterNe, Value: false},
+		{Field: "actor_id", Op: entities.FilterEq, Value: id},
+		{Field: "created_at", Op: entities.FilterLt, Value: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+	}, f)
+
+	f, err = Parse("  ", testFields)
+	require.NoError(t, err)
+	assert.Empty(t, f)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "unknown field", expr: "password:x"},
+		{name: "no field", expr: ":admin"},
+		{name: "no operator", expr: "email"},
+		{name: "no value", expr: "email:"},
+		{name: "operator the type doesn't take", expr: "created_at:2024-01-01"},
+		{name: "contains on an enum", expr: "account_type~adm"},
+		{name: "unknown enum value", expr: "account_type:root"},
+		{name: "bad time", expr: "created_at>yesterday"},
+		{name: "bad bool", expr: "email_verified:yes"},
+		{name: "bad uuid", expr: "actor_id:42"},
+		{name: "unterminated quote", expr: `email:"doe`},
+		{name: "misplaced quote", expr: `email:do"e`},
+		{name: "empty term", expr: "email:doe,,account_type:user"},
+		{name: "too many terms", expr: strings.Repeat("email:a,", MaxTerms) + "email:a"},
+		{name: "too long", expr: "email:" + strings.Repeat("a", MaxLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr, testFields)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	expr := `account_type:admin,created_at>=2024-01-01T00:00:00Z,email~"doe, jane",email_verified!:false`
+	f, err := Parse(expr, testFields)
+	require.NoError(t, err)
+	assert.Equal(t, expr, Format(f))
+
+	again, err := Parse(Format(f), testFields)
+	require.NoError(t, err)
+	assert.Equal(t, f, again)
+}
@@ -0,0 +1,149 @@
+// Package health answers the liveness and readiness probes of orchestrators
+// such as Kubernetes. Live only says the process serves requests. Ready runs
+// the checks of the dependencies, concurrently and each under a timeout, and
+// reports their status and latency. Why a check failed is only logged, as
+// the probes are served without authentication.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds each check when the Checker is given none.
+const DefaultTimeout = 2 * time.Second
+
+// Statuses of a check and of the service.
+const (
+	StatusOK = "ok"
+	// StatusDegraded is the service's status when only optional
+	// dependencies failed. It still takes requests.
+	StatusDegraded = "degraded"
+	// StatusUnavailable is the status of a failed check, and of the
+	// service when a required dependency failed.
+	StatusUnavailable = "unavailable"
+)
+
+// Check reports whether a dependency can be used, with an error saying why
+// not. It is given a context with the check's timeout.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a dependency's check.
+type Result struct {
+	Status string `json:"status"`
+	// Required dependencies make the service unavailable when they fail
+	Required  bool    `json:"required"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// Report is the outcome of all the checks, keyed by dependency.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type namedCheck struct {
+	name     string
+	required bool
+	check    Check
+}
+
+// Checker runs the checks of the dependencies.
+type Checker struct {
+	timeout time.Duration
+	checks  []namedCheck
+	logger  *slog.Logger
+}
+
+// New creates a Checker giving each check up to timeout.
+func New(timeout time.Duration, logger *slog.Logger) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, logger: logger}
+}
+
+// Add checks the dependency called name. When a required dependency fails
+// the service is not ready; other dependencies only degrade it, as taking
+// every instance out of the load balancer doesn't help when a service they
+// share is down.
+func (c *Checker) Add(name string, required bool, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, required: required, check: check})
+}
+
+// Check runs all the checks concurrently and returns once they are done or
+// timed out.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := c.run(ctx, nc)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = result
+			if result.Status == StatusOK {
+				return
+			}
+			if nc.required {
+				report.Status = StatusUnavailable
+			} else if report.Status == StatusOK {
+				report.Status = StatusDegraded
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, nc namedCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := nc.check(ctx)
+	result := Result{
+		Status:    StatusOK,
+		Required:  nc.required,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		c.logger.WarnContext(ctx, "health check failed", "dependency", nc.name, "required", nc.required, "error", err)
+	}
+	return result
+}
+
+// Ready answers 200 with the Report while the required dependencies are
+// up, and 503 otherwise.
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+	status := http.StatusOK
+	if report.Status == StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+// Live answers 200 as long as the process serves requests. It checks no
+// dependency, so an outage elsewhere doesn't get the process restarted.
+func Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	// Probes must not be answered from a cache
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(ctx context.Context) error { return nil }
+
+func failing(ctx context.Context) error { return errors.New("connection refused") }
+
+func hanging(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(c *Checker)
+		wantCode   int
+		wantStatus string
+	}{
+		{
+			name: "all up",
+			setup: func(c *Checker) {
+				c.Add("postgres", true, ok)
+				c.Add("redis", false, ok)
+			},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusOK,
+		},
+		{
+			name: "optional dependency down",
+			setup: func(c *Checker) {
+				c.Add("postgres", true, ok)
+				c.Add("redis", false, failing)
+			},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusDegraded,
+		},
+		{
+			name: "required dependency timed out",
+			setup: func(c *Checker) {
+				c.Add("postgres", true, hanging)
+				c.Add("redis", false, failing)
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(50*time.Millisecond, slog.New(slog.DiscardHandler))
+			tt.setup(c)
+
+			w := httptest.NewRecorder()
+			c.Ready(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			var report Report
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.wantStatus, report.Status)
+			require.Len(t, report.Checks, 2)
+			assert.True(t, report.Checks["postgres"].Required)
+			assert.False(t, report.Checks["redis"].Required)
+		})
+	}
+}
+
+func TestChecker_RunsChecksConcurrently(t *testing.T) {
+	c := New(100*time.Millisecond, slog.New(slog.DiscardHandler))
+	c.Add("a", true, hanging)
+	c.Add("b", true, hanging)
+	c.Add("c", true, hanging)
+
+	start := time.Now()
+	report := c.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+	for name, result := range report.Checks {
+		assert.Equal(t, StatusUnavailable, result.Status, name)
+		assert.GreaterOrEqual(t, result.LatencyMS, float64(100), name)
+	}
+}
+
+func TestLive(t *testing.T) {
+	w := httptest.NewRecorder()
+	Live(w, httptest.NewRequest(http.MethodGet, "/live", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
@@ -0,0 +1,130 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is an asymmetric key the service signs access tokens with: RSA keys
+// sign RS256, Ed25519 keys sign EdDSA. Other services verify the tokens
+// offline with the public part, published in the JWKS.
+type Key struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+// ParseKey parses a PEM encoded RSA (PKCS#1 or PKCS#8) or Ed25519 (PKCS#8)
+// private key.
+func ParseKey(pemData []byte) (*Key, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM block found in signing key")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS#1 key: %w", err)
+		}
+		return newKey(k)
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS#8 key: %w", err)
+		}
+		signer, ok := k.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported signing key type")
+		}
+		return newKey(signer)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// GenerateEd25519Key creates an ephemeral Ed25519 key. Tokens signed with it
+// do not survive a restart, so it is only meant for development and tests.
+func GenerateEd25519Key() (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating Ed25519 key: %w", err)
+	}
+	return newKey(private)
+}
+
+func newKey(private crypto.Signer) (*Key, error) {
+	var method jwt.SigningMethod
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("RSA signing keys must be at least 2048 bits")
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("signing key is neither an RSA nor an Ed25519 key")
+	}
+
+	// The kid is derived from the public key so it is stable across restarts
+	// and the same on every instance
+	der, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("encoding public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+
+	return &Key{
+		kid:     base64.RawURLEncoding.EncodeToString(sum[:8]),
+		method:  method,
+		private: private,
+	}, nil
+}
+
+// KeyID returns the kid placed in token headers.
+func (k *Key) KeyID() string {
+	return k.kid
+}
+
+// Algorithm returns the JWS alg the key signs with.
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// JWK returns the public part of the key.
+func (k *Key) JWK() JWK {
+	switch pub := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Kid: k.kid,
+			Alg: k.method.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Use: "sig",
+			Kid: k.kid,
+			Alg: k.method.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}
+	}
+	return JWK{}
+}
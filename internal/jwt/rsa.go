@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWK is the public part of a signing key in JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 keys (kty OKP)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set as served on /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// RSAKey signs and verifies RS256 tokens that third parties validate offline
+// through the published JWKS.
+type RSAKey struct {
+	kid        string
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSAKey parses a PEM encoded RSA private key (PKCS#1 or PKCS#8).
+func NewRSAKey(pemData []byte) (*RSAKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM block found in signing key")
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS#1 key: %w", err)
+		}
+		key = k
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS#8 key: %w", err)
+		}
+		rsaKey, ok := k.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing key is not an RSA key")
+		}
+		key = rsaKey
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	return newRSAKey(key), nil
+}
+
+// GenerateRSAKey creates an ephemeral 2048-bit key. Tokens signed with it do
+// not survive a restart, so it is only meant for development.
+func GenerateRSAKey() (*RSAKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("generating RSA key: %w", err)
+	}
+	return newRSAKey(key), nil
+}
+
+func newRSAKey(key *rsa.PrivateKey) *RSAKey {
+	// The kid is derived from the public key so it is stable across restarts
+	der := x509.MarshalPKCS1PublicKey(&key.PublicKey)
+	sum := sha256.Sum256(der)
+
+	return &RSAKey{
+		kid:        base64.RawURLEncoding.EncodeToString(sum[:8]),
+		privateKey: key,
+	}
+}
+
+// KeyID returns the kid placed in token headers.
+func (k *RSAKey) KeyID() string {
+	return k.kid
+}
+
+// Sign returns claims as a compact RS256 JWT.
+func (k *RSAKey) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.kid
+	return token.SignedString(k.privateKey)
+}
+
+// Parse verifies tokenString and decodes it into claims.
+func (k *RSAKey) Parse(tokenString string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return &k.privateKey.PublicKey, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
+
+// JWKS returns the public key set used to verify tokens signed by k.
+func (k *RSAKey) JWKS() JWKS {
+	pub := k.privateKey.PublicKey
+	return JWKS{
+		Keys: []JWK{{
+			Kty: "RSA",
+			Use: "sig",
+			Kid: k.kid,
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	}
+}
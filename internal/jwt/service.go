@@ -2,23 +2,117 @@ package jwt
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Audiences of the applications that consume tokens issued by the service
+const (
+	AudienceAPI        = "api"
+	AudienceWeb        = "web"
+	AudienceAdmin      = "admin"
+	AudienceThirdParty = "third-party"
+	// AudienceMFA marks the short-lived token handed out while a two-factor
+	// challenge is pending. It is never accepted as an access token.
+	AudienceMFA = "mfa"
+)
+
+// Authentication methods recorded in the amr claim (RFC 8176)
+const (
+	AMROneTimePassword = "otp"
+	AMRMultiFactor     = "mfa"
+)
+
 type Claims struct {
 	UserID      string `json:"user_id"`
 	Email       string `json:"email"`
 	AccountType string `json:"account_type"`
+	// AMR lists the authentication methods used to sign in
+	AMR []string `json:"amr,omitempty"`
+	// SessionID identifies the login the token was issued for. It stays
+	// the same across refreshes, unlike the jti.
+	SessionID string `json:"sid,omitempty"`
+	// Roles, Permissions and OrgID are added by claims enrichers when the
+	// token is issued. They describe the user at that time, for services
+	// that only see the token.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	OrgID       string   `json:"org_id,omitempty"`
+	// Custom holds application specific claims, keyed by name
+	Custom map[string]any `json:"custom,omitempty"`
+	// Impersonator is set when an admin acts as the user, in the act claim
+	// of RFC 8693
+	Impersonator *Actor `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Actor identifies who is acting on behalf of the token's subject.
+type Actor struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email,omitempty"`
+}
+
+// HasAudience reports whether the token was issued for audience.
+func (c *Claims) HasAudience(audience string) bool {
+	return slices.Contains(c.Audience, audience)
+}
+
+// Session returns the ID of the login the token belongs to. Tokens issued
+// outside a login, such as break-glass sessions, are a session of their own.
+func (c *Claims) Session() string {
+	if c.SessionID != "" {
+		return c.SessionID
+	}
+	return c.ID
+}
+
+// Impersonated reports whether the token was issued to an admin acting as
+// the user.
+func (c *Claims) Impersonated() bool {
+	return c.Impersonator != nil
+}
+
+// HasRole reports whether the token was issued with role.
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// HasPermission reports whether the token was issued with permission.
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// SetCustom adds an application specific claim.
+func (c *Claims) SetCustom(name string, value any) {
+	if c.Custom == nil {
+		c.Custom = map[string]any{}
+	}
+	c.Custom[name] = value
+}
+
+// MFA reports whether the token was issued after a second factor was
+// verified.
+func (c *Claims) MFA() bool {
+	return slices.Contains(c.AMR, AMRMultiFactor)
+}
+
+// Service issues and validates access tokens. By default they are signed
+// with HS256 and the shared secret. With signing keys, tokens are signed with
+// the first key and validated against all of them by kid, which lets a key be
+// rotated out without invalidating the tokens it already signed.
 type Service struct {
 	secretKey []byte
+	keys      []*Key
 	issuer    string
 	expiry    time.Duration
+	audience  string
+	amr       []string
+	// accepted lists the audiences ValidateToken accepts; empty accepts any
+	accepted []string
+	leeway   time.Duration
 }
 
 func NewService(secretKey, issuer string, expiry string) Service {
@@ -33,13 +127,77 @@ func NewService(secretKey, issuer string, expiry string) Service {
 	}
 }
 
+// WithSigningKeys returns a copy of the service that signs tokens with the
+// first key and accepts tokens signed with any of them. HS256 tokens are no
+// longer accepted, since the secret may be known to other applications.
+func (s Service) WithSigningKeys(keys ...*Key) Service {
+	s.keys = keys
+	return s
+}
+
+// JWKS returns the public keys tokens are verified with. It is empty when
+// tokens are signed with the shared secret.
+func (s Service) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, k := range s.keys {
+		set.Keys = append(set.Keys, k.JWK())
+	}
+	return set
+}
+
+// WithAcceptedAudiences returns a copy of the service that only validates
+// tokens issued for one of audiences. Two-factor challenge tokens are always
+// accepted; the auth middleware keeps them off every route but the challenge.
+func (s Service) WithAcceptedAudiences(audiences ...string) Service {
+	s.accepted = audiences
+	return s
+}
+
+// WithLeeway returns a copy of the service that tolerates clocks that are off
+// by up to leeway when checking exp, nbf and iat.
+func (s Service) WithLeeway(leeway time.Duration) Service {
+	s.leeway = leeway
+	return s
+}
+
+// WithAudience returns a copy of the service that issues tokens for audience.
+func (s Service) WithAudience(audience string) Service {
+	s.audience = audience
+	return s
+}
+
+// WithAMR returns a copy of the service that records the authentication
+// methods in the tokens it issues.
+func (s Service) WithAMR(methods ...string) Service {
+	s.amr = methods
+	return s
+}
+
+// Expiry returns how long the tokens issued by GenerateToken last.
+func (s Service) Expiry() time.Duration {
+	return s.expiry
+}
+
 func (s Service) GenerateToken(userID, email, accountType string) (string, error) {
+	return s.GenerateTokenWithTTL(userID, email, accountType, s.expiry)
+}
+
+// GenerateTokenWithTTL issues a token that expires after ttl instead of the
+// service default.
+func (s Service) GenerateTokenWithTTL(userID, email, accountType string, ttl time.Duration) (string, error) {
+	return s.Sign(s.NewClaims(userID, email, accountType, ttl))
+}
+
+// NewClaims returns the claims GenerateTokenWithTTL would sign, for callers
+// that add claims of their own before calling Sign.
+func (s Service) NewClaims(userID, email, accountType string, ttl time.Duration) *Claims {
 	claims := &Claims{
 		UserID:      userID,
 		Email:       email,
 		AccountType: accountType,
+		AMR:         s.amr,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    s.issuer,
@@ -48,17 +206,34 @@ func (s Service) GenerateToken(userID, email, accountType string) (string, error
 		},
 	}
 
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return claims
+}
+
+// Sign returns claims as a signed token.
+func (s Service) Sign(claims *Claims) (string, error) {
+	if len(s.keys) > 0 {
+		key := s.keys[0]
+		token := jwt.NewWithClaims(key.method, claims)
+		token.Header["kid"] = key.kid
+		return token.SignedString(key.private)
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(s.secretKey)
 }
 
+// ValidateToken checks the signature, the expiry and the issuer of a token,
+// and its audience when accepted audiences are set.
 func (s Service) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.verificationKey,
+		jwt.WithIssuer(s.issuer),
+		jwt.WithLeeway(s.leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -73,20 +248,45 @@ func (s Service) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	if !s.audienceAccepted(claims) {
+		return nil, fmt.Errorf("token audience %v not accepted", []string(claims.Audience))
+	}
+
 	return claims, nil
 }
 
-func (s Service) RefreshToken(tokenString string) (string, error) {
-	claims, err := s.ValidateToken(tokenString)
-	if err != nil {
-		return "", fmt.Errorf("invalid token for refresh: %w", err)
+func (s Service) audienceAccepted(claims *Claims) bool {
+	if len(s.accepted) == 0 || claims.HasAudience(AudienceMFA) {
+		return true
+	}
+	for _, audience := range s.accepted {
+		if claims.HasAudience(audience) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Check if token is close to expiration (within 5 minutes)
-	if time.Until(claims.ExpiresAt.Time) > 5*time.Minute {
-		return tokenString, nil // Token is still fresh
+// verificationKey picks the key a token is checked against: the signing key
+// named by its kid, or the secret when the service has no signing keys. The
+// key must match the token's algorithm so one can't pass for the other.
+func (s Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	if len(s.keys) == 0 {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secretKey, nil
 	}
 
-	// Generate new token
-	return s.GenerateToken(claims.UserID, claims.Email, claims.AccountType)
+	kid, _ := token.Header["kid"].(string)
+	for _, k := range s.keys {
+		if k.kid != kid {
+			continue
+		}
+		if token.Method.Alg() != k.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return k.private.Public(), nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
 }
@@ -0,0 +1,115 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+func TestService_SigningKeyRotation(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+	oldKey, err := ParseKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	if err != nil {
+		t.Fatalf("parsing RSA key: %v", err)
+	}
+	newKey, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("generating Ed25519 key: %v", err)
+	}
+
+	base := NewService("secret", "test", "1h")
+	before := base.WithSigningKeys(oldKey)
+	after := base.WithSigningKeys(newKey, oldKey)
+
+	inFlight, err := before.GenerateToken("user-1", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+	claims, err := after.ValidateToken(inFlight)
+	if err != nil {
+		t.Fatalf("expected a token signed with the previous key to validate, got %v", err)
+	}
+	if claims.UserID != "user-1" {
+		t.Fatalf("expected user-1, got %q", claims.UserID)
+	}
+
+	rotated, err := after.GenerateToken("user-2", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+	if _, err := after.ValidateToken(rotated); err != nil {
+		t.Fatalf("expected an EdDSA token to validate, got %v", err)
+	}
+	if _, err := before.ValidateToken(rotated); err == nil {
+		t.Fatal("expected a token signed with an unknown key to be rejected")
+	}
+
+	// Tokens signed with the shared secret stop validating once keys are set
+	legacy, err := base.GenerateToken("user-3", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+	if _, err := after.ValidateToken(legacy); err == nil {
+		t.Fatal("expected an HS256 token to be rejected")
+	}
+
+	jwks := after.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("expected 2 keys in the JWKS, got %d", len(jwks.Keys))
+	}
+	if k := jwks.Keys[0]; k.Kty != "OKP" || k.Crv != "Ed25519" || k.Alg != "EdDSA" || k.Kid != newKey.KeyID() {
+		t.Fatalf("unexpected Ed25519 JWK: %+v", k)
+	}
+	if k := jwks.Keys[1]; k.Kty != "RSA" || k.Alg != "RS256" || k.Kid != oldKey.KeyID() {
+		t.Fatalf("unexpected RSA JWK: %+v", k)
+	}
+}
+
+func TestService_ValidateToken_IssuerAndAudience(t *testing.T) {
+	svc := NewService("secret", "issuer-a", "1h").WithAcceptedAudiences(AudienceAPI, AudienceWeb)
+
+	tests := []struct {
+		name    string
+		issuer  Service
+		wantErr bool
+	}{
+		{"accepted audience", svc.WithAudience(AudienceWeb), false},
+		{"other audience", svc.WithAudience(AudienceAdmin), true},
+		{"no audience", svc, true},
+		{"challenge token", svc.WithAudience(AudienceMFA), false},
+		{"other issuer", NewService("secret", "issuer-b", "1h").WithAudience(AudienceAPI), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.issuer.GenerateToken("user-1", "user@example.com", "user")
+			if err != nil {
+				t.Fatalf("generating token: %v", err)
+			}
+			_, err = svc.ValidateToken(token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestService_ValidateToken_Leeway(t *testing.T) {
+	svc := NewService("secret", "test", "1h")
+	expired, err := svc.GenerateTokenWithTTL("user-1", "user@example.com", "user", -10*time.Second)
+	if err != nil {
+		t.Fatalf("generating token: %v", err)
+	}
+
+	if _, err := svc.ValidateToken(expired); err == nil {
+		t.Fatal("expected an expired token to be rejected")
+	}
+	if _, err := svc.WithLeeway(time.Minute).ValidateToken(expired); err != nil {
+		t.Fatalf("expected the token to pass within the leeway, got %v", err)
+	}
+}
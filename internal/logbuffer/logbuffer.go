@@ -0,0 +1,208 @@
+// Package logbuffer keeps the most recent structured log entries in memory so
+// admins can tail them without shell access to the servers. Buffer.Handler
+// wraps the application's slog handler and copies every record it handles
+// into a fixed size ring.
+package logbuffer
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"log/slog"
+	"sync"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDKey is the attribute that links an entry to an HTTP request. When
+// it's missing the request ID is read from the context given to the logger.
+const RequestIDKey = "request_id"
+
+const DefaultSize = 1000
+
+// Buffer is a fixed size ring of log entries, safe for concurrent use.
+type Buffer struct {
+	mu      sync.RWMutex
+	entries []entities.LogEntry
+	next    int
+	full    bool
+	lastID  uint64
+}
+
+// New creates a Buffer holding the last size entries.
+func New(size int) *Buffer {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Buffer{entries: make([]entities.LogEntry, size)}
+}
+
+// Handler returns a slog.Handler that records entries in the buffer and then
+// passes them on to next.
+func (b *Buffer) Handler(next slog.Handler) slog.Handler {
+	return &handler{buf: b, next: next}
+}
+
+// Logs returns the newest entries matching filter, oldest first, along with
+// the ID of the last entry recorded so callers can poll for newer ones.
+func (b *Buffer) Logs(ctx context.Context, filter entities.LogFilter) (entities.LogListResponse, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	start, count := 0, b.next
+	if b.full {
+		start, count = b.next, len(b.entries)
+	}
+
+	matched := make([]entities.LogEntry, 0)
+	for i := 0; i < count; i++ {
+		entry := b.entries[(start+i)%len(b.entries)]
+		if entry.ID <= filter.After {
+			continue
+		}
+		if filter.RequestID != "" && entry.RequestID != filter.RequestID {
+			continue
+		}
+		if filter.Level != "" && levelOf(entry.Level) < levelOf(filter.Level) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[len(matched)-filter.Limit:]
+	}
+
+	return entities.LogListResponse{
+		Entries: matched,
+		LastID:  b.lastID,
+	}, nil
+}
+
+func (b *Buffer) add(entry entities.LogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	entry.ID = b.lastID
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// ParseLevel reports whether level is a valid slog level name such as
+// "debug", "info", "warn" or "error".
+func ParseLevel(level string) (slog.Level, bool) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, false
+	}
+	return l, true
+}
+
+func levelOf(level string) slog.Level {
+	l, _ := ParseLevel(level)
+	return l
+}
+
+type handler struct {
+	buf   *Buffer
+	next  slog.Handler
+	attrs []slog.Attr
+	group string
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	entry := entities.LogEntry{
+		Time:    r.Time,
+		Level:   r.Level.String(),
+		Message: r.Message,
+		Attrs:   make(map[string]any, len(h.attrs)+r.NumAttrs()),
+	}
+
+	for _, a := range h.attrs {
+		addAttr(entry.Attrs, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(entry.Attrs, h.group, a)
+		return true
+	})
+
+	if id, ok := entry.Attrs[RequestIDKey].(string); ok {
+		entry.RequestID = id
+		delete(entry.Attrs, RequestIDKey)
+	} else {
+		entry.RequestID = middleware.GetReqID(ctx)
+	}
+	if len(entry.Attrs) == 0 {
+		entry.Attrs = nil
+	}
+
+	h.buf.add(entry)
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	grouped := make([]slog.Attr, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(grouped, h.attrs)
+	for _, a := range attrs {
+		grouped = append(grouped, slog.Attr{Key: h.group + a.Key, Value: a.Value})
+	}
+
+	return &handler{
+		buf:   h.buf,
+		next:  h.next.WithAttrs(attrs),
+		attrs: grouped,
+		group: h.group,
+	}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{
+		buf:   h.buf,
+		next:  h.next.WithGroup(name),
+		attrs: h.attrs,
+		group: h.group + name + ".",
+	}
+}
+
+// addAttr flattens groups into dotted keys and turns values into something
+// that encodes cleanly as JSON.
+func addAttr(attrs map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addAttr(attrs, groupPrefix, ga)
+		}
+		return
+	}
+
+	switch v := a.Value.Any().(type) {
+	case error:
+		attrs[prefix+a.Key] = v.Error()
+	case slog.Level:
+		attrs[prefix+a.Key] = v.String()
+	default:
+		if a.Value.Kind() == slog.KindDuration {
+			attrs[prefix+a.Key] = a.Value.Duration().String()
+			return
+		}
+		attrs[prefix+a.Key] = v
+	}
+}
@@ -0,0 +1,103 @@
+package logbuffer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-template/domain/entities"
+	"log/slog"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(size int) (*slog.Logger, *Buffer, *bytes.Buffer) {
+	var out bytes.Buffer
+	buf := New(size)
+	next := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(buf.Handler(next)), buf, &out
+}
+
+func TestBuffer_Handler(t *testing.T) {
+	log, buf, out := newTestLogger(10)
+	log = log.With(slog.String("app", "service")).WithGroup("http")
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	log.ErrorContext(ctx, "request failed",
+		slog.Int("status", 500),
+		slog.Any("error", errors.New("boom")),
+	)
+
+	assert.Contains(t, out.String(), "request failed", "records still reach the wrapped handler")
+
+	logs, err := buf.Logs(context.Background(), entities.LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, logs.Entries, 1)
+
+	entry := logs.Entries[0]
+	assert.Equal(t, uint64(1), entry.ID)
+	assert.Equal(t, "ERROR", entry.Level)
+	assert.Equal(t, "request failed", entry.Message)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, map[string]any{
+		"app":         "service",
+		"http.status": int64(500),
+		"http.error":  "boom",
+	}, entry.Attrs)
+}
+
+func TestBuffer_Logs(t *testing.T) {
+	log, buf, _ := newTestLogger(3)
+
+	log.Debug("one")
+	log.Info("two", slog.String(RequestIDKey, "req-1"))
+	log.Warn("three", slog.String(RequestIDKey, "req-2"))
+	log.Error("four", slog.String(RequestIDKey, "req-1"))
+
+	tests := []struct {
+		name   string
+		filter entities.LogFilter
+		want   []string
+	}{
+		{
+			name: "keeps only the newest entries",
+			want: []string{"two", "three", "four"},
+		},
+		{
+			name:   "minimum level",
+			filter: entities.LogFilter{Level: "warn"},
+			want:   []string{"three", "four"},
+		},
+		{
+			name:   "request id",
+			filter: entities.LogFilter{RequestID: "req-1"},
+			want:   []string{"two", "four"},
+		},
+		{
+			name:   "after cursor",
+			filter: entities.LogFilter{After: 3},
+			want:   []string{"four"},
+		},
+		{
+			name:   "limit keeps the newest",
+			filter: entities.LogFilter{Limit: 1},
+			want:   []string{"four"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := buf.Logs(context.Background(), tt.filter)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(logs.Entries))
+			for _, e := range logs.Entries {
+				got = append(got, e.Message)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, uint64(4), logs.LastID)
+		})
+	}
+}
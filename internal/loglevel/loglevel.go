@@ -0,0 +1,114 @@
+// Package loglevel lets the level of the logs be changed without a
+// restart. NewLogger builds the same logger as the gox logger package, with
+// its level held in a slog.LevelVar that the admin API sets, and Watch
+// toggles debug logs on SIGHUP.
+package loglevel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ardanlabs/conf/v3"
+	"github.com/guilhermebr/gox/logger"
+)
+
+// NewLogger creates the logger configured by the LOGGING_* variables under
+// prefix, and the level it logs at, which starts at LOGGING_LEVEL.
+func NewLogger(prefix string) (*slog.Logger, *slog.LevelVar, error) {
+	var cfg logger.Config
+	if _, err := conf.Parse(prefix, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("parsing logger config from prefix [%s]: %w", prefix, err)
+	}
+
+	level := new(slog.LevelVar)
+	level.Set(configuredLevel(cfg))
+
+	// The handler itself lets everything through, the level is checked in
+	// front of it
+	cfg.Level = "debug"
+	log, err := logger.NewLoggerConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log = slog.New(Handler(level, log.Handler()))
+	slog.SetDefault(log)
+	return log, level, nil
+}
+
+// configuredLevel is the level the gox logger package would use for cfg.
+func configuredLevel(cfg logger.Config) slog.Level {
+	switch strings.ToUpper(cfg.Level) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "INFO":
+		return slog.LevelInfo
+	case "WARN", "WARNING":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	}
+	if cfg.Environment == "development" {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// Handler returns a slog.Handler passing the records at level or above on to
+// next. The handlers wrapping it, such as the audit log's, only see the
+// records it lets through.
+func Handler(level slog.Leveler, next slog.Handler) slog.Handler {
+	return &handler{level: level, next: next}
+}
+
+type handler struct {
+	level slog.Leveler
+	next  slog.Handler
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{level: h.level, next: h.next.WithAttrs(attrs)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{level: h.level, next: h.next.WithGroup(name)}
+}
+
+// Watch switches level to debug on SIGHUP, and back to the level it had
+// before, or info, on the next SIGHUP, until ctx is done.
+func Watch(ctx context.Context, level *slog.LevelVar, log *slog.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	previous := max(level.Level(), slog.LevelInfo)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			from := level.Level()
+			to := slog.LevelDebug
+			if from <= slog.LevelDebug {
+				to = previous
+			} else {
+				previous = from
+			}
+			level.Set(to)
+			log.Warn("log level changed", "from", from.String(), "to", to.String(), "signal", "SIGHUP")
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package loglevel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/guilhermebr/gox/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	var out bytes.Buffer
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	log := slog.New(Handler(level, slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	log.Info("hidden")
+	log.Warn("shown")
+	level.Set(slog.LevelDebug)
+	log.With("k", "v").Debug("shown after the change")
+
+	assert.NotContains(t, out.String(), "hidden")
+	assert.Contains(t, out.String(), "msg=shown")
+	assert.Contains(t, out.String(), `msg="shown after the change" k=v`)
+}
+
+func TestConfiguredLevel(t *testing.T) {
+	tests := []struct {
+		cfg  logger.Config
+		want slog.Level
+	}{
+		{logger.Config{Level: "warning"}, slog.LevelWarn},
+		{logger.Config{Level: "ERROR"}, slog.LevelError},
+		{logger.Config{Environment: "development"}, slog.LevelDebug},
+		{logger.Config{Environment: "production"}, slog.LevelInfo},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, configuredLevel(tt.cfg), "%+v", tt.cfg)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Catch SIGHUP from the start, as it ends the test binary until Watch
+	// listens for it
+	ignored := make(chan os.Signal, 1)
+	signal.Notify(ignored, syscall.SIGHUP)
+	defer signal.Stop(ignored)
+
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	go Watch(ctx, level, slog.New(slog.DiscardHandler))
+
+	hup := func(want slog.Level) {
+		t.Helper()
+		// Watch may not be listening yet, so keep signalling until it acts
+		assert.Eventually(t, func() bool {
+			if level.Level() == want {
+				return true
+			}
+			_ = syscall.Kill(syscall.Getpid(), syscall.SIGHUP)
+			return false
+		}, 5*time.Second, 50*time.Millisecond)
+	}
+	hup(slog.LevelDebug)
+	hup(slog.LevelWarn)
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var authProviderCircuitOpen = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "auth_provider_circuit_open",
+	Help:      "1 while calls to the auth provider are failing fast because its circuit breaker is open.",
+}, []string{"provider"})
+
+var authProviderUnavailable = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "auth_provider_unavailable_total",
+	Help:      "Auth provider calls that timed out, failed with a server error or were refused by the open circuit, by provider and method.",
+}, []string{"provider", "method"})
+
+// SetAuthProviderCircuitOpen records whether the provider's circuit is open
+func SetAuthProviderCircuitOpen(provider string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	authProviderCircuitOpen.WithLabelValues(provider).Set(value)
+}
+
+// RecordAuthProviderUnavailable counts a provider call that got no usable
+// answer
+func RecordAuthProviderUnavailable(provider, method string) {
+	authProviderUnavailable.WithLabelValues(provider, method).Inc()
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var botSubmissionsBlocked = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "bot_submissions_blocked_total",
+	Help:      "Public form submissions rejected as automated, by form and reason.",
+}, []string{"form", "reason"})
+
+// RecordBotBlocked counts a form submission rejected by bot detection
+func RecordBotBlocked(form, reason string) {
+	botSubmissionsBlocked.WithLabelValues(form, reason).Inc()
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dbQueryDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by sqlc query name and whether the query failed.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"query", "error"})
+
+	dbSlowQueries = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_slow_queries_total",
+		Help:      "Database queries that took longer than DB_SLOW_QUERY_THRESHOLD, by sqlc query name.",
+	}, []string{"query"})
+)
+
+// RecordDBQuery records a query's latency, labelled by its sqlc query name
+// rather than its SQL to keep label cardinality bounded
+func RecordDBQuery(query string, d time.Duration, failed bool) {
+	errLabel := "false"
+	if failed {
+		errLabel = "true"
+	}
+	dbQueryDuration.WithLabelValues(query, errLabel).Observe(d.Seconds())
+}
+
+// RecordDBSlowQuery counts a query that went over the slow query threshold
+func RecordDBSlowQuery(query string) {
+	dbSlowQueries.WithLabelValues(query).Inc()
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"go-template/domain/entities"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ActiveUserWindow is the period a user counts as active after logging in
+const ActiveUserWindow = 24 * time.Hour
+
+const (
+	LoginResultSuccess = "success"
+	LoginResultFailure = "failure"
+)
+
+var (
+	signupsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_signups_total",
+		Help:      "Users created, by auth provider and account type.",
+	}, []string{"provider", "account_type"})
+
+	loginsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_logins_total",
+		Help:      "Login attempts by result (success or failure).",
+	}, []string{"result"})
+
+	examplesCreatedTotal = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "examples_created_total",
+		Help:      "Examples created.",
+	})
+
+	activeUsers = newActiveUserTracker(ActiveUserWindow)
+)
+
+func init() {
+	promauto.With(Registry).NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_users",
+		Help:      "Distinct users that logged in on this instance within the active window.",
+	}, func() float64 {
+		return float64(activeUsers.count(time.Now()))
+	})
+}
+
+// RecordSignup counts a newly created user
+func RecordSignup(provider string, accountType entities.AccountType) {
+	if accountType == "" {
+		accountType = entities.AccountTypeUser
+	}
+	signupsTotal.WithLabelValues(provider, accountType.String()).Inc()
+}
+
+// RecordLogin counts a login attempt and marks the user active on success
+func RecordLogin(userID string, success bool) {
+	if !success {
+		loginsTotal.WithLabelValues(LoginResultFailure).Inc()
+		return
+	}
+	loginsTotal.WithLabelValues(LoginResultSuccess).Inc()
+	activeUsers.touch(userID, time.Now())
+}
+
+// RecordExampleCreated counts a newly created example
+func RecordExampleCreated() {
+	examplesCreatedTotal.Inc()
+}
+
+// UserStatsFunc returns the current user totals
+type UserStatsFunc func(ctx context.Context) (entities.UserStats, error)
+
+// RegisterUserStats exports user totals by account type, read from fn on
+// every scrape.
+func RegisterUserStats(fn UserStatsFunc) error {
+	return Registry.Register(&userStatsCollector{fn: fn})
+}
+
+var usersTotalDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "users_total"),
+	"Registered users by account type.",
+	[]string{"account_type"}, nil,
+)
+
+type userStatsCollector struct {
+	fn UserStatsFunc
+}
+
+func (c *userStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- usersTotalDesc
+}
+
+func (c *userStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := c.fn(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(usersTotalDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(usersTotalDesc, prometheus.GaugeValue, float64(stats.RegularUsers), entities.AccountTypeUser.String())
+	ch <- prometheus.MustNewConstMetric(usersTotalDesc, prometheus.GaugeValue, float64(stats.AdminUsers), entities.AccountTypeAdmin.String())
+	ch <- prometheus.MustNewConstMetric(usersTotalDesc, prometheus.GaugeValue, float64(stats.SuperAdminUsers), entities.AccountTypeSuperAdmin.String())
+}
+
+// activeUserTracker keeps the last login time of each user within window
+type activeUserTracker struct {
+	mu       sync.Mutex
+	window   time.Duration
+	lastSeen map[string]time.Time
+}
+
+func newActiveUserTracker(window time.Duration) *activeUserTracker {
+	return &activeUserTracker{
+		window:   window,
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+func (t *activeUserTracker) touch(userID string, now time.Time) {
+	if userID == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastSeen[userID] = now
+}
+
+func (t *activeUserTracker) count(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := now.Add(-t.window)
+	for id, seen := range t.lastSeen {
+		if seen.Before(cutoff) {
+			delete(t.lastSeen, id)
+		}
+	}
+	return len(t.lastSeen)
+}
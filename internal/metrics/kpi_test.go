@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"go-template/domain/entities"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActiveUserTracker(t *testing.T) {
+	now := time.Now()
+	tracker := newActiveUserTracker(time.Hour)
+
+	tracker.touch("user-1", now.Add(-2*time.Hour))
+	tracker.touch("user-2", now.Add(-30*time.Minute))
+	tracker.touch("user-3", now)
+	tracker.touch("user-3", now)
+	tracker.touch("", now)
+
+	assert.Equal(t, 2, tracker.count(now))
+	assert.Equal(t, 0, tracker.count(now.Add(2*time.Hour)))
+}
+
+func TestRecordLogin(t *testing.T) {
+	success := testutil.ToFloat64(loginsTotal.WithLabelValues(LoginResultSuccess))
+	failure := testutil.ToFloat64(loginsTotal.WithLabelValues(LoginResultFailure))
+
+	RecordLogin("user-1", true)
+	RecordLogin("", false)
+	RecordLogin("", false)
+
+	assert.Equal(t, success+1, testutil.ToFloat64(loginsTotal.WithLabelValues(LoginResultSuccess)))
+	assert.Equal(t, failure+2, testutil.ToFloat64(loginsTotal.WithLabelValues(LoginResultFailure)))
+}
+
+func TestRecordSignup(t *testing.T) {
+	before := testutil.ToFloat64(signupsTotal.WithLabelValues("supabase", "user"))
+
+	RecordSignup("supabase", "")
+	RecordSignup("supabase", entities.AccountTypeUser)
+
+	assert.Equal(t, before+2, testutil.ToFloat64(signupsTotal.WithLabelValues("supabase", "user")))
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var requestsRateLimited = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "requests_rate_limited_total",
+	Help:      "Requests rejected by rate limiting, by endpoint and scope (ip or account).",
+}, []string{"endpoint", "scope"})
+
+// RecordRateLimited counts a request rejected by rate limiting
+func RecordRateLimited(endpoint, scope string) {
+	requestsRateLimited.WithLabelValues(endpoint, scope).Inc()
+}
@@ -0,0 +1,71 @@
+// Package profiling serves the net/http/pprof profiles and the expvar
+// variables, so a running service can be profiled without a redeploy. The
+// API mounts Handler behind super admin auth with DEBUG_ENDPOINTS=true, and
+// Serve answers on a separate port, DEBUG_ADDR, meant to be reachable only
+// from inside the cluster.
+package profiling
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"time"
+)
+
+// Handler serves the profiles under /debug/pprof/ and the variables at
+// /debug/vars. Mounted elsewhere, the mount path has to be stripped down to
+// /debug, as the pprof index links to its profiles by that path.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// LimitSeconds rejects CPU profiles and traces asked to run for more than
+// limit, as pprof does past the server's write timeout, for handlers mounted
+// behind a shorter deadline than the profile would take. Without seconds,
+// profiles run for 30s and traces for 1s.
+func LimitSeconds(next http.Handler, limit time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.URL.Query().Get("seconds"); v != "" {
+			sec, err := strconv.ParseFloat(v, 64)
+			if err == nil && time.Duration(sec*float64(time.Second)) > limit {
+				http.Error(w, "profile duration exceeds "+limit.String(), http.StatusBadRequest)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Serve serves Handler on addr until ctx is done. The server has no write
+// timeout, unlike the API's, so CPU profiles and traces can run for as long
+// as they are asked to.
+func Serve(ctx context.Context, addr string, logger *slog.Logger) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving debug endpoints", "address", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("debug server failed", "address", addr, "error", err)
+	}
+}
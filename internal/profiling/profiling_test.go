@@ -0,0 +1,51 @@
+package profiling
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/admin/v1/debug/", http.StripPrefix("/admin/v1", Handler()))
+
+	tests := []struct {
+		path     string
+		wantBody string
+	}{
+		{path: "/admin/v1/debug/pprof/", wantBody: "goroutine"},
+		{path: "/admin/v1/debug/pprof/goroutine?debug=1", wantBody: "goroutine profile"},
+		{path: "/admin/v1/debug/vars", wantBody: `"memstats"`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code, tt.path)
+		assert.Contains(t, w.Body.String(), tt.wantBody, tt.path)
+	}
+}
+
+func TestLimitSeconds(t *testing.T) {
+	h := LimitSeconds(Handler(), 50*time.Second)
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/debug/pprof/profile?seconds=60", wantCode: http.StatusBadRequest},
+		{path: "/debug/pprof/trace?seconds=50.5", wantCode: http.StatusBadRequest},
+		{path: "/debug/pprof/trace?seconds=0.01", wantCode: http.StatusOK},
+		{path: "/debug/pprof/goroutine", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		assert.Equal(t, tt.wantCode, w.Code, tt.path)
+	}
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many calls to Allow pass between removals of keys
+// without recent attempts.
+const sweepEvery = 1024
+
+// MemoryStore keeps attempts in process memory. Limits are per instance and
+// reset on restart; use RedisStore when several instances serve logins.
+type MemoryStore struct {
+	mu       sync.Mutex
+	attempts map[string][]time.Time
+	calls    int
+	longest  time.Duration
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{attempts: map[string][]time.Time{}}
+}
+
+func (s *MemoryStore) Allow(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.longest = max(s.longest, window)
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	attempts := dropBefore(s.attempts[key], now.Add(-window))
+	if len(attempts) >= limit {
+		s.attempts[key] = attempts
+		return false, attempts[0].Add(window).Sub(now), nil
+	}
+	s.attempts[key] = append(attempts, now)
+	return true, 0, nil
+}
+
+// sweep removes keys whose attempts have all left the longest window in use.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, attempts := range s.attempts {
+		if len(dropBefore(attempts, now.Add(-s.longest))) == 0 {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// dropBefore returns the attempts made after cutoff. attempts are in the
+// order they were made.
+func dropBefore(attempts []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(attempts) && !attempts[i].After(cutoff) {
+		i++
+	}
+	return attempts[i:]
+}
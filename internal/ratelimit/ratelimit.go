@@ -0,0 +1,153 @@
+// Package ratelimit limits login attempts per client IP and per account with
+// a sliding window. Counters live in Redis when several instances share the
+// limits, or in memory otherwise. Every rejected request is counted in the
+// metrics package and logged as an audit event.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-template/internal/clientip"
+	"go-template/internal/metrics"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scopes a request is limited by
+const (
+	ScopeIP      = "ip"
+	ScopeAccount = "account"
+)
+
+const maxBodySize = 1 << 20
+
+// Store keeps the attempts made in the last window for each key.
+type Store interface {
+	// Allow records an attempt for key at now unless limit attempts were
+	// already made in the window before it. When it refuses, it returns how
+	// long until the oldest attempt leaves the window.
+	Allow(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (bool, time.Duration, error)
+}
+
+// Rule allows Limit requests per Window. A zero Limit disables the rule.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Config configures a Limiter.
+type Config struct {
+	// PerIP limits requests from one client IP address.
+	PerIP Rule
+	// PerAccount limits requests for one account, identified by the email
+	// field of the form or JSON body.
+	PerAccount Rule
+}
+
+type Limiter struct {
+	cfg    Config
+	store  Store
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func New(cfg Config, store Store, logger *slog.Logger) *Limiter {
+	return &Limiter{
+		cfg:    cfg,
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Middleware limits requests to endpoint before they reach the handler.
+// Rejected requests get 429 Too Many Requests with a Retry-After header. The
+// request body is restored for the next handler. Store failures let the
+// request through.
+func (l *Limiter) Middleware(endpoint string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientip.FromRequest(r)
+			if retry, limited := l.check(r.Context(), endpoint, ScopeIP, ip, l.cfg.PerIP); limited {
+				l.reject(w, endpoint, ScopeIP, ip, retry)
+				return
+			}
+
+			if l.cfg.PerAccount.Limit > 0 {
+				if account := accountFromRequest(r); account != "" {
+					if retry, limited := l.check(r.Context(), endpoint, ScopeAccount, account, l.cfg.PerAccount); limited {
+						l.reject(w, endpoint, ScopeAccount, account, retry)
+						return
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (l *Limiter) check(ctx context.Context, endpoint, scope, value string, rule Rule) (time.Duration, bool) {
+	if rule.Limit <= 0 || value == "" {
+		return 0, false
+	}
+
+	key := endpoint + ":" + scope + ":" + value
+	allowed, retry, err := l.store.Allow(ctx, key, rule.Limit, rule.Window, l.now())
+	if err != nil {
+		l.logger.Error("rate limit check failed", "endpoint", endpoint, "scope", scope, "error", err)
+		return 0, false
+	}
+	return retry, !allowed
+}
+
+func (l *Limiter) reject(w http.ResponseWriter, endpoint, scope, value string, retry time.Duration) {
+	metrics.RecordRateLimited(endpoint, scope)
+	l.logger.Warn("rate limit exceeded", "audit", true, "endpoint", endpoint, "scope", scope, scope, value, "retry_after", retry)
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retry)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": "too many attempts, try again later",
+	})
+}
+
+// retryAfterSeconds rounds up, so clients never retry before the window
+// has moved on.
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
+
+// accountFromRequest returns the normalized email a form or JSON body signs
+// in with, or an empty string when there is none.
+func accountFromRequest(r *http.Request) string {
+	var email string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			return ""
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields struct {
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		email = fields.Email
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return ""
+		}
+		email = r.PostFormValue("email")
+	}
+	return strings.ToLower(strings.TrimSpace(email))
+}
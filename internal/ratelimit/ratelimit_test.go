@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingStore struct{}
+
+func (failingStore) Allow(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (bool, time.Duration, error) {
+	return false, 0, errors.New("unavailable")
+}
+
+func newTestLimiter(cfg Config, store Store, now *time.Time) http.Handler {
+	l := New(cfg, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	l.now = func() time.Time { return *now }
+	return l.Middleware("login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The handler still sees the body the limiter read
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+}
+
+func login(h http.Handler, ip, email string) *httptest.ResponseRecorder {
+	body := `{"email":"` + email + `","password":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestLimiter_PerAccount(t *testing.T) {
+	now := time.Now()
+	rule := Rule{Limit: 2, Window: time.Minute}
+	h := newTestLimiter(Config{PerAccount: rule}, NewMemoryStore(), &now)
+
+	for i := range 2 {
+		w := login(h, "10.0.0.1", "a@b.com")
+		require.Equal(t, http.StatusOK, w.Code, "attempt %d", i)
+		assert.Contains(t, w.Body.String(), `"password":"secret"`)
+	}
+
+	// Other IPs and a differently cased email count against the same account
+	w := login(h, "10.0.0.2", " A@B.com")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// Other accounts aren't affected
+	assert.Equal(t, http.StatusOK, login(h, "10.0.0.1", "c@d.com").Code)
+
+	// The window slides
+	now = now.Add(45 * time.Second)
+	w = login(h, "10.0.0.1", "a@b.com")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "15", w.Header().Get("Retry-After"))
+
+	now = now.Add(16 * time.Second)
+	assert.Equal(t, http.StatusOK, login(h, "10.0.0.1", "a@b.com").Code)
+}
+
+func TestLimiter_PerIP(t *testing.T) {
+	now := time.Now()
+	cfg := Config{
+		PerIP:      Rule{Limit: 3, Window: time.Minute},
+		PerAccount: Rule{Limit: 10, Window: time.Minute},
+	}
+	h := newTestLimiter(cfg, NewMemoryStore(), &now)
+
+	for i, email := range []string{"a@b.com", "b@b.com", "c@b.com"} {
+		require.Equal(t, http.StatusOK, login(h, "10.0.0.1", email).Code, "attempt %d", i)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, login(h, "10.0.0.1", "d@b.com").Code)
+	assert.Equal(t, http.StatusOK, login(h, "10.0.0.2", "d@b.com").Code)
+}
+
+func TestLimiter_StoreFailureAllows(t *testing.T) {
+	now := time.Now()
+	rule := Rule{Limit: 1, Window: time.Minute}
+	h := newTestLimiter(Config{PerIP: rule, PerAccount: rule}, failingStore{}, &now)
+
+	for range 3 {
+		assert.Equal(t, http.StatusOK, login(h, "10.0.0.1", "a@b.com").Code)
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, 1, retryAfterSeconds(0))
+	assert.Equal(t, 1, retryAfterSeconds(200*time.Millisecond))
+	assert.Equal(t, 2, retryAfterSeconds(1100*time.Millisecond))
+}
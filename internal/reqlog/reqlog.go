@@ -0,0 +1,116 @@
+// Package reqlog logs HTTP requests with slog. Middleware gives each request
+// a logger carrying its request_id and route, which the auth middleware
+// adds the user_id to with WithUser, and stores it in the context for
+// handlers and use cases to pull with domain.Logger. Every line logged
+// while serving a request can then be told apart from other requests'.
+package reqlog
+
+import (
+	"context"
+	"go-template/domain"
+	"go-template/internal/clientip"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type requestKey struct{}
+
+// request is what the request line is told by the code serving the
+// request, deeper down the middleware chain.
+type request struct {
+	mu     sync.Mutex
+	userID string
+}
+
+// routeHandler adds the route pattern of a request to every record,
+// which chi only knows once the request was routed, well after the
+// request's logger was made.
+type routeHandler struct {
+	next slog.Handler
+	rctx *chi.Context
+}
+
+func (h *routeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *routeHandler) Handle(ctx context.Context, r slog.Record) error {
+	if pattern := h.rctx.RoutePattern(); pattern != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String("route", pattern))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *routeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &routeHandler{next: h.next.WithAttrs(attrs), rctx: h.rctx}
+}
+
+func (h *routeHandler) WithGroup(name string) slog.Handler {
+	return &routeHandler{next: h.next.WithGroup(name), rctx: h.rctx}
+}
+
+// Middleware logs a line for every request once it is answered, and gives
+// the code serving it a logger naming it. It has to run after
+// middleware.RequestID and clientip.Middleware, and before
+// middleware.Recoverer so requests that panicked are logged with their
+// 500.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			req := &request{}
+			reqLogger := logger.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				reqLogger = slog.New(&routeHandler{next: reqLogger.Handler(), rctx: rctx})
+			}
+			ctx := context.WithValue(r.Context(), requestKey{}, req)
+			ctx = domain.WithLogger(ctx, reqLogger)
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				attrs := []slog.Attr{
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", status),
+					slog.Int("bytes", ww.BytesWritten()),
+					slog.Duration("duration", time.Since(start)),
+					slog.String("remote_addr", clientip.FromRequest(r)),
+				}
+				req.mu.Lock()
+				if req.userID != "" {
+					attrs = append(attrs, slog.String("user_id", req.userID))
+				}
+				req.mu.Unlock()
+				level := slog.LevelInfo
+				if status >= http.StatusInternalServerError {
+					level = slog.LevelWarn
+				}
+				reqLogger.LogAttrs(ctx, level, "request completed", attrs...)
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		})
+	}
+}
+
+// WithUser adds the user a request was authenticated as to its logger, and
+// to its request line.
+func WithUser(ctx context.Context, userID string) context.Context {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		req.mu.Lock()
+		req.userID = userID
+		req.mu.Unlock()
+	}
+	return domain.WithLogger(ctx, domain.Logger(ctx).With(slog.String("user_id", userID)))
+}
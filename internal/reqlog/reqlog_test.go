@@ -0,0 +1,65 @@
+package reqlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-template/domain"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(Middleware(logger))
+	r.Use(middleware.Recoverer)
+	r.Get("/examples/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithUser(r.Context(), "user-1")
+		domain.Logger(ctx).Error("failed to get example", "error", "boom")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/examples/7", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var handlerLine, requestLine map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &handlerLine))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &requestLine))
+
+	assert.Equal(t, "failed to get example", handlerLine["msg"])
+	assert.Equal(t, "req-1", handlerLine["request_id"])
+	assert.Equal(t, "/examples/{id}", handlerLine["route"])
+	assert.Equal(t, "user-1", handlerLine["user_id"])
+
+	assert.Equal(t, "request completed", requestLine["msg"])
+	assert.Equal(t, "WARN", requestLine["level"])
+	assert.Equal(t, "req-1", requestLine["request_id"])
+	assert.Equal(t, "/examples/{id}", requestLine["route"])
+	assert.Equal(t, "/examples/7", requestLine["path"])
+	assert.Equal(t, float64(http.StatusInternalServerError), requestLine["status"])
+	assert.Equal(t, "user-1", requestLine["user_id"])
+
+	t.Run("panics are logged with their 500", func(t *testing.T) {
+		out.Reset()
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+		assert.Contains(t, out.String(), `"status":500`)
+	})
+}
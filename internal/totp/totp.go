@@ -0,0 +1,87 @@
+// Package totp generates and checks time-based one-time passwords (RFC 6238)
+// as produced by authenticator apps: six digits, SHA-1, 30 second steps.
+package totp
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// Period is the lifetime of a code in seconds
+const Period = 30
+
+// skew is how many steps before and after the current one are accepted, to
+// allow for clock drift and slow typing
+const skew = 1
+
+var opts = totp.ValidateOpts{
+	Period:    Period,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// Key is a new TOTP secret with the ways to hand it to an authenticator app.
+type Key struct {
+	Secret string
+	// URI is the otpauth:// provisioning URI
+	URI string
+	// QRCode is the URI as a PNG QR code, encoded as a data URL
+	QRCode string
+}
+
+// Generate creates a secret for account, shown as issuer in the app.
+func Generate(issuer, account string) (Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: account,
+		Period:      Period,
+		Digits:      opts.Digits,
+		Algorithm:   opts.Algorithm,
+	})
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to render totp qr code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return Key{}, fmt.Errorf("failed to encode totp qr code: %w", err)
+	}
+
+	return Key{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// Code returns the code for secret at t.
+func Code(secret string, t time.Time) (string, error) {
+	return totp.GenerateCodeCustom(secret, t, opts)
+}
+
+// Match checks code against secret around now. It returns the time step the
+// code belongs to, so callers can refuse a code that was already used.
+func Match(secret, code string, now time.Time) (int64, bool) {
+	for i := -skew; i <= skew; i++ {
+		t := now.Add(time.Duration(i*Period) * time.Second)
+		expected, err := Code(secret, t)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return t.Unix() / Period, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	key, err := Generate("Go Template", "a@b.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.Secret == "" {
+		t.Fatalf("expected a secret")
+	}
+	if !strings.HasPrefix(key.URI, "otpauth://totp/Go%20Template:a@b.com?") || !strings.Contains(key.URI, "secret="+key.Secret) {
+		t.Fatalf("unexpected provisioning uri: %s", key.URI)
+	}
+	if !strings.HasPrefix(key.QRCode, "data:image/png;base64,") {
+		t.Fatalf("expected a png data url, got %.40s", key.QRCode)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	key, err := Generate("Go Template", "a@b.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Unix(1_700_000_010, 0)
+
+	code, err := Code(key.Secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	step, ok := Match(key.Secret, code, now)
+	if !ok || step != now.Unix()/Period {
+		t.Fatalf("expected current code to match step %d, got %d %v", now.Unix()/Period, step, ok)
+	}
+
+	// The previous step is still accepted
+	previous, _ := Code(key.Secret, now.Add(-Period*time.Second))
+	if step, ok := Match(key.Secret, previous, now); !ok || step != now.Unix()/Period-1 {
+		t.Fatalf("expected previous code to match, got %d %v", step, ok)
+	}
+
+	// Codes from two steps ago are not
+	stale, _ := Code(key.Secret, now.Add(-2*Period*time.Second))
+	if stale != code && stale != previous {
+		if _, ok := Match(key.Secret, stale, now); ok {
+			t.Fatalf("expected stale code to be rejected")
+		}
+	}
+}
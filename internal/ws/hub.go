@@ -0,0 +1,245 @@
+// Package ws keeps the WebSocket connections signed in users open on this
+// instance, and pushes messages to them.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// sendBuffer is how many messages a connection may fall behind by
+	// before new ones are dropped for it.
+	sendBuffer = 16
+	// pingInterval is how often an idle connection is pinged, so proxies
+	// don't close it and dead peers are noticed.
+	pingInterval = 30 * time.Second
+	// writeTimeout bounds each write and ping.
+	writeTimeout = 10 * time.Second
+
+	// bearerProtocol is the subprotocol browsers offer, followed by their
+	// access token, as they can't set the Authorization header.
+	bearerProtocol = "bearer"
+)
+
+// Message is pushed to a connection as JSON.
+type Message struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+// Feed streams the messages for a user's connection while it is open,
+// until ctx is done, when the channel must be closed.
+type Feed func(ctx context.Context, userID uuid.UUID) <-chan Message
+
+// Forward turns a channel of values into one of messages of the given
+// type, closed once ch is.
+func Forward[T any](typ string, ch <-chan T) <-chan Message {
+	out := make(chan Message)
+	go func() {
+		defer close(out)
+		for v := range ch {
+			out <- Message{Type: typ, Data: v}
+		}
+	}()
+	return out
+}
+
+type conn struct {
+	userID uuid.UUID
+	send   chan Message
+}
+
+// Hub keeps the WebSocket connections of signed in users. Messages sent to
+// a user reach each of their connections on this instance; one falling
+// behind misses them. Connections are closed when the hub is.
+type Hub struct {
+	logger *slog.Logger
+	feeds  []Feed
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	byUser map[uuid.UUID]map[*conn]struct{}
+}
+
+func NewHub(logger *slog.Logger) *Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Hub{
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+		byUser: map[uuid.UUID]map[*conn]struct{}{},
+	}
+}
+
+// AddFeed streams f's messages to every connection opened from now on.
+func (h *Hub) AddFeed(f Feed) {
+	h.feeds = append(h.feeds, f)
+}
+
+// Send pushes msg to each of the user's connections, and returns how many
+// it was queued for.
+func (h *Hub) Send(userID uuid.UUID, msg Message) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sent := 0
+	for c := range h.byUser[userID] {
+		select {
+		case c.send <- msg:
+			sent++
+		default:
+			// A slow reader misses it
+		}
+	}
+	return sent
+}
+
+// Connections returns how many connections the user has open.
+func (h *Hub) Connections(userID uuid.UUID) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.byUser[userID])
+}
+
+func (h *Hub) add(userID uuid.UUID) *conn {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c := &conn{userID: userID, send: make(chan Message, sendBuffer)}
+	if h.byUser[userID] == nil {
+		h.byUser[userID] = map[*conn]struct{}{}
+	}
+	h.byUser[userID][c] = struct{}{}
+	return c
+}
+
+func (h *Hub) remove(c *conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.byUser[c.userID], c)
+	if len(h.byUser[c.userID]) == 0 {
+		delete(h.byUser, c.userID)
+	}
+}
+
+// ErrClosed is returned by Serve once the hub is closed.
+var ErrClosed = errors.New("websocket hub closed")
+
+// Serve upgrades the request to a WebSocket connection for the user, and
+// pushes their messages to it until the client goes away or the hub is
+// closed. Clients aren't expected to send messages; one that does is
+// disconnected.
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, userID uuid.UUID) error {
+	if h.ctx.Err() != nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return ErrClosed
+	}
+	ws, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		// Picked when the client authenticates with it
+		Subprotocols: []string{bearerProtocol},
+		// Connections authenticate with a token rather than cookies, so any
+		// origin may open them, as with CORS
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer ws.CloseNow()
+
+	// The connection outlives the request's timeout. Its context is done
+	// once the client goes away, and its feeds' once Serve returns.
+	connCtx := ws.CloseRead(context.WithoutCancel(r.Context()))
+	ctx, cancel := context.WithCancel(connCtx)
+	defer cancel()
+
+	c := h.add(userID)
+	defer h.remove(c)
+	for _, feed := range h.feeds {
+		go func() {
+			for msg := range feed(ctx, userID) {
+				select {
+				case c.send <- msg:
+				default:
+				}
+			}
+		}()
+	}
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-connCtx.Done():
+			return nil
+		case <-h.ctx.Done():
+			return ws.Close(websocket.StatusGoingAway, "server shutting down")
+		case msg := <-c.send:
+			if err := h.write(ctx, ws, msg); err != nil {
+				return err
+			}
+		case <-ping.C:
+			pingCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+			err := ws.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *Hub) write(ctx context.Context, ws *websocket.Conn, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to encode websocket message", "type", msg.Type, "error", err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	return ws.Write(ctx, websocket.MessageText, data)
+}
+
+// Close closes every connection, telling clients the server is going away.
+func (h *Hub) Close() {
+	h.cancel()
+}
+
+// bearerToken returns the access token offered with the bearer subprotocol.
+func bearerToken(r *http.Request) (string, bool) {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for p := range strings.SplitSeq(header, ",") {
+			protocols = append(protocols, strings.TrimSpace(p))
+		}
+	}
+	for i, p := range protocols {
+		if p == bearerProtocol && i+1 < len(protocols) {
+			return protocols[i+1], true
+		}
+	}
+	return "", false
+}
+
+// BearerProtocol lets browsers authenticate a WebSocket request by offering
+// the subprotocols "bearer" and their access token, as in
+// new WebSocket(url, ["bearer", token]): it sets the Authorization header
+// from them for the auth middleware after it.
+func BearerProtocol(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,131 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(t *testing.T, userID uuid.UUID) (*Hub, string) {
+	hub := NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = hub.Serve(w, r, userID)
+	}))
+	t.Cleanup(srv.Close)
+	return hub, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func readMessage(t *testing.T, ctx context.Context, c *websocket.Conn) Message {
+	_, data, err := c.Read(ctx)
+	require.NoError(t, err)
+	var msg Message
+	require.NoError(t, json.Unmarshal(data, &msg))
+	return msg
+}
+
+func waitForConnections(t *testing.T, hub *Hub, userID uuid.UUID, n int) {
+	require.Eventually(t, func() bool {
+		return hub.Connections(userID) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestHub(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	userID := uuid.Must(uuid.NewV4())
+
+	t.Run("sends to each of the user's connections", func(t *testing.T) {
+		hub, url := newTestHub(t, userID)
+		first, _, err := websocket.Dial(ctx, url, nil)
+		require.NoError(t, err)
+		defer first.CloseNow()
+		second, _, err := websocket.Dial(ctx, url, nil)
+		require.NoError(t, err)
+		defer second.CloseNow()
+		waitForConnections(t, hub, userID, 2)
+
+		assert.Equal(t, 0, hub.Send(uuid.Must(uuid.NewV4()), Message{Type: "ping"}), "other users have no connections")
+		assert.Equal(t, 2, hub.Send(userID, Message{Type: "hello", Data: "world"}))
+		assert.Equal(t, Message{Type: "hello", Data: "world"}, readMessage(t, ctx, first))
+		assert.Equal(t, Message{Type: "hello", Data: "world"}, readMessage(t, ctx, second))
+
+		require.NoError(t, first.Close(websocket.StatusNormalClosure, ""))
+		waitForConnections(t, hub, userID, 1)
+	})
+
+	t.Run("streams feeds while connected", func(t *testing.T) {
+		hub, url := newTestHub(t, userID)
+		values := make(chan string, 1)
+		done := make(chan struct{})
+		hub.AddFeed(func(ctx context.Context, id uuid.UUID) <-chan Message {
+			assert.Equal(t, userID, id)
+			go func() {
+				<-ctx.Done()
+				close(done)
+			}()
+			return Forward("value", values)
+		})
+
+		c, _, err := websocket.Dial(ctx, url, nil)
+		require.NoError(t, err)
+		values <- "first"
+		assert.Equal(t, Message{Type: "value", Data: "first"}, readMessage(t, ctx, c))
+
+		require.NoError(t, c.Close(websocket.StatusNormalClosure, ""))
+		select {
+		case <-done:
+		case <-ctx.Done():
+			t.Fatal("feed not stopped when the connection closed")
+		}
+	})
+
+	t.Run("closes connections when closed", func(t *testing.T) {
+		hub, url := newTestHub(t, userID)
+		c, _, err := websocket.Dial(ctx, url, nil)
+		require.NoError(t, err)
+		defer c.CloseNow()
+		waitForConnections(t, hub, userID, 1)
+
+		hub.Close()
+		_, _, err = c.Read(ctx)
+		assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+
+		_, resp, err := websocket.Dial(ctx, url, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+}
+
+func TestBearerProtocol(t *testing.T) {
+	var authorization string
+	handler := BearerProtocol(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Header.Set("Sec-WebSocket-Protocol", "bearer, token.value")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "Bearer token.value", authorization)
+
+	r = httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Header.Set("Sec-WebSocket-Protocol", "bearer")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Empty(t, authorization, "no token offered")
+
+	r = httptest.NewRequest(http.MethodGet, "/ws", nil)
+	r.Header.Set("Authorization", "Bearer header.token")
+	r.Header.Set("Sec-WebSocket-Protocol", "bearer, token.value")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "Bearer header.token", authorization, "the header wins")
+}